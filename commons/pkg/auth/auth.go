@@ -0,0 +1,206 @@
+// Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package auth provides role based authorization for the HTTP APIs exposed by
+// NVSentinel components.
+//
+// Callers are identified by an Authenticator and granted one of three roles:
+//   - viewer: read-only access, suitable for dashboards
+//   - operator: may additionally trigger remediation and overrides (on-call)
+//   - admin: may additionally change component configuration
+//
+// Roles are ordered, a caller holding a role is granted every lower role.
+//
+// Example usage:
+//
+//	authn, err := auth.LoadTokenFile("/etc/nvsentinel/api-tokens.toml")
+//	if err != nil {
+//	    return err
+//	}
+//
+//	mw := auth.NewMiddleware(authn)
+//	srv := server.NewServer(
+//	    server.WithHandler("/config", mw.Require(auth.RoleViewer, configHandler)),
+//	)
+package auth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strings"
+)
+
+// Role is the access level granted to a caller.
+type Role int
+
+const (
+	// RoleNone grants no access.
+	RoleNone Role = iota
+	// RoleViewer grants read-only access.
+	RoleViewer
+	// RoleOperator grants access to remediation and override actions.
+	RoleOperator
+	// RoleAdmin grants full access.
+	RoleAdmin
+)
+
+var (
+	// ErrNoCredentials is returned when the request carries no credentials.
+	ErrNoCredentials = errors.New("no credentials provided")
+	// ErrInvalidCredentials is returned when the request credentials are not recognised.
+	ErrInvalidCredentials = errors.New("invalid credentials")
+)
+
+// String returns the name of the role.
+func (r Role) String() string {
+	switch r {
+	case RoleViewer:
+		return "viewer"
+	case RoleOperator:
+		return "operator"
+	case RoleAdmin:
+		return "admin"
+	default:
+		return "none"
+	}
+}
+
+// ParseRole converts a role name to a Role. The input is case-insensitive.
+func ParseRole(name string) (Role, error) {
+	switch strings.ToLower(strings.TrimSpace(name)) {
+	case "viewer":
+		return RoleViewer, nil
+	case "operator":
+		return RoleOperator, nil
+	case "admin":
+		return RoleAdmin, nil
+	default:
+		return RoleNone, fmt.Errorf("unknown role %q", name)
+	}
+}
+
+// Principal is an authenticated caller.
+type Principal struct {
+	// Name identifies the caller in logs and audit trails. It never contains credentials.
+	Name string
+	// Role is the access level granted to the caller.
+	Role Role
+}
+
+// Authenticator identifies the caller of a request.
+type Authenticator interface {
+	// Authenticate returns the caller of the request. It returns ErrNoCredentials when the
+	// request carries no credentials this authenticator understands, so the next
+	// authenticator can be tried.
+	Authenticate(r *http.Request) (*Principal, error)
+}
+
+type principalKey struct{}
+
+// PrincipalFromContext returns the caller stored in the request context by Middleware.
+func PrincipalFromContext(ctx context.Context) (*Principal, bool) {
+	p, ok := ctx.Value(principalKey{}).(*Principal)
+	return p, ok
+}
+
+// Middleware enforces roles on HTTP handlers.
+type Middleware struct {
+	authenticators []Authenticator
+}
+
+// NewMiddleware creates a Middleware trying the authenticators in order. Without
+// authenticators only viewer endpoints are reachable, anonymously, which preserves
+// the behaviour of deployments that did not configure authorization.
+func NewMiddleware(authenticators ...Authenticator) *Middleware {
+	return &Middleware{authenticators: authenticators}
+}
+
+// Enabled returns true if at least one authenticator is configured.
+func (m *Middleware) Enabled() bool {
+	return m != nil && len(m.authenticators) > 0
+}
+
+// Authenticate returns the caller of the request using the first authenticator that
+// accepts its credentials. Several authenticators may understand the same kind of
+// credentials, e.g. bearer tokens, so a rejection only fails the request once every
+// authenticator was tried.
+func (m *Middleware) Authenticate(r *http.Request) (*Principal, error) {
+	result := ErrNoCredentials
+
+	for _, a := range m.authenticators {
+		p, err := a.Authenticate(r)
+		if err == nil {
+			return p, nil
+		}
+
+		if !errors.Is(err, ErrNoCredentials) {
+			result = err
+		}
+	}
+
+	return nil, result
+}
+
+// Require wraps next so that it is only served to callers holding at least role.
+func (m *Middleware) Require(role Role, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !m.Enabled() {
+			if role > RoleViewer {
+				http.Error(w, "authorization is not configured", http.StatusForbidden)
+				return
+			}
+
+			next.ServeHTTP(w, r)
+
+			return
+		}
+
+		p, err := m.Authenticate(r)
+		if err != nil {
+			slog.Debug("API request rejected", "path", r.URL.Path, "error", err)
+			w.Header().Set("WWW-Authenticate", `Bearer realm="nvsentinel"`)
+			http.Error(w, "unauthorized", http.StatusUnauthorized)
+
+			return
+		}
+
+		if p.Role < role {
+			slog.Info("API request denied",
+				"path", r.URL.Path,
+				"principal", p.Name,
+				"role", p.Role.String(),
+				"required", role.String())
+			http.Error(w, "forbidden", http.StatusForbidden)
+
+			return
+		}
+
+		next.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), principalKey{}, p)))
+	})
+}
+
+// bearerToken returns the token from the Authorization header.
+func bearerToken(r *http.Request) (string, bool) {
+	header := r.Header.Get("Authorization")
+
+	scheme, token, ok := strings.Cut(header, " ")
+	if !ok || !strings.EqualFold(scheme, "Bearer") || token == "" {
+		return "", false
+	}
+
+	return strings.TrimSpace(token), true
+}
@@ -0,0 +1,139 @@
+// Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package auth
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestMiddleware(t *testing.T) *Middleware {
+	t.Helper()
+
+	authn, err := NewTokenAuthenticator([]TokenConfig{
+		{Name: "grafana", Role: "viewer", Token: "viewer-token"},
+		{Name: "oncall", Role: "Operator", Token: "operator-token"},
+		{Name: "platform", Role: "admin", Token: "admin-token"},
+	})
+	require.NoError(t, err)
+
+	return NewMiddleware(authn)
+}
+
+func serve(mw *Middleware, role Role, token string) *httptest.ResponseRecorder {
+	handler := mw.Require(role, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if p, ok := PrincipalFromContext(r.Context()); ok {
+			_, _ = w.Write([]byte(p.Name))
+		}
+	}))
+
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+
+	return rec
+}
+
+func TestMiddleware_Require(t *testing.T) {
+	mw := newTestMiddleware(t)
+
+	tests := []struct {
+		name     string
+		role     Role
+		token    string
+		expected int
+	}{
+		{"viewer reads", RoleViewer, "viewer-token", http.StatusOK},
+		{"viewer cannot operate", RoleOperator, "viewer-token", http.StatusForbidden},
+		{"operator operates", RoleOperator, "operator-token", http.StatusOK},
+		{"operator cannot administer", RoleAdmin, "operator-token", http.StatusForbidden},
+		{"admin administers", RoleAdmin, "admin-token", http.StatusOK},
+		{"admin reads", RoleViewer, "admin-token", http.StatusOK},
+		{"missing token", RoleViewer, "", http.StatusUnauthorized},
+		{"unknown token", RoleViewer, "bogus", http.StatusUnauthorized},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := serve(mw, tt.role, tt.token)
+			assert.Equal(t, tt.expected, rec.Code)
+		})
+	}
+
+	assert.Equal(t, "oncall", serve(mw, RoleOperator, "operator-token").Body.String())
+}
+
+func TestMiddleware_Disabled(t *testing.T) {
+	mw := NewMiddleware()
+
+	assert.Equal(t, http.StatusOK, serve(mw, RoleViewer, "").Code)
+	assert.Equal(t, http.StatusForbidden, serve(mw, RoleOperator, "").Code)
+
+	var nilMiddleware *Middleware
+	assert.False(t, nilMiddleware.Enabled())
+}
+
+func TestNewTokenAuthenticator_Invalid(t *testing.T) {
+	tests := []struct {
+		name   string
+		tokens []TokenConfig
+	}{
+		{"missing name", []TokenConfig{{Role: "viewer", Token: "t"}}},
+		{"missing token", []TokenConfig{{Name: "a", Role: "viewer"}}},
+		{"unknown role", []TokenConfig{{Name: "a", Role: "root", Token: "t"}}},
+		{"duplicate token", []TokenConfig{
+			{Name: "a", Role: "viewer", Token: "t"},
+			{Name: "b", Role: "admin", Token: "t"},
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewTokenAuthenticator(tt.tokens)
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestLoadTokenFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "tokens.toml")
+	content := `
+[[tokens]]
+name = "grafana"
+role = "viewer"
+token = "viewer-token"
+`
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+
+	authn, err := LoadTokenFile(path)
+	require.NoError(t, err)
+
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.Header.Set("Authorization", "bearer viewer-token")
+
+	p, err := authn.Authenticate(req)
+	require.NoError(t, err)
+	assert.Equal(t, &Principal{Name: "grafana", Role: RoleViewer}, p)
+}
@@ -0,0 +1,246 @@
+// Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package auth
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+const (
+	deviceCodeGrantType   = "urn:ietf:params:oauth:grant-type:device_code"
+	refreshTokenGrantType = "refresh_token"
+
+	defaultDevicePollInterval = 5 * time.Second
+	// slowDownIncrement is added to the poll interval when the provider asks us to slow down
+	slowDownIncrement = 5 * time.Second
+)
+
+// DeviceCodeFlow obtains an ID token with the OAuth 2.0 device authorization grant
+// (RFC 8628). It is meant for command line clients: the user opens the verification
+// URI in a browser and signs in with the identity provider while the client polls.
+type DeviceCodeFlow struct {
+	IssuerURL  string
+	ClientID   string
+	Scopes     []string
+	HTTPClient *http.Client
+	// PollInterval is used when the provider does not return a poll interval,
+	// defaults to 5 seconds.
+	PollInterval time.Duration
+}
+
+// DeviceAuthorization is the pending sign-in the user has to complete.
+type DeviceAuthorization struct {
+	DeviceCode              string `json:"device_code"`
+	UserCode                string `json:"user_code"`
+	VerificationURI         string `json:"verification_uri"`
+	VerificationURIComplete string `json:"verification_uri_complete"`
+	ExpiresIn               int    `json:"expires_in"`
+	Interval                int    `json:"interval"`
+
+	tokenEndpoint string
+}
+
+// DeviceToken is the result of a device sign-in. The ID token is the bearer token of
+// the NVSentinel APIs; the refresh token, when the provider issued one, renews it
+// without signing in again.
+type DeviceToken struct {
+	IDToken      string    `json:"id_token"`
+	RefreshToken string    `json:"refresh_token,omitempty"`
+	Expiry       time.Time `json:"expiry"`
+}
+
+// Expired reports whether the ID token expired or is about to. A token without a
+// known expiry is taken as valid.
+func (t *DeviceToken) Expired() bool {
+	return !t.Expiry.IsZero() && time.Now().Add(tokenLeeway).After(t.Expiry)
+}
+
+type deviceTokenResponse struct {
+	IDToken          string `json:"id_token"`
+	RefreshToken     string `json:"refresh_token"`
+	ExpiresIn        int    `json:"expires_in"`
+	Error            string `json:"error"`
+	ErrorDescription string `json:"error_description"`
+}
+
+// Start requests a device code. The caller shows UserCode and VerificationURI to the
+// user and then calls Wait.
+func (f *DeviceCodeFlow) Start(ctx context.Context) (*DeviceAuthorization, error) {
+	metadata, err := discover(ctx, f.httpClient(), f.IssuerURL)
+	if err != nil {
+		return nil, err
+	}
+
+	if metadata.DeviceAuthorizationEndpoint == "" {
+		return nil, errors.New("oidc: provider does not support the device authorization grant")
+	}
+
+	scopes := append([]string{"openid"}, f.Scopes...)
+
+	var authorization DeviceAuthorization
+	if err := f.postForm(ctx, metadata.DeviceAuthorizationEndpoint, url.Values{
+		"client_id": {f.ClientID},
+		"scope":     {strings.Join(scopes, " ")},
+	}, &authorization); err != nil {
+		return nil, fmt.Errorf("oidc: device authorization: %w", err)
+	}
+
+	authorization.tokenEndpoint = metadata.TokenEndpoint
+
+	return &authorization, nil
+}
+
+// Wait polls the provider until the user completed the sign-in and returns the token.
+func (f *DeviceCodeFlow) Wait(ctx context.Context, authorization *DeviceAuthorization) (*DeviceToken, error) {
+	interval := time.Duration(authorization.Interval) * time.Second
+	if interval <= 0 {
+		interval = f.PollInterval
+	}
+
+	if interval <= 0 {
+		interval = defaultDevicePollInterval
+	}
+
+	if authorization.ExpiresIn > 0 {
+		var cancel context.CancelFunc
+
+		ctx, cancel = context.WithTimeout(ctx, time.Duration(authorization.ExpiresIn)*time.Second)
+		defer cancel()
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil, fmt.Errorf("oidc: waiting for device sign-in: %w", ctx.Err())
+		case <-time.After(interval):
+		}
+
+		var resp deviceTokenResponse
+		if err := f.postForm(ctx, authorization.tokenEndpoint, url.Values{
+			"grant_type":  {deviceCodeGrantType},
+			"device_code": {authorization.DeviceCode},
+			"client_id":   {f.ClientID},
+		}, &resp); err != nil {
+			return nil, fmt.Errorf("oidc: device token: %w", err)
+		}
+
+		switch resp.Error {
+		case "":
+			return resp.token()
+		case "authorization_pending":
+		case "slow_down":
+			interval += slowDownIncrement
+		default:
+			return nil, fmt.Errorf("oidc: device sign-in failed: %s %s", resp.Error, resp.ErrorDescription)
+		}
+	}
+}
+
+// Refresh renews an expired token with its refresh token. Providers that do not
+// rotate refresh tokens return none, the previous one is kept then.
+func (f *DeviceCodeFlow) Refresh(ctx context.Context, token *DeviceToken) (*DeviceToken, error) {
+	if token.RefreshToken == "" {
+		return nil, errors.New("oidc: token has no refresh token")
+	}
+
+	metadata, err := discover(ctx, f.httpClient(), f.IssuerURL)
+	if err != nil {
+		return nil, err
+	}
+
+	var resp deviceTokenResponse
+	if err := f.postForm(ctx, metadata.TokenEndpoint, url.Values{
+		"grant_type":    {refreshTokenGrantType},
+		"refresh_token": {token.RefreshToken},
+		"client_id":     {f.ClientID},
+	}, &resp); err != nil {
+		return nil, fmt.Errorf("oidc: refresh token: %w", err)
+	}
+
+	if resp.Error != "" {
+		return nil, fmt.Errorf("oidc: refresh failed: %s %s", resp.Error, resp.ErrorDescription)
+	}
+
+	refreshed, err := resp.token()
+	if err != nil {
+		return nil, err
+	}
+
+	if refreshed.RefreshToken == "" {
+		refreshed.RefreshToken = token.RefreshToken
+	}
+
+	return refreshed, nil
+}
+
+// token takes the expiry from the exp claim of the ID token, which is what the APIs
+// check, and falls back to expires_in of the response.
+func (r *deviceTokenResponse) token() (*DeviceToken, error) {
+	if r.IDToken == "" {
+		return nil, errors.New("oidc: token response did not contain an id_token")
+	}
+
+	token := &DeviceToken{IDToken: r.IDToken, RefreshToken: r.RefreshToken}
+
+	var claims jwt.RegisteredClaims
+	if _, _, err := jwt.NewParser().ParseUnverified(r.IDToken, &claims); err == nil && claims.ExpiresAt != nil {
+		token.Expiry = claims.ExpiresAt.Time
+	} else if r.ExpiresIn > 0 {
+		token.Expiry = time.Now().Add(time.Duration(r.ExpiresIn) * time.Second)
+	}
+
+	return token, nil
+}
+
+// postForm posts a form and decodes the JSON response. Error responses of the token
+// endpoint carry a JSON body too, so any 2xx or 400 body is decoded.
+func (f *DeviceCodeFlow) postForm(ctx context.Context, endpoint string, form url.Values, v any) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, strings.NewReader(form.Encode()))
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := f.httpClient().Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode/100 != 2 && resp.StatusCode != http.StatusBadRequest {
+		return fmt.Errorf("POST %s: unexpected status %d", endpoint, resp.StatusCode)
+	}
+
+	return json.NewDecoder(resp.Body).Decode(v)
+}
+
+func (f *DeviceCodeFlow) httpClient() *http.Client {
+	if f.HTTPClient == nil {
+		return http.DefaultClient
+	}
+
+	return f.HTTPClient
+}
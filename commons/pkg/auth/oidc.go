@@ -0,0 +1,358 @@
+// Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package auth
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"math/big"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+const (
+	defaultUsernameClaim = "email"
+	defaultGroupsClaim   = "groups"
+
+	// jwksMinRefreshInterval bounds how often an unknown key ID triggers a JWKS refetch
+	jwksMinRefreshInterval = time.Minute
+	// tokenLeeway tolerates clock skew between the identity provider and the component
+	tokenLeeway = 30 * time.Second
+)
+
+// OIDCConfig configures validation of ID tokens issued by an OpenID Connect provider.
+type OIDCConfig struct {
+	// IssuerURL is the provider URL, it must match the iss claim of the tokens.
+	IssuerURL string `toml:"issuerURL"`
+	// ClientID is the client the tokens were issued for, it must be in the aud claim.
+	ClientID string `toml:"clientID"`
+	// UsernameClaim names the caller in logs, defaults to "email".
+	UsernameClaim string `toml:"usernameClaim"`
+	// GroupsClaim holds the groups of the caller, defaults to "groups".
+	GroupsClaim string `toml:"groupsClaim"`
+	// GroupRoles maps identity provider groups to roles. A caller in several
+	// groups is granted the highest role.
+	GroupRoles map[string]string `toml:"groupRoles"`
+}
+
+// providerMetadata is the subset of the OpenID provider discovery document we use.
+type providerMetadata struct {
+	Issuer                      string `json:"issuer"`
+	JWKSURI                     string `json:"jwks_uri"`
+	TokenEndpoint               string `json:"token_endpoint"`
+	DeviceAuthorizationEndpoint string `json:"device_authorization_endpoint"`
+}
+
+// OIDCAuthenticator authenticates bearer tokens that are ID tokens of an OpenID
+// Connect provider and grants roles based on the groups claim.
+type OIDCAuthenticator struct {
+	cfg        OIDCConfig
+	groupRoles map[string]Role
+	parser     *jwt.Parser
+	httpClient *http.Client
+	jwksURI    string
+
+	mu          sync.RWMutex
+	keys        map[string]crypto.PublicKey
+	lastRefresh time.Time
+}
+
+// NewOIDCAuthenticator discovers the provider and fetches its signing keys.
+func NewOIDCAuthenticator(ctx context.Context, cfg OIDCConfig, httpClient *http.Client) (*OIDCAuthenticator, error) {
+	if cfg.IssuerURL == "" {
+		return nil, errors.New("oidc: issuerURL is required")
+	}
+
+	if cfg.ClientID == "" {
+		return nil, errors.New("oidc: clientID is required")
+	}
+
+	if cfg.UsernameClaim == "" {
+		cfg.UsernameClaim = defaultUsernameClaim
+	}
+
+	if cfg.GroupsClaim == "" {
+		cfg.GroupsClaim = defaultGroupsClaim
+	}
+
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+
+	groupRoles := make(map[string]Role, len(cfg.GroupRoles))
+
+	for group, name := range cfg.GroupRoles {
+		role, err := ParseRole(name)
+		if err != nil {
+			return nil, fmt.Errorf("oidc: group %q: %w", group, err)
+		}
+
+		groupRoles[group] = role
+	}
+
+	metadata, err := discover(ctx, httpClient, cfg.IssuerURL)
+	if err != nil {
+		return nil, err
+	}
+
+	a := &OIDCAuthenticator{
+		cfg:        cfg,
+		groupRoles: groupRoles,
+		httpClient: httpClient,
+		jwksURI:    metadata.JWKSURI,
+		parser: jwt.NewParser(
+			jwt.WithValidMethods([]string{"RS256", "RS384", "RS512", "ES256", "ES384", "ES512"}),
+			jwt.WithIssuer(cfg.IssuerURL),
+			jwt.WithAudience(cfg.ClientID),
+			jwt.WithExpirationRequired(),
+			jwt.WithLeeway(tokenLeeway),
+		),
+	}
+
+	if err := a.refreshKeys(ctx); err != nil {
+		return nil, err
+	}
+
+	return a, nil
+}
+
+// Authenticate implements Authenticator. Bearer tokens that are not JWTs are left to
+// the other authenticators.
+func (a *OIDCAuthenticator) Authenticate(r *http.Request) (*Principal, error) {
+	raw, ok := bearerToken(r)
+	if !ok || strings.Count(raw, ".") != 2 {
+		return nil, ErrNoCredentials
+	}
+
+	claims := jwt.MapClaims{}
+
+	if _, err := a.parser.ParseWithClaims(raw, claims, func(t *jwt.Token) (any, error) {
+		return a.key(r.Context(), t)
+	}); err != nil {
+		slog.Debug("OIDC token rejected", "error", err)
+		return nil, fmt.Errorf("%w: %w", ErrInvalidCredentials, err)
+	}
+
+	name, _ := claims[a.cfg.UsernameClaim].(string)
+	if name == "" {
+		name, _ = claims["sub"].(string)
+	}
+
+	role := RoleNone
+
+	for _, group := range stringsClaim(claims[a.cfg.GroupsClaim]) {
+		if groupRole, ok := a.groupRoles[group]; ok && groupRole > role {
+			role = groupRole
+		}
+	}
+
+	return &Principal{Name: name, Role: role}, nil
+}
+
+// key returns the public key the token was signed with. Keys are refetched when
+// an unknown key ID is seen, so provider key rotation is picked up.
+func (a *OIDCAuthenticator) key(ctx context.Context, t *jwt.Token) (crypto.PublicKey, error) {
+	kid, _ := t.Header["kid"].(string)
+
+	a.mu.RLock()
+	key, ok := a.keys[kid]
+	stale := time.Since(a.lastRefresh) > jwksMinRefreshInterval
+	a.mu.RUnlock()
+
+	if ok {
+		return key, nil
+	}
+
+	if stale {
+		if err := a.refreshKeys(ctx); err != nil {
+			return nil, err
+		}
+
+		a.mu.RLock()
+		key, ok = a.keys[kid]
+		a.mu.RUnlock()
+
+		if ok {
+			return key, nil
+		}
+	}
+
+	return nil, fmt.Errorf("unknown signing key %q", kid)
+}
+
+func (a *OIDCAuthenticator) refreshKeys(ctx context.Context) error {
+	var set struct {
+		Keys []jsonWebKey `json:"keys"`
+	}
+
+	if err := getJSON(ctx, a.httpClient, a.jwksURI, &set); err != nil {
+		return fmt.Errorf("oidc: fetching signing keys: %w", err)
+	}
+
+	keys := make(map[string]crypto.PublicKey, len(set.Keys))
+
+	for _, k := range set.Keys {
+		if k.Use != "" && k.Use != "sig" {
+			continue
+		}
+
+		key, err := k.publicKey()
+		if err != nil {
+			slog.Warn("Skipping unsupported OIDC signing key", "kid", k.Kid, "error", err)
+			continue
+		}
+
+		keys[k.Kid] = key
+	}
+
+	a.mu.Lock()
+	a.keys = keys
+	a.lastRefresh = time.Now()
+	a.mu.Unlock()
+
+	return nil
+}
+
+// jsonWebKey is an RSA or EC public key in JWK format (RFC 7517).
+type jsonWebKey struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Use string `json:"use"`
+	N   string `json:"n"`
+	E   string `json:"e"`
+	Crv string `json:"crv"`
+	X   string `json:"x"`
+	Y   string `json:"y"`
+}
+
+func (k jsonWebKey) publicKey() (crypto.PublicKey, error) {
+	switch k.Kty {
+	case "RSA":
+		n, err := decodeBigInt(k.N)
+		if err != nil {
+			return nil, err
+		}
+
+		e, err := decodeBigInt(k.E)
+		if err != nil {
+			return nil, err
+		}
+
+		return &rsa.PublicKey{N: n, E: int(e.Int64())}, nil
+	case "EC":
+		var curve elliptic.Curve
+
+		switch k.Crv {
+		case "P-256":
+			curve = elliptic.P256()
+		case "P-384":
+			curve = elliptic.P384()
+		case "P-521":
+			curve = elliptic.P521()
+		default:
+			return nil, fmt.Errorf("unsupported curve %q", k.Crv)
+		}
+
+		x, err := decodeBigInt(k.X)
+		if err != nil {
+			return nil, err
+		}
+
+		y, err := decodeBigInt(k.Y)
+		if err != nil {
+			return nil, err
+		}
+
+		return &ecdsa.PublicKey{Curve: curve, X: x, Y: y}, nil
+	default:
+		return nil, fmt.Errorf("unsupported key type %q", k.Kty)
+	}
+}
+
+func decodeBigInt(s string) (*big.Int, error) {
+	b, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return nil, fmt.Errorf("invalid key encoding: %w", err)
+	}
+
+	return new(big.Int).SetBytes(b), nil
+}
+
+// stringsClaim returns a claim holding a string or a list of strings.
+func stringsClaim(v any) []string {
+	switch claim := v.(type) {
+	case string:
+		return []string{claim}
+	case []any:
+		values := make([]string, 0, len(claim))
+
+		for _, item := range claim {
+			if s, ok := item.(string); ok {
+				values = append(values, s)
+			}
+		}
+
+		return values
+	default:
+		return nil
+	}
+}
+
+// discover fetches the OpenID provider configuration of the issuer.
+func discover(ctx context.Context, httpClient *http.Client, issuerURL string) (*providerMetadata, error) {
+	var metadata providerMetadata
+
+	url := strings.TrimSuffix(issuerURL, "/") + "/.well-known/openid-configuration"
+	if err := getJSON(ctx, httpClient, url, &metadata); err != nil {
+		return nil, fmt.Errorf("oidc: discovery: %w", err)
+	}
+
+	if metadata.Issuer != issuerURL {
+		return nil, fmt.Errorf("oidc: discovery returned issuer %q, expected %q", metadata.Issuer, issuerURL)
+	}
+
+	return &metadata, nil
+}
+
+func getJSON(ctx context.Context, httpClient *http.Client, url string, v any) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return err
+	}
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("GET %s: unexpected status %d", url, resp.StatusCode)
+	}
+
+	return json.NewDecoder(resp.Body).Decode(v)
+}
@@ -0,0 +1,335 @@
+// Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package auth
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testClientID = "nvsentinel"
+
+// testProvider is a minimal OpenID provider serving discovery, JWKS and the device flow.
+type testProvider struct {
+	server      *httptest.Server
+	key         *rsa.PrivateKey
+	kid         string
+	jwksFetches atomic.Int32
+	pendingPoll atomic.Int32
+}
+
+func newTestProvider(t *testing.T) *testProvider {
+	t.Helper()
+
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	p := &testProvider{key: key, kid: "key-1"}
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("/.well-known/openid-configuration", func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode(providerMetadata{
+			Issuer:                      p.server.URL,
+			JWKSURI:                     p.server.URL + "/keys",
+			TokenEndpoint:               p.server.URL + "/token",
+			DeviceAuthorizationEndpoint: p.server.URL + "/device",
+		})
+	})
+	mux.HandleFunc("/keys", func(w http.ResponseWriter, r *http.Request) {
+		p.jwksFetches.Add(1)
+		_ = json.NewEncoder(w).Encode(map[string]any{"keys": []jsonWebKey{{
+			Kty: "RSA",
+			Kid: p.kid,
+			Use: "sig",
+			N:   base64.RawURLEncoding.EncodeToString(p.key.N.Bytes()),
+			E:   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(p.key.E)).Bytes()),
+		}}})
+	})
+	mux.HandleFunc("/device", func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode(DeviceAuthorization{
+			DeviceCode:      "device-code",
+			UserCode:        "ABCD-EFGH",
+			VerificationURI: p.server.URL + "/activate",
+			ExpiresIn:       10,
+			Interval:        0,
+		})
+	})
+	mux.HandleFunc("/token", func(w http.ResponseWriter, r *http.Request) {
+		require.NoError(t, r.ParseForm())
+
+		if r.PostForm.Get("grant_type") == refreshTokenGrantType {
+			if r.PostForm.Get("refresh_token") != "refresh-token" {
+				w.WriteHeader(http.StatusBadRequest)
+				_ = json.NewEncoder(w).Encode(deviceTokenResponse{Error: "invalid_grant"})
+
+				return
+			}
+
+			_ = json.NewEncoder(w).Encode(deviceTokenResponse{IDToken: p.sign(t, p.claims())})
+
+			return
+		}
+
+		assert.Equal(t, deviceCodeGrantType, r.PostForm.Get("grant_type"))
+		assert.Equal(t, "device-code", r.PostForm.Get("device_code"))
+
+		if p.pendingPoll.Add(-1) >= 0 {
+			w.WriteHeader(http.StatusBadRequest)
+			_ = json.NewEncoder(w).Encode(deviceTokenResponse{Error: "authorization_pending"})
+
+			return
+		}
+
+		_ = json.NewEncoder(w).Encode(deviceTokenResponse{
+			IDToken:      "id-token",
+			RefreshToken: "refresh-token",
+			ExpiresIn:    60,
+		})
+	})
+
+	p.server = httptest.NewServer(mux)
+	t.Cleanup(p.server.Close)
+
+	return p
+}
+
+func (p *testProvider) sign(t *testing.T, claims jwt.MapClaims) string {
+	t.Helper()
+
+	token := jwt.NewWithClaims(jwt.SigningMethodRS256, claims)
+	token.Header["kid"] = p.kid
+
+	signed, err := token.SignedString(p.key)
+	require.NoError(t, err)
+
+	return signed
+}
+
+func (p *testProvider) claims(groups ...string) jwt.MapClaims {
+	return jwt.MapClaims{
+		"iss":    p.server.URL,
+		"aud":    testClientID,
+		"sub":    "user-1",
+		"email":  "jane@example.com",
+		"groups": groups,
+		"exp":    time.Now().Add(time.Hour).Unix(),
+	}
+}
+
+func newTestOIDCAuthenticator(t *testing.T, p *testProvider) *OIDCAuthenticator {
+	t.Helper()
+
+	a, err := NewOIDCAuthenticator(context.Background(), OIDCConfig{
+		IssuerURL: p.server.URL,
+		ClientID:  testClientID,
+		GroupRoles: map[string]string{
+			"gpu-dashboards": "viewer",
+			"gpu-oncall":     "operator",
+		},
+	}, nil)
+	require.NoError(t, err)
+
+	return a
+}
+
+func TestOIDCAuthenticator(t *testing.T) {
+	p := newTestProvider(t)
+	a := newTestOIDCAuthenticator(t, p)
+
+	expired := p.claims("gpu-oncall")
+	expired["exp"] = time.Now().Add(-time.Hour).Unix()
+
+	wrongAudience := p.claims("gpu-oncall")
+	wrongAudience["aud"] = "other-client"
+
+	tests := []struct {
+		name         string
+		token        string
+		expectedErr  error
+		expectedRole Role
+	}{
+		{
+			name:         "highest group role wins",
+			token:        p.sign(t, p.claims("gpu-dashboards", "gpu-oncall")),
+			expectedRole: RoleOperator,
+		},
+		{
+			name:         "no mapped group",
+			token:        p.sign(t, p.claims("everyone")),
+			expectedRole: RoleNone,
+		},
+		{
+			name:        "expired token",
+			token:       p.sign(t, expired),
+			expectedErr: ErrInvalidCredentials,
+		},
+		{
+			name:        "wrong audience",
+			token:       p.sign(t, wrongAudience),
+			expectedErr: ErrInvalidCredentials,
+		},
+		{
+			name:        "static token is left to other authenticators",
+			token:       "operator-token",
+			expectedErr: ErrNoCredentials,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			req.Header.Set("Authorization", "Bearer "+tt.token)
+
+			principal, err := a.Authenticate(req)
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, "jane@example.com", principal.Name)
+			assert.Equal(t, tt.expectedRole, principal.Role)
+		})
+	}
+}
+
+func TestOIDCAuthenticator_KeyRotation(t *testing.T) {
+	p := newTestProvider(t)
+	a := newTestOIDCAuthenticator(t, p)
+
+	// the provider rotates its key after the authenticator fetched the key set
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	p.key = key
+	p.kid = "key-2"
+	token := p.sign(t, p.claims("gpu-oncall"))
+
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.Header.Set("Authorization", "Bearer "+token)
+
+	// keys were just fetched, the refetch is rate limited
+	_, err = a.Authenticate(req)
+	assert.ErrorIs(t, err, ErrInvalidCredentials)
+
+	a.mu.Lock()
+	a.lastRefresh = time.Now().Add(-2 * jwksMinRefreshInterval)
+	a.mu.Unlock()
+
+	principal, err := a.Authenticate(req)
+	require.NoError(t, err)
+	assert.Equal(t, RoleOperator, principal.Role)
+	assert.Equal(t, int32(2), p.jwksFetches.Load())
+}
+
+func TestMiddleware_TokensAndOIDC(t *testing.T) {
+	p := newTestProvider(t)
+
+	tokens, err := NewTokenAuthenticator([]TokenConfig{{Name: "grafana", Role: "viewer", Token: "viewer-token"}})
+	require.NoError(t, err)
+
+	mw := NewMiddleware(tokens, newTestOIDCAuthenticator(t, p))
+
+	rec := serve(mw, RoleOperator, p.sign(t, p.claims("gpu-oncall")))
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "jane@example.com", rec.Body.String())
+
+	rec = serve(mw, RoleViewer, "viewer-token")
+	assert.Equal(t, http.StatusOK, rec.Code)
+
+	rec = serve(mw, RoleViewer, p.sign(t, p.claims("everyone")))
+	assert.Equal(t, http.StatusForbidden, rec.Code)
+}
+
+func TestLoadAuthenticators(t *testing.T) {
+	p := newTestProvider(t)
+
+	path := filepath.Join(t.TempDir(), "tokens.toml")
+	require.NoError(t, os.WriteFile(path, []byte(`
+[[tokens]]
+name = "grafana"
+role = "viewer"
+token = "viewer-token"
+
+[oidc]
+issuerURL = "`+p.server.URL+`"
+clientID = "`+testClientID+`"
+groupRoles = { "gpu-oncall" = "operator" }
+`), 0o600))
+
+	authenticators, err := LoadAuthenticators(context.Background(), path)
+	require.NoError(t, err)
+	require.Len(t, authenticators, 2)
+	assert.IsType(t, &TokenAuthenticator{}, authenticators[0])
+	assert.IsType(t, &OIDCAuthenticator{}, authenticators[1])
+}
+
+func TestDeviceCodeFlow(t *testing.T) {
+	p := newTestProvider(t)
+	p.pendingPoll.Store(1)
+
+	flow := &DeviceCodeFlow{IssuerURL: p.server.URL, ClientID: testClientID, PollInterval: 10 * time.Millisecond}
+
+	authorization, err := flow.Start(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "ABCD-EFGH", authorization.UserCode)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	token, err := flow.Wait(ctx, authorization)
+	require.NoError(t, err)
+	assert.Equal(t, "id-token", token.IDToken)
+	assert.Equal(t, "refresh-token", token.RefreshToken)
+	assert.WithinDuration(t, time.Now().Add(time.Minute), token.Expiry, 5*time.Second)
+	assert.False(t, token.Expired())
+}
+
+func TestDeviceCodeFlow_Refresh(t *testing.T) {
+	p := newTestProvider(t)
+	flow := &DeviceCodeFlow{IssuerURL: p.server.URL, ClientID: testClientID}
+
+	expired := &DeviceToken{IDToken: "id-token", RefreshToken: "refresh-token", Expiry: time.Now().Add(-time.Minute)}
+	assert.True(t, expired.Expired())
+
+	token, err := flow.Refresh(context.Background(), expired)
+	require.NoError(t, err)
+	assert.NotEqual(t, "id-token", token.IDToken)
+	// the provider did not rotate the refresh token
+	assert.Equal(t, "refresh-token", token.RefreshToken)
+	// the expiry is the exp claim of the new ID token
+	assert.WithinDuration(t, time.Now().Add(time.Hour), token.Expiry, 5*time.Second)
+
+	_, err = flow.Refresh(context.Background(), &DeviceToken{IDToken: "id-token", RefreshToken: "revoked"})
+	assert.ErrorContains(t, err, "invalid_grant")
+
+	_, err = flow.Refresh(context.Background(), &DeviceToken{IDToken: "id-token"})
+	assert.Error(t, err)
+}
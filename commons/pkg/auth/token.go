@@ -0,0 +1,136 @@
+// Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package auth
+
+import (
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"net/http"
+
+	"github.com/nvidia/nvsentinel/commons/pkg/configmanager"
+)
+
+// TokenConfig is a static API token granted a role.
+type TokenConfig struct {
+	// Name identifies the token holder, e.g. "grafana" or "oncall".
+	Name  string `toml:"name"`
+	Role  string `toml:"role"`
+	Token string `toml:"token"`
+}
+
+// TokenFile is the layout of the token file, typically mounted from a Secret:
+//
+//	[[tokens]]
+//	name = "grafana"
+//	role = "viewer"
+//	token = "..."
+//
+//	[oidc]
+//	issuerURL = "https://login.example.com"
+//	clientID = "nvsentinel"
+//	groupRoles = { "gpu-oncall" = "operator", "gpu-admins" = "admin" }
+type TokenFile struct {
+	Tokens []TokenConfig `toml:"tokens"`
+	// OIDC additionally accepts ID tokens of an OpenID Connect provider.
+	OIDC *OIDCConfig `toml:"oidc"`
+}
+
+// TokenAuthenticator authenticates bearer tokens against a static token list.
+// Only SHA-256 digests of the tokens are kept in memory.
+type TokenAuthenticator struct {
+	principals map[[sha256.Size]byte]*Principal
+}
+
+// NewTokenAuthenticator creates a TokenAuthenticator from the given tokens.
+func NewTokenAuthenticator(tokens []TokenConfig) (*TokenAuthenticator, error) {
+	a := &TokenAuthenticator{principals: make(map[[sha256.Size]byte]*Principal, len(tokens))}
+
+	for i, t := range tokens {
+		if t.Name == "" {
+			return nil, fmt.Errorf("token %d: name is required", i)
+		}
+
+		if t.Token == "" {
+			return nil, fmt.Errorf("token %q: token is required", t.Name)
+		}
+
+		role, err := ParseRole(t.Role)
+		if err != nil {
+			return nil, fmt.Errorf("token %q: %w", t.Name, err)
+		}
+
+		digest := sha256.Sum256([]byte(t.Token))
+		if _, exists := a.principals[digest]; exists {
+			return nil, fmt.Errorf("token %q: duplicate token", t.Name)
+		}
+
+		a.principals[digest] = &Principal{Name: t.Name, Role: role}
+	}
+
+	return a, nil
+}
+
+// LoadTokenFile creates a TokenAuthenticator from a TOML token file.
+func LoadTokenFile(path string) (*TokenAuthenticator, error) {
+	var file TokenFile
+	if err := configmanager.LoadTOMLConfig(path, &file); err != nil {
+		return nil, err
+	}
+
+	return NewTokenAuthenticator(file.Tokens)
+}
+
+// LoadAuthenticators creates the authenticators configured in a TOML token file:
+// the static tokens and, when the oidc section is present, an OIDCAuthenticator.
+func LoadAuthenticators(ctx context.Context, path string) ([]Authenticator, error) {
+	var file TokenFile
+	if err := configmanager.LoadTOMLConfig(path, &file); err != nil {
+		return nil, err
+	}
+
+	tokens, err := NewTokenAuthenticator(file.Tokens)
+	if err != nil {
+		return nil, err
+	}
+
+	authenticators := []Authenticator{tokens}
+
+	if file.OIDC != nil {
+		oidc, err := NewOIDCAuthenticator(ctx, *file.OIDC, nil)
+		if err != nil {
+			return nil, err
+		}
+
+		authenticators = append(authenticators, oidc)
+	}
+
+	return authenticators, nil
+}
+
+// Authenticate implements Authenticator.
+func (a *TokenAuthenticator) Authenticate(r *http.Request) (*Principal, error) {
+	token, ok := bearerToken(r)
+	if !ok {
+		return nil, ErrNoCredentials
+	}
+
+	p, ok := a.principals[sha256.Sum256([]byte(token))]
+	if !ok {
+		return nil, ErrInvalidCredentials
+	}
+
+	return p, nil
+}
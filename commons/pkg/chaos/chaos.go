@@ -0,0 +1,168 @@
+// Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package chaos holds the fault specification shared by the chaos wrappers of the
+// remediation components, which inject failures into the calls they make so the
+// components can be exercised against unreliable dependencies.
+//
+// Faults are configured with a comma separated list of op=mode[:count] entries,
+// for example "reboot=timeout:2,ready=partial". The operations, and the modes
+// each supports besides timeout and error, are defined by the wrapper. When count
+// is set the fault is injected for that many calls and the wrapped dependency is
+// used afterwards; otherwise every call fails.
+package chaos
+
+import (
+	"fmt"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// EnvVar is the environment variable holding the fault specification.
+const EnvVar = "CHAOS_FAULTS"
+
+// Operation identifies a call that faults can be injected into.
+type Operation string
+
+// Mode is the kind of failure injected into an operation.
+type Mode string
+
+const (
+	// ModeTimeout fails the call with context.DeadlineExceeded
+	ModeTimeout Mode = "timeout"
+	// ModeError fails the call with a generic error
+	ModeError Mode = "error"
+)
+
+// Fault describes the failure injected into a single operation.
+type Fault struct {
+	Mode Mode
+	// Count is the number of calls to fail; zero fails every call.
+	Count int
+}
+
+// Operations maps the operations supported by a wrapper to the modes they support
+// besides ModeTimeout and ModeError.
+type Operations map[Operation][]Mode
+
+// FaultsFromEnv parses the faults configured in EnvVar, nil when it is empty.
+func FaultsFromEnv(operations Operations) (map[Operation]Fault, error) {
+	spec := os.Getenv(EnvVar)
+	if spec == "" {
+		return nil, nil
+	}
+
+	faults, err := ParseFaults(spec, operations)
+	if err != nil {
+		return nil, fmt.Errorf("parsing %s: %w", EnvVar, err)
+	}
+
+	return faults, nil
+}
+
+// ParseFaults parses a fault specification of the form op=mode[:count],...
+func ParseFaults(spec string, operations Operations) (map[Operation]Fault, error) {
+	faults := make(map[Operation]Fault)
+
+	for _, entry := range strings.Split(spec, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+
+		op, rest, ok := strings.Cut(entry, "=")
+		if !ok {
+			return nil, fmt.Errorf("invalid fault %q: expected op=mode[:count]", entry)
+		}
+
+		modeStr, countStr, hasCount := strings.Cut(rest, ":")
+
+		fault := Fault{Mode: Mode(strings.ToLower(modeStr))}
+
+		if hasCount {
+			count, err := strconv.Atoi(countStr)
+			if err != nil || count < 0 {
+				return nil, fmt.Errorf("invalid count in fault %q", entry)
+			}
+
+			fault.Count = count
+		}
+
+		operation := Operation(strings.ToLower(op))
+		if err := operations.validate(operation, fault.Mode); err != nil {
+			return nil, fmt.Errorf("invalid fault %q: %w", entry, err)
+		}
+
+		faults[operation] = fault
+	}
+
+	return faults, nil
+}
+
+func (o Operations) validate(op Operation, mode Mode) error {
+	modes, ok := o[op]
+	if !ok {
+		return fmt.Errorf("unknown operation %q", op)
+	}
+
+	if mode == ModeTimeout || mode == ModeError || slices.Contains(modes, mode) {
+		return nil
+	}
+
+	for other, otherModes := range o {
+		if slices.Contains(otherModes, mode) {
+			return fmt.Errorf("mode %q is only supported for %q", mode, other)
+		}
+	}
+
+	return fmt.Errorf("unknown mode %q", mode)
+}
+
+// Injector tracks the calls of every operation to decide which of them fail. It is
+// safe for concurrent use.
+type Injector struct {
+	mu     sync.Mutex
+	faults map[Operation]Fault
+	calls  map[Operation]int
+}
+
+// NewInjector returns an Injector for the given faults.
+func NewInjector(faults map[Operation]Fault) *Injector {
+	return &Injector{
+		faults: faults,
+		calls:  make(map[Operation]int),
+	}
+}
+
+// Inject returns the fault to apply to this call of op, if any.
+func (i *Injector) Inject(op Operation) (Mode, bool) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	fault, ok := i.faults[op]
+	if !ok {
+		return "", false
+	}
+
+	i.calls[op]++
+
+	if fault.Count > 0 && i.calls[op] > fault.Count {
+		return "", false
+	}
+
+	return fault.Mode, true
+}
@@ -0,0 +1,107 @@
+// Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package chaos
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testOperations = Operations{
+	"reboot": nil,
+	"ready":  {"partial"},
+}
+
+func TestParseFaults(t *testing.T) {
+	tests := []struct {
+		name     string
+		spec     string
+		expected map[Operation]Fault
+		wantErr  string
+	}{
+		{
+			name: "multiple faults",
+			spec: "reboot=timeout:2, ready=PARTIAL",
+			expected: map[Operation]Fault{
+				"reboot": {Mode: ModeTimeout, Count: 2},
+				"ready":  {Mode: "partial"},
+			},
+		},
+		{name: "empty spec", spec: "", expected: map[Operation]Fault{}},
+		{name: "missing mode", spec: "reboot", wantErr: "expected op=mode[:count]"},
+		{name: "unknown operation", spec: "drain=error", wantErr: `unknown operation "drain"`},
+		{name: "unknown mode", spec: "reboot=explode", wantErr: `unknown mode "explode"`},
+		{name: "mode of another operation", spec: "reboot=partial", wantErr: `only supported for "ready"`},
+		{name: "negative count", spec: "ready=error:-1", wantErr: "invalid count"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			faults, err := ParseFaults(tt.spec, testOperations)
+			if tt.wantErr != "" {
+				assert.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, faults)
+		})
+	}
+}
+
+func TestFaultsFromEnv(t *testing.T) {
+	t.Setenv(EnvVar, "")
+
+	faults, err := FaultsFromEnv(testOperations)
+	require.NoError(t, err)
+	assert.Nil(t, faults)
+
+	t.Setenv(EnvVar, "ready=error")
+
+	faults, err = FaultsFromEnv(testOperations)
+	require.NoError(t, err)
+	assert.Equal(t, map[Operation]Fault{"ready": {Mode: ModeError}}, faults)
+
+	t.Setenv(EnvVar, "ready=bogus")
+
+	_, err = FaultsFromEnv(testOperations)
+	assert.ErrorContains(t, err, EnvVar)
+}
+
+func TestInjectorCount(t *testing.T) {
+	injector := NewInjector(map[Operation]Fault{
+		"reboot": {Mode: ModeError, Count: 2},
+		"ready":  {Mode: "partial"},
+	})
+
+	for range 2 {
+		mode, ok := injector.Inject("reboot")
+		assert.True(t, ok)
+		assert.Equal(t, ModeError, mode)
+	}
+
+	_, ok := injector.Inject("reboot")
+	assert.False(t, ok, "the fault is only injected for its count")
+
+	for range 5 {
+		_, ok := injector.Inject("ready")
+		assert.True(t, ok, "a fault without count is injected into every call")
+	}
+
+	_, ok = injector.Inject("terminate")
+	assert.False(t, ok)
+}
@@ -0,0 +1,202 @@
+// Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cloudmetadata
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func notFound() *httptest.Server {
+	return httptest.NewServer(http.NotFoundHandler())
+}
+
+func TestFetchAWS(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("PUT /latest/api/token", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, awsTokenTTL, r.Header.Get("X-aws-ec2-metadata-token-ttl-seconds"))
+		_, _ = w.Write([]byte("token"))
+	})
+
+	for path, value := range map[string]string{
+		"instance-id":                 "i-0123456789abcdef0",
+		"instance-type":               "p5.48xlarge",
+		"placement/availability-zone": "us-east-1a",
+	} {
+		mux.HandleFunc("GET /latest/meta-data/"+path, func(w http.ResponseWriter, r *http.Request) {
+			if r.Header.Get("X-aws-ec2-metadata-token") != "token" {
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+
+			_, _ = w.Write([]byte(value))
+		})
+	}
+
+	aws := httptest.NewServer(mux)
+	defer aws.Close()
+
+	other := notFound()
+	defer other.Close()
+
+	client := NewClientWithEndpoints(time.Second, Endpoints{AWS: aws.URL, GCP: other.URL, Azure: other.URL})
+
+	instance, err := client.Fetch(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, &Instance{
+		Provider:     ProviderAWS,
+		InstanceID:   "i-0123456789abcdef0",
+		InstanceType: "p5.48xlarge",
+		Zone:         "us-east-1a",
+	}, instance)
+}
+
+func TestFetchGCP(t *testing.T) {
+	values := map[string]string{
+		"id":           "4520031799277581759",
+		"machine-type": "projects/123456/machineTypes/a3-highgpu-8g",
+		"zone":         "projects/123456/zones/us-central1-a",
+	}
+
+	gcp := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		value, ok := values[r.URL.Path[len("/computeMetadata/v1/instance/"):]]
+		if !ok || r.Header.Get("Metadata-Flavor") != "Google" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		_, _ = w.Write([]byte(value))
+	}))
+	defer gcp.Close()
+
+	client := NewClientWithEndpoints(time.Second, Endpoints{GCP: gcp.URL})
+
+	instance, err := client.Fetch(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, &Instance{
+		Provider:     ProviderGCP,
+		InstanceID:   "4520031799277581759",
+		InstanceType: "a3-highgpu-8g",
+		Zone:         "us-central1-a",
+	}, instance)
+}
+
+func TestFetchAzure(t *testing.T) {
+	azure := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/metadata/instance/compute" || r.Header.Get("Metadata") != "true" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		_, _ = w.Write([]byte(`{"vmId":"02aab8a4-74ef-476e-8182-f6d2ba4166a6",` +
+			`"vmSize":"Standard_ND96isr_H100_v5","location":"eastus2","zone":"1"}`))
+	}))
+	defer azure.Close()
+
+	other := notFound()
+	defer other.Close()
+
+	client := NewClientWithEndpoints(time.Second, Endpoints{AWS: other.URL, Azure: azure.URL})
+
+	instance, err := client.Fetch(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, &Instance{
+		Provider:     ProviderAzure,
+		InstanceID:   "02aab8a4-74ef-476e-8182-f6d2ba4166a6",
+		InstanceType: "Standard_ND96isr_H100_v5",
+		Zone:         "eastus2-1",
+	}, instance)
+}
+
+func TestFetchNoMetadataService(t *testing.T) {
+	other := notFound()
+	defer other.Close()
+
+	client := NewClientWithEndpoints(time.Second, Endpoints{AWS: other.URL, GCP: other.URL, Azure: other.URL})
+
+	_, err := client.Fetch(context.Background())
+	require.ErrorIs(t, err, ErrNoMetadataService)
+}
+
+func TestFromNode(t *testing.T) {
+	tests := []struct {
+		name       string
+		providerID string
+		labels     map[string]string
+		expected   *Instance
+	}{
+		{
+			name:       "aws",
+			providerID: "aws:///us-west-2b/i-0abc",
+			labels: map[string]string{
+				LabelInstanceType: "p5.48xlarge",
+				"karpenter.k8s.aws/capacity-reservation-id": "cr-0123",
+			},
+			expected: &Instance{
+				Provider:              ProviderAWS,
+				InstanceID:            "i-0abc",
+				InstanceType:          "p5.48xlarge",
+				Zone:                  "us-west-2b",
+				CapacityReservationID: "cr-0123",
+			},
+		},
+		{
+			name:       "gcp with zone label",
+			providerID: "gce://my-project/us-central1-a/gpu-node-1",
+			labels:     map[string]string{LabelZone: "us-central1-c"},
+			expected:   &Instance{Provider: ProviderGCP, InstanceID: "gpu-node-1", Zone: "us-central1-c"},
+		},
+		{
+			name: "azure",
+			providerID: "azure:///subscriptions/sub/resourceGroups/rg/providers/" +
+				"Microsoft.Compute/virtualMachineScaleSets/gpu/virtualMachines/3",
+			expected: &Instance{Provider: ProviderAzure, InstanceID: "3"},
+		},
+		{
+			name:       "oci",
+			providerID: "oci://ocid1.instance.oc1.iad.abc",
+			expected:   &Instance{Provider: ProviderOCI, InstanceID: "ocid1.instance.oc1.iad.abc"},
+		},
+		{name: "kind", providerID: "kind://docker/kind/kind-worker"},
+		{name: "empty"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, FromNode(tt.providerID, tt.labels, DefaultCapacityReservationLabels))
+		})
+	}
+}
+
+func TestMergeAndAddMetadata(t *testing.T) {
+	instance := &Instance{Provider: ProviderAWS, InstanceID: "i-imds", Zone: "us-east-1a"}
+	instance.Merge(&Instance{Provider: ProviderAWS, InstanceID: "i-node", CapacityReservationID: "cr-1"})
+
+	metadata := map[string]string{}
+	instance.AddMetadata(metadata)
+
+	assert.Equal(t, map[string]string{
+		MetadataKeyProvider:              "aws",
+		MetadataKeyInstanceID:            "i-imds",
+		MetadataKeyZone:                  "us-east-1a",
+		MetadataKeyCapacityReservationID: "cr-1",
+	}, metadata)
+}
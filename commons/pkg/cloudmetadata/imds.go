@@ -0,0 +1,260 @@
+// Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cloudmetadata
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"path"
+	"strings"
+	"time"
+)
+
+const (
+	// DefaultTimeout bounds the IMDS lookup. Metadata services answer within
+	// milliseconds, off-cloud the link-local address does not answer at all.
+	DefaultTimeout = 2 * time.Second
+
+	defaultAWSEndpoint   = "http://169.254.169.254"
+	defaultGCPEndpoint   = "http://metadata.google.internal"
+	defaultAzureEndpoint = "http://169.254.169.254"
+
+	azureAPIVersion = "2021-02-01"
+	awsTokenTTL     = "60"
+	maxResponseSize = 64 << 10
+)
+
+// ErrNoMetadataService is returned when no provider metadata service answered.
+var ErrNoMetadataService = errors.New("no cloud instance metadata service reachable")
+
+// errNotFound is returned by get when the metadata path does not exist, which
+// the interruption endpoints use to signal that no notice is pending.
+var errNotFound = errors.New("not found")
+
+// Endpoints are the base URLs of the provider metadata services.
+type Endpoints struct {
+	AWS   string
+	GCP   string
+	Azure string
+}
+
+// Client queries the instance metadata services of the supported providers.
+type Client struct {
+	endpoints  Endpoints
+	httpClient *http.Client
+}
+
+// NewClient creates an IMDS client. A zero timeout uses DefaultTimeout.
+func NewClient(timeout time.Duration) *Client {
+	return NewClientWithEndpoints(timeout, Endpoints{
+		AWS:   defaultAWSEndpoint,
+		GCP:   defaultGCPEndpoint,
+		Azure: defaultAzureEndpoint,
+	})
+}
+
+// NewClientWithEndpoints creates an IMDS client using the given endpoints.
+// Providers with an empty endpoint are not queried.
+func NewClientWithEndpoints(timeout time.Duration, endpoints Endpoints) *Client {
+	if timeout <= 0 {
+		timeout = DefaultTimeout
+	}
+
+	return &Client{
+		endpoints: endpoints,
+		// Metadata services must not be reached through a proxy
+		httpClient: &http.Client{
+			Timeout:   timeout,
+			Transport: &http.Transport{Proxy: nil},
+		},
+	}
+}
+
+// Fetch queries all provider metadata services concurrently and returns the
+// instance reported by the first one that answers. Capacity reservations are
+// not exposed by the metadata services and are left empty.
+func (c *Client) Fetch(ctx context.Context) (*Instance, error) {
+	ctx, cancel := context.WithTimeout(ctx, c.httpClient.Timeout)
+	defer cancel()
+
+	type result struct {
+		instance *Instance
+		err      error
+	}
+
+	fetchers := map[Provider]func(context.Context) (*Instance, error){}
+	if c.endpoints.AWS != "" {
+		fetchers[ProviderAWS] = c.fetchAWS
+	}
+
+	if c.endpoints.GCP != "" {
+		fetchers[ProviderGCP] = c.fetchGCP
+	}
+
+	if c.endpoints.Azure != "" {
+		fetchers[ProviderAzure] = c.fetchAzure
+	}
+
+	results := make(chan result, len(fetchers))
+
+	for provider, fetch := range fetchers {
+		go func() {
+			instance, err := fetch(ctx)
+			if err != nil {
+				err = fmt.Errorf("%s: %w", provider, err)
+			}
+
+			results <- result{instance: instance, err: err}
+		}()
+	}
+
+	errs := []error{ErrNoMetadataService}
+
+	for range fetchers {
+		r := <-results
+		if r.err == nil {
+			return r.instance, nil
+		}
+
+		errs = append(errs, r.err)
+	}
+
+	return nil, errors.Join(errs...)
+}
+
+func (c *Client) fetchAWS(ctx context.Context) (*Instance, error) {
+	// IMDSv2: every request carries a session token
+	token, err := c.get(ctx, http.MethodPut, c.endpoints.AWS+"/latest/api/token",
+		map[string]string{"X-aws-ec2-metadata-token-ttl-seconds": awsTokenTTL})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get IMDSv2 token: %w", err)
+	}
+
+	header := map[string]string{"X-aws-ec2-metadata-token": token}
+	instance := &Instance{Provider: ProviderAWS}
+
+	for field, path := range map[*string]string{
+		&instance.InstanceID:   "instance-id",
+		&instance.InstanceType: "instance-type",
+		&instance.Zone:         "placement/availability-zone",
+	} {
+		if *field, err = c.get(ctx, http.MethodGet, c.endpoints.AWS+"/latest/meta-data/"+path, header); err != nil {
+			return nil, err
+		}
+	}
+
+	return instance, nil
+}
+
+func (c *Client) fetchGCP(ctx context.Context) (*Instance, error) {
+	header := map[string]string{"Metadata-Flavor": "Google"}
+	instance := &Instance{Provider: ProviderGCP}
+
+	var err error
+
+	for field, attr := range map[*string]string{
+		&instance.InstanceID:   "id",
+		&instance.InstanceType: "machine-type",
+		&instance.Zone:         "zone",
+	} {
+		if *field, err = c.get(ctx, http.MethodGet, c.endpoints.GCP+"/computeMetadata/v1/instance/"+attr, header); err != nil {
+			return nil, err
+		}
+	}
+
+	// machine-type and zone are returned as projects/<number>/<kind>/<name>
+	instance.InstanceType = path.Base(instance.InstanceType)
+	instance.Zone = path.Base(instance.Zone)
+
+	return instance, nil
+}
+
+func (c *Client) fetchAzure(ctx context.Context) (*Instance, error) {
+	body, err := c.get(ctx, http.MethodGet,
+		c.endpoints.Azure+"/metadata/instance/compute?api-version="+azureAPIVersion+"&format=json",
+		map[string]string{"Metadata": "true"})
+	if err != nil {
+		return nil, err
+	}
+
+	var compute struct {
+		VMID     string `json:"vmId"`
+		VMSize   string `json:"vmSize"`
+		Zone     string `json:"zone"`
+		Location string `json:"location"`
+	}
+
+	if err := json.Unmarshal([]byte(body), &compute); err != nil {
+		return nil, fmt.Errorf("failed to decode compute metadata: %w", err)
+	}
+
+	if compute.VMID == "" {
+		return nil, fmt.Errorf("compute metadata has no vmId")
+	}
+
+	// Zonal VMs report the zone number only, e.g. eastus2 zone 1 is eastus2-1
+	zone := compute.Location
+	if compute.Zone != "" {
+		zone = compute.Location + "-" + compute.Zone
+	}
+
+	return &Instance{
+		Provider:     ProviderAzure,
+		InstanceID:   compute.VMID,
+		InstanceType: compute.VMSize,
+		Zone:         zone,
+	}, nil
+}
+
+func (c *Client) get(ctx context.Context, method, url string, header map[string]string) (string, error) {
+	req, err := http.NewRequestWithContext(ctx, method, url, nil)
+	if err != nil {
+		return "", fmt.Errorf("failed to create request: %w", err)
+	}
+
+	for key, value := range header {
+		req.Header.Set(key, value)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("request to %s failed: %w", url, err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxResponseSize))
+	if err != nil {
+		return "", fmt.Errorf("failed to read response of %s: %w", url, err)
+	}
+
+	if resp.StatusCode == http.StatusNotFound {
+		return "", fmt.Errorf("request to %s: %w", url, errNotFound)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("request to %s returned status %d", url, resp.StatusCode)
+	}
+
+	value := strings.TrimSpace(string(body))
+	if value == "" {
+		return "", fmt.Errorf("request to %s returned an empty response", url)
+	}
+
+	return value, nil
+}
@@ -0,0 +1,162 @@
+// Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package cloudmetadata identifies the cloud instance a node runs on: the
+// provider, instance ID, instance type, zone and capacity reservation. The
+// information is read from the provider instance metadata service (IMDS) when
+// it is reachable and otherwise derived from the Kubernetes node provider ID
+// and well-known labels, so that incidents can be cross-referenced with cloud
+// support tickets. It also reads the interruption notices of spot and
+// preemptible instances.
+package cloudmetadata
+
+import (
+	"path"
+	"strings"
+)
+
+// Provider is a cloud provider.
+type Provider string
+
+const (
+	ProviderAWS   Provider = "aws"
+	ProviderGCP   Provider = "gcp"
+	ProviderAzure Provider = "azure"
+	ProviderOCI   Provider = "oci"
+)
+
+// Keys of the health event metadata entries set from an Instance.
+const (
+	MetadataKeyProvider              = "cloud.provider"
+	MetadataKeyInstanceID            = "cloud.instanceID"
+	MetadataKeyInstanceType          = "cloud.instanceType"
+	MetadataKeyZone                  = "cloud.zone"
+	MetadataKeyCapacityReservationID = "cloud.capacityReservationID"
+)
+
+// Well-known node labels the instance is derived from when IMDS is not used.
+const (
+	LabelInstanceType = "node.kubernetes.io/instance-type"
+	LabelZone         = "topology.kubernetes.io/zone"
+)
+
+// DefaultCapacityReservationLabels are node labels set by the cloud node
+// provisioners to the capacity reservation or block backing the node.
+var DefaultCapacityReservationLabels = []string{
+	"karpenter.k8s.aws/capacity-reservation-id",
+	"topology.k8s.aws/capacity-block-id",
+	"cloud.google.com/reservation-name",
+}
+
+// Instance describes a cloud instance. Fields that are unknown are empty.
+type Instance struct {
+	Provider              Provider `json:"provider"`
+	InstanceID            string   `json:"instance_id"`
+	InstanceType          string   `json:"instance_type,omitempty"`
+	Zone                  string   `json:"zone,omitempty"`
+	CapacityReservationID string   `json:"capacity_reservation_id,omitempty"`
+}
+
+// AddMetadata sets the non-empty instance fields in the event metadata map.
+func (i *Instance) AddMetadata(metadata map[string]string) {
+	for key, value := range map[string]string{
+		MetadataKeyProvider:              string(i.Provider),
+		MetadataKeyInstanceID:            i.InstanceID,
+		MetadataKeyInstanceType:          i.InstanceType,
+		MetadataKeyZone:                  i.Zone,
+		MetadataKeyCapacityReservationID: i.CapacityReservationID,
+	} {
+		if value != "" {
+			metadata[key] = value
+		}
+	}
+}
+
+// Merge fills the empty fields of i from other. Fields already set are kept,
+// so the more authoritative source should be merged into.
+func (i *Instance) Merge(other *Instance) {
+	if other == nil {
+		return
+	}
+
+	if i.Provider == "" {
+		i.Provider = other.Provider
+	}
+
+	fill := func(dst *string, src string) {
+		if *dst == "" {
+			*dst = src
+		}
+	}
+
+	fill(&i.InstanceID, other.InstanceID)
+	fill(&i.InstanceType, other.InstanceType)
+	fill(&i.Zone, other.Zone)
+	fill(&i.CapacityReservationID, other.CapacityReservationID)
+}
+
+// FromNode derives the instance from a node provider ID and labels. The first
+// of reservationLabels present on the node is used as capacity reservation.
+// It returns nil if the provider ID is not one of a known cloud.
+//
+// Supported provider IDs:
+//
+//	aws:///<zone>/<instance-id>
+//	gce://<project>/<zone>/<instance-name>
+//	azure:///subscriptions/<sub>/resourceGroups/<rg>/providers/Microsoft.Compute/virtualMachines/<name>
+//	oci://<instance-ocid>
+func FromNode(providerID string, labels map[string]string, reservationLabels []string) *Instance {
+	scheme, rest, ok := strings.Cut(providerID, "://")
+	if !ok || rest == "" {
+		return nil
+	}
+
+	segments := strings.Split(strings.Trim(rest, "/"), "/")
+
+	instance := &Instance{
+		InstanceID:   path.Base(rest),
+		InstanceType: labels[LabelInstanceType],
+		Zone:         labels[LabelZone],
+	}
+
+	switch scheme {
+	case "aws":
+		instance.Provider = ProviderAWS
+
+		if instance.Zone == "" && len(segments) == 2 {
+			instance.Zone = segments[0]
+		}
+	case "gce":
+		instance.Provider = ProviderGCP
+
+		if instance.Zone == "" && len(segments) == 3 {
+			instance.Zone = segments[1]
+		}
+	case "azure":
+		instance.Provider = ProviderAzure
+	case "oci":
+		instance.Provider = ProviderOCI
+	default:
+		return nil
+	}
+
+	for _, label := range reservationLabels {
+		if value := labels[label]; value != "" {
+			instance.CapacityReservationID = value
+			break
+		}
+	}
+
+	return instance
+}
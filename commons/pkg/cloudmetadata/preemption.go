@@ -0,0 +1,183 @@
+// Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cloudmetadata
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+)
+
+const (
+	azureScheduledEventsAPIVersion = "2020-07-01"
+	azurePreemptEventType          = "Preempt"
+)
+
+// PreemptionImminentAnnotation is set on a node by the preemption watcher once
+// a notice was received, with the reclaim time as value if it is known.
+// Remediation skips such nodes since they are about to disappear anyway.
+const PreemptionImminentAnnotation = "nvsentinel.nvidia.com/preemption-imminent"
+
+// Keys of the health event metadata entries set from a PreemptionNotice.
+const (
+	MetadataKeyPreemptionAction = "cloud.preemptionAction"
+	MetadataKeyPreemptionTime   = "cloud.preemptionTime"
+)
+
+// spotLabels are node labels set by the cloud node provisioners on spot and
+// preemptible capacity, with the value marking such a node.
+var spotLabels = map[string]string{
+	"eks.amazonaws.com/capacityType":        "SPOT",
+	"karpenter.sh/capacity-type":            "spot",
+	"cloud.google.com/gke-spot":             "true",
+	"cloud.google.com/gke-preemptible":      "true",
+	"kubernetes.azure.com/scalesetpriority": "spot",
+}
+
+// PreemptionNotice is an interruption notice of spot or preemptible capacity.
+type PreemptionNotice struct {
+	Provider Provider
+	// Action is the provider action, e.g. terminate, stop or Preempt.
+	Action string
+	// Time is when the instance is reclaimed. It is zero if the provider does
+	// not announce it, e.g. GCP preempts within 30 seconds of the notice.
+	Time time.Time
+}
+
+// AddMetadata sets the notice fields in the event metadata map.
+func (n *PreemptionNotice) AddMetadata(metadata map[string]string) {
+	metadata[MetadataKeyProvider] = string(n.Provider)
+	metadata[MetadataKeyPreemptionAction] = n.Action
+
+	if !n.Time.IsZero() {
+		metadata[MetadataKeyPreemptionTime] = n.Time.UTC().Format(time.RFC3339)
+	}
+}
+
+// IsSpotNode reports whether the node labels mark spot or preemptible capacity.
+func IsSpotNode(labels map[string]string) bool {
+	for label, value := range spotLabels {
+		if strings.EqualFold(labels[label], value) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// PreemptionNotice queries the interruption endpoint of the provider metadata
+// service and returns the pending notice, or nil if there is none.
+func (c *Client) PreemptionNotice(ctx context.Context, provider Provider) (*PreemptionNotice, error) {
+	ctx, cancel := context.WithTimeout(ctx, c.httpClient.Timeout)
+	defer cancel()
+
+	switch provider {
+	case ProviderAWS:
+		return c.awsPreemptionNotice(ctx)
+	case ProviderGCP:
+		return c.gcpPreemptionNotice(ctx)
+	case ProviderAzure:
+		return c.azurePreemptionNotice(ctx)
+	default:
+		return nil, fmt.Errorf("preemption notices are not supported for provider %q", provider)
+	}
+}
+
+func (c *Client) awsPreemptionNotice(ctx context.Context) (*PreemptionNotice, error) {
+	token, err := c.get(ctx, http.MethodPut, c.endpoints.AWS+"/latest/api/token",
+		map[string]string{"X-aws-ec2-metadata-token-ttl-seconds": awsTokenTTL})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get IMDSv2 token: %w", err)
+	}
+
+	// The spot instance action only exists once the interruption is scheduled
+	body, err := c.get(ctx, http.MethodGet, c.endpoints.AWS+"/latest/meta-data/spot/instance-action",
+		map[string]string{"X-aws-ec2-metadata-token": token})
+	if errors.Is(err, errNotFound) {
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	var action struct {
+		Action string    `json:"action"`
+		Time   time.Time `json:"time"`
+	}
+
+	if err := json.Unmarshal([]byte(body), &action); err != nil {
+		return nil, fmt.Errorf("failed to decode spot instance action: %w", err)
+	}
+
+	return &PreemptionNotice{Provider: ProviderAWS, Action: action.Action, Time: action.Time}, nil
+}
+
+func (c *Client) gcpPreemptionNotice(ctx context.Context) (*PreemptionNotice, error) {
+	body, err := c.get(ctx, http.MethodGet, c.endpoints.GCP+"/computeMetadata/v1/instance/preempted",
+		map[string]string{"Metadata-Flavor": "Google"})
+	if err != nil {
+		return nil, err
+	}
+
+	if !strings.EqualFold(body, "TRUE") {
+		return nil, nil
+	}
+
+	return &PreemptionNotice{Provider: ProviderGCP, Action: "preempted"}, nil
+}
+
+func (c *Client) azurePreemptionNotice(ctx context.Context) (*PreemptionNotice, error) {
+	body, err := c.get(ctx, http.MethodGet,
+		c.endpoints.Azure+"/metadata/scheduledevents?api-version="+azureScheduledEventsAPIVersion,
+		map[string]string{"Metadata": "true"})
+	if err != nil {
+		return nil, err
+	}
+
+	var scheduled struct {
+		Events []struct {
+			EventType string `json:"EventType"`
+			NotBefore string `json:"NotBefore"`
+		} `json:"Events"`
+	}
+
+	if err := json.Unmarshal([]byte(body), &scheduled); err != nil {
+		return nil, fmt.Errorf("failed to decode scheduled events: %w", err)
+	}
+
+	for _, event := range scheduled.Events {
+		if event.EventType != azurePreemptEventType {
+			continue
+		}
+
+		notice := &PreemptionNotice{Provider: ProviderAzure, Action: event.EventType}
+
+		// NotBefore is an RFC 1123 time, empty once the event started
+		if event.NotBefore != "" {
+			if notBefore, err := time.Parse(time.RFC1123, event.NotBefore); err == nil {
+				notice.Time = notBefore
+			}
+		}
+
+		return notice, nil
+	}
+
+	return nil, nil
+}
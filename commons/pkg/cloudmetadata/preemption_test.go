@@ -0,0 +1,137 @@
+// Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cloudmetadata
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func awsSpotServer(t *testing.T, action string) *httptest.Server {
+	t.Helper()
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("PUT /latest/api/token", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte("token"))
+	})
+	mux.HandleFunc("GET /latest/meta-data/spot/instance-action", func(w http.ResponseWriter, r *http.Request) {
+		if action == "" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		_, _ = w.Write([]byte(action))
+	})
+
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	return server
+}
+
+func TestPreemptionNoticeAWS(t *testing.T) {
+	none := awsSpotServer(t, "")
+
+	notice, err := NewClientWithEndpoints(time.Second, Endpoints{AWS: none.URL}).
+		PreemptionNotice(context.Background(), ProviderAWS)
+	require.NoError(t, err)
+	assert.Nil(t, notice)
+
+	pending := awsSpotServer(t, `{"action": "terminate", "time": "2025-06-01T08:22:00Z"}`)
+
+	notice, err = NewClientWithEndpoints(time.Second, Endpoints{AWS: pending.URL}).
+		PreemptionNotice(context.Background(), ProviderAWS)
+	require.NoError(t, err)
+	assert.Equal(t, &PreemptionNotice{
+		Provider: ProviderAWS,
+		Action:   "terminate",
+		Time:     time.Date(2025, 6, 1, 8, 22, 0, 0, time.UTC),
+	}, notice)
+}
+
+func TestPreemptionNoticeGCP(t *testing.T) {
+	preempted := "FALSE"
+
+	gcp := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/computeMetadata/v1/instance/preempted" || r.Header.Get("Metadata-Flavor") != "Google" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		_, _ = w.Write([]byte(preempted))
+	}))
+	defer gcp.Close()
+
+	client := NewClientWithEndpoints(time.Second, Endpoints{GCP: gcp.URL})
+
+	notice, err := client.PreemptionNotice(context.Background(), ProviderGCP)
+	require.NoError(t, err)
+	assert.Nil(t, notice)
+
+	preempted = "TRUE"
+
+	notice, err = client.PreemptionNotice(context.Background(), ProviderGCP)
+	require.NoError(t, err)
+	assert.Equal(t, &PreemptionNotice{Provider: ProviderGCP, Action: "preempted"}, notice)
+}
+
+func TestPreemptionNoticeAzure(t *testing.T) {
+	azure := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/metadata/scheduledevents" || r.Header.Get("Metadata") != "true" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		_, _ = w.Write([]byte(`{"DocumentIncarnation": 2, "Events": [` +
+			`{"EventId": "a", "EventType": "Freeze", "NotBefore": ""},` +
+			`{"EventId": "b", "EventType": "Preempt", "NotBefore": "Mon, 19 Sep 2016 18:29:47 GMT"}]}`))
+	}))
+	defer azure.Close()
+
+	notice, err := NewClientWithEndpoints(time.Second, Endpoints{Azure: azure.URL}).
+		PreemptionNotice(context.Background(), ProviderAzure)
+	require.NoError(t, err)
+	require.NotNil(t, notice)
+	assert.Equal(t, "Preempt", notice.Action)
+	assert.True(t, notice.Time.Equal(time.Date(2016, 9, 19, 18, 29, 47, 0, time.UTC)))
+
+	metadata := map[string]string{}
+	notice.AddMetadata(metadata)
+	assert.Equal(t, map[string]string{
+		MetadataKeyProvider:         "azure",
+		MetadataKeyPreemptionAction: "Preempt",
+		MetadataKeyPreemptionTime:   "2016-09-19T18:29:47Z",
+	}, metadata)
+}
+
+func TestPreemptionNoticeUnsupportedProvider(t *testing.T) {
+	_, err := NewClient(time.Second).PreemptionNotice(context.Background(), ProviderOCI)
+	assert.Error(t, err)
+}
+
+func TestIsSpotNode(t *testing.T) {
+	assert.True(t, IsSpotNode(map[string]string{"eks.amazonaws.com/capacityType": "SPOT"}))
+	assert.True(t, IsSpotNode(map[string]string{"karpenter.sh/capacity-type": "spot"}))
+	assert.True(t, IsSpotNode(map[string]string{"cloud.google.com/gke-preemptible": "true"}))
+	assert.True(t, IsSpotNode(map[string]string{"kubernetes.azure.com/scalesetpriority": "spot"}))
+	assert.False(t, IsSpotNode(map[string]string{"karpenter.sh/capacity-type": "on-demand"}))
+	assert.False(t, IsSpotNode(nil))
+}
@@ -0,0 +1,233 @@
+// Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package configmanager
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"reflect"
+	"slices"
+	"strconv"
+
+	"github.com/BurntSushi/toml"
+)
+
+// ConfigPath is the path Handler is served under. The effective configuration
+// is served on ConfigPath, and a candidate configuration POSTed to
+// ConfigPath + "diff" is compared with it.
+const ConfigPath = "/config/"
+
+// maxCandidateBytes bounds the size of candidate configurations.
+const maxCandidateBytes = 4 << 20
+
+// Effective is the configuration a component runs with: its flags, including
+// the defaults, and its configuration file after loading.
+type Effective struct {
+	Component string            `json:"component"`
+	Flags     map[string]string `json:"flags"`
+	Config    map[string]any    `json:"config"`
+}
+
+// Difference is a setting that differs between the effective and a candidate
+// configuration. Effective or Candidate is absent when only one of them has
+// the setting.
+type Difference struct {
+	Path      string `json:"path"`
+	Effective any    `json:"effective,omitempty"`
+	Candidate any    `json:"candidate,omitempty"`
+}
+
+// DecodeTOMLConfig decodes TOML configuration data into the provided config
+// struct, like LoadTOMLConfig does for files.
+func DecodeTOMLConfig[T any](data []byte, config *T) error {
+	if _, err := toml.Decode(string(data), config); err != nil {
+		return fmt.Errorf("failed to decode TOML config: %w", err)
+	}
+
+	return nil
+}
+
+// Handler serves the effective configuration of a component on ConfigPath,
+// and the differences with a candidate TOML configuration POSTed to
+// ConfigPath + "diff". Candidates are loaded with load, which should apply
+// the defaults and validation the component applies to its own file, so that
+// the same configuration does not show differences. A nil load only decodes
+// the candidate.
+//
+// Example usage:
+//
+//	srv := server.NewServer(
+//	    server.WithHandler(configmanager.ConfigPath,
+//	        configmanager.Handler("fault-quarantine", &cfg, nil)),
+//	)
+func Handler[T any](component string, config *T, load func(data []byte) (*T, error)) http.Handler {
+	if load == nil {
+		load = func(data []byte) (*T, error) {
+			var candidate T
+			if err := DecodeTOMLConfig(data, &candidate); err != nil {
+				return nil, err
+			}
+
+			return &candidate, nil
+		}
+	}
+
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("GET "+ConfigPath+"{$}", func(w http.ResponseWriter, _ *http.Request) {
+		settings, err := normalize(config)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+
+		writeJSON(w, Effective{Component: component, Flags: flags(), Config: settings})
+	})
+
+	mux.HandleFunc("POST "+ConfigPath+"diff", func(w http.ResponseWriter, r *http.Request) {
+		data, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxCandidateBytes))
+		if err != nil {
+			http.Error(w, fmt.Sprintf("failed to read candidate config: %v", err), http.StatusBadRequest)
+			return
+		}
+
+		candidate, err := load(data)
+		if err != nil {
+			http.Error(w, fmt.Sprintf("invalid candidate config: %v", err), http.StatusBadRequest)
+			return
+		}
+
+		differences, err := Diff(config, candidate)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+
+		writeJSON(w, map[string]any{"component": component, "differences": differences})
+	})
+
+	return mux
+}
+
+func writeJSON(w http.ResponseWriter, v any) {
+	w.Header().Set("Content-Type", "application/json")
+
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		slog.Warn("Failed to write config response", "error", err)
+	}
+}
+
+// flags returns the values of the command line flags, defaults included.
+func flags() map[string]string {
+	values := map[string]string{}
+
+	flag.CommandLine.VisitAll(func(f *flag.Flag) {
+		values[f.Name] = f.Value.String()
+	})
+
+	return values
+}
+
+// normalize returns the settings of a configuration as they are written in
+// its TOML file, so that configurations compare by their settings.
+func normalize(config any) (map[string]any, error) {
+	var buf bytes.Buffer
+	if err := toml.NewEncoder(&buf).Encode(config); err != nil {
+		return nil, fmt.Errorf("failed to encode config: %w", err)
+	}
+
+	settings := map[string]any{}
+	if _, err := toml.Decode(buf.String(), &settings); err != nil {
+		return nil, fmt.Errorf("failed to decode config: %w", err)
+	}
+
+	return settings, nil
+}
+
+// Diff returns the settings that differ between two configurations, ordered
+// by path.
+func Diff(effective, candidate any) ([]Difference, error) {
+	a, err := normalize(effective)
+	if err != nil {
+		return nil, err
+	}
+
+	b, err := normalize(candidate)
+	if err != nil {
+		return nil, err
+	}
+
+	differences := []Difference{}
+	diff("", a, b, &differences)
+
+	return differences, nil
+}
+
+func diff(path string, a, b any, differences *[]Difference) {
+	switch a := a.(type) {
+	case map[string]any:
+		if b, ok := b.(map[string]any); ok {
+			keys := make([]string, 0, len(a)+len(b))
+			for key := range a {
+				keys = append(keys, key)
+			}
+
+			for key := range b {
+				if _, ok := a[key]; !ok {
+					keys = append(keys, key)
+				}
+			}
+
+			slices.Sort(keys)
+
+			for _, key := range keys {
+				child := key
+				if path != "" {
+					child = path + "." + key
+				}
+
+				diff(child, a[key], b[key], differences)
+			}
+
+			return
+		}
+	case []map[string]any:
+		if b, ok := b.([]map[string]any); ok {
+			for i := range max(len(a), len(b)) {
+				var x, y any
+				if i < len(a) {
+					x = a[i]
+				}
+
+				if i < len(b) {
+					y = b[i]
+				}
+
+				diff(path+"["+strconv.Itoa(i)+"]", x, y, differences)
+			}
+
+			return
+		}
+	}
+
+	if !reflect.DeepEqual(a, b) {
+		*differences = append(*differences, Difference{Path: path, Effective: a, Candidate: b})
+	}
+}
@@ -0,0 +1,123 @@
+// Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package configmanager
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+type testRule struct {
+	Name     string `toml:"name"`
+	Priority int    `toml:"priority"`
+}
+
+type testInspectConfig struct {
+	Name  string     `toml:"name"`
+	Port  int        `toml:"port"`
+	Rules []testRule `toml:"rules"`
+}
+
+func TestDiff(t *testing.T) {
+	t.Parallel()
+
+	effective := testInspectConfig{
+		Name:  "test",
+		Port:  8080,
+		Rules: []testRule{{Name: "xid", Priority: 1}, {Name: "sxid", Priority: 2}},
+	}
+	candidate := testInspectConfig{
+		Name:  "test",
+		Port:  9090,
+		Rules: []testRule{{Name: "xid", Priority: 5}},
+	}
+
+	differences, err := Diff(&effective, &candidate)
+	if err != nil {
+		t.Fatalf("failed to diff configs: %v", err)
+	}
+
+	expected := []Difference{
+		{Path: "port", Effective: int64(8080), Candidate: int64(9090)},
+		{Path: "rules[0].priority", Effective: int64(1), Candidate: int64(5)},
+		{Path: "rules[1]", Effective: map[string]any{"name": "sxid", "priority": int64(2)}},
+	}
+
+	if !reflect.DeepEqual(differences, expected) {
+		t.Errorf("expected differences %v, got %v", expected, differences)
+	}
+
+	differences, err = Diff(&effective, &effective)
+	if err != nil {
+		t.Fatalf("failed to diff configs: %v", err)
+	}
+
+	if len(differences) != 0 {
+		t.Errorf("expected no differences, got %v", differences)
+	}
+}
+
+func TestHandler(t *testing.T) {
+	t.Parallel()
+
+	cfg := testInspectConfig{Name: "test", Port: 8080}
+	handler := Handler("test-component", &cfg, nil)
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, ConfigPath, nil))
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d", rec.Code)
+	}
+
+	var effective Effective
+	if err := json.Unmarshal(rec.Body.Bytes(), &effective); err != nil {
+		t.Fatalf("failed to decode effective config: %v", err)
+	}
+
+	if effective.Component != "test-component" || effective.Config["port"] != float64(8080) {
+		t.Errorf("unexpected effective config %+v", effective)
+	}
+
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, ConfigPath+"diff",
+		strings.NewReader("name = \"test\"\nport = 9090\n")))
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d: %s", rec.Code, rec.Body)
+	}
+
+	var result struct {
+		Differences []Difference `json:"differences"`
+	}
+	if err := json.Unmarshal(rec.Body.Bytes(), &result); err != nil {
+		t.Fatalf("failed to decode differences: %v", err)
+	}
+
+	if len(result.Differences) != 1 || result.Differences[0].Path != "port" {
+		t.Errorf("expected a port difference, got %+v", result.Differences)
+	}
+
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, ConfigPath+"diff", strings.NewReader("port = ")))
+
+	if rec.Code != http.StatusBadRequest {
+		t.Errorf("expected status 400 for an invalid candidate, got %d", rec.Code)
+	}
+}
@@ -0,0 +1,364 @@
+// Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package connpool manages the gRPC connections of an agent to the platform
+// connector. It reconnects with exponential backoff and jitter, probes the health
+// of the connections, recreates those that stay unhealthy so DNS names are
+// resolved again, and fails calls over between the endpoints of several
+// connector replicas. A Pool is a grpc.ClientConnInterface, generated clients are
+// created on it like on a single connection.
+package connpool
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/backoff"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/connectivity"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/status"
+)
+
+// roundRobin spreads the calls of an endpoint over all the addresses its name
+// resolves to, e.g. the pods behind a headless service
+const roundRobin = `{"loadBalancingConfig": [{"round_robin": {}}]}`
+
+// Config describes the endpoints and the connection management.
+type Config struct {
+	// Endpoints are the gRPC targets of the platform connector in order of
+	// preference, e.g. unix:///var/run/nvsentinel.sock, then
+	// dns:///platform-connector.nvsentinel.svc:50051.
+	Endpoints []string
+	// BaseDelay and MaxDelay bound the exponential backoff between reconnection
+	// attempts, which are spread by Jitter, a fraction of the delay.
+	BaseDelay time.Duration
+	MaxDelay  time.Duration
+	Jitter    float64
+	// ProbeInterval is how often the health of the endpoints is probed, each probe
+	// times out after ProbeTimeout.
+	ProbeInterval time.Duration
+	ProbeTimeout  time.Duration
+	// FailureThreshold is the number of consecutive failed probes after which the
+	// connection of an endpoint is recreated.
+	FailureThreshold int
+	// DialOptions are added to the options of every connection, e.g. credentials.
+	DialOptions []grpc.DialOption
+}
+
+// DefaultConfig returns the configuration of the given endpoints with the default
+// connection management.
+func DefaultConfig(endpoints ...string) Config {
+	return Config{
+		Endpoints:        endpoints,
+		BaseDelay:        time.Second,
+		MaxDelay:         30 * time.Second,
+		Jitter:           0.2,
+		ProbeInterval:    10 * time.Second,
+		ProbeTimeout:     2 * time.Second,
+		FailureThreshold: 3,
+	}
+}
+
+// Validate checks the configuration.
+func (c *Config) Validate() error {
+	if len(c.Endpoints) == 0 {
+		return fmt.Errorf("at least one endpoint is required")
+	}
+
+	if c.BaseDelay <= 0 || c.MaxDelay < c.BaseDelay {
+		return fmt.Errorf("base delay must be positive and not exceed the max delay")
+	}
+
+	if c.Jitter < 0 || c.Jitter > 1 {
+		return fmt.Errorf("jitter must be between 0 and 1")
+	}
+
+	if c.ProbeInterval <= 0 || c.ProbeTimeout <= 0 || c.FailureThreshold <= 0 {
+		return fmt.Errorf("probe interval, probe timeout and failure threshold must be positive")
+	}
+
+	return nil
+}
+
+// endpoint is the connection to a target.
+type endpoint struct {
+	target   string
+	conn     *grpc.ClientConn
+	healthy  bool
+	failures int
+}
+
+// Pool holds a connection per endpoint.
+type Pool struct {
+	config Config
+
+	mu        sync.RWMutex
+	endpoints []*endpoint
+}
+
+var _ grpc.ClientConnInterface = (*Pool)(nil)
+
+// New creates the connections to the endpoints. Like grpc.NewClient it does not
+// wait for them to connect, see WaitReady. The endpoints are healthy until a probe
+// or a call fails.
+func New(config Config) (*Pool, error) {
+	if err := config.Validate(); err != nil {
+		return nil, err
+	}
+
+	pool := &Pool{config: config}
+
+	for _, target := range config.Endpoints {
+		conn, err := pool.dial(target)
+		if err != nil {
+			pool.Close()
+			return nil, err
+		}
+
+		pool.endpoints = append(pool.endpoints, &endpoint{target: target, conn: conn, healthy: true})
+		endpointHealthy.WithLabelValues(target).Set(1)
+	}
+
+	return pool, nil
+}
+
+func (p *Pool) dial(target string) (*grpc.ClientConn, error) {
+	opts := append([]grpc.DialOption{
+		grpc.WithConnectParams(grpc.ConnectParams{
+			Backoff: backoff.Config{
+				BaseDelay:  p.config.BaseDelay,
+				Multiplier: backoff.DefaultConfig.Multiplier,
+				Jitter:     p.config.Jitter,
+				MaxDelay:   p.config.MaxDelay,
+			},
+		}),
+		grpc.WithDefaultServiceConfig(roundRobin),
+	}, p.config.DialOptions...)
+
+	conn, err := grpc.NewClient(target, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create client for %s: %w", target, err)
+	}
+
+	return conn, nil
+}
+
+// Invoke calls method on the first healthy endpoint. When an endpoint is
+// unavailable it is marked unhealthy and the call fails over to the next one; the
+// unhealthy endpoints are tried last.
+func (p *Pool) Invoke(ctx context.Context, method string, args, reply any, opts ...grpc.CallOption) error {
+	var err error
+
+	for i, candidate := range p.ordered() {
+		if i > 0 {
+			slog.Warn("Failing over to the next platform connector endpoint",
+				"endpoint", candidate.endpoint.target, "error", err)
+			failovers.WithLabelValues(candidate.endpoint.target).Inc()
+		}
+
+		err = candidate.conn.Invoke(ctx, method, args, reply, opts...)
+		if status.Code(err) != codes.Unavailable || ctx.Err() != nil {
+			return err
+		}
+
+		p.markUnhealthy(candidate.endpoint)
+	}
+
+	return err
+}
+
+// NewStream opens the stream on the first healthy endpoint.
+func (p *Pool) NewStream(ctx context.Context, desc *grpc.StreamDesc, method string,
+	opts ...grpc.CallOption) (grpc.ClientStream, error) {
+	return p.ordered()[0].conn.NewStream(ctx, desc, method, opts...)
+}
+
+// candidate is an endpoint with its connection at the time of a call, which a
+// probe may replace meanwhile.
+type candidate struct {
+	endpoint *endpoint
+	conn     *grpc.ClientConn
+}
+
+// ordered returns the healthy endpoints, then the unhealthy ones, each in the
+// configured order.
+func (p *Pool) ordered() []candidate {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	ordered := make([]candidate, 0, len(p.endpoints))
+
+	for _, healthy := range []bool{true, false} {
+		for _, ep := range p.endpoints {
+			if ep.healthy == healthy {
+				ordered = append(ordered, candidate{endpoint: ep, conn: ep.conn})
+			}
+		}
+	}
+
+	return ordered
+}
+
+func (p *Pool) markUnhealthy(ep *endpoint) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if ep.healthy {
+		slog.Warn("Platform connector endpoint is unhealthy", "endpoint", ep.target)
+	}
+
+	ep.healthy = false
+
+	endpointHealthy.WithLabelValues(ep.target).Set(0)
+}
+
+// Run probes the endpoints every probe interval until ctx is done.
+func (p *Pool) Run(ctx context.Context) error {
+	ticker := time.NewTicker(p.config.ProbeInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+
+		p.Probe(ctx)
+	}
+}
+
+// Probe checks the health of every endpoint with the gRPC health service. Servers
+// without a health service are healthy once connected. The connection of an
+// endpoint failing FailureThreshold probes in a row is recreated.
+func (p *Pool) Probe(ctx context.Context) {
+	for _, candidate := range p.ordered() {
+		ep := candidate.endpoint
+		err := p.probe(ctx, candidate.conn)
+
+		p.mu.Lock()
+
+		if err == nil {
+			if !ep.healthy {
+				slog.Info("Platform connector endpoint is healthy again", "endpoint", ep.target)
+			}
+
+			ep.healthy, ep.failures = true, 0
+			endpointHealthy.WithLabelValues(ep.target).Set(1)
+			p.mu.Unlock()
+
+			continue
+		}
+
+		ep.healthy = false
+		ep.failures++
+		endpointHealthy.WithLabelValues(ep.target).Set(0)
+
+		slog.Warn("Platform connector endpoint probe failed", "endpoint", ep.target,
+			"failures", ep.failures, "error", err)
+
+		if ep.failures >= p.config.FailureThreshold {
+			p.reconnect(ep)
+		}
+
+		p.mu.Unlock()
+	}
+}
+
+func (p *Pool) probe(ctx context.Context, conn *grpc.ClientConn) error {
+	ctx, cancel := context.WithTimeout(ctx, p.config.ProbeTimeout)
+	defer cancel()
+
+	response, err := healthpb.NewHealthClient(conn).Check(ctx, &healthpb.HealthCheckRequest{},
+		grpc.WaitForReady(true))
+
+	switch {
+	case status.Code(err) == codes.Unimplemented:
+		return nil
+	case err != nil:
+		return err
+	case response.GetStatus() != healthpb.HealthCheckResponse_SERVING:
+		return fmt.Errorf("endpoint is %s", response.GetStatus())
+	default:
+		return nil
+	}
+}
+
+// reconnect replaces the connection of the endpoint, which resolves its name
+// again and skips the pending backoff. Called with the lock held.
+func (p *Pool) reconnect(ep *endpoint) {
+	conn, err := p.dial(ep.target)
+	if err != nil {
+		slog.Error("Failed to recreate platform connector connection", "endpoint", ep.target, "error", err)
+		return
+	}
+
+	slog.Info("Recreated platform connector connection", "endpoint", ep.target, "failures", ep.failures)
+	reconnects.WithLabelValues(ep.target).Inc()
+
+	previous := ep.conn
+	ep.conn, ep.failures = conn, 0
+
+	// calls still running on the previous connection fail and are retried
+	_ = previous.Close()
+}
+
+// WaitReady blocks until an endpoint is connected or ctx is done.
+func (p *Pool) WaitReady(ctx context.Context) error {
+	for {
+		var conns []*grpc.ClientConn
+
+		p.mu.RLock()
+		for _, ep := range p.endpoints {
+			conns = append(conns, ep.conn)
+		}
+		p.mu.RUnlock()
+
+		for _, conn := range conns {
+			conn.Connect()
+
+			if conn.GetState() == connectivity.Ready {
+				return nil
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("no platform connector endpoint is ready: %w", ctx.Err())
+		case <-time.After(100 * time.Millisecond):
+		}
+	}
+}
+
+// Close closes the connections.
+func (p *Pool) Close() error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	var errs []error
+
+	for _, ep := range p.endpoints {
+		if err := ep.conn.Close(); err != nil {
+			errs = append(errs, fmt.Errorf("failed to close connection to %s: %w", ep.target, err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
@@ -0,0 +1,192 @@
+// Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package connpool
+
+import (
+	"context"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+// socketDir is short enough for unix socket paths
+func socketDir(t *testing.T) string {
+	t.Helper()
+
+	dir, err := os.MkdirTemp("", "connpool")
+	require.NoError(t, err)
+	t.Cleanup(func() { os.RemoveAll(dir) })
+
+	return dir
+}
+
+// startServer serves on a unix socket, with a health service unless healthServer is nil.
+func startServer(t *testing.T, socket string, healthServer *health.Server) {
+	t.Helper()
+
+	listener, err := net.Listen("unix", socket)
+	require.NoError(t, err)
+
+	server := grpc.NewServer()
+	if healthServer != nil {
+		healthpb.RegisterHealthServer(server, healthServer)
+	}
+
+	go func() { _ = server.Serve(listener) }()
+
+	t.Cleanup(server.Stop)
+}
+
+func testConfig(endpoints ...string) Config {
+	config := DefaultConfig(endpoints...)
+	config.BaseDelay = 10 * time.Millisecond
+	config.MaxDelay = 100 * time.Millisecond
+	config.ProbeTimeout = 200 * time.Millisecond
+	config.FailureThreshold = 2
+	config.DialOptions = []grpc.DialOption{grpc.WithTransportCredentials(insecure.NewCredentials())}
+
+	return config
+}
+
+func newPool(t *testing.T, config Config) *Pool {
+	t.Helper()
+
+	pool, err := New(config)
+	require.NoError(t, err)
+	t.Cleanup(func() { pool.Close() })
+
+	return pool
+}
+
+func TestInvokeFailsOver(t *testing.T) {
+	dir := socketDir(t)
+	primary, secondary := filepath.Join(dir, "primary.sock"), filepath.Join(dir, "secondary.sock")
+	startServer(t, secondary, health.NewServer())
+
+	pool := newPool(t, testConfig("unix://"+primary, "unix://"+secondary))
+	client := healthpb.NewHealthClient(pool)
+
+	response, err := client.Check(context.Background(), &healthpb.HealthCheckRequest{})
+	require.NoError(t, err)
+	assert.Equal(t, healthpb.HealthCheckResponse_SERVING, response.GetStatus())
+
+	// the unavailable primary is tried last from now on
+	ordered := pool.ordered()
+	assert.Equal(t, "unix://"+secondary, ordered[0].endpoint.target)
+	assert.False(t, ordered[1].endpoint.healthy)
+
+	_, err = client.Check(context.Background(), &healthpb.HealthCheckRequest{})
+	require.NoError(t, err)
+}
+
+func TestInvokeFailsWhenNoEndpointIsAvailable(t *testing.T) {
+	dir := socketDir(t)
+	pool := newPool(t, testConfig("unix://"+filepath.Join(dir, "a.sock"), "unix://"+filepath.Join(dir, "b.sock")))
+
+	_, err := healthpb.NewHealthClient(pool).Check(context.Background(), &healthpb.HealthCheckRequest{})
+	assert.Error(t, err)
+
+	for _, candidate := range pool.ordered() {
+		assert.False(t, candidate.endpoint.healthy)
+	}
+}
+
+func TestProbe(t *testing.T) {
+	dir := socketDir(t)
+	socket := filepath.Join(dir, "connector.sock")
+	healthServer := health.NewServer()
+	startServer(t, socket, healthServer)
+
+	pool := newPool(t, testConfig("unix://"+socket))
+	ep := pool.endpoints[0]
+	conn := ep.conn
+
+	healthServer.SetServingStatus("", healthpb.HealthCheckResponse_NOT_SERVING)
+	pool.Probe(context.Background())
+	assert.False(t, ep.healthy)
+	assert.Equal(t, 1, ep.failures)
+	assert.Same(t, conn, ep.conn)
+
+	// the connection is recreated after the second failed probe in a row
+	pool.Probe(context.Background())
+	assert.Equal(t, 0, ep.failures)
+	assert.NotSame(t, conn, ep.conn)
+
+	healthServer.SetServingStatus("", healthpb.HealthCheckResponse_SERVING)
+	pool.Probe(context.Background())
+	assert.True(t, ep.healthy)
+}
+
+func TestProbeWithoutHealthService(t *testing.T) {
+	dir := socketDir(t)
+	socket := filepath.Join(dir, "connector.sock")
+	startServer(t, socket, nil)
+
+	pool := newPool(t, testConfig("unix://"+socket))
+	pool.endpoints[0].healthy = false
+
+	pool.Probe(context.Background())
+	assert.True(t, pool.endpoints[0].healthy)
+}
+
+func TestWaitReady(t *testing.T) {
+	dir := socketDir(t)
+	socket := filepath.Join(dir, "connector.sock")
+
+	pool := newPool(t, testConfig("unix://"+filepath.Join(dir, "missing.sock"), "unix://"+socket))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 200*time.Millisecond)
+	defer cancel()
+
+	assert.ErrorContains(t, pool.WaitReady(ctx), "no platform connector endpoint is ready")
+
+	startServer(t, socket, nil)
+
+	ctx, cancel = context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	assert.NoError(t, pool.WaitReady(ctx))
+}
+
+func TestConfigValidate(t *testing.T) {
+	valid := DefaultConfig("unix:///var/run/nvsentinel.sock")
+	assert.NoError(t, valid.Validate())
+
+	tests := map[string]func(*Config){
+		"no endpoints":       func(c *Config) { c.Endpoints = nil },
+		"no base delay":      func(c *Config) { c.BaseDelay = 0 },
+		"max below base":     func(c *Config) { c.MaxDelay = c.BaseDelay / 2 },
+		"jitter above 1":     func(c *Config) { c.Jitter = 1.5 },
+		"no probe interval":  func(c *Config) { c.ProbeInterval = 0 },
+		"no failure allowed": func(c *Config) { c.FailureThreshold = 0 },
+	}
+
+	for name, mutate := range tests {
+		t.Run(name, func(t *testing.T) {
+			config := DefaultConfig("unix:///var/run/nvsentinel.sock")
+			mutate(&config)
+			assert.Error(t, config.Validate())
+		})
+	}
+}
@@ -0,0 +1,44 @@
+// Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package connpool
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+var (
+	endpointHealthy = promauto.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "nvsentinel_platform_connector_endpoint_healthy",
+			Help: "1 when the platform connector endpoint passed its last probe or call, 0 otherwise.",
+		},
+		[]string{"endpoint"},
+	)
+	failovers = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "nvsentinel_platform_connector_failovers_total",
+			Help: "Total number of calls failed over to a platform connector endpoint.",
+		},
+		[]string{"endpoint"},
+	)
+	reconnects = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "nvsentinel_platform_connector_reconnects_total",
+			Help: "Total number of connections recreated after repeatedly failed probes.",
+		},
+		[]string{"endpoint"},
+	)
+)
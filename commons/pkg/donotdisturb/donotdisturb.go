@@ -0,0 +1,64 @@
+// Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package donotdisturb lets workload schedulers mark a node "do not disturb" during
+// critical job phases, e.g. checkpointing or the last steps of a training run.
+// Active checks and non-urgent remediation are paused on such a node, while passive
+// monitoring continues and fatal faults are still remediated.
+package donotdisturb
+
+import (
+	"strings"
+	"time"
+)
+
+const (
+	// Key is the node annotation or label marking the node do not disturb. Its value
+	// is true, or in the annotation an RFC 3339 time until which the node must not
+	// be disturbed, so a mark the scheduler failed to remove expires.
+	Key = "nvsentinel.nvidia.com/do-not-disturb"
+	// On is the value of Key marking the node without an end.
+	On = "true"
+)
+
+// Until reports whether the annotations or the labels of a node mark it do not
+// disturb at now, and until when. until is zero for a mark without an end.
+func Until(annotations, labels map[string]string, now time.Time) (time.Time, bool) {
+	if isOn(labels[Key]) {
+		return time.Time{}, true
+	}
+
+	value := strings.TrimSpace(annotations[Key])
+	if isOn(value) {
+		return time.Time{}, true
+	}
+
+	until, err := time.Parse(time.RFC3339, value)
+	if err != nil || !now.Before(until) {
+		return time.Time{}, false
+	}
+
+	return until, true
+}
+
+// Active reports whether the annotations or the labels of a node mark it do not
+// disturb at now.
+func Active(annotations, labels map[string]string, now time.Time) bool {
+	_, active := Until(annotations, labels, now)
+	return active
+}
+
+func isOn(value string) bool {
+	return strings.EqualFold(strings.TrimSpace(value), On)
+}
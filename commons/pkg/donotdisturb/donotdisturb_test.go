@@ -0,0 +1,43 @@
+// Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package donotdisturb
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUntil(t *testing.T) {
+	now := time.Date(2025, 6, 1, 8, 0, 0, 0, time.UTC)
+
+	_, active := Until(nil, nil, now)
+	assert.False(t, active)
+
+	until, active := Until(map[string]string{Key: "true"}, nil, now)
+	assert.True(t, active)
+	assert.True(t, until.IsZero())
+
+	assert.True(t, Active(nil, map[string]string{Key: " TRUE "}, now))
+	assert.False(t, Active(map[string]string{Key: "false"}, map[string]string{"other": "true"}, now))
+
+	until, active = Until(map[string]string{Key: "2025-06-01T09:30:00Z"}, nil, now)
+	assert.True(t, active)
+	assert.Equal(t, now.Add(90*time.Minute), until)
+
+	assert.False(t, Active(map[string]string{Key: "2025-06-01T07:59:59Z"}, nil, now))
+	assert.False(t, Active(map[string]string{Key: "tomorrow"}, nil, now))
+}
@@ -0,0 +1,38 @@
+// Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package enforcement lets operators switch NVSentinel to observe-only for a node,
+// e.g. for storage or login nodes that must never be cordoned or rebooted.
+package enforcement
+
+import "strings"
+
+const (
+	// Key is the node annotation or label that switches enforcement off for the
+	// node when set to Off. Faults are still detected, evaluated and reported, but
+	// the node is not quarantined, drained or remediated.
+	Key = "nvsentinel.nvidia.com/enforcement"
+	// Off is the value of Key that switches enforcement off.
+	Off = "off"
+)
+
+// Disabled reports whether enforcement is switched off by the annotations or the
+// labels of a node.
+func Disabled(annotations, labels map[string]string) bool {
+	return isOff(annotations[Key]) || isOff(labels[Key])
+}
+
+func isOff(value string) bool {
+	return strings.EqualFold(strings.TrimSpace(value), Off)
+}
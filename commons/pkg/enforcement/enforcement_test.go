@@ -0,0 +1,28 @@
+// Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package enforcement
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDisabled(t *testing.T) {
+	assert.False(t, Disabled(nil, nil))
+	assert.True(t, Disabled(map[string]string{Key: "off"}, nil))
+	assert.True(t, Disabled(nil, map[string]string{Key: " OFF "}))
+	assert.False(t, Disabled(map[string]string{Key: "on"}, map[string]string{"other": "off"}))
+}
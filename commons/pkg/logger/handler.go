@@ -0,0 +1,139 @@
+// Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package logger
+
+import (
+	"context"
+	"log/slog"
+)
+
+// Keys of the attributes every component logs with.
+const (
+	// ComponentKey names the component, e.g. fault-quarantine
+	ComponentKey = "module"
+	// SubsystemKey names the part of the component, e.g. reconciler. Its level can
+	// be changed separately.
+	SubsystemKey = "subsystem"
+	// EventIDKey is the ID of the health event a line is about, distinct from the
+	// ID of the document storing it
+	EventIDKey = "healthEventID"
+	// IncidentIDKey is the ID of the health event that quarantined the node a line
+	// is about
+	IncidentIDKey = "incidentID"
+)
+
+type traceIDsKey struct{}
+
+// traceIDs are the IDs logged with every line of a context.
+type traceIDs struct {
+	subsystem  string
+	eventID    string
+	incidentID string
+}
+
+// WithEventID returns a context whose log lines carry the event ID. Log with the
+// Context variants, e.g. slog.InfoContext, for the ID to be added.
+func WithEventID(ctx context.Context, eventID string) context.Context {
+	if eventID == "" {
+		return ctx
+	}
+
+	ids, _ := ctx.Value(traceIDsKey{}).(traceIDs)
+	ids.eventID = eventID
+
+	return context.WithValue(ctx, traceIDsKey{}, ids)
+}
+
+// WithIncidentID returns a context whose log lines carry the incident ID.
+func WithIncidentID(ctx context.Context, incidentID string) context.Context {
+	if incidentID == "" {
+		return ctx
+	}
+
+	ids, _ := ctx.Value(traceIDsKey{}).(traceIDs)
+	ids.incidentID = incidentID
+
+	return context.WithValue(ctx, traceIDsKey{}, ids)
+}
+
+// WithSubsystem returns a context whose log lines are of the subsystem, they are
+// filtered by its level. A subsystem set on the logger, see For, takes precedence.
+func WithSubsystem(ctx context.Context, subsystem string) context.Context {
+	ids, _ := ctx.Value(traceIDsKey{}).(traceIDs)
+	ids.subsystem = subsystem
+
+	return context.WithValue(ctx, traceIDsKey{}, ids)
+}
+
+// For returns the default logger for a subsystem of the component.
+func For(subsystem string) *slog.Logger {
+	return slog.Default().With(SubsystemKey, subsystem)
+}
+
+// handler filters records by the level of their subsystem and adds the trace IDs
+// of their context.
+type handler struct {
+	next      slog.Handler
+	subsystem string
+	// grouped is set once attributes go into a group, where a subsystem key is
+	// not the subsystem of the logger
+	grouped bool
+}
+
+func (h *handler) Enabled(ctx context.Context, level slog.Level) bool {
+	subsystem := h.subsystem
+	if subsystem == "" && ctx != nil {
+		ids, _ := ctx.Value(traceIDsKey{}).(traceIDs)
+		subsystem = ids.subsystem
+	}
+
+	return level >= levels.level(subsystem)
+}
+
+func (h *handler) Handle(ctx context.Context, record slog.Record) error {
+	if ids, ok := ctx.Value(traceIDsKey{}).(traceIDs); ok {
+		if h.subsystem == "" && ids.subsystem != "" {
+			record.AddAttrs(slog.String(SubsystemKey, ids.subsystem))
+		}
+
+		if ids.eventID != "" {
+			record.AddAttrs(slog.String(EventIDKey, ids.eventID))
+		}
+
+		if ids.incidentID != "" {
+			record.AddAttrs(slog.String(IncidentIDKey, ids.incidentID))
+		}
+	}
+
+	return h.next.Handle(ctx, record)
+}
+
+func (h *handler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	subsystem := h.subsystem
+
+	if !h.grouped {
+		for _, attr := range attrs {
+			if attr.Key == SubsystemKey {
+				subsystem = attr.Value.String()
+			}
+		}
+	}
+
+	return &handler{next: h.next.WithAttrs(attrs), subsystem: subsystem, grouped: h.grouped}
+}
+
+func (h *handler) WithGroup(name string) slog.Handler {
+	return &handler{next: h.next.WithGroup(name), subsystem: h.subsystem, grouped: true}
+}
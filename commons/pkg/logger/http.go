@@ -0,0 +1,109 @@
+// Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package logger
+
+import (
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strings"
+)
+
+// LevelsPath is the path LevelsHandler is served under.
+const LevelsPath = "/loglevels/"
+
+// levelsResponse lists the default level and the subsystems with their own.
+type levelsResponse struct {
+	Default    string            `json:"default"`
+	Subsystems map[string]string `json:"subsystems"`
+}
+
+// LevelsHandler serves the log levels of the component on LevelsPath and changes
+// them at runtime, without a restart:
+//
+//	GET    /loglevels/                        lists the levels
+//	PUT    /loglevels/?level=debug            sets the default level
+//	PUT    /loglevels/<subsystem>?level=debug sets the level of a subsystem
+//	DELETE /loglevels/<subsystem>             resets a subsystem to the default
+//
+// Changes last until the component restarts, which logs at LOG_LEVEL again.
+//
+// Example usage:
+//
+//	srv := server.NewServer(
+//	    server.WithHandler(logger.LevelsPath, logger.LevelsHandler()),
+//	)
+func LevelsHandler() http.Handler {
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("GET "+LevelsPath+"{$}", func(w http.ResponseWriter, _ *http.Request) {
+		writeLevels(w)
+	})
+
+	mux.HandleFunc("PUT "+LevelsPath+"{subsystem...}", func(w http.ResponseWriter, r *http.Request) {
+		level, err := ParseLevel(r.URL.Query().Get("level"))
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		subsystem := strings.TrimSuffix(r.PathValue("subsystem"), "/")
+		SetLevel(subsystem, level)
+
+		slog.Info("Log level changed", SubsystemKey, subsystem, "level", level.String())
+		writeLevels(w)
+	})
+
+	mux.HandleFunc("DELETE "+LevelsPath+"{subsystem}", func(w http.ResponseWriter, r *http.Request) {
+		ResetLevel(r.PathValue("subsystem"))
+
+		slog.Info("Log level reset to the default", SubsystemKey, r.PathValue("subsystem"))
+		writeLevels(w)
+	})
+
+	return mux
+}
+
+func writeLevels(w http.ResponseWriter) {
+	defaultLevel, subsystems := Levels()
+
+	response := levelsResponse{Default: levelName(defaultLevel), Subsystems: map[string]string{}}
+	for subsystem, level := range subsystems {
+		response.Subsystems[subsystem] = levelName(level)
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+
+	if err := json.NewEncoder(w).Encode(response); err != nil {
+		slog.Warn("Failed to write log levels response", "error", err)
+	}
+}
+
+// levelName returns the level as accepted by ParseLevel.
+func levelName(level slog.Level) string {
+	switch level {
+	case slog.LevelDebug:
+		return "debug"
+	case slog.LevelInfo:
+		return "info"
+	case slog.LevelWarn:
+		return "warn"
+	case slog.LevelError:
+		return "error"
+	default:
+		return fmt.Sprint(level)
+	}
+}
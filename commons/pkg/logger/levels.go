@@ -0,0 +1,110 @@
+// Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package logger
+
+import (
+	"fmt"
+	"log/slog"
+	"strings"
+	"sync"
+)
+
+// levels holds the log level of the process and of the subsystems whose level
+// was changed at runtime. Subsystems without their own level log at the default.
+var levels = &levelRegistry{subsystems: map[string]*slog.LevelVar{}}
+
+type levelRegistry struct {
+	defaultLevel slog.LevelVar
+
+	mu         sync.RWMutex
+	subsystems map[string]*slog.LevelVar
+}
+
+// level returns the level of the subsystem, the default level for "".
+func (l *levelRegistry) level(subsystem string) slog.Level {
+	if subsystem != "" {
+		l.mu.RLock()
+		level, ok := l.subsystems[subsystem]
+		l.mu.RUnlock()
+
+		if ok {
+			return level.Level()
+		}
+	}
+
+	return l.defaultLevel.Level()
+}
+
+// SetLevel sets the log level of the subsystem, or the default level of the
+// process for "", at runtime.
+func SetLevel(subsystem string, level slog.Level) {
+	if subsystem == "" {
+		levels.defaultLevel.Set(level)
+		return
+	}
+
+	levels.mu.Lock()
+	defer levels.mu.Unlock()
+
+	if _, ok := levels.subsystems[subsystem]; !ok {
+		levels.subsystems[subsystem] = &slog.LevelVar{}
+	}
+
+	levels.subsystems[subsystem].Set(level)
+}
+
+// ResetLevel makes the subsystem log at the default level again.
+func ResetLevel(subsystem string) {
+	levels.mu.Lock()
+	defer levels.mu.Unlock()
+
+	delete(levels.subsystems, subsystem)
+}
+
+// Level returns the log level of the subsystem, the default level for "".
+func Level(subsystem string) slog.Level {
+	return levels.level(subsystem)
+}
+
+// Levels returns the default log level and the levels of the subsystems that have
+// their own.
+func Levels() (slog.Level, map[string]slog.Level) {
+	levels.mu.RLock()
+	defer levels.mu.RUnlock()
+
+	subsystems := make(map[string]slog.Level, len(levels.subsystems))
+	for subsystem, level := range levels.subsystems {
+		subsystems[subsystem] = level.Level()
+	}
+
+	return levels.defaultLevel.Level(), subsystems
+}
+
+// ParseLevel is the strict variant of ParseLogLevel for levels set at runtime, it
+// rejects unknown levels instead of defaulting to info.
+func ParseLevel(level string) (slog.Level, error) {
+	switch strings.ToLower(strings.TrimSpace(level)) {
+	case "debug":
+		return slog.LevelDebug, nil
+	case "info":
+		return slog.LevelInfo, nil
+	case "warn", "warning":
+		return slog.LevelWarn, nil
+	case "error":
+		return slog.LevelError, nil
+	default:
+		return slog.LevelInfo, fmt.Errorf("unknown log level %q, expected debug, info, warn or error", level)
+	}
+}
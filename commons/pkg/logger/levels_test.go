@@ -0,0 +1,185 @@
+// Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package logger
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// captureLogger returns a logger writing JSON lines through the level handler to buf.
+func captureLogger(buf *bytes.Buffer) *slog.Logger {
+	return slog.New(&handler{next: slog.NewJSONHandler(buf, &slog.HandlerOptions{Level: slog.LevelDebug})})
+}
+
+func decodeLines(t *testing.T, buf *bytes.Buffer) []map[string]any {
+	t.Helper()
+
+	var lines []map[string]any
+
+	for _, line := range strings.Split(strings.TrimSpace(buf.String()), "\n") {
+		if line == "" {
+			continue
+		}
+
+		var record map[string]any
+		if err := json.Unmarshal([]byte(line), &record); err != nil {
+			t.Fatalf("invalid log line %q: %v", line, err)
+		}
+
+		lines = append(lines, record)
+	}
+
+	return lines
+}
+
+func TestSubsystemLevels(t *testing.T) {
+	SetLevel("", slog.LevelInfo)
+	defer SetLevel("", slog.LevelInfo)
+	defer ResetLevel("reconciler")
+
+	var buf bytes.Buffer
+
+	base := captureLogger(&buf)
+	reconciler := base.With(SubsystemKey, "reconciler")
+	informer := base.With(SubsystemKey, "informer")
+
+	reconciler.Debug("hidden")
+	informer.Debug("hidden")
+
+	SetLevel("reconciler", slog.LevelDebug)
+
+	reconciler.Debug("reconciler debug")
+	informer.Debug("hidden")
+	base.WithGroup("request").With(SubsystemKey, "other").Debug("hidden")
+
+	lines := decodeLines(t, &buf)
+	if len(lines) != 1 || lines[0]["msg"] != "reconciler debug" || lines[0][SubsystemKey] != "reconciler" {
+		t.Fatalf("only the reconciler debug line should be logged, got %v", lines)
+	}
+
+	ResetLevel("reconciler")
+	buf.Reset()
+
+	reconciler.Debug("hidden")
+
+	if buf.Len() != 0 {
+		t.Errorf("reset subsystem still logs at debug: %s", buf.String())
+	}
+}
+
+func TestContextSubsystem(t *testing.T) {
+	SetLevel("", slog.LevelInfo)
+	defer ResetLevel("reconciler")
+
+	var buf bytes.Buffer
+
+	log := captureLogger(&buf)
+	ctx := WithSubsystem(context.Background(), "reconciler")
+
+	log.DebugContext(ctx, "hidden")
+
+	SetLevel("reconciler", slog.LevelDebug)
+
+	log.DebugContext(ctx, "reconciler debug")
+	log.DebugContext(context.Background(), "hidden")
+	log.With(SubsystemKey, "informer").DebugContext(ctx, "hidden")
+
+	lines := decodeLines(t, &buf)
+	if len(lines) != 1 || lines[0][SubsystemKey] != "reconciler" {
+		t.Fatalf("only the reconciler debug line should be logged, got %v", lines)
+	}
+}
+
+func TestTraceIDs(t *testing.T) {
+	SetLevel("", slog.LevelInfo)
+
+	var buf bytes.Buffer
+
+	log := captureLogger(&buf)
+
+	ctx := WithIncidentID(WithEventID(context.Background(), "01EVENT"), "01INCIDENT")
+	log.InfoContext(ctx, "with ids")
+	log.InfoContext(WithEventID(context.Background(), ""), "without ids")
+
+	lines := decodeLines(t, &buf)
+	if len(lines) != 2 {
+		t.Fatalf("expected 2 lines, got %v", lines)
+	}
+
+	if lines[0][EventIDKey] != "01EVENT" || lines[0][IncidentIDKey] != "01INCIDENT" {
+		t.Errorf("trace IDs missing: %v", lines[0])
+	}
+
+	if _, ok := lines[1][EventIDKey]; ok {
+		t.Errorf("unexpected event ID: %v", lines[1])
+	}
+}
+
+func TestLevelsHandler(t *testing.T) {
+	SetLevel("", slog.LevelInfo)
+	defer SetLevel("", slog.LevelInfo)
+	defer ResetLevel("reconciler")
+
+	h := LevelsHandler()
+
+	do := func(method, target string) (int, levelsResponse) {
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, httptest.NewRequest(method, target, nil))
+
+		var response levelsResponse
+		if rec.Code == http.StatusOK {
+			if err := json.Unmarshal(rec.Body.Bytes(), &response); err != nil {
+				t.Fatalf("invalid response %q: %v", rec.Body.String(), err)
+			}
+		}
+
+		return rec.Code, response
+	}
+
+	code, response := do(http.MethodPut, "/loglevels/reconciler?level=debug")
+	if code != http.StatusOK || response.Subsystems["reconciler"] != "debug" || response.Default != "info" {
+		t.Fatalf("unexpected response to setting a subsystem level: %d %v", code, response)
+	}
+
+	if Level("reconciler") != slog.LevelDebug || Level("informer") != slog.LevelInfo {
+		t.Errorf("subsystem levels not applied")
+	}
+
+	code, response = do(http.MethodPut, "/loglevels/?level=warn")
+	if code != http.StatusOK || response.Default != "warn" {
+		t.Fatalf("unexpected response to setting the default level: %d %v", code, response)
+	}
+
+	if code, _ = do(http.MethodPut, "/loglevels/reconciler?level=verbose"); code != http.StatusBadRequest {
+		t.Errorf("invalid level accepted: %d", code)
+	}
+
+	code, response = do(http.MethodDelete, "/loglevels/reconciler")
+	if code != http.StatusOK || len(response.Subsystems) != 0 {
+		t.Fatalf("unexpected response to resetting a subsystem: %d %v", code, response)
+	}
+
+	code, response = do(http.MethodGet, "/loglevels/")
+	if code != http.StatusOK || response.Default != "warn" {
+		t.Fatalf("unexpected levels: %d %v", code, response)
+	}
+}
@@ -29,6 +29,9 @@ const (
 // NewStructuredLogger creates a new structured logger with the specified log level
 // Defined module name and version are included in the logger's context.
 // AddSource is enabled for debug level logging only.
+// The level becomes the default level of the process, which SetLevel and
+// LevelsHandler change at runtime along with the levels of subsystems, see For.
+// Records logged with a context carry its event and incident IDs, see WithEventID.
 // Parameters:
 //   - module: The name of the module/application using the logger.
 //   - version: The version of the module/application (e.g., "v1.0.0").
@@ -40,10 +43,15 @@ func NewStructuredLogger(module, version, level string) *slog.Logger {
 	lev := ParseLogLevel(level)
 	addSource := lev <= slog.LevelDebug
 
-	return slog.New(slog.NewJSONHandler(os.Stderr, &slog.HandlerOptions{
-		Level:     lev,
+	SetLevel("", lev)
+
+	// Levels are decided per subsystem by the handler, the JSON handler passes all
+	next := slog.NewJSONHandler(os.Stderr, &slog.HandlerOptions{
+		Level:     slog.LevelDebug - 4,
 		AddSource: addSource,
-	})).With("module", module, "version", version)
+	})
+
+	return slog.New(&handler{next: next}).With(ComponentKey, module, "version", version)
 }
 
 // NewLogLogger creates a new standard library log.Logger that writes logs
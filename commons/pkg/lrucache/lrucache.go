@@ -0,0 +1,115 @@
+// Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package lrucache bounds the per-entity state of handlers and controllers, such
+// as dedup and threshold state keyed by GPU, kernel or node. On nodes with many
+// transient entities that state would grow without bound, so the least recently
+// used entries are evicted beyond a capacity and the evictions are counted per
+// cache, a growing count means the capacity is too small for the workload.
+package lrucache
+
+import (
+	lru "github.com/hashicorp/golang-lru/v2"
+)
+
+// DefaultCapacity is the capacity of caches created with a non-positive one.
+const DefaultCapacity = 4096
+
+// Cache is a thread-safe LRU cache reporting its size and evictions under its name.
+type Cache[K comparable, V any] struct {
+	name  string
+	cache *lru.Cache[K, V]
+}
+
+// New returns a cache holding up to capacity entries, DefaultCapacity when not
+// positive. The name labels the metrics of the cache.
+func New[K comparable, V any](name string, capacity int) *Cache[K, V] {
+	if capacity <= 0 {
+		capacity = DefaultCapacity
+	}
+
+	// only fails on a non-positive size
+	cache, _ := lru.New[K, V](capacity)
+
+	capacityGauge.WithLabelValues(name).Set(float64(capacity))
+	entries.WithLabelValues(name).Set(0)
+
+	return &Cache[K, V]{name: name, cache: cache}
+}
+
+// Get returns the value of the key and marks it as recently used.
+func (c *Cache[K, V]) Get(key K) (V, bool) {
+	return c.cache.Get(key)
+}
+
+// Peek returns the value of the key without marking it as recently used.
+func (c *Cache[K, V]) Peek(key K) (V, bool) {
+	return c.cache.Peek(key)
+}
+
+// Contains reports whether the key is cached without marking it as recently used.
+func (c *Cache[K, V]) Contains(key K) bool {
+	return c.cache.Contains(key)
+}
+
+// Add sets the value of the key, evicting the least recently used entry when the
+// cache is full. It reports whether an entry was evicted.
+func (c *Cache[K, V]) Add(key K, value V) bool {
+	evicted := c.cache.Add(key, value)
+	if evicted {
+		evictions.WithLabelValues(c.name).Inc()
+	}
+
+	entries.WithLabelValues(c.name).Set(float64(c.cache.Len()))
+
+	return evicted
+}
+
+// Remove deletes the key.
+func (c *Cache[K, V]) Remove(key K) {
+	if c.cache.Remove(key) {
+		entries.WithLabelValues(c.name).Set(float64(c.cache.Len()))
+	}
+}
+
+// Keys returns the keys from the least to the most recently used.
+func (c *Cache[K, V]) Keys() []K {
+	return c.cache.Keys()
+}
+
+// Len returns the number of entries.
+func (c *Cache[K, V]) Len() int {
+	return c.cache.Len()
+}
+
+// Purge deletes all entries.
+func (c *Cache[K, V]) Purge() {
+	c.cache.Purge()
+	entries.WithLabelValues(c.name).Set(0)
+}
+
+// Resize changes the capacity, DefaultCapacity when not positive, evicting the
+// least recently used entries beyond it.
+func (c *Cache[K, V]) Resize(capacity int) {
+	if capacity <= 0 {
+		capacity = DefaultCapacity
+	}
+
+	if evicted := c.cache.Resize(capacity); evicted > 0 {
+		evictions.WithLabelValues(c.name).Add(float64(evicted))
+	}
+
+	capacityGauge.WithLabelValues(c.name).Set(float64(capacity))
+	entries.WithLabelValues(c.name).Set(float64(c.cache.Len()))
+}
@@ -0,0 +1,71 @@
+// Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package lrucache
+
+import (
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEviction(t *testing.T) {
+	cache := New[string, int]("test_eviction", 2)
+
+	assert.False(t, cache.Add("a", 1))
+	assert.False(t, cache.Add("b", 2))
+
+	// a becomes the most recently used, b is evicted next
+	value, ok := cache.Get("a")
+	assert.True(t, ok)
+	assert.Equal(t, 1, value)
+
+	assert.True(t, cache.Add("c", 3))
+	assert.False(t, cache.Contains("b"))
+	assert.Equal(t, []string{"a", "c"}, cache.Keys())
+
+	assert.Equal(t, 1.0, testutil.ToFloat64(evictions.WithLabelValues("test_eviction")))
+	assert.Equal(t, 2.0, testutil.ToFloat64(entries.WithLabelValues("test_eviction")))
+	assert.Equal(t, 2.0, testutil.ToFloat64(capacityGauge.WithLabelValues("test_eviction")))
+
+	// peeking does not protect a from eviction
+	_, ok = cache.Peek("a")
+	assert.True(t, ok)
+	assert.True(t, cache.Add("d", 4))
+	assert.False(t, cache.Contains("a"))
+
+	cache.Remove("c")
+	assert.Equal(t, 1, cache.Len())
+	assert.Equal(t, 1.0, testutil.ToFloat64(entries.WithLabelValues("test_eviction")))
+	assert.Equal(t, 2.0, testutil.ToFloat64(evictions.WithLabelValues("test_eviction")))
+}
+
+func TestResize(t *testing.T) {
+	cache := New[int, bool]("test_resize", 0)
+	assert.Equal(t, float64(DefaultCapacity), testutil.ToFloat64(capacityGauge.WithLabelValues("test_resize")))
+
+	for i := range 10 {
+		cache.Add(i, true)
+	}
+
+	cache.Resize(4)
+	assert.Equal(t, []int{6, 7, 8, 9}, cache.Keys())
+	assert.Equal(t, 6.0, testutil.ToFloat64(evictions.WithLabelValues("test_resize")))
+	assert.Equal(t, 4.0, testutil.ToFloat64(capacityGauge.WithLabelValues("test_resize")))
+
+	cache.Purge()
+	assert.Zero(t, cache.Len())
+	assert.Zero(t, testutil.ToFloat64(entries.WithLabelValues("test_resize")))
+}
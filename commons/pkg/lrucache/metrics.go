@@ -0,0 +1,44 @@
+// Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package lrucache
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+var (
+	evictions = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "nvsentinel_lru_cache_evictions_total",
+			Help: "Total number of entries evicted from a bounded state cache because it was full.",
+		},
+		[]string{"cache"},
+	)
+	entries = promauto.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "nvsentinel_lru_cache_entries",
+			Help: "Number of entries in a bounded state cache.",
+		},
+		[]string{"cache"},
+	)
+	capacityGauge = promauto.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "nvsentinel_lru_cache_capacity",
+			Help: "Capacity of a bounded state cache.",
+		},
+		[]string{"cache"},
+	)
+)
@@ -0,0 +1,41 @@
+// Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package nodecache
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+const (
+	sourceCache = "cache"
+	sourceAPI   = "api"
+)
+
+var (
+	reads = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "nvsentinel_node_cache_reads_total",
+			Help: "Total number of node reads by source, the informer cache or the API server.",
+		},
+		[]string{"source"},
+	)
+	staleReads = promauto.NewCounter(
+		prometheus.CounterOpts{
+			Name: "nvsentinel_node_cache_stale_total",
+			Help: "Total number of node updates that conflicted and were retried against the API server.",
+		},
+	)
+)
@@ -0,0 +1,154 @@
+// Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package nodecache serves node reads from a shared informer instead of the API server.
+//
+// The informer lags the API server by the watch latency, so a read right after a write
+// could return the node as it was before the write. The cache overlays the informer
+// with the nodes returned by its own writes, and updates that still hit a stale node
+// fail with a conflict and are retried against the API server.
+package nodecache
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/util/retry"
+	"k8s.io/klog/v2"
+)
+
+// mutationTTL is how long a written node overlays the informer, well above the watch latency.
+const mutationTTL = time.Minute
+
+// Cache reads nodes from an informer and writes them through the API server.
+type Cache struct {
+	clientset kubernetes.Interface
+	synced    cache.InformerSynced
+	mutations cache.MutationCache
+}
+
+// New returns a cache over the node informer. The informer is run by the caller.
+func New(clientset kubernetes.Interface, informer cache.SharedIndexInformer) *Cache {
+	return &Cache{
+		clientset: clientset,
+		synced:    informer.HasSynced,
+		mutations: cache.NewIntegerResourceVersionMutationCache(klog.Background(),
+			informer.GetStore(), informer.GetIndexer(), mutationTTL, false),
+	}
+}
+
+// Cached returns the node from the cache without calling the API server. The node is
+// shared with the cache and must not be modified.
+func (c *Cache) Cached(name string) (*v1.Node, error) {
+	obj, exists, err := c.mutations.GetByKey(name)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get node %s from cache: %w", name, err)
+	}
+
+	if !exists {
+		return nil, apierrors.NewNotFound(v1.Resource("nodes"), name)
+	}
+
+	node, ok := obj.(*v1.Node)
+	if !ok {
+		return nil, fmt.Errorf("expected node object for %s, got %T", name, obj)
+	}
+
+	return node, nil
+}
+
+// Get returns a copy of the node, from the cache once the informer has synced and from
+// the API server otherwise or when the informer has not seen the node yet.
+func (c *Cache) Get(ctx context.Context, name string) (*v1.Node, error) {
+	if c.synced() {
+		if node, err := c.Cached(name); err == nil {
+			reads.WithLabelValues(sourceCache).Inc()
+			return node.DeepCopy(), nil
+		}
+	}
+
+	return c.getFromAPI(ctx, name)
+}
+
+func (c *Cache) getFromAPI(ctx context.Context, name string) (*v1.Node, error) {
+	reads.WithLabelValues(sourceAPI).Inc()
+
+	node, err := c.clientset.CoreV1().Nodes().Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get node %s: %w", name, err)
+	}
+
+	return node, nil
+}
+
+// Update applies mutate to the node and writes it back when mutate changed it. The first
+// attempt reads the node from the cache. A conflict means the cached node was stale, so
+// later attempts read it from the API server.
+//
+// Skipping unchanged nodes relies on the cache too: a change made by someone else in the
+// last moments may not be visible yet, and is seen through the informer events instead.
+func (c *Cache) Update(ctx context.Context, name string, mutate func(*v1.Node) error) error {
+	fresh := false
+
+	return retry.RetryOnConflict(retry.DefaultBackoff, func() error {
+		var (
+			node *v1.Node
+			err  error
+		)
+
+		if fresh {
+			node, err = c.getFromAPI(ctx, name)
+		} else {
+			node, err = c.Get(ctx, name)
+		}
+
+		if err != nil {
+			return err
+		}
+
+		original := node.DeepCopy()
+
+		if err := mutate(node); err != nil {
+			return err
+		}
+
+		if equality.Semantic.DeepEqual(original, node) {
+			slog.Debug("Node unchanged, skipping update", "node", name)
+			return nil
+		}
+
+		updated, err := c.clientset.CoreV1().Nodes().Update(ctx, node, metav1.UpdateOptions{})
+		if apierrors.IsConflict(err) {
+			staleReads.Inc()
+
+			fresh = true
+		}
+
+		if err != nil {
+			return err
+		}
+
+		c.mutations.Mutation(updated)
+
+		return nil
+	})
+}
@@ -0,0 +1,233 @@
+// Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package nodecache
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	v1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/informers"
+	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+	"k8s.io/client-go/tools/cache"
+)
+
+// newSyncedCache returns a cache whose informer has synced and then stopped, so the
+// informer never sees later writes and the tests control exactly what it holds.
+func newSyncedCache(t *testing.T, nodes ...*v1.Node) (*Cache, *fake.Clientset) {
+	t.Helper()
+
+	clientset := fake.NewClientset()
+	withResourceVersions(clientset)
+
+	for _, node := range nodes {
+		_, err := clientset.CoreV1().Nodes().Create(context.Background(), node, metav1.CreateOptions{})
+		require.NoError(t, err)
+	}
+
+	informer := informers.NewSharedInformerFactory(clientset, 0).Core().V1().Nodes().Informer()
+	nodeCache := New(clientset, informer)
+
+	stopCh := make(chan struct{})
+	go informer.Run(stopCh)
+
+	require.True(t, cache.WaitForCacheSync(t.Context().Done(), informer.HasSynced))
+	close(stopCh)
+
+	clientset.ClearActions()
+
+	return nodeCache, clientset
+}
+
+// withResourceVersions gives written nodes increasing resource versions as the API
+// server does, the fake leaves them empty.
+func withResourceVersions(clientset *fake.Clientset) {
+	var version atomic.Uint64
+
+	for _, verb := range []string{"create", "update"} {
+		clientset.PrependReactor(verb, "nodes", func(action k8stesting.Action) (bool, runtime.Object, error) {
+			if write, ok := action.(interface{ GetObject() runtime.Object }); ok {
+				if n, ok := write.GetObject().(*v1.Node); ok {
+					n.ResourceVersion = strconv.FormatUint(version.Add(1), 10)
+				}
+			}
+
+			return false, nil, nil
+		})
+	}
+}
+
+func countActions(clientset *fake.Clientset, verb string) int {
+	count := 0
+
+	for _, action := range clientset.Actions() {
+		if action.GetVerb() == verb {
+			count++
+		}
+	}
+
+	return count
+}
+
+func node(name string) *v1.Node {
+	return &v1.Node{ObjectMeta: metav1.ObjectMeta{Name: name, Labels: map[string]string{}}}
+}
+
+func TestGet(t *testing.T) {
+	nodeCache, clientset := newSyncedCache(t, node("cached"))
+
+	got, err := nodeCache.Get(t.Context(), "cached")
+	require.NoError(t, err)
+	assert.Equal(t, "cached", got.Name)
+	assert.Zero(t, countActions(clientset, "get"))
+
+	// the copy is the caller's to modify
+	got.Labels["changed"] = "true"
+
+	cached, err := nodeCache.Cached("cached")
+	require.NoError(t, err)
+	assert.NotContains(t, cached.Labels, "changed")
+
+	// a node the informer has not seen yet is read from the API server
+	_, err = clientset.CoreV1().Nodes().Create(t.Context(), node("new"), metav1.CreateOptions{})
+	require.NoError(t, err)
+
+	got, err = nodeCache.Get(t.Context(), "new")
+	require.NoError(t, err)
+	assert.Equal(t, "new", got.Name)
+	assert.Equal(t, 1, countActions(clientset, "get"))
+
+	_, err = nodeCache.Cached("new")
+	assert.True(t, apierrors.IsNotFound(err))
+
+	_, err = nodeCache.Get(t.Context(), "missing")
+	assert.True(t, apierrors.IsNotFound(err))
+}
+
+func TestGetBeforeSync(t *testing.T) {
+	clientset := fake.NewClientset(node("node-a"))
+	informer := informers.NewSharedInformerFactory(clientset, 0).Core().V1().Nodes().Informer()
+	nodeCache := New(clientset, informer)
+
+	got, err := nodeCache.Get(t.Context(), "node-a")
+	require.NoError(t, err)
+	assert.Equal(t, "node-a", got.Name)
+	assert.Equal(t, 1, countActions(clientset, "get"))
+}
+
+func TestUpdateOverlaysOwnWrites(t *testing.T) {
+	nodeCache, clientset := newSyncedCache(t, node("node-a"))
+
+	require.NoError(t, nodeCache.Update(t.Context(), "node-a", func(n *v1.Node) error {
+		n.Labels["quarantined"] = "true"
+		return nil
+	}))
+
+	assert.Zero(t, countActions(clientset, "get"))
+	assert.Equal(t, 1, countActions(clientset, "update"))
+
+	// the informer is stopped and never saw the write, the cache still returns it
+	cached, err := nodeCache.Cached("node-a")
+	require.NoError(t, err)
+	assert.Equal(t, "true", cached.Labels["quarantined"])
+
+	// a second update builds on the written node and does not conflict
+	require.NoError(t, nodeCache.Update(t.Context(), "node-a", func(n *v1.Node) error {
+		n.Labels["reason"] = "xid"
+		return nil
+	}))
+
+	stored, err := clientset.CoreV1().Nodes().Get(t.Context(), "node-a", metav1.GetOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"quarantined": "true", "reason": "xid"}, stored.Labels)
+}
+
+func TestUpdateSkipsUnchangedNode(t *testing.T) {
+	nodeCache, clientset := newSyncedCache(t, node("node-a"))
+
+	require.NoError(t, nodeCache.Update(t.Context(), "node-a", func(n *v1.Node) error {
+		n.Labels = map[string]string{}
+		return nil
+	}))
+
+	assert.Zero(t, countActions(clientset, "get"))
+	assert.Zero(t, countActions(clientset, "update"))
+}
+
+func TestUpdateRetriesStaleNodeFromAPI(t *testing.T) {
+	nodeCache, clientset := newSyncedCache(t, node("node-a"))
+
+	// someone else changes the node, the stopped informer keeps the old version
+	stored, err := clientset.CoreV1().Nodes().Get(t.Context(), "node-a", metav1.GetOptions{})
+	require.NoError(t, err)
+
+	stored.Labels["other"] = "true"
+	_, err = clientset.CoreV1().Nodes().Update(t.Context(), stored, metav1.UpdateOptions{})
+	require.NoError(t, err)
+
+	clientset.ClearActions()
+
+	// the fake does not check resource versions, fail the write of the stale node the
+	// way the API server does
+	conflicted := false
+
+	clientset.PrependReactor("update", "nodes", func(k8stesting.Action) (bool, runtime.Object, error) {
+		if conflicted {
+			return false, nil, nil
+		}
+
+		conflicted = true
+
+		return true, nil, apierrors.NewConflict(v1.Resource("nodes"), "node-a", errors.New("stale"))
+	})
+
+	attempts := 0
+
+	require.NoError(t, nodeCache.Update(t.Context(), "node-a", func(n *v1.Node) error {
+		attempts++
+		n.Labels["quarantined"] = "true"
+
+		return nil
+	}))
+
+	assert.Equal(t, 2, attempts)
+	assert.Equal(t, 1, countActions(clientset, "get"))
+
+	stored, err = clientset.CoreV1().Nodes().Get(t.Context(), "node-a", metav1.GetOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"other": "true", "quarantined": "true"}, stored.Labels)
+}
+
+func TestUpdateReturnsMutateError(t *testing.T) {
+	nodeCache, clientset := newSyncedCache(t, node("node-a"))
+
+	clientset.PrependReactor("update", "nodes", func(k8stesting.Action) (bool, runtime.Object, error) {
+		return true, nil, errors.New("unexpected update")
+	})
+
+	err := nodeCache.Update(t.Context(), "node-a", func(*v1.Node) error {
+		return errors.New("invalid taint")
+	})
+	assert.ErrorContains(t, err, "invalid taint")
+}
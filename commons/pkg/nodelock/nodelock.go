@@ -0,0 +1,229 @@
+// Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package nodelock serializes destructive actions on a node across controllers and
+// replicas with a Lease per node. Each acquisition of a lock increments its fencing
+// token, so a holder that lost the lock can tell before it acts on the node.
+package nodelock
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"time"
+
+	coordinationv1 "k8s.io/api/coordination/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/utils/ptr"
+)
+
+const (
+	// DefaultDuration is how long a lock is held without being renewed
+	DefaultDuration = time.Hour
+	// DefaultNamespace keeps the leases when the namespace of the pod is unknown
+	DefaultNamespace = "nvsentinel"
+
+	// leasePrefix is prepended to the node name to name its lease
+	leasePrefix = "nvsentinel-node-"
+	// NodeLabel is the label of the lease holding the node name
+	NodeLabel = "nvsentinel.nvidia.com/node"
+)
+
+// ErrHeld is returned when another holder owns the lock of the node.
+var ErrHeld = errors.New("node is locked by another action")
+
+// ErrLost is returned when the fencing token of a holder is no longer current.
+var ErrLost = errors.New("node lock was lost")
+
+// Locker takes the locks of nodes.
+type Locker struct {
+	client    kubernetes.Interface
+	namespace string
+	duration  time.Duration
+	now       func() time.Time
+}
+
+// NewLocker creates a Locker keeping the leases in namespace. Locks that are not
+// renewed within duration can be taken over.
+func NewLocker(client kubernetes.Interface, namespace string, duration time.Duration) *Locker {
+	if duration <= 0 {
+		duration = DefaultDuration
+	}
+
+	return &Locker{
+		client:    client,
+		namespace: namespace,
+		duration:  duration,
+		now:       time.Now,
+	}
+}
+
+// Namespace returns the namespace the leases are kept in, the namespace of the pod
+// from the POD_NAMESPACE environment variable. Every component taking node locks
+// must run in the same namespace.
+func Namespace() string {
+	if namespace := os.Getenv("POD_NAMESPACE"); namespace != "" {
+		return namespace
+	}
+
+	return DefaultNamespace
+}
+
+// LeaseName returns the name of the lease of the node. Node names are DNS
+// subdomains, so the prefixed name is a valid lease name up to 253 characters.
+func LeaseName(nodeName string) string {
+	return leasePrefix + nodeName
+}
+
+// Acquire takes or renews the lock of the node for holder and returns its fencing
+// token. It returns ErrHeld when another holder owns an unexpired lock.
+func (l *Locker) Acquire(ctx context.Context, nodeName, holder string) (int64, error) {
+	leases := l.client.CoordinationV1().Leases(l.namespace)
+	now := metav1.NewMicroTime(l.now())
+
+	lease, err := leases.Get(ctx, LeaseName(nodeName), metav1.GetOptions{})
+	if apierrors.IsNotFound(err) {
+		lease = &coordinationv1.Lease{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      LeaseName(nodeName),
+				Namespace: l.namespace,
+				Labels:    map[string]string{NodeLabel: nodeName},
+			},
+			Spec: coordinationv1.LeaseSpec{
+				HolderIdentity:       ptr.To(holder),
+				LeaseDurationSeconds: ptr.To(int32(l.duration.Seconds())),
+				AcquireTime:          &now,
+				RenewTime:            &now,
+				LeaseTransitions:     ptr.To(int32(1)),
+			},
+		}
+
+		if _, err := leases.Create(ctx, lease, metav1.CreateOptions{}); err != nil {
+			if apierrors.IsAlreadyExists(err) {
+				return 0, fmt.Errorf("%w: %s was locked concurrently", ErrHeld, nodeName)
+			}
+
+			return 0, fmt.Errorf("failed to create lock of node %s: %w", nodeName, err)
+		}
+
+		slog.Info("Node locked", "node", nodeName, "holder", holder, "token", 1)
+
+		return 1, nil
+	}
+
+	if err != nil {
+		return 0, fmt.Errorf("failed to get lock of node %s: %w", nodeName, err)
+	}
+
+	current := ptr.Deref(lease.Spec.HolderIdentity, "")
+	token := int64(ptr.Deref(lease.Spec.LeaseTransitions, 0))
+
+	if current != holder {
+		if current != "" && !l.expired(lease) {
+			return 0, fmt.Errorf("%w: %s is held by %s", ErrHeld, nodeName, current)
+		}
+
+		// a new holder fences off every previous one
+		token++
+		lease.Spec.HolderIdentity = ptr.To(holder)
+		lease.Spec.AcquireTime = &now
+		lease.Spec.LeaseTransitions = ptr.To(int32(token))
+	}
+
+	lease.Spec.RenewTime = &now
+	lease.Spec.LeaseDurationSeconds = ptr.To(int32(l.duration.Seconds()))
+
+	// the resource version makes concurrent takeovers conflict
+	if _, err := leases.Update(ctx, lease, metav1.UpdateOptions{}); err != nil {
+		if apierrors.IsConflict(err) {
+			return 0, fmt.Errorf("%w: %s was locked concurrently", ErrHeld, nodeName)
+		}
+
+		return 0, fmt.Errorf("failed to update lock of node %s: %w", nodeName, err)
+	}
+
+	if current != holder {
+		slog.Info("Node locked", "node", nodeName, "holder", holder, "token", token, "previousHolder", current)
+	}
+
+	return token, nil
+}
+
+// Check returns ErrLost unless holder still owns the lock of the node with the
+// fencing token. It is called right before acting on the node.
+func (l *Locker) Check(ctx context.Context, nodeName, holder string, token int64) error {
+	lease, err := l.client.CoordinationV1().Leases(l.namespace).Get(ctx, LeaseName(nodeName), metav1.GetOptions{})
+	if apierrors.IsNotFound(err) {
+		return fmt.Errorf("%w: %s is not locked", ErrLost, nodeName)
+	}
+
+	if err != nil {
+		return fmt.Errorf("failed to get lock of node %s: %w", nodeName, err)
+	}
+
+	current := ptr.Deref(lease.Spec.HolderIdentity, "")
+	currentToken := int64(ptr.Deref(lease.Spec.LeaseTransitions, 0))
+
+	if current != holder || currentToken != token || l.expired(lease) {
+		return fmt.Errorf("%w: %s is held by %q with token %d, not %q with token %d",
+			ErrLost, nodeName, current, currentToken, holder, token)
+	}
+
+	return nil
+}
+
+// Release gives up the lock of the node if holder owns it. The lease is kept, so
+// the fencing token keeps increasing across holders.
+func (l *Locker) Release(ctx context.Context, nodeName, holder string) error {
+	leases := l.client.CoordinationV1().Leases(l.namespace)
+
+	lease, err := leases.Get(ctx, LeaseName(nodeName), metav1.GetOptions{})
+	if apierrors.IsNotFound(err) {
+		return nil
+	}
+
+	if err != nil {
+		return fmt.Errorf("failed to get lock of node %s: %w", nodeName, err)
+	}
+
+	if ptr.Deref(lease.Spec.HolderIdentity, "") != holder {
+		return nil
+	}
+
+	lease.Spec.HolderIdentity = nil
+	lease.Spec.AcquireTime = nil
+	lease.Spec.RenewTime = nil
+
+	if _, err := leases.Update(ctx, lease, metav1.UpdateOptions{}); err != nil {
+		return fmt.Errorf("failed to release lock of node %s: %w", nodeName, err)
+	}
+
+	slog.Info("Node unlocked", "node", nodeName, "holder", holder)
+
+	return nil
+}
+
+func (l *Locker) expired(lease *coordinationv1.Lease) bool {
+	if lease.Spec.RenewTime == nil {
+		return true
+	}
+
+	duration := time.Duration(ptr.Deref(lease.Spec.LeaseDurationSeconds, 0)) * time.Second
+
+	return l.now().After(lease.Spec.RenewTime.Add(duration))
+}
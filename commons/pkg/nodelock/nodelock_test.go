@@ -0,0 +1,103 @@
+// Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package nodelock
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func newTestLocker() (*Locker, *time.Time) {
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	locker := NewLocker(fake.NewSimpleClientset(), "nvsentinel", 10*time.Minute)
+	locker.now = func() time.Time { return now }
+
+	return locker, &now
+}
+
+func TestAcquire(t *testing.T) {
+	ctx := context.Background()
+	locker, _ := newTestLocker()
+
+	token, err := locker.Acquire(ctx, "node-1", "rebootnode/a")
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), token)
+
+	// renewing keeps the token
+	token, err = locker.Acquire(ctx, "node-1", "rebootnode/a")
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), token)
+
+	_, err = locker.Acquire(ctx, "node-1", "terminatenode/b")
+	require.ErrorIs(t, err, ErrHeld)
+
+	// other nodes are locked independently
+	_, err = locker.Acquire(ctx, "node-2", "terminatenode/b")
+	require.NoError(t, err)
+
+	lease, err := locker.client.CoordinationV1().Leases("nvsentinel").Get(ctx, LeaseName("node-1"), metav1.GetOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, "node-1", lease.Labels[NodeLabel])
+	assert.Equal(t, "rebootnode/a", *lease.Spec.HolderIdentity)
+}
+
+func TestAcquireAfterRelease(t *testing.T) {
+	ctx := context.Background()
+	locker, _ := newTestLocker()
+
+	_, err := locker.Acquire(ctx, "node-1", "rebootnode/a")
+	require.NoError(t, err)
+
+	// only the holder releases the lock
+	require.NoError(t, locker.Release(ctx, "node-1", "terminatenode/b"))
+	_, err = locker.Acquire(ctx, "node-1", "terminatenode/b")
+	require.ErrorIs(t, err, ErrHeld)
+
+	require.NoError(t, locker.Release(ctx, "node-1", "rebootnode/a"))
+
+	token, err := locker.Acquire(ctx, "node-1", "terminatenode/b")
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), token, "the token increases with every holder")
+
+	require.NoError(t, locker.Release(ctx, "node-unknown", "rebootnode/a"))
+}
+
+func TestExpiredLockIsTakenOver(t *testing.T) {
+	ctx := context.Background()
+	locker, now := newTestLocker()
+
+	oldToken, err := locker.Acquire(ctx, "node-1", "rebootnode/a")
+	require.NoError(t, err)
+	require.NoError(t, locker.Check(ctx, "node-1", "rebootnode/a", oldToken))
+
+	*now = now.Add(11 * time.Minute)
+
+	require.ErrorIs(t, locker.Check(ctx, "node-1", "rebootnode/a", oldToken), ErrLost)
+
+	token, err := locker.Acquire(ctx, "node-1", "terminatenode/b")
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), token)
+
+	// the previous holder is fenced off even if it renews its view of the lock
+	require.ErrorIs(t, locker.Check(ctx, "node-1", "rebootnode/a", oldToken), ErrLost)
+	require.NoError(t, locker.Check(ctx, "node-1", "terminatenode/b", token))
+	require.ErrorIs(t, locker.Check(ctx, "node-2", "terminatenode/b", token), ErrLost)
+}
@@ -0,0 +1,74 @@
+// Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package secrets
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// FileProvider reads secrets from a directory holding one subdirectory per secret
+// and one file per key. Kubernetes updates mounted Secret volumes in place when the
+// Secret changes, so rotation works without restarts.
+type FileProvider struct {
+	dir string
+}
+
+// NewFileProvider creates a FileProvider reading from dir.
+func NewFileProvider(dir string) *FileProvider {
+	return &FileProvider{dir: dir}
+}
+
+// Get implements Provider.
+func (p *FileProvider) Get(_ context.Context, name string) (*Secret, error) {
+	if name == "" || strings.ContainsAny(name, `/\`) || name == "." || name == ".." {
+		return nil, fmt.Errorf("invalid secret name %q", name)
+	}
+
+	secretDir := filepath.Join(p.dir, name)
+
+	entries, err := os.ReadDir(secretDir)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil, fmt.Errorf("%w: %s", ErrNotFound, name)
+		}
+
+		return nil, fmt.Errorf("reading secret %s: %w", name, err)
+	}
+
+	data := make(map[string][]byte, len(entries))
+
+	for _, entry := range entries {
+		// Kubernetes keeps the current data in a hidden ..data directory and links the
+		// keys to it, skip the hidden entries.
+		if strings.HasPrefix(entry.Name(), ".") {
+			continue
+		}
+
+		value, err := os.ReadFile(filepath.Join(secretDir, entry.Name()))
+		if err != nil {
+			return nil, fmt.Errorf("reading secret %s key %s: %w", name, entry.Name(), err)
+		}
+
+		data[entry.Name()] = value
+	}
+
+	return NewSecret(name, data), nil
+}
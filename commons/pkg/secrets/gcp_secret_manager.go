@@ -0,0 +1,230 @@
+// Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package secrets
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"path"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	defaultGCPSecretManagerEndpoint = "https://secretmanager.googleapis.com"
+	defaultGCPMetadataURL           = "http://metadata.google.internal"
+
+	gcpTokenPath = "/computeMetadata/v1/instance/service-accounts/default/token"
+
+	// gcpTokenExpiryMargin is how long before its expiry an access token is renewed
+	gcpTokenExpiryMargin = time.Minute
+
+	// gcpValueKey is the key holding the whole payload of a secret
+	gcpValueKey = "value"
+)
+
+// GCPSecretManagerConfig configures the Google Cloud Secret Manager backend.
+type GCPSecretManagerConfig struct {
+	// Project is the ID or number of the project owning the secrets.
+	Project string
+	// Endpoint of the Secret Manager API, defaults to https://secretmanager.googleapis.com.
+	Endpoint string
+	// MetadataURL of the GCE metadata server issuing access tokens, defaults to
+	// http://metadata.google.internal. On GKE the token is the one of the
+	// Google service account bound through Workload Identity.
+	MetadataURL string
+}
+
+// GCPSecretManagerConfigFromEnv returns the Secret Manager configuration from
+// GCP_SECRET_MANAGER_PROJECT, GCP_SECRET_MANAGER_ENDPOINT and GCE_METADATA_HOST.
+func GCPSecretManagerConfigFromEnv() GCPSecretManagerConfig {
+	cfg := GCPSecretManagerConfig{
+		Project:  os.Getenv("GCP_SECRET_MANAGER_PROJECT"),
+		Endpoint: os.Getenv("GCP_SECRET_MANAGER_ENDPOINT"),
+	}
+
+	if host := os.Getenv("GCE_METADATA_HOST"); host != "" {
+		cfg.MetadataURL = "http://" + host
+	}
+
+	return cfg
+}
+
+// GCPSecretManagerProvider reads the latest version of secrets from Google Cloud
+// Secret Manager. The whole payload is returned under the "value" key and, when
+// it is a JSON object of strings, each field under its own key as well.
+type GCPSecretManagerProvider struct {
+	cfg        GCPSecretManagerConfig
+	httpClient *http.Client
+	now        func() time.Time
+
+	mu          sync.Mutex
+	token       string
+	tokenExpiry time.Time
+}
+
+// NewGCPSecretManagerProvider creates a GCPSecretManagerProvider. A nil httpClient
+// uses http.DefaultClient.
+func NewGCPSecretManagerProvider(cfg GCPSecretManagerConfig,
+	httpClient *http.Client) (*GCPSecretManagerProvider, error) {
+	if cfg.Project == "" {
+		return nil, errors.New("gcp secret manager: project is required")
+	}
+
+	if cfg.Endpoint == "" {
+		cfg.Endpoint = defaultGCPSecretManagerEndpoint
+	}
+
+	if cfg.MetadataURL == "" {
+		cfg.MetadataURL = defaultGCPMetadataURL
+	}
+
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+
+	return &GCPSecretManagerProvider{cfg: cfg, httpClient: httpClient, now: time.Now}, nil
+}
+
+type gcpAccessResponse struct {
+	Name    string `json:"name"`
+	Payload struct {
+		Data string `json:"data"`
+	} `json:"payload"`
+}
+
+// Get implements Provider. The version of the returned secret is the Secret
+// Manager version number.
+func (p *GCPSecretManagerProvider) Get(ctx context.Context, name string) (*Secret, error) {
+	token, err := p.accessToken(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	endpoint := strings.TrimSuffix(p.cfg.Endpoint, "/") + "/v1/projects/" + p.cfg.Project +
+		"/secrets/" + strings.TrimPrefix(name, "/") + "/versions/latest:access"
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return nil, fmt.Errorf("gcp secret manager: %w", err)
+	}
+
+	req.Header.Set("Authorization", "Bearer "+token)
+
+	resp, err := p.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("gcp secret manager: getting secret %s: %w", name, err)
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+	case http.StatusNotFound:
+		return nil, fmt.Errorf("%w: %s", ErrNotFound, name)
+	default:
+		// the response body may echo request details, it is not included in the error
+		return nil, fmt.Errorf("gcp secret manager: getting secret %s: unexpected status %d",
+			name, resp.StatusCode)
+	}
+
+	var access gcpAccessResponse
+	if err := json.NewDecoder(resp.Body).Decode(&access); err != nil {
+		return nil, fmt.Errorf("gcp secret manager: decoding secret %s: %w", name, err)
+	}
+
+	payload, err := base64.StdEncoding.DecodeString(access.Payload.Data)
+	if err != nil {
+		return nil, fmt.Errorf("gcp secret manager: decoding secret %s: %w", name, err)
+	}
+
+	secret := NewSecret(name, gcpSecretData(payload))
+	if access.Name != "" {
+		secret.Version = path.Base(access.Name)
+	}
+
+	return secret, nil
+}
+
+// gcpSecretData returns the payload and, for a JSON object payload, its fields.
+func gcpSecretData(payload []byte) map[string][]byte {
+	data := map[string][]byte{gcpValueKey: payload}
+
+	var fields map[string]string
+	if err := json.Unmarshal(payload, &fields); err != nil {
+		return data
+	}
+
+	for k, v := range fields {
+		if k != gcpValueKey {
+			data[k] = []byte(v)
+		}
+	}
+
+	return data
+}
+
+type gcpTokenResponse struct {
+	AccessToken string `json:"access_token"`
+	ExpiresIn   int    `json:"expires_in"`
+}
+
+// accessToken returns an access token of the metadata server, renewed shortly
+// before it expires.
+func (p *GCPSecretManagerProvider) accessToken(ctx context.Context) (string, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.token != "" && p.now().Before(p.tokenExpiry) {
+		return p.token, nil
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet,
+		strings.TrimSuffix(p.cfg.MetadataURL, "/")+gcpTokenPath, nil)
+	if err != nil {
+		return "", fmt.Errorf("gcp secret manager: %w", err)
+	}
+
+	req.Header.Set("Metadata-Flavor", "Google")
+
+	resp, err := p.httpClient.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("gcp secret manager: getting access token: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("gcp secret manager: getting access token: unexpected status %d", resp.StatusCode)
+	}
+
+	var token gcpTokenResponse
+	if err := json.NewDecoder(resp.Body).Decode(&token); err != nil {
+		return "", fmt.Errorf("gcp secret manager: decoding access token: %w", err)
+	}
+
+	if token.AccessToken == "" {
+		return "", errors.New("gcp secret manager: metadata server returned no access token")
+	}
+
+	p.token = token.AccessToken
+	p.tokenExpiry = p.now().Add(time.Duration(token.ExpiresIn)*time.Second - gcpTokenExpiryMargin)
+
+	return p.token, nil
+}
@@ -0,0 +1,84 @@
+// Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package secrets
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+)
+
+// serviceAccountNamespaceFile holds the namespace of the pod
+const serviceAccountNamespaceFile = "/var/run/secrets/kubernetes.io/serviceaccount/namespace"
+
+// KubernetesProvider reads Secret objects from a namespace through the API server.
+// The service account needs get permission on the secrets.
+type KubernetesProvider struct {
+	client    kubernetes.Interface
+	namespace string
+}
+
+// NewKubernetesProvider creates a KubernetesProvider reading secrets from namespace.
+func NewKubernetesProvider(client kubernetes.Interface, namespace string) *KubernetesProvider {
+	return &KubernetesProvider{client: client, namespace: namespace}
+}
+
+// NewKubernetesProviderInCluster creates a KubernetesProvider using the in-cluster
+// configuration. An empty namespace selects the namespace of the pod.
+func NewKubernetesProviderInCluster(namespace string) (*KubernetesProvider, error) {
+	cfg, err := rest.InClusterConfig()
+	if err != nil {
+		return nil, fmt.Errorf("loading in-cluster config: %w", err)
+	}
+
+	client, err := kubernetes.NewForConfig(cfg)
+	if err != nil {
+		return nil, fmt.Errorf("creating kubernetes client: %w", err)
+	}
+
+	if namespace == "" {
+		b, err := os.ReadFile(serviceAccountNamespaceFile)
+		if err != nil {
+			return nil, fmt.Errorf("reading pod namespace: %w", err)
+		}
+
+		namespace = strings.TrimSpace(string(b))
+	}
+
+	return NewKubernetesProvider(client, namespace), nil
+}
+
+// Get implements Provider. The version of the returned secret is the resource version.
+func (p *KubernetesProvider) Get(ctx context.Context, name string) (*Secret, error) {
+	obj, err := p.client.CoreV1().Secrets(p.namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, fmt.Errorf("%w: %s/%s", ErrNotFound, p.namespace, name)
+		}
+
+		return nil, fmt.Errorf("getting secret %s/%s: %w", p.namespace, name, err)
+	}
+
+	secret := NewSecret(name, obj.Data)
+	secret.Version = obj.ResourceVersion
+
+	return secret, nil
+}
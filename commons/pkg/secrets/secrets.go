@@ -0,0 +1,261 @@
+// Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package secrets provides access to credentials used by connectors, e.g. CSP or
+// BMC credentials, independently of where they are stored.
+//
+// Supported backends:
+//   - file: a directory with one subdirectory per secret and one file per key, as
+//     mounted by Kubernetes Secret volumes or the Secrets Store CSI driver (which
+//     also covers the AWS, Azure and GCP secret managers)
+//   - kubernetes: Secret objects read through the API server
+//   - vault: HashiCorp Vault KV version 2
+//   - gcp-secret-manager: Google Cloud Secret Manager, authenticated with the
+//     service account of the workload
+//
+// Rotation is supported by reading secrets again instead of holding on to them:
+// consumers call Get whenever they need the credentials, and wrap the provider
+// with NewCached to bound the backend load.
+//
+// Secret values are never logged: Secret formats as [REDACTED] with fmt and slog.
+package secrets
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"sort"
+	"sync"
+	"time"
+)
+
+const redacted = "[REDACTED]"
+
+var (
+	// ErrNotFound is returned when the secret does not exist.
+	ErrNotFound = errors.New("secret not found")
+	// ErrKeyNotFound is returned when the secret does not hold the requested key.
+	ErrKeyNotFound = errors.New("secret key not found")
+)
+
+// Provider returns secrets by name.
+type Provider interface {
+	Get(ctx context.Context, name string) (*Secret, error)
+}
+
+// Secret is a set of key/value pairs. Its values are not printed by fmt or slog.
+type Secret struct {
+	name string
+	data map[string][]byte
+	// Version changes whenever the secret content changes.
+	Version string
+}
+
+// NewSecret creates a Secret. The version is derived from the content.
+func NewSecret(name string, data map[string][]byte) *Secret {
+	return &Secret{name: name, data: data, Version: contentVersion(data)}
+}
+
+// Name returns the name of the secret.
+func (s *Secret) Name() string {
+	return s.name
+}
+
+// Value returns the value of key.
+func (s *Secret) Value(key string) (string, error) {
+	v, ok := s.data[key]
+	if !ok {
+		return "", fmt.Errorf("%w: %s/%s", ErrKeyNotFound, s.name, key)
+	}
+
+	return string(v), nil
+}
+
+// Keys returns the sorted keys of the secret.
+func (s *Secret) Keys() []string {
+	keys := make([]string, 0, len(s.data))
+	for k := range s.data {
+		keys = append(keys, k)
+	}
+
+	sort.Strings(keys)
+
+	return keys
+}
+
+// String implements fmt.Stringer without exposing the values.
+func (s *Secret) String() string {
+	return redacted
+}
+
+// GoString implements fmt.GoStringer without exposing the values.
+func (s *Secret) GoString() string {
+	return redacted
+}
+
+// LogValue implements slog.LogValuer without exposing the values.
+func (s *Secret) LogValue() slog.Value {
+	return slog.GroupValue(
+		slog.String("name", s.name),
+		slog.String("version", s.Version),
+		slog.Any("keys", s.Keys()),
+	)
+}
+
+func contentVersion(data map[string][]byte) string {
+	h := sha256.New()
+
+	keys := make([]string, 0, len(data))
+	for k := range data {
+		keys = append(keys, k)
+	}
+
+	sort.Strings(keys)
+
+	for _, k := range keys {
+		h.Write([]byte(k))
+		h.Write([]byte{0})
+		h.Write(data[k])
+		h.Write([]byte{0})
+	}
+
+	return hex.EncodeToString(h.Sum(nil))[:16]
+}
+
+// Cached caches secrets of another provider for a TTL, so that rotated secrets are
+// picked up within the TTL without hitting the backend on every use.
+type Cached struct {
+	provider Provider
+	ttl      time.Duration
+	now      func() time.Time
+
+	mu      sync.Mutex
+	entries map[string]cachedSecret
+}
+
+type cachedSecret struct {
+	secret  *Secret
+	fetched time.Time
+}
+
+// NewCached wraps provider with a cache holding secrets for ttl.
+func NewCached(provider Provider, ttl time.Duration) *Cached {
+	return &Cached{
+		provider: provider,
+		ttl:      ttl,
+		now:      time.Now,
+		entries:  make(map[string]cachedSecret),
+	}
+}
+
+// Get implements Provider. When the backend fails, a cached secret is returned until
+// it is twice the TTL old, so a short backend outage does not break the consumers.
+func (c *Cached) Get(ctx context.Context, name string) (*Secret, error) {
+	c.mu.Lock()
+	entry, ok := c.entries[name]
+	c.mu.Unlock()
+
+	age := c.now().Sub(entry.fetched)
+	if ok && age < c.ttl {
+		return entry.secret, nil
+	}
+
+	secret, err := c.provider.Get(ctx, name)
+	if err != nil {
+		if ok && age < 2*c.ttl && !errors.Is(err, ErrNotFound) {
+			slog.Warn("Using cached secret, refresh failed", "secret", name, "error", err)
+			return entry.secret, nil
+		}
+
+		return nil, err
+	}
+
+	if ok && entry.secret.Version != secret.Version {
+		slog.Info("Secret rotated", "secret", secret)
+	}
+
+	c.mu.Lock()
+	c.entries[name] = cachedSecret{secret: secret, fetched: c.now()}
+	c.mu.Unlock()
+
+	return secret, nil
+}
+
+// Environment variables selecting the backend of NewProviderFromEnv.
+const (
+	// EnvProvider selects the backend: file (default), kubernetes, vault or
+	// gcp-secret-manager.
+	EnvProvider = "SECRETS_PROVIDER"
+	// EnvDir is the directory of the file backend.
+	EnvDir = "SECRETS_DIR"
+	// EnvNamespace is the namespace of the kubernetes backend, defaults to the pod namespace.
+	EnvNamespace = "SECRETS_NAMESPACE"
+	// EnvCacheTTL is how long secrets are cached, defaults to DefaultCacheTTL.
+	EnvCacheTTL = "SECRETS_CACHE_TTL"
+
+	// DefaultDir is the default directory of the file backend.
+	DefaultDir = "/etc/nvsentinel/secrets"
+	// DefaultCacheTTL is the default time secrets are cached before being read again.
+	DefaultCacheTTL = 5 * time.Minute
+)
+
+// NewProviderFromEnv creates the provider selected by the SECRETS_* environment
+// variables, wrapped with a cache. The vault backend is configured by the VAULT_*
+// variables, see VaultConfigFromEnv, the gcp-secret-manager backend by the
+// GCP_SECRET_MANAGER_* variables, see GCPSecretManagerConfigFromEnv.
+func NewProviderFromEnv() (Provider, error) {
+	ttl := DefaultCacheTTL
+
+	if v := os.Getenv(EnvCacheTTL); v != "" {
+		d, err := time.ParseDuration(v)
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s %q: %w", EnvCacheTTL, v, err)
+		}
+
+		ttl = d
+	}
+
+	var (
+		provider Provider
+		err      error
+	)
+
+	switch backend := os.Getenv(EnvProvider); backend {
+	case "", "file":
+		dir := os.Getenv(EnvDir)
+		if dir == "" {
+			dir = DefaultDir
+		}
+
+		provider = NewFileProvider(dir)
+	case "kubernetes":
+		provider, err = NewKubernetesProviderInCluster(os.Getenv(EnvNamespace))
+	case "vault":
+		provider, err = NewVaultProvider(VaultConfigFromEnv(), nil)
+	case "gcp-secret-manager":
+		provider, err = NewGCPSecretManagerProvider(GCPSecretManagerConfigFromEnv(), nil)
+	default:
+		return nil, fmt.Errorf("unsupported %s %q", EnvProvider, backend)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return NewCached(provider, ttl), nil
+}
@@ -0,0 +1,283 @@
+// Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package secrets
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func writeSecretFiles(t *testing.T, dir, name string, data map[string]string) {
+	t.Helper()
+
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, name), 0o700))
+
+	for k, v := range data {
+		require.NoError(t, os.WriteFile(filepath.Join(dir, name, k), []byte(v), 0o600))
+	}
+}
+
+func TestSecret_Redacted(t *testing.T) {
+	secret := NewSecret("aws", map[string][]byte{"aws_secret_access_key": []byte("super-secret")})
+
+	assert.NotContains(t, fmt.Sprintf("%v %+v %s %#v", secret, secret, secret, secret), "super-secret")
+
+	var buf bytes.Buffer
+	slog.New(slog.NewJSONHandler(&buf, nil)).Info("loaded", "secret", secret)
+	assert.NotContains(t, buf.String(), "super-secret")
+	assert.Contains(t, buf.String(), "aws_secret_access_key")
+
+	value, err := secret.Value("aws_secret_access_key")
+	require.NoError(t, err)
+	assert.Equal(t, "super-secret", value)
+
+	_, err = secret.Value("missing")
+	assert.ErrorIs(t, err, ErrKeyNotFound)
+}
+
+func TestFileProvider(t *testing.T) {
+	dir := t.TempDir()
+	writeSecretFiles(t, dir, "aws", map[string]string{"aws_access_key_id": "AKIA", ".hidden": "x"})
+
+	p := NewFileProvider(dir)
+
+	secret, err := p.Get(context.Background(), "aws")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"aws_access_key_id"}, secret.Keys())
+
+	_, err = p.Get(context.Background(), "missing")
+	assert.ErrorIs(t, err, ErrNotFound)
+
+	_, err = p.Get(context.Background(), "../etc")
+	assert.Error(t, err)
+}
+
+func TestKubernetesProvider(t *testing.T) {
+	client := fake.NewSimpleClientset(&corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "bmc", Namespace: "nvsentinel", ResourceVersion: "42"},
+		Data:       map[string][]byte{"password": []byte("hunter2")},
+	})
+
+	p := NewKubernetesProvider(client, "nvsentinel")
+
+	secret, err := p.Get(context.Background(), "bmc")
+	require.NoError(t, err)
+	assert.Equal(t, "42", secret.Version)
+
+	value, err := secret.Value("password")
+	require.NoError(t, err)
+	assert.Equal(t, "hunter2", value)
+
+	_, err = p.Get(context.Background(), "missing")
+	assert.ErrorIs(t, err, ErrNotFound)
+}
+
+func TestVaultProvider(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-Vault-Token") != "vault-token" {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+
+		if r.URL.Path != "/v1/kv/data/janitor/aws" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		_, _ = w.Write([]byte(`{"data":{"data":{"aws_access_key_id":"AKIA","port":443},"metadata":{"version":3}}}`))
+	}))
+	t.Cleanup(server.Close)
+
+	tokenFile := filepath.Join(t.TempDir(), "token")
+	require.NoError(t, os.WriteFile(tokenFile, []byte("vault-token\n"), 0o600))
+
+	p, err := NewVaultProvider(VaultConfig{Address: server.URL, Mount: "kv", TokenFile: tokenFile}, nil)
+	require.NoError(t, err)
+
+	secret, err := p.Get(context.Background(), "janitor/aws")
+	require.NoError(t, err)
+	assert.Equal(t, "3", secret.Version)
+
+	value, err := secret.Value("port")
+	require.NoError(t, err)
+	assert.Equal(t, "443", value)
+
+	_, err = p.Get(context.Background(), "janitor/missing")
+	assert.ErrorIs(t, err, ErrNotFound)
+
+	require.NoError(t, os.WriteFile(tokenFile, []byte("revoked"), 0o600))
+
+	_, err = p.Get(context.Background(), "janitor/aws")
+	require.Error(t, err)
+	assert.NotContains(t, err.Error(), "revoked")
+}
+
+func TestGCPSecretManagerProvider(t *testing.T) {
+	tokenRequests := 0
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case gcpTokenPath:
+			if r.Header.Get("Metadata-Flavor") != "Google" {
+				w.WriteHeader(http.StatusForbidden)
+				return
+			}
+
+			tokenRequests++
+			_, _ = w.Write([]byte(`{"access_token":"gcp-token","expires_in":3600}`))
+		case "/v1/projects/proj/secrets/janitor-gcp/versions/latest:access":
+			if r.Header.Get("Authorization") != "Bearer gcp-token" {
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+
+			payload := base64.StdEncoding.EncodeToString([]byte(`{"client_id":"abc","client_secret":"s3cr3t"}`))
+			_, _ = fmt.Fprintf(w, `{"name":"projects/123/secrets/janitor-gcp/versions/7","payload":{"data":%q}}`,
+				payload)
+		case "/v1/projects/proj/secrets/plain/versions/latest:access":
+			payload := base64.StdEncoding.EncodeToString([]byte("hunter2\n"))
+			_, _ = fmt.Fprintf(w, `{"name":"projects/123/secrets/plain/versions/2","payload":{"data":%q}}`, payload)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	p, err := NewGCPSecretManagerProvider(GCPSecretManagerConfig{
+		Project:     "proj",
+		Endpoint:    server.URL,
+		MetadataURL: server.URL,
+	}, nil)
+	require.NoError(t, err)
+
+	secret, err := p.Get(context.Background(), "janitor-gcp")
+	require.NoError(t, err)
+	assert.Equal(t, "7", secret.Version)
+	assert.Equal(t, []string{"client_id", "client_secret", gcpValueKey}, secret.Keys())
+
+	value, err := secret.Value("client_secret")
+	require.NoError(t, err)
+	assert.Equal(t, "s3cr3t", value)
+
+	// a payload that is not a JSON object is only returned whole
+	secret, err = p.Get(context.Background(), "plain")
+	require.NoError(t, err)
+
+	value, err = secret.Value(gcpValueKey)
+	require.NoError(t, err)
+	assert.Equal(t, "hunter2\n", value)
+
+	_, err = p.Get(context.Background(), "missing")
+	assert.ErrorIs(t, err, ErrNotFound)
+
+	// the access token is reused until it expires
+	assert.Equal(t, 1, tokenRequests)
+
+	_, err = NewGCPSecretManagerProvider(GCPSecretManagerConfig{}, nil)
+	assert.Error(t, err)
+}
+
+type flakyProvider struct {
+	secret *Secret
+	err    error
+	calls  int
+}
+
+func (p *flakyProvider) Get(_ context.Context, _ string) (*Secret, error) {
+	p.calls++
+	return p.secret, p.err
+}
+
+func TestCached(t *testing.T) {
+	now := time.Now()
+	backend := &flakyProvider{secret: NewSecret("aws", map[string][]byte{"key": []byte("v1")})}
+
+	c := NewCached(backend, time.Minute)
+	c.now = func() time.Time { return now }
+
+	secret, err := c.Get(context.Background(), "aws")
+	require.NoError(t, err)
+	assert.Equal(t, backend.secret.Version, secret.Version)
+
+	// served from the cache within the TTL
+	_, err = c.Get(context.Background(), "aws")
+	require.NoError(t, err)
+	assert.Equal(t, 1, backend.calls)
+
+	// rotated secret is picked up once the TTL passed
+	backend.secret = NewSecret("aws", map[string][]byte{"key": []byte("v2")})
+	now = now.Add(2 * time.Minute)
+
+	secret, err = c.Get(context.Background(), "aws")
+	require.NoError(t, err)
+
+	value, _ := secret.Value("key")
+	assert.Equal(t, "v2", value)
+
+	// backend outage: the cached secret is used up to twice the TTL
+	backend.err = errors.New("backend unavailable")
+	now = now.Add(90 * time.Second)
+
+	secret, err = c.Get(context.Background(), "aws")
+	require.NoError(t, err)
+
+	value, _ = secret.Value("key")
+	assert.Equal(t, "v2", value)
+
+	now = now.Add(time.Minute)
+
+	_, err = c.Get(context.Background(), "aws")
+	assert.Error(t, err)
+}
+
+func TestNewProviderFromEnv(t *testing.T) {
+	dir := t.TempDir()
+	writeSecretFiles(t, dir, "aws", map[string]string{"aws_access_key_id": "AKIA"})
+
+	t.Setenv(EnvDir, dir)
+
+	p, err := NewProviderFromEnv()
+	require.NoError(t, err)
+
+	_, err = p.Get(context.Background(), "aws")
+	require.NoError(t, err)
+
+	t.Setenv(EnvProvider, "unknown")
+
+	_, err = NewProviderFromEnv()
+	assert.Error(t, err)
+
+	t.Setenv(EnvProvider, "file")
+	t.Setenv(EnvCacheTTL, "soon")
+
+	_, err = NewProviderFromEnv()
+	assert.Error(t, err)
+}
@@ -0,0 +1,151 @@
+// Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package secrets
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+)
+
+const defaultVaultMount = "secret"
+
+// VaultConfig configures the Vault KV version 2 backend.
+type VaultConfig struct {
+	// Address of the Vault server, e.g. https://vault.example.com:8200.
+	Address string
+	// Mount is the path of the KV engine, defaults to "secret".
+	Mount string
+	// Namespace is the Vault Enterprise namespace, if any.
+	Namespace string
+	// TokenFile holds the Vault token. It is read on every request so a token
+	// renewed by the Vault agent is picked up.
+	TokenFile string
+}
+
+// VaultConfigFromEnv returns the Vault configuration from VAULT_ADDR, VAULT_KV_MOUNT,
+// VAULT_NAMESPACE and VAULT_TOKEN_FILE.
+func VaultConfigFromEnv() VaultConfig {
+	return VaultConfig{
+		Address:   os.Getenv("VAULT_ADDR"),
+		Mount:     os.Getenv("VAULT_KV_MOUNT"),
+		Namespace: os.Getenv("VAULT_NAMESPACE"),
+		TokenFile: os.Getenv("VAULT_TOKEN_FILE"),
+	}
+}
+
+// VaultProvider reads secrets from HashiCorp Vault KV version 2.
+type VaultProvider struct {
+	cfg        VaultConfig
+	httpClient *http.Client
+}
+
+// NewVaultProvider creates a VaultProvider. A nil httpClient uses http.DefaultClient.
+func NewVaultProvider(cfg VaultConfig, httpClient *http.Client) (*VaultProvider, error) {
+	if cfg.Address == "" {
+		return nil, errors.New("vault: address is required")
+	}
+
+	if cfg.TokenFile == "" {
+		return nil, errors.New("vault: token file is required")
+	}
+
+	if cfg.Mount == "" {
+		cfg.Mount = defaultVaultMount
+	}
+
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+
+	return &VaultProvider{cfg: cfg, httpClient: httpClient}, nil
+}
+
+type vaultKVResponse struct {
+	Data struct {
+		Data     map[string]any `json:"data"`
+		Metadata struct {
+			Version int `json:"version"`
+		} `json:"metadata"`
+	} `json:"data"`
+}
+
+// Get implements Provider. The version of the returned secret is the KV version.
+func (p *VaultProvider) Get(ctx context.Context, name string) (*Secret, error) {
+	token, err := os.ReadFile(p.cfg.TokenFile)
+	if err != nil {
+		return nil, fmt.Errorf("vault: reading token: %w", err)
+	}
+
+	endpoint := strings.TrimSuffix(p.cfg.Address, "/") + "/v1/" +
+		strings.Trim(p.cfg.Mount, "/") + "/data/" + strings.TrimPrefix(name, "/")
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return nil, fmt.Errorf("vault: %w", err)
+	}
+
+	req.Header.Set("X-Vault-Token", strings.TrimSpace(string(token)))
+
+	if p.cfg.Namespace != "" {
+		req.Header.Set("X-Vault-Namespace", p.cfg.Namespace)
+	}
+
+	resp, err := p.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("vault: getting secret %s: %w", name, err)
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+	case http.StatusNotFound:
+		return nil, fmt.Errorf("%w: %s", ErrNotFound, name)
+	default:
+		// the response body may echo request details, it is not included in the error
+		return nil, fmt.Errorf("vault: getting secret %s: unexpected status %d", name, resp.StatusCode)
+	}
+
+	var kv vaultKVResponse
+	if err := json.NewDecoder(resp.Body).Decode(&kv); err != nil {
+		return nil, fmt.Errorf("vault: decoding secret %s: %w", name, err)
+	}
+
+	data := make(map[string][]byte, len(kv.Data.Data))
+
+	for k, v := range kv.Data.Data {
+		s, ok := v.(string)
+		if !ok {
+			b, err := json.Marshal(v)
+			if err != nil {
+				return nil, fmt.Errorf("vault: secret %s key %s: %w", name, k, err)
+			}
+
+			s = string(b)
+		}
+
+		data[k] = []byte(s)
+	}
+
+	secret := NewSecret(name, data)
+	secret.Version = strconv.Itoa(kv.Data.Metadata.Version)
+
+	return secret, nil
+}
@@ -0,0 +1,130 @@
+// Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package compression registers the gRPC compressors supported on the
+// health monitor → platform connector path. Importing it registers both gzip
+// and zstd, so a server that imports it can decode either, and advertises them
+// to clients through the grpc-accept-encoding header.
+package compression
+
+import (
+	"fmt"
+	"io"
+	"sync"
+
+	"github.com/klauspost/compress/zstd"
+	"google.golang.org/grpc/encoding"
+	"google.golang.org/grpc/encoding/gzip"
+)
+
+const (
+	// None disables compression.
+	None = "none"
+	// Gzip is the gzip compressor name.
+	Gzip = gzip.Name
+	// Zstd is the zstd compressor name.
+	Zstd = "zstd"
+)
+
+func init() {
+	encoding.RegisterCompressor(&zstdCompressor{})
+}
+
+// Validate returns the canonical compressor name for name, mapping "" to None,
+// or an error when the compressor is not supported.
+func Validate(name string) (string, error) {
+	switch name {
+	case "", None:
+		return None, nil
+	case Gzip, Zstd:
+		return name, nil
+	default:
+		return "", fmt.Errorf("unsupported compression %q, must be one of %s, %s, %s", name, None, Gzip, Zstd)
+	}
+}
+
+type zstdCompressor struct {
+	encoders sync.Pool
+	decoders sync.Pool
+}
+
+func (c *zstdCompressor) Name() string {
+	return Zstd
+}
+
+func (c *zstdCompressor) Compress(w io.Writer) (io.WriteCloser, error) {
+	if enc, ok := c.encoders.Get().(*zstd.Encoder); ok {
+		enc.Reset(w)
+		return &pooledEncoder{Encoder: enc, pool: &c.encoders}, nil
+	}
+
+	enc, err := zstd.NewWriter(w, zstd.WithEncoderLevel(zstd.SpeedFastest), zstd.WithEncoderConcurrency(1))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create zstd encoder: %w", err)
+	}
+
+	return &pooledEncoder{Encoder: enc, pool: &c.encoders}, nil
+}
+
+func (c *zstdCompressor) Decompress(r io.Reader) (io.Reader, error) {
+	if dec, ok := c.decoders.Get().(*zstd.Decoder); ok {
+		if err := dec.Reset(r); err != nil {
+			c.decoders.Put(dec)
+			return nil, fmt.Errorf("failed to reset zstd decoder: %w", err)
+		}
+
+		return &pooledDecoder{Decoder: dec, pool: &c.decoders}, nil
+	}
+
+	dec, err := zstd.NewReader(r, zstd.WithDecoderConcurrency(1))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create zstd decoder: %w", err)
+	}
+
+	return &pooledDecoder{Decoder: dec, pool: &c.decoders}, nil
+}
+
+// pooledEncoder returns the encoder to the pool once the message is flushed.
+type pooledEncoder struct {
+	*zstd.Encoder
+	pool *sync.Pool
+}
+
+func (e *pooledEncoder) Close() error {
+	err := e.Encoder.Close()
+	e.pool.Put(e.Encoder)
+
+	return err
+}
+
+// pooledDecoder returns the decoder to the pool once the message is fully read.
+type pooledDecoder struct {
+	*zstd.Decoder
+	pool *sync.Pool
+}
+
+func (d *pooledDecoder) Read(p []byte) (int, error) {
+	if d.Decoder == nil {
+		return 0, io.EOF
+	}
+
+	n, err := d.Decoder.Read(p)
+	if err == io.EOF { //nolint:errorlint // io.EOF is returned unwrapped by io.Reader
+		_ = d.Decoder.Reset(nil)
+		d.pool.Put(d.Decoder)
+		d.Decoder = nil
+	}
+
+	return n, err
+}
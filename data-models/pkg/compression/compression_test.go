@@ -0,0 +1,82 @@
+// Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package compression
+
+import (
+	"bytes"
+	"io"
+	"strings"
+	"testing"
+
+	"google.golang.org/grpc/encoding"
+)
+
+func TestZstdRoundTrip(t *testing.T) {
+	c := encoding.GetCompressor(Zstd)
+	if c == nil {
+		t.Fatal("zstd compressor is not registered")
+	}
+
+	payload := []byte(strings.Repeat("NVRM: Xid (PCI:0000:b3:00.0): 79, pid=1234, name=process\n", 64))
+
+	// Run twice so the pooled encoder and decoder are reused.
+	for range 2 {
+		var buf bytes.Buffer
+
+		w, err := c.Compress(&buf)
+		if err != nil {
+			t.Fatalf("Compress: %v", err)
+		}
+
+		if _, err := w.Write(payload); err != nil {
+			t.Fatalf("Write: %v", err)
+		}
+
+		if err := w.Close(); err != nil {
+			t.Fatalf("Close: %v", err)
+		}
+
+		if buf.Len() >= len(payload) {
+			t.Errorf("compressed size %d is not smaller than raw size %d", buf.Len(), len(payload))
+		}
+
+		r, err := c.Decompress(&buf)
+		if err != nil {
+			t.Fatalf("Decompress: %v", err)
+		}
+
+		got, err := io.ReadAll(r)
+		if err != nil {
+			t.Fatalf("ReadAll: %v", err)
+		}
+
+		if !bytes.Equal(got, payload) {
+			t.Fatal("decompressed payload does not match the original")
+		}
+	}
+}
+
+func TestValidate(t *testing.T) {
+	for in, want := range map[string]string{"": None, None: None, Gzip: Gzip, Zstd: Zstd} {
+		got, err := Validate(in)
+		if err != nil || got != want {
+			t.Errorf("Validate(%q) = %q, %v; want %q", in, got, err, want)
+		}
+	}
+
+	if _, err := Validate("lz4"); err == nil {
+		t.Error("Validate(lz4) should fail")
+	}
+}
@@ -0,0 +1,65 @@
+// Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/nvidia/nvsentinel/data-models/pkg/protos"
+)
+
+// MetadataAffectedNodes is the metadata key of the comma separated nodes an incident
+// affects besides the node of its event, e.g. the compute trays behind a failed
+// switch tray. Fault quarantine quarantines all of them together.
+const MetadataAffectedNodes = "affected_nodes"
+
+// SetAffectedNodes records the nodes the event affects. The node of the event may
+// be among them.
+func SetAffectedNodes(event *protos.HealthEvent, nodes []string) {
+	if event.Metadata == nil {
+		event.Metadata = map[string]string{}
+	}
+
+	sorted := slices.Clone(nodes)
+	slices.Sort(sorted)
+
+	event.Metadata[MetadataAffectedNodes] = strings.Join(slices.Compact(sorted), ",")
+}
+
+// AffectedNodes returns the sorted nodes the event affects: its own node and the
+// ones listed in its metadata.
+func AffectedNodes(event *protos.HealthEvent) []string {
+	var nodes []string
+
+	if event.GetNodeName() != "" {
+		nodes = append(nodes, event.GetNodeName())
+	}
+
+	for _, node := range strings.Split(event.GetMetadata()[MetadataAffectedNodes], ",") {
+		if node = strings.TrimSpace(node); node != "" {
+			nodes = append(nodes, node)
+		}
+	}
+
+	slices.Sort(nodes)
+
+	return slices.Compact(nodes)
+}
+
+// IsCrossNode reports whether the event affects more than its own node.
+func IsCrossNode(event *protos.HealthEvent) bool {
+	return len(AffectedNodes(event)) > 1
+}
@@ -0,0 +1,53 @@
+// Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/nvidia/nvsentinel/data-models/pkg/protos"
+)
+
+func TestAffectedNodes(t *testing.T) {
+	event := &protos.HealthEvent{NodeName: "node-2"}
+
+	if got := AffectedNodes(event); !reflect.DeepEqual(got, []string{"node-2"}) {
+		t.Errorf("AffectedNodes of a single node event = %v, want its node", got)
+	}
+
+	if IsCrossNode(event) {
+		t.Error("IsCrossNode of a single node event = true, want false")
+	}
+
+	SetAffectedNodes(event, []string{"node-3", "node-1", "node-3"})
+
+	if got := event.Metadata[MetadataAffectedNodes]; got != "node-1,node-3" {
+		t.Errorf("affected nodes metadata = %q, want the sorted unique nodes", got)
+	}
+
+	if got := AffectedNodes(event); !reflect.DeepEqual(got, []string{"node-1", "node-2", "node-3"}) {
+		t.Errorf("AffectedNodes = %v, want the nodes of the metadata and the event", got)
+	}
+
+	if !IsCrossNode(event) {
+		t.Error("IsCrossNode of a cross-node event = false, want true")
+	}
+
+	event.Metadata[MetadataAffectedNodes] = "node-2, ,node-2"
+	if IsCrossNode(event) {
+		t.Error("IsCrossNode of an event listing only its own node = true, want false")
+	}
+}
@@ -0,0 +1,43 @@
+// Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"github.com/nvidia/nvsentinel/data-models/pkg/protos"
+)
+
+// MetadataBackfilled is the metadata key marking an event as backfilled: a health
+// monitor emitted it for a log line it re-read after restarting, either because it
+// already emitted an event for the line or because the line is from a previous boot.
+// Backfilled events are historical and must not trigger new actions.
+const MetadataBackfilled = "backfilled"
+
+// MarkBackfilled marks the event as backfilled.
+func MarkBackfilled(event *protos.HealthEvent) {
+	if event == nil {
+		return
+	}
+
+	if event.Metadata == nil {
+		event.Metadata = make(map[string]string)
+	}
+
+	event.Metadata[MetadataBackfilled] = "true"
+}
+
+// IsBackfilled returns true if the event is marked as backfilled.
+func IsBackfilled(event *protos.HealthEvent) bool {
+	return event != nil && event.Metadata[MetadataBackfilled] == "true"
+}
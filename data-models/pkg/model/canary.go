@@ -0,0 +1,55 @@
+// Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"time"
+
+	"github.com/nvidia/nvsentinel/data-models/pkg/protos"
+)
+
+// MetadataCanary is the metadata key marking an event as a synthetic canary event,
+// injected periodically on a designated canary node to verify the pipeline end to
+// end. Components process canary events in dry-run: they record that they saw the
+// event but never act on the node.
+const MetadataCanary = "canary"
+
+// CanaryQuarantined is the quarantine status fault-quarantine records on a canary
+// event its rulesets would have quarantined the node for. Downstream modules do not
+// watch for it, so the canary never drains or remediates the node.
+const CanaryQuarantined Status = "CanaryQuarantined"
+
+// CanaryStatus records the components that processed a canary event.
+type CanaryStatus struct {
+	AnalyzedAt *time.Time `bson:"analyzedat,omitempty"`
+}
+
+// MarkCanary marks the event as a canary event.
+func MarkCanary(event *protos.HealthEvent) {
+	if event == nil {
+		return
+	}
+
+	if event.Metadata == nil {
+		event.Metadata = make(map[string]string)
+	}
+
+	event.Metadata[MetadataCanary] = "true"
+}
+
+// IsCanary returns true if the event is marked as a canary event.
+func IsCanary(event *protos.HealthEvent) bool {
+	return event != nil && event.Metadata[MetadataCanary] == "true"
+}
@@ -0,0 +1,47 @@
+// Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"github.com/nvidia/nvsentinel/data-models/pkg/protos"
+)
+
+// MetadataCluster is the metadata key holding the name of the cluster an event
+// was reported in. Platform connectors set it on ingest when several clusters
+// share one store, so that the modules acting on the events reach the right one.
+const MetadataCluster = "cluster"
+
+// SetCluster records the cluster the event was reported in, unless the event
+// already names one.
+func SetCluster(event *protos.HealthEvent, cluster string) {
+	if event == nil || cluster == "" || event.Metadata[MetadataCluster] != "" {
+		return
+	}
+
+	if event.Metadata == nil {
+		event.Metadata = make(map[string]string)
+	}
+
+	event.Metadata[MetadataCluster] = cluster
+}
+
+// Cluster returns the cluster the event was reported in, empty if unknown.
+func Cluster(event *protos.HealthEvent) string {
+	if event == nil {
+		return ""
+	}
+
+	return event.Metadata[MetadataCluster]
+}
@@ -0,0 +1,47 @@
+// Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"testing"
+
+	"github.com/nvidia/nvsentinel/data-models/pkg/protos"
+)
+
+func TestSetCluster(t *testing.T) {
+	event := &protos.HealthEvent{}
+
+	SetCluster(event, "")
+	if event.Metadata != nil {
+		t.Errorf("SetCluster with an empty name set metadata %v", event.Metadata)
+	}
+
+	SetCluster(event, "edge-1")
+	if got := Cluster(event); got != "edge-1" {
+		t.Errorf("Cluster = %q, want edge-1", got)
+	}
+
+	// Events relayed from another cluster keep their cluster
+	SetCluster(event, "central")
+	if got := Cluster(event); got != "edge-1" {
+		t.Errorf("Cluster = %q after a second SetCluster, want edge-1", got)
+	}
+
+	SetCluster(nil, "edge-1")
+
+	if got := Cluster(nil); got != "" {
+		t.Errorf("Cluster(nil) = %q", got)
+	}
+}
@@ -0,0 +1,26 @@
+// Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+// Cumulative error counters reported by the health monitors in the event metadata,
+// evaluated by the rate of change rules of the health events analyzer. They count
+// since the monitor started, so they reset when it restarts.
+const (
+	// MetadataECCSBETotal is the metadata key of the single-bit ECC errors of a GPU.
+	MetadataECCSBETotal = "ecc_sbe_total"
+	// MetadataAERCorrectedTotal is the metadata key of the corrected PCIe AER errors
+	// of a device.
+	MetadataAERCorrectedTotal = "aer_corrected_total"
+)
@@ -0,0 +1,64 @@
+// Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"crypto/rand"
+	"encoding/binary"
+	"time"
+
+	"github.com/nvidia/nvsentinel/data-models/pkg/protos"
+)
+
+// crockford is the Crockford base32 alphabet ULIDs are encoded with.
+const crockford = "0123456789ABCDEFGHJKMNPQRSTVWXYZ"
+
+// EventIDLength is the length of an encoded event ID.
+const EventIDLength = 26
+
+// NewEventID returns a new globally unique event ID. IDs are ULIDs: a 48 bit
+// millisecond timestamp followed by 80 random bits, so they sort by creation
+// time when compared as strings.
+func NewEventID() string {
+	return newEventID(time.Now())
+}
+
+func newEventID(t time.Time) string {
+	var id [16]byte
+
+	// The timestamp fills the first 6 bytes, the random bits the remaining 10
+	binary.BigEndian.PutUint64(id[:8], uint64(t.UnixMilli())<<16) //nolint:gosec // times after 1970
+	_, _ = rand.Read(id[6:])                                      // crypto/rand.Read never fails
+
+	hi := binary.BigEndian.Uint64(id[:8])
+	lo := binary.BigEndian.Uint64(id[8:])
+
+	encoded := make([]byte, EventIDLength)
+	for i := len(encoded) - 1; i >= 0; i-- {
+		encoded[i] = crockford[lo&0x1f]
+		lo = lo>>5 | hi<<59
+		hi >>= 5
+	}
+
+	return string(encoded)
+}
+
+// AssignEventID gives the event a new ID unless it already has one. Agents call
+// it once when they create an event, so retries of the event keep its ID.
+func AssignEventID(event *protos.HealthEvent) {
+	if event != nil && event.Id == "" {
+		event.Id = NewEventID()
+	}
+}
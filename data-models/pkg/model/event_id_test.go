@@ -0,0 +1,71 @@
+// Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/nvidia/nvsentinel/data-models/pkg/protos"
+)
+
+func TestNewEventID(t *testing.T) {
+	// Timestamp of the example in the ULID specification
+	id := newEventID(time.UnixMilli(1469918176385))
+	if !strings.HasPrefix(id, "01ARYZ6S41") {
+		t.Errorf("newEventID = %s, want timestamp 01ARYZ6S41", id)
+	}
+
+	seen := make(map[string]bool)
+
+	for i := 0; i < 1000; i++ {
+		id := NewEventID()
+		if len(id) != EventIDLength || strings.Trim(id, crockford) != "" {
+			t.Fatalf("NewEventID = %q is not a ULID", id)
+		}
+
+		if seen[id] {
+			t.Fatalf("NewEventID returned %s twice", id)
+		}
+
+		seen[id] = true
+	}
+
+	earlier := newEventID(time.UnixMilli(1700000000000))
+	later := newEventID(time.UnixMilli(1700000000001))
+
+	if earlier >= later {
+		t.Errorf("event IDs do not sort by time: %s >= %s", earlier, later)
+	}
+}
+
+func TestAssignEventID(t *testing.T) {
+	event := &protos.HealthEvent{}
+	AssignEventID(event)
+
+	id := event.Id
+	if id == "" {
+		t.Fatal("AssignEventID did not assign an ID")
+	}
+
+	AssignEventID(event)
+
+	if event.Id != id {
+		t.Errorf("AssignEventID replaced ID %s with %s", id, event.Id)
+	}
+
+	AssignEventID(nil)
+}
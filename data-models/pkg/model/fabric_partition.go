@@ -0,0 +1,82 @@
+// Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/nvidia/nvsentinel/data-models/pkg/protos"
+)
+
+const (
+	// EntityTypeFabricPartition is the entity type of a multi-node NVLink partition,
+	// e.g. of a GB200 NVL72 rack. The entity value is the partition ID.
+	EntityTypeFabricPartition = "FABRIC_PARTITION"
+	// MetadataFabricPartitionName is the metadata key of the name of the partition.
+	MetadataFabricPartitionName = "fabric_partition_name"
+	// MetadataFabricPartitionNodes is the metadata key of the comma separated nodes
+	// the partition spans.
+	MetadataFabricPartitionNodes = "fabric_partition_nodes"
+)
+
+// FabricPartition is an NVLink partition spanning the GPUs of several nodes. A fault
+// of the partition affects all its nodes, so it is reported with an event on every
+// node, each carrying the partition entity and the nodes of the partition.
+type FabricPartition struct {
+	ID    string
+	Name  string
+	Nodes []string
+}
+
+// Entity returns the entity of the partition.
+func (p *FabricPartition) Entity() *protos.Entity {
+	return &protos.Entity{EntityType: EntityTypeFabricPartition, EntityValue: p.ID}
+}
+
+// AddMetadata records the name and nodes of the partition.
+func (p *FabricPartition) AddMetadata(metadata map[string]string) {
+	if p.Name != "" {
+		metadata[MetadataFabricPartitionName] = p.Name
+	}
+
+	nodes := slices.Clone(p.Nodes)
+	slices.Sort(nodes)
+
+	metadata[MetadataFabricPartitionNodes] = strings.Join(nodes, ",")
+}
+
+// FabricPartitionFromEvent returns the partition an event was reported for, nil if
+// the event does not impact a partition.
+func FabricPartitionFromEvent(event *protos.HealthEvent) *FabricPartition {
+	for _, entity := range event.GetEntitiesImpacted() {
+		if entity.GetEntityType() != EntityTypeFabricPartition {
+			continue
+		}
+
+		partition := &FabricPartition{
+			ID:   entity.GetEntityValue(),
+			Name: event.GetMetadata()[MetadataFabricPartitionName],
+		}
+
+		if nodes := event.GetMetadata()[MetadataFabricPartitionNodes]; nodes != "" {
+			partition.Nodes = strings.Split(nodes, ",")
+		}
+
+		return partition
+	}
+
+	return nil
+}
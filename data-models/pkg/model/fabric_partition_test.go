@@ -0,0 +1,45 @@
+// Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/nvidia/nvsentinel/data-models/pkg/protos"
+)
+
+func TestFabricPartition(t *testing.T) {
+	partition := &FabricPartition{ID: "32766", Name: "rack-7", Nodes: []string{"node-2", "node-1"}}
+
+	event := &protos.HealthEvent{
+		EntitiesImpacted: []*protos.Entity{{EntityType: "GPU", EntityValue: "0"}, partition.Entity()},
+		Metadata:         map[string]string{},
+	}
+	partition.AddMetadata(event.Metadata)
+
+	if got := event.Metadata[MetadataFabricPartitionNodes]; got != "node-1,node-2" {
+		t.Errorf("nodes metadata = %q, want the sorted nodes", got)
+	}
+
+	want := &FabricPartition{ID: "32766", Name: "rack-7", Nodes: []string{"node-1", "node-2"}}
+	if got := FabricPartitionFromEvent(event); !reflect.DeepEqual(got, want) {
+		t.Errorf("FabricPartitionFromEvent = %+v, want %+v", got, want)
+	}
+
+	if got := FabricPartitionFromEvent(&protos.HealthEvent{}); got != nil {
+		t.Errorf("FabricPartitionFromEvent of an event without partition = %+v, want nil", got)
+	}
+}
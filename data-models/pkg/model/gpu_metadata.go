@@ -21,6 +21,19 @@ type GPUMetadata struct {
 	ChassisSerial *string   `json:"chassis_serial"`
 	GPUs          []GPUInfo `json:"gpus"`
 	NVSwitches    []string  `json:"nvswitches"`
+	// Cloud is the cloud instance of the node, nil off-cloud or when the
+	// instance metadata service is not reachable
+	Cloud *CloudInstance `json:"cloud,omitempty"`
+}
+
+// CloudInstance identifies the cloud instance of a node for cross-referencing
+// incidents with cloud support tickets.
+type CloudInstance struct {
+	Provider              string `json:"provider"`
+	InstanceID            string `json:"instance_id"`
+	InstanceType          string `json:"instance_type,omitempty"`
+	Zone                  string `json:"zone,omitempty"`
+	CapacityReservationID string `json:"capacity_reservation_id,omitempty"`
 }
 
 type GPUInfo struct {
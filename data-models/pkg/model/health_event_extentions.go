@@ -35,6 +35,19 @@ const (
 	Quarantined        Status = "Quarantined"
 	AlreadyQuarantined Status = "AlreadyQuarantined"
 	Cancelled          Status = "Cancelled"
+	// ObserveOnlyQuarantined is recorded on an event the rulesets would have
+	// quarantined the node for while enforcement is switched off for the node.
+	// Downstream modules do not watch for it, so the node is neither drained nor
+	// remediated.
+	ObserveOnlyQuarantined Status = "ObserveOnlyQuarantined"
+	// PendingApproval is recorded on the event of a cross-node incident until it
+	// is approved and all its nodes are quarantined together. Downstream modules
+	// do not watch for it.
+	PendingApproval Status = "PendingApproval"
+	// AwaitingCorroboration is recorded on a fatal event held until a composite
+	// rule of the health events analyzer corroborates it, in which case the event
+	// of the rule quarantines the node. Downstream modules do not watch for it.
+	AwaitingCorroboration Status = "AwaitingCorroboration"
 )
 
 type OperationStatus struct {
@@ -47,6 +60,16 @@ type HealthEventStatus struct {
 	UserPodsEvictionStatus   OperationStatus `bson:"userpodsevictionstatus"`
 	FaultRemediated          *bool           `bson:"faultremediated"`
 	LastRemediationTimestamp *time.Time      `bson:"lastremediationtimestamp,omitempty"`
+	// Canary is only set on canary events, see IsCanary
+	Canary *CanaryStatus `bson:"canary,omitempty"`
+	// SeverityOverride is only set on events a severity override applied to
+	SeverityOverride *SeverityOverrideRecord `bson:"severityoverride,omitempty"`
+	// Classification is only set on the events of incidents tagged through the API
+	Classification *IncidentClassification `bson:"classification,omitempty"`
+	// Runbook is only set on events fault remediation ran a runbook for
+	Runbook *RunbookRecord `bson:"runbook,omitempty"`
+	// QuarantineReason is only set on the event that quarantined the node
+	QuarantineReason *QuarantineReason `bson:"quarantinereason,omitempty"`
 }
 
 type HealthEventWithStatus struct {
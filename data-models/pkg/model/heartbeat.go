@@ -0,0 +1,84 @@
+// Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"time"
+
+	"github.com/nvidia/nvsentinel/data-models/pkg/protos"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+const (
+	// HeartbeatCheckName is the check name of the healthy event a health monitor
+	// emits periodically to report that it is running and which version it runs.
+	// Heartbeats are recorded per node and agent instead of being stored as events.
+	HeartbeatCheckName = "AgentHeartbeat"
+	// MetadataAgentVersion is the metadata key of the version of the agent.
+	MetadataAgentVersion = "agent_version"
+	// MetadataRulePack is the metadata key of the rule pack the agent selected.
+	MetadataRulePack = "rule_pack"
+	// MetadataRulePackVersion is the metadata key of the version of that rule pack.
+	MetadataRulePackVersion = "rule_pack_version"
+)
+
+// AgentHeartbeat is the last heartbeat of an agent on a node.
+type AgentHeartbeat struct {
+	NodeName        string    `bson:"nodename" json:"nodeName"`
+	Agent           string    `bson:"agent" json:"agent"`
+	Version         string    `bson:"version" json:"version"`
+	RulePack        string    `bson:"rulepack,omitempty" json:"rulePack,omitempty"`
+	RulePackVersion string    `bson:"rulepackversion,omitempty" json:"rulePackVersion,omitempty"`
+	LastSeen        time.Time `bson:"lastseen" json:"lastSeen"`
+}
+
+// NewHeartbeat returns the heartbeat event of the agent running version on the node.
+func NewHeartbeat(agent, nodeName, version string, now time.Time) *protos.HealthEvent {
+	return &protos.HealthEvent{
+		Version:            1,
+		Id:                 NewEventID(),
+		Agent:              agent,
+		CheckName:          HeartbeatCheckName,
+		IsHealthy:          true,
+		Message:            "Agent heartbeat",
+		RecommendedAction:  protos.RecommendedAction_NONE,
+		NodeName:           nodeName,
+		GeneratedTimestamp: timestamppb.New(now),
+		Metadata:           map[string]string{MetadataAgentVersion: version},
+	}
+}
+
+// IsHeartbeat returns true if the event is an agent heartbeat.
+func IsHeartbeat(event *protos.HealthEvent) bool {
+	return event != nil && event.IsHealthy && event.CheckName == HeartbeatCheckName
+}
+
+// HeartbeatFromEvent returns the heartbeat reported by the event.
+func HeartbeatFromEvent(event *protos.HealthEvent) AgentHeartbeat {
+	heartbeat := AgentHeartbeat{
+		NodeName:        event.NodeName,
+		Agent:           event.Agent,
+		Version:         event.Metadata[MetadataAgentVersion],
+		RulePack:        event.Metadata[MetadataRulePack],
+		RulePackVersion: event.Metadata[MetadataRulePackVersion],
+		LastSeen:        time.Now().UTC(),
+	}
+
+	if event.GeneratedTimestamp != nil {
+		heartbeat.LastSeen = event.GeneratedTimestamp.AsTime().UTC()
+	}
+
+	return heartbeat
+}
@@ -0,0 +1,54 @@
+// Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"testing"
+	"time"
+
+	"github.com/nvidia/nvsentinel/data-models/pkg/protos"
+)
+
+func TestHeartbeat(t *testing.T) {
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+
+	event := NewHeartbeat("syslog-health-monitor", "node-1", "v0.4.0", now)
+	if !IsHeartbeat(event) {
+		t.Fatalf("IsHeartbeat(NewHeartbeat()) = false")
+	}
+
+	event.Metadata[MetadataRulePack] = "H100"
+	event.Metadata[MetadataRulePackVersion] = "3"
+
+	want := AgentHeartbeat{
+		NodeName:        "node-1",
+		Agent:           "syslog-health-monitor",
+		Version:         "v0.4.0",
+		RulePack:        "H100",
+		RulePackVersion: "3",
+		LastSeen:        now,
+	}
+	if got := HeartbeatFromEvent(event); got != want {
+		t.Errorf("HeartbeatFromEvent = %+v, want %+v", got, want)
+	}
+
+	if IsHeartbeat(&protos.HealthEvent{CheckName: HeartbeatCheckName}) {
+		t.Errorf("an unhealthy event with the heartbeat check name is not a heartbeat")
+	}
+
+	if IsHeartbeat(nil) {
+		t.Errorf("IsHeartbeat(nil) = true")
+	}
+}
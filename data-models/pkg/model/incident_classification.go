@@ -0,0 +1,84 @@
+// Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"fmt"
+	"time"
+)
+
+// IncidentTag classifies the cause of an incident.
+type IncidentTag string
+
+const (
+	IncidentTagHardware IncidentTag = "hardware"
+	IncidentTagDriver   IncidentTag = "driver"
+	IncidentTagThermal  IncidentTag = "thermal"
+	IncidentTagNetwork  IncidentTag = "network"
+	IncidentTagUnknown  IncidentTag = "unknown"
+)
+
+// IncidentTags are the valid incident tags.
+var IncidentTags = []IncidentTag{
+	IncidentTagHardware, IncidentTagDriver, IncidentTagThermal, IncidentTagNetwork, IncidentTagUnknown,
+}
+
+// Metadata keys of the tags the health events analyzer classified the incident of an
+// event it published with, and of what classified it.
+const (
+	IncidentTagsMetadataKey      = "incident_tags"
+	IncidentTagSourceMetadataKey = "incident_tag_source"
+)
+
+// IncidentTagSourceManual is the source of the tags set through the incident API.
+const IncidentTagSourceManual = "manual"
+
+// ParseIncidentTags validates the tags.
+func ParseIncidentTags(values []string) ([]IncidentTag, error) {
+	tags := make([]IncidentTag, 0, len(values))
+
+	for _, value := range values {
+		tag := IncidentTag(value)
+
+		valid := false
+
+		for _, known := range IncidentTags {
+			if tag == known {
+				valid = true
+				break
+			}
+		}
+
+		if !valid {
+			return nil, fmt.Errorf("invalid incident tag %q, expected one of %v", value, IncidentTags)
+		}
+
+		tags = append(tags, tag)
+	}
+
+	return tags, nil
+}
+
+// IncidentClassification records on the stored events of an incident the tags an
+// operator set through the incident API, which take precedence over the tags the
+// health events analyzer classified the incident with.
+type IncidentClassification struct {
+	Tags   []IncidentTag `bson:"tags"`
+	Source string        `bson:"source"`
+	Reason string        `bson:"reason,omitempty"`
+	// SetBy is who set the tags, as given to the API
+	SetBy        string    `bson:"setby,omitempty"`
+	ClassifiedAt time.Time `bson:"classifiedat"`
+}
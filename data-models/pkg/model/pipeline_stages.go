@@ -0,0 +1,114 @@
+// Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"time"
+
+	"github.com/nvidia/nvsentinel/data-models/pkg/protos"
+)
+
+// PipelineStage identifies a point in the pipeline a health event passes through.
+// Each stage records its timestamp in the event metadata so that stage-to-stage
+// latency (e.g. fault-to-cordon time) can be measured downstream.
+type PipelineStage string
+
+const (
+	// StageLineRead is set by a health monitor to the time the source log line was
+	// logged, e.g. the time of the journal entry.
+	StageLineRead PipelineStage = "stage_line_read"
+	// StageEventEmitted is set by a health monitor right before sending the event.
+	StageEventEmitted PipelineStage = "stage_event_emitted"
+	// StageIngested is set by the platform connector when the event is received.
+	StageIngested PipelineStage = "stage_ingested"
+	// StageIncidentCreated is set by fault-quarantine when its rulesets decide to
+	// quarantine the node for the event, before any approval or cordon.
+	StageIncidentCreated PipelineStage = "stage_incident_created"
+	// StageQuarantined is observed by fault-quarantine when the node is cordoned.
+	StageQuarantined PipelineStage = "stage_quarantined"
+	// StageRemediated is observed by fault-remediation when the action is created.
+	StageRemediated PipelineStage = "stage_remediated"
+)
+
+// PipelineStages lists the pipeline stages in the order an event traverses them.
+var PipelineStages = []PipelineStage{
+	StageLineRead,
+	StageEventEmitted,
+	StageIngested,
+	StageIncidentCreated,
+	StageQuarantined,
+	StageRemediated,
+}
+
+// SetStageTimestamp records t as the timestamp of stage in the event metadata.
+func SetStageTimestamp(event *protos.HealthEvent, stage PipelineStage, t time.Time) {
+	if event == nil {
+		return
+	}
+
+	if event.Metadata == nil {
+		event.Metadata = make(map[string]string)
+	}
+
+	event.Metadata[string(stage)] = t.UTC().Format(time.RFC3339Nano)
+}
+
+// StageTimestamp returns the timestamp recorded for stage, if any.
+func StageTimestamp(event *protos.HealthEvent, stage PipelineStage) (time.Time, bool) {
+	if event == nil {
+		return time.Time{}, false
+	}
+
+	value, ok := event.Metadata[string(stage)]
+	if !ok {
+		return time.Time{}, false
+	}
+
+	t, err := time.Parse(time.RFC3339Nano, value)
+	if err != nil {
+		return time.Time{}, false
+	}
+
+	return t, true
+}
+
+// StageLatency returns the time elapsed between the from stage recorded in the
+// event and the to time. It reports false when the from stage is missing.
+func StageLatency(event *protos.HealthEvent, from PipelineStage, to time.Time) (time.Duration, bool) {
+	start, ok := StageTimestamp(event, from)
+	if !ok {
+		return 0, false
+	}
+
+	return to.Sub(start), true
+}
+
+// StageLatencies returns, for every stage preceding to that is recorded in the
+// event, the time elapsed between that stage and at.
+func StageLatencies(event *protos.HealthEvent, to PipelineStage, at time.Time) map[PipelineStage]time.Duration {
+	latencies := make(map[PipelineStage]time.Duration)
+
+	for _, from := range PipelineStages {
+		if from == to {
+			break
+		}
+
+		if latency, ok := StageLatency(event, from, at); ok {
+			latencies[from] = latency
+		}
+	}
+
+	return latencies
+}
@@ -0,0 +1,24 @@
+// Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+// Process attribution of an error, e.g. of an Xid the driver logs with the process
+// that used the GPU, so the workload can be identified from the event.
+const (
+	// MetadataPID is the metadata key of the id of the process.
+	MetadataPID = "pid"
+	// MetadataProcessName is the metadata key of the name of the process.
+	MetadataProcessName = "process"
+)
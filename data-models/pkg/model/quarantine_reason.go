@@ -0,0 +1,53 @@
+// Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import "time"
+
+// QuarantineReasonAnnotationKey is the node annotation fault quarantine records the
+// QuarantineReason of the node it quarantined in, as JSON.
+const QuarantineReasonAnnotationKey = "dgxc.nvidia.com/nvsentinel-quarantine-reason"
+
+// QuarantineReason records why fault quarantine quarantined a node. It is written
+// into the QuarantineReasonAnnotationKey annotation of the node and on the stored
+// event that quarantined it.
+type QuarantineReason struct {
+	// IncidentID is the ID of the health event that quarantined the node
+	IncidentID string   `json:"incidentId" bson:"incidentid"`
+	Agent      string   `json:"agent" bson:"agent"`
+	CheckName  string   `json:"checkName" bson:"checkname"`
+	ErrorCodes []string `json:"errorCodes,omitempty" bson:"errorcodes,omitempty"`
+	Message    string   `json:"message,omitempty" bson:"message,omitempty"`
+	// RuleSets are the rule sets the event matched, empty for forced quarantines
+	RuleSets []string `json:"ruleSets,omitempty" bson:"rulesets,omitempty"`
+	Cordoned bool     `json:"cordoned" bson:"cordoned"`
+	Taints   []string `json:"taints,omitempty" bson:"taints,omitempty"`
+	// AffectedNodes are all the nodes a cross-node incident quarantined together,
+	// empty when it quarantined a single node
+	AffectedNodes []string `json:"affectedNodes,omitempty" bson:"affectednodes,omitempty"`
+	// RunbookURL is the runbook of the first matched rule set that has one
+	RunbookURL    string    `json:"runbookUrl,omitempty" bson:"runbookurl,omitempty"`
+	QuarantinedAt time.Time `json:"quarantinedAt" bson:"quarantinedat"`
+}
+
+// IncidentID returns the ID of the incident the event opened, empty unless the
+// event quarantined its node.
+func (e *HealthEventWithStatus) IncidentID() string {
+	if e == nil || e.HealthEventStatus.QuarantineReason == nil {
+		return ""
+	}
+
+	return e.HealthEventStatus.QuarantineReason.IncidentID
+}
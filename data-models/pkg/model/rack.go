@@ -0,0 +1,25 @@
+// Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+const (
+	// EntityTypeRack is the entity type of a rack, e.g. of a liquid-cooled GB200
+	// NVL72 rack whose nodes share the coolant loop. The entity value is the chassis
+	// serial number of the rack.
+	EntityTypeRack = "RACK"
+	// MetadataChassisSerial is the metadata key of the chassis serial number of the
+	// rack of the node.
+	MetadataChassisSerial = "chassis_serial"
+)
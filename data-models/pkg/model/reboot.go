@@ -0,0 +1,45 @@
+// Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"github.com/nvidia/nvsentinel/data-models/pkg/protos"
+)
+
+const (
+	// RebootCheckName is the check name of the healthy event a health monitor emits
+	// when it detects that its node rebooted.
+	RebootCheckName = "NodeRebooted"
+	// MetadataBootID is the metadata key of the boot ID the node came up with.
+	MetadataBootID = "boot_id"
+	// MetadataPreviousBootID is the metadata key of the boot ID before the reboot.
+	MetadataPreviousBootID = "previous_boot_id"
+)
+
+// IsRebootEvent returns true if the event reports a node reboot.
+func IsRebootEvent(event *protos.HealthEvent) bool {
+	return event != nil && event.IsHealthy && event.CheckName == RebootCheckName
+}
+
+// IsRebootRequiringAction returns true if the action is resolved by rebooting the
+// node, so incidents recommending it can be closed once the node rebooted.
+func IsRebootRequiringAction(action protos.RecommendedAction) bool {
+	switch action {
+	case protos.RecommendedAction_RESTART_BM, protos.RecommendedAction_RESTART_VM:
+		return true
+	default:
+		return false
+	}
+}
@@ -0,0 +1,122 @@
+// Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"crypto/ed25519"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/binary"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/nvidia/nvsentinel/data-models/pkg/protos"
+)
+
+// rulePackBundleDomain separates bundle digests from other signed data.
+const rulePackBundleDomain = "nvsentinel-rule-pack-bundle-v1"
+
+// RulePackBundleDigest returns the SHA-256 digest of the version, schema version and
+// files of the bundle that its signature covers. Files are hashed in name order, so
+// the digest does not depend on their order in the message.
+func RulePackBundleDigest(bundle *protos.RulePackBundle) []byte {
+	files := make([]*protos.RulePackFile, len(bundle.GetFiles()))
+	copy(files, bundle.GetFiles())
+	sort.Slice(files, func(i, j int) bool { return files[i].GetName() < files[j].GetName() })
+
+	hash := sha256.New()
+
+	// Every field is length-prefixed so that no two bundles share an encoding
+	write := func(data []byte) {
+		var length [8]byte
+
+		binary.BigEndian.PutUint64(length[:], uint64(len(data)))
+		hash.Write(length[:])
+		hash.Write(data)
+	}
+
+	write([]byte(rulePackBundleDomain))
+	write([]byte(bundle.GetVersion()))
+	write(binary.BigEndian.AppendUint32(nil, bundle.GetSchemaVersion()))
+
+	for _, file := range files {
+		write([]byte(file.GetName()))
+		write(file.GetContent())
+	}
+
+	return hash.Sum(nil)
+}
+
+// SignRulePackBundle sets the signature of the bundle.
+func SignRulePackBundle(bundle *protos.RulePackBundle, key ed25519.PrivateKey) {
+	bundle.Signature = ed25519.Sign(key, RulePackBundleDigest(bundle))
+}
+
+// VerifyRulePackBundle returns an error unless the bundle is signed by key.
+func VerifyRulePackBundle(bundle *protos.RulePackBundle, key ed25519.PublicKey) error {
+	if len(bundle.GetSignature()) == 0 {
+		return errors.New("rule pack bundle is not signed")
+	}
+
+	if !ed25519.Verify(key, RulePackBundleDigest(bundle), bundle.GetSignature()) {
+		return fmt.Errorf("invalid signature of rule pack bundle %s", bundle.GetVersion())
+	}
+
+	return nil
+}
+
+// ParseRulePackSigningKey parses a PEM encoded PKCS #8 Ed25519 private key, as
+// created by "openssl genpkey -algorithm ed25519".
+func ParseRulePackSigningKey(data []byte) (ed25519.PrivateKey, error) {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, errors.New("no PEM data found")
+	}
+
+	key, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse private key: %w", err)
+	}
+
+	signingKey, ok := key.(ed25519.PrivateKey)
+	if !ok {
+		return nil, fmt.Errorf("expected an Ed25519 private key, got %T", key)
+	}
+
+	return signingKey, nil
+}
+
+// ParseRulePackPublicKey parses a PEM encoded PKIX Ed25519 public key, as created
+// by "openssl pkey -pubout".
+func ParseRulePackPublicKey(data []byte) (ed25519.PublicKey, error) {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, errors.New("no PEM data found")
+	}
+
+	key, err := x509.ParsePKIXPublicKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse public key: %w", err)
+	}
+
+	publicKey, ok := key.(ed25519.PublicKey)
+	if !ok {
+		return nil, fmt.Errorf("expected an Ed25519 public key, got %T", key)
+	}
+
+	return publicKey, nil
+}
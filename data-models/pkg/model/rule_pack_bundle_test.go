@@ -0,0 +1,92 @@
+// Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"crypto/ed25519"
+	"crypto/x509"
+	"encoding/pem"
+	"testing"
+
+	"github.com/nvidia/nvsentinel/data-models/pkg/protos"
+)
+
+func TestRulePackBundleSignature(t *testing.T) {
+	publicKey, privateKey, err := ed25519.GenerateKey(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	bundle := &protos.RulePackBundle{
+		Version:       "2",
+		SchemaVersion: 1,
+		Files: []*protos.RulePackFile{
+			{Name: "h100.toml", Content: []byte(`name = "H100"`)},
+			{Name: "gb200.toml", Content: []byte(`name = "GB200"`)},
+		},
+	}
+
+	if err := VerifyRulePackBundle(bundle, publicKey); err == nil {
+		t.Fatal("unsigned bundle verified")
+	}
+
+	SignRulePackBundle(bundle, privateKey)
+
+	if err := VerifyRulePackBundle(bundle, publicKey); err != nil {
+		t.Fatalf("VerifyRulePackBundle() = %v", err)
+	}
+
+	// the file order does not matter
+	bundle.Files[0], bundle.Files[1] = bundle.Files[1], bundle.Files[0]
+	if err := VerifyRulePackBundle(bundle, publicKey); err != nil {
+		t.Fatalf("VerifyRulePackBundle() after reordering = %v", err)
+	}
+
+	bundle.Files[0].Content = []byte(`name = "GB300"`)
+	if err := VerifyRulePackBundle(bundle, publicKey); err == nil {
+		t.Fatal("tampered bundle verified")
+	}
+}
+
+func TestParseRulePackKeys(t *testing.T) {
+	publicKey, privateKey, err := ed25519.GenerateKey(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	privateDER, err := x509.MarshalPKCS8PrivateKey(privateKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	publicDER, err := x509.MarshalPKIXPublicKey(publicKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	parsedPrivate, err := ParseRulePackSigningKey(pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: privateDER}))
+	if err != nil || !parsedPrivate.Equal(privateKey) {
+		t.Fatalf("ParseRulePackSigningKey() = %v", err)
+	}
+
+	parsedPublic, err := ParseRulePackPublicKey(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: publicDER}))
+	if err != nil || !parsedPublic.Equal(publicKey) {
+		t.Fatalf("ParseRulePackPublicKey() = %v", err)
+	}
+
+	if _, err := ParseRulePackPublicKey([]byte("not a key")); err == nil {
+		t.Fatal("ParseRulePackPublicKey() accepted invalid data")
+	}
+}
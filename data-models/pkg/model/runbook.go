@@ -0,0 +1,46 @@
+// Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import "time"
+
+// Environment variables fault remediation injects into every container of a runbook
+// Job, describing the incident the runbook runs for.
+const (
+	RunbookEnvRunbook           = "NVSENTINEL_RUNBOOK"
+	RunbookEnvNodeName          = "NVSENTINEL_NODE_NAME"
+	RunbookEnvHealthEventID     = "NVSENTINEL_HEALTH_EVENT_ID"
+	RunbookEnvAgent             = "NVSENTINEL_AGENT"
+	RunbookEnvCheckName         = "NVSENTINEL_CHECK_NAME"
+	RunbookEnvComponentClass    = "NVSENTINEL_COMPONENT_CLASS"
+	RunbookEnvErrorCodes        = "NVSENTINEL_ERROR_CODES"
+	RunbookEnvRecommendedAction = "NVSENTINEL_RECOMMENDED_ACTION"
+	RunbookEnvMessage           = "NVSENTINEL_MESSAGE"
+	RunbookEnvEntities          = "NVSENTINEL_ENTITIES"
+)
+
+// RunbookRecord records on a stored event the runbook fault remediation ran for it
+// and the output the script printed.
+type RunbookRecord struct {
+	Name string `bson:"name"`
+	Job  string `bson:"job"`
+	// Succeeded is false when the Job failed, timed out or could not be created
+	Succeeded bool `bson:"succeeded"`
+	// Output is the tail of the log of the Job pod
+	Output      string    `bson:"output,omitempty"`
+	Error       string    `bson:"error,omitempty"`
+	StartedAt   time.Time `bson:"startedat"`
+	CompletedAt time.Time `bson:"completedat"`
+}
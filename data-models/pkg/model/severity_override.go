@@ -0,0 +1,38 @@
+// Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import "time"
+
+// Severities an operator can override the severity of error codes with. Events are
+// FATAL or, downgraded, WARNING: they stay unhealthy but trigger no action.
+const (
+	SeverityFatal   = "FATAL"
+	SeverityWarning = "WARNING"
+)
+
+// SeverityOverrideRecord records on a stored event the cluster severity override the
+// health events analyzer applied to it, as an audit of the override.
+type SeverityOverrideRecord struct {
+	Name     string `bson:"name"`
+	Severity string `bson:"severity"`
+	// RecommendedAction is the action of the event the analyzer published for a
+	// FATAL override.
+	RecommendedAction         string    `bson:"recommendedaction,omitempty"`
+	Reason                    string    `bson:"reason"`
+	OriginalIsFatal           bool      `bson:"originalisfatal"`
+	OriginalRecommendedAction string    `bson:"originalrecommendedaction"`
+	AppliedAt                 time.Time `bson:"appliedat"`
+}
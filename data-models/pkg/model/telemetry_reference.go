@@ -0,0 +1,179 @@
+// Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"slices"
+	"strings"
+)
+
+// Metadata keys of the telemetry cross-reference of a log-detected fault.
+const (
+	// MetadataTelemetryConfirmation is the result of the confirmation of the fault
+	// against the DCGM health watches: confirmed, contradicted or unavailable
+	MetadataTelemetryConfirmation = "telemetry_confirmation"
+	// MetadataDCGMWatches lists the DCGM health watches that report the fault
+	MetadataDCGMWatches = "dcgm_watches"
+	// MetadataNVMLReturnCodes lists the NVML return codes of the GPU while the fault lasts
+	MetadataNVMLReturnCodes = "nvml_return_codes"
+)
+
+// TelemetryReference links the error code of a fault detected in the logs to the
+// live telemetry reporting the same fault.
+type TelemetryReference struct {
+	// DCGMWatches are the DCGM health watches that report the fault, e.g.
+	// DCGM_HEALTH_WATCH_PCIE
+	DCGMWatches []string
+	// NVMLReturnCodes are the return codes of the NVML calls on the GPU while the
+	// fault lasts, e.g. NVML_ERROR_GPU_IS_LOST
+	NVMLReturnCodes []string
+}
+
+// anyErrorCode references all the error codes of a check
+const anyErrorCode = "*"
+
+var (
+	gpuLost = TelemetryReference{
+		DCGMWatches:     []string{"DCGM_HEALTH_WATCH_PCIE"},
+		NVMLReturnCodes: []string{"NVML_ERROR_GPU_IS_LOST"},
+	}
+	memoryError = TelemetryReference{
+		DCGMWatches: []string{"DCGM_HEALTH_WATCH_MEM"},
+	}
+	memoryResetRequired = TelemetryReference{
+		DCGMWatches:     []string{"DCGM_HEALTH_WATCH_MEM"},
+		NVMLReturnCodes: []string{"NVML_ERROR_RESET_REQUIRED"},
+	}
+	gspCrash = TelemetryReference{
+		DCGMWatches:     []string{"DCGM_HEALTH_WATCH_DRIVER"},
+		NVMLReturnCodes: []string{"NVML_ERROR_GPU_IS_LOST"},
+	}
+	gspTimeout = TelemetryReference{
+		DCGMWatches:     []string{"DCGM_HEALTH_WATCH_DRIVER"},
+		NVMLReturnCodes: []string{"NVML_ERROR_TIMEOUT"},
+	}
+	nvlinkError = TelemetryReference{
+		DCGMWatches: []string{"DCGM_HEALTH_WATCH_NVLINK"},
+	}
+	nvswitchError = TelemetryReference{
+		DCGMWatches: []string{"DCGM_HEALTH_WATCH_NVSWITCH_FATAL", "DCGM_HEALTH_WATCH_NVSWITCH_NONFATAL"},
+	}
+	pcieError = TelemetryReference{
+		DCGMWatches: []string{"DCGM_HEALTH_WATCH_PCIE"},
+	}
+)
+
+// telemetryReferences holds the references by check name and error code. Faults
+// without live telemetry, e.g. application errors or driver install failures, are
+// not referenced.
+var telemetryReferences = map[string]map[string]TelemetryReference{
+	"SysLogsXIDError": {
+		"48":  memoryError,
+		"63":  memoryResetRequired,
+		"64":  memoryResetRequired,
+		"74":  nvlinkError,
+		"79":  gpuLost,
+		"92":  memoryError,
+		"94":  memoryError,
+		"95":  memoryResetRequired,
+		"119": gspTimeout,
+		"120": gspCrash,
+	},
+	"SysLogsSXIDError": {
+		anyErrorCode: nvswitchError,
+	},
+	"SysLogsGPUFallenOff": {
+		"GPU_FALLEN_OFF_BUS": gpuLost,
+	},
+	"SysLogsECCError": {
+		"ECC_DBE":           memoryError,
+		"ECC_SBE_THRESHOLD": memoryError,
+		"ROW_REMAP_PENDING": memoryResetRequired,
+		"ROW_REMAP_FAILURE": memoryResetRequired,
+	},
+	"SysLogsGSPFirmwareCrash": {
+		"GSP_FIRMWARE_CRASH": gspCrash,
+		"GSP_RPC_TIMEOUT":    gspTimeout,
+	},
+	"SysLogsPCIeAER": {
+		"PCIE_AER_UNCORRECTED_FATAL":    pcieError,
+		"PCIE_AER_UNCORRECTED_NONFATAL": pcieError,
+		"PCIE_AER_ERROR_RATE":           pcieError,
+	},
+}
+
+// TelemetryReferenceFor returns the telemetry reporting the faults of the error
+// codes of a check, merged over the error codes. It returns false when none of
+// them is referenced.
+func TelemetryReferenceFor(checkName string, errorCodes []string) (TelemetryReference, bool) {
+	references, ok := telemetryReferences[checkName]
+	if !ok {
+		return TelemetryReference{}, false
+	}
+
+	var (
+		merged TelemetryReference
+		found  bool
+	)
+
+	for _, errorCode := range errorCodes {
+		reference, ok := references[errorCode]
+		if !ok {
+			reference, ok = references[anyErrorCode]
+		}
+
+		if !ok {
+			continue
+		}
+
+		found = true
+		merged.DCGMWatches = appendMissing(merged.DCGMWatches, reference.DCGMWatches)
+		merged.NVMLReturnCodes = appendMissing(merged.NVMLReturnCodes, reference.NVMLReturnCodes)
+	}
+
+	return merged, found
+}
+
+func appendMissing(values, add []string) []string {
+	for _, value := range add {
+		if !slices.Contains(values, value) {
+			values = append(values, value)
+		}
+	}
+
+	return values
+}
+
+// DCGMWatchCheckName returns the check name of the events the GPU health monitor
+// publishes for a DCGM health watch, e.g. GpuPcieWatch for DCGM_HEALTH_WATCH_PCIE
+// and GpuNvswitchFatalWatch for DCGM_HEALTH_WATCH_NVSWITCH_FATAL.
+func DCGMWatchCheckName(watch string) string {
+	var name strings.Builder
+
+	name.WriteString("Gpu")
+
+	for _, part := range strings.Split(strings.TrimPrefix(watch, "DCGM_HEALTH_WATCH_"), "_") {
+		if part == "" {
+			continue
+		}
+
+		name.WriteString(part[:1])
+		name.WriteString(strings.ToLower(part[1:]))
+	}
+
+	name.WriteString("Watch")
+
+	return name.String()
+}
@@ -0,0 +1,96 @@
+// Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestTelemetryReferenceFor(t *testing.T) {
+	testCases := []struct {
+		name       string
+		checkName  string
+		errorCodes []string
+		found      bool
+		watches    []string
+		nvml       []string
+	}{
+		{
+			name:       "Xid 79",
+			checkName:  "SysLogsXIDError",
+			errorCodes: []string{"79"},
+			found:      true,
+			watches:    []string{"DCGM_HEALTH_WATCH_PCIE"},
+			nvml:       []string{"NVML_ERROR_GPU_IS_LOST"},
+		},
+		{
+			name:       "merged error codes",
+			checkName:  "SysLogsECCError",
+			errorCodes: []string{"ECC_DBE", "ROW_REMAP_PENDING"},
+			found:      true,
+			watches:    []string{"DCGM_HEALTH_WATCH_MEM"},
+			nvml:       []string{"NVML_ERROR_RESET_REQUIRED"},
+		},
+		{
+			name:       "any error code of the check",
+			checkName:  "SysLogsSXIDError",
+			errorCodes: []string{"24007"},
+			found:      true,
+			watches:    []string{"DCGM_HEALTH_WATCH_NVSWITCH_FATAL", "DCGM_HEALTH_WATCH_NVSWITCH_NONFATAL"},
+		},
+		{
+			name:       "error code without telemetry",
+			checkName:  "SysLogsXIDError",
+			errorCodes: []string{"13"},
+		},
+		{
+			name:       "check without telemetry",
+			checkName:  "GpuPcieWatch",
+			errorCodes: []string{"DCGM_FR_PCI_REPLAY_RATE"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			reference, found := TelemetryReferenceFor(tc.checkName, tc.errorCodes)
+			if found != tc.found {
+				t.Fatalf("TelemetryReferenceFor found = %v, want %v", found, tc.found)
+			}
+
+			if !slices.Equal(reference.DCGMWatches, tc.watches) {
+				t.Errorf("DCGMWatches = %v, want %v", reference.DCGMWatches, tc.watches)
+			}
+
+			if !slices.Equal(reference.NVMLReturnCodes, tc.nvml) {
+				t.Errorf("NVMLReturnCodes = %v, want %v", reference.NVMLReturnCodes, tc.nvml)
+			}
+		})
+	}
+}
+
+func TestDCGMWatchCheckName(t *testing.T) {
+	testCases := map[string]string{
+		"DCGM_HEALTH_WATCH_PCIE":           "GpuPcieWatch",
+		"DCGM_HEALTH_WATCH_SM":             "GpuSmWatch",
+		"DCGM_HEALTH_WATCH_NVSWITCH_FATAL": "GpuNvswitchFatalWatch",
+	}
+
+	for watch, want := range testCases {
+		if got := DCGMWatchCheckName(watch); got != want {
+			t.Errorf("DCGMWatchCheckName(%s) = %s, want %s", watch, got, want)
+		}
+	}
+}
@@ -0,0 +1,50 @@
+// Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/nvidia/nvsentinel/data-models/pkg/protos"
+)
+
+// ValidateHealthEvent returns an error when the event lacks the fields every
+// consumer of health events relies on.
+func ValidateHealthEvent(event *protos.HealthEvent) error {
+	if event == nil {
+		return errors.New("health event is empty")
+	}
+
+	var errs []error
+
+	if event.GetNodeName() == "" {
+		errs = append(errs, errors.New("nodeName is required"))
+	}
+
+	if event.GetAgent() == "" {
+		errs = append(errs, errors.New("agent is required"))
+	}
+
+	if event.GetCheckName() == "" {
+		errs = append(errs, errors.New("checkName is required"))
+	}
+
+	if err := errors.Join(errs...); err != nil {
+		return fmt.Errorf("invalid health event %s: %w", event.GetId(), err)
+	}
+
+	return nil
+}
@@ -0,0 +1,56 @@
+// Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/nvidia/nvsentinel/data-models/pkg/protos"
+)
+
+func TestValidateHealthEvent(t *testing.T) {
+	valid := func() *protos.HealthEvent {
+		return &protos.HealthEvent{Agent: "syslog-health-monitor", CheckName: "SysLogsXIDError", NodeName: "node-1"}
+	}
+
+	tests := []struct {
+		name    string
+		event   *protos.HealthEvent
+		wantErr string
+	}{
+		{name: "valid", event: valid()},
+		{name: "nil", event: nil, wantErr: "empty"},
+		{name: "no node", event: func() *protos.HealthEvent { e := valid(); e.NodeName = ""; return e }(),
+			wantErr: "nodeName"},
+		{name: "no agent", event: func() *protos.HealthEvent { e := valid(); e.Agent = ""; return e }(),
+			wantErr: "agent"},
+		{name: "no check", event: func() *protos.HealthEvent { e := valid(); e.CheckName = ""; return e }(),
+			wantErr: "checkName"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateHealthEvent(tt.event)
+
+			switch {
+			case tt.wantErr == "" && err != nil:
+				t.Fatalf("ValidateHealthEvent() = %v, want nil", err)
+			case tt.wantErr != "" && (err == nil || !strings.Contains(err.Error(), tt.wantErr)):
+				t.Fatalf("ValidateHealthEvent() = %v, want error containing %q", err, tt.wantErr)
+			}
+		})
+	}
+}
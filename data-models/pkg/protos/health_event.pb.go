@@ -46,7 +46,20 @@ const (
 	RecommendedAction_RESTART_VM      RecommendedAction = 15
 	RecommendedAction_RESTART_BM      RecommendedAction = 24
 	RecommendedAction_REPLACE_VM      RecommendedAction = 25
-	RecommendedAction_UNKNOWN         RecommendedAction = 99
+	RecommendedAction_DRIVER_RELOAD   RecommendedAction = 26
+	// The node is about to be reclaimed by the cloud provider (spot or
+	// preemptible capacity): drain it right away, do not remediate it.
+	RecommendedAction_PREEMPTION_IMMINENT RecommendedAction = 27
+	// A recoverable fault of MIG instances: disable MIG and recreate the
+	// instances of the configured MIG layout.
+	RecommendedAction_MIG_RECONFIGURE RecommendedAction = 28
+	// A fault endangering the hardware or the facility, e.g. a coolant leak:
+	// power the node off right away.
+	RecommendedAction_POWER_OFF RecommendedAction = 29
+	// A sustained thermal fault: cap the power of the GPUs until the thermals
+	// are back to normal, without draining the node.
+	RecommendedAction_POWER_CAP RecommendedAction = 30
+	RecommendedAction_UNKNOWN   RecommendedAction = 99
 )
 
 // Enum value maps for RecommendedAction.
@@ -58,16 +71,26 @@ var (
 		15: "RESTART_VM",
 		24: "RESTART_BM",
 		25: "REPLACE_VM",
+		26: "DRIVER_RELOAD",
+		27: "PREEMPTION_IMMINENT",
+		28: "MIG_RECONFIGURE",
+		29: "POWER_OFF",
+		30: "POWER_CAP",
 		99: "UNKNOWN",
 	}
 	RecommendedAction_value = map[string]int32{
-		"NONE":            0,
-		"COMPONENT_RESET": 2,
-		"CONTACT_SUPPORT": 5,
-		"RESTART_VM":      15,
-		"RESTART_BM":      24,
-		"REPLACE_VM":      25,
-		"UNKNOWN":         99,
+		"NONE":                0,
+		"COMPONENT_RESET":     2,
+		"CONTACT_SUPPORT":     5,
+		"RESTART_VM":          15,
+		"RESTART_BM":          24,
+		"REPLACE_VM":          25,
+		"DRIVER_RELOAD":       26,
+		"PREEMPTION_IMMINENT": 27,
+		"MIG_RECONFIGURE":     28,
+		"POWER_OFF":           29,
+		"POWER_CAP":           30,
+		"UNKNOWN":             99,
 	}
 )
 
@@ -98,6 +121,54 @@ func (RecommendedAction) EnumDescriptor() ([]byte, []int) {
 	return file_health_event_proto_rawDescGZIP(), []int{0}
 }
 
+// Delivery priority of a health event. HIGH events bypass queued NORMAL
+// traffic in health monitors and in the platform connector.
+type Priority int32
+
+const (
+	Priority_PRIORITY_NORMAL Priority = 0
+	Priority_PRIORITY_HIGH   Priority = 1
+)
+
+// Enum value maps for Priority.
+var (
+	Priority_name = map[int32]string{
+		0: "PRIORITY_NORMAL",
+		1: "PRIORITY_HIGH",
+	}
+	Priority_value = map[string]int32{
+		"PRIORITY_NORMAL": 0,
+		"PRIORITY_HIGH":   1,
+	}
+)
+
+func (x Priority) Enum() *Priority {
+	p := new(Priority)
+	*p = x
+	return p
+}
+
+func (x Priority) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (Priority) Descriptor() protoreflect.EnumDescriptor {
+	return file_health_event_proto_enumTypes[1].Descriptor()
+}
+
+func (Priority) Type() protoreflect.EnumType {
+	return &file_health_event_proto_enumTypes[1]
+}
+
+func (x Priority) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use Priority.Descriptor instead.
+func (Priority) EnumDescriptor() ([]byte, []int) {
+	return file_health_event_proto_rawDescGZIP(), []int{1}
+}
+
 type HealthEvents struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Version       uint32                 `protobuf:"varint,1,opt,name=version,proto3" json:"version,omitempty"`
@@ -219,8 +290,23 @@ type HealthEvent struct {
 	NodeName            string                 `protobuf:"bytes,13,opt,name=nodeName,proto3" json:"nodeName,omitempty"`
 	QuarantineOverrides *BehaviourOverrides    `protobuf:"bytes,14,opt,name=quarantineOverrides,proto3" json:"quarantineOverrides,omitempty"`
 	DrainOverrides      *BehaviourOverrides    `protobuf:"bytes,15,opt,name=drainOverrides,proto3" json:"drainOverrides,omitempty"`
-	unknownFields       protoimpl.UnknownFields
-	sizeCache           protoimpl.SizeCache
+	Priority            Priority               `protobuf:"varint,16,opt,name=priority,proto3,enum=datamodels.Priority" json:"priority,omitempty"`
+	// Relationships to other stored events, referenced by their store IDs, so
+	// consumers can reconstruct causality chains. Set by the analyzer on the
+	// events it publishes, empty on events from health monitors.
+	//
+	// causedById is the event that triggered this one.
+	CausedById string `protobuf:"bytes,17,opt,name=causedById,proto3" json:"causedById,omitempty"`
+	// supersedesId is the earlier event of the same incident that this one
+	// replaces, e.g. a repeated rule match or the closing healthy event.
+	SupersedesId string `protobuf:"bytes,18,opt,name=supersedesId,proto3" json:"supersedesId,omitempty"`
+	// correlationId is shared by all events of one incident.
+	CorrelationId string `protobuf:"bytes,19,opt,name=correlationId,proto3" json:"correlationId,omitempty"`
+	// Globally unique ID of the event, a ULID assigned by the agent when it
+	// creates the event. Retries of an event keep its ID.
+	Id            string `protobuf:"bytes,20,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
 func (x *HealthEvent) Reset() {
@@ -358,6 +444,41 @@ func (x *HealthEvent) GetDrainOverrides() *BehaviourOverrides {
 	return nil
 }
 
+func (x *HealthEvent) GetPriority() Priority {
+	if x != nil {
+		return x.Priority
+	}
+	return Priority_PRIORITY_NORMAL
+}
+
+func (x *HealthEvent) GetCausedById() string {
+	if x != nil {
+		return x.CausedById
+	}
+	return ""
+}
+
+func (x *HealthEvent) GetSupersedesId() string {
+	if x != nil {
+		return x.SupersedesId
+	}
+	return ""
+}
+
+func (x *HealthEvent) GetCorrelationId() string {
+	if x != nil {
+		return x.CorrelationId
+	}
+	return ""
+}
+
+func (x *HealthEvent) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
 type BehaviourOverrides struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Force         bool                   `protobuf:"varint,1,opt,name=force,proto3" json:"force,omitempty"`
@@ -423,7 +544,7 @@ const file_health_event_proto_rawDesc = "" +
 	"\n" +
 	"entityType\x18\x01 \x01(\tR\n" +
 	"entityType\x12 \n" +
-	"\ventityValue\x18\x02 \x01(\tR\ventityValue\"\x82\x06\n" +
+	"\ventityValue\x18\x02 \x01(\tR\ventityValue\"\xae\a\n" +
 	"\vHealthEvent\x12\x18\n" +
 	"\aversion\x18\x01 \x01(\rR\aversion\x12\x14\n" +
 	"\x05agent\x18\x02 \x01(\tR\x05agent\x12&\n" +
@@ -440,13 +561,20 @@ const file_health_event_proto_rawDesc = "" +
 	"\x12generatedTimestamp\x18\f \x01(\v2\x1a.google.protobuf.TimestampR\x12generatedTimestamp\x12\x1a\n" +
 	"\bnodeName\x18\r \x01(\tR\bnodeName\x12P\n" +
 	"\x13quarantineOverrides\x18\x0e \x01(\v2\x1e.datamodels.BehaviourOverridesR\x13quarantineOverrides\x12F\n" +
-	"\x0edrainOverrides\x18\x0f \x01(\v2\x1e.datamodels.BehaviourOverridesR\x0edrainOverrides\x1a;\n" +
+	"\x0edrainOverrides\x18\x0f \x01(\v2\x1e.datamodels.BehaviourOverridesR\x0edrainOverrides\x120\n" +
+	"\bpriority\x18\x10 \x01(\x0e2\x14.datamodels.PriorityR\bpriority\x12\x1e\n" +
+	"\n" +
+	"causedById\x18\x11 \x01(\tR\n" +
+	"causedById\x12\"\n" +
+	"\fsupersedesId\x18\x12 \x01(\tR\fsupersedesId\x12$\n" +
+	"\rcorrelationId\x18\x13 \x01(\tR\rcorrelationId\x12\x0e\n" +
+	"\x02id\x18\x14 \x01(\tR\x02id\x1a;\n" +
 	"\rMetadataEntry\x12\x10\n" +
 	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
 	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\">\n" +
 	"\x12BehaviourOverrides\x12\x14\n" +
 	"\x05force\x18\x01 \x01(\bR\x05force\x12\x12\n" +
-	"\x04skip\x18\x02 \x01(\bR\x04skip*\x84\x01\n" +
+	"\x04skip\x18\x02 \x01(\bR\x04skip*\xe3\x01\n" +
 	"\x11RecommendedAction\x12\b\n" +
 	"\x04NONE\x10\x00\x12\x13\n" +
 	"\x0fCOMPONENT_RESET\x10\x02\x12\x13\n" +
@@ -456,8 +584,16 @@ const file_health_event_proto_rawDesc = "" +
 	"\n" +
 	"RESTART_BM\x10\x18\x12\x0e\n" +
 	"\n" +
-	"REPLACE_VM\x10\x19\x12\v\n" +
-	"\aUNKNOWN\x10c2`\n" +
+	"REPLACE_VM\x10\x19\x12\x11\n" +
+	"\rDRIVER_RELOAD\x10\x1a\x12\x17\n" +
+	"\x13PREEMPTION_IMMINENT\x10\x1b\x12\x13\n" +
+	"\x0fMIG_RECONFIGURE\x10\x1c\x12\r\n" +
+	"\tPOWER_OFF\x10\x1d\x12\r\n" +
+	"\tPOWER_CAP\x10\x1e\x12\v\n" +
+	"\aUNKNOWN\x10c*2\n" +
+	"\bPriority\x12\x13\n" +
+	"\x0fPRIORITY_NORMAL\x10\x00\x12\x11\n" +
+	"\rPRIORITY_HIGH\x10\x012`\n" +
 	"\x11PlatformConnector\x12K\n" +
 	"\x15HealthEventOccurredV1\x12\x18.datamodels.HealthEvents\x1a\x16.google.protobuf.Empty\"\x00B5Z3github.com/nvidia/nvsentinel/data-models/pkg/protosb\x06proto3"
 
@@ -473,33 +609,35 @@ func file_health_event_proto_rawDescGZIP() []byte {
 	return file_health_event_proto_rawDescData
 }
 
-var file_health_event_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_health_event_proto_enumTypes = make([]protoimpl.EnumInfo, 2)
 var file_health_event_proto_msgTypes = make([]protoimpl.MessageInfo, 5)
 var file_health_event_proto_goTypes = []any{
 	(RecommendedAction)(0),        // 0: datamodels.RecommendedAction
-	(*HealthEvents)(nil),          // 1: datamodels.HealthEvents
-	(*Entity)(nil),                // 2: datamodels.Entity
-	(*HealthEvent)(nil),           // 3: datamodels.HealthEvent
-	(*BehaviourOverrides)(nil),    // 4: datamodels.BehaviourOverrides
-	nil,                           // 5: datamodels.HealthEvent.MetadataEntry
-	(*timestamppb.Timestamp)(nil), // 6: google.protobuf.Timestamp
-	(*emptypb.Empty)(nil),         // 7: google.protobuf.Empty
+	(Priority)(0),                 // 1: datamodels.Priority
+	(*HealthEvents)(nil),          // 2: datamodels.HealthEvents
+	(*Entity)(nil),                // 3: datamodels.Entity
+	(*HealthEvent)(nil),           // 4: datamodels.HealthEvent
+	(*BehaviourOverrides)(nil),    // 5: datamodels.BehaviourOverrides
+	nil,                           // 6: datamodels.HealthEvent.MetadataEntry
+	(*timestamppb.Timestamp)(nil), // 7: google.protobuf.Timestamp
+	(*emptypb.Empty)(nil),         // 8: google.protobuf.Empty
 }
 var file_health_event_proto_depIdxs = []int32{
-	3, // 0: datamodels.HealthEvents.events:type_name -> datamodels.HealthEvent
+	4, // 0: datamodels.HealthEvents.events:type_name -> datamodels.HealthEvent
 	0, // 1: datamodels.HealthEvent.recommendedAction:type_name -> datamodels.RecommendedAction
-	2, // 2: datamodels.HealthEvent.entitiesImpacted:type_name -> datamodels.Entity
-	5, // 3: datamodels.HealthEvent.metadata:type_name -> datamodels.HealthEvent.MetadataEntry
-	6, // 4: datamodels.HealthEvent.generatedTimestamp:type_name -> google.protobuf.Timestamp
-	4, // 5: datamodels.HealthEvent.quarantineOverrides:type_name -> datamodels.BehaviourOverrides
-	4, // 6: datamodels.HealthEvent.drainOverrides:type_name -> datamodels.BehaviourOverrides
-	1, // 7: datamodels.PlatformConnector.HealthEventOccurredV1:input_type -> datamodels.HealthEvents
-	7, // 8: datamodels.PlatformConnector.HealthEventOccurredV1:output_type -> google.protobuf.Empty
-	8, // [8:9] is the sub-list for method output_type
-	7, // [7:8] is the sub-list for method input_type
-	7, // [7:7] is the sub-list for extension type_name
-	7, // [7:7] is the sub-list for extension extendee
-	0, // [0:7] is the sub-list for field type_name
+	3, // 2: datamodels.HealthEvent.entitiesImpacted:type_name -> datamodels.Entity
+	6, // 3: datamodels.HealthEvent.metadata:type_name -> datamodels.HealthEvent.MetadataEntry
+	7, // 4: datamodels.HealthEvent.generatedTimestamp:type_name -> google.protobuf.Timestamp
+	5, // 5: datamodels.HealthEvent.quarantineOverrides:type_name -> datamodels.BehaviourOverrides
+	5, // 6: datamodels.HealthEvent.drainOverrides:type_name -> datamodels.BehaviourOverrides
+	1, // 7: datamodels.HealthEvent.priority:type_name -> datamodels.Priority
+	2, // 8: datamodels.PlatformConnector.HealthEventOccurredV1:input_type -> datamodels.HealthEvents
+	8, // 9: datamodels.PlatformConnector.HealthEventOccurredV1:output_type -> google.protobuf.Empty
+	9, // [9:10] is the sub-list for method output_type
+	8, // [8:9] is the sub-list for method input_type
+	8, // [8:8] is the sub-list for extension type_name
+	8, // [8:8] is the sub-list for extension extendee
+	0, // [0:8] is the sub-list for field type_name
 }
 
 func init() { file_health_event_proto_init() }
@@ -512,7 +650,7 @@ func file_health_event_proto_init() {
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_health_event_proto_rawDesc), len(file_health_event_proto_rawDesc)),
-			NumEnums:      1,
+			NumEnums:      2,
 			NumMessages:   5,
 			NumExtensions: 0,
 			NumServices:   1,
@@ -0,0 +1,372 @@
+// Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.10
+// 	protoc        v6.33.0
+// source: rule_pack.proto
+
+package protos
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type RulePackBundleRequest struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	NodeName string                 `protobuf:"bytes,1,opt,name=node_name,json=nodeName,proto3" json:"node_name,omitempty"`
+	Agent    string                 `protobuf:"bytes,2,opt,name=agent,proto3" json:"agent,omitempty"`
+	// Version of the agent; bundles requiring a newer agent are not served.
+	AgentVersion string `protobuf:"bytes,3,opt,name=agent_version,json=agentVersion,proto3" json:"agent_version,omitempty"`
+	// Highest bundle schema version the agent understands.
+	MaxSchemaVersion uint32 `protobuf:"varint,4,opt,name=max_schema_version,json=maxSchemaVersion,proto3" json:"max_schema_version,omitempty"`
+	// Version of the bundle the agent currently applies, empty if none.
+	CurrentBundleVersion string `protobuf:"bytes,5,opt,name=current_bundle_version,json=currentBundleVersion,proto3" json:"current_bundle_version,omitempty"`
+	unknownFields        protoimpl.UnknownFields
+	sizeCache            protoimpl.SizeCache
+}
+
+func (x *RulePackBundleRequest) Reset() {
+	*x = RulePackBundleRequest{}
+	mi := &file_rule_pack_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RulePackBundleRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RulePackBundleRequest) ProtoMessage() {}
+
+func (x *RulePackBundleRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_rule_pack_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RulePackBundleRequest.ProtoReflect.Descriptor instead.
+func (*RulePackBundleRequest) Descriptor() ([]byte, []int) {
+	return file_rule_pack_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *RulePackBundleRequest) GetNodeName() string {
+	if x != nil {
+		return x.NodeName
+	}
+	return ""
+}
+
+func (x *RulePackBundleRequest) GetAgent() string {
+	if x != nil {
+		return x.Agent
+	}
+	return ""
+}
+
+func (x *RulePackBundleRequest) GetAgentVersion() string {
+	if x != nil {
+		return x.AgentVersion
+	}
+	return ""
+}
+
+func (x *RulePackBundleRequest) GetMaxSchemaVersion() uint32 {
+	if x != nil {
+		return x.MaxSchemaVersion
+	}
+	return 0
+}
+
+func (x *RulePackBundleRequest) GetCurrentBundleVersion() string {
+	if x != nil {
+		return x.CurrentBundleVersion
+	}
+	return ""
+}
+
+type RulePackBundleResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// True when the agent already applies the bundle it should use.
+	NotModified bool `protobuf:"varint,1,opt,name=not_modified,json=notModified,proto3" json:"not_modified,omitempty"`
+	// The bundle the agent should apply, unset when not_modified is true or when
+	// no bundle is rolled out to the node; agents then apply their local packs.
+	Bundle        *RulePackBundle `protobuf:"bytes,2,opt,name=bundle,proto3" json:"bundle,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RulePackBundleResponse) Reset() {
+	*x = RulePackBundleResponse{}
+	mi := &file_rule_pack_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RulePackBundleResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RulePackBundleResponse) ProtoMessage() {}
+
+func (x *RulePackBundleResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_rule_pack_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RulePackBundleResponse.ProtoReflect.Descriptor instead.
+func (*RulePackBundleResponse) Descriptor() ([]byte, []int) {
+	return file_rule_pack_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *RulePackBundleResponse) GetNotModified() bool {
+	if x != nil {
+		return x.NotModified
+	}
+	return false
+}
+
+func (x *RulePackBundleResponse) GetBundle() *RulePackBundle {
+	if x != nil {
+		return x.Bundle
+	}
+	return nil
+}
+
+type RulePackBundle struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Version       string                 `protobuf:"bytes,1,opt,name=version,proto3" json:"version,omitempty"`
+	SchemaVersion uint32                 `protobuf:"varint,2,opt,name=schema_version,json=schemaVersion,proto3" json:"schema_version,omitempty"`
+	Files         []*RulePackFile        `protobuf:"bytes,3,rep,name=files,proto3" json:"files,omitempty"`
+	// Ed25519 signature of the bundle digest, see model.RulePackBundleDigest.
+	Signature     []byte `protobuf:"bytes,4,opt,name=signature,proto3" json:"signature,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RulePackBundle) Reset() {
+	*x = RulePackBundle{}
+	mi := &file_rule_pack_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RulePackBundle) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RulePackBundle) ProtoMessage() {}
+
+func (x *RulePackBundle) ProtoReflect() protoreflect.Message {
+	mi := &file_rule_pack_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RulePackBundle.ProtoReflect.Descriptor instead.
+func (*RulePackBundle) Descriptor() ([]byte, []int) {
+	return file_rule_pack_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *RulePackBundle) GetVersion() string {
+	if x != nil {
+		return x.Version
+	}
+	return ""
+}
+
+func (x *RulePackBundle) GetSchemaVersion() uint32 {
+	if x != nil {
+		return x.SchemaVersion
+	}
+	return 0
+}
+
+func (x *RulePackBundle) GetFiles() []*RulePackFile {
+	if x != nil {
+		return x.Files
+	}
+	return nil
+}
+
+func (x *RulePackBundle) GetSignature() []byte {
+	if x != nil {
+		return x.Signature
+	}
+	return nil
+}
+
+type RulePackFile struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Content       []byte                 `protobuf:"bytes,2,opt,name=content,proto3" json:"content,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RulePackFile) Reset() {
+	*x = RulePackFile{}
+	mi := &file_rule_pack_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RulePackFile) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RulePackFile) ProtoMessage() {}
+
+func (x *RulePackFile) ProtoReflect() protoreflect.Message {
+	mi := &file_rule_pack_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RulePackFile.ProtoReflect.Descriptor instead.
+func (*RulePackFile) Descriptor() ([]byte, []int) {
+	return file_rule_pack_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *RulePackFile) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *RulePackFile) GetContent() []byte {
+	if x != nil {
+		return x.Content
+	}
+	return nil
+}
+
+var File_rule_pack_proto protoreflect.FileDescriptor
+
+const file_rule_pack_proto_rawDesc = "" +
+	"\n" +
+	"\x0frule_pack.proto\x12\n" +
+	"datamodels\"\xd3\x01\n" +
+	"\x15RulePackBundleRequest\x12\x1b\n" +
+	"\tnode_name\x18\x01 \x01(\tR\bnodeName\x12\x14\n" +
+	"\x05agent\x18\x02 \x01(\tR\x05agent\x12#\n" +
+	"\ragent_version\x18\x03 \x01(\tR\fagentVersion\x12,\n" +
+	"\x12max_schema_version\x18\x04 \x01(\rR\x10maxSchemaVersion\x124\n" +
+	"\x16current_bundle_version\x18\x05 \x01(\tR\x14currentBundleVersion\"o\n" +
+	"\x16RulePackBundleResponse\x12!\n" +
+	"\fnot_modified\x18\x01 \x01(\bR\vnotModified\x122\n" +
+	"\x06bundle\x18\x02 \x01(\v2\x1a.datamodels.RulePackBundleR\x06bundle\"\x9f\x01\n" +
+	"\x0eRulePackBundle\x12\x18\n" +
+	"\aversion\x18\x01 \x01(\tR\aversion\x12%\n" +
+	"\x0eschema_version\x18\x02 \x01(\rR\rschemaVersion\x12.\n" +
+	"\x05files\x18\x03 \x03(\v2\x18.datamodels.RulePackFileR\x05files\x12\x1c\n" +
+	"\tsignature\x18\x04 \x01(\fR\tsignature\"<\n" +
+	"\fRulePackFile\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x18\n" +
+	"\acontent\x18\x02 \x01(\fR\acontent2s\n" +
+	"\x13RulePackDistributor\x12\\\n" +
+	"\x11GetRulePackBundle\x12!.datamodels.RulePackBundleRequest\x1a\".datamodels.RulePackBundleResponse\"\x00B5Z3github.com/nvidia/nvsentinel/data-models/pkg/protosb\x06proto3"
+
+var (
+	file_rule_pack_proto_rawDescOnce sync.Once
+	file_rule_pack_proto_rawDescData []byte
+)
+
+func file_rule_pack_proto_rawDescGZIP() []byte {
+	file_rule_pack_proto_rawDescOnce.Do(func() {
+		file_rule_pack_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_rule_pack_proto_rawDesc), len(file_rule_pack_proto_rawDesc)))
+	})
+	return file_rule_pack_proto_rawDescData
+}
+
+var file_rule_pack_proto_msgTypes = make([]protoimpl.MessageInfo, 4)
+var file_rule_pack_proto_goTypes = []any{
+	(*RulePackBundleRequest)(nil),  // 0: datamodels.RulePackBundleRequest
+	(*RulePackBundleResponse)(nil), // 1: datamodels.RulePackBundleResponse
+	(*RulePackBundle)(nil),         // 2: datamodels.RulePackBundle
+	(*RulePackFile)(nil),           // 3: datamodels.RulePackFile
+}
+var file_rule_pack_proto_depIdxs = []int32{
+	2, // 0: datamodels.RulePackBundleResponse.bundle:type_name -> datamodels.RulePackBundle
+	3, // 1: datamodels.RulePackBundle.files:type_name -> datamodels.RulePackFile
+	0, // 2: datamodels.RulePackDistributor.GetRulePackBundle:input_type -> datamodels.RulePackBundleRequest
+	1, // 3: datamodels.RulePackDistributor.GetRulePackBundle:output_type -> datamodels.RulePackBundleResponse
+	3, // [3:4] is the sub-list for method output_type
+	2, // [2:3] is the sub-list for method input_type
+	2, // [2:2] is the sub-list for extension type_name
+	2, // [2:2] is the sub-list for extension extendee
+	0, // [0:2] is the sub-list for field type_name
+}
+
+func init() { file_rule_pack_proto_init() }
+func file_rule_pack_proto_init() {
+	if File_rule_pack_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_rule_pack_proto_rawDesc), len(file_rule_pack_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   4,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_rule_pack_proto_goTypes,
+		DependencyIndexes: file_rule_pack_proto_depIdxs,
+		MessageInfos:      file_rule_pack_proto_msgTypes,
+	}.Build()
+	File_rule_pack_proto = out.File
+	file_rule_pack_proto_goTypes = nil
+	file_rule_pack_proto_depIdxs = nil
+}
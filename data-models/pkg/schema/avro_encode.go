@@ -0,0 +1,159 @@
+// Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package schema
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math"
+	"slices"
+
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/nvidia/nvsentinel/data-models/pkg/protos"
+)
+
+// EncodeAvro encodes the event in the Avro binary encoding of the schema
+// returned by Avro.
+func EncodeAvro(event *protos.HealthEvent) ([]byte, error) {
+	return appendAvroRecord(nil, event.ProtoReflect())
+}
+
+func appendAvroRecord(buf []byte, msg protoreflect.Message) ([]byte, error) {
+	fields := msg.Descriptor().Fields()
+
+	var err error
+
+	for i := range fields.Len() {
+		if buf, err = appendAvroField(buf, msg, fields.Get(i)); err != nil {
+			return nil, fmt.Errorf("field %s: %w", fields.Get(i).Name(), err)
+		}
+	}
+
+	return buf, nil
+}
+
+func appendAvroField(buf []byte, msg protoreflect.Message, fd protoreflect.FieldDescriptor) ([]byte, error) {
+	value := msg.Get(fd)
+
+	switch {
+	case fd.IsMap():
+		return appendAvroMap(buf, value.Map(), fd.MapValue())
+	case fd.IsList():
+		return appendAvroList(buf, value.List(), fd)
+	case fd.Kind() == protoreflect.MessageKind:
+		// Nullable union: branch 0 is null, branch 1 the message
+		if !msg.Has(fd) {
+			return binary.AppendVarint(buf, 0), nil
+		}
+
+		return appendAvroValue(binary.AppendVarint(buf, 1), value, fd)
+	default:
+		return appendAvroValue(buf, value, fd)
+	}
+}
+
+func appendAvroList(buf []byte, list protoreflect.List, fd protoreflect.FieldDescriptor) ([]byte, error) {
+	var err error
+
+	if list.Len() > 0 {
+		buf = binary.AppendVarint(buf, int64(list.Len()))
+
+		for i := range list.Len() {
+			if buf, err = appendAvroValue(buf, list.Get(i), fd); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	return binary.AppendVarint(buf, 0), nil
+}
+
+func appendAvroMap(buf []byte, m protoreflect.Map, fd protoreflect.FieldDescriptor) ([]byte, error) {
+	if m.Len() > 0 {
+		// Sort the keys so that equal events have equal encodings
+		keys := make([]string, 0, m.Len())
+
+		m.Range(func(key protoreflect.MapKey, _ protoreflect.Value) bool {
+			keys = append(keys, key.String())
+			return true
+		})
+		slices.Sort(keys)
+
+		buf = binary.AppendVarint(buf, int64(len(keys)))
+
+		var err error
+
+		for _, key := range keys {
+			buf = appendAvroString(buf, key)
+
+			value := m.Get(protoreflect.ValueOfString(key).MapKey())
+			if buf, err = appendAvroValue(buf, value, fd); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	return binary.AppendVarint(buf, 0), nil
+}
+
+func appendAvroValue(buf []byte, value protoreflect.Value, fd protoreflect.FieldDescriptor) ([]byte, error) {
+	switch fd.Kind() {
+	case protoreflect.BoolKind:
+		if value.Bool() {
+			return append(buf, 1), nil
+		}
+
+		return append(buf, 0), nil
+	case protoreflect.StringKind:
+		return appendAvroString(buf, value.String()), nil
+	case protoreflect.BytesKind:
+		return append(binary.AppendVarint(buf, int64(len(value.Bytes()))), value.Bytes()...), nil
+	case protoreflect.Int32Kind, protoreflect.Sint32Kind, protoreflect.Sfixed32Kind,
+		protoreflect.Int64Kind, protoreflect.Sint64Kind, protoreflect.Sfixed64Kind:
+		return binary.AppendVarint(buf, value.Int()), nil
+	case protoreflect.Uint32Kind, protoreflect.Fixed32Kind, protoreflect.Uint64Kind, protoreflect.Fixed64Kind:
+		if value.Uint() > math.MaxInt64 {
+			return nil, fmt.Errorf("value %d overflows avro long", value.Uint())
+		}
+
+		return binary.AppendVarint(buf, int64(value.Uint())), nil
+	case protoreflect.FloatKind:
+		return binary.LittleEndian.AppendUint32(buf, math.Float32bits(float32(value.Float()))), nil
+	case protoreflect.DoubleKind:
+		return binary.LittleEndian.AppendUint64(buf, math.Float64bits(value.Float())), nil
+	case protoreflect.EnumKind:
+		// Avro enums are encoded by symbol position; unknown values map to the default
+		index := 0
+		if ev := fd.Enum().Values().ByNumber(value.Enum()); ev != nil {
+			index = ev.Index()
+		}
+
+		return binary.AppendVarint(buf, int64(index)), nil
+	case protoreflect.MessageKind, protoreflect.GroupKind:
+		if ts, ok := value.Message().Interface().(*timestamppb.Timestamp); ok {
+			return binary.AppendVarint(buf, ts.AsTime().UnixMicro()), nil
+		}
+
+		return appendAvroRecord(buf, value.Message())
+	default:
+		return nil, fmt.Errorf("unsupported field kind %s", fd.Kind())
+	}
+}
+
+func appendAvroString(buf []byte, s string) []byte {
+	return append(binary.AppendVarint(buf, int64(len(s))), s...)
+}
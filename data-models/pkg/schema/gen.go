@@ -0,0 +1,44 @@
+// Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build ignore
+
+// gen writes the HealthEvent Avro and JSON-Schema files next to the schema
+// package. Run it with `go generate ./pkg/schema/...`.
+package main
+
+import (
+	"log"
+	"os"
+
+	"github.com/nvidia/nvsentinel/data-models/pkg/schema"
+)
+
+func main() {
+	generators := map[string]func() ([]byte, error){
+		schema.AvroFile:       schema.Avro,
+		schema.JSONSchemaFile: schema.JSONSchema,
+	}
+
+	for file, generate := range generators {
+		data, err := generate()
+		if err != nil {
+			log.Fatalf("failed to generate %s: %v", file, err)
+		}
+
+		if err := os.WriteFile(file, data, 0o600); err != nil {
+			log.Fatalf("failed to write %s: %v", file, err)
+		}
+	}
+}
@@ -0,0 +1,84 @@
+// Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package schema
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+)
+
+const (
+	// PathPrefix is the path the registry handler is mounted on.
+	PathPrefix = "/schemas/"
+
+	avroContentType       = "application/vnd.apache.avro+json"
+	jsonSchemaContentType = "application/schema+json"
+)
+
+// entry is a schema published by the registry.
+type entry struct {
+	Name        string `json:"name"`
+	Format      string `json:"format"`
+	Path        string `json:"path"`
+	contentType string
+	data        []byte
+}
+
+// Handler serves the HealthEvent schemas:
+//
+//	GET /schemas/                       lists the published schemas
+//	GET /schemas/healthevent/avro       Avro schema
+//	GET /schemas/healthevent/jsonschema JSON-Schema
+func Handler() (http.Handler, error) {
+	avro, err := Avro()
+	if err != nil {
+		return nil, err
+	}
+
+	jsonSchema, err := JSONSchema()
+	if err != nil {
+		return nil, err
+	}
+
+	entries := []entry{
+		{Name: "healthevent", Format: "avro", contentType: avroContentType, data: avro},
+		{Name: "healthevent", Format: "jsonschema", contentType: jsonSchemaContentType, data: jsonSchema},
+	}
+
+	mux := http.NewServeMux()
+
+	for i := range entries {
+		e := &entries[i]
+		e.Path = fmt.Sprintf("%s%s/%s", PathPrefix, e.Name, e.Format)
+
+		mux.HandleFunc("GET "+e.Path, func(w http.ResponseWriter, _ *http.Request) {
+			w.Header().Set("Content-Type", e.contentType)
+			_, _ = w.Write(e.data)
+		})
+	}
+
+	index, err := json.Marshal(entries)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal schema index: %w", err)
+	}
+
+	mux.HandleFunc("GET "+PathPrefix+"{$}", func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write(index)
+	})
+
+	return mux, nil
+}
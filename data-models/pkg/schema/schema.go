@@ -0,0 +1,275 @@
+// Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package schema derives Avro and JSON-Schema representations of HealthEvent
+// from its protobuf descriptor, so consumers outside Go never maintain schemas
+// by hand. The JSON-Schema describes the protojson encoding of the event. The
+// checked in healthevent.avsc and healthevent.schema.json files are regenerated
+// with `go generate` whenever health_event.proto changes.
+package schema
+
+//go:generate go run gen.go
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/nvidia/nvsentinel/data-models/pkg/protos"
+)
+
+const (
+	// AvroFile is the name of the generated Avro schema file.
+	AvroFile = "healthevent.avsc"
+	// JSONSchemaFile is the name of the generated JSON-Schema file.
+	JSONSchemaFile = "healthevent.schema.json"
+
+	jsonSchemaDraft = "https://json-schema.org/draft/2020-12/schema"
+	jsonSchemaID    = "https://nvidia.github.io/nvsentinel/schemas/healthevent.schema.json"
+)
+
+var timestampName = (&timestamppb.Timestamp{}).ProtoReflect().Descriptor().FullName()
+
+// healthEventDescriptor returns the descriptor the schemas are derived from.
+func healthEventDescriptor() protoreflect.MessageDescriptor {
+	return (&protos.HealthEvent{}).ProtoReflect().Descriptor()
+}
+
+// Avro returns the Avro schema of HealthEvent. Message fields are nullable,
+// timestamps are encoded as timestamp-micros and enums by symbol name.
+func Avro() ([]byte, error) {
+	g := &avroGenerator{defined: map[protoreflect.FullName]bool{}}
+
+	data, err := json.MarshalIndent(g.record(healthEventDescriptor()), "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal avro schema: %w", err)
+	}
+
+	return append(data, '\n'), nil
+}
+
+// JSONSchema returns the JSON-Schema (draft 2020-12) of the protojson encoding
+// of HealthEvent.
+func JSONSchema() ([]byte, error) {
+	g := &jsonSchemaGenerator{defs: map[string]any{}}
+
+	root := g.message(healthEventDescriptor())
+	root["$schema"] = jsonSchemaDraft
+	root["$id"] = jsonSchemaID
+	root["title"] = string(healthEventDescriptor().Name())
+	root["$defs"] = g.defs
+
+	data, err := json.MarshalIndent(root, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal json schema: %w", err)
+	}
+
+	return append(data, '\n'), nil
+}
+
+// avroGenerator builds Avro types; named types are defined once and referenced
+// by full name afterwards.
+type avroGenerator struct {
+	defined map[protoreflect.FullName]bool
+}
+
+func (g *avroGenerator) record(md protoreflect.MessageDescriptor) any {
+	if g.defined[md.FullName()] {
+		return string(md.FullName())
+	}
+
+	g.defined[md.FullName()] = true
+
+	fields := make([]any, 0, md.Fields().Len())
+
+	for i := range md.Fields().Len() {
+		fd := md.Fields().Get(i)
+		fieldType, fieldDefault := g.field(fd)
+
+		fields = append(fields, map[string]any{
+			"name":    fd.JSONName(),
+			"type":    fieldType,
+			"default": fieldDefault,
+		})
+	}
+
+	return map[string]any{
+		"type":      "record",
+		"name":      string(md.Name()),
+		"namespace": string(md.ParentFile().Package()),
+		"fields":    fields,
+	}
+}
+
+// field returns the Avro type of fd and the default of the field.
+func (g *avroGenerator) field(fd protoreflect.FieldDescriptor) (any, any) {
+	switch {
+	case fd.IsMap():
+		return map[string]any{"type": "map", "values": g.singular(fd.MapValue())}, map[string]any{}
+	case fd.IsList():
+		return map[string]any{"type": "array", "items": g.singular(fd)}, []any{}
+	case fd.Kind() == protoreflect.MessageKind:
+		return []any{"null", g.singular(fd)}, nil
+	default:
+		return g.singular(fd), avroDefault(fd)
+	}
+}
+
+func (g *avroGenerator) singular(fd protoreflect.FieldDescriptor) any {
+	switch fd.Kind() {
+	case protoreflect.BoolKind:
+		return "boolean"
+	case protoreflect.StringKind:
+		return "string"
+	case protoreflect.BytesKind:
+		return "bytes"
+	case protoreflect.Int32Kind, protoreflect.Sint32Kind, protoreflect.Sfixed32Kind:
+		return "int"
+	case protoreflect.FloatKind:
+		return "float"
+	case protoreflect.DoubleKind:
+		return "double"
+	case protoreflect.EnumKind:
+		return g.enum(fd.Enum())
+	case protoreflect.MessageKind, protoreflect.GroupKind:
+		if fd.Message().FullName() == timestampName {
+			return map[string]any{"type": "long", "logicalType": "timestamp-micros"}
+		}
+
+		return g.record(fd.Message())
+	default:
+		// Unsigned 32-bit and all 64-bit integers do not fit into an Avro int
+		return "long"
+	}
+}
+
+func (g *avroGenerator) enum(ed protoreflect.EnumDescriptor) any {
+	if g.defined[ed.FullName()] {
+		return string(ed.FullName())
+	}
+
+	g.defined[ed.FullName()] = true
+
+	symbols := make([]string, 0, ed.Values().Len())
+	for i := range ed.Values().Len() {
+		symbols = append(symbols, string(ed.Values().Get(i).Name()))
+	}
+
+	return map[string]any{
+		"type":      "enum",
+		"name":      string(ed.Name()),
+		"namespace": string(ed.ParentFile().Package()),
+		"symbols":   symbols,
+		// Readers fall back to the proto3 zero value for unknown symbols
+		"default": symbols[0],
+	}
+}
+
+// avroDefault returns the proto3 zero value of a singular scalar field.
+func avroDefault(fd protoreflect.FieldDescriptor) any {
+	switch fd.Kind() {
+	case protoreflect.BoolKind:
+		return false
+	case protoreflect.StringKind, protoreflect.BytesKind:
+		return ""
+	case protoreflect.EnumKind:
+		return string(fd.Enum().Values().Get(0).Name())
+	default:
+		return 0
+	}
+}
+
+// jsonSchemaGenerator builds JSON-Schema types; nested messages and enums are
+// placed in $defs and referenced by name.
+type jsonSchemaGenerator struct {
+	defs map[string]any
+}
+
+func (g *jsonSchemaGenerator) message(md protoreflect.MessageDescriptor) map[string]any {
+	properties := map[string]any{}
+
+	for i := range md.Fields().Len() {
+		fd := md.Fields().Get(i)
+		properties[fd.JSONName()] = g.field(fd)
+	}
+
+	return map[string]any{
+		"type":                 "object",
+		"properties":           properties,
+		"additionalProperties": false,
+	}
+}
+
+func (g *jsonSchemaGenerator) field(fd protoreflect.FieldDescriptor) any {
+	switch {
+	case fd.IsMap():
+		return map[string]any{"type": "object", "additionalProperties": g.singular(fd.MapValue())}
+	case fd.IsList():
+		return map[string]any{"type": "array", "items": g.singular(fd)}
+	default:
+		return g.singular(fd)
+	}
+}
+
+func (g *jsonSchemaGenerator) singular(fd protoreflect.FieldDescriptor) any {
+	switch fd.Kind() {
+	case protoreflect.BoolKind:
+		return map[string]any{"type": "boolean"}
+	case protoreflect.StringKind:
+		return map[string]any{"type": "string"}
+	case protoreflect.BytesKind:
+		return map[string]any{"type": "string", "contentEncoding": "base64"}
+	case protoreflect.Int32Kind, protoreflect.Sint32Kind, protoreflect.Sfixed32Kind:
+		return map[string]any{"type": "integer", "minimum": -1 << 31, "maximum": 1<<31 - 1}
+	case protoreflect.Uint32Kind, protoreflect.Fixed32Kind:
+		return map[string]any{"type": "integer", "minimum": 0, "maximum": 1<<32 - 1}
+	case protoreflect.FloatKind, protoreflect.DoubleKind:
+		return map[string]any{"type": "number"}
+	case protoreflect.EnumKind:
+		return g.ref(fd.Enum().FullName(), func() any { return jsonSchemaEnum(fd.Enum()) })
+	case protoreflect.MessageKind, protoreflect.GroupKind:
+		if fd.Message().FullName() == timestampName {
+			return map[string]any{"type": "string", "format": "date-time"}
+		}
+
+		return g.ref(fd.Message().FullName(), func() any { return g.message(fd.Message()) })
+	default:
+		// protojson encodes 64-bit integers as strings
+		return map[string]any{"type": []string{"integer", "string"}}
+	}
+}
+
+// ref defines the named type once in $defs and returns a reference to it.
+func (g *jsonSchemaGenerator) ref(name protoreflect.FullName, define func() any) any {
+	key := string(name.Name())
+
+	if _, ok := g.defs[key]; !ok {
+		// Reserve the name first so recursive messages terminate
+		g.defs[key] = nil
+		g.defs[key] = define()
+	}
+
+	return map[string]any{"$ref": "#/$defs/" + key}
+}
+
+func jsonSchemaEnum(ed protoreflect.EnumDescriptor) any {
+	names := make([]string, 0, ed.Values().Len())
+	for i := range ed.Values().Len() {
+		names = append(names, string(ed.Values().Get(i).Name()))
+	}
+
+	return map[string]any{"type": "string", "enum": names}
+}
@@ -0,0 +1,270 @@
+// Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package schema
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+	"time"
+
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/nvidia/nvsentinel/data-models/pkg/protos"
+)
+
+func TestGeneratedFilesInSync(t *testing.T) {
+	for file, generate := range map[string]func() ([]byte, error){
+		AvroFile:       Avro,
+		JSONSchemaFile: JSONSchema,
+	} {
+		want, err := generate()
+		if err != nil {
+			t.Fatalf("generate %s: %v", file, err)
+		}
+
+		got, err := os.ReadFile(file)
+		if err != nil {
+			t.Fatalf("read %s: %v", file, err)
+		}
+
+		if !bytes.Equal(want, got) {
+			t.Errorf("%s is stale, run go generate ./pkg/schema/...", file)
+		}
+	}
+}
+
+// assertJSONEqual fails the test if got and want are not the same JSON value.
+func assertJSONEqual(t *testing.T, name string, got []byte, want string) {
+	t.Helper()
+
+	var gotValue, wantValue any
+	if err := json.Unmarshal(got, &gotValue); err != nil {
+		t.Fatalf("%s: invalid JSON %s: %v", name, got, err)
+	}
+
+	if err := json.Unmarshal([]byte(want), &wantValue); err != nil {
+		t.Fatalf("%s: invalid expected JSON %s: %v", name, want, err)
+	}
+
+	gotNorm, _ := json.Marshal(gotValue)
+	wantNorm, _ := json.Marshal(wantValue)
+
+	if !bytes.Equal(gotNorm, wantNorm) {
+		t.Errorf("%s = %s, want %s", name, gotNorm, wantNorm)
+	}
+}
+
+func TestAvroCoversAllFields(t *testing.T) {
+	data, err := Avro()
+	if err != nil {
+		t.Fatalf("Avro: %v", err)
+	}
+
+	var record struct {
+		Name   string `json:"name"`
+		Fields []struct {
+			Name string          `json:"name"`
+			Type json.RawMessage `json:"type"`
+		} `json:"fields"`
+	}
+	if err := json.Unmarshal(data, &record); err != nil {
+		t.Fatalf("Unmarshal: %v", err)
+	}
+
+	if record.Name != "HealthEvent" {
+		t.Errorf("record name = %q, want HealthEvent", record.Name)
+	}
+
+	fields := healthEventDescriptor().Fields()
+	if len(record.Fields) != fields.Len() {
+		t.Fatalf("got %d fields, want %d", len(record.Fields), fields.Len())
+	}
+
+	types := map[string][]byte{}
+
+	for i, field := range record.Fields {
+		if want := fields.Get(i).JSONName(); field.Name != want {
+			t.Errorf("field %d = %q, want %q", i, field.Name, want)
+		}
+
+		types[field.Name] = field.Type
+	}
+
+	assertJSONEqual(t, "generatedTimestamp", types["generatedTimestamp"],
+		`["null",{"type":"long","logicalType":"timestamp-micros"}]`)
+	assertJSONEqual(t, "checkName", types["checkName"], `"string"`)
+	// The second use of BehaviourOverrides references the first definition
+	assertJSONEqual(t, "drainOverrides", types["drainOverrides"], `["null","datamodels.BehaviourOverrides"]`)
+}
+
+func TestJSONSchemaDescribesProtoJSON(t *testing.T) {
+	data, err := JSONSchema()
+	if err != nil {
+		t.Fatalf("JSONSchema: %v", err)
+	}
+
+	var schema struct {
+		Properties map[string]json.RawMessage `json:"properties"`
+		Defs       map[string]json.RawMessage `json:"$defs"`
+	}
+	if err := json.Unmarshal(data, &schema); err != nil {
+		t.Fatalf("Unmarshal: %v", err)
+	}
+
+	event := &protos.HealthEvent{
+		Version:            1,
+		Agent:              "syslog-health-monitor",
+		CheckName:          "SysLogsXIDError",
+		IsFatal:            true,
+		RecommendedAction:  protos.RecommendedAction_RESTART_BM,
+		ErrorCode:          []string{"79"},
+		EntitiesImpacted:   []*protos.Entity{{EntityType: "GPU", EntityValue: "0"}},
+		Metadata:           map[string]string{"key": "value"},
+		GeneratedTimestamp: timestamppb.New(time.Now()),
+		NodeName:           "node-1",
+		DrainOverrides:     &protos.BehaviourOverrides{Skip: true},
+		Priority:           protos.Priority_PRIORITY_HIGH,
+	}
+
+	encoded, err := protojson.Marshal(event)
+	if err != nil {
+		t.Fatalf("protojson.Marshal: %v", err)
+	}
+
+	var decoded map[string]any
+	if err := json.Unmarshal(encoded, &decoded); err != nil {
+		t.Fatalf("Unmarshal: %v", err)
+	}
+
+	for key := range decoded {
+		if _, ok := schema.Properties[key]; !ok {
+			t.Errorf("protojson field %q is missing from the schema", key)
+		}
+	}
+
+	assertJSONEqual(t, "recommendedAction", schema.Properties["recommendedAction"],
+		`{"$ref":"#/$defs/RecommendedAction"}`)
+	assertJSONEqual(t, "generatedTimestamp", schema.Properties["generatedTimestamp"],
+		`{"type":"string","format":"date-time"}`)
+
+	if !bytes.Contains(schema.Defs["RecommendedAction"], []byte(`"RESTART_BM"`)) {
+		t.Errorf("RecommendedAction enum = %s, want RESTART_BM", schema.Defs["RecommendedAction"])
+	}
+}
+
+func TestHandler(t *testing.T) {
+	handler, err := Handler()
+	if err != nil {
+		t.Fatalf("Handler: %v", err)
+	}
+
+	server := httptest.NewServer(handler)
+	defer server.Close()
+
+	tests := []struct {
+		path        string
+		status      int
+		contentType string
+	}{
+		{path: "/schemas/", status: http.StatusOK, contentType: "application/json"},
+		{path: "/schemas/healthevent/avro", status: http.StatusOK, contentType: avroContentType},
+		{path: "/schemas/healthevent/jsonschema", status: http.StatusOK, contentType: jsonSchemaContentType},
+		{path: "/schemas/healthevent/protobuf", status: http.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			resp, err := http.Get(server.URL + tt.path)
+			if err != nil {
+				t.Fatalf("GET: %v", err)
+			}
+			defer resp.Body.Close()
+
+			if resp.StatusCode != tt.status {
+				t.Fatalf("status = %d, want %d", resp.StatusCode, tt.status)
+			}
+
+			if tt.status != http.StatusOK {
+				return
+			}
+
+			if got := resp.Header.Get("Content-Type"); got != tt.contentType {
+				t.Errorf("Content-Type = %q, want %q", got, tt.contentType)
+			}
+
+			body, err := io.ReadAll(resp.Body)
+			if err != nil {
+				t.Fatalf("ReadAll: %v", err)
+			}
+
+			if !json.Valid(body) {
+				t.Errorf("body is not valid JSON: %s", body)
+			}
+		})
+	}
+}
+
+func TestEncodeAvro(t *testing.T) {
+	tests := []struct {
+		name  string
+		event *protos.HealthEvent
+		want  []byte
+	}{
+		{
+			name:  "zero values",
+			event: &protos.HealthEvent{Version: 1, CheckName: "x"},
+			// version, agent, componentClass, checkName, then 16 empty/zero fields
+			want: append([]byte{0x02, 0x00, 0x00, 0x02, 'x'}, make([]byte, 16)...),
+		},
+		{
+			name: "enums, lists and nullable messages",
+			event: &protos.HealthEvent{
+				RecommendedAction: protos.RecommendedAction_DRIVER_RELOAD,
+				ErrorCode:         []string{"79"},
+				DrainOverrides:    &protos.BehaviourOverrides{Skip: true},
+				Priority:          protos.Priority_PRIORITY_HIGH,
+			},
+			want: []byte{
+				0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, // version to message
+				0x0c,                       // DRIVER_RELOAD is the 7th symbol
+				0x02, 0x04, '7', '9', 0x00, // errorCode block of one item
+				0x00, 0x00, 0x00, 0x00, 0x00, // entities, metadata, timestamp, nodeName, quarantineOverrides
+				0x02, 0x00, 0x01, // drainOverrides union branch 1 {force: false, skip: true}
+				0x02,             // PRIORITY_HIGH
+				0x00, 0x00, 0x00, // causedById, supersedesId, correlationId
+				0x00, // id
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := EncodeAvro(tt.event)
+			if err != nil {
+				t.Fatalf("EncodeAvro: %v", err)
+			}
+
+			if !bytes.Equal(got, tt.want) {
+				t.Errorf("EncodeAvro = %x, want %x", got, tt.want)
+			}
+		})
+	}
+}
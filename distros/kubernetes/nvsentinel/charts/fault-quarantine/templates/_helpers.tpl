@@ -38,3 +38,49 @@ Selector labels
 app.kubernetes.io/name: {{ include "fault-quarantine.name" . }}
 app.kubernetes.io/instance: {{ .Release.Name }}
 {{- end }}
+
+{{/*
+TOML rule sets. Takes a dict with the "ruleSets" to render and the "table"
+they are rendered as, "rule-sets" or "clusters.rule-sets".
+*/}}
+{{- define "fault-quarantine.ruleSets" -}}
+{{- $table := .table }}
+{{- range .ruleSets }}
+[[{{ $table }}]]
+  version = {{ .version | quote }}
+  name = {{ .name | quote }}
+  {{- with .runbookURL }}
+  runbookURL = {{ . | quote }}
+  {{- end }}
+  {{- if .match.all }}
+  {{- range .match.all }}
+
+  [[{{ $table }}.match.all]]
+    kind = {{ .kind | quote }}
+    expression = {{ if contains "\n" .expression }}'''
+        {{ .expression | trim }}
+    '''{{ else }}{{ .expression | quote }}{{ end }}
+  {{- end }}
+  {{- end }}
+  {{- if .match.any }}
+  {{- range .match.any }}
+
+  [[{{ $table }}.match.any]]
+    kind = {{ .kind | quote }}
+    expression = {{ if contains "\n" .expression }}'''
+        {{ .expression | trim }}
+    '''{{ else }}{{ .expression | quote }}{{ end }}
+  {{- end }}
+  {{- end }}
+
+  {{- if .taint }}
+  [{{ $table }}.taint]
+    key = {{ .taint.key | quote }}
+    value = {{ .taint.value | quote }}
+    effect = {{ .taint.effect | quote }}
+  {{- end }}
+
+  [{{ $table }}.cordon]
+    shouldCordon = {{ .cordon.shouldCordon }}
+{{- end }}
+{{- end }}
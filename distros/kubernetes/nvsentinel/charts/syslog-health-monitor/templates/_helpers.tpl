@@ -73,6 +73,7 @@ spec:
         {{- include "syslog-health-monitor.selectorLabels" $root | nindent 8 }}
         nvsentinel.dgxc.nvidia.com/kata: {{ $kataLabel | quote }}
     spec:
+      terminationGracePeriodSeconds: {{ $root.Values.terminationGracePeriodSeconds }}
       {{- with $root.Values.global.imagePullSecrets }}
       imagePullSecrets:
         {{- toYaml . | nindent 8 }}
@@ -97,9 +98,70 @@ spec:
             - "http://localhost:8080"
             {{- end }}
             - "--checks"
-            - "{{ join "," $root.Values.enabledChecks }}"
+            - "{{ join "," $root.Values.enabledChecks }}{{ if $root.Values.missingLineWatchdog.enabled }},SysLogsMissingLine{{ end }}"
+            {{- if $root.Values.missingLineWatchdog.enabled }}
+            - "--watchdog-config"
+            - "/etc/nvsentinel/watchdog/watchdog.toml"
+            {{- end }}
+            {{- if $root.Values.eventPolicy.enabled }}
+            - "--policy-config"
+            - "/etc/nvsentinel/policy/policy.toml"
+            {{- end }}
+            {{- with $root.Values.driverInstallLogFiles }}
+            - "--driver-install-log-files"
+            - "{{ join "," . }}"
+            {{- end }}
             - "--metadata-path"
             - "{{ $root.Values.global.metadataPath }}"
+            - "--event-storm-threshold"
+            - "{{ $root.Values.eventStorm.threshold }}"
+            - "--event-storm-minutes"
+            - "{{ $root.Values.eventStorm.minutes }}"
+            - "--event-storm-cooldown"
+            - "{{ $root.Values.eventStorm.cooldown }}"
+            - "--cpu-budget"
+            - "{{ $root.Values.cpuBudget }}"
+            - "--check-workers"
+            - "{{ $root.Values.checkWorkers }}"
+            - "--state-capacity"
+            - "{{ $root.Values.stateCapacity }}"
+            - "--ecc-sbe-threshold"
+            - "{{ $root.Values.eccSBEThreshold }}"
+            - "--min-shipped-severity"
+            - "{{ $root.Values.minShippedSeverity }}"
+            - "--local-buffer-mb"
+            - "{{ $root.Values.localBufferMB }}"
+            - "--express-interval"
+            - "{{ $root.Values.expressInterval }}"
+            - "--compression"
+            - "{{ $root.Values.transport.compression }}"
+            - "--batch-size"
+            - "{{ $root.Values.transport.batchSize }}"
+            - "--platform-connector-probe-interval"
+            - "{{ $root.Values.transport.probeInterval }}"
+            {{- with $root.Values.transport.fallbackEndpoints }}
+            - "--platform-connector-fallback-endpoints"
+            - "{{ join "," . }}"
+            {{- end }}
+            - "--heartbeat-interval"
+            - "{{ $root.Values.heartbeatInterval }}"
+            {{- if $root.Values.rulePacks.enabled }}
+            - "--rule-packs"
+            {{- if $root.Values.rulePacks.configMap }}
+            - "--rule-packs-dir"
+            - "/etc/nvsentinel/rule-packs"
+            {{- end }}
+            {{- with $root.Values.rulePacks.distribution }}
+            {{- if .enabled }}
+            - "--rule-pack-server"
+            - {{ .server | default (printf "health-events-analyzer.%s.svc:50052" $root.Release.Namespace) | quote }}
+            - "--rule-pack-public-key"
+            - "/etc/nvsentinel/rule-pack-key/public.pem"
+            - "--rule-pack-sync-interval"
+            - {{ .syncInterval | quote }}
+            {{- end }}
+            {{- end }}
+            {{- end }}
           resources:
             {{- toYaml $root.Values.resources | nindent 12 }}
           ports:
@@ -158,6 +220,26 @@ spec:
             - name: sys-vol
               mountPath: /nvsentinel/sys
               readOnly: true
+            {{- if and $root.Values.rulePacks.enabled $root.Values.rulePacks.configMap }}
+            - name: rule-packs-vol
+              mountPath: /etc/nvsentinel/rule-packs
+              readOnly: true
+            {{- end }}
+            {{- if and $root.Values.rulePacks.enabled $root.Values.rulePacks.distribution.enabled }}
+            - name: rule-pack-key-vol
+              mountPath: /etc/nvsentinel/rule-pack-key
+              readOnly: true
+            {{- end }}
+            {{- if $root.Values.missingLineWatchdog.enabled }}
+            - name: watchdog-vol
+              mountPath: /etc/nvsentinel/watchdog
+              readOnly: true
+            {{- end }}
+            {{- if $root.Values.eventPolicy.enabled }}
+            - name: policy-vol
+              mountPath: /etc/nvsentinel/policy
+              readOnly: true
+            {{- end }}
         {{- if $root.Values.xidSideCar.enabled }}
         - name: xid-analyzer-sidecar
           image: {{ $root.Values.xidSideCar.image.repository }}:{{ $root.Values.xidSideCar.image.tag }}
@@ -219,6 +301,26 @@ spec:
           hostPath:
             path: /proc
             type: Directory
+        {{- if and $root.Values.rulePacks.enabled $root.Values.rulePacks.configMap }}
+        - name: rule-packs-vol
+          configMap:
+            name: {{ $root.Values.rulePacks.configMap }}
+        {{- end }}
+        {{- if and $root.Values.rulePacks.enabled $root.Values.rulePacks.distribution.enabled }}
+        - name: rule-pack-key-vol
+          configMap:
+            name: {{ $root.Values.rulePacks.distribution.publicKeyConfigMap }}
+        {{- end }}
+        {{- if $root.Values.missingLineWatchdog.enabled }}
+        - name: watchdog-vol
+          configMap:
+            name: {{ include "syslog-health-monitor.fullname" $root }}-watchdog
+        {{- end }}
+        {{- if $root.Values.eventPolicy.enabled }}
+        - name: policy-vol
+          configMap:
+            name: {{ include "syslog-health-monitor.fullname" $root }}-policy
+        {{- end }}
       nodeSelector:
         nvsentinel.dgxc.nvidia.com/driver.installed: "true"
         nvsentinel.dgxc.nvidia.com/kata.enabled: {{ $kataLabel | quote }}
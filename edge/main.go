@@ -0,0 +1,340 @@
+// Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Command edge runs the syslog health monitor, the platform connectors and
+// fault quarantine in one process, with the health events kept in an embedded
+// store instead of MongoDB. It is meant for small clusters that cannot run the
+// full set of services, and runs on every node as a DaemonSet.
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log/slog"
+	"net"
+	"os"
+	"os/signal"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/nvidia/nvsentinel/commons/pkg/logger"
+	srv "github.com/nvidia/nvsentinel/commons/pkg/server"
+	pb "github.com/nvidia/nvsentinel/data-models/pkg/protos"
+	"github.com/nvidia/nvsentinel/edge/pkg/retention"
+	"github.com/nvidia/nvsentinel/fault-quarantine/pkg/initializer"
+	fd "github.com/nvidia/nvsentinel/health-monitors/syslog-health-monitor/pkg/syslog-monitor"
+	"github.com/nvidia/nvsentinel/platform-connectors/pkg/connectors/embedded"
+	"github.com/nvidia/nvsentinel/platform-connectors/pkg/connectors/kubernetes"
+	"github.com/nvidia/nvsentinel/platform-connectors/pkg/ringbuffer"
+	"github.com/nvidia/nvsentinel/platform-connectors/pkg/server"
+	"github.com/nvidia/nvsentinel/store-client/pkg/store"
+	"github.com/nvidia/nvsentinel/store-client/pkg/store/bolt"
+	"github.com/nvidia/nvsentinel/store-client/pkg/store/sqlite"
+	"golang.org/x/sync/errgroup"
+
+	"google.golang.org/grpc"
+)
+
+const (
+	defaultAgentName      = "syslog-health-monitor"
+	defaultComponentClass = "GPU"
+)
+
+var (
+	// These variables will be populated during the build process
+	version = "dev"
+	commit  = "none"
+	date    = "unknown"
+)
+
+type options struct {
+	nodeName      string
+	metricsPort   string
+	storeBackend  string
+	storePath     string
+	retention     time.Duration
+	pollInterval  time.Duration
+	socket        string
+	clusterName   string
+	k8sConnector  bool
+	k8sQPS        float64
+	k8sBurst      int
+	checks        string
+	syslogPolling string
+	stateFile     string
+	metadataPath  string
+
+	quarantineConfigPath  string
+	kubeconfigPath        string
+	dryRun                bool
+	circuitBreakerEnabled bool
+}
+
+func main() {
+	logger.SetDefaultStructuredLogger("edge", version)
+	slog.Info("Starting edge", "version", version, "commit", commit, "date", date)
+
+	if err := run(); err != nil {
+		slog.Error("Edge exited with error", "error", err)
+		os.Exit(1)
+	}
+}
+
+func parseFlags() options {
+	var opts options
+
+	flag.StringVar(&opts.nodeName, "node-name", os.Getenv("NODE_NAME"), "Node name. Defaults to NODE_NAME env var.")
+	flag.StringVar(&opts.metricsPort, "metrics-port", "2112", "port to expose Prometheus metrics on")
+	flag.StringVar(&opts.storeBackend, "store-backend", "bolt", "embedded health event store: bolt or sqlite")
+	flag.StringVar(&opts.storePath, "store-path", "/var/lib/nvsentinel/edge.db",
+		"path of the embedded health event store")
+	flag.DurationVar(&opts.retention, "retention", 7*24*time.Hour, "how long health events are kept in the store")
+	flag.DurationVar(&opts.pollInterval, "poll-interval", time.Second,
+		"how often fault quarantine polls the store for new health events")
+	flag.StringVar(&opts.socket, "socket", "",
+		"unix socket accepting health events from other health monitors, empty to only run the embedded one")
+	flag.StringVar(&opts.clusterName, "cluster-name", "", "cluster name stamped on the health events")
+	flag.BoolVar(&opts.k8sConnector, "k8s-connector", true, "update node conditions and events from health events")
+	flag.Float64Var(&opts.k8sQPS, "k8s-connector-qps", 5, "QPS of the kubernetes connector client")
+	flag.IntVar(&opts.k8sBurst, "k8s-connector-burst", 10, "burst of the kubernetes connector client")
+	flag.StringVar(&opts.checks, "checks", "SysLogsXIDError,SysLogsSXIDError,SysLogsGPUFallenOff",
+		"Comma separated listed of syslog checks to enable")
+	flag.StringVar(&opts.syslogPolling, "syslog-polling-interval", "30m", "Polling interval of the syslog checks")
+	flag.StringVar(&opts.stateFile, "state-file", "/var/run/syslog_monitor/state.json",
+		"Path to state file for syslog cursor persistence.")
+	flag.StringVar(&opts.metadataPath, "metadata-path", "/var/lib/nvsentinel/gpu_metadata.json",
+		"Path to GPU metadata JSON file.")
+	flag.StringVar(&opts.quarantineConfigPath, "fault-quarantine-config-path", "/etc/config/fault-quarantine.toml",
+		"path where the fault quarantine config file is present")
+	flag.StringVar(&opts.kubeconfigPath, "kubeconfig-path", "", "path to kubeconfig file")
+	flag.BoolVar(&opts.dryRun, "dry-run", false, "flag to run fault quarantine in dry-run mode")
+	// Every node runs its own instance, so the breaker is off unless asked for
+	flag.BoolVar(&opts.circuitBreakerEnabled, "circuit-breaker-enabled", false,
+		"enable or disable the fault quarantine circuit breaker")
+
+	flag.Parse()
+
+	return opts
+}
+
+//nolint:cyclop // function wires the embedded services together
+func run() error {
+	opts := parseFlags()
+
+	if opts.nodeName == "" {
+		return fmt.Errorf("NODE_NAME env not set and --node-name flag not provided, cannot run")
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	portInt, err := strconv.Atoi(opts.metricsPort)
+	if err != nil {
+		return fmt.Errorf("invalid metrics port: %w", err)
+	}
+
+	eventStore, err := openStore(opts.storeBackend, opts.storePath)
+	if err != nil {
+		return fmt.Errorf("failed to open store: %w", err)
+	}
+
+	defer func() {
+		if err := eventStore.Close(); err != nil {
+			slog.Error("Failed to close store", "error", err)
+		}
+	}()
+
+	stopCh := make(chan struct{})
+	defer close(stopCh)
+
+	storeRingBuffer := ringbuffer.NewRingBuffer("embeddedStore", ctx)
+	server.InitializeAndAttachRingBufferForConnectors(storeRingBuffer)
+
+	storeConnector := embedded.NewEmbeddedStoreConnector(eventStore, storeRingBuffer)
+	go storeConnector.FetchAndProcessHealthMetric(ctx)
+
+	if opts.k8sConnector {
+		k8sRingBuffer := ringbuffer.NewRingBuffer("kubernetes", ctx)
+		server.InitializeAndAttachRingBufferForConnectors(k8sRingBuffer)
+
+		k8sConnector, _, err := kubernetes.InitializeK8sConnector(
+			ctx, k8sRingBuffer, float32(opts.k8sQPS), opts.k8sBurst, stopCh)
+		if err != nil {
+			return fmt.Errorf("failed to initialize K8sConnector: %w", err)
+		}
+
+		go k8sConnector.FetchAndProcessHealthMetric(ctx)
+	}
+
+	connectorServer := &server.PlatformConnectorServer{ClusterName: opts.clusterName}
+
+	if opts.socket != "" {
+		lis, err := startGRPCServer(ctx, opts.socket, connectorServer)
+		if err != nil {
+			return err
+		}
+
+		defer lis.Close()
+	}
+
+	syslogMonitor, err := newSyslogMonitor(opts, server.LocalClient{Server: connectorServer})
+	if err != nil {
+		return err
+	}
+
+	syslogPolling, err := time.ParseDuration(opts.syslogPolling)
+	if err != nil {
+		return fmt.Errorf("error parsing syslog polling interval: %w", err)
+	}
+
+	components, err := initializer.InitializeAll(ctx, initializer.InitializationParams{
+		KubeconfigPath:        opts.kubeconfigPath,
+		TomlConfigPath:        opts.quarantineConfigPath,
+		DryRun:                opts.dryRun,
+		CircuitBreakerEnabled: opts.circuitBreakerEnabled,
+		EmbeddedStore:         eventStore,
+		EmbeddedPollInterval:  opts.pollInterval,
+	})
+	if err != nil {
+		return fmt.Errorf("fault quarantine initialization failed: %w", err)
+	}
+
+	quarantine := components.Clusters[0]
+
+	if err := quarantine.K8sClient.NodeInformer.Run(ctx.Done()); err != nil {
+		return fmt.Errorf("failed to start node informer: %w", err)
+	}
+
+	metricsServer := srv.NewServer(
+		srv.WithPort(portInt),
+		srv.WithPrometheusMetrics(),
+		srv.WithHandler(logger.LevelsPath, logger.LevelsHandler()),
+		srv.WithSimpleHealth(),
+	)
+
+	g, gCtx := errgroup.WithContext(ctx)
+
+	g.Go(func() error {
+		slog.Info("Starting metrics server", "port", portInt)
+
+		if err := metricsServer.Serve(gCtx); err != nil {
+			slog.Error("Metrics server failed - continuing without metrics", "error", err)
+		}
+
+		return nil
+	})
+
+	g.Go(func() error {
+		return quarantine.Reconciler.Start(gCtx)
+	})
+
+	g.Go(func() error {
+		return retention.NewPruner(eventStore, opts.retention, time.Hour).Run(gCtx)
+	})
+
+	g.Go(func() error {
+		return pollSyslog(gCtx, syslogMonitor, syslogPolling)
+	})
+
+	return g.Wait()
+}
+
+func openStore(backend, path string) (store.Store, error) {
+	switch backend {
+	case "bolt":
+		return bolt.Open(path)
+	case "sqlite":
+		return sqlite.Open(path)
+	default:
+		return nil, fmt.Errorf("unknown store backend %q", backend)
+	}
+}
+
+func newSyslogMonitor(opts options, client pb.PlatformConnectorClient) (*fd.SyslogMonitor, error) {
+	var checks []fd.CheckDefinition
+
+	for c := range strings.SplitSeq(opts.checks, ",") {
+		checks = append(checks, fd.CheckDefinition{
+			Name:        c,
+			JournalPath: "/nvsentinel/var/log/journal/",
+		})
+	}
+
+	monitor, err := fd.NewSyslogMonitor(
+		opts.nodeName,
+		checks,
+		client,
+		defaultAgentName,
+		defaultComponentClass,
+		opts.syslogPolling,
+		opts.stateFile,
+		"",
+		opts.metadataPath,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("error creating syslog health monitor: %w", err)
+	}
+
+	return monitor, nil
+}
+
+// pollSyslog runs the syslog checks every interval. Failed runs are retried at
+// the next interval.
+func pollSyslog(ctx context.Context, monitor *fd.SyslogMonitor, interval time.Duration) error {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+			if err := monitor.Run(); err != nil {
+				slog.Error("Syslog health check run failed", "error", err)
+			}
+		}
+	}
+}
+
+func startGRPCServer(
+	ctx context.Context,
+	socket string,
+	connectorServer *server.PlatformConnectorServer,
+) (net.Listener, error) {
+	err := os.Remove(socket)
+	if err != nil && !os.IsNotExist(err) {
+		return nil, fmt.Errorf("failed to remove existing socket: %w", err)
+	}
+
+	lc := &net.ListenConfig{}
+
+	lis, err := lc.Listen(ctx, "unix", socket)
+	if err != nil {
+		return nil, fmt.Errorf("failed to listen on unix socket %s: %w", socket, err)
+	}
+
+	grpcServer := grpc.NewServer(grpc.StatsHandler(server.PayloadStatsHandler{}))
+	pb.RegisterPlatformConnectorServer(grpcServer, connectorServer)
+
+	go func() {
+		if err := grpcServer.Serve(lis); err != nil {
+			slog.Error("Not able to accept incoming connections", "error", err)
+		}
+	}()
+
+	return lis, nil
+}
@@ -0,0 +1,73 @@
+// Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package retention prunes old health events from the embedded store.
+package retention
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/nvidia/nvsentinel/store-client/pkg/store"
+)
+
+// Pruner deletes the events older than the retention.
+type Pruner struct {
+	store     store.Store
+	retention time.Duration
+	interval  time.Duration
+	now       func() time.Time
+}
+
+// NewPruner creates a Pruner deleting the events older than retention every
+// interval.
+func NewPruner(s store.Store, retention, interval time.Duration) *Pruner {
+	return &Pruner{store: s, retention: retention, interval: interval, now: time.Now}
+}
+
+// Run prunes the store until the context is cancelled.
+func (p *Pruner) Run(ctx context.Context) error {
+	ticker := time.NewTicker(p.interval)
+	defer ticker.Stop()
+
+	for {
+		if err := p.Prune(ctx); err != nil {
+			slog.Error("Failed to prune health events", "error", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// Prune deletes the events older than the retention once.
+func (p *Pruner) Prune(ctx context.Context) error {
+	before := p.now().Add(-p.retention)
+
+	deleted, err := p.store.Delete(ctx, store.Filter{CreatedBefore: before})
+	if err != nil {
+		return fmt.Errorf("failed to delete health events created before %s: %w", before, err)
+	}
+
+	if deleted > 0 {
+		slog.Info("Pruned health events", "deleted", deleted, "before", before)
+	}
+
+	return nil
+}
@@ -0,0 +1,54 @@
+// Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package retention
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/nvidia/nvsentinel/data-models/pkg/model"
+	"github.com/nvidia/nvsentinel/data-models/pkg/protos"
+	"github.com/nvidia/nvsentinel/store-client/pkg/store"
+	"github.com/nvidia/nvsentinel/store-client/pkg/store/bolt"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPrune(t *testing.T) {
+	s, err := bolt.Open(filepath.Join(t.TempDir(), "events.db"))
+	require.NoError(t, err)
+
+	defer s.Close()
+
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+
+	_, err = s.Insert(context.Background(), []model.HealthEventWithStatus{
+		{CreatedAt: now.Add(-48 * time.Hour), HealthEvent: &protos.HealthEvent{NodeName: "old"}},
+		{CreatedAt: now.Add(-time.Hour), HealthEvent: &protos.HealthEvent{NodeName: "recent"}},
+	})
+	require.NoError(t, err)
+
+	p := NewPruner(s, 24*time.Hour, time.Hour)
+	p.now = func() time.Time { return now }
+
+	require.NoError(t, p.Prune(context.Background()))
+
+	records, err := s.List(context.Background(), store.Filter{}, store.ListOptions{})
+	require.NoError(t, err)
+	require.Len(t, records, 1)
+	assert.Equal(t, "recent", records[0].HealthEvent.GetNodeName())
+}
@@ -24,6 +24,7 @@ import (
 	"strconv"
 	"syscall"
 
+	"github.com/nvidia/nvsentinel/commons/pkg/configmanager"
 	"github.com/nvidia/nvsentinel/commons/pkg/logger"
 	"github.com/nvidia/nvsentinel/commons/pkg/server"
 	"github.com/nvidia/nvsentinel/fault-quarantine/pkg/initializer"
@@ -58,12 +59,6 @@ func run() error {
 		return fmt.Errorf("invalid metrics port: %w", err)
 	}
 
-	srv := server.NewServer(
-		server.WithPort(portInt),
-		server.WithPrometheusMetrics(),
-		server.WithSimpleHealth(),
-	)
-
 	params := initializer.InitializationParams{
 		KubeconfigPath:        *kubeconfigPath,
 		TomlConfigPath:        *tomlConfigPath,
@@ -76,13 +71,24 @@ func run() error {
 		return fmt.Errorf("initialization failed: %w", err)
 	}
 
-	slog.Info("Starting node informer")
+	srv := server.NewServer(
+		server.WithPort(portInt),
+		server.WithPrometheusMetrics(),
+		server.WithHandler(logger.LevelsPath, logger.LevelsHandler()),
+		server.WithSimpleHealth(),
+		server.WithHandler(configmanager.ConfigPath,
+			configmanager.Handler("fault-quarantine", components.Config, initializer.LoadCandidateConfig)),
+	)
+
+	for _, cluster := range components.Clusters {
+		slog.Info("Starting node informer", "cluster", cluster.Name)
 
-	if err := components.K8sClient.NodeInformer.Run(ctx.Done()); err != nil {
-		return fmt.Errorf("failed to start node informer: %w", err)
-	}
+		if err := cluster.K8sClient.NodeInformer.Run(ctx.Done()); err != nil {
+			return fmt.Errorf("failed to start node informer of cluster %q: %w", cluster.Name, err)
+		}
 
-	slog.Info("Node informer started and synced")
+		slog.Info("Node informer started and synced", "cluster", cluster.Name)
+	}
 
 	g, gCtx := errgroup.WithContext(ctx)
 
@@ -96,9 +102,17 @@ func run() error {
 		return nil
 	})
 
-	g.Go(func() error {
-		return components.Reconciler.Start(gCtx)
-	})
+	for _, cluster := range components.Clusters {
+		g.Go(func() error {
+			return cluster.Reconciler.Start(gCtx)
+		})
+	}
+
+	if components.GarbageCollector != nil {
+		g.Go(func() error {
+			return components.GarbageCollector.Run(gCtx)
+		})
+	}
 
 	return g.Wait()
 }
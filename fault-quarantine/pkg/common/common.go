@@ -31,5 +31,10 @@ const (
 	QuarantinedNodeUncordonedManuallyAnnotationKey     = "quarantinedNodeUncordonedManually"
 	QuarantinedNodeUncordonedManuallyAnnotationValue   = "True"
 
+	// QuarantineIncidentApprovalAnnotationKey approves the cross-node incident whose
+	// ID is its value. It is set on any node the incident affects and removed once
+	// the approval is handled.
+	QuarantineIncidentApprovalAnnotationKey = "quarantineIncidentApproval"
+
 	ServiceName = "NVSentinel"
 )
@@ -14,6 +14,8 @@
 
 package config
 
+import "fmt"
+
 type Rule struct {
 	Kind       string `toml:"kind"`
 	Expression string `toml:"expression"`
@@ -34,6 +36,16 @@ type CircuitBreaker struct {
 	Duration   string `toml:"duration"`
 }
 
+// GarbageCollection configures the removal of resolved incidents and released
+// quarantine records from the health events collection. Durations use Go
+// duration syntax, empty values fall back to the defaults of the gc package.
+type GarbageCollection struct {
+	Interval          string `toml:"interval"`
+	Retention         string `toml:"retention"`
+	ArchiveCollection string `toml:"archiveCollection"`
+	BatchSize         int    `toml:"batchSize"`
+}
+
 type Match struct {
 	Any []Rule `toml:"any"`
 	All []Rule `toml:"all"`
@@ -46,10 +58,114 @@ type RuleSet struct {
 	Match    Match  `toml:"match"`
 	Taint    Taint  `toml:"taint"`
 	Cordon   Cordon `toml:"cordon"`
+	// RunbookURL is recorded in the quarantine reason of the nodes the rule set
+	// quarantines, optional
+	RunbookURL string `toml:"runbookURL"`
+}
+
+// Cluster is a cluster whose nodes are quarantined by this instance. Events are
+// routed to a cluster by the cluster name the platform connectors stamp into
+// their metadata. Rule sets and circuit breaker default to the top level ones.
+type Cluster struct {
+	Name string `toml:"name"`
+	// Kubeconfig is the path of the kubeconfig of the cluster, empty for the
+	// cluster this instance runs in
+	Kubeconfig string `toml:"kubeconfig"`
+	// Default clusters also receive the events without a cluster name
+	Default bool `toml:"default"`
+	// Namespace holds the circuit breaker state in the cluster, defaults to
+	// the namespace of this instance
+	Namespace      string          `toml:"namespace"`
+	CircuitBreaker *CircuitBreaker `toml:"circuitBreaker"`
+	RuleSets       []RuleSet       `toml:"rule-sets"`
+}
+
+// Corroboration holds the fatal events of the error codes until the composite
+// rule of the health events analyzer corroborates them with other signals, for
+// sites that prioritize precision over speed. The rule sets then act on the fatal
+// event the rule publishes instead.
+type Corroboration struct {
+	// CompositeRule is the name of the composite rule, the check name of the
+	// events it publishes
+	CompositeRule string   `toml:"compositeRule"`
+	ErrorCodes    []string `toml:"errorCodes"`
 }
 
 type TomlConfig struct {
 	LabelPrefix    string         `toml:"label-prefix"`
 	CircuitBreaker CircuitBreaker `toml:"circuitBreaker"`
-	RuleSets       []RuleSet      `toml:"rule-sets"`
+	// GarbageCollection is nil when garbage collection is disabled
+	GarbageCollection *GarbageCollection `toml:"garbageCollection"`
+	// Clusters is empty when only the cluster this instance runs in is
+	// quarantined
+	Clusters []Cluster `toml:"clusters"`
+	RuleSets []RuleSet `toml:"rule-sets"`
+	// Corroborations is empty when fatal events are acted on right away
+	Corroborations []Corroboration `toml:"corroborations"`
+}
+
+// ForCluster returns the configuration the cluster is quarantined with.
+func (c TomlConfig) ForCluster(cluster Cluster) TomlConfig {
+	clusterCfg := c
+	clusterCfg.Clusters = nil
+
+	if cluster.CircuitBreaker != nil {
+		clusterCfg.CircuitBreaker = *cluster.CircuitBreaker
+	}
+
+	if len(cluster.RuleSets) > 0 {
+		clusterCfg.RuleSets = cluster.RuleSets
+	}
+
+	return clusterCfg
+}
+
+// Validate checks that the clusters can be told apart and that an error code is
+// corroborated by a single composite rule.
+func (c TomlConfig) Validate() error {
+	names := make(map[string]bool, len(c.Clusters))
+	defaults := 0
+
+	for _, cluster := range c.Clusters {
+		if cluster.Name == "" {
+			return fmt.Errorf("cluster name must not be empty")
+		}
+
+		if names[cluster.Name] {
+			return fmt.Errorf("cluster %q is configured more than once", cluster.Name)
+		}
+
+		names[cluster.Name] = true
+
+		if cluster.Default {
+			defaults++
+		}
+	}
+
+	if defaults > 1 {
+		return fmt.Errorf("at most one cluster can be the default, %d are", defaults)
+	}
+
+	return c.validateCorroborations()
+}
+
+func (c TomlConfig) validateCorroborations() error {
+	rules := make(map[string]string)
+
+	for _, corroboration := range c.Corroborations {
+		if corroboration.CompositeRule == "" || len(corroboration.ErrorCodes) == 0 {
+			return fmt.Errorf("corroborations require a compositeRule and errorCodes")
+		}
+
+		for _, errorCode := range corroboration.ErrorCodes {
+			if rule, ok := rules[errorCode]; ok {
+				return fmt.Errorf("error code %s is corroborated by both %s and %s",
+					errorCode, rule, corroboration.CompositeRule)
+			}
+
+			rules[errorCode] = corroboration.CompositeRule
+		}
+	}
+
+	return nil
 }
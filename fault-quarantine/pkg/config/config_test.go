@@ -0,0 +1,83 @@
+// Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestForCluster(t *testing.T) {
+	cfg := TomlConfig{
+		LabelPrefix:    "k8saas.nvidia.com/",
+		CircuitBreaker: CircuitBreaker{Percentage: 50, Duration: "5m"},
+		RuleSets:       []RuleSet{{Name: "default"}},
+		Clusters:       []Cluster{{Name: "edge-1"}, {Name: "edge-2"}},
+	}
+
+	inherited := cfg.ForCluster(cfg.Clusters[0])
+	assert.Equal(t, cfg.CircuitBreaker, inherited.CircuitBreaker)
+	assert.Equal(t, cfg.RuleSets, inherited.RuleSets)
+	assert.Nil(t, inherited.Clusters)
+
+	overridden := cfg.ForCluster(Cluster{
+		Name:           "edge-2",
+		CircuitBreaker: &CircuitBreaker{Percentage: 25, Duration: "10m"},
+		RuleSets:       []RuleSet{{Name: "edge"}},
+	})
+	assert.Equal(t, 25, overridden.CircuitBreaker.Percentage)
+	assert.Equal(t, "edge", overridden.RuleSets[0].Name)
+	assert.Equal(t, cfg.LabelPrefix, overridden.LabelPrefix)
+
+	// The top level configuration is left alone
+	assert.Equal(t, 50, cfg.CircuitBreaker.Percentage)
+	assert.Equal(t, "default", cfg.RuleSets[0].Name)
+}
+
+func TestValidate(t *testing.T) {
+	assert.NoError(t, TomlConfig{}.Validate())
+	assert.NoError(t, TomlConfig{Clusters: []Cluster{{Name: "edge-1"}, {Name: "central", Default: true}}}.Validate())
+
+	invalid := [][]Cluster{
+		{{Name: ""}},
+		{{Name: "edge-1"}, {Name: "edge-1"}},
+		{{Name: "edge-1", Default: true}, {Name: "edge-2", Default: true}},
+	}
+
+	for _, clusters := range invalid {
+		assert.Error(t, TomlConfig{Clusters: clusters}.Validate(), clusters)
+	}
+}
+
+func TestValidateCorroborations(t *testing.T) {
+	assert.NoError(t, TomlConfig{Corroborations: []Corroboration{
+		{CompositeRule: "CorroboratedGPUFallenOffBus", ErrorCodes: []string{"79"}},
+		{CompositeRule: "CorroboratedDBE", ErrorCodes: []string{"48", "ECC_DBE"}},
+	}}.Validate())
+
+	invalid := [][]Corroboration{
+		{{ErrorCodes: []string{"79"}}},
+		{{CompositeRule: "CorroboratedGPUFallenOffBus"}},
+		{
+			{CompositeRule: "CorroboratedGPUFallenOffBus", ErrorCodes: []string{"79"}},
+			{CompositeRule: "CorroboratedPCIe", ErrorCodes: []string{"79"}},
+		},
+	}
+
+	for _, corroborations := range invalid {
+		assert.Error(t, TomlConfig{Corroborations: corroborations}.Validate(), corroborations)
+	}
+}
@@ -0,0 +1,203 @@
+// Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package embedded feeds the reconciler from an embedded store instead of a
+// MongoDB change stream, for deployments without MongoDB.
+package embedded
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/nvidia/nvsentinel/data-models/pkg/model"
+	"github.com/nvidia/nvsentinel/fault-quarantine/pkg/metrics"
+	"github.com/nvidia/nvsentinel/fault-quarantine/pkg/mongodb"
+	"github.com/nvidia/nvsentinel/store-client/pkg/store"
+)
+
+const (
+	// ClientName is the name the watcher records its position in the store under.
+	ClientName = "fault-quarantine"
+	// DefaultPollInterval is used when no poll interval is given.
+	DefaultPollInterval = time.Second
+)
+
+// EventWatcher passes the health events inserted into the store to the
+// reconciler and records the quarantine status it returns on them.
+type EventWatcher struct {
+	store                store.Store
+	pollInterval         time.Duration
+	processEventCallback func(ctx context.Context, event *model.HealthEventWithStatus) *model.Status
+}
+
+var _ mongodb.EventWatcherInterface = (*EventWatcher)(nil)
+
+// NewEventWatcher creates an EventWatcher polling the store every pollInterval.
+func NewEventWatcher(s store.Store, pollInterval time.Duration) *EventWatcher {
+	if pollInterval <= 0 {
+		pollInterval = DefaultPollInterval
+	}
+
+	return &EventWatcher{store: s, pollInterval: pollInterval}
+}
+
+func (w *EventWatcher) SetProcessEventCallback(
+	callback func(
+		ctx context.Context,
+		event *model.HealthEventWithStatus,
+	) *model.Status,
+) {
+	w.processEventCallback = callback
+}
+
+// Start processes the events until the context is cancelled. Like the MongoDB
+// watcher, events that fail to process are logged and skipped.
+func (w *EventWatcher) Start(ctx context.Context) error {
+	slog.Info("Starting embedded store event watcher", "pollInterval", w.pollInterval)
+
+	return store.Watch(ctx, w.store, ClientName, store.Filter{}, w.pollInterval,
+		func(ctx context.Context, record store.Record) error {
+			metrics.TotalEventsReceived.Inc()
+
+			if err := w.processEvent(ctx, record); err != nil {
+				slog.Error("Event processing failed, but still marking as processed to proceed ahead", "error", err)
+			}
+
+			return nil
+		})
+}
+
+func (w *EventWatcher) processEvent(ctx context.Context, record store.Record) error {
+	slog.Debug("Processing event", "id", record.ID, "event", record.HealthEventWithStatus)
+
+	startTime := time.Now()
+	status := w.processEventCallback(ctx, &record.HealthEventWithStatus)
+
+	if status != nil {
+		_, err := w.store.Update(ctx, store.Filter{IDs: []uint64{record.ID}}, func(s *model.HealthEventStatus) {
+			s.NodeQuarantined = status
+
+			if reason := record.HealthEventStatus.QuarantineReason; reason != nil {
+				s.QuarantineReason = reason
+			}
+		})
+		if err != nil {
+			metrics.ProcessingErrors.WithLabelValues("update_quarantine_status_error").Inc()
+			return fmt.Errorf("failed to update node quarantine status of event %d: %w", record.ID, err)
+		}
+	}
+
+	metrics.EventHandlingDuration.Observe(time.Since(startTime).Seconds())
+
+	return nil
+}
+
+// CancelLatestQuarantiningEvents cancels the events of the current quarantine
+// session of the node, as the MongoDB watcher does.
+func (w *EventWatcher) CancelLatestQuarantiningEvents(ctx context.Context, nodeName string) error {
+	latest, err := w.store.List(ctx, store.Filter{
+		NodeName:        nodeName,
+		NodeQuarantined: []model.Status{model.Quarantined, model.UnQuarantined},
+	}, store.ListOptions{Limit: 1, Descending: true})
+	if err != nil {
+		return fmt.Errorf("error finding latest quarantining event for node %s: %w", nodeName, err)
+	}
+
+	if len(latest) == 0 {
+		slog.Warn("No quarantining/unquarantining events found for node", "node", nodeName)
+		return nil
+	}
+
+	if *latest[0].HealthEventStatus.NodeQuarantined != model.Quarantined {
+		slog.Info("No latest quarantining event found for node, no events to cancel", "node", nodeName)
+		return nil
+	}
+
+	cancelled := model.Cancelled
+
+	updated, err := w.store.Update(ctx, store.Filter{
+		NodeName:        nodeName,
+		AfterID:         latest[0].ID - 1,
+		NodeQuarantined: []model.Status{model.Quarantined, model.AlreadyQuarantined},
+	}, func(s *model.HealthEventStatus) {
+		s.NodeQuarantined = &cancelled
+	})
+	if err != nil {
+		return fmt.Errorf("error cancelling quarantining events for node %s: %w", nodeName, err)
+	}
+
+	slog.Info("Updated quarantining events to cancelled status",
+		"node", nodeName,
+		"firstEventId", latest[0].ID,
+		"documentsUpdated", updated)
+
+	return nil
+}
+
+// ResolvePendingApproval records the status on the event with the ID pending
+// approval, as the MongoDB watcher does.
+func (w *EventWatcher) ResolvePendingApproval(
+	ctx context.Context,
+	eventID string,
+	status model.Status,
+	quarantineReason *model.QuarantineReason,
+) error {
+	records, err := w.store.List(ctx, store.Filter{
+		NodeQuarantined: []model.Status{model.PendingApproval},
+	}, store.ListOptions{})
+	if err != nil {
+		return fmt.Errorf("error finding event %s: %w", eventID, err)
+	}
+
+	for _, record := range records {
+		if record.HealthEvent.GetId() != eventID {
+			continue
+		}
+
+		_, err := w.store.Update(ctx, store.Filter{IDs: []uint64{record.ID}}, func(s *model.HealthEventStatus) {
+			s.NodeQuarantined = &status
+
+			if quarantineReason != nil {
+				s.QuarantineReason = quarantineReason
+			}
+		})
+		if err != nil {
+			return fmt.Errorf("error updating quarantine status of event %s: %w", eventID, err)
+		}
+
+		return nil
+	}
+
+	return fmt.Errorf("no event %s pending approval", eventID)
+}
+
+// PendingApprovalEvents returns the events of the cross-node incidents awaiting
+// approval, oldest first.
+func (w *EventWatcher) PendingApprovalEvents(ctx context.Context) ([]model.HealthEventWithStatus, error) {
+	records, err := w.store.List(ctx, store.Filter{
+		NodeQuarantined: []model.Status{model.PendingApproval},
+	}, store.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("error finding events pending approval: %w", err)
+	}
+
+	events := make([]model.HealthEventWithStatus, 0, len(records))
+	for _, record := range records {
+		events = append(events, record.HealthEventWithStatus)
+	}
+
+	return events, nil
+}
@@ -0,0 +1,187 @@
+// Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package embedded
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/nvidia/nvsentinel/data-models/pkg/model"
+	"github.com/nvidia/nvsentinel/data-models/pkg/protos"
+	"github.com/nvidia/nvsentinel/store-client/pkg/store"
+	"github.com/nvidia/nvsentinel/store-client/pkg/store/bolt"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func openTestStore(t *testing.T) *bolt.Store {
+	t.Helper()
+
+	s, err := bolt.Open(filepath.Join(t.TempDir(), "events.db"))
+	require.NoError(t, err)
+
+	t.Cleanup(func() { _ = s.Close() })
+
+	return s
+}
+
+func insert(t *testing.T, s store.Store, node string, status *model.Status) {
+	t.Helper()
+
+	_, err := s.Insert(context.Background(), []model.HealthEventWithStatus{{
+		CreatedAt:         time.Now(),
+		HealthEvent:       &protos.HealthEvent{NodeName: node, CheckName: "SysLogsXIDError"},
+		HealthEventStatus: model.HealthEventStatus{NodeQuarantined: status},
+	}})
+	require.NoError(t, err)
+}
+
+func statuses(t *testing.T, s store.Store) []model.Status {
+	t.Helper()
+
+	records, err := s.List(context.Background(), store.Filter{}, store.ListOptions{})
+	require.NoError(t, err)
+
+	result := make([]model.Status, 0, len(records))
+
+	for _, record := range records {
+		if record.HealthEventStatus.NodeQuarantined == nil {
+			result = append(result, "")
+			continue
+		}
+
+		result = append(result, *record.HealthEventStatus.NodeQuarantined)
+	}
+
+	return result
+}
+
+func status(s model.Status) *model.Status {
+	return &s
+}
+
+func TestStartRecordsStatus(t *testing.T) {
+	s := openTestStore(t)
+	insert(t, s, "node-1", nil)
+	insert(t, s, "node-1", nil)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	processed := 0
+	w := NewEventWatcher(s, time.Millisecond)
+	w.SetProcessEventCallback(func(_ context.Context, event *model.HealthEventWithStatus) *model.Status {
+		processed++
+		if processed == 2 {
+			cancel()
+			return nil
+		}
+
+		return status(model.Quarantined)
+	})
+
+	require.NoError(t, w.Start(ctx))
+	assert.Equal(t, []model.Status{model.Quarantined, ""}, statuses(t, s))
+
+	position, err := s.Position(context.Background(), ClientName)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(2), position)
+}
+
+func TestStartRecordsQuarantineReason(t *testing.T) {
+	s := openTestStore(t)
+	insert(t, s, "node-1", nil)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	reason := &model.QuarantineReason{
+		IncidentID: "01J0000000000000000000000",
+		CheckName:  "SysLogsXIDError",
+		ErrorCodes: []string{"79"},
+		RuleSets:   []string{"GPU fatal error ruleset"},
+		Cordoned:   true,
+		RunbookURL: "https://runbooks.example.com/xid-79",
+	}
+
+	w := NewEventWatcher(s, time.Millisecond)
+	w.SetProcessEventCallback(func(_ context.Context, event *model.HealthEventWithStatus) *model.Status {
+		defer cancel()
+
+		event.HealthEventStatus.QuarantineReason = reason
+
+		return status(model.Quarantined)
+	})
+
+	require.NoError(t, w.Start(ctx))
+
+	records, err := s.List(context.Background(), store.Filter{}, store.ListOptions{})
+	require.NoError(t, err)
+	require.Len(t, records, 1)
+	assert.Equal(t, reason, records[0].HealthEventStatus.QuarantineReason)
+}
+
+func TestCancelLatestQuarantiningEvents(t *testing.T) {
+	s := openTestStore(t)
+
+	// An earlier, released quarantine session and the current one
+	insert(t, s, "node-1", status(model.Quarantined))
+	insert(t, s, "node-1", status(model.UnQuarantined))
+	insert(t, s, "node-1", status(model.Quarantined))
+	insert(t, s, "node-2", status(model.Quarantined))
+	insert(t, s, "node-1", status(model.AlreadyQuarantined))
+
+	w := NewEventWatcher(s, time.Second)
+	require.NoError(t, w.CancelLatestQuarantiningEvents(context.Background(), "node-1"))
+
+	assert.Equal(t, []model.Status{
+		model.Quarantined, model.UnQuarantined, model.Cancelled, model.Quarantined, model.Cancelled,
+	}, statuses(t, s))
+
+	// Released nodes have nothing to cancel
+	require.NoError(t, w.CancelLatestQuarantiningEvents(context.Background(), "node-3"))
+}
+
+func TestResolvePendingApproval(t *testing.T) {
+	s := openTestStore(t)
+	insert(t, s, "node-1", status(model.Quarantined))
+
+	_, err := s.Insert(context.Background(), []model.HealthEventWithStatus{{
+		CreatedAt:         time.Now(),
+		HealthEvent:       &protos.HealthEvent{Id: "incident-1", NodeName: "node-2", CheckName: "NVSwitchTrayFailure"},
+		HealthEventStatus: model.HealthEventStatus{NodeQuarantined: status(model.PendingApproval)},
+	}})
+	require.NoError(t, err)
+
+	w := NewEventWatcher(s, time.Millisecond)
+
+	pending, err := w.PendingApprovalEvents(context.Background())
+	require.NoError(t, err)
+	require.Len(t, pending, 1)
+	assert.Equal(t, "incident-1", pending[0].HealthEvent.GetId())
+
+	reason := &model.QuarantineReason{IncidentID: "incident-1", AffectedNodes: []string{"node-2", "node-3"}}
+	require.NoError(t, w.ResolvePendingApproval(context.Background(), "incident-1", model.Quarantined, reason))
+	assert.Equal(t, []model.Status{model.Quarantined, model.Quarantined}, statuses(t, s))
+
+	require.Error(t, w.ResolvePendingApproval(context.Background(), "incident-1", model.Cancelled, nil),
+		"an incident is resolved once")
+
+	pending, err = w.PendingApprovalEvents(context.Background())
+	require.NoError(t, err)
+	assert.Empty(t, pending)
+}
@@ -0,0 +1,400 @@
+// Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package gc periodically removes health events that no longer describe the
+// current state of the cluster from the live health events collection: the
+// events of incidents that have been resolved by a healthy event, and the
+// quarantine records of nodes that have since been released. Removed documents
+// are optionally copied to an archive collection first.
+package gc
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/nvidia/nvsentinel/data-models/pkg/model"
+	"github.com/nvidia/nvsentinel/fault-quarantine/pkg/metrics"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+const (
+	DefaultInterval          = time.Hour
+	DefaultRetention         = 7 * 24 * time.Hour
+	DefaultArchiveCollection = "HealthEventsArchive"
+	DefaultBatchSize         = 500
+
+	ReasonResolvedIncident   = "resolved_incident"
+	ReasonReleasedQuarantine = "released_quarantine"
+
+	createdAt       = "createdAt"
+	nodeQuarantined = "healtheventstatus.nodequarantined"
+)
+
+// Store is the health events collection.
+type Store interface {
+	Aggregate(ctx context.Context, pipeline interface{}, opts ...*options.AggregateOptions) (*mongo.Cursor, error)
+	Find(ctx context.Context, filter interface{}, opts ...*options.FindOptions) (*mongo.Cursor, error)
+	DeleteMany(ctx context.Context, filter interface{}, opts ...*options.DeleteOptions) (*mongo.DeleteResult, error)
+}
+
+// Archive receives the documents before they are removed.
+type Archive interface {
+	InsertMany(ctx context.Context, documents []interface{},
+		opts ...*options.InsertManyOptions) (*mongo.InsertManyResult, error)
+}
+
+// Config configures a Collector.
+type Config struct {
+	// Interval is the time between two collections
+	Interval time.Duration
+	// Retention is how long documents stay in the collection after the
+	// incident was resolved or the node was released
+	Retention time.Duration
+	// BatchSize is the number of documents archived and deleted at once
+	BatchSize int
+}
+
+// Collector removes resolved incidents and released quarantine records.
+type Collector struct {
+	config  Config
+	store   Store
+	archive Archive
+	now     func() time.Time
+}
+
+// NewCollector creates a Collector. archive may be nil to delete documents
+// without archiving them.
+func NewCollector(config Config, store Store, archive Archive) *Collector {
+	if config.Interval <= 0 {
+		config.Interval = DefaultInterval
+	}
+
+	if config.Retention <= 0 {
+		config.Retention = DefaultRetention
+	}
+
+	if config.BatchSize <= 0 {
+		config.BatchSize = DefaultBatchSize
+	}
+
+	return &Collector{config: config, store: store, archive: archive, now: time.Now}
+}
+
+// Run collects every interval until the context is cancelled. Failed
+// collections are logged and retried at the next interval.
+func (c *Collector) Run(ctx context.Context) error {
+	slog.Info("Starting health events garbage collection",
+		"interval", c.config.Interval,
+		"retention", c.config.Retention,
+		"archive", c.archive != nil)
+
+	ticker := time.NewTicker(c.config.Interval)
+	defer ticker.Stop()
+
+	for {
+		if err := c.Collect(ctx); err != nil {
+			slog.Error("Health events garbage collection failed", "error", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// nodeState is the quarantine state of a node. The latest Quarantined or
+// UnQuarantined record is what the quarantine, drain and cancellation logic
+// read the current state of the node from, so it is never removed.
+type nodeState struct {
+	Node         string             `bson:"_id"`
+	LatestID     primitive.ObjectID `bson:"latestID"`
+	LatestStatus model.Status       `bson:"latestStatus"`
+	LatestAt     time.Time          `bson:"latestAt"`
+	ReleasedAt   *time.Time         `bson:"releasedAt"`
+}
+
+// protect restricts filter to the documents of the node that may be removed:
+// everything but the latest quarantine record and, while the node is
+// quarantined, the records of the current quarantine session.
+func (s nodeState) protect(filter bson.M) bson.M {
+	filter["_id"] = bson.M{"$ne": s.LatestID}
+
+	if s.LatestStatus == model.Quarantined {
+		filter[createdAt] = restrictBefore(filter[createdAt], s.LatestAt)
+	}
+
+	return filter
+}
+
+func restrictBefore(condition interface{}, before time.Time) bson.M {
+	restricted := bson.M{}
+
+	if existing, ok := condition.(bson.M); ok {
+		for op, value := range existing {
+			restricted[op] = value
+		}
+	}
+
+	if lt, ok := restricted["$lt"].(time.Time); !ok || before.Before(lt) {
+		restricted["$lt"] = before
+	}
+
+	return restricted
+}
+
+// Collect removes the documents that are past the retention once.
+func (c *Collector) Collect(ctx context.Context) error {
+	cutoff := c.now().Add(-c.config.Retention)
+
+	if err := c.collect(ctx, cutoff); err != nil {
+		metrics.GCRuns.WithLabelValues(metrics.StatusFailed).Inc()
+		return err
+	}
+
+	metrics.GCRuns.WithLabelValues(metrics.StatusPassed).Inc()
+
+	return nil
+}
+
+func (c *Collector) collect(ctx context.Context, cutoff time.Time) error {
+	nodes, err := c.nodeStates(ctx)
+	if err != nil {
+		return err
+	}
+
+	resolved, err := c.resolvedIncidents(ctx, cutoff, nodes)
+	if err != nil {
+		return err
+	}
+
+	for _, filter := range resolved {
+		if err := c.remove(ctx, filter, ReasonResolvedIncident); err != nil {
+			return err
+		}
+	}
+
+	for _, filter := range releasedQuarantines(cutoff, nodes) {
+		if err := c.remove(ctx, filter, ReasonReleasedQuarantine); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (c *Collector) nodeStates(ctx context.Context) (map[string]nodeState, error) {
+	pipeline := []bson.M{
+		{"$match": bson.M{nodeQuarantined: bson.M{"$in": []model.Status{model.Quarantined, model.UnQuarantined}}}},
+		{"$sort": bson.M{createdAt: -1}},
+		{"$group": bson.M{
+			"_id":          "$healthevent.nodename",
+			"latestID":     bson.M{"$first": "$_id"},
+			"latestStatus": bson.M{"$first": "$" + nodeQuarantined},
+			"latestAt":     bson.M{"$first": "$" + createdAt},
+			"releasedAt": bson.M{"$max": bson.M{"$cond": bson.A{
+				bson.M{"$eq": bson.A{"$" + nodeQuarantined, model.UnQuarantined}},
+				"$" + createdAt,
+				nil,
+			}}},
+		}},
+	}
+
+	cursor, err := c.store.Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, fmt.Errorf("failed to aggregate node quarantine states: %w", err)
+	}
+
+	defer cursor.Close(ctx)
+
+	var states []nodeState
+	if err := cursor.All(ctx, &states); err != nil {
+		return nil, fmt.Errorf("failed to decode node quarantine states: %w", err)
+	}
+
+	nodes := make(map[string]nodeState, len(states))
+	for _, state := range states {
+		nodes[state.Node] = state
+	}
+
+	return nodes, nil
+}
+
+// resolvedIncidents returns a filter per incident, a check of an agent on the
+// same entities of a node, that was resolved by a healthy event before the
+// cutoff. The filters select the events of the incident up to its resolution.
+func (c *Collector) resolvedIncidents(
+	ctx context.Context,
+	cutoff time.Time,
+	nodes map[string]nodeState,
+) ([]bson.M, error) {
+	pipeline := []bson.M{
+		{"$match": bson.M{"healthevent.ishealthy": true, createdAt: bson.M{"$lt": cutoff}}},
+		{"$group": bson.M{
+			"_id": bson.M{
+				"node":     "$healthevent.nodename",
+				"agent":    "$healthevent.agent",
+				"check":    "$healthevent.checkname",
+				"entities": "$healthevent.entitiesimpacted",
+			},
+			"resolvedAt": bson.M{"$max": "$" + createdAt},
+		}},
+	}
+
+	cursor, err := c.store.Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, fmt.Errorf("failed to aggregate resolved incidents: %w", err)
+	}
+
+	defer cursor.Close(ctx)
+
+	var incidents []struct {
+		ID struct {
+			Node     string        `bson:"node"`
+			Agent    string        `bson:"agent"`
+			Check    string        `bson:"check"`
+			Entities bson.RawValue `bson:"entities"`
+		} `bson:"_id"`
+		ResolvedAt time.Time `bson:"resolvedAt"`
+	}
+
+	if err := cursor.All(ctx, &incidents); err != nil {
+		return nil, fmt.Errorf("failed to decode resolved incidents: %w", err)
+	}
+
+	filters := make([]bson.M, 0, len(incidents))
+
+	for _, incident := range incidents {
+		// Events without entities are grouped without the field
+		var entities interface{}
+		if incident.ID.Entities.Type != 0 {
+			entities = incident.ID.Entities
+		}
+
+		filter := bson.M{
+			"healthevent.nodename":         incident.ID.Node,
+			"healthevent.agent":            incident.ID.Agent,
+			"healthevent.checkname":        incident.ID.Check,
+			"healthevent.entitiesimpacted": entities,
+			createdAt:                      bson.M{"$lte": incident.ResolvedAt},
+		}
+
+		if state, ok := nodes[incident.ID.Node]; ok {
+			filter = state.protect(filter)
+		}
+
+		filters = append(filters, filter)
+	}
+
+	return filters, nil
+}
+
+// releasedQuarantines returns filters for the quarantine records of nodes that
+// were released before the cutoff, and for cancelled records older than it.
+func releasedQuarantines(cutoff time.Time, nodes map[string]nodeState) []bson.M {
+	filters := []bson.M{{
+		nodeQuarantined: model.Cancelled,
+		createdAt:       bson.M{"$lt": cutoff},
+	}}
+
+	for node, state := range nodes {
+		if state.ReleasedAt == nil {
+			continue
+		}
+
+		before := cutoff
+		if state.ReleasedAt.Before(before) {
+			before = *state.ReleasedAt
+		}
+
+		filters = append(filters, state.protect(bson.M{
+			"healthevent.nodename": node,
+			nodeQuarantined: bson.M{"$in": []model.Status{
+				model.Quarantined, model.AlreadyQuarantined, model.UnQuarantined,
+			}},
+			createdAt: bson.M{"$lt": before},
+		}))
+	}
+
+	return filters
+}
+
+// remove archives and deletes the documents matching filter in batches.
+func (c *Collector) remove(ctx context.Context, filter bson.M, reason string) error {
+	for {
+		findOptions := options.Find().
+			SetSort(bson.D{{Key: "_id", Value: 1}}).
+			SetLimit(int64(c.config.BatchSize))
+
+		cursor, err := c.store.Find(ctx, filter, findOptions)
+		if err != nil {
+			return fmt.Errorf("failed to find documents to collect: %w", err)
+		}
+
+		var documents []bson.Raw
+		if err := cursor.All(ctx, &documents); err != nil {
+			return fmt.Errorf("failed to decode documents to collect: %w", err)
+		}
+
+		if len(documents) == 0 {
+			return nil
+		}
+
+		if err := c.removeBatch(ctx, documents, reason); err != nil {
+			return err
+		}
+
+		if len(documents) < c.config.BatchSize {
+			return nil
+		}
+	}
+}
+
+func (c *Collector) removeBatch(ctx context.Context, documents []bson.Raw, reason string) error {
+	ids := make(bson.A, 0, len(documents))
+	batch := make([]interface{}, 0, len(documents))
+
+	for _, document := range documents {
+		ids = append(ids, document.Lookup("_id"))
+		batch = append(batch, document)
+	}
+
+	if c.archive != nil {
+		// Documents archived by an earlier run that failed to delete them
+		// already exist in the archive
+		_, err := c.archive.InsertMany(ctx, batch, options.InsertMany().SetOrdered(false))
+		if err != nil && !mongo.IsDuplicateKeyError(err) {
+			return fmt.Errorf("failed to archive %d documents: %w", len(batch), err)
+		}
+
+		metrics.GCDocumentsArchived.Add(float64(len(batch)))
+	}
+
+	result, err := c.store.DeleteMany(ctx, bson.M{"_id": bson.M{"$in": ids}})
+	if err != nil {
+		return fmt.Errorf("failed to delete %d collected documents: %w", len(ids), err)
+	}
+
+	metrics.GCDocumentsDeleted.WithLabelValues(reason).Add(float64(result.DeletedCount))
+
+	slog.Info("Collected health events", "reason", reason, "deleted", result.DeletedCount)
+
+	return nil
+}
@@ -0,0 +1,262 @@
+// Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gc
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/nvidia/nvsentinel/data-models/pkg/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+var testNow = time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+
+type fakeStore struct {
+	nodeStates []bson.M
+	incidents  []bson.M
+	// found is returned by Find for filters on the given node, or on the
+	// status for node independent filters
+	found   map[string][]bson.M
+	finds   []bson.M
+	deletes []bson.M
+}
+
+func cursorOf(documents []bson.M) (*mongo.Cursor, error) {
+	docs := make([]interface{}, 0, len(documents))
+
+	for _, document := range documents {
+		data, err := bson.Marshal(document)
+		if err != nil {
+			return nil, err
+		}
+
+		docs = append(docs, bson.Raw(data))
+	}
+
+	return mongo.NewCursorFromDocuments(docs, nil, nil)
+}
+
+func (f *fakeStore) Aggregate(_ context.Context, pipeline interface{},
+	_ ...*options.AggregateOptions) (*mongo.Cursor, error) {
+	match := pipeline.([]bson.M)[0]["$match"].(bson.M)
+	if _, ok := match["healthevent.ishealthy"]; ok {
+		return cursorOf(f.incidents)
+	}
+
+	return cursorOf(f.nodeStates)
+}
+
+func (f *fakeStore) Find(_ context.Context, filter interface{}, _ ...*options.FindOptions) (*mongo.Cursor, error) {
+	query := filter.(bson.M)
+	f.finds = append(f.finds, query)
+
+	key, ok := query["healthevent.nodename"].(string)
+	if !ok {
+		key = string(query[nodeQuarantined].(model.Status))
+	}
+
+	found := f.found[key]
+	delete(f.found, key)
+
+	return cursorOf(found)
+}
+
+func (f *fakeStore) DeleteMany(_ context.Context, filter interface{},
+	_ ...*options.DeleteOptions) (*mongo.DeleteResult, error) {
+	query := filter.(bson.M)
+	f.deletes = append(f.deletes, query)
+
+	return &mongo.DeleteResult{DeletedCount: int64(len(query["_id"].(bson.M)["$in"].(bson.A)))}, nil
+}
+
+type fakeArchive struct {
+	inserted []interface{}
+	err      error
+}
+
+func (f *fakeArchive) InsertMany(_ context.Context, documents []interface{},
+	_ ...*options.InsertManyOptions) (*mongo.InsertManyResult, error) {
+	f.inserted = append(f.inserted, documents...)
+
+	return &mongo.InsertManyResult{}, f.err
+}
+
+func newTestCollector(store *fakeStore, archive Archive) *Collector {
+	c := NewCollector(Config{Retention: 24 * time.Hour}, store, archive)
+	c.now = func() time.Time { return testNow }
+
+	return c
+}
+
+func TestCollectResolvedIncidents(t *testing.T) {
+	anchor := primitive.NewObjectID()
+	quarantinedAt := testNow.Add(-72 * time.Hour)
+	resolvedAt := testNow.Add(-48 * time.Hour)
+	event := primitive.NewObjectID()
+
+	store := &fakeStore{
+		nodeStates: []bson.M{{
+			"_id":          "node-1",
+			"latestID":     anchor,
+			"latestStatus": model.Quarantined,
+			"latestAt":     quarantinedAt,
+		}},
+		incidents: []bson.M{{
+			"_id": bson.M{
+				"node":     "node-1",
+				"agent":    "gpu-health-monitor",
+				"check":    "GpuXidError",
+				"entities": bson.A{bson.M{"entitytype": "GPU", "entityvalue": "0"}},
+			},
+			"resolvedAt": resolvedAt,
+		}},
+		found: map[string][]bson.M{"node-1": {{"_id": event}}},
+	}
+	archive := &fakeArchive{}
+
+	require.NoError(t, newTestCollector(store, archive).Collect(context.Background()))
+
+	require.Len(t, store.finds, 2)
+
+	filter := store.finds[0]
+	assert.Equal(t, "GpuXidError", filter["healthevent.checkname"])
+	assert.NotNil(t, filter["healthevent.entitiesimpacted"])
+	assert.Equal(t, bson.M{"$ne": anchor}, filter["_id"])
+	// The node is still quarantined, so the current session is kept
+	assert.Equal(t, bson.M{"$lte": resolvedAt, "$lt": quarantinedAt}, filter[createdAt])
+
+	// The node was never released, only cancelled records are collected
+	assert.Equal(t, model.Cancelled, store.finds[1][nodeQuarantined])
+
+	require.Len(t, archive.inserted, 1)
+	require.Len(t, store.deletes, 1)
+	assert.Equal(t, event, store.deletes[0]["_id"].(bson.M)["$in"].(bson.A)[0].(bson.RawValue).ObjectID())
+}
+
+func TestCollectReleasedQuarantines(t *testing.T) {
+	anchor := primitive.NewObjectID()
+	releasedAt := testNow.Add(-72 * time.Hour)
+	recentRelease := testNow.Add(-time.Hour)
+
+	store := &fakeStore{
+		nodeStates: []bson.M{
+			{
+				"_id":          "node-1",
+				"latestID":     anchor,
+				"latestStatus": model.UnQuarantined,
+				"latestAt":     releasedAt,
+				"releasedAt":   releasedAt,
+			},
+			{
+				"_id":          "node-2",
+				"latestID":     primitive.NewObjectID(),
+				"latestStatus": model.UnQuarantined,
+				"latestAt":     recentRelease,
+				"releasedAt":   recentRelease,
+			},
+			{
+				"_id":          "node-3",
+				"latestID":     primitive.NewObjectID(),
+				"latestStatus": model.Quarantined,
+				"latestAt":     testNow.Add(-96 * time.Hour),
+			},
+		},
+		found: map[string][]bson.M{
+			"node-1":                {{"_id": primitive.NewObjectID()}, {"_id": primitive.NewObjectID()}},
+			string(model.Cancelled): {{"_id": primitive.NewObjectID()}},
+		},
+	}
+
+	require.NoError(t, newTestCollector(store, nil).Collect(context.Background()))
+
+	filters := map[string]bson.M{}
+	for _, filter := range store.finds {
+		if node, ok := filter["healthevent.nodename"].(string); ok {
+			filters[node] = filter
+		}
+	}
+
+	// Records are kept for the retention after the release
+	require.Contains(t, filters, "node-1")
+	assert.Equal(t, bson.M{"$lt": releasedAt}, filters["node-1"][createdAt])
+	assert.Equal(t, bson.M{"$ne": anchor}, filters["node-1"]["_id"])
+	require.Contains(t, filters, "node-2")
+	assert.Equal(t, bson.M{"$lt": testNow.Add(-24 * time.Hour)}, filters["node-2"][createdAt])
+	// Nodes that were never released are left alone
+	assert.NotContains(t, filters, "node-3")
+
+	require.Len(t, store.deletes, 2)
+	assert.Len(t, store.deletes[0]["_id"].(bson.M)["$in"], 1)
+	assert.Len(t, store.deletes[1]["_id"].(bson.M)["$in"], 2)
+}
+
+func TestCollectBatches(t *testing.T) {
+	store := &fakeStore{found: map[string][]bson.M{}}
+	c := newTestCollector(store, nil)
+	c.config.BatchSize = 2
+
+	batches := [][]bson.M{
+		{{"_id": 1}, {"_id": 2}},
+		{{"_id": 3}},
+	}
+	calls := 0
+
+	finder := &batchStore{fakeStore: store, next: func() []bson.M {
+		calls++
+		if calls > len(batches) {
+			return nil
+		}
+
+		return batches[calls-1]
+	}}
+	c.store = finder
+
+	require.NoError(t, c.remove(context.Background(), bson.M{"healthevent.nodename": "node-1"}, ReasonResolvedIncident))
+	assert.Equal(t, 2, calls)
+	assert.Len(t, store.deletes, 2)
+}
+
+type batchStore struct {
+	*fakeStore
+	next func() []bson.M
+}
+
+func (b *batchStore) Find(_ context.Context, _ interface{}, _ ...*options.FindOptions) (*mongo.Cursor, error) {
+	return cursorOf(b.next())
+}
+
+func TestCollectKeepsDocumentsWhenArchivingFails(t *testing.T) {
+	store := &fakeStore{found: map[string][]bson.M{string(model.Cancelled): {{"_id": 1}}}}
+	archive := &fakeArchive{err: errors.New("archive unavailable")}
+
+	assert.Error(t, newTestCollector(store, archive).Collect(context.Background()))
+	assert.Empty(t, store.deletes)
+}
+
+func TestRestrictBefore(t *testing.T) {
+	earlier := testNow.Add(-time.Hour)
+
+	assert.Equal(t, bson.M{"$lt": earlier}, restrictBefore(bson.M{"$lt": testNow}, earlier))
+	assert.Equal(t, bson.M{"$lt": earlier}, restrictBefore(bson.M{"$lt": earlier}, testNow))
+	assert.Equal(t, bson.M{"$lt": earlier}, restrictBefore(nil, earlier))
+}
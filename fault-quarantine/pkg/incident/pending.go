@@ -0,0 +1,136 @@
+// Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package incident holds the cross-node incidents awaiting approval. An incident
+// affecting several nodes, e.g. a switch tray failure behind many compute trays,
+// quarantines all of them together once a single approval is given for it.
+package incident
+
+import (
+	"errors"
+	"slices"
+	"sync"
+
+	"github.com/nvidia/nvsentinel/data-models/pkg/model"
+	"github.com/nvidia/nvsentinel/data-models/pkg/protos"
+	"github.com/nvidia/nvsentinel/fault-quarantine/pkg/config"
+	"github.com/nvidia/nvsentinel/fault-quarantine/pkg/metrics"
+)
+
+var (
+	// ErrNotPending is returned when approving an incident that is not awaiting approval
+	ErrNotPending = errors.New("incident is not pending approval")
+	// ErrNodeNotAffected is returned when an incident is approved on a node it does not affect
+	ErrNodeNotAffected = errors.New("incident does not affect the node")
+)
+
+// Incident is the quarantine of the nodes of a cross-node incident, decided by the
+// rulesets for the event that opened it.
+type Incident struct {
+	// Event opened the incident, its ID is the ID of the incident
+	Event *model.HealthEventWithStatus
+	// Nodes are all the nodes the incident affects, sorted
+	Nodes  []string
+	Taints []config.Taint
+	Cordon bool
+	// Annotations and Labels are set on every node, next to the health event
+	// annotation of the node
+	Annotations map[string]string
+	Labels      map[string]string
+	Reason      *model.QuarantineReason
+}
+
+// ID returns the ID of the incident.
+func (i *Incident) ID() string {
+	return i.Event.HealthEvent.GetId()
+}
+
+// Affects reports whether the incident affects the node.
+func (i *Incident) Affects(nodeName string) bool {
+	_, found := slices.BinarySearch(i.Nodes, nodeName)
+	return found
+}
+
+// Pending holds the incidents awaiting approval by ID. It is safe for concurrent use.
+type Pending struct {
+	mu        sync.Mutex
+	incidents map[string]*Incident
+}
+
+// NewPending returns an empty Pending.
+func NewPending() *Pending {
+	return &Pending{incidents: map[string]*Incident{}}
+}
+
+// Hold adds the incident, replacing a held incident with the same ID.
+func (p *Pending) Hold(incident *Incident) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.incidents[incident.ID()] = incident
+	metrics.CrossNodeIncidentsPendingApproval.Set(float64(len(p.incidents)))
+}
+
+// Approve removes and returns the incident approved on the node. The approval is
+// rejected when the incident does not affect the node.
+func (p *Pending) Approve(incidentID, nodeName string) (*Incident, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	incident, ok := p.incidents[incidentID]
+	if !ok {
+		return nil, ErrNotPending
+	}
+
+	if !incident.Affects(nodeName) {
+		return nil, ErrNodeNotAffected
+	}
+
+	delete(p.incidents, incidentID)
+	metrics.CrossNodeIncidentsPendingApproval.Set(float64(len(p.incidents)))
+
+	return incident, nil
+}
+
+// Resolve removes and returns the incidents a healthy event recovers: those opened
+// by the same check of the same agent that affect the node of the event.
+func (p *Pending) Resolve(event *protos.HealthEvent) []*Incident {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	var resolved []*Incident
+
+	for id, incident := range p.incidents {
+		opening := incident.Event.HealthEvent
+		if opening.GetAgent() != event.GetAgent() || opening.GetCheckName() != event.GetCheckName() ||
+			!incident.Affects(event.GetNodeName()) {
+			continue
+		}
+
+		resolved = append(resolved, incident)
+		delete(p.incidents, id)
+	}
+
+	metrics.CrossNodeIncidentsPendingApproval.Set(float64(len(p.incidents)))
+
+	return resolved
+}
+
+// Len returns the number of incidents awaiting approval.
+func (p *Pending) Len() int {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	return len(p.incidents)
+}
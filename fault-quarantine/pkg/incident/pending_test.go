@@ -0,0 +1,77 @@
+// Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package incident
+
+import (
+	"testing"
+
+	"github.com/nvidia/nvsentinel/data-models/pkg/model"
+	"github.com/nvidia/nvsentinel/data-models/pkg/protos"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newIncident(id string, nodes ...string) *Incident {
+	return &Incident{
+		Event: &model.HealthEventWithStatus{HealthEvent: &protos.HealthEvent{
+			Id:        id,
+			Agent:     "fabric-health-monitor",
+			CheckName: "NVSwitchTrayFailure",
+			NodeName:  nodes[0],
+		}},
+		Nodes: nodes,
+	}
+}
+
+func TestApprove(t *testing.T) {
+	pending := NewPending()
+	pending.Hold(newIncident("incident-1", "node-1", "node-2", "node-3"))
+
+	_, err := pending.Approve("incident-2", "node-1")
+	require.ErrorIs(t, err, ErrNotPending)
+
+	_, err = pending.Approve("incident-1", "node-4")
+	require.ErrorIs(t, err, ErrNodeNotAffected)
+	assert.Equal(t, 1, pending.Len(), "a rejected approval keeps the incident pending")
+
+	incident, err := pending.Approve("incident-1", "node-3")
+	require.NoError(t, err)
+	assert.Equal(t, "incident-1", incident.ID())
+	assert.Equal(t, 0, pending.Len())
+
+	_, err = pending.Approve("incident-1", "node-3")
+	require.ErrorIs(t, err, ErrNotPending, "an incident is approved once")
+}
+
+func TestResolve(t *testing.T) {
+	pending := NewPending()
+	pending.Hold(newIncident("incident-1", "node-1", "node-2"))
+	pending.Hold(newIncident("incident-2", "node-3", "node-4"))
+
+	healthy := &protos.HealthEvent{
+		Agent:     "fabric-health-monitor",
+		CheckName: "NVSwitchTrayFailure",
+		NodeName:  "node-2",
+		IsHealthy: true,
+	}
+
+	otherCheck := &protos.HealthEvent{Agent: healthy.Agent, CheckName: "NVLinkFabricPartition", NodeName: "node-2"}
+	assert.Empty(t, pending.Resolve(otherCheck), "events of other checks resolve nothing")
+
+	resolved := pending.Resolve(healthy)
+	require.Len(t, resolved, 1)
+	assert.Equal(t, "incident-1", resolved[0].ID())
+	assert.Equal(t, 1, pending.Len())
+}
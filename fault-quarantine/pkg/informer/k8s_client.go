@@ -118,6 +118,25 @@ func (c *FaultQuarantineClient) GetTotalNodes(ctx context.Context) (int, error)
 	return totalNodes, nil
 }
 
+// GetNode returns the node from the informer's cache, or from the API server when the
+// cache misses it. API server errors other than NotFound are retried.
+func (c *FaultQuarantineClient) GetNode(ctx context.Context, nodeName string) (*v1.Node, error) {
+	var node *v1.Node
+
+	err := retry.OnError(customBackoff, func(err error) bool { return !errors.IsNotFound(err) }, func() error {
+		var err error
+
+		node, err = c.NodeInformer.FetchNode(ctx, nodeName)
+
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return node, nil
+}
+
 func (c *FaultQuarantineClient) SetLabelKeys(cordonedReasonKey, uncordonedReasonKey string) {
 	c.cordonedReasonLabelKey = cordonedReasonKey
 	c.uncordonedReasonLabelKey = uncordonedReasonKey
@@ -129,25 +148,13 @@ func (c *FaultQuarantineClient) UpdateNode(ctx context.Context, nodeName string,
 
 	defer mu.(*sync.Mutex).Unlock()
 
-	return retry.OnError(retry.DefaultBackoff, errors.IsConflict, func() error {
-		node, err := c.Clientset.CoreV1().Nodes().Get(ctx, nodeName, metav1.GetOptions{})
-		if err != nil {
-			return err
-		}
-
-		if err := updateFn(node); err != nil {
-			return err
-		}
-
-		_, err = c.Clientset.CoreV1().Nodes().Update(ctx, node, metav1.UpdateOptions{})
-		if err != nil {
-			return err
-		}
+	if err := c.NodeInformer.UpdateNode(ctx, nodeName, updateFn); err != nil {
+		return err
+	}
 
-		slog.Debug("Updated node", "node", nodeName)
+	slog.Debug("Updated node", "node", nodeName)
 
-		return nil
-	})
+	return nil
 }
 
 func (c *FaultQuarantineClient) ReadCircuitBreakerState(
@@ -25,6 +25,7 @@ import (
 	"github.com/nvidia/nvsentinel/fault-quarantine/pkg/config"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/wait"
 	"k8s.io/client-go/kubernetes"
@@ -702,3 +703,34 @@ func TestUnTaintAndUnCordonNode_NonExistentNode(t *testing.T) {
 		t.Errorf("Expected error for non-existent node, got nil")
 	}
 }
+
+func TestGetNode_FallsBackToAPIServer(t *testing.T) {
+	ctx := context.Background()
+
+	nodeName := "get-node-fallback-" + primitive.NewObjectID().Hex()[:6]
+	createTestNode(ctx, t, nodeName, nil, nil, nil, false)
+	defer func() {
+		_ = testClient.CoreV1().Nodes().Delete(ctx, nodeName, metav1.DeleteOptions{})
+	}()
+
+	// an informer that never ran has nothing cached
+	nodeInformer, err := NewNodeInformer(testClient, 0)
+	if err != nil {
+		t.Fatalf("Failed to create NodeInformer: %v", err)
+	}
+
+	k8sClient := &FaultQuarantineClient{Clientset: testClient, NodeInformer: nodeInformer}
+
+	node, err := k8sClient.GetNode(ctx, nodeName)
+	if err != nil {
+		t.Fatalf("Expected node to be read from the API server, got %v", err)
+	}
+
+	if node.Name != nodeName {
+		t.Errorf("Expected node %s, got %s", nodeName, node.Name)
+	}
+
+	if _, err := k8sClient.GetNode(ctx, "no-such-node"); !errors.IsNotFound(err) {
+		t.Errorf("Expected NotFound for non-existent node, got %v", err)
+	}
+}
@@ -20,6 +20,7 @@ import (
 	"log/slog"
 	"time"
 
+	"github.com/nvidia/nvsentinel/commons/pkg/nodecache"
 	"github.com/nvidia/nvsentinel/fault-quarantine/pkg/common"
 	v1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/labels"
@@ -39,12 +40,17 @@ type NodeInformer struct {
 	informer       cache.SharedIndexInformer
 	lister         corelisters.NodeLister
 	informerSynced cache.InformerSynced
+	// nodes serves node reads from the informer, overlaid with this process' own writes
+	nodes *nodecache.Cache
 
 	// onQuarantinedNodeDeleted is called when a quarantined node with annotations is deleted
 	onQuarantinedNodeDeleted func(nodeName string)
 
 	// onManualUncordon is called when a node is manually uncordoned while having FQ annotations
 	onManualUncordon func(nodeName string) error
+
+	// onIncidentApproved is called when a cross-node incident is approved on a node
+	onIncidentApproved func(nodeName, incidentID string) error
 }
 
 // Lister returns the informer's node lister.
@@ -70,6 +76,7 @@ func NewNodeInformer(clientset kubernetes.Interface,
 	ni.informer = nodeInformerObj.Informer()
 	ni.lister = nodeInformerObj.Lister()
 	ni.informerSynced = nodeInformerObj.Informer().HasSynced
+	ni.nodes = nodecache.New(clientset, ni.informer)
 
 	err := ni.informer.AddIndexers(cache.Indexers{
 		quarantineAnnotationIndexName: quarantineAnnotationIndexFunc,
@@ -167,9 +174,22 @@ func (ni *NodeInformer) GetNodeCounts() (totalNodes int, quarantinedNodesMap map
 	return total, quarantinedMap, nil
 }
 
-// GetNode retrieves a node from the informer's cache.
+// GetNode retrieves a node from the informer's cache, including the writes of UpdateNode
+// the informer has not observed yet. The node must not be modified.
 func (ni *NodeInformer) GetNode(name string) (*v1.Node, error) {
-	return ni.lister.Get(name)
+	return ni.nodes.Cached(name)
+}
+
+// FetchNode returns a copy of the node from the informer's cache, or from the API server
+// when the cache does not have it.
+func (ni *NodeInformer) FetchNode(ctx context.Context, name string) (*v1.Node, error) {
+	return ni.nodes.Get(ctx, name)
+}
+
+// UpdateNode applies updateFn to the cached node and writes it when it changed, reading
+// the node from the API server when the cached one is missing or stale.
+func (ni *NodeInformer) UpdateNode(ctx context.Context, name string, updateFn func(*v1.Node) error) error {
+	return ni.nodes.Update(ctx, name, updateFn)
 }
 
 // ListNodes lists all nodes from the informer's cache.
@@ -233,9 +253,34 @@ func (ni *NodeInformer) detectAndHandleManualUncordon(oldNode, newNode *v1.Node)
 	return true
 }
 
-// handleUpdateNode detects and handles manual uncordon of quarantined nodes.
+// detectIncidentApproval passes the cross-node incident approved on the node to the
+// incident approval callback
+func (ni *NodeInformer) detectIncidentApproval(oldNode, newNode *v1.Node) {
+	incidentID := newNode.Annotations[common.QuarantineIncidentApprovalAnnotationKey]
+	if incidentID == "" || incidentID == oldNode.Annotations[common.QuarantineIncidentApprovalAnnotationKey] {
+		return
+	}
+
+	slog.Info("Detected approval of cross-node incident", "node", newNode.Name, "incident", incidentID)
+
+	if ni.onIncidentApproved == nil {
+		slog.Warn("Incident approval callback not registered - approval will not be handled",
+			"node", newNode.Name, "incident", incidentID)
+
+		return
+	}
+
+	if err := ni.onIncidentApproved(newNode.Name, incidentID); err != nil {
+		slog.Error("Failed to handle approval of cross-node incident",
+			"node", newNode.Name, "incident", incidentID, "error", err)
+	}
+}
+
+// handleUpdateNode detects and handles manual uncordon of quarantined nodes and
+// approvals of cross-node incidents.
 func (ni *NodeInformer) handleUpdateNode(oldNode, newNode *v1.Node) {
 	ni.detectAndHandleManualUncordon(oldNode, newNode)
+	ni.detectIncidentApproval(oldNode, newNode)
 }
 
 // SetOnQuarantinedNodeDeletedCallback sets the callback function for when a quarantined node is deleted
@@ -248,6 +293,12 @@ func (ni *NodeInformer) SetOnManualUncordonCallback(callback func(nodeName strin
 	ni.onManualUncordon = callback
 }
 
+// SetOnIncidentApprovedCallback sets the callback function for when a cross-node
+// incident is approved on a node
+func (ni *NodeInformer) SetOnIncidentApprovedCallback(callback func(nodeName, incidentID string) error) {
+	ni.onIncidentApproved = callback
+}
+
 // handleDeleteNode handles node deletion events.
 func (ni *NodeInformer) handleDeleteNode(obj interface{}) {
 	node, ok := obj.(*v1.Node)
@@ -22,11 +22,15 @@ import (
 	"time"
 
 	"github.com/nvidia/nvsentinel/commons/pkg/configmanager"
+	"github.com/nvidia/nvsentinel/data-models/pkg/model"
 	"github.com/nvidia/nvsentinel/fault-quarantine/pkg/breaker"
 	"github.com/nvidia/nvsentinel/fault-quarantine/pkg/config"
+	"github.com/nvidia/nvsentinel/fault-quarantine/pkg/embedded"
+	"github.com/nvidia/nvsentinel/fault-quarantine/pkg/gc"
 	"github.com/nvidia/nvsentinel/fault-quarantine/pkg/informer"
 	"github.com/nvidia/nvsentinel/fault-quarantine/pkg/mongodb"
 	"github.com/nvidia/nvsentinel/fault-quarantine/pkg/reconciler"
+	"github.com/nvidia/nvsentinel/store-client/pkg/store"
 	"github.com/nvidia/nvsentinel/store-client/pkg/storewatcher"
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/mongo"
@@ -37,11 +41,32 @@ type InitializationParams struct {
 	TomlConfigPath        string
 	DryRun                bool
 	CircuitBreakerEnabled bool
+	// EmbeddedStore replaces MongoDB as the source of health events when set
+	EmbeddedStore store.Store
+	// EmbeddedPollInterval is how often the embedded store is polled for new
+	// events
+	EmbeddedPollInterval time.Duration
 }
 
+// Components are the components of the module. The module quarantines the
+// cluster it runs in, or each of the configured clusters.
 type Components struct {
+	// Config is the loaded configuration, before the per cluster overrides
+	Config   *config.TomlConfig
+	Clusters []*ClusterComponents
+	// GarbageCollector is nil when garbage collection is disabled
+	GarbageCollector *gc.Collector
+}
+
+// ClusterComponents quarantine the nodes of one cluster. Each cluster has its
+// own rule sets, circuit breaker and change stream resume token, so that the
+// clusters are isolated from each other.
+type ClusterComponents struct {
+	// Name is empty for the cluster the module runs in when no clusters are
+	// configured
+	Name           string
 	Reconciler     *reconciler.Reconciler
-	EventWatcher   *mongodb.EventWatcher
+	EventWatcher   mongodb.EventWatcherInterface
 	K8sClient      *informer.FaultQuarantineClient
 	CircuitBreaker breaker.CircuitBreaker
 }
@@ -49,36 +74,153 @@ type Components struct {
 func InitializeAll(ctx context.Context, params InitializationParams) (*Components, error) {
 	slog.Info("Starting fault quarantine module initialization")
 
+	var tomlCfg config.TomlConfig
+	if err := configmanager.LoadTOMLConfig(params.TomlConfigPath, &tomlCfg); err != nil {
+		return nil, fmt.Errorf("error while loading the toml config: %w", err)
+	}
+
+	if err := tomlCfg.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid toml config: %w", err)
+	}
+
+	if params.DryRun {
+		slog.Info("Running in dry-run mode")
+	}
+
+	if params.EmbeddedStore != nil {
+		return initializeEmbedded(ctx, params, tomlCfg)
+	}
+
 	mongoConfig, tokenConfig, err := storewatcher.LoadConfigFromEnv("fault-quarantine")
 	if err != nil {
 		return nil, fmt.Errorf("failed to load MongoDB configuration: %w", err)
 	}
 
-	pipeline := createMongoPipeline()
+	healthEventCollection, err := initializeMongoCollection(ctx, mongoConfig)
+	if err != nil {
+		return nil, fmt.Errorf("error while initializing mongo collection: %w", err)
+	}
+
+	clusters := tomlCfg.Clusters
+	if len(clusters) == 0 {
+		clusters = []config.Cluster{{Kubeconfig: params.KubeconfigPath, Default: true}}
+	}
+
+	components := &Components{Config: &tomlCfg}
+
+	for _, cluster := range clusters {
+		// Each cluster resumes its own change stream
+		clusterTokenConfig := tokenConfig
+		if cluster.Name != "" {
+			clusterTokenConfig.ClientName = tokenConfig.ClientName + "-" + cluster.Name
+		}
+
+		newEventWatcher := func(r *reconciler.Reconciler) mongodb.EventWatcherInterface {
+			return mongodb.NewEventWatcher(
+				mongoConfig,
+				clusterTokenConfig,
+				createMongoPipeline(cluster),
+				healthEventCollection,
+				r,
+			)
+		}
+
+		clusterComponents, err := initializeCluster(ctx, params, tomlCfg, cluster, newEventWatcher)
+		if err != nil {
+			return nil, fmt.Errorf("error while initializing cluster %q: %w", cluster.Name, err)
+		}
+
+		components.Clusters = append(components.Clusters, clusterComponents)
+	}
+
+	if tomlCfg.GarbageCollection != nil {
+		components.GarbageCollector, err = initializeGarbageCollector(healthEventCollection, *tomlCfg.GarbageCollection)
+		if err != nil {
+			return nil, fmt.Errorf("error while initializing garbage collection: %w", err)
+		}
+	}
+
+	slog.Info("Initialization completed successfully", "clusters", len(components.Clusters))
+
+	return components, nil
+}
+
+// initializeEmbedded quarantines the cluster the module runs in from the events
+// of an embedded store. Embedded stores hold the events of a single cluster and
+// are pruned by their owner, so clusters and garbage collection do not apply.
+func initializeEmbedded(
+	ctx context.Context,
+	params InitializationParams,
+	tomlCfg config.TomlConfig,
+) (*Components, error) {
+	if len(tomlCfg.Clusters) > 0 {
+		return nil, fmt.Errorf("clusters are not supported with an embedded store")
+	}
+
+	if tomlCfg.GarbageCollection != nil {
+		slog.Warn("Garbage collection is not supported with an embedded store, ignoring it")
+	}
+
+	newEventWatcher := func(*reconciler.Reconciler) mongodb.EventWatcherInterface {
+		return embedded.NewEventWatcher(params.EmbeddedStore, params.EmbeddedPollInterval)
+	}
+
+	cluster := config.Cluster{Kubeconfig: params.KubeconfigPath, Default: true}
+
+	clusterComponents, err := initializeCluster(ctx, params, tomlCfg, cluster, newEventWatcher)
+	if err != nil {
+		return nil, err
+	}
+
+	slog.Info("Initialization completed successfully with an embedded store")
+
+	return &Components{Config: &tomlCfg, Clusters: []*ClusterComponents{clusterComponents}}, nil
+}
 
+// LoadCandidateConfig loads a candidate configuration the way the module
+// loads its own, to compare it with the effective configuration.
+func LoadCandidateConfig(data []byte) (*config.TomlConfig, error) {
 	var tomlCfg config.TomlConfig
-	if err := configmanager.LoadTOMLConfig(params.TomlConfigPath, &tomlCfg); err != nil {
-		return nil, fmt.Errorf("error while loading the toml config: %w", err)
+	if err := configmanager.DecodeTOMLConfig(data, &tomlCfg); err != nil {
+		return nil, err
 	}
 
-	if params.DryRun {
-		slog.Info("Running in dry-run mode")
+	if err := tomlCfg.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid toml config: %w", err)
 	}
 
-	k8sClient, err := informer.NewFaultQuarantineClient(params.KubeconfigPath, params.DryRun, 30*time.Minute)
+	return &tomlCfg, nil
+}
+
+func initializeCluster(
+	ctx context.Context,
+	params InitializationParams,
+	tomlCfg config.TomlConfig,
+	cluster config.Cluster,
+	newEventWatcher func(*reconciler.Reconciler) mongodb.EventWatcherInterface,
+) (*ClusterComponents, error) {
+	clusterCfg := tomlCfg.ForCluster(cluster)
+
+	k8sClient, err := informer.NewFaultQuarantineClient(cluster.Kubeconfig, params.DryRun, 30*time.Minute)
 	if err != nil {
 		return nil, fmt.Errorf("error while initializing kubernetes client: %w", err)
 	}
 
-	slog.Info("Successfully initialized kubernetes client with embedded node informer")
+	slog.Info("Successfully initialized kubernetes client with embedded node informer", "cluster", cluster.Name)
 
 	var circuitBreaker breaker.CircuitBreaker
 
 	if params.CircuitBreakerEnabled {
+		namespace := cluster.Namespace
+		if namespace == "" {
+			namespace = os.Getenv("POD_NAMESPACE")
+		}
+
 		cb, err := initializeCircuitBreaker(
 			ctx,
 			k8sClient,
-			tomlCfg.CircuitBreaker,
+			clusterCfg.CircuitBreaker,
+			namespace,
 		)
 		if err != nil {
 			return nil, fmt.Errorf("error while initializing circuit breaker: %w", err)
@@ -86,13 +228,13 @@ func InitializeAll(ctx context.Context, params InitializationParams) (*Component
 
 		circuitBreaker = cb
 
-		slog.Info("Successfully initialized circuit breaker")
+		slog.Info("Successfully initialized circuit breaker", "cluster", cluster.Name)
 	} else {
 		slog.Info("Circuit breaker is disabled, skipping initialization")
 	}
 
 	reconcilerCfg := createReconcilerConfig(
-		tomlCfg,
+		clusterCfg,
 		params.DryRun,
 		params.CircuitBreakerEnabled,
 	)
@@ -103,24 +245,12 @@ func InitializeAll(ctx context.Context, params InitializationParams) (*Component
 		circuitBreaker,
 	)
 
-	healthEventCollection, err := initializeMongoCollection(ctx, mongoConfig)
-	if err != nil {
-		return nil, fmt.Errorf("error while initializing mongo collection: %w", err)
-	}
-
-	eventWatcher := mongodb.NewEventWatcher(
-		mongoConfig,
-		tokenConfig,
-		pipeline,
-		healthEventCollection,
-		reconcilerInstance,
-	)
+	eventWatcher := newEventWatcher(reconcilerInstance)
 
 	reconcilerInstance.SetEventWatcher(eventWatcher)
 
-	slog.Info("Initialization completed successfully")
-
-	return &Components{
+	return &ClusterComponents{
+		Name:           cluster.Name,
 		Reconciler:     reconcilerInstance,
 		EventWatcher:   eventWatcher,
 		K8sClient:      k8sClient,
@@ -128,14 +258,32 @@ func InitializeAll(ctx context.Context, params InitializationParams) (*Component
 	}, nil
 }
 
-func createMongoPipeline() mongo.Pipeline {
+// createMongoPipeline watches the inserted events of the cluster. Clusters are
+// matched by the name the platform connectors stamp on the events, the default
+// cluster also receives the events without one.
+func createMongoPipeline(cluster config.Cluster) mongo.Pipeline {
+	match := bson.D{
+		bson.E{Key: "operationType", Value: bson.D{
+			bson.E{Key: "$in", Value: bson.A{"insert"}},
+		}},
+	}
+
+	if cluster.Name != "" {
+		clusterKey := "fullDocument.healthevent.metadata." + model.MetadataCluster
+
+		clusters := bson.A{bson.D{bson.E{Key: clusterKey, Value: cluster.Name}}}
+		if cluster.Default {
+			clusters = append(clusters, bson.D{bson.E{Key: clusterKey, Value: bson.D{
+				bson.E{Key: "$in", Value: bson.A{nil, ""}},
+			}}})
+		}
+
+		match = append(match, bson.E{Key: "$or", Value: clusters})
+	}
+
 	return mongo.Pipeline{
 		bson.D{
-			bson.E{Key: "$match", Value: bson.D{
-				bson.E{Key: "operationType", Value: bson.D{
-					bson.E{Key: "$in", Value: bson.A{"insert"}},
-				}},
-			}},
+			bson.E{Key: "$match", Value: match},
 		},
 	}
 }
@@ -168,11 +316,10 @@ func initializeCircuitBreaker(
 	ctx context.Context,
 	k8sClient *informer.FaultQuarantineClient,
 	cbConfig config.CircuitBreaker,
+	namespace string,
 ) (breaker.CircuitBreaker, error) {
 	circuitBreakerName := "circuit-breaker"
 
-	namespace := os.Getenv("POD_NAMESPACE")
-
 	duration, err := time.ParseDuration(cbConfig.Duration)
 	if err != nil {
 		return nil, fmt.Errorf("invalid circuit breaker duration %q: %w", cbConfig.Duration, err)
@@ -197,3 +344,44 @@ func initializeCircuitBreaker(
 
 	return cb, nil
 }
+
+func initializeGarbageCollector(
+	collection *mongo.Collection,
+	gcConfig config.GarbageCollection,
+) (*gc.Collector, error) {
+	collectorConfig := gc.Config{BatchSize: gcConfig.BatchSize}
+
+	if gcConfig.Interval != "" {
+		interval, err := time.ParseDuration(gcConfig.Interval)
+		if err != nil {
+			return nil, fmt.Errorf("invalid garbage collection interval %q: %w", gcConfig.Interval, err)
+		}
+
+		collectorConfig.Interval = interval
+	}
+
+	if gcConfig.Retention != "" {
+		retention, err := time.ParseDuration(gcConfig.Retention)
+		if err != nil {
+			return nil, fmt.Errorf("invalid garbage collection retention %q: %w", gcConfig.Retention, err)
+		}
+
+		collectorConfig.Retention = retention
+	}
+
+	archiveCollection := gcConfig.ArchiveCollection
+	if archiveCollection == "" {
+		archiveCollection = gc.DefaultArchiveCollection
+	}
+
+	slog.Info("Initializing garbage collection", "archiveCollection", archiveCollection)
+
+	// "-" disables archiving, collected documents are only deleted
+	if archiveCollection == "-" {
+		return gc.NewCollector(collectorConfig, collection, nil), nil
+	}
+
+	archive := collection.Database().Collection(archiveCollection)
+
+	return gc.NewCollector(collectorConfig, collection, archive), nil
+}
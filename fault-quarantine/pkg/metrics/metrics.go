@@ -46,6 +46,42 @@ var (
 		},
 		[]string{"error_type"},
 	)
+	CanaryEventsEvaluated = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "fault_quarantine_canary_events_evaluated_total",
+			Help: "Total number of canary events evaluated in dry-run, by whether they would quarantine the node.",
+		},
+		[]string{"would_quarantine"},
+	)
+	ObserveOnlyQuarantines = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "fault_quarantine_observe_only_quarantines_total",
+			Help: "Total number of events that would have quarantined a node enforcement is switched off for.",
+		},
+		[]string{"node"},
+	)
+	EventsAwaitingCorroboration = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "fault_quarantine_events_awaiting_corroboration_total",
+			Help: "Total number of fatal events held until a composite rule corroborates them.",
+		},
+		[]string{"composite_rule"},
+	)
+
+	// Cross-node Incident Metrics
+	CrossNodeIncidents = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "fault_quarantine_cross_node_incidents_total",
+			Help: "Total number of incidents affecting several nodes, by what became of them.",
+		},
+		[]string{"result"},
+	)
+	CrossNodeIncidentsPendingApproval = promauto.NewGauge(
+		prometheus.GaugeOpts{
+			Name: "fault_quarantine_cross_node_incidents_pending_approval",
+			Help: "Number of incidents affecting several nodes held until they are approved.",
+		},
+	)
 
 	// Node Quarantine Metrics
 	TotalNodesQuarantined = promauto.NewCounterVec(
@@ -123,6 +159,16 @@ var (
 		},
 	)
 
+	PipelineStageLatency = promauto.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name: "fault_quarantine_pipeline_stage_latency_seconds",
+			Help: "Latency from earlier pipeline stages of a health event to the incident being created " +
+				"or the node being quarantined.",
+			Buckets: []float64{1, 5, 10, 30, 60, 120, 300, 600, 1800, 3600},
+		},
+		[]string{"from", "to"},
+	)
+
 	// Event Processing Metrics
 	EventBacklogSize = promauto.NewGauge(
 		prometheus.GaugeOpts{
@@ -167,6 +213,28 @@ var (
 			Buckets: []float64{0, 1, 2, 3, 5, 10},
 		},
 	)
+
+	// Garbage Collection Metrics
+	GCRuns = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "fault_quarantine_gc_runs_total",
+			Help: "Total number of garbage collection runs.",
+		},
+		[]string{"result"},
+	)
+	GCDocumentsDeleted = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "fault_quarantine_gc_documents_deleted_total",
+			Help: "Total number of documents removed from the health events collection by garbage collection.",
+		},
+		[]string{"reason"},
+	)
+	GCDocumentsArchived = promauto.NewCounter(
+		prometheus.CounterOpts{
+			Name: "fault_quarantine_gc_documents_archived_total",
+			Help: "Total number of documents copied to the archive collection by garbage collection.",
+		},
+	)
 )
 
 func SetFaultQuarantineBreakerUtilization(utilization float64) {
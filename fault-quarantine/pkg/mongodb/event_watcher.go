@@ -36,6 +36,7 @@ type MongoCollectionInterface interface {
 	UpdateMany(ctx context.Context, filter interface{}, update interface{},
 		opts ...*options.UpdateOptions) (*mongo.UpdateResult, error)
 	FindOne(ctx context.Context, filter interface{}, opts ...*options.FindOneOptions) *mongo.SingleResult
+	Find(ctx context.Context, filter interface{}, opts ...*options.FindOptions) (*mongo.Cursor, error)
 }
 
 type EventWatcher struct {
@@ -66,6 +67,13 @@ type EventWatcherInterface interface {
 		) *model.Status,
 	)
 	CancelLatestQuarantiningEvents(ctx context.Context, nodeName string) error
+	// ResolvePendingApproval records the status the cross-node incident opened by
+	// the event with the ID ended up with, once approved or recovered
+	ResolvePendingApproval(ctx context.Context, eventID string, status model.Status,
+		quarantineReason *model.QuarantineReason) error
+	// PendingApprovalEvents returns the events of the cross-node incidents awaiting
+	// approval, oldest first
+	PendingApprovalEvents(ctx context.Context) ([]model.HealthEventWithStatus, error)
 }
 
 func NewEventWatcher(
@@ -180,7 +188,7 @@ func (w *EventWatcher) processEvent(ctx context.Context, event bson.M) error {
 	status := w.processEventCallback(ctx, &healthEventWithStatus)
 
 	if status != nil {
-		if err := w.updateNodeQuarantineStatus(ctx, event, status); err != nil {
+		if err := w.updateNodeQuarantineStatus(ctx, event, status, &healthEventWithStatus); err != nil {
 			metrics.ProcessingErrors.WithLabelValues("update_quarantine_status_error").Inc()
 			return fmt.Errorf("failed to update node quarantine status: %w", err)
 		}
@@ -231,6 +239,7 @@ func (w *EventWatcher) updateNodeQuarantineStatus(
 	ctx context.Context,
 	event bson.M,
 	nodeQuarantinedStatus *model.Status,
+	healthEventWithStatus *model.HealthEventWithStatus,
 ) error {
 	document, ok := event["fullDocument"].(bson.M)
 	if !ok {
@@ -239,12 +248,23 @@ func (w *EventWatcher) updateNodeQuarantineStatus(
 
 	filter := bson.M{"_id": document["_id"]}
 
-	update := bson.M{
-		"$set": bson.M{
-			"healtheventstatus.nodequarantined": *nodeQuarantinedStatus,
-		},
+	set := bson.M{
+		"healtheventstatus.nodequarantined": *nodeQuarantinedStatus,
+	}
+
+	if quarantineReason := healthEventWithStatus.HealthEventStatus.QuarantineReason; quarantineReason != nil {
+		set["healtheventstatus.quarantinereason"] = quarantineReason
 	}
 
+	// The incident created stage is stamped by the reconciler, stored so that later
+	// stages such as fault-remediation measure their latency from it
+	stage := string(model.StageIncidentCreated)
+	if createdAt, ok := healthEventWithStatus.HealthEvent.GetMetadata()[stage]; ok {
+		set["healthevent.metadata."+stage] = createdAt
+	}
+
+	update := bson.M{"$set": set}
+
 	if _, err := w.collection.UpdateOne(ctx, filter, update); err != nil {
 		return fmt.Errorf("error updating document with _id: %v, error: %w", document["_id"], err)
 	}
@@ -320,3 +340,52 @@ func (w *EventWatcher) CancelLatestQuarantiningEvents(
 
 	return nil
 }
+
+func (w *EventWatcher) ResolvePendingApproval(
+	ctx context.Context,
+	eventID string,
+	status model.Status,
+	quarantineReason *model.QuarantineReason,
+) error {
+	set := bson.M{
+		"healtheventstatus.nodequarantined": status,
+	}
+
+	if quarantineReason != nil {
+		set["healtheventstatus.quarantinereason"] = quarantineReason
+	}
+
+	filter := bson.M{
+		"healthevent.id":                    eventID,
+		"healtheventstatus.nodequarantined": model.PendingApproval,
+	}
+
+	result, err := w.collection.UpdateOne(ctx, filter, bson.M{"$set": set})
+	if err != nil {
+		return fmt.Errorf("error updating quarantine status of event %s: %w", eventID, err)
+	}
+
+	if result.MatchedCount == 0 {
+		return fmt.Errorf("no event %s pending approval", eventID)
+	}
+
+	slog.Info("Document updated with status", "eventId", eventID, "status", status)
+
+	return nil
+}
+
+func (w *EventWatcher) PendingApprovalEvents(ctx context.Context) ([]model.HealthEventWithStatus, error) {
+	filter := bson.M{"healtheventstatus.nodequarantined": model.PendingApproval}
+
+	cursor, err := w.collection.Find(ctx, filter, options.Find().SetSort(bson.D{{Key: "createdAt", Value: 1}}))
+	if err != nil {
+		return nil, fmt.Errorf("error finding events pending approval: %w", err)
+	}
+
+	var events []model.HealthEventWithStatus
+	if err := cursor.All(ctx, &events); err != nil {
+		return nil, fmt.Errorf("error decoding events pending approval: %w", err)
+	}
+
+	return events, nil
+}
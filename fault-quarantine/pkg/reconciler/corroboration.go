@@ -0,0 +1,68 @@
+// Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package reconciler
+
+import (
+	"context"
+	"log/slog"
+	"slices"
+
+	"github.com/nvidia/nvsentinel/data-models/pkg/model"
+	"github.com/nvidia/nvsentinel/data-models/pkg/protos"
+	"github.com/nvidia/nvsentinel/fault-quarantine/pkg/metrics"
+)
+
+// awaitedCompositeRule returns the composite rule the event is held for, "" when
+// the event is acted on right away. Only fatal events are held, and the events
+// the composite rule publishes pass, as they are the corroboration.
+func (r *Reconciler) awaitedCompositeRule(event *protos.HealthEvent) string {
+	if event.IsHealthy || !event.IsFatal {
+		return ""
+	}
+
+	for _, corroboration := range r.config.TomlConfig.Corroborations {
+		if event.CheckName == corroboration.CompositeRule {
+			return ""
+		}
+
+		if slices.ContainsFunc(event.ErrorCode, func(errorCode string) bool {
+			return slices.Contains(corroboration.ErrorCodes, errorCode)
+		}) {
+			return corroboration.CompositeRule
+		}
+	}
+
+	return ""
+}
+
+// handleUncorroboratedEvent holds a fatal event until the composite rule
+// corroborates it, leaving the node untouched. The event the rule publishes then
+// goes through the rule sets like any other.
+func (r *Reconciler) handleUncorroboratedEvent(
+	ctx context.Context,
+	event *model.HealthEventWithStatus,
+	compositeRule string,
+) *model.Status {
+	slog.InfoContext(ctx, "Holding fatal event until it is corroborated",
+		"node", event.HealthEvent.NodeName,
+		"check", event.HealthEvent.CheckName,
+		"error_codes", event.HealthEvent.ErrorCode,
+		"composite_rule", compositeRule)
+	metrics.EventsAwaitingCorroboration.WithLabelValues(compositeRule).Inc()
+
+	status := model.AwaitingCorroboration
+
+	return &status
+}
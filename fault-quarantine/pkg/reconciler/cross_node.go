@@ -0,0 +1,430 @@
+// Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package reconciler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"maps"
+	"sync/atomic"
+	"time"
+
+	"github.com/nvidia/nvsentinel/commons/pkg/logger"
+	"github.com/nvidia/nvsentinel/data-models/pkg/model"
+	"github.com/nvidia/nvsentinel/data-models/pkg/protos"
+	"github.com/nvidia/nvsentinel/fault-quarantine/pkg/common"
+	"github.com/nvidia/nvsentinel/fault-quarantine/pkg/evaluator"
+	"github.com/nvidia/nvsentinel/fault-quarantine/pkg/healthEventsAnnotation"
+	"github.com/nvidia/nvsentinel/fault-quarantine/pkg/incident"
+	"github.com/nvidia/nvsentinel/fault-quarantine/pkg/metrics"
+	"google.golang.org/protobuf/proto"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// Results of cross-node incidents recorded in metrics.CrossNodeIncidents
+const (
+	incidentHeld      = "held"
+	incidentApproved  = "approved"
+	incidentForced    = "forced"
+	incidentRecovered = "recovered"
+	incidentFailed    = "failed"
+)
+
+// handleCrossNodeIncident handles an unhealthy event of an incident affecting
+// several nodes. The rulesets are evaluated once for the event, then the incident
+// is held until it is approved on any of its nodes and all of them are quarantined
+// together. Forced events are not held.
+func (r *Reconciler) handleCrossNodeIncident(
+	ctx context.Context,
+	event *model.HealthEventWithStatus,
+	ruleSetEvals []evaluator.RuleSetEvaluatorIface,
+	rulesetsConfig rulesetsConfig,
+) *model.Status {
+	decision := r.decideQuarantine(event, ruleSetEvals, rulesetsConfig)
+	if decision == nil {
+		return nil
+	}
+
+	pending := newIncident(event, decision)
+
+	if r.allQuarantined(pending.Nodes) {
+		// Nothing left to approve, the event is only added to the annotations
+		if err := r.quarantineIncident(ctx, pending); err != nil {
+			slog.ErrorContext(ctx, "Failed to add cross-node incident to its quarantined nodes",
+				"incident", pending.ID(), "nodes", pending.Nodes, "error", err)
+
+			return nil
+		}
+
+		status := model.AlreadyQuarantined
+
+		return &status
+	}
+
+	// Recorded on the stored event by the event watcher along with the status
+	event.HealthEventStatus.QuarantineReason = pending.Reason
+
+	if event.HealthEvent.QuarantineOverrides.GetForce() {
+		if err := r.quarantineIncident(ctx, pending); err != nil {
+			slog.ErrorContext(ctx, "Failed to quarantine the nodes of forced cross-node incident",
+				"incident", pending.ID(), "nodes", pending.Nodes, "error", err)
+			metrics.CrossNodeIncidents.WithLabelValues(incidentFailed).Inc()
+
+			return nil
+		}
+
+		metrics.CrossNodeIncidents.WithLabelValues(incidentForced).Inc()
+
+		status := model.Quarantined
+
+		return &status
+	}
+
+	r.pendingIncidents.Hold(pending)
+	metrics.CrossNodeIncidents.WithLabelValues(incidentHeld).Inc()
+
+	slog.WarnContext(ctx, "Cross-node incident held until it is approved on any of its nodes",
+		"incident", pending.ID(),
+		"check", event.HealthEvent.CheckName,
+		"nodes", pending.Nodes,
+		"approvalAnnotation", common.QuarantineIncidentApprovalAnnotationKey+"="+pending.ID())
+
+	status := model.PendingApproval
+
+	return &status
+}
+
+// allQuarantined reports whether all the nodes are quarantined already
+func (r *Reconciler) allQuarantined(nodes []string) bool {
+	for _, nodeName := range nodes {
+		if _, quarantined := r.hasExistingQuarantine(nodeName); !quarantined {
+			return false
+		}
+	}
+
+	return true
+}
+
+// newIncident returns the incident opened by the event, quarantining all the nodes
+// it affects as decided for the event
+func newIncident(event *model.HealthEventWithStatus, decision *quarantineDecision) *incident.Incident {
+	nodes := model.AffectedNodes(event.HealthEvent)
+	decision.reason.AffectedNodes = nodes
+
+	return &incident.Incident{
+		Event:       event,
+		Nodes:       nodes,
+		Taints:      decision.taints,
+		Cordon:      decision.isCordoned.Load(),
+		Annotations: decision.annotations,
+		Labels:      labelsToMap(decision.labels),
+		Reason:      decision.reason,
+	}
+}
+
+// handleIncidentApproval quarantines the nodes of the cross-node incident approved
+// on the node and records the quarantine on the event that opened it. The approval
+// annotation is removed whatever the outcome, an incident that failed to quarantine
+// is held again so it can be approved anew.
+func (r *Reconciler) handleIncidentApproval(nodeName, incidentID string) error {
+	ctx := logger.WithEventID(logger.WithSubsystem(context.Background(), "reconciler"), incidentID)
+
+	defer r.removeIncidentApproval(ctx, nodeName)
+
+	approved, err := r.pendingIncidents.Approve(incidentID, nodeName)
+	if err != nil {
+		return fmt.Errorf("failed to approve incident %s on node %s: %w", incidentID, nodeName, err)
+	}
+
+	slog.InfoContext(ctx, "Cross-node incident approved, quarantining its nodes",
+		"incident", incidentID, "approvedOn", nodeName, "nodes", approved.Nodes)
+
+	if err := r.quarantineIncident(ctx, approved); err != nil {
+		r.pendingIncidents.Hold(approved)
+		metrics.CrossNodeIncidents.WithLabelValues(incidentFailed).Inc()
+
+		return fmt.Errorf("failed to quarantine the nodes of incident %s: %w", incidentID, err)
+	}
+
+	metrics.CrossNodeIncidents.WithLabelValues(incidentApproved).Inc()
+
+	if err := r.eventWatcher.ResolvePendingApproval(ctx, incidentID, model.Quarantined, approved.Reason); err != nil {
+		metrics.ProcessingErrors.WithLabelValues("update_quarantine_status_error").Inc()
+		return fmt.Errorf("failed to record the quarantine of incident %s: %w", incidentID, err)
+	}
+
+	return nil
+}
+
+// removeIncidentApproval removes the approval annotation from the node
+func (r *Reconciler) removeIncidentApproval(ctx context.Context, nodeName string) {
+	updateFn := func(node *corev1.Node) error {
+		delete(node.Annotations, common.QuarantineIncidentApprovalAnnotationKey)
+		return nil
+	}
+
+	if err := r.k8sClient.UpdateNode(ctx, nodeName, updateFn); err != nil {
+		slog.ErrorContext(ctx, "Failed to remove incident approval annotation from node", "node", nodeName, "error", err)
+	}
+}
+
+// quarantineIncident quarantines all the nodes of the incident, or none of them:
+// the nodes quarantined before a failure are released again. Nodes that are already
+// quarantined get the event added to their health events annotation. The circuit
+// breaker does not record the cordons, the approval stands in for it.
+func (r *Reconciler) quarantineIncident(ctx context.Context, pending *incident.Incident) error {
+	var nodes []string
+
+	for _, nodeName := range pending.Nodes {
+		if _, err := r.k8sClient.NodeInformer.GetNode(nodeName); err != nil {
+			return fmt.Errorf("failed to get node %s: %w", nodeName, err)
+		}
+
+		if r.enforcementDisabled(ctx, nodeName) {
+			slog.WarnContext(ctx, "Enforcement is off for the node, skipping it in the cross-node incident",
+				"node", nodeName, "incident", pending.ID())
+
+			continue
+		}
+
+		nodes = append(nodes, nodeName)
+	}
+
+	pending.Reason.QuarantinedAt = time.Now().UTC()
+
+	annotations := maps.Clone(pending.Annotations)
+	addQuarantineReasonAnnotation(pending.Reason, annotations)
+
+	labels := maps.Clone(pending.Labels)
+	if _, ok := labels[r.cordonedTimestampLabelKey]; ok {
+		labels[r.cordonedTimestampLabelKey] = pending.Reason.QuarantinedAt.Format("2006-01-02T15-04-05Z")
+	}
+
+	var (
+		rollbacks   []func()
+		quarantined []string
+	)
+
+	for _, nodeName := range nodes {
+		rollback, newlyQuarantined, err := r.quarantineIncidentNode(ctx,
+			eventForNode(pending.Event.HealthEvent, nodeName), pending, annotations, labels)
+		if err != nil {
+			slog.ErrorContext(ctx, "Failed to quarantine node of cross-node incident, releasing the others",
+				"node", nodeName, "incident", pending.ID(), "error", err)
+
+			for i := len(rollbacks) - 1; i >= 0; i-- {
+				rollbacks[i]()
+			}
+
+			return fmt.Errorf("failed to quarantine node %s: %w", nodeName, err)
+		}
+
+		rollbacks = append(rollbacks, rollback)
+
+		if newlyQuarantined {
+			quarantined = append(quarantined, nodeName)
+		}
+	}
+
+	var isCordoned atomic.Bool
+	isCordoned.Store(pending.Cordon)
+
+	for _, nodeName := range quarantined {
+		r.updateQuarantineMetrics(nodeName, pending.Taints, &isCordoned)
+	}
+
+	observeQuarantineLatency(pending.Event.HealthEvent)
+
+	slog.InfoContext(ctx, "Quarantined the nodes of cross-node incident",
+		"incident", pending.ID(), "nodes", nodes, "newlyQuarantined", quarantined)
+
+	return nil
+}
+
+// quarantineIncidentNode quarantines a node of the incident with the event of the
+// node. It returns how to undo it and whether the node was not quarantined before.
+func (r *Reconciler) quarantineIncidentNode(
+	ctx context.Context,
+	event *protos.HealthEvent,
+	pending *incident.Incident,
+	annotations map[string]string,
+	labels map[string]string,
+) (func(), bool, error) {
+	existing, alreadyQuarantined := r.hasExistingQuarantine(event.NodeName)
+	if alreadyQuarantined {
+		if err := r.addEventToAnnotation(ctx, event); err != nil {
+			return nil, false, err
+		}
+
+		return func() {
+			if err := r.removeEventFromAnnotation(context.WithoutCancel(ctx), event); err != nil {
+				slog.ErrorContext(ctx, "Failed to remove cross-node incident from node annotation",
+					"node", event.NodeName, "error", err)
+			}
+		}, false, nil
+	}
+
+	healthEvents := healthEventsAnnotation.NewHealthEventsAnnotationMap()
+	healthEvents.AddOrUpdateEvent(event)
+
+	nodeAnnotations := maps.Clone(annotations)
+	if err := r.addHealthEventAnnotation(healthEvents, nodeAnnotations); err != nil {
+		return nil, false, err
+	}
+
+	r.cleanupManualUncordonAnnotation(ctx, event.NodeName, existing)
+
+	err := r.k8sClient.QuarantineNodeAndSetAnnotations(ctx, event.NodeName, pending.Taints, pending.Cordon,
+		nodeAnnotations, labels)
+	if err != nil {
+		metrics.ProcessingErrors.WithLabelValues("taint_and_cordon_error").Inc()
+		return nil, false, err
+	}
+
+	return func() { r.releaseIncidentNode(context.WithoutCancel(ctx), event.NodeName, pending) }, true, nil
+}
+
+// releaseIncidentNode undoes the quarantine of a node of an incident that failed to
+// quarantine all its nodes
+func (r *Reconciler) releaseIncidentNode(ctx context.Context, nodeName string, pending *incident.Incident) {
+	annotationKeys := []string{
+		common.QuarantineHealthEventAnnotationKey,
+		common.QuarantineHealthEventAppliedTaintsAnnotationKey,
+		common.QuarantineHealthEventIsCordonedAnnotationKey,
+		model.QuarantineReasonAnnotationKey,
+	}
+
+	labelsToRemove := make([]string, 0, len(pending.Labels))
+	for key := range pending.Labels {
+		labelsToRemove = append(labelsToRemove, key)
+	}
+
+	err := r.k8sClient.UnQuarantineNodeAndRemoveAnnotations(ctx, nodeName, pending.Taints, annotationKeys,
+		labelsToRemove, nil)
+	if err != nil {
+		slog.ErrorContext(ctx, "Failed to release node of cross-node incident", "node", nodeName, "error", err)
+		metrics.ProcessingErrors.WithLabelValues("untaint_and_uncordon_error").Inc()
+	}
+}
+
+// handleCrossNodeRecovery releases the nodes of a cross-node incident on its healthy
+// event, as the healthy event of every node would. It returns the status of the node
+// of the event.
+func (r *Reconciler) handleCrossNodeRecovery(
+	ctx context.Context,
+	event *protos.HealthEvent,
+	ruleSetEvals []evaluator.RuleSetEvaluatorIface,
+) *model.Status {
+	var status *model.Status
+
+	for _, nodeName := range model.AffectedNodes(event) {
+		if _, quarantined := r.hasExistingQuarantine(nodeName); !quarantined {
+			continue
+		}
+
+		nodeStatus := r.handleAlreadyQuarantinedNode(ctx, eventForNode(event, nodeName), ruleSetEvals)
+		if nodeName == event.NodeName {
+			status = nodeStatus
+		}
+	}
+
+	return status
+}
+
+// resolvePendingIncidents cancels the cross-node incidents the healthy event recovers
+// before they were approved
+func (r *Reconciler) resolvePendingIncidents(ctx context.Context, event *protos.HealthEvent) {
+	for _, resolved := range r.pendingIncidents.Resolve(event) {
+		slog.InfoContext(ctx, "Cross-node incident recovered before it was approved",
+			"incident", resolved.ID(), "nodes", resolved.Nodes)
+		metrics.CrossNodeIncidents.WithLabelValues(incidentRecovered).Inc()
+
+		if err := r.eventWatcher.ResolvePendingApproval(ctx, resolved.ID(), model.Cancelled, nil); err != nil {
+			slog.ErrorContext(ctx, "Failed to cancel recovered cross-node incident",
+				"incident", resolved.ID(), "error", err)
+			metrics.ProcessingErrors.WithLabelValues("update_quarantine_status_error").Inc()
+		}
+	}
+}
+
+// restorePendingIncidents holds the cross-node incidents that were awaiting approval
+// when the previous instance stopped. Incidents the rulesets no longer quarantine
+// the nodes for are cancelled.
+func (r *Reconciler) restorePendingIncidents(
+	ctx context.Context,
+	ruleSetEvals []evaluator.RuleSetEvaluatorIface,
+	rulesetsConfig rulesetsConfig,
+) error {
+	events, err := r.eventWatcher.PendingApprovalEvents(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to load cross-node incidents pending approval: %w", err)
+	}
+
+	for i := range events {
+		event := &events[i]
+
+		decision := r.decideQuarantine(event, ruleSetEvals, rulesetsConfig)
+		if decision == nil {
+			slog.WarnContext(ctx, "Rulesets no longer quarantine for cross-node incident, cancelling it",
+				"incident", event.HealthEvent.GetId())
+
+			if err := r.eventWatcher.ResolvePendingApproval(ctx, event.HealthEvent.GetId(), model.Cancelled,
+				nil); err != nil {
+				slog.ErrorContext(ctx, "Failed to cancel cross-node incident",
+					"incident", event.HealthEvent.GetId(), "error", err)
+			}
+
+			continue
+		}
+
+		r.pendingIncidents.Hold(newIncident(event, decision))
+	}
+
+	slog.InfoContext(ctx, "Restored cross-node incidents pending approval", "count", r.pendingIncidents.Len())
+
+	return nil
+}
+
+// handleExistingApprovals handles the approvals given while no instance was running
+func (r *Reconciler) handleExistingApprovals() {
+	nodes, err := r.k8sClient.NodeInformer.ListNodes()
+	if err != nil {
+		slog.Error("Failed to list nodes to find incident approvals", "error", err)
+		return
+	}
+
+	for _, node := range nodes {
+		incidentID := node.Annotations[common.QuarantineIncidentApprovalAnnotationKey]
+		if incidentID == "" {
+			continue
+		}
+
+		err := r.handleIncidentApproval(node.Name, incidentID)
+		if err != nil && !errors.Is(err, incident.ErrNotPending) {
+			slog.Error("Failed to handle approval of cross-node incident",
+				"node", node.Name, "incident", incidentID, "error", err)
+		}
+	}
+}
+
+// eventForNode returns a copy of the event reported for the node
+func eventForNode(event *protos.HealthEvent, nodeName string) *protos.HealthEvent {
+	nodeEvent, _ := proto.Clone(event).(*protos.HealthEvent)
+	nodeEvent.NodeName = nodeName
+
+	return nodeEvent
+}
@@ -26,6 +26,8 @@ import (
 	"sync/atomic"
 	"time"
 
+	"github.com/nvidia/nvsentinel/commons/pkg/enforcement"
+	"github.com/nvidia/nvsentinel/commons/pkg/logger"
 	"github.com/nvidia/nvsentinel/commons/pkg/statemanager"
 	"github.com/nvidia/nvsentinel/data-models/pkg/model"
 	"github.com/nvidia/nvsentinel/data-models/pkg/protos"
@@ -34,6 +36,7 @@ import (
 	"github.com/nvidia/nvsentinel/fault-quarantine/pkg/config"
 	"github.com/nvidia/nvsentinel/fault-quarantine/pkg/evaluator"
 	"github.com/nvidia/nvsentinel/fault-quarantine/pkg/healthEventsAnnotation"
+	"github.com/nvidia/nvsentinel/fault-quarantine/pkg/incident"
 	"github.com/nvidia/nvsentinel/fault-quarantine/pkg/informer"
 	"github.com/nvidia/nvsentinel/fault-quarantine/pkg/metrics"
 	"github.com/nvidia/nvsentinel/fault-quarantine/pkg/mongodb"
@@ -51,6 +54,7 @@ type rulesetsConfig struct {
 	TaintConfigMap     map[string]*config.Taint
 	CordonConfigMap    map[string]bool
 	RuleSetPriorityMap map[string]int
+	RunbookURLMap      map[string]string
 }
 
 // keyValTaint represents a taint key-value pair used for deduplication and priority tracking
@@ -64,6 +68,7 @@ type Reconciler struct {
 	k8sClient             *informer.FaultQuarantineClient
 	lastProcessedObjectID atomic.Value
 	cb                    breaker.CircuitBreaker
+	pendingIncidents      *incident.Pending
 	eventWatcher          mongodb.EventWatcherInterface
 	taintInitKeys         []keyValTaint // Pre-computed taint keys for map initialization
 	taintUpdateMu         sync.Mutex    // Protects taint priority updates
@@ -92,9 +97,10 @@ func NewReconciler(
 	circuitBreaker breaker.CircuitBreaker,
 ) *Reconciler {
 	r := &Reconciler{
-		config:    cfg,
-		k8sClient: k8sClient,
-		cb:        circuitBreaker,
+		config:           cfg,
+		k8sClient:        k8sClient,
+		cb:               circuitBreaker,
+		pendingIncidents: incident.NewPending(),
 	}
 
 	return r
@@ -153,6 +159,13 @@ func (r *Reconciler) Start(ctx context.Context) error {
 		return err
 	}
 
+	if err := r.restorePendingIncidents(ctx, ruleSetEvals, rulesetsConfig); err != nil {
+		return err
+	}
+
+	r.k8sClient.NodeInformer.SetOnIncidentApprovedCallback(r.handleIncidentApproval)
+	r.handleExistingApprovals()
+
 	r.eventWatcher.SetProcessEventCallback(
 		func(ctx context.Context, event *model.HealthEventWithStatus) *model.Status {
 			return r.ProcessEvent(ctx, event, ruleSetEvals, rulesetsConfig)
@@ -163,7 +176,7 @@ func (r *Reconciler) Start(ctx context.Context) error {
 		return fmt.Errorf("event watcher failed: %w", err)
 	}
 
-	slog.Info("Event watcher stopped, exiting fault-quarantine reconciler.")
+	slog.InfoContext(ctx, "Event watcher stopped, exiting fault-quarantine reconciler.")
 
 	return nil
 }
@@ -199,6 +212,7 @@ func (r *Reconciler) buildRulesetsConfig() rulesetsConfig {
 	taintConfigMap := make(map[string]*config.Taint)
 	cordonConfigMap := make(map[string]bool)
 	ruleSetPriorityMap := make(map[string]int)
+	runbookURLMap := make(map[string]string)
 
 	for _, ruleSet := range r.config.TomlConfig.RuleSets {
 		if ruleSet.Taint.Key != "" {
@@ -212,12 +226,17 @@ func (r *Reconciler) buildRulesetsConfig() rulesetsConfig {
 		if ruleSet.Priority > 0 {
 			ruleSetPriorityMap[ruleSet.Name] = ruleSet.Priority
 		}
+
+		if ruleSet.RunbookURL != "" {
+			runbookURLMap[ruleSet.Name] = ruleSet.RunbookURL
+		}
 	}
 
 	return rulesetsConfig{
 		TaintConfigMap:     taintConfigMap,
 		CordonConfigMap:    cordonConfigMap,
 		RuleSetPriorityMap: ruleSetPriorityMap,
+		RunbookURLMap:      runbookURLMap,
 	}
 }
 
@@ -272,20 +291,20 @@ func (r *Reconciler) checkCircuitBreakerAtStartup(ctx context.Context) error {
 			return err
 		}
 
-		slog.Error("Error checking if circuit breaker is tripped", "error", err)
+		slog.ErrorContext(ctx, "Error checking if circuit breaker is tripped", "error", err)
 		<-ctx.Done()
 
 		return fmt.Errorf("circuit breaker check failed: %w", err)
 	}
 
 	if tripped {
-		slog.Error("Fault Quarantine circuit breaker is TRIPPED. Halting event dequeuing indefinitely.")
+		slog.ErrorContext(ctx, "Fault Quarantine circuit breaker is TRIPPED. Halting event dequeuing indefinitely.")
 		<-ctx.Done()
 
 		return fmt.Errorf("circuit breaker is TRIPPED at startup")
 	}
 
-	slog.Info("Listening for events on the channel...")
+	slog.InfoContext(ctx, "Listening for events on the channel...")
 
 	return nil
 }
@@ -297,19 +316,23 @@ func (r *Reconciler) ProcessEvent(
 	ruleSetEvals []evaluator.RuleSetEvaluatorIface,
 	rulesetsConfig rulesetsConfig,
 ) *model.Status {
+	ctx = logger.WithEventID(logger.WithSubsystem(ctx, "reconciler"), event.HealthEvent.GetId())
+
 	if shouldHalt := r.checkCircuitBreakerAndHalt(ctx); shouldHalt {
 		return nil
 	}
 
-	slog.Debug("Processing event", "checkName", event.HealthEvent.CheckName)
+	slog.DebugContext(ctx, "Processing event", "checkName", event.HealthEvent.CheckName)
 
 	isNodeQuarantined := r.handleEvent(ctx, event, ruleSetEvals, rulesetsConfig)
 
 	if isNodeQuarantined == nil {
-		slog.Debug("Skipped processing event for node, no status update needed", "node", event.HealthEvent.NodeName)
+		slog.DebugContext(ctx, "Skipped processing event for node, no status update needed",
+			"node", event.HealthEvent.NodeName)
 	} else if *isNodeQuarantined == model.Quarantined ||
 		*isNodeQuarantined == model.UnQuarantined ||
-		*isNodeQuarantined == model.AlreadyQuarantined {
+		*isNodeQuarantined == model.AlreadyQuarantined ||
+		*isNodeQuarantined == model.PendingApproval {
 		metrics.TotalEventsSuccessfullyProcessed.Inc()
 	}
 
@@ -324,14 +347,14 @@ func (r *Reconciler) checkCircuitBreakerAndHalt(ctx context.Context) bool {
 
 	tripped, err := r.cb.IsTripped(ctx)
 	if err != nil {
-		slog.Error("Error checking if circuit breaker is tripped", "error", err)
+		slog.ErrorContext(ctx, "Error checking if circuit breaker is tripped", "error", err)
 		<-ctx.Done()
 
 		return true
 	}
 
 	if tripped {
-		slog.Error("Circuit breaker TRIPPED. Halting event processing until restart and breaker reset.")
+		slog.ErrorContext(ctx, "Circuit breaker TRIPPED. Halting event processing until restart and breaker reset.")
 		<-ctx.Done()
 
 		return true
@@ -346,6 +369,38 @@ func (r *Reconciler) handleEvent(
 	ruleSetEvals []evaluator.RuleSetEvaluatorIface,
 	rulesetsConfig rulesetsConfig,
 ) *model.Status {
+	// Canary events are evaluated in dry-run whatever the state of the node
+	if model.IsCanary(event.HealthEvent) {
+		return r.handleCanaryEvent(event, ruleSetEvals, rulesetsConfig)
+	}
+
+	if compositeRule := r.awaitedCompositeRule(event.HealthEvent); compositeRule != "" {
+		return r.handleUncorroboratedEvent(ctx, event, compositeRule)
+	}
+
+	// Healthy events are processed as usual, so a node quarantined before
+	// enforcement was switched off is still released once it recovers
+	if !event.HealthEvent.IsHealthy && r.enforcementDisabled(ctx, event.HealthEvent.NodeName) {
+		return r.handleObserveOnlyEvent(event, ruleSetEvals, rulesetsConfig)
+	}
+
+	if event.HealthEvent.IsHealthy {
+		r.resolvePendingIncidents(ctx, event.HealthEvent)
+	}
+
+	if model.IsCrossNode(event.HealthEvent) {
+		if event.HealthEvent.IsHealthy {
+			return r.handleCrossNodeRecovery(ctx, event.HealthEvent, ruleSetEvals)
+		}
+
+		if event.HealthEvent.Id != "" {
+			return r.handleCrossNodeIncident(ctx, event, ruleSetEvals, rulesetsConfig)
+		}
+
+		slog.WarnContext(ctx, "Cross-node event has no ID to approve it by, handling it for its own node only",
+			"node", event.HealthEvent.NodeName, "affectedNodes", model.AffectedNodes(event.HealthEvent))
+	}
+
 	annotations, quarantineAnnotationExists := r.hasExistingQuarantine(event.HealthEvent.NodeName)
 
 	if quarantineAnnotationExists {
@@ -355,12 +410,45 @@ func (r *Reconciler) handleEvent(
 	// For healthy events, if there's no existing quarantine annotation,
 	// skip processing as there's no transition from unhealthy to healthy
 	if event.HealthEvent.IsHealthy {
-		slog.Info("Skipping healthy event for node as there's no existing quarantine annotation",
+		slog.InfoContext(ctx, "Skipping healthy event for node as there's no existing quarantine annotation",
 			"node", event.HealthEvent.NodeName, "event", event.HealthEvent)
 
 		return nil
 	}
 
+	decision := r.decideQuarantine(event, ruleSetEvals, rulesetsConfig)
+	if decision == nil {
+		return nil
+	}
+
+	addQuarantineReasonAnnotation(decision.reason, decision.annotations)
+
+	status := r.applyQuarantine(ctx, event, annotations, decision.taints, decision.annotations,
+		decision.labels, decision.isCordoned)
+	if status != nil && *status == model.Quarantined {
+		// Recorded on the stored event by the event watcher along with the status
+		event.HealthEventStatus.QuarantineReason = decision.reason
+	}
+
+	return status
+}
+
+// quarantineDecision is the quarantine the rulesets decided on for an event
+type quarantineDecision struct {
+	taints      []config.Taint
+	annotations map[string]string
+	labels      *sync.Map
+	isCordoned  *atomic.Bool
+	reason      *model.QuarantineReason
+}
+
+// decideQuarantine evaluates the rulesets for the event, nil when they neither
+// taint nor cordon the node. The quarantine reason is not added to the annotations.
+func (r *Reconciler) decideQuarantine(
+	event *model.HealthEventWithStatus,
+	ruleSetEvals []evaluator.RuleSetEvaluatorIface,
+	rulesetsConfig rulesetsConfig,
+) *quarantineDecision {
 	taintAppliedMap := make(map[keyValTaint]string, len(r.taintInitKeys))
 	taintEffectPriorityMap := make(map[keyValTaint]int, len(r.taintInitKeys))
 
@@ -369,13 +457,13 @@ func (r *Reconciler) handleEvent(
 		taintEffectPriorityMap[keyVal] = -1
 	}
 
-	var labelsMap sync.Map
+	var labelsMap, matchedRuleSets sync.Map
 
 	var isCordoned atomic.Bool
 
 	r.evaluateRulesets(
 		event, ruleSetEvals, rulesetsConfig,
-		taintAppliedMap, &labelsMap, &isCordoned, taintEffectPriorityMap,
+		taintAppliedMap, &labelsMap, &isCordoned, taintEffectPriorityMap, &matchedRuleSets,
 	)
 
 	taintsToBeApplied := r.collectTaintsToApply(taintAppliedMap)
@@ -387,7 +475,110 @@ func (r *Reconciler) handleEvent(
 		return nil
 	}
 
-	return r.applyQuarantine(ctx, event, annotations, taintsToBeApplied, annotationsMap, &labelsMap, &isCordoned)
+	stampIncidentCreated(event.HealthEvent)
+
+	return &quarantineDecision{
+		taints:      taintsToBeApplied,
+		annotations: annotationsMap,
+		labels:      &labelsMap,
+		isCordoned:  &isCordoned,
+		reason: r.quarantineReason(event.HealthEvent, &matchedRuleSets, rulesetsConfig, taintsToBeApplied,
+			isCordoned.Load()),
+	}
+}
+
+// handleCanaryEvent evaluates the rulesets for a canary event without touching the
+// node. It returns CanaryQuarantined when the node would have been quarantined, so
+// the canary prober can tell the event went through policy evaluation.
+func (r *Reconciler) handleCanaryEvent(
+	event *model.HealthEventWithStatus,
+	ruleSetEvals []evaluator.RuleSetEvaluatorIface,
+	rulesetsConfig rulesetsConfig,
+) *model.Status {
+	taintsToBeApplied, cordon := r.wouldQuarantine(event, ruleSetEvals, rulesetsConfig)
+	if len(taintsToBeApplied) == 0 && !cordon {
+		slog.Warn("Canary event would not quarantine the node, check that it matches a ruleset",
+			"node", event.HealthEvent.NodeName, "check", event.HealthEvent.CheckName)
+		metrics.CanaryEventsEvaluated.WithLabelValues("false").Inc()
+
+		return nil
+	}
+
+	slog.Info("Canary event would quarantine the node, skipping quarantine",
+		"node", event.HealthEvent.NodeName, "taints", taintsToBeApplied, "cordon", cordon)
+	metrics.CanaryEventsEvaluated.WithLabelValues("true").Inc()
+
+	status := model.CanaryQuarantined
+
+	return &status
+}
+
+// handleObserveOnlyEvent evaluates the rulesets for an unhealthy event of a node
+// enforcement is switched off for, without touching the node. It returns
+// ObserveOnlyQuarantined when the node would have been quarantined.
+func (r *Reconciler) handleObserveOnlyEvent(
+	event *model.HealthEventWithStatus,
+	ruleSetEvals []evaluator.RuleSetEvaluatorIface,
+	rulesetsConfig rulesetsConfig,
+) *model.Status {
+	taintsToBeApplied, cordon := r.wouldQuarantine(event, ruleSetEvals, rulesetsConfig)
+	if len(taintsToBeApplied) == 0 && !cordon {
+		return nil
+	}
+
+	slog.Warn("Enforcement is off for the node, skipping quarantine",
+		"node", event.HealthEvent.NodeName,
+		"check", event.HealthEvent.CheckName,
+		"error_codes", event.HealthEvent.ErrorCode,
+		"taints", taintsToBeApplied,
+		"cordon", cordon)
+	metrics.ObserveOnlyQuarantines.WithLabelValues(event.HealthEvent.NodeName).Inc()
+
+	status := model.ObserveOnlyQuarantined
+
+	return &status
+}
+
+// wouldQuarantine evaluates the rulesets for the event and returns the taints and
+// cordon they would apply to the node.
+func (r *Reconciler) wouldQuarantine(
+	event *model.HealthEventWithStatus,
+	ruleSetEvals []evaluator.RuleSetEvaluatorIface,
+	rulesetsConfig rulesetsConfig,
+) ([]config.Taint, bool) {
+	taintAppliedMap := make(map[keyValTaint]string, len(r.taintInitKeys))
+	taintEffectPriorityMap := make(map[keyValTaint]int, len(r.taintInitKeys))
+
+	for _, keyVal := range r.taintInitKeys {
+		taintAppliedMap[keyVal] = ""
+		taintEffectPriorityMap[keyVal] = -1
+	}
+
+	var labelsMap, matchedRuleSets sync.Map
+
+	var isCordoned atomic.Bool
+
+	r.evaluateRulesets(
+		event, ruleSetEvals, rulesetsConfig,
+		taintAppliedMap, &labelsMap, &isCordoned, taintEffectPriorityMap, &matchedRuleSets,
+	)
+
+	return r.collectTaintsToApply(taintAppliedMap), isCordoned.Load()
+}
+
+// enforcementDisabled reports whether enforcement is switched off for the node.
+// Nodes missing from the cache are looked up on the API server; when that fails
+// too the node is only observed, as it may have opted out.
+func (r *Reconciler) enforcementDisabled(ctx context.Context, nodeName string) bool {
+	node, err := r.k8sClient.GetNode(ctx, nodeName)
+	if err != nil {
+		slog.WarnContext(ctx, "Failed to get node to check enforcement, observing only",
+			"node", nodeName, "error", err)
+
+		return true
+	}
+
+	return enforcement.Disabled(node.Annotations, node.Labels)
 }
 
 func (r *Reconciler) hasExistingQuarantine(nodeName string) (map[string]string, bool) {
@@ -464,6 +655,7 @@ func (r *Reconciler) evaluateRulesets(
 	labelsMap *sync.Map,
 	isCordoned *atomic.Bool,
 	taintEffectPriorityMap map[keyValTaint]int,
+	matchedRuleSets *sync.Map,
 ) {
 	// Handle quarantine override (force quarantine without rule evaluation)
 	if event.HealthEvent.QuarantineOverrides != nil && event.HealthEvent.QuarantineOverrides.Force {
@@ -491,8 +683,8 @@ func (r *Reconciler) evaluateRulesets(
 
 			switch {
 			case ruleEvaluatedResult == common.RuleEvaluationSuccess:
-				r.handleSuccessfulRuleEvaluation(
-					eval, rulesetsConfig, labelsMap, isCordoned, taintAppliedMap, taintEffectPriorityMap)
+				r.handleSuccessfulRuleEvaluation(eval, rulesetsConfig, labelsMap, isCordoned,
+					taintAppliedMap, taintEffectPriorityMap, matchedRuleSets)
 			case err != nil:
 				r.handleRuleEvaluationError(event.HealthEvent, eval.GetName(), err)
 			default:
@@ -512,10 +704,15 @@ func (r *Reconciler) handleSuccessfulRuleEvaluation(
 	isCordoned *atomic.Bool,
 	taintAppliedMap map[keyValTaint]string,
 	taintEffectPriorityMap map[keyValTaint]int,
+	matchedRuleSets *sync.Map,
 ) {
 	metrics.RulesetEvaluations.WithLabelValues(eval.GetName(), metrics.StatusPassed).Inc()
 
 	shouldCordon := rulesetsConfig.CordonConfigMap[eval.GetName()]
+	if shouldCordon || rulesetsConfig.TaintConfigMap[eval.GetName()] != nil {
+		matchedRuleSets.Store(eval.GetName(), true)
+	}
+
 	if shouldCordon {
 		isCordoned.Store(true)
 
@@ -632,7 +829,7 @@ func (r *Reconciler) applyQuarantine(
 	updated := healthEvents.AddOrUpdateEvent(event.HealthEvent)
 
 	if !updated {
-		slog.Info("Health event already exists for node, skipping quarantine",
+		slog.InfoContext(ctx, "Health event already exists for node, skipping quarantine",
 			"event", event.HealthEvent, "node", event.HealthEvent.NodeName)
 
 		return nil
@@ -646,45 +843,100 @@ func (r *Reconciler) applyQuarantine(
 	r.cleanupManualUncordonAnnotation(ctx, event.HealthEvent.NodeName, annotations)
 
 	if !r.config.CircuitBreakerEnabled {
-		slog.Info("Circuit breaker is disabled, proceeding with quarantine action without protection",
+		slog.InfoContext(ctx, "Circuit breaker is disabled, proceeding with quarantine action without protection",
 			"node", event.HealthEvent.NodeName)
 	}
 
-	// Convert sync.Map to regular map for K8s API call
-	labels := make(map[string]string)
-
-	labelsMap.Range(func(key, value any) bool {
-		if strKey, ok := key.(string); ok {
-			if strValue, ok := value.(string); ok {
-				labels[strKey] = strValue
-			}
-		}
-
-		return true
-	})
-
 	err := r.k8sClient.QuarantineNodeAndSetAnnotations(
 		ctx,
 		event.HealthEvent.NodeName,
 		taintsToBeApplied,
 		isCordoned.Load(),
 		annotationsMap,
-		labels,
+		labelsToMap(labelsMap),
 	)
 	if err != nil {
-		slog.Error("Failed to taint and cordon node", "node", event.HealthEvent.NodeName, "error", err)
+		slog.ErrorContext(ctx, "Failed to taint and cordon node", "node", event.HealthEvent.NodeName, "error", err)
 		metrics.ProcessingErrors.WithLabelValues("taint_and_cordon_error").Inc()
 
 		return nil
 	}
 
 	r.updateQuarantineMetrics(event.HealthEvent.NodeName, taintsToBeApplied, isCordoned)
+	observeQuarantineLatency(event.HealthEvent)
 
 	status := model.Quarantined
 
 	return &status
 }
 
+// labelsToMap converts the labels collected by the rulesets to a regular map for
+// the K8s API call
+func labelsToMap(labelsMap *sync.Map) map[string]string {
+	labels := make(map[string]string)
+
+	labelsMap.Range(func(key, value any) bool {
+		if strKey, ok := key.(string); ok {
+			if strValue, ok := value.(string); ok {
+				labels[strKey] = strValue
+			}
+		}
+
+		return true
+	})
+
+	return labels
+}
+
+// quarantineReason describes why the event quarantines the node. The matched rule
+// sets are listed in the order they are configured in.
+func (r *Reconciler) quarantineReason(
+	event *protos.HealthEvent,
+	matchedRuleSets *sync.Map,
+	rulesetsConfig rulesetsConfig,
+	taintsToBeApplied []config.Taint,
+	isCordoned bool,
+) *model.QuarantineReason {
+	reason := &model.QuarantineReason{
+		IncidentID:    event.Id,
+		Agent:         event.Agent,
+		CheckName:     event.CheckName,
+		ErrorCodes:    event.ErrorCode,
+		Message:       event.Message,
+		Cordoned:      isCordoned,
+		QuarantinedAt: time.Now().UTC(),
+	}
+
+	for _, ruleSet := range r.config.TomlConfig.RuleSets {
+		if _, matched := matchedRuleSets.Load(ruleSet.Name); !matched {
+			continue
+		}
+
+		reason.RuleSets = append(reason.RuleSets, ruleSet.Name)
+
+		if reason.RunbookURL == "" {
+			reason.RunbookURL = rulesetsConfig.RunbookURLMap[ruleSet.Name]
+		}
+	}
+
+	for _, taint := range taintsToBeApplied {
+		reason.Taints = append(reason.Taints, fmt.Sprintf("%s=%s:%s", taint.Key, taint.Value, taint.Effect))
+	}
+
+	return reason
+}
+
+// addQuarantineReasonAnnotation adds the quarantine reason annotation to the annotations map
+func addQuarantineReasonAnnotation(reason *model.QuarantineReason, annotationsMap map[string]string) {
+	reasonJsonStr, err := json.Marshal(reason)
+	if err != nil {
+		slog.Error("Failed to marshal quarantine reason for annotation", "error", err)
+		return
+	}
+
+	annotationsMap[model.QuarantineReasonAnnotationKey] = string(reasonJsonStr)
+}
+
 // recordCordonEventInCircuitBreaker records a cordon event in the circuit breaker if enabled
 func (r *Reconciler) recordCordonEventInCircuitBreaker(event *model.HealthEventWithStatus) {
 	if r.config.CircuitBreakerEnabled &&
@@ -726,6 +978,33 @@ func (r *Reconciler) updateQuarantineMetrics(
 	}
 }
 
+// stampIncidentCreated records the time the rulesets decided to quarantine the node
+// for the event, and the latency from the earlier stages to it. An event processed
+// again, e.g. after a restart, keeps the time it was first decided on.
+func stampIncidentCreated(event *protos.HealthEvent) {
+	if _, ok := model.StageTimestamp(event, model.StageIncidentCreated); ok {
+		return
+	}
+
+	now := time.Now()
+	model.SetStageTimestamp(event, model.StageIncidentCreated, now)
+
+	for from, latency := range model.StageLatencies(event, model.StageIncidentCreated, now) {
+		metrics.PipelineStageLatency.WithLabelValues(string(from), string(model.StageIncidentCreated)).
+			Observe(latency.Seconds())
+	}
+}
+
+// observeQuarantineLatency records the latency from every pipeline stage stamped
+// on the event (log line read, emitted, ingested, incident created) to the node being
+// quarantined.
+func observeQuarantineLatency(event *protos.HealthEvent) {
+	for from, latency := range model.StageLatencies(event, model.StageQuarantined, time.Now()) {
+		metrics.PipelineStageLatency.WithLabelValues(string(from), string(model.StageQuarantined)).
+			Observe(latency.Seconds())
+	}
+}
+
 // eventMatchesAnyRule checks if an event matches at least one configured ruleset
 func (r *Reconciler) eventMatchesAnyRule(
 	event *protos.HealthEvent,
@@ -753,7 +1032,7 @@ func (r *Reconciler) handleUnhealthyEventOnQuarantinedNode(
 	healthEventsAnnotationMap *healthEventsAnnotation.HealthEventsAnnotationMap,
 ) bool {
 	if !r.eventMatchesAnyRule(event, ruleSetEvals) {
-		slog.Info("Unhealthy event on node doesn't match any rules, skipping annotation update",
+		slog.InfoContext(ctx, "Unhealthy event on node doesn't match any rules, skipping annotation update",
 			"checkName", event.CheckName, "node", event.NodeName)
 
 		return true
@@ -762,15 +1041,15 @@ func (r *Reconciler) handleUnhealthyEventOnQuarantinedNode(
 	added := healthEventsAnnotationMap.AddOrUpdateEvent(event)
 
 	if added {
-		slog.Info("Added entity failures for check on node",
+		slog.InfoContext(ctx, "Added entity failures for check on node",
 			"checkName", event.CheckName, "node", event.NodeName, "totalTrackedEntities", healthEventsAnnotationMap.Count())
 
 		if err := r.addEventToAnnotation(ctx, event); err != nil {
-			slog.Error("Failed to update health events annotation", "error", err)
+			slog.ErrorContext(ctx, "Failed to update health events annotation", "error", err)
 			return true
 		}
 	} else {
-		slog.Debug("All entities already tracked for check on node",
+		slog.DebugContext(ctx, "All entities already tracked for check on node",
 			"checkName", event.CheckName, "node", event.NodeName)
 	}
 
@@ -795,7 +1074,7 @@ func (r *Reconciler) handleQuarantinedNode(
 	}
 
 	if !hasExistingCheck {
-		slog.Debug("Received healthy event for untracked check (other checks may still be failing)",
+		slog.DebugContext(ctx, "Received healthy event for untracked check (other checks may still be failing)",
 			"check", event.CheckName,
 			"node", event.NodeName)
 
@@ -807,19 +1086,19 @@ func (r *Reconciler) handleQuarantinedNode(
 	removedCount := healthEventsAnnotationMap.RemoveEvent(event)
 
 	if removedCount > 0 {
-		slog.Info("Removed recovered entities for check on node",
+		slog.InfoContext(ctx, "Removed recovered entities for check on node",
 			"removedCount", removedCount,
 			"check", event.CheckName,
 			"node", event.NodeName,
 			"remainingEntities", healthEventsAnnotationMap.Count())
 	} else {
-		slog.Debug("No matching entities to remove for check on node",
+		slog.DebugContext(ctx, "No matching entities to remove for check on node",
 			"check", event.CheckName,
 			"node", event.NodeName)
 	}
 
 	if healthEventsAnnotationMap.IsEmpty() {
-		slog.Info("All health checks recovered for node, proceeding with uncordon",
+		slog.InfoContext(ctx, "All health checks recovered for node, proceeding with uncordon",
 			"node", event.NodeName)
 
 		return r.performUncordon(ctx, event, annotations)
@@ -827,11 +1106,11 @@ func (r *Reconciler) handleQuarantinedNode(
 
 	// Remove this event's entities from the node's annotation
 	if err := r.removeEventFromAnnotation(ctx, event); err != nil {
-		slog.Error("Failed to update health events annotation after recovery", "error", err)
+		slog.ErrorContext(ctx, "Failed to update health events annotation after recovery", "error", err)
 		return true
 	}
 
-	slog.Info("Node remains quarantined with failing checks",
+	slog.InfoContext(ctx, "Node remains quarantined with failing checks",
 		"node", event.NodeName,
 		"failingChecksCount", healthEventsAnnotationMap.Count(),
 		"checks", healthEventsAnnotationMap.GetAllCheckNames())
@@ -902,7 +1181,7 @@ func (r *Reconciler) addEventToAnnotation(
 
 		added := healthEventsMap.AddOrUpdateEvent(event)
 		if !added {
-			slog.Debug("Event already exists for node, no annotation update needed", "node", event.NodeName)
+			slog.DebugContext(ctx, "Event already exists for node, no annotation update needed", "node", event.NodeName)
 			return nil
 		}
 
@@ -913,7 +1192,8 @@ func (r *Reconciler) addEventToAnnotation(
 
 		node.Annotations[common.QuarantineHealthEventAnnotationKey] = string(annotationBytes)
 
-		slog.Debug("Added/updated event for node", "node", event.NodeName, "totalEntityLevelEvents", healthEventsMap.Count())
+		slog.DebugContext(ctx, "Added/updated event for node",
+			"node", event.NodeName, "totalEntityLevelEvents", healthEventsMap.Count())
 
 		return nil
 	}
@@ -948,7 +1228,8 @@ func (r *Reconciler) removeEventFromAnnotation(
 
 		removed := healthEventsMap.RemoveEvent(event)
 		if removed == 0 {
-			slog.Debug("No matching entities to remove for node, no annotation update needed", "node", event.NodeName)
+			slog.DebugContext(ctx, "No matching entities to remove for node, no annotation update needed",
+				"node", event.NodeName)
 			return nil
 		}
 
@@ -959,7 +1240,8 @@ func (r *Reconciler) removeEventFromAnnotation(
 
 		node.Annotations[common.QuarantineHealthEventAnnotationKey] = string(annotationBytes)
 
-		slog.Debug("Removed entities for node", "node", event.NodeName, "remainingEntityLevelEvents", healthEventsMap.Count())
+		slog.DebugContext(ctx, "Removed entities for node",
+			"node", event.NodeName, "remainingEntityLevelEvents", healthEventsMap.Count())
 
 		return nil
 	}
@@ -972,7 +1254,7 @@ func (r *Reconciler) performUncordon(
 	event *protos.HealthEvent,
 	annotations map[string]string,
 ) bool {
-	slog.Info("All entities recovered for check - proceeding with uncordon",
+	slog.InfoContext(ctx, "All entities recovered for check - proceeding with uncordon",
 		"check", event.CheckName,
 		"node", event.NodeName)
 
@@ -980,7 +1262,7 @@ func (r *Reconciler) performUncordon(
 	taintsToBeRemoved, annotationsToBeRemoved, isUnCordon, labelsMap, err := r.prepareUncordonParams(
 		event, annotations)
 	if err != nil {
-		slog.Error("Failed to prepare uncordon params for node", "node", event.NodeName, "error", err)
+		slog.ErrorContext(ctx, "Failed to prepare uncordon params for node", "node", event.NodeName, "error", err)
 		return true
 	}
 
@@ -989,14 +1271,16 @@ func (r *Reconciler) performUncordon(
 	}
 
 	if !isUnCordon {
-		slog.Warn("Node is not cordoned but has quarantine taints/annotations, proceeding with cleanup",
+		slog.WarnContext(ctx, "Node is not cordoned but has quarantine taints/annotations, proceeding with cleanup",
 			"node", event.NodeName)
 	}
 
-	annotationsToBeRemoved = append(annotationsToBeRemoved, common.QuarantineHealthEventAnnotationKey)
+	annotationsToBeRemoved = append(annotationsToBeRemoved,
+		common.QuarantineHealthEventAnnotationKey, model.QuarantineReasonAnnotationKey)
 
 	if !r.config.CircuitBreakerEnabled {
-		slog.Info("Circuit breaker is disabled, proceeding with unquarantine action for node", "node", event.NodeName)
+		slog.InfoContext(ctx, "Circuit breaker is disabled, proceeding with unquarantine action for node",
+			"node", event.NodeName)
 	}
 
 	labelsToRemove := []string{
@@ -1014,7 +1298,7 @@ func (r *Reconciler) performUncordon(
 		labelsToRemove,
 		labelsMap,
 	); err != nil {
-		slog.Error("Failed to untaint and uncordon node", "node", event.NodeName, "error", err)
+		slog.ErrorContext(ctx, "Failed to untaint and uncordon node", "node", event.NodeName, "error", err)
 		metrics.ProcessingErrors.WithLabelValues("untaint_and_uncordon_error").Inc()
 
 		return true
@@ -1108,6 +1392,7 @@ func (r *Reconciler) getNodeQuarantineAnnotations(nodeName string) (map[string]s
 		common.QuarantineHealthEventAppliedTaintsAnnotationKey,
 		common.QuarantineHealthEventIsCordonedAnnotationKey,
 		common.QuarantinedNodeUncordonedManuallyAnnotationKey,
+		model.QuarantineReasonAnnotationKey,
 	}
 
 	if node.Annotations != nil {
@@ -1126,16 +1411,18 @@ func (r *Reconciler) getNodeQuarantineAnnotations(nodeName string) (map[string]s
 func (r *Reconciler) cleanupManualUncordonAnnotation(ctx context.Context, nodeName string,
 	annotations map[string]string) {
 	if _, hasManualUncordon := annotations[common.QuarantinedNodeUncordonedManuallyAnnotationKey]; hasManualUncordon {
-		slog.Info("Removing manual uncordon annotation from node before applying new quarantine", "node", nodeName)
+		slog.InfoContext(ctx, "Removing manual uncordon annotation from node before applying new quarantine",
+			"node", nodeName)
 
 		updateFn := func(node *corev1.Node) error {
 			if node.Annotations == nil {
-				slog.Debug("Node has no annotations, manual uncordon annotation already absent", "node", nodeName)
+				slog.DebugContext(ctx, "Node has no annotations, manual uncordon annotation already absent",
+					"node", nodeName)
 				return nil
 			}
 
 			if _, exists := node.Annotations[common.QuarantinedNodeUncordonedManuallyAnnotationKey]; !exists {
-				slog.Debug("Manual uncordon annotation already removed from node", "node", nodeName)
+				slog.DebugContext(ctx, "Manual uncordon annotation already removed from node", "node", nodeName)
 				return nil
 			}
 
@@ -1145,7 +1432,8 @@ func (r *Reconciler) cleanupManualUncordonAnnotation(ctx context.Context, nodeNa
 		}
 
 		if err := r.k8sClient.UpdateNode(ctx, nodeName, updateFn); err != nil {
-			slog.Error("Failed to remove manual uncordon annotation from node", "node", nodeName, "error", err)
+			slog.ErrorContext(ctx, "Failed to remove manual uncordon annotation from node",
+				"node", nodeName, "error", err)
 		}
 	}
 }
@@ -1180,6 +1468,10 @@ func (r *Reconciler) handleManualUncordon(nodeName string) error {
 		annotationsToRemove = append(annotationsToRemove, common.QuarantineHealthEventIsCordonedAnnotationKey)
 	}
 
+	if _, exists := annotations[model.QuarantineReasonAnnotationKey]; exists {
+		annotationsToRemove = append(annotationsToRemove, model.QuarantineReasonAnnotationKey)
+	}
+
 	newAnnotations := map[string]string{
 		common.QuarantinedNodeUncordonedManuallyAnnotationKey: common.QuarantinedNodeUncordonedManuallyAnnotationValue,
 	}
@@ -1194,14 +1486,14 @@ func (r *Reconciler) handleManualUncordon(nodeName string) error {
 		newAnnotations,
 		[]string{statemanager.NVSentinelStateLabelKey},
 	); err != nil {
-		slog.Error("Failed to clean up manually uncordoned node", "node", nodeName, "error", err)
+		slog.ErrorContext(ctx, "Failed to clean up manually uncordoned node", "node", nodeName, "error", err)
 		metrics.ProcessingErrors.WithLabelValues("manual_uncordon_cleanup_error").Inc()
 
 		return fmt.Errorf("failed to clean up manually uncordoned node %s: %w", nodeName, err)
 	}
 
 	if err := r.eventWatcher.CancelLatestQuarantiningEvents(ctx, nodeName); err != nil {
-		slog.Error("Failed to cancel latest quarantining events for manually uncordoned node",
+		slog.ErrorContext(ctx, "Failed to cancel latest quarantining events for manually uncordoned node",
 			"node", nodeName,
 			"error", err)
 		metrics.ProcessingErrors.WithLabelValues("mongodb_cancelled_update_error").Inc()
@@ -1211,9 +1503,9 @@ func (r *Reconciler) handleManualUncordon(nodeName string) error {
 
 	metrics.TotalNodesManuallyUncordoned.WithLabelValues(nodeName).Inc()
 	metrics.CurrentQuarantinedNodes.WithLabelValues(nodeName).Set(0)
-	slog.Info("Set currentQuarantinedNodes to 0 for manually uncordoned node", "node", nodeName)
+	slog.InfoContext(ctx, "Set currentQuarantinedNodes to 0 for manually uncordoned node", "node", nodeName)
 
-	slog.Info("Successfully handled manual uncordon for node", "node", nodeName)
+	slog.InfoContext(ctx, "Successfully handled manual uncordon for node", "node", nodeName)
 
 	return nil
 }
@@ -24,6 +24,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/nvidia/nvsentinel/commons/pkg/enforcement"
 	"github.com/nvidia/nvsentinel/commons/pkg/statemanager"
 	"github.com/nvidia/nvsentinel/data-models/pkg/model"
 	"github.com/nvidia/nvsentinel/data-models/pkg/protos"
@@ -32,6 +33,7 @@ import (
 	"github.com/nvidia/nvsentinel/fault-quarantine/pkg/config"
 	"github.com/nvidia/nvsentinel/fault-quarantine/pkg/evaluator"
 	"github.com/nvidia/nvsentinel/fault-quarantine/pkg/healthEventsAnnotation"
+	"github.com/nvidia/nvsentinel/fault-quarantine/pkg/incident"
 	"github.com/nvidia/nvsentinel/fault-quarantine/pkg/informer"
 	"github.com/nvidia/nvsentinel/fault-quarantine/pkg/metrics"
 	"github.com/nvidia/nvsentinel/fault-quarantine/pkg/mongodb"
@@ -200,6 +202,10 @@ func (m *MockMongoCollectionForCancellation) FindOne(ctx context.Context, filter
 	return mongo.NewSingleResultFromDocument(result, nil, nil)
 }
 
+func (m *MockMongoCollectionForCancellation) Find(ctx context.Context, filter interface{}, opts ...*options.FindOptions) (*mongo.Cursor, error) {
+	return mongo.NewCursorFromDocuments(nil, nil, nil)
+}
+
 func (m *MockMongoCollectionForCancellation) UpdateOne(ctx context.Context, filter interface{}, update interface{}, opts ...*options.UpdateOptions) (*mongo.UpdateResult, error) {
 	return &mongo.UpdateResult{
 		MatchedCount:  1,
@@ -312,6 +318,7 @@ func setupE2EReconcilerWithOptions(t *testing.T, ctx context.Context, cfg E2ERec
 		TaintConfigMap:     make(map[string]*config.Taint),
 		CordonConfigMap:    make(map[string]bool),
 		RuleSetPriorityMap: make(map[string]int),
+		RunbookURLMap:      make(map[string]string),
 	}
 
 	for _, ruleSet := range cfg.TomlConfig.RuleSets {
@@ -324,6 +331,9 @@ func setupE2EReconcilerWithOptions(t *testing.T, ctx context.Context, cfg E2ERec
 		if ruleSet.Priority > 0 {
 			rulesetsConfig.RuleSetPriorityMap[ruleSet.Name] = ruleSet.Priority
 		}
+		if ruleSet.RunbookURL != "" {
+			rulesetsConfig.RunbookURLMap[ruleSet.Name] = ruleSet.RunbookURL
+		}
 	}
 
 	r.precomputeTaintInitKeys(ruleSetEvals, rulesetsConfig)
@@ -518,8 +528,9 @@ func TestE2E_BasicQuarantineAndUnquarantine(t *testing.T) {
 						{Kind: "HealthEvent", Expression: "event.checkName == 'GpuXidError' && event.isFatal == true"},
 					},
 				},
-				Taint:  config.Taint{Key: "nvidia.com/gpu-xid-error", Value: "true", Effect: "NoSchedule"},
-				Cordon: config.Cordon{ShouldCordon: true},
+				Taint:      config.Taint{Key: "nvidia.com/gpu-xid-error", Value: "true", Effect: "NoSchedule"},
+				Cordon:     config.Cordon{ShouldCordon: true},
+				RunbookURL: "https://runbooks.example.com/gpu-xid",
 			},
 		},
 	}
@@ -568,6 +579,15 @@ func TestE2E_BasicQuarantineAndUnquarantine(t *testing.T) {
 	assert.Equal(t, "True", node.Annotations[quarantineHealthEventIsCordonedAnnotationKey], "Cordon annotation should be True")
 	verifyQuarantineLabels(t, node, "gpu-xid-critical-errors")
 
+	t.Log("Verify quarantine reason annotation content")
+	var reason model.QuarantineReason
+	require.NoError(t, json.Unmarshal([]byte(node.Annotations[model.QuarantineReasonAnnotationKey]), &reason))
+	assert.Equal(t, "GpuXidError", reason.CheckName)
+	assert.Equal(t, []string{"gpu-xid-critical-errors"}, reason.RuleSets)
+	assert.Equal(t, []string{"nvidia.com/gpu-xid-error=true:NoSchedule"}, reason.Taints)
+	assert.Equal(t, "https://runbooks.example.com/gpu-xid", reason.RunbookURL)
+	assert.True(t, reason.Cordoned)
+
 	afterProcessed := getCounterValue(t, metrics.TotalEventsSuccessfullyProcessed)
 	afterQuarantined := getCounterVecValue(t, metrics.TotalNodesQuarantined, nodeName)
 	afterGauge := getGaugeVecValue(t, metrics.CurrentQuarantinedNodes, nodeName)
@@ -622,6 +642,7 @@ func TestE2E_BasicQuarantineAndUnquarantine(t *testing.T) {
 	assert.Empty(t, node.Annotations[quarantineHealthEventAnnotationKey], "Quarantine annotation should be removed")
 	assert.Empty(t, node.Annotations[quarantineHealthEventAppliedTaintsAnnotationKey], "Applied taints annotation should be removed")
 	assert.Empty(t, node.Annotations[quarantineHealthEventIsCordonedAnnotationKey], "Cordoned annotation should be removed")
+	assert.Empty(t, node.Annotations[model.QuarantineReasonAnnotationKey], "Quarantine reason annotation should be removed")
 	verifyUnquarantineLabels(t, node)
 
 	afterUnquarantined := getCounterVecValue(t, metrics.TotalNodesUnquarantined, nodeName)
@@ -3542,6 +3563,206 @@ func TestE2E_DryRunMode(t *testing.T) {
 	assert.NotEmpty(t, node.Annotations[common.QuarantineHealthEventAnnotationKey], "Annotations are still added in dry run")
 }
 
+func TestE2E_CanaryEventIsDryRun(t *testing.T) {
+	ctx, cancel := context.WithTimeout(e2eTestContext, 20*time.Second)
+	defer cancel()
+
+	nodeName := "e2e-canary-" + primitive.NewObjectID().Hex()[:8]
+	createE2ETestNode(ctx, t, nodeName, nil, nil, nil, false)
+	defer func() {
+		_ = e2eTestClient.CoreV1().Nodes().Delete(ctx, nodeName, metav1.DeleteOptions{})
+	}()
+
+	tomlConfig := config.TomlConfig{
+		LabelPrefix: "k8s.nvidia.com/",
+		RuleSets: []config.RuleSet{
+			{
+				Name:     "gpu-xid-errors",
+				Version:  "1",
+				Priority: 10,
+				Match: config.Match{
+					Any: []config.Rule{
+						{Kind: "HealthEvent", Expression: "event.checkName == 'GpuXidError'"},
+					},
+				},
+				Taint:  config.Taint{Key: "nvidia.com/gpu-xid-error", Value: "true", Effect: "NoSchedule"},
+				Cordon: config.Cordon{ShouldCordon: true},
+			},
+		},
+	}
+
+	_, mockWatcher, getStatus, _ := setupE2EReconcilerWithOptions(t, ctx, E2EReconcilerConfig{
+		TomlConfig: tomlConfig,
+	})
+
+	t.Log("Sending matching canary event")
+	eventID := primitive.NewObjectID()
+	event := createHealthEventBSON(
+		eventID,
+		nodeName,
+		"GpuXidError",
+		false,
+		true,
+		[]*protos.Entity{{EntityType: "GPU", EntityValue: "0"}},
+		model.StatusInProgress,
+	)
+	event["fullDocument"].(bson.M)["healthevent"].(bson.M)["metadata"] = bson.M{model.MetadataCanary: "true"}
+	mockWatcher.EventsChan <- event
+
+	require.Eventually(t, func() bool {
+		status := getStatus(eventID)
+		return status != nil && *status == model.CanaryQuarantined
+	}, statusCheckTimeout, statusCheckPollInterval, "Status should be CanaryQuarantined")
+
+	t.Log("Verify the node is left untouched")
+	node, err := e2eTestClient.CoreV1().Nodes().Get(ctx, nodeName, metav1.GetOptions{})
+	require.NoError(t, err)
+	assert.False(t, node.Spec.Unschedulable, "Node should NOT be cordoned for a canary event")
+	assert.Empty(t, node.Spec.Taints, "Node should NOT be tainted for a canary event")
+	assert.Empty(t, node.Annotations[common.QuarantineHealthEventAnnotationKey],
+		"Node should NOT be annotated for a canary event")
+}
+
+func TestE2E_EnforcementOffIsObserveOnly(t *testing.T) {
+	ctx, cancel := context.WithTimeout(e2eTestContext, 20*time.Second)
+	defer cancel()
+
+	nodeName := "e2e-enforcement-off-" + primitive.NewObjectID().Hex()[:8]
+	createE2ETestNode(ctx, t, nodeName, map[string]string{enforcement.Key: enforcement.Off}, nil, nil, false)
+	defer func() {
+		_ = e2eTestClient.CoreV1().Nodes().Delete(ctx, nodeName, metav1.DeleteOptions{})
+	}()
+
+	tomlConfig := config.TomlConfig{
+		LabelPrefix: "k8s.nvidia.com/",
+		RuleSets: []config.RuleSet{
+			{
+				Name:     "gpu-xid-errors",
+				Version:  "1",
+				Priority: 10,
+				Match: config.Match{
+					Any: []config.Rule{
+						{Kind: "HealthEvent", Expression: "event.checkName == 'GpuXidError'"},
+					},
+				},
+				Taint:  config.Taint{Key: "nvidia.com/gpu-xid-error", Value: "true", Effect: "NoSchedule"},
+				Cordon: config.Cordon{ShouldCordon: true},
+			},
+		},
+	}
+
+	_, mockWatcher, getStatus, _ := setupE2EReconcilerWithOptions(t, ctx, E2EReconcilerConfig{
+		TomlConfig: tomlConfig,
+	})
+
+	t.Log("Sending matching unhealthy event")
+	eventID := primitive.NewObjectID()
+	mockWatcher.EventsChan <- createHealthEventBSON(
+		eventID,
+		nodeName,
+		"GpuXidError",
+		false,
+		true,
+		[]*protos.Entity{{EntityType: "GPU", EntityValue: "0"}},
+		model.StatusInProgress,
+	)
+
+	require.Eventually(t, func() bool {
+		status := getStatus(eventID)
+		return status != nil && *status == model.ObserveOnlyQuarantined
+	}, statusCheckTimeout, statusCheckPollInterval, "Status should be ObserveOnlyQuarantined")
+
+	t.Log("Verify the node is left untouched")
+	node, err := e2eTestClient.CoreV1().Nodes().Get(ctx, nodeName, metav1.GetOptions{})
+	require.NoError(t, err)
+	assert.False(t, node.Spec.Unschedulable, "Node should NOT be cordoned while enforcement is off")
+	assert.Empty(t, node.Spec.Taints, "Node should NOT be tainted while enforcement is off")
+	assert.Empty(t, node.Annotations[common.QuarantineHealthEventAnnotationKey],
+		"Node should NOT be annotated while enforcement is off")
+}
+
+func TestE2E_CorroborationHoldsFatalEvents(t *testing.T) {
+	ctx, cancel := context.WithTimeout(e2eTestContext, 20*time.Second)
+	defer cancel()
+
+	nodeName := "e2e-corroboration-" + primitive.NewObjectID().Hex()[:8]
+	createE2ETestNode(ctx, t, nodeName, nil, nil, nil, false)
+	defer func() {
+		_ = e2eTestClient.CoreV1().Nodes().Delete(ctx, nodeName, metav1.DeleteOptions{})
+	}()
+
+	tomlConfig := config.TomlConfig{
+		LabelPrefix: "k8s.nvidia.com/",
+		RuleSets: []config.RuleSet{
+			{
+				Name:     "fatal-errors",
+				Version:  "1",
+				Priority: 10,
+				Match: config.Match{
+					Any: []config.Rule{
+						{Kind: "HealthEvent", Expression: "event.isFatal == true"},
+					},
+				},
+				Cordon: config.Cordon{ShouldCordon: true},
+			},
+		},
+		Corroborations: []config.Corroboration{
+			{CompositeRule: "CorroboratedGPUFallenOffBus", ErrorCodes: []string{"79"}},
+		},
+	}
+
+	_, mockWatcher, getStatus, _ := setupE2EReconcilerWithOptions(t, ctx, E2EReconcilerConfig{
+		TomlConfig: tomlConfig,
+	})
+
+	withErrorCode := func(event bson.M, errorCode string) bson.M {
+		event["fullDocument"].(bson.M)["healthevent"].(bson.M)["errorcode"] = []string{errorCode}
+		return event
+	}
+
+	t.Log("Sending fatal Xid 79 event awaiting corroboration")
+	heldID := primitive.NewObjectID()
+	mockWatcher.EventsChan <- withErrorCode(createHealthEventBSON(
+		heldID,
+		nodeName,
+		"SysLogsXIDError",
+		false,
+		true,
+		[]*protos.Entity{{EntityType: "GPU", EntityValue: "0"}},
+		model.StatusInProgress,
+	), "79")
+
+	require.Eventually(t, func() bool {
+		status := getStatus(heldID)
+		return status != nil && *status == model.AwaitingCorroboration
+	}, statusCheckTimeout, statusCheckPollInterval, "Status should be AwaitingCorroboration")
+
+	node, err := e2eTestClient.CoreV1().Nodes().Get(ctx, nodeName, metav1.GetOptions{})
+	require.NoError(t, err)
+	assert.False(t, node.Spec.Unschedulable, "Node should NOT be cordoned before the fault is corroborated")
+
+	t.Log("Sending the event of the composite rule")
+	corroboratedID := primitive.NewObjectID()
+	mockWatcher.EventsChan <- withErrorCode(createHealthEventBSON(
+		corroboratedID,
+		nodeName,
+		"CorroboratedGPUFallenOffBus",
+		false,
+		true,
+		[]*protos.Entity{{EntityType: "GPU", EntityValue: "0"}},
+		model.StatusInProgress,
+	), "79")
+
+	require.Eventually(t, func() bool {
+		status := getStatus(corroboratedID)
+		return status != nil && *status == model.Quarantined
+	}, statusCheckTimeout, statusCheckPollInterval, "The composite rule should quarantine the node")
+
+	node, err = e2eTestClient.CoreV1().Nodes().Get(ctx, nodeName, metav1.GetOptions{})
+	require.NoError(t, err)
+	assert.True(t, node.Spec.Unschedulable, "Node should be cordoned once the fault is corroborated")
+}
+
 func TestE2E_TaintOnlyThenCordonRule(t *testing.T) {
 	ctx, cancel := context.WithTimeout(e2eTestContext, 20*time.Second)
 	defer cancel()
@@ -3878,3 +4099,107 @@ func TestE2E_UnhealthyEventNotMatchingRulesNotPropagated(t *testing.T) {
 	require.NoError(t, err)
 	assert.Equal(t, 1, healthEventsMap.Count(), "Should still have only GpuXidError tracked")
 }
+
+func TestE2E_CrossNodeIncidentQuarantinedOnApproval(t *testing.T) {
+	ctx, cancel := context.WithTimeout(e2eTestContext, 30*time.Second)
+	defer cancel()
+
+	suffix := primitive.NewObjectID().Hex()[:8]
+	nodeNames := []string{"e2e-cross-node-0-" + suffix, "e2e-cross-node-1-" + suffix, "e2e-cross-node-2-" + suffix}
+
+	for _, nodeName := range nodeNames {
+		createE2ETestNode(ctx, t, nodeName, nil, nil, nil, false)
+	}
+	defer func() {
+		for _, nodeName := range nodeNames {
+			_ = e2eTestClient.CoreV1().Nodes().Delete(ctx, nodeName, metav1.DeleteOptions{})
+		}
+	}()
+
+	tomlConfig := config.TomlConfig{
+		LabelPrefix: "k8s.nvidia.com/",
+		RuleSets: []config.RuleSet{
+			{
+				Name:     "switch-tray-failure",
+				Version:  "1",
+				Priority: 10,
+				Match: config.Match{
+					Any: []config.Rule{
+						{Kind: "HealthEvent", Expression: "event.checkName == 'NVSwitchTrayFailure'"},
+					},
+				},
+				Taint:  config.Taint{Key: "nvidia.com/nvswitch-failure", Value: "true", Effect: "NoSchedule"},
+				Cordon: config.Cordon{ShouldCordon: true},
+			},
+		},
+	}
+
+	r, mockWatcher, getStatus, _ := setupE2EReconcilerWithMongoDBMock(t, ctx, tomlConfig)
+	r.k8sClient.NodeInformer.SetOnIncidentApprovedCallback(r.handleIncidentApproval)
+
+	t.Log("Send an event affecting all three nodes")
+	incidentID := model.NewEventID()
+	eventID := primitive.NewObjectID()
+	mockWatcher.EventsChan <- bson.M{
+		"operationType": "insert",
+		"fullDocument": bson.M{
+			"_id": eventID,
+			"healtheventstatus": bson.M{
+				"nodequarantined": model.StatusInProgress,
+			},
+			"healthevent": bson.M{
+				"id":             incidentID,
+				"nodename":       nodeNames[0],
+				"agent":          "fabric-health-monitor",
+				"componentclass": "NVSWITCH",
+				"checkname":      "NVSwitchTrayFailure",
+				"version":        uint32(1),
+				"ishealthy":      false,
+				"isfatal":        true,
+				"metadata": bson.M{
+					model.MetadataAffectedNodes: nodeNames[1] + "," + nodeNames[2],
+				},
+			},
+		},
+	}
+
+	require.Eventually(t, func() bool {
+		status := getStatus(eventID)
+		return status != nil && *status == model.PendingApproval
+	}, statusCheckTimeout, statusCheckPollInterval, "Status should be PendingApproval")
+
+	for _, nodeName := range nodeNames {
+		node, err := e2eTestClient.CoreV1().Nodes().Get(ctx, nodeName, metav1.GetOptions{})
+		require.NoError(t, err)
+		assert.False(t, node.Spec.Unschedulable, "Node %s should NOT be cordoned before the approval", nodeName)
+	}
+
+	t.Log("Approve the incident on one of the affected nodes")
+	node, err := e2eTestClient.CoreV1().Nodes().Get(ctx, nodeNames[2], metav1.GetOptions{})
+	require.NoError(t, err)
+
+	if node.Annotations == nil {
+		node.Annotations = map[string]string{}
+	}
+
+	node.Annotations[common.QuarantineIncidentApprovalAnnotationKey] = incidentID
+	_, err = e2eTestClient.CoreV1().Nodes().Update(ctx, node, metav1.UpdateOptions{})
+	require.NoError(t, err)
+
+	t.Log("Verify all the nodes are quarantined together")
+	for _, nodeName := range nodeNames {
+		require.Eventually(t, func() bool {
+			node, err := e2eTestClient.CoreV1().Nodes().Get(ctx, nodeName, metav1.GetOptions{})
+			if err != nil {
+				return false
+			}
+
+			return node.Spec.Unschedulable &&
+				node.Annotations[quarantineHealthEventAnnotationKey] != "" &&
+				node.Annotations[common.QuarantineIncidentApprovalAnnotationKey] == ""
+		}, eventuallyTimeout, eventuallyPollInterval, "Node %s should be quarantined", nodeName)
+	}
+
+	_, err = r.pendingIncidents.Approve(incidentID, nodeNames[0])
+	assert.ErrorIs(t, err, incident.ErrNotPending, "The incident should no longer be pending")
+}
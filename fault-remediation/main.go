@@ -24,9 +24,11 @@ import (
 	"strconv"
 	"syscall"
 
+	"github.com/nvidia/nvsentinel/commons/pkg/configmanager"
 	"github.com/nvidia/nvsentinel/commons/pkg/logger"
 	"github.com/nvidia/nvsentinel/commons/pkg/server"
 	"github.com/nvidia/nvsentinel/fault-remediation/pkg/initializer"
+	"github.com/nvidia/nvsentinel/fault-remediation/pkg/plan"
 	"golang.org/x/sync/errgroup"
 )
 
@@ -58,12 +60,6 @@ func run() error {
 		return fmt.Errorf("invalid metrics port: %w", err)
 	}
 
-	srv := server.NewServer(
-		server.WithPort(portInt),
-		server.WithPrometheusMetrics(),
-		server.WithSimpleHealth(),
-	)
-
 	params := initializer.InitializationParams{
 		KubeconfigPath:     *kubeconfigPath,
 		TomlConfigPath:     *tomlConfigPath,
@@ -76,6 +72,16 @@ func run() error {
 		return fmt.Errorf("initialization failed: %w", err)
 	}
 
+	srv := server.NewServer(
+		server.WithPort(portInt),
+		server.WithPrometheusMetrics(),
+		server.WithHandler(logger.LevelsPath, logger.LevelsHandler()),
+		server.WithSimpleHealth(),
+		server.WithHandler(configmanager.ConfigPath,
+			configmanager.Handler("fault-remediation", components.Config, nil)),
+		server.WithHandler(plan.PathPrefix, plan.Handler(components.PlanStore)),
+	)
+
 	g, gCtx := errgroup.WithContext(ctx)
 
 	g.Go(func() error {
@@ -28,6 +28,15 @@ var RemediationEquivalenceGroups = map[string][]protos.RecommendedAction{
 		protos.RecommendedAction_RESTART_VM,
 		protos.RecommendedAction_RESTART_BM,
 	},
+	"driver-reload": {
+		protos.RecommendedAction_DRIVER_RELOAD,
+	},
+	"mig-reconfigure": {
+		protos.RecommendedAction_MIG_RECONFIGURE,
+	},
+	"power-cap": {
+		protos.RecommendedAction_POWER_CAP,
+	},
 }
 
 // GetRemediationGroupForAction returns the equivalence group key for a given action.
@@ -42,6 +42,21 @@ func TestGetRemediationGroupForAction(t *testing.T) {
 			action:        protos.RecommendedAction_RESTART_BM,
 			expectedGroup: "restart",
 		},
+		{
+			name:          "DRIVER_RELOAD returns driver-reload group",
+			action:        protos.RecommendedAction_DRIVER_RELOAD,
+			expectedGroup: "driver-reload",
+		},
+		{
+			name:          "MIG_RECONFIGURE returns mig-reconfigure group",
+			action:        protos.RecommendedAction_MIG_RECONFIGURE,
+			expectedGroup: "mig-reconfigure",
+		},
+		{
+			name:          "POWER_CAP returns power-cap group",
+			action:        protos.RecommendedAction_POWER_CAP,
+			expectedGroup: "power-cap",
+		},
 		{
 			name:          "CONTACT_SUPPORT returns empty string (not in any group)",
 			action:        protos.RecommendedAction_CONTACT_SUPPORT,
@@ -82,6 +97,20 @@ func TestGetActionsForGroup(t *testing.T) {
 				protos.RecommendedAction_RESTART_BM,
 			},
 		},
+		{
+			name:  "driver-reload group returns driver reload action",
+			group: "driver-reload",
+			expectedActions: []protos.RecommendedAction{
+				protos.RecommendedAction_DRIVER_RELOAD,
+			},
+		},
+		{
+			name:  "mig-reconfigure group returns MIG reconfigure action",
+			group: "mig-reconfigure",
+			expectedActions: []protos.RecommendedAction{
+				protos.RecommendedAction_MIG_RECONFIGURE,
+			},
+		},
 		{
 			name:            "non-existent group returns nil",
 			group:           "non-existent",
@@ -14,6 +14,11 @@
 
 package config
 
+import (
+	"slices"
+	"time"
+)
+
 // MaintenanceResource holds configuration for the maintenance custom resource
 type MaintenanceResource struct {
 	Namespace             string `toml:"namespace"`
@@ -21,6 +26,50 @@ type MaintenanceResource struct {
 	ApiGroup              string `toml:"apiGroup"`
 	Kind                  string `toml:"kind"`
 	CompleteConditionType string `toml:"completeConditionType"`
+	// EstimatedDowntimeSeconds is how long the maintenance takes the node out of
+	// service, shown in the remediation plans
+	EstimatedDowntimeSeconds int `toml:"estimatedDowntimeSeconds"`
+	// GroupResources overrides Kind and CompleteConditionType for the maintenance
+	// resources created for an equivalence group, keyed by group name
+	GroupResources map[string]GroupResource `toml:"groupResources"`
+}
+
+// GroupResource holds the maintenance resource kind of an equivalence group, e.g.
+// DriverReload for the driver-reload group
+type GroupResource struct {
+	Kind                     string `toml:"kind"`
+	CompleteConditionType    string `toml:"completeConditionType"`
+	EstimatedDowntimeSeconds int    `toml:"estimatedDowntimeSeconds"`
+}
+
+// ForGroup returns the kind and complete condition type of the maintenance resources
+// created for the equivalence group
+func (m MaintenanceResource) ForGroup(group string) (string, string) {
+	kind, conditionType := m.Kind, m.CompleteConditionType
+
+	if resource, ok := m.GroupResources[group]; ok {
+		if resource.Kind != "" {
+			kind = resource.Kind
+		}
+
+		if resource.CompleteConditionType != "" {
+			conditionType = resource.CompleteConditionType
+		}
+	}
+
+	return kind, conditionType
+}
+
+// EstimatedDowntimeForGroup returns how long the maintenance of the equivalence
+// group takes the node out of service, 0 if unknown
+func (m MaintenanceResource) EstimatedDowntimeForGroup(group string) time.Duration {
+	seconds := m.EstimatedDowntimeSeconds
+
+	if resource, ok := m.GroupResources[group]; ok && resource.EstimatedDowntimeSeconds > 0 {
+		seconds = resource.EstimatedDowntimeSeconds
+	}
+
+	return time.Duration(seconds) * time.Second
 }
 
 // Template holds configuration for template files
@@ -35,9 +84,47 @@ type UpdateRetry struct {
 	RetryDelaySeconds int `toml:"retryDelaySeconds"`
 }
 
+// Runbook runs an operator supplied script on the node of the events it matches,
+// instead of creating a maintenance resource, for site specific fixes such as
+// resetting the firmware of a NIC
+type Runbook struct {
+	Name string `toml:"name"`
+	// CheckName and ErrorCodes select the events; an empty field matches any event
+	// but at least one of them is required
+	CheckName  string   `toml:"checkName"`
+	ErrorCodes []string `toml:"errorCodes"`
+	// JobTemplate is the file name, under the template mount path, of the Go
+	// template of the Job running the script
+	JobTemplate string `toml:"jobTemplate"`
+	// TimeoutSeconds is how long to wait for the Job to complete
+	TimeoutSeconds int `toml:"timeoutSeconds"`
+}
+
+// Matches reports whether the runbook applies to an event of the check with the
+// error codes
+func (r Runbook) Matches(checkName string, errorCodes []string) bool {
+	if r.CheckName != "" && r.CheckName != checkName {
+		return false
+	}
+
+	if len(r.ErrorCodes) == 0 {
+		return true
+	}
+
+	for _, code := range errorCodes {
+		if slices.Contains(r.ErrorCodes, code) {
+			return true
+		}
+	}
+
+	return false
+}
+
 // TomlConfig holds the complete TOML configuration for fault remediation
 type TomlConfig struct {
 	MaintenanceResource MaintenanceResource `toml:"maintenanceResource"`
 	Template            Template            `toml:"template"`
 	UpdateRetry         UpdateRetry         `toml:"updateRetry"`
+	// Runbooks are matched in order, the first matching an event runs for it
+	Runbooks []Runbook `toml:"runbooks"`
 }
@@ -47,16 +47,20 @@ func NewCRStatusChecker(
 	}
 }
 
-func (c *CRStatusChecker) ShouldSkipCRCreation(ctx context.Context, crName string) bool {
+// ShouldSkipCRCreation returns true if the maintenance CR of the equivalence group
+// is still in progress
+func (c *CRStatusChecker) ShouldSkipCRCreation(ctx context.Context, crName string, group string) bool {
 	if c.dryRun {
 		slog.Info("DRY-RUN: CR doesn't exist (dry-run mode)", "crName", crName)
 		return false
 	}
 
+	kind, conditionType := c.config.ForGroup(group)
+
 	gvk := schema.GroupVersionKind{
 		Group:   c.config.ApiGroup,
 		Version: c.config.Version,
-		Kind:    c.config.Kind,
+		Kind:    kind,
 	}
 
 	mapping, err := c.restMapper.RESTMapping(gvk.GroupKind(), gvk.Version)
@@ -71,10 +75,10 @@ func (c *CRStatusChecker) ShouldSkipCRCreation(ctx context.Context, crName strin
 		return false
 	}
 
-	return c.checkCondition(resource)
+	return c.checkCondition(resource, conditionType)
 }
 
-func (c *CRStatusChecker) checkCondition(obj *unstructured.Unstructured) bool {
+func (c *CRStatusChecker) checkCondition(obj *unstructured.Unstructured, conditionType string) bool {
 	status, found, err := unstructured.NestedMap(obj.Object, "status")
 	if err != nil || !found {
 		return true
@@ -85,12 +89,12 @@ func (c *CRStatusChecker) checkCondition(obj *unstructured.Unstructured) bool {
 		return true
 	}
 
-	conditionStatus := c.findConditionStatus(conditions)
+	conditionStatus := c.findConditionStatus(conditions, conditionType)
 
 	return !c.isTerminal(conditionStatus)
 }
 
-func (c *CRStatusChecker) findConditionStatus(conditions []any) string {
+func (c *CRStatusChecker) findConditionStatus(conditions []any, conditionType string) string {
 	for _, cond := range conditions {
 		condition, ok := cond.(map[string]interface{})
 		if !ok {
@@ -98,7 +102,7 @@ func (c *CRStatusChecker) findConditionStatus(conditions []any) string {
 		}
 
 		condType, _ := condition["type"].(string)
-		if condType == c.config.CompleteConditionType {
+		if condType == conditionType {
 			condStatus, _ := condition["status"].(string)
 			return condStatus
 		}
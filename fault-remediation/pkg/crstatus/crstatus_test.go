@@ -110,8 +110,40 @@ func TestCheckCondition(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			result := checker.checkCondition(tt.cr)
+			result := checker.checkCondition(tt.cr, cfg.CompleteConditionType)
 			assert.Equal(t, tt.expected, result)
 		})
 	}
 }
+
+func TestCheckConditionForGroup(t *testing.T) {
+	cfg := &config.MaintenanceResource{
+		Kind:                  "RebootNode",
+		CompleteConditionType: "NodeReady",
+		GroupResources: map[string]config.GroupResource{
+			"driver-reload": {Kind: "DriverReload", CompleteConditionType: "DriverReady"},
+		},
+	}
+	checker := NewCRStatusChecker(nil, nil, cfg, false)
+
+	cr := &unstructured.Unstructured{
+		Object: map[string]any{
+			"status": map[string]any{
+				"conditions": []any{
+					map[string]any{
+						"type":   "DriverReady",
+						"status": "True",
+					},
+				},
+			},
+		},
+	}
+
+	kind, conditionType := cfg.ForGroup("driver-reload")
+	assert.Equal(t, "DriverReload", kind)
+	assert.False(t, checker.checkCondition(cr, conditionType))
+
+	kind, conditionType = cfg.ForGroup("restart")
+	assert.Equal(t, "RebootNode", kind)
+	assert.True(t, checker.checkCondition(cr, conditionType))
+}
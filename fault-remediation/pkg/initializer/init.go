@@ -21,10 +21,13 @@ import (
 	"time"
 
 	"github.com/nvidia/nvsentinel/commons/pkg/configmanager"
+	"github.com/nvidia/nvsentinel/commons/pkg/nodelock"
 	"github.com/nvidia/nvsentinel/commons/pkg/statemanager"
 	"github.com/nvidia/nvsentinel/data-models/pkg/model"
 	"github.com/nvidia/nvsentinel/fault-remediation/pkg/config"
+	"github.com/nvidia/nvsentinel/fault-remediation/pkg/plan"
 	"github.com/nvidia/nvsentinel/fault-remediation/pkg/reconciler"
+	"github.com/nvidia/nvsentinel/fault-remediation/pkg/runbook"
 	"github.com/nvidia/nvsentinel/store-client/pkg/storewatcher"
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/mongo"
@@ -38,7 +41,11 @@ type InitializationParams struct {
 }
 
 type Components struct {
+	// Config is the loaded configuration
+	Config     *config.TomlConfig
 	Reconciler *reconciler.Reconciler
+	// PlanStore holds the latest remediation plan of every node
+	PlanStore *plan.Store
 }
 
 func InitializeAll(ctx context.Context, params InitializationParams) (*Components, error) {
@@ -79,6 +86,13 @@ func InitializeAll(ctx context.Context, params InitializationParams) (*Component
 
 	slog.Info("Successfully initialized k8s client")
 
+	var logCollectorTimeout time.Duration
+	if params.EnableLogCollector {
+		logCollectorTimeout = reconciler.LogCollectorTimeout
+	}
+
+	planStore := plan.NewStore()
+
 	reconcilerCfg := reconciler.ReconcilerConfig{
 		MongoConfig:        mongoConfig,
 		TokenConfig:        tokenConfig,
@@ -88,6 +102,21 @@ func InitializeAll(ctx context.Context, params InitializationParams) (*Component
 		EnableLogCollector: params.EnableLogCollector,
 		UpdateMaxRetries:   tomlConfig.UpdateRetry.MaxRetries,
 		UpdateRetryDelay:   time.Duration(tomlConfig.UpdateRetry.RetryDelaySeconds) * time.Second,
+		Planner: plan.NewPlanner(clientSet, tomlConfig.MaintenanceResource,
+			logCollectorTimeout, params.DryRun),
+		PlanStore: planStore,
+	}
+
+	if len(tomlConfig.Runbooks) > 0 {
+		runner, err := runbook.NewRunner(clientSet, tomlConfig.Runbooks, tomlConfig.Template.MountPath, params.DryRun)
+		if err != nil {
+			return nil, fmt.Errorf("error while initializing runbooks: %w", err)
+		}
+
+		runner.SetNodeLock(nodelock.NewLocker(clientSet, nodelock.Namespace(), nodelock.DefaultDuration))
+		reconcilerCfg.Runbooks = runner
+
+		slog.Info("Runbooks enabled", "count", len(tomlConfig.Runbooks))
 	}
 
 	reconcilerInstance := reconciler.NewReconciler(reconcilerCfg, params.DryRun)
@@ -95,7 +124,9 @@ func InitializeAll(ctx context.Context, params InitializationParams) (*Component
 	slog.Info("Initialization completed successfully")
 
 	return &Components{
+		Config:     &tomlConfig,
 		Reconciler: reconcilerInstance,
+		PlanStore:  planStore,
 	}, nil
 }
 
@@ -0,0 +1,198 @@
+// Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package plan computes the remediation plan of a health event before fault
+// remediation executes it: the ordered steps, the workloads still running on the
+// node and the estimated downtime.
+package plan
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/nvidia/nvsentinel/data-models/pkg/protos"
+	"github.com/nvidia/nvsentinel/fault-remediation/pkg/common"
+	"github.com/nvidia/nvsentinel/fault-remediation/pkg/config"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+// Step types
+const (
+	StepCollectLogs = "CollectLogs"
+	StepMaintenance = "Maintenance"
+)
+
+// Plan statuses
+const (
+	StatusPending   = "Pending"
+	StatusSucceeded = "Succeeded"
+	StatusFailed    = "Failed"
+)
+
+// Step is a single action of a plan.
+type Step struct {
+	Order       int    `json:"order"`
+	Type        string `json:"type"`
+	Description string `json:"description"`
+	// Resource is the kind and name of the object the step creates
+	Resource                 string `json:"resource,omitempty"`
+	EstimatedDurationSeconds int    `json:"estimatedDurationSeconds"`
+}
+
+// Workload is a pod still running on the node, which the remediation affects.
+type Workload struct {
+	Namespace string `json:"namespace"`
+	Name      string `json:"name"`
+	OwnerKind string `json:"ownerKind,omitempty"`
+	OwnerName string `json:"ownerName,omitempty"`
+}
+
+// Plan is the remediation plan of a health event.
+type Plan struct {
+	NodeName          string     `json:"nodeName"`
+	HealthEventID     string     `json:"healthEventId"`
+	RecommendedAction string     `json:"recommendedAction"`
+	Group             string     `json:"group,omitempty"`
+	Steps             []Step     `json:"steps"`
+	AffectedWorkloads []Workload `json:"affectedWorkloads"`
+	// EstimatedDowntimeSeconds is the sum of the estimated durations of the steps
+	EstimatedDowntimeSeconds int       `json:"estimatedDowntimeSeconds"`
+	DryRun                   bool      `json:"dryRun,omitempty"`
+	Status                   string    `json:"status"`
+	CreatedAt                time.Time `json:"createdAt"`
+	UpdatedAt                time.Time `json:"updatedAt"`
+}
+
+// MultiStep reports whether the plan has more than one step.
+func (p *Plan) MultiStep() bool {
+	return len(p.Steps) > 1
+}
+
+// Planner computes remediation plans.
+type Planner struct {
+	kubeClient  kubernetes.Interface
+	maintenance config.MaintenanceResource
+	// logCollectorTimeout is the duration of the log collection step, 0 when
+	// the log collector is disabled
+	logCollectorTimeout time.Duration
+	dryRun              bool
+	now                 func() time.Time
+}
+
+// NewPlanner creates a Planner. logCollectorTimeout is 0 when the log collector
+// is disabled.
+func NewPlanner(kubeClient kubernetes.Interface, maintenance config.MaintenanceResource,
+	logCollectorTimeout time.Duration, dryRun bool) *Planner {
+	return &Planner{
+		kubeClient:          kubeClient,
+		maintenance:         maintenance,
+		logCollectorTimeout: logCollectorTimeout,
+		dryRun:              dryRun,
+		now:                 time.Now,
+	}
+}
+
+// Build computes the plan of remediating the node of the health event. When the
+// workloads of the node cannot be listed, it returns the plan without them along
+// with the error.
+func (p *Planner) Build(ctx context.Context, event *protos.HealthEvent, healthEventID string) (*Plan, error) {
+	group := common.GetRemediationGroupForAction(event.RecommendedAction)
+	now := p.now().UTC()
+
+	plan := &Plan{
+		NodeName:          event.NodeName,
+		HealthEventID:     healthEventID,
+		RecommendedAction: event.RecommendedAction.String(),
+		Group:             group,
+		DryRun:            p.dryRun,
+		Status:            StatusPending,
+		CreatedAt:         now,
+		UpdatedAt:         now,
+	}
+
+	if p.logCollectorTimeout > 0 {
+		plan.addStep(Step{
+			Type:                     StepCollectLogs,
+			Description:              "Run the log collector job on the node and wait for it to complete",
+			EstimatedDurationSeconds: int(p.logCollectorTimeout.Seconds()),
+		})
+	}
+
+	kind, conditionType := p.maintenance.ForGroup(group)
+	plan.addStep(Step{
+		Type: StepMaintenance,
+		Description: fmt.Sprintf("Create the %s maintenance resource and wait for its %s condition",
+			kind, conditionType),
+		Resource:                 fmt.Sprintf("%s/maintenance-%s-%s", kind, event.NodeName, healthEventID),
+		EstimatedDurationSeconds: int(p.maintenance.EstimatedDowntimeForGroup(group).Seconds()),
+	})
+
+	workloads, err := p.affectedWorkloads(ctx, event.NodeName)
+	if err != nil {
+		return plan, err
+	}
+
+	plan.AffectedWorkloads = workloads
+
+	return plan, nil
+}
+
+func (p *Plan) addStep(step Step) {
+	step.Order = len(p.Steps) + 1
+	p.Steps = append(p.Steps, step)
+	p.EstimatedDowntimeSeconds += step.EstimatedDurationSeconds
+}
+
+// affectedWorkloads lists the pods still running on the node, e.g. the pods of
+// DaemonSets and of the namespaces the node drainer does not evict.
+func (p *Planner) affectedWorkloads(ctx context.Context, nodeName string) ([]Workload, error) {
+	pods, err := p.kubeClient.CoreV1().Pods("").List(ctx, metav1.ListOptions{
+		FieldSelector: "spec.nodeName=" + nodeName,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list pods of node %s: %w", nodeName, err)
+	}
+
+	workloads := []Workload{}
+
+	for _, pod := range pods.Items {
+		if pod.Status.Phase == corev1.PodSucceeded || pod.Status.Phase == corev1.PodFailed {
+			continue
+		}
+
+		workload := Workload{Namespace: pod.Namespace, Name: pod.Name}
+
+		if owner := metav1.GetControllerOf(&pod); owner != nil {
+			workload.OwnerKind = owner.Kind
+			workload.OwnerName = owner.Name
+		}
+
+		workloads = append(workloads, workload)
+	}
+
+	sort.Slice(workloads, func(i, j int) bool {
+		if workloads[i].Namespace != workloads[j].Namespace {
+			return workloads[i].Namespace < workloads[j].Namespace
+		}
+
+		return workloads[i].Name < workloads[j].Name
+	})
+
+	return workloads, nil
+}
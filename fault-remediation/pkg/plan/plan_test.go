@@ -0,0 +1,168 @@
+// Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package plan
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/nvidia/nvsentinel/data-models/pkg/protos"
+	"github.com/nvidia/nvsentinel/fault-remediation/pkg/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+)
+
+var maintenance = config.MaintenanceResource{
+	Kind:                     "RebootNode",
+	CompleteConditionType:    "NodeReady",
+	EstimatedDowntimeSeconds: 600,
+	GroupResources: map[string]config.GroupResource{
+		"driver-reload": {Kind: "DriverReload", CompleteConditionType: "DriverReady", EstimatedDowntimeSeconds: 120},
+	},
+}
+
+func pod(name, nodeName string, phase corev1.PodPhase, ownerKind string) *corev1.Pod {
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "monitoring", Name: name},
+		Spec:       corev1.PodSpec{NodeName: nodeName},
+		Status:     corev1.PodStatus{Phase: phase},
+	}
+
+	if ownerKind != "" {
+		controller := true
+		pod.OwnerReferences = []metav1.OwnerReference{{Kind: ownerKind, Name: "exporter", Controller: &controller}}
+	}
+
+	return pod
+}
+
+func TestBuild(t *testing.T) {
+	client := fake.NewSimpleClientset(
+		pod("exporter-abc", "node-1", corev1.PodRunning, "DaemonSet"),
+		pod("debug", "node-1", corev1.PodPending, ""),
+		pod("done", "node-1", corev1.PodSucceeded, ""),
+	)
+
+	t.Run("single step", func(t *testing.T) {
+		planner := NewPlanner(client, maintenance, 0, false)
+
+		plan, err := planner.Build(t.Context(), &protos.HealthEvent{
+			NodeName:          "node-1",
+			RecommendedAction: protos.RecommendedAction_DRIVER_RELOAD,
+		}, "event-1")
+		require.NoError(t, err)
+
+		assert.False(t, plan.MultiStep())
+		assert.Equal(t, "driver-reload", plan.Group)
+		assert.Equal(t, StatusPending, plan.Status)
+		require.Len(t, plan.Steps, 1)
+		assert.Equal(t, StepMaintenance, plan.Steps[0].Type)
+		assert.Equal(t, "DriverReload/maintenance-node-1-event-1", plan.Steps[0].Resource)
+		assert.Equal(t, 120, plan.EstimatedDowntimeSeconds)
+	})
+
+	t.Run("multi step", func(t *testing.T) {
+		planner := NewPlanner(client, maintenance, 5*time.Minute, false)
+
+		plan, err := planner.Build(t.Context(), &protos.HealthEvent{
+			NodeName:          "node-1",
+			RecommendedAction: protos.RecommendedAction_RESTART_BM,
+		}, "event-2")
+		require.NoError(t, err)
+
+		assert.True(t, plan.MultiStep())
+		require.Len(t, plan.Steps, 2)
+		assert.Equal(t, StepCollectLogs, plan.Steps[0].Type)
+		assert.Equal(t, 1, plan.Steps[0].Order)
+		assert.Equal(t, "RebootNode/maintenance-node-1-event-2", plan.Steps[1].Resource)
+		assert.Equal(t, 2, plan.Steps[1].Order)
+		assert.Equal(t, 900, plan.EstimatedDowntimeSeconds)
+		assert.Equal(t, []Workload{
+			{Namespace: "monitoring", Name: "debug"},
+			{Namespace: "monitoring", Name: "exporter-abc", OwnerKind: "DaemonSet", OwnerName: "exporter"},
+		}, plan.AffectedWorkloads)
+	})
+
+	t.Run("workloads cannot be listed", func(t *testing.T) {
+		failing := fake.NewSimpleClientset()
+		failing.PrependReactor("list", "pods", func(k8stesting.Action) (bool, runtime.Object, error) {
+			return true, nil, errors.New("forbidden")
+		})
+
+		plan, err := NewPlanner(failing, maintenance, 0, false).Build(t.Context(), &protos.HealthEvent{
+			NodeName:          "node-1",
+			RecommendedAction: protos.RecommendedAction_RESTART_BM,
+		}, "event-3")
+		require.Error(t, err)
+		require.NotNil(t, plan, "the steps are returned along with the error")
+		assert.Len(t, plan.Steps, 1)
+	})
+}
+
+func TestStoreAndHandler(t *testing.T) {
+	store := NewStore()
+	store.Publish(&Plan{NodeName: "node-b", HealthEventID: "1", Status: StatusPending})
+	store.Publish(&Plan{NodeName: "node-a", HealthEventID: "2", Status: StatusPending})
+
+	store.SetStatus("node-a", "2", StatusSucceeded)
+	store.SetStatus("node-b", "stale", StatusFailed)
+
+	plan, ok := store.Get("node-a")
+	require.True(t, ok)
+	assert.Equal(t, StatusSucceeded, plan.Status)
+
+	plan, _ = store.Get("node-b")
+	assert.Equal(t, StatusPending, plan.Status, "only the plan of the event is updated")
+
+	handler := Handler(store)
+
+	get := func(target string) (*httptest.ResponseRecorder, Response) {
+		recorder := httptest.NewRecorder()
+		handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, target, nil))
+
+		var response Response
+		if recorder.Code == http.StatusOK {
+			require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &response))
+		}
+
+		return recorder, response
+	}
+
+	recorder, response := get(PathPrefix)
+	assert.Equal(t, http.StatusOK, recorder.Code)
+	require.Len(t, response.Plans, 2)
+	assert.Equal(t, "node-a", response.Plans[0].NodeName)
+
+	_, response = get(PathPrefix + "?node=node-b")
+	require.Len(t, response.Plans, 1)
+	assert.Equal(t, "1", response.Plans[0].HealthEventID)
+
+	recorder, _ = get(PathPrefix + "?node=unknown")
+	assert.Equal(t, http.StatusNotFound, recorder.Code)
+
+	recorder = httptest.NewRecorder()
+	handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodPost, PathPrefix, nil))
+	assert.Equal(t, http.StatusMethodNotAllowed, recorder.Code)
+}
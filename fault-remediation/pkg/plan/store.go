@@ -0,0 +1,132 @@
+// Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package plan
+
+import (
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+)
+
+// PathPrefix is where the handler is served
+const PathPrefix = "/remediation-plans"
+
+// Store keeps the latest plan of every node.
+type Store struct {
+	mu    sync.RWMutex
+	plans map[string]Plan
+	now   func() time.Time
+}
+
+// NewStore creates an empty Store.
+func NewStore() *Store {
+	return &Store{plans: make(map[string]Plan), now: time.Now}
+}
+
+// Publish makes the plan the latest plan of its node.
+func (s *Store) Publish(plan *Plan) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.plans[plan.NodeName] = clone(plan)
+}
+
+// SetStatus sets the status of the plan of the health event, unless a newer plan
+// of the node was published since.
+func (s *Store) SetStatus(nodeName, healthEventID, status string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	plan, ok := s.plans[nodeName]
+	if !ok || plan.HealthEventID != healthEventID {
+		return
+	}
+
+	plan.Status = status
+	plan.UpdatedAt = s.now().UTC()
+	s.plans[nodeName] = plan
+}
+
+// Get returns the latest plan of the node.
+func (s *Store) Get(nodeName string) (Plan, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	plan, ok := s.plans[nodeName]
+
+	return plan, ok
+}
+
+// List returns the latest plan of every node, by node name.
+func (s *Store) List() []Plan {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	plans := make([]Plan, 0, len(s.plans))
+	for _, plan := range s.plans {
+		plans = append(plans, plan)
+	}
+
+	sort.Slice(plans, func(i, j int) bool { return plans[i].NodeName < plans[j].NodeName })
+
+	return plans
+}
+
+func clone(plan *Plan) Plan {
+	copied := *plan
+	copied.Steps = append([]Step(nil), plan.Steps...)
+	copied.AffectedWorkloads = append([]Workload(nil), plan.AffectedWorkloads...)
+
+	return copied
+}
+
+// Response is the body served by the handler.
+type Response struct {
+	Plans []Plan `json:"plans"`
+}
+
+// Handler serves the plans of the store: GET /remediation-plans lists the latest
+// plan of every node, GET /remediation-plans?node=<node> the plan of one node.
+func Handler(store *Store) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			return
+		}
+
+		response := Response{Plans: []Plan{}}
+
+		if nodeName := r.URL.Query().Get("node"); nodeName != "" {
+			plan, ok := store.Get(nodeName)
+			if !ok {
+				http.Error(w, "no remediation plan for node "+nodeName, http.StatusNotFound)
+				return
+			}
+
+			response.Plans = append(response.Plans, plan)
+		} else {
+			response.Plans = append(response.Plans, store.List()...)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+
+		if err := json.NewEncoder(w).Encode(response); err != nil {
+			slog.Error("Failed to encode remediation plans", "error", err)
+		}
+	})
+}
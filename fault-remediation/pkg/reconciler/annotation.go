@@ -21,6 +21,9 @@ import (
 	"log/slog"
 	"time"
 
+	"github.com/nvidia/nvsentinel/commons/pkg/cloudmetadata"
+	"github.com/nvidia/nvsentinel/commons/pkg/enforcement"
+
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -40,6 +43,8 @@ type NodeAnnotationManagerInterface interface {
 	UpdateRemediationState(ctx context.Context, nodeName string, group string, crName string) error
 	ClearRemediationState(ctx context.Context, nodeName string) error
 	RemoveGroupFromState(ctx context.Context, nodeName string, group string) error
+	IsNodePreempting(ctx context.Context, nodeName string) (bool, error)
+	IsEnforcementDisabled(ctx context.Context, nodeName string) (bool, error)
 }
 
 // RemediationStateAnnotation represents the structure of the node annotation
@@ -70,7 +75,7 @@ func (m *NodeAnnotationManager) patchNodeWithRetry(ctx context.Context, nodeName
 	return retry.OnError(retry.DefaultRetry, isRetryableError, func() error {
 		_, err := m.kubeClient.CoreV1().Nodes().Patch(ctx, nodeName, types.MergePatchType, patch, metav1.PatchOptions{})
 		if err != nil && isRetryableError(err) {
-			slog.Warn("Retryable error patching node annotation. Retrying...",
+			slog.WarnContext(ctx, "Retryable error patching node annotation. Retrying...",
 				"node", nodeName,
 				"error", err)
 		}
@@ -114,7 +119,7 @@ func (m *NodeAnnotationManager) GetRemediationState(
 		n, err := m.kubeClient.CoreV1().Nodes().Get(ctx, nodeName, metav1.GetOptions{})
 		if err != nil {
 			if isRetryableError(err) {
-				slog.Warn("Retryable error getting node", "node", nodeName, "error", err)
+				slog.WarnContext(ctx, "Retryable error getting node", "node", nodeName, "error", err)
 			}
 
 			return err
@@ -138,7 +143,7 @@ func (m *NodeAnnotationManager) GetRemediationState(
 
 	var state RemediationStateAnnotation
 	if err := json.Unmarshal([]byte(annotationValue), &state); err != nil {
-		slog.Error("Failed to unmarshal annotation", "node", nodeName, "error", err)
+		slog.ErrorContext(ctx, "Failed to unmarshal annotation", "node", nodeName, "error", err)
 		// Return empty state if unmarshal fails
 		return &RemediationStateAnnotation{
 			EquivalenceGroups: make(map[string]EquivalenceGroupState),
@@ -155,7 +160,7 @@ func (m *NodeAnnotationManager) UpdateRemediationState(ctx context.Context, node
 	state, err := m.GetRemediationState(ctx, nodeName)
 	if err != nil {
 		// Log but continue with empty state
-		slog.Warn("Failed to get current remediation state", "node", nodeName, "error", err)
+		slog.WarnContext(ctx, "Failed to get current remediation state", "node", nodeName, "error", err)
 
 		state = &RemediationStateAnnotation{
 			EquivalenceGroups: make(map[string]EquivalenceGroupState),
@@ -182,7 +187,7 @@ func (m *NodeAnnotationManager) UpdateRemediationState(ctx context.Context, node
 		return fmt.Errorf("failed to update remediation state annotation for %s: %w", nodeName, err)
 	}
 
-	slog.Info("Updated remediation state annotation for node",
+	slog.InfoContext(ctx, "Updated remediation state annotation for node",
 		"node", nodeName,
 		"group", group,
 		"crName", crName)
@@ -200,7 +205,7 @@ func (m *NodeAnnotationManager) ClearRemediationState(ctx context.Context, nodeN
 		return fmt.Errorf("failed to clear remediation state annotation for node %s: %w", nodeName, err)
 	}
 
-	slog.Info("Cleared remediation state annotation for node", "node", nodeName)
+	slog.InfoContext(ctx, "Cleared remediation state annotation for node", "node", nodeName)
 
 	return nil
 }
@@ -234,7 +239,47 @@ func (m *NodeAnnotationManager) RemoveGroupFromState(ctx context.Context, nodeNa
 		return fmt.Errorf("failed to remove group from node annotation for %s: %w", nodeName, err)
 	}
 
-	slog.Info("Removed group from remediation state for node", "node", nodeName, "group", group)
+	slog.InfoContext(ctx, "Removed group from remediation state for node", "node", nodeName, "group", group)
 
 	return nil
 }
+
+// IsNodePreempting reports whether the node carries the preemption annotation
+// set by the preemption watcher, i.e. the cloud provider reclaims it shortly.
+func (m *NodeAnnotationManager) IsNodePreempting(ctx context.Context, nodeName string) (bool, error) {
+	node, err := m.kubeClient.CoreV1().Nodes().Get(ctx, nodeName, metav1.GetOptions{})
+	if err != nil {
+		return false, fmt.Errorf("failed to get node %s: %w", nodeName, err)
+	}
+
+	_, exists := node.Annotations[cloudmetadata.PreemptionImminentAnnotation]
+
+	return exists, nil
+}
+
+// IsEnforcementDisabled reports whether the node opted out of enforcement via
+// the enforcement annotation or label, i.e. NVSentinel only observes it.
+// Retryable API server errors are retried.
+func (m *NodeAnnotationManager) IsEnforcementDisabled(ctx context.Context, nodeName string) (bool, error) {
+	var node *corev1.Node
+
+	err := retry.OnError(retry.DefaultRetry, isRetryableError, func() error {
+		n, err := m.kubeClient.CoreV1().Nodes().Get(ctx, nodeName, metav1.GetOptions{})
+		if err != nil {
+			if isRetryableError(err) {
+				slog.WarnContext(ctx, "Retryable error getting node", "node", nodeName, "error", err)
+			}
+
+			return err
+		}
+
+		node = n
+
+		return nil
+	})
+	if err != nil {
+		return false, fmt.Errorf("failed to get node %s: %w", nodeName, err)
+	}
+
+	return enforcement.Disabled(node.Annotations, node.Labels), nil
+}
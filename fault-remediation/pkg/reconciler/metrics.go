@@ -23,6 +23,8 @@ import (
 const (
 	CRStatusCreated = "created"
 	CRStatusSkipped = "skipped"
+	// CRStatusRunbook counts events a runbook ran for instead of creating a CR
+	CRStatusRunbook = "runbook"
 )
 
 var (
@@ -54,6 +56,21 @@ var (
 		},
 		[]string{"action", "node_name"},
 	)
+	observeOnlySkippedRemediations = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "fault_remediation_observe_only_skipped_total",
+			Help: "Total number of remediations skipped because enforcement is off for the node.",
+		},
+		[]string{"action", "node_name"},
+	)
+
+	remediationPlansPublished = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "fault_remediation_plans_published_total",
+			Help: "Total number of remediation plans published before executing the remediation.",
+		},
+		[]string{"multi_step"},
+	)
 
 	// Performance Metrics
 	eventHandlingDuration = promauto.NewHistogram(
@@ -64,6 +81,15 @@ var (
 		},
 	)
 
+	pipelineStageLatency = promauto.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name:    "fault_remediation_pipeline_stage_latency_seconds",
+			Help:    "Latency from earlier pipeline stages of a health event to the remediation action being created.",
+			Buckets: []float64{1, 5, 10, 30, 60, 120, 300, 600, 1800, 3600},
+		},
+		[]string{"from", "to"},
+	)
+
 	// Log Collection Job Metrics
 	logCollectorJobs = promauto.NewCounterVec(
 		prometheus.CounterOpts{
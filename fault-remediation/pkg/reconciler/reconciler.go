@@ -19,13 +19,16 @@ import (
 	"fmt"
 	"log"
 	"log/slog"
+	"strconv"
 	"sync"
 	"time"
 
+	"github.com/nvidia/nvsentinel/commons/pkg/logger"
 	"github.com/nvidia/nvsentinel/commons/pkg/statemanager"
 	"github.com/nvidia/nvsentinel/data-models/pkg/model"
 	"github.com/nvidia/nvsentinel/data-models/pkg/protos"
 	"github.com/nvidia/nvsentinel/fault-remediation/pkg/common"
+	"github.com/nvidia/nvsentinel/fault-remediation/pkg/plan"
 	"github.com/nvidia/nvsentinel/store-client/pkg/storewatcher"
 
 	"go.mongodb.org/mongo-driver/bson"
@@ -42,6 +45,24 @@ type ReconcilerConfig struct {
 	EnableLogCollector bool
 	UpdateMaxRetries   int
 	UpdateRetryDelay   time.Duration
+	// Planner and PlanStore compute and publish the plan of every remediation
+	// before it is executed
+	Planner   RemediationPlanner
+	PlanStore *plan.Store
+	// Runbooks runs operator supplied scripts instead of creating a maintenance
+	// resource for the events matching a runbook, nil when none are configured
+	Runbooks RunbookRunner
+}
+
+// RunbookRunner runs the runbook matching a health event
+type RunbookRunner interface {
+	Match(event *protos.HealthEvent) (string, bool)
+	Run(ctx context.Context, name string, event *protos.HealthEvent, healthEventID string) *model.RunbookRecord
+}
+
+// RemediationPlanner computes the plan of a remediation
+type RemediationPlanner interface {
+	Build(ctx context.Context, event *protos.HealthEvent, healthEventID string) (*plan.Plan, error)
 }
 
 type Reconciler struct {
@@ -76,13 +97,13 @@ func (r *Reconciler) Start(ctx context.Context) error {
 
 	defer func() {
 		if err := watcher.Close(ctx); err != nil {
-			slog.Error("failed to close watcher", "error", err)
+			slog.ErrorContext(ctx, "failed to close watcher", "error", err)
 		}
 	}()
 
 	collection, err := storewatcher.GetCollectionClient(ctx, r.Config.MongoConfig)
 	if err != nil {
-		slog.Error("error initializing collection client for mongodb",
+		slog.ErrorContext(ctx, "error initializing collection client for mongodb",
 			"config", r.Config.MongoConfig,
 			"error", err)
 
@@ -90,10 +111,10 @@ func (r *Reconciler) Start(ctx context.Context) error {
 	}
 
 	watcher.Start(ctx)
-	slog.Info("Listening for events on the channel...")
+	slog.InfoContext(ctx, "Listening for events on the channel...")
 
 	for event := range watcher.Events() {
-		slog.Info("Event received", "event", event)
+		slog.InfoContext(ctx, "Event received", "event", event)
 		r.processEvent(ctx, event, watcher, collection)
 	}
 
@@ -114,16 +135,19 @@ func (r *Reconciler) processEvent(ctx context.Context, event bson.M, watcher Wat
 	healthEventWithStatus := HealthEventDoc{}
 	if err := storewatcher.UnmarshalFullDocumentFromEvent(event, &healthEventWithStatus); err != nil {
 		processingErrors.WithLabelValues("unmarshal_doc_error", "unknown").Inc()
-		slog.Error("Failed to unmarshal event", "error", err)
+		slog.ErrorContext(ctx, "Failed to unmarshal event", "error", err)
 
 		if err := watcher.MarkProcessed(context.Background()); err != nil {
 			processingErrors.WithLabelValues("mark_processed_error", "unknown").Inc()
-			slog.Error("Error updating resume token", "error", err)
+			slog.ErrorContext(ctx, "Error updating resume token", "error", err)
 		}
 
 		return
 	}
 
+	ctx = logger.WithEventID(logger.WithSubsystem(ctx, "reconciler"), healthEventWithStatus.HealthEvent.GetId())
+	ctx = logger.WithIncidentID(ctx, healthEventWithStatus.IncidentID())
+
 	nodeName := healthEventWithStatus.HealthEvent.NodeName
 	nodeQuarantined := healthEventWithStatus.HealthEventStatus.NodeQuarantined
 
@@ -143,7 +167,16 @@ func (r *Reconciler) shouldSkipEvent(ctx context.Context,
 	nodeName := healthEventWithStatus.HealthEvent.NodeName
 
 	if action == protos.RecommendedAction_NONE {
-		slog.Info("Skipping event for node: recommended action is NONE (no remediation needed)",
+		slog.InfoContext(ctx, "Skipping event for node: recommended action is NONE (no remediation needed)",
+			"node", nodeName)
+
+		return true
+	}
+
+	// Preemption is handled by draining the node, the cloud provider reclaims
+	// it shortly and there is nothing to remediate
+	if action == protos.RecommendedAction_PREEMPTION_IMMINENT {
+		slog.InfoContext(ctx, "Skipping event for node: node is being preempted (drain only)",
 			"node", nodeName)
 
 		return true
@@ -155,10 +188,10 @@ func (r *Reconciler) shouldSkipEvent(ctx context.Context,
 	}
 
 	if common.GetRemediationGroupForAction(action) != "" {
-		return false
+		return r.isNodePreempting(ctx, nodeName) || r.isEnforcementDisabled(ctx, nodeName, action)
 	}
 
-	slog.Info("Unsupported recommended action for node",
+	slog.InfoContext(ctx, "Unsupported recommended action for node",
 		"action", action.String(),
 		"node", nodeName)
 	totalUnsupportedRemediationActions.WithLabelValues(action.String(), nodeName).Inc()
@@ -167,7 +200,7 @@ func (r *Reconciler) shouldSkipEvent(ctx context.Context,
 		healthEventWithStatus.HealthEvent.NodeName,
 		statemanager.RemediationFailedLabelValue, false)
 	if err != nil {
-		slog.Error("Error updating node label",
+		slog.ErrorContext(ctx, "Error updating node label",
 			"label", statemanager.RemediationFailedLabelValue,
 			"error", err)
 		processingErrors.WithLabelValues("label_update_error",
@@ -177,6 +210,57 @@ func (r *Reconciler) shouldSkipEvent(ctx context.Context,
 	return true
 }
 
+// isNodePreempting reports whether hardware remediation of the node should be
+// skipped because the cloud provider is about to reclaim it. Lookup errors do
+// not block remediation.
+func (r *Reconciler) isNodePreempting(ctx context.Context, nodeName string) bool {
+	if r.annotationManager == nil {
+		return false
+	}
+
+	preempting, err := r.annotationManager.IsNodePreempting(ctx, nodeName)
+	if err != nil {
+		slog.WarnContext(ctx, "Failed to check node for pending preemption", "node", nodeName, "error", err)
+		return false
+	}
+
+	if preempting {
+		slog.InfoContext(ctx, "Skipping remediation for node: node is being preempted", "node", nodeName)
+	}
+
+	return preempting
+}
+
+// isEnforcementDisabled reports whether remediation of the node should be
+// skipped because it opted out of enforcement (observe-only). When the node
+// cannot be looked up it is only observed, as it may have opted out.
+func (r *Reconciler) isEnforcementDisabled(ctx context.Context, nodeName string,
+	action protos.RecommendedAction) bool {
+	if r.annotationManager == nil {
+		return false
+	}
+
+	disabled, err := r.annotationManager.IsEnforcementDisabled(ctx, nodeName)
+	if err != nil {
+		slog.WarnContext(ctx, "Failed to check node for enforcement opt-out, skipping remediation (observe-only)",
+			"node", nodeName,
+			"action", action.String(),
+			"error", err)
+		observeOnlySkippedRemediations.WithLabelValues(action.String(), nodeName).Inc()
+
+		return true
+	}
+
+	if disabled {
+		slog.WarnContext(ctx, "Enforcement is off for node, skipping remediation (observe-only)",
+			"node", nodeName,
+			"action", action.String())
+		observeOnlySkippedRemediations.WithLabelValues(action.String(), nodeName).Inc()
+	}
+
+	return disabled
+}
+
 // runLogCollector runs log collector for non-NONE actions if enabled
 func (r *Reconciler) runLogCollector(ctx context.Context, healthEvent *protos.HealthEvent) {
 	if healthEvent.RecommendedAction == protos.RecommendedAction_NONE ||
@@ -184,11 +268,11 @@ func (r *Reconciler) runLogCollector(ctx context.Context, healthEvent *protos.He
 		return
 	}
 
-	slog.Info("Log collector feature enabled; running log collector for node",
+	slog.InfoContext(ctx, "Log collector feature enabled; running log collector for node",
 		"node", healthEvent.NodeName)
 
 	if err := r.Config.RemediationClient.RunLogCollectorJob(ctx, healthEvent.NodeName); err != nil {
-		slog.Error("Log collector job failed for node",
+		slog.ErrorContext(ctx, "Log collector job failed for node",
 			"node", healthEvent.NodeName,
 			"error", err)
 	}
@@ -203,7 +287,7 @@ func (r *Reconciler) performRemediation(ctx context.Context, healthEventWithStat
 		healthEventWithStatus.HealthEvent.NodeName,
 		statemanager.RemediatingLabelValue, false)
 	if err != nil {
-		slog.Error("Error updating node label to remediating", "error", err)
+		slog.ErrorContext(ctx, "Error updating node label to remediating", "error", err)
 		processingErrors.WithLabelValues("label_update_error", nodeName).Inc()
 	}
 
@@ -211,7 +295,7 @@ func (r *Reconciler) performRemediation(ctx context.Context, healthEventWithStat
 	crName := ""
 
 	for i := 1; i <= r.Config.UpdateMaxRetries; i++ {
-		slog.Info("Handle event for node",
+		slog.InfoContext(ctx, "Handle event for node",
 			"attempt", i,
 			"node", healthEventWithStatus.HealthEvent.NodeName)
 
@@ -227,6 +311,8 @@ func (r *Reconciler) performRemediation(ctx context.Context, healthEventWithStat
 
 	if !success {
 		processingErrors.WithLabelValues("cr_creation_failed", nodeName).Inc()
+	} else {
+		observeRemediationLatency(healthEventWithStatus.HealthEvent)
 	}
 
 	// Update final state based on success/failure
@@ -239,7 +325,7 @@ func (r *Reconciler) performRemediation(ctx context.Context, healthEventWithStat
 		healthEventWithStatus.HealthEvent.NodeName,
 		remediationLabelValue, false)
 	if err != nil {
-		slog.Error("Error updating node label",
+		slog.ErrorContext(ctx, "Error updating node label",
 			"label", remediationLabelValue,
 			"error", err)
 		processingErrors.WithLabelValues("label_update_error", nodeName).Inc()
@@ -248,25 +334,33 @@ func (r *Reconciler) performRemediation(ctx context.Context, healthEventWithStat
 	return success, crName
 }
 
+// observeRemediationLatency records the latency from every pipeline stage stamped
+// on the event to the remediation action being created.
+func observeRemediationLatency(event *protos.HealthEvent) {
+	for from, latency := range model.StageLatencies(event, model.StageRemediated, time.Now()) {
+		pipelineStageLatency.WithLabelValues(string(from), string(model.StageRemediated)).Observe(latency.Seconds())
+	}
+}
+
 func (r *Reconciler) handleCancellationEvent(
 	ctx context.Context,
 	nodeName string,
 	status model.Status,
 	watcher WatcherInterface,
 ) {
-	slog.Info("Cancellation event received, clearing all remediation state",
+	slog.InfoContext(ctx, "Cancellation event received, clearing all remediation state",
 		"node", nodeName,
 		"status", status)
 
 	if err := r.annotationManager.ClearRemediationState(ctx, nodeName); err != nil {
-		slog.Error("Failed to clear remediation state for node",
+		slog.ErrorContext(ctx, "Failed to clear remediation state for node",
 			"node", nodeName,
 			"error", err)
 	}
 
 	if err := watcher.MarkProcessed(context.Background()); err != nil {
 		processingErrors.WithLabelValues("mark_processed_error", nodeName).Inc()
-		slog.Error("Error updating resume token", "error", err)
+		slog.ErrorContext(ctx, "Error updating resume token", "error", err)
 	}
 }
 
@@ -281,13 +375,18 @@ func (r *Reconciler) handleRemediationEvent(
 	healthEvent := healthEventWithStatus.HealthEvent
 	nodeName := healthEvent.NodeName
 
-	r.runLogCollector(ctx, healthEvent)
+	if runbook, ok := r.matchRunbook(healthEventWithStatus.HealthEventWithStatus); ok {
+		r.handleRunbookEvent(ctx, healthEventWithStatus, runbook, event, watcher, collection)
+		return
+	}
 
 	// Check if we should skip this event (NONE actions or unsupported actions)
 	if r.shouldSkipEvent(ctx, healthEventWithStatus.HealthEventWithStatus) {
+		r.runLogCollector(ctx, healthEvent)
+
 		if err := watcher.MarkProcessed(ctx); err != nil {
 			processingErrors.WithLabelValues("mark_processed_error", nodeName).Inc()
-			slog.Error("Error updating resume token", "error", err)
+			slog.ErrorContext(ctx, "Error updating resume token", "error", err)
 		}
 
 		return
@@ -296,25 +395,41 @@ func (r *Reconciler) handleRemediationEvent(
 	shouldCreateCR, existingCR, err := r.checkExistingCRStatus(ctx, healthEvent)
 	if err != nil {
 		processingErrors.WithLabelValues("cr_status_check_error", nodeName).Inc()
-		slog.Error("Error checking existing CR status", "node", nodeName, "error", err)
+		slog.ErrorContext(ctx, "Error checking existing CR status", "node", nodeName, "error", err)
 	}
 
 	if !shouldCreateCR {
-		slog.Info("Skipping event for node due to existing CR",
+		slog.InfoContext(ctx, "Skipping event for node due to existing CR",
 			"node", nodeName,
 			"existingCR", existingCR)
 
+		r.runLogCollector(ctx, healthEvent)
 		eventsProcessed.WithLabelValues(CRStatusSkipped, nodeName).Inc()
 
 		if err := watcher.MarkProcessed(ctx); err != nil {
 			processingErrors.WithLabelValues("mark_processed_error", nodeName).Inc()
-			slog.Error("Error updating resume token", "error", err)
+			slog.ErrorContext(ctx, "Error updating resume token", "error", err)
 		}
 
 		return
 	}
 
-	nodeRemediatedStatus, _ := r.performRemediation(ctx, healthEventWithStatus)
+	nodeRemediatedStatus := false
+
+	if remediationPlan, planned := r.planRemediation(ctx, healthEventWithStatus); planned {
+		r.runLogCollector(ctx, healthEvent)
+		nodeRemediatedStatus, _ = r.performRemediation(ctx, healthEventWithStatus)
+		r.completePlan(remediationPlan, nodeRemediatedStatus)
+	} else {
+		_, err := r.Config.StateManager.UpdateNVSentinelStateNodeLabel(ctx, nodeName,
+			statemanager.RemediationFailedLabelValue, false)
+		if err != nil {
+			slog.ErrorContext(ctx, "Error updating node label",
+				"label", statemanager.RemediationFailedLabelValue,
+				"error", err)
+			processingErrors.WithLabelValues("label_update_error", nodeName).Inc()
+		}
+	}
 
 	if err := r.updateNodeRemediatedStatus(ctx, collection, event, nodeRemediatedStatus); err != nil {
 		processingErrors.WithLabelValues("update_status_error", nodeName).Inc()
@@ -327,21 +442,158 @@ func (r *Reconciler) handleRemediationEvent(
 
 	if err := watcher.MarkProcessed(ctx); err != nil {
 		processingErrors.WithLabelValues("mark_processed_error", nodeName).Inc()
-		slog.Error("Error updating resume token", "error", err)
+		slog.ErrorContext(ctx, "Error updating resume token", "error", err)
 	}
 }
 
-func (r *Reconciler) updateNodeRemediatedStatus(ctx context.Context, collection MongoInterface,
-	event bson.M, nodeRemediatedStatus bool) error {
-	var err error
+// matchRunbook returns the runbook of the event. Events without a remediation to
+// run and events already remediated match no runbook.
+func (r *Reconciler) matchRunbook(healthEventWithStatus model.HealthEventWithStatus) (string, bool) {
+	if r.Config.Runbooks == nil {
+		return "", false
+	}
 
-	document, ok := event["fullDocument"].(bson.M)
-	if !ok {
-		return fmt.Errorf("error extracting fullDocument from event: %+v", event)
+	action := healthEventWithStatus.HealthEvent.RecommendedAction
+	if action == protos.RecommendedAction_NONE || action == protos.RecommendedAction_PREEMPTION_IMMINENT {
+		return "", false
 	}
 
-	filter := bson.M{"_id": document["_id"]}
+	if healthEventWithStatus.HealthEventStatus.FaultRemediated != nil &&
+		*healthEventWithStatus.HealthEventStatus.FaultRemediated {
+		return "", false
+	}
+
+	return r.Config.Runbooks.Match(healthEventWithStatus.HealthEvent)
+}
+
+// handleRunbookEvent runs the runbook of the event on its node in place of a
+// maintenance resource and records the outcome and output on the event.
+func (r *Reconciler) handleRunbookEvent(
+	ctx context.Context,
+	healthEventWithStatus *HealthEventDoc,
+	runbook string,
+	event bson.M,
+	watcher WatcherInterface,
+	collection MongoInterface,
+) {
+	healthEvent := healthEventWithStatus.HealthEvent
+	nodeName := healthEvent.NodeName
 
+	defer func() {
+		if err := watcher.MarkProcessed(ctx); err != nil {
+			processingErrors.WithLabelValues("mark_processed_error", nodeName).Inc()
+			slog.ErrorContext(ctx, "Error updating resume token", "error", err)
+		}
+	}()
+
+	if r.isNodePreempting(ctx, nodeName) || r.isEnforcementDisabled(ctx, nodeName, healthEvent.RecommendedAction) {
+		return
+	}
+
+	slog.InfoContext(ctx, "Running runbook for node", "runbook", runbook, "node", nodeName)
+
+	r.runLogCollector(ctx, healthEvent)
+	r.updateStateLabel(ctx, nodeName, statemanager.RemediatingLabelValue)
+
+	record := r.Config.Runbooks.Run(ctx, runbook, healthEvent, healthEventWithStatus.ID.Hex())
+
+	remediationLabelValue := statemanager.RemediationFailedLabelValue
+	if record.Succeeded {
+		remediationLabelValue = statemanager.RemediationSucceededLabelValue
+
+		observeRemediationLatency(healthEvent)
+	} else {
+		processingErrors.WithLabelValues("runbook_failed", nodeName).Inc()
+	}
+
+	r.updateStateLabel(ctx, nodeName, remediationLabelValue)
+
+	updateFields := bson.M{
+		"healtheventstatus.faultremediated": record.Succeeded,
+		"healtheventstatus.runbook":         record,
+	}
+
+	if record.Succeeded {
+		updateFields["healtheventstatus.lastremediationtimestamp"] = time.Now().UTC()
+	}
+
+	if err := r.updateHealthEventStatus(ctx, collection, event, updateFields); err != nil {
+		processingErrors.WithLabelValues("update_status_error", nodeName).Inc()
+		slog.ErrorContext(ctx, "Error recording runbook outcome", "node", nodeName, "runbook", runbook, "error", err)
+
+		return
+	}
+
+	eventsProcessed.WithLabelValues(CRStatusRunbook, nodeName).Inc()
+}
+
+// updateStateLabel sets the NVSentinel state label of the node.
+func (r *Reconciler) updateStateLabel(ctx context.Context, nodeName string,
+	value statemanager.NVSentinelStateLabelValue) {
+	if _, err := r.Config.StateManager.UpdateNVSentinelStateNodeLabel(ctx, nodeName, value, false); err != nil {
+		slog.ErrorContext(ctx, "Error updating node label",
+			"label", value,
+			"error", err)
+		processingErrors.WithLabelValues("label_update_error", nodeName).Inc()
+	}
+}
+
+// planRemediation computes and publishes the plan of the remediation before it is
+// executed. Multi-step remediations are only executed once their plan is
+// complete, single-step ones also with a partial plan.
+func (r *Reconciler) planRemediation(ctx context.Context, healthEventWithStatus *HealthEventDoc) (*plan.Plan, bool) {
+	if r.Config.Planner == nil || r.Config.PlanStore == nil {
+		return nil, true
+	}
+
+	nodeName := healthEventWithStatus.HealthEvent.NodeName
+
+	remediationPlan, err := r.Config.Planner.Build(ctx, healthEventWithStatus.HealthEvent,
+		healthEventWithStatus.ID.Hex())
+	if err != nil {
+		processingErrors.WithLabelValues("plan_error", nodeName).Inc()
+
+		if remediationPlan == nil || remediationPlan.MultiStep() {
+			slog.ErrorContext(ctx, "Failed to compute remediation plan, not executing the remediation",
+				"node", nodeName,
+				"error", err)
+
+			return nil, false
+		}
+
+		slog.WarnContext(ctx, "Failed to compute the affected workloads of the remediation plan",
+			"node", nodeName,
+			"error", err)
+	}
+
+	r.Config.PlanStore.Publish(remediationPlan)
+	remediationPlansPublished.WithLabelValues(strconv.FormatBool(remediationPlan.MultiStep())).Inc()
+
+	slog.InfoContext(ctx, "Published remediation plan",
+		"node", nodeName,
+		"steps", len(remediationPlan.Steps),
+		"affectedWorkloads", len(remediationPlan.AffectedWorkloads),
+		"estimatedDowntimeSeconds", remediationPlan.EstimatedDowntimeSeconds)
+
+	return remediationPlan, true
+}
+
+// completePlan records the outcome of the executed plan.
+func (r *Reconciler) completePlan(remediationPlan *plan.Plan, success bool) {
+	if remediationPlan == nil {
+		return
+	}
+
+	status := plan.StatusFailed
+	if success {
+		status = plan.StatusSucceeded
+	}
+
+	r.Config.PlanStore.SetStatus(remediationPlan.NodeName, remediationPlan.HealthEventID, status)
+}
+
+func (r *Reconciler) updateNodeRemediatedStatus(ctx context.Context, collection MongoInterface,
+	event bson.M, nodeRemediatedStatus bool) error {
 	updateFields := bson.M{
 		"healtheventstatus.faultremediated": nodeRemediatedStatus,
 	}
@@ -351,12 +603,28 @@ func (r *Reconciler) updateNodeRemediatedStatus(ctx context.Context, collection
 		updateFields["healtheventstatus.lastremediationtimestamp"] = time.Now().UTC()
 	}
 
+	return r.updateHealthEventStatus(ctx, collection, event, updateFields)
+}
+
+// updateHealthEventStatus sets the status fields of the health event of the change
+// stream event, with retries.
+func (r *Reconciler) updateHealthEventStatus(ctx context.Context, collection MongoInterface,
+	event bson.M, updateFields bson.M) error {
+	var err error
+
+	document, ok := event["fullDocument"].(bson.M)
+	if !ok {
+		return fmt.Errorf("error extracting fullDocument from event: %+v", event)
+	}
+
+	filter := bson.M{"_id": document["_id"]}
+
 	update := bson.M{
 		"$set": updateFields,
 	}
 
 	for i := 1; i <= r.Config.UpdateMaxRetries; i++ {
-		slog.Info("Updating health event with ID",
+		slog.InfoContext(ctx, "Updating health event with ID",
 			"attempt", i,
 			"id", document["_id"])
 
@@ -372,9 +640,9 @@ func (r *Reconciler) updateNodeRemediatedStatus(ctx context.Context, collection
 		return fmt.Errorf("error updating document with ID: %v, error: %w", document["_id"], err)
 	}
 
-	slog.Info("Health event has been updated with status",
+	slog.InfoContext(ctx, "Health event has been updated with status",
 		"id", document["_id"],
-		"status", nodeRemediatedStatus)
+		"status", updateFields["healtheventstatus.faultremediated"])
 
 	return nil
 }
@@ -392,12 +660,12 @@ func (r *Reconciler) checkExistingCRStatus(
 
 	state, err := r.annotationManager.GetRemediationState(ctx, nodeName)
 	if err != nil {
-		slog.Error("Error getting remediation state", "node", nodeName, "error", err)
+		slog.ErrorContext(ctx, "Error getting remediation state", "node", nodeName, "error", err)
 		return true, "", nil
 	}
 
 	if state == nil {
-		slog.Warn("Remediation state is nil for node, allowing CR creation",
+		slog.WarnContext(ctx, "Remediation state is nil for node, allowing CR creation",
 			"node", nodeName)
 
 		return true, "", nil
@@ -410,20 +678,22 @@ func (r *Reconciler) checkExistingCRStatus(
 
 	statusChecker := r.remediationClient.GetStatusChecker()
 	if statusChecker == nil {
-		slog.Warn("Status checker is not available, allowing creation")
+		slog.WarnContext(ctx, "Status checker is not available, allowing creation")
 		return true, "", nil
 	}
 
-	shouldSkip := statusChecker.ShouldSkipCRCreation(ctx, groupState.MaintenanceCR)
+	shouldSkip := statusChecker.ShouldSkipCRCreation(ctx, groupState.MaintenanceCR, group)
 	if shouldSkip {
-		slog.Info("CR exists and is in progress, skipping event", "node", nodeName, "crName", groupState.MaintenanceCR)
+		slog.InfoContext(ctx, "CR exists and is in progress, skipping event",
+			"node", nodeName, "crName", groupState.MaintenanceCR)
 		return false, groupState.MaintenanceCR, nil
 	}
 
-	slog.Info("CR completed or failed, allowing retry", "node", nodeName, "crName", groupState.MaintenanceCR)
+	slog.InfoContext(ctx, "CR completed or failed, allowing retry",
+		"node", nodeName, "crName", groupState.MaintenanceCR)
 
 	if err := r.annotationManager.RemoveGroupFromState(ctx, nodeName, group); err != nil {
-		slog.Error("Failed to remove CR from annotation", "error", err)
+		slog.ErrorContext(ctx, "Failed to remove CR from annotation", "error", err)
 	}
 
 	return true, "", nil
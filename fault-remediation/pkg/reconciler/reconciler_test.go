@@ -16,6 +16,7 @@ package reconciler
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"testing"
 	"time"
@@ -24,6 +25,7 @@ import (
 	"github.com/nvidia/nvsentinel/data-models/pkg/model"
 	"github.com/nvidia/nvsentinel/data-models/pkg/protos"
 	"github.com/nvidia/nvsentinel/fault-remediation/pkg/crstatus"
+	"github.com/nvidia/nvsentinel/fault-remediation/pkg/plan"
 	"github.com/nvidia/nvsentinel/store-client/pkg/storewatcher"
 	"github.com/stretchr/testify/assert"
 	"go.mongodb.org/mongo-driver/bson"
@@ -96,7 +98,10 @@ func (w *MockCRStatusCheckerWrapper) IsSuccessful(ctx context.Context, crName st
 }
 
 type MockNodeAnnotationManager struct {
-	existingCR string
+	existingCR     string
+	preempting     bool
+	enforcementOff bool
+	enforcementErr error
 }
 
 func (m *MockNodeAnnotationManager) GetRemediationState(ctx context.Context, nodeName string) (*RemediationStateAnnotation, error) {
@@ -129,6 +134,14 @@ func (m *MockNodeAnnotationManager) RemoveGroupFromState(ctx context.Context, no
 	return nil
 }
 
+func (m *MockNodeAnnotationManager) IsNodePreempting(ctx context.Context, nodeName string) (bool, error) {
+	return m.preempting, nil
+}
+
+func (m *MockNodeAnnotationManager) IsEnforcementDisabled(ctx context.Context, nodeName string) (bool, error) {
+	return m.enforcementOff, m.enforcementErr
+}
+
 func (m *MockCollection) UpdateOne(ctx context.Context, filter interface{}, update interface{}, opts ...*options.UpdateOptions) (*mongo.UpdateResult, error) {
 	return m.updateOneFn(ctx, filter, update, opts...)
 }
@@ -502,6 +515,183 @@ func TestShouldSkipEvent(t *testing.T) {
 	}
 }
 
+func TestShouldSkipEventPreemption(t *testing.T) {
+	labelUpdated := false
+	stateManager := &statemanager.MockStateManager{
+		UpdateNVSentinelStateNodeLabelFn: func(ctx context.Context, nodeName string,
+			newStateLabelValue statemanager.NVSentinelStateLabelValue, removeStateLabel bool) (bool, error) {
+			labelUpdated = true
+			return true, nil
+		},
+	}
+	annotationManager := &MockNodeAnnotationManager{}
+	mockK8sClient := &MockK8sClient{annotationManagerOverride: annotationManager}
+
+	r := NewReconciler(ReconcilerConfig{RemediationClient: mockK8sClient, StateManager: stateManager}, false)
+
+	preemption := model.HealthEventWithStatus{HealthEvent: &protos.HealthEvent{
+		NodeName:          "spot-node",
+		RecommendedAction: protos.RecommendedAction_PREEMPTION_IMMINENT,
+	}}
+	assert.True(t, r.shouldSkipEvent(t.Context(), preemption))
+	assert.False(t, labelUpdated, "preemption must not be reported as an unsupported action")
+
+	restart := model.HealthEventWithStatus{HealthEvent: &protos.HealthEvent{
+		NodeName:          "spot-node",
+		RecommendedAction: protos.RecommendedAction_RESTART_VM,
+	}}
+	assert.False(t, r.shouldSkipEvent(t.Context(), restart))
+
+	annotationManager.preempting = true
+	assert.True(t, r.shouldSkipEvent(t.Context(), restart), "nodes being preempted are not remediated")
+	assert.False(t, labelUpdated)
+}
+
+func TestShouldSkipEventEnforcementOff(t *testing.T) {
+	annotationManager := &MockNodeAnnotationManager{}
+	mockK8sClient := &MockK8sClient{annotationManagerOverride: annotationManager}
+
+	r := NewReconciler(ReconcilerConfig{RemediationClient: mockK8sClient}, false)
+
+	restart := model.HealthEventWithStatus{HealthEvent: &protos.HealthEvent{
+		NodeName:          "login-node",
+		RecommendedAction: protos.RecommendedAction_RESTART_BM,
+	}}
+	assert.False(t, r.shouldSkipEvent(t.Context(), restart))
+
+	annotationManager.enforcementOff = true
+	assert.True(t, r.shouldSkipEvent(t.Context(), restart), "observe-only nodes are not remediated")
+
+	annotationManager.enforcementOff = false
+	annotationManager.enforcementErr = errors.New("apiserver unavailable")
+	assert.True(t, r.shouldSkipEvent(t.Context(), restart), "nodes that cannot be looked up are not remediated")
+}
+
+type mockRunbookRunner struct {
+	checkName string
+}
+
+func (m *mockRunbookRunner) Match(event *protos.HealthEvent) (string, bool) {
+	return "nic-reset", event.CheckName == m.checkName
+}
+
+func (m *mockRunbookRunner) Run(ctx context.Context, name string, event *protos.HealthEvent,
+	healthEventID string) *model.RunbookRecord {
+	return &model.RunbookRecord{Name: name, Succeeded: true}
+}
+
+func TestMatchRunbook(t *testing.T) {
+	mockK8sClient := &MockK8sClient{annotationManagerOverride: &MockNodeAnnotationManager{}}
+
+	r := NewReconciler(ReconcilerConfig{RemediationClient: mockK8sClient}, false)
+
+	nicEvent := model.HealthEventWithStatus{HealthEvent: &protos.HealthEvent{
+		NodeName:          "node-1",
+		CheckName:         "NICHealth",
+		RecommendedAction: protos.RecommendedAction_CONTACT_SUPPORT,
+	}}
+
+	_, ok := r.matchRunbook(nicEvent)
+	assert.False(t, ok, "no runbooks configured")
+
+	r.Config.Runbooks = &mockRunbookRunner{checkName: "NICHealth"}
+
+	name, ok := r.matchRunbook(nicEvent)
+	assert.True(t, ok)
+	assert.Equal(t, "nic-reset", name)
+
+	remediated := nicEvent
+	remediated.HealthEventStatus.FaultRemediated = ptr.To(true)
+	_, ok = r.matchRunbook(remediated)
+	assert.False(t, ok, "remediated events do not run the runbook again")
+
+	none := model.HealthEventWithStatus{HealthEvent: &protos.HealthEvent{
+		CheckName:         "NICHealth",
+		RecommendedAction: protos.RecommendedAction_NONE,
+	}}
+	_, ok = r.matchRunbook(none)
+	assert.False(t, ok)
+}
+
+type mockPlanner struct {
+	plan *plan.Plan
+	err  error
+}
+
+func (m *mockPlanner) Build(ctx context.Context, event *protos.HealthEvent, healthEventID string) (*plan.Plan, error) {
+	return m.plan, m.err
+}
+
+func TestPlanRemediation(t *testing.T) {
+	singleStep := []plan.Step{{Order: 1, Type: plan.StepMaintenance}}
+	multiStep := []plan.Step{{Order: 1, Type: plan.StepCollectLogs}, {Order: 2, Type: plan.StepMaintenance}}
+
+	tests := []struct {
+		name      string
+		planner   *mockPlanner
+		planned   bool
+		published bool
+	}{
+		{
+			name:      "plan is published",
+			planner:   &mockPlanner{plan: &plan.Plan{NodeName: "node-1", Steps: multiStep}},
+			planned:   true,
+			published: true,
+		},
+		{
+			name: "single-step remediation runs with a partial plan",
+			planner: &mockPlanner{
+				plan: &plan.Plan{NodeName: "node-1", Steps: singleStep},
+				err:  fmt.Errorf("failed to list pods"),
+			},
+			planned:   true,
+			published: true,
+		},
+		{
+			name: "multi-step remediation requires a complete plan",
+			planner: &mockPlanner{
+				plan: &plan.Plan{NodeName: "node-1", Steps: multiStep},
+				err:  fmt.Errorf("failed to list pods"),
+			},
+			planned: false,
+		},
+		{
+			name:    "no plan",
+			planner: &mockPlanner{err: fmt.Errorf("failed")},
+			planned: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := plan.NewStore()
+			r := NewReconciler(ReconcilerConfig{
+				RemediationClient: &MockK8sClient{},
+				Planner:           tt.planner,
+				PlanStore:         store,
+			}, false)
+
+			doc := &HealthEventDoc{
+				ID:                    primitive.NewObjectID(),
+				HealthEventWithStatus: model.HealthEventWithStatus{HealthEvent: &protos.HealthEvent{NodeName: "node-1"}},
+			}
+
+			remediationPlan, planned := r.planRemediation(t.Context(), doc)
+			assert.Equal(t, tt.planned, planned)
+
+			_, published := store.Get("node-1")
+			assert.Equal(t, tt.published, published)
+
+			if published {
+				r.completePlan(remediationPlan, true)
+
+				stored, _ := store.Get("node-1")
+				assert.Equal(t, plan.StatusSucceeded, stored.Status)
+			}
+		})
+	}
+}
+
 func TestRunLogCollectorOnNoneActionWhenEnabled(t *testing.T) {
 	ctx := context.Background()
 
@@ -50,6 +50,9 @@ import (
 const (
 	// Environment variable names
 	LogCollectorManifestPathEnv = "LOG_COLLECTOR_MANIFEST_PATH"
+
+	// LogCollectorTimeout is how long to wait for the log collector job to complete
+	LogCollectorTimeout = 5 * time.Minute
 )
 
 type FaultRemediationClient struct {
@@ -68,6 +71,9 @@ type TemplateData struct {
 	NodeName          string
 	HealthEventID     string
 	RecommendedAction protos.RecommendedAction
+	// CheckName is the check of the health event, which is also the type of the node
+	// condition the platform connector sets for it
+	CheckName         string
 	TemplateMountPath string
 	TemplateFileName  string
 	config.MaintenanceResource
@@ -182,15 +188,16 @@ func (c *FaultRemediationClient) CreateMaintenanceResource(
 		return true, crName
 	}
 
-	log.Printf("Creating RebootNode CR for node: %s", healthEvent.NodeName)
+	log.Printf("Creating maintenance CR for node: %s", healthEvent.NodeName)
 	c.templateData.NodeName = healthEvent.NodeName
 	c.templateData.RecommendedAction = healthEvent.RecommendedAction
+	c.templateData.CheckName = healthEvent.CheckName
 	c.templateData.HealthEventID = healthEventID
 
 	// Execute the template
 	var buf bytes.Buffer
 	if err := c.template.Execute(&buf, c.templateData); err != nil {
-		slog.Error("Failed to execute maintenance template", "error", err)
+		slog.ErrorContext(ctx, "Failed to execute maintenance template", "error", err)
 		return false, ""
 	}
 
@@ -199,7 +206,7 @@ func (c *FaultRemediationClient) CreateMaintenanceResource(
 	// Convert YAML to unstructured
 	var obj map[string]any
 	if err := yaml.Unmarshal(buf.Bytes(), &obj); err != nil {
-		slog.Error("Failed to unmarshal YAML", "error", err)
+		slog.ErrorContext(ctx, "Failed to unmarshal YAML", "error", err)
 		return false, ""
 	}
 
@@ -211,7 +218,7 @@ func (c *FaultRemediationClient) CreateMaintenanceResource(
 	// Convert GVK to GVR using RESTMapper
 	mapping, err := c.restMapper.RESTMapping(gvk.GroupKind(), gvk.Version)
 	if err != nil {
-		slog.Error("Failed to get REST mapping", "error", err, "gvk", gvk)
+		slog.ErrorContext(ctx, "Failed to get REST mapping", "error", err, "gvk", gvk)
 		return false, ""
 	}
 
@@ -231,7 +238,7 @@ func (c *FaultRemediationClient) CreateMaintenanceResource(
 	if group != "" && c.annotationManager != nil {
 		if err := c.annotationManager.UpdateRemediationState(ctx, healthEvent.NodeName,
 			group, actualCRName); err != nil {
-			slog.Warn("Failed to update node annotation", "node", healthEvent.NodeName,
+			slog.WarnContext(ctx, "Failed to update node annotation", "node", healthEvent.NodeName,
 				"error", err)
 		}
 	}
@@ -256,7 +263,7 @@ func (c *FaultRemediationClient) handleCreateCRError(
 		if group != "" && c.annotationManager != nil {
 			if err := c.annotationManager.UpdateRemediationState(ctx, healthEvent.NodeName,
 				group, crName); err != nil {
-				slog.Warn("Failed to update node annotation", "node", healthEvent.NodeName,
+				slog.WarnContext(ctx, "Failed to update node annotation", "node", healthEvent.NodeName,
 					"error", err)
 			}
 		}
@@ -311,7 +318,7 @@ func (c *FaultRemediationClient) RunLogCollectorJob(ctx context.Context, nodeNam
 	log.Printf("Waiting for log collector job %s to complete", created.Name)
 
 	// Use a context with timeout for the watch
-	watchCtx, cancel := context.WithTimeout(ctx, 5*time.Minute)
+	watchCtx, cancel := context.WithTimeout(ctx, LogCollectorTimeout)
 	defer cancel()
 
 	// Use SharedInformerFactory for efficient job status monitoring with filtering
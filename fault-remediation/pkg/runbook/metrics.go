@@ -0,0 +1,38 @@
+// Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package runbook
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+var (
+	runbookRuns = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "fault_remediation_runbook_runs_total",
+			Help: "Total number of runbook jobs run by result.",
+		},
+		[]string{"runbook", "result"},
+	)
+	runbookDuration = promauto.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name:    "fault_remediation_runbook_duration_seconds",
+			Help:    "Duration of runbook runs, from creating the job to its completion.",
+			Buckets: []float64{5, 15, 30, 60, 120, 300, 600, 1200, 1800},
+		},
+		[]string{"runbook"},
+	)
+)
@@ -0,0 +1,405 @@
+// Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package runbook runs operator supplied scripts on the node of an incident, for
+// site specific fixes fault remediation has no maintenance resource for. A runbook
+// is the template of a Job that runs on the node with the context of the incident
+// in its environment; the outcome and output of the Job are recorded on the
+// health event.
+package runbook
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/nvidia/nvsentinel/commons/pkg/nodelock"
+	"github.com/nvidia/nvsentinel/data-models/pkg/model"
+	"github.com/nvidia/nvsentinel/data-models/pkg/protos"
+	"github.com/nvidia/nvsentinel/fault-remediation/pkg/config"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/kubernetes"
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	// DefaultTimeout is how long to wait for a runbook Job without a configured timeout
+	DefaultTimeout = 10 * time.Minute
+
+	// LabelKey is set on runbook Jobs to the name of the runbook
+	LabelKey = "nvsentinel.dgxc.nvidia.com/runbook"
+
+	// maxOutputBytes bounds the output recorded on the health event, the tail of
+	// the last outputTailLines lines of the pod log
+	maxOutputBytes        = 16 * 1024
+	outputTailLines int64 = 500
+)
+
+// TemplateData holds the data runbook Job templates are executed with
+type TemplateData struct {
+	Runbook       string
+	NodeName      string
+	HealthEventID string
+}
+
+type runbook struct {
+	config.Runbook
+	template *template.Template
+	timeout  time.Duration
+}
+
+// Runner runs the runbook matching a health event.
+type Runner struct {
+	kubeClient   kubernetes.Interface
+	runbooks     []runbook
+	dryRun       bool
+	pollInterval time.Duration
+	now          func() time.Time
+	// nodeLock keeps runbooks from running while another action acts on the node
+	nodeLock *nodelock.Locker
+}
+
+// NewRunner validates the runbooks and parses their Job templates, which are read
+// from templateDir.
+func NewRunner(kubeClient kubernetes.Interface, runbooks []config.Runbook, templateDir string,
+	dryRun bool) (*Runner, error) {
+	runner := &Runner{
+		kubeClient:   kubeClient,
+		dryRun:       dryRun,
+		pollInterval: 5 * time.Second,
+		now:          time.Now,
+	}
+
+	names := make(map[string]bool, len(runbooks))
+
+	for _, rb := range runbooks {
+		if rb.Name == "" {
+			return nil, fmt.Errorf("runbook name is required")
+		}
+
+		if names[rb.Name] {
+			return nil, fmt.Errorf("duplicate runbook %q", rb.Name)
+		}
+
+		names[rb.Name] = true
+
+		if rb.CheckName == "" && len(rb.ErrorCodes) == 0 {
+			return nil, fmt.Errorf("runbook %q: checkName or errorCodes is required", rb.Name)
+		}
+
+		if rb.JobTemplate == "" {
+			return nil, fmt.Errorf("runbook %q: jobTemplate is required", rb.Name)
+		}
+
+		if rb.TimeoutSeconds < 0 {
+			return nil, fmt.Errorf("runbook %q: timeoutSeconds must not be negative", rb.Name)
+		}
+
+		content, err := os.ReadFile(filepath.Join(templateDir, rb.JobTemplate))
+		if err != nil {
+			return nil, fmt.Errorf("runbook %q: error reading job template: %w", rb.Name, err)
+		}
+
+		tmpl, err := template.New(rb.Name).Parse(string(content))
+		if err != nil {
+			return nil, fmt.Errorf("runbook %q: error parsing job template: %w", rb.Name, err)
+		}
+
+		timeout := DefaultTimeout
+		if rb.TimeoutSeconds > 0 {
+			timeout = time.Duration(rb.TimeoutSeconds) * time.Second
+		}
+
+		runner.runbooks = append(runner.runbooks, runbook{Runbook: rb, template: tmpl, timeout: timeout})
+	}
+
+	return runner, nil
+}
+
+// SetNodeLock makes runbooks take the lock of their node while their Job runs, so
+// they never run concurrently with another action on the node.
+func (r *Runner) SetNodeLock(locker *nodelock.Locker) {
+	r.nodeLock = locker
+}
+
+// Match returns the name of the first runbook matching the event.
+func (r *Runner) Match(event *protos.HealthEvent) (string, bool) {
+	for _, rb := range r.runbooks {
+		if rb.Matches(event.CheckName, event.ErrorCode) {
+			return rb.Name, true
+		}
+	}
+
+	return "", false
+}
+
+// Run runs the runbook on the node of the event and waits for its Job to complete.
+// The returned record reports the outcome and the output of the Job.
+func (r *Runner) Run(ctx context.Context, name string, event *protos.HealthEvent,
+	healthEventID string) *model.RunbookRecord {
+	record := &model.RunbookRecord{Name: name, StartedAt: r.now().UTC()}
+
+	result := r.run(ctx, name, event, healthEventID, record)
+
+	record.CompletedAt = r.now().UTC()
+	record.Succeeded = result == resultSuccess
+
+	runbookRuns.WithLabelValues(name, result).Inc()
+	runbookDuration.WithLabelValues(name).Observe(record.CompletedAt.Sub(record.StartedAt).Seconds())
+
+	slog.Info("Runbook completed",
+		"runbook", name,
+		"node", event.NodeName,
+		"job", record.Job,
+		"result", result)
+
+	return record
+}
+
+// Results of a runbook run
+const (
+	resultSuccess = "success"
+	resultFailure = "failure"
+	resultTimeout = "timeout"
+	resultError   = "error"
+	resultLocked  = "locked"
+)
+
+func (r *Runner) run(ctx context.Context, name string, event *protos.HealthEvent, healthEventID string,
+	record *model.RunbookRecord) string {
+	idx := slices.IndexFunc(r.runbooks, func(rb runbook) bool { return rb.Name == name })
+	if idx < 0 {
+		record.Error = fmt.Sprintf("unknown runbook %q", name)
+		return resultError
+	}
+
+	rb := r.runbooks[idx]
+
+	job, err := r.buildJob(rb, event, healthEventID)
+	if err != nil {
+		record.Error = err.Error()
+		return resultError
+	}
+
+	if r.dryRun {
+		slog.Info("DRY-RUN: Skipping runbook job", "runbook", name, "node", event.NodeName)
+		return resultSuccess
+	}
+
+	if r.nodeLock != nil {
+		holder := "runbook/" + healthEventID
+
+		if _, err := r.nodeLock.Acquire(ctx, event.NodeName, holder); err != nil {
+			record.Error = fmt.Sprintf("failed to lock node: %v", err)
+
+			if errors.Is(err, nodelock.ErrHeld) {
+				return resultLocked
+			}
+
+			return resultError
+		}
+
+		defer func() {
+			if err := r.nodeLock.Release(ctx, event.NodeName, holder); err != nil {
+				slog.Error("Failed to release node lock, it will expire", "node", event.NodeName, "error", err)
+			}
+		}()
+	}
+
+	created, err := r.kubeClient.BatchV1().Jobs(job.Namespace).Create(ctx, job, metav1.CreateOptions{})
+	if err != nil {
+		record.Error = fmt.Sprintf("failed to create job: %v", err)
+		return resultError
+	}
+
+	record.Job = created.Name
+
+	slog.Info("Waiting for runbook job to complete", "runbook", name, "job", created.Name, "node", event.NodeName)
+
+	succeeded, err := r.waitForJob(ctx, created, rb.timeout)
+
+	record.Output = r.jobOutput(ctx, created)
+
+	switch {
+	case err != nil:
+		record.Error = fmt.Sprintf("job did not complete within %s: %v", rb.timeout, err)
+		r.deleteJob(ctx, created)
+
+		return resultTimeout
+	case !succeeded:
+		record.Error = "job failed"
+		return resultFailure
+	default:
+		return resultSuccess
+	}
+}
+
+// buildJob executes the Job template of the runbook, pins the Job to the node of
+// the event and injects the context of the incident into its containers.
+func (r *Runner) buildJob(rb runbook, event *protos.HealthEvent, healthEventID string) (*batchv1.Job, error) {
+	var buf bytes.Buffer
+
+	data := TemplateData{Runbook: rb.Name, NodeName: event.NodeName, HealthEventID: healthEventID}
+	if err := rb.template.Execute(&buf, data); err != nil {
+		return nil, fmt.Errorf("failed to execute job template: %w", err)
+	}
+
+	job := &batchv1.Job{}
+	if err := yaml.Unmarshal(buf.Bytes(), job); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal job template: %w", err)
+	}
+
+	if job.Labels == nil {
+		job.Labels = make(map[string]string)
+	}
+
+	job.Labels[LabelKey] = rb.Name
+
+	podSpec := &job.Spec.Template.Spec
+	podSpec.NodeName = event.NodeName
+
+	env := incidentEnv(rb.Name, event, healthEventID)
+
+	for i := range podSpec.InitContainers {
+		podSpec.InitContainers[i].Env = mergeEnv(podSpec.InitContainers[i].Env, env)
+	}
+
+	for i := range podSpec.Containers {
+		podSpec.Containers[i].Env = mergeEnv(podSpec.Containers[i].Env, env)
+	}
+
+	return job, nil
+}
+
+// incidentEnv returns the environment describing the incident the runbook runs for.
+func incidentEnv(name string, event *protos.HealthEvent, healthEventID string) []corev1.EnvVar {
+	entities := make([]string, 0, len(event.EntitiesImpacted))
+	for _, entity := range event.EntitiesImpacted {
+		entities = append(entities, entity.EntityType+"="+entity.EntityValue)
+	}
+
+	return []corev1.EnvVar{
+		{Name: model.RunbookEnvRunbook, Value: name},
+		{Name: model.RunbookEnvNodeName, Value: event.NodeName},
+		{Name: model.RunbookEnvHealthEventID, Value: healthEventID},
+		{Name: model.RunbookEnvAgent, Value: event.Agent},
+		{Name: model.RunbookEnvCheckName, Value: event.CheckName},
+		{Name: model.RunbookEnvComponentClass, Value: event.ComponentClass},
+		{Name: model.RunbookEnvErrorCodes, Value: strings.Join(event.ErrorCode, ",")},
+		{Name: model.RunbookEnvRecommendedAction, Value: event.RecommendedAction.String()},
+		{Name: model.RunbookEnvMessage, Value: event.Message},
+		{Name: model.RunbookEnvEntities, Value: strings.Join(entities, ",")},
+	}
+}
+
+// mergeEnv replaces the variables of the container named like incident variables.
+func mergeEnv(containerEnv, env []corev1.EnvVar) []corev1.EnvVar {
+	merged := slices.DeleteFunc(slices.Clone(containerEnv), func(v corev1.EnvVar) bool {
+		return slices.ContainsFunc(env, func(e corev1.EnvVar) bool { return e.Name == v.Name })
+	})
+
+	return append(merged, env...)
+}
+
+// waitForJob waits for the Job to complete and reports whether it succeeded.
+func (r *Runner) waitForJob(ctx context.Context, job *batchv1.Job, timeout time.Duration) (bool, error) {
+	succeeded := false
+
+	err := wait.PollUntilContextTimeout(ctx, r.pollInterval, timeout, true, func(ctx context.Context) (bool, error) {
+		current, err := r.kubeClient.BatchV1().Jobs(job.Namespace).Get(ctx, job.Name, metav1.GetOptions{})
+		if err != nil {
+			slog.Warn("Failed to get runbook job", "job", job.Name, "error", err)
+			return false, nil
+		}
+
+		for _, condition := range current.Status.Conditions {
+			if condition.Status != corev1.ConditionTrue {
+				continue
+			}
+
+			switch condition.Type {
+			case batchv1.JobComplete:
+				succeeded = true
+				return true, nil
+			case batchv1.JobFailed:
+				return true, nil
+			}
+		}
+
+		return false, nil
+	})
+
+	return succeeded, err
+}
+
+// jobOutput returns the tail of the log of the latest pod of the Job.
+func (r *Runner) jobOutput(ctx context.Context, job *batchv1.Job) string {
+	pods, err := r.kubeClient.CoreV1().Pods(job.Namespace).List(ctx, metav1.ListOptions{
+		LabelSelector: "job-name=" + job.Name,
+	})
+	if err != nil || len(pods.Items) == 0 {
+		slog.Warn("Failed to find the pod of runbook job", "job", job.Name, "error", err)
+		return ""
+	}
+
+	latest := slices.MaxFunc(pods.Items, func(a, b corev1.Pod) int {
+		return a.CreationTimestamp.Compare(b.CreationTimestamp.Time)
+	})
+
+	tailLines := outputTailLines
+
+	stream, err := r.kubeClient.CoreV1().Pods(latest.Namespace).
+		GetLogs(latest.Name, &corev1.PodLogOptions{TailLines: &tailLines}).Stream(ctx)
+	if err != nil {
+		slog.Warn("Failed to get the log of runbook job", "job", job.Name, "pod", latest.Name, "error", err)
+		return ""
+	}
+	defer stream.Close()
+
+	output, err := io.ReadAll(stream)
+	if err != nil {
+		slog.Warn("Failed to read the log of runbook job", "job", job.Name, "pod", latest.Name, "error", err)
+	}
+
+	if len(output) > maxOutputBytes {
+		output = output[len(output)-maxOutputBytes:]
+	}
+
+	return string(output)
+}
+
+// deleteJob deletes a Job that timed out, along with its pods, so that the script
+// does not keep running after the remediation failed.
+func (r *Runner) deleteJob(ctx context.Context, job *batchv1.Job) {
+	propagation := metav1.DeletePropagationBackground
+
+	err := r.kubeClient.BatchV1().Jobs(job.Namespace).Delete(ctx, job.Name,
+		metav1.DeleteOptions{PropagationPolicy: &propagation})
+	if err != nil {
+		slog.Warn("Failed to delete runbook job", "job", job.Name, "error", err)
+	}
+}
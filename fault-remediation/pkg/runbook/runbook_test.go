@@ -0,0 +1,267 @@
+// Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package runbook
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/nvidia/nvsentinel/commons/pkg/nodelock"
+	"github.com/nvidia/nvsentinel/data-models/pkg/model"
+	"github.com/nvidia/nvsentinel/data-models/pkg/protos"
+	"github.com/nvidia/nvsentinel/fault-remediation/pkg/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+)
+
+const jobTemplate = `apiVersion: batch/v1
+kind: Job
+metadata:
+  name: runbook-{{ .Runbook }}-{{ .HealthEventID }}
+  namespace: nvsentinel
+spec:
+  template:
+    spec:
+      restartPolicy: Never
+      containers:
+        - name: script
+          image: busybox
+          env:
+            - name: NVSENTINEL_NODE_NAME
+              value: overridden
+            - name: SITE
+              value: dc1
+`
+
+func writeTemplate(t *testing.T) string {
+	t.Helper()
+
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "nic-reset.yaml"), []byte(jobTemplate), 0600))
+
+	return dir
+}
+
+var nicReset = config.Runbook{
+	Name:        "nic-reset",
+	CheckName:   "NICHealth",
+	ErrorCodes:  []string{"NIC_FW_HANG"},
+	JobTemplate: "nic-reset.yaml",
+}
+
+func event() *protos.HealthEvent {
+	return &protos.HealthEvent{
+		NodeName:          "node-1",
+		Agent:             "nic-health-monitor",
+		CheckName:         "NICHealth",
+		ComponentClass:    "NIC",
+		ErrorCode:         []string{"NIC_FW_HANG"},
+		RecommendedAction: protos.RecommendedAction_CONTACT_SUPPORT,
+		Message:           "firmware not responding",
+		EntitiesImpacted: []*protos.Entity{
+			{EntityType: "NIC", EntityValue: "mlx5_0"},
+			{EntityType: "PCI", EntityValue: "0000:0c:00.0"},
+		},
+	}
+}
+
+func TestNewRunner(t *testing.T) {
+	dir := writeTemplate(t)
+
+	_, err := NewRunner(fake.NewSimpleClientset(), []config.Runbook{nicReset}, dir, false)
+	require.NoError(t, err)
+
+	invalid := map[string]config.Runbook{
+		"missing name":     {CheckName: "NICHealth", JobTemplate: "nic-reset.yaml"},
+		"no selector":      {Name: "a", JobTemplate: "nic-reset.yaml"},
+		"missing template": {Name: "a", CheckName: "NICHealth"},
+		"unknown template": {Name: "a", CheckName: "NICHealth", JobTemplate: "missing.yaml"},
+		"negative timeout": {Name: "a", CheckName: "NICHealth", JobTemplate: "nic-reset.yaml", TimeoutSeconds: -1},
+	}
+
+	for name, rb := range invalid {
+		t.Run(name, func(t *testing.T) {
+			_, err := NewRunner(fake.NewSimpleClientset(), []config.Runbook{rb}, dir, false)
+			assert.Error(t, err)
+		})
+	}
+
+	_, err = NewRunner(fake.NewSimpleClientset(), []config.Runbook{nicReset, nicReset}, dir, false)
+	assert.Error(t, err, "duplicate runbook")
+}
+
+func TestMatch(t *testing.T) {
+	runner, err := NewRunner(fake.NewSimpleClientset(), []config.Runbook{nicReset}, writeTemplate(t), false)
+	require.NoError(t, err)
+
+	name, ok := runner.Match(event())
+	assert.True(t, ok)
+	assert.Equal(t, "nic-reset", name)
+
+	other := event()
+	other.ErrorCode = []string{"NIC_LINK_DOWN"}
+
+	_, ok = runner.Match(other)
+	assert.False(t, ok)
+}
+
+// completeJobs makes the fake clientset report every job with the condition.
+func completeJobs(client *fake.Clientset, conditionType batchv1.JobConditionType) {
+	client.PrependReactor("get", "jobs", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		get := action.(k8stesting.GetAction)
+
+		return true, &batchv1.Job{
+			ObjectMeta: metav1.ObjectMeta{Namespace: get.GetNamespace(), Name: get.GetName()},
+			Status: batchv1.JobStatus{Conditions: []batchv1.JobCondition{
+				{Type: conditionType, Status: corev1.ConditionTrue},
+			}},
+		}, nil
+	})
+}
+
+func jobPod() *corev1.Pod {
+	return &corev1.Pod{ObjectMeta: metav1.ObjectMeta{
+		Namespace: "nvsentinel",
+		Name:      "runbook-nic-reset-abc-x1",
+		Labels:    map[string]string{"job-name": "runbook-nic-reset-abc"},
+	}}
+}
+
+func TestRun(t *testing.T) {
+	dir := writeTemplate(t)
+
+	t.Run("success", func(t *testing.T) {
+		client := fake.NewSimpleClientset(jobPod())
+		completeJobs(client, batchv1.JobComplete)
+
+		runner, err := NewRunner(client, []config.Runbook{nicReset}, dir, false)
+		require.NoError(t, err)
+
+		runner.pollInterval = time.Millisecond
+
+		record := runner.Run(context.Background(), "nic-reset", event(), "abc")
+		assert.True(t, record.Succeeded)
+		assert.Equal(t, "nic-reset", record.Name)
+		assert.Equal(t, "runbook-nic-reset-abc", record.Job)
+		assert.Equal(t, "fake logs", record.Output)
+		assert.Empty(t, record.Error)
+
+		job, err := client.Tracker().Get(batchv1.SchemeGroupVersion.WithResource("jobs"), "nvsentinel",
+			"runbook-nic-reset-abc")
+		require.NoError(t, err)
+
+		spec := job.(*batchv1.Job).Spec.Template.Spec
+		assert.Equal(t, "node-1", spec.NodeName)
+		assert.Equal(t, "nic-reset", job.(*batchv1.Job).Labels[LabelKey])
+
+		env := make(map[string]string)
+		for _, v := range spec.Containers[0].Env {
+			env[v.Name] = v.Value
+		}
+
+		assert.Equal(t, "dc1", env["SITE"])
+		assert.Equal(t, "node-1", env[model.RunbookEnvNodeName])
+		assert.Equal(t, "abc", env[model.RunbookEnvHealthEventID])
+		assert.Equal(t, "NIC_FW_HANG", env[model.RunbookEnvErrorCodes])
+		assert.Equal(t, "CONTACT_SUPPORT", env[model.RunbookEnvRecommendedAction])
+		assert.Equal(t, "NIC=mlx5_0,PCI=0000:0c:00.0", env[model.RunbookEnvEntities])
+		assert.Len(t, spec.Containers[0].Env, 11)
+	})
+
+	t.Run("failure", func(t *testing.T) {
+		client := fake.NewSimpleClientset(jobPod())
+		completeJobs(client, batchv1.JobFailed)
+
+		runner, err := NewRunner(client, []config.Runbook{nicReset}, dir, false)
+		require.NoError(t, err)
+
+		runner.pollInterval = time.Millisecond
+
+		record := runner.Run(context.Background(), "nic-reset", event(), "abc")
+		assert.False(t, record.Succeeded)
+		assert.Equal(t, "job failed", record.Error)
+		assert.Equal(t, "fake logs", record.Output)
+	})
+
+	t.Run("timeout deletes the job", func(t *testing.T) {
+		client := fake.NewSimpleClientset()
+
+		rb := nicReset
+		rb.TimeoutSeconds = 1
+
+		runner, err := NewRunner(client, []config.Runbook{rb}, dir, false)
+		require.NoError(t, err)
+
+		runner.pollInterval = 100 * time.Millisecond
+
+		record := runner.Run(context.Background(), "nic-reset", event(), "abc")
+		assert.False(t, record.Succeeded)
+		assert.Contains(t, record.Error, "did not complete")
+
+		jobs, err := client.BatchV1().Jobs("nvsentinel").List(context.Background(), metav1.ListOptions{})
+		require.NoError(t, err)
+		assert.Empty(t, jobs.Items)
+	})
+
+	t.Run("node locked by another action", func(t *testing.T) {
+		client := fake.NewSimpleClientset(jobPod())
+		completeJobs(client, batchv1.JobComplete)
+
+		runner, err := NewRunner(client, []config.Runbook{nicReset}, dir, false)
+		require.NoError(t, err)
+
+		runner.pollInterval = time.Millisecond
+		runner.SetNodeLock(nodelock.NewLocker(client, "nvsentinel", time.Hour))
+
+		_, err = runner.nodeLock.Acquire(context.Background(), "node-1", "rebootnode/node-1")
+		require.NoError(t, err)
+
+		record := runner.Run(context.Background(), "nic-reset", event(), "abc")
+		assert.False(t, record.Succeeded)
+		assert.Contains(t, record.Error, "failed to lock node")
+		assert.Empty(t, record.Job)
+
+		require.NoError(t, runner.nodeLock.Release(context.Background(), "node-1", "rebootnode/node-1"))
+
+		record = runner.Run(context.Background(), "nic-reset", event(), "abc")
+		assert.True(t, record.Succeeded)
+
+		// the runbook released the lock once its job completed
+		_, err = runner.nodeLock.Acquire(context.Background(), "node-1", "rebootnode/node-1")
+		assert.NoError(t, err)
+	})
+
+	t.Run("dry run", func(t *testing.T) {
+		client := fake.NewSimpleClientset()
+
+		runner, err := NewRunner(client, []config.Runbook{nicReset}, dir, true)
+		require.NoError(t, err)
+
+		record := runner.Run(context.Background(), "nic-reset", event(), "abc")
+		assert.True(t, record.Succeeded)
+		assert.Empty(t, record.Job)
+		assert.Empty(t, client.Actions())
+	})
+}
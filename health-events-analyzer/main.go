@@ -19,15 +19,31 @@ import (
 	"flag"
 	"fmt"
 	"log/slog"
+	"net"
 	"os"
 	"strconv"
+	"time"
 
+	"github.com/nvidia/nvsentinel/commons/pkg/configmanager"
 	"github.com/nvidia/nvsentinel/commons/pkg/logger"
 	"github.com/nvidia/nvsentinel/commons/pkg/server"
+	"github.com/nvidia/nvsentinel/data-models/pkg/model"
 	protos "github.com/nvidia/nvsentinel/data-models/pkg/protos"
+	"github.com/nvidia/nvsentinel/health-events-analyzer/pkg/affected"
+	"github.com/nvidia/nvsentinel/health-events-analyzer/pkg/classification"
 	config "github.com/nvidia/nvsentinel/health-events-analyzer/pkg/config"
+	"github.com/nvidia/nvsentinel/health-events-analyzer/pkg/events"
+	"github.com/nvidia/nvsentinel/health-events-analyzer/pkg/incidents"
+	"github.com/nvidia/nvsentinel/health-events-analyzer/pkg/overrides"
 	"github.com/nvidia/nvsentinel/health-events-analyzer/pkg/publisher"
+	"github.com/nvidia/nvsentinel/health-events-analyzer/pkg/quarantine"
 	"github.com/nvidia/nvsentinel/health-events-analyzer/pkg/reconciler"
+	"github.com/nvidia/nvsentinel/health-events-analyzer/pkg/reports"
+	"github.com/nvidia/nvsentinel/health-events-analyzer/pkg/rollout"
+	"github.com/nvidia/nvsentinel/health-events-analyzer/pkg/rulepacks"
+	"github.com/nvidia/nvsentinel/health-events-analyzer/pkg/sharding"
+	"github.com/nvidia/nvsentinel/health-events-analyzer/pkg/trends"
+	"github.com/nvidia/nvsentinel/health-events-analyzer/pkg/versionskew"
 	"github.com/nvidia/nvsentinel/store-client/pkg/storewatcher"
 	"golang.org/x/sync/errgroup"
 
@@ -35,8 +51,12 @@ import (
 	"go.mongodb.org/mongo-driver/mongo"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials/insecure"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/clientcmd"
 )
 
+const rolloutTrackerResyncPeriod = 10 * time.Minute
+
 var (
 	// These variables will be populated during the build process
 	version = "dev"
@@ -60,7 +80,10 @@ func createPipeline() mongo.Pipeline {
 			{Key: "$match", Value: bson.D{
 				{Key: "operationType", Value: "insert"},
 				{Key: "fullDocument.healthevent.agent", Value: bson.D{{Key: "$ne", Value: "health-events-analyzer"}}},
-				{Key: "fullDocument.healthevent.ishealthy", Value: false},
+				{Key: "$or", Value: bson.A{
+					bson.D{{Key: "fullDocument.healthevent.ishealthy", Value: false}},
+					bson.D{{Key: "fullDocument.healthevent.checkname", Value: model.RebootCheckName}},
+				}},
 			}},
 		},
 	}
@@ -86,6 +109,10 @@ func run() error {
 	metricsPort := flag.String("metrics-port", "2112", "port to expose Prometheus metrics on")
 	socket := flag.String("socket", "unix:///var/run/nvsentinel.sock", "unix domain socket")
 	tomlConfigPath := flag.String("config-path", "/etc/config/config.toml", "path to TOML config file")
+	kubeconfig := flag.String("kubeconfig", "",
+		"path to kubeconfig file, only used for rollout correlation, reliability reports and sharding")
+	rebootCacheSize := flag.Int("reboot-cache-size", 65536,
+		"maximum number of nodes whose last reboot is kept, the least recently used are evicted")
 
 	flag.Parse()
 
@@ -114,22 +141,91 @@ func run() error {
 		MongoPipeline:                    pipeline,
 		HealthEventsAnalyzerRules:        tomlConfig,
 		Publisher:                        pub,
+		Classifier:                       newClassifier(tomlConfig),
+		RebootCacheSize:                  *rebootCacheSize,
+	}
+
+	var rolloutTracker *rollout.Tracker
+
+	if correlation := tomlConfig.RolloutCorrelation; correlation != nil {
+		rolloutTracker, err = newRolloutTracker(*kubeconfig, correlation)
+		if err != nil {
+			return err
+		}
+
+		reconcilerCfg.RolloutTracker = rolloutTracker
+	}
+
+	var sharder *sharding.Sharder
+
+	if shardingConfig := tomlConfig.Sharding; shardingConfig != nil {
+		sharder, err = newSharder(ctx, *kubeconfig, shardingConfig)
+		if err != nil {
+			return err
+		}
+
+		reconcilerCfg.Sharder = sharder
+		reconcilerCfg.TokenConfig.ClientName = sharder.TokenClientName(tokenConfig.ClientName)
 	}
 
 	rec := reconciler.NewReconciler(reconcilerCfg)
 
+	if sharder != nil {
+		sharder.OnRebalance(rec.Rebalance)
+	}
+
 	// Parse the metrics port
 	portInt, err := strconv.Atoi(*metricsPort)
 	if err != nil {
 		return fmt.Errorf("invalid metrics port: %w", err)
 	}
 
-	// Create the server
-	srv := server.NewServer(
+	// The trend, event, affected node, severity override, incident and report APIs query the
+	// stored events with their own collection client
+	trendsCollection, err := storewatcher.GetCollectionClient(ctx, mongoConfig)
+	if err != nil {
+		return fmt.Errorf("failed to initialize trends collection client: %w", err)
+	}
+
+	serverOpts := []server.Option{
 		server.WithPort(portInt),
 		server.WithPrometheusMetrics(),
+		server.WithHandler(logger.LevelsPath, logger.LevelsHandler()),
 		server.WithSimpleHealth(),
-	)
+		server.WithHandler(trends.PathPrefix, trends.NewHandler(trendsCollection)),
+		server.WithHandler(events.PathPrefix, events.NewHandler(trendsCollection)),
+		server.WithHandler(affected.PathPrefix, affected.NewHandler(trendsCollection, driverVersionKey(tomlConfig))),
+		server.WithHandler(overrides.PathPrefix, overrides.NewHandler(trendsCollection, tomlConfig.SeverityOverrides)),
+		server.WithHandler(incidents.PathPrefix, incidents.NewHandler(trendsCollection)),
+		server.WithHandler(quarantine.PathPrefix, quarantine.NewHandler(trendsCollection)),
+		server.WithHandler(configmanager.ConfigPath,
+			configmanager.Handler("health-events-analyzer", tomlConfig, config.LoadTomlConfigFromBytes)),
+	}
+
+	var versionSkewChecker *versionskew.Checker
+
+	if tomlConfig.VersionSkew != nil {
+		versionSkewChecker, err = newVersionSkewChecker(ctx, mongoConfig, tomlConfig.VersionSkew)
+		if err != nil {
+			return err
+		}
+
+		serverOpts = append(serverOpts, server.WithHandler(versionskew.PathPrefix, versionSkewChecker))
+	}
+
+	var reportGenerator *reports.Generator
+
+	if report := tomlConfig.ReliabilityReport; report != nil {
+		reportGenerator, err = newReportGenerator(*kubeconfig, trendsCollection, report)
+		if err != nil {
+			return err
+		}
+
+		serverOpts = append(serverOpts, server.WithHandler(reports.PathPrefix, reportGenerator))
+	}
+
+	// Create the server
+	srv := server.NewServer(serverOpts...)
 
 	// Start server and reconciler concurrently
 	g, gCtx := errgroup.WithContext(ctx)
@@ -150,6 +246,208 @@ func run() error {
 		return rec.Start(gCtx)
 	})
 
+	if rolloutTracker != nil {
+		g.Go(func() error {
+			return rolloutTracker.Run(gCtx)
+		})
+	}
+
+	if sharder != nil {
+		g.Go(func() error {
+			return sharder.Run(gCtx)
+		})
+	}
+
+	if versionSkewChecker != nil {
+		g.Go(func() error {
+			return versionSkewChecker.Run(gCtx)
+		})
+	}
+
+	if reportGenerator != nil {
+		g.Go(func() error {
+			return reportGenerator.Run(gCtx)
+		})
+	}
+
+	if distribution := tomlConfig.RulePackDistribution; distribution != nil {
+		if err := startRulePackDistribution(gCtx, g, distribution); err != nil {
+			return err
+		}
+	}
+
 	// Wait for both goroutines to finish
 	return g.Wait()
 }
+
+// driverVersionKey returns the event metadata key holding the driver version, empty
+// when the affected nodes API is not configured.
+func driverVersionKey(tomlConfig *config.TomlConfig) string {
+	if tomlConfig.AffectedNodes == nil {
+		return ""
+	}
+
+	return tomlConfig.AffectedNodes.DriverVersionKey
+}
+
+// newClassifier chains the external classifier, when configured, and the
+// classification rules.
+func newClassifier(tomlConfig *config.TomlConfig) *classification.Chain {
+	var classifiers []classification.Classifier
+
+	if external := tomlConfig.Classifier; external != nil {
+		slog.Info("External incident classifier enabled", "url", external.URL, "timeout", external.Timeout)
+
+		classifiers = append(classifiers,
+			classification.NewExternalClassifier(external.URL, external.TimeoutDuration()))
+	}
+
+	classifiers = append(classifiers, classification.NewRuleClassifier(tomlConfig.ClassificationRules))
+
+	return classification.NewChain(classifiers...)
+}
+
+func newClientset(kubeconfig string) (*kubernetes.Clientset, error) {
+	restConfig, err := clientcmd.BuildConfigFromFlags("", kubeconfig)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build kubernetes config: %w", err)
+	}
+
+	clientset, err := kubernetes.NewForConfig(restConfig)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create kubernetes clientset: %w", err)
+	}
+
+	return clientset, nil
+}
+
+func newRolloutTracker(kubeconfig string, correlation *config.RolloutCorrelation) (*rollout.Tracker, error) {
+	clientset, err := newClientset(kubeconfig)
+	if err != nil {
+		return nil, err
+	}
+
+	slog.Info("Rollout correlation enabled",
+		"window", correlation.Window,
+		"namespace", correlation.Namespace,
+		"podSelector", correlation.PodSelector,
+		"recommendedAction", correlation.RecommendedAction)
+
+	tracker, err := rollout.NewTracker(clientset, correlation.Namespace, correlation.PodSelector,
+		rolloutTrackerResyncPeriod)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create rollout tracker: %w", err)
+	}
+
+	return tracker, nil
+}
+
+// newSharder creates the sharder of this replica, named by the POD_NAME environment
+// variable, and lists the replicas in the POD_NAMESPACE namespace once. The replicas
+// all watch the same change stream, each with its own resume token.
+func newSharder(ctx context.Context, kubeconfig string, shardingConfig *config.Sharding) (*sharding.Sharder, error) {
+	podName, namespace := os.Getenv("POD_NAME"), os.Getenv("POD_NAMESPACE")
+	if podName == "" || namespace == "" {
+		return nil, fmt.Errorf("sharding requires POD_NAME and POD_NAMESPACE to be set")
+	}
+
+	clientset, err := newClientset(kubeconfig)
+	if err != nil {
+		return nil, err
+	}
+
+	sharder := sharding.NewSharder(podName,
+		sharding.NewPodMembers(clientset, namespace, shardingConfig.PodSelector), shardingConfig)
+
+	if err := sharder.Refresh(ctx); err != nil {
+		return nil, err
+	}
+
+	slog.Info("Sharding enabled", "shard", podName, "members", sharder.Members(),
+		"podSelector", shardingConfig.PodSelector, "refreshInterval", shardingConfig.RefreshInterval)
+
+	return sharder, nil
+}
+
+func newVersionSkewChecker(ctx context.Context, mongoConfig storewatcher.MongoDBConfig,
+	versionSkew *config.VersionSkew) (*versionskew.Checker, error) {
+	heartbeatCollection := os.Getenv("MONGODB_HEARTBEAT_COLLECTION_NAME")
+	if heartbeatCollection == "" {
+		return nil, fmt.Errorf("version_skew requires MONGODB_HEARTBEAT_COLLECTION_NAME to be set")
+	}
+
+	heartbeatConfig := mongoConfig
+	heartbeatConfig.Collection = heartbeatCollection
+
+	collection, err := storewatcher.GetCollectionClient(ctx, heartbeatConfig)
+	if err != nil {
+		return nil, fmt.Errorf("failed to initialize heartbeat collection client: %w", err)
+	}
+
+	slog.Info("Version skew detection enabled",
+		"minAgentVersions", versionSkew.MinAgentVersions,
+		"minRulePackVersions", versionSkew.MinRulePackVersions,
+		"staleAfter", versionSkew.StaleAfter)
+
+	return versionskew.NewChecker(collection, versionSkew), nil
+}
+
+func newReportGenerator(kubeconfig string, collection reports.Aggregator,
+	report *config.ReliabilityReport) (*reports.Generator, error) {
+	clientset, err := newClientset(kubeconfig)
+	if err != nil {
+		return nil, err
+	}
+
+	slog.Info("Reliability reports enabled",
+		"interval", report.Interval,
+		"skuKey", report.SKUKey,
+		"nodePoolLabel", report.NodePoolLabel,
+		"uploadURL", report.UploadURL,
+		"mail", report.Mail != nil)
+
+	builder := reports.NewBuilder(collection, clientset.CoreV1().Nodes(), report.SKUKey, report.NodePoolLabel)
+
+	return reports.NewGenerator(builder, report), nil
+}
+
+// startRulePackDistribution serves the rule pack bundles on the configured port.
+func startRulePackDistribution(ctx context.Context, g *errgroup.Group,
+	distribution *config.RulePackDistribution) error {
+	distributor, err := rulepacks.NewDistributor(distribution)
+	if err != nil {
+		return err
+	}
+
+	listener, err := net.Listen("tcp", fmt.Sprintf(":%d", distribution.Port))
+	if err != nil {
+		return fmt.Errorf("failed to listen on rule pack distribution port %d: %w", distribution.Port, err)
+	}
+
+	grpcServer := grpc.NewServer()
+	protos.RegisterRulePackDistributorServer(grpcServer, distributor)
+
+	g.Go(func() error {
+		slog.Info("Starting rule pack distribution server", "port", distribution.Port,
+			"bundles", distribution.BundlesPath)
+
+		if err := grpcServer.Serve(listener); err != nil {
+			return fmt.Errorf("rule pack distribution server failed: %w", err)
+		}
+
+		return nil
+	})
+
+	g.Go(func() error {
+		<-ctx.Done()
+		grpcServer.GracefulStop()
+
+		return nil
+	})
+
+	g.Go(func() error {
+		return distributor.Run(ctx)
+	})
+
+	return nil
+}
@@ -0,0 +1,314 @@
+// Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package affected serves the nodes and GPUs affected by an error code, an entity
+// or a driver version over the last days, e.g. to decide which nodes a driver
+// rollback targets.
+package affected
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"slices"
+	"sort"
+	"strconv"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+const (
+	// PathPrefix is where the handler is served
+	PathPrefix = "/affected"
+
+	defaultDays  = 7
+	maxDays      = 90
+	queryTimeout = 30 * time.Second
+
+	timestamp = "healthevent.generatedtimestamp.seconds"
+)
+
+// gpuEntityTypes are the entity types identifying a GPU
+var gpuEntityTypes = []string{"GPU", "GPU_UUID"}
+
+// Aggregator runs aggregation pipelines on the health events collection.
+type Aggregator interface {
+	Aggregate(ctx context.Context, pipeline interface{}, opts ...*options.AggregateOptions) (*mongo.Cursor, error)
+}
+
+// Filter selects the unhealthy events of the last Days. Empty fields match any event.
+type Filter struct {
+	ErrorCode   string
+	EntityValue string
+	// DriverVersion requires a driver version metadata key
+	DriverVersion string
+	Days          int
+}
+
+// GPU is a GPU of an affected node.
+type GPU struct {
+	Type  string `json:"type"`
+	Value string `json:"value"`
+}
+
+// Node is a node with matching events.
+type Node struct {
+	NodeName string `json:"nodeName"`
+	// DriverVersion is the driver version of the most recent matching event
+	DriverVersion string    `json:"driverVersion,omitempty"`
+	Events        int       `json:"events"`
+	ErrorCodes    []string  `json:"errorCodes"`
+	GPUs          []GPU     `json:"gpus,omitempty"`
+	FirstSeen     time.Time `json:"firstSeen"`
+	LastSeen      time.Time `json:"lastSeen"`
+}
+
+// Response is the body served by the handler.
+type Response struct {
+	ErrorCode     string    `json:"errorCode,omitempty"`
+	EntityValue   string    `json:"entityValue,omitempty"`
+	DriverVersion string    `json:"driverVersion,omitempty"`
+	Since         time.Time `json:"since"`
+	// Nodes are sorted by number of events, most affected first
+	Nodes []Node `json:"nodes"`
+	// GPUs is the number of distinct GPUs of the nodes
+	GPUs int `json:"gpus"`
+}
+
+// Handler serves the nodes affected by an error code, entity or driver version.
+type Handler struct {
+	collection       Aggregator
+	driverVersionKey string
+	now              func() time.Time
+}
+
+// NewHandler creates a Handler. driverVersionKey is the event metadata key holding
+// the driver version, empty when it is not known.
+func NewHandler(collection Aggregator, driverVersionKey string) *Handler {
+	return &Handler{collection: collection, driverVersionKey: driverVersionKey, now: time.Now}
+}
+
+// ServeHTTP serves GET /affected?errorCode=&entity=&driverVersion=&days=. At least
+// one of errorCode, entity and driverVersion is required, days defaults to 7 and is
+// at most 90.
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+
+	params := r.URL.Query()
+
+	filter := Filter{
+		ErrorCode:     params.Get("errorCode"),
+		EntityValue:   params.Get("entity"),
+		DriverVersion: params.Get("driverVersion"),
+		Days:          defaultDays,
+	}
+
+	if filter.ErrorCode == "" && filter.EntityValue == "" && filter.DriverVersion == "" {
+		http.Error(w, "one of errorCode, entity and driverVersion is required", http.StatusBadRequest)
+		return
+	}
+
+	if filter.DriverVersion != "" && h.driverVersionKey == "" {
+		http.Error(w, "driver versions are not recorded, configure affected_nodes.driver_version_key",
+			http.StatusBadRequest)
+
+		return
+	}
+
+	if value := params.Get("days"); value != "" {
+		days, err := strconv.Atoi(value)
+		if err != nil || days < 1 || days > maxDays {
+			http.Error(w, fmt.Sprintf("days must be between 1 and %d", maxDays), http.StatusBadRequest)
+			return
+		}
+
+		filter.Days = days
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), queryTimeout)
+	defer cancel()
+
+	response, err := h.Affected(ctx, filter)
+	if err != nil {
+		slog.Error("Failed to query affected nodes", "filter", filter, "error", err)
+		http.Error(w, "failed to query affected nodes", http.StatusInternalServerError)
+
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+
+	if err := json.NewEncoder(w).Encode(response); err != nil {
+		slog.Error("Failed to encode affected nodes", "error", err)
+	}
+}
+
+// Affected returns the nodes with unhealthy events matching the filter.
+func (h *Handler) Affected(ctx context.Context, filter Filter) (*Response, error) {
+	since := h.now().UTC().AddDate(0, 0, -filter.Days).Truncate(time.Second)
+
+	cursor, err := h.collection.Aggregate(ctx, h.pipeline(filter, since))
+	if err != nil {
+		return nil, fmt.Errorf("failed to query events: %w", err)
+	}
+
+	defer cursor.Close(ctx)
+
+	var documents []struct {
+		NodeName      string     `bson:"_id"`
+		Events        int        `bson:"events"`
+		FirstSeen     int64      `bson:"firstseen"`
+		LastSeen      int64      `bson:"lastseen"`
+		ErrorCodes    [][]string `bson:"errorcodes"`
+		Entities      [][]bson.M `bson:"entities"`
+		DriverVersion *string    `bson:"driverversion"`
+	}
+
+	if err := cursor.All(ctx, &documents); err != nil {
+		return nil, fmt.Errorf("failed to decode affected nodes: %w", err)
+	}
+
+	response := &Response{
+		ErrorCode:     filter.ErrorCode,
+		EntityValue:   filter.EntityValue,
+		DriverVersion: filter.DriverVersion,
+		Since:         since,
+		Nodes:         make([]Node, 0, len(documents)),
+	}
+
+	for _, document := range documents {
+		node := Node{
+			NodeName:   document.NodeName,
+			Events:     document.Events,
+			ErrorCodes: flatten(document.ErrorCodes),
+			FirstSeen:  time.Unix(document.FirstSeen, 0).UTC(),
+			LastSeen:   time.Unix(document.LastSeen, 0).UTC(),
+		}
+
+		if document.DriverVersion != nil {
+			node.DriverVersion = *document.DriverVersion
+		}
+
+		node.GPUs = gpus(document.Entities)
+		response.GPUs += len(node.GPUs)
+
+		response.Nodes = append(response.Nodes, node)
+	}
+
+	sort.Slice(response.Nodes, func(i, j int) bool {
+		a, b := response.Nodes[i], response.Nodes[j]
+		if a.Events != b.Events {
+			return a.Events > b.Events
+		}
+
+		return a.NodeName < b.NodeName
+	})
+
+	return response, nil
+}
+
+// pipeline groups the matching unhealthy events by node. The error code and entity
+// value filters are served by the reverse indexes of the events collection.
+func (h *Handler) pipeline(filter Filter, since time.Time) []bson.M {
+	match := bson.M{
+		timestamp:               bson.M{"$gte": since.Unix()},
+		"healthevent.ishealthy": false,
+	}
+
+	if filter.ErrorCode != "" {
+		match["healthevent.errorcode"] = filter.ErrorCode
+	}
+
+	if filter.EntityValue != "" {
+		match["healthevent.entitiesimpacted.entityvalue"] = filter.EntityValue
+	}
+
+	if filter.DriverVersion != "" {
+		match["healthevent.metadata."+h.driverVersionKey] = filter.DriverVersion
+	}
+
+	group := bson.M{
+		"_id":        "$healthevent.nodename",
+		"events":     bson.M{"$sum": 1},
+		"firstseen":  bson.M{"$min": "$" + timestamp},
+		"lastseen":   bson.M{"$max": "$" + timestamp},
+		"errorcodes": bson.M{"$addToSet": "$healthevent.errorcode"},
+		"entities": bson.M{"$addToSet": bson.M{"$filter": bson.M{
+			"input": "$healthevent.entitiesimpacted",
+			"cond":  bson.M{"$in": bson.A{"$$this.entitytype", gpuEntityTypes}},
+		}}},
+	}
+
+	if h.driverVersionKey != "" {
+		group["driverversion"] = bson.M{"$last": "$healthevent.metadata." + h.driverVersionKey}
+	}
+
+	return []bson.M{
+		{"$match": match},
+		{"$sort": bson.D{{Key: timestamp, Value: 1}}},
+		{"$group": group},
+	}
+}
+
+// flatten returns the distinct values of the sets, sorted.
+func flatten(sets [][]string) []string {
+	values := []string{}
+
+	for _, set := range sets {
+		for _, value := range set {
+			if !slices.Contains(values, value) {
+				values = append(values, value)
+			}
+		}
+	}
+
+	sort.Strings(values)
+
+	return values
+}
+
+// gpus returns the distinct GPU entities, sorted.
+func gpus(sets [][]bson.M) []GPU {
+	var result []GPU
+
+	for _, set := range sets {
+		for _, entity := range set {
+			gpu := GPU{}
+			gpu.Type, _ = entity["entitytype"].(string)
+			gpu.Value, _ = entity["entityvalue"].(string)
+
+			if !slices.Contains(result, gpu) {
+				result = append(result, gpu)
+			}
+		}
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Type != result[j].Type {
+			return result[i].Type < result[j].Type
+		}
+
+		return result[i].Value < result[j].Value
+	})
+
+	return result
+}
@@ -0,0 +1,131 @@
+// Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package affected
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type fakeCollection struct {
+	documents []interface{}
+	pipeline  []bson.M
+}
+
+func (f *fakeCollection) Aggregate(_ context.Context, pipeline interface{},
+	_ ...*options.AggregateOptions) (*mongo.Cursor, error) {
+	f.pipeline = pipeline.([]bson.M)
+
+	documents := make([]interface{}, 0, len(f.documents))
+
+	for _, document := range f.documents {
+		data, err := bson.Marshal(document)
+		if err != nil {
+			return nil, err
+		}
+
+		documents = append(documents, bson.Raw(data))
+	}
+
+	return mongo.NewCursorFromDocuments(documents, nil, nil)
+}
+
+var testNow = time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+
+func gpu(value string) bson.M {
+	return bson.M{"entitytype": "GPU", "entityvalue": value}
+}
+
+func TestServeHTTP(t *testing.T) {
+	collection := &fakeCollection{documents: []interface{}{
+		bson.M{
+			"_id": "h100-1", "events": 1, "firstseen": testNow.Add(-time.Hour).Unix(),
+			"lastseen": testNow.Add(-time.Hour).Unix(), "errorcodes": bson.A{bson.A{"79"}},
+			"entities": bson.A{bson.A{gpu("0")}}, "driverversion": "550.54.15",
+		},
+		bson.M{
+			"_id": "h100-2", "events": 3, "firstseen": testNow.Add(-48 * time.Hour).Unix(),
+			"lastseen": testNow.Add(-time.Hour).Unix(), "errorcodes": bson.A{bson.A{"79"}, bson.A{"79", "48"}},
+			"entities": bson.A{bson.A{gpu("1")}, bson.A{gpu("0"), gpu("1")}}, "driverversion": "550.54.15",
+		},
+	}}
+	handler := NewHandler(collection, "nvidia.com/cuda.driver-version.full")
+	handler.now = func() time.Time { return testNow }
+
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet,
+		"/affected?errorCode=79&driverVersion=550.54.15&days=3", nil))
+
+	require.Equal(t, http.StatusOK, recorder.Code)
+
+	var response Response
+	require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &response))
+
+	assert.Equal(t, "79", response.ErrorCode)
+	assert.Equal(t, testNow.AddDate(0, 0, -3), response.Since)
+	require.Len(t, response.Nodes, 2)
+	assert.Equal(t, "h100-2", response.Nodes[0].NodeName)
+	assert.Equal(t, 3, response.Nodes[0].Events)
+	assert.Equal(t, []string{"48", "79"}, response.Nodes[0].ErrorCodes)
+	assert.Equal(t, []GPU{{Type: "GPU", Value: "0"}, {Type: "GPU", Value: "1"}}, response.Nodes[0].GPUs)
+	assert.Equal(t, testNow.Add(-48*time.Hour), response.Nodes[0].FirstSeen)
+	assert.Equal(t, "550.54.15", response.Nodes[1].DriverVersion)
+	assert.Equal(t, 3, response.GPUs)
+
+	match := collection.pipeline[0]["$match"].(bson.M)
+	assert.Equal(t, "79", match["healthevent.errorcode"])
+	assert.Equal(t, "550.54.15", match["healthevent.metadata.nvidia.com/cuda.driver-version.full"])
+	assert.Equal(t, false, match["healthevent.ishealthy"])
+	assert.Equal(t, bson.M{"$gte": testNow.AddDate(0, 0, -3).Unix()}, match[timestamp])
+}
+
+func TestServeHTTPRejectsInvalidRequests(t *testing.T) {
+	tests := map[string]struct {
+		url    string
+		method string
+		code   int
+	}{
+		"no filter":           {url: "/affected?days=3", code: http.StatusBadRequest},
+		"unknown driver":      {url: "/affected?driverVersion=550.54.15", code: http.StatusBadRequest},
+		"invalid days":        {url: "/affected?errorCode=79&days=x", code: http.StatusBadRequest},
+		"too many days":       {url: "/affected?errorCode=79&days=91", code: http.StatusBadRequest},
+		"method not allowed":  {url: "/affected?errorCode=79", method: http.MethodPost, code: http.StatusMethodNotAllowed},
+		"entity only allowed": {url: "/affected?entity=GPU-1234", code: http.StatusOK},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			method := test.method
+			if method == "" {
+				method = http.MethodGet
+			}
+
+			recorder := httptest.NewRecorder()
+			NewHandler(&fakeCollection{}, "").ServeHTTP(recorder, httptest.NewRequest(method, test.url, nil))
+
+			assert.Equal(t, test.code, recorder.Code)
+		})
+	}
+}
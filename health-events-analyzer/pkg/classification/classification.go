@@ -0,0 +1,112 @@
+// Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package classification tags the incidents the analyzer publishes with their
+// probable cause: hardware, driver, thermal, network or unknown. Classifiers are
+// pluggable, so external models can be attached without changes to the analyzer.
+package classification
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/nvidia/nvsentinel/data-models/pkg/model"
+	protos "github.com/nvidia/nvsentinel/data-models/pkg/protos"
+	"github.com/nvidia/nvsentinel/health-events-analyzer/pkg/config"
+)
+
+// SourceUnknown is the source of the unknown tag of incidents no classifier had an
+// opinion on.
+const SourceUnknown = "unknown"
+
+// Result is the classification of an incident.
+type Result struct {
+	Tags   []model.IncidentTag
+	Reason string
+}
+
+// Classifier classifies the incident the analyzer publishes for the rule that
+// matched the event. It returns false when it has no opinion, the next classifier
+// of the chain is asked then.
+type Classifier interface {
+	// Name identifies the classifier as the source of the tags.
+	Name() string
+	Classify(ctx context.Context, event *protos.HealthEvent, ruleName string) (Result, bool, error)
+}
+
+// Chain asks its classifiers in order.
+type Chain struct {
+	classifiers []Classifier
+}
+
+// NewChain creates a Chain of the classifiers.
+func NewChain(classifiers ...Classifier) *Chain {
+	return &Chain{classifiers: classifiers}
+}
+
+// Classify returns the result of the first classifier with an opinion and the name
+// of the classifier, the unknown tag otherwise. Classifier errors are logged and
+// the next classifier is asked.
+func (c *Chain) Classify(ctx context.Context, event *protos.HealthEvent, ruleName string) (Result, string) {
+	for _, classifier := range c.classifiers {
+		result, ok, err := classifier.Classify(ctx, event, ruleName)
+		if err != nil {
+			slog.Warn("Incident classifier failed",
+				"classifier", classifier.Name(),
+				"rule_name", ruleName,
+				"node", event.NodeName,
+				"error", err)
+			classifierErrorsTotal.WithLabelValues(classifier.Name()).Inc()
+
+			continue
+		}
+
+		if ok && len(result.Tags) > 0 {
+			return result, classifier.Name()
+		}
+	}
+
+	return Result{Tags: []model.IncidentTag{model.IncidentTagUnknown}}, SourceUnknown
+}
+
+// RuleClassifier classifies incidents with the configured classification rules.
+type RuleClassifier struct {
+	rules []config.ClassificationRule
+}
+
+// NewRuleClassifier creates a RuleClassifier for the classification rules.
+func NewRuleClassifier(rules []config.ClassificationRule) *RuleClassifier {
+	return &RuleClassifier{rules: rules}
+}
+
+// Name implements Classifier.
+func (c *RuleClassifier) Name() string {
+	return "classification_rules"
+}
+
+// Classify implements Classifier with the first matching classification rule.
+func (c *RuleClassifier) Classify(_ context.Context, event *protos.HealthEvent, _ string) (Result, bool, error) {
+	for _, rule := range c.rules {
+		if !rule.Matches(event) {
+			continue
+		}
+
+		// Validate guarantees valid tags
+		tags, _ := model.ParseIncidentTags(rule.Tags)
+
+		return Result{Tags: tags, Reason: "classification rule " + rule.Name}, true, nil
+	}
+
+	return Result{}, false, nil
+}
@@ -0,0 +1,97 @@
+// Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package classification
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/nvidia/nvsentinel/data-models/pkg/model"
+	protos "github.com/nvidia/nvsentinel/data-models/pkg/protos"
+	"github.com/nvidia/nvsentinel/health-events-analyzer/pkg/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type failingClassifier struct{}
+
+func (failingClassifier) Name() string {
+	return "failing"
+}
+
+func (failingClassifier) Classify(context.Context, *protos.HealthEvent, string) (Result, bool, error) {
+	return Result{}, false, errors.New("model unavailable")
+}
+
+var thermalEvent = &protos.HealthEvent{
+	Agent:     "gpu-health-monitor",
+	CheckName: "GpuThermalWatch",
+	ErrorCode: []string{"DCGM_FR_CLOCK_THROTTLE_THERMAL"},
+	NodeName:  "node-1",
+}
+
+func TestChain(t *testing.T) {
+	rules := NewRuleClassifier([]config.ClassificationRule{
+		{Name: "nvlink", CheckName: "GpuNvLinkWatch", Tags: []string{"network"}},
+		{Name: "thermal", CheckName: "GpuThermalWatch", Tags: []string{"thermal"}},
+	})
+
+	chain := NewChain(failingClassifier{}, rules)
+
+	result, source := chain.Classify(context.Background(), thermalEvent, "RepeatedThrottling")
+	assert.Equal(t, []model.IncidentTag{model.IncidentTagThermal}, result.Tags)
+	assert.Equal(t, "classification_rules", source)
+	assert.Equal(t, "classification rule thermal", result.Reason)
+
+	result, source = chain.Classify(context.Background(), &protos.HealthEvent{CheckName: "Other"}, "Rule")
+	assert.Equal(t, []model.IncidentTag{model.IncidentTagUnknown}, result.Tags)
+	assert.Equal(t, SourceUnknown, source)
+}
+
+func TestExternalClassifier(t *testing.T) {
+	var received ExternalRequest
+
+	response := `{"tags": ["driver"], "reason": "similar to 12 driver incidents"}`
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&received))
+		_, _ = w.Write([]byte(response))
+	}))
+	defer server.Close()
+
+	classifier := NewExternalClassifier(server.URL, time.Second)
+
+	result, ok, err := classifier.Classify(context.Background(), thermalEvent, "RepeatedThrottling")
+	require.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, []model.IncidentTag{model.IncidentTagDriver}, result.Tags)
+	assert.Equal(t, "similar to 12 driver incidents", result.Reason)
+	assert.Equal(t, "RepeatedThrottling", received.Rule)
+	assert.Contains(t, string(received.Event), `"checkName":"GpuThermalWatch"`)
+
+	response = `{"tags": []}`
+	_, ok, err = classifier.Classify(context.Background(), thermalEvent, "RepeatedThrottling")
+	require.NoError(t, err)
+	assert.False(t, ok, "no tags is no opinion")
+
+	response = `{"tags": ["cosmic-rays"]}`
+	_, _, err = classifier.Classify(context.Background(), thermalEvent, "RepeatedThrottling")
+	assert.ErrorContains(t, err, "invalid incident tag")
+}
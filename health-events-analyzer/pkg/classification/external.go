@@ -0,0 +1,119 @@
+// Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package classification
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/nvidia/nvsentinel/data-models/pkg/model"
+	protos "github.com/nvidia/nvsentinel/data-models/pkg/protos"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+const maxExternalResponseBytes = 64 * 1024
+
+// ExternalRequest is posted to the external classifier.
+type ExternalRequest struct {
+	// Event is the event that matched the rule, in the protobuf JSON encoding
+	Event json.RawMessage `json:"event"`
+	Rule  string          `json:"rule"`
+}
+
+// ExternalResponse is returned by the external classifier. No tags means no
+// opinion.
+type ExternalResponse struct {
+	Tags   []string `json:"tags"`
+	Reason string   `json:"reason"`
+}
+
+// ExternalClassifier asks an external service, e.g. an ML model, to classify
+// incidents over HTTP.
+type ExternalClassifier struct {
+	url     string
+	timeout time.Duration
+	client  *http.Client
+}
+
+// NewExternalClassifier creates an ExternalClassifier posting to url.
+func NewExternalClassifier(url string, timeout time.Duration) *ExternalClassifier {
+	return &ExternalClassifier{url: url, timeout: timeout, client: http.DefaultClient}
+}
+
+// Name implements Classifier.
+func (c *ExternalClassifier) Name() string {
+	return "external"
+}
+
+// Classify implements Classifier by posting the event to the external service.
+func (c *ExternalClassifier) Classify(ctx context.Context, event *protos.HealthEvent,
+	ruleName string) (Result, bool, error) {
+	encoded, err := protojson.Marshal(event)
+	if err != nil {
+		return Result{}, false, fmt.Errorf("failed to encode event: %w", err)
+	}
+
+	payload, err := json.Marshal(ExternalRequest{Event: encoded, Rule: ruleName})
+	if err != nil {
+		return Result{}, false, fmt.Errorf("failed to encode request: %w", err)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, c.url, bytes.NewReader(payload))
+	if err != nil {
+		return Result{}, false, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	request.Header.Set("Content-Type", "application/json")
+
+	response, err := c.client.Do(request)
+	if err != nil {
+		return Result{}, false, fmt.Errorf("failed to reach classifier: %w", err)
+	}
+
+	defer response.Body.Close()
+
+	body, err := io.ReadAll(io.LimitReader(response.Body, maxExternalResponseBytes))
+	if err != nil {
+		return Result{}, false, fmt.Errorf("failed to read response: %w", err)
+	}
+
+	if response.StatusCode != http.StatusOK {
+		return Result{}, false, fmt.Errorf("classifier returned %s", response.Status)
+	}
+
+	var classified ExternalResponse
+	if err := json.Unmarshal(body, &classified); err != nil {
+		return Result{}, false, fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	if len(classified.Tags) == 0 {
+		return Result{}, false, nil
+	}
+
+	tags, err := model.ParseIncidentTags(classified.Tags)
+	if err != nil {
+		return Result{}, false, err
+	}
+
+	return Result{Tags: tags, Reason: classified.Reason}, true, nil
+}
@@ -0,0 +1,23 @@
+// Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+// AffectedNodes configures the affected nodes API.
+type AffectedNodes struct {
+	// DriverVersionKey is the event metadata key holding the driver version of the
+	// node when the event was generated, e.g. the GPU feature discovery label
+	// "nvidia.com/cuda.driver-version.full" copied by the platform connectors
+	DriverVersionKey string `toml:"driver_version_key"`
+}
@@ -0,0 +1,231 @@
+// Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	protos "github.com/nvidia/nvsentinel/data-models/pkg/protos"
+)
+
+const (
+	BaselineScopeNode = "node"
+	BaselineScopeSKU  = "sku"
+
+	defaultBaselineSensitivity = 3.0
+	defaultBaselineMinCount    = 5
+)
+
+// BaselineRule learns the normal rate of an event, e.g. benign messages or
+// correctable errors, over a training window and triggers when the rate in the
+// detection window deviates significantly from it. This replaces hand tuned
+// thresholds that are too tight for some nodes and too loose for others.
+//
+// The baseline is learned per node, or per SKU across all nodes reporting the same
+// SKU in the event metadata. Event counts are assumed to be Poisson distributed: the
+// rule triggers when the count in the detection window exceeds the expected count
+// by Sensitivity standard deviations.
+type BaselineRule struct {
+	Name              string `toml:"name"`
+	Description       string `toml:"description"`
+	RecommendedAction string `toml:"recommended_action"`
+	// Tags classify the incidents of the rule, the classifiers do otherwise.
+	Tags []string `toml:"tags"`
+	// CheckName restricts the rule to events of this check. Empty matches any check.
+	CheckName string `toml:"check_name"`
+	// ErrorCodes restricts the rule to events with one of these error codes.
+	ErrorCodes []string `toml:"error_codes"`
+	// Scope is "node" (default) or "sku".
+	Scope string `toml:"scope"`
+	// SKUKey is the event metadata key holding the SKU, required for the sku scope.
+	SKUKey string `toml:"sku_key"`
+	// TrainingWindow is the history the baseline is learned from, e.g. "168h".
+	TrainingWindow string `toml:"training_window"`
+	// DetectionWindow is the recent window compared against the baseline, e.g. "1h".
+	DetectionWindow string `toml:"detection_window"`
+	// Sensitivity is the number of standard deviations above the expected count
+	// that triggers the rule, defaults to 3.
+	Sensitivity float64 `toml:"sensitivity"`
+	// MinCount is the minimum count in the detection window to trigger, defaults to 5.
+	MinCount int `toml:"min_count"`
+}
+
+// Validate checks the rule configuration.
+func (r BaselineRule) Validate() error {
+	if r.Name == "" {
+		return fmt.Errorf("baseline rule: name is required")
+	}
+
+	switch r.Scope {
+	case "", BaselineScopeNode:
+	case BaselineScopeSKU:
+		if r.SKUKey == "" || strings.ContainsAny(r.SKUKey, ".$") {
+			return fmt.Errorf("baseline rule %s: invalid sku_key %q", r.Name, r.SKUKey)
+		}
+	default:
+		return fmt.Errorf("baseline rule %s: invalid scope %q", r.Name, r.Scope)
+	}
+
+	training, err := time.ParseDuration(r.TrainingWindow)
+	if err != nil {
+		return fmt.Errorf("baseline rule %s: invalid training_window %q", r.Name, r.TrainingWindow)
+	}
+
+	detection, err := time.ParseDuration(r.DetectionWindow)
+	if err != nil || detection < time.Second {
+		return fmt.Errorf("baseline rule %s: invalid detection_window %q", r.Name, r.DetectionWindow)
+	}
+
+	if training < 2*detection {
+		return fmt.Errorf("baseline rule %s: training_window must be at least twice the detection_window", r.Name)
+	}
+
+	if r.Sensitivity < 0 || r.MinCount < 0 {
+		return fmt.Errorf("baseline rule %s: sensitivity and min_count must not be negative", r.Name)
+	}
+
+	return nil
+}
+
+// RuleFor returns the aggregation pipeline rule comparing the recent rate of events
+// like event against the learned baseline. It returns false when the rule does not
+// apply to the event.
+func (r BaselineRule) RuleFor(event *protos.HealthEvent) (HealthEventsAnalyzerRule, bool, error) {
+	if event == nil || event.IsHealthy {
+		return HealthEventsAnalyzerRule{}, false, nil
+	}
+
+	if r.CheckName != "" && event.CheckName != r.CheckName {
+		return HealthEventsAnalyzerRule{}, false, nil
+	}
+
+	if len(r.ErrorCodes) > 0 && !slices.ContainsFunc(event.ErrorCode, func(code string) bool {
+		return slices.Contains(r.ErrorCodes, code)
+	}) {
+		return HealthEventsAnalyzerRule{}, false, nil
+	}
+
+	if r.Scope == BaselineScopeSKU {
+		if _, ok := event.Metadata[r.SKUKey]; !ok {
+			return HealthEventsAnalyzerRule{}, false, nil
+		}
+	}
+
+	stages, err := r.stages()
+	if err != nil {
+		return HealthEventsAnalyzerRule{}, false, fmt.Errorf("baseline rule %s: %w", r.Name, err)
+	}
+
+	return HealthEventsAnalyzerRule{
+		Name:              r.Name,
+		Description:       r.Description,
+		RecommendedAction: r.RecommendedAction,
+		Stage:             stages,
+		Tags:              r.Tags,
+	}, true, nil
+}
+
+func (r BaselineRule) stages() ([]string, error) {
+	// Validate guarantees parsable windows
+	training, _ := time.ParseDuration(r.TrainingWindow)
+	detection, _ := time.ParseDuration(r.DetectionWindow)
+
+	sensitivity := r.Sensitivity
+	if sensitivity == 0 {
+		sensitivity = defaultBaselineSensitivity
+	}
+
+	minCount := r.MinCount
+	if minCount == 0 {
+		minCount = defaultBaselineMinCount
+	}
+
+	now := map[string]any{"$divide": []any{map[string]any{"$toLong": "$$NOW"}, 1000}}
+	timestamp := "$healthevent.generatedtimestamp.seconds"
+	inDetectionWindow := map[string]any{"$gte": []any{
+		timestamp, map[string]any{"$subtract": []any{now, int64(detection.Seconds())}},
+	}}
+	isThisNode := map[string]any{"$eq": []any{"$healthevent.nodename", "this.healthevent.nodename"}}
+
+	match := map[string]any{
+		"healthevent.ishealthy": false,
+		"$expr": map[string]any{"$gte": []any{
+			timestamp, map[string]any{"$subtract": []any{now, int64(training.Seconds())}},
+		}},
+	}
+
+	if r.CheckName != "" {
+		match["healthevent.checkname"] = r.CheckName
+	}
+
+	if len(r.ErrorCodes) > 0 {
+		match["healthevent.errorcode"] = map[string]any{"$in": r.ErrorCodes}
+	}
+
+	if r.Scope == BaselineScopeSKU {
+		match["healthevent.metadata."+r.SKUKey] = "this.healthevent.metadata." + r.SKUKey
+	} else {
+		match["healthevent.nodename"] = "this.healthevent.nodename"
+	}
+
+	// The expected count in the detection window is the training rate scaled to the
+	// detection window. For the sku scope the rate is averaged over the nodes that
+	// reported the event, which overestimates the per-node rate and errs towards
+	// fewer alerts.
+	windowRatio := detection.Seconds() / (training - detection).Seconds()
+
+	stages := []map[string]any{
+		{"$match": match},
+		{"$group": map[string]any{
+			"_id": nil,
+			"recent": map[string]any{"$sum": map[string]any{"$cond": []any{
+				map[string]any{"$and": []any{inDetectionWindow, isThisNode}}, 1, 0,
+			}}},
+			"training": map[string]any{"$sum": map[string]any{"$cond": []any{inDetectionWindow, 0, 1}}},
+			"nodes":    map[string]any{"$addToSet": "$healthevent.nodename"},
+		}},
+		{"$addFields": map[string]any{"expected": map[string]any{"$divide": []any{
+			map[string]any{"$multiply": []any{"$training", windowRatio}},
+			map[string]any{"$max": []any{map[string]any{"$size": "$nodes"}, 1}},
+		}}}},
+		{"$match": map[string]any{"$expr": map[string]any{"$and": []any{
+			map[string]any{"$gte": []any{"$recent", minCount}},
+			map[string]any{"$gt": []any{"$recent", map[string]any{"$add": []any{
+				"$expected",
+				map[string]any{"$multiply": []any{
+					sensitivity,
+					map[string]any{"$sqrt": map[string]any{"$max": []any{"$expected", 1}}},
+				}},
+			}}}},
+		}}}},
+	}
+
+	result := make([]string, 0, len(stages))
+
+	for _, stage := range stages {
+		b, err := json.Marshal(stage)
+		if err != nil {
+			return nil, err
+		}
+
+		result = append(result, string(b))
+	}
+
+	return result, nil
+}
@@ -0,0 +1,180 @@
+// Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	datamodels "github.com/nvidia/nvsentinel/data-models/pkg/model"
+	protos "github.com/nvidia/nvsentinel/data-models/pkg/protos"
+	"github.com/nvidia/nvsentinel/health-events-analyzer/pkg/parser"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newBaselineRule() BaselineRule {
+	return BaselineRule{
+		Name:            "UnusualCorrectableErrorRate",
+		CheckName:       "SysLogsSXIDError",
+		TrainingWindow:  "168h",
+		DetectionWindow: "1h",
+	}
+}
+
+func TestBaselineRule_Validate(t *testing.T) {
+	tests := []struct {
+		name   string
+		modify func(r *BaselineRule)
+		valid  bool
+	}{
+		{name: "valid", modify: func(r *BaselineRule) {}, valid: true},
+		{name: "valid sku scope", modify: func(r *BaselineRule) { r.Scope, r.SKUKey = BaselineScopeSKU, "gpu_product" }, valid: true},
+		{name: "missing name", modify: func(r *BaselineRule) { r.Name = "" }},
+		{name: "unknown scope", modify: func(r *BaselineRule) { r.Scope = "rack" }},
+		{name: "sku scope without key", modify: func(r *BaselineRule) { r.Scope = BaselineScopeSKU }},
+		{name: "invalid training window", modify: func(r *BaselineRule) { r.TrainingWindow = "weekly" }},
+		{name: "training window too short", modify: func(r *BaselineRule) { r.TrainingWindow = "90m" }},
+		{name: "negative sensitivity", modify: func(r *BaselineRule) { r.Sensitivity = -1 }},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rule := newBaselineRule()
+			tt.modify(&rule)
+
+			err := rule.Validate()
+			if tt.valid {
+				assert.NoError(t, err)
+			} else {
+				assert.Error(t, err)
+			}
+		})
+	}
+}
+
+func TestBaselineRule_RuleFor(t *testing.T) {
+	event := func(modify func(e *protos.HealthEvent)) *protos.HealthEvent {
+		e := &protos.HealthEvent{
+			CheckName: "SysLogsSXIDError",
+			NodeName:  "node1",
+			ErrorCode: []string{"12028"},
+			Metadata:  map[string]string{"gpu_product": "H100-SXM5-80GB"},
+		}
+		modify(e)
+
+		return e
+	}
+
+	tests := []struct {
+		name    string
+		modify  func(r *BaselineRule)
+		event   *protos.HealthEvent
+		applies bool
+		scope   map[string]interface{}
+	}{
+		{
+			name:    "node scope",
+			event:   event(func(e *protos.HealthEvent) {}),
+			applies: true,
+			scope:   map[string]interface{}{"healthevent.nodename": "node1"},
+		},
+		{
+			name:    "sku scope",
+			modify:  func(r *BaselineRule) { r.Scope, r.SKUKey = BaselineScopeSKU, "gpu_product" },
+			event:   event(func(e *protos.HealthEvent) {}),
+			applies: true,
+			scope:   map[string]interface{}{"healthevent.metadata.gpu_product": "H100-SXM5-80GB"},
+		},
+		{
+			name:   "sku scope without sku",
+			modify: func(r *BaselineRule) { r.Scope, r.SKUKey = BaselineScopeSKU, "gpu_product" },
+			event:  event(func(e *protos.HealthEvent) { e.Metadata = nil }),
+		},
+		{
+			name:   "other error code",
+			modify: func(r *BaselineRule) { r.ErrorCodes = []string{"20034"} },
+			event:  event(func(e *protos.HealthEvent) {}),
+		},
+		{name: "other check", event: event(func(e *protos.HealthEvent) { e.CheckName = "SysLogsXIDError" })},
+		{name: "healthy event", event: event(func(e *protos.HealthEvent) { e.IsHealthy = true })},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			baseline := newBaselineRule()
+			if tt.modify != nil {
+				tt.modify(&baseline)
+			}
+
+			rule, applies, err := baseline.RuleFor(tt.event)
+			require.NoError(t, err)
+			assert.Equal(t, tt.applies, applies)
+
+			if !applies {
+				return
+			}
+
+			// every generated stage must be accepted by the sequence stage parser
+			var stages []map[string]interface{}
+
+			for _, stage := range rule.Stage {
+				parsed, err := parser.ParseSequenceStage(stage, datamodels.HealthEventWithStatus{HealthEvent: tt.event})
+				require.NoError(t, err)
+
+				stages = append(stages, parsed)
+			}
+
+			match := stages[0]["$match"].(map[string]interface{})
+			for key, value := range tt.scope {
+				assert.Equal(t, value, match[key])
+			}
+
+			assert.Equal(t, "SysLogsSXIDError", match["healthevent.checkname"])
+		})
+	}
+}
+
+func TestLoadTomlConfig_BaselineRules(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.toml")
+	require.NoError(t, os.WriteFile(path, []byte(`
+[[baseline_rules]]
+name = "UnusualCorrectableErrorRate"
+recommended_action = "CONTACT_SUPPORT"
+check_name = "SysLogsSXIDError"
+scope = "sku"
+sku_key = "gpu_product"
+training_window = "168h"
+detection_window = "1h"
+sensitivity = 4
+`), 0o600))
+
+	cfg, err := LoadTomlConfig(path)
+	require.NoError(t, err)
+	require.Len(t, cfg.BaselineRules, 1)
+	assert.Equal(t, 4.0, cfg.BaselineRules[0].Sensitivity)
+	assert.Len(t, cfg.EventRules(), 1)
+
+	require.NoError(t, os.WriteFile(path, []byte(`
+[[baseline_rules]]
+name = "Broken"
+training_window = "1h"
+detection_window = "1h"
+`), 0o600))
+
+	_, err = LoadTomlConfig(path)
+	assert.Error(t, err)
+}
@@ -0,0 +1,129 @@
+// Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"fmt"
+	"net/url"
+	"slices"
+	"time"
+
+	"github.com/nvidia/nvsentinel/data-models/pkg/model"
+	protos "github.com/nvidia/nvsentinel/data-models/pkg/protos"
+)
+
+const defaultClassifierTimeout = 2 * time.Second
+
+// ClassificationRule tags the incidents the analyzer publishes for events of a
+// check or with one of ErrorCodes, unless the rule that matched sets its own tags.
+// The first matching classification rule wins.
+type ClassificationRule struct {
+	// Name identifies the classification rule as the source of the tags.
+	Name string `toml:"name"`
+	// Agent, CheckName, ComponentClass and ErrorCodes optionally restrict the
+	// classification rule, at least one of them is required.
+	Agent          string   `toml:"agent"`
+	CheckName      string   `toml:"check_name"`
+	ComponentClass string   `toml:"component_class"`
+	ErrorCodes     []string `toml:"error_codes"`
+	// Tags are among hardware, driver, thermal, network and unknown.
+	Tags []string `toml:"tags"`
+}
+
+// Validate checks the classification rule.
+func (r ClassificationRule) Validate() error {
+	if r.Name == "" {
+		return fmt.Errorf("classification_rules: rule without name")
+	}
+
+	if r.Agent == "" && r.CheckName == "" && r.ComponentClass == "" && len(r.ErrorCodes) == 0 {
+		return fmt.Errorf("classification_rules: rule %s matches every event, set agent, check_name, "+
+			"component_class or error_codes", r.Name)
+	}
+
+	if err := validateTags(r.Tags); err != nil {
+		return fmt.Errorf("classification_rules: rule %s: %w", r.Name, err)
+	}
+
+	return nil
+}
+
+// Matches returns true if the classification rule applies to the event.
+func (r ClassificationRule) Matches(event *protos.HealthEvent) bool {
+	if r.Agent != "" && event.Agent != r.Agent {
+		return false
+	}
+
+	if r.CheckName != "" && event.CheckName != r.CheckName {
+		return false
+	}
+
+	if r.ComponentClass != "" && event.ComponentClass != r.ComponentClass {
+		return false
+	}
+
+	if len(r.ErrorCodes) > 0 && !slices.ContainsFunc(event.ErrorCode, func(code string) bool {
+		return slices.Contains(r.ErrorCodes, code)
+	}) {
+		return false
+	}
+
+	return true
+}
+
+// ExternalClassifier asks an external service, e.g. an ML model, to classify the
+// incidents no rule sets tags for, before the classification rules apply.
+type ExternalClassifier struct {
+	// URL the incidents are posted to.
+	URL string `toml:"url"`
+	// Timeout of a classification, defaults to "2s". Incidents are published
+	// without waiting longer.
+	Timeout string `toml:"timeout"`
+}
+
+// Validate checks the configuration and fills in defaults.
+func (c *ExternalClassifier) Validate() error {
+	parsed, err := url.Parse(c.URL)
+	if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+		return fmt.Errorf("classifier: invalid url %q", c.URL)
+	}
+
+	if c.Timeout == "" {
+		c.Timeout = defaultClassifierTimeout.String()
+	}
+
+	if timeout, err := time.ParseDuration(c.Timeout); err != nil || timeout <= 0 {
+		return fmt.Errorf("classifier: invalid timeout %q", c.Timeout)
+	}
+
+	return nil
+}
+
+// TimeoutDuration returns the parsed Timeout.
+func (c *ExternalClassifier) TimeoutDuration() time.Duration {
+	// Validate guarantees a parsable timeout
+	timeout, _ := time.ParseDuration(c.Timeout)
+	return timeout
+}
+
+func validateTags(tags []string) error {
+	if len(tags) == 0 {
+		return fmt.Errorf("no tags")
+	}
+
+	_, err := model.ParseIncidentTags(tags)
+
+	return err
+}
@@ -0,0 +1,80 @@
+// Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"testing"
+
+	protos "github.com/nvidia/nvsentinel/data-models/pkg/protos"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClassificationRule(t *testing.T) {
+	rule := ClassificationRule{Name: "xid-hardware", CheckName: "SysLogsXIDError", ErrorCodes: []string{"48", "79"},
+		Tags: []string{"hardware"}}
+	require.NoError(t, rule.Validate())
+
+	assert.True(t, rule.Matches(&protos.HealthEvent{CheckName: "SysLogsXIDError", ErrorCode: []string{"79"}}))
+	assert.False(t, rule.Matches(&protos.HealthEvent{CheckName: "SysLogsXIDError", ErrorCode: []string{"13"}}))
+	assert.False(t, rule.Matches(&protos.HealthEvent{CheckName: "GpuXidError", ErrorCode: []string{"79"}}))
+
+	assert.Error(t, ClassificationRule{CheckName: "SysLogsXIDError", Tags: []string{"hardware"}}.Validate(),
+		"name is required")
+	assert.Error(t, ClassificationRule{Name: "all", Tags: []string{"hardware"}}.Validate(),
+		"a rule must not match every event")
+	assert.Error(t, ClassificationRule{Name: "none", CheckName: "SysLogsXIDError"}.Validate())
+	assert.Error(t, ClassificationRule{Name: "bad", CheckName: "SysLogsXIDError", Tags: []string{"power"}}.Validate())
+}
+
+func TestExternalClassifier_Validate(t *testing.T) {
+	classifier := ExternalClassifier{URL: "http://classifier.ml.svc:8080/classify"}
+	require.NoError(t, classifier.Validate())
+	assert.Equal(t, defaultClassifierTimeout, classifier.TimeoutDuration())
+
+	assert.Error(t, (&ExternalClassifier{URL: "classifier:8080"}).Validate())
+	assert.Error(t, (&ExternalClassifier{URL: "http://classifier", Timeout: "soon"}).Validate())
+}
+
+func TestLoadTomlConfig_Classification(t *testing.T) {
+	cfg, err := LoadTomlConfigFromBytes([]byte(`
+[[rules]]
+name = "RepeatedXidError"
+recommended_action = "CONTACT_SUPPORT"
+tags = ["hardware"]
+stage = []
+
+[[classification_rules]]
+name = "thermal"
+check_name = "GpuThermalWatch"
+tags = ["thermal"]
+
+[classifier]
+url = "http://classifier:8080/classify"
+timeout = "500ms"
+`))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"hardware"}, cfg.Rules[0].Tags)
+	require.Len(t, cfg.ClassificationRules, 1)
+	require.NotNil(t, cfg.Classifier)
+
+	_, err = LoadTomlConfigFromBytes([]byte(`
+[[rules]]
+name = "RepeatedXidError"
+tags = ["power"]
+stage = []
+`))
+	assert.ErrorContains(t, err, "rule RepeatedXidError")
+}
@@ -0,0 +1,221 @@
+// Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+	"time"
+
+	protos "github.com/nvidia/nvsentinel/data-models/pkg/protos"
+)
+
+// CompositeRule triggers only when at least MinSignals distinct signals report a
+// fault on the node within Window, e.g. an Xid 79 from syslog and a failure of the
+// DCGM PCIe health watch. Sites that prioritize precision over speed have fault
+// quarantine hold the fatal events of the error codes, see the corroborations of
+// its configuration, until the rule publishes its fatal event.
+type CompositeRule struct {
+	Name              string `toml:"name"`
+	Description       string `toml:"description"`
+	RecommendedAction string `toml:"recommended_action"`
+	// Tags classify the incidents of the rule, the classifiers do otherwise.
+	Tags []string `toml:"tags"`
+	// Signals are the sources that may corroborate the fault.
+	Signals []Signal `toml:"signals"`
+	// MinSignals is the number of distinct signals required within Window, defaults
+	// to all of them.
+	MinSignals int `toml:"min_signals"`
+	// Window is the time window the signals must be reported in, e.g. "10m".
+	Window string `toml:"window"`
+}
+
+// Signal selects the unhealthy events of a source corroborating a fault.
+type Signal struct {
+	// Name identifies the signal, it must be unique within the rule.
+	Name string `toml:"name"`
+	// Agent restricts the signal to events of this health monitor. Empty matches
+	// any agent.
+	Agent     string `toml:"agent"`
+	CheckName string `toml:"check_name"`
+	// ErrorCodes restricts the signal to events with one of these error codes, e.g.
+	// ["79"]. Empty matches any error code of the check.
+	ErrorCodes []string `toml:"error_codes"`
+}
+
+// Validate checks the rule configuration.
+func (r CompositeRule) Validate() error {
+	if r.Name == "" {
+		return fmt.Errorf("composite rule: name is required")
+	}
+
+	if len(r.Signals) < 2 {
+		return fmt.Errorf("composite rule %s: at least 2 signals are required", r.Name)
+	}
+
+	names := make(map[string]bool, len(r.Signals))
+
+	for _, signal := range r.Signals {
+		if signal.Name == "" || signal.CheckName == "" {
+			return fmt.Errorf("composite rule %s: signals require a name and a check_name", r.Name)
+		}
+
+		if names[signal.Name] {
+			return fmt.Errorf("composite rule %s: duplicate signal %s", r.Name, signal.Name)
+		}
+
+		names[signal.Name] = true
+	}
+
+	if r.MinSignals != 0 && (r.MinSignals < 2 || r.MinSignals > len(r.Signals)) {
+		return fmt.Errorf("composite rule %s: min_signals must be between 2 and the number of signals", r.Name)
+	}
+
+	if window, err := time.ParseDuration(r.Window); err != nil || window < time.Second {
+		return fmt.Errorf("composite rule %s: invalid window %q", r.Name, r.Window)
+	}
+
+	return nil
+}
+
+// matches reports whether the event is reported by the signal.
+func (s Signal) matches(event *protos.HealthEvent) bool {
+	if s.Agent != "" && event.Agent != s.Agent {
+		return false
+	}
+
+	if event.CheckName != s.CheckName {
+		return false
+	}
+
+	if len(s.ErrorCodes) == 0 {
+		return true
+	}
+
+	for _, errorCode := range event.ErrorCode {
+		if slices.Contains(s.ErrorCodes, errorCode) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// RuleFor returns the aggregation pipeline rule counting the distinct signals on
+// the node of the event. It returns false when no signal reports the event, so the
+// rule is evaluated whenever one of its signals fires, whichever comes first.
+func (r CompositeRule) RuleFor(event *protos.HealthEvent) (HealthEventsAnalyzerRule, bool, error) {
+	if event == nil || event.IsHealthy {
+		return HealthEventsAnalyzerRule{}, false, nil
+	}
+
+	if !slices.ContainsFunc(r.Signals, func(signal Signal) bool { return signal.matches(event) }) {
+		return HealthEventsAnalyzerRule{}, false, nil
+	}
+
+	// Validate guarantees a parsable window
+	window, _ := time.ParseDuration(r.Window)
+
+	minSignals := r.MinSignals
+	if minSignals == 0 {
+		minSignals = len(r.Signals)
+	}
+
+	stages, err := r.stages(int64(window.Seconds()), minSignals)
+	if err != nil {
+		return HealthEventsAnalyzerRule{}, false, fmt.Errorf("composite rule %s: %w", r.Name, err)
+	}
+
+	return HealthEventsAnalyzerRule{
+		Name:              r.Name,
+		Description:       r.Description,
+		RecommendedAction: r.RecommendedAction,
+		Stage:             stages,
+		Tags:              r.Tags,
+	}, true, nil
+}
+
+func (r CompositeRule) stages(windowSeconds int64, minSignals int) ([]string, error) {
+	matches := make([]any, 0, len(r.Signals))
+	branches := make([]any, 0, len(r.Signals))
+
+	for _, signal := range r.Signals {
+		match := map[string]any{"healthevent.checkname": signal.CheckName}
+		conditions := []any{map[string]any{"$eq": []any{"$healthevent.checkname", signal.CheckName}}}
+
+		if signal.Agent != "" {
+			match["healthevent.agent"] = signal.Agent
+			conditions = append(conditions, map[string]any{"$eq": []any{"$healthevent.agent", signal.Agent}})
+		}
+
+		if len(signal.ErrorCodes) > 0 {
+			match["healthevent.errorcode"] = map[string]any{"$in": signal.ErrorCodes}
+			conditions = append(conditions, map[string]any{"$gt": []any{
+				map[string]any{"$size": map[string]any{"$setIntersection": []any{
+					map[string]any{"$ifNull": []any{"$healthevent.errorcode", []any{}}},
+					signal.ErrorCodes,
+				}}},
+				0,
+			}})
+		}
+
+		matches = append(matches, match)
+		branches = append(branches, map[string]any{
+			"case": map[string]any{"$and": conditions},
+			"then": signal.Name,
+		})
+	}
+
+	stages := []map[string]any{
+		{"$match": map[string]any{
+			"healthevent.nodename":  "this.healthevent.nodename",
+			"healthevent.ishealthy": false,
+			"$or":                   matches,
+			"$expr": map[string]any{"$gte": []any{
+				"$healthevent.generatedtimestamp.seconds",
+				map[string]any{"$subtract": []any{
+					map[string]any{"$divide": []any{map[string]any{"$toLong": "$$NOW"}, 1000}},
+					windowSeconds,
+				}},
+			}},
+		}},
+		{"$addFields": map[string]any{"signal": map[string]any{"$switch": map[string]any{
+			"branches": branches,
+			"default":  nil,
+		}}}},
+		{"$group": map[string]any{
+			"_id":     nil,
+			"signals": map[string]any{"$addToSet": "$signal"},
+		}},
+		{"$match": map[string]any{"$expr": map[string]any{"$gte": []any{
+			map[string]any{"$size": map[string]any{"$setDifference": []any{"$signals", []any{nil}}}},
+			minSignals,
+		}}}},
+	}
+
+	result := make([]string, 0, len(stages))
+
+	for _, stage := range stages {
+		b, err := json.Marshal(stage)
+		if err != nil {
+			return nil, err
+		}
+
+		result = append(result, string(b))
+	}
+
+	return result, nil
+}
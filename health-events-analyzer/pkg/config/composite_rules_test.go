@@ -0,0 +1,174 @@
+// Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	datamodels "github.com/nvidia/nvsentinel/data-models/pkg/model"
+	protos "github.com/nvidia/nvsentinel/data-models/pkg/protos"
+	"github.com/nvidia/nvsentinel/health-events-analyzer/pkg/parser"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newCompositeRule() CompositeRule {
+	return CompositeRule{
+		Name:              "CorroboratedGPUFallenOffBus",
+		RecommendedAction: "RESTART_BM",
+		Signals: []Signal{
+			{Name: "xid", Agent: "syslog-health-monitor", CheckName: "SysLogsXIDError", ErrorCodes: []string{"79"}},
+			{Name: "pcie", Agent: "gpu-health-monitor", CheckName: "GpuPcieWatch"},
+		},
+		Window: "10m",
+	}
+}
+
+func TestCompositeRule_Validate(t *testing.T) {
+	tests := []struct {
+		name   string
+		modify func(r *CompositeRule)
+		valid  bool
+	}{
+		{name: "valid", modify: func(r *CompositeRule) {}, valid: true},
+		{name: "explicit min signals", modify: func(r *CompositeRule) { r.MinSignals = 2 }, valid: true},
+		{name: "missing name", modify: func(r *CompositeRule) { r.Name = "" }},
+		{name: "single signal", modify: func(r *CompositeRule) { r.Signals = r.Signals[:1] }},
+		{name: "duplicate signal", modify: func(r *CompositeRule) { r.Signals[1].Name = "xid" }},
+		{name: "signal without check", modify: func(r *CompositeRule) { r.Signals[1].CheckName = "" }},
+		{name: "single required signal", modify: func(r *CompositeRule) { r.MinSignals = 1 }},
+		{name: "more required than configured", modify: func(r *CompositeRule) { r.MinSignals = 3 }},
+		{name: "invalid window", modify: func(r *CompositeRule) { r.Window = "soon" }},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rule := newCompositeRule()
+			tt.modify(&rule)
+
+			err := rule.Validate()
+			if tt.valid {
+				assert.NoError(t, err)
+			} else {
+				assert.Error(t, err)
+			}
+		})
+	}
+}
+
+func TestCompositeRule_RuleFor(t *testing.T) {
+	event := func(modify func(e *protos.HealthEvent)) *protos.HealthEvent {
+		e := &protos.HealthEvent{
+			Agent:     "syslog-health-monitor",
+			CheckName: "SysLogsXIDError",
+			NodeName:  "node1",
+			ErrorCode: []string{"79"},
+		}
+		modify(e)
+
+		return e
+	}
+
+	tests := []struct {
+		name    string
+		event   *protos.HealthEvent
+		applies bool
+	}{
+		{name: "xid signal", event: event(func(e *protos.HealthEvent) {}), applies: true},
+		{name: "pcie signal", event: event(func(e *protos.HealthEvent) {
+			e.Agent, e.CheckName, e.ErrorCode = "gpu-health-monitor", "GpuPcieWatch", []string{"DCGM_FR_PCI_REPLAY_RATE"}
+		}), applies: true},
+		{name: "other xid", event: event(func(e *protos.HealthEvent) { e.ErrorCode = []string{"48"} })},
+		{name: "other agent", event: event(func(e *protos.HealthEvent) { e.Agent = "csp-health-monitor" })},
+		{name: "healthy event", event: event(func(e *protos.HealthEvent) { e.IsHealthy = true })},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rule, applies, err := newCompositeRule().RuleFor(tt.event)
+			require.NoError(t, err)
+			assert.Equal(t, tt.applies, applies)
+
+			if !applies {
+				return
+			}
+
+			assert.Equal(t, "RESTART_BM", rule.RecommendedAction)
+
+			// every generated stage must be accepted by the sequence stage parser
+			var stages []map[string]interface{}
+
+			for _, stage := range rule.Stage {
+				parsed, err := parser.ParseSequenceStage(stage, datamodels.HealthEventWithStatus{HealthEvent: tt.event})
+				require.NoError(t, err)
+
+				stages = append(stages, parsed)
+			}
+
+			match := stages[0]["$match"].(map[string]interface{})
+			assert.Equal(t, "node1", match["healthevent.nodename"])
+			assert.Len(t, match["$or"], 2)
+
+			// the rule requires all signals by default
+			last := stages[len(stages)-1]["$match"].(map[string]interface{})
+			gte := last["$expr"].(map[string]interface{})["$gte"].([]interface{})
+			assert.Equal(t, float64(2), gte[1])
+		})
+	}
+}
+
+func TestLoadTomlConfig_CompositeRules(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.toml")
+	require.NoError(t, os.WriteFile(path, []byte(`
+[[composite_rules]]
+name = "CorroboratedGPUFallenOffBus"
+recommended_action = "RESTART_BM"
+window = "10m"
+min_signals = 2
+
+[[composite_rules.signals]]
+name = "xid"
+agent = "syslog-health-monitor"
+check_name = "SysLogsXIDError"
+error_codes = ["79"]
+
+[[composite_rules.signals]]
+name = "pcie"
+agent = "gpu-health-monitor"
+check_name = "GpuPcieWatch"
+`), 0o600))
+
+	cfg, err := LoadTomlConfig(path)
+	require.NoError(t, err)
+	require.Len(t, cfg.CompositeRules, 1)
+	assert.Len(t, cfg.CompositeRules[0].Signals, 2)
+	assert.Len(t, cfg.EventRules(), 1)
+	assert.Contains(t, cfg.RebootResolvedRules(), "CorroboratedGPUFallenOffBus")
+
+	require.NoError(t, os.WriteFile(path, []byte(`
+[[composite_rules]]
+name = "Broken"
+window = "10m"
+
+[[composite_rules.signals]]
+name = "xid"
+check_name = "SysLogsXIDError"
+`), 0o600))
+
+	_, err = LoadTomlConfig(path)
+	assert.Error(t, err)
+}
@@ -0,0 +1,205 @@
+// Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	protos "github.com/nvidia/nvsentinel/data-models/pkg/protos"
+)
+
+const defaultRateRuleMinSamples = 2
+
+// RateOfChangeRule triggers when a cumulative counter reported by health monitors,
+// e.g. correctable ECC (SBE), corrected PCIe AER or InfiniBand symbol errors, grows
+// by at least MinIncrease within Window. The slope of correctable errors is often
+// the earliest predictor of a failure, well before absolute thresholds are crossed.
+//
+// Monitors report the counter as a number in the event metadata under CounterKey.
+// Counter resets, e.g. after a reboot, are handled like Prometheus increase(): a
+// sample lower than its predecessor counts as an increase from zero.
+type RateOfChangeRule struct {
+	Name              string `toml:"name"`
+	Description       string `toml:"description"`
+	RecommendedAction string `toml:"recommended_action"`
+	// Tags classify the incidents of the rule, the classifiers do otherwise.
+	Tags []string `toml:"tags"`
+	// CheckName restricts the rule to events of this check. Empty matches any check
+	// reporting CounterKey.
+	CheckName string `toml:"check_name"`
+	// CounterKey is the event metadata key holding the counter value.
+	CounterKey string `toml:"counter_key"`
+	// EntityType scopes the counter to the first impacted entity of this type,
+	// e.g. "GPU" or "PCI". Empty scopes it to the node.
+	EntityType string `toml:"entity_type"`
+	// Window is the time window the increase is measured over, e.g. "1h".
+	Window string `toml:"window"`
+	// MinIncrease is the increase within Window that triggers the rule.
+	MinIncrease float64 `toml:"min_increase"`
+	// MinSamples is the number of samples required in Window, defaults to 2.
+	MinSamples int `toml:"min_samples"`
+}
+
+// Validate checks the rule configuration.
+func (r RateOfChangeRule) Validate() error {
+	if r.Name == "" {
+		return fmt.Errorf("rate rule: name is required")
+	}
+
+	if r.CounterKey == "" || strings.ContainsAny(r.CounterKey, ".$") {
+		return fmt.Errorf("rate rule %s: invalid counter_key %q", r.Name, r.CounterKey)
+	}
+
+	if window, err := time.ParseDuration(r.Window); err != nil || window < time.Second {
+		return fmt.Errorf("rate rule %s: invalid window %q", r.Name, r.Window)
+	}
+
+	if r.MinIncrease <= 0 {
+		return fmt.Errorf("rate rule %s: min_increase must be positive", r.Name)
+	}
+
+	if r.MinSamples != 0 && r.MinSamples < 2 {
+		return fmt.Errorf("rate rule %s: min_samples must be at least 2", r.Name)
+	}
+
+	return nil
+}
+
+// RuleFor returns the aggregation pipeline rule evaluating the counter reported by
+// event. It returns false when the event does not report the counter.
+func (r RateOfChangeRule) RuleFor(event *protos.HealthEvent) (HealthEventsAnalyzerRule, bool, error) {
+	if event == nil || event.IsHealthy {
+		return HealthEventsAnalyzerRule{}, false, nil
+	}
+
+	if _, ok := event.Metadata[r.CounterKey]; !ok {
+		return HealthEventsAnalyzerRule{}, false, nil
+	}
+
+	if r.CheckName != "" && event.CheckName != r.CheckName {
+		return HealthEventsAnalyzerRule{}, false, nil
+	}
+
+	entityValue := ""
+
+	if r.EntityType != "" {
+		for _, entity := range event.EntitiesImpacted {
+			if entity.EntityType == r.EntityType {
+				entityValue = entity.EntityValue
+				break
+			}
+		}
+
+		if entityValue == "" {
+			return HealthEventsAnalyzerRule{}, false, nil
+		}
+	}
+
+	// Validate guarantees a parsable window
+	window, _ := time.ParseDuration(r.Window)
+
+	minSamples := r.MinSamples
+	if minSamples == 0 {
+		minSamples = defaultRateRuleMinSamples
+	}
+
+	stages, err := r.stages(int64(window.Seconds()), minSamples, entityValue)
+	if err != nil {
+		return HealthEventsAnalyzerRule{}, false, fmt.Errorf("rate rule %s: %w", r.Name, err)
+	}
+
+	return HealthEventsAnalyzerRule{
+		Name:              r.Name,
+		Description:       r.Description,
+		RecommendedAction: r.RecommendedAction,
+		Stage:             stages,
+		Tags:              r.Tags,
+	}, true, nil
+}
+
+func (r RateOfChangeRule) stages(windowSeconds int64, minSamples int, entityValue string) ([]string, error) {
+	counterField := "healthevent.metadata." + r.CounterKey
+
+	match := map[string]any{
+		"healthevent.nodename":  "this.healthevent.nodename",
+		"healthevent.ishealthy": false,
+		counterField:            map[string]any{"$exists": true},
+		"$expr": map[string]any{"$gte": []any{
+			"$healthevent.generatedtimestamp.seconds",
+			map[string]any{"$subtract": []any{
+				map[string]any{"$divide": []any{map[string]any{"$toLong": "$$NOW"}, 1000}},
+				windowSeconds,
+			}},
+		}},
+	}
+
+	if r.CheckName != "" {
+		match["healthevent.checkname"] = r.CheckName
+	}
+
+	if entityValue != "" {
+		match["healthevent.entitiesimpacted"] = map[string]any{"$elemMatch": map[string]any{
+			"entitytype":  r.EntityType,
+			"entityvalue": entityValue,
+		}}
+	}
+
+	stages := []map[string]any{
+		{"$match": match},
+		{"$addFields": map[string]any{"counter": map[string]any{"$convert": map[string]any{
+			"input": "$" + counterField, "to": "double", "onError": nil, "onNull": nil,
+		}}}},
+		{"$match": map[string]any{"counter": map[string]any{"$ne": nil}}},
+		{"$setWindowFields": map[string]any{
+			"sortBy": map[string]any{"healthevent.generatedtimestamp.seconds": 1},
+			"output": map[string]any{"prevCounter": map[string]any{"$shift": map[string]any{"output": "$counter", "by": -1}}},
+		}},
+		{"$addFields": map[string]any{"delta": map[string]any{"$cond": []any{
+			map[string]any{"$eq": []any{"$prevCounter", nil}},
+			0,
+			map[string]any{"$cond": []any{
+				// counter reset: the whole current value accumulated since the reset
+				map[string]any{"$lt": []any{"$counter", "$prevCounter"}},
+				"$counter",
+				map[string]any{"$subtract": []any{"$counter", "$prevCounter"}},
+			}},
+		}}}},
+		{"$group": map[string]any{
+			"_id":      nil,
+			"increase": map[string]any{"$sum": "$delta"},
+			"samples":  map[string]any{"$sum": 1},
+		}},
+		{"$match": map[string]any{
+			"increase": map[string]any{"$gte": r.MinIncrease},
+			"samples":  map[string]any{"$gte": minSamples},
+		}},
+	}
+
+	result := make([]string, 0, len(stages))
+
+	for _, stage := range stages {
+		b, err := json.Marshal(stage)
+		if err != nil {
+			return nil, err
+		}
+
+		result = append(result, string(b))
+	}
+
+	return result, nil
+}
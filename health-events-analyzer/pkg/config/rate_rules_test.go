@@ -0,0 +1,158 @@
+// Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	datamodels "github.com/nvidia/nvsentinel/data-models/pkg/model"
+	protos "github.com/nvidia/nvsentinel/data-models/pkg/protos"
+	"github.com/nvidia/nvsentinel/health-events-analyzer/pkg/parser"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newRateRule() RateOfChangeRule {
+	return RateOfChangeRule{
+		Name:        "RapidCorrectableECC",
+		CheckName:   "GpuMemWatch",
+		CounterKey:  "ecc_sbe_total",
+		EntityType:  "GPU",
+		Window:      "1h",
+		MinIncrease: 100,
+	}
+}
+
+func TestRateOfChangeRule_Validate(t *testing.T) {
+	tests := []struct {
+		name   string
+		modify func(r *RateOfChangeRule)
+		valid  bool
+	}{
+		{name: "valid", modify: func(r *RateOfChangeRule) {}, valid: true},
+		{name: "missing name", modify: func(r *RateOfChangeRule) { r.Name = "" }},
+		{name: "counter key with dot", modify: func(r *RateOfChangeRule) { r.CounterKey = "ecc.sbe" }},
+		{name: "invalid window", modify: func(r *RateOfChangeRule) { r.Window = "hourly" }},
+		{name: "zero increase", modify: func(r *RateOfChangeRule) { r.MinIncrease = 0 }},
+		{name: "single sample", modify: func(r *RateOfChangeRule) { r.MinSamples = 1 }},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rule := newRateRule()
+			tt.modify(&rule)
+
+			err := rule.Validate()
+			if tt.valid {
+				assert.NoError(t, err)
+			} else {
+				assert.Error(t, err)
+			}
+		})
+	}
+}
+
+func TestRateOfChangeRule_RuleFor(t *testing.T) {
+	event := func(modify func(e *protos.HealthEvent)) *protos.HealthEvent {
+		e := &protos.HealthEvent{
+			CheckName: "GpuMemWatch",
+			NodeName:  "node1",
+			EntitiesImpacted: []*protos.Entity{
+				{EntityType: "PCI", EntityValue: "0000:3b:00.0"},
+				{EntityType: "GPU", EntityValue: "3"},
+			},
+			Metadata: map[string]string{"ecc_sbe_total": "1200"},
+		}
+		modify(e)
+
+		return e
+	}
+
+	tests := []struct {
+		name    string
+		event   *protos.HealthEvent
+		applies bool
+	}{
+		{name: "reports the counter", event: event(func(e *protos.HealthEvent) {}), applies: true},
+		{name: "no counter", event: event(func(e *protos.HealthEvent) { e.Metadata = nil })},
+		{name: "other check", event: event(func(e *protos.HealthEvent) { e.CheckName = "GpuXidError" })},
+		{name: "no GPU entity", event: event(func(e *protos.HealthEvent) { e.EntitiesImpacted = e.EntitiesImpacted[:1] })},
+		{name: "healthy event", event: event(func(e *protos.HealthEvent) { e.IsHealthy = true })},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rule, applies, err := newRateRule().RuleFor(tt.event)
+			require.NoError(t, err)
+			assert.Equal(t, tt.applies, applies)
+
+			if !applies {
+				return
+			}
+
+			// every generated stage must be accepted by the sequence stage parser
+			var stages []map[string]interface{}
+
+			for _, stage := range rule.Stage {
+				parsed, err := parser.ParseSequenceStage(stage, datamodels.HealthEventWithStatus{HealthEvent: tt.event})
+				require.NoError(t, err)
+
+				stages = append(stages, parsed)
+			}
+
+			match := stages[0]["$match"].(map[string]interface{})
+			assert.Equal(t, "node1", match["healthevent.nodename"])
+			assert.Equal(t, map[string]interface{}{"$elemMatch": map[string]interface{}{
+				"entitytype":  "GPU",
+				"entityvalue": "3",
+			}}, match["healthevent.entitiesimpacted"])
+
+			last := stages[len(stages)-1]["$match"].(map[string]interface{})
+			assert.Equal(t, map[string]interface{}{"$gte": float64(100)}, last["increase"])
+			assert.Equal(t, map[string]interface{}{"$gte": float64(2)}, last["samples"])
+		})
+	}
+}
+
+func TestLoadTomlConfig_RateRules(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.toml")
+	require.NoError(t, os.WriteFile(path, []byte(`
+[[rate_rules]]
+name = "RapidCorrectableECC"
+recommended_action = "CONTACT_SUPPORT"
+check_name = "GpuMemWatch"
+counter_key = "ecc_sbe_total"
+entity_type = "GPU"
+window = "1h"
+min_increase = 100
+`), 0o600))
+
+	cfg, err := LoadTomlConfig(path)
+	require.NoError(t, err)
+	require.Len(t, cfg.RateRules, 1)
+	assert.Equal(t, "ecc_sbe_total", cfg.RateRules[0].CounterKey)
+
+	require.NoError(t, os.WriteFile(path, []byte(`
+[[rate_rules]]
+name = "Broken"
+counter_key = "ecc_sbe_total"
+window = "1h"
+`), 0o600))
+
+	_, err = LoadTomlConfig(path)
+	assert.Error(t, err)
+}
@@ -0,0 +1,125 @@
+// Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"fmt"
+	"net"
+	"net/mail"
+	"net/url"
+	"strings"
+	"time"
+)
+
+const (
+	defaultReliabilityReportInterval = "168h"
+	minReliabilityReportInterval     = time.Hour
+)
+
+// ReliabilityReport generates periodic reliability reports of the stored events:
+// faults per SKU, mean time between failures per node pool and remediation
+// success rates, written as CSV files to object storage and optionally mailed.
+type ReliabilityReport struct {
+	// Interval between reports, defaults to "168h". Each report covers the
+	// previous interval; intervals are aligned in UTC, so weekly reports run on
+	// Mondays at 00:00 UTC.
+	Interval string `toml:"interval"`
+	// SKUKey is the event metadata key holding the SKU. Faults of events without
+	// it are reported for the "unknown" SKU.
+	SKUKey string `toml:"sku_key"`
+	// NodePoolLabel is the node label naming the node pool. Without it all nodes
+	// are reported as the "all" pool.
+	NodePoolLabel string `toml:"node_pool_label"`
+	// UploadURL is where the CSV files are uploaded with HTTP PUT, as
+	// <upload_url>/reliability/<start date>/<report>.csv. Reports are only served
+	// on the API when empty.
+	UploadURL string `toml:"upload_url"`
+	// Mail is nil when reports are not mailed.
+	Mail *ReportMail `toml:"mail"`
+}
+
+// ReportMail sends the reports as attachments through an SMTP server.
+type ReportMail struct {
+	// Server is the host:port of the SMTP server.
+	Server string   `toml:"server"`
+	From   string   `toml:"from"`
+	To     []string `toml:"to"`
+	// Username authenticates with PLAIN auth, whose password is read from the
+	// PasswordEnv environment variable. No authentication when empty.
+	Username    string `toml:"username"`
+	PasswordEnv string `toml:"password_env"`
+}
+
+// Validate checks the configuration and fills in defaults.
+func (c *ReliabilityReport) Validate() error {
+	if c.Interval == "" {
+		c.Interval = defaultReliabilityReportInterval
+	}
+
+	if interval, err := time.ParseDuration(c.Interval); err != nil || interval < minReliabilityReportInterval {
+		return fmt.Errorf("reliability_report: invalid interval %q, must be at least %s",
+			c.Interval, minReliabilityReportInterval)
+	}
+
+	if strings.ContainsAny(c.SKUKey, ".$") {
+		return fmt.Errorf("reliability_report: invalid sku_key %q", c.SKUKey)
+	}
+
+	if c.UploadURL != "" {
+		parsed, err := url.Parse(c.UploadURL)
+		if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+			return fmt.Errorf("reliability_report: invalid upload_url %q", c.UploadURL)
+		}
+	}
+
+	if c.Mail != nil {
+		return c.Mail.validate()
+	}
+
+	return nil
+}
+
+func (c *ReportMail) validate() error {
+	if _, _, err := net.SplitHostPort(c.Server); err != nil {
+		return fmt.Errorf("reliability_report.mail: invalid server %q", c.Server)
+	}
+
+	if _, err := mail.ParseAddress(c.From); err != nil {
+		return fmt.Errorf("reliability_report.mail: invalid from %q", c.From)
+	}
+
+	if len(c.To) == 0 {
+		return fmt.Errorf("reliability_report.mail: no recipients")
+	}
+
+	for _, to := range c.To {
+		if _, err := mail.ParseAddress(to); err != nil {
+			return fmt.Errorf("reliability_report.mail: invalid recipient %q", to)
+		}
+	}
+
+	if c.Username != "" && c.PasswordEnv == "" {
+		return fmt.Errorf("reliability_report.mail: password_env is required with username")
+	}
+
+	return nil
+}
+
+// IntervalDuration returns the parsed Interval.
+func (c *ReliabilityReport) IntervalDuration() time.Duration {
+	// Validate guarantees a parsable interval
+	interval, _ := time.ParseDuration(c.Interval)
+	return interval
+}
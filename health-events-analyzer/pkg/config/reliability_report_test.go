@@ -0,0 +1,92 @@
+// Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReliabilityReport_Validate(t *testing.T) {
+	mail := func(modify func(*ReportMail)) *ReportMail {
+		m := &ReportMail{Server: "smtp.example.com:587", From: "nvsentinel@example.com", To: []string{"ops@example.com"}}
+		modify(m)
+
+		return m
+	}
+
+	tests := []struct {
+		name   string
+		report ReliabilityReport
+		valid  bool
+	}{
+		{name: "defaults", report: ReliabilityReport{}, valid: true},
+		{name: "all options", report: ReliabilityReport{
+			Interval:      "24h",
+			SKUKey:        "gpu_sku",
+			NodePoolLabel: "nvidia.com/node-pool",
+			UploadURL:     "http://file-server.nvsentinel.svc/upload",
+			Mail:          mail(func(m *ReportMail) { m.Username = "nvsentinel"; m.PasswordEnv = "SMTP_PASSWORD" }),
+		}, valid: true},
+		{name: "interval too short", report: ReliabilityReport{Interval: "5m"}},
+		{name: "invalid interval", report: ReliabilityReport{Interval: "weekly"}},
+		{name: "invalid sku_key", report: ReliabilityReport{SKUKey: "gpu.sku"}},
+		{name: "invalid upload_url", report: ReliabilityReport{UploadURL: "s3://bucket"}},
+		{name: "invalid mail server", report: ReliabilityReport{Mail: mail(func(m *ReportMail) { m.Server = "smtp" })}},
+		{name: "invalid mail from", report: ReliabilityReport{Mail: mail(func(m *ReportMail) { m.From = "nvsentinel" })}},
+		{name: "no recipients", report: ReliabilityReport{Mail: mail(func(m *ReportMail) { m.To = nil })}},
+		{name: "username without password", report: ReliabilityReport{
+			Mail: mail(func(m *ReportMail) { m.Username = "nvsentinel" }),
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.report.Validate()
+			if tt.valid {
+				assert.NoError(t, err)
+			} else {
+				assert.Error(t, err)
+			}
+		})
+	}
+}
+
+func TestLoadTomlConfig_ReliabilityReport(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.toml")
+	require.NoError(t, os.WriteFile(path, []byte(`
+[reliability_report]
+sku_key = "gpu_sku"
+upload_url = "http://file-server.nvsentinel.svc/upload"
+
+[reliability_report.mail]
+server = "smtp.example.com:25"
+from = "nvsentinel@example.com"
+to = ["ops@example.com"]
+`), 0o600))
+
+	cfg, err := LoadTomlConfig(path)
+	require.NoError(t, err)
+	require.NotNil(t, cfg.ReliabilityReport)
+	assert.Equal(t, 7*24*time.Hour, cfg.ReliabilityReport.IntervalDuration())
+	assert.Equal(t, "gpu_sku", cfg.ReliabilityReport.SKUKey)
+	require.NotNil(t, cfg.ReliabilityReport.Mail)
+	assert.Equal(t, []string{"ops@example.com"}, cfg.ReliabilityReport.Mail.To)
+}
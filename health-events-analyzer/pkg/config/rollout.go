@@ -0,0 +1,81 @@
+// Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"fmt"
+	"time"
+
+	protos "github.com/nvidia/nvsentinel/data-models/pkg/protos"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+const (
+	defaultRolloutNamespace   = "gpu-operator"
+	defaultRolloutPodSelector = "app in (nvidia-driver-daemonset)"
+)
+
+// RolloutCorrelation tags events published within Window of a driver or GPU Operator
+// component rollout on the node as possibly rollout-induced, and replaces their
+// recommended action with the more conservative RecommendedAction, so a bad
+// rollout is investigated instead of being masked by automated remediation.
+type RolloutCorrelation struct {
+	// Window is how long after a rollout events are correlated with it, e.g. "30m".
+	Window string `toml:"window"`
+	// Namespace of the rolled out pods, defaults to "gpu-operator".
+	Namespace string `toml:"namespace"`
+	// PodSelector selects the rolled out pods, defaults to the GPU Operator driver
+	// daemonset.
+	PodSelector string `toml:"pod_selector"`
+	// RecommendedAction replaces the action of correlated events, defaults to
+	// CONTACT_SUPPORT.
+	RecommendedAction string `toml:"recommended_action"`
+}
+
+// Validate checks the configuration and fills in defaults.
+func (c *RolloutCorrelation) Validate() error {
+	if window, err := time.ParseDuration(c.Window); err != nil || window <= 0 {
+		return fmt.Errorf("rollout_correlation: invalid window %q", c.Window)
+	}
+
+	if c.Namespace == "" {
+		c.Namespace = defaultRolloutNamespace
+	}
+
+	if c.PodSelector == "" {
+		c.PodSelector = defaultRolloutPodSelector
+	}
+
+	if _, err := labels.Parse(c.PodSelector); err != nil {
+		return fmt.Errorf("rollout_correlation: invalid pod_selector %q: %w", c.PodSelector, err)
+	}
+
+	if c.RecommendedAction == "" {
+		c.RecommendedAction = protos.RecommendedAction_CONTACT_SUPPORT.String()
+	}
+
+	if _, ok := protos.RecommendedAction_value[c.RecommendedAction]; !ok {
+		return fmt.Errorf("rollout_correlation: invalid recommended_action %q", c.RecommendedAction)
+	}
+
+	return nil
+}
+
+// WindowDuration returns the parsed Window.
+func (c *RolloutCorrelation) WindowDuration() time.Duration {
+	// Validate guarantees a parsable window
+	window, _ := time.ParseDuration(c.Window)
+	return window
+}
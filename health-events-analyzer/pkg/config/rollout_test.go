@@ -0,0 +1,65 @@
+// Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRolloutCorrelation_Validate(t *testing.T) {
+	tests := []struct {
+		name        string
+		correlation RolloutCorrelation
+		valid       bool
+	}{
+		{name: "defaults", correlation: RolloutCorrelation{Window: "30m"}, valid: true},
+		{name: "missing window", correlation: RolloutCorrelation{}},
+		{name: "invalid selector", correlation: RolloutCorrelation{Window: "30m", PodSelector: "app in ("}},
+		{name: "invalid action", correlation: RolloutCorrelation{Window: "30m", RecommendedAction: "PANIC"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.correlation.Validate()
+			if tt.valid {
+				assert.NoError(t, err)
+			} else {
+				assert.Error(t, err)
+			}
+		})
+	}
+}
+
+func TestLoadTomlConfig_RolloutCorrelation(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.toml")
+	require.NoError(t, os.WriteFile(path, []byte(`
+[rollout_correlation]
+window = "45m"
+`), 0o600))
+
+	cfg, err := LoadTomlConfig(path)
+	require.NoError(t, err)
+	require.NotNil(t, cfg.RolloutCorrelation)
+	assert.Equal(t, 45*time.Minute, cfg.RolloutCorrelation.WindowDuration())
+	assert.Equal(t, "gpu-operator", cfg.RolloutCorrelation.Namespace)
+	assert.Equal(t, "app in (nvidia-driver-daemonset)", cfg.RolloutCorrelation.PodSelector)
+	assert.Equal(t, "CONTACT_SUPPORT", cfg.RolloutCorrelation.RecommendedAction)
+}
@@ -0,0 +1,76 @@
+// Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"fmt"
+	"time"
+)
+
+const (
+	defaultRulePackDistributionPort           = 50052
+	defaultRulePackDistributionReloadInterval = "1m"
+)
+
+// RulePackDistribution serves signed rule pack bundles to agents over gRPC, so
+// detection rules are updated in place instead of restarting the agent daemonsets.
+type RulePackDistribution struct {
+	// Port of the gRPC server, defaults to 50052.
+	Port int `toml:"port"`
+	// BundlesPath is the TOML file listing the bundles. It is reloaded every
+	// ReloadInterval, so bundles are added and rolled out by updating the file.
+	BundlesPath string `toml:"bundles_path"`
+	// SigningKeyPath is the PEM encoded Ed25519 private key the bundles are signed
+	// with. Agents verify the bundles with the matching public key.
+	SigningKeyPath string `toml:"signing_key_path"`
+	// ReloadInterval is how often BundlesPath is reloaded, defaults to "1m".
+	ReloadInterval string `toml:"reload_interval"`
+}
+
+// Validate checks the configuration and fills in defaults.
+func (c *RulePackDistribution) Validate() error {
+	if c.Port == 0 {
+		c.Port = defaultRulePackDistributionPort
+	}
+
+	if c.Port < 1 || c.Port > 65535 {
+		return fmt.Errorf("rule_pack_distribution: invalid port %d", c.Port)
+	}
+
+	if c.BundlesPath == "" {
+		return fmt.Errorf("rule_pack_distribution: bundles_path is required")
+	}
+
+	if c.SigningKeyPath == "" {
+		return fmt.Errorf("rule_pack_distribution: signing_key_path is required")
+	}
+
+	if c.ReloadInterval == "" {
+		c.ReloadInterval = defaultRulePackDistributionReloadInterval
+	}
+
+	if interval, err := time.ParseDuration(c.ReloadInterval); err != nil || interval <= 0 {
+		return fmt.Errorf("rule_pack_distribution: invalid reload_interval %q", c.ReloadInterval)
+	}
+
+	return nil
+}
+
+// ReloadIntervalDuration returns the parsed ReloadInterval.
+func (c *RulePackDistribution) ReloadIntervalDuration() time.Duration {
+	// Validate guarantees a parsable duration
+	interval, _ := time.ParseDuration(c.ReloadInterval)
+	return interval
+}
@@ -0,0 +1,36 @@
+// Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRulePackDistribution_Validate(t *testing.T) {
+	distribution := RulePackDistribution{BundlesPath: "/etc/rule-packs/bundles.toml", SigningKeyPath: "/etc/key.pem"}
+	require.NoError(t, distribution.Validate())
+	assert.Equal(t, 50052, distribution.Port)
+	assert.Equal(t, time.Minute, distribution.ReloadIntervalDuration())
+
+	assert.Error(t, (&RulePackDistribution{SigningKeyPath: "/etc/key.pem"}).Validate())
+	assert.Error(t, (&RulePackDistribution{BundlesPath: "/etc/bundles.toml"}).Validate())
+	assert.Error(t, (&RulePackDistribution{
+		BundlesPath: "/etc/bundles.toml", SigningKeyPath: "/etc/key.pem", ReloadInterval: "never",
+	}).Validate())
+}
@@ -18,6 +18,8 @@ import (
 	"fmt"
 
 	"github.com/nvidia/nvsentinel/commons/pkg/configmanager"
+	"github.com/nvidia/nvsentinel/data-models/pkg/model"
+	protos "github.com/nvidia/nvsentinel/data-models/pkg/protos"
 )
 
 type HealthEventsAnalyzerRule struct {
@@ -25,10 +27,100 @@ type HealthEventsAnalyzerRule struct {
 	Description       string   `toml:"description"`
 	RecommendedAction string   `toml:"recommended_action"`
 	Stage             []string `toml:"stage"`
+	// ResetOnReboot restricts the rule to events after the last reboot of the node, so
+	// counts of faults resolved by the reboot start over
+	ResetOnReboot bool `toml:"reset_on_reboot"`
+	// Tags classify the incidents of the rule, the classifiers do otherwise
+	Tags []string `toml:"tags"`
+}
+
+// EventRule builds the pipeline rule to evaluate for a specific event. It returns
+// false when the rule does not apply to the event.
+type EventRule interface {
+	RuleFor(event *protos.HealthEvent) (HealthEventsAnalyzerRule, bool, error)
 }
 
 type TomlConfig struct {
-	Rules []HealthEventsAnalyzerRule `toml:"rules"`
+	Rules         []HealthEventsAnalyzerRule `toml:"rules"`
+	RateRules     []RateOfChangeRule         `toml:"rate_rules"`
+	BaselineRules []BaselineRule             `toml:"baseline_rules"`
+	// CompositeRules require several corroborating signals before they trigger.
+	CompositeRules []CompositeRule `toml:"composite_rules"`
+	// RolloutCorrelation is nil when rollout correlation is disabled.
+	RolloutCorrelation *RolloutCorrelation `toml:"rollout_correlation"`
+	// TelemetryConfirmation is nil when log-detected faults are acted on unconfirmed.
+	TelemetryConfirmation *TelemetryConfirmation `toml:"telemetry_confirmation"`
+	// VersionSkew is nil when version skew detection is disabled.
+	VersionSkew *VersionSkew `toml:"version_skew"`
+	// ReliabilityReport is nil when no reliability reports are generated.
+	ReliabilityReport *ReliabilityReport `toml:"reliability_report"`
+	// RulePackDistribution is nil when rule packs are not distributed.
+	RulePackDistribution *RulePackDistribution `toml:"rule_pack_distribution"`
+	// SeverityOverrides apply in order, the first matching override wins.
+	SeverityOverrides []SeverityOverride `toml:"severity_overrides"`
+	// ClassificationRules apply in order, the first matching rule wins.
+	ClassificationRules []ClassificationRule `toml:"classification_rules"`
+	// Classifier is nil when no external classifier is configured.
+	Classifier *ExternalClassifier `toml:"classifier"`
+	// Sharding is nil when a single analyzer evaluates the events of all nodes.
+	Sharding *Sharding `toml:"sharding"`
+	// AffectedNodes is nil when the affected nodes API reports no driver versions.
+	AffectedNodes *AffectedNodes `toml:"affected_nodes"`
+}
+
+// RebootResolvedRules returns the names of the rules whose recommended action is
+// resolved by rebooting the node.
+func (c *TomlConfig) RebootResolvedRules() []string {
+	var names []string
+
+	isRebootResolved := func(action string) bool {
+		return model.IsRebootRequiringAction(protos.RecommendedAction(protos.RecommendedAction_value[action]))
+	}
+
+	for _, rule := range c.Rules {
+		if isRebootResolved(rule.RecommendedAction) {
+			names = append(names, rule.Name)
+		}
+	}
+
+	for _, rule := range c.RateRules {
+		if isRebootResolved(rule.RecommendedAction) {
+			names = append(names, rule.Name)
+		}
+	}
+
+	for _, rule := range c.BaselineRules {
+		if isRebootResolved(rule.RecommendedAction) {
+			names = append(names, rule.Name)
+		}
+	}
+
+	for _, rule := range c.CompositeRules {
+		if isRebootResolved(rule.RecommendedAction) {
+			names = append(names, rule.Name)
+		}
+	}
+
+	return names
+}
+
+// EventRules returns the rules that are built per event.
+func (c *TomlConfig) EventRules() []EventRule {
+	rules := make([]EventRule, 0, len(c.RateRules)+len(c.BaselineRules)+len(c.CompositeRules))
+
+	for _, rule := range c.RateRules {
+		rules = append(rules, rule)
+	}
+
+	for _, rule := range c.BaselineRules {
+		rules = append(rules, rule)
+	}
+
+	for _, rule := range c.CompositeRules {
+		rules = append(rules, rule)
+	}
+
+	return rules
 }
 
 func LoadTomlConfig(path string) (*TomlConfig, error) {
@@ -37,5 +129,136 @@ func LoadTomlConfig(path string) (*TomlConfig, error) {
 		return nil, fmt.Errorf("failed to decode TOML config from %s: %w", path, err)
 	}
 
+	if err := config.validate(); err != nil {
+		return nil, fmt.Errorf("invalid TOML config %s: %w", path, err)
+	}
+
+	return &config, nil
+}
+
+// LoadTomlConfigFromBytes loads a configuration from data, like LoadTomlConfig
+// does from a file.
+func LoadTomlConfigFromBytes(data []byte) (*TomlConfig, error) {
+	var config TomlConfig
+	if err := configmanager.DecodeTOMLConfig(data, &config); err != nil {
+		return nil, err
+	}
+
+	if err := config.validate(); err != nil {
+		return nil, fmt.Errorf("invalid TOML config: %w", err)
+	}
+
 	return &config, nil
 }
+
+func (c *TomlConfig) validate() error {
+	type namedTags struct {
+		name string
+		tags []string
+	}
+
+	ruleTags := make([]namedTags, 0, len(c.Rules)+len(c.RateRules)+len(c.BaselineRules)+len(c.CompositeRules))
+
+	for _, rule := range c.Rules {
+		ruleTags = append(ruleTags, namedTags{rule.Name, rule.Tags})
+	}
+
+	for _, rule := range c.RateRules {
+		if err := rule.Validate(); err != nil {
+			return err
+		}
+
+		ruleTags = append(ruleTags, namedTags{rule.Name, rule.Tags})
+	}
+
+	for _, rule := range c.BaselineRules {
+		if err := rule.Validate(); err != nil {
+			return err
+		}
+
+		ruleTags = append(ruleTags, namedTags{rule.Name, rule.Tags})
+	}
+
+	for _, rule := range c.CompositeRules {
+		if err := rule.Validate(); err != nil {
+			return err
+		}
+
+		ruleTags = append(ruleTags, namedTags{rule.Name, rule.Tags})
+	}
+
+	for _, rule := range ruleTags {
+		if len(rule.tags) == 0 {
+			continue
+		}
+
+		if err := validateTags(rule.tags); err != nil {
+			return fmt.Errorf("rule %s: %w", rule.name, err)
+		}
+	}
+
+	if c.RolloutCorrelation != nil {
+		if err := c.RolloutCorrelation.Validate(); err != nil {
+			return err
+		}
+	}
+
+	if c.TelemetryConfirmation != nil {
+		if err := c.TelemetryConfirmation.Validate(); err != nil {
+			return err
+		}
+	}
+
+	if c.VersionSkew != nil {
+		if err := c.VersionSkew.Validate(); err != nil {
+			return err
+		}
+	}
+
+	if c.ReliabilityReport != nil {
+		if err := c.ReliabilityReport.Validate(); err != nil {
+			return err
+		}
+	}
+
+	if c.RulePackDistribution != nil {
+		if err := c.RulePackDistribution.Validate(); err != nil {
+			return err
+		}
+	}
+
+	if c.Sharding != nil {
+		if err := c.Sharding.Validate(); err != nil {
+			return err
+		}
+	}
+
+	names := make(map[string]bool, len(c.SeverityOverrides))
+
+	for i := range c.SeverityOverrides {
+		override := &c.SeverityOverrides[i]
+		if err := override.Validate(); err != nil {
+			return err
+		}
+
+		if names[override.Name] {
+			return fmt.Errorf("severity_overrides: duplicate override %s", override.Name)
+		}
+
+		names[override.Name] = true
+	}
+
+	for _, rule := range c.ClassificationRules {
+		if err := rule.Validate(); err != nil {
+			return err
+		}
+	}
+
+	if c.Classifier != nil {
+		if err := c.Classifier.Validate(); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
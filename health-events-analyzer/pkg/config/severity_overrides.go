@@ -0,0 +1,152 @@
+// Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/nvidia/nvsentinel/data-models/pkg/model"
+	protos "github.com/nvidia/nvsentinel/data-models/pkg/protos"
+)
+
+// SeverityOverride changes how the analyzer treats unhealthy events with one of
+// ErrorCodes across the cluster, e.g. to treat Xid 63 as WARNING on a driver known
+// to report it spuriously.
+//
+// A WARNING override downgrades the events: they trigger no rule and do not count
+// towards the rules. A FATAL override upgrades them: the analyzer publishes a fatal
+// event named after the override with RecommendedAction for each of them. Every
+// application is recorded on the stored event.
+type SeverityOverride struct {
+	// Name identifies the override in the audit records, metrics and, for FATAL
+	// overrides, as the check name of the published events.
+	Name string `toml:"name"`
+	// Agent and CheckName optionally restrict the override to events of an agent or
+	// a check.
+	Agent      string   `toml:"agent"`
+	CheckName  string   `toml:"check_name"`
+	ErrorCodes []string `toml:"error_codes"`
+	// Metadata optionally restricts the override to events with these metadata
+	// values, e.g. the driver version when the agent reports it.
+	Metadata map[string]string `toml:"metadata"`
+	// Severity is FATAL or WARNING.
+	Severity string `toml:"severity"`
+	// RecommendedAction of the events published for a FATAL override, defaults to
+	// CONTACT_SUPPORT.
+	RecommendedAction string `toml:"recommended_action"`
+	// Reason is why the override is in effect, e.g. a bug or ticket reference.
+	Reason string `toml:"reason"`
+	// ExpiresAt optionally ends the override, as an RFC 3339 timestamp.
+	ExpiresAt string `toml:"expires_at"`
+}
+
+// Validate checks the override and fills in defaults.
+func (o *SeverityOverride) Validate() error {
+	if o.Name == "" {
+		return fmt.Errorf("severity_overrides: override without name")
+	}
+
+	if len(o.ErrorCodes) == 0 {
+		return fmt.Errorf("severity_overrides: override %s has no error_codes", o.Name)
+	}
+
+	if o.Reason == "" {
+		return fmt.Errorf("severity_overrides: override %s has no reason", o.Name)
+	}
+
+	switch o.Severity {
+	case model.SeverityFatal:
+		if o.RecommendedAction == "" {
+			o.RecommendedAction = protos.RecommendedAction_CONTACT_SUPPORT.String()
+		}
+
+		if _, ok := protos.RecommendedAction_value[o.RecommendedAction]; !ok {
+			return fmt.Errorf("severity_overrides: override %s has invalid recommended_action %q",
+				o.Name, o.RecommendedAction)
+		}
+	case model.SeverityWarning:
+		if o.RecommendedAction != "" {
+			return fmt.Errorf("severity_overrides: WARNING override %s cannot set recommended_action", o.Name)
+		}
+	default:
+		return fmt.Errorf("severity_overrides: override %s has invalid severity %q, expected %s or %s",
+			o.Name, o.Severity, model.SeverityFatal, model.SeverityWarning)
+	}
+
+	if o.ExpiresAt != "" {
+		if _, err := time.Parse(time.RFC3339, o.ExpiresAt); err != nil {
+			return fmt.Errorf("severity_overrides: override %s has invalid expires_at %q: %w", o.Name, o.ExpiresAt, err)
+		}
+	}
+
+	return nil
+}
+
+// Expiry returns the parsed ExpiresAt, false when the override does not expire.
+func (o *SeverityOverride) Expiry() (time.Time, bool) {
+	if o.ExpiresAt == "" {
+		return time.Time{}, false
+	}
+
+	// Validate guarantees a parsable timestamp
+	expiry, _ := time.Parse(time.RFC3339, o.ExpiresAt)
+
+	return expiry, true
+}
+
+// InEffect returns true if the override has not expired at now.
+func (o *SeverityOverride) InEffect(now time.Time) bool {
+	expiry, ok := o.Expiry()
+	return !ok || now.Before(expiry)
+}
+
+// Matches returns true if the override applies to the event.
+func (o *SeverityOverride) Matches(event *protos.HealthEvent) bool {
+	if event.IsHealthy {
+		return false
+	}
+
+	if o.Agent != "" && event.Agent != o.Agent {
+		return false
+	}
+
+	if o.CheckName != "" && event.CheckName != o.CheckName {
+		return false
+	}
+
+	for key, value := range o.Metadata {
+		if event.Metadata[key] != value {
+			return false
+		}
+	}
+
+	return slices.ContainsFunc(event.ErrorCode, func(code string) bool {
+		return slices.Contains(o.ErrorCodes, code)
+	})
+}
+
+// SeverityOverrideFor returns the first override in effect at now that applies to
+// the event.
+func (c *TomlConfig) SeverityOverrideFor(event *protos.HealthEvent, now time.Time) (SeverityOverride, bool) {
+	for _, override := range c.SeverityOverrides {
+		if override.InEffect(now) && override.Matches(event) {
+			return override, true
+		}
+	}
+
+	return SeverityOverride{}, false
+}
@@ -0,0 +1,144 @@
+// Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	protos "github.com/nvidia/nvsentinel/data-models/pkg/protos"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSeverityOverride_Validate(t *testing.T) {
+	valid := func() SeverityOverride {
+		return SeverityOverride{Name: "xid-63", ErrorCodes: []string{"63"}, Severity: "WARNING", Reason: "bug 4711"}
+	}
+
+	tests := []struct {
+		name   string
+		modify func(*SeverityOverride)
+		valid  bool
+	}{
+		{name: "warning", modify: func(*SeverityOverride) {}, valid: true},
+		{name: "fatal", modify: func(o *SeverityOverride) { o.Severity = "FATAL" }, valid: true},
+		{name: "expiring", modify: func(o *SeverityOverride) { o.ExpiresAt = "2025-07-01T00:00:00Z" }, valid: true},
+		{name: "missing name", modify: func(o *SeverityOverride) { o.Name = "" }},
+		{name: "missing error codes", modify: func(o *SeverityOverride) { o.ErrorCodes = nil }},
+		{name: "missing reason", modify: func(o *SeverityOverride) { o.Reason = "" }},
+		{name: "invalid severity", modify: func(o *SeverityOverride) { o.Severity = "INFO" }},
+		{name: "warning with action", modify: func(o *SeverityOverride) { o.RecommendedAction = "RESTART_BM" }},
+		{name: "invalid action", modify: func(o *SeverityOverride) {
+			o.Severity = "FATAL"
+			o.RecommendedAction = "PANIC"
+		}},
+		{name: "invalid expiry", modify: func(o *SeverityOverride) { o.ExpiresAt = "next week" }},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			override := valid()
+			tt.modify(&override)
+
+			err := override.Validate()
+			if tt.valid {
+				assert.NoError(t, err)
+			} else {
+				assert.Error(t, err)
+			}
+		})
+	}
+}
+
+func TestSeverityOverrideFor(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.toml")
+	require.NoError(t, os.WriteFile(path, []byte(`
+[[severity_overrides]]
+name = "expired"
+error_codes = ["63"]
+severity = "FATAL"
+reason = "superseded"
+expires_at = "2025-06-01T00:00:00Z"
+
+[[severity_overrides]]
+name = "xid-63-driver-550"
+agent = "syslog-health-monitor"
+error_codes = ["63", "64"]
+metadata = { driver_version = "550.54.15" }
+severity = "WARNING"
+reason = "Spurious Xid 63 on driver 550.54.15, bug 4711"
+
+[[severity_overrides]]
+name = "xid-13-fatal"
+check_name = "SysLogsXIDError"
+error_codes = ["13"]
+severity = "FATAL"
+reason = "Xid 13 precedes hangs on this fleet"
+`), 0o600))
+
+	cfg, err := LoadTomlConfig(path)
+	require.NoError(t, err)
+	require.Len(t, cfg.SeverityOverrides, 3)
+	assert.Equal(t, "CONTACT_SUPPORT", cfg.SeverityOverrides[2].RecommendedAction)
+
+	now := time.Date(2025, 6, 15, 0, 0, 0, 0, time.UTC)
+	event := func(agent string, code string, driver string) *protos.HealthEvent {
+		return &protos.HealthEvent{
+			Agent:     agent,
+			CheckName: "SysLogsXIDError",
+			ErrorCode: []string{code},
+			Metadata:  map[string]string{"driver_version": driver},
+		}
+	}
+
+	override, ok := cfg.SeverityOverrideFor(event("syslog-health-monitor", "63", "550.54.15"), now)
+	require.True(t, ok)
+	assert.Equal(t, "xid-63-driver-550", override.Name)
+
+	_, ok = cfg.SeverityOverrideFor(event("syslog-health-monitor", "63", "560.28.03"), now)
+	assert.False(t, ok, "other drivers are not affected")
+
+	_, ok = cfg.SeverityOverrideFor(event("gpu-health-monitor", "64", "550.54.15"), now)
+	assert.False(t, ok, "other agents are not affected")
+
+	override, ok = cfg.SeverityOverrideFor(event("gpu-health-monitor", "13", ""), now)
+	require.True(t, ok)
+	assert.Equal(t, "xid-13-fatal", override.Name)
+
+	override, ok = cfg.SeverityOverrideFor(event("syslog-health-monitor", "63", "560.28.03"),
+		time.Date(2025, 5, 1, 0, 0, 0, 0, time.UTC))
+	require.True(t, ok, "the first override applies until it expires")
+	assert.Equal(t, "expired", override.Name)
+}
+
+func TestLoadTomlConfig_DuplicateSeverityOverride(t *testing.T) {
+	_, err := LoadTomlConfigFromBytes([]byte(`
+[[severity_overrides]]
+name = "xid-63"
+error_codes = ["63"]
+severity = "WARNING"
+reason = "bug 4711"
+
+[[severity_overrides]]
+name = "xid-63"
+error_codes = ["64"]
+severity = "WARNING"
+reason = "bug 4712"
+`))
+	assert.ErrorContains(t, err, "duplicate override xid-63")
+}
@@ -0,0 +1,76 @@
+// Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"fmt"
+	"time"
+
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+const (
+	defaultShardingRefreshInterval = "15s"
+	defaultShardingVirtualNodes    = 100
+)
+
+// Sharding spreads the nodes over the analyzer replicas with consistent hashing,
+// each replica evaluates the rules for the events of its nodes only. The replicas
+// are the ready pods matching PodSelector in the namespace of the analyzer.
+type Sharding struct {
+	// PodSelector is the label selector of the analyzer pods.
+	PodSelector string `toml:"pod_selector"`
+	// RefreshInterval is how often the replicas are listed, defaults to "15s".
+	RefreshInterval string `toml:"refresh_interval"`
+	// VirtualNodes is the number of points of a replica on the hash ring, more
+	// points spread the nodes more evenly. Defaults to 100.
+	VirtualNodes int `toml:"virtual_nodes"`
+}
+
+// Validate checks the configuration and fills in defaults.
+func (c *Sharding) Validate() error {
+	if c.PodSelector == "" {
+		return fmt.Errorf("sharding: pod_selector is required")
+	}
+
+	if _, err := labels.Parse(c.PodSelector); err != nil {
+		return fmt.Errorf("sharding: invalid pod_selector %q: %w", c.PodSelector, err)
+	}
+
+	if c.RefreshInterval == "" {
+		c.RefreshInterval = defaultShardingRefreshInterval
+	}
+
+	if interval, err := time.ParseDuration(c.RefreshInterval); err != nil || interval <= 0 {
+		return fmt.Errorf("sharding: invalid refresh_interval %q", c.RefreshInterval)
+	}
+
+	if c.VirtualNodes == 0 {
+		c.VirtualNodes = defaultShardingVirtualNodes
+	}
+
+	if c.VirtualNodes < 0 {
+		return fmt.Errorf("sharding: virtual_nodes must be positive")
+	}
+
+	return nil
+}
+
+// RefreshIntervalDuration returns the parsed RefreshInterval.
+func (c *Sharding) RefreshIntervalDuration() time.Duration {
+	// Validate guarantees a parsable duration
+	interval, _ := time.ParseDuration(c.RefreshInterval)
+	return interval
+}
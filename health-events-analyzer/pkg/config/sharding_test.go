@@ -0,0 +1,56 @@
+// Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSharding_Validate(t *testing.T) {
+	tests := []struct {
+		name     string
+		sharding Sharding
+		valid    bool
+	}{
+		{name: "defaults", sharding: Sharding{PodSelector: "app=health-events-analyzer"}, valid: true},
+		{name: "missing pod_selector", sharding: Sharding{}},
+		{name: "invalid pod_selector", sharding: Sharding{PodSelector: "app in ("}},
+		{name: "invalid refresh_interval", sharding: Sharding{PodSelector: "app=a", RefreshInterval: "often"}},
+		{name: "negative virtual_nodes", sharding: Sharding{PodSelector: "app=a", VirtualNodes: -1}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.sharding.Validate()
+			if tt.valid {
+				assert.NoError(t, err)
+			} else {
+				assert.Error(t, err)
+			}
+		})
+	}
+}
+
+func TestSharding_Defaults(t *testing.T) {
+	sharding := Sharding{PodSelector: "app=health-events-analyzer"}
+	require.NoError(t, sharding.Validate())
+
+	assert.Equal(t, 15*time.Second, sharding.RefreshIntervalDuration())
+	assert.Equal(t, 100, sharding.VirtualNodes)
+}
@@ -0,0 +1,54 @@
+// Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"fmt"
+
+	protos "github.com/nvidia/nvsentinel/data-models/pkg/protos"
+)
+
+const defaultTelemetryAgent = "gpu-health-monitor"
+
+// TelemetryConfirmation confirms the faults detected in the logs against the live
+// DCGM health watches before a rule acts on them. The latest events of the watches
+// cross-referenced to the error codes of the event are looked up on the node; when
+// all of them report the GPUs healthy, the log line is likely stale or replayed and
+// the recommended action is replaced with the more conservative RecommendedAction.
+type TelemetryConfirmation struct {
+	// TelemetryAgent is the agent publishing the DCGM health watch events, defaults
+	// to "gpu-health-monitor".
+	TelemetryAgent string `toml:"telemetry_agent"`
+	// RecommendedAction replaces the action of events the telemetry contradicts,
+	// defaults to CONTACT_SUPPORT.
+	RecommendedAction string `toml:"recommended_action"`
+}
+
+// Validate checks the configuration and fills in defaults.
+func (c *TelemetryConfirmation) Validate() error {
+	if c.TelemetryAgent == "" {
+		c.TelemetryAgent = defaultTelemetryAgent
+	}
+
+	if c.RecommendedAction == "" {
+		c.RecommendedAction = protos.RecommendedAction_CONTACT_SUPPORT.String()
+	}
+
+	if _, ok := protos.RecommendedAction_value[c.RecommendedAction]; !ok {
+		return fmt.Errorf("telemetry_confirmation: invalid recommended_action %q", c.RecommendedAction)
+	}
+
+	return nil
+}
@@ -0,0 +1,50 @@
+// Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTelemetryConfirmation_Validate(t *testing.T) {
+	confirmation := TelemetryConfirmation{}
+	require.NoError(t, confirmation.Validate())
+	assert.Equal(t, "gpu-health-monitor", confirmation.TelemetryAgent)
+	assert.Equal(t, "CONTACT_SUPPORT", confirmation.RecommendedAction)
+
+	confirmation = TelemetryConfirmation{RecommendedAction: "PANIC"}
+	assert.Error(t, confirmation.Validate())
+}
+
+func TestLoadTomlConfig_TelemetryConfirmation(t *testing.T) {
+	cfg, err := LoadTomlConfigFromBytes([]byte(`
+[telemetry_confirmation]
+telemetry_agent = "dcgm-monitor"
+recommended_action = "NONE"
+`))
+	require.NoError(t, err)
+	require.NotNil(t, cfg.TelemetryConfirmation)
+	assert.Equal(t, "dcgm-monitor", cfg.TelemetryConfirmation.TelemetryAgent)
+	assert.Equal(t, "NONE", cfg.TelemetryConfirmation.RecommendedAction)
+
+	_, err = LoadTomlConfigFromBytes([]byte(`
+[telemetry_confirmation]
+recommended_action = "PANIC"
+`))
+	assert.Error(t, err)
+}
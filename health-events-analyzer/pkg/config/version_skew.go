@@ -0,0 +1,107 @@
+// Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"golang.org/x/mod/semver"
+)
+
+const (
+	defaultVersionSkewStaleAfter = "15m"
+	defaultVersionSkewInterval   = "1m"
+)
+
+// VersionSkew flags agents that run an unsupported version, use a stale rule pack
+// or stopped sending heartbeats, from the heartbeats the agents report.
+type VersionSkew struct {
+	// MinAgentVersions maps agent names to the oldest supported version, e.g.
+	// syslog-health-monitor = "v0.6.0". Agents not listed are not checked.
+	MinAgentVersions map[string]string `toml:"min_agent_versions"`
+	// MinRulePackVersions maps rule pack names to their oldest supported version.
+	// Rule packs not listed are stale when older than the newest version of the
+	// pack reported in the cluster.
+	MinRulePackVersions map[string]string `toml:"min_rule_pack_versions"`
+	// StaleAfter is how long after its last heartbeat an agent is missing,
+	// defaults to "15m".
+	StaleAfter string `toml:"stale_after"`
+	// Interval is how often the heartbeats are checked, defaults to "1m".
+	Interval string `toml:"interval"`
+}
+
+// Validate checks the configuration and fills in defaults.
+func (c *VersionSkew) Validate() error {
+	if c.StaleAfter == "" {
+		c.StaleAfter = defaultVersionSkewStaleAfter
+	}
+
+	if staleAfter, err := time.ParseDuration(c.StaleAfter); err != nil || staleAfter <= 0 {
+		return fmt.Errorf("version_skew: invalid stale_after %q", c.StaleAfter)
+	}
+
+	if c.Interval == "" {
+		c.Interval = defaultVersionSkewInterval
+	}
+
+	if interval, err := time.ParseDuration(c.Interval); err != nil || interval <= 0 {
+		return fmt.Errorf("version_skew: invalid interval %q", c.Interval)
+	}
+
+	for agent, version := range c.MinAgentVersions {
+		if _, ok := ParseVersion(version); !ok {
+			return fmt.Errorf("version_skew: invalid minimum version %q of agent %s", version, agent)
+		}
+	}
+
+	for pack, version := range c.MinRulePackVersions {
+		if _, ok := ParseVersion(version); !ok {
+			return fmt.Errorf("version_skew: invalid minimum version %q of rule pack %s", version, pack)
+		}
+	}
+
+	return nil
+}
+
+// StaleAfterDuration returns the parsed StaleAfter.
+func (c *VersionSkew) StaleAfterDuration() time.Duration {
+	// Validate guarantees a parsable duration
+	staleAfter, _ := time.ParseDuration(c.StaleAfter)
+	return staleAfter
+}
+
+// IntervalDuration returns the parsed Interval.
+func (c *VersionSkew) IntervalDuration() time.Duration {
+	// Validate guarantees a parsable duration
+	interval, _ := time.ParseDuration(c.Interval)
+	return interval
+}
+
+// ParseVersion returns the canonical semantic version of version, which may omit
+// the leading "v" and trailing components, e.g. "1" for rule packs. It returns
+// false when version is not a semantic version, like "dev" builds.
+func ParseVersion(version string) (string, bool) {
+	if !strings.HasPrefix(version, "v") {
+		version = "v" + version
+	}
+
+	if !semver.IsValid(version) {
+		return "", false
+	}
+
+	return semver.Canonical(version), true
+}
@@ -0,0 +1,88 @@
+// Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVersionSkew_Validate(t *testing.T) {
+	tests := []struct {
+		name  string
+		skew  VersionSkew
+		valid bool
+	}{
+		{name: "defaults", skew: VersionSkew{}, valid: true},
+		{name: "minimum versions", skew: VersionSkew{
+			MinAgentVersions:    map[string]string{"syslog-health-monitor": "v0.6.0"},
+			MinRulePackVersions: map[string]string{"GB200": "2"},
+		}, valid: true},
+		{name: "invalid stale_after", skew: VersionSkew{StaleAfter: "soon"}},
+		{name: "invalid agent version", skew: VersionSkew{MinAgentVersions: map[string]string{"a": "latest"}}},
+		{name: "invalid rule pack version", skew: VersionSkew{MinRulePackVersions: map[string]string{"GB200": "x"}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.skew.Validate()
+			if tt.valid {
+				assert.NoError(t, err)
+			} else {
+				assert.Error(t, err)
+			}
+		})
+	}
+}
+
+func TestParseVersion(t *testing.T) {
+	for version, expected := range map[string]string{
+		"v0.6.0":       "v0.6.0",
+		"0.6.0":        "v0.6.0",
+		"1":            "v1.0.0",
+		"v1.2.0-rc.1":  "v1.2.0-rc.1",
+		"dev":          "",
+		"":             "",
+		"v1.2.3.4":     "",
+		"v1.2.0+build": "v1.2.0",
+	} {
+		canonical, ok := ParseVersion(version)
+		assert.Equal(t, expected != "", ok, version)
+		assert.Equal(t, expected, canonical, version)
+	}
+}
+
+func TestLoadTomlConfig_VersionSkew(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.toml")
+	require.NoError(t, os.WriteFile(path, []byte(`
+[version_skew]
+stale_after = "10m"
+
+[version_skew.min_agent_versions]
+syslog-health-monitor = "v0.6.0"
+`), 0o600))
+
+	cfg, err := LoadTomlConfig(path)
+	require.NoError(t, err)
+	require.NotNil(t, cfg.VersionSkew)
+	assert.Equal(t, 10*time.Minute, cfg.VersionSkew.StaleAfterDuration())
+	assert.Equal(t, time.Minute, cfg.VersionSkew.IntervalDuration())
+	assert.Equal(t, "v0.6.0", cfg.VersionSkew.MinAgentVersions["syslog-health-monitor"])
+}
@@ -0,0 +1,208 @@
+// Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package events serves the stored health events matching a filter expression
+// of package query.
+package events
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/nvidia/nvsentinel/data-models/pkg/protos"
+	"github.com/nvidia/nvsentinel/health-events-analyzer/pkg/query"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+const (
+	// PathPrefix is where the handler is served
+	PathPrefix = "/events"
+
+	defaultLimit = 100
+	maxLimit     = 1000
+	queryTimeout = 30 * time.Second
+
+	timestamp = "healthevent.generatedtimestamp.seconds"
+)
+
+// Aggregator runs aggregation pipelines on the health events collection.
+type Aggregator interface {
+	Aggregate(ctx context.Context, pipeline interface{}, opts ...*options.AggregateOptions) (*mongo.Cursor, error)
+}
+
+// Entity is an entity impacted by an event.
+type Entity struct {
+	Type  string `json:"type"`
+	Value string `json:"value"`
+}
+
+// Event is a stored health event.
+type Event struct {
+	ID                string            `json:"id"`
+	NodeName          string            `json:"nodeName"`
+	Agent             string            `json:"agent"`
+	CheckName         string            `json:"checkName"`
+	ComponentClass    string            `json:"componentClass"`
+	ErrorCodes        []string          `json:"errorCodes,omitempty"`
+	Message           string            `json:"message"`
+	IsFatal           bool              `json:"isFatal"`
+	IsHealthy         bool              `json:"isHealthy"`
+	RecommendedAction string            `json:"recommendedAction"`
+	Entities          []Entity          `json:"entities,omitempty"`
+	Metadata          map[string]string `json:"metadata,omitempty"`
+	GeneratedAt       time.Time         `json:"generatedAt"`
+}
+
+// Response is the body served by the handler.
+type Response struct {
+	Query  string  `json:"query"`
+	Events []Event `json:"events"`
+	// Truncated is set when more events match than the limit
+	Truncated bool `json:"truncated"`
+}
+
+// Handler serves the stored health events matching a query.
+type Handler struct {
+	collection Aggregator
+	now        func() time.Time
+}
+
+// NewHandler creates a Handler.
+func NewHandler(collection Aggregator) *Handler {
+	return &Handler{collection: collection, now: time.Now}
+}
+
+// ServeHTTP serves GET /events?query=&limit=, the most recent events first.
+// query is a filter expression and matches every event when empty, limit
+// defaults to 100 and is at most 1000.
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+
+	expression := r.URL.Query().Get("query")
+
+	filter, err := query.Parse(expression, h.now())
+	if err != nil {
+		http.Error(w, fmt.Sprintf("invalid query: %v", err), http.StatusBadRequest)
+		return
+	}
+
+	limit := defaultLimit
+
+	if value := r.URL.Query().Get("limit"); value != "" {
+		limit, err = strconv.Atoi(value)
+		if err != nil || limit < 1 || limit > maxLimit {
+			http.Error(w, fmt.Sprintf("limit must be between 1 and %d", maxLimit), http.StatusBadRequest)
+			return
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), queryTimeout)
+	defer cancel()
+
+	events, truncated, err := h.Events(ctx, filter, limit)
+	if err != nil {
+		slog.Error("Failed to query health events", "query", expression, "error", err)
+		http.Error(w, "failed to query events", http.StatusInternalServerError)
+
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+
+	response := Response{Query: expression, Events: events, Truncated: truncated}
+	if err := json.NewEncoder(w).Encode(response); err != nil {
+		slog.Error("Failed to encode health events", "error", err)
+	}
+}
+
+// Events returns up to limit events matching filter, the most recent first,
+// and whether more events match.
+func (h *Handler) Events(ctx context.Context, filter bson.M, limit int) ([]Event, bool, error) {
+	pipeline := []bson.M{
+		{"$match": filter},
+		{"$sort": bson.D{{Key: timestamp, Value: -1}, {Key: "_id", Value: -1}}},
+		{"$limit": limit + 1},
+	}
+
+	cursor, err := h.collection.Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to query events: %w", err)
+	}
+
+	defer cursor.Close(ctx)
+
+	var documents []struct {
+		ID          primitive.ObjectID  `bson:"_id"`
+		HealthEvent *protos.HealthEvent `bson:"healthevent"`
+	}
+
+	if err := cursor.All(ctx, &documents); err != nil {
+		return nil, false, fmt.Errorf("failed to decode events: %w", err)
+	}
+
+	truncated := len(documents) > limit
+	if truncated {
+		documents = documents[:limit]
+	}
+
+	events := make([]Event, 0, len(documents))
+
+	for _, document := range documents {
+		if document.HealthEvent == nil {
+			continue
+		}
+
+		events = append(events, NewEvent(document.ID.Hex(), document.HealthEvent))
+	}
+
+	return events, truncated, nil
+}
+
+// NewEvent converts the stored health event with the ID.
+func NewEvent(id string, healthEvent *protos.HealthEvent) Event {
+	event := Event{
+		ID:                id,
+		NodeName:          healthEvent.NodeName,
+		Agent:             healthEvent.Agent,
+		CheckName:         healthEvent.CheckName,
+		ComponentClass:    healthEvent.ComponentClass,
+		ErrorCodes:        healthEvent.ErrorCode,
+		Message:           healthEvent.Message,
+		IsFatal:           healthEvent.IsFatal,
+		IsHealthy:         healthEvent.IsHealthy,
+		RecommendedAction: healthEvent.RecommendedAction.String(),
+		Metadata:          healthEvent.Metadata,
+	}
+
+	for _, entity := range healthEvent.EntitiesImpacted {
+		event.Entities = append(event.Entities, Entity{Type: entity.EntityType, Value: entity.EntityValue})
+	}
+
+	if healthEvent.GeneratedTimestamp != nil {
+		event.GeneratedAt = healthEvent.GeneratedTimestamp.AsTime().UTC()
+	}
+
+	return event
+}
@@ -0,0 +1,118 @@
+// Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package events
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/nvidia/nvsentinel/data-models/pkg/protos"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+type fakeCollection struct {
+	documents []interface{}
+	pipeline  []bson.M
+}
+
+func (f *fakeCollection) Aggregate(_ context.Context, pipeline interface{},
+	_ ...*options.AggregateOptions) (*mongo.Cursor, error) {
+	f.pipeline = pipeline.([]bson.M)
+
+	documents := make([]interface{}, 0, len(f.documents))
+
+	for _, document := range f.documents {
+		data, err := bson.Marshal(document)
+		if err != nil {
+			return nil, err
+		}
+
+		documents = append(documents, bson.Raw(data))
+	}
+
+	return mongo.NewCursorFromDocuments(documents, nil, nil)
+}
+
+var testNow = time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+
+func storedEvent(nodeName string) bson.M {
+	return bson.M{
+		"_id": primitive.NewObjectID(),
+		"healthevent": &protos.HealthEvent{
+			NodeName:           nodeName,
+			Agent:              "syslog-health-monitor",
+			CheckName:          "SysLogsXIDError",
+			ErrorCode:          []string{"79"},
+			IsFatal:            true,
+			RecommendedAction:  protos.RecommendedAction_RESTART_BM,
+			EntitiesImpacted:   []*protos.Entity{{EntityType: "GPU", EntityValue: "0"}},
+			GeneratedTimestamp: timestamppb.New(testNow.Add(-time.Hour)),
+		},
+	}
+}
+
+func TestServeHTTP(t *testing.T) {
+	collection := &fakeCollection{documents: []interface{}{storedEvent("h100-1"), storedEvent("h100-2")}}
+	handler := NewHandler(collection)
+	handler.now = func() time.Time { return testNow }
+
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet,
+		`/events?limit=1&query=node+%3D~+%22h100-*%22+and+time+%3E+now-24h`, nil))
+	require.Equal(t, http.StatusOK, recorder.Code, recorder.Body.String())
+
+	var response Response
+	require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &response))
+
+	assert.Equal(t, `node =~ "h100-*" and time > now-24h`, response.Query)
+	assert.True(t, response.Truncated)
+	require.Len(t, response.Events, 1)
+
+	event := response.Events[0]
+	assert.Equal(t, "h100-1", event.NodeName)
+	assert.Equal(t, []string{"79"}, event.ErrorCodes)
+	assert.Equal(t, "RESTART_BM", event.RecommendedAction)
+	assert.Equal(t, []Entity{{Type: "GPU", Value: "0"}}, event.Entities)
+	assert.Equal(t, testNow.Add(-time.Hour), event.GeneratedAt)
+	assert.NotEmpty(t, event.ID)
+
+	match := collection.pipeline[0]["$match"].(bson.M)
+	assert.Len(t, match["$and"], 2)
+	assert.Equal(t, 2, collection.pipeline[2]["$limit"])
+}
+
+func TestServeHTTPInvalidRequest(t *testing.T) {
+	handler := NewHandler(&fakeCollection{})
+
+	for _, target := range []string{"/events?query=node", "/events?limit=0", "/events?limit=1001"} {
+		recorder := httptest.NewRecorder()
+		handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, target, nil))
+		assert.Equal(t, http.StatusBadRequest, recorder.Code, target)
+	}
+
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodPost, "/events", nil))
+	assert.Equal(t, http.StatusMethodNotAllowed, recorder.Code)
+}
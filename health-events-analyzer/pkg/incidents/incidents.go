@@ -0,0 +1,282 @@
+// Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package incidents serves the classification tags of the incidents the analyzer
+// published and lets operators set them manually.
+package incidents
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/nvidia/nvsentinel/data-models/pkg/model"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+const (
+	// PathPrefix is where the handler is served
+	PathPrefix = "/incidents/tags"
+
+	queryTimeout   = 30 * time.Second
+	maxRequestSize = 64 * 1024
+)
+
+// ErrNotFound is returned for correlation IDs without stored events.
+var ErrNotFound = errors.New("incident not found")
+
+// Collection queries and updates the health events collection.
+type Collection interface {
+	Aggregate(ctx context.Context, pipeline interface{}, opts ...*options.AggregateOptions) (*mongo.Cursor, error)
+	UpdateMany(ctx context.Context, filter interface{}, update interface{},
+		opts ...*options.UpdateOptions) (*mongo.UpdateResult, error)
+}
+
+// Tags is the classification of an incident. Tags set manually take precedence
+// over the tags the analyzer classified the incident with.
+type Tags struct {
+	CorrelationID string   `json:"correlationId"`
+	Tags          []string `json:"tags"`
+	// Source is manual, the rule, the classifier that set the tags or unknown
+	Source       string     `json:"source,omitempty"`
+	Reason       string     `json:"reason,omitempty"`
+	SetBy        string     `json:"setBy,omitempty"`
+	ClassifiedAt *time.Time `json:"classifiedAt,omitempty"`
+}
+
+// SetRequest is the body of PUT /incidents/tags.
+type SetRequest struct {
+	CorrelationID string   `json:"correlationId"`
+	Tags          []string `json:"tags"`
+	Reason        string   `json:"reason"`
+	SetBy         string   `json:"setBy"`
+}
+
+// Handler serves the classification tags of incidents.
+type Handler struct {
+	collection Collection
+	now        func() time.Time
+}
+
+// NewHandler creates a Handler.
+func NewHandler(collection Collection) *Handler {
+	return &Handler{collection: collection, now: time.Now}
+}
+
+// ServeHTTP serves GET /incidents/tags?correlationId=<id> and PUT /incidents/tags.
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), queryTimeout)
+	defer cancel()
+
+	var (
+		tags *Tags
+		err  error
+	)
+
+	switch r.Method {
+	case http.MethodGet:
+		correlationID := r.URL.Query().Get("correlationId")
+		if correlationID == "" {
+			http.Error(w, "correlationId is required", http.StatusBadRequest)
+			return
+		}
+
+		tags, err = h.Get(ctx, correlationID)
+	case http.MethodPut:
+		var request SetRequest
+
+		decoder := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxRequestSize))
+		decoder.DisallowUnknownFields()
+
+		if err := decoder.Decode(&request); err != nil {
+			http.Error(w, fmt.Sprintf("invalid request: %v", err), http.StatusBadRequest)
+			return
+		}
+
+		tags, err = h.Set(ctx, request)
+	default:
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+
+	var invalid *invalidRequestError
+
+	switch {
+	case errors.Is(err, ErrNotFound):
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	case errors.As(err, &invalid):
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	case err != nil:
+		slog.Error("Failed to serve incident tags", "error", err)
+		http.Error(w, "failed to serve incident tags", http.StatusInternalServerError)
+
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+
+	if err := json.NewEncoder(w).Encode(tags); err != nil {
+		slog.Error("Failed to encode incident tags", "error", err)
+	}
+}
+
+type invalidRequestError struct {
+	err error
+}
+
+func (e *invalidRequestError) Error() string {
+	return e.err.Error()
+}
+
+// Get returns the classification of the incident with the correlation ID.
+func (h *Handler) Get(ctx context.Context, correlationID string) (*Tags, error) {
+	cursor, err := h.collection.Aggregate(ctx, pipeline(correlationID))
+	if err != nil {
+		return nil, fmt.Errorf("failed to query incident %s: %w", correlationID, err)
+	}
+
+	defer cursor.Close(ctx)
+
+	var results []struct {
+		Manual []struct {
+			Classification model.IncidentClassification `bson:"classification"`
+		} `bson:"manual"`
+		Automatic []struct {
+			Tags      string    `bson:"tags"`
+			Source    string    `bson:"source"`
+			CreatedAt time.Time `bson:"createdAt"`
+		} `bson:"automatic"`
+		Events []struct {
+			Count int `bson:"count"`
+		} `bson:"events"`
+	}
+
+	if err := cursor.All(ctx, &results); err != nil {
+		return nil, fmt.Errorf("failed to decode incident %s: %w", correlationID, err)
+	}
+
+	if len(results) == 0 || len(results[0].Events) == 0 || results[0].Events[0].Count == 0 {
+		return nil, fmt.Errorf("%w: %s", ErrNotFound, correlationID)
+	}
+
+	result := results[0]
+	tags := &Tags{CorrelationID: correlationID, Tags: []string{}}
+
+	switch {
+	case len(result.Manual) > 0:
+		classification := result.Manual[0].Classification
+		for _, tag := range classification.Tags {
+			tags.Tags = append(tags.Tags, string(tag))
+		}
+
+		classifiedAt := classification.ClassifiedAt.UTC()
+		tags.Source = classification.Source
+		tags.Reason = classification.Reason
+		tags.SetBy = classification.SetBy
+		tags.ClassifiedAt = &classifiedAt
+	case len(result.Automatic) > 0:
+		automatic := result.Automatic[0]
+		classifiedAt := automatic.CreatedAt.UTC()
+		tags.Tags = strings.Split(automatic.Tags, ",")
+		tags.Source = automatic.Source
+		tags.ClassifiedAt = &classifiedAt
+	}
+
+	return tags, nil
+}
+
+// Set records the tags set manually on the stored events of the incident.
+func (h *Handler) Set(ctx context.Context, request SetRequest) (*Tags, error) {
+	if request.CorrelationID == "" {
+		return nil, &invalidRequestError{errors.New("correlationId is required")}
+	}
+
+	if len(request.Tags) == 0 {
+		return nil, &invalidRequestError{errors.New("tags are required")}
+	}
+
+	tags, err := model.ParseIncidentTags(request.Tags)
+	if err != nil {
+		return nil, &invalidRequestError{err}
+	}
+
+	classification := model.IncidentClassification{
+		Tags:         tags,
+		Source:       model.IncidentTagSourceManual,
+		Reason:       request.Reason,
+		SetBy:        request.SetBy,
+		ClassifiedAt: h.now().UTC(),
+	}
+
+	result, err := h.collection.UpdateMany(ctx,
+		bson.M{"healthevent.correlationid": request.CorrelationID},
+		bson.M{"$set": bson.M{"healtheventstatus.classification": classification}})
+	if err != nil {
+		return nil, fmt.Errorf("failed to tag incident %s: %w", request.CorrelationID, err)
+	}
+
+	if result.MatchedCount == 0 {
+		return nil, fmt.Errorf("%w: %s", ErrNotFound, request.CorrelationID)
+	}
+
+	slog.Info("Incident tagged manually",
+		"correlation_id", request.CorrelationID,
+		"tags", request.Tags,
+		"set_by", request.SetBy,
+		"reason", request.Reason)
+
+	return &Tags{
+		CorrelationID: request.CorrelationID,
+		Tags:          request.Tags,
+		Source:        classification.Source,
+		Reason:        classification.Reason,
+		SetBy:         classification.SetBy,
+		ClassifiedAt:  &classification.ClassifiedAt,
+	}, nil
+}
+
+// pipeline looks up the latest manual and automatic classification of the events of
+// the incident, and counts them.
+func pipeline(correlationID string) []bson.M {
+	return []bson.M{
+		{"$match": bson.M{"healthevent.correlationid": correlationID}},
+		{"$sort": bson.M{"_id": -1}},
+		{"$facet": bson.M{
+			"manual": []bson.M{
+				{"$match": bson.M{"healtheventstatus.classification": bson.M{"$exists": true}}},
+				{"$limit": 1},
+				{"$project": bson.M{"classification": "$healtheventstatus.classification"}},
+			},
+			"automatic": []bson.M{
+				{"$match": bson.M{"healthevent.metadata." + model.IncidentTagsMetadataKey: bson.M{"$exists": true}}},
+				{"$limit": 1},
+				{"$project": bson.M{
+					"tags":      "$healthevent.metadata." + model.IncidentTagsMetadataKey,
+					"source":    "$healthevent.metadata." + model.IncidentTagSourceMetadataKey,
+					"createdAt": "$createdAt",
+				}},
+			},
+			"events": []bson.M{{"$count": "count"}},
+		}},
+	}
+}
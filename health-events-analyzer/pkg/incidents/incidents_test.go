@@ -0,0 +1,133 @@
+// Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package incidents
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/nvidia/nvsentinel/data-models/pkg/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type fakeCollection struct {
+	result  bson.M
+	matched int64
+	filter  interface{}
+	update  interface{}
+}
+
+func (f *fakeCollection) Aggregate(_ context.Context, _ interface{},
+	_ ...*options.AggregateOptions) (*mongo.Cursor, error) {
+	data, err := bson.Marshal(f.result)
+	if err != nil {
+		return nil, err
+	}
+
+	return mongo.NewCursorFromDocuments([]interface{}{bson.Raw(data)}, nil, nil)
+}
+
+func (f *fakeCollection) UpdateMany(_ context.Context, filter interface{}, update interface{},
+	_ ...*options.UpdateOptions) (*mongo.UpdateResult, error) {
+	f.filter = filter
+	f.update = update
+
+	return &mongo.UpdateResult{MatchedCount: f.matched, ModifiedCount: f.matched}, nil
+}
+
+var testNow = time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+
+func TestGet(t *testing.T) {
+	automatic := bson.A{bson.M{"tags": "thermal,hardware", "source": "rule", "createdAt": testNow.Add(-time.Hour)}}
+	events := bson.A{bson.M{"count": 3}}
+
+	collection := &fakeCollection{result: bson.M{"manual": bson.A{}, "automatic": automatic, "events": events}}
+	handler := NewHandler(collection)
+
+	tags, err := handler.Get(context.Background(), "incident-1")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"thermal", "hardware"}, tags.Tags)
+	assert.Equal(t, "rule", tags.Source)
+	assert.Equal(t, testNow.Add(-time.Hour), *tags.ClassifiedAt)
+
+	collection.result["manual"] = bson.A{bson.M{"classification": model.IncidentClassification{
+		Tags:         []model.IncidentTag{model.IncidentTagNetwork},
+		Source:       model.IncidentTagSourceManual,
+		Reason:       "switch firmware bug",
+		SetBy:        "oncall",
+		ClassifiedAt: testNow,
+	}}}
+
+	tags, err = handler.Get(context.Background(), "incident-1")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"network"}, tags.Tags, "manual tags take precedence")
+	assert.Equal(t, model.IncidentTagSourceManual, tags.Source)
+	assert.Equal(t, "oncall", tags.SetBy)
+
+	collection.result = bson.M{"manual": bson.A{}, "automatic": bson.A{}, "events": bson.A{}}
+
+	_, err = handler.Get(context.Background(), "incident-2")
+	assert.ErrorIs(t, err, ErrNotFound)
+}
+
+func TestServeHTTP(t *testing.T) {
+	collection := &fakeCollection{matched: 2}
+	handler := NewHandler(collection)
+	handler.now = func() time.Time { return testNow }
+
+	put := func(body string) *httptest.ResponseRecorder {
+		recorder := httptest.NewRecorder()
+		handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodPut, PathPrefix, strings.NewReader(body)))
+
+		return recorder
+	}
+
+	recorder := put(`{"correlationId": "incident-1", "tags": ["driver"], "reason": "bad driver rollout", "setBy": "oncall"}`)
+	require.Equal(t, http.StatusOK, recorder.Code, recorder.Body.String())
+
+	var tags Tags
+	require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &tags))
+	assert.Equal(t, []string{"driver"}, tags.Tags)
+	assert.Equal(t, model.IncidentTagSourceManual, tags.Source)
+	assert.Equal(t, testNow, *tags.ClassifiedAt)
+	assert.Equal(t, bson.M{"healthevent.correlationid": "incident-1"}, collection.filter)
+
+	classification := collection.update.(bson.M)["$set"].(bson.M)["healtheventstatus.classification"]
+	assert.Equal(t, []model.IncidentTag{model.IncidentTagDriver}, classification.(model.IncidentClassification).Tags)
+
+	assert.Equal(t, http.StatusBadRequest, put(`{"correlationId": "incident-1", "tags": ["power"]}`).Code)
+	assert.Equal(t, http.StatusBadRequest, put(`{"tags": ["driver"]}`).Code)
+	assert.Equal(t, http.StatusBadRequest, put(`{"correlationId": "incident-1", "tags": ["driver"], "owner": "x"}`).Code)
+
+	collection.matched = 0
+	assert.Equal(t, http.StatusNotFound, put(`{"correlationId": "incident-3", "tags": ["driver"]}`).Code)
+
+	recorder = httptest.NewRecorder()
+	handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, PathPrefix, nil))
+	assert.Equal(t, http.StatusBadRequest, recorder.Code)
+
+	recorder = httptest.NewRecorder()
+	handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodDelete, PathPrefix, nil))
+	assert.Equal(t, http.StatusMethodNotAllowed, recorder.Code)
+}
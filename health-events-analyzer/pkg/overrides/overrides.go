@@ -0,0 +1,179 @@
+// Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package overrides serves the audit of the cluster severity overrides: the
+// configured overrides, whether they are in effect and the events they were
+// applied to.
+package overrides
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/nvidia/nvsentinel/health-events-analyzer/pkg/config"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+const (
+	// PathPrefix is where the handler is served
+	PathPrefix = "/severity-overrides"
+
+	queryTimeout = 30 * time.Second
+)
+
+// Aggregator runs aggregation pipelines on the health events collection.
+type Aggregator interface {
+	Aggregate(ctx context.Context, pipeline interface{}, opts ...*options.AggregateOptions) (*mongo.Cursor, error)
+}
+
+// Override is the audit of a single override.
+type Override struct {
+	Name              string            `json:"name"`
+	Agent             string            `json:"agent,omitempty"`
+	CheckName         string            `json:"checkName,omitempty"`
+	ErrorCodes        []string          `json:"errorCodes"`
+	Metadata          map[string]string `json:"metadata,omitempty"`
+	Severity          string            `json:"severity"`
+	RecommendedAction string            `json:"recommendedAction,omitempty"`
+	Reason            string            `json:"reason"`
+	ExpiresAt         string            `json:"expiresAt,omitempty"`
+	InEffect          bool              `json:"inEffect"`
+	// AppliedEvents is the number of stored events the override was applied to
+	AppliedEvents int        `json:"appliedEvents"`
+	LastAppliedAt *time.Time `json:"lastAppliedAt,omitempty"`
+}
+
+// Response is the body served by the handler.
+type Response struct {
+	GeneratedAt time.Time  `json:"generatedAt"`
+	Overrides   []Override `json:"overrides"`
+}
+
+// Handler serves the audit of the severity overrides.
+type Handler struct {
+	collection Aggregator
+	overrides  []config.SeverityOverride
+	now        func() time.Time
+}
+
+// NewHandler creates a Handler for the configured overrides.
+func NewHandler(collection Aggregator, overrides []config.SeverityOverride) *Handler {
+	return &Handler{collection: collection, overrides: overrides, now: time.Now}
+}
+
+// ServeHTTP serves GET /severity-overrides.
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), queryTimeout)
+	defer cancel()
+
+	response, err := h.Audit(ctx)
+	if err != nil {
+		slog.Error("Failed to audit severity overrides", "error", err)
+		http.Error(w, "failed to audit severity overrides", http.StatusInternalServerError)
+
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+
+	if err := json.NewEncoder(w).Encode(response); err != nil {
+		slog.Error("Failed to encode severity overrides", "error", err)
+	}
+}
+
+// Audit returns the configured overrides in order, with the events they were
+// applied to according to the audit records on the stored events.
+func (h *Handler) Audit(ctx context.Context) (*Response, error) {
+	now := h.now().UTC()
+	response := &Response{GeneratedAt: now, Overrides: []Override{}}
+
+	if len(h.overrides) == 0 {
+		return response, nil
+	}
+
+	names := make([]string, 0, len(h.overrides))
+	for _, override := range h.overrides {
+		names = append(names, override.Name)
+	}
+
+	cursor, err := h.collection.Aggregate(ctx, pipeline(names))
+	if err != nil {
+		return nil, fmt.Errorf("failed to aggregate severity overrides: %w", err)
+	}
+
+	defer cursor.Close(ctx)
+
+	var results []struct {
+		Name          string    `bson:"_id"`
+		Events        int       `bson:"events"`
+		LastAppliedAt time.Time `bson:"lastAppliedAt"`
+	}
+
+	if err := cursor.All(ctx, &results); err != nil {
+		return nil, fmt.Errorf("failed to decode severity overrides: %w", err)
+	}
+
+	applied := make(map[string]int, len(results))
+	for i, result := range results {
+		applied[result.Name] = i
+	}
+
+	for _, override := range h.overrides {
+		audit := Override{
+			Name:              override.Name,
+			Agent:             override.Agent,
+			CheckName:         override.CheckName,
+			ErrorCodes:        override.ErrorCodes,
+			Metadata:          override.Metadata,
+			Severity:          override.Severity,
+			RecommendedAction: override.RecommendedAction,
+			Reason:            override.Reason,
+			ExpiresAt:         override.ExpiresAt,
+			InEffect:          override.InEffect(now),
+		}
+
+		if i, ok := applied[override.Name]; ok {
+			audit.AppliedEvents = results[i].Events
+			lastAppliedAt := results[i].LastAppliedAt.UTC()
+			audit.LastAppliedAt = &lastAppliedAt
+		}
+
+		response.Overrides = append(response.Overrides, audit)
+	}
+
+	return response, nil
+}
+
+// pipeline counts the events each of the overrides was applied to.
+func pipeline(names []string) []bson.M {
+	return []bson.M{
+		{"$match": bson.M{"healtheventstatus.severityoverride.name": bson.M{"$in": names}}},
+		{"$group": bson.M{
+			"_id":           "$healtheventstatus.severityoverride.name",
+			"events":        bson.M{"$sum": 1},
+			"lastAppliedAt": bson.M{"$max": "$healtheventstatus.severityoverride.appliedat"},
+		}},
+	}
+}
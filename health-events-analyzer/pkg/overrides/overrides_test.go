@@ -0,0 +1,117 @@
+// Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package overrides
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/nvidia/nvsentinel/health-events-analyzer/pkg/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type fakeCollection struct {
+	results  []bson.M
+	pipeline []bson.M
+}
+
+func (f *fakeCollection) Aggregate(_ context.Context, pipeline interface{},
+	_ ...*options.AggregateOptions) (*mongo.Cursor, error) {
+	f.pipeline = pipeline.([]bson.M)
+
+	docs := make([]interface{}, 0, len(f.results))
+
+	for _, result := range f.results {
+		data, err := bson.Marshal(result)
+		if err != nil {
+			return nil, err
+		}
+
+		docs = append(docs, bson.Raw(data))
+	}
+
+	return mongo.NewCursorFromDocuments(docs, nil, nil)
+}
+
+var testNow = time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+
+func TestAudit(t *testing.T) {
+	lastApplied := testNow.Add(-time.Hour)
+	collection := &fakeCollection{results: []bson.M{
+		{"_id": "xid63-driver-bug", "events": 12, "lastAppliedAt": lastApplied},
+	}}
+
+	handler := NewHandler(collection, []config.SeverityOverride{
+		{
+			Name:       "xid63-driver-bug",
+			ErrorCodes: []string{"63"},
+			Severity:   "WARNING",
+			Reason:     "spurious on the current driver",
+			ExpiresAt:  "2025-07-01T00:00:00Z",
+		},
+		{
+			Name:              "xid48-rma",
+			ErrorCodes:        []string{"48"},
+			Severity:          "FATAL",
+			RecommendedAction: "CONTACT_SUPPORT",
+			Reason:            "needs RMA",
+			ExpiresAt:         "2025-05-01T00:00:00Z",
+		},
+	})
+	handler.now = func() time.Time { return testNow }
+
+	response, err := handler.Audit(context.Background())
+	require.NoError(t, err)
+	require.Len(t, response.Overrides, 2)
+
+	assert.Equal(t, bson.M{"healtheventstatus.severityoverride.name": bson.M{
+		"$in": []string{"xid63-driver-bug", "xid48-rma"},
+	}}, collection.pipeline[0]["$match"])
+
+	downgrade := response.Overrides[0]
+	assert.True(t, downgrade.InEffect)
+	assert.Equal(t, 12, downgrade.AppliedEvents)
+	require.NotNil(t, downgrade.LastAppliedAt)
+	assert.True(t, lastApplied.Equal(*downgrade.LastAppliedAt))
+
+	upgrade := response.Overrides[1]
+	assert.False(t, upgrade.InEffect)
+	assert.Zero(t, upgrade.AppliedEvents)
+	assert.Nil(t, upgrade.LastAppliedAt)
+}
+
+func TestServeHTTP(t *testing.T) {
+	handler := NewHandler(&fakeCollection{}, nil)
+
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodPost, PathPrefix, nil))
+	assert.Equal(t, http.StatusMethodNotAllowed, recorder.Code)
+
+	recorder = httptest.NewRecorder()
+	handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, PathPrefix, nil))
+	require.Equal(t, http.StatusOK, recorder.Code)
+
+	var response Response
+	require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &response))
+	assert.Empty(t, response.Overrides)
+}
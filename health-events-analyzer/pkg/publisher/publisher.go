@@ -20,6 +20,7 @@ import (
 	"log/slog"
 	"time"
 
+	datamodels "github.com/nvidia/nvsentinel/data-models/pkg/model"
 	protos "github.com/nvidia/nvsentinel/data-models/pkg/protos"
 
 	"google.golang.org/grpc/codes"
@@ -29,6 +30,9 @@ import (
 )
 
 const (
+	// Agent is the agent of the events published by the analyzer
+	Agent = "health-events-analyzer"
+
 	maxRetries int           = 5
 	delay      time.Duration = 5 * time.Second
 )
@@ -89,19 +93,59 @@ func (p *PublisherConfig) sendHealthEventWithRetry(ctx context.Context, healthEv
 	return nil
 }
 
+// Relationships link a published event to other stored events by their IDs.
+type Relationships struct {
+	CausedByID    string
+	SupersedesID  string
+	CorrelationID string
+}
+
+func (r Relationships) apply(event *protos.HealthEvent) {
+	event.CausedById = r.CausedByID
+	event.SupersedesId = r.SupersedesID
+	event.CorrelationId = r.CorrelationID
+}
+
 func NewPublisher(platformConnectorClient protos.PlatformConnectorClient) *PublisherConfig {
 	return &PublisherConfig{platformConnectorClient: platformConnectorClient}
 }
 
 func (p *PublisherConfig) Publish(ctx context.Context, event *protos.HealthEvent,
-	recommendedAction protos.RecommendedAction, ruleName string) error {
+	recommendedAction protos.RecommendedAction, ruleName string, relationships Relationships) error {
 	newEvent := proto.Clone(event).(*protos.HealthEvent)
 
-	newEvent.Agent = "health-events-analyzer"
+	newEvent.Id = datamodels.NewEventID()
+	newEvent.Agent = Agent
 	newEvent.CheckName = ruleName
 	newEvent.RecommendedAction = recommendedAction
 	newEvent.IsHealthy = false
 	newEvent.IsFatal = true
+	newEvent.Priority = protos.Priority_PRIORITY_HIGH
+	relationships.apply(newEvent)
+
+	req := &protos.HealthEvents{
+		Version: 1,
+		Events:  []*protos.HealthEvent{newEvent},
+	}
+
+	return p.sendHealthEventWithRetry(ctx, req)
+}
+
+// PublishHealthy publishes a healthy event for the rule, closing the incident the rule
+// raised on the node of the event.
+func (p *PublisherConfig) PublishHealthy(ctx context.Context, event *protos.HealthEvent, ruleName string,
+	relationships Relationships) error {
+	newEvent := proto.Clone(event).(*protos.HealthEvent)
+
+	newEvent.Id = datamodels.NewEventID()
+	newEvent.Agent = Agent
+	newEvent.CheckName = ruleName
+	newEvent.RecommendedAction = protos.RecommendedAction_NONE
+	newEvent.IsHealthy = true
+	newEvent.IsFatal = false
+	newEvent.ErrorCode = nil
+	newEvent.EntitiesImpacted = nil
+	relationships.apply(newEvent)
 
 	req := &protos.HealthEvents{
 		Version: 1,
@@ -0,0 +1,187 @@
+// Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package quarantine serves why fault quarantine quarantined a node, from the
+// quarantine reason and statuses it recorded on the stored events.
+package quarantine
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/nvidia/nvsentinel/data-models/pkg/model"
+	"github.com/nvidia/nvsentinel/data-models/pkg/protos"
+	"github.com/nvidia/nvsentinel/health-events-analyzer/pkg/events"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+const (
+	// PathPrefix is where the handler is served
+	PathPrefix = "/quarantine"
+
+	// maxEvents is the number of events of a quarantine looked at
+	maxEvents    = 200
+	queryTimeout = 30 * time.Second
+)
+
+// Aggregator runs aggregation pipelines on the health events collection.
+type Aggregator interface {
+	Aggregate(ctx context.Context, pipeline interface{}, opts ...*options.AggregateOptions) (*mongo.Cursor, error)
+}
+
+// Event is a stored event of a quarantine with the status fault quarantine recorded
+// on it.
+type Event struct {
+	events.Event
+	Status model.Status `json:"status"`
+}
+
+// Response is the body served by the handler.
+type Response struct {
+	NodeName string `json:"nodeName"`
+	// Quarantined is false when fault quarantine released the node or it was
+	// uncordoned manually, Status is then the status of the last event
+	Quarantined bool         `json:"quarantined"`
+	Status      model.Status `json:"status,omitempty"`
+	// Reason is unset for the quarantines recorded before reasons were
+	Reason *model.QuarantineReason `json:"reason,omitempty"`
+	// Events are the events of the current quarantine that kept the node
+	// quarantined, the most recent first and the one that quarantined it last
+	Events []Event `json:"events,omitempty"`
+	// Truncated is set when the quarantine has more events than are served
+	Truncated bool `json:"truncated"`
+}
+
+// Handler serves why nodes are quarantined.
+type Handler struct {
+	collection Aggregator
+}
+
+// NewHandler creates a Handler.
+func NewHandler(collection Aggregator) *Handler {
+	return &Handler{collection: collection}
+}
+
+// ServeHTTP serves GET /quarantine?node=<name>.
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+
+	nodeName := r.URL.Query().Get("node")
+	if nodeName == "" {
+		http.Error(w, "node is required", http.StatusBadRequest)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), queryTimeout)
+	defer cancel()
+
+	response, err := h.Get(ctx, nodeName)
+	if err != nil {
+		slog.Error("Failed to query quarantine of node", "node", nodeName, "error", err)
+		http.Error(w, "failed to query quarantine", http.StatusInternalServerError)
+
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+
+	if err := json.NewEncoder(w).Encode(response); err != nil {
+		slog.Error("Failed to encode quarantine", "error", err)
+	}
+}
+
+// Get returns the current quarantine of the node. It walks the events fault
+// quarantine recorded a status on back to the one that quarantined the node.
+func (h *Handler) Get(ctx context.Context, nodeName string) (*Response, error) {
+	pipeline := []bson.M{
+		{"$match": bson.M{
+			"healthevent.nodename": nodeName,
+			"healtheventstatus.nodequarantined": bson.M{"$in": []model.Status{
+				model.Quarantined, model.AlreadyQuarantined, model.UnQuarantined, model.Cancelled,
+			}},
+		}},
+		{"$sort": bson.M{"_id": -1}},
+		{"$limit": maxEvents},
+	}
+
+	cursor, err := h.collection.Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query events of node %s: %w", nodeName, err)
+	}
+
+	defer cursor.Close(ctx)
+
+	var documents []struct {
+		ID                primitive.ObjectID      `bson:"_id"`
+		HealthEvent       *protos.HealthEvent     `bson:"healthevent"`
+		HealthEventStatus model.HealthEventStatus `bson:"healtheventstatus"`
+	}
+
+	if err := cursor.All(ctx, &documents); err != nil {
+		return nil, fmt.Errorf("failed to decode events of node %s: %w", nodeName, err)
+	}
+
+	response := &Response{NodeName: nodeName}
+
+	for i, document := range documents {
+		status := *document.HealthEventStatus.NodeQuarantined
+
+		if status == model.UnQuarantined || status == model.Cancelled {
+			if i == 0 {
+				response.Status = status
+				return response, nil
+			}
+
+			// The event that quarantined the node was not recorded
+			break
+		}
+
+		if document.HealthEvent == nil {
+			continue
+		}
+
+		response.Events = append(response.Events, Event{
+			Event:  events.NewEvent(document.ID.Hex(), document.HealthEvent),
+			Status: status,
+		})
+
+		if status == model.Quarantined {
+			response.Quarantined = true
+			response.Status = status
+			response.Reason = document.HealthEventStatus.QuarantineReason
+
+			return response, nil
+		}
+	}
+
+	// The event that quarantined the node is older than the events looked at, or
+	// missing
+	if len(response.Events) > 0 {
+		response.Quarantined = true
+		response.Status = model.Quarantined
+		response.Truncated = len(documents) == maxEvents
+	}
+
+	return response, nil
+}
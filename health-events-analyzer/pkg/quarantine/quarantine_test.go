@@ -0,0 +1,136 @@
+// Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package quarantine
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/nvidia/nvsentinel/data-models/pkg/model"
+	"github.com/nvidia/nvsentinel/data-models/pkg/protos"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type fakeCollection struct {
+	documents []interface{}
+}
+
+func (f *fakeCollection) Aggregate(_ context.Context, _ interface{},
+	_ ...*options.AggregateOptions) (*mongo.Cursor, error) {
+	documents := make([]interface{}, 0, len(f.documents))
+
+	for _, document := range f.documents {
+		data, err := bson.Marshal(document)
+		if err != nil {
+			return nil, err
+		}
+
+		documents = append(documents, bson.Raw(data))
+	}
+
+	return mongo.NewCursorFromDocuments(documents, nil, nil)
+}
+
+func storedEvent(checkName string, status model.Status, reason *model.QuarantineReason) bson.M {
+	return bson.M{
+		"_id": primitive.NewObjectID(),
+		"healthevent": &protos.HealthEvent{
+			NodeName:  "h100-1",
+			Agent:     "syslog-health-monitor",
+			CheckName: checkName,
+			ErrorCode: []string{"79"},
+			IsFatal:   true,
+		},
+		"healtheventstatus": model.HealthEventStatus{NodeQuarantined: &status, QuarantineReason: reason},
+	}
+}
+
+func TestGet(t *testing.T) {
+	reason := &model.QuarantineReason{
+		IncidentID: "01J0000000000000000000000",
+		CheckName:  "SysLogsXIDError",
+		ErrorCodes: []string{"79"},
+		RuleSets:   []string{"GPU fatal error ruleset"},
+		Cordoned:   true,
+		RunbookURL: "https://runbooks.example.com/xid-79",
+	}
+
+	// Most recent first, as the pipeline sorts them
+	collection := &fakeCollection{documents: []interface{}{
+		storedEvent("GpuMemWatch", model.AlreadyQuarantined, nil),
+		storedEvent("SysLogsXIDError", model.Quarantined, reason),
+		storedEvent("SysLogsXIDError", model.UnQuarantined, nil),
+	}}
+	handler := NewHandler(collection)
+
+	response, err := handler.Get(context.Background(), "h100-1")
+	require.NoError(t, err)
+	assert.True(t, response.Quarantined)
+	assert.Equal(t, reason, response.Reason)
+	require.Len(t, response.Events, 2, "the events of the earlier quarantine are not part of the chain")
+	assert.Equal(t, "GpuMemWatch", response.Events[0].CheckName)
+	assert.Equal(t, model.AlreadyQuarantined, response.Events[0].Status)
+	assert.Equal(t, model.Quarantined, response.Events[1].Status)
+	assert.False(t, response.Truncated)
+
+	collection.documents = []interface{}{storedEvent("SysLogsXIDError", model.Cancelled, nil)}
+
+	response, err = handler.Get(context.Background(), "h100-1")
+	require.NoError(t, err)
+	assert.False(t, response.Quarantined, "manually uncordoned nodes are not quarantined")
+	assert.Equal(t, model.Cancelled, response.Status)
+	assert.Empty(t, response.Events)
+
+	collection.documents = nil
+
+	response, err = handler.Get(context.Background(), "h100-1")
+	require.NoError(t, err)
+	assert.False(t, response.Quarantined)
+}
+
+func TestServeHTTP(t *testing.T) {
+	collection := &fakeCollection{documents: []interface{}{
+		storedEvent("SysLogsXIDError", model.Quarantined, &model.QuarantineReason{CheckName: "SysLogsXIDError"}),
+	}}
+	handler := NewHandler(collection)
+
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, PathPrefix+"?node=h100-1", nil))
+	require.Equal(t, http.StatusOK, recorder.Code, recorder.Body.String())
+
+	var response Response
+	require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &response))
+	assert.Equal(t, "h100-1", response.NodeName)
+	assert.True(t, response.Quarantined)
+	assert.Equal(t, "SysLogsXIDError", response.Reason.CheckName)
+	require.Len(t, response.Events, 1)
+	assert.Equal(t, []string{"79"}, response.Events[0].ErrorCodes)
+
+	recorder = httptest.NewRecorder()
+	handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, PathPrefix, nil))
+	assert.Equal(t, http.StatusBadRequest, recorder.Code)
+
+	recorder = httptest.NewRecorder()
+	handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodPost, PathPrefix+"?node=h100-1", nil))
+	assert.Equal(t, http.StatusMethodNotAllowed, recorder.Code)
+}
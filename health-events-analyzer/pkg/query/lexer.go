@@ -0,0 +1,152 @@
+// Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package query
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+type tokenKind int
+
+const (
+	tokenEOF tokenKind = iota
+	tokenIdent
+	tokenKeyword
+	tokenString
+	tokenNumber
+	tokenOperator
+)
+
+type token struct {
+	kind tokenKind
+	text string
+	// Byte offset of the token in the expression
+	pos int
+}
+
+func (t token) String() string {
+	switch t.kind {
+	case tokenEOF:
+		return "end of query"
+	case tokenString:
+		return strconv.Quote(t.text)
+	default:
+		return fmt.Sprintf("%q", t.text)
+	}
+}
+
+var keywords = map[string]bool{
+	"and": true, "or": true, "not": true, "in": true, "between": true, "true": true, "false": true, "now": true,
+}
+
+// Operators, longest first so that "<=" is not lexed as "<" and "=".
+var operators = []string{"==", "!=", "<=", ">=", "=~", "!~", "=", "<", ">", "(", ")", ",", "-"}
+
+func lex(expression string) ([]token, error) {
+	var tokens []token
+
+	for pos := 0; pos < len(expression); {
+		c := rune(expression[pos])
+
+		switch {
+		case unicode.IsSpace(c):
+			pos++
+		case c == '"':
+			end := closingQuote(expression, pos)
+			if end < 0 {
+				return nil, fmt.Errorf("position %d: unterminated string", pos+1)
+			}
+
+			text, err := strconv.Unquote(expression[pos : end+1])
+			if err != nil {
+				return nil, fmt.Errorf("position %d: invalid string: %w", pos+1, err)
+			}
+
+			tokens = append(tokens, token{kind: tokenString, text: text, pos: pos})
+			pos = end + 1
+		case isIdentStart(c):
+			end := scan(expression, pos, isIdentPart)
+			text := expression[pos:end]
+
+			kind := tokenIdent
+			if keywords[strings.ToLower(text)] {
+				kind = tokenKeyword
+				text = strings.ToLower(text)
+			}
+
+			tokens = append(tokens, token{kind: kind, text: text, pos: pos})
+			pos = end
+		case c >= '0' && c <= '9':
+			end := scan(expression, pos, func(r rune) bool {
+				return r == '.' || unicode.IsLetter(r) || unicode.IsDigit(r)
+			})
+			tokens = append(tokens, token{kind: tokenNumber, text: expression[pos:end], pos: pos})
+			pos = end
+		default:
+			operator := ""
+
+			for _, candidate := range operators {
+				if strings.HasPrefix(expression[pos:], candidate) {
+					operator = candidate
+					break
+				}
+			}
+
+			if operator == "" {
+				return nil, fmt.Errorf("position %d: unexpected character %q", pos+1, c)
+			}
+
+			tokens = append(tokens, token{kind: tokenOperator, text: operator, pos: pos})
+			pos += len(operator)
+		}
+	}
+
+	return append(tokens, token{kind: tokenEOF, pos: len(expression)}), nil
+}
+
+// closingQuote returns the index of the quote closing the string starting at
+// start, -1 if it is not terminated.
+func closingQuote(expression string, start int) int {
+	for i := start + 1; i < len(expression); i++ {
+		switch expression[i] {
+		case '\\':
+			i++
+		case '"':
+			return i
+		}
+	}
+
+	return -1
+}
+
+func scan(expression string, start int, part func(rune) bool) int {
+	end := start
+	for end < len(expression) && part(rune(expression[end])) {
+		end++
+	}
+
+	return end
+}
+
+func isIdentStart(r rune) bool {
+	return r == '_' || (r < unicode.MaxASCII && unicode.IsLetter(r))
+}
+
+func isIdentPart(r rune) bool {
+	return r == '_' || r == '.' || (r < unicode.MaxASCII && (unicode.IsLetter(r) || unicode.IsDigit(r)))
+}
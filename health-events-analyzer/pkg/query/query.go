@@ -0,0 +1,436 @@
+// Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package query implements the filter expression language of the event store
+// APIs, e.g.
+//
+//	error_code in ("GPU_DRIVER_ERROR", "79") and node =~ "h100-*" and time > now-24h
+//
+// Comparisons of a field with a value are combined with and, or, not and
+// parentheses. Expressions are translated to MongoDB filters on the health
+// events collection.
+package query
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	pb "github.com/nvidia/nvsentinel/data-models/pkg/protos"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type fieldType int
+
+const (
+	stringField fieldType = iota
+	boolField
+	actionField
+	timeField
+)
+
+type field struct {
+	path string
+	kind fieldType
+}
+
+// fields maps the field names of the language to the stored event. Fields not
+// listed here are only accepted as metadata.<key>.
+var fields = map[string]field{
+	"node":           {"healthevent.nodename", stringField},
+	"agent":          {"healthevent.agent", stringField},
+	"check":          {"healthevent.checkname", stringField},
+	"component":      {"healthevent.componentclass", stringField},
+	"error_code":     {"healthevent.errorcode", stringField},
+	"message":        {"healthevent.message", stringField},
+	"entity":         {"healthevent.entitiesimpacted.entityvalue", stringField},
+	"entity_type":    {"healthevent.entitiesimpacted.entitytype", stringField},
+	"correlation_id": {"healthevent.correlationid", stringField},
+	"is_fatal":       {"healthevent.isfatal", boolField},
+	"is_healthy":     {"healthevent.ishealthy", boolField},
+	"action":         {"healthevent.recommendedaction", actionField},
+	"time":           {"healthevent.generatedtimestamp.seconds", timeField},
+}
+
+const metadataPrefix = "metadata."
+
+// Fields returns the names of the fields expressions can compare, sorted.
+func Fields() []string {
+	names := make([]string, 0, len(fields)+1)
+	for name := range fields {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	return append(names, metadataPrefix+"<key>")
+}
+
+// Parse translates the expression to a MongoDB filter. now is the time relative
+// times such as now-24h refer to. An empty expression matches every event.
+func Parse(expression string, now time.Time) (bson.M, error) {
+	tokens, err := lex(expression)
+	if err != nil {
+		return nil, err
+	}
+
+	p := &parser{tokens: tokens, now: now}
+
+	if p.peek().kind == tokenEOF {
+		return bson.M{}, nil
+	}
+
+	filter, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+
+	if token := p.peek(); token.kind != tokenEOF {
+		return nil, p.errorf(token, "unexpected %s", token)
+	}
+
+	return filter, nil
+}
+
+type parser struct {
+	tokens []token
+	pos    int
+	now    time.Time
+}
+
+func (p *parser) peek() token {
+	return p.tokens[p.pos]
+}
+
+func (p *parser) next() token {
+	token := p.tokens[p.pos]
+	if token.kind != tokenEOF {
+		p.pos++
+	}
+
+	return token
+}
+
+// accept consumes the next token if it is the keyword or operator text.
+func (p *parser) accept(text string) bool {
+	token := p.peek()
+	if (token.kind == tokenKeyword || token.kind == tokenOperator) && token.text == text {
+		p.pos++
+		return true
+	}
+
+	return false
+}
+
+func (p *parser) expect(text string) error {
+	if !p.accept(text) {
+		token := p.peek()
+		return p.errorf(token, "expected %q, got %s", text, token)
+	}
+
+	return nil
+}
+
+func (p *parser) errorf(token token, format string, args ...interface{}) error {
+	return fmt.Errorf("position %d: %s", token.pos+1, fmt.Sprintf(format, args...))
+}
+
+// parseOr parses or := and ("or" and)*.
+func (p *parser) parseOr() (bson.M, error) {
+	return p.parseBinary("or", "$or", p.parseAnd)
+}
+
+// parseAnd parses and := unary ("and" unary)*.
+func (p *parser) parseAnd() (bson.M, error) {
+	return p.parseBinary("and", "$and", p.parseUnary)
+}
+
+func (p *parser) parseBinary(keyword, operator string, operand func() (bson.M, error)) (bson.M, error) {
+	first, err := operand()
+	if err != nil {
+		return nil, err
+	}
+
+	operands := bson.A{first}
+
+	for p.accept(keyword) {
+		next, err := operand()
+		if err != nil {
+			return nil, err
+		}
+
+		operands = append(operands, next)
+	}
+
+	if len(operands) == 1 {
+		return first, nil
+	}
+
+	return bson.M{operator: operands}, nil
+}
+
+// parseUnary parses unary := "not" unary | "(" or ")" | comparison.
+func (p *parser) parseUnary() (bson.M, error) {
+	if p.accept("not") {
+		operand, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+
+		return bson.M{"$nor": bson.A{operand}}, nil
+	}
+
+	if p.accept("(") {
+		filter, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+
+		if err := p.expect(")"); err != nil {
+			return nil, err
+		}
+
+		return filter, nil
+	}
+
+	return p.parseComparison()
+}
+
+// parseComparison parses
+//
+//	comparison := field op value | field "in" "(" value ("," value)* ")" |
+//	              field "between" value "and" value
+func (p *parser) parseComparison() (bson.M, error) {
+	name := p.next()
+	if name.kind != tokenIdent {
+		return nil, p.errorf(name, "expected a field, got %s", name)
+	}
+
+	f, ok := fields[name.text]
+	if !ok {
+		key, isMetadata := strings.CutPrefix(name.text, metadataPrefix)
+		if !isMetadata || key == "" {
+			return nil, p.errorf(name, "unknown field %q, expected one of %s", name.text,
+				strings.Join(Fields(), ", "))
+		}
+
+		f = field{path: "healthevent.metadata." + key, kind: stringField}
+	}
+
+	op := p.next()
+
+	switch {
+	case op.kind == tokenKeyword && op.text == "in":
+		return p.parseIn(name, f)
+	case op.kind == tokenKeyword && op.text == "between":
+		return p.parseBetween(name, f)
+	case op.kind != tokenOperator || !comparisonOperators[op.text]:
+		return nil, p.errorf(op, "expected a comparison operator after %s, got %s", name.text, op)
+	}
+
+	if op.text == "=~" || op.text == "!~" {
+		if f.kind != stringField {
+			return nil, p.errorf(op, "%s only applies to text fields", op.text)
+		}
+
+		pattern, err := p.parseString()
+		if err != nil {
+			return nil, err
+		}
+
+		regex := primitive.Regex{Pattern: globToRegex(pattern)}
+		if op.text == "!~" {
+			return bson.M{f.path: bson.M{"$not": regex}}, nil
+		}
+
+		return bson.M{f.path: bson.M{"$regex": regex}}, nil
+	}
+
+	value, err := p.parseValue(name, f)
+	if err != nil {
+		return nil, err
+	}
+
+	mongoOperator := map[string]string{
+		"=": "$eq", "==": "$eq", "!=": "$ne", "<": "$lt", "<=": "$lte", ">": "$gt", ">=": "$gte",
+	}[op.text]
+
+	if f.kind != timeField && mongoOperator != "$eq" && mongoOperator != "$ne" {
+		return nil, p.errorf(op, "%s only applies to time", op.text)
+	}
+
+	return bson.M{f.path: bson.M{mongoOperator: value}}, nil
+}
+
+var comparisonOperators = map[string]bool{
+	"=": true, "==": true, "!=": true, "<": true, "<=": true, ">": true, ">=": true, "=~": true, "!~": true,
+}
+
+func (p *parser) parseIn(name token, f field) (bson.M, error) {
+	if f.kind == timeField || f.kind == boolField {
+		return nil, p.errorf(name, "in does not apply to %s", name.text)
+	}
+
+	if err := p.expect("("); err != nil {
+		return nil, err
+	}
+
+	var values bson.A
+
+	for {
+		value, err := p.parseValue(name, f)
+		if err != nil {
+			return nil, err
+		}
+
+		values = append(values, value)
+
+		if !p.accept(",") {
+			break
+		}
+	}
+
+	if err := p.expect(")"); err != nil {
+		return nil, err
+	}
+
+	return bson.M{f.path: bson.M{"$in": values}}, nil
+}
+
+func (p *parser) parseBetween(name token, f field) (bson.M, error) {
+	if f.kind != timeField {
+		return nil, p.errorf(name, "between only applies to time")
+	}
+
+	from, err := p.parseValue(name, f)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := p.expect("and"); err != nil {
+		return nil, err
+	}
+
+	to, err := p.parseValue(name, f)
+	if err != nil {
+		return nil, err
+	}
+
+	return bson.M{f.path: bson.M{"$gte": from, "$lt": to}}, nil
+}
+
+func (p *parser) parseString() (string, error) {
+	token := p.next()
+	if token.kind != tokenString {
+		return "", p.errorf(token, "expected a quoted string, got %s", token)
+	}
+
+	return token.text, nil
+}
+
+// parseValue parses a value of the field type.
+//
+//nolint:cyclop // one case per field type
+func (p *parser) parseValue(name token, f field) (interface{}, error) {
+	token := p.next()
+
+	switch f.kind {
+	case stringField:
+		if token.kind != tokenString {
+			return nil, p.errorf(token, "expected a quoted string for %s, got %s", name.text, token)
+		}
+
+		return token.text, nil
+	case boolField:
+		if token.kind != tokenKeyword || (token.text != "true" && token.text != "false") {
+			return nil, p.errorf(token, "expected true or false for %s, got %s", name.text, token)
+		}
+
+		return token.text == "true", nil
+	case actionField:
+		if token.kind != tokenString && token.kind != tokenIdent {
+			return nil, p.errorf(token, "expected a recommended action for %s, got %s", name.text, token)
+		}
+
+		action, ok := pb.RecommendedAction_value[token.text]
+		if !ok {
+			return nil, p.errorf(token, "unknown recommended action %q", token.text)
+		}
+
+		return action, nil
+	case timeField:
+		return p.parseTime(token)
+	}
+
+	return nil, p.errorf(token, "unsupported field %s", name.text)
+}
+
+// parseTime parses an RFC 3339 time or now, optionally minus a duration, into
+// Unix seconds.
+func (p *parser) parseTime(token token) (int64, error) {
+	if token.kind == tokenString {
+		parsed, err := time.Parse(time.RFC3339, token.text)
+		if err != nil {
+			return 0, p.errorf(token, "invalid time %q, expected RFC 3339", token.text)
+		}
+
+		return parsed.Unix(), nil
+	}
+
+	if token.kind != tokenKeyword || token.text != "now" {
+		return 0, p.errorf(token, "expected a quoted RFC 3339 time or now, got %s", token)
+	}
+
+	if !p.accept("-") {
+		return p.now.Unix(), nil
+	}
+
+	durationToken := p.next()
+	if durationToken.kind != tokenNumber {
+		return 0, p.errorf(durationToken, "expected a duration such as 24h, got %s", durationToken)
+	}
+
+	duration, err := time.ParseDuration(durationToken.text)
+	if err != nil {
+		return 0, p.errorf(durationToken, "invalid duration %q", durationToken.text)
+	}
+
+	return p.now.Add(-duration).Unix(), nil
+}
+
+// globToRegex translates a glob pattern, where * matches any text and ? a
+// single character, to an anchored regular expression.
+func globToRegex(glob string) string {
+	var b strings.Builder
+
+	b.WriteString("^")
+
+	for _, r := range glob {
+		switch r {
+		case '*':
+			b.WriteString(".*")
+		case '?':
+			b.WriteString(".")
+		default:
+			b.WriteString(regexp.QuoteMeta(string(r)))
+		}
+	}
+
+	b.WriteString("$")
+
+	return b.String()
+}
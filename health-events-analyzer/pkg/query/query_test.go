@@ -0,0 +1,148 @@
+// Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package query
+
+import (
+	"testing"
+	"time"
+
+	pb "github.com/nvidia/nvsentinel/data-models/pkg/protos"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+var testNow = time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+
+func TestParse(t *testing.T) {
+	testCases := []struct {
+		name       string
+		expression string
+		expected   bson.M
+	}{
+		{
+			name:       "empty",
+			expression: "  ",
+			expected:   bson.M{},
+		},
+		{
+			name:       "set membership and glob",
+			expression: `error_code in ("GPU_DRIVER_ERROR", "79") and node =~ "h100-*"`,
+			expected: bson.M{"$and": bson.A{
+				bson.M{"healthevent.errorcode": bson.M{"$in": bson.A{"GPU_DRIVER_ERROR", "79"}}},
+				bson.M{"healthevent.nodename": bson.M{"$regex": primitive.Regex{Pattern: `^h100-.*$`}}},
+			}},
+		},
+		{
+			name:       "precedence of and over or",
+			expression: `check = "SysLogsXIDError" or is_fatal == true AND is_healthy != true`,
+			expected: bson.M{"$or": bson.A{
+				bson.M{"healthevent.checkname": bson.M{"$eq": "SysLogsXIDError"}},
+				bson.M{"$and": bson.A{
+					bson.M{"healthevent.isfatal": bson.M{"$eq": true}},
+					bson.M{"healthevent.ishealthy": bson.M{"$ne": true}},
+				}},
+			}},
+		},
+		{
+			name:       "parentheses and not",
+			expression: `not (agent = "a" or agent = "b") and node !~ "cpu-?"`,
+			expected: bson.M{"$and": bson.A{
+				bson.M{"$nor": bson.A{bson.M{"$or": bson.A{
+					bson.M{"healthevent.agent": bson.M{"$eq": "a"}},
+					bson.M{"healthevent.agent": bson.M{"$eq": "b"}},
+				}}}},
+				bson.M{"healthevent.nodename": bson.M{"$not": primitive.Regex{Pattern: `^cpu-.$`}}},
+			}},
+		},
+		{
+			name:       "relative time",
+			expression: `time > now-24h and time <= now`,
+			expected: bson.M{"$and": bson.A{
+				bson.M{"healthevent.generatedtimestamp.seconds": bson.M{"$gt": testNow.Add(-24 * time.Hour).Unix()}},
+				bson.M{"healthevent.generatedtimestamp.seconds": bson.M{"$lte": testNow.Unix()}},
+			}},
+		},
+		{
+			name:       "time range",
+			expression: `time between "2025-05-01T00:00:00Z" and "2025-05-02T00:00:00Z" and message = "x"`,
+			expected: bson.M{"$and": bson.A{
+				bson.M{"healthevent.generatedtimestamp.seconds": bson.M{
+					"$gte": time.Date(2025, 5, 1, 0, 0, 0, 0, time.UTC).Unix(),
+					"$lt":  time.Date(2025, 5, 2, 0, 0, 0, 0, time.UTC).Unix(),
+				}},
+				bson.M{"healthevent.message": bson.M{"$eq": "x"}},
+			}},
+		},
+		{
+			name:       "recommended action and metadata",
+			expression: `action in (RESTART_BM, "COMPONENT_RESET") and metadata.kernel_version = "6.8.0"`,
+			expected: bson.M{"$and": bson.A{
+				bson.M{"healthevent.recommendedaction": bson.M{"$in": bson.A{
+					int32(pb.RecommendedAction_RESTART_BM), int32(pb.RecommendedAction_COMPONENT_RESET),
+				}}},
+				bson.M{"healthevent.metadata.kernel_version": bson.M{"$eq": "6.8.0"}},
+			}},
+		},
+		{
+			name:       "escaped string",
+			expression: `message = "say \"hi\""`,
+			expected:   bson.M{"healthevent.message": bson.M{"$eq": `say "hi"`}},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			filter, err := Parse(tc.expression, testNow)
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, filter)
+		})
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	testCases := map[string]string{
+		`gpu = "0"`:                              "unknown field",
+		`node "a"`:                               "expected a comparison operator",
+		`node = a`:                               "expected a quoted string",
+		`node > "a"`:                             "only applies to time",
+		`is_fatal =~ "t*"`:                       "only applies to text fields",
+		`is_fatal = "yes"`:                       "expected true or false",
+		`action = REBOOT`:                        "unknown recommended action",
+		`time > "yesterday"`:                     "invalid time",
+		`time > now-1d`:                          "invalid duration",
+		`time in (now)`:                          "in does not apply",
+		`node between "a" and "b"`:               "between only applies to time",
+		`node = "a" and`:                         "expected a field",
+		`(node = "a"`:                            `expected ")"`,
+		`node = "a" node = "b"`:                  "unexpected",
+		`node = "a`:                              "unterminated string",
+		`node = "a" & check = "b"`:               "unexpected character",
+		`error_code in ("a" "b")`:                `expected ")"`,
+		`metadata. = "x"`:                        "unknown field",
+		`time between now-1h or now`:             `expected "and"`,
+		`node = "a" or (check = "b" and not )`:   "expected a field",
+		`node = "a" and error_code in "GPU_ERR"`: `expected "("`,
+	}
+
+	for expression, expected := range testCases {
+		t.Run(expression, func(t *testing.T) {
+			_, err := Parse(expression, testNow)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), expected)
+		})
+	}
+}
@@ -57,6 +57,62 @@ var (
 		[]string{"rule_name", "node_name"},
 	)
 
+	rolloutInducedTotal = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "health_event_analyzer_rollout_induced_total",
+			Help: "Total number of matched rules tagged as possibly rollout-induced.",
+		},
+		[]string{"rule_name"},
+	)
+
+	telemetryConfirmationsTotal = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "health_event_analyzer_telemetry_confirmations_total",
+			Help: "Total number of log-detected faults of matched rules confirmed against the DCGM health watches",
+		},
+		[]string{"rule_name", "result"},
+	)
+
+	rebootsObservedTotal = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "health_event_analyzer_reboots_observed_total",
+			Help: "Total number of node reboots observed, which reset rule counts and close reboot-resolved incidents.",
+		},
+		[]string{"node_name"},
+	)
+
+	backfilledEventsSkippedTotal = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "health_event_analyzer_backfilled_events_skipped_total",
+			Help: "Total number of backfilled events that were not evaluated against the rules.",
+		},
+		[]string{"node_name"},
+	)
+
+	severityOverridesAppliedTotal = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "health_event_analyzer_severity_overrides_applied_total",
+			Help: "Total number of events a severity override was applied to.",
+		},
+		[]string{"override", "severity"},
+	)
+
+	severityOverridesInEffect = promauto.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "health_event_analyzer_severity_overrides_in_effect",
+			Help: "Whether a configured severity override is in effect (1) or expired (0).",
+		},
+		[]string{"override", "severity"},
+	)
+
+	incidentsClassifiedTotal = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "health_event_analyzer_incidents_classified_total",
+			Help: "Total number of published incidents by classification tag and source of the tag.",
+		},
+		[]string{"tag", "source"},
+	)
+
 	// performance metrics
 	eventHandlingDuration = promauto.NewHistogram(
 		prometheus.HistogramOpts{
@@ -18,15 +18,24 @@ import (
 	"context"
 	"fmt"
 	"log/slog"
+	"strings"
+	"sync"
+	"sync/atomic"
 	"time"
 
 	multierror "github.com/hashicorp/go-multierror"
+	"github.com/nvidia/nvsentinel/commons/pkg/logger"
+	"github.com/nvidia/nvsentinel/commons/pkg/lrucache"
 	datamodels "github.com/nvidia/nvsentinel/data-models/pkg/model"
 	protos "github.com/nvidia/nvsentinel/data-models/pkg/protos"
+	"github.com/nvidia/nvsentinel/health-events-analyzer/pkg/classification"
 	config "github.com/nvidia/nvsentinel/health-events-analyzer/pkg/config"
 	parser "github.com/nvidia/nvsentinel/health-events-analyzer/pkg/parser"
 	"github.com/nvidia/nvsentinel/health-events-analyzer/pkg/publisher"
+	"github.com/nvidia/nvsentinel/health-events-analyzer/pkg/rollout"
 	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"google.golang.org/protobuf/proto"
 
 	"github.com/nvidia/nvsentinel/store-client/pkg/storewatcher"
 	"go.mongodb.org/mongo-driver/mongo"
@@ -35,6 +44,19 @@ import (
 
 type CollectionInterface interface {
 	Aggregate(ctx context.Context, pipeline interface{}, opts ...*options.AggregateOptions) (*mongo.Cursor, error)
+	UpdateOne(ctx context.Context, filter interface{}, update interface{},
+		opts ...*options.UpdateOptions) (*mongo.UpdateResult, error)
+}
+
+// RolloutTracker returns the most recent driver or GPU Operator rollout on a node.
+type RolloutTracker interface {
+	LastRollout(nodeName string) (rollout.Rollout, bool)
+}
+
+// Sharder tells whether this replica evaluates the events of a node.
+type Sharder interface {
+	Owns(nodeName string) bool
+	Processed(generatedAt time.Time)
 }
 
 type HealthEventsAnalyzerReconcilerConfig struct {
@@ -44,15 +66,32 @@ type HealthEventsAnalyzerReconcilerConfig struct {
 	HealthEventsAnalyzerRules        *config.TomlConfig
 	Publisher                        *publisher.PublisherConfig
 	CollectionClient                 CollectionInterface
+	// RolloutTracker is required when rollout correlation is configured.
+	RolloutTracker RolloutTracker
+	// Classifier tags the incidents of rules without tags, which are published
+	// untagged when it is nil.
+	Classifier *classification.Chain
+	// Sharder is nil when this replica evaluates the events of all nodes.
+	Sharder Sharder
+	// RebootCacheSize bounds the number of nodes whose last reboot is kept,
+	// lrucache.DefaultCapacity when not positive.
+	RebootCacheSize int
 }
 
 type Reconciler struct {
 	config HealthEventsAnalyzerReconcilerConfig
+
+	// lastReboots holds the time of the last reboot per node
+	lastReboots   *lrucache.Cache[string, time.Time]
+	lastRebootsMu sync.RWMutex
+	// reloadReboots is set when the nodes moved between the analyzer replicas
+	reloadReboots atomic.Bool
 }
 
 func NewReconciler(cfg HealthEventsAnalyzerReconcilerConfig) *Reconciler {
 	return &Reconciler{
-		config: cfg,
+		config:      cfg,
+		lastReboots: lrucache.New[string, time.Time]("analyzer_last_reboots", cfg.RebootCacheSize),
 	}
 }
 
@@ -72,7 +111,7 @@ func (r *Reconciler) Start(ctx context.Context) error {
 
 	r.config.CollectionClient, err = storewatcher.GetCollectionClient(ctx, r.config.MongoHealthEventCollectionConfig)
 	if err != nil {
-		slog.Error(
+		slog.ErrorContext(ctx,
 			"Error initializing healthEventCollection client",
 			"config", r.config.MongoHealthEventCollectionConfig,
 			"error", err,
@@ -81,20 +120,31 @@ func (r *Reconciler) Start(ctx context.Context) error {
 		return fmt.Errorf("failed to initialize healthEventCollection client: %w", err)
 	}
 
+	if err := r.loadLastReboots(ctx); err != nil {
+		// Rules are evaluated without reboot boundaries until the next reboot of a node
+		slog.WarnContext(ctx, "Failed to load last node reboots", "error", err)
+	}
+
 	watcher.Start(ctx)
 
-	slog.Info("Listening for events on the channel...")
+	slog.InfoContext(ctx, "Listening for events on the channel...")
 
 	for event := range watcher.Events() {
-		slog.Info("Processing event", "event", event)
+		slog.InfoContext(ctx, "Processing event", "event", event)
+
+		if r.reloadReboots.Swap(false) {
+			if err := r.loadLastReboots(ctx); err != nil {
+				slog.WarnContext(ctx, "Failed to reload last node reboots", "error", err)
+			}
+		}
 
 		err := r.processEvent(ctx, event)
 		if err != nil {
-			slog.Error("Error processing event", "error", err)
+			slog.ErrorContext(ctx, "Error processing event", "error", err)
 		}
 
 		if err := watcher.MarkProcessed(ctx); err != nil {
-			slog.Error("Error updating resume token", "error", err)
+			slog.ErrorContext(ctx, "Error updating resume token", "error", err)
 		}
 	}
 
@@ -109,14 +159,25 @@ func (r *Reconciler) processEvent(ctx context.Context, event bson.M) error {
 		event,
 		&healthEventWithStatus,
 	); err != nil {
-		slog.Error("Failed to unmarshal event", "error", err)
+		slog.ErrorContext(ctx, "Failed to unmarshal event", "error", err)
 
 		totalEventProcessingError.WithLabelValues("unmarshal_doc_error").Inc()
 
 		return fmt.Errorf("failed to unmarshal event: %w", err)
 	}
 
-	slog.Debug("Received event", "event", healthEventWithStatus)
+	ctx = logger.WithEventID(logger.WithSubsystem(ctx, "reconciler"), healthEventWithStatus.HealthEvent.GetId())
+	ctx = logger.WithIncidentID(ctx, healthEventWithStatus.IncidentID())
+
+	slog.DebugContext(ctx, "Received event", "event", healthEventWithStatus)
+
+	if sharder := r.config.Sharder; sharder != nil {
+		if !sharder.Owns(healthEventWithStatus.HealthEvent.NodeName) {
+			return nil
+		}
+
+		defer sharder.Processed(healthEventWithStatus.HealthEvent.GetGeneratedTimestamp().AsTime())
+	}
 
 	totalEventsReceived.WithLabelValues(healthEventWithStatus.HealthEvent.NodeName).Inc()
 
@@ -124,19 +185,19 @@ func (r *Reconciler) processEvent(ctx context.Context, event bson.M) error {
 
 	var publishedNewEvent bool
 
-	publishedNewEvent, err = r.handleEvent(ctx, &healthEventWithStatus)
+	publishedNewEvent, err = r.handleEvent(ctx, documentID(event), &healthEventWithStatus)
 	if err != nil {
-		slog.Error("Error in handling the event", "event", healthEventWithStatus, "error", err)
+		slog.ErrorContext(ctx, "Error in handling the event", "event", healthEventWithStatus, "error", err)
 
 		totalEventProcessingError.WithLabelValues("handle_event_error").Inc()
 	} else {
 		totalEventsSuccessfullyProcessed.Inc()
 
 		if publishedNewEvent {
-			slog.Info("New event successfully published.")
+			slog.InfoContext(ctx, "New event successfully published.")
 			newEventsPublishedTotal.WithLabelValues(healthEventWithStatus.HealthEvent.NodeName).Inc()
 		} else {
-			slog.Info("New event is not published, rule set criteria didn't match.")
+			slog.InfoContext(ctx, "New event is not published, rule set criteria didn't match.")
 		}
 	}
 
@@ -147,13 +208,85 @@ func (r *Reconciler) processEvent(ctx context.Context, event bson.M) error {
 	return err
 }
 
-func (r *Reconciler) handleEvent(ctx context.Context, event *datamodels.HealthEventWithStatus) (bool, error) {
+// documentID returns the store ID of the document of a change stream event.
+func documentID(event bson.M) string {
+	document, ok := event["fullDocument"].(bson.M)
+	if !ok {
+		return ""
+	}
+
+	if id, ok := document["_id"].(primitive.ObjectID); ok {
+		return id.Hex()
+	}
+
+	return ""
+}
+
+// handleEvent evaluates the rules for the event stored with the given ID.
+func (r *Reconciler) handleEvent(ctx context.Context, eventID string,
+	event *datamodels.HealthEventWithStatus) (bool, error) {
+	if datamodels.IsRebootEvent(event.HealthEvent) {
+		return r.handleReboot(ctx, eventID, event)
+	}
+
+	// Backfilled events were replayed by a restarted monitor and are historical, they
+	// must not trigger rules again
+	if datamodels.IsBackfilled(event.HealthEvent) {
+		slog.InfoContext(ctx, "Skipping rule evaluation for backfilled event",
+			"node", event.HealthEvent.NodeName, "check", event.HealthEvent.CheckName)
+		backfilledEventsSkippedTotal.WithLabelValues(event.HealthEvent.NodeName).Inc()
+
+		return false, nil
+	}
+
+	// Canary events verify the analyzer consumes the event stream, they must not
+	// trigger rules
+	if datamodels.IsCanary(event.HealthEvent) {
+		return false, r.handleCanary(ctx, event)
+	}
+
 	var multiErr *multierror.Error
 
 	publishedNewEvent := false
 
+	if override, ok := r.severityOverride(event.HealthEvent); ok {
+		published, err := r.applySeverityOverride(ctx, eventID, event, override)
+		if err != nil {
+			multiErr = multierror.Append(multiErr, err)
+		}
+
+		// Downgraded events trigger no rule
+		if override.Severity == datamodels.SeverityWarning {
+			return false, multiErr.ErrorOrNil()
+		}
+
+		publishedNewEvent = published
+	}
+
 	for _, rule := range r.config.HealthEventsAnalyzerRules.Rules {
-		published, err := r.processRule(ctx, rule, event)
+		published, err := r.processRule(ctx, rule, eventID, event)
+		if err != nil {
+			multiErr = multierror.Append(multiErr, err)
+			continue
+		}
+
+		if published {
+			publishedNewEvent = true
+		}
+	}
+
+	for _, eventRule := range r.config.HealthEventsAnalyzerRules.EventRules() {
+		rule, applies, err := eventRule.RuleFor(event.HealthEvent)
+		if err != nil {
+			multiErr = multierror.Append(multiErr, err)
+			continue
+		}
+
+		if !applies {
+			continue
+		}
+
+		published, err := r.processRule(ctx, rule, eventID, event)
 		if err != nil {
 			multiErr = multierror.Append(multiErr, err)
 			continue
@@ -165,7 +298,7 @@ func (r *Reconciler) handleEvent(ctx context.Context, event *datamodels.HealthEv
 	}
 
 	if multiErr.ErrorOrNil() != nil {
-		slog.Error("Error in handling the event", "error", multiErr)
+		slog.ErrorContext(ctx, "Error in handling the event", "error", multiErr)
 		return publishedNewEvent, fmt.Errorf("error in handling the event: %w", multiErr)
 	}
 
@@ -174,10 +307,11 @@ func (r *Reconciler) handleEvent(ctx context.Context, event *datamodels.HealthEv
 
 func (r *Reconciler) processRule(ctx context.Context,
 	rule config.HealthEventsAnalyzerRule,
+	eventID string,
 	event *datamodels.HealthEventWithStatus) (bool, error) {
 	matchedSequences, err := r.validateAllSequenceCriteria(ctx, rule, *event)
 	if err != nil {
-		slog.Error("Error in validating all sequence criteria", "error", err)
+		slog.ErrorContext(ctx, "Error in validating all sequence criteria", "error", err)
 		return false, fmt.Errorf("error in validating all sequence criteria: %w", err)
 	}
 
@@ -185,9 +319,9 @@ func (r *Reconciler) processRule(ctx context.Context,
 		return false, nil
 	}
 
-	err = r.publishMatchedEvent(ctx, rule, event)
+	err = r.publishMatchedEvent(ctx, rule, eventID, event)
 	if err != nil {
-		slog.Error("Error in publishing the matched event", "error", err)
+		slog.ErrorContext(ctx, "Error in publishing the matched event", "error", err)
 		return false, fmt.Errorf("error in publishing the matched event: %w", err)
 	}
 
@@ -196,23 +330,518 @@ func (r *Reconciler) processRule(ctx context.Context,
 
 func (r *Reconciler) publishMatchedEvent(ctx context.Context,
 	rule config.HealthEventsAnalyzerRule,
+	eventID string,
 	event *datamodels.HealthEventWithStatus) error {
-	slog.Info("Rule matched for event", "rule_name", rule.Name, "event", event)
+	slog.InfoContext(ctx, "Rule matched for event", "rule_name", rule.Name, "event", event)
 	ruleMatchedTotal.WithLabelValues(rule.Name, event.HealthEvent.NodeName).Inc()
 
 	actionVal := r.getRecommendedActionValue(rule.RecommendedAction, rule.Name)
+	healthEvent := event.HealthEvent
+
+	if recent, ok := r.recentRollout(healthEvent.NodeName); ok {
+		healthEvent, actionVal = r.tagRolloutInduced(healthEvent, recent, rule.Name)
+	}
+
+	if reference, ok := r.telemetryReference(healthEvent); ok {
+		healthEvent, actionVal = r.confirmTelemetry(ctx, healthEvent, reference, actionVal, rule.Name)
+	}
+
+	healthEvent = r.classify(ctx, healthEvent, rule)
+
+	relationships := r.relationships(ctx, eventID, healthEvent.NodeName, rule.Name)
 
-	err := r.config.Publisher.Publish(ctx, event.HealthEvent, protos.RecommendedAction(actionVal), rule.Name)
+	err := r.config.Publisher.Publish(ctx, healthEvent, protos.RecommendedAction(actionVal), rule.Name, relationships)
 	if err != nil {
-		slog.Error("Error in publishing the new fatal event", "error", err)
+		slog.ErrorContext(ctx, "Error in publishing the new fatal event", "error", err)
 		return fmt.Errorf("error in publishing the new fatal event: %w", err)
 	}
 
-	slog.Info("New event successfully published for matching rule", "rule_name", rule.Name)
+	slog.InfoContext(ctx, "New event successfully published for matching rule", "rule_name", rule.Name)
 
 	return nil
 }
 
+// classify tags the incident published for the rule with the tags of the rule or,
+// without, of the classifier.
+func (r *Reconciler) classify(ctx context.Context, event *protos.HealthEvent,
+	rule config.HealthEventsAnalyzerRule) *protos.HealthEvent {
+	var (
+		tags           []datamodels.IncidentTag
+		source, reason string
+	)
+
+	switch {
+	case len(rule.Tags) > 0:
+		// Validate guarantees valid tags
+		tags, _ = datamodels.ParseIncidentTags(rule.Tags)
+		source = "rule"
+	case r.config.Classifier != nil:
+		var result classification.Result
+
+		result, source = r.config.Classifier.Classify(ctx, event, rule.Name)
+		tags, reason = result.Tags, result.Reason
+	default:
+		return event
+	}
+
+	values := make([]string, 0, len(tags))
+
+	for _, tag := range tags {
+		values = append(values, string(tag))
+		incidentsClassifiedTotal.WithLabelValues(string(tag), source).Inc()
+	}
+
+	slog.InfoContext(ctx, "Classified incident",
+		"rule_name", rule.Name,
+		"node", event.NodeName,
+		"tags", values,
+		"source", source,
+		"reason", reason)
+
+	tagged := proto.Clone(event).(*protos.HealthEvent)
+	if tagged.Metadata == nil {
+		tagged.Metadata = make(map[string]string)
+	}
+
+	tagged.Metadata[datamodels.IncidentTagsMetadataKey] = strings.Join(values, ",")
+	tagged.Metadata[datamodels.IncidentTagSourceMetadataKey] = source
+
+	return tagged
+}
+
+// handleCanary records on the canary event that the analyzer processed it.
+func (r *Reconciler) handleCanary(ctx context.Context, event *datamodels.HealthEventWithStatus) error {
+	_, err := r.config.CollectionClient.UpdateOne(ctx,
+		bson.M{"healthevent.id": event.HealthEvent.Id},
+		bson.M{"$set": bson.M{"healtheventstatus.canary.analyzedat": time.Now().UTC()}})
+	if err != nil {
+		totalEventProcessingError.WithLabelValues("update_canary_status_error").Inc()
+		return fmt.Errorf("failed to record canary event %s as analyzed: %w", event.HealthEvent.Id, err)
+	}
+
+	slog.InfoContext(ctx, "Recorded canary event as analyzed",
+		"node", event.HealthEvent.NodeName, "id", event.HealthEvent.Id)
+
+	return nil
+}
+
+// severityOverride returns the severity override that applies to the event and
+// updates which overrides are in effect.
+func (r *Reconciler) severityOverride(event *protos.HealthEvent) (config.SeverityOverride, bool) {
+	now := time.Now()
+
+	for _, override := range r.config.HealthEventsAnalyzerRules.SeverityOverrides {
+		inEffect := 0.0
+		if override.InEffect(now) {
+			inEffect = 1
+		}
+
+		severityOverridesInEffect.WithLabelValues(override.Name, override.Severity).Set(inEffect)
+	}
+
+	return r.config.HealthEventsAnalyzerRules.SeverityOverrideFor(event, now)
+}
+
+// applySeverityOverride records the override on the stored event and, for a FATAL
+// override, publishes a fatal event named after the override.
+func (r *Reconciler) applySeverityOverride(ctx context.Context, eventID string,
+	event *datamodels.HealthEventWithStatus, override config.SeverityOverride) (bool, error) {
+	healthEvent := event.HealthEvent
+
+	slog.InfoContext(ctx, "Applying severity override",
+		"override", override.Name,
+		"severity", override.Severity,
+		"node", healthEvent.NodeName,
+		"check", healthEvent.CheckName,
+		"error_codes", healthEvent.ErrorCode)
+	severityOverridesAppliedTotal.WithLabelValues(override.Name, override.Severity).Inc()
+
+	record := datamodels.SeverityOverrideRecord{
+		Name:                      override.Name,
+		Severity:                  override.Severity,
+		Reason:                    override.Reason,
+		OriginalIsFatal:           healthEvent.IsFatal,
+		OriginalRecommendedAction: healthEvent.RecommendedAction.String(),
+		AppliedAt:                 time.Now().UTC(),
+	}
+
+	var multiErr *multierror.Error
+
+	if override.Severity == datamodels.SeverityFatal {
+		record.RecommendedAction = override.RecommendedAction
+	}
+
+	_, err := r.config.CollectionClient.UpdateOne(ctx,
+		bson.M{"healthevent.id": healthEvent.Id},
+		bson.M{"$set": bson.M{"healtheventstatus.severityoverride": record}})
+	if err != nil {
+		// The override applies regardless, only without its audit record
+		totalEventProcessingError.WithLabelValues("update_severity_override_error").Inc()
+		multiErr = multierror.Append(multiErr,
+			fmt.Errorf("failed to record severity override %s on event %s: %w", override.Name, healthEvent.Id, err))
+	}
+
+	if override.Severity != datamodels.SeverityFatal {
+		return false, multiErr.ErrorOrNil()
+	}
+
+	actionVal := r.getRecommendedActionValue(override.RecommendedAction, override.Name)
+
+	// The event already triggers the action of the override
+	if healthEvent.IsFatal && int32(healthEvent.RecommendedAction) == actionVal {
+		return false, multiErr.ErrorOrNil()
+	}
+
+	tagged := proto.Clone(healthEvent).(*protos.HealthEvent)
+	if tagged.Metadata == nil {
+		tagged.Metadata = make(map[string]string)
+	}
+
+	tagged.Metadata["severity_override"] = override.Name
+
+	relationships := r.relationships(ctx, eventID, healthEvent.NodeName, override.Name)
+
+	err = r.config.Publisher.Publish(ctx, tagged, protos.RecommendedAction(actionVal), override.Name, relationships)
+	if err != nil {
+		multiErr = multierror.Append(multiErr,
+			fmt.Errorf("failed to publish the event of severity override %s: %w", override.Name, err))
+
+		return false, multiErr.ErrorOrNil()
+	}
+
+	slog.InfoContext(ctx, "New event successfully published for severity override", "override", override.Name)
+
+	return true, multiErr.ErrorOrNil()
+}
+
+// handleReboot records the reboot of the node, which resets the counts of rules with
+// ResetOnReboot, and closes the incidents of rules resolved by the reboot.
+func (r *Reconciler) handleReboot(ctx context.Context, eventID string,
+	event *datamodels.HealthEventWithStatus) (bool, error) {
+	nodeName := event.HealthEvent.NodeName
+
+	rebootTime := event.CreatedAt
+	if event.HealthEvent.GeneratedTimestamp != nil {
+		rebootTime = event.HealthEvent.GeneratedTimestamp.AsTime()
+	}
+
+	slog.InfoContext(ctx, "Node rebooted, resetting rule counts", "node", nodeName, "reboot_time", rebootTime)
+	rebootsObservedTotal.WithLabelValues(nodeName).Inc()
+	r.setLastReboot(nodeName, rebootTime)
+
+	var multiErr *multierror.Error
+
+	publishedNewEvent := false
+
+	for _, ruleName := range r.config.HealthEventsAnalyzerRules.RebootResolvedRules() {
+		relationships := r.relationships(ctx, eventID, nodeName, ruleName)
+
+		if err := r.config.Publisher.PublishHealthy(ctx, event.HealthEvent, ruleName, relationships); err != nil {
+			multiErr = multierror.Append(multiErr, fmt.Errorf("failed to close incident of rule %s: %w", ruleName, err))
+			continue
+		}
+
+		slog.InfoContext(ctx, "Closed reboot-resolved incident", "rule_name", ruleName, "node", nodeName)
+
+		publishedNewEvent = true
+	}
+
+	if multiErr.ErrorOrNil() != nil {
+		return publishedNewEvent, fmt.Errorf("error in handling the reboot: %w", multiErr)
+	}
+
+	return publishedNewEvent, nil
+}
+
+// incident is the latest event the analyzer published for a rule on a node.
+type incident struct {
+	ID            primitive.ObjectID `bson:"_id"`
+	IsHealthy     bool               `bson:"ishealthy"`
+	CorrelationID string             `bson:"correlationid"`
+}
+
+// relationships links an event published for the rule to the stored event that
+// caused it and to the open incident of the rule on the node, which it
+// supersedes. An event opening a new incident is correlated by the ID of the
+// event that caused it.
+func (r *Reconciler) relationships(ctx context.Context, causedByID, nodeName,
+	ruleName string) publisher.Relationships {
+	relationships := publisher.Relationships{CausedByID: causedByID, CorrelationID: causedByID}
+
+	open, ok, err := r.openIncident(ctx, nodeName, ruleName)
+	if err != nil {
+		// The event is published regardless, only without the link to the incident
+		slog.WarnContext(ctx, "Failed to look up the open incident of the rule",
+			"rule_name", ruleName, "node", nodeName, "error", err)
+		totalEventProcessingError.WithLabelValues("incident_lookup_error").Inc()
+
+		return relationships
+	}
+
+	if ok {
+		relationships.SupersedesID = open.ID.Hex()
+		relationships.CorrelationID = open.CorrelationID
+
+		// Incidents opened before events carried correlation IDs
+		if relationships.CorrelationID == "" {
+			relationships.CorrelationID = open.ID.Hex()
+		}
+	}
+
+	return relationships
+}
+
+// openIncident returns the latest event published for the rule on the node,
+// unless the incident was closed by a healthy event since.
+func (r *Reconciler) openIncident(ctx context.Context, nodeName, ruleName string) (incident, bool, error) {
+	cursor, err := r.config.CollectionClient.Aggregate(ctx, []bson.M{
+		{"$match": bson.M{
+			"healthevent.agent":     publisher.Agent,
+			"healthevent.nodename":  nodeName,
+			"healthevent.checkname": ruleName,
+		}},
+		{"$sort": bson.M{"_id": -1}},
+		{"$limit": 1},
+		{"$project": bson.M{
+			"ishealthy":     "$healthevent.ishealthy",
+			"correlationid": "$healthevent.correlationid",
+		}},
+	})
+	if err != nil {
+		return incident{}, false, fmt.Errorf("failed to query incident: %w", err)
+	}
+
+	defer cursor.Close(ctx)
+
+	var results []incident
+	if err := cursor.All(ctx, &results); err != nil {
+		return incident{}, false, fmt.Errorf("failed to decode incident: %w", err)
+	}
+
+	if len(results) == 0 || results[0].IsHealthy || results[0].ID.IsZero() {
+		return incident{}, false, nil
+	}
+
+	return results[0], true, nil
+}
+
+// Rebalance reloads the state of the nodes after they moved between the analyzer
+// replicas: the last reboots of the nodes this replica took over were not tracked.
+// They are reloaded before the next event is processed.
+func (r *Reconciler) Rebalance(context.Context) {
+	r.reloadReboots.Store(true)
+}
+
+// loadLastReboots loads the last reboot of every node from the stored reboot events.
+func (r *Reconciler) loadLastReboots(ctx context.Context) error {
+	cursor, err := r.config.CollectionClient.Aggregate(ctx, []bson.M{
+		{"$match": bson.M{
+			"healthevent.checkname": datamodels.RebootCheckName,
+			"healthevent.ishealthy": true,
+		}},
+		{"$group": bson.M{
+			"_id":        "$healthevent.nodename",
+			"rebootedAt": bson.M{"$max": "$healthevent.generatedtimestamp.seconds"},
+		}},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to query reboot events: %w", err)
+	}
+
+	defer cursor.Close(ctx)
+
+	var results []struct {
+		NodeName   string `bson:"_id"`
+		RebootedAt int64  `bson:"rebootedAt"`
+	}
+
+	if err := cursor.All(ctx, &results); err != nil {
+		return fmt.Errorf("failed to decode reboot events: %w", err)
+	}
+
+	for _, result := range results {
+		r.setLastReboot(result.NodeName, time.Unix(result.RebootedAt, 0))
+	}
+
+	slog.InfoContext(ctx, "Loaded last node reboots", "nodes", len(results))
+
+	return nil
+}
+
+func (r *Reconciler) setLastReboot(nodeName string, rebootTime time.Time) {
+	r.lastRebootsMu.Lock()
+	defer r.lastRebootsMu.Unlock()
+
+	if last, ok := r.lastReboots.Peek(nodeName); !ok || rebootTime.After(last) {
+		r.lastReboots.Add(nodeName, rebootTime)
+	}
+}
+
+func (r *Reconciler) lastReboot(nodeName string) (time.Time, bool) {
+	r.lastRebootsMu.RLock()
+	defer r.lastRebootsMu.RUnlock()
+
+	rebootTime, ok := r.lastReboots.Get(nodeName)
+
+	return rebootTime, ok
+}
+
+// recentRollout returns the rollout on the node within the rollout correlation
+// window, if any.
+func (r *Reconciler) recentRollout(nodeName string) (rollout.Rollout, bool) {
+	correlation := r.config.HealthEventsAnalyzerRules.RolloutCorrelation
+	if correlation == nil || r.config.RolloutTracker == nil {
+		return rollout.Rollout{}, false
+	}
+
+	last, ok := r.config.RolloutTracker.LastRollout(nodeName)
+	if !ok || time.Since(last.Time) > correlation.WindowDuration() {
+		return rollout.Rollout{}, false
+	}
+
+	return last, true
+}
+
+// tagRolloutInduced marks the event as possibly rollout-induced and returns it
+// together with the conservative recommended action of the rollout correlation.
+func (r *Reconciler) tagRolloutInduced(event *protos.HealthEvent, recent rollout.Rollout,
+	ruleName string) (*protos.HealthEvent, int32) {
+	correlation := r.config.HealthEventsAnalyzerRules.RolloutCorrelation
+
+	slog.Warn("Rule matched shortly after a rollout, applying conservative remediation",
+		"rule_name", ruleName,
+		"node", event.NodeName,
+		"component", recent.Component,
+		"rollout_time", recent.Time,
+		"recommended_action", correlation.RecommendedAction)
+	rolloutInducedTotal.WithLabelValues(ruleName).Inc()
+
+	tagged := proto.Clone(event).(*protos.HealthEvent)
+	if tagged.Metadata == nil {
+		tagged.Metadata = make(map[string]string)
+	}
+
+	tagged.Metadata["possibly_rollout_induced"] = "true"
+	tagged.Metadata["rollout_component"] = recent.Component
+	tagged.Metadata["rollout_time"] = recent.Time.UTC().Format(time.RFC3339)
+
+	return tagged, r.getRecommendedActionValue(correlation.RecommendedAction, ruleName)
+}
+
+// Results of the confirmation of a log-detected fault against the DCGM health watches
+const (
+	telemetryConfirmed    = "confirmed"
+	telemetryContradicted = "contradicted"
+	telemetryUnavailable  = "unavailable"
+)
+
+// telemetryReference returns the DCGM health watches and NVML return codes of the
+// fault of the event when telemetry confirmation is configured.
+func (r *Reconciler) telemetryReference(event *protos.HealthEvent) (datamodels.TelemetryReference, bool) {
+	if r.config.HealthEventsAnalyzerRules.TelemetryConfirmation == nil {
+		return datamodels.TelemetryReference{}, false
+	}
+
+	return datamodels.TelemetryReferenceFor(event.CheckName, event.ErrorCode)
+}
+
+// confirmTelemetry tags the event with the telemetry cross-referenced to its fault
+// and whether the DCGM health watches confirm it. When they contradict it, the
+// conservative recommended action of the telemetry confirmation is returned.
+func (r *Reconciler) confirmTelemetry(ctx context.Context, event *protos.HealthEvent,
+	reference datamodels.TelemetryReference, actionVal int32, ruleName string) (*protos.HealthEvent, int32) {
+	confirmation := r.config.HealthEventsAnalyzerRules.TelemetryConfirmation
+
+	result, err := r.telemetryState(ctx, event.NodeName, reference)
+	if err != nil {
+		// The rule acts as if no telemetry was available
+		slog.WarnContext(ctx, "Failed to look up the DCGM health watches of the node",
+			"rule_name", ruleName, "node", event.NodeName, "error", err)
+		totalEventProcessingError.WithLabelValues("telemetry_lookup_error").Inc()
+	}
+
+	telemetryConfirmationsTotal.WithLabelValues(ruleName, result).Inc()
+
+	tagged := proto.Clone(event).(*protos.HealthEvent)
+	if tagged.Metadata == nil {
+		tagged.Metadata = make(map[string]string)
+	}
+
+	tagged.Metadata[datamodels.MetadataTelemetryConfirmation] = result
+	tagged.Metadata[datamodels.MetadataDCGMWatches] = strings.Join(reference.DCGMWatches, ",")
+
+	if len(reference.NVMLReturnCodes) > 0 {
+		tagged.Metadata[datamodels.MetadataNVMLReturnCodes] = strings.Join(reference.NVMLReturnCodes, ",")
+	}
+
+	if result != telemetryContradicted {
+		return tagged, actionVal
+	}
+
+	slog.WarnContext(ctx, "DCGM health watches report the GPUs healthy, applying conservative remediation",
+		"rule_name", ruleName,
+		"node", event.NodeName,
+		"check", event.CheckName,
+		"error_codes", event.ErrorCode,
+		"dcgm_watches", reference.DCGMWatches,
+		"recommended_action", confirmation.RecommendedAction)
+
+	return tagged, r.getRecommendedActionValue(confirmation.RecommendedAction, ruleName)
+}
+
+// telemetryState looks up the latest event of every GPU for the DCGM health watches
+// on the node. The fault is confirmed when a watch reports a GPU unhealthy and
+// contradicted when the watches report all GPUs healthy. The GPU health monitor
+// publishes the state of every watch when it starts and whenever it changes.
+func (r *Reconciler) telemetryState(ctx context.Context, nodeName string,
+	reference datamodels.TelemetryReference) (string, error) {
+	checks := make([]string, 0, len(reference.DCGMWatches))
+	for _, watch := range reference.DCGMWatches {
+		checks = append(checks, datamodels.DCGMWatchCheckName(watch))
+	}
+
+	cursor, err := r.config.CollectionClient.Aggregate(ctx, []bson.M{
+		{"$match": bson.M{
+			"healthevent.agent":     r.config.HealthEventsAnalyzerRules.TelemetryConfirmation.TelemetryAgent,
+			"healthevent.nodename":  nodeName,
+			"healthevent.checkname": bson.M{"$in": checks},
+		}},
+		{"$sort": bson.M{"_id": -1}},
+		{"$group": bson.M{
+			"_id": bson.M{
+				"check": "$healthevent.checkname",
+				"gpu":   bson.M{"$arrayElemAt": bson.A{"$healthevent.entitiesimpacted.entityvalue", 0}},
+			},
+			"ishealthy": bson.M{"$first": "$healthevent.ishealthy"},
+		}},
+	})
+	if err != nil {
+		return telemetryUnavailable, fmt.Errorf("failed to query DCGM health watch events: %w", err)
+	}
+
+	defer cursor.Close(ctx)
+
+	var results []struct {
+		IsHealthy bool `bson:"ishealthy"`
+	}
+
+	if err := cursor.All(ctx, &results); err != nil {
+		return telemetryUnavailable, fmt.Errorf("failed to decode DCGM health watch events: %w", err)
+	}
+
+	if len(results) == 0 {
+		return telemetryUnavailable, nil
+	}
+
+	for _, result := range results {
+		if !result.IsHealthy {
+			return telemetryConfirmed, nil
+		}
+	}
+
+	return telemetryContradicted, nil
+}
+
 // getRecommendedActionValue returns the action value, with fallback to RecommendedAction_CONTACT_SUPPORT if invalid
 func (r *Reconciler) getRecommendedActionValue(recommendedAction, ruleName string) int32 {
 	actionVal, ok := protos.RecommendedAction_value[recommendedAction]
@@ -231,18 +860,23 @@ func (r *Reconciler) getRecommendedActionValue(recommendedAction, ruleName strin
 
 func (r *Reconciler) validateAllSequenceCriteria(ctx context.Context, rule config.HealthEventsAnalyzerRule,
 	healthEventWithStatus datamodels.HealthEventWithStatus) (bool, error) {
-	slog.Debug("Evaluating rule for event", "rule_name", rule.Name, "event", healthEventWithStatus)
+	slog.DebugContext(ctx, "Evaluating rule for event", "rule_name", rule.Name, "event", healthEventWithStatus)
 
-	pipelineStages, err := getPipelineStages(rule, healthEventWithStatus)
+	var since time.Time
+	if rule.ResetOnReboot {
+		since, _ = r.lastReboot(healthEventWithStatus.HealthEvent.NodeName)
+	}
+
+	pipelineStages, err := getPipelineStages(rule, healthEventWithStatus, since)
 	if err != nil {
-		slog.Error("Failed to generate pipeline", "error", err)
+		slog.ErrorContext(ctx, "Failed to generate pipeline", "error", err)
 		return false, fmt.Errorf("failed to generate pipeline: %w", err)
 	}
 
-	slog.Debug("Generated pipeline", "pipeline_stages", pipelineStages)
+	slog.DebugContext(ctx, "Generated pipeline", "pipeline_stages", pipelineStages)
 
 	if len(pipelineStages) == 0 {
-		slog.Debug("No pipeline stages created for rule", "rule_name", rule.Name)
+		slog.DebugContext(ctx, "No pipeline stages created for rule", "rule_name", rule.Name)
 		totalEventProcessingError.WithLabelValues("no_pipeline_stages_error").Inc()
 
 		return false, nil
@@ -254,7 +888,7 @@ func (r *Reconciler) validateAllSequenceCriteria(ctx context.Context, rule confi
 
 	cursor, err := r.config.CollectionClient.Aggregate(ctx, pipelineStages)
 	if err != nil {
-		slog.Error("Failed to execute aggregation pipeline", "error", err)
+		slog.ErrorContext(ctx, "Failed to execute aggregation pipeline", "error", err)
 		totalEventProcessingError.WithLabelValues("execute_pipeline_error").Inc()
 
 		return false, fmt.Errorf("failed to execute aggregation pipeline: %w", err)
@@ -266,26 +900,38 @@ func (r *Reconciler) validateAllSequenceCriteria(ctx context.Context, rule confi
 	defer cursor.Close(ctx)
 
 	if err = cursor.All(ctx, &result); err != nil {
-		slog.Error("Failed to decode cursor", "error", err)
+		slog.ErrorContext(ctx, "Failed to decode cursor", "error", err)
 		totalEventProcessingError.WithLabelValues("decode_cursor_error").Inc()
 
 		return false, fmt.Errorf("failed to decode cursor: %w", err)
 	}
 
 	if len(result) > 0 {
-		slog.Debug("All sequence conditions met for rule", "rule_name", rule.Name, "result", result)
+		slog.DebugContext(ctx, "All sequence conditions met for rule", "rule_name", rule.Name, "result", result)
 		return true, nil
 	}
 
 	return false, nil
 }
 
+// getPipelineStages builds the aggregation pipeline of the rule. Events generated
+// before since are excluded when it is set.
 func getPipelineStages(rule config.HealthEventsAnalyzerRule,
-	healthEventWithStatus datamodels.HealthEventWithStatus) ([]map[string]interface{}, error) {
+	healthEventWithStatus datamodels.HealthEventWithStatus, since time.Time) ([]map[string]interface{}, error) {
+	match := map[string]interface{}{
+		"healthevent.agent": map[string]interface{}{"$ne": "health-events-analyzer"},
+		// Canary events must not count towards the rules of the canary node
+		"healthevent.metadata." + datamodels.MetadataCanary: map[string]interface{}{"$ne": "true"},
+		// Events downgraded by a severity override must not count towards the rules
+		"healtheventstatus.severityoverride.severity": map[string]interface{}{"$ne": datamodels.SeverityWarning},
+	}
+
+	if !since.IsZero() {
+		match["healthevent.generatedtimestamp.seconds"] = map[string]interface{}{"$gte": since.Unix()}
+	}
+
 	pipelineStages := []map[string]interface{}{
-		{"$match": map[string]interface{}{
-			"healthevent.agent": map[string]interface{}{"$ne": "health-events-analyzer"},
-		}},
+		{"$match": match},
 	}
 
 	if len(rule.Stage) > 0 {
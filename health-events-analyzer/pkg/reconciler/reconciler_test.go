@@ -17,21 +17,28 @@ package reconciler
 import (
 	"context"
 	"fmt"
+	"os"
 	"testing"
 	"time"
 
 	datamodels "github.com/nvidia/nvsentinel/data-models/pkg/model"
 	protos "github.com/nvidia/nvsentinel/data-models/pkg/protos"
+	"github.com/nvidia/nvsentinel/health-events-analyzer/pkg/classification"
 	config "github.com/nvidia/nvsentinel/health-events-analyzer/pkg/config"
 	"github.com/nvidia/nvsentinel/health-events-analyzer/pkg/publisher"
+	"github.com/nvidia/nvsentinel/health-events-analyzer/pkg/rollout"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
 	"go.mongodb.org/mongo-driver/mongo"
 	"go.mongodb.org/mongo-driver/mongo/options"
 	"google.golang.org/grpc"
+	"google.golang.org/protobuf/proto"
 	"google.golang.org/protobuf/types/known/emptypb"
 	"google.golang.org/protobuf/types/known/timestamppb"
+	"sigs.k8s.io/yaml"
 )
 
 type mockPublisher struct {
@@ -55,6 +62,38 @@ func (m *mockCollectionClient) Aggregate(ctx context.Context, pipeline interface
 	return args.Get(0).(*mongo.Cursor), args.Error(1)
 }
 
+func (m *mockCollectionClient) UpdateOne(ctx context.Context, filter interface{}, update interface{},
+	opts ...*options.UpdateOptions) (*mongo.UpdateResult, error) {
+	args := m.Called(ctx, filter, update)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*mongo.UpdateResult), args.Error(1)
+}
+
+// expectIncident mocks the lookup of the open incident of the rule on the node.
+func expectIncident(mockClient *mockCollectionClient, ctx context.Context, ruleName string, docs []bson.M) {
+	mockCursor, _ := createMockCursor(docs)
+	mockClient.On("Aggregate", ctx, mock.MatchedBy(func(pipeline []bson.M) bool {
+		match, ok := pipeline[0]["$match"].(bson.M)
+		return ok && match["healthevent.agent"] == publisher.Agent && match["healthevent.checkname"] == ruleName
+	}), mock.Anything).Return(mockCursor, nil).Once()
+}
+
+// matchPublished matches the expected events, which get a new ID when published.
+func matchPublished(expected *protos.HealthEvents) interface{} {
+	return mock.MatchedBy(func(events *protos.HealthEvents) bool {
+		if len(events.Events) != 1 || len(events.Events[0].Id) != datamodels.EventIDLength {
+			return false
+		}
+
+		published := proto.Clone(events).(*protos.HealthEvents)
+		published.Events[0].Id = ""
+
+		return proto.Equal(expected, published)
+	})
+}
+
 func createMockCursor(docs []bson.M) (*mongo.Cursor, error) {
 	var rawDocs []interface{}
 	for _, doc := range docs {
@@ -71,6 +110,8 @@ func createMockCursor(docs []bson.M) (*mongo.Cursor, error) {
 	return mongo.NewCursorFromDocuments(rawDocs, nil, nil)
 }
 
+const testEventID = "6720abc123def456789abcde"
+
 var (
 	rules = []config.HealthEventsAnalyzerRule{
 		{
@@ -109,6 +150,15 @@ var (
 			RecommendedAction: "CONTACT_SUPPORT",
 		},
 	}
+	rateRule = config.RateOfChangeRule{
+		Name:              "RapidCorrectableECC",
+		RecommendedAction: "CONTACT_SUPPORT",
+		CheckName:         "GpuMemWatch",
+		CounterKey:        "ecc_sbe_total",
+		EntityType:        "GPU",
+		Window:            "1h",
+		MinIncrease:       100,
+	}
 	healthEvent_13 = datamodels.HealthEventWithStatus{
 		CreatedAt: time.Now(),
 		HealthEvent: &protos.HealthEvent{
@@ -193,18 +243,21 @@ func TestHandleEvent(t *testing.T) {
 			Metadata:           healthEvent_13.HealthEvent.Metadata,
 			GeneratedTimestamp: healthEvent_13.HealthEvent.GeneratedTimestamp,
 			NodeName:           healthEvent_13.HealthEvent.NodeName,
+			Priority:           protos.Priority_PRIORITY_HIGH, // Publisher sets this
+			CausedById:         testEventID,
+			CorrelationId:      testEventID, // Opens a new incident
 		}
 		expectedHealthEvents := &protos.HealthEvents{
 			Version: 1,
 			Events:  []*protos.HealthEvent{expectedTransformedEvent},
 		}
 
-		mockPublisher.On("HealthEventOccurredV1", ctx, expectedHealthEvents).Return(&emptypb.Empty{}, nil)
+		mockPublisher.On("HealthEventOccurredV1", ctx, matchPublished(expectedHealthEvents)).Return(&emptypb.Empty{}, nil)
 
 		mockCursor, _ := createMockCursor([]bson.M{{"count": 5}})
 		mockClient.On("Aggregate", ctx, mock.Anything, mock.Anything).Return(mockCursor, nil)
 
-		published, _ := reconciler.handleEvent(ctx, &healthEvent_13)
+		published, _ := reconciler.handleEvent(ctx, testEventID, &healthEvent_13)
 		assert.True(t, published)
 		mockClient.AssertExpectations(t)
 		mockPublisher.AssertExpectations(t)
@@ -236,17 +289,20 @@ func TestHandleEvent(t *testing.T) {
 			Metadata:           healthEvent_13.HealthEvent.Metadata,
 			GeneratedTimestamp: healthEvent_13.HealthEvent.GeneratedTimestamp,
 			NodeName:           healthEvent_13.HealthEvent.NodeName,
+			Priority:           protos.Priority_PRIORITY_HIGH, // Publisher sets this
+			CausedById:         testEventID,
+			CorrelationId:      testEventID, // Opens a new incident
 		}
 		expectedHealthEvents := &protos.HealthEvents{
 			Version: 1,
 			Events:  []*protos.HealthEvent{expectedTransformedEvent},
 		}
 
-		mockPublisher.On("HealthEventOccurredV1", ctx, expectedHealthEvents).Return(&emptypb.Empty{}, nil)
+		mockPublisher.On("HealthEventOccurredV1", ctx, matchPublished(expectedHealthEvents)).Return(&emptypb.Empty{}, nil)
 		mockCursor, _ := createMockCursor([]bson.M{{"count": 5}})
 		mockClient.On("Aggregate", ctx, mock.Anything, mock.Anything).Return(mockCursor, nil)
 
-		published, _ := reconciler.handleEvent(ctx, &healthEvent_13)
+		published, _ := reconciler.handleEvent(ctx, testEventID, &healthEvent_13)
 		assert.True(t, published)
 		mockClient.AssertExpectations(t)
 		mockPublisher.AssertExpectations(t)
@@ -265,7 +321,7 @@ func TestHandleEvent(t *testing.T) {
 		mockCursor, _ := createMockCursor([]bson.M{})
 		mockClient.On("Aggregate", ctx, mock.Anything, mock.Anything).Return(mockCursor, nil)
 
-		published, _ := reconciler.handleEvent(ctx, &healthEvent_48)
+		published, _ := reconciler.handleEvent(ctx, testEventID, &healthEvent_48)
 		assert.False(t, published)
 		mockClient.AssertExpectations(t)
 		mockPublisher.AssertNotCalled(t, "HealthEventOccurredV1")
@@ -284,7 +340,7 @@ func TestHandleEvent(t *testing.T) {
 		mockCursor, _ := createMockCursor([]bson.M{})
 		mockClient.On("Aggregate", ctx, mock.Anything, mock.Anything).Return(mockCursor, nil)
 
-		published, _ := reconciler.handleEvent(ctx, &healthEvent_13)
+		published, _ := reconciler.handleEvent(ctx, testEventID, &healthEvent_13)
 		assert.False(t, published)
 		mockClient.AssertExpectations(t)
 		mockPublisher.AssertNotCalled(t, "HealthEventOccurredV1")
@@ -302,10 +358,767 @@ func TestHandleEvent(t *testing.T) {
 		}
 		reconciler := NewReconciler(cfg)
 
-		published, err := reconciler.handleEvent(ctx, &healthEvent_13)
+		published, err := reconciler.handleEvent(ctx, testEventID, &healthEvent_13)
+		assert.NoError(t, err)
+		assert.False(t, published)
+		mockClient.AssertNotCalled(t, "Aggregate")
+		mockPublisher.AssertNotCalled(t, "HealthEventOccurredV1")
+	})
+	t.Run("rate rule skips events without the counter", func(t *testing.T) {
+		mockClient := new(mockCollectionClient)
+		mockPublisher := &mockPublisher{}
+		cfg := HealthEventsAnalyzerReconcilerConfig{
+			HealthEventsAnalyzerRules: &config.TomlConfig{RateRules: []config.RateOfChangeRule{rateRule}},
+			CollectionClient:          mockClient,
+			Publisher:                 publisher.NewPublisher(mockPublisher),
+		}
+		reconciler := NewReconciler(cfg)
+
+		published, err := reconciler.handleEvent(ctx, testEventID, &healthEvent_13)
+		assert.NoError(t, err)
+		assert.False(t, published)
+		mockClient.AssertNotCalled(t, "Aggregate")
+	})
+
+	t.Run("rate rule matches a rapidly increasing counter", func(t *testing.T) {
+		mockClient := new(mockCollectionClient)
+		mockPublisher := &mockPublisher{}
+		cfg := HealthEventsAnalyzerReconcilerConfig{
+			HealthEventsAnalyzerRules: &config.TomlConfig{RateRules: []config.RateOfChangeRule{rateRule}},
+			CollectionClient:          mockClient,
+			Publisher:                 publisher.NewPublisher(mockPublisher),
+		}
+		reconciler := NewReconciler(cfg)
+
+		event := datamodels.HealthEventWithStatus{
+			HealthEvent: &protos.HealthEvent{
+				Agent:            "gpu-health-monitor",
+				CheckName:        "GpuMemWatch",
+				EntitiesImpacted: []*protos.Entity{{EntityType: "GPU", EntityValue: "3"}},
+				Metadata:         map[string]string{"ecc_sbe_total": "1200"},
+				NodeName:         "node1",
+			},
+		}
+
+		mockPublisher.On("HealthEventOccurredV1", ctx, mock.Anything).Return(&emptypb.Empty{}, nil)
+		expectIncident(mockClient, ctx, "RapidCorrectableECC", nil)
+		mockCursor, _ := createMockCursor([]bson.M{{"increase": 150.0, "samples": 4}})
+		mockClient.On("Aggregate", ctx, mock.MatchedBy(func(pipeline []map[string]interface{}) bool {
+			match, ok := pipeline[1]["$match"].(map[string]interface{})
+			return ok && match["healthevent.nodename"] == "node1" && match["healthevent.checkname"] == "GpuMemWatch"
+		}), mock.Anything).Return(mockCursor, nil)
+
+		published, err := reconciler.handleEvent(ctx, testEventID, &event)
+		assert.NoError(t, err)
+		assert.True(t, published)
+		mockClient.AssertExpectations(t)
+		mockPublisher.AssertExpectations(t)
+	})
+	t.Run("rule matched shortly after a rollout uses the conservative action", func(t *testing.T) {
+		mockClient := new(mockCollectionClient)
+		mockPublisher := &mockPublisher{}
+		correlation := &config.RolloutCorrelation{Window: "30m"}
+		require.NoError(t, correlation.Validate())
+
+		rule := rules[0]
+		rule.RecommendedAction = "RESTART_BM"
+
+		cfg := HealthEventsAnalyzerReconcilerConfig{
+			HealthEventsAnalyzerRules: &config.TomlConfig{
+				Rules:              []config.HealthEventsAnalyzerRule{rule},
+				RolloutCorrelation: correlation,
+			},
+			CollectionClient: mockClient,
+			Publisher:        publisher.NewPublisher(mockPublisher),
+			RolloutTracker: fakeRolloutTracker{"node1": {
+				Component: "nvcr.io/nvidia/driver:570.86",
+				Time:      time.Now().Add(-10 * time.Minute),
+			}},
+		}
+		reconciler := NewReconciler(cfg)
+
+		mockPublisher.On("HealthEventOccurredV1", ctx, mock.MatchedBy(func(events *protos.HealthEvents) bool {
+			event := events.Events[0]
+			return event.RecommendedAction == protos.RecommendedAction_CONTACT_SUPPORT &&
+				event.Metadata["possibly_rollout_induced"] == "true" &&
+				event.Metadata["rollout_component"] == "nvcr.io/nvidia/driver:570.86"
+		})).Return(&emptypb.Empty{}, nil)
+		mockCursor, _ := createMockCursor([]bson.M{{"count": 5}})
+		mockClient.On("Aggregate", ctx, mock.Anything, mock.Anything).Return(mockCursor, nil)
+
+		published, err := reconciler.handleEvent(ctx, testEventID, &healthEvent_13)
+		assert.NoError(t, err)
+		assert.True(t, published)
+		mockPublisher.AssertExpectations(t)
+		assert.NotContains(t, healthEvent_13.HealthEvent.Metadata, "possibly_rollout_induced",
+			"the source event must not be modified")
+	})
+
+	t.Run("repeated match supersedes the open incident of the rule", func(t *testing.T) {
+		mockClient := new(mockCollectionClient)
+		mockPublisher := &mockPublisher{}
+		cfg := HealthEventsAnalyzerReconcilerConfig{
+			HealthEventsAnalyzerRules: &config.TomlConfig{Rules: []config.HealthEventsAnalyzerRule{rules[1]}},
+			CollectionClient:          mockClient,
+			Publisher:                 publisher.NewPublisher(mockPublisher),
+		}
+		reconciler := NewReconciler(cfg)
+
+		// Opened before correlation IDs were set, so it correlates by its own ID
+		incidentID := primitive.NewObjectID()
+		expectIncident(mockClient, ctx, "rule2", []bson.M{{"_id": incidentID, "ishealthy": false}})
+
+		mockPublisher.On("HealthEventOccurredV1", ctx, mock.MatchedBy(func(events *protos.HealthEvents) bool {
+			event := events.Events[0]
+			return event.CausedById == testEventID && event.SupersedesId == incidentID.Hex() &&
+				event.CorrelationId == incidentID.Hex()
+		})).Return(&emptypb.Empty{}, nil)
+		mockCursor, _ := createMockCursor([]bson.M{{"count": 5}})
+		mockClient.On("Aggregate", ctx, mock.MatchedBy(func(pipeline []map[string]interface{}) bool {
+			return true
+		}), mock.Anything).Return(mockCursor, nil)
+
+		published, err := reconciler.handleEvent(ctx, testEventID, &healthEvent_13)
+		assert.NoError(t, err)
+		assert.True(t, published)
+		mockClient.AssertExpectations(t)
+		mockPublisher.AssertExpectations(t)
+	})
+
+	t.Run("baseline rule matches a rate above the SKU baseline", func(t *testing.T) {
+		mockClient := new(mockCollectionClient)
+		mockPublisher := &mockPublisher{}
+		cfg := HealthEventsAnalyzerReconcilerConfig{
+			HealthEventsAnalyzerRules: &config.TomlConfig{BaselineRules: []config.BaselineRule{{
+				Name:            "UnusualCorrectableErrorRate",
+				CheckName:       "GpuMemWatch",
+				Scope:           config.BaselineScopeSKU,
+				SKUKey:          "gpu_product",
+				TrainingWindow:  "168h",
+				DetectionWindow: "1h",
+			}}},
+			CollectionClient: mockClient,
+			Publisher:        publisher.NewPublisher(mockPublisher),
+		}
+		reconciler := NewReconciler(cfg)
+
+		event := datamodels.HealthEventWithStatus{
+			HealthEvent: &protos.HealthEvent{
+				Agent:     "gpu-health-monitor",
+				CheckName: "GpuMemWatch",
+				Metadata:  map[string]string{"gpu_product": "H100-SXM5-80GB"},
+				NodeName:  "node1",
+			},
+		}
+
+		mockPublisher.On("HealthEventOccurredV1", ctx, mock.Anything).Return(&emptypb.Empty{}, nil)
+		expectIncident(mockClient, ctx, "UnusualCorrectableErrorRate", nil)
+		mockCursor, _ := createMockCursor([]bson.M{{"recent": 12, "expected": 0.5}})
+		mockClient.On("Aggregate", ctx, mock.MatchedBy(func(pipeline []map[string]interface{}) bool {
+			match, ok := pipeline[1]["$match"].(map[string]interface{})
+			return ok && match["healthevent.metadata.gpu_product"] == "H100-SXM5-80GB"
+		}), mock.Anything).Return(mockCursor, nil)
+
+		published, err := reconciler.handleEvent(ctx, testEventID, &event)
+		assert.NoError(t, err)
+		assert.True(t, published)
+		mockClient.AssertExpectations(t)
+		mockPublisher.AssertExpectations(t)
+	})
+}
+
+func TestHandleBackfilledEvent(t *testing.T) {
+	ctx := context.Background()
+
+	mockClient := new(mockCollectionClient)
+	mockPublisher := &mockPublisher{}
+	cfg := HealthEventsAnalyzerReconcilerConfig{
+		HealthEventsAnalyzerRules: &config.TomlConfig{Rules: rules},
+		CollectionClient:          mockClient,
+		Publisher:                 publisher.NewPublisher(mockPublisher),
+	}
+	reconciler := NewReconciler(cfg)
+
+	event := datamodels.HealthEventWithStatus{
+		HealthEvent: &protos.HealthEvent{
+			Agent:     "syslog-health-monitor",
+			CheckName: "SysLogsXIDError",
+			ErrorCode: []string{"13"},
+			Metadata:  map[string]string{datamodels.MetadataBackfilled: "true"},
+			NodeName:  "node1",
+		},
+	}
+
+	published, err := reconciler.handleEvent(ctx, testEventID, &event)
+	assert.NoError(t, err)
+	assert.False(t, published)
+	mockClient.AssertNotCalled(t, "Aggregate")
+	mockPublisher.AssertNotCalled(t, "HealthEventOccurredV1")
+}
+
+type fakeSharder struct {
+	owned     string
+	processed []time.Time
+}
+
+func (f *fakeSharder) Owns(nodeName string) bool {
+	return nodeName == f.owned
+}
+
+func (f *fakeSharder) Processed(generatedAt time.Time) {
+	f.processed = append(f.processed, generatedAt)
+}
+
+func TestProcessEventSkipsOtherShards(t *testing.T) {
+	ctx := context.Background()
+
+	mockClient := new(mockCollectionClient)
+	mockPublisher := &mockPublisher{}
+	sharder := &fakeSharder{owned: "node1"}
+	reconciler := NewReconciler(HealthEventsAnalyzerReconcilerConfig{
+		HealthEventsAnalyzerRules: &config.TomlConfig{Rules: rules},
+		CollectionClient:          mockClient,
+		Publisher:                 publisher.NewPublisher(mockPublisher),
+		Sharder:                   sharder,
+	})
+
+	generatedAt := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+
+	for _, nodeName := range []string{"node1", "node2"} {
+		event := bson.M{"fullDocument": bson.M{"healthevent": &protos.HealthEvent{
+			Agent:              "syslog-health-monitor",
+			CheckName:          "SysLogsXIDError",
+			ErrorCode:          []string{"13"},
+			Metadata:           map[string]string{datamodels.MetadataBackfilled: "true"},
+			NodeName:           nodeName,
+			GeneratedTimestamp: timestamppb.New(generatedAt),
+		}}}
+
+		assert.NoError(t, reconciler.processEvent(ctx, event))
+	}
+
+	// only the event of the owned node was evaluated
+	assert.Equal(t, []time.Time{generatedAt}, sharder.processed)
+	mockClient.AssertNotCalled(t, "Aggregate")
+	mockPublisher.AssertNotCalled(t, "HealthEventOccurredV1")
+}
+
+func TestHandleCanaryEvent(t *testing.T) {
+	ctx := context.Background()
+
+	mockClient := new(mockCollectionClient)
+	mockPublisher := &mockPublisher{}
+	cfg := HealthEventsAnalyzerReconcilerConfig{
+		HealthEventsAnalyzerRules: &config.TomlConfig{Rules: rules},
+		CollectionClient:          mockClient,
+		Publisher:                 publisher.NewPublisher(mockPublisher),
+	}
+	reconciler := NewReconciler(cfg)
+
+	event := datamodels.HealthEventWithStatus{
+		HealthEvent: &protos.HealthEvent{
+			Id:        "canary-1",
+			Agent:     "syslog-health-monitor",
+			CheckName: "SysLogsXIDError",
+			ErrorCode: []string{"13"},
+			Metadata:  map[string]string{datamodels.MetadataCanary: "true"},
+			NodeName:  "node1",
+		},
+	}
+
+	mockClient.On("UpdateOne", ctx, bson.M{"healthevent.id": "canary-1"}, mock.MatchedBy(func(update bson.M) bool {
+		set, ok := update["$set"].(bson.M)
+		return ok && set["healtheventstatus.canary.analyzedat"] != nil
+	})).Return(&mongo.UpdateResult{MatchedCount: 1}, nil).Once()
+
+	published, err := reconciler.handleEvent(ctx, testEventID, &event)
+	assert.NoError(t, err)
+	assert.False(t, published)
+	mockClient.AssertExpectations(t)
+	mockClient.AssertNotCalled(t, "Aggregate")
+	mockPublisher.AssertNotCalled(t, "HealthEventOccurredV1")
+
+	t.Run("canary events are excluded from the rule counts", func(t *testing.T) {
+		stages, err := getPipelineStages(rules[0], healthEvent_13, time.Time{})
+		require.NoError(t, err)
+
+		match, ok := stages[0]["$match"].(map[string]interface{})
+		require.True(t, ok)
+		assert.Equal(t, map[string]interface{}{"$ne": "true"}, match["healthevent.metadata.canary"])
+	})
+}
+
+func TestHandleSeverityOverride(t *testing.T) {
+	ctx := context.Background()
+
+	warning := config.SeverityOverride{
+		Name:       "xid13-known-driver-bug",
+		Agent:      "gpu-health-monitor",
+		ErrorCodes: []string{"13"},
+		Severity:   datamodels.SeverityWarning,
+		Reason:     "spurious on the current driver",
+	}
+	fatal := config.SeverityOverride{
+		Name:              "xid48-replace-gpu",
+		ErrorCodes:        []string{"48"},
+		Severity:          datamodels.SeverityFatal,
+		RecommendedAction: "CONTACT_SUPPORT",
+		Reason:            "double bit ECC errors on this fleet need RMA",
+	}
+
+	expectRecord := func(mockClient *mockCollectionClient, name, severity string) {
+		mockClient.On("UpdateOne", ctx, mock.Anything, mock.MatchedBy(func(update bson.M) bool {
+			set, ok := update["$set"].(bson.M)
+			if !ok {
+				return false
+			}
+
+			record, ok := set["healtheventstatus.severityoverride"].(datamodels.SeverityOverrideRecord)
+
+			return ok && record.Name == name && record.Severity == severity && record.OriginalIsFatal &&
+				!record.AppliedAt.IsZero()
+		})).Return(&mongo.UpdateResult{MatchedCount: 1}, nil).Once()
+	}
+
+	t.Run("WARNING override records the override and skips the rules", func(t *testing.T) {
+		mockClient := new(mockCollectionClient)
+		mockPublisher := &mockPublisher{}
+		cfg := HealthEventsAnalyzerReconcilerConfig{
+			HealthEventsAnalyzerRules: &config.TomlConfig{
+				Rules:             rules,
+				SeverityOverrides: []config.SeverityOverride{warning, fatal},
+			},
+			CollectionClient: mockClient,
+			Publisher:        publisher.NewPublisher(mockPublisher),
+		}
+		reconciler := NewReconciler(cfg)
+
+		expectRecord(mockClient, warning.Name, datamodels.SeverityWarning)
+
+		published, err := reconciler.handleEvent(ctx, testEventID, &healthEvent_13)
 		assert.NoError(t, err)
 		assert.False(t, published)
+		mockClient.AssertExpectations(t)
 		mockClient.AssertNotCalled(t, "Aggregate")
 		mockPublisher.AssertNotCalled(t, "HealthEventOccurredV1")
 	})
+
+	t.Run("FATAL override publishes an event named after the override", func(t *testing.T) {
+		mockClient := new(mockCollectionClient)
+		mockPublisher := &mockPublisher{}
+		cfg := HealthEventsAnalyzerReconcilerConfig{
+			HealthEventsAnalyzerRules: &config.TomlConfig{
+				SeverityOverrides: []config.SeverityOverride{warning, fatal},
+			},
+			CollectionClient: mockClient,
+			Publisher:        publisher.NewPublisher(mockPublisher),
+		}
+		reconciler := NewReconciler(cfg)
+
+		expectRecord(mockClient, fatal.Name, datamodels.SeverityFatal)
+		expectIncident(mockClient, ctx, fatal.Name, nil)
+
+		mockPublisher.On("HealthEventOccurredV1", ctx, mock.MatchedBy(func(events *protos.HealthEvents) bool {
+			event := events.Events[0]
+			return event.CheckName == fatal.Name && event.IsFatal &&
+				event.RecommendedAction == protos.RecommendedAction_CONTACT_SUPPORT &&
+				event.Metadata["severity_override"] == fatal.Name && event.CausedById == testEventID
+		})).Return(&emptypb.Empty{}, nil).Once()
+
+		published, err := reconciler.handleEvent(ctx, testEventID, &healthEvent_48)
+		assert.NoError(t, err)
+		assert.True(t, published)
+		mockClient.AssertExpectations(t)
+		mockPublisher.AssertExpectations(t)
+	})
+
+	t.Run("expired overrides do not apply", func(t *testing.T) {
+		expired := warning
+		expired.ExpiresAt = time.Now().Add(-time.Hour).Format(time.RFC3339)
+
+		mockClient := new(mockCollectionClient)
+		cfg := HealthEventsAnalyzerReconcilerConfig{
+			HealthEventsAnalyzerRules: &config.TomlConfig{SeverityOverrides: []config.SeverityOverride{expired}},
+			CollectionClient:          mockClient,
+			Publisher:                 publisher.NewPublisher(&mockPublisher{}),
+		}
+		reconciler := NewReconciler(cfg)
+
+		published, err := reconciler.handleEvent(ctx, testEventID, &healthEvent_13)
+		assert.NoError(t, err)
+		assert.False(t, published)
+		mockClient.AssertNotCalled(t, "UpdateOne")
+	})
+
+	t.Run("downgraded events are excluded from the rule counts", func(t *testing.T) {
+		stages, err := getPipelineStages(rules[0], healthEvent_13, time.Time{})
+		require.NoError(t, err)
+
+		match, ok := stages[0]["$match"].(map[string]interface{})
+		require.True(t, ok)
+		assert.Equal(t, map[string]interface{}{"$ne": datamodels.SeverityWarning},
+			match["healtheventstatus.severityoverride.severity"])
+	})
+}
+
+func TestClassifyIncident(t *testing.T) {
+	ctx := context.Background()
+	chain := classification.NewChain(classification.NewRuleClassifier([]config.ClassificationRule{
+		{Name: "xid48", CheckName: "GpuXidError", ErrorCodes: []string{"48"}, Tags: []string{"hardware"}},
+	}))
+
+	tests := []struct {
+		name       string
+		classifier *classification.Chain
+		rule       config.HealthEventsAnalyzerRule
+		event      *protos.HealthEvent
+		tags       string
+		source     string
+	}{
+		{
+			name:       "tags of the rule",
+			classifier: chain,
+			rule:       config.HealthEventsAnalyzerRule{Name: "RepeatedThrottling", Tags: []string{"thermal", "hardware"}},
+			event:      healthEvent_48.HealthEvent,
+			tags:       "thermal,hardware",
+			source:     "rule",
+		},
+		{
+			name:       "classification rule",
+			classifier: chain,
+			rule:       config.HealthEventsAnalyzerRule{Name: "RepeatedXidError"},
+			event:      healthEvent_48.HealthEvent,
+			tags:       "hardware",
+			source:     "classification_rules",
+		},
+		{
+			name:       "no classifier has an opinion",
+			classifier: chain,
+			rule:       config.HealthEventsAnalyzerRule{Name: "RepeatedXidError"},
+			event:      healthEvent_13.HealthEvent,
+			tags:       "unknown",
+			source:     classification.SourceUnknown,
+		},
+		{
+			name:  "no classifier",
+			rule:  config.HealthEventsAnalyzerRule{Name: "RepeatedXidError"},
+			event: healthEvent_13.HealthEvent,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reconciler := NewReconciler(HealthEventsAnalyzerReconcilerConfig{
+				HealthEventsAnalyzerRules: &config.TomlConfig{},
+				Classifier:                tt.classifier,
+			})
+
+			classified := reconciler.classify(ctx, tt.event, tt.rule)
+
+			assert.Equal(t, tt.tags, classified.Metadata[datamodels.IncidentTagsMetadataKey])
+			assert.Equal(t, tt.source, classified.Metadata[datamodels.IncidentTagSourceMetadataKey])
+			assert.Empty(t, tt.event.Metadata[datamodels.IncidentTagsMetadataKey], "the stored event is not modified")
+		})
+	}
+}
+
+func TestHandleReboot(t *testing.T) {
+	ctx := context.Background()
+
+	rebootRule := rules[1]
+	rebootRule.RecommendedAction = "RESTART_BM"
+	rebootRule.ResetOnReboot = true
+
+	rebootTime := time.Now().Add(-time.Hour)
+	rebootEvent := datamodels.HealthEventWithStatus{
+		HealthEvent: &protos.HealthEvent{
+			Agent:              "syslog-health-monitor",
+			CheckName:          datamodels.RebootCheckName,
+			IsHealthy:          true,
+			Message:            "Node rebooted",
+			NodeName:           "node1",
+			GeneratedTimestamp: timestamppb.New(rebootTime),
+		},
+	}
+
+	t.Run("reboot closes incidents of reboot-resolved rules", func(t *testing.T) {
+		mockClient := new(mockCollectionClient)
+		mockPublisher := &mockPublisher{}
+		cfg := HealthEventsAnalyzerReconcilerConfig{
+			HealthEventsAnalyzerRules: &config.TomlConfig{
+				Rules: []config.HealthEventsAnalyzerRule{rules[0], rebootRule},
+			},
+			CollectionClient: mockClient,
+			Publisher:        publisher.NewPublisher(mockPublisher),
+		}
+		reconciler := NewReconciler(cfg)
+
+		incidentID := primitive.NewObjectID()
+		expectIncident(mockClient, ctx, "rule2", []bson.M{{"_id": incidentID, "ishealthy": false, "correlationid": "opening-event"}})
+
+		mockPublisher.On("HealthEventOccurredV1", ctx, mock.MatchedBy(func(events *protos.HealthEvents) bool {
+			event := events.Events[0]
+			return event.CheckName == "rule2" && event.IsHealthy && !event.IsFatal &&
+				event.Agent == "health-events-analyzer" && event.NodeName == "node1" &&
+				event.CausedById == testEventID && event.SupersedesId == incidentID.Hex() &&
+				event.CorrelationId == "opening-event"
+		})).Return(&emptypb.Empty{}, nil).Once()
+
+		published, err := reconciler.handleEvent(ctx, testEventID, &rebootEvent)
+		assert.NoError(t, err)
+		assert.True(t, published)
+		mockClient.AssertExpectations(t)
+		mockPublisher.AssertExpectations(t)
+	})
+
+	t.Run("rules with reset_on_reboot only count events after the reboot", func(t *testing.T) {
+		mockClient := new(mockCollectionClient)
+		mockPublisher := &mockPublisher{}
+		cfg := HealthEventsAnalyzerReconcilerConfig{
+			HealthEventsAnalyzerRules: &config.TomlConfig{Rules: []config.HealthEventsAnalyzerRule{rebootRule}},
+			CollectionClient:          mockClient,
+			Publisher:                 publisher.NewPublisher(mockPublisher),
+		}
+		reconciler := NewReconciler(cfg)
+
+		mockPublisher.On("HealthEventOccurredV1", ctx, mock.Anything).Return(&emptypb.Empty{}, nil)
+		expectIncident(mockClient, ctx, "rule2", nil)
+
+		_, err := reconciler.handleEvent(ctx, testEventID, &rebootEvent)
+		require.NoError(t, err)
+
+		mockCursor, _ := createMockCursor([]bson.M{})
+		mockClient.On("Aggregate", ctx, mock.MatchedBy(func(pipeline []map[string]interface{}) bool {
+			match, ok := pipeline[0]["$match"].(map[string]interface{})
+			if !ok {
+				return false
+			}
+
+			since, ok := match["healthevent.generatedtimestamp.seconds"].(map[string]interface{})
+
+			return ok && since["$gte"] == rebootTime.Unix()
+		}), mock.Anything).Return(mockCursor, nil)
+
+		published, err := reconciler.handleEvent(ctx, testEventID, &healthEvent_13)
+		assert.NoError(t, err)
+		assert.False(t, published)
+		mockClient.AssertExpectations(t)
+	})
+
+	t.Run("rules without reset_on_reboot count events before the reboot", func(t *testing.T) {
+		stages, err := getPipelineStages(rules[0], healthEvent_13, time.Time{})
+		require.NoError(t, err)
+
+		match, ok := stages[0]["$match"].(map[string]interface{})
+		require.True(t, ok)
+		assert.NotContains(t, match, "healthevent.generatedtimestamp.seconds")
+	})
+}
+
+func TestConfirmTelemetry(t *testing.T) {
+	ctx := context.Background()
+
+	fallenOffEvent := datamodels.HealthEventWithStatus{
+		HealthEvent: &protos.HealthEvent{
+			Agent:             "syslog-health-monitor",
+			ComponentClass:    "GPU",
+			CheckName:         "SysLogsGPUFallenOff",
+			IsFatal:           true,
+			ErrorCode:         []string{"GPU_FALLEN_OFF_BUS"},
+			RecommendedAction: protos.RecommendedAction_CONTACT_SUPPORT,
+			EntitiesImpacted:  []*protos.Entity{{EntityType: "PCI", EntityValue: "0000:b3:00.0"}},
+			NodeName:          "node1",
+		},
+	}
+
+	rule := rules[0]
+	rule.RecommendedAction = "RESTART_BM"
+
+	testCases := []struct {
+		name      string
+		telemetry []bson.M
+		result    string
+		action    protos.RecommendedAction
+	}{
+		{
+			name:      "watch reports a GPU unhealthy",
+			telemetry: []bson.M{{"ishealthy": true}, {"ishealthy": false}},
+			result:    "confirmed",
+			action:    protos.RecommendedAction_RESTART_BM,
+		},
+		{
+			name:      "watches report all GPUs healthy",
+			telemetry: []bson.M{{"ishealthy": true}, {"ishealthy": true}},
+			result:    "contradicted",
+			action:    protos.RecommendedAction_NONE,
+		},
+		{
+			name:   "no watch events on the node",
+			result: "unavailable",
+			action: protos.RecommendedAction_RESTART_BM,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockClient := new(mockCollectionClient)
+			mockPublisher := &mockPublisher{}
+			confirmation := &config.TelemetryConfirmation{RecommendedAction: "NONE"}
+			require.NoError(t, confirmation.Validate())
+
+			reconciler := NewReconciler(HealthEventsAnalyzerReconcilerConfig{
+				HealthEventsAnalyzerRules: &config.TomlConfig{
+					Rules:                 []config.HealthEventsAnalyzerRule{rule},
+					TelemetryConfirmation: confirmation,
+				},
+				CollectionClient: mockClient,
+				Publisher:        publisher.NewPublisher(mockPublisher),
+			})
+
+			telemetryCursor, _ := createMockCursor(tc.telemetry)
+			mockClient.On("Aggregate", ctx, mock.MatchedBy(func(pipeline []bson.M) bool {
+				match, ok := pipeline[0]["$match"].(bson.M)
+				return ok && match["healthevent.agent"] == "gpu-health-monitor" &&
+					assert.ObjectsAreEqual(bson.M{"$in": []string{"GpuPcieWatch"}}, match["healthevent.checkname"])
+			}), mock.Anything).Return(telemetryCursor, nil).Once()
+			expectIncident(mockClient, ctx, rule.Name, nil)
+			mockCursor, _ := createMockCursor([]bson.M{{"count": 5}})
+			mockClient.On("Aggregate", ctx, mock.Anything, mock.Anything).Return(mockCursor, nil)
+
+			mockPublisher.On("HealthEventOccurredV1", ctx, mock.MatchedBy(func(events *protos.HealthEvents) bool {
+				event := events.Events[0]
+				return event.RecommendedAction == tc.action &&
+					event.Metadata["telemetry_confirmation"] == tc.result &&
+					event.Metadata["dcgm_watches"] == "DCGM_HEALTH_WATCH_PCIE" &&
+					event.Metadata["nvml_return_codes"] == "NVML_ERROR_GPU_IS_LOST"
+			})).Return(&emptypb.Empty{}, nil)
+
+			published, err := reconciler.handleEvent(ctx, testEventID, &fallenOffEvent)
+			require.NoError(t, err)
+			assert.True(t, published)
+			mockClient.AssertExpectations(t)
+			mockPublisher.AssertExpectations(t)
+			assert.Nil(t, fallenOffEvent.HealthEvent.Metadata, "the source event must not be modified")
+		})
+	}
+
+	t.Run("events without telemetry are not confirmed", func(t *testing.T) {
+		mockClient := new(mockCollectionClient)
+		mockPublisher := &mockPublisher{}
+
+		reconciler := NewReconciler(HealthEventsAnalyzerReconcilerConfig{
+			HealthEventsAnalyzerRules: &config.TomlConfig{
+				Rules:                 []config.HealthEventsAnalyzerRule{rule},
+				TelemetryConfirmation: &config.TelemetryConfirmation{},
+			},
+			CollectionClient: mockClient,
+			Publisher:        publisher.NewPublisher(mockPublisher),
+		})
+
+		mockCursor, _ := createMockCursor([]bson.M{{"count": 5}})
+		mockClient.On("Aggregate", ctx, mock.Anything, mock.Anything).Return(mockCursor, nil)
+		mockPublisher.On("HealthEventOccurredV1", ctx, mock.MatchedBy(func(events *protos.HealthEvents) bool {
+			_, tagged := events.Events[0].Metadata["telemetry_confirmation"]
+			return !tagged && events.Events[0].RecommendedAction == protos.RecommendedAction_RESTART_BM
+		})).Return(&emptypb.Empty{}, nil)
+
+		published, err := reconciler.handleEvent(ctx, testEventID, &healthEvent_13)
+		require.NoError(t, err)
+		assert.True(t, published)
+		mockPublisher.AssertExpectations(t)
+	})
+}
+
+func TestDocumentID(t *testing.T) {
+	id := primitive.NewObjectID()
+
+	assert.Equal(t, id.Hex(), documentID(bson.M{"fullDocument": bson.M{"_id": id}}))
+	assert.Empty(t, documentID(bson.M{}))
+}
+
+type fakeRolloutTracker map[string]rollout.Rollout
+
+func (f fakeRolloutTracker) LastRollout(nodeName string) (rollout.Rollout, bool) {
+	last, ok := f[nodeName]
+	return last, ok
+}
+
+// TestDefaultRateRules evaluates the rate rules shipped with the chart against the
+// events the syslog health monitor sends with its cumulative counters.
+func TestDefaultRateRules(t *testing.T) {
+	values, err := os.ReadFile("../../../distros/kubernetes/nvsentinel/charts/health-events-analyzer/values.yaml")
+	require.NoError(t, err)
+
+	var chart struct {
+		Config string `json:"config"`
+	}
+	require.NoError(t, yaml.Unmarshal(values, &chart))
+
+	defaults, err := config.LoadTomlConfigFromBytes([]byte(chart.Config))
+	require.NoError(t, err)
+
+	// the sequence rules are covered above; only the rate rules are evaluated here
+	rules := &config.TomlConfig{RateRules: defaults.RateRules}
+	ctx := context.Background()
+
+	tests := []struct {
+		rule  string
+		event *protos.HealthEvent
+	}{
+		{
+			rule: "RapidCorrectableECC",
+			event: &protos.HealthEvent{
+				Agent:     "syslog-health-monitor",
+				CheckName: "SysLogsECCError",
+				ErrorCode: []string{"ECC_SBE_THRESHOLD"},
+				EntitiesImpacted: []*protos.Entity{
+					{EntityType: "PCI", EntityValue: "0000:3b:00.0"},
+					{EntityType: "GPU", EntityValue: "3"},
+				},
+				Metadata: map[string]string{datamodels.MetadataECCSBETotal: "120"},
+				NodeName: "node1",
+			},
+		},
+		{
+			rule: "RapidCorrectedPCIeAER",
+			event: &protos.HealthEvent{
+				Agent:            "syslog-health-monitor",
+				CheckName:        "SysLogsPCIeAER",
+				ErrorCode:        []string{"PCIE_AER_ERROR_RATE"},
+				EntitiesImpacted: []*protos.Entity{{EntityType: "PCI", EntityValue: "0000:3b:00.0"}},
+				Metadata:         map[string]string{datamodels.MetadataAERCorrectedTotal: "600"},
+				NodeName:         "node1",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.rule, func(t *testing.T) {
+			mockClient := new(mockCollectionClient)
+			mockPublisher := &mockPublisher{}
+			reconciler := NewReconciler(HealthEventsAnalyzerReconcilerConfig{
+				HealthEventsAnalyzerRules: rules,
+				CollectionClient:          mockClient,
+				Publisher:                 publisher.NewPublisher(mockPublisher),
+			})
+
+			mockPublisher.On("HealthEventOccurredV1", ctx, mock.MatchedBy(func(events *protos.HealthEvents) bool {
+				return events.Events[0].CheckName == tt.rule
+			})).Return(&emptypb.Empty{}, nil)
+			expectIncident(mockClient, ctx, tt.rule, nil)
+
+			mockCursor, _ := createMockCursor([]bson.M{{"increase": 1000.0, "samples": 10}})
+			mockClient.On("Aggregate", ctx, mock.MatchedBy(func(pipeline []map[string]interface{}) bool {
+				match, ok := pipeline[1]["$match"].(map[string]interface{})
+				return ok && match["healthevent.checkname"] == tt.event.CheckName &&
+					match["healthevent.entitiesimpacted"] != nil
+			}), mock.Anything).Return(mockCursor, nil)
+
+			published, err := reconciler.handleEvent(ctx, testEventID,
+				&datamodels.HealthEventWithStatus{HealthEvent: tt.event})
+			require.NoError(t, err)
+			assert.True(t, published)
+			mockPublisher.AssertExpectations(t)
+		})
+	}
 }
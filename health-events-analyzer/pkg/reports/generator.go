@@ -0,0 +1,346 @@
+// Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package reports
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"mime/multipart"
+	"net/http"
+	"net/smtp"
+	"net/textproto"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/nvidia/nvsentinel/health-events-analyzer/pkg/config"
+)
+
+const (
+	// PathPrefix is where the last reliability report is served
+	PathPrefix = "/reports/reliability"
+
+	queryTimeout   = 30 * time.Second
+	publishTimeout = 2 * time.Minute
+)
+
+// Generator builds and publishes a report at the end of every interval.
+type Generator struct {
+	builder  *Builder
+	config   *config.ReliabilityReport
+	client   *http.Client
+	sendMail func(addr string, auth smtp.Auth, from string, to []string, msg []byte) error
+	now      func() time.Time
+
+	mu     sync.RWMutex
+	report *Report
+}
+
+// NewGenerator creates a Generator of the reports of builder.
+func NewGenerator(builder *Builder, cfg *config.ReliabilityReport) *Generator {
+	return &Generator{
+		builder:  builder,
+		config:   cfg,
+		client:   &http.Client{Timeout: publishTimeout},
+		sendMail: smtp.SendMail,
+		now:      time.Now,
+	}
+}
+
+// Run publishes the report of each interval when it ends, until ctx is done. On
+// start, the report of the last complete interval is published when it was not
+// uploaded yet, so a restart at the end of an interval does not skip it. Failed
+// reports are logged and not retried.
+func (g *Generator) Run(ctx context.Context) error {
+	interval := g.config.IntervalDuration()
+
+	if from, to := g.lastInterval(); g.config.UploadURL != "" && !g.uploaded(ctx, from) {
+		g.generate(ctx, from, to)
+	}
+
+	for {
+		next := g.now().UTC().Truncate(interval).Add(interval)
+		timer := time.NewTimer(next.Sub(g.now()))
+
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil
+		case <-timer.C:
+		}
+
+		from, to := g.lastInterval()
+		g.generate(ctx, from, to)
+	}
+}
+
+// lastInterval returns the last complete interval. Intervals are aligned to
+// multiples of the interval since January 1 of year 1, a Monday, in UTC.
+func (g *Generator) lastInterval() (time.Time, time.Time) {
+	interval := g.config.IntervalDuration()
+	to := g.now().UTC().Truncate(interval)
+
+	return to.Add(-interval), to
+}
+
+func (g *Generator) generate(ctx context.Context, from, to time.Time) {
+	buildCtx, cancel := context.WithTimeout(ctx, queryTimeout)
+	report, err := g.builder.Build(buildCtx, from, to, g.now())
+
+	cancel()
+
+	if err != nil {
+		reportFailures.WithLabelValues("generate").Inc()
+		slog.Error("Failed to generate reliability report", "from", from, "to", to, "error", err)
+
+		return
+	}
+
+	g.mu.Lock()
+	g.report = report
+	g.mu.Unlock()
+
+	if err := g.Publish(ctx, report); err != nil {
+		slog.Error("Failed to publish reliability report", "from", from, "to", to, "error", err)
+		return
+	}
+
+	reportsGenerated.Inc()
+	slog.Info("Published reliability report", "from", from, "to", to)
+}
+
+// Publish uploads the CSV files of report and mails them, as configured. It
+// attempts both and returns their errors.
+func (g *Generator) Publish(ctx context.Context, report *Report) error {
+	files, err := report.CSVFiles()
+	if err != nil {
+		reportFailures.WithLabelValues("generate").Inc()
+		return err
+	}
+
+	var errs []error
+
+	if g.config.UploadURL != "" {
+		if err := g.upload(ctx, report.From, files); err != nil {
+			reportFailures.WithLabelValues("upload").Inc()
+			errs = append(errs, err)
+		}
+	}
+
+	if g.config.Mail != nil {
+		if err := g.mail(report, files); err != nil {
+			reportFailures.WithLabelValues("mail").Inc()
+			errs = append(errs, err)
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+func (g *Generator) fileURL(from time.Time, name string) string {
+	return fmt.Sprintf("%s/reliability/%s/%s", strings.TrimSuffix(g.config.UploadURL, "/"),
+		from.UTC().Format("2006-01-02T15-04"), name)
+}
+
+// uploaded reports whether the report of the interval starting at from was
+// uploaded, assuming it was when that cannot be determined.
+func (g *Generator) uploaded(ctx context.Context, from time.Time) bool {
+	request, err := http.NewRequestWithContext(ctx, http.MethodHead, g.fileURL(from, "faults_per_sku.csv"), nil)
+	if err != nil {
+		return true
+	}
+
+	response, err := g.client.Do(request)
+	if err != nil {
+		slog.Warn("Failed to check for the last reliability report", "error", err)
+		return true
+	}
+
+	response.Body.Close()
+
+	return response.StatusCode != http.StatusNotFound
+}
+
+func (g *Generator) upload(ctx context.Context, from time.Time, files map[string][]byte) error {
+	for _, name := range sortedNames(files) {
+		request, err := http.NewRequestWithContext(ctx, http.MethodPut, g.fileURL(from, name),
+			bytes.NewReader(files[name]))
+		if err != nil {
+			return fmt.Errorf("failed to create upload request of %s: %w", name, err)
+		}
+
+		request.Header.Set("Content-Type", "text/csv")
+
+		response, err := g.client.Do(request)
+		if err != nil {
+			return fmt.Errorf("failed to upload %s: %w", name, err)
+		}
+
+		response.Body.Close()
+
+		if response.StatusCode/100 != 2 {
+			return fmt.Errorf("failed to upload %s: %s", name, response.Status)
+		}
+	}
+
+	return nil
+}
+
+func (g *Generator) mail(report *Report, files map[string][]byte) error {
+	cfg := g.config.Mail
+
+	var auth smtp.Auth
+
+	if cfg.Username != "" {
+		host := cfg.Server[:strings.LastIndex(cfg.Server, ":")]
+		auth = smtp.PlainAuth("", cfg.Username, os.Getenv(cfg.PasswordEnv), host)
+	}
+
+	message, err := mailMessage(cfg, report, files)
+	if err != nil {
+		return err
+	}
+
+	if err := g.sendMail(cfg.Server, auth, cfg.From, cfg.To, message); err != nil {
+		return fmt.Errorf("failed to mail reliability report: %w", err)
+	}
+
+	return nil
+}
+
+// mailMessage builds a multipart message with a summary and the CSV files as
+// attachments.
+func mailMessage(cfg *config.ReportMail, report *Report, files map[string][]byte) ([]byte, error) {
+	var body bytes.Buffer
+
+	writer := multipart.NewWriter(&body)
+
+	part, err := writer.CreatePart(textproto.MIMEHeader{"Content-Type": {"text/plain; charset=utf-8"}})
+	if err != nil {
+		return nil, err
+	}
+
+	fmt.Fprintf(part, "NVSentinel reliability report from %s to %s.\n\n", report.From.Format(time.RFC3339),
+		report.To.Format(time.RFC3339))
+
+	for _, faults := range report.FaultsPerSKU {
+		fmt.Fprintf(part, "SKU %s: %d faults (%d fatal) on %d nodes\n", faults.SKU, faults.Faults,
+			faults.FatalFaults, faults.AffectedNodes)
+	}
+
+	for _, name := range sortedNames(files) {
+		part, err := writer.CreatePart(textproto.MIMEHeader{
+			"Content-Type":              {"text/csv"},
+			"Content-Disposition":       {fmt.Sprintf("attachment; filename=%q", name)},
+			"Content-Transfer-Encoding": {"base64"},
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		encoder := base64.NewEncoder(base64.StdEncoding, part)
+		encoder.Write(files[name]) //nolint:errcheck // writes to a buffer
+		encoder.Close()
+	}
+
+	if err := writer.Close(); err != nil {
+		return nil, err
+	}
+
+	var message bytes.Buffer
+
+	fmt.Fprintf(&message, "From: %s\r\n", cfg.From)
+	fmt.Fprintf(&message, "To: %s\r\n", strings.Join(cfg.To, ", "))
+	fmt.Fprintf(&message, "Subject: NVSentinel reliability report %s\r\n", report.From.Format("2006-01-02"))
+	fmt.Fprintf(&message, "MIME-Version: 1.0\r\n")
+	fmt.Fprintf(&message, "Content-Type: multipart/mixed; boundary=%s\r\n\r\n", writer.Boundary())
+	message.Write(body.Bytes())
+
+	return message.Bytes(), nil
+}
+
+func sortedNames(files map[string][]byte) []string {
+	names := make([]string, 0, len(files))
+	for name := range files {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	return names
+}
+
+// ServeHTTP serves GET /reports/reliability, the last report, or the report of
+// the last complete interval when none was generated yet. A report of another
+// range is built, but not published, with the RFC 3339 from and to parameters.
+func (g *Generator) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+
+	g.mu.RLock()
+	report := g.report
+	g.mu.RUnlock()
+
+	values := r.URL.Query()
+	if values.Has("from") || values.Has("to") || report == nil {
+		from, to := g.lastInterval()
+
+		var err error
+
+		if value := values.Get("from"); value != "" {
+			if from, err = time.Parse(time.RFC3339, value); err != nil {
+				http.Error(w, fmt.Sprintf("invalid from: %v", err), http.StatusBadRequest)
+				return
+			}
+		}
+
+		if value := values.Get("to"); value != "" {
+			if to, err = time.Parse(time.RFC3339, value); err != nil {
+				http.Error(w, fmt.Sprintf("invalid to: %v", err), http.StatusBadRequest)
+				return
+			}
+		}
+
+		if !from.Before(to) {
+			http.Error(w, "from must be before to", http.StatusBadRequest)
+			return
+		}
+
+		ctx, cancel := context.WithTimeout(r.Context(), queryTimeout)
+		defer cancel()
+
+		if report, err = g.builder.Build(ctx, from, to, g.now()); err != nil {
+			slog.Error("Failed to build reliability report", "error", err)
+			http.Error(w, "failed to build reliability report", http.StatusInternalServerError)
+
+			return
+		}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+
+	if err := json.NewEncoder(w).Encode(report); err != nil {
+		slog.Error("Failed to encode reliability report", "error", err)
+	}
+}
@@ -0,0 +1,36 @@
+// Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package reports
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+var (
+	reportsGenerated = promauto.NewCounter(
+		prometheus.CounterOpts{
+			Name: "health_event_analyzer_reliability_reports_total",
+			Help: "Total number of reliability reports generated and published.",
+		},
+	)
+	reportFailures = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "health_event_analyzer_reliability_report_failures_total",
+			Help: "Total number of failures to generate, upload or mail a reliability report.",
+		},
+		[]string{"step"},
+	)
+)
@@ -0,0 +1,354 @@
+// Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package reports generates periodic reliability reports of the stored health
+// events: faults per SKU, mean time between failures per node pool and
+// remediation success rates. The reports are aggregated in the database like the
+// trends, written as CSV files to object storage and optionally mailed.
+package reports
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"fmt"
+	"sort"
+	"strconv"
+	"time"
+
+	protos "github.com/nvidia/nvsentinel/data-models/pkg/protos"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	analyzerAgent = "health-events-analyzer"
+	timestamp     = "healthevent.generatedtimestamp.seconds"
+
+	// UnknownSKU is the SKU of events without the SKU metadata key
+	UnknownSKU = "unknown"
+	// AllPool is the node pool of all nodes when no node pool label is configured
+	AllPool = "all"
+	// UnknownPool is the node pool of nodes that are not in the cluster anymore
+	// or lack the node pool label
+	UnknownPool = "unknown"
+)
+
+// Aggregator runs aggregation pipelines on the health events collection.
+type Aggregator interface {
+	Aggregate(ctx context.Context, pipeline interface{}, opts ...*options.AggregateOptions) (*mongo.Cursor, error)
+}
+
+// NodeLister lists the nodes of the cluster.
+type NodeLister interface {
+	List(ctx context.Context, opts metav1.ListOptions) (*v1.NodeList, error)
+}
+
+// SKUFaults counts the faults of a SKU.
+type SKUFaults struct {
+	SKU           string `json:"sku"`
+	Faults        int    `json:"faults"`
+	FatalFaults   int    `json:"fatalFaults"`
+	AffectedNodes int    `json:"affectedNodes"`
+}
+
+// PoolMTBF is the mean time between failures of the nodes of a pool, the node
+// hours of the period divided by the fatal faults. It is unset without faults or
+// for nodes not in the cluster anymore.
+type PoolMTBF struct {
+	Pool      string   `json:"pool"`
+	Nodes     int      `json:"nodes"`
+	Failures  int      `json:"failures"`
+	MTBFHours *float64 `json:"mtbfHours,omitempty"`
+}
+
+// RemediationRate counts the remediation outcomes of a recommended action.
+type RemediationRate struct {
+	Action      string  `json:"action"`
+	Remediated  int     `json:"remediated"`
+	Failed      int     `json:"failed"`
+	SuccessRate float64 `json:"successRate"`
+}
+
+// Report is a reliability report of the events generated in [From, To).
+type Report struct {
+	From         time.Time         `json:"from"`
+	To           time.Time         `json:"to"`
+	GeneratedAt  time.Time         `json:"generatedAt"`
+	FaultsPerSKU []SKUFaults       `json:"faultsPerSku"`
+	MTBFPerPool  []PoolMTBF        `json:"mtbfPerNodePool"`
+	Remediations []RemediationRate `json:"remediations"`
+}
+
+// Builder aggregates reliability reports.
+type Builder struct {
+	collection    Aggregator
+	nodes         NodeLister
+	skuKey        string
+	nodePoolLabel string
+}
+
+// NewBuilder creates a Builder of reports from the events in collection. skuKey
+// is the event metadata key holding the SKU and nodePoolLabel the node label
+// naming the node pool, both optional.
+func NewBuilder(collection Aggregator, nodes NodeLister, skuKey, nodePoolLabel string) *Builder {
+	return &Builder{collection: collection, nodes: nodes, skuKey: skuKey, nodePoolLabel: nodePoolLabel}
+}
+
+// Build aggregates the report of the events generated in [from, to).
+func (b *Builder) Build(ctx context.Context, from, to, now time.Time) (*Report, error) {
+	report := &Report{From: from.UTC(), To: to.UTC(), GeneratedAt: now.UTC()}
+
+	var err error
+
+	if report.FaultsPerSKU, err = b.faultsPerSKU(ctx, from, to); err != nil {
+		return nil, err
+	}
+
+	if report.MTBFPerPool, err = b.mtbfPerPool(ctx, from, to); err != nil {
+		return nil, err
+	}
+
+	if report.Remediations, err = b.remediations(ctx, from, to); err != nil {
+		return nil, err
+	}
+
+	return report, nil
+}
+
+// match selects the unhealthy events of the monitors in [from, to).
+func match(from, to time.Time) bson.M {
+	return bson.M{
+		"healthevent.agent":     bson.M{"$ne": analyzerAgent},
+		"healthevent.ishealthy": false,
+		timestamp:               bson.M{"$gte": from.Unix(), "$lt": to.Unix()},
+	}
+}
+
+func (b *Builder) faultsPerSKU(ctx context.Context, from, to time.Time) ([]SKUFaults, error) {
+	var sku interface{} = UnknownSKU
+	if b.skuKey != "" {
+		sku = bson.M{"$ifNull": bson.A{"$healthevent.metadata." + b.skuKey, UnknownSKU}}
+	}
+
+	var results []struct {
+		SKU         string   `bson:"_id"`
+		Faults      int      `bson:"faults"`
+		FatalFaults int      `bson:"fatalFaults"`
+		Nodes       []string `bson:"nodes"`
+	}
+
+	if err := b.aggregate(ctx, []bson.M{
+		{"$match": match(from, to)},
+		{"$group": bson.M{
+			"_id":         sku,
+			"faults":      bson.M{"$sum": 1},
+			"fatalFaults": bson.M{"$sum": bson.M{"$cond": bson.A{"$healthevent.isfatal", 1, 0}}},
+			"nodes":       bson.M{"$addToSet": "$healthevent.nodename"},
+		}},
+	}, &results); err != nil {
+		return nil, fmt.Errorf("failed to aggregate faults per SKU: %w", err)
+	}
+
+	faults := make([]SKUFaults, 0, len(results))
+	for _, result := range results {
+		faults = append(faults, SKUFaults{
+			SKU:           result.SKU,
+			Faults:        result.Faults,
+			FatalFaults:   result.FatalFaults,
+			AffectedNodes: len(result.Nodes),
+		})
+	}
+
+	sort.Slice(faults, func(i, j int) bool {
+		if faults[i].Faults != faults[j].Faults {
+			return faults[i].Faults > faults[j].Faults
+		}
+
+		return faults[i].SKU < faults[j].SKU
+	})
+
+	return faults, nil
+}
+
+func (b *Builder) mtbfPerPool(ctx context.Context, from, to time.Time) ([]PoolMTBF, error) {
+	nodes, err := b.nodes.List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list nodes: %w", err)
+	}
+
+	pools := make(map[string]*PoolMTBF)
+	nodePools := make(map[string]string, len(nodes.Items))
+
+	pool := func(name string) *PoolMTBF {
+		if pools[name] == nil {
+			pools[name] = &PoolMTBF{Pool: name}
+		}
+
+		return pools[name]
+	}
+
+	for _, node := range nodes.Items {
+		name := AllPool
+		if b.nodePoolLabel != "" {
+			if name = node.Labels[b.nodePoolLabel]; name == "" {
+				name = UnknownPool
+			}
+		}
+
+		nodePools[node.Name] = name
+		pool(name).Nodes++
+	}
+
+	var results []struct {
+		Node     string `bson:"_id"`
+		Failures int    `bson:"failures"`
+	}
+
+	failures := match(from, to)
+	failures["healthevent.isfatal"] = true
+
+	if err := b.aggregate(ctx, []bson.M{
+		{"$match": failures},
+		{"$group": bson.M{"_id": "$healthevent.nodename", "failures": bson.M{"$sum": 1}}},
+	}, &results); err != nil {
+		return nil, fmt.Errorf("failed to aggregate failures per node: %w", err)
+	}
+
+	for _, result := range results {
+		name, ok := nodePools[result.Node]
+		if !ok {
+			name = UnknownPool
+		}
+
+		pool(name).Failures += result.Failures
+	}
+
+	hours := to.Sub(from).Hours()
+	mtbf := make([]PoolMTBF, 0, len(pools))
+
+	for _, pool := range pools {
+		if pool.Failures > 0 && pool.Nodes > 0 {
+			value := float64(pool.Nodes) * hours / float64(pool.Failures)
+			pool.MTBFHours = &value
+		}
+
+		mtbf = append(mtbf, *pool)
+	}
+
+	sort.Slice(mtbf, func(i, j int) bool { return mtbf[i].Pool < mtbf[j].Pool })
+
+	return mtbf, nil
+}
+
+func (b *Builder) remediations(ctx context.Context, from, to time.Time) ([]RemediationRate, error) {
+	remediated := match(from, to)
+	remediated["healtheventstatus.faultremediated"] = bson.M{"$type": "bool"}
+
+	var results []struct {
+		Action     int32 `bson:"_id"`
+		Remediated int   `bson:"remediated"`
+		Failed     int   `bson:"failed"`
+	}
+
+	if err := b.aggregate(ctx, []bson.M{
+		{"$match": remediated},
+		{"$group": bson.M{
+			"_id":        "$healthevent.recommendedaction",
+			"remediated": bson.M{"$sum": bson.M{"$cond": bson.A{"$healtheventstatus.faultremediated", 1, 0}}},
+			"failed":     bson.M{"$sum": bson.M{"$cond": bson.A{"$healtheventstatus.faultremediated", 0, 1}}},
+		}},
+	}, &results); err != nil {
+		return nil, fmt.Errorf("failed to aggregate remediation outcomes: %w", err)
+	}
+
+	rates := make([]RemediationRate, 0, len(results))
+	for _, result := range results {
+		rate := RemediationRate{
+			Action:     protos.RecommendedAction(result.Action).String(),
+			Remediated: result.Remediated,
+			Failed:     result.Failed,
+		}
+
+		if total := result.Remediated + result.Failed; total > 0 {
+			rate.SuccessRate = float64(result.Remediated) / float64(total)
+		}
+
+		rates = append(rates, rate)
+	}
+
+	sort.Slice(rates, func(i, j int) bool { return rates[i].Action < rates[j].Action })
+
+	return rates, nil
+}
+
+func (b *Builder) aggregate(ctx context.Context, pipeline []bson.M, results interface{}) error {
+	cursor, err := b.collection.Aggregate(ctx, pipeline)
+	if err != nil {
+		return err
+	}
+
+	defer cursor.Close(ctx)
+
+	return cursor.All(ctx, results)
+}
+
+// CSVFiles returns the CSV files of the report by file name.
+func (r *Report) CSVFiles() (map[string][]byte, error) {
+	tables := map[string][][]string{
+		"faults_per_sku.csv":      {{"from", "to", "sku", "faults", "fatal_faults", "affected_nodes"}},
+		"mtbf_per_node_pool.csv":  {{"from", "to", "node_pool", "nodes", "failures", "mtbf_hours"}},
+		"remediation_success.csv": {{"from", "to", "action", "remediated", "failed", "success_rate"}},
+	}
+
+	from, to := r.From.Format(time.RFC3339), r.To.Format(time.RFC3339)
+
+	for _, faults := range r.FaultsPerSKU {
+		tables["faults_per_sku.csv"] = append(tables["faults_per_sku.csv"], []string{from, to, faults.SKU,
+			strconv.Itoa(faults.Faults), strconv.Itoa(faults.FatalFaults), strconv.Itoa(faults.AffectedNodes)})
+	}
+
+	for _, pool := range r.MTBFPerPool {
+		mtbf := ""
+		if pool.MTBFHours != nil {
+			mtbf = strconv.FormatFloat(*pool.MTBFHours, 'f', 1, 64)
+		}
+
+		tables["mtbf_per_node_pool.csv"] = append(tables["mtbf_per_node_pool.csv"], []string{from, to, pool.Pool,
+			strconv.Itoa(pool.Nodes), strconv.Itoa(pool.Failures), mtbf})
+	}
+
+	for _, rate := range r.Remediations {
+		tables["remediation_success.csv"] = append(tables["remediation_success.csv"], []string{from, to, rate.Action,
+			strconv.Itoa(rate.Remediated), strconv.Itoa(rate.Failed), strconv.FormatFloat(rate.SuccessRate, 'f', 4, 64)})
+	}
+
+	files := make(map[string][]byte, len(tables))
+
+	for name, rows := range tables {
+		var buf bytes.Buffer
+
+		writer := csv.NewWriter(&buf)
+		if err := writer.WriteAll(rows); err != nil {
+			return nil, fmt.Errorf("failed to write %s: %w", name, err)
+		}
+
+		files[name] = buf.Bytes()
+	}
+
+	return files, nil
+}
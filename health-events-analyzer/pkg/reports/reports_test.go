@@ -0,0 +1,263 @@
+// Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package reports
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/smtp"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/nvidia/nvsentinel/health-events-analyzer/pkg/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+// fakeCollection answers the aggregations of a report in order: faults per SKU,
+// failures per node and remediation outcomes.
+type fakeCollection struct {
+	results   [][]bson.M
+	pipelines [][]bson.M
+}
+
+func (f *fakeCollection) Aggregate(_ context.Context, pipeline interface{},
+	_ ...*options.AggregateOptions) (*mongo.Cursor, error) {
+	f.pipelines = append(f.pipelines, pipeline.([]bson.M))
+	results := f.results[(len(f.pipelines)-1)%len(f.results)]
+
+	documents := make([]interface{}, 0, len(results))
+
+	for _, result := range results {
+		data, err := bson.Marshal(result)
+		if err != nil {
+			return nil, err
+		}
+
+		documents = append(documents, bson.Raw(data))
+	}
+
+	return mongo.NewCursorFromDocuments(documents, nil, nil)
+}
+
+var (
+	testFrom = time.Date(2025, 6, 2, 0, 0, 0, 0, time.UTC)
+	testTo   = testFrom.Add(7 * 24 * time.Hour)
+)
+
+func newTestBuilder(collection *fakeCollection) *Builder {
+	node := func(name, pool string) *v1.Node {
+		labels := map[string]string{}
+		if pool != "" {
+			labels["nvidia.com/node-pool"] = pool
+		}
+
+		return &v1.Node{ObjectMeta: metav1.ObjectMeta{Name: name, Labels: labels}}
+	}
+
+	clientset := fake.NewSimpleClientset(
+		node("gb200-1", "gb200"), node("gb200-2", "gb200"), node("h100-1", "h100"), node("cpu-1", ""))
+
+	return NewBuilder(collection, clientset.CoreV1().Nodes(), "gpu_sku", "nvidia.com/node-pool")
+}
+
+func testCollection() *fakeCollection {
+	return &fakeCollection{results: [][]bson.M{
+		{
+			{"_id": "H100", "faults": 3, "fatalFaults": 1, "nodes": bson.A{"h100-1"}},
+			{"_id": "GB200", "faults": 5, "fatalFaults": 2, "nodes": bson.A{"gb200-1", "gb200-2"}},
+		},
+		{
+			{"_id": "gb200-1", "failures": 2},
+			{"_id": "removed-1", "failures": 1},
+		},
+		{
+			{"_id": int32(15), "remediated": 3, "failed": 1},
+		},
+	}}
+}
+
+func TestBuild(t *testing.T) {
+	collection := testCollection()
+
+	report, err := newTestBuilder(collection).Build(context.Background(), testFrom, testTo, testTo)
+	require.NoError(t, err)
+
+	assert.Equal(t, []SKUFaults{
+		{SKU: "GB200", Faults: 5, FatalFaults: 2, AffectedNodes: 2},
+		{SKU: "H100", Faults: 3, FatalFaults: 1, AffectedNodes: 1},
+	}, report.FaultsPerSKU)
+
+	require.Len(t, report.MTBFPerPool, 3)
+	assert.Equal(t, "gb200", report.MTBFPerPool[0].Pool)
+	assert.Equal(t, 2, report.MTBFPerPool[0].Nodes)
+	assert.Equal(t, 2, report.MTBFPerPool[0].Failures)
+	require.NotNil(t, report.MTBFPerPool[0].MTBFHours)
+	assert.InDelta(t, 168.0, *report.MTBFPerPool[0].MTBFHours, 0.001)
+	assert.Equal(t, PoolMTBF{Pool: "h100", Nodes: 1}, report.MTBFPerPool[1])
+	// The unlabeled node and the failure of the removed node
+	assert.Equal(t, UnknownPool, report.MTBFPerPool[2].Pool)
+	assert.Equal(t, 1, report.MTBFPerPool[2].Nodes)
+	assert.Equal(t, 1, report.MTBFPerPool[2].Failures)
+
+	assert.Equal(t, []RemediationRate{{Action: "RESTART_VM", Remediated: 3, Failed: 1, SuccessRate: 0.75}},
+		report.Remediations)
+
+	require.Len(t, collection.pipelines, 3)
+	match := collection.pipelines[0][0]["$match"].(bson.M)
+	assert.Equal(t, bson.M{"$gte": testFrom.Unix(), "$lt": testTo.Unix()}, match[timestamp])
+	assert.Equal(t, bson.M{"$ifNull": bson.A{"$healthevent.metadata.gpu_sku", UnknownSKU}},
+		collection.pipelines[0][1]["$group"].(bson.M)["_id"])
+	assert.Equal(t, true, collection.pipelines[1][0]["$match"].(bson.M)["healthevent.isfatal"])
+	assert.Equal(t, bson.M{"$type": "bool"},
+		collection.pipelines[2][0]["$match"].(bson.M)["healtheventstatus.faultremediated"])
+}
+
+func TestBuild_WithoutSKUAndPools(t *testing.T) {
+	collection := testCollection()
+	builder := newTestBuilder(collection)
+	builder.skuKey, builder.nodePoolLabel = "", ""
+
+	report, err := builder.Build(context.Background(), testFrom, testTo, testTo)
+	require.NoError(t, err)
+
+	assert.Equal(t, UnknownSKU, collection.pipelines[0][1]["$group"].(bson.M)["_id"])
+	require.Len(t, report.MTBFPerPool, 2)
+	assert.Equal(t, AllPool, report.MTBFPerPool[0].Pool)
+	assert.Equal(t, 4, report.MTBFPerPool[0].Nodes)
+	assert.Equal(t, 2, report.MTBFPerPool[0].Failures)
+}
+
+func TestCSVFiles(t *testing.T) {
+	report, err := newTestBuilder(testCollection()).Build(context.Background(), testFrom, testTo, testTo)
+	require.NoError(t, err)
+
+	files, err := report.CSVFiles()
+	require.NoError(t, err)
+
+	assert.Equal(t, "from,to,sku,faults,fatal_faults,affected_nodes\n"+
+		"2025-06-02T00:00:00Z,2025-06-09T00:00:00Z,GB200,5,2,2\n"+
+		"2025-06-02T00:00:00Z,2025-06-09T00:00:00Z,H100,3,1,1\n", string(files["faults_per_sku.csv"]))
+	assert.Contains(t, string(files["mtbf_per_node_pool.csv"]), ",gb200,2,2,168.0\n")
+	assert.Contains(t, string(files["mtbf_per_node_pool.csv"]), ",h100,1,0,\n")
+	assert.Contains(t, string(files["remediation_success.csv"]), ",RESTART_VM,3,1,0.7500\n")
+}
+
+func TestGenerator(t *testing.T) {
+	var (
+		mu       sync.Mutex
+		uploaded = map[string]string{}
+	)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		switch r.Method {
+		case http.MethodHead:
+			if _, ok := uploaded[r.URL.Path]; !ok {
+				w.WriteHeader(http.StatusNotFound)
+			}
+		case http.MethodPut:
+			body, _ := io.ReadAll(r.Body)
+			uploaded[r.URL.Path] = string(body)
+		}
+	}))
+	defer server.Close()
+
+	var mails []string
+
+	generator := NewGenerator(newTestBuilder(testCollection()), &config.ReliabilityReport{
+		Interval:  "168h",
+		UploadURL: server.URL + "/upload/",
+		Mail: &config.ReportMail{
+			Server: "smtp.example.com:25",
+			From:   "nvsentinel@example.com",
+			To:     []string{"ops@example.com"},
+		},
+	})
+	generator.now = func() time.Time { return testTo.Add(36 * time.Hour) }
+	generator.sendMail = func(addr string, auth smtp.Auth, from string, to []string, msg []byte) error {
+		assert.Equal(t, "smtp.example.com:25", addr)
+		assert.Nil(t, auth)
+		mails = append(mails, string(msg))
+
+		return nil
+	}
+
+	// Weekly intervals start on Mondays
+	from, to := generator.lastInterval()
+	assert.Equal(t, testFrom, from)
+	assert.Equal(t, testTo, to)
+	assert.False(t, generator.uploaded(context.Background(), from))
+
+	generator.generate(context.Background(), from, to)
+
+	assert.True(t, generator.uploaded(context.Background(), from))
+	assert.Len(t, uploaded, 3)
+	assert.Contains(t, uploaded["/upload/reliability/2025-06-02T00-00/faults_per_sku.csv"], "GB200,5,2,2")
+
+	require.Len(t, mails, 1)
+	assert.Contains(t, mails[0], "Subject: NVSentinel reliability report 2025-06-02\r\n")
+	assert.Contains(t, mails[0], `filename="mtbf_per_node_pool.csv"`)
+	assert.Contains(t, mails[0], "SKU GB200: 5 faults (2 fatal) on 2 nodes")
+
+	recorder := httptest.NewRecorder()
+	generator.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, PathPrefix, nil))
+	require.Equal(t, http.StatusOK, recorder.Code)
+
+	var report Report
+	require.NoError(t, json.NewDecoder(recorder.Body).Decode(&report))
+	assert.Equal(t, testFrom, report.From)
+	assert.Len(t, report.FaultsPerSKU, 2)
+}
+
+func TestServeHTTP(t *testing.T) {
+	generator := NewGenerator(newTestBuilder(testCollection()), &config.ReliabilityReport{Interval: "24h"})
+	generator.now = func() time.Time { return testTo.Add(time.Hour) }
+
+	for target, code := range map[string]int{
+		PathPrefix: http.StatusOK,
+		PathPrefix + "?from=2025-05-01T00:00:00Z&to=2025-06-01T00:00:00Z": http.StatusOK,
+		PathPrefix + "?from=yesterday":                                    http.StatusBadRequest,
+		PathPrefix + "?from=2025-07-01T00:00:00Z":                         http.StatusBadRequest,
+	} {
+		recorder := httptest.NewRecorder()
+		generator.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, target, nil))
+		assert.Equal(t, code, recorder.Code, target)
+
+		if code == http.StatusOK && !strings.Contains(target, "from") {
+			var report Report
+			require.NoError(t, json.NewDecoder(recorder.Body).Decode(&report))
+			assert.Equal(t, testTo.Add(-24*time.Hour), report.From)
+			assert.Equal(t, testTo, report.To)
+		}
+	}
+
+	recorder := httptest.NewRecorder()
+	generator.ServeHTTP(recorder, httptest.NewRequest(http.MethodPost, PathPrefix, nil))
+	assert.Equal(t, http.StatusMethodNotAllowed, recorder.Code)
+}
@@ -0,0 +1,218 @@
+// Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package rollout tracks driver and GPU Operator component rollouts per node, so
+// incidents shortly after a rollout can be correlated with it.
+package rollout
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/informers"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/cache"
+)
+
+const (
+	// TimeAnnotation marks a rollout on a node that is not visible as a pod, e.g. a
+	// host driver upgrade by a CD pipeline. The value is an RFC 3339 timestamp.
+	TimeAnnotation = "nvsentinel.dgxc.nvidia.com/rollout-time"
+	// ComponentAnnotation optionally names what was rolled out with TimeAnnotation.
+	ComponentAnnotation = "nvsentinel.dgxc.nvidia.com/rollout-component"
+
+	// revisionLabel is set by the DaemonSet controller to the revision a pod runs
+	revisionLabel = "controller-revision-hash"
+)
+
+// Rollout is the most recent rollout on a node.
+type Rollout struct {
+	// Component is the rolled out image, or the value of ComponentAnnotation.
+	Component string
+	Time      time.Time
+}
+
+// podRevision is what a node last ran, a rollout changes it.
+type podRevision struct {
+	revision string
+	image    string
+}
+
+// nodeRollouts is the revision a node runs and the last rollout seen on it.
+type nodeRollouts struct {
+	current podRevision
+	last    Rollout
+}
+
+// Tracker watches the rolled out pods and the rollout annotations of nodes.
+type Tracker struct {
+	podInformer  cache.SharedIndexInformer
+	nodeInformer cache.SharedIndexInformer
+
+	mu    sync.Mutex
+	nodes map[string]*nodeRollouts
+}
+
+// NewTracker returns a tracker for the pods in namespace matching podSelector, e.g.
+// the GPU Operator driver daemonset. A pod becoming ready on a node with another
+// controller-revision-hash or image than the node ran before counts as a rollout on
+// that node. Pods restarting on the same revision do not, and neither do the pods
+// found when the tracker starts, they are what the nodes run.
+func NewTracker(clientset kubernetes.Interface, namespace, podSelector string,
+	resyncPeriod time.Duration) (*Tracker, error) {
+	podInformerFactory := informers.NewSharedInformerFactoryWithOptions(
+		clientset,
+		resyncPeriod,
+		informers.WithNamespace(namespace),
+		informers.WithTweakListOptions(func(options *metav1.ListOptions) {
+			options.LabelSelector = podSelector
+		}),
+	)
+	nodeInformerFactory := informers.NewSharedInformerFactory(clientset, resyncPeriod)
+
+	t := &Tracker{
+		podInformer:  podInformerFactory.Core().V1().Pods().Informer(),
+		nodeInformer: nodeInformerFactory.Core().V1().Nodes().Informer(),
+		nodes:        make(map[string]*nodeRollouts),
+	}
+
+	_, err := t.podInformer.AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc: t.observe,
+		UpdateFunc: func(_, newObj any) {
+			t.observe(newObj)
+		},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to add pod event handler: %w", err)
+	}
+
+	return t, nil
+}
+
+// observe records the revision of a ready pod on its node, and a rollout if the
+// node ran another one before.
+func (t *Tracker) observe(obj any) {
+	pod, ok := obj.(*v1.Pod)
+	if !ok || pod.Spec.NodeName == "" || !pod.DeletionTimestamp.IsZero() {
+		return
+	}
+
+	readyAt, ok := podReadyTime(pod)
+	if !ok {
+		return
+	}
+
+	revision := podRevision{revision: pod.Labels[revisionLabel], image: podImage(pod)}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	node, ok := t.nodes[pod.Spec.NodeName]
+	if !ok {
+		t.nodes[pod.Spec.NodeName] = &nodeRollouts{current: revision}
+		return
+	}
+
+	if revision == node.current {
+		return
+	}
+
+	slog.Info("Detected rollout on node", "node", pod.Spec.NodeName, "pod", pod.Name,
+		"fromRevision", node.current.revision, "toRevision", revision.revision,
+		"fromImage", node.current.image, "toImage", revision.image)
+
+	node.current = revision
+	node.last = Rollout{Component: revision.image, Time: readyAt}
+}
+
+// Run starts the informers, waits for their caches to sync and blocks until ctx
+// is done.
+func (t *Tracker) Run(ctx context.Context) error {
+	go t.podInformer.Run(ctx.Done())
+	go t.nodeInformer.Run(ctx.Done())
+
+	if !cache.WaitForCacheSync(ctx.Done(), t.podInformer.HasSynced, t.nodeInformer.HasSynced) {
+		return fmt.Errorf("failed to wait for rollout tracker caches to sync")
+	}
+
+	slog.Info("Rollout tracker caches synced")
+
+	<-ctx.Done()
+
+	return nil
+}
+
+// LastRollout returns the most recent rollout on the node.
+func (t *Tracker) LastRollout(nodeName string) (Rollout, bool) {
+	var last Rollout
+
+	t.mu.Lock()
+	if node, ok := t.nodes[nodeName]; ok {
+		last = node.last
+	}
+	t.mu.Unlock()
+
+	if obj, exists, err := t.nodeInformer.GetStore().GetByKey(nodeName); err == nil && exists {
+		if node, ok := obj.(*v1.Node); ok {
+			if annotated, ok := annotatedRollout(node); ok && annotated.Time.After(last.Time) {
+				last = annotated
+			}
+		}
+	}
+
+	return last, !last.Time.IsZero()
+}
+
+func podReadyTime(pod *v1.Pod) (time.Time, bool) {
+	for _, condition := range pod.Status.Conditions {
+		if condition.Type == v1.PodReady && condition.Status == v1.ConditionTrue {
+			return condition.LastTransitionTime.Time, true
+		}
+	}
+
+	return time.Time{}, false
+}
+
+func podImage(pod *v1.Pod) string {
+	if len(pod.Spec.Containers) == 0 {
+		return pod.Name
+	}
+
+	return pod.Spec.Containers[0].Image
+}
+
+func annotatedRollout(node *v1.Node) (Rollout, bool) {
+	value, ok := node.Annotations[TimeAnnotation]
+	if !ok {
+		return Rollout{}, false
+	}
+
+	rolloutTime, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		slog.Warn("Ignoring invalid rollout annotation", "node", node.Name, "value", value, "error", err)
+		return Rollout{}, false
+	}
+
+	component := node.Annotations[ComponentAnnotation]
+	if component == "" {
+		component = "unknown"
+	}
+
+	return Rollout{Component: component, Time: rolloutTime}, true
+}
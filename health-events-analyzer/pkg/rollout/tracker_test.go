@@ -0,0 +1,140 @@
+// Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rollout
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func driverPod(name, nodeName, revision, image string, readyAt time.Time) *v1.Pod {
+	pod := &v1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: "gpu-operator",
+			Labels:    map[string]string{"app": "nvidia-driver-daemonset"},
+		},
+		Spec: v1.PodSpec{
+			NodeName:   nodeName,
+			Containers: []v1.Container{{Name: "driver", Image: image}},
+		},
+	}
+
+	if revision != "" {
+		pod.Labels[revisionLabel] = revision
+	}
+
+	if !readyAt.IsZero() {
+		pod.Status.Conditions = []v1.PodCondition{{
+			Type:               v1.PodReady,
+			Status:             v1.ConditionTrue,
+			LastTransitionTime: metav1.NewTime(readyAt),
+		}}
+	}
+
+	return pod
+}
+
+func TestTracker_LastRollout(t *testing.T) {
+	now := time.Now().Truncate(time.Second)
+
+	clientset := fake.NewSimpleClientset(
+		driverPod("driver-a", "node-a", "5d8f7c", "nvcr.io/nvidia/driver:550.54", now.Add(-2*time.Hour)),
+		driverPod("driver-b", "node-b", "5d8f7c", "nvcr.io/nvidia/driver:550.54", now.Add(-2*time.Hour)),
+		driverPod("driver-f", "node-f", "", "nvcr.io/nvidia/driver:550.54", now.Add(-2*time.Hour)),
+		&v1.Pod{
+			ObjectMeta: metav1.ObjectMeta{Name: "other", Namespace: "gpu-operator", Labels: map[string]string{"app": "other"}},
+			Spec:       v1.PodSpec{NodeName: "node-d"},
+			Status: v1.PodStatus{Conditions: []v1.PodCondition{{
+				Type: v1.PodReady, Status: v1.ConditionTrue, LastTransitionTime: metav1.NewTime(now),
+			}}},
+		},
+		&v1.Node{ObjectMeta: metav1.ObjectMeta{Name: "node-c", Annotations: map[string]string{
+			TimeAnnotation:      now.Add(-5 * time.Minute).Format(time.RFC3339),
+			ComponentAnnotation: "host-driver-570.86",
+		}}},
+		&v1.Node{ObjectMeta: metav1.ObjectMeta{Name: "node-e", Annotations: map[string]string{
+			TimeAnnotation: "yesterday",
+		}}},
+	)
+
+	tracker, err := NewTracker(clientset, "gpu-operator", "app in (nvidia-driver-daemonset)", 0)
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	go func() { _ = tracker.Run(ctx) }()
+
+	require.Eventually(t, func() bool {
+		return tracker.podInformer.HasSynced() && tracker.nodeInformer.HasSynced()
+	}, 5*time.Second, 10*time.Millisecond)
+
+	// The pods found at start are what the nodes run, not rollouts
+	_, found := tracker.LastRollout("node-a")
+	assert.False(t, found)
+
+	pods := clientset.CoreV1().Pods("gpu-operator")
+
+	// The driver container of node-b restarts on the same revision
+	_, err = pods.Update(ctx, driverPod("driver-b", "node-b", "5d8f7c", "nvcr.io/nvidia/driver:550.54",
+		now.Add(-time.Minute)), metav1.UpdateOptions{})
+	require.NoError(t, err)
+
+	// The pod of node-f, which is not run by a DaemonSet, is updated to a new image
+	_, err = pods.Update(ctx, driverPod("driver-f", "node-f", "", "nvcr.io/nvidia/driver:570.86",
+		now.Add(-3*time.Minute)), metav1.UpdateOptions{})
+	require.NoError(t, err)
+
+	// The DaemonSet replaces the pod of node-a with one of a new revision
+	require.NoError(t, pods.Delete(ctx, "driver-a", metav1.DeleteOptions{}))
+	_, err = pods.Create(ctx, driverPod("driver-a2", "node-a", "7b9c4d", "nvcr.io/nvidia/driver:570.86",
+		now.Add(-10*time.Minute)), metav1.CreateOptions{})
+	require.NoError(t, err)
+
+	require.Eventually(t, func() bool {
+		_, found := tracker.LastRollout("node-a")
+		return found
+	}, 5*time.Second, 10*time.Millisecond)
+
+	tests := []struct {
+		node     string
+		expected Rollout
+		found    bool
+	}{
+		{node: "node-a", expected: Rollout{Component: "nvcr.io/nvidia/driver:570.86", Time: now.Add(-10 * time.Minute)}, found: true},
+		{node: "node-b"},
+		{node: "node-c", expected: Rollout{Component: "host-driver-570.86", Time: now.Add(-5 * time.Minute)}, found: true},
+		{node: "node-d"},
+		{node: "node-e"},
+		{node: "node-f", expected: Rollout{Component: "nvcr.io/nvidia/driver:570.86", Time: now.Add(-3 * time.Minute)}, found: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.node, func(t *testing.T) {
+			last, found := tracker.LastRollout(tt.node)
+			assert.Equal(t, tt.found, found)
+			assert.Equal(t, tt.expected.Component, last.Component)
+			assert.True(t, tt.expected.Time.Equal(last.Time), "expected %s, got %s", tt.expected.Time, last.Time)
+		})
+	}
+}
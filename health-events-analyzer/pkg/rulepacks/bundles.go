@@ -0,0 +1,167 @@
+// Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rulepacks
+
+import (
+	"crypto/ed25519"
+	"fmt"
+	"hash/fnv"
+	"sort"
+
+	"github.com/nvidia/nvsentinel/commons/pkg/configmanager"
+	"github.com/nvidia/nvsentinel/data-models/pkg/model"
+	"github.com/nvidia/nvsentinel/data-models/pkg/protos"
+	"github.com/nvidia/nvsentinel/health-events-analyzer/pkg/config"
+	"golang.org/x/mod/semver"
+)
+
+const defaultSchemaVersion = 1
+
+// BundleConfig is a bundle of the bundles file.
+type BundleConfig struct {
+	// Version of the bundle, a semantic version that may omit the leading "v" and
+	// trailing components. Agents are served the newest bundle they are eligible for.
+	Version string `toml:"version"`
+	// SchemaVersion of the pack files, defaults to 1. Agents only receive bundles
+	// up to the highest schema version they understand.
+	SchemaVersion uint32 `toml:"schema_version"`
+	// MinAgentVersion is the oldest agent version the bundle is served to. Agents
+	// whose version is not a semantic version only receive bundles without one.
+	MinAgentVersion string `toml:"min_agent_version"`
+	// RolloutPercent is the percentage of nodes the bundle is rolled out to,
+	// defaults to 100. Raising it keeps the nodes already selected, so a bundle is
+	// staged by increasing it step by step.
+	RolloutPercent *int `toml:"rollout_percent"`
+	// Packs maps file names to rule pack TOML files.
+	Packs map[string]string `toml:"packs"`
+}
+
+type bundlesFile struct {
+	Bundles []BundleConfig `toml:"bundles"`
+}
+
+// bundle is a loaded and signed bundle.
+type bundle struct {
+	version         string
+	minAgentVersion string
+	rolloutPercent  int
+	message         *protos.RulePackBundle
+}
+
+// loadBundles loads the bundles of the file at path and signs them with key. The
+// bundles are returned newest first.
+func loadBundles(path string, key ed25519.PrivateKey) ([]*bundle, error) {
+	var file bundlesFile
+	if err := configmanager.LoadTOMLConfig(path, &file); err != nil {
+		return nil, err
+	}
+
+	bundles := make([]*bundle, 0, len(file.Bundles))
+	versions := make(map[string]bool, len(file.Bundles))
+
+	for _, cfg := range file.Bundles {
+		b, err := newBundle(cfg, key)
+		if err != nil {
+			return nil, err
+		}
+
+		if versions[b.version] {
+			return nil, fmt.Errorf("duplicate rule pack bundle version %s", cfg.Version)
+		}
+
+		versions[b.version] = true
+		bundles = append(bundles, b)
+	}
+
+	sort.Slice(bundles, func(i, j int) bool { return semver.Compare(bundles[i].version, bundles[j].version) > 0 })
+
+	return bundles, nil
+}
+
+func newBundle(cfg BundleConfig, key ed25519.PrivateKey) (*bundle, error) {
+	version, ok := config.ParseVersion(cfg.Version)
+	if !ok {
+		return nil, fmt.Errorf("rule pack bundle: invalid version %q", cfg.Version)
+	}
+
+	b := &bundle{version: version, rolloutPercent: 100}
+
+	if cfg.MinAgentVersion != "" {
+		if b.minAgentVersion, ok = config.ParseVersion(cfg.MinAgentVersion); !ok {
+			return nil, fmt.Errorf("rule pack bundle %s: invalid min_agent_version %q", cfg.Version, cfg.MinAgentVersion)
+		}
+	}
+
+	if cfg.RolloutPercent != nil {
+		b.rolloutPercent = *cfg.RolloutPercent
+	}
+
+	if b.rolloutPercent < 0 || b.rolloutPercent > 100 {
+		return nil, fmt.Errorf("rule pack bundle %s: rollout_percent must be between 0 and 100", cfg.Version)
+	}
+
+	if len(cfg.Packs) == 0 {
+		return nil, fmt.Errorf("rule pack bundle %s: no packs", cfg.Version)
+	}
+
+	b.message = &protos.RulePackBundle{
+		Version:       cfg.Version,
+		SchemaVersion: cfg.SchemaVersion,
+	}
+
+	if b.message.SchemaVersion == 0 {
+		b.message.SchemaVersion = defaultSchemaVersion
+	}
+
+	for name, content := range cfg.Packs {
+		b.message.Files = append(b.message.Files, &protos.RulePackFile{Name: name, Content: []byte(content)})
+	}
+
+	sort.Slice(b.message.Files, func(i, j int) bool { return b.message.Files[i].Name < b.message.Files[j].Name })
+
+	model.SignRulePackBundle(b.message, key)
+
+	return b, nil
+}
+
+// servesTo returns true if the bundle is served to the agent of the request.
+func (b *bundle) servesTo(request *protos.RulePackBundleRequest) bool {
+	if b.message.SchemaVersion > request.GetMaxSchemaVersion() {
+		return false
+	}
+
+	if b.minAgentVersion != "" {
+		agentVersion, ok := config.ParseVersion(request.GetAgentVersion())
+		if !ok || semver.Compare(agentVersion, b.minAgentVersion) < 0 {
+			return false
+		}
+	}
+
+	return b.inRollout(request.GetNodeName())
+}
+
+// inRollout returns true if the node is among the rollout percentage of the nodes.
+// Nodes are ordered by a hash of the bundle version and node name, so a different
+// set of nodes receives each bundle first.
+func (b *bundle) inRollout(nodeName string) bool {
+	if b.rolloutPercent >= 100 {
+		return true
+	}
+
+	hash := fnv.New32a()
+	hash.Write([]byte(b.version + "/" + nodeName))
+
+	return int(hash.Sum32()%100) < b.rolloutPercent
+}
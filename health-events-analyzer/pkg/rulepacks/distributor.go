@@ -0,0 +1,133 @@
+// Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package rulepacks serves signed rule pack bundles to agents over gRPC. Each agent
+// receives the newest bundle its version and schema support and that is rolled
+// out to its node, so rule packs are updated and staged without restarting agents.
+package rulepacks
+
+import (
+	"context"
+	"crypto/ed25519"
+	"fmt"
+	"log/slog"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/nvidia/nvsentinel/data-models/pkg/model"
+	"github.com/nvidia/nvsentinel/data-models/pkg/protos"
+	"github.com/nvidia/nvsentinel/health-events-analyzer/pkg/config"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Distributor implements the RulePackDistributor service.
+type Distributor struct {
+	protos.UnimplementedRulePackDistributorServer
+
+	config *config.RulePackDistribution
+	key    ed25519.PrivateKey
+
+	mu      sync.RWMutex
+	bundles []*bundle
+}
+
+// NewDistributor loads the signing key and the bundles.
+func NewDistributor(cfg *config.RulePackDistribution) (*Distributor, error) {
+	data, err := os.ReadFile(cfg.SigningKeyPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read rule pack signing key: %w", err)
+	}
+
+	key, err := model.ParseRulePackSigningKey(data)
+	if err != nil {
+		return nil, fmt.Errorf("invalid rule pack signing key %s: %w", cfg.SigningKeyPath, err)
+	}
+
+	d := &Distributor{config: cfg, key: key}
+
+	if err := d.Reload(); err != nil {
+		return nil, err
+	}
+
+	return d, nil
+}
+
+// Reload reloads the bundles file. The served bundles are kept when it fails.
+func (d *Distributor) Reload() error {
+	bundles, err := loadBundles(d.config.BundlesPath, d.key)
+	if err != nil {
+		return fmt.Errorf("failed to load rule pack bundles: %w", err)
+	}
+
+	d.mu.Lock()
+	d.bundles = bundles
+	d.mu.Unlock()
+
+	bundlesLoaded.Set(float64(len(bundles)))
+
+	return nil
+}
+
+// Run reloads the bundles file every reload interval until ctx is done.
+func (d *Distributor) Run(ctx context.Context) error {
+	ticker := time.NewTicker(d.config.ReloadIntervalDuration())
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+			if err := d.Reload(); err != nil {
+				bundleReloadErrors.Inc()
+				slog.Error("Failed to reload rule pack bundles, serving the previous bundles", "error", err)
+			}
+		}
+	}
+}
+
+// GetRulePackBundle returns the bundle the agent should apply.
+func (d *Distributor) GetRulePackBundle(_ context.Context,
+	request *protos.RulePackBundleRequest) (*protos.RulePackBundleResponse, error) {
+	if request.GetNodeName() == "" {
+		return nil, status.Error(codes.InvalidArgument, "node_name is required")
+	}
+
+	d.mu.RLock()
+	bundles := d.bundles
+	d.mu.RUnlock()
+
+	for _, b := range bundles {
+		if !b.servesTo(request) {
+			continue
+		}
+
+		if b.message.Version == request.GetCurrentBundleVersion() {
+			bundleRequests.WithLabelValues(b.message.Version, resultNotModified).Inc()
+			return &protos.RulePackBundleResponse{NotModified: true}, nil
+		}
+
+		slog.Info("Serving rule pack bundle", "node", request.GetNodeName(), "agent", request.GetAgent(),
+			"bundle", b.message.Version, "previous", request.GetCurrentBundleVersion())
+		bundleRequests.WithLabelValues(b.message.Version, resultServed).Inc()
+
+		return &protos.RulePackBundleResponse{Bundle: b.message}, nil
+	}
+
+	bundleRequests.WithLabelValues("", resultNone).Inc()
+
+	return &protos.RulePackBundleResponse{}, nil
+}
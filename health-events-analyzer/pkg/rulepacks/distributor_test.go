@@ -0,0 +1,222 @@
+// Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rulepacks
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/nvidia/nvsentinel/data-models/pkg/model"
+	"github.com/nvidia/nvsentinel/data-models/pkg/protos"
+	"github.com/nvidia/nvsentinel/health-events-analyzer/pkg/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testBundles = `
+[[bundles]]
+version = "1"
+[bundles.packs]
+"h100.toml" = 'name = "H100"'
+
+[[bundles]]
+version = "2"
+min_agent_version = "v0.6.0"
+rollout_percent = 50
+[bundles.packs]
+"h100.toml" = 'name = "H100"'
+"gb200.toml" = 'name = "GB200"'
+
+[[bundles]]
+version = "3"
+schema_version = 2
+[bundles.packs]
+"h100.toml" = 'name = "H100"'
+`
+
+func newTestDistributor(t *testing.T, bundles string) (*Distributor, ed25519.PublicKey, *config.RulePackDistribution) {
+	t.Helper()
+
+	publicKey, privateKey, err := ed25519.GenerateKey(nil)
+	require.NoError(t, err)
+
+	der, err := x509.MarshalPKCS8PrivateKey(privateKey)
+	require.NoError(t, err)
+
+	dir := t.TempDir()
+	cfg := &config.RulePackDistribution{
+		BundlesPath:    filepath.Join(dir, "bundles.toml"),
+		SigningKeyPath: filepath.Join(dir, "key.pem"),
+	}
+	require.NoError(t, cfg.Validate())
+	require.NoError(t, os.WriteFile(cfg.SigningKeyPath,
+		pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der}), 0o600))
+	require.NoError(t, os.WriteFile(cfg.BundlesPath, []byte(bundles), 0o600))
+
+	distributor, err := NewDistributor(cfg)
+	require.NoError(t, err)
+
+	return distributor, publicKey, cfg
+}
+
+// nodeInRollout returns a node that is, or is not, in the rollout of the bundle.
+func nodeInRollout(t *testing.T, d *Distributor, version string, in bool) string {
+	t.Helper()
+
+	for _, b := range d.bundles {
+		if b.message.Version != version {
+			continue
+		}
+
+		for i := 0; i < 1000; i++ {
+			node := fmt.Sprintf("node-%d", i)
+			if b.inRollout(node) == in {
+				return node
+			}
+		}
+	}
+
+	t.Fatalf("no node found for bundle %s", version)
+
+	return ""
+}
+
+func TestGetRulePackBundle(t *testing.T) {
+	d, publicKey, _ := newTestDistributor(t, testBundles)
+	ctx := context.Background()
+
+	inRollout := nodeInRollout(t, d, "2", true)
+	notInRollout := nodeInRollout(t, d, "2", false)
+
+	tests := []struct {
+		name     string
+		request  *protos.RulePackBundleRequest
+		expected string
+	}{
+		{
+			name:     "newest bundle rolled out to the node",
+			request:  &protos.RulePackBundleRequest{NodeName: inRollout, AgentVersion: "v0.6.1", MaxSchemaVersion: 1},
+			expected: "2",
+		},
+		{
+			name:     "node not in the rollout",
+			request:  &protos.RulePackBundleRequest{NodeName: notInRollout, AgentVersion: "v0.6.1", MaxSchemaVersion: 1},
+			expected: "1",
+		},
+		{
+			name:     "agent older than the bundle minimum",
+			request:  &protos.RulePackBundleRequest{NodeName: inRollout, AgentVersion: "v0.5.0", MaxSchemaVersion: 1},
+			expected: "1",
+		},
+		{
+			name:     "agent without a semantic version",
+			request:  &protos.RulePackBundleRequest{NodeName: inRollout, AgentVersion: "dev", MaxSchemaVersion: 1},
+			expected: "1",
+		},
+		{
+			name:     "agent supporting a newer schema",
+			request:  &protos.RulePackBundleRequest{NodeName: notInRollout, MaxSchemaVersion: 2},
+			expected: "3",
+		},
+		{
+			name:    "agent not supporting any schema",
+			request: &protos.RulePackBundleRequest{NodeName: inRollout},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			response, err := d.GetRulePackBundle(ctx, tt.request)
+			require.NoError(t, err)
+			assert.False(t, response.NotModified)
+
+			if tt.expected == "" {
+				assert.Nil(t, response.Bundle)
+				return
+			}
+
+			require.NotNil(t, response.Bundle)
+			assert.Equal(t, tt.expected, response.Bundle.Version)
+			assert.NoError(t, model.VerifyRulePackBundle(response.Bundle, publicKey))
+		})
+	}
+
+	response, err := d.GetRulePackBundle(ctx, &protos.RulePackBundleRequest{
+		NodeName: notInRollout, MaxSchemaVersion: 1, CurrentBundleVersion: "1",
+	})
+	require.NoError(t, err)
+	assert.True(t, response.NotModified)
+	assert.Nil(t, response.Bundle)
+
+	_, err = d.GetRulePackBundle(ctx, &protos.RulePackBundleRequest{MaxSchemaVersion: 1})
+	assert.Error(t, err)
+}
+
+func TestRollout(t *testing.T) {
+	rollout := func(percent int) map[string]bool {
+		b := &bundle{version: "v2.0.0", rolloutPercent: percent}
+		nodes := map[string]bool{}
+
+		for i := 0; i < 1000; i++ {
+			node := fmt.Sprintf("node-%d", i)
+			if b.inRollout(node) {
+				nodes[node] = true
+			}
+		}
+
+		return nodes
+	}
+
+	assert.Empty(t, rollout(0))
+	assert.Len(t, rollout(100), 1000)
+
+	stage1, stage2 := rollout(10), rollout(50)
+	assert.InDelta(t, 100, len(stage1), 40)
+	assert.InDelta(t, 500, len(stage2), 80)
+
+	// raising the percentage keeps the nodes already selected
+	for node := range stage1 {
+		assert.True(t, stage2[node], node)
+	}
+}
+
+func TestReload(t *testing.T) {
+	d, _, cfg := newTestDistributor(t, testBundles)
+	assert.Len(t, d.bundles, 3)
+	assert.Equal(t, "3", d.bundles[0].message.Version)
+
+	for _, invalid := range []string{
+		`[[bundles]]` + "\n" + `version = "x"`,
+		`[[bundles]]` + "\n" + `version = "4"`,
+		`[[bundles]]` + "\n" + `version = "4"` + "\n" + `rollout_percent = 101` + "\n" + `packs = { "a.toml" = "" }`,
+		testBundles + "\n" + `[[bundles]]` + "\n" + `version = "1.0.0"` + "\n" + `packs = { "a.toml" = "" }`,
+	} {
+		require.NoError(t, os.WriteFile(cfg.BundlesPath, []byte(invalid), 0o600))
+		assert.Error(t, d.Reload(), invalid)
+	}
+
+	// the previous bundles are still served
+	assert.Len(t, d.bundles, 3)
+
+	require.NoError(t, os.WriteFile(cfg.BundlesPath, []byte(""), 0o600))
+	require.NoError(t, d.Reload())
+	assert.Empty(t, d.bundles)
+}
@@ -0,0 +1,48 @@
+// Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rulepacks
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+const (
+	resultServed      = "served"
+	resultNotModified = "not_modified"
+	resultNone        = "none"
+)
+
+var (
+	bundleRequests = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "health_event_analyzer_rule_pack_bundle_requests_total",
+			Help: "Total number of rule pack bundle requests by the bundle served.",
+		},
+		[]string{"bundle_version", "result"},
+	)
+	bundlesLoaded = promauto.NewGauge(
+		prometheus.GaugeOpts{
+			Name: "health_event_analyzer_rule_pack_bundles_loaded",
+			Help: "Number of rule pack bundles currently served.",
+		},
+	)
+	bundleReloadErrors = promauto.NewCounter(
+		prometheus.CounterOpts{
+			Name: "health_event_analyzer_rule_pack_bundle_reload_errors_total",
+			Help: "Total number of failed reloads of the rule pack bundles file.",
+		},
+	)
+)
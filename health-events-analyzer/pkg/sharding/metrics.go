@@ -0,0 +1,49 @@
+// Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sharding
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+var (
+	shardMembers = promauto.NewGauge(
+		prometheus.GaugeOpts{
+			Name: "health_event_analyzer_shard_members",
+			Help: "Number of analyzer replicas the nodes are spread over.",
+		},
+	)
+	shardRebalances = promauto.NewCounter(
+		prometheus.CounterOpts{
+			Name: "health_event_analyzer_shard_rebalances_total",
+			Help: "Total number of times the nodes were spread over a changed set of replicas.",
+		},
+	)
+	shardEvents = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "health_event_analyzer_shard_events_total",
+			Help: "Total number of events evaluated (owned) or left to another replica (skipped) by a shard.",
+		},
+		[]string{"shard", "result"},
+	)
+	shardLag = promauto.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "health_event_analyzer_shard_lag_seconds",
+			Help: "Time between the generation and the evaluation of the last event of a shard.",
+		},
+		[]string{"shard"},
+	)
+)
@@ -0,0 +1,63 @@
+// Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sharding
+
+import (
+	"context"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+// PodMembers lists the ready analyzer pods as replicas.
+type PodMembers struct {
+	clientset kubernetes.Interface
+	namespace string
+	selector  string
+}
+
+// NewPodMembers lists the pods matching selector in namespace.
+func NewPodMembers(clientset kubernetes.Interface, namespace, selector string) *PodMembers {
+	return &PodMembers{clientset: clientset, namespace: namespace, selector: selector}
+}
+
+// Members returns the names of the ready pods that are not terminating.
+func (p *PodMembers) Members(ctx context.Context) ([]string, error) {
+	pods, err := p.clientset.CoreV1().Pods(p.namespace).List(ctx, metav1.ListOptions{LabelSelector: p.selector})
+	if err != nil {
+		return nil, err
+	}
+
+	var members []string
+
+	for _, pod := range pods.Items {
+		if pod.DeletionTimestamp == nil && ready(&pod) {
+			members = append(members, pod.Name)
+		}
+	}
+
+	return members, nil
+}
+
+func ready(pod *corev1.Pod) bool {
+	for _, condition := range pod.Status.Conditions {
+		if condition.Type == corev1.PodReady {
+			return condition.Status == corev1.ConditionTrue
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,91 @@
+// Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sharding
+
+import (
+	"hash/fnv"
+	"slices"
+	"sort"
+	"strconv"
+)
+
+// Ring assigns keys to members with consistent hashing: a membership change only
+// moves the keys of the points of the added or removed member.
+type Ring struct {
+	members []string
+	points  []point
+}
+
+type point struct {
+	hash   uint64
+	member string
+}
+
+// NewRing places virtualNodes points per member on the ring.
+func NewRing(members []string, virtualNodes int) *Ring {
+	ring := &Ring{members: slices.Sorted(slices.Values(members))}
+	ring.members = slices.Compact(ring.members)
+
+	for _, member := range ring.members {
+		for i := range virtualNodes {
+			ring.points = append(ring.points, point{hash: hash(member + "#" + strconv.Itoa(i)), member: member})
+		}
+	}
+
+	sort.Slice(ring.points, func(i, j int) bool {
+		if ring.points[i].hash != ring.points[j].hash {
+			return ring.points[i].hash < ring.points[j].hash
+		}
+
+		return ring.points[i].member < ring.points[j].member
+	})
+
+	return ring
+}
+
+// Owner returns the member owning key, the one of the first point at or after the
+// hash of key. It returns "" for an empty ring.
+func (r *Ring) Owner(key string) string {
+	if len(r.points) == 0 {
+		return ""
+	}
+
+	h := hash(key)
+
+	i := sort.Search(len(r.points), func(i int) bool { return r.points[i].hash >= h })
+	if i == len(r.points) {
+		i = 0
+	}
+
+	return r.points[i].member
+}
+
+// Members returns the sorted members of the ring.
+func (r *Ring) Members() []string {
+	return r.members
+}
+
+// hash is FNV-1a followed by the SplitMix64 finalizer, which spreads the similar
+// member and node names over the whole ring.
+func hash(key string) uint64 {
+	h := fnv.New64a()
+	_, _ = h.Write([]byte(key))
+
+	x := h.Sum64()
+	x = (x ^ (x >> 30)) * 0xbf58476d1ce4e5b9
+	x = (x ^ (x >> 27)) * 0x94d049bb133111eb
+
+	return x ^ (x >> 31)
+}
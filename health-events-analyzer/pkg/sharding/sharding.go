@@ -0,0 +1,172 @@
+// Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package sharding spreads the nodes over the analyzer replicas. Every replica
+// watches all the events and evaluates the rules only for the nodes it owns on a
+// consistent hash ring of the ready replicas, which is rebuilt when replicas are
+// added or removed.
+package sharding
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/nvidia/nvsentinel/health-events-analyzer/pkg/config"
+)
+
+// MemberLister lists the names of the analyzer replicas.
+type MemberLister interface {
+	Members(ctx context.Context) ([]string, error)
+}
+
+// Sharder tells whether this replica owns a node.
+type Sharder struct {
+	self         string
+	lister       MemberLister
+	virtualNodes int
+	interval     time.Duration
+	now          func() time.Time
+
+	mu          sync.RWMutex
+	ring        *Ring
+	onRebalance []func(ctx context.Context)
+}
+
+// NewSharder creates the Sharder of the replica named self.
+func NewSharder(self string, lister MemberLister, cfg *config.Sharding) *Sharder {
+	return &Sharder{
+		self:         self,
+		lister:       lister,
+		virtualNodes: cfg.VirtualNodes,
+		interval:     cfg.RefreshIntervalDuration(),
+		now:          time.Now,
+		ring:         NewRing([]string{self}, cfg.VirtualNodes),
+	}
+}
+
+// OnRebalance registers fn to be called after the nodes moved between replicas,
+// e.g. to reload the state of the nodes this replica took over.
+func (s *Sharder) OnRebalance(fn func(ctx context.Context)) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.onRebalance = append(s.onRebalance, fn)
+}
+
+// Run refreshes the replicas every interval until ctx is done. Failed refreshes
+// are logged and the current ring is kept until the next interval.
+func (s *Sharder) Run(ctx context.Context) error {
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+
+		if err := s.Refresh(ctx); err != nil {
+			slog.Error("Failed to refresh analyzer replicas", "error", err)
+		}
+	}
+}
+
+// Refresh lists the replicas and rebuilds the ring when they changed. This replica
+// is always a member, also before its pod is ready: until the others see it, its
+// nodes are evaluated twice rather than not at all.
+func (s *Sharder) Refresh(ctx context.Context) error {
+	members, err := s.lister.Members(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to list analyzer replicas: %w", err)
+	}
+
+	if !slices.Contains(members, s.self) {
+		members = append(members, s.self)
+	}
+
+	ring := NewRing(members, s.virtualNodes)
+
+	s.mu.Lock()
+	previous := s.ring.Members()
+	changed := !slices.Equal(previous, ring.Members())
+
+	if changed {
+		s.ring = ring
+	}
+
+	callbacks := s.onRebalance
+	s.mu.Unlock()
+
+	shardMembers.Set(float64(len(ring.Members())))
+
+	if !changed {
+		return nil
+	}
+
+	slog.Info("Analyzer replicas changed, rebalancing nodes", "shard", s.self,
+		"previous", previous, "members", ring.Members())
+
+	shardRebalances.Inc()
+
+	for _, fn := range callbacks {
+		fn(ctx)
+	}
+
+	return nil
+}
+
+// Owns returns whether this replica evaluates the events of the node.
+func (s *Sharder) Owns(nodeName string) bool {
+	s.mu.RLock()
+	owner := s.ring.Owner(nodeName)
+	s.mu.RUnlock()
+
+	owned := owner == s.self
+
+	result := "skipped"
+	if owned {
+		result = "owned"
+	}
+
+	shardEvents.WithLabelValues(s.self, result).Inc()
+
+	return owned
+}
+
+// Processed records the lag of the shard from the generation time of the last
+// event it evaluated.
+func (s *Sharder) Processed(generatedAt time.Time) {
+	shardLag.WithLabelValues(s.self).Set(s.now().Sub(generatedAt).Seconds())
+}
+
+// TokenClientName returns the client name of the change stream resume token of
+// this replica. Every replica resumes the change stream from its own token, so a
+// restarted replica does not skip the events it had not evaluated yet when other
+// replicas are ahead of it.
+func (s *Sharder) TokenClientName(clientName string) string {
+	return clientName + "-" + s.self
+}
+
+// Members returns the replicas of the current ring.
+func (s *Sharder) Members() []string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return s.ring.Members()
+}
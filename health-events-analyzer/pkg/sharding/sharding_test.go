@@ -0,0 +1,192 @@
+// Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sharding
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/nvidia/nvsentinel/health-events-analyzer/pkg/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func nodeNames(n int) []string {
+	names := make([]string, 0, n)
+	for i := range n {
+		names = append(names, fmt.Sprintf("gb200-rack%02d-node%03d", i/72, i))
+	}
+
+	return names
+}
+
+func TestRingSpreadsNodes(t *testing.T) {
+	ring := NewRing([]string{"analyzer-a", "analyzer-b", "analyzer-c"}, 100)
+	counts := map[string]int{}
+
+	for _, node := range nodeNames(3000) {
+		counts[ring.Owner(node)]++
+	}
+
+	require.Len(t, counts, 3)
+
+	for member, count := range counts {
+		assert.InDelta(t, 1000, count, 250, member)
+	}
+}
+
+func TestRingMovesFewNodes(t *testing.T) {
+	before := NewRing([]string{"analyzer-a", "analyzer-b", "analyzer-c"}, 100)
+	after := NewRing([]string{"analyzer-a", "analyzer-b", "analyzer-c", "analyzer-d"}, 100)
+
+	moved := 0
+
+	for _, node := range nodeNames(3000) {
+		if owner := after.Owner(node); owner != before.Owner(node) {
+			moved++
+			// nodes only move to the new member
+			assert.Equal(t, "analyzer-d", owner)
+		}
+	}
+
+	assert.InDelta(t, 750, moved, 250)
+}
+
+func TestRingOwner(t *testing.T) {
+	assert.Equal(t, "", NewRing(nil, 100).Owner("node"))
+
+	ring := NewRing([]string{"b", "a", "b"}, 10)
+	assert.Equal(t, []string{"a", "b"}, ring.Members())
+	assert.Equal(t, ring.Owner("node-1"), NewRing([]string{"a", "b"}, 10).Owner("node-1"))
+}
+
+type fakeLister struct {
+	members []string
+	err     error
+}
+
+func (f *fakeLister) Members(context.Context) ([]string, error) {
+	return f.members, f.err
+}
+
+func TestSharderRefresh(t *testing.T) {
+	lister := &fakeLister{}
+	sharder := NewSharder("analyzer-a", lister, &config.Sharding{VirtualNodes: 100, RefreshInterval: "1s"})
+
+	rebalances := 0
+	sharder.OnRebalance(func(context.Context) { rebalances++ })
+
+	nodes := nodeNames(300)
+
+	// alone until the other replicas are listed
+	for _, node := range nodes {
+		assert.True(t, sharder.Owns(node))
+	}
+
+	lister.members = []string{"analyzer-a", "analyzer-b"}
+	require.NoError(t, sharder.Refresh(context.Background()))
+	assert.Equal(t, 1, rebalances)
+	assert.Equal(t, []string{"analyzer-a", "analyzer-b"}, sharder.Members())
+
+	owned := 0
+
+	for _, node := range nodes {
+		if sharder.Owns(node) {
+			owned++
+		}
+	}
+
+	assert.InDelta(t, 150, owned, 50)
+
+	// unchanged members do not rebalance, and this replica stays a member while
+	// its pod is not ready
+	lister.members = []string{"analyzer-b"}
+	require.NoError(t, sharder.Refresh(context.Background()))
+	assert.Equal(t, 1, rebalances)
+
+	lister.err = errors.New("forbidden")
+	assert.ErrorContains(t, sharder.Refresh(context.Background()), "forbidden")
+	assert.Equal(t, []string{"analyzer-a", "analyzer-b"}, sharder.Members())
+}
+
+func TestLaggingShardRestartResumesFromItsToken(t *testing.T) {
+	cfg := &config.Sharding{VirtualNodes: 100, RefreshInterval: "1s"}
+	lister := &fakeLister{members: []string{"analyzer-0", "analyzer-1"}}
+
+	// resume tokens by client name, as stored by the change stream watcher
+	tokens := map[string]int{}
+
+	ahead := NewSharder("analyzer-0", lister, cfg)
+	lagging := NewSharder("analyzer-1", lister, cfg)
+
+	tokens[ahead.TokenClientName("health-events-analyzer")] = 100
+	tokens[lagging.TokenClientName("health-events-analyzer")] = 40
+
+	// the restarted pod keeps its name and resumes from the last event it evaluated
+	restarted := NewSharder("analyzer-1", lister, cfg)
+	resumeFrom, ok := tokens[restarted.TokenClientName("health-events-analyzer")]
+	require.True(t, ok)
+	assert.Equal(t, 40, resumeFrom, "the events after 40 are evaluated again rather than skipped")
+
+	assert.NotEqual(t, ahead.TokenClientName("health-events-analyzer"),
+		restarted.TokenClientName("health-events-analyzer"))
+	assert.Equal(t, "health-events-analyzer-analyzer-1", restarted.TokenClientName("health-events-analyzer"))
+}
+
+func pod(name string, ready bool, terminating bool) *corev1.Pod {
+	status := corev1.ConditionFalse
+	if ready {
+		status = corev1.ConditionTrue
+	}
+
+	p := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: "nvsentinel",
+			Labels:    map[string]string{"app.kubernetes.io/name": "health-events-analyzer"},
+		},
+		Status: corev1.PodStatus{Conditions: []corev1.PodCondition{{Type: corev1.PodReady, Status: status}}},
+	}
+
+	if terminating {
+		p.DeletionTimestamp = &metav1.Time{Time: time.Now()}
+	}
+
+	return p
+}
+
+func TestPodMembers(t *testing.T) {
+	other := pod("other", true, false)
+	other.Labels = map[string]string{"app.kubernetes.io/name": "fault-quarantine"}
+
+	clientset := fake.NewClientset(
+		pod("analyzer-a", true, false),
+		pod("analyzer-b", false, false),
+		pod("analyzer-c", true, true),
+		pod("analyzer-d", true, false),
+		other,
+	)
+
+	members, err := NewPodMembers(clientset, "nvsentinel", "app.kubernetes.io/name=health-events-analyzer").
+		Members(context.Background())
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{"analyzer-a", "analyzer-d"}, members)
+}
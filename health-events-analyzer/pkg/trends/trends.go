@@ -0,0 +1,340 @@
+// Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package trends serves historical per error code trends of the stored health
+// events: time-bucketed event counts and affected node counts over arbitrary
+// ranges, aggregated in the database so that no raw events are exported.
+package trends
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"sort"
+	"time"
+
+	eventquery "github.com/nvidia/nvsentinel/health-events-analyzer/pkg/query"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+const (
+	// PathPrefix is where the handler is served
+	PathPrefix = "/trends"
+
+	defaultRange  = 7 * 24 * time.Hour
+	defaultBucket = time.Hour
+	minBucket     = time.Minute
+	maxBuckets    = 1000
+	queryTimeout  = 30 * time.Second
+
+	analyzerAgent = "health-events-analyzer"
+	timestamp     = "healthevent.generatedtimestamp.seconds"
+)
+
+// Aggregator runs aggregation pipelines on the health events collection.
+type Aggregator interface {
+	Aggregate(ctx context.Context, pipeline interface{}, opts ...*options.AggregateOptions) (*mongo.Cursor, error)
+}
+
+// Query selects the events of a trend.
+type Query struct {
+	From   time.Time
+	To     time.Time
+	Bucket time.Duration
+	// ErrorCodes restricts the trend to these codes, all codes if empty
+	ErrorCodes []string
+	// Filter restricts the trend to the events matching a query expression
+	Filter bson.M
+}
+
+// Bucket holds the counts of one time bucket.
+type Bucket struct {
+	Start         time.Time `json:"start"`
+	Events        int       `json:"events"`
+	AffectedNodes int       `json:"affectedNodes"`
+}
+
+// ErrorCodeTrend is the trend of a single error code.
+type ErrorCodeTrend struct {
+	ErrorCode     string   `json:"errorCode"`
+	Events        int      `json:"events"`
+	AffectedNodes int      `json:"affectedNodes"`
+	Buckets       []Bucket `json:"buckets"`
+}
+
+// Response is the body served by the handler.
+type Response struct {
+	From       time.Time        `json:"from"`
+	To         time.Time        `json:"to"`
+	Bucket     string           `json:"bucket"`
+	ErrorCodes []ErrorCodeTrend `json:"errorCodes"`
+}
+
+// Handler serves trends from the health events collection.
+type Handler struct {
+	collection Aggregator
+	now        func() time.Time
+}
+
+// NewHandler creates a Handler.
+func NewHandler(collection Aggregator) *Handler {
+	return &Handler{collection: collection, now: time.Now}
+}
+
+// ServeHTTP serves GET /trends?from=&to=&bucket=&errorCode=&query=. from and to
+// are RFC 3339 times and default to the last 7 days, bucket is a duration and
+// defaults to 1h. errorCode may be repeated. query is a filter expression, see
+// package query.
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+
+	query, err := ParseQuery(r.URL.Query(), h.now())
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(r.Context(), queryTimeout)
+	defer cancel()
+
+	response, err := h.Trends(ctx, query)
+	if err != nil {
+		slog.Error("Failed to query error code trends", "error", err)
+		http.Error(w, "failed to query trends", http.StatusInternalServerError)
+
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+
+	if err := json.NewEncoder(w).Encode(response); err != nil {
+		slog.Error("Failed to encode error code trends", "error", err)
+	}
+}
+
+// ParseQuery parses and validates the query parameters.
+func ParseQuery(values url.Values, now time.Time) (Query, error) {
+	query := Query{
+		To:         now.UTC(),
+		Bucket:     defaultBucket,
+		ErrorCodes: values["errorCode"],
+	}
+
+	if to := values.Get("to"); to != "" {
+		parsed, err := time.Parse(time.RFC3339, to)
+		if err != nil {
+			return Query{}, fmt.Errorf("invalid to: %w", err)
+		}
+
+		query.To = parsed.UTC()
+	}
+
+	query.From = query.To.Add(-defaultRange)
+
+	if from := values.Get("from"); from != "" {
+		parsed, err := time.Parse(time.RFC3339, from)
+		if err != nil {
+			return Query{}, fmt.Errorf("invalid from: %w", err)
+		}
+
+		query.From = parsed.UTC()
+	}
+
+	if bucket := values.Get("bucket"); bucket != "" {
+		parsed, err := time.ParseDuration(bucket)
+		if err != nil {
+			return Query{}, fmt.Errorf("invalid bucket: %w", err)
+		}
+
+		query.Bucket = parsed
+	}
+
+	if expression := values.Get("query"); expression != "" {
+		filter, err := eventquery.Parse(expression, now)
+		if err != nil {
+			return Query{}, fmt.Errorf("invalid query: %w", err)
+		}
+
+		query.Filter = filter
+	}
+
+	if !query.From.Before(query.To) {
+		return Query{}, fmt.Errorf("from must be before to")
+	}
+
+	if query.Bucket < minBucket || query.Bucket%time.Second != 0 {
+		return Query{}, fmt.Errorf("bucket must be whole seconds and at least %s", minBucket)
+	}
+
+	if buckets := query.bucketCount(); buckets > maxBuckets {
+		return Query{}, fmt.Errorf("range spans %d buckets, at most %d are allowed", buckets, maxBuckets)
+	}
+
+	return query, nil
+}
+
+func (q Query) bucketCount() int {
+	return int((q.To.Sub(q.From) + q.Bucket - 1) / q.Bucket)
+}
+
+// Trends aggregates the trends of the query, error codes with the most events
+// first.
+func (h *Handler) Trends(ctx context.Context, query Query) (*Response, error) {
+	cursor, err := h.collection.Aggregate(ctx, pipeline(query))
+	if err != nil {
+		return nil, fmt.Errorf("failed to aggregate trends: %w", err)
+	}
+
+	defer cursor.Close(ctx)
+
+	var results []struct {
+		Buckets []struct {
+			ID struct {
+				ErrorCode string `bson:"errorCode"`
+				Bucket    int    `bson:"bucket"`
+			} `bson:"_id"`
+			Events        int `bson:"events"`
+			AffectedNodes int `bson:"affectedNodes"`
+		} `bson:"buckets"`
+		Totals []struct {
+			ErrorCode     string `bson:"_id"`
+			Events        int    `bson:"events"`
+			AffectedNodes int    `bson:"affectedNodes"`
+		} `bson:"totals"`
+	}
+
+	if err := cursor.All(ctx, &results); err != nil {
+		return nil, fmt.Errorf("failed to decode trends: %w", err)
+	}
+
+	response := &Response{
+		From:       query.From,
+		To:         query.To,
+		Bucket:     query.Bucket.String(),
+		ErrorCodes: []ErrorCodeTrend{},
+	}
+
+	if len(results) == 0 {
+		return response, nil
+	}
+
+	trends := make(map[string]*ErrorCodeTrend, len(results[0].Totals))
+
+	for _, total := range results[0].Totals {
+		trend := &ErrorCodeTrend{
+			ErrorCode:     total.ErrorCode,
+			Events:        total.Events,
+			AffectedNodes: total.AffectedNodes,
+			Buckets:       make([]Bucket, query.bucketCount()),
+		}
+
+		for i := range trend.Buckets {
+			trend.Buckets[i].Start = query.From.Add(time.Duration(i) * query.Bucket)
+		}
+
+		trends[total.ErrorCode] = trend
+	}
+
+	for _, bucket := range results[0].Buckets {
+		trend, ok := trends[bucket.ID.ErrorCode]
+		if !ok || bucket.ID.Bucket < 0 || bucket.ID.Bucket >= len(trend.Buckets) {
+			continue
+		}
+
+		trend.Buckets[bucket.ID.Bucket].Events = bucket.Events
+		trend.Buckets[bucket.ID.Bucket].AffectedNodes = bucket.AffectedNodes
+	}
+
+	for _, trend := range trends {
+		response.ErrorCodes = append(response.ErrorCodes, *trend)
+	}
+
+	sort.Slice(response.ErrorCodes, func(i, j int) bool {
+		if response.ErrorCodes[i].Events != response.ErrorCodes[j].Events {
+			return response.ErrorCodes[i].Events > response.ErrorCodes[j].Events
+		}
+
+		return response.ErrorCodes[i].ErrorCode < response.ErrorCodes[j].ErrorCode
+	})
+
+	return response, nil
+}
+
+// pipeline counts the unhealthy events per error code and bucket, and in total
+// over the range. Events with several error codes count for each of them.
+func pipeline(query Query) []bson.M {
+	from := query.From.Unix()
+	bucketSeconds := int64(query.Bucket / time.Second)
+
+	match := bson.M{
+		"healthevent.agent":     bson.M{"$ne": analyzerAgent},
+		"healthevent.ishealthy": false,
+		timestamp:               bson.M{"$gte": from, "$lt": query.To.Unix()},
+	}
+
+	if len(query.ErrorCodes) > 0 {
+		match["healthevent.errorcode"] = bson.M{"$in": query.ErrorCodes}
+	}
+
+	if len(query.Filter) > 0 {
+		match["$and"] = bson.A{query.Filter}
+	}
+
+	stages := []bson.M{
+		{"$match": match},
+		{"$unwind": "$healthevent.errorcode"},
+	}
+
+	// Drop the other codes of events matching one of the requested codes
+	if len(query.ErrorCodes) > 0 {
+		stages = append(stages, bson.M{"$match": bson.M{"healthevent.errorcode": bson.M{"$in": query.ErrorCodes}}})
+	}
+
+	return append(stages,
+		bson.M{"$project": bson.M{
+			"errorCode": "$healthevent.errorcode",
+			"node":      "$healthevent.nodename",
+			"bucket": bson.M{"$floor": bson.M{"$divide": bson.A{
+				bson.M{"$subtract": bson.A{"$" + timestamp, from}},
+				bucketSeconds,
+			}}},
+		}},
+		bson.M{"$facet": bson.M{
+			"buckets": bson.A{
+				bson.M{"$group": bson.M{
+					"_id":    bson.M{"errorCode": "$errorCode", "bucket": "$bucket"},
+					"events": bson.M{"$sum": 1},
+					"nodes":  bson.M{"$addToSet": "$node"},
+				}},
+				bson.M{"$project": bson.M{"events": 1, "affectedNodes": bson.M{"$size": "$nodes"}}},
+			},
+			"totals": bson.A{
+				bson.M{"$group": bson.M{
+					"_id":    "$errorCode",
+					"events": bson.M{"$sum": 1},
+					"nodes":  bson.M{"$addToSet": "$node"},
+				}},
+				bson.M{"$project": bson.M{"events": 1, "affectedNodes": bson.M{"$size": "$nodes"}}},
+			},
+		}},
+	)
+}
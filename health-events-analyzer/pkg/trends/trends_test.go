@@ -0,0 +1,149 @@
+// Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package trends
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type fakeCollection struct {
+	result   bson.M
+	pipeline []bson.M
+}
+
+func (f *fakeCollection) Aggregate(_ context.Context, pipeline interface{},
+	_ ...*options.AggregateOptions) (*mongo.Cursor, error) {
+	f.pipeline = pipeline.([]bson.M)
+
+	data, err := bson.Marshal(f.result)
+	if err != nil {
+		return nil, err
+	}
+
+	return mongo.NewCursorFromDocuments([]interface{}{bson.Raw(data)}, nil, nil)
+}
+
+var testNow = time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+
+func TestParseQuery(t *testing.T) {
+	query, err := ParseQuery(url.Values{}, testNow)
+	require.NoError(t, err)
+	assert.Equal(t, testNow, query.To)
+	assert.Equal(t, testNow.Add(-7*24*time.Hour), query.From)
+	assert.Equal(t, time.Hour, query.Bucket)
+	assert.Empty(t, query.ErrorCodes)
+
+	query, err = ParseQuery(url.Values{
+		"from":      {"2025-05-01T00:00:00Z"},
+		"to":        {"2025-06-01T00:00:00Z"},
+		"bucket":    {"24h"},
+		"errorCode": {"79", "48"},
+	}, testNow)
+	require.NoError(t, err)
+	assert.Equal(t, 31, query.bucketCount())
+	assert.Equal(t, []string{"79", "48"}, query.ErrorCodes)
+
+	query, err = ParseQuery(url.Values{"query": {`node =~ "h100-*"`}}, testNow)
+	require.NoError(t, err)
+	assert.Contains(t, query.Filter, "healthevent.nodename")
+	assert.Equal(t, bson.A{query.Filter}, pipeline(query)[0]["$match"].(bson.M)["$and"])
+
+	invalid := []url.Values{
+		{"query": {`node = `}},
+		{"from": {"yesterday"}},
+		{"bucket": {"30s"}},
+		{"bucket": {"90500ms"}},
+		{"from": {"2025-06-02T00:00:00Z"}},
+		{"from": {"2024-01-01T00:00:00Z"}, "bucket": {"1h"}},
+	}
+
+	for _, values := range invalid {
+		_, err := ParseQuery(values, testNow)
+		assert.Error(t, err, values)
+	}
+}
+
+func TestTrends(t *testing.T) {
+	collection := &fakeCollection{result: bson.M{
+		"buckets": bson.A{
+			bson.M{"_id": bson.M{"errorCode": "79", "bucket": 0.0}, "events": 3, "affectedNodes": 2},
+			bson.M{"_id": bson.M{"errorCode": "79", "bucket": 2.0}, "events": 1, "affectedNodes": 1},
+			bson.M{"_id": bson.M{"errorCode": "48", "bucket": 1.0}, "events": 2, "affectedNodes": 1},
+		},
+		"totals": bson.A{
+			bson.M{"_id": "48", "events": 2, "affectedNodes": 1},
+			bson.M{"_id": "79", "events": 4, "affectedNodes": 3},
+		},
+	}}
+
+	query := Query{From: testNow.Add(-3 * time.Hour), To: testNow, Bucket: time.Hour}
+
+	response, err := NewHandler(collection).Trends(context.Background(), query)
+	require.NoError(t, err)
+	assert.Equal(t, "1h0m0s", response.Bucket)
+	require.Len(t, response.ErrorCodes, 2)
+
+	xid79 := response.ErrorCodes[0]
+	assert.Equal(t, "79", xid79.ErrorCode)
+	assert.Equal(t, 4, xid79.Events)
+	assert.Equal(t, 3, xid79.AffectedNodes)
+	assert.Equal(t, []Bucket{
+		{Start: testNow.Add(-3 * time.Hour), Events: 3, AffectedNodes: 2},
+		{Start: testNow.Add(-2 * time.Hour)},
+		{Start: testNow.Add(-1 * time.Hour), Events: 1, AffectedNodes: 1},
+	}, xid79.Buckets)
+
+	assert.Equal(t, "48", response.ErrorCodes[1].ErrorCode)
+	assert.Equal(t, 2, response.ErrorCodes[1].Buckets[1].Events)
+
+	match := collection.pipeline[0]["$match"].(bson.M)
+	assert.Equal(t, bson.M{"$gte": query.From.Unix(), "$lt": query.To.Unix()}, match[timestamp])
+	assert.NotContains(t, match, "healthevent.errorcode")
+}
+
+func TestServeHTTP(t *testing.T) {
+	collection := &fakeCollection{result: bson.M{"buckets": bson.A{}, "totals": bson.A{}}}
+	handler := NewHandler(collection)
+	handler.now = func() time.Time { return testNow }
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/trends?errorCode=79&bucket=6h", nil))
+	require.Equal(t, http.StatusOK, rec.Code)
+
+	var response Response
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &response))
+	assert.Equal(t, "6h0m0s", response.Bucket)
+	assert.Empty(t, response.ErrorCodes)
+
+	// The requested codes are matched before and after unwinding
+	assert.Equal(t, bson.M{"$in": []string{"79"}}, collection.pipeline[0]["$match"].(bson.M)["healthevent.errorcode"])
+	assert.Contains(t, collection.pipeline[2], "$match")
+
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/trends?bucket=1s", nil))
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+}
@@ -0,0 +1,68 @@
+// Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package versionskew
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+var (
+	agentVersions = promauto.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "health_event_analyzer_agent_versions",
+			Help: "Number of nodes running each version of an agent, from the last heartbeats.",
+		},
+		[]string{"agent", "version"},
+	)
+	rulePackVersions = promauto.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "health_event_analyzer_rule_pack_versions",
+			Help: "Number of nodes using each version of a rule pack, from the last heartbeats.",
+		},
+		[]string{"rule_pack", "version"},
+	)
+	versionSkewNodes = promauto.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "health_event_analyzer_version_skew_nodes",
+			Help: "Number of nodes where an agent runs an unsupported version, " +
+				"uses a stale rule pack or stopped sending heartbeats.",
+		},
+		[]string{"agent", "reason"},
+	)
+)
+
+// updateMetrics replaces the gauges with the counts of the summary.
+func updateMetrics(summary *Summary) {
+	agentVersions.Reset()
+	rulePackVersions.Reset()
+	versionSkewNodes.Reset()
+
+	for _, agent := range summary.Agents {
+		for version, nodes := range agent.Versions {
+			agentVersions.WithLabelValues(agent.Agent, version).Set(float64(nodes))
+		}
+	}
+
+	for _, pack := range summary.RulePacks {
+		for version, nodes := range pack.Versions {
+			rulePackVersions.WithLabelValues(pack.RulePack, version).Set(float64(nodes))
+		}
+	}
+
+	for _, issue := range summary.Issues {
+		versionSkewNodes.WithLabelValues(issue.Agent, string(issue.Reason)).Inc()
+	}
+}
@@ -0,0 +1,357 @@
+// Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package versionskew flags agents that run an unsupported version, use a stale
+// rule pack or stopped sending heartbeats, and serves the versions reported by the
+// agents as the cluster summary.
+package versionskew
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/nvidia/nvsentinel/data-models/pkg/model"
+	"github.com/nvidia/nvsentinel/health-events-analyzer/pkg/config"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"golang.org/x/mod/semver"
+)
+
+const (
+	// PathPrefix is where the cluster summary is served
+	PathPrefix = "/summary"
+
+	queryTimeout = 30 * time.Second
+)
+
+// Reason is why an agent is flagged.
+type Reason string
+
+const (
+	// ReasonUnsupportedVersion flags agents older than their minimum version, or
+	// whose version is not a semantic version while a minimum is configured.
+	ReasonUnsupportedVersion Reason = "UnsupportedVersion"
+	// ReasonStaleRulePack flags rule packs older than their minimum version, or
+	// than the newest version of the pack in the cluster.
+	ReasonStaleRulePack Reason = "StaleRulePack"
+	// ReasonMissingHeartbeat flags agents that stopped sending heartbeats.
+	ReasonMissingHeartbeat Reason = "MissingHeartbeat"
+)
+
+// Finder lists the documents of the heartbeat collection.
+type Finder interface {
+	Find(ctx context.Context, filter interface{}, opts ...*options.FindOptions) (*mongo.Cursor, error)
+}
+
+// Issue is an agent flagged on a node.
+type Issue struct {
+	NodeName string `json:"nodeName"`
+	Agent    string `json:"agent"`
+	Reason   Reason `json:"reason"`
+	Detail   string `json:"detail"`
+}
+
+// AgentSummary counts the nodes per version of an agent.
+type AgentSummary struct {
+	Agent      string         `json:"agent"`
+	MinVersion string         `json:"minVersion,omitempty"`
+	Versions   map[string]int `json:"versions"`
+}
+
+// RulePackSummary counts the nodes per version of a rule pack.
+type RulePackSummary struct {
+	RulePack      string         `json:"rulePack"`
+	MinVersion    string         `json:"minVersion,omitempty"`
+	LatestVersion string         `json:"latestVersion,omitempty"`
+	Versions      map[string]int `json:"versions"`
+}
+
+// Summary is the cluster summary served by the checker. Agents whose heartbeat is
+// missing are reported as issues and not counted in the versions.
+type Summary struct {
+	GeneratedAt time.Time         `json:"generatedAt"`
+	Nodes       int               `json:"nodes"`
+	Agents      []AgentSummary    `json:"agents"`
+	RulePacks   []RulePackSummary `json:"rulePacks"`
+	Issues      []Issue           `json:"issues"`
+}
+
+// Checker periodically checks the heartbeats against the configuration.
+type Checker struct {
+	collection Finder
+	config     *config.VersionSkew
+	now        func() time.Time
+
+	mu      sync.RWMutex
+	summary *Summary
+}
+
+// NewChecker creates a Checker of the heartbeats in collection.
+func NewChecker(collection Finder, cfg *config.VersionSkew) *Checker {
+	return &Checker{collection: collection, config: cfg, now: time.Now}
+}
+
+// Run checks the heartbeats every interval until ctx is done. Failed checks are
+// logged and retried on the next interval.
+func (c *Checker) Run(ctx context.Context) error {
+	ticker := time.NewTicker(c.config.IntervalDuration())
+	defer ticker.Stop()
+
+	for {
+		if _, err := c.Check(ctx); err != nil {
+			slog.Error("Failed to check agent versions", "error", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// Check evaluates the current heartbeats and updates the metrics and the served
+// summary.
+func (c *Checker) Check(ctx context.Context) (*Summary, error) {
+	cursor, err := c.collection.Find(ctx, bson.M{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list heartbeats: %w", err)
+	}
+
+	defer cursor.Close(ctx)
+
+	var heartbeats []model.AgentHeartbeat
+	if err := cursor.All(ctx, &heartbeats); err != nil {
+		return nil, fmt.Errorf("failed to decode heartbeats: %w", err)
+	}
+
+	summary := Evaluate(heartbeats, c.config, c.now())
+
+	for _, issue := range summary.Issues {
+		slog.Warn("Agent version skew", "node", issue.NodeName, "agent", issue.Agent,
+			"reason", issue.Reason, "detail", issue.Detail)
+	}
+
+	updateMetrics(summary)
+
+	c.mu.Lock()
+	c.summary = summary
+	c.mu.Unlock()
+
+	return summary, nil
+}
+
+// ServeHTTP serves GET /summary, the summary of the last check.
+func (c *Checker) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+
+	c.mu.RLock()
+	summary := c.summary
+	c.mu.RUnlock()
+
+	if summary == nil {
+		ctx, cancel := context.WithTimeout(r.Context(), queryTimeout)
+		defer cancel()
+
+		var err error
+		if summary, err = c.Check(ctx); err != nil {
+			slog.Error("Failed to build cluster summary", "error", err)
+			http.Error(w, "failed to build cluster summary", http.StatusInternalServerError)
+
+			return
+		}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+
+	if err := json.NewEncoder(w).Encode(summary); err != nil {
+		slog.Error("Failed to encode cluster summary", "error", err)
+	}
+}
+
+// Evaluate builds the summary of the heartbeats at now.
+func Evaluate(heartbeats []model.AgentHeartbeat, cfg *config.VersionSkew, now time.Time) *Summary {
+	summary := &Summary{
+		GeneratedAt: now.UTC(),
+		Agents:      []AgentSummary{},
+		RulePacks:   []RulePackSummary{},
+		Issues:      []Issue{},
+	}
+
+	staleAfter := cfg.StaleAfterDuration()
+	nodes := map[string]bool{}
+	agents := map[string]*AgentSummary{}
+	packs := map[string]*RulePackSummary{}
+
+	var current []model.AgentHeartbeat
+
+	for _, heartbeat := range heartbeats {
+		if now.Sub(heartbeat.LastSeen) > staleAfter {
+			summary.Issues = append(summary.Issues, Issue{
+				NodeName: heartbeat.NodeName,
+				Agent:    heartbeat.Agent,
+				Reason:   ReasonMissingHeartbeat,
+				Detail:   fmt.Sprintf("last heartbeat at %s", heartbeat.LastSeen.UTC().Format(time.RFC3339)),
+			})
+
+			continue
+		}
+
+		current = append(current, heartbeat)
+		nodes[heartbeat.NodeName] = true
+
+		agent, ok := agents[heartbeat.Agent]
+		if !ok {
+			agent = &AgentSummary{
+				Agent:      heartbeat.Agent,
+				MinVersion: cfg.MinAgentVersions[heartbeat.Agent],
+				Versions:   map[string]int{},
+			}
+			agents[heartbeat.Agent] = agent
+		}
+
+		agent.Versions[heartbeat.Version]++
+
+		if heartbeat.RulePack == "" {
+			continue
+		}
+
+		pack, ok := packs[heartbeat.RulePack]
+		if !ok {
+			pack = &RulePackSummary{
+				RulePack:   heartbeat.RulePack,
+				MinVersion: cfg.MinRulePackVersions[heartbeat.RulePack],
+				Versions:   map[string]int{},
+			}
+			packs[heartbeat.RulePack] = pack
+		}
+
+		pack.Versions[heartbeat.RulePackVersion]++
+
+		if version, ok := config.ParseVersion(heartbeat.RulePackVersion); ok {
+			if latest, _ := config.ParseVersion(pack.LatestVersion); semver.Compare(version, latest) > 0 {
+				pack.LatestVersion = heartbeat.RulePackVersion
+			}
+		}
+	}
+
+	for _, heartbeat := range current {
+		if issue, ok := checkAgent(heartbeat, agents[heartbeat.Agent]); ok {
+			summary.Issues = append(summary.Issues, issue)
+		}
+
+		if heartbeat.RulePack == "" {
+			continue
+		}
+
+		if issue, ok := checkRulePack(heartbeat, packs[heartbeat.RulePack]); ok {
+			summary.Issues = append(summary.Issues, issue)
+		}
+	}
+
+	summary.Nodes = len(nodes)
+
+	for _, agent := range agents {
+		summary.Agents = append(summary.Agents, *agent)
+	}
+
+	for _, pack := range packs {
+		summary.RulePacks = append(summary.RulePacks, *pack)
+	}
+
+	sort.Slice(summary.Agents, func(i, j int) bool { return summary.Agents[i].Agent < summary.Agents[j].Agent })
+	sort.Slice(summary.RulePacks, func(i, j int) bool {
+		return summary.RulePacks[i].RulePack < summary.RulePacks[j].RulePack
+	})
+	sort.Slice(summary.Issues, func(i, j int) bool {
+		if summary.Issues[i].NodeName != summary.Issues[j].NodeName {
+			return summary.Issues[i].NodeName < summary.Issues[j].NodeName
+		}
+
+		if summary.Issues[i].Agent != summary.Issues[j].Agent {
+			return summary.Issues[i].Agent < summary.Issues[j].Agent
+		}
+
+		return summary.Issues[i].Reason < summary.Issues[j].Reason
+	})
+
+	return summary
+}
+
+// checkAgent flags the agent when it runs a version older than its minimum.
+func checkAgent(heartbeat model.AgentHeartbeat, agent *AgentSummary) (Issue, bool) {
+	if agent.MinVersion == "" {
+		return Issue{}, false
+	}
+
+	issue := Issue{NodeName: heartbeat.NodeName, Agent: heartbeat.Agent, Reason: ReasonUnsupportedVersion}
+
+	version, ok := config.ParseVersion(heartbeat.Version)
+	if !ok {
+		issue.Detail = fmt.Sprintf("version %q is not a semantic version, minimum is %s",
+			heartbeat.Version, agent.MinVersion)
+		return issue, true
+	}
+
+	// Validate guarantees a parsable minimum
+	minVersion, _ := config.ParseVersion(agent.MinVersion)
+	if semver.Compare(version, minVersion) >= 0 {
+		return Issue{}, false
+	}
+
+	issue.Detail = fmt.Sprintf("version %s is older than the minimum %s", heartbeat.Version, agent.MinVersion)
+
+	return issue, true
+}
+
+// checkRulePack flags the rule pack of the agent when it is older than its
+// minimum version, or than the newest version of the pack without a minimum.
+func checkRulePack(heartbeat model.AgentHeartbeat, pack *RulePackSummary) (Issue, bool) {
+	expected := pack.MinVersion
+	if expected == "" {
+		expected = pack.LatestVersion
+	}
+
+	if expected == "" {
+		return Issue{}, false
+	}
+
+	issue := Issue{NodeName: heartbeat.NodeName, Agent: heartbeat.Agent, Reason: ReasonStaleRulePack}
+
+	version, ok := config.ParseVersion(heartbeat.RulePackVersion)
+	if !ok {
+		issue.Detail = fmt.Sprintf("rule pack %s has no version, expected %s", heartbeat.RulePack, expected)
+		return issue, true
+	}
+
+	expectedVersion, _ := config.ParseVersion(expected)
+	if semver.Compare(version, expectedVersion) >= 0 {
+		return Issue{}, false
+	}
+
+	issue.Detail = fmt.Sprintf("rule pack %s version %s is older than %s",
+		heartbeat.RulePack, heartbeat.RulePackVersion, expected)
+
+	return issue, true
+}
@@ -0,0 +1,140 @@
+// Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package versionskew
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/nvidia/nvsentinel/data-models/pkg/model"
+	"github.com/nvidia/nvsentinel/health-events-analyzer/pkg/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type fakeCollection struct {
+	heartbeats []model.AgentHeartbeat
+}
+
+func (f *fakeCollection) Find(_ context.Context, _ interface{}, _ ...*options.FindOptions) (*mongo.Cursor, error) {
+	documents := make([]interface{}, 0, len(f.heartbeats))
+
+	for _, heartbeat := range f.heartbeats {
+		data, err := bson.Marshal(heartbeat)
+		if err != nil {
+			return nil, err
+		}
+
+		documents = append(documents, bson.Raw(data))
+	}
+
+	return mongo.NewCursorFromDocuments(documents, nil, nil)
+}
+
+var testNow = time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+
+func testConfig(t *testing.T) *config.VersionSkew {
+	t.Helper()
+
+	cfg := &config.VersionSkew{
+		MinAgentVersions:    map[string]string{"syslog-health-monitor": "v0.6.0"},
+		MinRulePackVersions: map[string]string{"H100": "2"},
+	}
+	require.NoError(t, cfg.Validate())
+
+	return cfg
+}
+
+func heartbeat(node, version, pack, packVersion string, age time.Duration) model.AgentHeartbeat {
+	return model.AgentHeartbeat{
+		NodeName:        node,
+		Agent:           "syslog-health-monitor",
+		Version:         version,
+		RulePack:        pack,
+		RulePackVersion: packVersion,
+		LastSeen:        testNow.Add(-age),
+	}
+}
+
+func TestEvaluate(t *testing.T) {
+	summary := Evaluate([]model.AgentHeartbeat{
+		heartbeat("node-1", "v0.6.1", "GB200", "3", time.Minute),
+		heartbeat("node-2", "v0.5.9", "GB200", "2", time.Minute),
+		heartbeat("node-3", "dev", "H100", "1", time.Minute),
+		heartbeat("node-4", "v0.6.0", "H100", "2", time.Hour),
+		{NodeName: "node-1", Agent: "gpu-health-monitor", Version: "v0.1.0", LastSeen: testNow},
+	}, testConfig(t), testNow)
+
+	assert.Equal(t, 3, summary.Nodes)
+
+	require.Len(t, summary.Agents, 2)
+	assert.Equal(t, AgentSummary{Agent: "gpu-health-monitor", Versions: map[string]int{"v0.1.0": 1}}, summary.Agents[0])
+	assert.Equal(t, AgentSummary{
+		Agent:      "syslog-health-monitor",
+		MinVersion: "v0.6.0",
+		Versions:   map[string]int{"v0.6.1": 1, "v0.5.9": 1, "dev": 1},
+	}, summary.Agents[1])
+
+	require.Len(t, summary.RulePacks, 2)
+	assert.Equal(t, RulePackSummary{
+		RulePack:      "GB200",
+		LatestVersion: "3",
+		Versions:      map[string]int{"3": 1, "2": 1},
+	}, summary.RulePacks[0])
+	assert.Equal(t, "2", summary.RulePacks[1].MinVersion)
+
+	reasons := map[string][]Reason{}
+	for _, issue := range summary.Issues {
+		reasons[issue.NodeName] = append(reasons[issue.NodeName], issue.Reason)
+	}
+
+	assert.Equal(t, map[string][]Reason{
+		// older agent, and older than the newest GB200 pack in the cluster
+		"node-2": {ReasonStaleRulePack, ReasonUnsupportedVersion},
+		// not a semantic version, and below the minimum H100 pack
+		"node-3": {ReasonStaleRulePack, ReasonUnsupportedVersion},
+		"node-4": {ReasonMissingHeartbeat},
+	}, reasons)
+}
+
+func TestCheckerServeHTTP(t *testing.T) {
+	checker := NewChecker(&fakeCollection{heartbeats: []model.AgentHeartbeat{
+		heartbeat("node-1", "v0.5.0", "", "", time.Minute),
+	}}, testConfig(t))
+	checker.now = func() time.Time { return testNow }
+
+	// the first request checks when no check ran yet
+	rec := httptest.NewRecorder()
+	checker.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, PathPrefix, nil))
+	require.Equal(t, http.StatusOK, rec.Code)
+
+	var summary Summary
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &summary))
+	assert.Equal(t, 1, summary.Nodes)
+	assert.Empty(t, summary.RulePacks)
+	require.Len(t, summary.Issues, 1)
+	assert.Equal(t, ReasonUnsupportedVersion, summary.Issues[0].Reason)
+
+	rec = httptest.NewRecorder()
+	checker.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, PathPrefix, nil))
+	assert.Equal(t, http.StatusMethodNotAllowed, rec.Code)
+}
@@ -165,6 +165,7 @@ func run() error {
 	server := srv.NewServer(
 		srv.WithPort(portInt),
 		srv.WithPrometheusMetrics(),
+		srv.WithHandler(logger.LevelsPath, logger.LevelsHandler()),
 		srv.WithSimpleHealth(),
 	)
 
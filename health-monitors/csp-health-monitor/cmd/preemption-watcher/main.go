@@ -0,0 +1,162 @@
+// Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"log/slog"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/nvidia/nvsentinel/commons/pkg/cloudmetadata"
+	"github.com/nvidia/nvsentinel/commons/pkg/logger"
+	srv "github.com/nvidia/nvsentinel/commons/pkg/server"
+	pb "github.com/nvidia/nvsentinel/data-models/pkg/protos"
+	"github.com/nvidia/nvsentinel/health-monitors/csp-health-monitor/pkg/preemption"
+
+	"golang.org/x/sync/errgroup"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+)
+
+const (
+	defaultUdsPath      = "/run/nvsentinel/nvsentinel.sock"
+	defaultMetricsPort  = 2114
+	defaultPollInterval = 5 * time.Second
+)
+
+var (
+	// These variables will be populated during the build process
+	version = "dev"
+	commit  = "none"
+	date    = "unknown"
+)
+
+type appConfig struct {
+	udsPath         string
+	metricsPort     int
+	nodeName        string
+	pollInterval    time.Duration
+	metadataTimeout time.Duration
+	spotOnly        bool
+	annotateNode    bool
+}
+
+func parseFlags() *appConfig {
+	cfg := &appConfig{}
+
+	flag.StringVar(&cfg.udsPath, "uds-path", defaultUdsPath, "Path to the Platform Connector UDS socket.")
+	flag.IntVar(&cfg.metricsPort, "metrics-port", defaultMetricsPort, "Port for the Prometheus metrics.")
+	flag.StringVar(&cfg.nodeName, "node-name", os.Getenv("NODE_NAME"),
+		"Name of the node the watcher runs on (defaults to $NODE_NAME).")
+	flag.DurationVar(&cfg.pollInterval, "poll-interval", defaultPollInterval,
+		"Interval between preemption notice queries of the instance metadata service.")
+	flag.DurationVar(&cfg.metadataTimeout, "metadata-timeout", cloudmetadata.DefaultTimeout,
+		"Timeout of a single instance metadata service query.")
+	flag.BoolVar(&cfg.spotOnly, "spot-only", true,
+		"Only watch nodes labelled as spot or preemptible capacity.")
+	flag.BoolVar(&cfg.annotateNode, "annotate-node", true,
+		"Annotate the node once a preemption notice is received so that remediation skips it.")
+
+	flag.Parse()
+
+	return cfg
+}
+
+func main() {
+	logger.SetDefaultStructuredLogger("preemption-watcher", version)
+	slog.Info("Starting preemption-watcher", "version", version, "commit", commit, "date", date)
+
+	if err := run(); err != nil {
+		slog.Error("Fatal error", "error", err)
+		os.Exit(1)
+	}
+}
+
+func run() error {
+	cfg := parseFlags()
+
+	if cfg.nodeName == "" {
+		return errors.New("node name is required, set --node-name or NODE_NAME")
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	restCfg, err := rest.InClusterConfig()
+	if err != nil {
+		return fmt.Errorf("failed to obtain in-cluster Kubernetes config: %w", err)
+	}
+
+	k8sClient, err := kubernetes.NewForConfig(restCfg)
+	if err != nil {
+		return fmt.Errorf("failed to create Kubernetes clientset: %w", err)
+	}
+
+	conn, err := grpc.NewClient("unix:"+cfg.udsPath, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		return fmt.Errorf("failed to dial Platform Connector UDS %s: %w", cfg.udsPath, err)
+	}
+
+	defer func() {
+		if errClose := conn.Close(); errClose != nil {
+			slog.Error("Error closing UDS connection", "error", errClose)
+		}
+	}()
+
+	watcher := preemption.NewWatcher(preemption.Config{
+		NodeName:     cfg.nodeName,
+		PollInterval: cfg.pollInterval,
+		SpotOnly:     cfg.spotOnly,
+		AnnotateNode: cfg.annotateNode,
+	}, cloudmetadata.NewClient(cfg.metadataTimeout), pb.NewPlatformConnectorClient(conn), k8sClient)
+
+	server := srv.NewServer(
+		srv.WithPort(cfg.metricsPort),
+		srv.WithPrometheusMetrics(),
+		srv.WithHandler(logger.LevelsPath, logger.LevelsHandler()),
+		srv.WithSimpleHealth(),
+	)
+
+	g, gCtx := errgroup.WithContext(ctx)
+
+	// Metrics server failures are logged but do NOT terminate the watcher.
+	g.Go(func() error {
+		if err := server.Serve(gCtx); err != nil {
+			slog.Error("Metrics server failed - continuing without metrics", "error", err)
+		}
+
+		return nil
+	})
+
+	g.Go(func() error {
+		return watcher.Start(gCtx)
+	})
+
+	if err := g.Wait(); err != nil {
+		return fmt.Errorf("service error: %w", err)
+	}
+
+	slog.Info("Preemption watcher shut down.")
+
+	return nil
+}
@@ -243,3 +243,28 @@ var (
 		[]string{"node_name"}, // Track which nodes are being monitored
 	)
 )
+
+// --- Preemption Watcher (DaemonSet) Metrics ---
+
+var (
+	PreemptionPollErrors = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "csp_health_monitor_preemption_poll_errors_total",
+			Help: "Total number of errors querying the instance metadata service for preemption notices.",
+		},
+		[]string{"csp"}, // aws, gcp, azure
+	)
+	PreemptionNoticesReceived = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "csp_health_monitor_preemption_notices_received_total",
+			Help: "Total number of spot/preemptible interruption notices received.",
+		},
+		[]string{"csp"}, // aws, gcp, azure
+	)
+	PreemptionEventSendErrors = promauto.NewCounter(
+		prometheus.CounterOpts{
+			Name: "csp_health_monitor_preemption_event_send_errors_total",
+			Help: "Total number of errors sending PREEMPTION_IMMINENT events via UDS.",
+		},
+	)
+)
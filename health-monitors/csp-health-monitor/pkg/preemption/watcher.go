@@ -0,0 +1,280 @@
+// Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package preemption watches the instance metadata service of a spot or
+// preemptible node for interruption notices. A notice gives between 30 seconds
+// (GCP, Azure) and two minutes (AWS) of warning, so the watcher runs on every
+// node and reports the notice as a PREEMPTION_IMMINENT health event that is
+// drained immediately instead of going through the maintenance poll loop.
+package preemption
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/nvidia/nvsentinel/commons/pkg/cloudmetadata"
+	datamodels "github.com/nvidia/nvsentinel/data-models/pkg/model"
+	pb "github.com/nvidia/nvsentinel/data-models/pkg/protos"
+	"github.com/nvidia/nvsentinel/health-monitors/csp-health-monitor/pkg/metrics"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/kubernetes"
+)
+
+const (
+	// CheckName is the check name of the events sent by the watcher
+	CheckName = "PreemptionImminent"
+
+	agentName      = "csp-health-monitor"
+	componentClass = "Node"
+
+	udsMaxRetries = 3
+	udsRetryDelay = time.Second
+)
+
+// NoticeSource returns the pending preemption notice of the local instance.
+type NoticeSource interface {
+	PreemptionNotice(ctx context.Context, provider cloudmetadata.Provider) (*cloudmetadata.PreemptionNotice, error)
+}
+
+// Config configures a Watcher.
+type Config struct {
+	// NodeName is the Kubernetes node the watcher runs on
+	NodeName string
+	// PollInterval is how often the metadata service is queried
+	PollInterval time.Duration
+	// SpotOnly restricts watching to nodes labelled as spot or preemptible
+	SpotOnly bool
+	// AnnotateNode sets cloudmetadata.PreemptionImminentAnnotation on the node
+	// once a notice is received
+	AnnotateNode bool
+}
+
+// Watcher polls for a preemption notice of its node and reports it once.
+type Watcher struct {
+	cfg       Config
+	source    NoticeSource
+	udsClient pb.PlatformConnectorClient
+	k8sClient kubernetes.Interface
+	instance  *cloudmetadata.Instance
+}
+
+// NewWatcher constructs a Watcher.
+func NewWatcher(
+	cfg Config,
+	source NoticeSource,
+	udsClient pb.PlatformConnectorClient,
+	k8sClient kubernetes.Interface,
+) *Watcher {
+	return &Watcher{
+		cfg:       cfg,
+		source:    source,
+		udsClient: udsClient,
+		k8sClient: k8sClient,
+	}
+}
+
+// Start resolves the cloud instance of the node and polls for a notice until
+// it was reported or the context is cancelled. Nodes that cannot be preempted
+// are not polled; Start then blocks until the context is cancelled so that the
+// DaemonSet pod does not restart.
+func (w *Watcher) Start(ctx context.Context) error {
+	watch, err := w.resolveInstance(ctx)
+	if err != nil {
+		return err
+	}
+
+	if watch {
+		slog.Info("Watching for preemption notices",
+			"node", w.cfg.NodeName,
+			"csp", w.instance.Provider,
+			"instanceID", w.instance.InstanceID,
+			"pollInterval", w.cfg.PollInterval)
+
+		ticker := time.NewTicker(w.cfg.PollInterval)
+		defer ticker.Stop()
+
+		for done := w.poll(ctx); !done; done = w.poll(ctx) {
+			select {
+			case <-ctx.Done():
+				return nil
+			case <-ticker.C:
+			}
+		}
+	}
+
+	<-ctx.Done()
+
+	return nil
+}
+
+// resolveInstance derives the cloud instance from the node and reports
+// whether the node should be watched.
+func (w *Watcher) resolveInstance(ctx context.Context) (bool, error) {
+	node, err := w.k8sClient.CoreV1().Nodes().Get(ctx, w.cfg.NodeName, metav1.GetOptions{})
+	if err != nil {
+		return false, fmt.Errorf("failed to get node %s: %w", w.cfg.NodeName, err)
+	}
+
+	w.instance = cloudmetadata.FromNode(node.Spec.ProviderID, node.Labels, nil)
+
+	switch {
+	case w.instance == nil:
+		slog.Info("Node does not run on a supported cloud, not watching for preemption",
+			"node", w.cfg.NodeName, "providerID", node.Spec.ProviderID)
+
+		return false, nil
+	case w.instance.Provider == cloudmetadata.ProviderOCI:
+		slog.Info("Preemption notices are not supported on OCI", "node", w.cfg.NodeName)
+
+		return false, nil
+	case w.cfg.SpotOnly && !cloudmetadata.IsSpotNode(node.Labels):
+		slog.Info("Node is not spot or preemptible capacity, not watching for preemption",
+			"node", w.cfg.NodeName)
+
+		return false, nil
+	}
+
+	return true, nil
+}
+
+// poll queries for a notice and reports it. It returns true once a notice was
+// reported.
+func (w *Watcher) poll(ctx context.Context) bool {
+	csp := string(w.instance.Provider)
+
+	notice, err := w.source.PreemptionNotice(ctx, w.instance.Provider)
+	if err != nil {
+		metrics.PreemptionPollErrors.WithLabelValues(csp).Inc()
+		slog.Warn("Failed to query preemption notice", "node", w.cfg.NodeName, "error", err)
+
+		return false
+	}
+
+	if notice == nil {
+		return false
+	}
+
+	metrics.PreemptionNoticesReceived.WithLabelValues(csp).Inc()
+	slog.Warn("Received preemption notice",
+		"node", w.cfg.NodeName,
+		"csp", csp,
+		"action", notice.Action,
+		"time", notice.Time)
+
+	if w.cfg.AnnotateNode {
+		if err := w.annotateNode(ctx, notice); err != nil {
+			slog.Error("Failed to annotate node with preemption notice", "node", w.cfg.NodeName, "error", err)
+		}
+	}
+
+	if err := w.sendHealthEventWithRetry(ctx, w.healthEvent(notice)); err != nil {
+		metrics.PreemptionEventSendErrors.Inc()
+		slog.Error("Failed to send preemption event, retrying on next poll", "node", w.cfg.NodeName, "error", err)
+
+		return false
+	}
+
+	return true
+}
+
+// healthEvent maps a notice to a fatal event that is drained right away.
+func (w *Watcher) healthEvent(notice *cloudmetadata.PreemptionNotice) *pb.HealthEvent {
+	metadata := map[string]string{}
+	w.instance.AddMetadata(metadata)
+	notice.AddMetadata(metadata)
+
+	message := fmt.Sprintf("%s preemption notice (%s): instance is reclaimed", notice.Provider, notice.Action)
+	if !notice.Time.IsZero() {
+		message += " at " + notice.Time.UTC().Format(time.RFC3339)
+	}
+
+	return &pb.HealthEvent{
+		Agent:             agentName,
+		ComponentClass:    componentClass,
+		CheckName:         CheckName,
+		IsFatal:           true,
+		IsHealthy:         false,
+		Message:           message,
+		RecommendedAction: pb.RecommendedAction_PREEMPTION_IMMINENT,
+		EntitiesImpacted: []*pb.Entity{
+			{
+				EntityType:  "instance",
+				EntityValue: w.instance.InstanceID,
+			},
+		},
+		Metadata:           metadata,
+		NodeName:           w.cfg.NodeName,
+		GeneratedTimestamp: timestamppb.New(time.Now()),
+		// There is no time to wait for workloads to finish on their own
+		DrainOverrides: &pb.BehaviourOverrides{Force: true},
+		Priority:       pb.Priority_PRIORITY_HIGH,
+	}
+}
+
+func (w *Watcher) annotateNode(ctx context.Context, notice *cloudmetadata.PreemptionNotice) error {
+	value := ""
+	if !notice.Time.IsZero() {
+		value = notice.Time.UTC().Format(time.RFC3339)
+	}
+
+	patch := []byte(fmt.Sprintf(`{"metadata":{"annotations":{%q:%q}}}`,
+		cloudmetadata.PreemptionImminentAnnotation, value))
+
+	_, err := w.k8sClient.CoreV1().Nodes().Patch(ctx, w.cfg.NodeName, types.MergePatchType, patch, metav1.PatchOptions{})
+
+	return err
+}
+
+func (w *Watcher) sendHealthEventWithRetry(ctx context.Context, healthEvent *pb.HealthEvent) error {
+	// Assigned once so that retries of the event keep its ID
+	datamodels.AssignEventID(healthEvent)
+
+	backoff := wait.Backoff{
+		Steps:    udsMaxRetries,
+		Duration: udsRetryDelay,
+		Factor:   1.5,
+		Jitter:   0.1,
+	}
+
+	var lastErr error
+
+	err := wait.ExponentialBackoffWithContext(ctx, backoff, func(ctx context.Context) (bool, error) {
+		_, lastErr = w.udsClient.HealthEventOccurredV1(ctx, &pb.HealthEvents{
+			Events: []*pb.HealthEvent{healthEvent},
+		})
+		if lastErr == nil {
+			return true, nil
+		}
+
+		if st, ok := status.FromError(lastErr); ok && st.Code() == codes.Unavailable {
+			slog.Warn("Retryable error sending preemption event via UDS. Retrying...", "error", lastErr)
+			return false, nil
+		}
+
+		return false, lastErr
+	})
+	if err != nil && lastErr != nil {
+		return lastErr
+	}
+
+	return err
+}
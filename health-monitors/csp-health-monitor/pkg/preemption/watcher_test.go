@@ -0,0 +1,161 @@
+// Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package preemption
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/nvidia/nvsentinel/commons/pkg/cloudmetadata"
+	pb "github.com/nvidia/nvsentinel/data-models/pkg/protos"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/types/known/emptypb"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+const testNodeName = "spot-node"
+
+type fakeSource struct {
+	mu     sync.Mutex
+	notice *cloudmetadata.PreemptionNotice
+	polls  int
+}
+
+func (f *fakeSource) PreemptionNotice(
+	context.Context, cloudmetadata.Provider,
+) (*cloudmetadata.PreemptionNotice, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.polls++
+
+	return f.notice, nil
+}
+
+type fakeUDSClient struct {
+	mu     sync.Mutex
+	events []*pb.HealthEvent
+}
+
+func (f *fakeUDSClient) HealthEventOccurredV1(
+	_ context.Context, in *pb.HealthEvents, _ ...grpc.CallOption,
+) (*emptypb.Empty, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.events = append(f.events, in.Events...)
+
+	return &emptypb.Empty{}, nil
+}
+
+func (f *fakeUDSClient) sent() []*pb.HealthEvent {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	return append([]*pb.HealthEvent(nil), f.events...)
+}
+
+func testNode(labels map[string]string) *corev1.Node {
+	return &corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{Name: testNodeName, Labels: labels},
+		Spec:       corev1.NodeSpec{ProviderID: "aws:///us-east-1a/i-0123456789abcdef0"},
+	}
+}
+
+func TestWatcherReportsNotice(t *testing.T) {
+	k8sClient := fake.NewSimpleClientset(testNode(map[string]string{"karpenter.sh/capacity-type": "spot"}))
+	reclaim := time.Date(2025, 6, 1, 8, 22, 0, 0, time.UTC)
+	source := &fakeSource{notice: &cloudmetadata.PreemptionNotice{
+		Provider: cloudmetadata.ProviderAWS,
+		Action:   "terminate",
+		Time:     reclaim,
+	}}
+	uds := &fakeUDSClient{}
+
+	w := NewWatcher(Config{
+		NodeName:     testNodeName,
+		PollInterval: 10 * time.Millisecond,
+		SpotOnly:     true,
+		AnnotateNode: true,
+	}, source, uds, k8sClient)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error)
+
+	go func() { done <- w.Start(ctx) }()
+
+	require.Eventually(t, func() bool { return len(uds.sent()) == 1 }, time.Second, 5*time.Millisecond)
+
+	// The notice is reported once, the node is gone shortly after
+	time.Sleep(50 * time.Millisecond)
+	cancel()
+	require.NoError(t, <-done)
+
+	events := uds.sent()
+	require.Len(t, events, 1)
+
+	event := events[0]
+	assert.Equal(t, CheckName, event.CheckName)
+	assert.Equal(t, testNodeName, event.NodeName)
+	assert.True(t, event.IsFatal)
+	assert.Equal(t, pb.RecommendedAction_PREEMPTION_IMMINENT, event.RecommendedAction)
+	assert.True(t, event.DrainOverrides.Force)
+	assert.Equal(t, pb.Priority_PRIORITY_HIGH, event.Priority)
+	assert.Equal(t, "i-0123456789abcdef0", event.Metadata[cloudmetadata.MetadataKeyInstanceID])
+	assert.Equal(t, "2025-06-01T08:22:00Z", event.Metadata[cloudmetadata.MetadataKeyPreemptionTime])
+
+	node, err := k8sClient.CoreV1().Nodes().Get(context.Background(), testNodeName, metav1.GetOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, "2025-06-01T08:22:00Z", node.Annotations[cloudmetadata.PreemptionImminentAnnotation])
+}
+
+func TestWatcherSkipsOnDemandNodes(t *testing.T) {
+	k8sClient := fake.NewSimpleClientset(testNode(nil))
+	source := &fakeSource{}
+
+	w := NewWatcher(Config{
+		NodeName:     testNodeName,
+		PollInterval: 10 * time.Millisecond,
+		SpotOnly:     true,
+	}, source, &fakeUDSClient{}, k8sClient)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	require.NoError(t, w.Start(ctx))
+	assert.Zero(t, source.polls)
+}
+
+func TestWatcherNoNotice(t *testing.T) {
+	k8sClient := fake.NewSimpleClientset(testNode(nil))
+	source := &fakeSource{}
+	uds := &fakeUDSClient{}
+
+	w := NewWatcher(Config{NodeName: testNodeName, PollInterval: 10 * time.Millisecond}, source, uds, k8sClient)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	require.NoError(t, w.Start(ctx))
+	assert.Positive(t, source.polls)
+	assert.Empty(t, uds.sent())
+}
@@ -22,6 +22,7 @@ import (
 	"sync"
 	"time"
 
+	datamodels "github.com/nvidia/nvsentinel/data-models/pkg/model"
 	pb "github.com/nvidia/nvsentinel/data-models/pkg/protos"
 	"github.com/nvidia/nvsentinel/health-monitors/csp-health-monitor/pkg/config"
 	"github.com/nvidia/nvsentinel/health-monitors/csp-health-monitor/pkg/datastore"
@@ -381,6 +382,9 @@ func isRetryableGRPCError(err error) bool {
 
 // sendHealthEventWithRetry attempts to send a HealthEvent via UDS, with retries and metrics.
 func (e *Engine) sendHealthEventWithRetry(ctx context.Context, healthEvent *pb.HealthEvent) error {
+	// Assigned once so that retries of the event keep its ID
+	datamodels.AssignEventID(healthEvent)
+
 	backoff := wait.Backoff{
 		Steps:    udsMaxRetries,
 		Duration: udsRetryDelay,
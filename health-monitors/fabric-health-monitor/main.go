@@ -0,0 +1,149 @@
+// Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log/slog"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/nvidia/nvsentinel/commons/pkg/logger"
+	srv "github.com/nvidia/nvsentinel/commons/pkg/server"
+	pb "github.com/nvidia/nvsentinel/data-models/pkg/protos"
+	"github.com/nvidia/nvsentinel/health-monitors/fabric-health-monitor/pkg/fabric"
+	"github.com/nvidia/nvsentinel/health-monitors/fabric-health-monitor/pkg/nmx"
+
+	"golang.org/x/sync/errgroup"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+const (
+	defaultAgentName    = "fabric-health-monitor"
+	defaultUdsPath      = "/run/nvsentinel/nvsentinel.sock"
+	defaultMetricsPort  = 2112
+	defaultPollInterval = 30 * time.Second
+)
+
+var (
+	// These variables will be populated during the build process
+	version = "dev"
+	commit  = "none"
+	date    = "unknown"
+)
+
+type appConfig struct {
+	udsPath      string
+	metricsPort  int
+	nmxEndpoint  string
+	nmxCAFile    string
+	nmxTimeout   time.Duration
+	pollInterval time.Duration
+}
+
+func parseFlags() *appConfig {
+	cfg := &appConfig{}
+
+	flag.StringVar(&cfg.udsPath, "uds-path", defaultUdsPath, "Path to the Platform Connector UDS socket.")
+	flag.IntVar(&cfg.metricsPort, "metrics-port", defaultMetricsPort, "Port for the Prometheus metrics.")
+	flag.StringVar(&cfg.nmxEndpoint, "nmx-endpoint", "",
+		"Base URL of the NMX-M REST API. The NMX_USERNAME and NMX_PASSWORD environment variables authenticate.")
+	flag.StringVar(&cfg.nmxCAFile, "nmx-ca-file", "",
+		"PEM file of the CAs the NMX-M certificate is verified with. The system CAs when empty.")
+	flag.DurationVar(&cfg.nmxTimeout, "nmx-timeout", nmx.DefaultTimeout, "Timeout of a single NMX-M request.")
+	flag.DurationVar(&cfg.pollInterval, "poll-interval", defaultPollInterval,
+		"Interval between queries of the NVLink partitions.")
+
+	flag.Parse()
+
+	return cfg
+}
+
+func main() {
+	logger.SetDefaultStructuredLogger(defaultAgentName, version)
+	slog.Info("Starting fabric-health-monitor", "version", version, "commit", commit, "date", date)
+
+	if err := run(); err != nil {
+		slog.Error("Fatal error", "error", err)
+		os.Exit(1)
+	}
+}
+
+func run() error {
+	cfg := parseFlags()
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	source, err := nmx.NewClient(nmx.Config{
+		Endpoint: cfg.nmxEndpoint,
+		Username: os.Getenv("NMX_USERNAME"),
+		Password: os.Getenv("NMX_PASSWORD"),
+		CAFile:   cfg.nmxCAFile,
+		Timeout:  cfg.nmxTimeout,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create NMX-M client: %w", err)
+	}
+
+	conn, err := grpc.NewClient("unix:"+cfg.udsPath, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		return fmt.Errorf("failed to dial Platform Connector UDS %s: %w", cfg.udsPath, err)
+	}
+
+	defer func() {
+		if errClose := conn.Close(); errClose != nil {
+			slog.Error("Error closing UDS connection", "error", errClose)
+		}
+	}()
+
+	monitor := fabric.NewMonitor(fabric.Config{PollInterval: cfg.pollInterval}, source,
+		pb.NewPlatformConnectorClient(conn))
+
+	server := srv.NewServer(
+		srv.WithPort(cfg.metricsPort),
+		srv.WithPrometheusMetrics(),
+		srv.WithHandler(logger.LevelsPath, logger.LevelsHandler()),
+		srv.WithSimpleHealth(),
+	)
+
+	g, gCtx := errgroup.WithContext(ctx)
+
+	// Metrics server failures are logged but do NOT terminate the monitor.
+	g.Go(func() error {
+		if err := server.Serve(gCtx); err != nil {
+			slog.Error("Metrics server failed - continuing without metrics", "error", err)
+		}
+
+		return nil
+	})
+
+	g.Go(func() error {
+		return monitor.Start(gCtx)
+	})
+
+	if err := g.Wait(); err != nil {
+		return fmt.Errorf("service error: %w", err)
+	}
+
+	slog.Info("Fabric health monitor shut down.")
+
+	return nil
+}
@@ -0,0 +1,60 @@
+// Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fabric
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+var (
+	queryErrors = promauto.NewCounter(
+		prometheus.CounterOpts{
+			Name: "fabric_health_monitor_query_errors_total",
+			Help: "Total number of errors listing the NVLink partitions of the fabric manager.",
+		},
+	)
+	partitionsGauge = promauto.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "fabric_health_monitor_partitions",
+			Help: "Number of NVLink partitions by health.",
+		},
+		[]string{"health"},
+	)
+	eventsSent = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "fabric_health_monitor_events_sent_total",
+			Help: "Total number of fabric partition events sent, one per node of a partition.",
+		},
+		[]string{"healthy"},
+	)
+	eventSendErrors = promauto.NewCounter(
+		prometheus.CounterOpts{
+			Name: "fabric_health_monitor_event_send_errors_total",
+			Help: "Total number of errors sending fabric partition events via UDS.",
+		},
+	)
+)
+
+func updatePartitionMetrics(partitions map[string]reported) {
+	counts := map[Health]int{HealthHealthy: 0, HealthDegraded: 0, HealthUnhealthy: 0}
+	for _, partition := range partitions {
+		counts[partition.status.health]++
+	}
+
+	for health, count := range counts {
+		partitionsGauge.WithLabelValues(string(health)).Set(float64(count))
+	}
+}
@@ -0,0 +1,301 @@
+// Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package fabric monitors the multi-node NVLink partitions of NVL72 (GB200) racks.
+// A partition spans the GPUs of several nodes, so a degraded partition slows down
+// or breaks the jobs of all its nodes even if the GPUs of a node are healthy. The
+// monitor polls the fabric manager for the partitions and reports every change of
+// the health of a partition with an event on each of its nodes.
+package fabric
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strconv"
+	"time"
+
+	datamodels "github.com/nvidia/nvsentinel/data-models/pkg/model"
+	pb "github.com/nvidia/nvsentinel/data-models/pkg/protos"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"k8s.io/apimachinery/pkg/util/wait"
+)
+
+const (
+	// CheckName is the check name of the events sent by the monitor
+	CheckName = "NVLinkFabricPartition"
+
+	agentName      = "fabric-health-monitor"
+	componentClass = "NVSWITCH"
+
+	udsMaxRetries = 3
+	udsRetryDelay = time.Second
+)
+
+// Config configures a Monitor.
+type Config struct {
+	// PollInterval is how often the partitions are listed
+	PollInterval time.Duration
+}
+
+// reported is the state of a partition last reported to the platform connector.
+type reported struct {
+	partition Partition
+	status    partitionStatus
+}
+
+func (r reported) unhealthy() bool {
+	return r.status.health.severity() > 0
+}
+
+// Monitor polls the partitions of the fabric and reports their health changes.
+type Monitor struct {
+	cfg       Config
+	source    Source
+	udsClient pb.PlatformConnectorClient
+	now       func() time.Time
+	// partitions holds the reported state by partition ID
+	partitions map[string]reported
+}
+
+// NewMonitor constructs a Monitor.
+func NewMonitor(cfg Config, source Source, udsClient pb.PlatformConnectorClient) *Monitor {
+	return &Monitor{
+		cfg:        cfg,
+		source:     source,
+		udsClient:  udsClient,
+		now:        time.Now,
+		partitions: map[string]reported{},
+	}
+}
+
+// Start polls the partitions every poll interval until the context is cancelled.
+func (m *Monitor) Start(ctx context.Context) error {
+	slog.Info("Monitoring NVLink fabric partitions", "pollInterval", m.cfg.PollInterval)
+
+	ticker := time.NewTicker(m.cfg.PollInterval)
+	defer ticker.Stop()
+
+	for {
+		m.poll(ctx)
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// poll lists the partitions and reports the changes since the last poll. The
+// changes are reported again on the next poll when they could not be sent.
+func (m *Monitor) poll(ctx context.Context) {
+	partitions, err := m.source.Partitions(ctx)
+	if err != nil {
+		queryErrors.Inc()
+		slog.Warn("Failed to list NVLink fabric partitions", "error", err)
+
+		return
+	}
+
+	next, events := m.changes(partitions)
+	updatePartitionMetrics(next)
+
+	if len(events) > 0 {
+		if err := m.sendHealthEventsWithRetry(ctx, events); err != nil {
+			eventSendErrors.Inc()
+			slog.Error("Failed to send fabric partition events, retrying on next poll", "events", len(events), "error", err)
+
+			return
+		}
+
+		for _, event := range events {
+			eventsSent.WithLabelValues(strconv.FormatBool(event.IsHealthy)).Inc()
+		}
+	}
+
+	m.partitions = next
+}
+
+// changes compares the partitions with the reported state. Every node of a
+// partition that is not healthy gets an unhealthy event when the health, the
+// unhealthy GPUs or the nodes of the partition changed, nodes that are no longer
+// part of an unhealthy partition get a healthy event. Partitions of unknown health
+// keep their reported state.
+func (m *Monitor) changes(partitions []Partition) (map[string]reported, []*pb.HealthEvent) {
+	next := make(map[string]reported, len(partitions))
+
+	var events []*pb.HealthEvent
+
+	for i := range partitions {
+		partition := &partitions[i]
+		current := reported{partition: *partition, status: evaluate(partition)}
+		previous, known := m.partitions[partition.ID]
+
+		if current.status.health == HealthUnknown {
+			if known {
+				next[partition.ID] = previous
+			}
+
+			continue
+		}
+
+		next[partition.ID] = current
+
+		if current.unhealthy() && (!known || !previous.status.equal(current.status) ||
+			!equalNodes(previous.partition, current.partition)) {
+			if current.status.health != previous.status.health {
+				slog.Warn("NVLink fabric partition health changed",
+					"partition", partition.ID,
+					"name", partition.Name,
+					"health", current.status.describe(),
+					"nodes", len(partition.GPUs))
+			}
+
+			for _, node := range partition.Nodes() {
+				events = append(events, m.healthEvent(partition, current.status, node))
+			}
+		}
+
+		events = append(events, m.cleared(previous, current)...)
+	}
+
+	for id, previous := range m.partitions {
+		if _, ok := next[id]; !ok {
+			slog.Info("NVLink fabric partition removed", "partition", id, "name", previous.partition.Name)
+			events = append(events, m.cleared(previous, reported{})...)
+		}
+	}
+
+	return next, events
+}
+
+// cleared returns the healthy events of the nodes of the previously unhealthy
+// partition that are not unhealthy in the current state.
+func (m *Monitor) cleared(previous, current reported) []*pb.HealthEvent {
+	if !previous.unhealthy() {
+		return nil
+	}
+
+	var events []*pb.HealthEvent
+
+	for _, node := range previous.partition.Nodes() {
+		if _, ok := current.partition.GPUs[node]; ok && current.unhealthy() {
+			continue
+		}
+
+		events = append(events, m.healthEvent(&previous.partition, partitionStatus{health: HealthHealthy}, node))
+	}
+
+	if len(events) > 0 && !current.unhealthy() && current.partition.ID != "" {
+		slog.Info("NVLink fabric partition recovered",
+			"partition", current.partition.ID, "name", current.partition.Name)
+	}
+
+	return events
+}
+
+func equalNodes(a, b Partition) bool {
+	if len(a.GPUs) != len(b.GPUs) {
+		return false
+	}
+
+	for node := range a.GPUs {
+		if _, ok := b.GPUs[node]; !ok {
+			return false
+		}
+	}
+
+	return true
+}
+
+// healthEvent returns the event of a node of the partition. A degraded partition
+// still runs jobs at reduced bandwidth and is reported as non-fatal; an unhealthy
+// partition breaks the jobs spanning it and needs the fabric to be repaired, which
+// remediating the node does not do.
+func (m *Monitor) healthEvent(partition *Partition, st partitionStatus, node string) *pb.HealthEvent {
+	fabricPartition := &datamodels.FabricPartition{ID: partition.ID, Name: partition.Name, Nodes: partition.Nodes()}
+
+	metadata := map[string]string{}
+	fabricPartition.AddMetadata(metadata)
+
+	entities := []*pb.Entity{fabricPartition.Entity()}
+	for _, gpu := range partition.GPUs[node] {
+		entities = append(entities, &pb.Entity{EntityType: "GPU", EntityValue: strconv.Itoa(gpu.Index)})
+	}
+
+	name := partition.ID
+	if partition.Name != "" {
+		name = fmt.Sprintf("%s (%s)", partition.Name, partition.ID)
+	}
+
+	nodes := len(partition.GPUs)
+
+	event := &pb.HealthEvent{
+		Agent:              agentName,
+		ComponentClass:     componentClass,
+		CheckName:          CheckName,
+		IsHealthy:          st.health == HealthHealthy,
+		Message:            fmt.Sprintf("NVLink partition %s spanning %d nodes is %s", name, nodes, st.describe()),
+		RecommendedAction:  pb.RecommendedAction_NONE,
+		EntitiesImpacted:   entities,
+		Metadata:           metadata,
+		NodeName:           node,
+		GeneratedTimestamp: timestamppb.New(m.now()),
+	}
+
+	if st.health == HealthUnhealthy {
+		event.IsFatal = true
+		event.RecommendedAction = pb.RecommendedAction_CONTACT_SUPPORT
+	}
+
+	return event
+}
+
+func (m *Monitor) sendHealthEventsWithRetry(ctx context.Context, events []*pb.HealthEvent) error {
+	// Assigned once so that retries of the events keep their IDs
+	for _, event := range events {
+		datamodels.AssignEventID(event)
+	}
+
+	backoff := wait.Backoff{
+		Steps:    udsMaxRetries,
+		Duration: udsRetryDelay,
+		Factor:   1.5,
+		Jitter:   0.1,
+	}
+
+	var lastErr error
+
+	err := wait.ExponentialBackoffWithContext(ctx, backoff, func(ctx context.Context) (bool, error) {
+		_, lastErr = m.udsClient.HealthEventOccurredV1(ctx, &pb.HealthEvents{Events: events})
+		if lastErr == nil {
+			return true, nil
+		}
+
+		if st, ok := status.FromError(lastErr); ok && st.Code() == codes.Unavailable {
+			slog.Warn("Retryable error sending fabric partition events via UDS. Retrying...", "error", lastErr)
+			return false, nil
+		}
+
+		return false, lastErr
+	})
+	if err != nil && lastErr != nil {
+		return lastErr
+	}
+
+	return err
+}
@@ -0,0 +1,193 @@
+// Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fabric
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	datamodels "github.com/nvidia/nvsentinel/data-models/pkg/model"
+	pb "github.com/nvidia/nvsentinel/data-models/pkg/protos"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+type fakeSource struct {
+	partitions []Partition
+	err        error
+}
+
+func (f *fakeSource) Partitions(context.Context) ([]Partition, error) {
+	return f.partitions, f.err
+}
+
+type fakeUDSClient struct {
+	events []*pb.HealthEvent
+	err    error
+}
+
+func (f *fakeUDSClient) HealthEventOccurredV1(
+	_ context.Context, in *pb.HealthEvents, _ ...grpc.CallOption,
+) (*emptypb.Empty, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+
+	f.events = append(f.events, in.Events...)
+
+	return &emptypb.Empty{}, nil
+}
+
+// take returns the events sent since the last call by node
+func (f *fakeUDSClient) take() map[string]*pb.HealthEvent {
+	events := map[string]*pb.HealthEvent{}
+	for _, event := range f.events {
+		events[event.NodeName] = event
+	}
+
+	f.events = nil
+
+	return events
+}
+
+func rack(health Health, nodes ...string) Partition {
+	partition := Partition{ID: "32766", Name: "rack-7", Health: health, GPUs: map[string][]GPU{}}
+	for _, node := range nodes {
+		for i := range 4 {
+			partition.GPUs[node] = append(partition.GPUs[node], GPU{Index: i, Health: HealthHealthy})
+		}
+	}
+
+	return partition
+}
+
+func newTestMonitor() (*Monitor, *fakeSource, *fakeUDSClient) {
+	source := &fakeSource{}
+	client := &fakeUDSClient{}
+
+	return NewMonitor(Config{}, source, client), source, client
+}
+
+func TestMonitorReportsDegradedPartitionOnAllNodes(t *testing.T) {
+	monitor, source, client := newTestMonitor()
+
+	source.partitions = []Partition{rack(HealthHealthy, "node-1", "node-2")}
+	monitor.poll(context.Background())
+	assert.Empty(t, client.take(), "healthy partitions are not reported")
+
+	degraded := rack(HealthHealthy, "node-1", "node-2")
+	degraded.GPUs["node-2"][3].Health = HealthUnhealthy
+	source.partitions = []Partition{degraded}
+	monitor.poll(context.Background())
+
+	events := client.take()
+	require.Len(t, events, 2)
+
+	event := events["node-1"]
+	assert.Equal(t, CheckName, event.CheckName)
+	assert.False(t, event.IsHealthy)
+	assert.False(t, event.IsFatal)
+	assert.Equal(t, pb.RecommendedAction_NONE, event.RecommendedAction)
+	assert.Contains(t, event.Message, "degraded, unhealthy GPUs node-2/3")
+	assert.NotEmpty(t, event.Id)
+	assert.Equal(t, &datamodels.FabricPartition{ID: "32766", Name: "rack-7", Nodes: []string{"node-1", "node-2"}},
+		datamodels.FabricPartitionFromEvent(event))
+	assert.Len(t, event.EntitiesImpacted, 5, "the partition and the GPUs of the node")
+
+	// Unchanged partitions are not reported again
+	monitor.poll(context.Background())
+	assert.Empty(t, client.take())
+
+	source.partitions = []Partition{rack(HealthHealthy, "node-1", "node-2")}
+	monitor.poll(context.Background())
+
+	events = client.take()
+	require.Len(t, events, 2)
+	assert.True(t, events["node-2"].IsHealthy)
+}
+
+func TestMonitorReportsUnhealthyPartitionAsFatal(t *testing.T) {
+	monitor, source, client := newTestMonitor()
+
+	source.partitions = []Partition{rack(HealthUnhealthy, "node-1")}
+	monitor.poll(context.Background())
+
+	events := client.take()
+	require.Len(t, events, 1)
+	assert.True(t, events["node-1"].IsFatal)
+	assert.Equal(t, pb.RecommendedAction_CONTACT_SUPPORT, events["node-1"].RecommendedAction)
+}
+
+func TestMonitorClearsNodesLeavingUnhealthyPartition(t *testing.T) {
+	monitor, source, client := newTestMonitor()
+
+	source.partitions = []Partition{rack(HealthDegraded, "node-1", "node-2")}
+	monitor.poll(context.Background())
+	require.Len(t, client.take(), 2)
+
+	// node-2 leaves the partition
+	source.partitions = []Partition{rack(HealthDegraded, "node-1")}
+	monitor.poll(context.Background())
+
+	events := client.take()
+	require.Len(t, events, 2)
+	assert.False(t, events["node-1"].IsHealthy)
+	assert.True(t, events["node-2"].IsHealthy)
+
+	// The partition is removed
+	source.partitions = nil
+	monitor.poll(context.Background())
+
+	events = client.take()
+	require.Len(t, events, 1)
+	assert.True(t, events["node-1"].IsHealthy)
+}
+
+func TestMonitorKeepsStateOfUnknownHealth(t *testing.T) {
+	monitor, source, client := newTestMonitor()
+
+	source.partitions = []Partition{rack(HealthDegraded, "node-1")}
+	monitor.poll(context.Background())
+	require.Len(t, client.take(), 1)
+
+	source.partitions = []Partition{rack(HealthUnknown, "node-1")}
+	monitor.poll(context.Background())
+	assert.Empty(t, client.take())
+
+	source.err = errors.New("connection refused")
+	monitor.poll(context.Background())
+	assert.Empty(t, client.take())
+
+	source.partitions, source.err = []Partition{rack(HealthHealthy, "node-1")}, nil
+	monitor.poll(context.Background())
+	assert.True(t, client.take()["node-1"].IsHealthy)
+}
+
+func TestMonitorRetriesFailedSends(t *testing.T) {
+	monitor, source, client := newTestMonitor()
+
+	client.err = errors.New("permission denied")
+	source.partitions = []Partition{rack(HealthDegraded, "node-1")}
+	monitor.poll(context.Background())
+	assert.Empty(t, client.take())
+
+	client.err = nil
+	monitor.poll(context.Background())
+	assert.Len(t, client.take(), 1)
+}
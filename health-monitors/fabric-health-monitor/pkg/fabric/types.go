@@ -0,0 +1,124 @@
+// Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fabric
+
+import (
+	"context"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Health is the health of a partition or GPU reported by the fabric manager.
+type Health string
+
+const (
+	HealthHealthy   Health = "HEALTHY"
+	HealthDegraded  Health = "DEGRADED"
+	HealthUnhealthy Health = "UNHEALTHY"
+	// HealthUnknown is reported while the fabric manager has not determined the
+	// health, e.g. during a partition change
+	HealthUnknown Health = "UNKNOWN"
+)
+
+// severity orders the health values, unknown health is not comparable
+func (h Health) severity() int {
+	switch h {
+	case HealthHealthy:
+		return 0
+	case HealthDegraded:
+		return 1
+	case HealthUnhealthy:
+		return 2
+	default:
+		return -1
+	}
+}
+
+// GPU is a GPU of a partition.
+type GPU struct {
+	// Index is the index of the GPU on its node
+	Index  int
+	UUID   string
+	Health Health
+}
+
+// Partition is a multi-node NVLink partition with the GPUs it spans by node.
+type Partition struct {
+	ID     string
+	Name   string
+	Health Health
+	// GPUs holds the GPUs of the partition by Kubernetes node name
+	GPUs map[string][]GPU
+}
+
+// Nodes returns the sorted nodes of the partition.
+func (p *Partition) Nodes() []string {
+	nodes := make([]string, 0, len(p.GPUs))
+	for node := range p.GPUs {
+		nodes = append(nodes, node)
+	}
+
+	sort.Strings(nodes)
+
+	return nodes
+}
+
+// Source lists the partitions of the fabric, e.g. from NMX-M.
+type Source interface {
+	Partitions(ctx context.Context) ([]Partition, error)
+}
+
+// partitionStatus is the evaluated health of a partition.
+type partitionStatus struct {
+	health Health
+	// unhealthyGPUs holds the GPUs of the partition that are not healthy, as
+	// node/index
+	unhealthyGPUs []string
+}
+
+// evaluate derives the health of the partition from its own health and the health
+// of its GPUs: a partition with unhealthy GPUs is at least degraded.
+func evaluate(partition *Partition) partitionStatus {
+	result := partitionStatus{health: partition.Health}
+
+	for _, node := range partition.Nodes() {
+		for _, gpu := range partition.GPUs[node] {
+			if gpu.Health.severity() > 0 {
+				result.unhealthyGPUs = append(result.unhealthyGPUs, node+"/"+strconv.Itoa(gpu.Index))
+			}
+		}
+	}
+
+	if len(result.unhealthyGPUs) > 0 && result.health.severity() < HealthDegraded.severity() &&
+		result.health != HealthUnknown {
+		result.health = HealthDegraded
+	}
+
+	return result
+}
+
+func (s partitionStatus) equal(other partitionStatus) bool {
+	return s.health == other.health && slices.Equal(s.unhealthyGPUs, other.unhealthyGPUs)
+}
+
+func (s partitionStatus) describe() string {
+	if len(s.unhealthyGPUs) == 0 {
+		return strings.ToLower(string(s.health))
+	}
+
+	return strings.ToLower(string(s.health)) + ", unhealthy GPUs " + strings.Join(s.unhealthyGPUs, ", ")
+}
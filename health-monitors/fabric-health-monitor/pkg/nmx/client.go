@@ -0,0 +1,241 @@
+// Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package nmx lists the NVLink partitions of NMX-M, the manager of the NVLink
+// fabric of NVL72 racks, through its REST API. Only the fields of the partition,
+// GPU and compute node resources the monitor relies on are decoded.
+package nmx
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/nvidia/nvsentinel/health-monitors/fabric-health-monitor/pkg/fabric"
+)
+
+const (
+	// DefaultTimeout bounds a request to NMX-M
+	DefaultTimeout = 10 * time.Second
+
+	partitionsPath   = "/nmx/v1/partitions"
+	gpusPath         = "/nmx/v1/gpus"
+	computeNodesPath = "/nmx/v1/compute-nodes"
+	maxResponseSize  = 16 << 20
+)
+
+// Config configures a Client.
+type Config struct {
+	// Endpoint is the base URL of NMX-M, e.g. https://nmx-m.example.com
+	Endpoint string
+	// Username and Password authenticate with HTTP basic authentication when set
+	Username string
+	Password string
+	// CAFile is the PEM file of the CAs the certificate of NMX-M is verified with,
+	// the system CAs when empty
+	CAFile  string
+	Timeout time.Duration
+}
+
+// partition is an NVLink partition of NMX-M.
+type partition struct {
+	ID          string   `json:"ID"`
+	PartitionID int      `json:"PartitionID"`
+	Name        string   `json:"Name"`
+	Health      string   `json:"Health"`
+	GPUIDs      []string `json:"GpuIDList"`
+}
+
+// gpu is a GPU of the fabric.
+type gpu struct {
+	ID string `json:"ID"`
+	// DeviceID is the index of the GPU on its compute node
+	DeviceID  int    `json:"DeviceID"`
+	DeviceUID string `json:"DeviceUID"`
+	Health    string `json:"Health"`
+}
+
+// computeNode is a compute tray of the fabric.
+type computeNode struct {
+	ID       string   `json:"ID"`
+	Hostname string   `json:"Hostname"`
+	GPUIDs   []string `json:"GpuIDList"`
+}
+
+// Client lists the partitions of NMX-M. It implements fabric.Source.
+type Client struct {
+	cfg        Config
+	httpClient *http.Client
+}
+
+// NewClient creates a Client.
+func NewClient(cfg Config) (*Client, error) {
+	if cfg.Endpoint == "" {
+		return nil, errors.New("NMX-M endpoint is required")
+	}
+
+	if cfg.Timeout <= 0 {
+		cfg.Timeout = DefaultTimeout
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+
+	if cfg.CAFile != "" {
+		pem, err := os.ReadFile(cfg.CAFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read CA file %s: %w", cfg.CAFile, err)
+		}
+
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("no certificates found in CA file %s", cfg.CAFile)
+		}
+
+		transport.TLSClientConfig = &tls.Config{RootCAs: pool, MinVersion: tls.VersionTLS12}
+	}
+
+	cfg.Endpoint = strings.TrimSuffix(cfg.Endpoint, "/")
+
+	return &Client{
+		cfg:        cfg,
+		httpClient: &http.Client{Timeout: cfg.Timeout, Transport: transport},
+	}, nil
+}
+
+// Partitions lists the partitions with their GPUs by node. The Kubernetes node of
+// a GPU is the host name of its compute node; GPUs whose compute node is unknown
+// are left out.
+func (c *Client) Partitions(ctx context.Context) ([]fabric.Partition, error) {
+	var (
+		partitions   []partition
+		gpus         []gpu
+		computeNodes []computeNode
+	)
+
+	if err := c.get(ctx, partitionsPath, &partitions); err != nil {
+		return nil, err
+	}
+
+	if err := c.get(ctx, gpusPath, &gpus); err != nil {
+		return nil, err
+	}
+
+	if err := c.get(ctx, computeNodesPath, &computeNodes); err != nil {
+		return nil, err
+	}
+
+	gpusByID := make(map[string]gpu, len(gpus))
+	for _, g := range gpus {
+		gpusByID[g.ID] = g
+	}
+
+	nodeByGPU := map[string]string{}
+
+	for _, node := range computeNodes {
+		for _, id := range node.GPUIDs {
+			nodeByGPU[id] = node.Hostname
+		}
+	}
+
+	result := make([]fabric.Partition, 0, len(partitions))
+
+	for _, p := range partitions {
+		resolved := fabric.Partition{
+			ID:     strconv.Itoa(p.PartitionID),
+			Name:   p.Name,
+			Health: parseHealth(p.Health),
+			GPUs:   map[string][]fabric.GPU{},
+		}
+
+		for _, id := range p.GPUIDs {
+			node, ok := nodeByGPU[id]
+			if !ok || node == "" {
+				slog.Debug("Skipping GPU of an unknown compute node", "partition", resolved.ID, "gpu", id)
+				continue
+			}
+
+			g := gpusByID[id]
+			resolved.GPUs[node] = append(resolved.GPUs[node], fabric.GPU{
+				Index:  g.DeviceID,
+				UUID:   g.DeviceUID,
+				Health: parseHealth(g.Health),
+			})
+		}
+
+		result = append(result, resolved)
+	}
+
+	return result, nil
+}
+
+// parseHealth maps the health of NMX-M, which qualifies degradations, e.g.
+// DEGRADED_BW, to the health of the monitor.
+func parseHealth(value string) fabric.Health {
+	value = strings.ToUpper(value)
+
+	switch {
+	case value == string(fabric.HealthHealthy):
+		return fabric.HealthHealthy
+	case strings.HasPrefix(value, string(fabric.HealthDegraded)):
+		return fabric.HealthDegraded
+	case strings.HasPrefix(value, string(fabric.HealthUnhealthy)):
+		return fabric.HealthUnhealthy
+	default:
+		return fabric.HealthUnknown
+	}
+}
+
+func (c *Client) get(ctx context.Context, path string, result any) error {
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, c.cfg.Endpoint+path, nil)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+
+	request.Header.Set("Accept", "application/json")
+
+	if c.cfg.Username != "" {
+		request.SetBasicAuth(c.cfg.Username, c.cfg.Password)
+	}
+
+	response, err := c.httpClient.Do(request)
+	if err != nil {
+		return fmt.Errorf("failed to query %s: %w", path, err)
+	}
+	defer response.Body.Close()
+
+	body, err := io.ReadAll(io.LimitReader(response.Body, maxResponseSize))
+	if err != nil {
+		return fmt.Errorf("failed to read response of %s: %w", path, err)
+	}
+
+	if response.StatusCode != http.StatusOK {
+		return fmt.Errorf("%s returned %s: %s", path, response.Status, strings.TrimSpace(string(body)))
+	}
+
+	if err := json.Unmarshal(body, result); err != nil {
+		return fmt.Errorf("failed to decode response of %s: %w", path, err)
+	}
+
+	return nil
+}
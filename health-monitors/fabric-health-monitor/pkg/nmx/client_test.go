@@ -0,0 +1,88 @@
+// Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package nmx
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/nvidia/nvsentinel/health-monitors/fabric-health-monitor/pkg/fabric"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPartitions(t *testing.T) {
+	responses := map[string]string{
+		partitionsPath: `[{"ID": "p-1", "PartitionID": 32766, "Name": "rack-7", "Health": "DEGRADED_BW",
+			"GpuIDList": ["g-1", "g-2", "g-3", "g-orphan"]}]`,
+		gpusPath: `[{"ID": "g-1", "DeviceID": 0, "DeviceUID": "123", "Health": "HEALTHY"},
+			{"ID": "g-2", "DeviceID": 1, "DeviceUID": "456", "Health": "UNHEALTHY"},
+			{"ID": "g-3", "DeviceID": 0, "DeviceUID": "789", "Health": "HEALTHY"}]`,
+		computeNodesPath: `[{"ID": "n-1", "Hostname": "node-1", "GpuIDList": ["g-1", "g-2"]},
+			{"ID": "n-2", "Hostname": "node-2", "GpuIDList": ["g-3"]}]`,
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if user, password, ok := r.BasicAuth(); !ok || user != "admin" || password != "secret" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+
+		response, ok := responses[r.URL.Path]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		_, _ = w.Write([]byte(response))
+	}))
+	defer server.Close()
+
+	client, err := NewClient(Config{Endpoint: server.URL + "/", Username: "admin", Password: "secret"})
+	require.NoError(t, err)
+
+	partitions, err := client.Partitions(context.Background())
+	require.NoError(t, err)
+	require.Len(t, partitions, 1)
+
+	assert.Equal(t, fabric.Partition{
+		ID:     "32766",
+		Name:   "rack-7",
+		Health: fabric.HealthDegraded,
+		GPUs: map[string][]fabric.GPU{
+			"node-1": {
+				{Index: 0, UUID: "123", Health: fabric.HealthHealthy},
+				{Index: 1, UUID: "456", Health: fabric.HealthUnhealthy},
+			},
+			"node-2": {{Index: 0, UUID: "789", Health: fabric.HealthHealthy}},
+		},
+	}, partitions[0])
+
+	client, err = NewClient(Config{Endpoint: server.URL})
+	require.NoError(t, err)
+
+	_, err = client.Partitions(context.Background())
+	assert.ErrorContains(t, err, "401 Unauthorized")
+}
+
+func TestParseHealth(t *testing.T) {
+	assert.Equal(t, fabric.HealthHealthy, parseHealth("healthy"))
+	assert.Equal(t, fabric.HealthDegraded, parseHealth("DEGRADED_BW"))
+	assert.Equal(t, fabric.HealthUnhealthy, parseHealth("UNHEALTHY"))
+	assert.Equal(t, fabric.HealthUnknown, parseHealth(""))
+}
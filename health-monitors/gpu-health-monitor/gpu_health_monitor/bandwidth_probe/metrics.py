@@ -0,0 +1,31 @@
+# Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+
+from prometheus_client import Counter, Gauge, Histogram
+
+bandwidth_probe_runs = Counter(
+    "bandwidth_probe_runs",
+    "Number of bandwidth probe runs by result",
+    labelnames=["result"],
+)
+bandwidth_probe_duration = Histogram(
+    "bandwidth_probe_duration_seconds",
+    "Amount of time spent running the bandwidth benchmark",
+    buckets=[1, 5, 10, 30, 60, 120, 300],
+)
+bandwidth_probe_measured_gbps = Gauge(
+    "bandwidth_probe_measured_gbps",
+    "Lowest bandwidth in GB/s measured for a GPU by the last probe of a testcase",
+    labelnames=["gpu_id", "testcase"],
+)
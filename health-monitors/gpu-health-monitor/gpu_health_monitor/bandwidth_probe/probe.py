@@ -0,0 +1,156 @@
+# Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+
+import dataclasses
+import json
+import logging as log
+import shlex
+from threading import Event
+from typing import Callable
+
+from gpu_health_monitor import nvidia_smi
+from . import metrics
+
+
+@dataclasses.dataclass
+class BandwidthResult:
+    testcase: str
+    measured_gbps: float
+    expected_gbps: float
+
+
+class BandwidthCallbackInterface:
+    def bandwidth_measured(self, results: dict[int, list[BandwidthResult]], degraded_threshold_percent: int):
+        """Called with the results of the GPUs a probe ran on, keyed by GPU index."""
+        pass
+
+
+def _parse_gbps(cell) -> float | None:
+    try:
+        return float(cell)
+    except (TypeError, ValueError):
+        # nvbandwidth reports N/A for pairs it does not measure, e.g. a GPU to itself
+        return None
+
+
+def parse_nvbandwidth_output(output: str, gpu_ids: list[int]) -> dict[str, dict[int, float]]:
+    """Returns the lowest bandwidth measured for each GPU per testcase from the JSON
+    output of nvbandwidth. Columns of the bandwidth matrix are the probed GPUs in
+    order, and so are rows of square matrices (device to device testcases), so a
+    GPU's bandwidth is the lowest cell of its column and, for those, of its row."""
+    report = json.loads(output).get("nvbandwidth", {})
+    measured: dict[str, dict[int, float]] = {}
+    for testcase in report.get("testcases", []):
+        name = testcase.get("name", "")
+        if testcase.get("status", "Passed") != "Passed":
+            log.warning(f"nvbandwidth testcase {name} did not pass: {testcase.get('status')}")
+            continue
+        matrix = [[_parse_gbps(cell) for cell in row] for row in testcase.get("bandwidth_matrix", [])]
+        if not matrix:
+            continue
+        square = len(matrix) > 1
+        per_gpu: dict[int, float] = {}
+        for position, gpu_id in enumerate(gpu_ids):
+            cells = [row[position] for row in matrix if position < len(row)]
+            if square and position < len(matrix):
+                cells += matrix[position]
+            cells = [cell for cell in cells if cell is not None]
+            if cells:
+                per_gpu[gpu_id] = min(cells)
+        measured[name] = per_gpu
+    return measured
+
+
+class BandwidthProbe:
+    """Periodically runs a memory bandwidth benchmark (nvbandwidth by default) on the
+    GPUs that are idle and reports the measured against the expected bandwidth of
+    each testcase, to detect silent PCIe or NVLink bandwidth degradation.
+
+    The benchmark runs on the GPUs with no compute process and a utilization of at
+    most idle_utilization_percent, so it never competes with a workload. The probe
+    is skipped when no GPU is idle."""
+
+    def __init__(
+        self,
+        command: str,
+        expected_gbps: dict[str, float],
+        interval_seconds: int,
+        timeout_seconds: int,
+        idle_utilization_percent: int,
+        degraded_threshold_percent: int,
+        callbacks: list[BandwidthCallbackInterface],
+        run: Callable[..., str] = nvidia_smi.run,
+    ) -> None:
+        self._command = shlex.split(command)
+        self._expected_gbps = expected_gbps
+        self._interval_seconds = interval_seconds
+        self._timeout_seconds = timeout_seconds
+        self._idle_utilization_percent = idle_utilization_percent
+        self._degraded_threshold_percent = degraded_threshold_percent
+        self._callbacks = callbacks
+        self._run = run
+
+    def probe(self) -> dict[int, list[BandwidthResult]] | None:
+        """Runs the benchmark once on the idle GPUs. Returns the results per GPU
+        index, or None if the probe was skipped or failed."""
+        try:
+            gpu_ids = nvidia_smi.idle_gpus(self._run, self._timeout_seconds, self._idle_utilization_percent)
+        except Exception as e:
+            log.error(f"Failed to find idle GPUs for the bandwidth probe: {e}")
+            metrics.bandwidth_probe_runs.labels("failed").inc()
+            return None
+        if not gpu_ids:
+            log.info("Skipping bandwidth probe, no GPU is idle")
+            metrics.bandwidth_probe_runs.labels("skipped_busy").inc()
+            return None
+
+        command = list(self._command)
+        for testcase in self._expected_gbps:
+            command += ["-t", testcase]
+        env = nvidia_smi.visible_devices_env(gpu_ids)
+        log.info(f"Running bandwidth probe on GPUs {gpu_ids}: {command}")
+        try:
+            with metrics.bandwidth_probe_duration.time():
+                output = self._run(command, self._timeout_seconds, env)
+            measured = parse_nvbandwidth_output(output, gpu_ids)
+        except Exception as e:
+            log.error(f"Bandwidth probe failed: {e}")
+            metrics.bandwidth_probe_runs.labels("failed").inc()
+            return None
+
+        results: dict[int, list[BandwidthResult]] = {}
+        for testcase, expected in self._expected_gbps.items():
+            for gpu_id, gbps in measured.get(testcase, {}).items():
+                metrics.bandwidth_probe_measured_gbps.labels(str(gpu_id), testcase).set(gbps)
+                results.setdefault(gpu_id, []).append(
+                    BandwidthResult(testcase=testcase, measured_gbps=gbps, expected_gbps=expected)
+                )
+        metrics.bandwidth_probe_runs.labels("completed").inc()
+        return results
+
+    def run_once(self) -> None:
+        """Runs the probe and notifies the callbacks of the results."""
+        results = self.probe()
+        if not results:
+            return
+        for callback in self._callbacks:
+            try:
+                callback.bandwidth_measured(results, self._degraded_threshold_percent)
+            except Exception as e:
+                log.exception(e)
+
+    def start(self, exit: Event) -> None:
+        while not exit.wait(self._interval_seconds):
+            self.run_once()
+
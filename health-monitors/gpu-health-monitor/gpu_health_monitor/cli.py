@@ -13,13 +13,18 @@
 # limitations under the License.
 
 import os
+import re
 import click, configparser, signal, sys
 import logging as log
 from threading import Event
 from prometheus_client import start_http_server
 import csv
+from .bandwidth_probe import probe as bandwidth_probe
+from .config_check import check as config_check
 from .dcgm_watcher import dcgm
 from .platform_connector import platform_connector
+from .scheduler import scheduler
+from .sdc_screen import screen as sdc_screen
 from gpu_health_monitor.protos import health_event_pb2
 
 
@@ -50,6 +55,134 @@ def _init_event_processor(
             sys.exit(1)
 
 
+def _init_bandwidth_probe(config: configparser.ConfigParser, callbacks: list) -> scheduler.ScheduledCheck | None:
+    if not config.has_section("bandwidthprobe") or not config["bandwidthprobe"].getboolean("Enabled", False):
+        return None
+    probe_config = config["bandwidthprobe"]
+    expected_gbps = {}
+    if config.has_section("bandwidthprobe.expected"):
+        expected_gbps = {name: float(gbps) for name, gbps in config["bandwidthprobe.expected"].items()}
+    if not expected_gbps:
+        log.fatal("Bandwidth probe is enabled but no testcase has an expected bandwidth in [bandwidthprobe.expected]")
+        sys.exit(1)
+    interval_seconds = probe_config.getint("IntervalSeconds", 3600)
+    probe = bandwidth_probe.BandwidthProbe(
+        command=probe_config.get("Command", "nvbandwidth --json"),
+        expected_gbps=expected_gbps,
+        interval_seconds=interval_seconds,
+        timeout_seconds=probe_config.getint("TimeoutSeconds", 300),
+        idle_utilization_percent=probe_config.getint("IdleUtilizationPercent", 0),
+        degraded_threshold_percent=probe_config.getint("DegradedThresholdPercent", 80),
+        callbacks=[
+            callback for callback in callbacks if isinstance(callback, bandwidth_probe.BandwidthCallbackInterface)
+        ],
+    )
+    return scheduler.ScheduledCheck(
+        name="bandwidth_probe",
+        run=probe.run_once,
+        interval_seconds=interval_seconds,
+        jitter_seconds=probe_config.getint("JitterSeconds", 0),
+    )
+
+
+def _init_sdc_screen(config: configparser.ConfigParser, callbacks: list) -> scheduler.ScheduledCheck | None:
+    if not config.has_section("sdcscreen") or not config["sdcscreen"].getboolean("Enabled", False):
+        return None
+    screen_config = config["sdcscreen"]
+    if not screen_config.get("Command"):
+        log.fatal("SDC screen is enabled but no screening tool is set in Command")
+        sys.exit(1)
+    interval_seconds = screen_config.getint("IntervalSeconds", 86400)
+    screen = sdc_screen.SDCScreen(
+        command=screen_config["Command"],
+        interval_seconds=interval_seconds,
+        timeout_seconds=screen_config.getint("TimeoutSeconds", 1800),
+        idle_utilization_percent=screen_config.getint("IdleUtilizationPercent", 0),
+        burn_in_state_file=screen_config.get("BurnInStateFile", ""),
+        callbacks=[callback for callback in callbacks if isinstance(callback, sdc_screen.SDCCallbackInterface)],
+    )
+    return scheduler.ScheduledCheck(
+        name="sdc_screen",
+        run=screen.run_once,
+        interval_seconds=interval_seconds,
+        jitter_seconds=screen_config.getint("JitterSeconds", 0),
+        initial_delay_seconds=screen.initial_delay_seconds(),
+    )
+
+
+def _init_config_check(config: configparser.ConfigParser, callbacks: list) -> scheduler.ScheduledCheck | None:
+    if not config.has_section("configcheck") or not config["configcheck"].getboolean("Enabled", False):
+        return None
+    check_config = config["configcheck"]
+    defaults = config_check.Profile()
+    profile = config_check.Profile(
+        iommu_mode=check_config.get("IommuMode", defaults.iommu_mode),
+        acs_redirect_disabled=check_config.getboolean("AcsRedirectDisabled", defaults.acs_redirect_disabled),
+        min_pcie_max_payload_bytes=check_config.getint(
+            "MinPcieMaxPayloadBytes", defaults.min_pcie_max_payload_bytes
+        ),
+        min_hugepages=check_config.getint("MinHugePages", defaults.min_hugepages),
+        transparent_hugepages=check_config.get("TransparentHugePages", defaults.transparent_hugepages),
+        require_peermem=check_config.getboolean("RequirePeermem", defaults.require_peermem),
+        cmdline=dict(config["configcheck.cmdline"]) if config.has_section("configcheck.cmdline") else {},
+        sysctls=dict(config["configcheck.sysctl"]) if config.has_section("configcheck.sysctl") else {},
+    )
+    if profile.iommu_mode not in ("", "off", "passthrough"):
+        log.fatal(f"Invalid IommuMode {profile.iommu_mode}, expected off or passthrough")
+        sys.exit(1)
+    if profile.transparent_hugepages not in ("", "always", "madvise", "never"):
+        log.fatal(f"Invalid TransparentHugePages {profile.transparent_hugepages}, expected always, madvise or never")
+        sys.exit(1)
+    interval_seconds = check_config.getint("IntervalSeconds", 3600)
+    check = config_check.ConfigCheck(
+        profile=profile,
+        interval_seconds=interval_seconds,
+        callbacks=[
+            callback for callback in callbacks if isinstance(callback, config_check.ConfigCheckCallbackInterface)
+        ],
+    )
+    # The configuration is checked right away, reading it does not disturb workloads
+    return scheduler.ScheduledCheck(
+        name="config_check",
+        run=check.run_once,
+        interval_seconds=interval_seconds,
+        jitter_seconds=check_config.getint("JitterSeconds", 0),
+        initial_delay_seconds=0,
+        disruptive=False,
+    )
+
+
+def _init_scheduler(
+    config: configparser.ConfigParser, checks: list[scheduler.ScheduledCheck], node_name: str
+) -> scheduler.Scheduler:
+    # Without a scheduler section, the defaults apply
+    scheduler_config = config["scheduler"] if config.has_section("scheduler") else config[config.default_section]
+    process_patterns = [
+        pattern.strip() for pattern in scheduler_config.get("SuppressProcesses", "").split(",") if pattern.strip()
+    ]
+    marker_file = scheduler_config.get("SuppressFile", "")
+    # Workload schedulers mark nodes do not disturb with a node annotation or label
+    do_not_disturb = scheduler_config.getboolean("DoNotDisturb", False)
+    sensitivity = None
+    if process_patterns or marker_file or do_not_disturb:
+        try:
+            sensitivity = scheduler.WorkloadSensitivity(
+                process_patterns=process_patterns,
+                marker_file=marker_file,
+                timeout_seconds=scheduler_config.getint("TimeoutSeconds", 30),
+                node_name=node_name if do_not_disturb else "",
+            )
+        except re.error as e:
+            log.fatal(f"Invalid pattern in SuppressProcesses: {e}")
+            sys.exit(1)
+    return scheduler.Scheduler(
+        checks=checks,
+        max_concurrent=scheduler_config.getint("MaxConcurrentChecks", 1),
+        sensitivity=sensitivity,
+        suppressed_retry_seconds=scheduler_config.getint("SuppressedRetrySeconds", 300),
+    )
+
+
 @click.command()
 @click.option("--dcgm-addr", type=str, help="Host:Port where DCGM is running", required=True)
 @click.option(
@@ -136,11 +269,23 @@ def cli(
     signal.signal(signal.SIGTERM, process_exit_signal)
     signal.signal(signal.SIGINT, process_exit_signal)
 
+    active_checks = [
+        active_check
+        for active_check in [
+            _init_bandwidth_probe(config, enabled_event_processors),
+            _init_sdc_screen(config, enabled_event_processors),
+            _init_config_check(config, enabled_event_processors),
+        ]
+        if active_check is not None
+    ]
+    _init_scheduler(config, active_checks, node_name).start(exit)
+
     dcgm_watcher = dcgm.DCGMWatcher(
         addr=dcgm_addr,
         poll_interval_seconds=int(dcgm_config["PollIntervalSeconds"]),
         callbacks=enabled_event_processors,
         dcgm_k8s_service_enabled=dcgm_k8s_service_enabled,
+        utilization_window_seconds=dcgm_config.getint("UtilizationWindowSeconds", 0),
     )
     dcgm_watcher.start([], exit)
 
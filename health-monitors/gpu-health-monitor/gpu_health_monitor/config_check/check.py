@@ -0,0 +1,417 @@
+# Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+
+import dataclasses
+import logging as log
+import os
+import re
+import shlex
+from threading import Event
+
+from . import metrics
+
+NVIDIA_VENDOR_ID = 0x10DE
+# PCI base classes of GPUs: display controllers (VGA and 3D controllers)
+DISPLAY_CONTROLLER_CLASS = 0x03
+
+PCI_CAPABILITY_LIST = 0x34
+PCI_STATUS = 0x06
+PCI_STATUS_CAP_LIST = 0x10
+PCI_CAP_ID_EXP = 0x10
+PCI_EXP_DEVCTL = 0x08
+PCI_EXT_CAP_START = 0x100
+PCI_EXT_CAP_ID_ACS = 0x0D
+PCI_ACS_CTRL = 0x06
+# ACS P2P Request Redirect and Completion Redirect route peer to peer traffic
+# through the root complex, which breaks or slows down GPUDirect P2P and RDMA
+PCI_ACS_RR = 0x04
+PCI_ACS_CR = 0x08
+
+BDF = re.compile(r"^[0-9a-f]{4}:[0-9a-f]{2}:[0-9a-f]{2}\.[0-7]$")
+
+SETTING_IOMMU = "iommu"
+SETTING_ACS = "pcie_acs"
+SETTING_MAX_PAYLOAD = "pcie_max_payload"
+SETTING_HUGEPAGES = "hugepages"
+SETTING_THP = "transparent_hugepages"
+SETTING_PEERMEM = "nvidia_peermem"
+SETTING_CMDLINE = "kernel_cmdline"
+SETTING_SYSCTL = "sysctl"
+SETTINGS = [
+    SETTING_IOMMU,
+    SETTING_ACS,
+    SETTING_MAX_PAYLOAD,
+    SETTING_HUGEPAGES,
+    SETTING_THP,
+    SETTING_PEERMEM,
+    SETTING_CMDLINE,
+    SETTING_SYSCTL,
+]
+
+ERROR_CODE_MISCONFIGURED = "MISCONFIGURED"
+# Drift from the baseline of the node image, e.g. a misapplied image
+ERROR_CODE_DRIFT = "CONFIG_DRIFT"
+
+# Baseline values of kernel command line parameters that are not compared
+CMDLINE_PRESENT = ""
+CMDLINE_ABSENT = "-"
+
+
+@dataclasses.dataclass
+class Profile:
+    """The recommended configuration of GPU nodes. Empty or zero values are not checked."""
+
+    # off: no IOMMU translation for the GPUs, passthrough: off or identity mapped (iommu=pt)
+    iommu_mode: str = "passthrough"
+    # Fails the bridges upstream of a GPU with ACS P2P redirect enabled
+    acs_redirect_disabled: bool = True
+    min_pcie_max_payload_bytes: int = 256
+    min_hugepages: int = 0
+    # Expected mode of /sys/kernel/mm/transparent_hugepage/enabled: always, madvise or never
+    transparent_hugepages: str = ""
+    # Whether the nvidia_peermem module for GPUDirect RDMA must be loaded
+    require_peermem: bool = False
+    # Baseline of kernel command line parameters: the expected value, CMDLINE_PRESENT
+    # for a parameter that must be set with any or no value, or CMDLINE_ABSENT
+    cmdline: dict[str, str] = dataclasses.field(default_factory=dict)
+    # Baseline of sysctls by name, e.g. kernel.numa_balancing. Whitespace is not compared
+    sysctls: dict[str, str] = dataclasses.field(default_factory=dict)
+
+
+@dataclasses.dataclass
+class Deviation:
+    setting: str
+    expected: str
+    actual: str
+    # PCI address of the deviating device, empty for node settings
+    device: str = ""
+    # Kernel command line parameter or sysctl of the baseline
+    parameter: str = ""
+    error_code: str = ERROR_CODE_MISCONFIGURED
+
+    @property
+    def key(self) -> str:
+        return "/".join(part for part in (self.setting, self.device, self.parameter) if part)
+
+    @property
+    def message(self) -> str:
+        if self.parameter:
+            return f"{self.setting} {self.parameter} is {self.actual}, expected {self.expected}"
+        device = f" of {self.device}" if self.device else ""
+        return f"{self.setting}{device} is {self.actual}, expected {self.expected}"
+
+
+class ConfigCheckCallbackInterface:
+    def configuration_checked(self, deviations: list[Deviation]):
+        """Called with the settings deviating from the recommended profile after every check."""
+        pass
+
+
+def _read(path: str) -> str:
+    with open(path, "r") as f:
+        return f.read().strip()
+
+
+class ConfigCheck:
+    """Validates the host configuration GPU workloads depend on against a recommended
+    profile: IOMMU mode and ACS redirection on the PCIe path of the GPUs, which
+    degrade GPUDirect peer to peer and RDMA traffic, the PCIe max payload size of
+    the GPUs, hugepages and the nvidia_peermem module. Kernel command line
+    parameters and sysctls are compared against the baseline of the node image.
+
+    PCI configuration space is read from sysfs; reading the capabilities beyond the
+    first 64 bytes requires CAP_SYS_ADMIN, settings that cannot be read are skipped."""
+
+    def __init__(
+        self,
+        profile: Profile,
+        interval_seconds: int,
+        callbacks: list[ConfigCheckCallbackInterface],
+        sys_root: str = "/sys",
+        proc_root: str = "/proc",
+    ) -> None:
+        self._profile = profile
+        self._interval_seconds = interval_seconds
+        self._callbacks = callbacks
+        self._sys_root = sys_root
+        self._proc_root = proc_root
+
+    def _pci_devices_path(self) -> str:
+        return os.path.join(self._sys_root, "bus", "pci", "devices")
+
+    def _gpus(self) -> list[str]:
+        gpus = []
+        devices = self._pci_devices_path()
+        for address in sorted(os.listdir(devices)):
+            try:
+                vendor = int(_read(os.path.join(devices, address, "vendor")), 16)
+                pci_class = int(_read(os.path.join(devices, address, "class")), 16)
+            except (OSError, ValueError):
+                continue
+            if vendor == NVIDIA_VENDOR_ID and pci_class >> 16 == DISPLAY_CONTROLLER_CLASS:
+                gpus.append(address)
+        return gpus
+
+    def _config_space(self, address: str) -> bytes:
+        with open(os.path.join(self._pci_devices_path(), address, "config"), "rb") as f:
+            return f.read()
+
+    def _upstream_bridges(self, address: str) -> list[str]:
+        """Returns the PCI addresses on the path from the root complex to the device."""
+        path = os.path.realpath(os.path.join(self._pci_devices_path(), address))
+        return [part for part in path.split(os.sep)[:-1] if BDF.match(part)]
+
+    def check(self) -> list[Deviation] | None:
+        """Runs the check once. Returns the deviating settings, or None if the check failed."""
+        try:
+            gpus = self._gpus()
+            deviations = (
+                self._check_iommu(gpus)
+                + self._check_acs(gpus)
+                + self._check_max_payload(gpus)
+                + self._check_hugepages()
+                + self._check_peermem()
+                + self._check_cmdline()
+                + self._check_sysctls()
+            )
+        except Exception as e:
+            log.error(f"Configuration check failed: {e}")
+            metrics.config_check_runs.labels("failed").inc()
+            return None
+
+        for setting in SETTINGS:
+            metrics.config_check_deviations.labels(setting).set(
+                sum(1 for deviation in deviations if deviation.setting == setting)
+            )
+        for deviation in deviations:
+            log.warning(f"Configuration deviates from the recommended profile: {deviation.message}")
+        metrics.config_check_runs.labels("completed").inc()
+        return deviations
+
+    def _check_iommu(self, gpus: list[str]) -> list[Deviation]:
+        expected = self._profile.iommu_mode
+        if not expected:
+            return []
+        modes = {}
+        for gpu in gpus:
+            group = os.path.join(self._pci_devices_path(), gpu, "iommu_group")
+            if not os.path.exists(group):
+                modes[gpu] = "off"
+                continue
+            try:
+                domain_type = _read(os.path.join(group, "type"))
+            except FileNotFoundError:
+                # Kernels before 5.11 do not expose the domain type
+                cmdline = _read(os.path.join(self._proc_root, "cmdline")).split()
+                domain_type = "identity" if "iommu=pt" in cmdline else "DMA"
+            modes[gpu] = "passthrough" if domain_type == "identity" else "translated"
+
+        accepted = {"off"} if expected == "off" else {"off", "passthrough"}
+        deviating = sorted({mode for mode in modes.values() if mode not in accepted})
+        if not deviating:
+            return []
+        # The IOMMU mode is a node setting, reported once for all GPUs
+        gpus_deviating = [gpu for gpu, mode in modes.items() if mode not in accepted]
+        return [
+            Deviation(
+                setting=SETTING_IOMMU,
+                expected=expected,
+                actual=f"{'/'.join(deviating)} for GPUs {', '.join(gpus_deviating)}",
+            )
+        ]
+
+    def _check_acs(self, gpus: list[str]) -> list[Deviation]:
+        if not self._profile.acs_redirect_disabled:
+            return []
+        deviations = []
+        bridges = sorted({bridge for gpu in gpus for bridge in self._upstream_bridges(gpu)})
+        for bridge in bridges:
+            config = self._config_space(bridge)
+            if len(config) <= PCI_EXT_CAP_START:
+                log.warning(f"Skipping ACS check of {bridge}, its extended configuration space is not readable")
+                continue
+            acs = _find_extended_capability(config, PCI_EXT_CAP_ID_ACS)
+            if acs is None:
+                continue
+            control = int.from_bytes(config[acs + PCI_ACS_CTRL : acs + PCI_ACS_CTRL + 2], "little")
+            enabled = [name for bit, name in ((PCI_ACS_RR, "RR"), (PCI_ACS_CR, "CR")) if control & bit]
+            if enabled:
+                deviations.append(
+                    Deviation(
+                        setting=SETTING_ACS,
+                        expected="P2P redirect disabled",
+                        actual=f"P2P redirect enabled ({'+'.join(enabled)})",
+                        device=bridge,
+                    )
+                )
+        return deviations
+
+    def _check_max_payload(self, gpus: list[str]) -> list[Deviation]:
+        minimum = self._profile.min_pcie_max_payload_bytes
+        if not minimum:
+            return []
+        deviations = []
+        for gpu in gpus:
+            config = self._config_space(gpu)
+            express = _find_capability(config, PCI_CAP_ID_EXP)
+            if express is None or len(config) < express + PCI_EXP_DEVCTL + 2:
+                log.warning(f"Skipping max payload check of {gpu}, its PCI Express capability is not readable")
+                continue
+            control = int.from_bytes(config[express + PCI_EXP_DEVCTL : express + PCI_EXP_DEVCTL + 2], "little")
+            payload = 128 << ((control >> 5) & 0x7)
+            if payload < minimum:
+                deviations.append(
+                    Deviation(
+                        setting=SETTING_MAX_PAYLOAD,
+                        expected=f"at least {minimum} bytes",
+                        actual=f"{payload} bytes",
+                        device=gpu,
+                    )
+                )
+        return deviations
+
+    def _check_hugepages(self) -> list[Deviation]:
+        deviations = []
+        if self._profile.min_hugepages:
+            meminfo = _read(os.path.join(self._proc_root, "meminfo"))
+            match = re.search(r"^HugePages_Total:\s+(\d+)", meminfo, re.MULTILINE)
+            total = int(match.group(1)) if match else 0
+            if total < self._profile.min_hugepages:
+                deviations.append(
+                    Deviation(
+                        setting=SETTING_HUGEPAGES,
+                        expected=f"at least {self._profile.min_hugepages}",
+                        actual=str(total),
+                    )
+                )
+        if self._profile.transparent_hugepages:
+            enabled = _read(os.path.join(self._sys_root, "kernel", "mm", "transparent_hugepage", "enabled"))
+            # The active mode is in brackets: "always [madvise] never"
+            match = re.search(r"\[(\w+)\]", enabled)
+            mode = match.group(1) if match else enabled
+            if mode != self._profile.transparent_hugepages:
+                deviations.append(
+                    Deviation(setting=SETTING_THP, expected=self._profile.transparent_hugepages, actual=mode)
+                )
+        return deviations
+
+    def _check_peermem(self) -> list[Deviation]:
+        if not self._profile.require_peermem:
+            return []
+        if os.path.exists(os.path.join(self._sys_root, "module", "nvidia_peermem")):
+            return []
+        return [Deviation(setting=SETTING_PEERMEM, expected="loaded", actual="not loaded")]
+
+    def _check_cmdline(self) -> list[Deviation]:
+        if not self._profile.cmdline:
+            return []
+        parameters: dict[str, str] = {}
+        for word in shlex.split(_read(os.path.join(self._proc_root, "cmdline"))):
+            # The last occurrence of a parameter is the one the kernel applies
+            name, _, value = word.partition("=")
+            parameters[name] = value
+
+        deviations = []
+        for name, expected in sorted(self._profile.cmdline.items()):
+            actual = parameters.get(name)
+            if expected == CMDLINE_ABSENT:
+                if actual is None:
+                    continue
+                expected_description = "absent"
+            elif expected == CMDLINE_PRESENT:
+                if actual is not None:
+                    continue
+                expected_description = "present"
+            else:
+                if actual == expected:
+                    continue
+                expected_description = expected
+            deviations.append(
+                Deviation(
+                    setting=SETTING_CMDLINE,
+                    expected=expected_description,
+                    actual="absent" if actual is None else (actual or "present"),
+                    parameter=name,
+                    error_code=ERROR_CODE_DRIFT,
+                )
+            )
+        return deviations
+
+    def _check_sysctls(self) -> list[Deviation]:
+        deviations = []
+        for name, expected in sorted(self._profile.sysctls.items()):
+            try:
+                actual = " ".join(_read(os.path.join(self._proc_root, "sys", *name.split("."))).split())
+            except FileNotFoundError:
+                actual = "unavailable"
+            if actual == " ".join(expected.split()):
+                continue
+            deviations.append(
+                Deviation(
+                    setting=SETTING_SYSCTL,
+                    expected=expected,
+                    actual=actual,
+                    parameter=name,
+                    error_code=ERROR_CODE_DRIFT,
+                )
+            )
+        return deviations
+
+    def run_once(self) -> None:
+        """Checks the configuration and notifies the callbacks of the deviations."""
+        deviations = self.check()
+        if deviations is None:
+            return
+        for callback in self._callbacks:
+            try:
+                callback.configuration_checked(deviations)
+            except Exception as e:
+                log.exception(e)
+
+    def start(self, exit: Event) -> None:
+        # The configuration is checked right away, it does not disturb workloads
+        wait = 0
+        while not exit.wait(wait):
+            wait = self._interval_seconds
+            self.run_once()
+
+
+def _find_capability(config: bytes, capability_id: int) -> int | None:
+    """Returns the offset of a capability in the standard configuration space."""
+    if len(config) <= PCI_CAPABILITY_LIST or not config[PCI_STATUS] & PCI_STATUS_CAP_LIST:
+        return None
+    offset = config[PCI_CAPABILITY_LIST] & ~0x3
+    # The list holds at most 48 capabilities, which bounds malformed lists
+    for _ in range(48):
+        if offset < 0x40 or offset + 1 >= len(config):
+            return None
+        if config[offset] == capability_id:
+            return offset
+        offset = config[offset + 1] & ~0x3
+    return None
+
+
+def _find_extended_capability(config: bytes, capability_id: int) -> int | None:
+    """Returns the offset of a capability in the extended configuration space."""
+    offset = PCI_EXT_CAP_START
+    for _ in range(960):
+        if offset < PCI_EXT_CAP_START or offset + 4 > len(config):
+            return None
+        header = int.from_bytes(config[offset : offset + 4], "little")
+        if header == 0:
+            return None
+        if header & 0xFFFF == capability_id:
+            return offset
+        offset = (header >> 20) & 0xFFC
+    return None
@@ -0,0 +1,26 @@
+# Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+
+from prometheus_client import Counter, Gauge
+
+config_check_runs = Counter(
+    "config_check_runs",
+    "Number of configuration check runs by result",
+    labelnames=["result"],
+)
+config_check_deviations = Gauge(
+    "config_check_deviations",
+    "Number of devices or node settings deviating from the recommended profile, by setting",
+    labelnames=["setting"],
+)
@@ -14,7 +14,7 @@
 
 import dcgm_structs, dcgm_errors, dcgm_fields, dcgmvalue, pydcgm, bisect
 import logging as log
-from . import types, metrics
+from . import types, metrics, utilization
 from threading import Event
 from ctypes import *
 from functools import partial
@@ -35,6 +35,7 @@ class DCGMWatcher:
         poll_interval_seconds: int,
         callbacks: list[types.CallbackInterface],
         dcgm_k8s_service_enabled: bool,
+        utilization_window_seconds: int = 0,
     ) -> None:
         self._addr = addr
         self._poll_interval_seconds = poll_interval_seconds
@@ -50,6 +51,11 @@ class DCGMWatcher:
         self._callback_thread_pool = ThreadPoolExecutor()
         self._dcgm_k8s_service_enabled = dcgm_k8s_service_enabled
 
+        # Recent utilization of each GPU, attached to its fault events. Disabled with a window of 0.
+        self._utilization = (
+            utilization.UtilizationTracker(utilization_window_seconds) if utilization_window_seconds > 0 else None
+        )
+
     def _get_available_health_watches(self) -> dict[int, str]:
         health_watches = {}
         for var in dir(dcgm_structs):
@@ -248,13 +254,69 @@ class DCGMWatcher:
 
         return dcgm_group, gpu_ids, gpu_serials
 
+    def _utilization_field_ids(self) -> dict[str, int]:
+        return {
+            "gpu_util_percent": dcgm_fields.DCGM_FI_DEV_GPU_UTIL,
+            "mem_copy_util_percent": dcgm_fields.DCGM_FI_DEV_MEM_COPY_UTIL,
+            "fb_used_mib": dcgm_fields.DCGM_FI_DEV_FB_USED,
+            "fb_total_mib": dcgm_fields.DCGM_FI_DEV_FB_TOTAL,
+        }
+
+    def _watch_utilization_fields(
+        self, dcgm_handle: pydcgm.DcgmHandle, dcgm_group: pydcgm.DcgmGroup
+    ) -> "pydcgm.DcgmFieldGroup | None":
+        """Watches the utilization fields of the GPUs, sampled every poll interval and kept for the
+        utilization window. Returns None when utilization context is disabled or cannot be watched,
+        the fault events are then published without it."""
+        if self._utilization is None:
+            return None
+        try:
+            field_group = pydcgm.DcgmFieldGroup(
+                dcgm_handle, name="gpu_utilization", fieldIds=list(self._utilization_field_ids().values())
+            )
+            with metrics.dcgm_api_latency.labels("watch_fields").time():
+                dcgm_group.samples.WatchFields(
+                    field_group, self._poll_interval_seconds * 1000000, self._utilization.window_seconds, 0
+                )
+            return field_group
+        except Exception as e:
+            log.warning(f"Failed to watch GPU utilization fields, fault events will not carry utilization: {e}")
+            metrics.dcgm_api_failures.labels("watch_utilization_fields").inc()
+            return None
+
+    def _sample_utilization(self, dcgm_group: pydcgm.DcgmGroup, field_group: "pydcgm.DcgmFieldGroup") -> None:
+        try:
+            with metrics.dcgm_api_latency.labels("get_latest_values").time():
+                collection = dcgm_group.samples.GetLatest(field_group)
+        except Exception as e:
+            log.warning(f"Failed to read GPU utilization: {e}")
+            metrics.dcgm_api_failures.labels("get_utilization").inc()
+            return
+
+        now = time.monotonic()
+        for gpu_id, fields in collection.values.items():
+            sample = utilization.UtilizationSample(timestamp=now)
+            for name, field_id in self._utilization_field_ids().items():
+                series = fields.get(field_id)
+                if series is None or not series.values:
+                    continue
+                value = series.values[-1]
+                if not getattr(value, "isBlank", False):
+                    setattr(sample, name, int(value.value))
+            self._utilization.add(gpu_id, sample)
+
     def _cleanup_dcgm_resources(
         self,
         dcgm_group: pydcgm.DcgmGroup,
         dcgm_handle: pydcgm.DcgmHandle,
+        field_group: "pydcgm.DcgmFieldGroup | None" = None,
     ):
         """Clean up DCGM resources safely."""
+        if self._utilization is not None:
+            self._utilization.clear()
         try:
+            if field_group:
+                field_group.Delete()
             if dcgm_group:
                 dcgm_group.Delete()
                 dcgm_group = None
@@ -268,6 +330,7 @@ class DCGMWatcher:
     def start(self, fields_to_monitor: list[str], exit: Event) -> None:
         dcgm_handle = None
         dcgm_group = None
+        field_group = None
         gpu_ids = []
         gpu_serials = {}
 
@@ -278,36 +341,47 @@ class DCGMWatcher:
                     try:
                         dcgm_handle = self._get_dcgm_handle()
                         dcgm_group, gpu_ids, gpu_serials = self._initialize_dcgm_monitoring(dcgm_handle)
+                        field_group = self._watch_utilization_fields(dcgm_handle, dcgm_group)
                     except Exception as e:
                         log.error(f"Error getting DCGM handle: {e}")
                         self._fire_callback_funcs(types.CallbackInterface.dcgm_connectivity_failed.__name__, [])
-                        self._cleanup_dcgm_resources(dcgm_group, dcgm_handle)
+                        self._cleanup_dcgm_resources(dcgm_group, dcgm_handle, field_group)
                         dcgm_handle = None
                         dcgm_group = None
+                        field_group = None
                         gpu_ids = []
                         gpu_serials = {}
                 else:
+                    if field_group is not None:
+                        self._sample_utilization(dcgm_group, field_group)
+
                     log.debug("Running health check")
                     health_status, connectivity_success = self._perform_health_check(dcgm_group)
 
                     if not connectivity_success:
                         log.warning("DCGM connectivity failure detected")
-                        self._cleanup_dcgm_resources(dcgm_group, dcgm_handle)
+                        self._cleanup_dcgm_resources(dcgm_group, dcgm_handle, field_group)
                         dcgm_handle = None
                         dcgm_group = None
+                        field_group = None
                         gpu_ids = []
                         gpu_serials = {}
                     else:
                         log.debug("Publish DCGM health checks")
                         self._fire_callback_funcs(
                             types.CallbackInterface.health_event_occurred.__name__,
-                            [health_status, gpu_ids, gpu_serials],
+                            [
+                                health_status,
+                                gpu_ids,
+                                gpu_serials,
+                                self._utilization.contexts() if self._utilization is not None else {},
+                            ],
                         )
 
             log.debug("Waiting till next cycle")
             exit.wait(self._poll_interval_seconds)
 
         # Cleanup on exit
-        self._cleanup_dcgm_resources(dcgm_group, dcgm_handle)
+        self._cleanup_dcgm_resources(dcgm_group, dcgm_handle, field_group)
 
         self._callback_thread_pool.shutdown(cancel_futures=True)
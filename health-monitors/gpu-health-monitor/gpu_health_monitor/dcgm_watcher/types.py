@@ -13,6 +13,7 @@
 # limitations under the License.
 
 import abc, dataclasses, enum, dcgm_structs
+from .utilization import UtilizationContext
 
 
 class HealthStatus(enum.Enum):
@@ -42,7 +43,14 @@ class FieldDetails:
 
 class CallbackInterface(abc.ABC):
     @abc.abstractmethod
-    def health_event_occurred(self, health_details: dict[str, HealthDetails], gpu_ids: list[int]):
+    def health_event_occurred(
+        self,
+        health_details: dict[str, HealthDetails],
+        gpu_ids: list[int],
+        serials: dict[int, str],
+        utilization: dict[int, UtilizationContext] | None = None,
+    ):
+        """utilization holds the recent utilization of the GPUs, by GPU ID, when it is sampled."""
         pass
 
     @abc.abstractmethod
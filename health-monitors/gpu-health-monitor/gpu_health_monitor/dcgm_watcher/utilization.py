@@ -0,0 +1,111 @@
+# Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+
+import collections
+import dataclasses
+import time
+
+
+@dataclasses.dataclass
+class UtilizationSample:
+    timestamp: float
+    gpu_util_percent: int | None = None
+    mem_copy_util_percent: int | None = None
+    fb_used_mib: int | None = None
+    fb_total_mib: int | None = None
+
+
+@dataclasses.dataclass
+class UtilizationContext:
+    """Summary of the recent utilization of a GPU, attached to its fault events."""
+
+    window_seconds: int
+    samples: int
+    gpu_util_percent: int | None
+    gpu_util_avg_percent: int | None
+    gpu_util_max_percent: int | None
+    mem_copy_util_percent: int | None
+    fb_used_mib: int | None
+    fb_total_mib: int | None
+
+    def metadata(self) -> dict[str, str]:
+        """Returns the context as event metadata, leaving out unknown values."""
+        values = {
+            "gpu_util_percent": self.gpu_util_percent,
+            "gpu_util_avg_percent": self.gpu_util_avg_percent,
+            "gpu_util_max_percent": self.gpu_util_max_percent,
+            "mem_copy_util_percent": self.mem_copy_util_percent,
+            "fb_used_mib": self.fb_used_mib,
+            "fb_total_mib": self.fb_total_mib,
+        }
+        metadata = {key: str(value) for key, value in values.items() if value is not None}
+        if metadata:
+            metadata["utilization_window_seconds"] = str(self.window_seconds)
+        return metadata
+
+
+class UtilizationTracker:
+    """Keeps the utilization samples of each GPU over the last window_seconds."""
+
+    def __init__(self, window_seconds: int, clock=time.monotonic) -> None:
+        self._window_seconds = window_seconds
+        self._clock = clock
+        self._samples: dict[int, collections.deque[UtilizationSample]] = {}
+
+    @property
+    def window_seconds(self) -> int:
+        return self._window_seconds
+
+    def add(self, gpu_id: int, sample: UtilizationSample) -> None:
+        samples = self._samples.setdefault(gpu_id, collections.deque())
+        samples.append(sample)
+        self._expire(samples)
+
+    def clear(self) -> None:
+        self._samples = {}
+
+    def _expire(self, samples: collections.deque[UtilizationSample]) -> None:
+        oldest = self._clock() - self._window_seconds
+        while samples and samples[0].timestamp < oldest:
+            samples.popleft()
+
+    def context(self, gpu_id: int) -> UtilizationContext | None:
+        """Returns the summary of the samples of the GPU in the window, None without samples."""
+        samples = self._samples.get(gpu_id)
+        if samples:
+            self._expire(samples)
+        if not samples:
+            return None
+
+        latest = samples[-1]
+        utilizations = [sample.gpu_util_percent for sample in samples if sample.gpu_util_percent is not None]
+        return UtilizationContext(
+            window_seconds=self._window_seconds,
+            samples=len(samples),
+            gpu_util_percent=latest.gpu_util_percent,
+            gpu_util_avg_percent=round(sum(utilizations) / len(utilizations)) if utilizations else None,
+            gpu_util_max_percent=max(utilizations) if utilizations else None,
+            mem_copy_util_percent=latest.mem_copy_util_percent,
+            fb_used_mib=latest.fb_used_mib,
+            fb_total_mib=latest.fb_total_mib,
+        )
+
+    def contexts(self) -> dict[int, UtilizationContext]:
+        """Returns the summaries of all GPUs with samples in the window."""
+        contexts = {}
+        for gpu_id in list(self._samples):
+            context = self.context(gpu_id)
+            if context is not None:
+                contexts[gpu_id] = context
+        return contexts
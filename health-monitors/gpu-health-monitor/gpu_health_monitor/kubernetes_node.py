@@ -0,0 +1,60 @@
+# Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+
+import json
+import os
+import ssl
+import urllib.request
+from datetime import datetime
+
+SERVICE_ACCOUNT_DIR = "/var/run/secrets/kubernetes.io/serviceaccount"
+
+# Node annotation or label by which workload schedulers mark a node do not disturb,
+# see commons/pkg/donotdisturb. The value is true, or in the annotation an RFC 3339
+# time until which the node must not be disturbed.
+DO_NOT_DISTURB_KEY = "nvsentinel.nvidia.com/do-not-disturb"
+
+
+def get_node(node_name: str, timeout_seconds: int, service_account_dir: str = SERVICE_ACCOUNT_DIR) -> dict:
+    """Returns the node object from the API server, with the in-cluster credentials
+    of the pod."""
+    host = os.environ["KUBERNETES_SERVICE_HOST"]
+    if ":" in host:
+        host = f"[{host}]"
+    port = os.environ.get("KUBERNETES_SERVICE_PORT", "443")
+    with open(os.path.join(service_account_dir, "token"), "r") as f:
+        token = f.read().strip()
+    context = ssl.create_default_context(cafile=os.path.join(service_account_dir, "ca.crt"))
+    request = urllib.request.Request(
+        f"https://{host}:{port}/api/v1/nodes/{node_name}",
+        headers={"Authorization": f"Bearer {token}", "Accept": "application/json"},
+    )
+    with urllib.request.urlopen(request, timeout=timeout_seconds, context=context) as response:
+        return json.load(response)
+
+
+def do_not_disturb(node: dict, now: datetime) -> bool:
+    """Reports whether the annotations or the labels of the node mark it do not
+    disturb at now. now must be timezone aware."""
+    metadata = node.get("metadata", {})
+    if metadata.get("labels", {}).get(DO_NOT_DISTURB_KEY, "").strip().lower() == "true":
+        return True
+    value = metadata.get("annotations", {}).get(DO_NOT_DISTURB_KEY, "").strip()
+    if value.lower() == "true":
+        return True
+    try:
+        until = datetime.fromisoformat(value.replace("Z", "+00:00"))
+    except ValueError:
+        return False
+    return until.tzinfo is not None and now < until
@@ -0,0 +1,62 @@
+# Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+
+import os
+import subprocess
+from typing import Callable
+
+NVIDIA_SMI = "nvidia-smi"
+
+
+def run(command: list[str], timeout_seconds: int, env: dict[str, str] | None = None, check: bool = True) -> str:
+    return subprocess.run(command, capture_output=True, text=True, check=check, timeout=timeout_seconds, env=env).stdout
+
+
+def _run_csv(run: Callable[..., str], command: list[str], timeout_seconds: int) -> list[list[str]]:
+    return [
+        [column.strip() for column in line.split(",")] for line in run(command, timeout_seconds).splitlines() if line
+    ]
+
+
+def idle_gpus(run: Callable[..., str], timeout_seconds: int, idle_utilization_percent: int) -> list[int]:
+    """Returns the indexes of the GPUs with no compute process and a utilization of
+    at most idle_utilization_percent, so that active checks never compete with a
+    workload."""
+    gpus = _run_csv(
+        run,
+        [NVIDIA_SMI, "--query-gpu=index,pci.bus_id,utilization.gpu", "--format=csv,noheader,nounits"],
+        timeout_seconds,
+    )
+    compute_apps = _run_csv(
+        run, [NVIDIA_SMI, "--query-compute-apps=gpu_bus_id", "--format=csv,noheader"], timeout_seconds
+    )
+    busy_bus_ids = {row[0].lower() for row in compute_apps}
+    idle = []
+    for index, bus_id, utilization in gpus:
+        if bus_id.lower() in busy_bus_ids:
+            continue
+        try:
+            if int(utilization) > idle_utilization_percent:
+                continue
+        except ValueError:
+            # Utilization is not supported or not available, do not assume the GPU is idle
+            continue
+        idle.append(int(index))
+    return sorted(idle)
+
+
+def visible_devices_env(gpu_ids: list[int]) -> dict[str, str]:
+    """Returns the environment making only the given GPUs visible to CUDA, as devices
+    0 to len(gpu_ids)-1 in the order of their index."""
+    return dict(os.environ, CUDA_DEVICE_ORDER="PCI_BUS_ID", CUDA_VISIBLE_DEVICES=",".join(map(str, gpu_ids)))
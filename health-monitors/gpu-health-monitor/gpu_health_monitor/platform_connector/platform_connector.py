@@ -14,8 +14,11 @@
 
 import dataclasses
 import logging as log
+from gpu_health_monitor.bandwidth_probe import probe as bandwidthprobe
+from gpu_health_monitor.config_check import check as configcheck
 from gpu_health_monitor.dcgm_watcher import types as dcgmtypes
 from gpu_health_monitor.metadata import MetadataReader
+from gpu_health_monitor.sdc_screen import screen as sdcscreen
 from threading import Event
 
 from gpu_health_monitor.protos import (
@@ -26,11 +29,23 @@ from google.protobuf.timestamp_pb2 import Timestamp
 import grpc
 from . import metrics
 from time import sleep
+import os
 import re
+import time
 
 MAX_RETRIES = 10
 INITIAL_DELAY = 5
 
+# Crockford base32 alphabet ULIDs are encoded with
+CROCKFORD = "0123456789ABCDEFGHJKMNPQRSTVWXYZ"
+
+
+def new_event_id() -> str:
+    """Returns a new globally unique event ID: a ULID of a 48 bit millisecond
+    timestamp followed by 80 random bits, sorting by creation time."""
+    value = (int(time.time() * 1000) << 80) | int.from_bytes(os.urandom(10), "big")
+    return "".join(CROCKFORD[(value >> shift) & 0x1F] for shift in range(125, -1, -5))
+
 
 @dataclasses.dataclass
 class CachedEntityState:
@@ -38,7 +53,12 @@ class CachedEntityState:
     isHealthy: bool
 
 
-class PlatformConnectorEventProcessor(dcgmtypes.CallbackInterface):
+class PlatformConnectorEventProcessor(
+    dcgmtypes.CallbackInterface,
+    bandwidthprobe.BandwidthCallbackInterface,
+    sdcscreen.SDCCallbackInterface,
+    configcheck.ConfigCheckCallbackInterface,
+):
     def __init__(
         self,
         socket_path: str,
@@ -62,6 +82,8 @@ class PlatformConnectorEventProcessor(dcgmtypes.CallbackInterface):
         self.entity_cache: dict[str, CachedEntityState] = {}
         self.dcgm_health_conditions_categorization_mapping_config = dcgm_health_conditions_categorization_mapping_config
         self._metadata_reader = MetadataReader(metadata_path)
+        # Settings reported as deviating from the recommended profile, by Deviation.key
+        self._misconfigurations: dict[str, configcheck.Deviation] = {}
 
     def read_old_system_bootid_from_state_file(self) -> str:
         bootid = ""
@@ -129,7 +151,11 @@ class PlatformConnectorEventProcessor(dcgmtypes.CallbackInterface):
                 raise
 
     def health_event_occurred(
-        self, health_details: dict[str, dcgmtypes.HealthDetails], gpu_ids: list, serials: dict[int, str]
+        self,
+        health_details: dict[str, dcgmtypes.HealthDetails],
+        gpu_ids: list,
+        serials: dict[int, str],
+        utilization: dict[int, dcgmtypes.UtilizationContext] | None = None,
     ):
         with metrics.dcgm_health_events_publish_time_to_grpc_channel.labels(
             "dcgm_health_events_to_grpc_channel"
@@ -199,6 +225,9 @@ class PlatformConnectorEventProcessor(dcgmtypes.CallbackInterface):
                             chassis_serial = self._metadata_reader.get_chassis_serial()
                             if chassis_serial:
                                 event_metadata["chassis_serial"] = chassis_serial
+                            # Whether the GPU was idle or under load when it failed
+                            if not isHealthy and utilization and utilization.get(gpu_id):
+                                event_metadata.update(utilization[gpu_id].metadata())
 
                             health_events.append(
                                 platformconnector_pb2.HealthEvent(
@@ -296,6 +325,230 @@ class PlatformConnectorEventProcessor(dcgmtypes.CallbackInterface):
                     log.error(f"Exception while sending health events: {e}")
                     self.entity_cache = {}
 
+    def bandwidth_measured(
+        self, results: dict[int, list[bandwidthprobe.BandwidthResult]], degraded_threshold_percent: int
+    ):
+        """Publishes a non-fatal unhealthy event for each GPU whose measured bandwidth is
+        below degraded_threshold_percent of the expected bandwidth in any testcase, and a
+        healthy event once a later probe of a degraded GPU measures the expected bandwidth."""
+        timestamp = Timestamp()
+        timestamp.GetCurrentTime()
+        check_name = "GpuBandwidthProbe"
+        health_events = []
+        for gpu_id, gpu_results in sorted(results.items()):
+            degraded = [
+                result
+                for result in gpu_results
+                if result.measured_gbps < result.expected_gbps * degraded_threshold_percent / 100
+            ]
+            isHealthy = not degraded
+
+            key = self._build_cache_key(check_name, self._component_class, str(gpu_id))
+            cached = self.entity_cache.get(key)
+            # Like non-fatal DCGM watches, healthy results are only published to clear a degradation
+            if (cached is None and isHealthy) or (cached is not None and cached.isHealthy == isHealthy):
+                continue
+            self.entity_cache[key] = CachedEntityState(isFatal=False, isHealthy=isHealthy)
+            log.info(f"Updated cache for key {key} with value {self.entity_cache[key]}")
+
+            entities_impacted = [
+                platformconnector_pb2.Entity(entityType=self._component_class, entityValue=str(gpu_id))
+            ]
+            pci_address = self._metadata_reader.get_pci_address(gpu_id)
+            if pci_address:
+                entities_impacted.append(platformconnector_pb2.Entity(entityType="PCI", entityValue=pci_address))
+            gpu_uuid = self._metadata_reader.get_gpu_uuid(gpu_id)
+            if gpu_uuid:
+                entities_impacted.append(platformconnector_pb2.Entity(entityType="GPU_UUID", entityValue=gpu_uuid))
+
+            event_metadata = {}
+            chassis_serial = self._metadata_reader.get_chassis_serial()
+            if chassis_serial:
+                event_metadata["chassis_serial"] = chassis_serial
+            for result in gpu_results:
+                event_metadata[f"{result.testcase}.measured_gbps"] = f"{result.measured_gbps:.2f}"
+                event_metadata[f"{result.testcase}.expected_gbps"] = f"{result.expected_gbps:.2f}"
+
+            if isHealthy:
+                message = "GPU bandwidth probe measured the expected bandwidth"
+            else:
+                message = "; ".join(
+                    f"{result.testcase} measured {result.measured_gbps:.2f} GB/s, "
+                    f"expected {result.expected_gbps:.2f} GB/s"
+                    for result in degraded
+                )
+            health_events.append(
+                platformconnector_pb2.HealthEvent(
+                    version=self._version,
+                    agent=self._agent,
+                    componentClass=self._component_class,
+                    checkName=check_name,
+                    generatedTimestamp=timestamp,
+                    isFatal=False,
+                    isHealthy=isHealthy,
+                    errorCode=[] if isHealthy else ["GPU_BANDWIDTH_DEGRADED"],
+                    entitiesImpacted=entities_impacted,
+                    message=message,
+                    recommendedAction=(
+                        platformconnector_pb2.NONE if isHealthy else platformconnector_pb2.CONTACT_SUPPORT
+                    ),
+                    nodeName=self._node_name,
+                    metadata=event_metadata,
+                )
+            )
+            metrics.dcgm_health_active_events.labels(event_type=check_name, gpu_id=gpu_id, severity="non_fatal").set(
+                0 if isHealthy else 1
+            )
+
+        log.debug(f"bandwidth probe health events are {health_events}")
+        if len(health_events):
+            try:
+                self.send_health_event_with_retries(health_events)
+            except Exception as e:
+                log.error(f"Exception while sending bandwidth probe events: {e}")
+                self.entity_cache = {}
+
+    def sdc_screened(self, results: dict[int, list[sdcscreen.SDCResult]], burn_in: bool):
+        """Publishes a fatal SDC_SUSPECTED event for each GPU with a result that did not
+        match the known answer. SDC is often intermittent, so a GPU is only reported
+        healthy again by passing the burn-in screen after the node rebooted, e.g. once
+        the GPU was replaced, not by passing a periodic screen."""
+        timestamp = Timestamp()
+        timestamp.GetCurrentTime()
+        check_name = "GpuSdcScreen"
+        health_events = []
+        for gpu_id, gpu_results in sorted(results.items()):
+            mismatched = [result for result in gpu_results if result.mismatches > 0]
+            isHealthy = not mismatched
+
+            key = self._build_cache_key(check_name, self._component_class, str(gpu_id))
+            cached = self.entity_cache.get(key)
+            if isHealthy and not burn_in:
+                continue
+            if cached is not None and cached.isHealthy == isHealthy:
+                continue
+            self.entity_cache[key] = CachedEntityState(isFatal=not isHealthy, isHealthy=isHealthy)
+            log.info(f"Updated cache for key {key} with value {self.entity_cache[key]}")
+
+            entities_impacted = [
+                platformconnector_pb2.Entity(entityType=self._component_class, entityValue=str(gpu_id))
+            ]
+            pci_address = self._metadata_reader.get_pci_address(gpu_id)
+            if pci_address:
+                entities_impacted.append(platformconnector_pb2.Entity(entityType="PCI", entityValue=pci_address))
+            gpu_uuid = self._metadata_reader.get_gpu_uuid(gpu_id)
+            if gpu_uuid:
+                entities_impacted.append(platformconnector_pb2.Entity(entityType="GPU_UUID", entityValue=gpu_uuid))
+
+            event_metadata = {"sdc_screen": "burn_in" if burn_in else "periodic"}
+            chassis_serial = self._metadata_reader.get_chassis_serial()
+            if chassis_serial:
+                event_metadata["chassis_serial"] = chassis_serial
+            for result in gpu_results:
+                event_metadata[f"{result.test}.mismatches"] = str(result.mismatches)
+
+            if isHealthy:
+                message = "GPU SDC screen results matched the known answers"
+            else:
+                message = "; ".join(
+                    f"{result.test} returned {result.mismatches} results not matching the known answer"
+                    + (f": {result.message}" if result.message else "")
+                    for result in mismatched
+                )
+            health_events.append(
+                platformconnector_pb2.HealthEvent(
+                    version=self._version,
+                    agent=self._agent,
+                    componentClass=self._component_class,
+                    checkName=check_name,
+                    generatedTimestamp=timestamp,
+                    isFatal=not isHealthy,
+                    isHealthy=isHealthy,
+                    errorCode=[] if isHealthy else ["SDC_SUSPECTED"],
+                    entitiesImpacted=entities_impacted,
+                    message=message,
+                    recommendedAction=(
+                        platformconnector_pb2.NONE if isHealthy else platformconnector_pb2.CONTACT_SUPPORT
+                    ),
+                    nodeName=self._node_name,
+                    metadata=event_metadata,
+                )
+            )
+            metrics.dcgm_health_active_events.labels(event_type=check_name, gpu_id=gpu_id, severity="fatal").set(
+                0 if isHealthy else 1
+            )
+
+        log.debug(f"SDC screen health events are {health_events}")
+        if len(health_events):
+            try:
+                self.send_health_event_with_retries(health_events)
+            except Exception as e:
+                log.error(f"Exception while sending SDC screen events: {e}")
+                self.entity_cache = {}
+
+    def configuration_checked(self, deviations: list[configcheck.Deviation]):
+        """Publishes a non-fatal MISCONFIGURED event for each setting deviating from the
+        recommended profile, a CONFIG_DRIFT event for each kernel command line parameter
+        or sysctl drifting from the baseline, and a healthy event once a reported setting
+        is fixed."""
+        timestamp = Timestamp()
+        timestamp.GetCurrentTime()
+        check_name = "GpuConfigCheck"
+        current = {deviation.key: deviation for deviation in deviations}
+        health_events = []
+        for key, deviation in sorted(current.items()):
+            if key not in self._misconfigurations:
+                health_events.append(self._config_check_event(check_name, timestamp, deviation, isHealthy=False))
+        for key, deviation in sorted(self._misconfigurations.items()):
+            if key not in current:
+                health_events.append(self._config_check_event(check_name, timestamp, deviation, isHealthy=True))
+
+        log.debug(f"configuration check health events are {health_events}")
+        if len(health_events):
+            try:
+                self.send_health_event_with_retries(health_events)
+            except Exception as e:
+                # The reported settings are kept, so the next check publishes the events again
+                log.error(f"Exception while sending configuration check events: {e}")
+                return
+        self._misconfigurations = current
+
+    def _config_check_event(
+        self, check_name: str, timestamp: Timestamp, deviation: configcheck.Deviation, isHealthy: bool
+    ) -> platformconnector_pb2.HealthEvent:
+        entities_impacted = [platformconnector_pb2.Entity(entityType="SETTING", entityValue=deviation.setting)]
+        if deviation.device:
+            entities_impacted.append(platformconnector_pb2.Entity(entityType="PCI", entityValue=deviation.device))
+        if deviation.parameter:
+            entities_impacted.append(
+                platformconnector_pb2.Entity(entityType="PARAMETER", entityValue=deviation.parameter)
+            )
+
+        event_metadata = {"setting": deviation.setting, "expected": deviation.expected}
+        if not isHealthy:
+            event_metadata["actual"] = deviation.actual
+        chassis_serial = self._metadata_reader.get_chassis_serial()
+        if chassis_serial:
+            event_metadata["chassis_serial"] = chassis_serial
+
+        return platformconnector_pb2.HealthEvent(
+            version=self._version,
+            agent=self._agent,
+            componentClass=self._component_class,
+            checkName=check_name,
+            generatedTimestamp=timestamp,
+            isFatal=False,
+            isHealthy=isHealthy,
+            errorCode=[] if isHealthy else [deviation.error_code],
+            entitiesImpacted=entities_impacted,
+            message=(
+                f"{deviation.key} matches the recommended profile" if isHealthy else deviation.message
+            ),
+            recommendedAction=platformconnector_pb2.NONE,
+            nodeName=self._node_name,
+            metadata=event_metadata,
+        )
+
     def get_recommended_action_from_dcgm_error_map(self, error_code):
         if error_code in self.dcgm_errors_info_dict:
             recommended_action = self.dcgm_errors_info_dict[error_code]
@@ -305,6 +558,11 @@ class PlatformConnectorEventProcessor(dcgmtypes.CallbackInterface):
         return platformconnector_pb2.RecommendedAction.CONTACT_SUPPORT
 
     def send_health_event_with_retries(self, health_events: list[platformconnector_pb2.HealthEvent]):
+        # IDs are assigned once so that retries of an event keep its ID
+        for health_event in health_events:
+            if not health_event.id:
+                health_event.id = new_event_id()
+
         delay = INITIAL_DELAY
         for _ in range(MAX_RETRIES):
             with grpc.insecure_channel(f"unix://{self._socket_path}") as chan:
@@ -19,15 +19,32 @@ class RecommendedAction(int, metaclass=_enum_type_wrapper.EnumTypeWrapper):
     RESTART_VM: _ClassVar[RecommendedAction]
     RESTART_BM: _ClassVar[RecommendedAction]
     REPLACE_VM: _ClassVar[RecommendedAction]
+    DRIVER_RELOAD: _ClassVar[RecommendedAction]
+    PREEMPTION_IMMINENT: _ClassVar[RecommendedAction]
+    MIG_RECONFIGURE: _ClassVar[RecommendedAction]
+    POWER_OFF: _ClassVar[RecommendedAction]
+    POWER_CAP: _ClassVar[RecommendedAction]
     UNKNOWN: _ClassVar[RecommendedAction]
 
+class Priority(int, metaclass=_enum_type_wrapper.EnumTypeWrapper):
+    __slots__ = ()
+    PRIORITY_NORMAL: _ClassVar[Priority]
+    PRIORITY_HIGH: _ClassVar[Priority]
+
 NONE: RecommendedAction
 COMPONENT_RESET: RecommendedAction
 CONTACT_SUPPORT: RecommendedAction
 RESTART_VM: RecommendedAction
 RESTART_BM: RecommendedAction
 REPLACE_VM: RecommendedAction
+DRIVER_RELOAD: RecommendedAction
+PREEMPTION_IMMINENT: RecommendedAction
+MIG_RECONFIGURE: RecommendedAction
+POWER_OFF: RecommendedAction
+POWER_CAP: RecommendedAction
 UNKNOWN: RecommendedAction
+PRIORITY_NORMAL: Priority
+PRIORITY_HIGH: Priority
 
 class HealthEvents(_message.Message):
     __slots__ = ("version", "events")
@@ -64,6 +81,11 @@ class HealthEvent(_message.Message):
         "nodeName",
         "quarantineOverrides",
         "drainOverrides",
+        "priority",
+        "causedById",
+        "supersedesId",
+        "correlationId",
+        "id",
     )
 
     class MetadataEntry(_message.Message):
@@ -89,6 +111,11 @@ class HealthEvent(_message.Message):
     NODENAME_FIELD_NUMBER: _ClassVar[int]
     QUARANTINEOVERRIDES_FIELD_NUMBER: _ClassVar[int]
     DRAINOVERRIDES_FIELD_NUMBER: _ClassVar[int]
+    PRIORITY_FIELD_NUMBER: _ClassVar[int]
+    CAUSEDBYID_FIELD_NUMBER: _ClassVar[int]
+    SUPERSEDESID_FIELD_NUMBER: _ClassVar[int]
+    CORRELATIONID_FIELD_NUMBER: _ClassVar[int]
+    ID_FIELD_NUMBER: _ClassVar[int]
     version: int
     agent: str
     componentClass: str
@@ -104,6 +131,11 @@ class HealthEvent(_message.Message):
     nodeName: str
     quarantineOverrides: BehaviourOverrides
     drainOverrides: BehaviourOverrides
+    priority: Priority
+    causedById: str
+    supersedesId: str
+    correlationId: str
+    id: str
     def __init__(
         self,
         version: _Optional[int] = ...,
@@ -121,6 +153,11 @@ class HealthEvent(_message.Message):
         nodeName: _Optional[str] = ...,
         quarantineOverrides: _Optional[_Union[BehaviourOverrides, _Mapping]] = ...,
         drainOverrides: _Optional[_Union[BehaviourOverrides, _Mapping]] = ...,
+        priority: _Optional[_Union[Priority, str]] = ...,
+        causedById: _Optional[str] = ...,
+        supersedesId: _Optional[str] = ...,
+        correlationId: _Optional[str] = ...,
+        id: _Optional[str] = ...,
     ) -> None: ...
 
 class BehaviourOverrides(_message.Message):
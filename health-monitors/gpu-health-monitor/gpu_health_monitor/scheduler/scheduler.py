@@ -0,0 +1,180 @@
+# Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+
+import dataclasses
+import logging as log
+import os
+import random
+import re
+from datetime import datetime, timezone
+from threading import Event, Semaphore, Thread
+from typing import Callable
+
+from gpu_health_monitor import kubernetes_node, nvidia_smi
+from . import metrics
+
+
+@dataclasses.dataclass
+class ScheduledCheck:
+    name: str
+    # run runs the check once and notifies its callbacks
+    run: Callable[[], None]
+    interval_seconds: int
+    # Every wait is extended by a random delay of up to jitter_seconds, so the
+    # checks of the nodes of a cluster do not run in lockstep
+    jitter_seconds: int = 0
+    # Wait before the first run, the interval by default
+    initial_delay_seconds: int | None = None
+    # Disruptive checks load the GPUs: they count against the concurrency limit and
+    # are suppressed while latency-sensitive workloads run. Read-only checks are not
+    disruptive: bool = True
+
+
+class WorkloadSensitivity:
+    """Tells whether the node runs latency-sensitive workloads that active checks must
+    not disturb: while a workload scheduler marks the node do not disturb, while the
+    marker file exists, e.g. created by the prolog of an inference service, or while
+    a compute process whose name matches one of the patterns runs on a GPU.
+
+    The do not disturb mark is only read with a node name. Failing to read it does not
+    suppress the checks, like for the other NVSentinel components."""
+
+    def __init__(
+        self,
+        process_patterns: list[str],
+        marker_file: str,
+        timeout_seconds: int,
+        run: Callable[..., str] = nvidia_smi.run,
+        node_name: str = "",
+        get_node: Callable[[str, int], dict] = kubernetes_node.get_node,
+    ) -> None:
+        self._process_patterns = [re.compile(pattern) for pattern in process_patterns]
+        self._marker_file = marker_file
+        self._timeout_seconds = timeout_seconds
+        self._run = run
+        self._node_name = node_name
+        self._get_node = get_node
+
+    def _do_not_disturb(self) -> bool:
+        if not self._node_name:
+            return False
+        try:
+            node = self._get_node(self._node_name, self._timeout_seconds)
+        except Exception as e:
+            log.warning(f"Failed to check node {self._node_name} for the do not disturb mark: {e}")
+            return False
+        return kubernetes_node.do_not_disturb(node, datetime.now(timezone.utc))
+
+    def reason(self) -> str | None:
+        """Returns why active checks are suppressed, or None if they may run."""
+        if self._do_not_disturb():
+            return f"node {self._node_name} is marked do not disturb"
+        if self._marker_file and os.path.exists(self._marker_file):
+            return f"marker file {self._marker_file} exists"
+        if not self._process_patterns:
+            return None
+        try:
+            output = self._run(
+                [nvidia_smi.NVIDIA_SMI, "--query-compute-apps=process_name", "--format=csv,noheader"],
+                self._timeout_seconds,
+            )
+        except Exception as e:
+            # The workloads are unknown, do not risk disturbing them
+            return f"failed to list compute processes: {e}"
+        for process_name in output.splitlines():
+            process_name = process_name.strip()
+            for pattern in self._process_patterns:
+                if process_name and pattern.search(process_name):
+                    return f"latency-sensitive process {process_name} is running"
+        return None
+
+
+class Scheduler:
+    """Runs the active checks of the agent, each on its own interval with jitter. At
+    most max_concurrent disruptive checks run at a time, the others wait for a slot.
+    While the workload sensitivity reports a reason, disruptive checks are skipped
+    and retried after suppressed_retry_seconds instead of their interval."""
+
+    def __init__(
+        self,
+        checks: list[ScheduledCheck],
+        max_concurrent: int,
+        sensitivity: WorkloadSensitivity | None,
+        suppressed_retry_seconds: int,
+        uniform: Callable[[float, float], float] = random.uniform,
+    ) -> None:
+        self._checks = checks
+        self._slots = Semaphore(max(max_concurrent, 1))
+        self._sensitivity = sensitivity
+        self._suppressed_retry_seconds = suppressed_retry_seconds
+        self._uniform = uniform
+
+    def _with_jitter(self, check: ScheduledCheck, seconds: float) -> float:
+        if check.jitter_seconds <= 0:
+            return seconds
+        return seconds + self._uniform(0, check.jitter_seconds)
+
+    def _acquire(self, exit: Event) -> bool:
+        while not self._slots.acquire(timeout=1):
+            if exit.is_set():
+                return False
+        return True
+
+    def run_check(self, check: ScheduledCheck, exit: Event) -> str:
+        """Runs the check once, unless it is suppressed. Returns the result:
+        completed, failed, suppressed or, if the agent exits while the check waits for
+        a slot, cancelled."""
+        if not check.disruptive:
+            return self._run(check)
+        if not self._acquire(exit):
+            return "cancelled"
+        try:
+            reason = self._sensitivity.reason() if self._sensitivity is not None else None
+            if reason is not None:
+                log.info(f"Suppressing active check {check.name}: {reason}")
+                metrics.active_check_runs.labels(check.name, "suppressed").inc()
+                return "suppressed"
+            return self._run(check)
+        finally:
+            self._slots.release()
+
+    def _run(self, check: ScheduledCheck) -> str:
+        metrics.active_checks_running.inc()
+        try:
+            check.run()
+            result = "completed"
+        except Exception as e:
+            log.exception(f"Active check {check.name} failed: {e}")
+            result = "failed"
+        finally:
+            metrics.active_checks_running.dec()
+        metrics.active_check_runs.labels(check.name, result).inc()
+        return result
+
+    def _loop(self, check: ScheduledCheck, exit: Event) -> None:
+        initial_delay = check.interval_seconds if check.initial_delay_seconds is None else check.initial_delay_seconds
+        wait = self._with_jitter(check, initial_delay)
+        while not exit.wait(wait):
+            result = self.run_check(check, exit)
+            interval = self._suppressed_retry_seconds if result == "suppressed" else check.interval_seconds
+            wait = self._with_jitter(check, interval)
+
+    def start(self, exit: Event) -> list[Thread]:
+        """Starts a thread per check, which runs until exit is set."""
+        threads = []
+        for check in self._checks:
+            thread = Thread(target=self._loop, args=(check, exit), name=f"active-check-{check.name}", daemon=True)
+            thread.start()
+            threads.append(thread)
+        return threads
@@ -0,0 +1,31 @@
+# Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+
+from prometheus_client import Counter, Histogram
+
+sdc_screen_runs = Counter(
+    "sdc_screen_runs",
+    "Number of silent data corruption screen runs by kind and result",
+    labelnames=["kind", "result"],
+)
+sdc_screen_duration = Histogram(
+    "sdc_screen_duration_seconds",
+    "Amount of time spent running the silent data corruption screen",
+    buckets=[10, 30, 60, 120, 300, 600, 1200],
+)
+sdc_screen_mismatches = Counter(
+    "sdc_screen_mismatches",
+    "Number of results that did not match the known answer, by GPU and test",
+    labelnames=["gpu_id", "test"],
+)
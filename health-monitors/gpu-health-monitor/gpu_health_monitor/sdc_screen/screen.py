@@ -0,0 +1,181 @@
+# Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+
+import dataclasses
+import json
+import logging as log
+import shlex
+from threading import Event
+from typing import Callable
+
+from gpu_health_monitor import nvidia_smi
+from . import metrics
+
+BOOT_ID_PATH = "/proc/sys/kernel/random/boot_id"
+
+
+@dataclasses.dataclass
+class SDCResult:
+    test: str
+    mismatches: int
+    message: str = ""
+
+
+class SDCCallbackInterface:
+    def sdc_screened(self, results: dict[int, list[SDCResult]], burn_in: bool):
+        """Called with the results of the GPUs a screen ran on, keyed by GPU index.
+        burn_in is set for the screen run after the node booted."""
+        pass
+
+
+def parse_screen_output(output: str, gpu_ids: list[int]) -> dict[int, list[SDCResult]]:
+    """Returns the results per GPU index from the JSON report of a screening tool:
+
+        {"results": [{"gpu": 0, "test": "hgemm", "mismatches": 0, "message": ""}]}
+
+    gpu is the CUDA device ordinal of the GPU, i.e. its position among the screened
+    GPUs. Every screened GPU must be reported, a screen without results for a GPU
+    did not check it."""
+    results: dict[int, list[SDCResult]] = {}
+    for result in json.loads(output).get("results", []):
+        position = int(result["gpu"])
+        if not 0 <= position < len(gpu_ids):
+            raise ValueError(f"result for unknown GPU {position}, {len(gpu_ids)} GPUs were screened")
+        results.setdefault(gpu_ids[position], []).append(
+            SDCResult(
+                test=str(result.get("test", "")),
+                mismatches=int(result.get("mismatches", 0)),
+                message=str(result.get("message", "")),
+            )
+        )
+    missing = [gpu_id for gpu_id in gpu_ids if gpu_id not in results]
+    if missing:
+        raise ValueError(f"no results for GPUs {missing}")
+    return results
+
+
+class SDCScreen:
+    """Runs a silent data corruption screening tool, e.g. compute kernels with known
+    answers, on the idle GPUs. SDC is invisible to log based monitoring: the GPU
+    reports no error while returning wrong results.
+
+    With a burn-in state file, the first screen after the node booted runs right away
+    (burn-in) and records the boot ID in the file. Other screens run every interval.
+    The tool exits with any status but must print a JSON report, see
+    parse_screen_output."""
+
+    def __init__(
+        self,
+        command: str,
+        interval_seconds: int,
+        timeout_seconds: int,
+        idle_utilization_percent: int,
+        burn_in_state_file: str,
+        callbacks: list[SDCCallbackInterface],
+        run: Callable[..., str] = nvidia_smi.run,
+        boot_id_path: str = BOOT_ID_PATH,
+    ) -> None:
+        self._command = shlex.split(command)
+        self._interval_seconds = interval_seconds
+        self._timeout_seconds = timeout_seconds
+        self._idle_utilization_percent = idle_utilization_percent
+        self._burn_in_state_file = burn_in_state_file
+        self._callbacks = callbacks
+        self._run = run
+        self._boot_id_path = boot_id_path
+
+    def screen(self, kind: str) -> dict[int, list[SDCResult]] | None:
+        """Runs the screen once on the idle GPUs. Returns the results per GPU index,
+        or None if the screen was skipped or failed."""
+        try:
+            gpu_ids = nvidia_smi.idle_gpus(self._run, self._timeout_seconds, self._idle_utilization_percent)
+        except Exception as e:
+            log.error(f"Failed to find idle GPUs for the SDC screen: {e}")
+            metrics.sdc_screen_runs.labels(kind, "failed").inc()
+            return None
+        if not gpu_ids:
+            log.info(f"Skipping {kind} SDC screen, no GPU is idle")
+            metrics.sdc_screen_runs.labels(kind, "skipped_busy").inc()
+            return None
+
+        log.info(f"Running {kind} SDC screen on GPUs {gpu_ids}: {self._command}")
+        try:
+            with metrics.sdc_screen_duration.time():
+                output = self._run(
+                    self._command, self._timeout_seconds, nvidia_smi.visible_devices_env(gpu_ids), check=False
+                )
+            results = parse_screen_output(output, gpu_ids)
+        except Exception as e:
+            log.error(f"SDC screen failed: {e}")
+            metrics.sdc_screen_runs.labels(kind, "failed").inc()
+            return None
+
+        for gpu_id, gpu_results in results.items():
+            for result in gpu_results:
+                if result.mismatches:
+                    log.error(f"SDC screen {result.test} on GPU {gpu_id}: {result.mismatches} mismatches")
+                    metrics.sdc_screen_mismatches.labels(str(gpu_id), result.test).inc(result.mismatches)
+        metrics.sdc_screen_runs.labels(kind, "completed").inc()
+        return results
+
+    def _boot_id(self) -> str:
+        with open(self._boot_id_path, "r") as f:
+            return f.read().strip()
+
+    def _burn_in_pending(self) -> bool:
+        if not self._burn_in_state_file:
+            return False
+        try:
+            with open(self._burn_in_state_file, "r") as f:
+                return f.read().strip() != self._boot_id()
+        except FileNotFoundError:
+            return True
+        except OSError as e:
+            log.error(f"Failed to read the SDC burn-in state: {e}")
+            return False
+
+    def _record_burn_in(self) -> None:
+        with open(self._burn_in_state_file, "w") as f:
+            f.write(self._boot_id())
+
+    def _notify(self, results: dict[int, list[SDCResult]], burn_in: bool) -> None:
+        for callback in self._callbacks:
+            try:
+                callback.sdc_screened(results, burn_in)
+            except Exception as e:
+                log.exception(e)
+
+    def initial_delay_seconds(self) -> int:
+        # The burn-in screen runs right away, and again every interval until it ran
+        return 0 if self._burn_in_pending() else self._interval_seconds
+
+    def run_once(self) -> None:
+        """Runs a burn-in screen if one is pending, a periodic screen otherwise, and
+        notifies the callbacks of the results."""
+        burn_in = self._burn_in_pending()
+        results = self.screen("burn_in" if burn_in else "periodic")
+        if not results:
+            return
+        self._notify(results, burn_in)
+        if burn_in:
+            try:
+                self._record_burn_in()
+            except OSError as e:
+                log.error(f"Failed to record the SDC burn-in: {e}")
+
+    def start(self, exit: Event) -> None:
+        wait = self.initial_delay_seconds()
+        while not exit.wait(wait):
+            wait = self._interval_seconds
+            self.run_once()
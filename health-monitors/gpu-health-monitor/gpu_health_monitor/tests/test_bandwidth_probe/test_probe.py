@@ -0,0 +1,138 @@
+# Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+
+import json
+from threading import Event
+from gpu_health_monitor.bandwidth_probe import BandwidthProbe, BandwidthResult, parse_nvbandwidth_output
+from gpu_health_monitor.bandwidth_probe.probe import BandwidthCallbackInterface
+
+
+def nvbandwidth_output(*testcases) -> str:
+    return json.dumps({"nvbandwidth": {"version": "v0.7", "testcases": list(testcases)}})
+
+
+HOST_TO_DEVICE = {
+    "name": "host_to_device_memcpy_ce",
+    "status": "Passed",
+    "bandwidth_matrix": [["55.20", "12.40", "54.90"]],
+}
+DEVICE_TO_DEVICE = {
+    "name": "device_to_device_memcpy_read_ce",
+    "status": "Passed",
+    "bandwidth_matrix": [["N/A", "700.1", "698.5"], ["701.0", "N/A", "350.2"], ["699.9", "702.3", "N/A"]],
+}
+
+
+class FakeRunner:
+    def __init__(self, gpus: str, compute_apps: str, output: str) -> None:
+        self.gpus = gpus
+        self.compute_apps = compute_apps
+        self.output = output
+        self.benchmark_command = None
+        self.benchmark_env = None
+
+    def __call__(self, command, timeout_seconds, env=None):
+        if command[0] == "nvidia-smi":
+            return self.compute_apps if "--query-compute-apps=gpu_bus_id" in command else self.gpus
+        self.benchmark_command = command
+        self.benchmark_env = env
+        return self.output
+
+
+class RecordingCallback(BandwidthCallbackInterface):
+    def __init__(self, exit: Event) -> None:
+        self.exit = exit
+        self.results = None
+
+    def bandwidth_measured(self, results, degraded_threshold_percent):
+        self.results = results
+        self.exit.set()
+
+
+def new_probe(runner: FakeRunner, callbacks=None) -> BandwidthProbe:
+    return BandwidthProbe(
+        command="nvbandwidth --json",
+        expected_gbps={"host_to_device_memcpy_ce": 55.0, "device_to_device_memcpy_read_ce": 700.0},
+        interval_seconds=0,
+        timeout_seconds=10,
+        idle_utilization_percent=0,
+        degraded_threshold_percent=80,
+        callbacks=callbacks or [],
+        run=runner,
+    )
+
+
+def test_parse_nvbandwidth_output():
+    measured = parse_nvbandwidth_output(nvbandwidth_output(HOST_TO_DEVICE, DEVICE_TO_DEVICE), [0, 2, 5])
+    assert measured["host_to_device_memcpy_ce"] == {0: 55.2, 2: 12.4, 5: 54.9}
+    # A slow link lowers the bandwidth of both GPUs it connects
+    assert measured["device_to_device_memcpy_read_ce"] == {0: 698.5, 2: 350.2, 5: 350.2}
+
+
+def test_parse_nvbandwidth_output_skips_failed_testcases():
+    failed = {"name": "host_to_device_memcpy_ce", "status": "Error", "bandwidth_matrix": []}
+    assert parse_nvbandwidth_output(nvbandwidth_output(failed), [0]) == {}
+
+
+def test_probe_runs_on_idle_gpus():
+    runner = FakeRunner(
+        gpus="0, 00000000:17:00.0, 0\n1, 00000000:2A:00.0, 0\n2, 00000000:3D:00.0, 87\n3, 00000000:63:00.0, 0\n",
+        compute_apps="00000000:2A:00.0\n",
+        output=nvbandwidth_output(
+            {"name": "host_to_device_memcpy_ce", "status": "Passed", "bandwidth_matrix": [["55.0", "20.0"]]}
+        ),
+    )
+    results = new_probe(runner).probe()
+
+    assert runner.benchmark_env["CUDA_VISIBLE_DEVICES"] == "0,3"
+    assert runner.benchmark_env["CUDA_DEVICE_ORDER"] == "PCI_BUS_ID"
+    assert runner.benchmark_command == [
+        "nvbandwidth",
+        "--json",
+        "-t",
+        "host_to_device_memcpy_ce",
+        "-t",
+        "device_to_device_memcpy_read_ce",
+    ]
+    assert results == {
+        0: [BandwidthResult(testcase="host_to_device_memcpy_ce", measured_gbps=55.0, expected_gbps=55.0)],
+        3: [BandwidthResult(testcase="host_to_device_memcpy_ce", measured_gbps=20.0, expected_gbps=55.0)],
+    }
+
+
+def test_probe_skipped_when_no_gpu_is_idle():
+    runner = FakeRunner(gpus="0, 00000000:17:00.0, 100\n1, 00000000:2A:00.0, [N/A]\n", compute_apps="", output="")
+    assert new_probe(runner).probe() is None
+    assert runner.benchmark_command is None
+
+
+def test_probe_failure():
+    runner = FakeRunner(gpus="0, 00000000:17:00.0, 0\n", compute_apps="", output="not json")
+    assert new_probe(runner).probe() is None
+
+
+def test_start_invokes_callbacks():
+    exit = Event()
+    callback = RecordingCallback(exit)
+    runner = FakeRunner(
+        gpus="0, 00000000:17:00.0, 0\n",
+        compute_apps="",
+        output=nvbandwidth_output(
+            {"name": "host_to_device_memcpy_ce", "status": "Passed", "bandwidth_matrix": [["54.0"]]}
+        ),
+    )
+    new_probe(runner, [callback]).start(exit)
+    assert callback.results == {
+        0: [BandwidthResult(testcase="host_to_device_memcpy_ce", measured_gbps=54.0, expected_gbps=55.0)]
+    }
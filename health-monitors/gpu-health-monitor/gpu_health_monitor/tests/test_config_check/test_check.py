@@ -0,0 +1,211 @@
+# Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+
+import os
+import tempfile
+from threading import Event
+import pytest
+from gpu_health_monitor.config_check import ConfigCheck, ConfigCheckCallbackInterface, Deviation, Profile
+
+GPU = "0000:03:00.0"
+SWITCH_PORT = "0000:02:00.0"
+ROOT_PORT = "0000:00:01.0"
+
+
+def config_space(max_payload_bytes: int = 256, acs_control: int | None = None) -> bytes:
+    """Returns a 4 KiB configuration space with a PCI Express capability at 0x40
+    and, if acs_control is set, an ACS extended capability at 0x100."""
+    config = bytearray(4096)
+    config[0x06] = 0x10
+    config[0x34] = 0x40
+    config[0x40] = 0x10
+    encoded = {128: 0, 256: 1, 512: 2}[max_payload_bytes]
+    config[0x48:0x4A] = (encoded << 5).to_bytes(2, "little")
+    if acs_control is not None:
+        config[0x100:0x104] = (0x000D | 1 << 16).to_bytes(4, "little")
+        config[0x106:0x108] = acs_control.to_bytes(2, "little")
+    return bytes(config)
+
+
+def write(path: str, content: str | bytes) -> None:
+    os.makedirs(os.path.dirname(path), exist_ok=True)
+    with open(path, "wb" if isinstance(content, bytes) else "w") as f:
+        f.write(content)
+
+
+@pytest.fixture
+def host():
+    """A host with a GPU behind a PCIe switch port, with IOMMU in translated mode,
+    ACS P2P redirect enabled on the switch port and a 128 bytes max payload."""
+    root = tempfile.mkdtemp()
+    sys_root = os.path.join(root, "sys")
+    proc_root = os.path.join(root, "proc")
+
+    devices = os.path.join(sys_root, "devices", "pci0000:00")
+    root_port = os.path.join(devices, ROOT_PORT)
+    switch_port = os.path.join(root_port, SWITCH_PORT)
+    gpu = os.path.join(switch_port, GPU)
+    write(os.path.join(root_port, "config"), config_space(acs_control=0x01))
+    write(os.path.join(switch_port, "config"), config_space(acs_control=0x1D))
+    write(os.path.join(gpu, "config"), config_space(max_payload_bytes=128))
+    write(os.path.join(gpu, "vendor"), "0x10de\n")
+    write(os.path.join(gpu, "class"), "0x030200\n")
+    for device in (root_port, switch_port):
+        write(os.path.join(device, "vendor"), "0x8086\n")
+        write(os.path.join(device, "class"), "0x060400\n")
+
+    group = os.path.join(sys_root, "kernel", "iommu_groups", "12")
+    write(os.path.join(group, "type"), "DMA-FQ\n")
+    os.symlink(group, os.path.join(gpu, "iommu_group"))
+
+    bus = os.path.join(sys_root, "bus", "pci", "devices")
+    os.makedirs(bus)
+    for address, path in ((ROOT_PORT, root_port), (SWITCH_PORT, switch_port), (GPU, gpu)):
+        os.symlink(path, os.path.join(bus, address))
+
+    write(os.path.join(sys_root, "kernel", "mm", "transparent_hugepage", "enabled"), "always [madvise] never\n")
+    write(os.path.join(proc_root, "meminfo"), "MemTotal:       2113523340 kB\nHugePages_Total:       0\n")
+    write(os.path.join(proc_root, "cmdline"), "BOOT_IMAGE=/vmlinuz root=/dev/sda1\n")
+    return sys_root, proc_root
+
+
+def new_check(host, profile: Profile, callbacks=None) -> ConfigCheck:
+    sys_root, proc_root = host
+    return ConfigCheck(
+        profile=profile,
+        interval_seconds=3600,
+        callbacks=callbacks or [],
+        sys_root=sys_root,
+        proc_root=proc_root,
+    )
+
+
+def test_check_reports_deviating_settings(host):
+    profile = Profile(min_hugepages=1024, transparent_hugepages="never", require_peermem=True)
+    deviations = new_check(host, profile).check()
+
+    assert deviations == [
+        Deviation(setting="iommu", expected="passthrough", actual=f"translated for GPUs {GPU}"),
+        Deviation(
+            setting="pcie_acs",
+            expected="P2P redirect disabled",
+            actual="P2P redirect enabled (RR+CR)",
+            device=SWITCH_PORT,
+        ),
+        Deviation(setting="pcie_max_payload", expected="at least 256 bytes", actual="128 bytes", device=GPU),
+        Deviation(setting="hugepages", expected="at least 1024", actual="0"),
+        Deviation(setting="transparent_hugepages", expected="never", actual="madvise"),
+        Deviation(setting="nvidia_peermem", expected="loaded", actual="not loaded"),
+    ]
+    assert deviations[1].key == f"pcie_acs/{SWITCH_PORT}"
+    assert deviations[2].message == f"pcie_max_payload of {GPU} is 128 bytes, expected at least 256 bytes"
+
+
+def test_check_matching_profile(host):
+    sys_root, _ = host
+    write(os.path.join(sys_root, "kernel", "iommu_groups", "12", "type"), "identity\n")
+    os.makedirs(os.path.join(sys_root, "module", "nvidia_peermem"))
+
+    profile = Profile(
+        acs_redirect_disabled=False,
+        min_pcie_max_payload_bytes=128,
+        transparent_hugepages="madvise",
+        require_peermem=True,
+    )
+    assert new_check(host, profile).check() == []
+
+
+def test_check_iommu_off(host):
+    sys_root, _ = host
+    write(os.path.join(sys_root, "kernel", "iommu_groups", "12", "type"), "identity\n")
+
+    profile = Profile(iommu_mode="off", acs_redirect_disabled=False, min_pcie_max_payload_bytes=0)
+    assert new_check(host, profile).check() == [
+        Deviation(setting="iommu", expected="off", actual=f"passthrough for GPUs {GPU}")
+    ]
+
+
+def test_check_skips_unreadable_config_space(host):
+    sys_root, _ = host
+    # Without CAP_SYS_ADMIN only the first 64 bytes of the configuration space are readable
+    for device in (SWITCH_PORT, GPU):
+        write(os.path.join(sys_root, "bus", "pci", "devices", device, "config"), config_space()[:64])
+
+    assert new_check(host, Profile(iommu_mode="")).check() == []
+
+
+def test_check_cmdline_and_sysctl_drift(host):
+    sys_root, proc_root = host
+    write(os.path.join(proc_root, "cmdline"), 'BOOT_IMAGE=/vmlinuz iommu=off nosmt pci=realloc pci=noats quiet\n')
+    write(os.path.join(proc_root, "sys", "kernel", "numa_balancing"), "1\n")
+    write(os.path.join(proc_root, "sys", "vm", "swappiness"), "0\n")
+    write(os.path.join(proc_root, "sys", "net", "ipv4", "tcp_rmem"), "4096\t131072\t6291456\n")
+
+    profile = Profile(
+        iommu_mode="",
+        acs_redirect_disabled=False,
+        min_pcie_max_payload_bytes=0,
+        cmdline={"iommu": "pt", "nosmt": "", "pci": "noats", "quiet": "-", "init_on_alloc": ""},
+        sysctls={
+            "kernel.numa_balancing": "0",
+            "vm.swappiness": "0",
+            "net.ipv4.tcp_rmem": "4096 131072 6291456",
+            "vm.nr_hugepages_mempolicy": "0",
+        },
+    )
+    deviations = new_check(host, profile).check()
+
+    assert deviations == [
+        Deviation(
+            setting="kernel_cmdline",
+            expected="present",
+            actual="absent",
+            parameter="init_on_alloc",
+            error_code="CONFIG_DRIFT",
+        ),
+        Deviation(setting="kernel_cmdline", expected="pt", actual="off", parameter="iommu", error_code="CONFIG_DRIFT"),
+        Deviation(
+            setting="kernel_cmdline", expected="absent", actual="present", parameter="quiet", error_code="CONFIG_DRIFT"
+        ),
+        Deviation(
+            setting="sysctl", expected="0", actual="1", parameter="kernel.numa_balancing", error_code="CONFIG_DRIFT"
+        ),
+        Deviation(
+            setting="sysctl",
+            expected="0",
+            actual="unavailable",
+            parameter="vm.nr_hugepages_mempolicy",
+            error_code="CONFIG_DRIFT",
+        ),
+    ]
+    assert deviations[1].key == "kernel_cmdline/iommu"
+    assert deviations[1].message == "kernel_cmdline iommu is off, expected pt"
+
+
+def test_start_notifies_callbacks(host):
+    exit = Event()
+
+    class RecordingCallback(ConfigCheckCallbackInterface):
+        def __init__(self) -> None:
+            self.calls = []
+
+        def configuration_checked(self, deviations):
+            self.calls.append(deviations)
+            exit.set()
+
+    callback = RecordingCallback()
+    new_check(host, Profile(acs_redirect_disabled=False), callbacks=[callback]).start(exit)
+
+    assert len(callback.calls) == 1
+    assert [deviation.setting for deviation in callback.calls[0]] == ["iommu", "pcie_max_payload"]
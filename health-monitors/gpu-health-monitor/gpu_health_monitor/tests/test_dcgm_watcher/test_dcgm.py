@@ -29,10 +29,15 @@ class FakeEventProcessorInTest(dcgm.types.CallbackInterface):
         self.connectivity_failed_called = False
 
     def health_event_occurred(
-        self, health_details: dict[str, dcgm.types.HealthDetails], gpu_ids: list[int], serials: dict[int, str]
+        self,
+        health_details: dict[str, dcgm.types.HealthDetails],
+        gpu_ids: list[int],
+        serials: dict[int, str],
+        utilization: dict[int, dcgm.types.UtilizationContext] | None = None,
     ):
         self.health_details = health_details
         self.serials = serials
+        self.utilization = utilization
 
     def dcgm_connectivity_failed(self):
         self.connectivity_failed_called = True
@@ -440,3 +445,47 @@ class TestDCGMHealthChecks:
         assert len(gpu_serials) == 4
         # Verify that health.Set was called on the actual group object
         group.health.Set.assert_called_once()
+
+    def test_sample_utilization(self):
+        """Test that the latest utilization values are kept per GPU and blank values are skipped."""
+        watcher = dcgm.DCGMWatcher(
+            addr="localhost:5555",
+            poll_interval_seconds=10,
+            callbacks=[],
+            dcgm_k8s_service_enabled=False,
+            utilization_window_seconds=300,
+        )
+        field_ids = {"gpu_util_percent": 203, "mem_copy_util_percent": 204, "fb_used_mib": 252, "fb_total_mib": 250}
+
+        def series(value, blank=False):
+            return MagicMock(values=[MagicMock(value=value, isBlank=blank)])
+
+        dcgm_group_mock = MagicMock()
+        dcgm_group_mock.samples.GetLatest.return_value = MagicMock(
+            values={
+                0: {203: series(97), 204: series(41), 252: series(71234), 250: series(81559)},
+                1: {203: series(0, blank=True), 250: series(81559)},
+            }
+        )
+
+        with patch.object(watcher, "_utilization_field_ids", return_value=field_ids):
+            watcher._sample_utilization(dcgm_group_mock, MagicMock())
+
+        contexts = watcher._utilization.contexts()
+        assert contexts[0].gpu_util_percent == 97
+        assert contexts[0].mem_copy_util_percent == 41
+        assert contexts[0].fb_used_mib == 71234
+        assert contexts[1].gpu_util_percent is None
+        assert contexts[1].fb_total_mib == 81559
+
+    def test_watch_utilization_fields_disabled(self):
+        """Test that no fields are watched when the utilization window is 0."""
+        watcher = dcgm.DCGMWatcher(
+            addr="localhost:5555",
+            poll_interval_seconds=10,
+            callbacks=[],
+            dcgm_k8s_service_enabled=False,
+        )
+        dcgm_group_mock = MagicMock()
+        assert watcher._watch_utilization_fields(MagicMock(), dcgm_group_mock) is None
+        dcgm_group_mock.samples.WatchFields.assert_not_called()
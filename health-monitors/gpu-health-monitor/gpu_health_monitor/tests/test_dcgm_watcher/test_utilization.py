@@ -0,0 +1,72 @@
+# Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+
+from gpu_health_monitor.dcgm_watcher.utilization import UtilizationSample, UtilizationTracker
+
+
+class FakeClock:
+    def __init__(self) -> None:
+        self.now = 1000.0
+
+    def __call__(self) -> float:
+        return self.now
+
+
+def test_context_summarizes_window():
+    clock = FakeClock()
+    tracker = UtilizationTracker(window_seconds=60, clock=clock)
+    tracker.add(0, UtilizationSample(timestamp=clock.now - 90, gpu_util_percent=100))
+    tracker.add(0, UtilizationSample(timestamp=clock.now - 30, gpu_util_percent=20, fb_used_mib=100))
+    tracker.add(0, UtilizationSample(timestamp=clock.now, gpu_util_percent=60, fb_used_mib=200, fb_total_mib=1000))
+
+    context = tracker.context(0)
+    assert context.samples == 2, "Samples older than the window should be dropped"
+    assert context.gpu_util_percent == 60
+    assert context.gpu_util_avg_percent == 40
+    assert context.gpu_util_max_percent == 60
+    assert context.fb_used_mib == 200
+    assert context.fb_total_mib == 1000
+    assert context.metadata() == {
+        "gpu_util_percent": "60",
+        "gpu_util_avg_percent": "40",
+        "gpu_util_max_percent": "60",
+        "fb_used_mib": "200",
+        "fb_total_mib": "1000",
+        "utilization_window_seconds": "60",
+    }
+
+
+def test_context_expires():
+    clock = FakeClock()
+    tracker = UtilizationTracker(window_seconds=60, clock=clock)
+    tracker.add(1, UtilizationSample(timestamp=clock.now, gpu_util_percent=5))
+    assert tracker.contexts().keys() == {1}
+
+    clock.now += 61
+    assert tracker.context(1) is None
+    assert tracker.contexts() == {}
+    assert tracker.context(2) is None
+
+
+def test_metadata_without_values():
+    clock = FakeClock()
+    tracker = UtilizationTracker(window_seconds=60, clock=clock)
+    tracker.add(0, UtilizationSample(timestamp=clock.now))
+
+    context = tracker.context(0)
+    assert context.gpu_util_avg_percent is None
+    assert context.metadata() == {}
+
+    tracker.clear()
+    assert tracker.context(0) is None
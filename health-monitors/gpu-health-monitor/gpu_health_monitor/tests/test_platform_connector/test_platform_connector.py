@@ -19,7 +19,11 @@ import time
 import unittest
 from typing import Any
 from concurrent import futures
+from gpu_health_monitor.bandwidth_probe import BandwidthResult
+from gpu_health_monitor.config_check import Deviation
 from gpu_health_monitor.dcgm_watcher import types as dcgmtypes
+from gpu_health_monitor.dcgm_watcher.utilization import UtilizationContext
+from gpu_health_monitor.sdc_screen import SDCResult
 from gpu_health_monitor.platform_connector import platform_connector
 
 from gpu_health_monitor.protos import (
@@ -45,6 +49,13 @@ class PlatformConnectorServicer(platformconnector_pb2_grpc.PlatformConnectorServ
 
 class TestPlatformConnectors(unittest.TestCase):
 
+    def test_new_event_id(self):
+        first = platform_connector.new_event_id()
+        time.sleep(0.002)
+        second = platform_connector.new_event_id()
+        assert len(first) == 26 and set(first) <= set(platform_connector.CROCKFORD)
+        assert first < second, "Event IDs should sort by creation time"
+
     def test_health_event_occurred(self):
         healthEventProcessor = PlatformConnectorServicer()
         server = grpc.server(futures.ThreadPoolExecutor(max_workers=10))
@@ -123,7 +134,9 @@ class TestPlatformConnectors(unittest.TestCase):
         gpu_ids = [0, 1, 2, 3, 4, 5, 6, 7]
         platform_connector_test.health_event_occurred(dcgm_health_events, gpu_ids, gpu_serials)
         health_events = healthEventProcessor.health_events
+        assert len({event.id for event in health_events}) == len(health_events), "Event IDs should be unique"
         for event in health_events:
+            assert len(event.id) == 26
             if event.checkName == "GpuInforomWatch" and event.isHealthy == False:
                 assert event.errorCode[0] == "DCGM_FR_CORRUPT_INFOROM"
                 assert event.entitiesImpacted[0].entityValue == "0"
@@ -539,6 +552,248 @@ class TestPlatformConnectors(unittest.TestCase):
 
         server.stop(0)
 
+    def test_health_event_occurred_with_utilization(self):
+        """Test that fault events carry the recent utilization of the failed GPU and healthy events do not."""
+        healthEventProcessor = PlatformConnectorServicer()
+        server = grpc.server(futures.ThreadPoolExecutor(max_workers=10))
+        platformconnector_pb2_grpc.add_PlatformConnectorServicer_to_server(healthEventProcessor, server)
+        server.add_insecure_port(f"unix://{socket_path}")
+        server.start()
+
+        platform_connector_processor = platform_connector.PlatformConnectorEventProcessor(
+            socket_path=socket_path,
+            node_name=node_name,
+            exit=Event(),
+            dcgm_errors_info_dict={"DCGM_FR_VOLATILE_DBE_DETECTED": "COMPONENT_RESET"},
+            state_file_path="statefile",
+            dcgm_health_conditions_categorization_mapping_config={"DCGM_HEALTH_WATCH_MEM": "Fatal"},
+            metadata_path="/tmp/test_metadata.json",
+        )
+
+        utilization = {
+            0: UtilizationContext(
+                window_seconds=300,
+                samples=20,
+                gpu_util_percent=97,
+                gpu_util_avg_percent=92,
+                gpu_util_max_percent=100,
+                mem_copy_util_percent=41,
+                fb_used_mib=71234,
+                fb_total_mib=81559,
+            ),
+            1: UtilizationContext(
+                window_seconds=300,
+                samples=20,
+                gpu_util_percent=0,
+                gpu_util_avg_percent=0,
+                gpu_util_max_percent=0,
+                mem_copy_util_percent=0,
+                fb_used_mib=0,
+                fb_total_mib=81559,
+            ),
+        }
+        health_details = {
+            "DCGM_HEALTH_WATCH_MEM": dcgmtypes.HealthDetails(
+                status=dcgmtypes.HealthStatus.FAIL,
+                entity_failures={
+                    0: dcgmtypes.ErrorDetails(code="DCGM_FR_VOLATILE_DBE_DETECTED", message="Volatile DBEs detected"),
+                },
+            )
+        }
+        platform_connector_processor.health_event_occurred(health_details, [0, 1], {}, utilization)
+
+        health_events = {event.entitiesImpacted[0].entityValue: event for event in healthEventProcessor.health_events}
+        assert health_events["0"].isHealthy == False
+        assert health_events["0"].metadata["gpu_util_percent"] == "97"
+        assert health_events["0"].metadata["gpu_util_avg_percent"] == "92"
+        assert health_events["0"].metadata["gpu_util_max_percent"] == "100"
+        assert health_events["0"].metadata["mem_copy_util_percent"] == "41"
+        assert health_events["0"].metadata["fb_used_mib"] == "71234"
+        assert health_events["0"].metadata["fb_total_mib"] == "81559"
+        assert health_events["0"].metadata["utilization_window_seconds"] == "300"
+        assert health_events["1"].isHealthy == True
+        assert "gpu_util_percent" not in health_events["1"].metadata
+
+        server.stop(0)
+
+    def test_bandwidth_measured(self):
+        """Test that degraded GPU bandwidth is published as a non-fatal event and cleared once restored."""
+        healthEventProcessor = PlatformConnectorServicer()
+        server = grpc.server(futures.ThreadPoolExecutor(max_workers=10))
+        platformconnector_pb2_grpc.add_PlatformConnectorServicer_to_server(healthEventProcessor, server)
+        server.add_insecure_port(f"unix://{socket_path}")
+        server.start()
+
+        platform_connector_processor = platform_connector.PlatformConnectorEventProcessor(
+            socket_path=socket_path,
+            node_name=node_name,
+            exit=Event(),
+            dcgm_errors_info_dict={},
+            state_file_path="statefile",
+            dcgm_health_conditions_categorization_mapping_config={},
+            metadata_path="/tmp/test_metadata.json",
+        )
+
+        healthy = {
+            0: [BandwidthResult(testcase="host_to_device_memcpy_ce", measured_gbps=54.1, expected_gbps=55.0)],
+            1: [BandwidthResult(testcase="host_to_device_memcpy_ce", measured_gbps=53.8, expected_gbps=55.0)],
+        }
+        platform_connector_processor.bandwidth_measured(healthy, 80)
+        assert healthEventProcessor.health_events is None, "Healthy GPUs should not publish events"
+
+        degraded = {
+            0: healthy[0],
+            1: [BandwidthResult(testcase="host_to_device_memcpy_ce", measured_gbps=12.5, expected_gbps=55.0)],
+        }
+        platform_connector_processor.bandwidth_measured(degraded, 80)
+        health_events = healthEventProcessor.health_events
+        assert len(health_events) == 1
+        event = health_events[0]
+        assert event.checkName == "GpuBandwidthProbe"
+        assert event.isFatal == False
+        assert event.isHealthy == False
+        assert event.errorCode == ["GPU_BANDWIDTH_DEGRADED"]
+        assert event.entitiesImpacted[0].entityValue == "1"
+        assert event.message == "host_to_device_memcpy_ce measured 12.50 GB/s, expected 55.00 GB/s"
+        assert event.metadata["host_to_device_memcpy_ce.measured_gbps"] == "12.50"
+        assert event.metadata["host_to_device_memcpy_ce.expected_gbps"] == "55.00"
+        assert event.recommendedAction == platformconnector_pb2.CONTACT_SUPPORT
+
+        healthEventProcessor.health_events = None
+        platform_connector_processor.bandwidth_measured(degraded, 80)
+        assert healthEventProcessor.health_events is None, "Unchanged degradation should not be published again"
+
+        platform_connector_processor.bandwidth_measured(healthy, 80)
+        health_events = healthEventProcessor.health_events
+        assert len(health_events) == 1
+        assert health_events[0].entitiesImpacted[0].entityValue == "1"
+        assert health_events[0].isHealthy == True
+        assert health_events[0].errorCode == []
+        assert health_events[0].recommendedAction == platformconnector_pb2.NONE
+
+        server.stop(0)
+
+    def test_sdc_screened(self):
+        """Test that SDC mismatches are published as fatal events that only a passing burn-in clears."""
+        healthEventProcessor = PlatformConnectorServicer()
+        server = grpc.server(futures.ThreadPoolExecutor(max_workers=10))
+        platformconnector_pb2_grpc.add_PlatformConnectorServicer_to_server(healthEventProcessor, server)
+        server.add_insecure_port(f"unix://{socket_path}")
+        server.start()
+
+        platform_connector_processor = platform_connector.PlatformConnectorEventProcessor(
+            socket_path=socket_path,
+            node_name=node_name,
+            exit=Event(),
+            dcgm_errors_info_dict={},
+            state_file_path="statefile",
+            dcgm_health_conditions_categorization_mapping_config={},
+            metadata_path="/tmp/test_metadata.json",
+        )
+
+        passed = {0: [SDCResult(test="hgemm", mismatches=0)], 1: [SDCResult(test="hgemm", mismatches=0)]}
+        mismatched = {
+            0: passed[0],
+            1: [SDCResult(test="hgemm", mismatches=3, message="element 1024 differs")],
+        }
+
+        platform_connector_processor.sdc_screened(passed, False)
+        assert healthEventProcessor.health_events is None, "Periodic screens should not publish passing GPUs"
+
+        platform_connector_processor.sdc_screened(mismatched, False)
+        health_events = healthEventProcessor.health_events
+        assert len(health_events) == 1
+        event = health_events[0]
+        assert event.checkName == "GpuSdcScreen"
+        assert event.isFatal == True
+        assert event.isHealthy == False
+        assert event.errorCode == ["SDC_SUSPECTED"]
+        assert event.entitiesImpacted[0].entityValue == "1"
+        assert event.message == "hgemm returned 3 results not matching the known answer: element 1024 differs"
+        assert event.metadata["hgemm.mismatches"] == "3"
+        assert event.metadata["sdc_screen"] == "periodic"
+        assert event.recommendedAction == platformconnector_pb2.CONTACT_SUPPORT
+
+        healthEventProcessor.health_events = None
+        platform_connector_processor.sdc_screened(passed, False)
+        assert healthEventProcessor.health_events is None, "A periodic screen should not clear a suspected SDC"
+
+        platform_connector_processor.sdc_screened(passed, True)
+        health_events = healthEventProcessor.health_events
+        assert [event.entitiesImpacted[0].entityValue for event in health_events] == ["0", "1"]
+        for event in health_events:
+            assert event.isHealthy == True
+            assert event.isFatal == False
+            assert event.errorCode == []
+            assert event.metadata["sdc_screen"] == "burn_in"
+
+        server.stop(0)
+
+    def test_configuration_checked(self):
+        """Test that settings deviating from the profile are published once and cleared once fixed."""
+        healthEventProcessor = PlatformConnectorServicer()
+        server = grpc.server(futures.ThreadPoolExecutor(max_workers=10))
+        platformconnector_pb2_grpc.add_PlatformConnectorServicer_to_server(healthEventProcessor, server)
+        server.add_insecure_port(f"unix://{socket_path}")
+        server.start()
+
+        platform_connector_processor = platform_connector.PlatformConnectorEventProcessor(
+            socket_path=socket_path,
+            node_name=node_name,
+            exit=Event(),
+            dcgm_errors_info_dict={},
+            state_file_path="statefile",
+            dcgm_health_conditions_categorization_mapping_config={},
+            metadata_path="/tmp/test_metadata.json",
+        )
+
+        iommu = Deviation(setting="iommu", expected="passthrough", actual="translated for GPUs 0000:03:00.0")
+        payload = Deviation(
+            setting="pcie_max_payload", expected="at least 256 bytes", actual="128 bytes", device="0000:03:00.0"
+        )
+
+        platform_connector_processor.configuration_checked([iommu, payload])
+        health_events = healthEventProcessor.health_events
+        assert len(health_events) == 2
+        event = health_events[1]
+        assert event.checkName == "GpuConfigCheck"
+        assert event.isFatal == False
+        assert event.isHealthy == False
+        assert event.errorCode == ["MISCONFIGURED"]
+        assert [(entity.entityType, entity.entityValue) for entity in event.entitiesImpacted] == [
+            ("SETTING", "pcie_max_payload"),
+            ("PCI", "0000:03:00.0"),
+        ]
+        assert event.message == "pcie_max_payload of 0000:03:00.0 is 128 bytes, expected at least 256 bytes"
+        assert event.metadata["actual"] == "128 bytes"
+        assert event.recommendedAction == platformconnector_pb2.NONE
+
+        healthEventProcessor.health_events = None
+        platform_connector_processor.configuration_checked([iommu, payload])
+        assert healthEventProcessor.health_events is None, "Reported settings should not be published again"
+
+        platform_connector_processor.configuration_checked([iommu])
+        health_events = healthEventProcessor.health_events
+        assert len(health_events) == 1
+        assert health_events[0].isHealthy == True
+        assert health_events[0].errorCode == []
+        assert health_events[0].entitiesImpacted[0].entityValue == "pcie_max_payload"
+
+        drift = Deviation(
+            setting="sysctl", expected="0", actual="1", parameter="kernel.numa_balancing", error_code="CONFIG_DRIFT"
+        )
+        platform_connector_processor.configuration_checked([iommu, drift])
+        health_events = healthEventProcessor.health_events
+        assert len(health_events) == 1
+        assert health_events[0].errorCode == ["CONFIG_DRIFT"]
+        assert [(entity.entityType, entity.entityValue) for entity in health_events[0].entitiesImpacted] == [
+            ("SETTING", "sysctl"),
+            ("PARAMETER", "kernel.numa_balancing"),
+        ]
+        assert health_events[0].message == "sysctl kernel.numa_balancing is 1, expected 0"
+
+        server.stop(0)
+
     def test_event_retry_and_cache_cleanup_when_platform_connector_down(self):
         """Test when platform connector goes down and comes back up."""
         import tempfile
@@ -0,0 +1,196 @@
+# Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+
+import os
+import tempfile
+import time
+from datetime import datetime, timedelta, timezone
+from threading import Event, Lock
+from gpu_health_monitor import kubernetes_node
+from gpu_health_monitor.scheduler import ScheduledCheck, Scheduler, WorkloadSensitivity
+
+
+class FakeRunner:
+    def __init__(self, process_names: str = "", error: Exception | None = None) -> None:
+        self.process_names = process_names
+        self.error = error
+        self.calls = 0
+
+    def __call__(self, command, timeout_seconds, env=None, check=True):
+        self.calls += 1
+        if self.error is not None:
+            raise self.error
+        return self.process_names
+
+
+class CountingCheck:
+    def __init__(self, exit: Event | None = None, runs: int = 1, duration_seconds: float = 0) -> None:
+        self.exit = exit
+        self.runs = runs
+        self.duration_seconds = duration_seconds
+        self.calls = 0
+        self.running = 0
+        self.max_running = 0
+        self.lock = Lock()
+
+    def __call__(self) -> None:
+        with self.lock:
+            self.calls += 1
+            self.running += 1
+            self.max_running = max(self.max_running, self.running)
+        time.sleep(self.duration_seconds)
+        with self.lock:
+            self.running -= 1
+            if self.exit is not None and self.calls >= self.runs:
+                self.exit.set()
+
+
+def test_sensitivity_marker_file():
+    with tempfile.TemporaryDirectory() as directory:
+        marker_file = os.path.join(directory, "latency-sensitive")
+        runner = FakeRunner()
+        sensitivity = WorkloadSensitivity(process_patterns=[], marker_file=marker_file, timeout_seconds=10, run=runner)
+        assert sensitivity.reason() is None
+
+        open(marker_file, "w").close()
+        assert sensitivity.reason() == f"marker file {marker_file} exists"
+        # Without process patterns the compute processes are not listed
+        assert runner.calls == 0
+
+
+def test_sensitivity_processes():
+    runner = FakeRunner(process_names="/usr/bin/python3\n/opt/triton/bin/tritonserver\n")
+    sensitivity = WorkloadSensitivity(
+        process_patterns=["tritonserver", "^vllm"], marker_file="", timeout_seconds=10, run=runner
+    )
+    assert sensitivity.reason() == "latency-sensitive process /opt/triton/bin/tritonserver is running"
+
+    runner.process_names = "/usr/bin/python3\n"
+    assert sensitivity.reason() is None
+
+
+def test_sensitivity_suppresses_when_processes_are_unknown():
+    runner = FakeRunner(error=TimeoutError("nvidia-smi timed out"))
+    sensitivity = WorkloadSensitivity(process_patterns=["tritonserver"], marker_file="", timeout_seconds=10, run=runner)
+    assert sensitivity.reason() == "failed to list compute processes: nvidia-smi timed out"
+
+
+def test_run_check_suppressed():
+    run = CountingCheck()
+    sensitivity = WorkloadSensitivity(
+        process_patterns=["tritonserver"], marker_file="", timeout_seconds=10, run=FakeRunner("tritonserver\n")
+    )
+    scheduler = Scheduler(checks=[], max_concurrent=1, sensitivity=sensitivity, suppressed_retry_seconds=60)
+
+    disruptive = ScheduledCheck(name="sdc_screen", run=run, interval_seconds=3600)
+    assert scheduler.run_check(disruptive, Event()) == "suppressed"
+    assert run.calls == 0
+
+    # Read-only checks run regardless of the workloads
+    read_only = ScheduledCheck(name="config_check", run=run, interval_seconds=3600, disruptive=False)
+    assert scheduler.run_check(read_only, Event()) == "completed"
+    assert run.calls == 1
+
+
+def test_run_check_failed():
+    def fail():
+        raise RuntimeError("boom")
+
+    scheduler = Scheduler(checks=[], max_concurrent=1, sensitivity=None, suppressed_retry_seconds=60)
+    assert scheduler.run_check(ScheduledCheck(name="probe", run=fail, interval_seconds=3600), Event()) == "failed"
+
+
+def test_start_runs_checks_every_interval_with_jitter():
+    exit = Event()
+    run = CountingCheck(exit, runs=3)
+    jitters = []
+
+    def uniform(low, high):
+        jitters.append((low, high))
+        return 0
+
+    scheduler = Scheduler(
+        checks=[ScheduledCheck(name="probe", run=run, interval_seconds=0, jitter_seconds=5, initial_delay_seconds=0)],
+        max_concurrent=1,
+        sensitivity=None,
+        suppressed_retry_seconds=60,
+        uniform=uniform,
+    )
+    for thread in scheduler.start(exit):
+        thread.join(timeout=10)
+
+    assert run.calls == 3
+    assert jitters[:3] == [(0, 5), (0, 5), (0, 5)]
+
+
+def test_start_limits_concurrent_checks():
+    exit = Event()
+    run = CountingCheck(exit, runs=4, duration_seconds=0.1)
+    checks = [
+        ScheduledCheck(name=f"check{i}", run=run, interval_seconds=0, initial_delay_seconds=0) for i in range(4)
+    ]
+    scheduler = Scheduler(checks=checks, max_concurrent=2, sensitivity=None, suppressed_retry_seconds=60)
+    for thread in scheduler.start(exit):
+        thread.join(timeout=10)
+
+    assert run.calls >= 4
+    assert run.max_running == 2
+
+
+def test_sensitivity_do_not_disturb():
+    now = datetime.now(timezone.utc)
+    annotations = {}
+    get_node_calls = []
+
+    def get_node(node_name, timeout_seconds):
+        get_node_calls.append(node_name)
+        return {"metadata": {"name": node_name, "annotations": annotations}}
+
+    sensitivity = WorkloadSensitivity(
+        process_patterns=[], marker_file="", timeout_seconds=10, run=FakeRunner(), node_name="node-1", get_node=get_node
+    )
+    assert sensitivity.reason() is None
+
+    annotations[kubernetes_node.DO_NOT_DISTURB_KEY] = "true"
+    assert sensitivity.reason() == "node node-1 is marked do not disturb"
+
+    annotations[kubernetes_node.DO_NOT_DISTURB_KEY] = (now + timedelta(hours=1)).isoformat()
+    assert sensitivity.reason() == "node node-1 is marked do not disturb"
+
+    # An expired mark no longer suppresses the checks
+    annotations[kubernetes_node.DO_NOT_DISTURB_KEY] = (now - timedelta(minutes=1)).isoformat()
+    assert sensitivity.reason() is None
+    assert get_node_calls == ["node-1"] * 4
+
+
+def test_sensitivity_ignores_node_lookup_errors():
+    def get_node(node_name, timeout_seconds):
+        raise OSError("connection refused")
+
+    sensitivity = WorkloadSensitivity(
+        process_patterns=[], marker_file="", timeout_seconds=10, run=FakeRunner(), node_name="node-1", get_node=get_node
+    )
+    assert sensitivity.reason() is None
+
+
+def test_do_not_disturb():
+    now = datetime(2025, 6, 1, 8, 0, tzinfo=timezone.utc)
+    key = kubernetes_node.DO_NOT_DISTURB_KEY
+
+    assert not kubernetes_node.do_not_disturb({}, now)
+    assert kubernetes_node.do_not_disturb({"metadata": {"labels": {key: "True"}}}, now)
+    assert kubernetes_node.do_not_disturb({"metadata": {"annotations": {key: "2025-06-01T09:30:00Z"}}}, now)
+    assert not kubernetes_node.do_not_disturb({"metadata": {"annotations": {key: "2025-06-01T07:00:00Z"}}}, now)
+    assert not kubernetes_node.do_not_disturb({"metadata": {"annotations": {key: "false"}}}, now)
+    assert not kubernetes_node.do_not_disturb({"metadata": {"annotations": {key: "tomorrow"}}}, now)
@@ -0,0 +1,144 @@
+# Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+#
+# Licensed under the Apache License, Version 2.0 (the "License");
+# you may not use this file except in compliance with the License.
+# You may obtain a copy of the License at
+#
+#     http://www.apache.org/licenses/LICENSE-2.0
+#
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+
+import json
+import os
+import tempfile
+from threading import Event
+import pytest
+from gpu_health_monitor.sdc_screen import SDCResult, SDCScreen, parse_screen_output
+from gpu_health_monitor.sdc_screen.screen import SDCCallbackInterface
+
+GPUS = "0, 00000000:17:00.0, 0\n1, 00000000:2A:00.0, 0\n2, 00000000:3D:00.0, 0\n"
+
+
+def screen_output(*results) -> str:
+    return json.dumps({"results": list(results)})
+
+
+class FakeRunner:
+    def __init__(self, gpus: str, compute_apps: str, output: str) -> None:
+        self.gpus = gpus
+        self.compute_apps = compute_apps
+        self.output = output
+        self.screens = 0
+        self.screen_env = None
+        self.screen_check = None
+
+    def __call__(self, command, timeout_seconds, env=None, check=True):
+        if command[0] == "nvidia-smi":
+            return self.compute_apps if "--query-compute-apps=gpu_bus_id" in command else self.gpus
+        self.screens += 1
+        self.screen_env = env
+        self.screen_check = check
+        return self.output
+
+
+class RecordingCallback(SDCCallbackInterface):
+    def __init__(self, exit: Event) -> None:
+        self.exit = exit
+        self.calls = []
+
+    def sdc_screened(self, results, burn_in):
+        self.calls.append((results, burn_in))
+        self.exit.set()
+
+
+@pytest.fixture
+def boot_id_file():
+    with tempfile.NamedTemporaryFile(mode="w", delete=False) as f:
+        f.write("boot-2\n")
+    yield f.name
+    os.unlink(f.name)
+
+
+@pytest.fixture
+def state_file():
+    path = os.path.join(tempfile.mkdtemp(), "sdc_burn_in_boot_id")
+    yield path
+    if os.path.exists(path):
+        os.unlink(path)
+
+
+def new_screen(runner: FakeRunner, state_file="", boot_id_path="", callbacks=None, interval_seconds=3600) -> SDCScreen:
+    return SDCScreen(
+        command="sdc-screen --json",
+        interval_seconds=interval_seconds,
+        timeout_seconds=10,
+        idle_utilization_percent=0,
+        burn_in_state_file=state_file,
+        callbacks=callbacks or [],
+        run=runner,
+        boot_id_path=boot_id_path,
+    )
+
+
+def test_parse_screen_output():
+    output = screen_output(
+        {"gpu": 0, "test": "hgemm", "mismatches": 0},
+        {"gpu": 1, "test": "hgemm", "mismatches": 4, "message": "element 1024 differs"},
+    )
+    assert parse_screen_output(output, [3, 7]) == {
+        3: [SDCResult(test="hgemm", mismatches=0)],
+        7: [SDCResult(test="hgemm", mismatches=4, message="element 1024 differs")],
+    }
+
+
+def test_parse_screen_output_rejects_incomplete_reports():
+    with pytest.raises(ValueError):
+        parse_screen_output(screen_output({"gpu": 0, "test": "hgemm", "mismatches": 0}), [3, 7])
+    with pytest.raises(ValueError):
+        parse_screen_output(screen_output({"gpu": 2, "test": "hgemm", "mismatches": 0}), [3, 7])
+
+
+def test_screen_runs_on_idle_gpus():
+    runner = FakeRunner(
+        gpus=GPUS,
+        compute_apps="00000000:2A:00.0\n",
+        output=screen_output(
+            {"gpu": 0, "test": "hgemm", "mismatches": 0}, {"gpu": 1, "test": "hgemm", "mismatches": 2}
+        ),
+    )
+    results = new_screen(runner).screen("periodic")
+    assert runner.screen_env["CUDA_VISIBLE_DEVICES"] == "0,2"
+    # Screening tools report mismatches with a failing exit status
+    assert runner.screen_check == False
+    assert results == {0: [SDCResult(test="hgemm", mismatches=0)], 2: [SDCResult(test="hgemm", mismatches=2)]}
+
+
+def test_screen_skipped_when_no_gpu_is_idle():
+    runner = FakeRunner(gpus="0, 00000000:17:00.0, 40\n", compute_apps="", output="")
+    assert new_screen(runner).screen("periodic") is None
+    assert runner.screens == 0
+
+
+def test_burn_in_after_boot(boot_id_file, state_file):
+    exit = Event()
+    callback = RecordingCallback(exit)
+    runner = FakeRunner(
+        gpus="0, 00000000:17:00.0, 0\n", compute_apps="", output=screen_output({"gpu": 0, "test": "hgemm"})
+    )
+    new_screen(runner, state_file, boot_id_file, [callback]).start(exit)
+
+    assert callback.calls == [({0: [SDCResult(test="hgemm", mismatches=0)]}, True)]
+    with open(state_file) as f:
+        assert f.read() == "boot-2"
+
+    # The burn-in already ran after this boot: the next screen is a periodic one after the interval
+    exit.clear()
+    callback.calls = []
+    screen = new_screen(runner, state_file, boot_id_file, [callback], interval_seconds=0)
+    assert not screen._burn_in_pending()
+    screen.start(exit)
+    assert callback.calls == [({0: [SDCResult(test="hgemm", mismatches=0)]}, False)]
@@ -0,0 +1,276 @@
+// Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Command npd-adapter integrates NVSentinel with Node Problem Detector. By default
+// it runs on every node and sends what NPD reports on the node as health events.
+// `npd-adapter plugin --check <check>` is an NPD custom plugin reporting an
+// NVSentinel check, and `npd-adapter config` prints the NPD custom plugin monitor
+// configuration running it.
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"log/slog"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/nvidia/nvsentinel/commons/pkg/logger"
+	srv "github.com/nvidia/nvsentinel/commons/pkg/server"
+	pb "github.com/nvidia/nvsentinel/data-models/pkg/protos"
+	"github.com/nvidia/nvsentinel/health-monitors/kubernetes-object-monitor/pkg/npd"
+
+	"golang.org/x/sync/errgroup"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+)
+
+const (
+	defaultUdsPath      = "/run/nvsentinel/nvsentinel.sock"
+	defaultMetricsPort  = 2115
+	defaultPollInterval = 30 * time.Second
+	defaultPluginPath   = "/opt/nvsentinel/npd-adapter"
+	defaultChecks       = "SysLogsXIDError,SysLogsSXIDError,SysLogsGPUFallenOff"
+)
+
+var (
+	// These variables will be populated during the build process
+	version = "dev"
+	commit  = "none"
+	date    = "unknown"
+)
+
+type appConfig struct {
+	udsPath         string
+	metricsPort     int
+	nodeName        string
+	pollInterval    time.Duration
+	conditions      string
+	fatalConditions string
+	sources         string
+}
+
+func parseFlags(args []string) (*appConfig, error) {
+	cfg := &appConfig{}
+
+	flags := flag.NewFlagSet("npd-adapter", flag.ContinueOnError)
+	flags.StringVar(&cfg.udsPath, "uds-path", defaultUdsPath, "Path to the Platform Connector UDS socket.")
+	flags.IntVar(&cfg.metricsPort, "metrics-port", defaultMetricsPort, "Port for the Prometheus metrics.")
+	flags.StringVar(&cfg.nodeName, "node-name", os.Getenv("NODE_NAME"),
+		"Name of the node the adapter runs on (defaults to $NODE_NAME).")
+	flags.DurationVar(&cfg.pollInterval, "poll-interval", defaultPollInterval,
+		"Interval between reads of the node conditions and events.")
+	flags.StringVar(&cfg.conditions, "conditions", strings.Join(npd.DefaultConditions, ","),
+		"Comma separated NPD condition types to ingest.")
+	flags.StringVar(&cfg.fatalConditions, "fatal-conditions", strings.Join(npd.DefaultFatalConditions, ","),
+		"Comma separated NPD condition types reported as fatal health events.")
+	flags.StringVar(&cfg.sources, "sources", strings.Join(npd.DefaultSources, ","),
+		"Comma separated sources of the NPD events to ingest. Empty ingests no events.")
+
+	return cfg, flags.Parse(args)
+}
+
+func main() {
+	logger.SetDefaultStructuredLogger("npd-adapter", version)
+
+	var err error
+
+	switch {
+	case len(os.Args) > 1 && os.Args[1] == "plugin":
+		os.Exit(runPlugin(os.Args[2:]))
+	case len(os.Args) > 1 && os.Args[1] == "config":
+		err = runConfig(os.Args[2:])
+	default:
+		slog.Info("Starting npd-adapter", "version", version, "commit", commit, "date", date)
+
+		err = run(os.Args[1:])
+	}
+
+	if errors.Is(err, flag.ErrHelp) {
+		return
+	}
+
+	if err != nil {
+		slog.Error("Fatal error", "error", err)
+		os.Exit(1)
+	}
+}
+
+func run(args []string) error {
+	cfg, err := parseFlags(args)
+	if err != nil {
+		return err
+	}
+
+	if cfg.nodeName == "" {
+		return errors.New("node name is required, set --node-name or NODE_NAME")
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	k8sClient, err := newKubernetesClient()
+	if err != nil {
+		return err
+	}
+
+	conn, err := grpc.NewClient("unix:"+cfg.udsPath, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		return fmt.Errorf("failed to dial Platform Connector UDS %s: %w", cfg.udsPath, err)
+	}
+
+	defer func() {
+		if errClose := conn.Close(); errClose != nil {
+			slog.Error("Error closing UDS connection", "error", errClose)
+		}
+	}()
+
+	adapter := npd.NewAdapter(npd.Config{
+		NodeName:        cfg.nodeName,
+		PollInterval:    cfg.pollInterval,
+		Conditions:      splitList(cfg.conditions),
+		FatalConditions: splitList(cfg.fatalConditions),
+		Sources:         splitList(cfg.sources),
+	}, pb.NewPlatformConnectorClient(conn), k8sClient)
+
+	server := srv.NewServer(
+		srv.WithPort(cfg.metricsPort),
+		srv.WithPrometheusMetrics(),
+		srv.WithHandler(logger.LevelsPath, logger.LevelsHandler()),
+		srv.WithSimpleHealth(),
+	)
+
+	g, gCtx := errgroup.WithContext(ctx)
+
+	// Metrics server failures are logged but do NOT terminate the adapter.
+	g.Go(func() error {
+		if err := server.Serve(gCtx); err != nil {
+			slog.Error("Metrics server failed - continuing without metrics", "error", err)
+		}
+
+		return nil
+	})
+
+	g.Go(func() error {
+		return adapter.Start(gCtx)
+	})
+
+	if err := g.Wait(); err != nil {
+		return fmt.Errorf("service error: %w", err)
+	}
+
+	slog.Info("NPD adapter shut down.")
+
+	return nil
+}
+
+// runPlugin runs as an NPD custom plugin: it prints the status of the check on
+// stdout and returns the exit code NPD sets the condition from.
+func runPlugin(args []string) int {
+	var (
+		nodeName string
+		check    string
+		timeout  time.Duration
+	)
+
+	flags := flag.NewFlagSet("npd-adapter plugin", flag.ContinueOnError)
+	flags.StringVar(&nodeName, "node-name", os.Getenv("NODE_NAME"),
+		"Name of the node NPD runs on (defaults to $NODE_NAME).")
+	flags.StringVar(&check, "check", "", "NVSentinel check to report, e.g. SysLogsXIDError.")
+	flags.DurationVar(&timeout, "timeout", 5*time.Second, "Timeout of the node read.")
+
+	if err := flags.Parse(args); err != nil {
+		return npd.PluginUnknown
+	}
+
+	if nodeName == "" || check == "" {
+		fmt.Println("--node-name and --check are required")
+		return npd.PluginUnknown
+	}
+
+	k8sClient, err := newKubernetesClient()
+	if err != nil {
+		fmt.Println(err)
+		return npd.PluginUnknown
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	code, message := npd.PluginStatus(ctx, k8sClient, nodeName, check)
+	fmt.Println(message)
+
+	return code
+}
+
+// runConfig prints the NPD custom plugin monitor configuration for the checks
+func runConfig(args []string) error {
+	var (
+		path           string
+		checks         string
+		invokeInterval string
+	)
+
+	flags := flag.NewFlagSet("npd-adapter config", flag.ContinueOnError)
+	flags.StringVar(&path, "plugin-path", defaultPluginPath,
+		"Path of the npd-adapter binary in the Node Problem Detector container.")
+	flags.StringVar(&checks, "checks", defaultChecks, "Comma separated NVSentinel checks to report to NPD.")
+	flags.StringVar(&invokeInterval, "invoke-interval", "30s", "Interval between runs of the plugin.")
+
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	config, err := npd.NewMonitorConfig(path, splitList(checks), invokeInterval).JSON()
+	if err != nil {
+		return err
+	}
+
+	_, err = fmt.Println(string(config))
+
+	return err
+}
+
+func newKubernetesClient() (kubernetes.Interface, error) {
+	restCfg, err := rest.InClusterConfig()
+	if err != nil {
+		return nil, fmt.Errorf("failed to obtain in-cluster Kubernetes config: %w", err)
+	}
+
+	k8sClient, err := kubernetes.NewForConfig(restCfg)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create Kubernetes clientset: %w", err)
+	}
+
+	return k8sClient, nil
+}
+
+func splitList(value string) []string {
+	var items []string
+
+	for item := range strings.SplitSeq(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+
+	return items
+}
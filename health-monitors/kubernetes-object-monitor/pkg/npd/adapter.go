@@ -0,0 +1,353 @@
+// Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package npd integrates NVSentinel with Node Problem Detector (NPD), so sites
+// already running NPD can migrate incrementally or run both. The Adapter ingests
+// what NPD reports on a node, its permanent problems as node conditions and its
+// temporary problems as node events, and sends them as health events. In the other
+// direction, PluginStatus reports the NVSentinel condition of a check in the NPD
+// custom plugin protocol and NewMonitorConfig generates the NPD custom plugin monitor
+// that runs it.
+package npd
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"slices"
+	"time"
+
+	datamodels "github.com/nvidia/nvsentinel/data-models/pkg/model"
+	pb "github.com/nvidia/nvsentinel/data-models/pkg/protos"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/kubernetes"
+)
+
+const (
+	// AgentName is the agent of the health events sent by the adapter
+	AgentName = "npd-adapter"
+	// CheckPrefix prefixes the NPD condition types and event reasons to form check
+	// names, so the node conditions written for them do not clash with NPD's own
+	CheckPrefix = "NPD"
+
+	componentClass = "Node"
+
+	udsMaxRetries = 3
+	udsRetryDelay = time.Second
+)
+
+var (
+	// DefaultConditions are the condition types set by the NPD default monitors
+	DefaultConditions = []string{
+		"KernelDeadlock",
+		"ReadonlyFilesystem",
+		"FrequentKubeletRestart",
+		"FrequentDockerRestart",
+		"FrequentContainerdRestart",
+		"CorruptDockerOverlay2",
+	}
+	// DefaultFatalConditions are the conditions reported as fatal, the node cannot
+	// run workloads reliably while they hold
+	DefaultFatalConditions = []string{"KernelDeadlock", "ReadonlyFilesystem"}
+	// DefaultSources are the sources of the events of the NPD default monitors
+	DefaultSources = []string{
+		"kernel-monitor",
+		"systemd-monitor",
+		"docker-monitor",
+		"custom-plugin-monitor",
+	}
+)
+
+// Config configures an Adapter.
+type Config struct {
+	// NodeName is the Kubernetes node the adapter runs on
+	NodeName string
+	// PollInterval is how often the node and its events are read
+	PollInterval time.Duration
+	// Conditions are the NPD condition types ingested
+	Conditions []string
+	// FatalConditions are the ingested conditions reported as fatal events, the
+	// others as non-fatal events
+	FatalConditions []string
+	// Sources are the NPD event sources whose warnings are ingested
+	Sources []string
+}
+
+// conditionState is the last reported state of a condition
+type conditionState struct {
+	status  corev1.ConditionStatus
+	reason  string
+	message string
+}
+
+// Adapter polls the conditions and events NPD reports on its node and sends the
+// changes as health events.
+type Adapter struct {
+	cfg       Config
+	udsClient pb.PlatformConnectorClient
+	k8sClient kubernetes.Interface
+
+	started    time.Time
+	conditions map[string]conditionState
+	// events holds the count of the NPD events already reported by UID
+	events map[string]int32
+}
+
+// NewAdapter constructs an Adapter.
+func NewAdapter(cfg Config, udsClient pb.PlatformConnectorClient, k8sClient kubernetes.Interface) *Adapter {
+	return &Adapter{
+		cfg:        cfg,
+		udsClient:  udsClient,
+		k8sClient:  k8sClient,
+		conditions: map[string]conditionState{},
+		events:     map[string]int32{},
+	}
+}
+
+// Start polls until the context is cancelled. Events NPD recorded before the
+// adapter started are not reported, the conditions are reported as they are.
+func (a *Adapter) Start(ctx context.Context) error {
+	a.started = time.Now()
+
+	slog.Info("Ingesting Node Problem Detector conditions and events",
+		"node", a.cfg.NodeName,
+		"conditions", a.cfg.Conditions,
+		"sources", a.cfg.Sources,
+		"pollInterval", a.cfg.PollInterval)
+
+	ticker := time.NewTicker(a.cfg.PollInterval)
+	defer ticker.Stop()
+
+	for {
+		a.poll(ctx)
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// poll reports the changed conditions and the new events. Changes that fail to be
+// sent are retried on the next poll.
+func (a *Adapter) poll(ctx context.Context) {
+	node, err := a.k8sClient.CoreV1().Nodes().Get(ctx, a.cfg.NodeName, metav1.GetOptions{})
+	if err != nil {
+		pollErrors.Inc()
+		slog.Warn("Failed to get node", "node", a.cfg.NodeName, "error", err)
+
+		return
+	}
+
+	for _, condition := range node.Status.Conditions {
+		conditionType := string(condition.Type)
+		if !slices.Contains(a.cfg.Conditions, conditionType) {
+			continue
+		}
+
+		// NPD sets Unknown until its monitor has run
+		if condition.Status == corev1.ConditionUnknown {
+			continue
+		}
+
+		state := conditionState{status: condition.Status, reason: condition.Reason, message: condition.Message}
+		if previous, ok := a.conditions[conditionType]; ok && previous == state {
+			continue
+		}
+
+		if a.send(ctx, a.conditionEvent(&condition), "condition") {
+			a.conditions[conditionType] = state
+		}
+	}
+
+	events, err := a.k8sClient.CoreV1().Events(metav1.NamespaceAll).List(ctx, metav1.ListOptions{
+		FieldSelector: fields.Set{
+			"involvedObject.kind": "Node",
+			"involvedObject.name": a.cfg.NodeName,
+			"type":                corev1.EventTypeWarning,
+		}.String(),
+	})
+	if err != nil {
+		pollErrors.Inc()
+		slog.Warn("Failed to list node events", "node", a.cfg.NodeName, "error", err)
+
+		return
+	}
+
+	seen := make(map[string]int32, len(events.Items))
+
+	for i := range events.Items {
+		event := &events.Items[i]
+		if event.InvolvedObject.Name != a.cfg.NodeName || event.Type != corev1.EventTypeWarning ||
+			!slices.Contains(a.cfg.Sources, eventSource(event)) {
+			continue
+		}
+
+		uid := string(event.UID)
+		seen[uid] = event.Count
+
+		if count, ok := a.events[uid]; (ok && count == event.Count) || eventTime(event).Before(a.started) {
+			continue
+		}
+
+		if !a.send(ctx, a.problemEvent(event), "event") {
+			delete(seen, uid)
+		}
+	}
+
+	// Expired events are forgotten
+	a.events = seen
+}
+
+// conditionEvent maps an NPD condition to a health event, unhealthy while the
+// condition is true.
+func (a *Adapter) conditionEvent(condition *corev1.NodeCondition) *pb.HealthEvent {
+	conditionType := string(condition.Type)
+	unhealthy := condition.Status == corev1.ConditionTrue
+	fatal := unhealthy && slices.Contains(a.cfg.FatalConditions, conditionType)
+
+	action := pb.RecommendedAction_NONE
+	if fatal {
+		action = pb.RecommendedAction_CONTACT_SUPPORT
+	}
+
+	healthEvent := &pb.HealthEvent{
+		Agent:             AgentName,
+		ComponentClass:    componentClass,
+		CheckName:         CheckPrefix + conditionType,
+		IsFatal:           fatal,
+		IsHealthy:         !unhealthy,
+		Message:           condition.Message,
+		RecommendedAction: action,
+		Metadata: map[string]string{
+			"npd.condition": conditionType,
+			"npd.reason":    condition.Reason,
+		},
+		NodeName:           a.cfg.NodeName,
+		GeneratedTimestamp: timestamppb.New(condition.LastTransitionTime.Time),
+	}
+
+	if unhealthy {
+		healthEvent.ErrorCode = []string{condition.Reason}
+	}
+
+	return healthEvent
+}
+
+// problemEvent maps a temporary problem reported by NPD to a non-fatal health event
+func (a *Adapter) problemEvent(event *corev1.Event) *pb.HealthEvent {
+	return &pb.HealthEvent{
+		Agent:             AgentName,
+		ComponentClass:    componentClass,
+		CheckName:         CheckPrefix + event.Reason,
+		IsFatal:           false,
+		IsHealthy:         false,
+		Message:           event.Message,
+		RecommendedAction: pb.RecommendedAction_NONE,
+		ErrorCode:         []string{event.Reason},
+		Metadata: map[string]string{
+			"npd.source": eventSource(event),
+			"npd.count":  fmt.Sprint(event.Count),
+		},
+		NodeName:           a.cfg.NodeName,
+		GeneratedTimestamp: timestamppb.New(eventTime(event)),
+	}
+}
+
+// send sends the event and reports whether it was delivered
+func (a *Adapter) send(ctx context.Context, healthEvent *pb.HealthEvent, kind string) bool {
+	if err := a.sendHealthEventWithRetry(ctx, healthEvent); err != nil {
+		eventSendErrors.Inc()
+		slog.Error("Failed to send health event, retrying on next poll",
+			"node", a.cfg.NodeName,
+			"check", healthEvent.CheckName,
+			"error", err)
+
+		return false
+	}
+
+	eventsSent.WithLabelValues(kind).Inc()
+	slog.Info("Sent Node Problem Detector health event",
+		"node", a.cfg.NodeName,
+		"check", healthEvent.CheckName,
+		"healthy", healthEvent.IsHealthy,
+		"fatal", healthEvent.IsFatal)
+
+	return true
+}
+
+func (a *Adapter) sendHealthEventWithRetry(ctx context.Context, healthEvent *pb.HealthEvent) error {
+	// Assigned once so that retries of the event keep its ID
+	datamodels.AssignEventID(healthEvent)
+
+	backoff := wait.Backoff{
+		Steps:    udsMaxRetries,
+		Duration: udsRetryDelay,
+		Factor:   1.5,
+		Jitter:   0.1,
+	}
+
+	var lastErr error
+
+	err := wait.ExponentialBackoffWithContext(ctx, backoff, func(ctx context.Context) (bool, error) {
+		_, lastErr = a.udsClient.HealthEventOccurredV1(ctx, &pb.HealthEvents{
+			Events: []*pb.HealthEvent{healthEvent},
+		})
+		if lastErr == nil {
+			return true, nil
+		}
+
+		if st, ok := status.FromError(lastErr); ok && st.Code() == codes.Unavailable {
+			slog.Warn("Retryable error sending health event via UDS. Retrying...", "error", lastErr)
+			return false, nil
+		}
+
+		return false, lastErr
+	})
+	if err != nil && lastErr != nil {
+		return lastErr
+	}
+
+	return err
+}
+
+// eventSource returns the component that reported the event, NPD sets the source
+// of its events to the source of the monitor config
+func eventSource(event *corev1.Event) string {
+	if event.Source.Component != "" {
+		return event.Source.Component
+	}
+
+	return event.ReportingController
+}
+
+// eventTime returns when the event last occurred
+func eventTime(event *corev1.Event) time.Time {
+	switch {
+	case !event.LastTimestamp.IsZero():
+		return event.LastTimestamp.Time
+	case !event.EventTime.IsZero():
+		return event.EventTime.Time
+	default:
+		return event.CreationTimestamp.Time
+	}
+}
@@ -0,0 +1,177 @@
+// Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package npd
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	pb "github.com/nvidia/nvsentinel/data-models/pkg/protos"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/types/known/emptypb"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+const testNodeName = "gpu-node"
+
+type fakeUDSClient struct {
+	events []*pb.HealthEvent
+}
+
+func (f *fakeUDSClient) HealthEventOccurredV1(
+	_ context.Context, in *pb.HealthEvents, _ ...grpc.CallOption,
+) (*emptypb.Empty, error) {
+	f.events = append(f.events, in.Events...)
+
+	return &emptypb.Empty{}, nil
+}
+
+func (f *fakeUDSClient) take() []*pb.HealthEvent {
+	events := f.events
+	f.events = nil
+
+	return events
+}
+
+func testNode(conditions ...corev1.NodeCondition) *corev1.Node {
+	return &corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{Name: testNodeName},
+		Status:     corev1.NodeStatus{Conditions: conditions},
+	}
+}
+
+func testEvent(name, source, reason string, count int32, at time.Time) *corev1.Event {
+	return &corev1.Event{
+		ObjectMeta:     metav1.ObjectMeta{Name: name, Namespace: "default", UID: types.UID("uid-" + name)},
+		InvolvedObject: corev1.ObjectReference{Kind: "Node", Name: testNodeName},
+		Reason:         reason,
+		Message:        reason + " on " + testNodeName,
+		Source:         corev1.EventSource{Component: source, Host: testNodeName},
+		Count:          count,
+		LastTimestamp:  metav1.NewTime(at),
+		Type:           corev1.EventTypeWarning,
+	}
+}
+
+func newTestAdapter(k8sClient *fake.Clientset, udsClient *fakeUDSClient) *Adapter {
+	adapter := NewAdapter(Config{
+		NodeName:        testNodeName,
+		PollInterval:    time.Second,
+		Conditions:      DefaultConditions,
+		FatalConditions: DefaultFatalConditions,
+		Sources:         DefaultSources,
+	}, udsClient, k8sClient)
+	adapter.started = time.Now().Add(-time.Minute)
+
+	return adapter
+}
+
+func TestAdapterConditions(t *testing.T) {
+	ctx := context.Background()
+
+	k8sClient := fake.NewSimpleClientset(testNode(
+		corev1.NodeCondition{Type: "KernelDeadlock", Status: corev1.ConditionTrue, Reason: "DockerHung",
+			Message: "task docker:7 blocked for more than 120 seconds."},
+		corev1.NodeCondition{Type: "FrequentKubeletRestart", Status: corev1.ConditionFalse, Reason: "NoFrequentKubeletRestart"},
+		corev1.NodeCondition{Type: "CorruptDockerOverlay2", Status: corev1.ConditionUnknown},
+		// conditions of NVSentinel and the kubelet are not NPD's
+		corev1.NodeCondition{Type: "SysLogsXIDError", Status: corev1.ConditionTrue},
+		corev1.NodeCondition{Type: corev1.NodeReady, Status: corev1.ConditionTrue},
+	))
+	udsClient := &fakeUDSClient{}
+	adapter := newTestAdapter(k8sClient, udsClient)
+
+	adapter.poll(ctx)
+
+	events := udsClient.take()
+	require.Len(t, events, 2)
+
+	deadlock := events[0]
+	assert.Equal(t, "NPDKernelDeadlock", deadlock.CheckName)
+	assert.Equal(t, AgentName, deadlock.Agent)
+	assert.True(t, deadlock.IsFatal)
+	assert.False(t, deadlock.IsHealthy)
+	assert.Equal(t, pb.RecommendedAction_CONTACT_SUPPORT, deadlock.RecommendedAction)
+	assert.Equal(t, []string{"DockerHung"}, deadlock.ErrorCode)
+	assert.Equal(t, testNodeName, deadlock.NodeName)
+	assert.NotEmpty(t, deadlock.Id)
+
+	restart := events[1]
+	assert.Equal(t, "NPDFrequentKubeletRestart", restart.CheckName)
+	assert.True(t, restart.IsHealthy)
+	assert.False(t, restart.IsFatal)
+
+	// unchanged conditions are not reported again
+	adapter.poll(ctx)
+	assert.Empty(t, udsClient.take())
+
+	node := testNode(corev1.NodeCondition{Type: "KernelDeadlock", Status: corev1.ConditionFalse, Reason: "KernelHasNoDeadlock"})
+	_, err := k8sClient.CoreV1().Nodes().Update(ctx, node, metav1.UpdateOptions{})
+	require.NoError(t, err)
+
+	adapter.poll(ctx)
+
+	events = udsClient.take()
+	require.Len(t, events, 1)
+	assert.Equal(t, "NPDKernelDeadlock", events[0].CheckName)
+	assert.True(t, events[0].IsHealthy)
+	assert.Empty(t, events[0].ErrorCode)
+}
+
+func TestAdapterEvents(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now()
+
+	k8sClient := fake.NewSimpleClientset(
+		testNode(),
+		testEvent("oom", "kernel-monitor", "OOMKilling", 1, now),
+		// recorded before the adapter started
+		testEvent("old", "kernel-monitor", "TaskHung", 1, now.Add(-time.Hour)),
+		// not reported by NPD
+		testEvent("xid", "syslog-health-monitor", "SysLogsXIDErrorIsNotHealthy", 1, now),
+	)
+	udsClient := &fakeUDSClient{}
+	adapter := newTestAdapter(k8sClient, udsClient)
+
+	adapter.poll(ctx)
+
+	events := udsClient.take()
+	require.Len(t, events, 1)
+	assert.Equal(t, "NPDOOMKilling", events[0].CheckName)
+	assert.False(t, events[0].IsFatal)
+	assert.False(t, events[0].IsHealthy)
+	assert.Equal(t, "kernel-monitor", events[0].Metadata["npd.source"])
+
+	adapter.poll(ctx)
+	assert.Empty(t, udsClient.take())
+
+	// a repeated event is reported again
+	oom := testEvent("oom", "kernel-monitor", "OOMKilling", 2, now.Add(time.Second))
+	_, err := k8sClient.CoreV1().Events("default").Update(ctx, oom, metav1.UpdateOptions{})
+	require.NoError(t, err)
+
+	adapter.poll(ctx)
+
+	events = udsClient.take()
+	require.Len(t, events, 1)
+	assert.Equal(t, "2", events[0].Metadata["npd.count"])
+}
@@ -0,0 +1,42 @@
+// Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package npd
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+var (
+	eventsSent = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "npd_adapter_events_sent_total",
+			Help: "Total number of health events sent for Node Problem Detector conditions and events.",
+		},
+		[]string{"kind"}, // condition, event
+	)
+	eventSendErrors = promauto.NewCounter(
+		prometheus.CounterOpts{
+			Name: "npd_adapter_event_send_errors_total",
+			Help: "Total number of errors sending health events via UDS.",
+		},
+	)
+	pollErrors = promauto.NewCounter(
+		prometheus.CounterOpts{
+			Name: "npd_adapter_poll_errors_total",
+			Help: "Total number of errors reading the node or its events.",
+		},
+	)
+)
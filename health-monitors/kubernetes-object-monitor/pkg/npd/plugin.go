@@ -0,0 +1,139 @@
+// Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package npd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+// Exit codes of the NPD custom plugin protocol
+const (
+	PluginOK      = 0
+	PluginNonOK   = 1
+	PluginUnknown = 2
+)
+
+// ConditionPrefix prefixes the check names to form the condition types NPD sets for
+// the NVSentinel checks, so they do not clash with the conditions NVSentinel sets
+const ConditionPrefix = "NVSentinel"
+
+// PluginStatus reports the NVSentinel condition of the check on the node in the NPD
+// custom plugin protocol: PluginNonOK with the condition message while the check
+// is unhealthy, PluginOK while it is healthy or has not reported anything, and
+// PluginUnknown when the node cannot be read.
+func PluginStatus(ctx context.Context, k8sClient kubernetes.Interface, nodeName, check string) (int, string) {
+	node, err := k8sClient.CoreV1().Nodes().Get(ctx, nodeName, metav1.GetOptions{})
+	if err != nil {
+		return PluginUnknown, fmt.Sprintf("failed to get node %s: %v", nodeName, err)
+	}
+
+	for _, condition := range node.Status.Conditions {
+		if string(condition.Type) != check {
+			continue
+		}
+
+		if condition.Status == corev1.ConditionTrue {
+			return PluginNonOK, strings.TrimSuffix(condition.Message, ";")
+		}
+
+		return PluginOK, check + " is healthy"
+	}
+
+	return PluginOK, check + " has not reported a problem"
+}
+
+// MonitorConfig is the NPD custom plugin monitor configuration, see
+// https://github.com/kubernetes/node-problem-detector/blob/master/docs/custom_plugin_monitor.md
+type MonitorConfig struct {
+	Plugin           string          `json:"plugin"`
+	PluginConfig     PluginConfig    `json:"pluginConfig"`
+	Source           string          `json:"source"`
+	MetricsReporting bool            `json:"metricsReporting"`
+	Conditions       []ConditionInfo `json:"conditions"`
+	Rules            []Rule          `json:"rules"`
+}
+
+// PluginConfig configures how NPD runs the plugins of a monitor.
+type PluginConfig struct {
+	InvokeInterval  string `json:"invoke_interval"`
+	Timeout         string `json:"timeout"`
+	MaxOutputLength int    `json:"max_output_length"`
+	Concurrency     int    `json:"concurrency"`
+}
+
+// ConditionInfo is a node condition set by the monitor, with its default state.
+type ConditionInfo struct {
+	Type    string `json:"type"`
+	Reason  string `json:"reason"`
+	Message string `json:"message"`
+}
+
+// Rule runs a plugin and sets a condition from its exit code.
+type Rule struct {
+	Type      string   `json:"type"`
+	Condition string   `json:"condition"`
+	Reason    string   `json:"reason"`
+	Path      string   `json:"path"`
+	Args      []string `json:"args"`
+	Timeout   string   `json:"timeout"`
+}
+
+// NewMonitorConfig returns the NPD custom plugin monitor that runs the plugin at
+// path for every check, each setting the condition ConditionPrefix+check.
+func NewMonitorConfig(path string, checks []string, invokeInterval string) *MonitorConfig {
+	config := &MonitorConfig{
+		Plugin: "custom",
+		PluginConfig: PluginConfig{
+			InvokeInterval:  invokeInterval,
+			Timeout:         "10s",
+			MaxOutputLength: 256,
+			Concurrency:     3,
+		},
+		Source:           "nvsentinel-custom-plugin-monitor",
+		MetricsReporting: true,
+	}
+
+	for _, check := range checks {
+		condition := ConditionPrefix + check
+
+		config.Conditions = append(config.Conditions, ConditionInfo{
+			Type:    condition,
+			Reason:  check + "IsHealthy",
+			Message: "NVSentinel reports no " + check + " problem",
+		})
+		config.Rules = append(config.Rules, Rule{
+			Type:      "permanent",
+			Condition: condition,
+			Reason:    check + "IsNotHealthy",
+			Path:      path,
+			Args:      []string{"plugin", "--check", check},
+			Timeout:   "10s",
+		})
+	}
+
+	return config
+}
+
+// JSON returns the indented configuration NPD reads.
+func (c *MonitorConfig) JSON() ([]byte, error) {
+	return json.MarshalIndent(c, "", "  ")
+}
@@ -0,0 +1,72 @@
+// Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package npd
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestPluginStatus(t *testing.T) {
+	ctx := context.Background()
+	k8sClient := fake.NewSimpleClientset(testNode(
+		corev1.NodeCondition{Type: "SysLogsXIDError", Status: corev1.ConditionTrue,
+			Message: "ErrorCode:79 GPU:0 GPU has fallen off the bus Recommended Action=RESTART_BM;"},
+		corev1.NodeCondition{Type: "SysLogsSXIDError", Status: corev1.ConditionFalse, Message: "No Health Failures"},
+	))
+
+	code, message := PluginStatus(ctx, k8sClient, testNodeName, "SysLogsXIDError")
+	assert.Equal(t, PluginNonOK, code)
+	assert.Equal(t, "ErrorCode:79 GPU:0 GPU has fallen off the bus Recommended Action=RESTART_BM", message)
+
+	code, _ = PluginStatus(ctx, k8sClient, testNodeName, "SysLogsSXIDError")
+	assert.Equal(t, PluginOK, code)
+
+	code, _ = PluginStatus(ctx, k8sClient, testNodeName, "SysLogsGPUFallenOff")
+	assert.Equal(t, PluginOK, code)
+
+	code, _ = PluginStatus(ctx, k8sClient, "missing", "SysLogsXIDError")
+	assert.Equal(t, PluginUnknown, code)
+}
+
+func TestMonitorConfig(t *testing.T) {
+	data, err := NewMonitorConfig("/opt/nvsentinel/npd-adapter", []string{"SysLogsXIDError"}, "30s").JSON()
+	require.NoError(t, err)
+
+	var config map[string]any
+	require.NoError(t, json.Unmarshal(data, &config))
+
+	assert.Equal(t, "custom", config["plugin"])
+	assert.Equal(t, "30s", config["pluginConfig"].(map[string]any)["invoke_interval"])
+	assert.Equal(t, []any{map[string]any{
+		"type":    "NVSentinelSysLogsXIDError",
+		"reason":  "SysLogsXIDErrorIsHealthy",
+		"message": "NVSentinel reports no SysLogsXIDError problem",
+	}}, config["conditions"])
+	assert.Equal(t, []any{map[string]any{
+		"type":      "permanent",
+		"condition": "NVSentinelSysLogsXIDError",
+		"reason":    "SysLogsXIDErrorIsNotHealthy",
+		"path":      "/opt/nvsentinel/npd-adapter",
+		"args":      []any{"plugin", "--check", "SysLogsXIDError"},
+		"timeout":   "10s",
+	}}, config["rules"])
+}
@@ -25,15 +25,21 @@ import (
 	"syscall"
 	"time"
 
+	"github.com/nvidia/nvsentinel/commons/pkg/connpool"
 	"github.com/nvidia/nvsentinel/commons/pkg/logger"
+	"github.com/nvidia/nvsentinel/commons/pkg/lrucache"
 	"github.com/nvidia/nvsentinel/commons/pkg/server"
 	"github.com/nvidia/nvsentinel/commons/pkg/stringutil"
+	"github.com/nvidia/nvsentinel/data-models/pkg/model"
 	pb "github.com/nvidia/nvsentinel/data-models/pkg/protos"
+	"github.com/nvidia/nvsentinel/health-monitors/syslog-health-monitor/pkg/ecc"
+	"github.com/nvidia/nvsentinel/health-monitors/syslog-health-monitor/pkg/policy"
+	"github.com/nvidia/nvsentinel/health-monitors/syslog-health-monitor/pkg/rulepack"
 	fd "github.com/nvidia/nvsentinel/health-monitors/syslog-health-monitor/pkg/syslog-monitor"
+	"github.com/nvidia/nvsentinel/health-monitors/syslog-health-monitor/pkg/watchdog"
 	"golang.org/x/sync/errgroup"
 
 	"google.golang.org/grpc"
-	"google.golang.org/grpc/connectivity"
 	"google.golang.org/grpc/credentials/insecure"
 )
 
@@ -42,6 +48,8 @@ const (
 	defaultComponentClass  = "GPU"                                // Or a more specific class if applicable
 	defaultPollingInterval = "30m"                                // Default polling interval
 	defaultStateFilePath   = "/var/run/syslog_monitor/state.json" // Default state file path
+	// platformConnectorReadyTimeout bounds the wait for the platform connector at startup
+	platformConnectorReadyTimeout = time.Minute
 )
 
 var (
@@ -55,6 +63,11 @@ var (
 		"Comma separated listed of checks to enable")
 	platformConnectorSocket = flag.String("platform-connector-socket", "unix:///var/run/nvsentinel.sock",
 		"Path to the platform-connector UDS socket.")
+	platformConnectorFallbacks = flag.String("platform-connector-fallback-endpoints", "",
+		"Comma separated gRPC targets of other platform connector replicas, "+
+			"events fail over to them when the socket is unavailable")
+	platformConnectorProbeInterval = flag.Duration("platform-connector-probe-interval", 10*time.Second,
+		"Interval between health probes of the platform connector endpoints")
 	nodeNameEnv         = flag.String("node-name", os.Getenv("NODE_NAME"), "Node name. Defaults to NODE_NAME env var.")
 	pollingIntervalFlag = flag.String("polling-interval", defaultPollingInterval,
 		"Polling interval for health checks (e.g., 15m, 1h).")
@@ -67,6 +80,56 @@ var (
 		"Indicates if this monitor is running in Kata Containers mode (set by DaemonSet variant).")
 	metadataPath = flag.String("metadata-path", "/var/lib/nvsentinel/gpu_metadata.json",
 		"Path to GPU metadata JSON file.")
+	eventStormThreshold = flag.Int("event-storm-threshold", 100,
+		"Events per minute above which the node is considered noisy. 0 disables the event storm breaker.")
+	eventStormMinutes = flag.Int("event-storm-minutes", 5,
+		"Consecutive noisy minutes after which further non-fatal events are collapsed into a NODE_EVENT_STORM event.")
+	eventStormCooldown = flag.Duration("event-storm-cooldown", 10*time.Minute,
+		"How long line processing backs off and non-fatal events are suppressed once the event storm breaker trips.")
+	cpuBudget = flag.Float64("cpu-budget", 0.2,
+		"CPU budget in cores; line processing is throttled while usage exceeds it. 0 disables throttling.")
+	checkWorkers = flag.Int("check-workers", 1,
+		"Number of checks processed concurrently; lines of a check stay in journal order. 1 runs checks sequentially.")
+	compressionFlag = flag.String("compression", "none",
+		"Compression for health events sent to the platform connector: none, gzip or zstd.")
+	batchSizeFlag = flag.Int("batch-size", 1,
+		"Maximum number of health events sent per call to the platform connector. 1 disables batching.")
+	rulePacksEnabled = flag.Bool("rule-packs", false,
+		"Apply the rule pack matching the GPU SKU of the node (thresholds, benign error codes, expected topology).")
+	rulePacksDir = flag.String("rule-packs-dir", "",
+		"Directory with additional *.toml rule packs; a pack replaces the built-in pack of the same name.")
+	heartbeatInterval = flag.Duration("heartbeat-interval", 5*time.Minute,
+		"Interval between heartbeats reporting the monitor and rule pack versions. 0 disables heartbeats.")
+	rulePackServer = flag.String("rule-pack-server", "",
+		"Address of the rule pack distribution service (host:port) to sync rule pack bundles from. "+
+			"Requires --rule-packs. Empty disables syncing.")
+	rulePackPublicKey = flag.String("rule-pack-public-key", "",
+		"Path to the PEM encoded Ed25519 public key distributed rule pack bundles are verified with.")
+	rulePackSyncInterval = flag.Duration("rule-pack-sync-interval", 5*time.Minute,
+		"Interval between rule pack bundle syncs.")
+	driverInstallLogFiles = flag.String("driver-install-log-files",
+		"/nvsentinel/var/log/nvidia-installer.log,"+
+			"/nvsentinel/var/log/pods/*_nvidia-driver-daemonset-*/nvidia-driver-ctr/*.log,"+
+			"/nvsentinel/var/log/apt/term.log",
+		"Comma separated glob patterns of nvidia-installer and dkms log files read by the SysLogsDriverInstall check.")
+	watchdogConfig = flag.String("watchdog-config", "",
+		"Path to the TOML file with the expected periodic log lines checked by the SysLogsMissingLine check.")
+	stateCapacity = flag.Int("state-capacity", lrucache.DefaultCapacity,
+		"Maximum entries of the per-GPU and per-kernel state kept by each check; least recently used entries are evicted.")
+	eccSBEThreshold = flag.Int("ecc-sbe-threshold", ecc.DefaultSBEThreshold,
+		"Single-bit ECC errors of a GPU after which the SysLogsECCError check reports it as degrading.")
+	minShippedSeverity = flag.String("min-shipped-severity", "info",
+		"Minimum severity of the unhealthy events shipped to the platform connector: info, warning (non-fatal events "+
+			"recommending an action) or fatal. Events below it are only retained on the node.")
+	localBufferMB = flag.Int("local-buffer-mb", fd.DefaultLocalBufferMB,
+		"Size in MiB of the buffer retaining the latest events, shipped or not, and the raw log lines they were "+
+			"generated from on the node, served on "+fd.LocalEventsPath+". 0 retains none.")
+	expressInterval = flag.Duration("express-interval", fd.DefaultExpressInterval,
+		"Interval between the scans of the journal for catastrophic lines (GPU fallen off the bus, double-bit ECC "+
+			"error, fatal SXid), which trigger a run right away instead of at the next polling interval. 0 disables it.")
+	policyConfig = flag.String("policy-config", "",
+		"Path to the TOML file with the event policy rules deciding the severity, recommended action and message "+
+			"of XID, SXID, GPU fallen off the bus and driver install events. Empty keeps the built-in decisions.")
 )
 
 var checks []fd.CheckDefinition
@@ -102,30 +165,62 @@ func run() error {
 	// Build gRPC dial options (mTLS can replace insecure credentials in production).
 	var dialOpts []grpc.DialOption
 
-	dialOpts = append(dialOpts, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	dialOpts = append(dialOpts,
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithStatsHandler(fd.PayloadStatsHandler{}),
+	)
 
-	// Create gRPC client to platform connector with retries and per-attempt timeout.
-	slog.Info("Creating gRPC client to platform connector", "socket", *platformConnectorSocket)
+	// Create gRPC clients to the platform connector endpoints and wait for one to connect.
+	slog.Info("Creating gRPC client to platform connector", "socket", *platformConnectorSocket,
+		"fallbacks", *platformConnectorFallbacks)
 
-	conn, err := dialWithRetry(ctx, *platformConnectorSocket, dialOpts...)
+	pool, err := newPlatformConnectorPool(ctx, dialOpts)
 	if err != nil {
-		return fmt.Errorf("failed to create gRPC client after retries: %w", err)
+		return err
 	}
 
 	defer func() {
-		if closeErr := conn.Close(); closeErr != nil {
-			slog.Error("Error closing gRPC connection", "error", closeErr)
+		if closeErr := pool.Close(); closeErr != nil {
+			slog.Error("Error closing gRPC connections", "error", closeErr)
 		}
 	}()
 
-	client := pb.NewPlatformConnectorClient(conn)
+	client := pb.NewPlatformConnectorClient(pool)
+
+	var watchdogConfigData watchdog.Config
+
+	if *watchdogConfig != "" {
+		watchdogConfigData, err = watchdog.LoadConfig(*watchdogConfig)
+		if err != nil {
+			return fmt.Errorf("failed to load watchdog config: %w", err)
+		}
+	}
+
+	var eventPolicy *policy.Policy
+
+	if *policyConfig != "" {
+		eventPolicy, err = policy.LoadConfig(*policyConfig)
+		if err != nil {
+			return fmt.Errorf("failed to load event policy config: %w", err)
+		}
+	}
 
 	checks = make([]fd.CheckDefinition, 0)
 	for c := range strings.SplitSeq((*checksList), ",") {
-		checks = append(checks, fd.CheckDefinition{
+		check := fd.CheckDefinition{
 			Name:        c,
 			JournalPath: "/nvsentinel/var/log/journal/",
-		})
+		}
+
+		if c == fd.DriverInstallCheck && *driverInstallLogFiles != "" {
+			check.LogFiles = strings.Split(*driverInstallLogFiles, ",")
+		}
+
+		if c == fd.MissingLineCheck {
+			check.LogFiles = watchdogConfigData.LogFiles
+		}
+
+		checks = append(checks, check)
 	}
 
 	if len(checks) == 0 {
@@ -174,6 +269,56 @@ func run() error {
 		return fmt.Errorf("error creating syslog health monitor: %w", err)
 	}
 
+	fdHealthMonitor.EnableEventStormBreaker(*eventStormThreshold, *eventStormMinutes, *eventStormCooldown)
+	fdHealthMonitor.EnableCPUThrottling(*cpuBudget)
+	fdHealthMonitor.EnableBatching(*batchSizeFlag)
+	fdHealthMonitor.EnableParallelChecks(*checkWorkers)
+	fdHealthMonitor.EnableStateCapacity(*stateCapacity)
+	fdHealthMonitor.EnableECCSBEThreshold(*eccSBEThreshold)
+	fdHealthMonitor.EnableLocalEvents(*localBufferMB)
+
+	minimumSeverity, err := fd.ParseSeverity(*minShippedSeverity)
+	if err != nil {
+		return fmt.Errorf("invalid minimum shipped severity: %w", err)
+	}
+
+	fdHealthMonitor.EnableSeverityFilter(minimumSeverity)
+	fdHealthMonitor.EnableExpressPath(*expressInterval)
+
+	fdHealthMonitor.EnableEventPolicy(eventPolicy)
+
+	if err := fdHealthMonitor.EnableWatchdog(watchdogConfigData.Rules); err != nil {
+		return fmt.Errorf("failed to enable watchdog: %w", err)
+	}
+
+	if *rulePacksEnabled {
+		packs, err := rulepack.Load(*rulePacksDir)
+		if err != nil {
+			return fmt.Errorf("failed to load rule packs: %w", err)
+		}
+
+		fdHealthMonitor.EnableRulePacks(packs)
+	}
+
+	if *rulePackServer != "" {
+		if !*rulePacksEnabled {
+			return fmt.Errorf("--rule-pack-server requires --rule-packs")
+		}
+
+		conn, err := enableRulePackSync(fdHealthMonitor)
+		if err != nil {
+			return err
+		}
+
+		defer conn.Close()
+	}
+
+	fdHealthMonitor.EnableHeartbeat(version, *heartbeatInterval)
+
+	if err := fdHealthMonitor.EnableCompression(*compressionFlag); err != nil {
+		return fmt.Errorf("invalid compression: %w", err)
+	}
+
 	pollingInterval, err := time.ParseDuration(*pollingIntervalFlag)
 	if err != nil {
 		return fmt.Errorf("error parsing polling interval: %w", err)
@@ -189,7 +334,10 @@ func run() error {
 	srv := server.NewServer(
 		server.WithPort(portInt),
 		server.WithPrometheusMetrics(),
+		server.WithHandler(logger.LevelsPath, logger.LevelsHandler()),
 		server.WithSimpleHealth(),
+		server.WithHandler(fd.RuleTestPath, fdHealthMonitor.RuleTestHandler()),
+		server.WithHandler(fd.LocalEventsPath, fdHealthMonitor.LocalEventsHandler()),
 	)
 
 	// Run the HTTP server and the polling loop under an errgroup bound to ctx.
@@ -207,6 +355,22 @@ func run() error {
 		return nil
 	})
 
+	g.Go(func() error {
+		return pool.Run(gCtx)
+	})
+
+	// Catastrophic lines trigger a run right away, coalesced while one is pending
+	expressTrigger := make(chan struct{}, 1)
+
+	g.Go(func() error {
+		return fdHealthMonitor.WatchExpress(gCtx, func() {
+			select {
+			case expressTrigger <- struct{}{}:
+			default:
+			}
+		})
+	})
+
 	// Polling loop with context-aware cancellation and tolerant error handling.
 	g.Go(func() error {
 		ticker := time.NewTicker(pollingInterval)
@@ -228,138 +392,114 @@ func run() error {
 				return nil // graceful shutdown (do not surface as error)
 			case <-ticker.C:
 				slog.Info("Performing scheduled health check run...")
+			case <-expressTrigger:
+				slog.Info("Performing express health check run...")
+			}
 
-				if err := fdHealthMonitor.Run(); err != nil {
-					// Log and continue; apply a capped backoff to avoid hot-looping on persistent failures.
-					if backoff == 0 {
-						backoff = 2 * time.Second
-					} else {
-						backoff *= 2
-					}
-
-					if backoff > 30*time.Second {
-						backoff = 30 * time.Second
-					}
+			if err := fdHealthMonitor.Run(); err != nil {
+				// Log and continue; apply a capped backoff to avoid hot-looping on persistent failures.
+				if backoff == 0 {
+					backoff = 2 * time.Second
+				} else {
+					backoff *= 2
+				}
 
-					slog.Error(
-						"Health check run failed; will retry after backoff",
-						"error", err,
-						"backoff", backoff,
-					)
+				if backoff > 30*time.Second {
+					backoff = 30 * time.Second
+				}
 
-					timer := time.NewTimer(backoff)
+				slog.Error(
+					"Health check run failed; will retry after backoff",
+					"error", err,
+					"backoff", backoff,
+				)
 
-					select {
-					case <-gCtx.Done():
-						timer.Stop()
-						slog.Info("Polling loop stopped during backoff due to context cancellation")
+				timer := time.NewTimer(backoff)
 
-						return nil
-					case <-timer.C:
-					}
+				select {
+				case <-gCtx.Done():
+					timer.Stop()
+					slog.Info("Polling loop stopped during backoff due to context cancellation")
 
-					continue
+					return nil
+				case <-timer.C:
 				}
 
-				// On success, reset backoff.
-				backoff = 0
+				continue
 			}
+
+			// On success, reset backoff.
+			backoff = 0
 		}
 	})
 
 	// Wait until either goroutine returns.
-	return g.Wait()
-}
-
-// dialWithRetry dials a gRPC target with bounded retries and per-attempt timeout.
-// It also verifies a unix domain socket path exists when scheme unix:// is used.
-func dialWithRetry(ctx context.Context, target string, opts ...grpc.DialOption) (*grpc.ClientConn, error) {
-	const (
-		maxRetries        = 10
-		perAttemptTimeout = 5 * time.Second
-	)
-
-	for attempt := 1; attempt <= maxRetries; attempt++ {
-		slog.Info("Checking platform connector socket availability",
-			"attempt", attempt,
-			"maxRetries", maxRetries,
-			"target", target,
-		)
+	if err := g.Wait(); err != nil {
+		return err
+	}
 
-		// For unix:// ensure the socket path exists before dialing.
-		if strings.HasPrefix(target, "unix://") {
-			socketPath := strings.TrimPrefix(target, "unix://")
-			if _, statErr := os.Stat(socketPath); statErr != nil {
-				slog.Warn("Platform connector socket file does not exist",
-					"attempt", attempt, "maxRetries", maxRetries, "error", statErr)
+	// The checks stopped: hand the state off to the pod replacing this one
+	if err := fdHealthMonitor.Handoff(version); err != nil {
+		slog.Error("Failed to hand off state", "error", err)
+	}
 
-				if attempt < maxRetries {
-					time.Sleep(time.Duration(attempt) * time.Second)
-					continue
-				}
+	return nil
+}
 
-				return nil, fmt.Errorf("platform connector socket file not found after retries: %w", statErr)
-			}
-		}
+// enableRulePackSync connects to the rule pack distribution service. The connection
+// is established lazily, so an unavailable service only fails the syncs.
+func enableRulePackSync(monitor *fd.SyslogMonitor) (*grpc.ClientConn, error) {
+	data, err := os.ReadFile(*rulePackPublicKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read rule pack public key: %w", err)
+	}
 
-		// Create client connection (non-blocking).
-		conn, err := grpc.NewClient(target, opts...)
-		if err != nil {
-			slog.Warn("Error creating gRPC client", "attempt", attempt, "maxRetries", maxRetries, "error", err)
+	publicKey, err := model.ParseRulePackPublicKey(data)
+	if err != nil {
+		return nil, fmt.Errorf("invalid rule pack public key %s: %w", *rulePackPublicKey, err)
+	}
 
-			if attempt < maxRetries {
-				time.Sleep(time.Duration(attempt) * time.Second)
-				continue
-			}
+	conn, err := grpc.NewClient(*rulePackServer, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create rule pack distribution client for %s: %w", *rulePackServer, err)
+	}
 
-			return nil, fmt.Errorf("failed to create gRPC client after retries: %w", err)
-		}
+	monitor.EnableRulePackSync(pb.NewRulePackDistributorClient(conn), publicKey, *rulePackSyncInterval)
 
-		// Actively connect and wait until Ready (or timeout/cancel).
-		if err := waitUntilReady(ctx, conn, perAttemptTimeout); err != nil {
-			_ = conn.Close()
+	slog.Info("Rule pack sync enabled", "server", *rulePackServer, "interval", *rulePackSyncInterval)
 
-			slog.Warn("gRPC client not ready before timeout",
-				"attempt", attempt,
-				"maxRetries", maxRetries,
-				"error", err,
-			)
+	return conn, nil
+}
 
-			if attempt < maxRetries {
-				time.Sleep(time.Duration(attempt) * time.Second)
-				continue
-			}
+// newPlatformConnectorPool connects to the platform connector socket and the
+// fallback endpoints, and waits until one of them is ready.
+func newPlatformConnectorPool(ctx context.Context, dialOpts []grpc.DialOption) (*connpool.Pool, error) {
+	endpoints := []string{*platformConnectorSocket}
 
-			return nil, fmt.Errorf("gRPC client not ready after retries: %w", err)
+	for endpoint := range strings.SplitSeq(*platformConnectorFallbacks, ",") {
+		if endpoint = strings.TrimSpace(endpoint); endpoint != "" {
+			endpoints = append(endpoints, endpoint)
 		}
+	}
 
-		slog.Info("Successfully connected to platform connector", "attempt", attempt)
+	config := connpool.DefaultConfig(endpoints...)
+	config.ProbeInterval = *platformConnectorProbeInterval
+	config.DialOptions = dialOpts
 
-		return conn, nil
+	pool, err := connpool.New(config)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create gRPC clients: %w", err)
 	}
 
-	// Unreachable, but keeps compiler happy.
-	return nil, fmt.Errorf("exhausted retries without creating gRPC client")
-}
-
-// waitUntilReady triggers connection establishment and blocks until the ClientConn
-// reaches connectivity.Ready or the timeout/context expires.
-func waitUntilReady(parent context.Context, conn *grpc.ClientConn, timeout time.Duration) error {
-	ctx, cancel := context.WithTimeout(parent, timeout)
+	readyCtx, cancel := context.WithTimeout(ctx, platformConnectorReadyTimeout)
 	defer cancel()
 
-	conn.Connect()
+	if err := pool.WaitReady(readyCtx); err != nil {
+		_ = pool.Close()
+		return nil, err
+	}
 
-	for {
-		state := conn.GetState()
-		if state == connectivity.Ready {
-			return nil
-		}
+	slog.Info("Successfully connected to platform connector", "endpoints", endpoints)
 
-		// Wait for a state change or context expiry.
-		if !conn.WaitForStateChange(ctx, state) {
-			// Context expired or canceled.
-			return ctx.Err()
-		}
-	}
+	return pool, nil
 }
@@ -0,0 +1,280 @@
+// Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package aer
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/nvidia/nvsentinel/commons/pkg/lrucache"
+	"github.com/nvidia/nvsentinel/data-models/pkg/model"
+	pb "github.com/nvidia/nvsentinel/data-models/pkg/protos"
+	"github.com/nvidia/nvsentinel/health-monitors/syslog-health-monitor/pkg/metadata"
+	"github.com/nvidia/nvsentinel/health-monitors/syslog-health-monitor/pkg/policy"
+)
+
+// NewAERHandler creates a new AERHandler instance. The GPU metadata tells the BDFs
+// of the GPUs apart from the other devices, e.g. NICs and NVMe drives.
+func NewAERHandler(nodeName, defaultAgentName,
+	defaultComponentClass, checkName, metadataPath string) (*AERHandler, error) {
+	return &AERHandler{
+		nodeName:              nodeName,
+		defaultAgentName:      defaultAgentName,
+		defaultComponentClass: defaultComponentClass,
+		checkName:             checkName,
+		metadataReader:        metadata.NewReader(metadataPath),
+		windows:               lrucache.New[string, *errorWindow]("syslog_aer_error_windows", lrucache.DefaultCapacity),
+		correctedTotals:       lrucache.New[string, int]("syslog_aer_corrected_totals", lrucache.DefaultCapacity),
+		escalationThreshold:   defaultEscalationThreshold,
+		escalationWindow:      defaultEscalationWindow,
+	}, nil
+}
+
+// SetStateCapacity bounds the number of devices whose errors are counted.
+func (h *AERHandler) SetStateCapacity(capacity int) {
+	h.windows.Resize(capacity)
+	h.correctedTotals.Resize(capacity)
+}
+
+// SetEscalation sets how many non-fatal errors of a device within the window
+// escalate them to an error rate event.
+func (h *AERHandler) SetEscalation(threshold int, window time.Duration) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.escalationThreshold = threshold
+	h.escalationWindow = window
+}
+
+// ProcessLine processes a single syslog line and returns any generated health events.
+func (h *AERHandler) ProcessLine(message string) (*pb.HealthEvents, error) {
+	event := parseAERError(message)
+	if event == nil {
+		return nil, nil
+	}
+
+	aerCounterMetric.WithLabelValues(h.nodeName, event.errorCode).Inc()
+
+	// Devices other than GPUs, or all of them without metadata, are reported by BDF only
+	gpuInfo, _ := h.metadataReader.GetGPUByPCI(event.bdf)
+
+	if event.errorCode == UncorrectedFatalErrorCode {
+		return h.createHealthEvent(h.extractFact(event, gpuInfo, 0), fatalDecision(event, gpuInfo)), nil
+	}
+
+	count, first, escalate := h.countNonFatal(event.bdf)
+
+	if event.errorCode == CorrectedErrorCode {
+		event.correctedTotal = h.countCorrected(event.bdf)
+	}
+
+	switch {
+	case escalate:
+		fact := h.extractFact(event, gpuInfo, count)
+		fact.ErrorCode = ErrorRateErrorCode
+
+		return h.createHealthEvent(fact, h.escalationDecision(event, count)), nil
+	case first:
+		return h.createHealthEvent(h.extractFact(event, gpuInfo, count), informationalDecision(event)), nil
+	}
+
+	// Later errors of the window are counted in the metric only
+	return nil, nil
+}
+
+// Prefilter reports whether the line may be an AER message.
+func (h *AERHandler) Prefilter(message string) bool {
+	return strings.Contains(message, "AER:")
+}
+
+// SetPolicy sets the event policy deciding the severity and action of AER facts.
+func (h *AERHandler) SetPolicy(eventPolicy *policy.Policy) {
+	h.policy = eventPolicy
+}
+
+func parseAERError(message string) *aerErrorEvent {
+	m := reAERPattern.FindStringSubmatch(message)
+	if m == nil {
+		return nil
+	}
+
+	bdf := strings.ToLower(m[reAERPattern.SubexpIndex("bdf")])
+	if id := m[reAERPattern.SubexpIndex("id")]; id != "" {
+		bdf = bdfFromRequesterID(id)
+	}
+
+	severity := m[reAERPattern.SubexpIndex("severity")]
+
+	errorCode := UncorrectedFatalErrorCode
+
+	switch {
+	case severity == "Corrected":
+		errorCode = CorrectedErrorCode
+	case m[reAERPattern.SubexpIndex("qualifier")] == "Non-Fatal":
+		errorCode = UncorrectedNonFatalErrorCode
+	}
+
+	return &aerErrorEvent{
+		errorCode: errorCode,
+		severity:  severity,
+		bdf:       bdf,
+		message:   message,
+	}
+}
+
+// bdfFromRequesterID converts the 16-bit requester ID of older kernels, the bus
+// number followed by the device and function numbers, to a BDF of domain 0000.
+func bdfFromRequesterID(id string) string {
+	value, err := strconv.ParseUint(id, 16, 16)
+	if err != nil {
+		return ""
+	}
+
+	return fmt.Sprintf("0000:%02x:%02x.%x", value>>8, (value>>3)&0x1f, value&0x7)
+}
+
+// countNonFatal counts a non-fatal error of the device. It returns the errors of
+// the current window, whether this is the first of the window and whether the
+// window just reached the escalation threshold.
+func (h *AERHandler) countNonFatal(bdf string) (int, bool, bool) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	now := time.Now()
+
+	window, ok := h.windows.Get(bdf)
+	if !ok || now.Sub(window.start) >= h.escalationWindow {
+		window = &errorWindow{start: now}
+		h.windows.Add(bdf, window)
+	}
+
+	window.count++
+
+	escalate := !window.escalated && h.escalationThreshold > 0 && window.count >= h.escalationThreshold
+	if escalate {
+		window.escalated = true
+	}
+
+	return window.count, window.count == 1, escalate
+}
+
+// countCorrected counts a corrected error of the device and returns the corrected
+// errors counted since the monitor started.
+func (h *AERHandler) countCorrected(bdf string) int {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	total, _ := h.correctedTotals.Get(bdf)
+	total++
+	h.correctedTotals.Add(bdf, total)
+
+	return total
+}
+
+// extractFact returns what the AER message reports, without severity or action.
+// gpuInfo is the GPU of the BDF, nil for other devices. count is the number of
+// non-fatal errors of the device in the current window.
+func (h *AERHandler) extractFact(event *aerErrorEvent, gpuInfo *model.GPUInfo, count int) policy.Fact {
+	entities := []*pb.Entity{{EntityType: "PCI", EntityValue: event.bdf}}
+
+	gpu := ""
+	if gpuInfo != nil {
+		gpu = strconv.Itoa(gpuInfo.GPUID)
+		entities = append(entities,
+			&pb.Entity{EntityType: "GPU", EntityValue: gpu},
+			&pb.Entity{EntityType: "GPU_UUID", EntityValue: gpuInfo.UUID},
+		)
+	}
+
+	metadata := make(map[string]string)
+	if chassisSerial := h.metadataReader.GetChassisSerial(); chassisSerial != nil {
+		metadata[model.MetadataChassisSerial] = *chassisSerial
+	}
+
+	// The cumulative counter lets the analyzer rate rules follow the growth of the errors
+	if event.correctedTotal > 0 {
+		metadata[model.MetadataAERCorrectedTotal] = strconv.Itoa(event.correctedTotal)
+	}
+
+	return policy.Fact{
+		CheckName: h.checkName,
+		ErrorCode: event.errorCode,
+		Entities:  entities,
+		Attributes: map[string]string{
+			"severity": event.severity,
+			"pci":      event.bdf,
+			"gpu":      gpu,
+			"count":    strconv.Itoa(count),
+		},
+		Metadata: metadata,
+		Line:     event.message,
+	}
+}
+
+// fatalDecision restarts the node for fatal errors of a GPU: the link is down
+// until the GPU is reset and the driver cannot recover it in place. Fatal errors
+// of other devices are reported to the operators without draining the node.
+func fatalDecision(event *aerErrorEvent, gpuInfo *model.GPUInfo) policy.Decision {
+	if gpuInfo == nil {
+		return policy.Decision{
+			IsFatal:           false,
+			RecommendedAction: pb.RecommendedAction_CONTACT_SUPPORT,
+			Message:           fmt.Sprintf("Uncorrectable PCIe error on device %s: %s", event.bdf, event.message),
+		}
+	}
+
+	return policy.Decision{
+		IsFatal:           true,
+		RecommendedAction: pb.RecommendedAction_RESTART_BM,
+		Message: fmt.Sprintf("Uncorrectable PCIe error on GPU %d (%s): %s",
+			gpuInfo.GPUID, event.bdf, event.message),
+	}
+}
+
+// informationalDecision reports the first non-fatal error of a window, which the
+// link recovered from.
+func informationalDecision(event *aerErrorEvent) policy.Decision {
+	return policy.Decision{
+		IsFatal:           false,
+		RecommendedAction: pb.RecommendedAction_NONE,
+		Message:           fmt.Sprintf("%s PCIe error on device %s: %s", event.severity, event.bdf, event.message),
+	}
+}
+
+// escalationDecision reports a device whose non-fatal errors reached the threshold
+// within the window, the sign of a marginal link or riser to be inspected.
+func (h *AERHandler) escalationDecision(event *aerErrorEvent, count int) policy.Decision {
+	return policy.Decision{
+		IsFatal:           false,
+		RecommendedAction: pb.RecommendedAction_CONTACT_SUPPORT,
+		Message: fmt.Sprintf("%d non-fatal PCIe errors on device %s within %s: %s",
+			count, event.bdf, h.escalationWindow, event.message),
+	}
+}
+
+func (h *AERHandler) createHealthEvent(fact policy.Fact, decision policy.Decision) *pb.HealthEvents {
+	source := policy.Source{
+		NodeName:       h.nodeName,
+		Agent:          h.defaultAgentName,
+		ComponentClass: h.defaultComponentClass,
+	}
+
+	return &pb.HealthEvents{
+		Version: 1,
+		Events:  []*pb.HealthEvent{h.policy.Event(source, fact, decision)},
+	}
+}
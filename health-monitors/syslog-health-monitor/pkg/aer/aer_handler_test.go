@@ -0,0 +1,219 @@
+// Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package aer
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/nvidia/nvsentinel/data-models/pkg/model"
+	pb "github.com/nvidia/nvsentinel/data-models/pkg/protos"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testMetadata = `{"version":"1.0","node_name":"test-node","gpus":[
+	{"gpu_id":3,"uuid":"GPU-3a1b","pci_address":"00000000:3B:00.0"}]}`
+
+func newTestHandler(t *testing.T) *AERHandler {
+	t.Helper()
+
+	metadataPath := filepath.Join(t.TempDir(), "gpu_metadata.json")
+	require.NoError(t, os.WriteFile(metadataPath, []byte(testMetadata), 0o600))
+
+	handler, err := NewAERHandler("test-node", "syslog-health-monitor", "GPU", "SysLogsPCIeAER", metadataPath)
+	require.NoError(t, err)
+
+	return handler
+}
+
+func TestParseAERError(t *testing.T) {
+	testCases := []struct {
+		name           string
+		message        string
+		expectCode     string
+		expectSeverity string
+		expectBDF      string
+	}{
+		{
+			name:           "corrected error",
+			message:        "kernel: pcieport 0000:00:01.0: AER: Corrected error received: 0000:3b:00.0",
+			expectCode:     CorrectedErrorCode,
+			expectSeverity: "Corrected",
+			expectBDF:      "0000:3b:00.0",
+		},
+		{
+			name:           "multiple corrected errors",
+			message:        "kernel: pcieport 0000:00:01.0: AER: Multiple Corrected error received: 0000:3B:00.0",
+			expectCode:     CorrectedErrorCode,
+			expectSeverity: "Corrected",
+			expectBDF:      "0000:3b:00.0",
+		},
+		{
+			name:           "uncorrected non-fatal error",
+			message:        "pcieport 0000:80:03.1: AER: Uncorrected (Non-Fatal) error received: 0000:86:00.0",
+			expectCode:     UncorrectedNonFatalErrorCode,
+			expectSeverity: "Uncorrected (Non-Fatal)",
+			expectBDF:      "0000:86:00.0",
+		},
+		{
+			name:           "uncorrected fatal error",
+			message:        "pcieport 0000:00:01.0: AER: Uncorrected (Fatal) error received: 0000:3b:00.0",
+			expectCode:     UncorrectedFatalErrorCode,
+			expectSeverity: "Uncorrected (Fatal)",
+			expectBDF:      "0000:3b:00.0",
+		},
+		{
+			name:           "uncorrectable error without qualifier",
+			message:        "pcieport 0000:00:01.0: AER: Uncorrectable error received: 0000:3b:00.0",
+			expectCode:     UncorrectedFatalErrorCode,
+			expectSeverity: "Uncorrectable",
+			expectBDF:      "0000:3b:00.0",
+		},
+		{
+			name:           "requester ID of older kernels",
+			message:        "pcieport 0000:00:03.0: AER: Corrected error received: id=3b0a",
+			expectCode:     CorrectedErrorCode,
+			expectSeverity: "Corrected",
+			expectBDF:      "0000:3b:01.2",
+		},
+		{
+			name:    "error details",
+			message: "nvidia 0000:3b:00.0: PCIe Bus Error: severity=Corrected, type=Physical Layer, (Receiver ID)",
+		},
+		{
+			name:    "AER capability enabled",
+			message: "pcieport 0000:00:01.0: AER: enabled with IRQ 27",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			event := parseAERError(tc.message)
+			if tc.expectCode == "" {
+				assert.Nil(t, event)
+				return
+			}
+
+			require.NotNil(t, event)
+			assert.Equal(t, tc.expectCode, event.errorCode)
+			assert.Equal(t, tc.expectSeverity, event.severity)
+			assert.Equal(t, tc.expectBDF, event.bdf)
+		})
+	}
+}
+
+func TestProcessLineFatal(t *testing.T) {
+	handler := newTestHandler(t)
+
+	t.Run("GPU", func(t *testing.T) {
+		line := "kernel: pcieport 0000:00:01.0: AER: Uncorrected (Fatal) error received: 0000:3b:00.0"
+		require.True(t, handler.Prefilter(line))
+
+		events, err := handler.ProcessLine(line)
+		require.NoError(t, err)
+		require.NotNil(t, events)
+		require.Len(t, events.Events, 1)
+
+		event := events.Events[0]
+		assert.True(t, event.IsFatal)
+		assert.Equal(t, pb.RecommendedAction_RESTART_BM, event.RecommendedAction)
+		assert.Equal(t, UncorrectedFatalErrorCode, event.ErrorCode[0])
+		assert.Equal(t, []*pb.Entity{
+			{EntityType: "PCI", EntityValue: "0000:3b:00.0"},
+			{EntityType: "GPU", EntityValue: "3"},
+			{EntityType: "GPU_UUID", EntityValue: "GPU-3a1b"},
+		}, event.EntitiesImpacted)
+	})
+
+	t.Run("other device", func(t *testing.T) {
+		events, err := handler.ProcessLine(
+			"kernel: pcieport 0000:80:03.1: AER: Uncorrected (Fatal) error received: 0000:86:00.0")
+		require.NoError(t, err)
+		require.NotNil(t, events)
+		require.Len(t, events.Events, 1)
+
+		event := events.Events[0]
+		assert.False(t, event.IsFatal)
+		assert.Equal(t, pb.RecommendedAction_CONTACT_SUPPORT, event.RecommendedAction)
+		assert.Equal(t, []*pb.Entity{{EntityType: "PCI", EntityValue: "0000:86:00.0"}}, event.EntitiesImpacted)
+	})
+}
+
+func TestProcessLineEscalation(t *testing.T) {
+	handler := newTestHandler(t)
+	handler.SetEscalation(3, time.Hour)
+
+	line := "kernel: pcieport 0000:00:01.0: AER: Corrected error received: 0000:3b:00.0"
+
+	events, err := handler.ProcessLine(line)
+	require.NoError(t, err)
+	require.NotNil(t, events, "the first error of the window is reported")
+	assert.False(t, events.Events[0].IsFatal)
+	assert.Equal(t, pb.RecommendedAction_NONE, events.Events[0].RecommendedAction)
+	assert.Equal(t, CorrectedErrorCode, events.Events[0].ErrorCode[0])
+	assert.Equal(t, "1", events.Events[0].Metadata[model.MetadataAERCorrectedTotal])
+
+	events, err = handler.ProcessLine(line)
+	require.NoError(t, err)
+	assert.Nil(t, events, "later errors are only counted")
+
+	events, err = handler.ProcessLine(line)
+	require.NoError(t, err)
+	require.NotNil(t, events, "the threshold escalates the errors")
+	assert.False(t, events.Events[0].IsFatal)
+	assert.Equal(t, pb.RecommendedAction_CONTACT_SUPPORT, events.Events[0].RecommendedAction)
+	assert.Equal(t, ErrorRateErrorCode, events.Events[0].ErrorCode[0])
+	assert.Equal(t, "3", events.Events[0].Metadata[model.MetadataAERCorrectedTotal])
+
+	events, err = handler.ProcessLine(line)
+	require.NoError(t, err)
+	assert.Nil(t, events, "a window escalates once")
+
+	events, err = handler.ProcessLine(
+		"kernel: pcieport 0000:80:03.1: AER: Corrected error received: 0000:86:00.0")
+	require.NoError(t, err)
+	require.NotNil(t, events, "devices are counted separately")
+	assert.Equal(t, CorrectedErrorCode, events.Events[0].ErrorCode[0])
+	assert.Equal(t, "1", events.Events[0].Metadata[model.MetadataAERCorrectedTotal])
+}
+
+func TestProcessLineWindowExpiry(t *testing.T) {
+	handler := newTestHandler(t)
+	handler.SetEscalation(2, 10*time.Millisecond)
+
+	line := "kernel: pcieport 0000:00:01.0: AER: Corrected error received: 0000:3b:00.0"
+
+	events, err := handler.ProcessLine(line)
+	require.NoError(t, err)
+	require.NotNil(t, events)
+
+	time.Sleep(20 * time.Millisecond)
+
+	events, err = handler.ProcessLine(line)
+	require.NoError(t, err)
+	require.NotNil(t, events, "an expired window starts a new one")
+	assert.Equal(t, CorrectedErrorCode, events.Events[0].ErrorCode[0])
+	assert.Equal(t, "2", events.Events[0].Metadata[model.MetadataAERCorrectedTotal], "the total spans windows")
+}
+
+func TestPrefilter(t *testing.T) {
+	handler := newTestHandler(t)
+
+	assert.True(t, handler.Prefilter("pcieport 0000:00:01.0: AER: Corrected error received: 0000:3b:00.0"))
+	assert.False(t, handler.Prefilter("NVRM: Xid (PCI:0000:3b:00): 79, pid=1234, GPU has fallen off the bus."))
+}
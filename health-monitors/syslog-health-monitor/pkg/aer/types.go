@@ -0,0 +1,95 @@
+// Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package aer
+
+import (
+	"regexp"
+	"sync"
+	"time"
+
+	"github.com/nvidia/nvsentinel/commons/pkg/lrucache"
+	"github.com/nvidia/nvsentinel/health-monitors/syslog-health-monitor/pkg/metadata"
+	"github.com/nvidia/nvsentinel/health-monitors/syslog-health-monitor/pkg/policy"
+)
+
+const (
+	// CorrectedErrorCode is the error code of corrected errors, which the link
+	// recovered from, e.g. by replaying a transaction
+	CorrectedErrorCode = "PCIE_AER_CORRECTED"
+	// UncorrectedNonFatalErrorCode is the error code of uncorrected errors that
+	// lost a transaction but left the link usable
+	UncorrectedNonFatalErrorCode = "PCIE_AER_UNCORRECTED_NONFATAL"
+	// UncorrectedFatalErrorCode is the error code of uncorrected errors that left
+	// the link unreliable until the device is reset
+	UncorrectedFatalErrorCode = "PCIE_AER_UNCORRECTED_FATAL"
+	// ErrorRateErrorCode is the error code of devices reporting non-fatal errors
+	// beyond the escalation threshold
+	ErrorRateErrorCode = "PCIE_AER_ERROR_RATE"
+
+	// Default escalation of non-fatal errors: a healthy link reports a few corrected
+	// errors a day, a marginal one reports them continuously
+	defaultEscalationThreshold = 100
+	defaultEscalationWindow    = time.Hour
+)
+
+var (
+	// Pattern of the errors the root port or switch port received from a device, e.g.
+	// "pcieport 0000:00:01.0: AER: Corrected error received: 0000:3b:00.0"
+	// "pcieport 0000:00:01.0: AER: Multiple Uncorrected (Fatal) error received: 0000:3b:00.0"
+	// Kernels before 4.20 report the source as its requester ID, e.g.
+	// "pcieport 0000:00:01.0: AER: Corrected error received: id=3b00"
+	// Uncorrectable errors without a qualifier are handled as fatal.
+	reAERPattern = regexp.MustCompile(`AER: (?:Multiple )?(?P<severity>Corrected|` +
+		`Uncorrect(?:ed|able)(?: \((?P<qualifier>Non-Fatal|Fatal)\))?) error received: ` +
+		`(?:id=(?P<id>[0-9a-fA-F]{4})|(?P<bdf>[0-9a-fA-F]{4}:[0-9a-fA-F]{2}:[0-9a-fA-F]{2}\.[0-7]))`)
+)
+
+// AERHandler processes the PCIe Advanced Error Reporting messages of the kernel
+// and reports them for the device that sent them, identified by its PCI bus,
+// device and function (BDF).
+type AERHandler struct {
+	nodeName              string
+	defaultAgentName      string
+	defaultComponentClass string
+	checkName             string
+	metadataReader        *metadata.Reader
+
+	mu sync.Mutex
+	// windows counts the non-fatal errors of every device in its current window
+	windows             *lrucache.Cache[string, *errorWindow] // BDF -> errors of the window
+	escalationThreshold int
+	escalationWindow    time.Duration
+	// correctedTotals counts the corrected errors of every device since the monitor started
+	correctedTotals *lrucache.Cache[string, int] // BDF -> corrected errors
+	// policy decides the reported severity and action, nil keeps the built-in ones
+	policy *policy.Policy
+}
+
+// errorWindow holds the non-fatal errors of a device since the window started.
+type errorWindow struct {
+	start     time.Time
+	count     int
+	escalated bool
+}
+
+// aerErrorEvent represents a parsed AER message
+type aerErrorEvent struct {
+	errorCode string
+	severity  string
+	bdf       string
+	message   string
+	// correctedTotal is the cumulative corrected errors of the device, 0 for other errors
+	correctedTotal int
+}
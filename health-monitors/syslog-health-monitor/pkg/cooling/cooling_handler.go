@@ -0,0 +1,172 @@
+// Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cooling
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/nvidia/nvsentinel/data-models/pkg/model"
+	pb "github.com/nvidia/nvsentinel/data-models/pkg/protos"
+	"github.com/nvidia/nvsentinel/health-monitors/syslog-health-monitor/pkg/metadata"
+	"github.com/nvidia/nvsentinel/health-monitors/syslog-health-monitor/pkg/policy"
+)
+
+// NewCoolingHandler creates a new CoolingHandler instance. The chassis serial of
+// the GPU metadata identifies the rack of the node.
+func NewCoolingHandler(nodeName, defaultAgentName,
+	defaultComponentClass, checkName, metadataPath string) (*CoolingHandler, error) {
+	return &CoolingHandler{
+		nodeName:              nodeName,
+		defaultAgentName:      defaultAgentName,
+		defaultComponentClass: defaultComponentClass,
+		checkName:             checkName,
+		metadataReader:        metadata.NewReader(metadataPath),
+	}, nil
+}
+
+// ProcessLine processes a single syslog line and returns any generated health events.
+func (h *CoolingHandler) ProcessLine(message string) (*pb.HealthEvents, error) {
+	event := parseCoolingError(message)
+	if event == nil {
+		return nil, nil
+	}
+
+	return h.createHealthEventFromError(event), nil
+}
+
+// Prefilter reports whether the line may be a BMC sensor record or a coolant
+// alert of a vendor agent.
+func (h *CoolingHandler) Prefilter(message string) bool {
+	return strings.Contains(message, "SEL System Event") ||
+		strings.Contains(message, "oolant") || strings.Contains(message, "OOLANT") ||
+		strings.Contains(message, "iquid") || strings.Contains(message, "IQUID")
+}
+
+// SetPolicy sets the event policy deciding the severity and action of cooling facts.
+func (h *CoolingHandler) SetPolicy(eventPolicy *policy.Policy) {
+	h.policy = eventPolicy
+}
+
+func parseCoolingError(message string) *coolingErrorEvent {
+	if m := reSELPattern.FindStringSubmatch(message); m != nil {
+		return parseSELRecord(m, message)
+	}
+
+	if m := reVendorLeakPattern.FindStringSubmatch(message); m != nil {
+		return &coolingErrorEvent{
+			errorCode: LeakErrorCode,
+			sensor:    m[reVendorLeakPattern.SubexpIndex("sensor")],
+			message:   message,
+		}
+	}
+
+	if m := reVendorTemperaturePattern.FindStringSubmatch(message); m != nil {
+		sensor := strings.TrimSpace(m[reVendorTemperaturePattern.SubexpIndex("sensor")])
+
+		return &coolingErrorEvent{
+			errorCode: CoolantTemperatureErrorCode,
+			sensor:    strings.TrimSpace("coolant " + sensor),
+			reading:   m[reVendorTemperaturePattern.SubexpIndex("reading")],
+			message:   message,
+		}
+	}
+
+	return nil
+}
+
+// parseSELRecord returns the cooling alert of an asserted SEL record, nil if the
+// record is about another sensor or a non-critical threshold.
+func parseSELRecord(m []string, message string) *coolingErrorEvent {
+	sensorType := strings.TrimSpace(m[reSELPattern.SubexpIndex("type")])
+	sensor := strings.TrimSpace(m[reSELPattern.SubexpIndex("sensor")])
+	event := m[reSELPattern.SubexpIndex("event")]
+
+	switch {
+	case reLeakWord.MatchString(sensor) || reLeakWord.MatchString(event):
+		return &coolingErrorEvent{errorCode: LeakErrorCode, sensor: sensor, message: message}
+
+	case strings.EqualFold(sensorType, "Temperature") && reCoolantWord.MatchString(sensor) &&
+		reCriticalEvent.MatchString(event):
+		return &coolingErrorEvent{
+			errorCode: CoolantTemperatureErrorCode,
+			sensor:    sensor,
+			reading:   m[reSELPattern.SubexpIndex("reading")],
+			message:   message,
+		}
+	}
+
+	return nil
+}
+
+// extractFact returns what the cooling alert reports, without severity or action.
+// Leaks and coolant temperatures are reported for the rack the node is in, since
+// the nodes of a rack share its coolant loop.
+func (h *CoolingHandler) extractFact(event *coolingErrorEvent) policy.Fact {
+	var entities []*pb.Entity
+
+	metadata := make(map[string]string)
+
+	if chassisSerial := h.metadataReader.GetChassisSerial(); chassisSerial != nil && *chassisSerial != "" {
+		entities = append(entities, &pb.Entity{EntityType: model.EntityTypeRack, EntityValue: *chassisSerial})
+		metadata[model.MetadataChassisSerial] = *chassisSerial
+	}
+
+	if event.sensor != "" {
+		entities = append(entities, &pb.Entity{EntityType: "SENSOR", EntityValue: event.sensor})
+	}
+
+	return policy.Fact{
+		CheckName: h.checkName,
+		ErrorCode: event.errorCode,
+		Entities:  entities,
+		Attributes: map[string]string{
+			"sensor":  event.sensor,
+			"reading": event.reading,
+		},
+		Metadata: metadata,
+		Line:     event.message,
+	}
+}
+
+// builtinDecision powers the node off: a leak or an overheating coolant loop
+// damages the hardware within minutes, long before a technician can respond.
+func builtinDecision(event *coolingErrorEvent) policy.Decision {
+	message := fmt.Sprintf("Coolant leak detected: %s", event.message)
+	if event.errorCode == CoolantTemperatureErrorCode {
+		message = fmt.Sprintf("Coolant temperature beyond its critical threshold: %s", event.message)
+	}
+
+	return policy.Decision{
+		IsFatal:           true,
+		RecommendedAction: pb.RecommendedAction_POWER_OFF,
+		Message:           message,
+	}
+}
+
+func (h *CoolingHandler) createHealthEventFromError(event *coolingErrorEvent) *pb.HealthEvents {
+	coolingCounterMetric.WithLabelValues(h.nodeName, event.errorCode).Inc()
+
+	source := policy.Source{
+		NodeName:       h.nodeName,
+		Agent:          h.defaultAgentName,
+		ComponentClass: h.defaultComponentClass,
+	}
+
+	return &pb.HealthEvents{
+		Version: 1,
+		Events:  []*pb.HealthEvent{h.policy.Event(source, h.extractFact(event), builtinDecision(event))},
+	}
+}
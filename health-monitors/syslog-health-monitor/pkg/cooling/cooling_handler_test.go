@@ -0,0 +1,156 @@
+// Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cooling
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/nvidia/nvsentinel/data-models/pkg/model"
+	pb "github.com/nvidia/nvsentinel/data-models/pkg/protos"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseCoolingError(t *testing.T) {
+	testCases := []struct {
+		name          string
+		message       string
+		expectCode    string
+		expectSensor  string
+		expectReading string
+	}{
+		{
+			name: "SEL leak detector assertion",
+			message: "ipmiseld: SEL System Event: 0x00A3, Cooling Device, Leak_Detect_Manifold, " +
+				"Assertion Event, Leak Detected",
+			expectCode:   LeakErrorCode,
+			expectSensor: "Leak_Detect_Manifold",
+		},
+		{
+			name: "SEL coolant temperature beyond the critical threshold",
+			message: "ipmiseld: SEL System Event: 0x00A4, Temperature, Coolant_Inlet_Temp, Assertion Event, " +
+				"Upper Critical - going high ; Sensor Reading = 47.00 C ; Threshold = 45.00 C",
+			expectCode:    CoolantTemperatureErrorCode,
+			expectSensor:  "Coolant_Inlet_Temp",
+			expectReading: "47.00 C",
+		},
+		{
+			name:         "vendor leak alert",
+			message:      "cdu-agent[2211]: CRITICAL: coolant leak detected by sensor LD_TRAY_07",
+			expectCode:   LeakErrorCode,
+			expectSensor: "LD_TRAY_07",
+		},
+		{
+			name:       "vendor leak alert without sensor",
+			message:    "rack-manager: Liquid leak detected, shutting down pumps",
+			expectCode: LeakErrorCode,
+		},
+		{
+			name:          "vendor coolant temperature alert",
+			message:       "cdu-agent[2211]: CRITICAL: coolant supply temperature 48.5C exceeds critical threshold 45.0C",
+			expectCode:    CoolantTemperatureErrorCode,
+			expectSensor:  "coolant supply",
+			expectReading: "48.5C",
+		},
+		{
+			name: "SEL coolant temperature beyond a non-critical threshold",
+			message: "ipmiseld: SEL System Event: 0x00A5, Temperature, Coolant_Inlet_Temp, Assertion Event, " +
+				"Upper Non-critical - going high ; Sensor Reading = 41.00 C ; Threshold = 40.00 C",
+		},
+		{
+			name: "SEL GPU temperature",
+			message: "ipmiseld: SEL System Event: 0x00A6, Temperature, GPU0_Temp, Assertion Event, " +
+				"Upper Critical - going high ; Sensor Reading = 95.00 C ; Threshold = 90.00 C",
+		},
+		{
+			name: "SEL leak detector deassertion",
+			message: "ipmiseld: SEL System Event: 0x00A7, Cooling Device, Leak_Detect_Manifold, " +
+				"Deassertion Event, Leak Detected",
+		},
+		{
+			name:    "coolant temperature reading",
+			message: "cdu-agent[2211]: coolant supply temperature 32.1C",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			event := parseCoolingError(tc.message)
+			if tc.expectCode == "" {
+				assert.Nil(t, event)
+				return
+			}
+
+			require.NotNil(t, event)
+			assert.Equal(t, tc.expectCode, event.errorCode)
+			assert.Equal(t, tc.expectSensor, event.sensor)
+			assert.Equal(t, tc.expectReading, event.reading)
+		})
+	}
+}
+
+func TestProcessLine(t *testing.T) {
+	metadataPath := filepath.Join(t.TempDir(), "gpu_metadata.json")
+	require.NoError(t, os.WriteFile(metadataPath,
+		[]byte(`{"version":"1.0","node_name":"test-node","chassis_serial":"1821324000123","gpus":[]}`), 0o600))
+
+	handler, err := NewCoolingHandler("test-node", "syslog-health-monitor", "GPU", "SysLogsCooling", metadataPath)
+	require.NoError(t, err)
+
+	line := "ipmiseld: SEL System Event: 0x00A3, Cooling Device, Leak_Detect_Manifold, Assertion Event, Leak Detected"
+	require.True(t, handler.Prefilter(line))
+
+	events, err := handler.ProcessLine(line)
+	require.NoError(t, err)
+	require.NotNil(t, events)
+	require.Len(t, events.Events, 1)
+
+	event := events.Events[0]
+	assert.Equal(t, "test-node", event.NodeName)
+	assert.Equal(t, "SysLogsCooling", event.CheckName)
+	assert.True(t, event.IsFatal)
+	assert.False(t, event.IsHealthy)
+	assert.Equal(t, pb.RecommendedAction_POWER_OFF, event.RecommendedAction)
+	assert.Equal(t, []string{LeakErrorCode}, event.ErrorCode)
+	assert.Equal(t, "1821324000123", event.Metadata[model.MetadataChassisSerial])
+	require.Len(t, event.EntitiesImpacted, 2)
+	assert.Equal(t, model.EntityTypeRack, event.EntitiesImpacted[0].EntityType)
+	assert.Equal(t, "1821324000123", event.EntitiesImpacted[0].EntityValue)
+	assert.Equal(t, "SENSOR", event.EntitiesImpacted[1].EntityType)
+	assert.Equal(t, "Leak_Detect_Manifold", event.EntitiesImpacted[1].EntityValue)
+
+	events, err = handler.ProcessLine("kernel: eth0: link up")
+	require.NoError(t, err)
+	assert.Nil(t, events)
+}
+
+func TestProcessLineWithoutMetadata(t *testing.T) {
+	handler, err := NewCoolingHandler("test-node", "syslog-health-monitor", "GPU", "SysLogsCooling",
+		"/nonexistent/gpu_metadata.json")
+	require.NoError(t, err)
+
+	events, err := handler.ProcessLine("cdu-agent[2211]: CRITICAL: coolant leak detected by sensor LD_TRAY_07")
+	require.NoError(t, err)
+	require.NotNil(t, events)
+	require.Len(t, events.Events, 1)
+
+	event := events.Events[0]
+	assert.Equal(t, pb.RecommendedAction_POWER_OFF, event.RecommendedAction)
+	require.Len(t, event.EntitiesImpacted, 1)
+	assert.Equal(t, "SENSOR", event.EntitiesImpacted[0].EntityType)
+	assert.NotContains(t, event.Metadata, model.MetadataChassisSerial)
+}
@@ -0,0 +1,78 @@
+// Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cooling
+
+import (
+	"regexp"
+
+	"github.com/nvidia/nvsentinel/health-monitors/syslog-health-monitor/pkg/metadata"
+	"github.com/nvidia/nvsentinel/health-monitors/syslog-health-monitor/pkg/policy"
+)
+
+const (
+	// LeakErrorCode is the error code of a coolant leak
+	LeakErrorCode = "COOLANT_LEAK"
+	// CoolantTemperatureErrorCode is the error code of a coolant temperature beyond
+	// its critical threshold
+	CoolantTemperatureErrorCode = "COOLANT_TEMPERATURE_CRITICAL"
+)
+
+var (
+	// Pattern of the BMC SEL records forwarded to syslog by ipmiseld, e.g.
+	// "ipmiseld: SEL System Event: 0x00A3, Cooling Device, Leak_Detect_Manifold, Assertion Event, Leak Detected"
+	// "ipmiseld: SEL System Event: 0x00A4, Temperature, Coolant_Inlet_Temp, Assertion Event,
+	//  Upper Critical - going high ; Sensor Reading = 47.00 C ; Threshold = 45.00 C"
+	reSELPattern = regexp.MustCompile(`SEL System Event: [^,]*, (?P<type>[^,]+), (?P<sensor>[^,]+), ` +
+		`Assertion Event, (?P<event>[^;]+?)\s*(?:;\s*Sensor Reading = (?P<reading>[^;]+?)\s*(?:;.*)?)?$`)
+
+	// Pattern of the leak alerts of rack and coolant distribution unit (CDU) agents, e.g.
+	// "cdu-agent[2211]: CRITICAL: coolant leak detected by sensor LD_TRAY_07"
+	reVendorLeakPattern = regexp.MustCompile(
+		`(?i)\b(?:coolant|liquid) leak detected(?:.*?\bsensor[:= ]\s*(?P<sensor>[\w.-]+))?`)
+
+	// Pattern of the coolant temperature alerts of rack and CDU agents, e.g.
+	// "cdu-agent[2211]: CRITICAL: coolant supply temperature 48.5C exceeds critical threshold 45.0C"
+	reVendorTemperaturePattern = regexp.MustCompile(`(?i)\bcoolant (?P<sensor>(?:\w+ )?)temperature ` +
+		`(?P<reading>[\d.]+ ?C) exceeds critical threshold (?P<threshold>[\d.]+ ?C)`)
+
+	// Words of a SEL sensor or event naming a leak detector
+	reLeakWord = regexp.MustCompile(`(?i)leak`)
+	// Words of a SEL sensor naming a coolant temperature, as opposed to e.g. a GPU
+	reCoolantWord = regexp.MustCompile(`(?i)coolant|liquid|cdu|manifold`)
+	// Critical thresholds of a SEL temperature event. Non-critical thresholds are
+	// warnings the cooling system handles on its own.
+	reCriticalEvent = regexp.MustCompile(`(?i)upper (?:critical|non-recoverable)`)
+)
+
+// CoolingHandler processes syslog lines reporting coolant leaks and critical coolant
+// temperatures of liquid-cooled racks, from BMC sensor records and vendor agents.
+type CoolingHandler struct {
+	nodeName              string
+	defaultAgentName      string
+	defaultComponentClass string
+	checkName             string
+	metadataReader        *metadata.Reader
+	// policy decides the reported severity and action, nil keeps the built-in ones
+	policy *policy.Policy
+}
+
+// coolingErrorEvent represents a parsed leak or coolant temperature alert
+type coolingErrorEvent struct {
+	errorCode string
+	sensor    string
+	// reading is the sensor reading of temperature alerts, when reported
+	reading string
+	message string
+}
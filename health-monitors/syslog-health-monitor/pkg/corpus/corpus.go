@@ -0,0 +1,55 @@
+// Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package corpus provides the shared seed corpus of real journal messages used
+// by the fuzz targets. FuzzProcessLine, in the tests of this package, runs every
+// input through all the handlers.
+//
+// Inputs that crash a fuzz target are written by the Go toolchain to
+// testdata/fuzz/<FuzzTarget>/ inside the package of the target. Committing those
+// files turns them into regression cases that run with every `go test`.
+package corpus
+
+import (
+	_ "embed"
+	"strings"
+	"testing"
+)
+
+//go:embed testdata/syslog.log
+var rawCorpus string
+
+// Lines returns every journal message in the seed corpus.
+func Lines() []string {
+	var lines []string
+
+	for line := range strings.SplitSeq(rawCorpus, "\n") {
+		if strings.TrimSpace(line) == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		lines = append(lines, strings.ReplaceAll(line, `\n`, "\n"))
+	}
+
+	return lines
+}
+
+// AddSeeds registers every corpus line as a seed input of the fuzz target.
+func AddSeeds(f *testing.F) {
+	f.Helper()
+
+	for _, line := range Lines() {
+		f.Add(line)
+	}
+}
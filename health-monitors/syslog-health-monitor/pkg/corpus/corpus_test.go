@@ -0,0 +1,41 @@
+// Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package corpus
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLines(t *testing.T) {
+	lines := Lines()
+	require.NotEmpty(t, lines)
+
+	multiLine := 0
+
+	for _, line := range lines {
+		assert.NotEmpty(t, strings.TrimSpace(line))
+		assert.False(t, strings.HasPrefix(line, "#"), "comments must be skipped")
+
+		if strings.Contains(line, "\n") {
+			multiLine++
+		}
+	}
+
+	assert.Positive(t, multiLine, "corpus should contain multi-line journal entries")
+}
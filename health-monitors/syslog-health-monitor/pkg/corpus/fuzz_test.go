@@ -0,0 +1,142 @@
+// Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package corpus_test
+
+import (
+	"testing"
+
+	pb "github.com/nvidia/nvsentinel/data-models/pkg/protos"
+	"github.com/nvidia/nvsentinel/health-monitors/syslog-health-monitor/pkg/aer"
+	"github.com/nvidia/nvsentinel/health-monitors/syslog-health-monitor/pkg/cooling"
+	"github.com/nvidia/nvsentinel/health-monitors/syslog-health-monitor/pkg/corpus"
+	"github.com/nvidia/nvsentinel/health-monitors/syslog-health-monitor/pkg/detections"
+	"github.com/nvidia/nvsentinel/health-monitors/syslog-health-monitor/pkg/driverinstall"
+	"github.com/nvidia/nvsentinel/health-monitors/syslog-health-monitor/pkg/ecc"
+	"github.com/nvidia/nvsentinel/health-monitors/syslog-health-monitor/pkg/gpufallen"
+	"github.com/nvidia/nvsentinel/health-monitors/syslog-health-monitor/pkg/gsp"
+	"github.com/nvidia/nvsentinel/health-monitors/syslog-health-monitor/pkg/notices"
+	"github.com/nvidia/nvsentinel/health-monitors/syslog-health-monitor/pkg/sxid"
+	"github.com/nvidia/nvsentinel/health-monitors/syslog-health-monitor/pkg/types"
+	"github.com/nvidia/nvsentinel/health-monitors/syslog-health-monitor/pkg/watchdog"
+	"github.com/nvidia/nvsentinel/health-monitors/syslog-health-monitor/pkg/xid"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	fuzzNode   = "fuzz-node"
+	fuzzAgent  = "fuzz-agent"
+	noMetadata = "/nonexistent/metadata.json"
+)
+
+// fuzzHandlers are the handlers every fuzz input is run through, by check.
+var fuzzHandlers = []struct {
+	check      string
+	newHandler func() (types.Handler, error)
+}{
+	{"xid", func() (types.Handler, error) {
+		return xid.NewXIDHandler(fuzzNode, fuzzAgent, "GPU", "xid-check", "", noMetadata)
+	}},
+	{"sxid", func() (types.Handler, error) {
+		return sxid.NewSXIDHandler(fuzzNode, fuzzAgent, "NVSWITCH", "sxid-check", noMetadata)
+	}},
+	{"gpufallen", func() (types.Handler, error) {
+		return gpufallen.NewGPUFallenHandler(fuzzNode, fuzzAgent, "GPU", "gpu-fallen-check")
+	}},
+	{"aer", func() (types.Handler, error) {
+		return aer.NewAERHandler(fuzzNode, fuzzAgent, "GPU", "aer-check", noMetadata)
+	}},
+	{"ecc", func() (types.Handler, error) {
+		return ecc.NewECCHandler(fuzzNode, fuzzAgent, "GPU", "ecc-check", noMetadata, 0)
+	}},
+	{"gsp", func() (types.Handler, error) {
+		return gsp.NewGSPHandler(fuzzNode, fuzzAgent, "GPU", "gsp-check", noMetadata)
+	}},
+	{"cooling", func() (types.Handler, error) {
+		return cooling.NewCoolingHandler(fuzzNode, fuzzAgent, "GPU", "cooling-check", noMetadata)
+	}},
+	{"driverinstall", func() (types.Handler, error) {
+		return driverinstall.NewDriverInstallHandler(fuzzNode, fuzzAgent, "GPU", "driver-install-check",
+			"/nonexistent/osrelease")
+	}},
+	{"notices", func() (types.Handler, error) {
+		return notices.NewNoticeHandler(fuzzNode)
+	}},
+	{"watchdog", func() (types.Handler, error) {
+		return watchdog.NewWatchdogHandler(fuzzNode, fuzzAgent, "GPU", "watchdog-check", []watchdog.Rule{
+			{Name: "fabric-manager-heartbeat", Pattern: `fabric manager heartbeat`, Window: "5m"},
+			{Name: "nvrm", Pattern: `NVRM:`, Window: "1m", IsFatal: true, RecommendedAction: "CONTACT_SUPPORT"},
+		})
+	}},
+}
+
+// FuzzProcessLine runs every input through all the handlers, the generated
+// detections among them, and checks the events they report. Inputs that crash
+// are written to testdata/fuzz/FuzzProcessLine/ of this package; committed,
+// they run as regression cases with every `go test`.
+func FuzzProcessLine(f *testing.F) {
+	corpus.AddSeeds(f)
+
+	handlers := make(map[string]types.Handler)
+
+	for _, h := range fuzzHandlers {
+		handler, err := h.newHandler()
+		require.NoError(f, err, h.check)
+
+		handlers[h.check] = handler
+	}
+
+	for _, check := range detections.Checks() {
+		handlers[check] = detections.NewHandler(check, fuzzNode, fuzzAgent, "GPU")
+	}
+
+	f.Cleanup(func() {
+		for _, handler := range handlers {
+			if closer, ok := handler.(interface{ Close() }); ok {
+				closer.Close()
+			}
+		}
+	})
+
+	f.Fuzz(func(t *testing.T, message string) {
+		for check, handler := range handlers {
+			events, err := handler.ProcessLine(message)
+			if err == nil && events != nil {
+				require.Len(t, events.Events, 1, check)
+				requireComplete(t, check, events)
+
+				// Lines a handler reports on must pass its prefilter
+				if prefilter, ok := handler.(types.Prefilter); ok {
+					require.True(t, prefilter.Prefilter(message), check)
+				}
+			}
+
+			if evaluator, ok := handler.(types.Evaluator); ok {
+				if events, err := evaluator.Evaluate(); err == nil && events != nil {
+					requireComplete(t, check, events)
+				}
+			}
+		}
+	})
+}
+
+func requireComplete(t *testing.T, check string, events *pb.HealthEvents) {
+	t.Helper()
+
+	for _, event := range events.Events {
+		require.Equal(t, fuzzNode, event.NodeName, check)
+		require.NotEmpty(t, event.CheckName, check)
+		require.NotNil(t, event.GeneratedTimestamp, check)
+	}
+}
@@ -0,0 +1,50 @@
+// Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package detections holds the handlers generated from the detection specs in
+// specs/. A spec declares a regex detection, its fields and how they map to the
+// health event; `go generate` turns every spec into a typed handler, a test running
+// the examples of the spec and an entry of the registry NewHandler looks checks up
+// in. Edit the specs, never the zz_generated files.
+package detections
+
+//go:generate go run gen.go
+
+import (
+	"sort"
+
+	"github.com/nvidia/nvsentinel/health-monitors/syslog-health-monitor/pkg/types"
+)
+
+// NewHandler creates the generated handler of the check, nil if no spec declares it.
+func NewHandler(checkName, nodeName, defaultAgentName, defaultComponentClass string) types.Handler {
+	constructor, ok := constructors[checkName]
+	if !ok {
+		return nil
+	}
+
+	return constructor(nodeName, defaultAgentName, defaultComponentClass)
+}
+
+// Checks returns the checks of the generated handlers, sorted.
+func Checks() []string {
+	checks := make([]string, 0, len(constructors))
+	for check := range constructors {
+		checks = append(checks, check)
+	}
+
+	sort.Strings(checks)
+
+	return checks
+}
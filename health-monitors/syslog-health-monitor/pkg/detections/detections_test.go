@@ -0,0 +1,67 @@
+// Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package detections
+
+import (
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/nvidia/nvsentinel/health-monitors/syslog-health-monitor/pkg/detections/spec"
+	"github.com/nvidia/nvsentinel/health-monitors/syslog-health-monitor/pkg/policy"
+	"github.com/nvidia/nvsentinel/health-monitors/syslog-health-monitor/pkg/types"
+)
+
+// TestGeneratedUpToDate fails when a spec changed without running go generate.
+func TestGeneratedUpToDate(t *testing.T) {
+	specs, err := spec.Load("specs")
+	require.NoError(t, err)
+
+	want := map[string]func() ([]byte, error){
+		spec.RegistryFile: func() ([]byte, error) { return spec.GenerateRegistry(specs, "detections") },
+	}
+
+	for i := range specs {
+		s := &specs[i]
+		want[s.HandlerFile()] = func() ([]byte, error) { return spec.GenerateHandler(s, "detections") }
+		want[s.TestFile()] = func() ([]byte, error) { return spec.GenerateTest(s, "detections") }
+	}
+
+	for file, generate := range want {
+		expected, err := generate()
+		require.NoError(t, err)
+
+		actual, err := os.ReadFile(file)
+		require.NoError(t, err, "run go generate ./pkg/detections/...")
+		assert.Equal(t, string(expected), string(actual), "%s is stale, run go generate ./pkg/detections/...", file)
+	}
+
+	assert.Len(t, Checks(), len(specs))
+}
+
+func TestNewHandler(t *testing.T) {
+	handler := NewHandler(RmInitAdapterFailedCheck, "node-1", "syslog-health-monitor", "GPU")
+	require.NotNil(t, handler)
+
+	_, ok := handler.(types.Prefilter)
+	assert.True(t, ok, "handlers with a marker prefilter lines")
+
+	_, ok = handler.(policy.Receiver)
+	assert.True(t, ok, "events of generated handlers are decided by the policy")
+
+	assert.Nil(t, NewHandler("SysLogsUnknown", "node-1", "syslog-health-monitor", "GPU"))
+}
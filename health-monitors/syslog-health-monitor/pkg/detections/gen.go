@@ -0,0 +1,80 @@
+// Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+//go:build ignore
+
+// gen writes the handlers, tests and registry of the detection specs in specs/.
+// Run it with `go generate ./pkg/detections/...`.
+package main
+
+import (
+	"log"
+	"os"
+	"path/filepath"
+
+	"github.com/nvidia/nvsentinel/health-monitors/syslog-health-monitor/pkg/detections/spec"
+)
+
+const pkg = "detections"
+
+func main() {
+	specs, err := spec.Load("specs")
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	// Handlers of removed specs must not linger
+	stale, err := filepath.Glob("zz_generated_*.go")
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	for _, file := range stale {
+		if err := os.Remove(file); err != nil {
+			log.Fatalf("failed to remove %s: %v", file, err)
+		}
+	}
+
+	files := map[string][]byte{}
+
+	for i := range specs {
+		s := &specs[i]
+
+		handler, err := spec.GenerateHandler(s, pkg)
+		if err != nil {
+			log.Fatalf("failed to generate the handler of %s: %v", s.File, err)
+		}
+
+		test, err := spec.GenerateTest(s, pkg)
+		if err != nil {
+			log.Fatalf("failed to generate the test of %s: %v", s.File, err)
+		}
+
+		files[s.HandlerFile()] = handler
+		files[s.TestFile()] = test
+	}
+
+	registry, err := spec.GenerateRegistry(specs, pkg)
+	if err != nil {
+		log.Fatalf("failed to generate the registry: %v", err)
+	}
+
+	files[spec.RegistryFile] = registry
+
+	for file, data := range files {
+		if err := os.WriteFile(file, data, 0o600); err != nil {
+			log.Fatalf("failed to write %s: %v", file, err)
+		}
+	}
+}
@@ -0,0 +1,376 @@
+// Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package spec
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"regexp"
+	"strconv"
+	"strings"
+	"text/template"
+)
+
+// Generated file names of the handlers, their tests and the registry.
+const (
+	RegistryFile = "zz_generated_registry.go"
+
+	generatedHeader = "// Code generated by gen.go. DO NOT EDIT."
+)
+
+// HandlerFile returns the file name of the handler generated from the spec.
+func (s *Spec) HandlerFile() string {
+	return "zz_generated_" + strings.ToLower(s.Name) + ".go"
+}
+
+// TestFile returns the file name of the test generated from the spec.
+func (s *Spec) TestFile() string {
+	return "zz_generated_" + strings.ToLower(s.Name) + "_test.go"
+}
+
+// field is a named group of the pattern.
+type field struct {
+	Name  string
+	Index int
+}
+
+type entity struct {
+	Type  string
+	Index int
+}
+
+// value is an entity or metadata value expected from an example.
+type value struct {
+	Type  string
+	Name  string
+	Value string
+}
+
+// example is an example with the entities and metadata expected from its line.
+type example struct {
+	Line     string
+	Entities []value
+	Metadata []value
+}
+
+// handlerData is the spec prepared for the templates.
+type handlerData struct {
+	*Spec
+	Header   string
+	Fields   []field
+	Entities []entity
+	Metadata []field
+	Examples []example
+}
+
+func (s *Spec) data(header string) (*handlerData, error) {
+	re, err := regexp.Compile(s.Pattern)
+	if err != nil {
+		return nil, fmt.Errorf("invalid pattern: %w", err)
+	}
+
+	data := &handlerData{Spec: s, Header: header}
+
+	for _, name := range s.Fields() {
+		data.Fields = append(data.Fields, field{Name: name, Index: re.SubexpIndex(name)})
+	}
+
+	for _, e := range s.Entities {
+		data.Entities = append(data.Entities, entity{Type: e.Type, Index: re.SubexpIndex(e.Field)})
+	}
+
+	for _, name := range s.Metadata {
+		data.Metadata = append(data.Metadata, field{Name: name, Index: re.SubexpIndex(name)})
+	}
+
+	for _, e := range s.Examples {
+		match := re.FindStringSubmatch(e.Line)
+		if match == nil {
+			return nil, fmt.Errorf("example %q does not match the pattern", e.Line)
+		}
+
+		ex := example{Line: e.Line}
+
+		for _, entity := range data.Entities {
+			if match[entity.Index] != "" {
+				ex.Entities = append(ex.Entities, value{Type: entity.Type, Value: match[entity.Index]})
+			}
+		}
+
+		for _, metadata := range data.Metadata {
+			ex.Metadata = append(ex.Metadata, value{Name: metadata.Name, Value: match[metadata.Index]})
+		}
+
+		data.Examples = append(data.Examples, ex)
+	}
+
+	return data, nil
+}
+
+// GenerateHandler returns the source of the handler of the spec in package pkg.
+func GenerateHandler(s *Spec, pkg string) ([]byte, error) {
+	return s.generate(handlerTemplate, pkg)
+}
+
+// GenerateTest returns the source of the test of the spec in package pkg.
+func GenerateTest(s *Spec, pkg string) ([]byte, error) {
+	return s.generate(testTemplate, pkg)
+}
+
+func (s *Spec) generate(tmpl *template.Template, pkg string) ([]byte, error) {
+	data, err := s.data("// Code generated by gen.go from specs/" + s.File + ". DO NOT EDIT.")
+	if err != nil {
+		return nil, err
+	}
+
+	return render(tmpl, pkg, data)
+}
+
+// GenerateRegistry returns the source of the registry mapping the check names of
+// the specs to their handler constructors in package pkg.
+func GenerateRegistry(specs []Spec, pkg string) ([]byte, error) {
+	return render(registryTemplate, pkg, struct {
+		Header string
+		Specs  []Spec
+	}{Header: generatedHeader, Specs: specs})
+}
+
+func render(tmpl *template.Template, pkg string, data any) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, struct {
+		Package string
+		Data    any
+	}{Package: pkg, Data: data}); err != nil {
+		return nil, fmt.Errorf("rendering %s: %w", tmpl.Name(), err)
+	}
+
+	source, err := format.Source(buf.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("formatting %s: %w\n%s", tmpl.Name(), err, buf.String())
+	}
+
+	return source, nil
+}
+
+// quote returns a Go string literal, a raw string for strings with backslashes or
+// quotes so patterns stay readable.
+func quote(s string) string {
+	if strings.ContainsAny(s, "\\\"") && strconv.CanBackquote(s) {
+		return "`" + s + "`"
+	}
+
+	return strconv.Quote(s)
+}
+
+var funcs = template.FuncMap{
+	"quote": quote,
+	"comment": func(s string) string {
+		return strings.TrimSpace(strings.ReplaceAll(strings.TrimSpace(s), "\n", "\n// "))
+	},
+}
+
+var handlerTemplate = template.Must(template.New("handler").Funcs(funcs).Parse(`{{ .Data.Header }}
+
+package {{ .Package }}
+{{ with .Data }}
+import (
+	"regexp"
+{{- if .Marker }}
+	"strings"
+{{- end }}
+
+	pb "github.com/nvidia/nvsentinel/data-models/pkg/protos"
+	"github.com/nvidia/nvsentinel/health-monitors/syslog-health-monitor/pkg/policy"
+)
+
+// {{ .Name }}Check is the check of the {{ .Name }} detection.
+const {{ .Name }}Check = {{ quote .CheckName }}
+
+var re{{ .Name }} = regexp.MustCompile({{ quote .Pattern }})
+
+// {{ .Name }}Handler reports {{ comment .Description }}
+type {{ .Name }}Handler struct {
+	source policy.Source
+	policy *policy.Policy
+}
+
+// New{{ .Name }}Handler creates a new {{ .Name }}Handler instance.
+func New{{ .Name }}Handler(nodeName, defaultAgentName, defaultComponentClass string) *{{ .Name }}Handler {
+{{- if .ComponentClass }}
+	defaultComponentClass = {{ quote .ComponentClass }}
+{{ end }}
+	return &{{ .Name }}Handler{
+		source: policy.Source{
+			NodeName:       nodeName,
+			Agent:          defaultAgentName,
+			ComponentClass: defaultComponentClass,
+		},
+	}
+}
+
+// SetPolicy sets the event policy deciding the severity and action of the facts.
+func (h *{{ .Name }}Handler) SetPolicy(eventPolicy *policy.Policy) {
+	h.policy = eventPolicy
+}
+{{ if .Marker }}
+// Prefilter reports whether the line contains {{ quote .Marker }}.
+func (h *{{ .Name }}Handler) Prefilter(message string) bool {
+	return strings.Contains(message, {{ quote .Marker }})
+}
+{{ end }}
+// ProcessLine returns the event of a matching line.
+func (h *{{ .Name }}Handler) ProcessLine(message string) (*pb.HealthEvents, error) {
+{{- if .Marker }}
+	if !h.Prefilter(message) {
+		return nil, nil
+	}
+{{ end }}
+	m := re{{ .Name }}.FindStringSubmatch(message)
+	if m == nil {
+		return nil, nil
+	}
+
+	var entities []*pb.Entity
+{{- range .Entities }}
+	if m[{{ .Index }}] != "" {
+		entities = append(entities, &pb.Entity{EntityType: {{ quote .Type }}, EntityValue: m[{{ .Index }}]})
+	}
+{{- end }}
+
+	fact := policy.Fact{
+		CheckName: {{ .Name }}Check,
+		ErrorCode: {{ quote .ErrorCode }},
+		Entities:  entities,
+		Attributes: map[string]string{
+{{- range .Fields }}
+			{{ quote .Name }}: m[{{ .Index }}],
+{{- end }}
+		},
+{{- if .Metadata }}
+		Metadata: map[string]string{
+{{- range .Metadata }}
+			{{ quote .Name }}: m[{{ .Index }}],
+{{- end }}
+		},
+{{- end }}
+		Line: message,
+	}
+
+	builtin := policy.Decision{
+		IsFatal:           {{ .Fatal }},
+		RecommendedAction: pb.RecommendedAction_{{ .RecommendedAction }},
+		Message:           message,
+	}
+
+	detectionsMetric.WithLabelValues(h.source.NodeName, {{ .Name }}Check).Inc()
+
+	return &pb.HealthEvents{
+		Version: 1,
+		Events:  []*pb.HealthEvent{h.policy.Event(h.source, fact, builtin)},
+	}, nil
+}
+{{- end }}
+`))
+
+var testTemplate = template.Must(template.New("test").Funcs(funcs).Parse(`{{ .Data.Header }}
+
+package {{ .Package }}
+{{ with .Data }}
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	pb "github.com/nvidia/nvsentinel/data-models/pkg/protos"
+)
+
+func Test{{ .Name }}Handler(t *testing.T) {
+	handler := New{{ .Name }}Handler("node-1", "syslog-health-monitor", "GPU")
+
+	examples := []struct {
+		line     string
+		entities []*pb.Entity
+		metadata map[string]string
+	}{
+{{- range .Examples }}
+		{
+			line: {{ quote .Line }},
+{{- if .Entities }}
+			entities: []*pb.Entity{
+{{- range .Entities }}
+				{EntityType: {{ quote .Type }}, EntityValue: {{ quote .Value }}},
+{{- end }}
+			},
+{{- end }}
+{{- if .Metadata }}
+			metadata: map[string]string{
+{{- range .Metadata }}
+				{{ quote .Name }}: {{ quote .Value }},
+{{- end }}
+			},
+{{- end }}
+		},
+{{- end }}
+	}
+
+	for _, example := range examples {
+		events, err := handler.ProcessLine(example.line)
+		require.NoError(t, err)
+		require.NotNil(t, events, example.line)
+		require.Len(t, events.Events, 1)
+
+		event := events.Events[0]
+		assert.Equal(t, {{ .Name }}Check, event.CheckName)
+		assert.Equal(t, []string{ {{- quote .ErrorCode -}} }, event.ErrorCode)
+		assert.{{ if .Fatal }}True{{ else }}False{{ end }}(t, event.IsFatal)
+		assert.Equal(t, pb.RecommendedAction_{{ .RecommendedAction }}, event.RecommendedAction)
+		assert.Equal(t, "node-1", event.NodeName)
+		assert.Equal(t, example.line, event.Message)
+		assert.Equal(t, example.entities, event.EntitiesImpacted)
+		assert.Equal(t, example.metadata, event.Metadata)
+	}
+{{- if .NonMatching }}
+
+	for _, line := range []string{
+{{- range .NonMatching }}
+		{{ quote . }},
+{{- end }}
+	} {
+		events, err := handler.ProcessLine(line)
+		require.NoError(t, err)
+		assert.Nil(t, events, line)
+	}
+{{- end }}
+}
+{{- end }}
+`))
+
+var registryTemplate = template.Must(template.New("registry").Funcs(funcs).Parse(`{{ .Data.Header }}
+
+package {{ .Package }}
+
+import "github.com/nvidia/nvsentinel/health-monitors/syslog-health-monitor/pkg/types"
+
+// constructors creates the generated handlers by check name.
+var constructors = map[string]func(nodeName, defaultAgentName, defaultComponentClass string) types.Handler{
+{{- range .Data.Specs }}
+	{{ .Name }}Check: func(nodeName, defaultAgentName, defaultComponentClass string) types.Handler {
+		return New{{ .Name }}Handler(nodeName, defaultAgentName, defaultComponentClass)
+	},
+{{- end }}
+}
+`))
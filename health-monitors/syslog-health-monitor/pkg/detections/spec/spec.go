@@ -0,0 +1,240 @@
+// Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package spec reads declarative detection specs and generates their handlers.
+// A spec names a regular expression, the fields captured by its named groups and
+// how they map to the health event; the generator turns it into a typed handler
+// and a test running the examples of the spec. Simple regex detections are authored
+// as specs while the fleet runs compiled handlers, without interpreting rules at
+// runtime.
+package spec
+
+import (
+	"fmt"
+	"go/token"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+
+	pb "github.com/nvidia/nvsentinel/data-models/pkg/protos"
+)
+
+// Spec declares a detection.
+type Spec struct {
+	// Name is the exported Go name of the detection, the handler is <Name>Handler
+	Name string `toml:"name"`
+	// CheckName is the check enabling the handler and reported on its events
+	CheckName string `toml:"check_name"`
+	// Description documents the handler
+	Description string `toml:"description"`
+	// Marker is a substring of every matching line, lines without it are skipped
+	// before the pattern is evaluated
+	Marker string `toml:"marker"`
+	// Pattern is the RE2 regular expression matching the line. Its named groups
+	// are the fields of the detection.
+	Pattern string `toml:"pattern"`
+	// ErrorCode is reported on the events
+	ErrorCode string `toml:"error_code"`
+	// Fatal and RecommendedAction are the built-in decision, the event policy may
+	// override them
+	Fatal             bool   `toml:"fatal"`
+	RecommendedAction string `toml:"recommended_action"`
+	// ComponentClass overrides the component class of the monitor
+	ComponentClass string `toml:"component_class"`
+	// Entities map fields to the impacted entities, empty fields are left out
+	Entities []Entity `toml:"entities"`
+	// Metadata are the fields published in the event metadata, every field is an
+	// attribute the policy can match on
+	Metadata []string `toml:"metadata"`
+	// Examples are lines the pattern must match with the fields they capture
+	Examples []Example `toml:"examples"`
+	// NonMatching are lines the handler must not report
+	NonMatching []string `toml:"non_matching"`
+
+	// File is the spec file, set by Load
+	File string `toml:"-"`
+}
+
+// Entity maps a field to an impacted entity.
+type Entity struct {
+	Type  string `toml:"type"`
+	Field string `toml:"field"`
+}
+
+// Example is a line matching the pattern.
+type Example struct {
+	Line   string            `toml:"line"`
+	Fields map[string]string `toml:"fields"`
+}
+
+var goName = regexp.MustCompile(`^[A-Z][A-Za-z0-9]*$`)
+
+// Load reads the *.toml specs in dir, sorted by name.
+func Load(dir string) ([]Spec, error) {
+	files, err := filepath.Glob(filepath.Join(dir, "*.toml"))
+	if err != nil {
+		return nil, fmt.Errorf("listing detection specs in %s: %w", dir, err)
+	}
+
+	specs := make([]Spec, 0, len(files))
+	names := map[string]string{}
+	checks := map[string]string{}
+
+	for _, file := range files {
+		data, err := os.ReadFile(file)
+		if err != nil {
+			return nil, fmt.Errorf("reading detection spec %s: %w", file, err)
+		}
+
+		spec, err := Decode(filepath.Base(file), data)
+		if err != nil {
+			return nil, err
+		}
+
+		if other, ok := names[spec.Name]; ok {
+			return nil, fmt.Errorf("detection spec %s: name %s is already declared by %s", file, spec.Name, other)
+		}
+
+		if other, ok := checks[spec.CheckName]; ok {
+			return nil, fmt.Errorf("detection spec %s: check %s is already declared by %s",
+				file, spec.CheckName, other)
+		}
+
+		names[spec.Name] = file
+		checks[spec.CheckName] = file
+
+		specs = append(specs, *spec)
+	}
+
+	sort.Slice(specs, func(i, j int) bool { return specs[i].Name < specs[j].Name })
+
+	return specs, nil
+}
+
+// Decode decodes and validates the spec in data, file names it in errors and in
+// the generated code.
+func Decode(file string, data []byte) (*Spec, error) {
+	spec := &Spec{File: file}
+
+	metadata, err := toml.Decode(string(data), spec)
+	if err != nil {
+		return nil, fmt.Errorf("decoding detection spec %s: %w", file, err)
+	}
+
+	if undecoded := metadata.Undecoded(); len(undecoded) > 0 {
+		return nil, fmt.Errorf("detection spec %s: unknown keys %v", file, undecoded)
+	}
+
+	if err := spec.Validate(); err != nil {
+		return nil, fmt.Errorf("detection spec %s: %w", file, err)
+	}
+
+	return spec, nil
+}
+
+// Validate checks the spec and runs its examples against the pattern.
+//
+//nolint:cyclop // one check per field
+func (s *Spec) Validate() error {
+	if !goName.MatchString(s.Name) || !token.IsExported(s.Name) {
+		return fmt.Errorf("name %q must be an exported Go identifier", s.Name)
+	}
+
+	if s.CheckName == "" || s.ErrorCode == "" || s.Pattern == "" {
+		return fmt.Errorf("check_name, error_code and pattern are required")
+	}
+
+	if _, ok := pb.RecommendedAction_value[s.RecommendedAction]; !ok {
+		return fmt.Errorf("unknown recommended_action %q", s.RecommendedAction)
+	}
+
+	re, err := regexp.Compile(s.Pattern)
+	if err != nil {
+		return fmt.Errorf("invalid pattern: %w", err)
+	}
+
+	fields := s.Fields()
+	if len(fields) == 0 {
+		return fmt.Errorf("pattern has no named groups")
+	}
+
+	for _, entity := range s.Entities {
+		if entity.Type == "" || !slices.Contains(fields, entity.Field) {
+			return fmt.Errorf("entity %q must map a field of the pattern, got %q", entity.Type, entity.Field)
+		}
+	}
+
+	for _, field := range s.Metadata {
+		if !slices.Contains(fields, field) {
+			return fmt.Errorf("metadata %q is not a field of the pattern", field)
+		}
+	}
+
+	if len(s.Examples) == 0 {
+		return fmt.Errorf("at least one example is required")
+	}
+
+	for _, example := range s.Examples {
+		if s.Marker != "" && !strings.Contains(example.Line, s.Marker) {
+			return fmt.Errorf("example %q does not contain the marker %q", example.Line, s.Marker)
+		}
+
+		match := re.FindStringSubmatch(example.Line)
+		if match == nil {
+			return fmt.Errorf("example %q does not match the pattern", example.Line)
+		}
+
+		for field, want := range example.Fields {
+			index := re.SubexpIndex(field)
+			if index < 0 {
+				return fmt.Errorf("example %q names the unknown field %q", example.Line, field)
+			}
+
+			if match[index] != want {
+				return fmt.Errorf("example %q captures %s=%q, want %q", example.Line, field, match[index], want)
+			}
+		}
+	}
+
+	for _, line := range s.NonMatching {
+		if re.MatchString(line) && (s.Marker == "" || strings.Contains(line, s.Marker)) {
+			return fmt.Errorf("non-matching line %q matches the pattern", line)
+		}
+	}
+
+	return nil
+}
+
+// Fields returns the named groups of the pattern in order.
+func (s *Spec) Fields() []string {
+	re, err := regexp.Compile(s.Pattern)
+	if err != nil {
+		return nil
+	}
+
+	var fields []string
+
+	for _, name := range re.SubexpNames() {
+		if name != "" && !slices.Contains(fields, name) {
+			fields = append(fields, name)
+		}
+	}
+
+	return fields
+}
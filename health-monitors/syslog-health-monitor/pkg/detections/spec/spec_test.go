@@ -0,0 +1,139 @@
+// Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package spec
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const validSpec = `
+name = "LinkDown"
+check_name = "SysLogsLinkDown"
+description = "links that went down."
+marker = "NVRM:"
+pattern = 'NVRM: GPU (?P<pci>[0-9a-f:.]+): link (?P<link>\d+) down(?: \((?P<reason>\w+)\))?'
+error_code = "LINK_DOWN"
+recommended_action = "NONE"
+metadata = ["link"]
+non_matching = ["NVRM: GPU 0000:3b:00.0: link 1 up"]
+
+[[entities]]
+type = "PCI"
+field = "pci"
+
+[[entities]]
+type = "REASON"
+field = "reason"
+
+[[examples]]
+line = "NVRM: GPU 0000:3b:00.0: link 3 down (timeout)"
+fields = { pci = "0000:3b:00.0", link = "3", reason = "timeout" }
+
+[[examples]]
+line = "NVRM: GPU 0000:3b:00.0: link 4 down"
+fields = { link = "4" }
+`
+
+func TestDecode(t *testing.T) {
+	spec, err := Decode("linkdown.toml", []byte(validSpec))
+	require.NoError(t, err)
+
+	assert.Equal(t, "LinkDown", spec.Name)
+	assert.Equal(t, []string{"pci", "link", "reason"}, spec.Fields())
+	assert.Equal(t, "zz_generated_linkdown.go", spec.HandlerFile())
+	assert.Equal(t, "zz_generated_linkdown_test.go", spec.TestFile())
+}
+
+func TestDecodeInvalid(t *testing.T) {
+	tests := map[string]struct {
+		old, new string
+		err      string
+	}{
+		"unexported name": {`name = "LinkDown"`, `name = "linkDown"`, "exported Go identifier"},
+		"unknown key":     {`marker =`, `markers =`, "unknown keys"},
+		"unknown action":  {`"NONE"`, `"REBOOT"`, "unknown recommended_action"},
+		"invalid pattern": {`down(?:`, `down(?`, "invalid pattern"},
+		"unknown entity":  {`field = "reason"`, `field = "cause"`, "must map a field"},
+		"unknown meta":    {`metadata = ["link"]`, `metadata = ["port"]`, "not a field"},
+		"wrong capture":   {`link = "3"`, `link = "2"`, `captures link="3"`},
+		"no match":        {`link 4 down"`, `link 4 lost"`, "does not match"},
+		"no marker":       {`line = "NVRM: GPU 0000:3b:00.0: link 4`, `line = "GPU 0000:3b:00.0: link 4`, "marker"},
+		"matching":        {`link 1 up`, `link 1 down`, "matches the pattern"},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			data := replaceOnce(t, validSpec, tt.old, tt.new)
+
+			_, err := Decode("linkdown.toml", []byte(data))
+			require.ErrorContains(t, err, tt.err)
+		})
+	}
+}
+
+func TestLoadRejectsDuplicateChecks(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "a.toml"), []byte(validSpec), 0o600))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "b.toml"),
+		[]byte(replaceOnce(t, validSpec, `name = "LinkDown"`, `name = "LinkLost"`)), 0o600))
+
+	_, err := Load(dir)
+	require.ErrorContains(t, err, "check SysLogsLinkDown is already declared")
+}
+
+func TestGenerate(t *testing.T) {
+	spec, err := Decode("linkdown.toml", []byte(validSpec))
+	require.NoError(t, err)
+
+	handler, err := GenerateHandler(spec, "detections")
+	require.NoError(t, err)
+
+	source := string(handler)
+	assert.Contains(t, source, "// Code generated by gen.go from specs/linkdown.toml. DO NOT EDIT.")
+	assert.Contains(t, source, `const LinkDownCheck = "SysLogsLinkDown"`)
+	assert.Contains(t, source, "func (h *LinkDownHandler) Prefilter(message string) bool")
+	// the optional reason group is only reported when captured
+	assert.Contains(t, source, `if m[3] != "" {`)
+	assert.Contains(t, source, `IsFatal:           false,`)
+
+	test, err := GenerateTest(spec, "detections")
+	require.NoError(t, err)
+	assert.Contains(t, string(test), `{EntityType: "REASON", EntityValue: "timeout"}`)
+	assert.Contains(t, string(test), `"NVRM: GPU 0000:3b:00.0: link 1 up",`)
+
+	registry, err := GenerateRegistry([]Spec{*spec}, "detections")
+	require.NoError(t, err)
+	assert.Contains(t, string(registry), "LinkDownCheck: func(")
+}
+
+func TestQuote(t *testing.T) {
+	assert.Equal(t, `"NVRM:"`, quote("NVRM:"))
+	assert.Equal(t, "`\\d+ \"x\"`", quote(`\d+ "x"`))
+	assert.Equal(t, `"a\nb"`, quote("a\nb"))
+}
+
+func replaceOnce(t *testing.T, s, old, new string) string {
+	t.Helper()
+
+	require.Contains(t, s, old)
+
+	return strings.Replace(s, old, new, 1)
+}
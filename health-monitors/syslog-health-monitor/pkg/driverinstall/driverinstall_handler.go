@@ -0,0 +1,227 @@
+// Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package driverinstall
+
+import (
+	"fmt"
+	"log/slog"
+	"os"
+	"strings"
+
+	"github.com/nvidia/nvsentinel/commons/pkg/lrucache"
+	pb "github.com/nvidia/nvsentinel/data-models/pkg/protos"
+	"github.com/nvidia/nvsentinel/health-monitors/syslog-health-monitor/pkg/policy"
+)
+
+// NewDriverInstallHandler creates a new DriverInstallHandler instance.
+// osReleasePath names the file holding the running kernel release, usually
+// /proc/sys/kernel/osrelease.
+func NewDriverInstallHandler(nodeName, defaultAgentName,
+	defaultComponentClass, checkName, osReleasePath string) (*DriverInstallHandler, error) {
+	return &DriverInstallHandler{
+		nodeName:              nodeName,
+		defaultAgentName:      defaultAgentName,
+		defaultComponentClass: defaultComponentClass,
+		checkName:             checkName,
+		osReleasePath:         osReleasePath,
+		reported:              lrucache.New[string, bool]("syslog_driverinstall_reported_kernels", lrucache.DefaultCapacity),
+	}, nil
+}
+
+// ProcessLine processes a single syslog or log file line and returns any
+// generated health events.
+func (h *DriverInstallHandler) ProcessLine(message string) (*pb.HealthEvents, error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	event := h.parseLine(message)
+	if event == nil {
+		return nil, nil
+	}
+
+	if event.kernelVersion == "" {
+		event.kernelVersion = h.runningKernel()
+	}
+
+	if event.driverVersion == "" {
+		event.driverVersion = h.driverVersion
+	}
+
+	if !event.failed {
+		h.reported.Remove(event.kernelVersion)
+
+		return h.createHealthEvent(event), nil
+	}
+
+	// A failed install logs several errors; report it once per kernel until
+	// the driver installs successfully
+	if h.reported.Contains(event.kernelVersion) {
+		return nil, nil
+	}
+
+	h.reported.Add(event.kernelVersion, true)
+
+	driverInstallFailureCounterMetric.WithLabelValues(h.nodeName, event.source).Inc()
+
+	return h.createHealthEvent(event), nil
+}
+
+// SetStateCapacity bounds the number of kernels a failure is remembered for.
+func (h *DriverInstallHandler) SetStateCapacity(capacity int) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.reported.Resize(capacity)
+}
+
+// Prefilter reports whether the line may be nvidia-installer or dkms output.
+func (h *DriverInstallHandler) Prefilter(message string) bool {
+	for _, marker := range markers {
+		if strings.Contains(message, marker) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// parseLine updates the install context from the line and returns the failure
+// or success it reports, nil if none.
+//
+//nolint:cyclop // one branch per output pattern
+func (h *DriverInstallHandler) parseLine(message string) *installEvent {
+	if m := reKernelVersion.FindStringSubmatch(message); m != nil {
+		h.kernelVersion = m[1]
+	} else if m := reKernelPath.FindStringSubmatch(message); m != nil {
+		h.kernelVersion = m[1]
+	}
+
+	if m := reInstallerVersion.FindStringSubmatch(message); m != nil {
+		h.driverVersion = m[1]
+	}
+
+	// The make.log line may be part of the same journal entry as the failure
+	if m := reDKMSBuildFailed.FindStringSubmatch(message); m != nil {
+		h.pendingDKMSKernel = m[1]
+	}
+
+	if m := reDKMSMakeLog.FindStringSubmatch(message); m != nil && h.pendingDKMSKernel != "" {
+		kernel := h.pendingDKMSKernel
+		h.pendingDKMSKernel = ""
+
+		return &installEvent{failed: true, kernelVersion: kernel, driverVersion: m[1],
+			source: sourceDKMS, message: message}
+	}
+
+	if m := reDKMSAutoinstall.FindStringSubmatch(message); m != nil && strings.Contains(m[3], "nvidia") {
+		return &installEvent{failed: m[2] == "failed", kernelVersion: m[1], source: sourceDKMS, message: message}
+	}
+
+	if reInstallerFailed.MatchString(message) {
+		return &installEvent{failed: true, kernelVersion: h.kernelVersion, source: sourceInstaller, message: message}
+	}
+
+	if m := reInstallerSucceeded.FindStringSubmatch(message); m != nil {
+		return &installEvent{kernelVersion: h.kernelVersion, driverVersion: m[1], source: sourceInstaller,
+			message: message}
+	}
+
+	if reDriverContainerReady.MatchString(message) {
+		return &installEvent{kernelVersion: h.kernelVersion, source: sourceInstaller, message: message}
+	}
+
+	return nil
+}
+
+// runningKernel returns the release of the running kernel, empty if it cannot
+// be read.
+func (h *DriverInstallHandler) runningKernel() string {
+	data, err := os.ReadFile(h.osReleasePath)
+	if err != nil {
+		slog.Warn("Failed to read running kernel release", "path", h.osReleasePath, "error", err)
+		return ""
+	}
+
+	return strings.TrimSpace(string(data))
+}
+
+// SetPolicy sets the event policy deciding the severity and action of driver
+// install failure facts.
+func (h *DriverInstallHandler) SetPolicy(eventPolicy *policy.Policy) {
+	h.policy = eventPolicy
+}
+
+// extractFact returns what the install output reports, without severity or action.
+func (h *DriverInstallHandler) extractFact(event *installEvent) policy.Fact {
+	var entitiesImpacted []*pb.Entity
+	if event.kernelVersion != "" {
+		entitiesImpacted = append(entitiesImpacted, &pb.Entity{EntityType: "KERNEL", EntityValue: event.kernelVersion})
+	}
+
+	metadata := map[string]string{
+		"kernel_version": event.kernelVersion,
+		"source":         event.source,
+	}
+	if event.driverVersion != "" {
+		metadata["driver_version"] = event.driverVersion
+	}
+
+	fact := policy.Fact{
+		CheckName: h.checkName,
+		Healthy:   !event.failed,
+		Entities:  entitiesImpacted,
+		Attributes: map[string]string{
+			"kernel_version": event.kernelVersion,
+			"driver_version": event.driverVersion,
+			"source":         event.source,
+		},
+		Metadata: metadata,
+		Line:     event.message,
+	}
+
+	if event.failed {
+		fact.ErrorCode = ErrorCode
+	}
+
+	return fact
+}
+
+func (h *DriverInstallHandler) createHealthEvent(event *installEvent) *pb.HealthEvents {
+	fact := h.extractFact(event)
+
+	builtin := policy.Decision{
+		RecommendedAction: pb.RecommendedAction_NONE,
+		Message:           event.message,
+	}
+
+	if event.failed {
+		// Without a driver for the kernel the node cannot run GPU workloads
+		builtin.Message = fmt.Sprintf("NVIDIA driver failed to install for kernel %s: %s",
+			event.kernelVersion, event.message)
+		builtin.IsFatal = true
+		builtin.RecommendedAction = pb.RecommendedAction_CONTACT_SUPPORT
+	}
+
+	source := policy.Source{
+		NodeName:       h.nodeName,
+		Agent:          h.defaultAgentName,
+		ComponentClass: h.defaultComponentClass,
+	}
+
+	return &pb.HealthEvents{
+		Version: 1,
+		Events:  []*pb.HealthEvent{h.policy.Event(source, fact, builtin)},
+	}
+}
@@ -0,0 +1,224 @@
+// Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package driverinstall
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	pb "github.com/nvidia/nvsentinel/data-models/pkg/protos"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestHandler(t *testing.T) *DriverInstallHandler {
+	t.Helper()
+
+	osRelease := filepath.Join(t.TempDir(), "osrelease")
+	require.NoError(t, os.WriteFile(osRelease, []byte("6.8.0-45-generic\n"), 0600))
+
+	h, err := NewDriverInstallHandler("node1", "syslog-health-monitor", "GPU", "SysLogsDriverInstall", osRelease)
+	require.NoError(t, err)
+
+	return h
+}
+
+// processLines runs lines through the handler and returns the events produced.
+func processLines(t *testing.T, h *DriverInstallHandler, lines ...string) []*pb.HealthEvent {
+	t.Helper()
+
+	var events []*pb.HealthEvent
+
+	for _, line := range lines {
+		if !h.Prefilter(line) {
+			continue
+		}
+
+		healthEvents, err := h.ProcessLine(line)
+		require.NoError(t, err)
+
+		if healthEvents != nil {
+			events = append(events, healthEvents.Events...)
+		}
+	}
+
+	return events
+}
+
+func TestProcessLine(t *testing.T) {
+	testCases := []struct {
+		name          string
+		lines         []string
+		expectFailed  bool
+		expectHealthy bool
+		expectKernel  string
+		expectDriver  string
+		expectSource  string
+	}{
+		{
+			name: "dkms build failure",
+			lines: []string{
+				"Building module:",
+				"Error! Bad return status for module build on kernel: 6.8.0-49-generic (x86_64)",
+				"Consult /var/lib/dkms/nvidia/550.90.07/build/make.log for more information.",
+			},
+			expectFailed: true,
+			expectKernel: "6.8.0-49-generic",
+			expectDriver: "550.90.07",
+			expectSource: sourceDKMS,
+		},
+		{
+			name: "dkms build failure in a single journal entry",
+			lines: []string{
+				"Error! Bad return status for module build on kernel: 6.8.0-49-generic (x86_64)\n" +
+					"Consult /var/lib/dkms/nvidia-srv/535.183.01/build/make.log for more information.",
+			},
+			expectFailed: true,
+			expectKernel: "6.8.0-49-generic",
+			expectDriver: "535.183.01",
+			expectSource: sourceDKMS,
+		},
+		{
+			name: "dkms build failure of another module",
+			lines: []string{
+				"Error! Bad return status for module build on kernel: 6.8.0-49-generic (x86_64)",
+				"Consult /var/lib/dkms/zfs/2.2.2/build/make.log for more information.",
+			},
+		},
+		{
+			name:         "dkms autoinstall failure",
+			lines:        []string{"Autoinstall on 6.8.0-49-generic failed for module(s) nvidia(10)."},
+			expectFailed: true,
+			expectKernel: "6.8.0-49-generic",
+			expectSource: sourceDKMS,
+		},
+		{
+			name:          "dkms autoinstall success",
+			lines:         []string{"Autoinstall on 6.8.0-49-generic succeeded for module(s) nvidia."},
+			expectHealthy: true,
+			expectKernel:  "6.8.0-49-generic",
+			expectSource:  sourceDKMS,
+		},
+		{
+			name: "nvidia-installer build failure",
+			lines: []string{
+				"installer version: 550.54.15",
+				"Kernel source path: '/lib/modules/5.15.0-1045-aws/build'",
+				`ERROR: An error occurred while performing the step: "Building kernel modules". ` +
+					"See /var/log/nvidia-installer.log for details.",
+			},
+			expectFailed: true,
+			expectKernel: "5.15.0-1045-aws",
+			expectDriver: "550.54.15",
+			expectSource: sourceInstaller,
+		},
+		{
+			name: "driver container failure",
+			lines: []string{
+				"2025-06-01T10:00:00.000000000Z stdout F Proceeding with Linux kernel version 5.15.0-1045-aws",
+				"2025-06-01T10:01:00.000000000Z stdout F ERROR: Unable to load the kernel module 'nvidia.ko'.",
+			},
+			expectFailed: true,
+			expectKernel: "5.15.0-1045-aws",
+			expectSource: sourceInstaller,
+		},
+		{
+			name:         "failure without kernel falls back to the running kernel",
+			lines:        []string{"ERROR: Installation has failed."},
+			expectFailed: true,
+			expectKernel: "6.8.0-45-generic",
+			expectSource: sourceInstaller,
+		},
+		{
+			name: "nvidia-installer success",
+			lines: []string{
+				"Installation of the NVIDIA Accelerated Graphics Driver for Linux-x86_64 (version: 550.54.15) " +
+					"is now complete.",
+			},
+			expectHealthy: true,
+			expectKernel:  "6.8.0-45-generic",
+			expectDriver:  "550.54.15",
+			expectSource:  sourceInstaller,
+		},
+		{
+			name:  "unrelated error",
+			lines: []string{"ERROR: Unable to open /dev/foo"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			events := processLines(t, newTestHandler(t), tc.lines...)
+
+			if !tc.expectFailed && !tc.expectHealthy {
+				assert.Empty(t, events)
+				return
+			}
+
+			require.Len(t, events, 1)
+			event := events[0]
+
+			assert.Equal(t, tc.expectHealthy, event.IsHealthy)
+			assert.Equal(t, tc.expectFailed, event.IsFatal)
+			assert.Equal(t, tc.expectKernel, event.Metadata["kernel_version"])
+			assert.Equal(t, tc.expectDriver, event.Metadata["driver_version"])
+			assert.Equal(t, tc.expectSource, event.Metadata["source"])
+			require.Len(t, event.EntitiesImpacted, 1)
+			assert.Equal(t, "KERNEL", event.EntitiesImpacted[0].EntityType)
+			assert.Equal(t, tc.expectKernel, event.EntitiesImpacted[0].EntityValue)
+
+			if tc.expectFailed {
+				assert.Equal(t, []string{ErrorCode}, event.ErrorCode)
+				assert.Equal(t, pb.RecommendedAction_CONTACT_SUPPORT, event.RecommendedAction)
+			} else {
+				assert.Empty(t, event.ErrorCode)
+				assert.Equal(t, pb.RecommendedAction_NONE, event.RecommendedAction)
+			}
+		})
+	}
+}
+
+func TestProcessLineReportsOncePerKernel(t *testing.T) {
+	h := newTestHandler(t)
+
+	events := processLines(t, h,
+		"Kernel source path: '/lib/modules/5.15.0-1045-aws/build'",
+		`ERROR: An error occurred while performing the step: "Building kernel modules".`,
+		"ERROR: Installation has failed.",
+	)
+	require.Len(t, events, 1)
+
+	// Another kernel is reported separately
+	events = processLines(t, h, "Autoinstall on 6.8.0-49-generic failed for module(s) nvidia(10).")
+	require.Len(t, events, 1)
+
+	// A successful install clears the kernel, so a later failure is reported again
+	events = processLines(t, h,
+		"Installation of the NVIDIA Accelerated Graphics Driver for Linux-x86_64 (version: 550.54.15) is now complete.",
+		"ERROR: Installation has failed.",
+	)
+	require.Len(t, events, 2)
+	assert.True(t, events[0].IsHealthy)
+	assert.False(t, events[1].IsHealthy)
+	assert.Equal(t, "5.15.0-1045-aws", events[1].Metadata["kernel_version"])
+}
+
+func TestPrefilter(t *testing.T) {
+	h := newTestHandler(t)
+
+	assert.False(t, h.Prefilter("kernel: usb 1-1: new high-speed USB device"))
+	assert.True(t, h.Prefilter("Consult /var/lib/dkms/nvidia/550.90.07/build/make.log for more information."))
+}
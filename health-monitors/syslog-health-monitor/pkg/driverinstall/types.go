@@ -0,0 +1,116 @@
+// Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package driverinstall
+
+import (
+	"regexp"
+	"sync"
+
+	"github.com/nvidia/nvsentinel/commons/pkg/lrucache"
+	"github.com/nvidia/nvsentinel/health-monitors/syslog-health-monitor/pkg/policy"
+)
+
+// ErrorCode is the error code of driver install failure events.
+const ErrorCode = "DRIVER_INSTALL_FAILED"
+
+// Sources of a driver install failure, reported in the event metadata.
+const (
+	sourceDKMS      = "dkms"
+	sourceInstaller = "nvidia-installer"
+)
+
+// Markers present in every line the handler parses. Lines containing none of
+// them are rejected by the prefilter.
+var markers = []string{
+	"kernel version",
+	"/lib/modules/",
+	"installer version",
+	"Error!",
+	"/var/lib/dkms/",
+	"Autoinstall on",
+	"ERROR:",
+	"is now complete",
+	"now waiting for signal",
+}
+
+var (
+	// Lines naming the kernel the driver is being built for, printed before a
+	// failure. Examples:
+	//   "Proceeding with Linux kernel version 5.15.0-1045-aws" (GPU Operator driver container)
+	//   "Kernel source path: '/lib/modules/5.15.0-1045-aws/build'" (nvidia-installer)
+	reKernelVersion = regexp.MustCompile(`Proceeding with Linux kernel version (\S+)`)
+	reKernelPath    = regexp.MustCompile(`/lib/modules/([^/\s']+)/`)
+
+	// Example: "installer version: 550.54.15"
+	reInstallerVersion = regexp.MustCompile(`installer version: (\S+)`)
+
+	// dkms reports a failed build on one line and names the module on the next:
+	//   "Error! Bad return status for module build on kernel: 6.8.0-45-generic (x86_64)"
+	//   "Consult /var/lib/dkms/nvidia/550.90.07/build/make.log for more information."
+	reDKMSBuildFailed = regexp.MustCompile(`Error! Bad return status for module build on kernel: (\S+)`)
+	reDKMSMakeLog     = regexp.MustCompile(`/var/lib/dkms/nvidia[^/]*/([^/\s]+)/build/make\.log`)
+
+	// dkms 3 summarizes autoinstall per kernel. Examples:
+	//   "Autoinstall on 6.8.0-45-generic failed for module(s) nvidia(10)."
+	//   "Autoinstall on 6.8.0-45-generic succeeded for module(s) nvidia."
+	reDKMSAutoinstall = regexp.MustCompile(`Autoinstall on (\S+) (failed|succeeded) for module\(s\) (.*)`)
+
+	// nvidia-installer and the GPU Operator driver container. Examples:
+	//   "ERROR: An error occurred while performing the step: "Building kernel modules"."
+	//   "ERROR: Unable to load the kernel module 'nvidia.ko'."
+	//   "ERROR: Installation has failed."
+	reInstallerFailed = regexp.MustCompile(`ERROR: (An error occurred while performing the step: "Building kernel modules"|` +
+		`Unable to build the NVIDIA kernel module|Unable to load the kernel module 'nvidia[^']*'|Installation has failed)`)
+
+	// Example: "Installation of the NVIDIA Accelerated Graphics Driver for Linux-x86_64
+	// (version: 550.54.15) is now complete."
+	reInstallerSucceeded = regexp.MustCompile(`Installation of the NVIDIA Accelerated Graphics Driver.*` +
+		`\(version: (\S+)\) is now complete`)
+
+	// Printed by the GPU Operator driver container once the driver is loaded.
+	reDriverContainerReady = regexp.MustCompile(`Done, now waiting for signal`)
+)
+
+// DriverInstallHandler processes nvidia-installer and dkms output from the
+// journal and their log files, and reports driver builds that failed for a
+// kernel.
+type DriverInstallHandler struct {
+	nodeName              string
+	defaultAgentName      string
+	defaultComponentClass string
+	checkName             string
+	// Path of the file holding the release of the running kernel, used when
+	// the failing kernel is not named in the output
+	osReleasePath string
+	mu            sync.Mutex
+	// Kernel and driver version named by the latest context lines
+	kernelVersion string
+	driverVersion string
+	// Kernel of a dkms build failure waiting for the line naming the module
+	pendingDKMSKernel string
+	// Kernels a failure was reported for since the last successful install
+	reported *lrucache.Cache[string, bool]
+	// policy decides the reported severity and action, nil keeps the built-in ones
+	policy *policy.Policy
+}
+
+// installEvent is a parsed driver install failure or success.
+type installEvent struct {
+	failed        bool
+	kernelVersion string
+	driverVersion string
+	source        string
+	message       string
+}
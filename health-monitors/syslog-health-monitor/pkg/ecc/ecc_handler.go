@@ -0,0 +1,253 @@
+// Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ecc
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/nvidia/nvsentinel/commons/pkg/lrucache"
+	"github.com/nvidia/nvsentinel/data-models/pkg/model"
+	pb "github.com/nvidia/nvsentinel/data-models/pkg/protos"
+	"github.com/nvidia/nvsentinel/health-monitors/syslog-health-monitor/pkg/common"
+	"github.com/nvidia/nvsentinel/health-monitors/syslog-health-monitor/pkg/metadata"
+	"github.com/nvidia/nvsentinel/health-monitors/syslog-health-monitor/pkg/policy"
+)
+
+// NewECCHandler creates a new ECCHandler instance. A GPU is reported once its
+// single-bit ECC errors reach sbeThreshold, DefaultSBEThreshold when not positive.
+func NewECCHandler(nodeName, defaultAgentName,
+	defaultComponentClass, checkName, metadataPath string, sbeThreshold int) (*ECCHandler, error) {
+	if sbeThreshold <= 0 {
+		sbeThreshold = DefaultSBEThreshold
+	}
+
+	return &ECCHandler{
+		nodeName:              nodeName,
+		defaultAgentName:      defaultAgentName,
+		defaultComponentClass: defaultComponentClass,
+		checkName:             checkName,
+		metadataReader:        metadata.NewReader(metadataPath),
+		sbeCounts:             lrucache.New[string, sbeCount]("syslog_ecc_sbe_counts", lrucache.DefaultCapacity),
+		sbeThreshold:          sbeThreshold,
+	}, nil
+}
+
+// SetStateCapacity bounds the number of GPUs whose single-bit ECC errors are counted.
+func (h *ECCHandler) SetStateCapacity(capacity int) {
+	h.sbeCounts.Resize(capacity)
+}
+
+// ProcessLine processes a single syslog line and returns any generated health events.
+func (h *ECCHandler) ProcessLine(message string) (*pb.HealthEvents, error) {
+	event := parseECCError(message)
+	if event == nil {
+		return nil, nil
+	}
+
+	eccCounterMetric.WithLabelValues(h.nodeName, event.errorCode).Inc()
+
+	decision := builtinDecision(event)
+
+	if event.errorCode == SBEErrorCode {
+		count, total, reached := h.countSBE(event.pci)
+		if !reached {
+			return nil, nil
+		}
+
+		event.sbeTotal = total
+
+		event.errorCode = SBEThresholdErrorCode
+		decision = policy.Decision{
+			IsFatal:           false,
+			RecommendedAction: pb.RecommendedAction_CONTACT_SUPPORT,
+			Message:           fmt.Sprintf("%d single-bit ECC errors on GPU %s: %s", count, event.pci, event.message),
+		}
+	}
+
+	source := policy.Source{
+		NodeName:       h.nodeName,
+		Agent:          h.defaultAgentName,
+		ComponentClass: h.defaultComponentClass,
+	}
+
+	return &pb.HealthEvents{
+		Version: 1,
+		Events:  []*pb.HealthEvent{h.policy.Event(source, h.extractFact(event), decision)},
+	}, nil
+}
+
+// Prefilter reports whether the line may be a driver message.
+func (h *ECCHandler) Prefilter(message string) bool {
+	return strings.Contains(message, nvrmMarker)
+}
+
+// SetPolicy sets the event policy deciding the severity and action of ECC facts.
+func (h *ECCHandler) SetPolicy(eventPolicy *policy.Policy) {
+	h.policy = eventPolicy
+}
+
+// SetSBEThreshold sets the number of single-bit ECC errors of a GPU reported as
+// a degradation. Counts already reached are reported on the next error.
+func (h *ECCHandler) SetSBEThreshold(threshold int) {
+	if threshold <= 0 {
+		return
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.sbeThreshold = threshold
+}
+
+func parseECCError(message string) *eccErrorEvent {
+	if !strings.Contains(message, nvrmMarker) {
+		return nil
+	}
+
+	// Xid 48, 63, 64 and 92 are the same errors, already reported by the XID handler
+	if common.XIDPattern.MatchString(message) {
+		return nil
+	}
+
+	m := rePCIPattern.FindStringSubmatch(message)
+	if m == nil {
+		// Errors cannot be attributed to a GPU without its PCI address
+		return nil
+	}
+
+	event := &eccErrorEvent{pci: normalizeBDF(m[1]), message: message}
+
+	// Row remapping messages name the ECC error that caused them, so they are
+	// matched first
+	switch {
+	case reRowRemapPattern.MatchString(message) && reFailurePattern.MatchString(message):
+		event.errorCode = RowRemapFailureErrorCode
+	case reRowRemapPattern.MatchString(message):
+		event.errorCode = RowRemapPendingErrorCode
+	case reDBEPattern.MatchString(message):
+		event.errorCode = DBEErrorCode
+	case reSBEPattern.MatchString(message):
+		event.errorCode = SBEErrorCode
+	default:
+		return nil
+	}
+
+	return event
+}
+
+// normalizeBDF returns the PCI address in the domain:bus:device.function form,
+// e.g. 0000:3b:00.0, so the messages of a GPU are counted together whether they
+// omit the function, e.g. PCI:0000:3b:00, or log a 32-bit domain.
+func normalizeBDF(pciAddr string) string {
+	bdf := strings.ToLower(pciAddr)
+
+	if domain, rest, ok := strings.Cut(bdf, ":"); ok && len(domain) > 4 {
+		bdf = domain[len(domain)-4:] + ":" + rest
+	}
+
+	if !strings.Contains(bdf, ".") {
+		bdf += ".0"
+	}
+
+	return bdf
+}
+
+// countSBE counts a single-bit ECC error of the GPU. It returns the errors counted
+// since the last report, the errors counted since the monitor started and whether
+// the former reached the threshold, in which case they start over.
+func (h *ECCHandler) countSBE(pci string) (int, int, bool) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	count, _ := h.sbeCounts.Get(pci)
+	count.sinceReport++
+	count.total++
+
+	reached := count.sinceReport >= h.sbeThreshold
+	sinceReport := count.sinceReport
+
+	if reached {
+		count.sinceReport = 0
+	}
+
+	h.sbeCounts.Add(pci, count)
+
+	return sinceReport, count.total, reached
+}
+
+// extractFact returns what the driver message reports, without severity or action.
+func (h *ECCHandler) extractFact(event *eccErrorEvent) policy.Fact {
+	entities := []*pb.Entity{{EntityType: "PCI", EntityValue: event.pci}}
+
+	gpu := ""
+	if gpuInfo, err := h.metadataReader.GetGPUByPCI(event.pci); err == nil {
+		gpu = strconv.Itoa(gpuInfo.GPUID)
+		entities = append(entities,
+			&pb.Entity{EntityType: "GPU", EntityValue: gpu},
+			&pb.Entity{EntityType: "GPU_UUID", EntityValue: gpuInfo.UUID},
+		)
+	}
+
+	metadata := make(map[string]string)
+	if chassisSerial := h.metadataReader.GetChassisSerial(); chassisSerial != nil {
+		metadata[model.MetadataChassisSerial] = *chassisSerial
+	}
+
+	// The cumulative counter lets the analyzer rate rules follow the growth of the errors
+	if event.sbeTotal > 0 {
+		metadata[model.MetadataECCSBETotal] = strconv.Itoa(event.sbeTotal)
+	}
+
+	return policy.Fact{
+		CheckName: h.checkName,
+		ErrorCode: event.errorCode,
+		Entities:  entities,
+		Attributes: map[string]string{
+			"pci": event.pci,
+			"gpu": gpu,
+		},
+		Metadata: metadata,
+		Line:     event.message,
+	}
+}
+
+// builtinDecision resets the GPU after a double-bit error: the corrupted memory is
+// retired or its row remapped, which only takes effect on a reset. A pending row
+// remapping needs the same reset without urgency, while a failed remapping means
+// the GPU ran out of spare rows and must be replaced.
+func builtinDecision(event *eccErrorEvent) policy.Decision {
+	switch event.errorCode {
+	case DBEErrorCode:
+		return policy.Decision{
+			IsFatal:           true,
+			RecommendedAction: pb.RecommendedAction_COMPONENT_RESET,
+			Message:           fmt.Sprintf("Double-bit ECC error on GPU %s: %s", event.pci, event.message),
+		}
+	case RowRemapFailureErrorCode:
+		return policy.Decision{
+			IsFatal:           true,
+			RecommendedAction: pb.RecommendedAction_CONTACT_SUPPORT,
+			Message:           fmt.Sprintf("Row remapping failed on GPU %s: %s", event.pci, event.message),
+		}
+	default:
+		return policy.Decision{
+			IsFatal:           false,
+			RecommendedAction: pb.RecommendedAction_COMPONENT_RESET,
+			Message:           fmt.Sprintf("Row remapping pending a reset on GPU %s: %s", event.pci, event.message),
+		}
+	}
+}
@@ -0,0 +1,247 @@
+// Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ecc
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/nvidia/nvsentinel/data-models/pkg/model"
+	pb "github.com/nvidia/nvsentinel/data-models/pkg/protos"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testMetadata = `{"version":"1.0","node_name":"test-node","gpus":[
+	{"gpu_id":3,"uuid":"GPU-3a1b","pci_address":"00000000:3B:00.0"}]}`
+
+func newTestHandler(t *testing.T, sbeThreshold int) *ECCHandler {
+	t.Helper()
+
+	metadataPath := filepath.Join(t.TempDir(), "gpu_metadata.json")
+	require.NoError(t, os.WriteFile(metadataPath, []byte(testMetadata), 0o600))
+
+	handler, err := NewECCHandler("test-node", "syslog-health-monitor", "GPU", "SysLogsECCError",
+		metadataPath, sbeThreshold)
+	require.NoError(t, err)
+
+	return handler
+}
+
+func TestParseECCError(t *testing.T) {
+	testCases := []struct {
+		name       string
+		message    string
+		expectCode string
+		expectPCI  string
+	}{
+		{
+			name:       "double-bit error",
+			message:    "kernel: NVRM: GPU 0000:3b:00.0: uncorrectable double-bit ECC error detected in the framebuffer",
+			expectCode: DBEErrorCode,
+			expectPCI:  "0000:3b:00.0",
+		},
+		{
+			name:       "double-bit error of older drivers",
+			message:    "NVRM: GPU at PCI:0000:B3:00: DBE (0x1) in FB, partition 0",
+			expectCode: DBEErrorCode,
+			expectPCI:  "0000:b3:00.0",
+		},
+		{
+			name:       "single-bit error",
+			message:    "NVRM: GPU 0000:3b:00.0: corrected single-bit ECC error in the framebuffer",
+			expectCode: SBEErrorCode,
+			expectPCI:  "0000:3b:00.0",
+		},
+		{
+			name:       "single-bit error with a 32-bit domain",
+			message:    "NVRM: GPU at PCI:00000000:3B:00.0: corrected single-bit ECC error",
+			expectCode: SBEErrorCode,
+			expectPCI:  "0000:3b:00.0",
+		},
+		{
+			name:       "row remapping pending",
+			message:    "NVRM: GPU 0000:3b:00.0: row remapping pending, reset the GPU to activate",
+			expectCode: RowRemapPendingErrorCode,
+			expectPCI:  "0000:3b:00.0",
+		},
+		{
+			name:       "row remapping failure",
+			message:    "NVRM: GPU 0000:3b:00.0: row remapping failed, no spare rows left",
+			expectCode: RowRemapFailureErrorCode,
+			expectPCI:  "0000:3b:00.0",
+		},
+		{
+			name:       "row remapping of a double-bit error",
+			message:    "NVRM: GPU 0000:3b:00.0: row remapping recorded after a double-bit error",
+			expectCode: RowRemapPendingErrorCode,
+			expectPCI:  "0000:3b:00.0",
+		},
+		{
+			name: "double-bit error Xid reported by the XID handler",
+			message: "kernel: NVRM: Xid (PCI:0000:3b:00): 48, pid=1234, name=python, An uncorrectable double bit " +
+				"error (DBE) has been detected on GPU in the framebuffer at partition 6, subpartition 0.",
+		},
+		{
+			name:    "single-bit error rate Xid reported by the XID handler",
+			message: "NVRM: Xid (PCI:0000:3b:00): 92, pid=1234, name=python, High single-bit ECC error rate",
+		},
+		{
+			name: "row remapping Xid reported by the XID handler",
+			message: "NVRM: Xid (PCI:0000:3b:00): 63, pid=1234, name=python, Row Remapper: New row " +
+				"(0x00000000000123ab) marked for remapping, reset gpu to activate.",
+		},
+		{
+			name:    "other Xid",
+			message: "NVRM: Xid (PCI:0000:3b:00): 79, pid=1234, name=python, GPU has fallen off the bus.",
+		},
+		{
+			name:    "ECC scrub notice",
+			message: "NVRM: GPU 0000:3b:00.0: ECC memory scrub completed",
+		},
+		{
+			name:    "without PCI address",
+			message: "NVRM: double bit error detected",
+		},
+		{
+			name:    "not a driver message",
+			message: "edac: MC0: 1 CE single-bit error on DIMM_A1",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			event := parseECCError(tc.message)
+			if tc.expectCode == "" {
+				assert.Nil(t, event)
+				return
+			}
+
+			require.NotNil(t, event)
+			assert.Equal(t, tc.expectCode, event.errorCode)
+			assert.Equal(t, tc.expectPCI, event.pci)
+		})
+	}
+}
+
+func TestProcessLineDBE(t *testing.T) {
+	handler := newTestHandler(t, 0)
+
+	line := "kernel: NVRM: GPU 0000:3b:00.0: uncorrectable double-bit ECC error detected in the framebuffer"
+	require.True(t, handler.Prefilter(line))
+
+	events, err := handler.ProcessLine(line)
+	require.NoError(t, err)
+	require.NotNil(t, events)
+	require.Len(t, events.Events, 1)
+
+	event := events.Events[0]
+	assert.True(t, event.IsFatal)
+	assert.Equal(t, pb.RecommendedAction_COMPONENT_RESET, event.RecommendedAction)
+	assert.Equal(t, DBEErrorCode, event.ErrorCode[0])
+	assert.Equal(t, []*pb.Entity{
+		{EntityType: "PCI", EntityValue: "0000:3b:00.0"},
+		{EntityType: "GPU", EntityValue: "3"},
+		{EntityType: "GPU_UUID", EntityValue: "GPU-3a1b"},
+	}, event.EntitiesImpacted)
+}
+
+func TestProcessLineRowRemapping(t *testing.T) {
+	handler := newTestHandler(t, 0)
+
+	events, err := handler.ProcessLine("NVRM: GPU 0000:3b:00.0: row remapping pending, reset the GPU to activate")
+	require.NoError(t, err)
+	require.NotNil(t, events)
+	assert.False(t, events.Events[0].IsFatal)
+	assert.Equal(t, pb.RecommendedAction_COMPONENT_RESET, events.Events[0].RecommendedAction)
+
+	events, err = handler.ProcessLine("NVRM: GPU 0000:3b:00.0: row remapping failed, no spare rows left")
+	require.NoError(t, err)
+	require.NotNil(t, events)
+	assert.True(t, events.Events[0].IsFatal)
+	assert.Equal(t, pb.RecommendedAction_CONTACT_SUPPORT, events.Events[0].RecommendedAction)
+}
+
+func TestProcessLineSBEThreshold(t *testing.T) {
+	handler := newTestHandler(t, 3)
+
+	line := "NVRM: GPU 0000:3b:00.0: corrected single-bit ECC error in the framebuffer"
+	other := "NVRM: GPU 0000:86:00.0: corrected single-bit ECC error in the framebuffer"
+
+	events, err := handler.ProcessLine(line)
+	require.NoError(t, err)
+	assert.Nil(t, events, "single-bit error 1 is below the threshold")
+
+	events, err = handler.ProcessLine("NVRM: GPU at PCI:0000:3B:00: corrected single-bit ECC error")
+	require.NoError(t, err)
+	assert.Nil(t, events, "single-bit error 2 of the same GPU is below the threshold")
+
+	events, err = handler.ProcessLine(other)
+	require.NoError(t, err)
+	assert.Nil(t, events, "GPUs are counted separately")
+
+	events, err = handler.ProcessLine(line)
+	require.NoError(t, err)
+	require.NotNil(t, events)
+	require.Len(t, events.Events, 1)
+	assert.False(t, events.Events[0].IsFatal)
+	assert.Equal(t, pb.RecommendedAction_CONTACT_SUPPORT, events.Events[0].RecommendedAction)
+	assert.Equal(t, SBEThresholdErrorCode, events.Events[0].ErrorCode[0])
+	assert.Equal(t, "3", events.Events[0].Metadata[model.MetadataECCSBETotal])
+
+	events, err = handler.ProcessLine(line)
+	require.NoError(t, err)
+	assert.Nil(t, events, "the count starts over after a report")
+
+	for range 2 {
+		events, err = handler.ProcessLine(line)
+		require.NoError(t, err)
+	}
+
+	require.NotNil(t, events)
+	assert.Equal(t, "6", events.Events[0].Metadata[model.MetadataECCSBETotal], "the total keeps counting")
+}
+
+func TestSetSBEThreshold(t *testing.T) {
+	handler := newTestHandler(t, 0)
+	assert.Equal(t, DefaultSBEThreshold, handler.sbeThreshold)
+
+	handler.SetSBEThreshold(1)
+
+	events, err := handler.ProcessLine("NVRM: GPU 0000:3b:00.0: corrected single-bit ECC error in the framebuffer")
+	require.NoError(t, err)
+	require.NotNil(t, events)
+	assert.Equal(t, SBEThresholdErrorCode, events.Events[0].ErrorCode[0])
+
+	handler.SetSBEThreshold(0)
+	assert.Equal(t, 1, handler.sbeThreshold, "0 keeps the threshold")
+}
+
+func TestProcessLineSkipsXids(t *testing.T) {
+	handler := newTestHandler(t, 1)
+
+	for _, line := range []string{
+		"NVRM: Xid (PCI:0000:3b:00): 48, pid=1234, name=python, An uncorrectable double bit error (DBE)",
+		"NVRM: Xid (PCI:0000:3b:00): 64, pid=1234, name=python, Row Remapper Error: failed to remap row",
+		"NVRM: Xid (PCI:0000:3b:00): 92, pid=1234, name=python, High single-bit ECC error rate",
+	} {
+		events, err := handler.ProcessLine(line)
+		require.NoError(t, err)
+		assert.Nil(t, events, line)
+	}
+
+	assert.Zero(t, handler.sbeCounts.Len(), "Xids are not counted")
+}
@@ -0,0 +1,101 @@
+// Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ecc
+
+import (
+	"regexp"
+	"sync"
+
+	"github.com/nvidia/nvsentinel/commons/pkg/lrucache"
+	"github.com/nvidia/nvsentinel/health-monitors/syslog-health-monitor/pkg/metadata"
+	"github.com/nvidia/nvsentinel/health-monitors/syslog-health-monitor/pkg/policy"
+)
+
+const (
+	// DBEErrorCode is the error code of an uncorrectable double-bit ECC error
+	DBEErrorCode = "ECC_DBE"
+	// SBEErrorCode counts the corrected single-bit ECC errors in the metric. They
+	// are not reported one by one.
+	SBEErrorCode = "ECC_SBE"
+	// SBEThresholdErrorCode is the error code of a GPU whose single-bit ECC errors
+	// reached the threshold, a sign of degrading memory
+	SBEThresholdErrorCode = "ECC_SBE_THRESHOLD"
+	// RowRemapPendingErrorCode is the error code of a row marked for remapping,
+	// applied on the next GPU reset
+	RowRemapPendingErrorCode = "ROW_REMAP_PENDING"
+	// RowRemapFailureErrorCode is the error code of a row the GPU failed to remap
+	RowRemapFailureErrorCode = "ROW_REMAP_FAILURE"
+
+	// DefaultSBEThreshold is the number of single-bit ECC errors of a GPU reported
+	// as a degradation
+	DefaultSBEThreshold = 10
+
+	// nvrmMarker is present in all the driver messages the handler parses
+	nvrmMarker = "NVRM:"
+)
+
+var (
+	// Pattern of the PCI address of the GPU in driver messages, e.g.
+	// "NVRM: GPU at PCI:0000:3b:00: GPU-3a1b..."
+	// "NVRM: GPU 0000:3b:00.0: ..."
+	rePCIPattern = regexp.MustCompile(
+		`(?:PCI:|NVRM: GPU )([0-9a-fA-F]{4,8}:[0-9a-fA-F]{2}:[0-9a-fA-F]{2}(?:\.[0-7])?)`)
+
+	// Double-bit ECC errors, e.g.
+	// "NVRM: GPU 0000:3b:00.0: uncorrectable double-bit ECC error detected in the framebuffer"
+	reDBEPattern = regexp.MustCompile(`(?i)double[- ]bit (?:ECC )?error|\bDBE\b`)
+	// Single-bit ECC errors, e.g.
+	// "NVRM: GPU 0000:3b:00.0: corrected single-bit ECC error in the framebuffer"
+	reSBEPattern = regexp.MustCompile(`(?i)single[- ]bit (?:ECC )?error|\bSBE\b`)
+	// Row remapping events, e.g.
+	// "NVRM: GPU 0000:3b:00.0: row remapping pending, reset the GPU to activate"
+	// "NVRM: GPU 0000:3b:00.0: row remapping failed, no spare rows left"
+	reRowRemapPattern = regexp.MustCompile(`(?i)row remap`)
+	// Failures of row remapping
+	reFailurePattern = regexp.MustCompile(`(?i)\bfail`)
+)
+
+// ECCHandler processes the ECC errors and row remapping events the NVIDIA driver
+// logs for the GPUs outside of Xids, which the XID handler reports.
+type ECCHandler struct {
+	nodeName              string
+	defaultAgentName      string
+	defaultComponentClass string
+	checkName             string
+	metadataReader        *metadata.Reader
+
+	mu sync.Mutex
+	// sbeCounts counts the single-bit ECC errors of every GPU
+	sbeCounts    *lrucache.Cache[string, sbeCount] // normalized PCI address -> single-bit ECC errors
+	sbeThreshold int
+	// policy decides the reported severity and action, nil keeps the built-in ones
+	policy *policy.Policy
+}
+
+// sbeCount holds the single-bit ECC errors of a GPU since its last report and since
+// the monitor started.
+type sbeCount struct {
+	sinceReport int
+	total       int
+}
+
+// eccErrorEvent represents a parsed ECC error or row remapping event
+type eccErrorEvent struct {
+	errorCode string
+	pci       string
+	message   string
+	// sbeTotal is the cumulative single-bit ECC errors of the GPU, 0 for other errors
+	sbeTotal int
+}
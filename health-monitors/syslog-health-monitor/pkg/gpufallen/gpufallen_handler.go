@@ -18,12 +18,14 @@ import (
 	"context"
 	"fmt"
 	"log/slog"
+	"strconv"
+	"strings"
 	"time"
 
+	"github.com/nvidia/nvsentinel/commons/pkg/lrucache"
 	pb "github.com/nvidia/nvsentinel/data-models/pkg/protos"
 	"github.com/nvidia/nvsentinel/health-monitors/syslog-health-monitor/pkg/common"
-
-	"google.golang.org/protobuf/types/known/timestamppb"
+	"github.com/nvidia/nvsentinel/health-monitors/syslog-health-monitor/pkg/policy"
 )
 
 // NewGPUFallenHandler creates a new GPUFallenHandler instance.
@@ -36,7 +38,8 @@ func NewGPUFallenHandler(nodeName, defaultAgentName,
 		defaultAgentName:      defaultAgentName,
 		defaultComponentClass: defaultComponentClass,
 		checkName:             checkName,
-		recentXIDs:            make(map[string]xidRecord),
+		recentXIDs:            newRecentXIDs(),
+		gpus:                  lrucache.New[string, gpuRecord]("syslog_gpufallen_gpus", lrucache.DefaultCapacity),
 		xidWindow:             5 * time.Minute, // Remember XIDs for 5 minutes
 		cancelCleanup:         cancel,
 	}
@@ -55,7 +58,19 @@ func (h *GPUFallenHandler) Close() {
 	}
 }
 
-// SetXIDWindow sets the time window for tracking XID errors.
+func newRecentXIDs() *lrucache.Cache[string, xidRecord] {
+	return lrucache.New[string, xidRecord]("syslog_gpufallen_recent_xids", lrucache.DefaultCapacity)
+}
+
+// SetStateCapacity bounds the number of GPUs whose recent XID and falls off the
+// bus are remembered.
+func (h *GPUFallenHandler) SetStateCapacity(capacity int) {
+	h.recentXIDs.Resize(capacity)
+	h.gpus.Resize(capacity)
+}
+
+// SetXIDWindow sets the time window for tracking XID errors, which is also the
+// window in which a GPU falling off the bus is reported once.
 // This is primarily used for testing with shorter time windows.
 func (h *GPUFallenHandler) SetXIDWindow(window time.Duration) {
 	h.mu.Lock()
@@ -66,11 +81,13 @@ func (h *GPUFallenHandler) SetXIDWindow(window time.Duration) {
 
 // ProcessLine processes a single syslog line and returns any generated health events.
 func (h *GPUFallenHandler) ProcessLine(message string) (*pb.HealthEvents, error) {
-	// First check if this is an XID message and track it
-	h.trackXIDIfPresent(message)
+	// First check if this is an XID message and track it, Xid 79 reports the fall
+	event := h.trackXIDIfPresent(message)
+	if event == nil {
+		// Check if this is a GPU falling off error
+		event = h.parseGPUFallenError(message)
+	}
 
-	// Check if this is a GPU falling off error
-	event := h.parseGPUFallenError(message)
 	if event == nil {
 		return nil, nil
 	}
@@ -78,14 +95,22 @@ func (h *GPUFallenHandler) ProcessLine(message string) (*pb.HealthEvents, error)
 	return h.createHealthEventFromError(event), nil
 }
 
-// trackXIDIfPresent checks if the message contains an XID error and records it
-func (h *GPUFallenHandler) trackXIDIfPresent(message string) {
+// Prefilter reports whether the line may be an XID or GPU fallen off the bus
+// message.
+func (h *GPUFallenHandler) Prefilter(message string) bool {
+	return strings.Contains(message, nvrmMarker)
+}
+
+// trackXIDIfPresent checks if the message contains an XID error and records it.
+// It returns the fall off the bus reported by an Xid 79, which the driver logs
+// before its fallen off the bus message.
+func (h *GPUFallenHandler) trackXIDIfPresent(message string) *gpuFallenErrorEvent {
 	matches := common.XIDPattern.FindStringSubmatch(message)
 	if len(matches) < 3 {
-		return // Not an XID message
+		return nil // Not an XID message
 	}
 
-	pciAddr := matches[1]
+	pciAddr := normalizeBDF(matches[1])
 	xidCode := 0
 	// Parse XID code (matches[2])
 	if _, err := fmt.Sscanf(matches[2], "%d", &xidCode); err != nil {
@@ -94,15 +119,23 @@ func (h *GPUFallenHandler) trackXIDIfPresent(message string) {
 			"xid_string", matches[2],
 			"error", err)
 
-		return
+		return nil
 	}
 
 	h.mu.Lock()
-	defer h.mu.Unlock()
-
-	h.recentXIDs[pciAddr] = xidRecord{
+	h.recentXIDs.Add(pciAddr, xidRecord{
 		timestamp: time.Now(),
 		xidCode:   xidCode,
+	})
+	h.mu.Unlock()
+
+	if xidCode != fallenXID {
+		return nil
+	}
+
+	return &gpuFallenErrorEvent{
+		pciAddr: pciAddr,
+		message: message,
 	}
 }
 
@@ -110,7 +143,7 @@ func (h *GPUFallenHandler) trackXIDIfPresent(message string) {
 // and opportunistically cleans up expired entries to prevent memory leaks
 func (h *GPUFallenHandler) hasRecentXID(pciAddr string) bool {
 	h.mu.RLock()
-	record, exists := h.recentXIDs[pciAddr]
+	record, exists := h.recentXIDs.Peek(pciAddr)
 	h.mu.RUnlock()
 
 	if !exists {
@@ -124,8 +157,8 @@ func (h *GPUFallenHandler) hasRecentXID(pciAddr string) bool {
 	if !isRecent {
 		h.mu.Lock()
 		// Double-check after acquiring write lock (entry might have been updated)
-		if record, exists := h.recentXIDs[pciAddr]; exists && time.Since(record.timestamp) >= h.xidWindow {
-			delete(h.recentXIDs, pciAddr)
+		if record, exists := h.recentXIDs.Peek(pciAddr); exists && time.Since(record.timestamp) >= h.xidWindow {
+			h.recentXIDs.Remove(pciAddr)
 		}
 
 		h.mu.Unlock()
@@ -158,9 +191,9 @@ func (h *GPUFallenHandler) cleanupExpiredXIDs(ctx context.Context) {
 			now := time.Now()
 			window := h.xidWindow // Read current window value
 
-			for pciAddr, record := range h.recentXIDs {
-				if now.Sub(record.timestamp) >= window {
-					delete(h.recentXIDs, pciAddr)
+			for _, pciAddr := range h.recentXIDs.Keys() {
+				if record, ok := h.recentXIDs.Peek(pciAddr); ok && now.Sub(record.timestamp) >= window {
+					h.recentXIDs.Remove(pciAddr)
 				}
 			}
 
@@ -181,7 +214,7 @@ func (h *GPUFallenHandler) parseGPUFallenError(message string) *gpuFallenErrorEv
 		return nil
 	}
 
-	pciAddr := m[1]
+	pciAddr := normalizeBDF(m[1])
 
 	// Check if this PCI address has had a recent XID error
 	// If so, skip generating an event to avoid duplicates with XID handler
@@ -204,7 +237,57 @@ func (h *GPUFallenHandler) parseGPUFallenError(message string) *gpuFallenErrorEv
 	}
 }
 
-func (h *GPUFallenHandler) createHealthEventFromError(event *gpuFallenErrorEvent) *pb.HealthEvents {
+// normalizeBDF returns the PCI address in the domain:bus:device.function form of
+// the driver message, e.g. 0000:b3:00.0, as XIDs omit the function and may omit
+// the domain, e.g. PCI:0000:b3:00 or PCI:b3:00.
+func normalizeBDF(pciAddr string) string {
+	bdf := strings.ToLower(pciAddr)
+
+	if strings.Count(bdf, ":") == 1 {
+		bdf = "0000:" + bdf
+	}
+
+	if !strings.Contains(bdf, ".") {
+		bdf += ".0"
+	}
+
+	return bdf
+}
+
+// recordFall counts a fall of the GPU off the bus. It returns the falls counted
+// and whether this line starts a new one: the Xid 79 and driver message of a
+// fall, and the lines the driver keeps logging while the GPU is off the bus, are
+// counted once per window.
+func (h *GPUFallenHandler) recordFall(pciAddr string) (int, bool) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	now := time.Now()
+
+	record, _ := h.gpus.Get(pciAddr)
+	if !record.lastReported.IsZero() && now.Sub(record.lastReported) < h.xidWindow {
+		return record.count, false
+	}
+
+	record.count++
+	record.lastReported = now
+	h.gpus.Add(pciAddr, record)
+
+	// A node has a handful of GPUs, so the PCI address label stays bounded
+	gpuFallenByGPUMetric.WithLabelValues(h.nodeName, pciAddr).Inc()
+
+	return record.count, true
+}
+
+// SetPolicy sets the event policy deciding the severity and action of GPU fallen
+// off the bus facts.
+func (h *GPUFallenHandler) SetPolicy(eventPolicy *policy.Policy) {
+	h.policy = eventPolicy
+}
+
+// extractFact returns what the GPU fallen off the bus message reports, without
+// severity or action.
+func (h *GPUFallenHandler) extractFact(event *gpuFallenErrorEvent, count int) policy.Fact {
 	entitiesImpacted := []*pb.Entity{
 		{EntityType: "PCI", EntityValue: event.pciAddr},
 	}
@@ -216,26 +299,49 @@ func (h *GPUFallenHandler) createHealthEventFromError(event *gpuFallenErrorEvent
 		})
 	}
 
-	// Increment metrics (node-level only to avoid cardinality explosion)
+	return policy.Fact{
+		CheckName: h.checkName,
+		ErrorCode: ErrorCode,
+		Entities:  entitiesImpacted,
+		Attributes: map[string]string{
+			"pci":    event.pciAddr,
+			"pci_id": event.pciID,
+		},
+		Metadata: map[string]string{
+			"fall_count": strconv.Itoa(count),
+		},
+		Line: event.message,
+	}
+}
+
+func (h *GPUFallenHandler) createHealthEventFromError(event *gpuFallenErrorEvent) *pb.HealthEvents {
+	count, report := h.recordFall(event.pciAddr)
+	if !report {
+		return nil
+	}
+
+	fact := h.extractFact(event, count)
+
 	gpuFallenCounterMetric.WithLabelValues(h.nodeName).Inc()
 
-	healthEvent := &pb.HealthEvent{
-		Version:            1,
-		Agent:              h.defaultAgentName,
-		CheckName:          h.checkName,
-		ComponentClass:     h.defaultComponentClass,
-		GeneratedTimestamp: timestamppb.New(time.Now()),
-		EntitiesImpacted:   entitiesImpacted,
-		Message:            event.message,
-		IsFatal:            true, // GPU falling off the bus is always fatal
-		IsHealthy:          false,
-		NodeName:           h.nodeName,
-		RecommendedAction:  pb.RecommendedAction_RESTART_BM,
-		ErrorCode:          []string{"GPU_FALLEN_OFF_BUS"},
+	source := policy.Source{
+		NodeName:       h.nodeName,
+		Agent:          h.defaultAgentName,
+		ComponentClass: h.defaultComponentClass,
+	}
+
+	// A GPU falling off the bus is always fatal. It is rarely recovered for good by
+	// a restart: the seating, power and riser of the GPU need a physical inspection,
+	// and GPUs that keep falling off are returned (RMA).
+	builtin := policy.Decision{
+		IsFatal:           true,
+		RecommendedAction: pb.RecommendedAction_CONTACT_SUPPORT,
+		Message: fmt.Sprintf("GPU %s has fallen off the bus (fall %d seen by the monitor), "+
+			"inspect it physically or RMA it: %s", event.pciAddr, count, event.message),
 	}
 
 	return &pb.HealthEvents{
 		Version: 1,
-		Events:  []*pb.HealthEvent{healthEvent},
+		Events:  []*pb.HealthEvent{h.policy.Event(source, fact, builtin)},
 	}
 }
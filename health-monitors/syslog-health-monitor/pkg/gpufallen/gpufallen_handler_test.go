@@ -24,7 +24,7 @@ import (
 )
 
 func TestParseGPUFallenError(t *testing.T) {
-	handler := &GPUFallenHandler{}
+	handler := &GPUFallenHandler{recentXIDs: newRecentXIDs()}
 
 	testCases := []struct {
 		name        string
@@ -111,10 +111,12 @@ func TestProcessLine(t *testing.T) {
 				assert.Equal(t, "GPU", event.ComponentClass)
 				assert.True(t, event.IsFatal)
 				assert.False(t, event.IsHealthy)
-				assert.Equal(t, pb.RecommendedAction_RESTART_BM, event.RecommendedAction)
+				assert.Equal(t, pb.RecommendedAction_CONTACT_SUPPORT, event.RecommendedAction)
 				require.Len(t, event.ErrorCode, 1)
 				assert.Equal(t, "GPU_FALLEN_OFF_BUS", event.ErrorCode[0])
-				assert.Equal(t, message, event.Message)
+				assert.Equal(t, "GPU 0000:b3:00.0 has fallen off the bus (fall 1 seen by the monitor), "+
+					"inspect it physically or RMA it: "+message, event.Message)
+				assert.Equal(t, "1", event.Metadata["fall_count"])
 
 				// Should have PCI and PCI_ID entities only
 				assert.Len(t, event.EntitiesImpacted, 2)
@@ -146,7 +148,7 @@ func TestProcessLine(t *testing.T) {
 				require.Len(t, events.Events, 1)
 
 				event := events.Events[0]
-				assert.Equal(t, message, event.Message)
+				assert.Contains(t, event.Message, message)
 
 				// Should have only PCI entity
 				assert.Len(t, event.EntitiesImpacted, 1)
@@ -188,7 +190,7 @@ func TestProcessLine(t *testing.T) {
 }
 
 func TestXIDTracking(t *testing.T) {
-	t.Run("XID then GPU fallen off - should suppress event", func(t *testing.T) {
+	t.Run("Xid 79 then GPU fallen off - should report the fall once", func(t *testing.T) {
 		// Create fresh handler for this test
 		handler, err := NewGPUFallenHandler(
 			"test-node",
@@ -203,7 +205,9 @@ func TestXIDTracking(t *testing.T) {
 		xidMsg := "NVRM: Xid (PCI:0000:b3:00.0): 79, pid=1234, name=process"
 		events, err := handler.ProcessLine(xidMsg)
 		require.NoError(t, err)
-		assert.Nil(t, events, "XID handler should process XID, not gpufallen handler")
+		require.NotNil(t, events, "Xid 79 should report the fall")
+		require.Len(t, events.Events, 1)
+		assert.Equal(t, pb.RecommendedAction_CONTACT_SUPPORT, events.Events[0].RecommendedAction)
 
 		// Now process GPU fallen off message - should be suppressed
 		fallenMsg := "[ 1843.308145] NVRM: The NVIDIA GPU 0000:b3:00.0\n" +
@@ -291,7 +295,7 @@ func TestXIDTracking(t *testing.T) {
 		assert.Nil(t, events, "Should suppress for PCI with recent XID")
 	})
 
-	t.Run("Single message with both XID and GPU fallen off - should not generate event", func(t *testing.T) {
+	t.Run("Single message with both XID and GPU fallen off - should generate one event", func(t *testing.T) {
 		handler5, err := NewGPUFallenHandler(
 			"test-node",
 			"test-agent",
@@ -305,7 +309,8 @@ func TestXIDTracking(t *testing.T) {
 		combinedMsg := "NVRM: Xid (PCI:0000:b3:00.0): 79, GPU has fallen off the bus and is not responding to commands."
 		events, err := handler5.ProcessLine(combinedMsg)
 		require.NoError(t, err)
-		assert.Nil(t, events, "Should not generate event when XID is in same message - let XID handler process it")
+		require.NotNil(t, events, "Should report the fall of the Xid 79 once")
+		assert.Len(t, events.Events, 1)
 	})
 
 	t.Run("Malformed XID code should not crash and GPU fallen off should still generate event", func(t *testing.T) {
@@ -348,13 +353,12 @@ func TestXIDTracking(t *testing.T) {
 
 		// Process XID message
 		xidMsg := "NVRM: Xid (PCI:0000:b3:00.0): 79, pid=1234, name=process"
-		events, err := handler7.ProcessLine(xidMsg)
+		_, err = handler7.ProcessLine(xidMsg)
 		require.NoError(t, err)
-		assert.Nil(t, events)
 
 		// Verify entry exists in map
 		handler7.mu.RLock()
-		_, exists := handler7.recentXIDs["0000:b3:00.0"]
+		exists := handler7.recentXIDs.Contains("0000:b3:00.0")
 		handler7.mu.RUnlock()
 		assert.True(t, exists, "XID should be recorded in map")
 
@@ -367,7 +371,7 @@ func TestXIDTracking(t *testing.T) {
 
 		// Verify entry was removed from map
 		handler7.mu.RLock()
-		_, exists = handler7.recentXIDs["0000:b3:00.0"]
+		exists = handler7.recentXIDs.Contains("0000:b3:00.0")
 		handler7.mu.RUnlock()
 		assert.False(t, exists, "Expired XID should be cleaned up from map")
 	})
@@ -396,7 +400,7 @@ func TestXIDTracking(t *testing.T) {
 
 		// Verify all entries exist
 		handler8.mu.RLock()
-		initialCount := len(handler8.recentXIDs)
+		initialCount := handler8.recentXIDs.Len()
 		handler8.mu.RUnlock()
 		assert.Equal(t, 3, initialCount, "Should have 3 XID entries")
 
@@ -411,8 +415,66 @@ func TestXIDTracking(t *testing.T) {
 
 		// Verify entries were cleaned up
 		handler8.mu.RLock()
-		finalCount := len(handler8.recentXIDs)
+		finalCount := handler8.recentXIDs.Len()
 		handler8.mu.RUnlock()
 		assert.Equal(t, 0, finalCount, "Expired entries should be cleaned up")
 	})
 }
+
+func TestNormalizeBDF(t *testing.T) {
+	testCases := map[string]string{
+		"0000:b3:00.0": "0000:b3:00.0",
+		"0000:B3:00":   "0000:b3:00.0",
+		"b3:00.1":      "0000:b3:00.1",
+		"b3:00":        "0000:b3:00.0",
+	}
+
+	for pciAddr, expected := range testCases {
+		assert.Equal(t, expected, normalizeBDF(pciAddr), pciAddr)
+	}
+}
+
+func TestFallsCountedPerGPU(t *testing.T) {
+	handler, err := NewGPUFallenHandler("test-node", "test-agent", "GPU", "test-check")
+	require.NoError(t, err)
+	defer handler.Close()
+
+	handler.SetXIDWindow(50 * time.Millisecond)
+
+	process := func(line string) *pb.HealthEvents {
+		events, err := handler.ProcessLine(line)
+		require.NoError(t, err)
+
+		return events
+	}
+
+	fallen := func(pci string) string {
+		return "NVRM: The NVIDIA GPU " + pci + " installed in this system has\n" +
+			"NVRM: fallen off the bus and is not responding to commands."
+	}
+
+	events := process(fallen("0000:B3:00.0"))
+	require.NotNil(t, events)
+	assert.Equal(t, "1", events.Events[0].Metadata["fall_count"])
+	assert.Equal(t, "0000:b3:00.0", events.Events[0].EntitiesImpacted[0].EntityValue)
+
+	assert.Nil(t, process(fallen("0000:b3:00.0")), "lines of the same fall are reported once")
+
+	events = process(fallen("0000:b4:00.0"))
+	require.NotNil(t, events)
+	assert.Equal(t, "1", events.Events[0].Metadata["fall_count"], "GPUs are counted independently")
+
+	time.Sleep(60 * time.Millisecond)
+
+	events = process("NVRM: Xid (PCI:0000:b3:00): 79, pid=1234, name=python, GPU has fallen off the bus.")
+	require.NotNil(t, events, "Xid 79 reports the fall")
+	assert.Equal(t, "2", events.Events[0].Metadata["fall_count"])
+	assert.Equal(t, "0000:b3:00.0", events.Events[0].EntitiesImpacted[0].EntityValue)
+
+	time.Sleep(60 * time.Millisecond)
+
+	events = process(fallen("0000:b3:00.0"))
+	require.NotNil(t, events, "a later fall is reported again")
+	assert.Equal(t, "3", events.Events[0].Metadata["fall_count"])
+	assert.Contains(t, events.Events[0].Message, "fall 3 seen by the monitor")
+}
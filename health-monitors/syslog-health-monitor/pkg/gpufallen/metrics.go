@@ -28,4 +28,14 @@ var (
 		},
 		[]string{"node"},
 	)
+
+	// Counter metric for the falls off the bus of every GPU, including those
+	// reported by the XID handler as Xid 79
+	gpuFallenByGPUMetric = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "syslog_health_monitor_gpu_fallen_errors_by_gpu",
+			Help: "Total number of falls off the bus detected per GPU, including Xid 79",
+		},
+		[]string{"node", "pci"},
+	)
 )
@@ -19,6 +19,23 @@ import (
 	"regexp"
 	"sync"
 	"time"
+
+	"github.com/nvidia/nvsentinel/commons/pkg/lrucache"
+	"github.com/nvidia/nvsentinel/health-monitors/syslog-health-monitor/pkg/policy"
+)
+
+const (
+	// ErrorCode is the error code of a GPU that fell off the bus
+	ErrorCode = "GPU_FALLEN_OFF_BUS"
+
+	// fallenXID is the Xid the driver logs when a GPU falls off the bus. The XID
+	// handler reports it with the action of the XID catalog, this handler reports
+	// the fall with its count.
+	fallenXID = 79
+
+	// nvrmMarker is present in both the XID messages tracked by the handler and the
+	// GPU fallen off the bus message.
+	nvrmMarker = "NVRM:"
 )
 
 var (
@@ -41,6 +58,12 @@ type xidRecord struct {
 	xidCode   int
 }
 
+// gpuRecord tracks the falls off the bus of a GPU
+type gpuRecord struct {
+	lastReported time.Time
+	count        int
+}
+
 // GPUFallenHandler processes syslog lines related to GPU fallen off bus errors.
 type GPUFallenHandler struct {
 	nodeName              string
@@ -48,9 +71,11 @@ type GPUFallenHandler struct {
 	defaultComponentClass string
 	checkName             string
 	mu                    sync.RWMutex
-	recentXIDs            map[string]xidRecord // pciAddr -> XID record
-	xidWindow             time.Duration        // how long to remember XID errors
-	cancelCleanup         context.CancelFunc   // stops the cleanup goroutine
+	recentXIDs            *lrucache.Cache[string, xidRecord] // pciAddr -> XID record
+	gpus                  *lrucache.Cache[string, gpuRecord] // pciAddr -> falls off the bus
+	xidWindow             time.Duration                      // how long to remember XID errors and reports
+	cancelCleanup         context.CancelFunc                 // stops the cleanup goroutine
+	policy                *policy.Policy                     // decides the reported severity and action
 }
 
 // gpuFallenErrorEvent represents a parsed GPU fallen off bus error event
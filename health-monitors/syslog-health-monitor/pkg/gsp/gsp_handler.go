@@ -0,0 +1,167 @@
+// Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gsp
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/nvidia/nvsentinel/data-models/pkg/model"
+	pb "github.com/nvidia/nvsentinel/data-models/pkg/protos"
+	"github.com/nvidia/nvsentinel/health-monitors/syslog-health-monitor/pkg/common"
+	"github.com/nvidia/nvsentinel/health-monitors/syslog-health-monitor/pkg/metadata"
+	"github.com/nvidia/nvsentinel/health-monitors/syslog-health-monitor/pkg/policy"
+)
+
+// NewGSPHandler creates a new GSPHandler instance.
+func NewGSPHandler(nodeName, defaultAgentName,
+	defaultComponentClass, checkName, metadataPath string) (*GSPHandler, error) {
+	return &GSPHandler{
+		nodeName:              nodeName,
+		defaultAgentName:      defaultAgentName,
+		defaultComponentClass: defaultComponentClass,
+		checkName:             checkName,
+		metadataReader:        metadata.NewReader(metadataPath),
+	}, nil
+}
+
+// ProcessLine processes a single syslog line and returns any generated health events.
+func (h *GSPHandler) ProcessLine(message string) (*pb.HealthEvents, error) {
+	event := parseGSPError(message)
+	if event == nil {
+		return nil, nil
+	}
+
+	gspCounterMetric.WithLabelValues(h.nodeName, event.errorCode).Inc()
+
+	source := policy.Source{
+		NodeName:       h.nodeName,
+		Agent:          h.defaultAgentName,
+		ComponentClass: h.defaultComponentClass,
+	}
+
+	return &pb.HealthEvents{
+		Version: 1,
+		Events:  []*pb.HealthEvent{h.policy.Event(source, h.extractFact(event), builtinDecision(event))},
+	}, nil
+}
+
+// Prefilter reports whether the line may be a driver message.
+func (h *GSPHandler) Prefilter(message string) bool {
+	return strings.Contains(message, nvrmMarker)
+}
+
+// SetPolicy sets the event policy deciding the severity and action of GSP facts.
+func (h *GSPHandler) SetPolicy(eventPolicy *policy.Policy) {
+	h.policy = eventPolicy
+}
+
+func parseGSPError(message string) *gspErrorEvent {
+	if !strings.Contains(message, nvrmMarker) {
+		return nil
+	}
+
+	// Xid 119 and 120 are the same errors, already reported by the XID handler
+	if common.XIDPattern.MatchString(message) {
+		return nil
+	}
+
+	var errorCode string
+
+	// A crash often follows timed out RPCs and its messages may mention them, so
+	// it is matched first
+	switch {
+	case reCrashPattern.MatchString(message):
+		errorCode = FirmwareCrashErrorCode
+	case reTimeoutPattern.MatchString(message):
+		errorCode = RPCTimeoutErrorCode
+	default:
+		return nil
+	}
+
+	m := rePCIPattern.FindStringSubmatch(message)
+	if m == nil {
+		// The driver also dumps the GSP state without the PCI address of the GPU,
+		// next to the attributed message reported above
+		return nil
+	}
+
+	return &gspErrorEvent{errorCode: errorCode, pci: normalizeBDF(m[1]), message: message}
+}
+
+// normalizeBDF returns the PCI address in the domain:bus:device.function form the
+// other handlers report and the GPU metadata is keyed by, e.g. 0000:1b:00.0, as
+// driver messages may omit the function or log an 8 digit domain.
+func normalizeBDF(pciAddr string) string {
+	bdf := strings.ToLower(pciAddr)
+
+	if domain, rest, ok := strings.Cut(bdf, ":"); ok && len(domain) > 4 {
+		bdf = domain[len(domain)-4:] + ":" + rest
+	}
+
+	if !strings.Contains(bdf, ".") {
+		bdf += ".0"
+	}
+
+	return bdf
+}
+
+// extractFact returns what the driver message reports, without severity or action.
+func (h *GSPHandler) extractFact(event *gspErrorEvent) policy.Fact {
+	entities := []*pb.Entity{{EntityType: "PCI", EntityValue: event.pci}}
+
+	gpu := ""
+	if gpuInfo, err := h.metadataReader.GetGPUByPCI(event.pci); err == nil {
+		gpu = strconv.Itoa(gpuInfo.GPUID)
+		entities = append(entities,
+			&pb.Entity{EntityType: "GPU", EntityValue: gpu},
+			&pb.Entity{EntityType: "GPU_UUID", EntityValue: gpuInfo.UUID},
+		)
+	}
+
+	metadata := make(map[string]string)
+	if chassisSerial := h.metadataReader.GetChassisSerial(); chassisSerial != nil {
+		metadata[model.MetadataChassisSerial] = *chassisSerial
+	}
+
+	return policy.Fact{
+		CheckName: h.checkName,
+		ErrorCode: event.errorCode,
+		Entities:  entities,
+		Attributes: map[string]string{
+			"pci": event.pci,
+			"gpu": gpu,
+		},
+		Metadata: metadata,
+		Line:     event.message,
+	}
+}
+
+// builtinDecision restarts the node after a crash or an RPC timeout of the GSP
+// firmware: the driver cannot use the GPU anymore, and only reloading the driver
+// restarts the firmware, which needs every process of the GPU stopped.
+func builtinDecision(event *gspErrorEvent) policy.Decision {
+	message := fmt.Sprintf("GSP firmware crashed on GPU %s: %s", event.pci, event.message)
+	if event.errorCode == RPCTimeoutErrorCode {
+		message = fmt.Sprintf("GSP firmware RPC timed out on GPU %s: %s", event.pci, event.message)
+	}
+
+	return policy.Decision{
+		IsFatal:           true,
+		RecommendedAction: pb.RecommendedAction_RESTART_BM,
+		Message:           message,
+	}
+}
@@ -0,0 +1,176 @@
+// Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gsp
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	pb "github.com/nvidia/nvsentinel/data-models/pkg/protos"
+	"github.com/nvidia/nvsentinel/health-monitors/syslog-health-monitor/pkg/xid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testMetadata = `{"version":"1.0","node_name":"test-node","gpus":[
+	{"gpu_id":1,"uuid":"GPU-1c2d","pci_address":"00000000:1B:00.0"}]}`
+
+func newTestHandler(t *testing.T) *GSPHandler {
+	t.Helper()
+
+	metadataPath := filepath.Join(t.TempDir(), "gpu_metadata.json")
+	require.NoError(t, os.WriteFile(metadataPath, []byte(testMetadata), 0o600))
+
+	handler, err := NewGSPHandler("test-node", "syslog-health-monitor", "GPU", "SysLogsGSPFirmwareCrash", metadataPath)
+	require.NoError(t, err)
+
+	return handler
+}
+
+func TestParseGSPError(t *testing.T) {
+	testCases := []struct {
+		name       string
+		message    string
+		expectCode string
+		expectPCI  string
+	}{
+		{
+			name:       "firmware crashed",
+			message:    "kernel: NVRM: GPU 0000:1b:00.0: GSP firmware crashed",
+			expectCode: FirmwareCrashErrorCode,
+			expectPCI:  "0000:1b:00.0",
+		},
+		{
+			name:       "heartbeat timed out",
+			message:    "NVRM: GPU 0000:1B:00.0: GSP heartbeat timed out, GSP-RM is not responding",
+			expectCode: FirmwareCrashErrorCode,
+			expectPCI:  "0000:1b:00.0",
+		},
+		{
+			name:       "8 digit domain",
+			message:    "NVRM: GPU 00000000:1B:00.0: GSP firmware crashed",
+			expectCode: FirmwareCrashErrorCode,
+			expectPCI:  "0000:1b:00.0",
+		},
+		{
+			name: "Xid 120 is reported by the XID handler",
+			message: "NVRM: Xid (PCI:0000:1b:00): 120, pid=1234, name=python, GSP task exception: " +
+				"(TASK:RM) (EC:0x5) (GSP:0xa)",
+		},
+		{
+			name:       "RPC timeout",
+			message:    "NVRM: GPU 0000:1b:00.0: GSP RPC timeout",
+			expectCode: RPCTimeoutErrorCode,
+			expectPCI:  "0000:1b:00.0",
+		},
+		{
+			name:       "RPC timeout without function",
+			message:    "NVRM: GPU at PCI:0000:1b:00: GSP RPC timeout",
+			expectCode: RPCTimeoutErrorCode,
+			expectPCI:  "0000:1b:00.0",
+		},
+		{
+			name: "Xid 119 is reported by the XID handler",
+			message: "NVRM: Xid (PCI:0000:1b:00): 119, pid=1234, name=python, Timeout after 6s of waiting for " +
+				"RPC response from GPU1 GSP! Expected function 76 (GSP_RM_CONTROL) (0x20800a4c 0x4).",
+		},
+		{
+			name:    "GSP state dump without PCI address",
+			message: "NVRM: GPU1 _kgspLogXid119: ********************************* GSP Timeout *****",
+		},
+		{
+			name:    "GSP firmware loaded",
+			message: "NVRM: GPU 0000:1b:00.0: GSP firmware version 550.54.15 loaded",
+		},
+		{
+			name:    "other Xid",
+			message: "NVRM: Xid (PCI:0000:1b:00): 79, pid=1234, name=python, GPU has fallen off the bus.",
+		},
+		{
+			name:    "not a driver message",
+			message: "systemd[1]: gsp-exporter.service: GSP RPC timeout",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			event := parseGSPError(tc.message)
+			if tc.expectCode == "" {
+				assert.Nil(t, event)
+				return
+			}
+
+			require.NotNil(t, event)
+			assert.Equal(t, tc.expectCode, event.errorCode)
+			assert.Equal(t, tc.expectPCI, event.pci)
+		})
+	}
+}
+
+func TestProcessLine(t *testing.T) {
+	handler := newTestHandler(t)
+
+	for line, code := range map[string]string{
+		"kernel: NVRM: GPU 0000:1b:00.0: GSP firmware crashed": FirmwareCrashErrorCode,
+		"kernel: NVRM: GPU 0000:1b:00.0: GSP RPC timeout":      RPCTimeoutErrorCode,
+	} {
+		require.True(t, handler.Prefilter(line))
+
+		events, err := handler.ProcessLine(line)
+		require.NoError(t, err)
+		require.NotNil(t, events)
+		require.Len(t, events.Events, 1)
+
+		event := events.Events[0]
+		assert.True(t, event.IsFatal)
+		assert.Equal(t, pb.RecommendedAction_RESTART_BM, event.RecommendedAction)
+		assert.Equal(t, []string{code}, event.ErrorCode)
+		assert.Equal(t, []*pb.Entity{
+			{EntityType: "PCI", EntityValue: "0000:1b:00.0"},
+			{EntityType: "GPU", EntityValue: "1"},
+			{EntityType: "GPU_UUID", EntityValue: "GPU-1c2d"},
+		}, event.EntitiesImpacted)
+	}
+
+	events, err := handler.ProcessLine("NVRM: GPU 0000:1b:00.0: GSP firmware version 550.54.15 loaded")
+	require.NoError(t, err)
+	assert.Nil(t, events)
+}
+
+// TestXidReportedOnce runs a GSP Xid through the GSP and XID handlers, as the
+// monitor does when both checks are enabled: only the XID handler reports it.
+func TestXidReportedOnce(t *testing.T) {
+	handler := newTestHandler(t)
+
+	xidHandler, err := xid.NewXIDHandler("test-node", "syslog-health-monitor", "GPU", "SysLogsXIDError", "",
+		filepath.Join(t.TempDir(), "missing.json"))
+	require.NoError(t, err)
+
+	line := "kernel: NVRM: Xid (PCI:0000:1b:00): 119, pid=1234, name=python, Timeout after 6s of waiting for " +
+		"RPC response from GPU1 GSP! Expected function 76 (GSP_RM_CONTROL) (0x20800a4c 0x4)."
+
+	require.True(t, handler.Prefilter(line))
+
+	events, err := handler.ProcessLine(line)
+	require.NoError(t, err)
+	assert.Nil(t, events)
+
+	events, err = xidHandler.ProcessLine(line)
+	require.NoError(t, err)
+	require.NotNil(t, events)
+	require.Len(t, events.Events, 1)
+	assert.Equal(t, []string{"119"}, events.Events[0].ErrorCode)
+}
@@ -0,0 +1,74 @@
+// Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gsp
+
+import (
+	"regexp"
+
+	"github.com/nvidia/nvsentinel/health-monitors/syslog-health-monitor/pkg/metadata"
+	"github.com/nvidia/nvsentinel/health-monitors/syslog-health-monitor/pkg/policy"
+)
+
+const (
+	// FirmwareCrashErrorCode is the error code of a GSP firmware that crashed or
+	// stopped responding
+	FirmwareCrashErrorCode = "GSP_FIRMWARE_CRASH"
+	// RPCTimeoutErrorCode is the error code of an RPC the GSP firmware did not
+	// answer in time
+	RPCTimeoutErrorCode = "GSP_RPC_TIMEOUT"
+
+	// nvrmMarker is present in all the driver messages the handler parses
+	nvrmMarker = "NVRM:"
+)
+
+var (
+	// Pattern of the PCI address of the GPU in driver messages, e.g.
+	// "NVRM: GPU at PCI:0000:1b:00: GPU-3a1b..."
+	// "NVRM: GPU 0000:1b:00.0: GSP firmware crashed"
+	rePCIPattern = regexp.MustCompile(
+		`(?:PCI:|NVRM: GPU )([0-9a-fA-F]{4,8}:[0-9a-fA-F]{2}:[0-9a-fA-F]{2}(?:\.[0-7])?)`)
+
+	// Crashes of the GSP firmware, e.g.
+	// "NVRM: GPU 0000:1b:00.0: GSP firmware crashed"
+	// "NVRM: GPU 0000:1b:00.0: GSP heartbeat timed out"
+	// Xid 120, a GSP task exception, is reported by the XID handler.
+	reCrashPattern = regexp.MustCompile(
+		`(?i)GSP(?:-RM| firmware)? (?:has )?crashed|GSP (?:task )?exception|GSP heartbeat timed out`)
+
+	// Timeouts of the RPCs of the driver to the GSP firmware, e.g.
+	// "NVRM: GPU 0000:1b:00.0: GSP RPC timeout"
+	// Xid 119, an RPC timeout the driver attributes to a process, is reported by
+	// the XID handler.
+	reTimeoutPattern = regexp.MustCompile(`(?i)GSP RPC timeout|waiting for RPC response from GPU\d* GSP`)
+)
+
+// GSPHandler processes the crashes and RPC timeouts the NVIDIA driver logs for the
+// GSP firmware of the GPUs.
+type GSPHandler struct {
+	nodeName              string
+	defaultAgentName      string
+	defaultComponentClass string
+	checkName             string
+	metadataReader        *metadata.Reader
+	// policy decides the reported severity and action, nil keeps the built-in ones
+	policy *policy.Policy
+}
+
+// gspErrorEvent represents a parsed GSP crash or RPC timeout
+type gspErrorEvent struct {
+	errorCode string
+	pci       string
+	message   string
+}
@@ -0,0 +1,62 @@
+// Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package notices
+
+import (
+	"strings"
+
+	pb "github.com/nvidia/nvsentinel/data-models/pkg/protos"
+)
+
+// NoticeHandler counts the driver notices of a node. It never produces health
+// events, the notices only give fleet-level visibility in metrics.
+type NoticeHandler struct {
+	nodeName string
+}
+
+// NewNoticeHandler creates a new NoticeHandler instance.
+func NewNoticeHandler(nodeName string) (*NoticeHandler, error) {
+	return &NoticeHandler{nodeName: nodeName}, nil
+}
+
+// ProcessLine counts the line if it is a driver notice. It returns no events.
+func (h *NoticeHandler) ProcessLine(message string) (*pb.HealthEvents, error) {
+	if category := Classify(message); category != "" {
+		driverNoticeCounterMetric.WithLabelValues(h.nodeName, category).Inc()
+	}
+
+	return nil, nil
+}
+
+// Prefilter reports whether the line is a driver message.
+func (h *NoticeHandler) Prefilter(message string) bool {
+	return strings.Contains(message, driverMarker)
+}
+
+// Classify returns the category of a driver notice, empty if the line is not
+// one.
+func Classify(message string) string {
+	if !strings.Contains(message, driverMarker) || reXid.MatchString(message) {
+		return ""
+	}
+
+	for _, category := range categories {
+		if category.re.MatchString(message) {
+			return category.name
+		}
+	}
+
+	return ""
+}
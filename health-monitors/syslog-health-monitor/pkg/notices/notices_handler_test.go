@@ -0,0 +1,83 @@
+// Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package notices
+
+import (
+	"testing"
+
+	dto "github.com/prometheus/client_model/go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClassify(t *testing.T) {
+	tests := []struct {
+		message  string
+		category string
+	}{
+		{"NVRM: GPU at PCI:0000:3b:00: GPU-5b9c1e2a: Clocks throttled due to SW Power Cap", CategoryThrottle},
+		{"NVRM: GPU 0000:3b:00.0: HW Slowdown engaged", CategoryThrottle},
+		{"NVRM: GPU 0000:3b:00.0: power brake asserted", CategoryThrottle},
+		{"NVRM: GPU 0000:3b:00.0: performance state changed from P0 to P8", CategoryPState},
+		{"NVRM: GPU 0000:3b:00.0: PState transition to P2", CategoryPState},
+		{"NVRM: GPU 0000:3b:00.0: ECC memory scrub completed", CategoryECCScrub},
+		{"NVRM: Xid (PCI:0000:3b:00): 79, GPU has fallen off the bus.", ""},
+		{"NVRM: SXid (PCI:0000:05:00): 20034, Fatal, power brake", ""},
+		{"NVRM: loading NVIDIA UNIX x86_64 Kernel Module  550.54.15", ""},
+		{"systemd: clocks throttled", ""},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.category, Classify(tt.message), tt.message)
+	}
+}
+
+func TestProcessLine(t *testing.T) {
+	h, err := NewNoticeHandler("notice-node")
+	require.NoError(t, err)
+
+	lines := []string{
+		"NVRM: GPU 0000:3b:00.0: HW Slowdown engaged",
+		"NVRM: GPU 0000:3b:00.0: HW Slowdown engaged",
+		"NVRM: GPU 0000:3b:00.0: ECC memory scrub completed",
+		"kernel: eth0 link up",
+	}
+
+	for _, line := range lines {
+		if !h.Prefilter(line) {
+			continue
+		}
+
+		events, err := h.ProcessLine(line)
+		require.NoError(t, err)
+		assert.Nil(t, events, "notices must not produce events")
+	}
+
+	assert.Equal(t, 2.0, counterValue(t, "notice-node", CategoryThrottle))
+	assert.Equal(t, 1.0, counterValue(t, "notice-node", CategoryECCScrub))
+	assert.Equal(t, 0.0, counterValue(t, "notice-node", CategoryPState))
+}
+
+func counterValue(t *testing.T, labelValues ...string) float64 {
+	t.Helper()
+
+	counter, err := driverNoticeCounterMetric.GetMetricWithLabelValues(labelValues...)
+	require.NoError(t, err)
+
+	metric := &dto.Metric{}
+	require.NoError(t, counter.Write(metric))
+
+	return metric.Counter.GetValue()
+}
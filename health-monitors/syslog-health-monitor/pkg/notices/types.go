@@ -0,0 +1,52 @@
+// Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package notices classifies benign but interesting informational NVIDIA driver
+// messages, like clock throttle notices, performance state changes and ECC
+// scrubs, and counts them in metrics without publishing health events.
+package notices
+
+import "regexp"
+
+// Categories of driver notices, the category label of the notices metric.
+const (
+	CategoryThrottle = "throttle"
+	CategoryPState   = "pstate"
+	CategoryECCScrub = "ecc_scrub"
+)
+
+// driverMarker is present in every driver message the handler classifies.
+const driverMarker = "NVRM:"
+
+// category matches the driver notices of a category.
+type category struct {
+	name string
+	re   *regexp.Regexp
+}
+
+// categories are tried in order, the first match classifies a notice. Examples:
+//
+//	"NVRM: GPU at PCI:0000:3b:00: GPU-5b9c...: Clocks throttled due to SW Power Cap"
+//	"NVRM: GPU 0000:3b:00.0: performance state changed from P0 to P8"
+//	"NVRM: GPU 0000:3b:00.0: ECC memory scrub completed"
+var categories = []category{
+	{name: CategoryThrottle, re: regexp.MustCompile(`(?i)clocks? (are )?throttled|clock throttl|` +
+		`(hw|sw|thermal) slowdown|power (cap|brake)`)},
+	{name: CategoryPState, re: regexp.MustCompile(`(?i)p-?state|performance state`)},
+	{name: CategoryECCScrub, re: regexp.MustCompile(`(?i)scrub`)},
+}
+
+// reXid matches Xid and SXid reports, which are errors handled by their own
+// checks and never counted as notices.
+var reXid = regexp.MustCompile(`\bS?Xid\b`)
@@ -0,0 +1,153 @@
+// Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package policy
+
+import (
+	"fmt"
+	"os"
+	"slices"
+	"text/template"
+
+	"github.com/BurntSushi/toml"
+
+	pb "github.com/nvidia/nvsentinel/data-models/pkg/protos"
+)
+
+// Severities of a rule.
+const (
+	SeverityFatal    = "fatal"
+	SeverityNonFatal = "non_fatal"
+)
+
+// Config is the event policy configuration file.
+type Config struct {
+	Rules []Rule `toml:"rule"`
+}
+
+// Rule decides the events of the facts it matches. Empty match fields match any
+// fact and empty decision fields keep the handler's built-in decision.
+type Rule struct {
+	// Name identifies the rule in event metadata and metrics
+	Name string `toml:"name"`
+	// Check and ErrorCodes select the facts by check name and error code
+	Check      string   `toml:"check"`
+	ErrorCodes []string `toml:"error_codes"`
+	// Attributes must all equal the attributes extracted by the handler, e.g.
+	// {mnemonic = "MMU Fault"}
+	Attributes map[string]string `toml:"attributes"`
+	// Severity is "fatal" or "non_fatal"
+	Severity          string `toml:"severity"`
+	RecommendedAction string `toml:"recommended_action"`
+	// Message is a text/template rendered with the Fact, e.g.
+	// "XID {{.ErrorCode}} on {{.Attributes.pci}}: {{.Line}}"
+	Message string `toml:"message"`
+
+	fatal   *bool
+	action  *pb.RecommendedAction
+	message *template.Template
+}
+
+// LoadConfig reads and validates the event policy configuration at path.
+func LoadConfig(path string) (*Policy, error) {
+	var config Config
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading event policy config %s: %w", path, err)
+	}
+
+	if _, err := toml.Decode(string(data), &config); err != nil {
+		return nil, fmt.Errorf("decoding event policy config %s: %w", path, err)
+	}
+
+	names := make(map[string]bool, len(config.Rules))
+
+	for i := range config.Rules {
+		rule := &config.Rules[i]
+
+		if err := rule.compile(); err != nil {
+			return nil, fmt.Errorf("event policy config %s: %w", path, err)
+		}
+
+		if names[rule.Name] {
+			return nil, fmt.Errorf("event policy config %s: duplicate rule %q", path, rule.Name)
+		}
+
+		names[rule.Name] = true
+	}
+
+	return New(config.Rules), nil
+}
+
+// compile validates the rule and parses its severity, action and message.
+func (r *Rule) compile() error {
+	if r.Name == "" {
+		return fmt.Errorf("rule name is required")
+	}
+
+	switch r.Severity {
+	case "":
+	case SeverityFatal, SeverityNonFatal:
+		fatal := r.Severity == SeverityFatal
+		r.fatal = &fatal
+	default:
+		return fmt.Errorf("rule %q: severity must be %q or %q, got %q",
+			r.Name, SeverityFatal, SeverityNonFatal, r.Severity)
+	}
+
+	if r.RecommendedAction != "" {
+		value, ok := pb.RecommendedAction_value[r.RecommendedAction]
+		if !ok {
+			return fmt.Errorf("rule %q: unknown recommended action %q", r.Name, r.RecommendedAction)
+		}
+
+		action := pb.RecommendedAction(value)
+		r.action = &action
+	}
+
+	if r.Message != "" {
+		message, err := template.New(r.Name).Option("missingkey=zero").Parse(r.Message)
+		if err != nil {
+			return fmt.Errorf("rule %q: invalid message template: %w", r.Name, err)
+		}
+
+		r.message = message
+	}
+
+	if r.fatal == nil && r.action == nil && r.message == nil {
+		return fmt.Errorf("rule %q: at least one of severity, recommended_action and message is required", r.Name)
+	}
+
+	return nil
+}
+
+// matches reports whether the rule applies to the fact.
+func (r *Rule) matches(fact Fact) bool {
+	if r.Check != "" && r.Check != fact.CheckName {
+		return false
+	}
+
+	if len(r.ErrorCodes) > 0 && !slices.Contains(r.ErrorCodes, fact.ErrorCode) {
+		return false
+	}
+
+	for key, value := range r.Attributes {
+		if fact.Attributes[key] != value {
+			return false
+		}
+	}
+
+	return true
+}
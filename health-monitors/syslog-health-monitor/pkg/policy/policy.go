@@ -0,0 +1,161 @@
+// Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package policy separates what a handler extracted from a log line from how it
+// is reported. Handlers produce a Fact, a structured description of what happened
+// and where, together with their built-in Decision. The policy maps facts to the
+// severity, recommended action and message of the published event, so operators
+// can change actions through configuration without touching the parsing code and
+// a single rule can apply to facts of several checks.
+package policy
+
+import (
+	"bytes"
+	"log/slog"
+	"maps"
+	"slices"
+	"time"
+
+	pb "github.com/nvidia/nvsentinel/data-models/pkg/protos"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// RuleMetadataKey is the event metadata key naming the policy rule that decided
+// the event. It is absent when the handler's built-in decision was kept.
+const RuleMetadataKey = "policy_rule"
+
+// Fact is what a handler extracted from a log line, without severity or action.
+type Fact struct {
+	CheckName string
+	// ErrorCode is empty for facts without one, e.g. a recovery
+	ErrorCode string
+	// Healthy facts report that a component recovered. Rules do not apply to them.
+	Healthy  bool
+	Entities []*pb.Entity
+	// Attributes are the fields parsed from the line, e.g. the XID mnemonic. Rules
+	// match on them and messages can reference them; they are not published.
+	Attributes map[string]string
+	// Metadata is published with the event
+	Metadata map[string]string
+	// Line is the log line the fact was extracted from
+	Line string
+}
+
+// Decision is how a fact is reported.
+type Decision struct {
+	IsFatal           bool
+	RecommendedAction pb.RecommendedAction
+	Message           string
+}
+
+// Source identifies the monitor publishing the events.
+type Source struct {
+	NodeName       string
+	Agent          string
+	ComponentClass string
+}
+
+// Receiver is implemented by handlers whose events are decided by a policy.
+type Receiver interface {
+	SetPolicy(policy *Policy)
+}
+
+// Policy maps facts to decisions with an ordered list of rules. The first rule
+// matching a fact applies. A nil Policy keeps the built-in decisions.
+type Policy struct {
+	rules []Rule
+}
+
+// New returns the policy applying rules in order. The rules must have been
+// validated by LoadConfig.
+func New(rules []Rule) *Policy {
+	return &Policy{rules: rules}
+}
+
+// Decide returns the decision for the fact and the name of the rule that made
+// it, empty when the built-in decision is kept.
+func (p *Policy) Decide(fact Fact, builtin Decision) (Decision, string) {
+	if p == nil || fact.Healthy {
+		return builtin, ""
+	}
+
+	for i := range p.rules {
+		rule := &p.rules[i]
+		if !rule.matches(fact) {
+			continue
+		}
+
+		decision := builtin
+
+		if rule.fatal != nil {
+			decision.IsFatal = *rule.fatal
+		}
+
+		if rule.action != nil {
+			decision.RecommendedAction = *rule.action
+		}
+
+		if rule.message != nil {
+			var message bytes.Buffer
+			if err := rule.message.Execute(&message, fact); err != nil {
+				slog.Error("Error rendering policy message, keeping the built-in message",
+					"rule", rule.Name, "error", err)
+			} else {
+				decision.Message = message.String()
+			}
+		}
+
+		policyDecisionsMetric.WithLabelValues(fact.CheckName, rule.Name).Inc()
+
+		return decision, rule.Name
+	}
+
+	return builtin, ""
+}
+
+// Event returns the health event of the fact as decided by the policy.
+func (p *Policy) Event(source Source, fact Fact, builtin Decision) *pb.HealthEvent {
+	decision, rule := p.Decide(fact, builtin)
+
+	metadata := maps.Clone(fact.Metadata)
+	if rule != "" {
+		if metadata == nil {
+			metadata = make(map[string]string)
+		}
+
+		metadata[RuleMetadataKey] = rule
+	}
+
+	var errorCode []string
+	if fact.ErrorCode != "" {
+		errorCode = []string{fact.ErrorCode}
+	}
+
+	return &pb.HealthEvent{
+		Version:            1,
+		Agent:              source.Agent,
+		CheckName:          fact.CheckName,
+		ComponentClass:     source.ComponentClass,
+		GeneratedTimestamp: timestamppb.New(time.Now()),
+		EntitiesImpacted:   slices.Clone(fact.Entities),
+		Message:            decision.Message,
+		IsFatal:            decision.IsFatal && !fact.Healthy,
+		IsHealthy:          fact.Healthy,
+		NodeName:           source.NodeName,
+		RecommendedAction:  decision.RecommendedAction,
+		ErrorCode:          errorCode,
+		Metadata:           metadata,
+	}
+}
@@ -0,0 +1,132 @@
+// Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package policy
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	pb "github.com/nvidia/nvsentinel/data-models/pkg/protos"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeConfig(t *testing.T, content string) string {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), "policy.toml")
+	require.NoError(t, os.WriteFile(path, []byte(content), 0600))
+
+	return path
+}
+
+func TestLoadConfig(t *testing.T) {
+	_, err := LoadConfig(writeConfig(t, `
+[[rule]]
+name = "mmu-fault-reset"
+check = "SysLogsXIDError"
+error_codes = ["31"]
+attributes = { mnemonic = "MMU Fault" }
+severity = "fatal"
+recommended_action = "COMPONENT_RESET"
+message = "XID {{.ErrorCode}} on {{.Attributes.pci}}"
+`))
+	require.NoError(t, err)
+
+	invalid := map[string]string{
+		"missing name":      "[[rule]]\nseverity = 'fatal'\n",
+		"unknown severity":  "[[rule]]\nname = 'a'\nseverity = 'critical'\n",
+		"unknown action":    "[[rule]]\nname = 'a'\nrecommended_action = 'REBOOT'\n",
+		"invalid message":   "[[rule]]\nname = 'a'\nmessage = '{{.Line'\n",
+		"no decision":       "[[rule]]\nname = 'a'\ncheck = 'SysLogsXIDError'\n",
+		"duplicate rule":    "[[rule]]\nname = 'a'\nseverity = 'fatal'\n[[rule]]\nname = 'a'\nseverity = 'fatal'\n",
+		"invalid toml file": "[[rule]\n",
+	}
+
+	for name, content := range invalid {
+		t.Run(name, func(t *testing.T) {
+			_, err := LoadConfig(writeConfig(t, content))
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestPolicyEvent(t *testing.T) {
+	policy, err := LoadConfig(writeConfig(t, `
+[[rule]]
+name = "mmu-fault-reset"
+check = "SysLogsXIDError"
+attributes = { mnemonic = "MMU Fault" }
+recommended_action = "COMPONENT_RESET"
+message = "XID {{.ErrorCode}} on {{.Attributes.pci}}{{.Attributes.missing}}"
+
+[[rule]]
+name = "xid-non-fatal"
+check = "SysLogsXIDError"
+error_codes = ["31", "43"]
+severity = "non_fatal"
+recommended_action = "NONE"
+`))
+	require.NoError(t, err)
+
+	source := Source{NodeName: "node1", Agent: "syslog-health-monitor", ComponentClass: "GPU"}
+	builtin := Decision{IsFatal: true, RecommendedAction: pb.RecommendedAction_CONTACT_SUPPORT, Message: "line"}
+	fact := Fact{
+		CheckName:  "SysLogsXIDError",
+		ErrorCode:  "31",
+		Entities:   []*pb.Entity{{EntityType: "PCI", EntityValue: "0000:b3:00"}},
+		Attributes: map[string]string{"mnemonic": "MMU Fault", "pci": "0000:b3:00"},
+		Metadata:   map[string]string{"chassis_serial": "1234"},
+		Line:       "line",
+	}
+
+	// The first matching rule applies; fields it does not set keep the built-in decision
+	event := policy.Event(source, fact, builtin)
+	assert.Equal(t, "node1", event.NodeName)
+	assert.Equal(t, "GPU", event.ComponentClass)
+	assert.True(t, event.IsFatal)
+	assert.Equal(t, pb.RecommendedAction_COMPONENT_RESET, event.RecommendedAction)
+	assert.Equal(t, "XID 31 on 0000:b3:00", event.Message)
+	assert.Equal(t, []string{"31"}, event.ErrorCode)
+	assert.Equal(t, map[string]string{"chassis_serial": "1234", RuleMetadataKey: "mmu-fault-reset"}, event.Metadata)
+	assert.Equal(t, map[string]string{"chassis_serial": "1234"}, fact.Metadata)
+
+	fact.Attributes["mnemonic"] = "Graphics Engine Exception"
+	event = policy.Event(source, fact, builtin)
+	assert.False(t, event.IsFatal)
+	assert.Equal(t, pb.RecommendedAction_NONE, event.RecommendedAction)
+	assert.Equal(t, "line", event.Message)
+	assert.Equal(t, "xid-non-fatal", event.Metadata[RuleMetadataKey])
+
+	// Facts matching no rule keep the built-in decision
+	fact.ErrorCode = "79"
+	event = policy.Event(source, fact, builtin)
+	assert.True(t, event.IsFatal)
+	assert.Equal(t, pb.RecommendedAction_CONTACT_SUPPORT, event.RecommendedAction)
+	assert.NotContains(t, event.Metadata, RuleMetadataKey)
+
+	// Rules do not apply to healthy facts
+	healthy := Fact{CheckName: "SysLogsXIDError", ErrorCode: "31", Healthy: true, Line: "recovered"}
+	event = policy.Event(source, healthy, Decision{Message: "recovered"})
+	assert.True(t, event.IsHealthy)
+	assert.False(t, event.IsFatal)
+	assert.Nil(t, event.Metadata)
+
+	// A nil policy keeps the built-in decision
+	var none *Policy
+	event = none.Event(source, fact, builtin)
+	assert.Equal(t, pb.RecommendedAction_CONTACT_SUPPORT, event.RecommendedAction)
+}
@@ -0,0 +1,223 @@
+// Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package rulepack provides GPU SKU specific rule packs: thresholds, known-benign
+// error codes and the expected topology of a node. Packs are TOML data files; the
+// built-in packs are embedded and can be extended or replaced by packs loaded from
+// a directory or distributed by health-events-analyzer, so heterogeneous fleets are
+// served by a single deployment.
+package rulepack
+
+import (
+	"embed"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+
+	"github.com/nvidia/nvsentinel/data-models/pkg/model"
+)
+
+//go:embed packs/*.toml
+var builtinPacks embed.FS
+
+// Pack is the rule pack of a GPU SKU.
+type Pack struct {
+	// Name identifies the pack, e.g. "H100". A pack loaded from a directory
+	// replaces the built-in pack of the same name.
+	Name string `toml:"name"`
+	// Version of the pack, reported in the agent heartbeat so that nodes running an
+	// outdated pack can be detected
+	Version string `toml:"version"`
+	// DeviceNames are case-insensitive substrings of the GPU device name reported by
+	// NVML selecting this pack, e.g. "H100 80GB HBM3".
+	DeviceNames []string `toml:"device_names"`
+	// BenignErrorCodes maps a check name to error codes that are expected on this
+	// SKU and only informational.
+	BenignErrorCodes map[string][]string `toml:"benign_error_codes"`
+	Thresholds       Thresholds          `toml:"thresholds"`
+	Topology         Topology            `toml:"topology"`
+}
+
+// Thresholds overrides the monitor thresholds for the SKU. Zero values keep the
+// configured defaults.
+type Thresholds struct {
+	EventStormPerMinute int `toml:"event_storm_per_minute"`
+}
+
+// Topology is the expected hardware topology of a node. Zero values are not checked.
+type Topology struct {
+	GPUs          int `toml:"gpus"`
+	NVSwitches    int `toml:"nvswitches"`
+	NVLinksPerGPU int `toml:"nvlinks_per_gpu"`
+}
+
+// SchemaVersion is the version of the pack file format this monitor understands.
+// Distributed bundles with a newer schema are not served to it.
+const SchemaVersion = 1
+
+// Load returns the built-in packs together with the packs in dir, sorted by name.
+// An empty dir only returns the built-in packs.
+func Load(dir string) ([]Pack, error) {
+	packs := make(map[string]Pack)
+
+	builtin, err := fs.Glob(builtinPacks, "packs/*.toml")
+	if err != nil {
+		return nil, fmt.Errorf("listing built-in rule packs: %w", err)
+	}
+
+	for _, name := range builtin {
+		data, err := builtinPacks.ReadFile(name)
+		if err != nil {
+			return nil, fmt.Errorf("reading built-in rule pack %s: %w", name, err)
+		}
+
+		if err := decode(packs, path.Base(name), data); err != nil {
+			return nil, err
+		}
+	}
+
+	if dir != "" {
+		files, err := filepath.Glob(filepath.Join(dir, "*.toml"))
+		if err != nil {
+			return nil, fmt.Errorf("listing rule packs in %s: %w", dir, err)
+		}
+
+		for _, file := range files {
+			data, err := os.ReadFile(file)
+			if err != nil {
+				return nil, fmt.Errorf("reading rule pack %s: %w", file, err)
+			}
+
+			if err := decode(packs, file, data); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	return sorted(packs), nil
+}
+
+// Overlay returns base together with the packs decoded from files, which maps
+// file names to their content, sorted by name. A pack in files replaces the pack
+// of the same name in base.
+func Overlay(base []Pack, files map[string][]byte) ([]Pack, error) {
+	packs := make(map[string]Pack, len(base)+len(files))
+	for _, pack := range base {
+		packs[pack.Name] = pack
+	}
+
+	for name, data := range files {
+		if err := decode(packs, name, data); err != nil {
+			return nil, err
+		}
+	}
+
+	return sorted(packs), nil
+}
+
+func sorted(packs map[string]Pack) []Pack {
+	result := make([]Pack, 0, len(packs))
+	for _, pack := range packs {
+		result = append(result, pack)
+	}
+
+	sort.Slice(result, func(i, j int) bool { return result[i].Name < result[j].Name })
+
+	return result
+}
+
+func decode(packs map[string]Pack, source string, data []byte) error {
+	var pack Pack
+	if _, err := toml.Decode(string(data), &pack); err != nil {
+		return fmt.Errorf("decoding rule pack %s: %w", source, err)
+	}
+
+	if pack.Name == "" || len(pack.DeviceNames) == 0 {
+		return fmt.Errorf("rule pack %s: name and device_names are required", source)
+	}
+
+	packs[pack.Name] = pack
+
+	return nil
+}
+
+// Select returns the pack matching the GPUs of the node, or nil when no pack
+// matches. When several packs match, the most specific device name wins, so a
+// "GB200" pack is preferred over a "B200" pack.
+func Select(packs []Pack, metadata *model.GPUMetadata) *Pack {
+	if metadata == nil || len(metadata.GPUs) == 0 {
+		return nil
+	}
+
+	deviceName := strings.ToLower(metadata.GPUs[0].DeviceName)
+
+	var (
+		selected *Pack
+		longest  int
+	)
+
+	for i := range packs {
+		for _, name := range packs[i].DeviceNames {
+			if len(name) > longest && strings.Contains(deviceName, strings.ToLower(name)) {
+				selected = &packs[i]
+				longest = len(name)
+			}
+		}
+	}
+
+	return selected
+}
+
+// IsBenign reports whether errorCode of check is known-benign on the SKU.
+func (p *Pack) IsBenign(check, errorCode string) bool {
+	return p != nil && slices.Contains(p.BenignErrorCodes[check], errorCode)
+}
+
+// CheckTopology compares the node topology against the expected topology and
+// returns a description of every mismatch.
+func (p *Pack) CheckTopology(metadata *model.GPUMetadata) []string {
+	if p == nil || metadata == nil {
+		return nil
+	}
+
+	var mismatches []string
+
+	if p.Topology.GPUs > 0 && len(metadata.GPUs) != p.Topology.GPUs {
+		mismatches = append(mismatches,
+			fmt.Sprintf("expected %d GPUs, found %d", p.Topology.GPUs, len(metadata.GPUs)))
+	}
+
+	if p.Topology.NVSwitches > 0 && len(metadata.NVSwitches) != p.Topology.NVSwitches {
+		mismatches = append(mismatches,
+			fmt.Sprintf("expected %d NVSwitches, found %d", p.Topology.NVSwitches, len(metadata.NVSwitches)))
+	}
+
+	if p.Topology.NVLinksPerGPU > 0 {
+		for _, gpu := range metadata.GPUs {
+			if len(gpu.NVLinks) != p.Topology.NVLinksPerGPU {
+				mismatches = append(mismatches, fmt.Sprintf("expected %d NVLinks on GPU %d, found %d",
+					p.Topology.NVLinksPerGPU, gpu.GPUID, len(gpu.NVLinks)))
+			}
+		}
+	}
+
+	return mismatches
+}
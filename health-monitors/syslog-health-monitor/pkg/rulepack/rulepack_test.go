@@ -0,0 +1,158 @@
+// Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rulepack
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/nvidia/nvsentinel/data-models/pkg/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func gpus(deviceName string, count, nvlinks int) []model.GPUInfo {
+	result := make([]model.GPUInfo, count)
+	for i := range result {
+		result[i] = model.GPUInfo{GPUID: i, DeviceName: deviceName, NVLinks: make([]model.NVLink, nvlinks)}
+	}
+
+	return result
+}
+
+func TestLoad_Builtin(t *testing.T) {
+	packs, err := Load("")
+	require.NoError(t, err)
+
+	names := make([]string, 0, len(packs))
+	for _, pack := range packs {
+		names = append(names, pack.Name)
+	}
+
+	assert.Equal(t, []string{"A100", "GB200", "H100"}, names)
+}
+
+func TestLoad_Directory(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "h100.toml"), []byte(`
+name = "H100"
+device_names = ["H100"]
+
+[benign_error_codes]
+SysLogsXIDError = ["63", "94"]
+`), 0o600))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "l40s.toml"), []byte(`
+name = "L40S"
+device_names = ["L40S"]
+`), 0o600))
+
+	packs, err := Load(dir)
+	require.NoError(t, err)
+	require.Len(t, packs, 4)
+
+	h100 := Select(packs, &model.GPUMetadata{GPUs: gpus("NVIDIA H100 80GB HBM3", 1, 0)})
+	require.NotNil(t, h100)
+	assert.True(t, h100.IsBenign("SysLogsXIDError", "94"), "directory pack must replace the built-in pack")
+	assert.Zero(t, h100.Topology.GPUs)
+
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "broken.toml"), []byte(`name = "Broken"`), 0o600))
+
+	_, err = Load(dir)
+	assert.Error(t, err)
+}
+
+func TestOverlay(t *testing.T) {
+	base, err := Load("")
+	require.NoError(t, err)
+
+	packs, err := Overlay(base, map[string][]byte{
+		"h100.toml": []byte(`
+name = "H100"
+version = "7"
+device_names = ["H100"]
+`),
+	})
+	require.NoError(t, err)
+	require.Len(t, packs, len(base))
+
+	h100 := Select(packs, &model.GPUMetadata{GPUs: gpus("NVIDIA H100 80GB HBM3", 1, 0)})
+	require.NotNil(t, h100)
+	assert.Equal(t, "7", h100.Version)
+
+	_, err = Overlay(base, map[string][]byte{"broken.toml": []byte(`name = "Broken"`)})
+	assert.Error(t, err)
+}
+
+func TestSelect(t *testing.T) {
+	packs := []Pack{
+		{Name: "B200", DeviceNames: []string{"B200"}},
+		{Name: "GB200", DeviceNames: []string{"GB200"}},
+		{Name: "H100", DeviceNames: []string{"H100 80GB HBM3"}},
+	}
+
+	tests := []struct {
+		name       string
+		deviceName string
+		expected   string
+	}{
+		{name: "exact SKU", deviceName: "NVIDIA H100 80GB HBM3", expected: "H100"},
+		{name: "most specific match wins", deviceName: "NVIDIA GB200", expected: "GB200"},
+		{name: "case insensitive", deviceName: "nvidia b200", expected: "B200"},
+		{name: "no match", deviceName: "NVIDIA H100 PCIe"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pack := Select(packs, &model.GPUMetadata{GPUs: gpus(tt.deviceName, 1, 0)})
+			if tt.expected == "" {
+				assert.Nil(t, pack)
+				return
+			}
+
+			require.NotNil(t, pack)
+			assert.Equal(t, tt.expected, pack.Name)
+		})
+	}
+
+	assert.Nil(t, Select(packs, &model.GPUMetadata{}), "no GPUs")
+	assert.Nil(t, Select(packs, nil))
+}
+
+func TestCheckTopology(t *testing.T) {
+	pack := &Pack{Name: "H100", Topology: Topology{GPUs: 8, NVSwitches: 4, NVLinksPerGPU: 18}}
+
+	healthy := &model.GPUMetadata{
+		GPUs:       gpus("NVIDIA H100 80GB HBM3", 8, 18),
+		NVSwitches: []string{"0000:05:00.0", "0000:06:00.0", "0000:07:00.0", "0000:08:00.0"},
+	}
+	assert.Empty(t, pack.CheckTopology(healthy))
+
+	degraded := &model.GPUMetadata{
+		GPUs:       gpus("NVIDIA H100 80GB HBM3", 7, 18),
+		NVSwitches: healthy.NVSwitches[:3],
+	}
+	degraded.GPUs[2].NVLinks = degraded.GPUs[2].NVLinks[:16]
+
+	assert.Equal(t, []string{
+		"expected 8 GPUs, found 7",
+		"expected 4 NVSwitches, found 3",
+		"expected 18 NVLinks on GPU 2, found 16",
+	}, pack.CheckTopology(degraded))
+
+	var none *Pack
+	assert.Empty(t, none.CheckTopology(healthy))
+	assert.False(t, none.IsBenign("SysLogsXIDError", "63"))
+}
@@ -18,13 +18,12 @@ import (
 	"fmt"
 	"log/slog"
 	"strconv"
-	"time"
+	"strings"
 
 	"github.com/nvidia/nvsentinel/data-models/pkg/model"
 	pb "github.com/nvidia/nvsentinel/data-models/pkg/protos"
 	"github.com/nvidia/nvsentinel/health-monitors/syslog-health-monitor/pkg/metadata"
-
-	"google.golang.org/protobuf/types/known/timestamppb"
+	"github.com/nvidia/nvsentinel/health-monitors/syslog-health-monitor/pkg/policy"
 )
 
 func NewSXIDHandler(nodeName, defaultAgentName,
@@ -49,39 +48,76 @@ func (sxidHandler *SXIDHandler) ProcessLine(message string) (*pb.HealthEvents, e
 		return nil, nil
 	}
 
-	gpuID, gpuInfo, err := sxidHandler.getGPUID(sxidErrorEvent.PCI, sxidErrorEvent.Link)
-	if err != nil {
-		slog.Error("Error finding GPU ID",
-			"pci", sxidErrorEvent.PCI,
-			"link", sxidErrorEvent.Link,
-			"error", err.Error())
-
-		return nil, fmt.Errorf(
-			"error in finding GPU ID with PCI %s and NVLink %d: %w",
-			sxidErrorEvent.PCI,
-			sxidErrorEvent.Link,
-			err,
-		)
+	var gpuInfo *model.GPUInfo
+
+	link := ""
+
+	// SXids of the NVSwitch itself affect all of its links, not a single GPU
+	if sxidErrorEvent.Link != noLink {
+		gpuInfo, err = sxidHandler.getGPUInfo(sxidErrorEvent.PCI, sxidErrorEvent.Link)
+		if err != nil {
+			slog.Error("Error finding GPU ID",
+				"pci", sxidErrorEvent.PCI,
+				"link", sxidErrorEvent.Link,
+				"error", err.Error())
+
+			return nil, fmt.Errorf(
+				"error in finding GPU ID with PCI %s and NVLink %d: %w",
+				sxidErrorEvent.PCI,
+				sxidErrorEvent.Link,
+				err,
+			)
+		}
+
+		link = fmt.Sprint(sxidErrorEvent.Link)
 	}
 
 	sxidCounterMetric.WithLabelValues(
 		sxidHandler.nodeName,
 		fmt.Sprint(sxidErrorEvent.ErrorNum),
-		fmt.Sprint(sxidErrorEvent.Link),
+		link,
 		fmt.Sprint(sxidErrorEvent.NVSwitch),
 	).Inc()
 
-	errRes := pb.RecommendedAction_NONE
-	if sxidErrorEvent.IsFatal {
-		errRes = pb.RecommendedAction_CONTACT_SUPPORT
+	fact := sxidHandler.extractFact(sxidErrorEvent, gpuInfo, message)
+
+	source := policy.Source{
+		NodeName:       sxidHandler.nodeName,
+		Agent:          sxidHandler.defaultAgentName,
+		ComponentClass: sxidHandler.defaultComponentClass,
 	}
 
+	return &pb.HealthEvents{
+		Version: 1,
+		Events:  []*pb.HealthEvent{sxidHandler.policy.Event(source, fact, builtinDecision(sxidErrorEvent, message))},
+	}, nil
+}
+
+// SetPolicy sets the event policy deciding the severity and action of SXid facts.
+func (sxidHandler *SXIDHandler) SetPolicy(eventPolicy *policy.Policy) {
+	sxidHandler.policy = eventPolicy
+}
+
+// extractFact returns what the SXid line reports, without severity or action.
+// gpuInfo is the GPU behind the link, nil for SXids of the NVSwitch itself.
+func (sxidHandler *SXIDHandler) extractFact(
+	sxidErrorEvent *sxidErrorEvent,
+	gpuInfo *model.GPUInfo,
+	message string,
+) policy.Fact {
 	entities := []*pb.Entity{
 		{EntityType: "NVSWITCH", EntityValue: strconv.Itoa(sxidErrorEvent.NVSwitch)},
 		{EntityType: "PCI", EntityValue: sxidErrorEvent.PCI},
-		{EntityType: "NVLINK", EntityValue: strconv.Itoa(sxidErrorEvent.Link)},
-		{EntityType: "GPU", EntityValue: strconv.Itoa(gpuID)},
-		{EntityType: "GPU_UUID", EntityValue: gpuInfo.UUID},
+	}
+
+	link, gpu := "", ""
+	if gpuInfo != nil {
+		link, gpu = strconv.Itoa(sxidErrorEvent.Link), strconv.Itoa(gpuInfo.GPUID)
+		entities = append(entities,
+			&pb.Entity{EntityType: "NVLINK", EntityValue: link},
+			&pb.Entity{EntityType: "GPU", EntityValue: gpu},
+			&pb.Entity{EntityType: "GPU_UUID", EntityValue: gpuInfo.UUID},
+		)
 	}
 
 	metadata := make(map[string]string)
@@ -89,32 +125,48 @@ func (sxidHandler *SXIDHandler) ProcessLine(message string) (*pb.HealthEvents, e
 		metadata["chassis_serial"] = *chassisSerial
 	}
 
-	event := &pb.HealthEvent{
-		Version:            1,
-		Agent:              sxidHandler.defaultAgentName,
-		CheckName:          sxidHandler.checkName,
-		ComponentClass:     sxidHandler.defaultComponentClass,
-		GeneratedTimestamp: timestamppb.New(time.Now()),
-		EntitiesImpacted:   entities,
-		Message:            message,
-		IsFatal:            sxidErrorEvent.IsFatal,
-		IsHealthy:          false,
-		NodeName:           sxidHandler.nodeName,
-		RecommendedAction:  errRes,
-		ErrorCode:          []string{fmt.Sprint(sxidErrorEvent.ErrorNum)},
-		Metadata:           metadata,
+	return policy.Fact{
+		CheckName: sxidHandler.checkName,
+		ErrorCode: fmt.Sprint(sxidErrorEvent.ErrorNum),
+		Entities:  entities,
+		Attributes: map[string]string{
+			"fatal":    strconv.FormatBool(sxidErrorEvent.IsFatal),
+			"nvswitch": strconv.Itoa(sxidErrorEvent.NVSwitch),
+			"pci":      sxidErrorEvent.PCI,
+			"link":     link,
+			"gpu":      gpu,
+			"detail":   sxidErrorEvent.Message,
+		},
+		Metadata: metadata,
+		Line:     message,
 	}
+}
 
-	return &pb.HealthEvents{
-		Version: 1,
-		Events:  []*pb.HealthEvent{event},
-	}, nil
+// builtinDecision reports the SXid with the severity printed by the driver, or
+// known for SXids of the NVSwitch itself. Fatal SXids need support, non-fatal ones
+// are informational.
+func builtinDecision(sxidErrorEvent *sxidErrorEvent, message string) policy.Decision {
+	errRes := pb.RecommendedAction_NONE
+	if sxidErrorEvent.IsFatal {
+		errRes = pb.RecommendedAction_CONTACT_SUPPORT
+	}
+
+	return policy.Decision{
+		IsFatal:           sxidErrorEvent.IsFatal,
+		RecommendedAction: errRes,
+		Message:           message,
+	}
+}
+
+// Prefilter reports whether the line may be an SXid message.
+func (sxidHandler *SXIDHandler) Prefilter(message string) bool {
+	return strings.Contains(message, sxidMarker)
 }
 
 func (sxidHandler *SXIDHandler) extractInfoFromNVSwitchErrorMsg(line string) (*sxidErrorEvent, error) {
 	m := reSXIDPattern.FindStringSubmatch(line)
 	if len(m) < 7 {
-		return nil, nil
+		return extractSwitchSXID(line)
 	}
 
 	nvswitch, err := strconv.Atoi(m[1])
@@ -142,14 +194,46 @@ func (sxidHandler *SXIDHandler) extractInfoFromNVSwitchErrorMsg(line string) (*s
 	}, nil
 }
 
-func (sxidHandler *SXIDHandler) getGPUID(
-	pciAddress string,
-	nvlink int,
-) (int, *model.GPUInfo, error) {
+// extractSwitchSXID parses SXids without a link. Lines with a severity are link
+// SXids truncated before the link, and the data the driver prints after an SXid
+// continues it, so neither is reported on its own.
+func extractSwitchSXID(line string) (*sxidErrorEvent, error) {
+	m := reSwitchSXIDPattern.FindStringSubmatch(line)
+	if len(m) < 5 {
+		return nil, nil
+	}
+
+	detail := strings.TrimSpace(m[4])
+	if strings.HasPrefix(detail, "Fatal") || strings.HasPrefix(detail, "Non-fatal") ||
+		strings.HasPrefix(detail, "Data {") || strings.Contains(detail, "data[") {
+		return nil, nil
+	}
+
+	nvswitch, err := strconv.Atoi(m[1])
+	if err != nil {
+		return nil, fmt.Errorf("error converting nvswitch ID to int %s: %w", m[1], err)
+	}
+
+	errorNum, err := strconv.Atoi(m[3])
+	if err != nil {
+		return nil, fmt.Errorf("error converting nvswitch SXID to int %s: %w", m[3], err)
+	}
+
+	return &sxidErrorEvent{
+		NVSwitch: nvswitch,
+		PCI:      m[2],
+		ErrorNum: errorNum,
+		IsFatal:  fatalSwitchSXIDs[errorNum],
+		Link:     noLink,
+		Message:  detail,
+	}, nil
+}
+
+func (sxidHandler *SXIDHandler) getGPUInfo(pciAddress string, nvlink int) (*model.GPUInfo, error) {
 	gpuInfo, _, err := sxidHandler.metadataReader.GetGPUByNVSwitchLink(pciAddress, nvlink)
 	if err != nil {
-		return -1, nil, fmt.Errorf("failed to lookup GPU from metadata: %w", err)
+		return nil, fmt.Errorf("failed to lookup GPU from metadata: %w", err)
 	}
 
-	return gpuInfo.GPUID, gpuInfo, nil
+	return gpuInfo, nil
 }
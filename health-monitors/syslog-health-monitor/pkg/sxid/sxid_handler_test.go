@@ -98,19 +98,43 @@ func TestExtractInfoFromNVSwitchErrorMsg(t *testing.T) {
 			},
 		},
 		{
-			name: "Invalid message 1: Link absent",
+			name: "Switch error: SOE watchdog",
 			line: "nvidia-nvswitch0: SXid (PCI:0004:00:00.0): 26008, SOE Watchdog error",
+			expectedEvent: &sxidErrorEvent{
+				NVSwitch: 0,
+				ErrorNum: 26008,
+				PCI:      "0004:00:00.0",
+				Link:     noLink,
+				Message:  "SOE Watchdog error",
+				IsFatal:  true,
+			},
 		},
 		{
-			name: "Invalid message 2: Link absent",
+			name: "Switch error: SOE halted",
 			line: "nvidia-nvswitch0: SXid (PCI:0004:00:00.0): 26006, SOE HALTED",
+			expectedEvent: &sxidErrorEvent{
+				NVSwitch: 0,
+				ErrorNum: 26006,
+				PCI:      "0004:00:00.0",
+				Link:     noLink,
+				Message:  "SOE HALTED",
+				IsFatal:  true,
+			},
 		},
 		{
-			name: "Invalid message 3: Link absent",
+			name: "Switch error: SOE exception",
 			line: "nvidia-nvswitch0: SXid (PCI:0004:00:00.0): 26007, SOE EXTERR",
+			expectedEvent: &sxidErrorEvent{
+				NVSwitch: 0,
+				ErrorNum: 26007,
+				PCI:      "0004:00:00.0",
+				Link:     noLink,
+				Message:  "SOE EXTERR",
+				IsFatal:  true,
+			},
 		},
 		{
-			name: "Invalid message 4: Link absent",
+			name: "Invalid message 4: Data of a switch error",
 			line: "nvidia-nvswitch0: SXid (PCI:0004:00:00.0): 26006, SOE HALT data[0] = 0x               0",
 		},
 		{
@@ -118,8 +142,20 @@ func TestExtractInfoFromNVSwitchErrorMsg(t *testing.T) {
 			line: "[38889.018130] nvidia-nvswitch1: SXid (PCI:0000:06:00.0): 20009, Non-fatal, Li",
 		},
 		{
-			name: "Invalid message 6: Link absent",
+			name: "Non-fatal switch error",
 			line: "[38889.018130] nvidia-nvswitch0: SXid (PCI:0000:c3:00.0): 12033, Severity 1 Engine instance 00 Sub-engine instance 00",
+			expectedEvent: &sxidErrorEvent{
+				NVSwitch: 0,
+				ErrorNum: 12033,
+				PCI:      "0000:c3:00.0",
+				Link:     noLink,
+				Message:  "Severity 1 Engine instance 00 Sub-engine instance 00",
+				IsFatal:  false,
+			},
+		},
+		{
+			name: "Invalid message 6: Data of an error",
+			line: "[38889.018130] nvidia-nvswitch0: SXid (PCI:0000:c3:00.0): 24007, Data {0x00000000, 0x00000000}",
 		},
 		{
 			name: "Invalid message 7: Link keyword but no link number",
@@ -142,12 +178,14 @@ func TestProcessLine(t *testing.T) {
 		name        string
 		message     string
 		expectEvent bool
+		expectFatal bool
 		expectError bool
 	}{
 		{
-			name:        "Invalid SXID - no Link field",
+			name:        "SXID of the NVSwitch without topology",
 			message:     "nvidia-nvswitch0: SXid (PCI:0004:00:00.0): 26008, SOE Watchdog error",
-			expectEvent: false,
+			expectEvent: true,
+			expectFatal: true,
 			expectError: false,
 		},
 		{
@@ -160,7 +198,7 @@ func TestProcessLine(t *testing.T) {
 			name:        "Valid SXID but topology unavailable",
 			message:     "[123] nvidia-nvswitch3: SXid (PCI:0000:c1:00.0): 28006, Non-fatal, Link 46 MC TS crumbstore MCTO (First)",
 			expectEvent: false,
-			expectError: true, // getGPUID will fail without topology
+			expectError: true, // getGPUInfo will fail without topology
 		},
 	}
 
@@ -181,7 +219,8 @@ func TestProcessLine(t *testing.T) {
 					require.Len(t, events.Events, 1)
 					event := events.Events[0]
 					assert.Equal(t, tc.message, event.Message)
-					assert.NotEmpty(t, event.Metadata)
+					assert.Equal(t, tc.expectFatal, event.IsFatal)
+					assert.Equal(t, "NVSWITCH", event.EntitiesImpacted[0].EntityType)
 				} else {
 					assert.Nil(t, events)
 				}
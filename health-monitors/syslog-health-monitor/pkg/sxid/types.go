@@ -18,19 +18,40 @@ import (
 	"regexp"
 
 	"github.com/nvidia/nvsentinel/health-monitors/syslog-health-monitor/pkg/metadata"
+	"github.com/nvidia/nvsentinel/health-monitors/syslog-health-monitor/pkg/policy"
 )
 
+// sxidMarker is present in every NVSwitch SXid message.
+const sxidMarker = "SXid ("
+
+// noLink is the link of SXids reported for the NVSwitch itself.
+const noLink = -1
+
 var (
 	reSXIDPattern = regexp.MustCompile(
 		`nvidia-nvswitch(\d+): SXid \(PCI:([0-9a-fA-F:.]+)\): (\d+), (Fatal|Non-fatal), Link (\d+) (.+)`)
+	// reSwitchSXIDPattern matches SXids without a link, e.g. failures of the SOE
+	reSwitchSXIDPattern = regexp.MustCompile(
+		`nvidia-nvswitch(\d+): SXid \(PCI:([0-9a-fA-F:.]+)\): (\d+), (.+)`)
 )
 
+// fatalSwitchSXIDs are the SXids without a link that take the NVSwitch out of the
+// fabric: the SOE (System Operations Engine) of the switch halted, raised an
+// exception or stopped responding.
+var fatalSwitchSXIDs = map[int]bool{
+	26006: true,
+	26007: true,
+	26008: true,
+}
+
 type SXIDHandler struct {
 	nodeName              string
 	defaultAgentName      string
 	defaultComponentClass string
 	checkName             string
 	metadataReader        *metadata.Reader
+	// policy decides the reported severity and action, nil keeps the built-in ones
+	policy *policy.Policy
 }
 
 type sxidErrorEvent struct {
@@ -0,0 +1,200 @@
+// Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package syslogmonitor
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/nvidia/nvsentinel/data-models/pkg/compression"
+	pb "github.com/nvidia/nvsentinel/data-models/pkg/protos"
+
+	"google.golang.org/grpc"
+)
+
+// errBatchFlush aborts the current check run when a full batch cannot be sent,
+// so that the run resumes from the last cursor whose events were delivered.
+var errBatchFlush = errors.New("failed to flush health event batch")
+
+// EnableBatching makes the monitor send up to size health events per gRPC call
+// instead of one call per event. Pending events are flushed when the batch is
+// full and at the end of every check run. The persisted cursor only advances
+// past a line once its events have been delivered. A size of 1 or less
+// disables batching.
+func (sm *SyslogMonitor) EnableBatching(size int) {
+	sm.batchSize = size
+}
+
+// EnableCompression compresses health events sent to the platform connector
+// with the named compressor (none, gzip or zstd). If the platform connector
+// does not support it, the monitor falls back to uncompressed payloads.
+func (sm *SyslogMonitor) EnableCompression(name string) error {
+	compressor, err := compression.Validate(name)
+	if err != nil {
+		return err
+	}
+
+	sm.compressorMu.Lock()
+	sm.compressor = compressor
+	sm.compressorMu.Unlock()
+
+	return nil
+}
+
+// callOptions returns the gRPC call options for sending health events.
+func (sm *SyslogMonitor) callOptions() []grpc.CallOption {
+	sm.compressorMu.RLock()
+	defer sm.compressorMu.RUnlock()
+
+	if sm.compressor == "" || sm.compressor == compression.None {
+		return nil
+	}
+
+	return []grpc.CallOption{grpc.UseCompressor(sm.compressor)}
+}
+
+// disableCompression switches to uncompressed payloads after the platform
+// connector rejected the negotiated compressor.
+func (sm *SyslogMonitor) disableCompression(err error) {
+	sm.compressorMu.Lock()
+	defer sm.compressorMu.Unlock()
+
+	slog.Warn("Platform connector does not accept compressed payloads, falling back to uncompressed",
+		"compressor", sm.compressor, "error", err)
+
+	sm.compressor = compression.None
+}
+
+// assignPriority marks fatal events as PRIORITY_HIGH and reports whether any
+// event in healthEvents is high priority.
+func assignPriority(healthEvents *pb.HealthEvents) bool {
+	highPriority := false
+
+	for _, event := range healthEvents.Events {
+		if event.IsFatal {
+			event.Priority = pb.Priority_PRIORITY_HIGH
+		}
+
+		if event.Priority == pb.Priority_PRIORITY_HIGH {
+			highPriority = true
+		}
+	}
+
+	return highPriority
+}
+
+// eventBatch holds the events of a check waiting to be sent, and the cursor
+// held back until they are delivered. A batch is only used by the worker running
+// its check.
+type eventBatch struct {
+	events []*pb.HealthEvent
+	cursor string
+}
+
+// batch returns the pending batch of checkName.
+func (sm *SyslogMonitor) batch(checkName string) *eventBatch {
+	sm.mu.Lock()
+	defer sm.mu.Unlock()
+
+	if sm.batches == nil {
+		sm.batches = make(map[string]*eventBatch)
+	}
+
+	batch, ok := sm.batches[checkName]
+	if !ok {
+		batch = &eventBatch{}
+		sm.batches[checkName] = batch
+	}
+
+	return batch
+}
+
+// emit sends the health events of at least the minimum shipped severity right
+// away, or adds them to the pending batch of the check when batching is enabled.
+// High priority events take the fast lane and are sent immediately, right after
+// the pending batch of the check, so that an earlier healthy event of the same
+// entity cannot arrive after them and clear the condition they set.
+func (sm *SyslogMonitor) emit(checkName string, healthEvents *pb.HealthEvents) error {
+	healthEvents = sm.shippedEvents(checkName, healthEvents)
+	if healthEvents == nil {
+		return nil
+	}
+
+	if assignPriority(healthEvents) {
+		if err := sm.flushBatch(checkName); err != nil {
+			return fmt.Errorf("%w: %w", errBatchFlush, err)
+		}
+
+		return sm.sendHealthEventWithRetry(healthEvents, 5, 2*time.Second)
+	}
+
+	if sm.batchSize <= 1 {
+		return sm.sendHealthEventWithRetry(healthEvents, 5, 2*time.Second)
+	}
+
+	batch := sm.batch(checkName)
+
+	batch.events = append(batch.events, healthEvents.Events...)
+	if len(batch.events) < sm.batchSize {
+		return nil
+	}
+
+	if err := sm.flushBatch(checkName); err != nil {
+		return fmt.Errorf("%w: %w", errBatchFlush, err)
+	}
+
+	return nil
+}
+
+// advanceCursor records cursor as processed for checkName. While events are
+// waiting in the batch the cursor is held back until the batch is flushed.
+func (sm *SyslogMonitor) advanceCursor(checkName, cursor string) {
+	if batch := sm.batch(checkName); len(batch.events) > 0 {
+		batch.cursor = cursor
+		return
+	}
+
+	sm.setLastCursor(checkName, cursor)
+}
+
+// flushBatch sends all pending events of checkName in a single call and commits
+// the held back cursor. On failure the pending events and cursor are dropped;
+// the corresponding journal entries are processed again on the next run.
+func (sm *SyslogMonitor) flushBatch(checkName string) error {
+	pending := sm.batch(checkName)
+	if len(pending.events) == 0 {
+		return nil
+	}
+
+	batch := &pb.HealthEvents{Version: 1, Events: pending.events}
+	pendingCursor := pending.cursor
+
+	pending.events = nil
+	pending.cursor = ""
+
+	batchSize.Observe(float64(len(batch.Events)))
+
+	if err := sm.sendHealthEventWithRetry(batch, 5, 2*time.Second); err != nil {
+		return err
+	}
+
+	if pendingCursor != "" {
+		sm.setLastCursor(checkName, pendingCursor)
+	}
+
+	return nil
+}
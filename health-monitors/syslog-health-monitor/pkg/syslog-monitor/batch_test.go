@@ -0,0 +1,203 @@
+// Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package syslogmonitor
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/nvidia/nvsentinel/data-models/pkg/compression"
+	pb "github.com/nvidia/nvsentinel/data-models/pkg/protos"
+	"github.com/nvidia/nvsentinel/health-monitors/syslog-health-monitor/pkg/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+// compressionRejectingClient fails calls that carry call options (i.e. a
+// compressor) with Unimplemented, like a platform connector without the
+// compressor registered.
+type compressionRejectingClient struct {
+	calls      int
+	compressed int
+	recorded   []*pb.HealthEvents
+}
+
+func (c *compressionRejectingClient) HealthEventOccurredV1(_ context.Context, events *pb.HealthEvents,
+	opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	c.calls++
+
+	if len(opts) > 0 {
+		c.compressed++
+		return nil, status.Error(codes.Unimplemented, "grpc: Decompressor is not installed for grpc-encoding \"zstd\"")
+	}
+
+	c.recorded = append(c.recorded, events)
+
+	return &emptypb.Empty{}, nil
+}
+
+// nonFatalHandler behaves like mockHandler but emits non-fatal events, which
+// are eligible for batching.
+type nonFatalHandler struct {
+	mockHandler
+}
+
+func (h *nonFatalHandler) ProcessLine(message string) (*pb.HealthEvents, error) {
+	healthEvents, err := h.mockHandler.ProcessLine(message)
+	if healthEvents != nil {
+		for _, event := range healthEvents.Events {
+			event.IsFatal = false
+		}
+	}
+
+	return healthEvents, err
+}
+
+func newBatchTestMonitor(pcClient pb.PlatformConnectorClient) (*SyslogMonitor, CheckDefinition) {
+	check := CheckDefinition{Name: "mockCheck"}
+
+	return &SyslogMonitor{
+		nodeName:         TEST_NODE,
+		pcClient:         pcClient,
+		checkLastCursors: map[string]string{},
+		checkToHandlerMap: map[string]types.Handler{
+			check.Name: &nonFatalHandler{mockHandler{nodeName: TEST_NODE, checkName: check.Name}},
+		},
+	}, check
+}
+
+func TestBatchingHoldsCursorUntilFlush(t *testing.T) {
+	pcClient := &mockPlatformConnectorClient{}
+	sm, check := newBatchTestMonitor(pcClient)
+	sm.EnableBatching(3)
+
+	for i, cursor := range []string{"c1", "c2"} {
+		require.NoError(t, sm.handleSingleLine(check, "sxid123"))
+		sm.advanceCursor(check.Name, cursor)
+
+		assert.Empty(t, pcClient.RecordedHealthEvents, "batch %d should not be sent yet", i)
+		assert.Empty(t, sm.checkLastCursors[check.Name], "cursor must be held back while events are pending")
+	}
+
+	// A line without events still only moves the held back cursor.
+	require.NoError(t, sm.handleSingleLine(check, "no match"))
+	sm.advanceCursor(check.Name, "c3")
+	assert.Empty(t, sm.checkLastCursors[check.Name])
+
+	// The third event fills the batch and sends it in a single call.
+	require.NoError(t, sm.handleSingleLine(check, "sxid123"))
+	require.Len(t, pcClient.RecordedHealthEvents, 1)
+	assert.Len(t, pcClient.RecordedHealthEvents[0].Events, 3)
+	assert.Equal(t, "c3", sm.checkLastCursors[check.Name])
+
+	sm.advanceCursor(check.Name, "c4")
+	assert.Equal(t, "c4", sm.checkLastCursors[check.Name])
+
+	// The remaining event is flushed at the end of the run.
+	require.NoError(t, sm.handleSingleLine(check, "sxid123"))
+	sm.advanceCursor(check.Name, "c5")
+	require.NoError(t, sm.flushBatch(check.Name))
+	require.Len(t, pcClient.RecordedHealthEvents, 2)
+	assert.Len(t, pcClient.RecordedHealthEvents[1].Events, 1)
+	assert.Equal(t, "c5", sm.checkLastCursors[check.Name])
+}
+
+func TestFlushBatchFailureKeepsCursor(t *testing.T) {
+	sm, check := newBatchTestMonitor(&failingPlatformConnectorClient{})
+	sm.EnableBatching(2)
+	sm.checkLastCursors[check.Name] = "c0"
+
+	require.NoError(t, sm.handleSingleLine(check, "sxid123"))
+	sm.advanceCursor(check.Name, "c1")
+
+	err := sm.handleSingleLine(check, "sxid123")
+	require.Error(t, err)
+	assert.True(t, errors.Is(err, errBatchFlush))
+	assert.Equal(t, "c0", sm.checkLastCursors[check.Name], "cursor must not advance past undelivered events")
+	assert.Empty(t, sm.batch(check.Name).events)
+}
+
+type failingPlatformConnectorClient struct{}
+
+func (failingPlatformConnectorClient) HealthEventOccurredV1(context.Context, *pb.HealthEvents,
+	...grpc.CallOption) (*emptypb.Empty, error) {
+	return nil, status.Error(codes.InvalidArgument, "rejected")
+}
+
+func TestCompressionFallback(t *testing.T) {
+	pcClient := &compressionRejectingClient{}
+	sm, check := newBatchTestMonitor(pcClient)
+	require.NoError(t, sm.EnableCompression(compression.Zstd))
+
+	require.NoError(t, sm.handleSingleLine(check, "sxid123"))
+	assert.Equal(t, 1, pcClient.compressed, "first attempt should use the compressor")
+	assert.Len(t, pcClient.recorded, 1, "event should be delivered uncompressed after fallback")
+	assert.Equal(t, compression.None, sm.compressor)
+
+	require.NoError(t, sm.handleSingleLine(check, "sxid123"))
+	assert.Equal(t, 1, pcClient.compressed, "compression stays disabled after fallback")
+}
+
+func TestEnableCompressionInvalid(t *testing.T) {
+	sm := &SyslogMonitor{}
+	assert.Error(t, sm.EnableCompression("lz4"))
+	assert.NoError(t, sm.EnableCompression(""))
+	assert.Empty(t, sm.callOptions())
+}
+
+func TestFatalEventsBypassBatch(t *testing.T) {
+	pcClient := &mockPlatformConnectorClient{}
+	sm, check := newBatchTestMonitor(pcClient)
+	sm.checkToHandlerMap[check.Name] = &mockHandler{nodeName: TEST_NODE, checkName: check.Name}
+	sm.EnableBatching(10)
+
+	// mockHandler produces fatal events, which must not wait in the batch.
+	require.NoError(t, sm.handleSingleLine(check, "sxid123"))
+	require.Len(t, pcClient.RecordedHealthEvents, 1)
+
+	event := pcClient.RecordedHealthEvents[0].Events[0]
+	assert.Equal(t, pb.Priority_PRIORITY_HIGH, event.Priority)
+	assert.Empty(t, sm.batch(check.Name).events)
+
+	info := &pb.HealthEvents{Events: []*pb.HealthEvent{{CheckName: "info", IsHealthy: true}}}
+	require.NoError(t, sm.emit(check.Name, info))
+	assert.Len(t, pcClient.RecordedHealthEvents, 1, "non-fatal events are batched")
+	assert.Equal(t, pb.Priority_PRIORITY_NORMAL, info.Events[0].Priority)
+}
+
+func TestFatalEventFlushesEarlierBatch(t *testing.T) {
+	pcClient := &mockPlatformConnectorClient{}
+	sm, check := newBatchTestMonitor(pcClient)
+	sm.EnableBatching(10)
+
+	healthy := &pb.HealthEvents{Events: []*pb.HealthEvent{{CheckName: check.Name, IsHealthy: true}}}
+	require.NoError(t, sm.emit(check.Name, healthy))
+	sm.advanceCursor(check.Name, "c1")
+	assert.Empty(t, pcClient.RecordedHealthEvents, "the healthy event waits in the batch")
+
+	fatal := &pb.HealthEvents{Events: []*pb.HealthEvent{{CheckName: check.Name, IsFatal: true}}}
+	require.NoError(t, sm.emit(check.Name, fatal))
+
+	require.Len(t, pcClient.RecordedHealthEvents, 2)
+	assert.True(t, pcClient.RecordedHealthEvents[0].Events[0].IsHealthy, "the earlier healthy event is sent first")
+	assert.True(t, pcClient.RecordedHealthEvents[1].Events[0].IsFatal)
+	assert.Empty(t, sm.batch(check.Name).events)
+	assert.Equal(t, "c1", sm.checkLastCursors[check.Name])
+}
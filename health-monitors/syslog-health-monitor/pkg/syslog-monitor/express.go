@@ -0,0 +1,209 @@
+// Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package syslogmonitor
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+)
+
+// DefaultExpressInterval is how often the journal is scanned for catastrophic lines
+const DefaultExpressInterval = time.Second
+
+// expressChecks are the checks reporting the catastrophic lines. The watcher
+// follows the journal of the first one enabled.
+var expressChecks = []string{XIDErrorCheck, GPUFallenOffCheck, ECCCheck, SXIDErrorCheck}
+
+// expressPatterns is the allowlist of catastrophic lines taking the express path,
+// by name. They leave a GPU or an NVSwitch unusable, so every second until the
+// node is quarantined risks the jobs scheduled on it.
+var expressPatterns = []struct {
+	name string
+	re   *regexp.Regexp
+}{
+	// "NVRM: Xid (PCI:0000:b3:00): 79, pid=1234, name=python, GPU has fallen off the bus."
+	// "NVRM: The NVIDIA GPU 0000:b3:00.0 ... has fallen off the bus and is not responding to commands."
+	{"gpu_fallen_off_bus", regexp.MustCompile(`NVRM: Xid \(PCI:[^)]*\): 79,|NVRM: .*fallen off the bus`)},
+	// "NVRM: Xid (PCI:0000:3b:00): 48, pid=1234, name=python, An uncorrectable double bit error (DBE) ..."
+	{"double_bit_ecc", regexp.MustCompile(`NVRM: Xid \(PCI:[^)]*\): 48,|NVRM: .*(?i:double[- ]bit (?:ECC )?error)`)},
+	// "nvidia-nvswitch3: SXid (PCI:0000:c1:00.0): 24007, Fatal, Link 44 sourcetrack TCEN0 crumbstore ECC DBE Error"
+	{"fatal_sxid", regexp.MustCompile(`SXid \(PCI:[^)]*\): \d+, Fatal\b`)},
+}
+
+// expressLine returns the name of the catastrophic pattern the line matches, ""
+// for the vast majority of lines.
+func expressLine(line string) string {
+	if !strings.Contains(line, "NVRM:") && !strings.Contains(line, "SXid") {
+		return ""
+	}
+
+	for _, pattern := range expressPatterns {
+		if pattern.re.MatchString(line) {
+			return pattern.name
+		}
+	}
+
+	return ""
+}
+
+// EnableExpressPath scans the journal for catastrophic lines every interval
+// between the polling runs, see WatchExpress. 0 disables the express path.
+func (sm *SyslogMonitor) EnableExpressPath(interval time.Duration) {
+	sm.expressInterval = interval
+}
+
+// WatchExpress scans the new kernel lines every express interval and calls
+// trigger when one matches the allowlist of catastrophic patterns, so that a run
+// reports it within seconds instead of at the next polling interval. Matching
+// lines are then neither throttled nor suppressed by the event storm breaker,
+// and their fatal events skip batching. The journal is opened once and reopened
+// only after an error. It returns when ctx is done.
+func (sm *SyslogMonitor) WatchExpress(ctx context.Context, trigger func()) error {
+	if sm.expressInterval <= 0 {
+		return nil
+	}
+
+	index := slices.IndexFunc(sm.checks, func(check CheckDefinition) bool {
+		return slices.Contains(expressChecks, check.Name) && check.JournalPath != ""
+	})
+	if index < 0 {
+		slog.Info("No check reports catastrophic lines, express path disabled")
+		return nil
+	}
+
+	scanner := &expressScanner{sm: sm, check: sm.checks[index]}
+	ticker := time.NewTicker(sm.expressInterval)
+
+	defer ticker.Stop()
+	defer scanner.close()
+
+	slog.Info("Express path enabled", "check", scanner.check.Name, "interval", sm.expressInterval)
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+
+		pattern, err := scanner.scan()
+		if err != nil {
+			slog.Warn("Failed to scan the journal for catastrophic lines", "check", scanner.check.Name, "error", err)
+			scanner.close()
+
+			continue
+		}
+
+		if pattern != "" {
+			slog.Info("Catastrophic line detected, triggering an express run", "pattern", pattern)
+			expressTriggers.WithLabelValues(pattern).Inc()
+			trigger()
+		}
+	}
+}
+
+// expressScanner reads the journal of a check for catastrophic lines, keeping
+// the journal open between scans.
+type expressScanner struct {
+	sm    *SyslogMonitor
+	check CheckDefinition
+	// journal is nil until the first scan and after an error
+	journal Journal
+	// cursor is the last entry scanned, "" until the first run of the check
+	cursor string
+}
+
+// scan reads the entries after the last one scanned, or after the cursor of the
+// check when the scanner has not scanned yet. It returns the pattern of the first
+// catastrophic line found.
+func (s *expressScanner) scan() (string, error) {
+	if s.cursor == "" {
+		s.cursor = s.sm.nextCursor(s.check.Name)
+	}
+
+	if s.cursor == "" {
+		// The first run of the check positions the journal
+		return "", nil
+	}
+
+	if s.journal == nil {
+		journal, err := s.sm.openJournal(s.check)
+		if err != nil {
+			return "", err
+		}
+
+		s.journal = journal
+
+		if err := s.sm.configureTagFilters(journal, s.check); err != nil {
+			return "", err
+		}
+	}
+
+	if err := s.journal.SeekCursor(s.cursor); err != nil {
+		cursor := s.cursor
+		s.cursor = ""
+
+		return "", fmt.Errorf("failed to seek to cursor %s: %w", cursor, err)
+	}
+
+	pattern := ""
+
+	for {
+		advanced, err := s.journal.Next()
+		if errors.Is(err, io.EOF) || (err == nil && advanced == 0) {
+			return pattern, nil
+		}
+
+		if err != nil {
+			return pattern, fmt.Errorf("failed to read the next journal entry: %w", err)
+		}
+
+		next, err := s.journal.GetCursor()
+		if err != nil {
+			return pattern, fmt.Errorf("failed to get the journal cursor: %w", err)
+		}
+
+		s.cursor = next
+
+		message, err := s.journal.GetData(FieldMessage)
+		if err != nil {
+			continue
+		}
+
+		if name := expressLine(message); name != "" && pattern == "" {
+			pattern = name
+		}
+	}
+}
+
+// close closes the journal, which the next scan reopens.
+func (s *expressScanner) close() {
+	if s.journal == nil {
+		return
+	}
+
+	if err := s.journal.Close(); err != nil {
+		slog.Warn("Error closing journal of the express path", "error", err)
+	}
+
+	s.journal = nil
+}
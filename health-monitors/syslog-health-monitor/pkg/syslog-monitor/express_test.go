@@ -0,0 +1,161 @@
+// Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package syslogmonitor
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/nvidia/nvsentinel/health-monitors/syslog-health-monitor/pkg/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExpressLine(t *testing.T) {
+	testCases := map[string]string{
+		"kernel: NVRM: Xid (PCI:0000:b3:00): 79, pid=1234, name=python, GPU has fallen off the bus.": "gpu_fallen_off_bus",
+		"NVRM: The NVIDIA GPU 0000:b3:00.0 (PCI ID: 10de:26b5) installed in this system has fallen off the bus " +
+			"and is not responding to commands.": "gpu_fallen_off_bus",
+		"NVRM: Xid (PCI:0000:3b:00): 48, pid=1234, name=python, An uncorrectable double bit error (DBE) " +
+			"has been detected on GPU in the framebuffer at partition 6, subpartition 0.": "double_bit_ecc",
+		"nvidia-nvswitch3: SXid (PCI:0000:c1:00.0): 24007, Fatal, Link 44 sourcetrack TCEN0 crumbstore " +
+			"ECC DBE Error": "fatal_sxid",
+		"nvidia-nvswitch3: SXid (PCI:0000:c1:00.0): 20009, Non-fatal, Link 32 RX Short Error Rate": "",
+		"NVRM: Xid (PCI:0000:b3:00): 13, pid=1234, name=python, Graphics Exception":                "",
+		"NVRM: Xid (PCI:0000:b3:00): 479, pid=1234, name=python, unknown":                          "",
+		"kubelet: pod fallen off the bus in test name":                                             "",
+	}
+
+	for line, expected := range testCases {
+		assert.Equal(t, expected, expressLine(line), line)
+	}
+}
+
+func newExpressTestMonitor(journal *FakeJournal) *SyslogMonitor {
+	factory := NewFakeJournalFactory()
+	factory.AddJournal("/journal", journal)
+
+	return &SyslogMonitor{
+		checks:           []CheckDefinition{{Name: XIDErrorCheck, JournalPath: "/journal"}},
+		checkLastCursors: map[string]string{XIDErrorCheck: "c1"},
+		journalFactory:   factory,
+	}
+}
+
+func TestScanExpress(t *testing.T) {
+	journal := PrepareFakeJournalWithEntries([]string{
+		"NVRM: Xid (PCI:0000:b3:00): 79, pid=1234, name=python, GPU has fallen off the bus.",
+		"NVRM: Xid (PCI:0000:b3:00): 13, pid=1234, name=python, Graphics Exception",
+	})
+	sm := newExpressTestMonitor(journal)
+	sm.checkLastCursors[XIDErrorCheck] = journal.Entries[0].Cursor
+	scanner := &expressScanner{sm: sm, check: sm.checks[0]}
+
+	pattern, err := scanner.scan()
+	require.NoError(t, err)
+	assert.Empty(t, pattern, "lines read by a run already are not scanned")
+	assert.Equal(t, journal.Entries[1].Cursor, scanner.cursor)
+
+	journal.AddEntryWithMessage("NVRM: Xid (PCI:0000:3b:00): 48, pid=1234, name=python, "+
+		"An uncorrectable double bit error (DBE) has been detected on GPU", "c-dbe")
+	journal.AddEntryWithMessage("systemd[1]: Started Session 42 of User root.", "c-noise")
+
+	pattern, err = scanner.scan()
+	require.NoError(t, err)
+	assert.Equal(t, "double_bit_ecc", pattern)
+	assert.Equal(t, "c-noise", scanner.cursor)
+	assert.False(t, journal.Closed, "the journal stays open between scans")
+
+	pattern, err = scanner.scan()
+	require.NoError(t, err)
+	assert.Empty(t, pattern, "a line triggers once")
+
+	scanner.close()
+	assert.True(t, journal.Closed)
+	assert.Nil(t, scanner.journal, "the next scan reopens the journal")
+
+	_, err = (&expressScanner{sm: sm, check: sm.checks[0], cursor: "unknown"}).scan()
+	require.Error(t, err)
+
+	sm.checkLastCursors = map[string]string{}
+	scanner = &expressScanner{sm: sm, check: sm.checks[0]}
+	pattern, err = scanner.scan()
+	require.NoError(t, err)
+	assert.Empty(t, pattern)
+	assert.Empty(t, scanner.cursor, "the first run of the check positions the journal")
+}
+
+func TestWatchExpress(t *testing.T) {
+	journal := PrepareFakeJournalWithEntries([]string{
+		"systemd[1]: Started Session 42 of User root.",
+		"nvidia-nvswitch3: SXid (PCI:0000:c1:00.0): 24007, Fatal, Link 44 sourcetrack TCEN0 crumbstore ECC DBE Error",
+	})
+	sm := newExpressTestMonitor(journal)
+	sm.checkLastCursors[XIDErrorCheck] = journal.Entries[0].Cursor
+	sm.EnableExpressPath(time.Millisecond)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	triggered := make(chan struct{})
+
+	go func() {
+		defer close(triggered)
+		require.NoError(t, sm.WatchExpress(ctx, cancel))
+	}()
+
+	select {
+	case <-triggered:
+	case <-time.After(5 * time.Second):
+		cancel()
+		t.Fatal("the fatal SXid did not trigger a run")
+	}
+
+	t.Run("disabled", func(t *testing.T) {
+		sm.EnableExpressPath(0)
+		require.NoError(t, sm.WatchExpress(context.Background(), func() { t.Error("unexpected trigger") }))
+	})
+}
+
+func TestHandleLineExpressBypassesEventStorm(t *testing.T) {
+	pcClient := &mockPlatformConnectorClient{}
+	check := CheckDefinition{Name: "mockCheck"}
+	sm := &SyslogMonitor{
+		nodeName: TEST_NODE,
+		pcClient: pcClient,
+		checkToHandlerMap: map[string]types.Handler{
+			check.Name: &mockHandler{nodeName: TEST_NODE, checkName: check.Name, nonFatal: true},
+		},
+	}
+
+	sm.EnableEventStormBreaker(1, 1, time.Minute)
+
+	var backoffs []time.Duration
+
+	sm.stormBreaker.sleep = func(d time.Duration) { backoffs = append(backoffs, d) }
+
+	require.NoError(t, sm.handleSingleLine(check, "sxid123"))
+	require.NoError(t, sm.handleSingleLine(check, "sxid123"))
+	require.True(t, sm.stormBreaker.suppress(0), "the breaker is open")
+	assert.Empty(t, backoffs, "processing only backs off during a storm")
+
+	sent := len(pcClient.RecordedHealthEvents)
+
+	require.NoError(t, sm.handleSingleLine(check, "sxid123"))
+	assert.Len(t, pcClient.RecordedHealthEvents, sent, "regular lines are suppressed")
+	assert.Equal(t, []time.Duration{eventStormBackoff}, backoffs, "regular lines back off")
+
+	require.NoError(t, sm.handleSingleLine(check, "NVRM: Xid (PCI:0000:b3:00): 79, sxid123"))
+	assert.Len(t, backoffs, 1, "catastrophic lines do not back off")
+}
@@ -21,8 +21,9 @@ import (
 
 // FakeJournalEntry represents a single entry in a fake journal
 type FakeJournalEntry struct {
-	Fields map[string]string
-	Cursor string
+	Fields   map[string]string
+	Cursor   string
+	Realtime uint64
 }
 
 // FakeJournal is a test implementation of the Journal interface
@@ -110,6 +111,16 @@ func (j *FakeJournal) GetData(field string) (string, error) {
 	return value, nil
 }
 
+// GetRealtimeUsec implements the Journal interface
+func (j *FakeJournal) GetRealtimeUsec() (uint64, error) {
+	// Allow even if closed for test purposes
+	if j.CurrentPosition < 0 || j.CurrentPosition >= len(j.Entries) {
+		return 0, fmt.Errorf("invalid cursor position")
+	}
+
+	return j.Entries[j.CurrentPosition].Realtime, nil
+}
+
 // Next implements the Journal interface
 func (j *FakeJournal) Next() (uint64, error) {
 	// Allow even if closed for test purposes
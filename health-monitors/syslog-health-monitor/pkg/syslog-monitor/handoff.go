@@ -0,0 +1,208 @@
+// Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package syslogmonitor
+
+import (
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+const (
+	// Version of the handoff file format written on shutdown
+	handoffFormatVersion = 1
+	// Oldest handoff file format that can be adopted
+	minHandoffFormatVersion = 1
+	// Age beyond which a handoff is not adopted. The replacing pod of a rolling
+	// update starts within seconds; an older handoff was left by a pod that was
+	// stopped, and the state file is as recent as it.
+	handoffMaxAge = 10 * time.Minute
+)
+
+// handoff is the state the outgoing pod of a rolling update hands to the pod
+// replacing it on the node. Unlike the state file, which is saved at the end of
+// every run, it is written after the last run and the last batch were delivered,
+// so the incoming pod resumes exactly where the outgoing one stopped.
+type handoff struct {
+	FormatVersion int `json:"format_version"`
+	// MonitorVersion is the version of the monitor that wrote the handoff
+	MonitorVersion string             `json:"monitor_version"`
+	NodeName       string             `json:"node_name"`
+	WrittenAt      time.Time          `json:"written_at"`
+	State          syslogMonitorState `json:"state"`
+}
+
+// handoffPath returns the path of the handoff file, next to the state file on the
+// node-local state volume.
+func handoffPath(stateFilePath string) string {
+	return stateFilePath + ".handoff"
+}
+
+// Handoff flushes the pending events of every check and writes the state of the
+// monitor for the pod replacing it. It must be called once the checks stopped
+// running, on shutdown.
+func (sm *SyslogMonitor) Handoff(monitorVersion string) error {
+	for _, check := range sm.checks {
+		if err := sm.flushBatch(check.Name); err != nil {
+			// The held back cursor is dropped with the batch, the lines are processed again
+			slog.Warn("Failed to flush pending events before the handoff", "check", check.Name, "error", err)
+		}
+	}
+
+	if err := sm.saveCurrentState(); err != nil {
+		return fmt.Errorf("failed to save state before the handoff: %w", err)
+	}
+
+	sm.mu.Lock()
+
+	bootID := sm.currentBootID
+	if bootID == "" {
+		bootID = sm.lastBootID
+	}
+
+	data, err := json.Marshal(handoff{
+		FormatVersion:  handoffFormatVersion,
+		MonitorVersion: monitorVersion,
+		NodeName:       sm.nodeName,
+		WrittenAt:      time.Now(),
+		State: syslogMonitorState{
+			Version:             stateFileVersion,
+			BootID:              bootID,
+			CheckLastCursors:    sm.checkLastCursors,
+			CheckLastEventTimes: sm.checkLastEventTimes,
+			CheckLogFileOffsets: sm.checkLogFileOffsets,
+		},
+	})
+
+	sm.mu.Unlock()
+
+	if err != nil {
+		return fmt.Errorf("failed to marshal handoff: %w", err)
+	}
+
+	if err := writeFileAtomically(handoffPath(sm.stateFilePath), data); err != nil {
+		return fmt.Errorf("failed to write handoff: %w", err)
+	}
+
+	stateHandoffs.WithLabelValues(sm.nodeName, "written").Inc()
+	slog.Info("Handed off state for the replacing pod", "path", handoffPath(sm.stateFilePath))
+
+	return nil
+}
+
+// adoptHandoff returns the state handed off by the previous pod of the node, and
+// false when there is none or it cannot be adopted, in which case the state file
+// is used. A handoff is consumed: it is removed whether it was adopted or not.
+func adoptHandoff(stateFilePath, nodeName, bootID string, now time.Time) (syslogMonitorState, bool) {
+	path := handoffPath(stateFilePath)
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if !os.IsNotExist(err) {
+			slog.Warn("Failed to read handoff, resuming from the state file", "path", path, "error", err)
+		}
+
+		return syslogMonitorState{}, false
+	}
+
+	defer func() {
+		if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+			slog.Warn("Failed to remove consumed handoff", "path", path, "error", err)
+		}
+	}()
+
+	var h handoff
+	if err := json.Unmarshal(data, &h); err != nil {
+		slog.Warn("Handoff is corrupted, resuming from the state file", "path", path, "error", err)
+		stateHandoffs.WithLabelValues(nodeName, "rejected").Inc()
+
+		return syslogMonitorState{}, false
+	}
+
+	if err := validateHandoff(h, nodeName, bootID, now); err != nil {
+		slog.Warn("Handoff rejected, resuming from the state file",
+			"path", path, "monitorVersion", h.MonitorVersion, "error", err)
+		stateHandoffs.WithLabelValues(nodeName, "rejected").Inc()
+
+		return syslogMonitorState{}, false
+	}
+
+	state := h.State
+	state.Version = stateFileVersion
+
+	if state.CheckLastEventTimes == nil {
+		state.CheckLastEventTimes = make(map[string]uint64)
+	}
+
+	// Persist the adopted state before the handoff is removed
+	if err := saveState(stateFilePath, state); err != nil {
+		slog.Warn("Failed to save adopted handoff state", "error", err)
+	}
+
+	slog.Info("Adopted state handed off by the previous pod",
+		"monitorVersion", h.MonitorVersion, "writtenAt", h.WrittenAt, "checks", len(state.CheckLastCursors))
+	stateHandoffs.WithLabelValues(nodeName, "adopted").Inc()
+
+	return state, true
+}
+
+// validateHandoff returns why the handoff cannot be adopted, nil if it can.
+func validateHandoff(h handoff, nodeName, bootID string, now time.Time) error {
+	if h.FormatVersion < minHandoffFormatVersion || h.FormatVersion > handoffFormatVersion {
+		return fmt.Errorf("unsupported format version %d, expected %d to %d",
+			h.FormatVersion, minHandoffFormatVersion, handoffFormatVersion)
+	}
+
+	if h.NodeName != nodeName {
+		return fmt.Errorf("written for node %q", h.NodeName)
+	}
+
+	// Journal cursors do not survive a reboot, which the state file handles
+	if bootID != "" && h.State.BootID != "" && h.State.BootID != bootID {
+		return fmt.Errorf("written during boot %s, the node rebooted since", h.State.BootID)
+	}
+
+	if age := now.Sub(h.WrittenAt); age > handoffMaxAge {
+		return fmt.Errorf("written %s ago, more than %s", age.Round(time.Second), handoffMaxAge)
+	}
+
+	if !verifyStateFields(h.State) {
+		return fmt.Errorf("state without cursors")
+	}
+
+	return nil
+}
+
+// writeFileAtomically writes data to a temporary file renamed to path, so that a
+// reader never sees a partial file.
+func writeFileAtomically(path string, data []byte) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("failed to create directory: %w", err)
+	}
+
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0600); err != nil {
+		return fmt.Errorf("failed to write %s: %w", tmp, err)
+	}
+
+	if err := os.Rename(tmp, path); err != nil {
+		return fmt.Errorf("failed to rename %s: %w", tmp, err)
+	}
+
+	return nil
+}
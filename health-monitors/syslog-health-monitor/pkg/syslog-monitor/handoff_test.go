@@ -0,0 +1,166 @@
+// Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package syslogmonitor
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newHandoffTestMonitor(t *testing.T, stateFile string) *SyslogMonitor {
+	t.Helper()
+
+	sm, err := NewSyslogMonitorWithFactory(
+		TEST_NODE,
+		[]CheckDefinition{{Name: "handoffCheck", JournalPath: TEST_JOURNAL_PATH}},
+		&mockPlatformConnectorClient{},
+		TEST_AGENT,
+		TEST_COMPONENT,
+		"60s",
+		stateFile,
+		NewMockJournalFactory(),
+		"http://localhost:8080",
+		"/tmp/metadata.json",
+	)
+	require.NoError(t, err)
+
+	return sm
+}
+
+func TestHandoffAdoptedByReplacingPod(t *testing.T) {
+	originalReadBootID := readBootID
+	readBootID = func() (string, error) { return "boot-1", nil }
+
+	defer func() { readBootID = originalReadBootID }()
+
+	stateFile := filepath.Join(t.TempDir(), "state.json")
+
+	outgoing := newHandoffTestMonitor(t, stateFile)
+	require.NoError(t, outgoing.saveCurrentState())
+
+	// Lines processed after the last save of the state file
+	outgoing.setLastCursor("handoffCheck", "cursor-42")
+	outgoing.checkLastEventTimes = map[string]uint64{"handoffCheck": 42}
+
+	require.NoError(t, outgoing.Handoff("v1.2.0"))
+	require.FileExists(t, handoffPath(stateFile))
+
+	incoming := newHandoffTestMonitor(t, stateFile)
+
+	assert.Equal(t, "cursor-42", incoming.checkLastCursors["handoffCheck"])
+	assert.Equal(t, uint64(42), incoming.checkLastEventTimes["handoffCheck"])
+	assert.NoFileExists(t, handoffPath(stateFile), "a handoff is consumed")
+
+	state, err := loadState(stateFile)
+	require.NoError(t, err)
+	assert.Equal(t, "cursor-42", state.CheckLastCursors["handoffCheck"], "the adopted state is persisted")
+}
+
+func TestRejectedHandoffFallsBackToStateFile(t *testing.T) {
+	originalReadBootID := readBootID
+	readBootID = func() (string, error) { return "boot-1", nil }
+
+	defer func() { readBootID = originalReadBootID }()
+
+	stateFile := filepath.Join(t.TempDir(), "state.json")
+	require.NoError(t, saveState(stateFile, syslogMonitorState{
+		Version:          stateFileVersion,
+		BootID:           "boot-1",
+		CheckLastCursors: map[string]string{"handoffCheck": "cursor-state-file"},
+	}))
+
+	// Written by a newer monitor on a rollback
+	data, err := json.Marshal(handoff{
+		FormatVersion: handoffFormatVersion + 1,
+		NodeName:      TEST_NODE,
+		WrittenAt:     time.Now(),
+		State: syslogMonitorState{
+			BootID:           "boot-1",
+			CheckLastCursors: map[string]string{"handoffCheck": "cursor-handoff"},
+		},
+	})
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(handoffPath(stateFile), data, 0600))
+
+	sm := newHandoffTestMonitor(t, stateFile)
+
+	assert.Equal(t, "cursor-state-file", sm.checkLastCursors["handoffCheck"])
+	assert.NoFileExists(t, handoffPath(stateFile), "a rejected handoff is consumed")
+}
+
+func TestValidateHandoff(t *testing.T) {
+	now := time.Now()
+	valid := handoff{
+		FormatVersion: handoffFormatVersion,
+		NodeName:      TEST_NODE,
+		WrittenAt:     now.Add(-time.Minute),
+		State: syslogMonitorState{
+			BootID:           "boot-1",
+			CheckLastCursors: map[string]string{"handoffCheck": "cursor-1"},
+		},
+	}
+
+	testCases := []struct {
+		name        string
+		modify      func(h *handoff)
+		bootID      string
+		expectError bool
+	}{
+		{name: "valid", modify: func(*handoff) {}, bootID: "boot-1"},
+		{name: "boot ID unknown", modify: func(*handoff) {}},
+		{name: "older format", modify: func(h *handoff) { h.FormatVersion = 0 }, bootID: "boot-1", expectError: true},
+		{
+			name:        "newer format",
+			modify:      func(h *handoff) { h.FormatVersion = handoffFormatVersion + 1 },
+			bootID:      "boot-1",
+			expectError: true,
+		},
+		{name: "other node", modify: func(h *handoff) { h.NodeName = "other" }, bootID: "boot-1", expectError: true},
+		{name: "rebooted since", modify: func(*handoff) {}, bootID: "boot-2", expectError: true},
+		{
+			name:        "stale",
+			modify:      func(h *handoff) { h.WrittenAt = now.Add(-time.Hour) },
+			bootID:      "boot-1",
+			expectError: true,
+		},
+		{
+			name:        "without cursors",
+			modify:      func(h *handoff) { h.State.CheckLastCursors = nil },
+			bootID:      "boot-1",
+			expectError: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			h := valid
+			h.State.CheckLastCursors = map[string]string{"handoffCheck": "cursor-1"}
+			tc.modify(&h)
+
+			err := validateHandoff(h, TEST_NODE, tc.bootID, now)
+			if tc.expectError {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,57 @@
+// Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package syslogmonitor
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/nvidia/nvsentinel/data-models/pkg/model"
+	pb "github.com/nvidia/nvsentinel/data-models/pkg/protos"
+)
+
+// EnableHeartbeat sends a heartbeat reporting version and the rule pack selected
+// for the node every interval, so the control plane can detect version skew. A
+// zero interval disables heartbeats.
+func (sm *SyslogMonitor) EnableHeartbeat(version string, interval time.Duration) {
+	sm.version = version
+	sm.heartbeatInterval = interval
+}
+
+// sendHeartbeat sends the heartbeat when it is due. A failed heartbeat is logged
+// and retried on the next run.
+func (sm *SyslogMonitor) sendHeartbeat(now time.Time) {
+	if sm.heartbeatInterval <= 0 || now.Sub(sm.lastHeartbeat) < sm.heartbeatInterval {
+		return
+	}
+
+	event := model.NewHeartbeat(sm.defaultAgentName, sm.nodeName, sm.version, now)
+	if sm.rulePack != nil {
+		event.Metadata[model.MetadataRulePack] = sm.rulePack.Name
+		event.Metadata[model.MetadataRulePackVersion] = sm.rulePack.Version
+	}
+
+	_, err := sm.pcClient.HealthEventOccurredV1(context.Background(), &pb.HealthEvents{
+		Version: 1,
+		Events:  []*pb.HealthEvent{event},
+	}, sm.callOptions()...)
+	if err != nil {
+		slog.Warn("Failed to send heartbeat", "error", err)
+		return
+	}
+
+	sm.lastHeartbeat = now
+}
@@ -0,0 +1,58 @@
+// Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package syslogmonitor
+
+import (
+	"testing"
+	"time"
+
+	"github.com/nvidia/nvsentinel/data-models/pkg/model"
+	"github.com/nvidia/nvsentinel/health-monitors/syslog-health-monitor/pkg/rulepack"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHeartbeat(t *testing.T) {
+	client := &mockPlatformConnectorClient{}
+	sm := &SyslogMonitor{nodeName: "node1", defaultAgentName: TEST_AGENT, pcClient: client}
+
+	// heartbeats are disabled by default
+	now := time.Now()
+	sm.sendHeartbeat(now)
+	assert.Empty(t, client.RecordedHealthEvents)
+
+	sm.EnableHeartbeat("v1.2.0", time.Minute)
+	sm.rulePack = &rulepack.Pack{Name: "GB200", Version: "3"}
+
+	sm.sendHeartbeat(now)
+	require.Len(t, client.RecordedHealthEvents, 1)
+
+	event := client.RecordedHealthEvents[0].Events[0]
+	require.True(t, model.IsHeartbeat(event))
+
+	heartbeat := model.HeartbeatFromEvent(event)
+	assert.Equal(t, "node1", heartbeat.NodeName)
+	assert.Equal(t, TEST_AGENT, heartbeat.Agent)
+	assert.Equal(t, "v1.2.0", heartbeat.Version)
+	assert.Equal(t, "GB200", heartbeat.RulePack)
+	assert.Equal(t, "3", heartbeat.RulePackVersion)
+
+	// not due yet
+	sm.sendHeartbeat(now.Add(30 * time.Second))
+	assert.Len(t, client.RecordedHealthEvents, 1)
+
+	sm.sendHeartbeat(now.Add(time.Minute))
+	assert.Len(t, client.RecordedHealthEvents, 2)
+}
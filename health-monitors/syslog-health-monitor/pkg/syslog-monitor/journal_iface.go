@@ -30,6 +30,10 @@ type Journal interface {
 	// GetData retrieves a field from the current journal entry
 	GetData(field string) (string, error)
 
+	// GetRealtimeUsec returns the wallclock time of the current journal entry in
+	// microseconds since the epoch
+	GetRealtimeUsec() (uint64, error)
+
 	// Next moves to the next journal entry
 	Next() (uint64, error)
 
@@ -49,6 +49,11 @@ func (j *RealJournal) GetData(field string) (string, error) {
 	return j.journal.GetData(field)
 }
 
+// GetRealtimeUsec returns the wallclock time of the current journal entry
+func (j *RealJournal) GetRealtimeUsec() (uint64, error) {
+	return j.journal.GetRealtimeUsec()
+}
+
 // Next moves to the next journal entry
 func (j *RealJournal) Next() (uint64, error) {
 	return j.journal.Next()
@@ -88,9 +88,23 @@ func (j *StubJournal) GetData(field string) (string, error) {
 		return "", fmt.Errorf("invalid current position: %d", j.currentPosition)
 	}
 
+	// All stub entries are from the current boot
+	if field == FieldBootID {
+		return j.bootID, nil
+	}
+
 	return journal[j.currentPosition], nil
 }
 
+// GetRealtimeUsec returns the current time, the stub journal does not keep entry times
+func (j *StubJournal) GetRealtimeUsec() (uint64, error) {
+	if j.closed {
+		return 0, errors.New(JOURNAL_CLOSED_ERROR_MESSAGE)
+	}
+
+	return uint64(time.Now().UnixMicro()), nil
+}
+
 // Next moves to the next journal entry
 func (j *StubJournal) Next() (uint64, error) {
 	if j.closed {
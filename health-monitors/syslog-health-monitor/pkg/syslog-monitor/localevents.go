@@ -0,0 +1,213 @@
+// Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package syslogmonitor
+
+import (
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"sync"
+	"time"
+
+	pb "github.com/nvidia/nvsentinel/data-models/pkg/protos"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+const (
+	// LocalEventsPath is where the events and lines retained on the node are served
+	LocalEventsPath = "/events/local"
+
+	// DefaultLocalBufferMB is the size of the local buffer in MiB
+	DefaultLocalBufferMB = 16
+
+	// LocalKindEvent marks a generated event in the local buffer
+	LocalKindEvent = "event"
+	// LocalKindLine marks a raw log line a check generated events for
+	LocalKindLine = "line"
+
+	// localRecordOverhead approximates the size of a record besides its payload
+	localRecordOverhead = 64
+)
+
+// LocalEvent is an event or a matched raw log line retained on the node.
+type LocalEvent struct {
+	Time  time.Time `json:"time"`
+	Kind  string    `json:"kind"`
+	Check string    `json:"check"`
+	// Shipped tells whether the event was sent to the platform connector, or
+	// only retained on the node because of its severity. Unset for lines.
+	Shipped bool            `json:"shipped,omitempty"`
+	Event   json.RawMessage `json:"event,omitempty"`
+	Line    string          `json:"line,omitempty"`
+}
+
+// LocalEventsResponse holds the retained events and lines, the oldest first.
+type LocalEventsResponse struct {
+	Events []LocalEvent `json:"events"`
+	// Since is the time of the oldest retained record, older records were
+	// overwritten
+	Since *time.Time `json:"since,omitempty"`
+}
+
+// localFilter selects retained records. Zero values select all.
+type localFilter struct {
+	check string
+	kind  string
+	since time.Time
+}
+
+func (f localFilter) matches(record LocalEvent) bool {
+	return (f.check == "" || record.Check == f.check) &&
+		(f.kind == "" || record.Kind == f.kind) &&
+		!record.Time.Before(f.since)
+}
+
+// localEventBuffer is a ring buffer of the latest events generated on the node,
+// shipped or not, and of the raw lines they were generated from. It is bounded
+// by the size of the records, so that a burst of long lines cannot grow it.
+type localEventBuffer struct {
+	mu      sync.Mutex
+	records []LocalEvent
+	size    int
+	maxSize int
+}
+
+func newLocalEventBuffer(maxSize int) *localEventBuffer {
+	return &localEventBuffer{maxSize: maxSize}
+}
+
+func (b *localEventBuffer) add(checkName string, event *pb.HealthEvent, shipped bool) {
+	if b == nil || b.maxSize <= 0 {
+		return
+	}
+
+	data, err := protojson.Marshal(event)
+	if err != nil {
+		slog.Warn("Failed to retain event locally", "check", checkName, "error", err)
+		return
+	}
+
+	b.push(LocalEvent{Time: time.Now(), Kind: LocalKindEvent, Check: checkName, Shipped: shipped, Event: data})
+}
+
+// addLine retains a raw log line checkName generated events for.
+func (b *localEventBuffer) addLine(checkName, line string) {
+	if b == nil || b.maxSize <= 0 {
+		return
+	}
+
+	b.push(LocalEvent{Time: time.Now(), Kind: LocalKindLine, Check: checkName, Line: line})
+}
+
+// push appends the record and drops the oldest records beyond the size of the
+// buffer. A record larger than the buffer is dropped right away.
+func (b *localEventBuffer) push(record LocalEvent) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.records = append(b.records, record)
+	b.size += recordSize(record)
+
+	for b.size > b.maxSize && len(b.records) > 0 {
+		b.size -= recordSize(b.records[0])
+		b.records[0] = LocalEvent{}
+		b.records = b.records[1:]
+	}
+
+	localBufferBytes.Set(float64(b.size))
+}
+
+func recordSize(record LocalEvent) int {
+	return localRecordOverhead + len(record.Check) + len(record.Event) + len(record.Line)
+}
+
+// list returns the retained records matching the filter, the oldest first, and
+// the time of the oldest retained record.
+func (b *localEventBuffer) list(filter localFilter) ([]LocalEvent, *time.Time) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	records := []LocalEvent{}
+
+	for _, record := range b.records {
+		if filter.matches(record) {
+			records = append(records, record)
+		}
+	}
+
+	if len(b.records) == 0 {
+		return records, nil
+	}
+
+	since := b.records[0].Time
+
+	return records, &since
+}
+
+// EnableLocalEvents sets the size in MiB of the buffer retaining the events and
+// matched raw lines on the node. 0 retains none.
+func (sm *SyslogMonitor) EnableLocalEvents(sizeMB int) {
+	sm.localEvents = newLocalEventBuffer(sizeMB << 20)
+}
+
+// LocalEventsHandler serves GET /events/local, optionally restricted with the
+// check, kind (event or line) and since (RFC 3339) query parameters. The
+// diagnostic bundle and `nvsentinelctl events local` pull it on demand.
+func (sm *SyslogMonitor) LocalEventsHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			return
+		}
+
+		filter, err := parseLocalFilter(r)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		response := LocalEventsResponse{Events: []LocalEvent{}}
+		if sm.localEvents != nil {
+			response.Events, response.Since = sm.localEvents.list(filter)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+
+		if err := json.NewEncoder(w).Encode(response); err != nil {
+			slog.Error("Failed to encode local events", "error", err)
+		}
+	})
+}
+
+func parseLocalFilter(r *http.Request) (localFilter, error) {
+	query := r.URL.Query()
+	filter := localFilter{check: query.Get("check"), kind: query.Get("kind")}
+
+	if filter.kind != "" && filter.kind != LocalKindEvent && filter.kind != LocalKindLine {
+		return filter, fmt.Errorf("invalid kind %q, expected %s or %s", filter.kind, LocalKindEvent, LocalKindLine)
+	}
+
+	if since := query.Get("since"); since != "" {
+		parsed, err := time.Parse(time.RFC3339, since)
+		if err != nil {
+			return filter, fmt.Errorf("invalid since %q, expected an RFC 3339 time: %w", since, err)
+		}
+
+		filter.since = parsed
+	}
+
+	return filter, nil
+}
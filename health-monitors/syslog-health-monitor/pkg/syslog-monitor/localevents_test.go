@@ -0,0 +1,121 @@
+// Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package syslogmonitor
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	pb "github.com/nvidia/nvsentinel/data-models/pkg/protos"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLocalEventBufferDropsOldestBeyondSize(t *testing.T) {
+	line := strings.Repeat("x", 100)
+	buffer := newLocalEventBuffer(3 * (localRecordOverhead + len("check") + len(line)))
+
+	for range 5 {
+		buffer.addLine("check", line)
+	}
+
+	records, since := buffer.list(localFilter{})
+	assert.Len(t, records, 3, "the oldest records are dropped")
+	require.NotNil(t, since)
+	assert.Equal(t, records[0].Time, *since)
+
+	buffer.addLine("check", strings.Repeat("x", 1000))
+
+	records, since = buffer.list(localFilter{})
+	assert.Empty(t, records, "a record larger than the buffer is not retained")
+	assert.Nil(t, since)
+	assert.Zero(t, buffer.size)
+}
+
+func TestLocalEventBufferFilter(t *testing.T) {
+	buffer := newLocalEventBuffer(1 << 20)
+
+	buffer.addLine("check", "NVRM: Xid (PCI:0000:b3:00): 79")
+	buffer.add("check", &pb.HealthEvent{Message: "fallen off the bus"}, true)
+	buffer.add("other", &pb.HealthEvent{Message: "other"}, false)
+
+	records, _ := buffer.list(localFilter{check: "check"})
+	require.Len(t, records, 2)
+	assert.Equal(t, LocalKindLine, records[0].Kind)
+	assert.Equal(t, "NVRM: Xid (PCI:0000:b3:00): 79", records[0].Line)
+	assert.Equal(t, LocalKindEvent, records[1].Kind)
+
+	records, _ = buffer.list(localFilter{kind: LocalKindEvent})
+	assert.Len(t, records, 2)
+
+	records, _ = buffer.list(localFilter{since: time.Now().Add(time.Minute)})
+	assert.Empty(t, records)
+}
+
+func TestHandleLineRetainsMatchedLines(t *testing.T) {
+	sm, check := newBatchTestMonitor(&mockPlatformConnectorClient{})
+	sm.EnableLocalEvents(1)
+
+	require.NoError(t, sm.handleSingleLine(check, "no match"))
+	require.NoError(t, sm.handleSingleLine(check, "kernel: sxid123"))
+
+	records, _ := sm.localEvents.list(localFilter{})
+	require.Len(t, records, 2, "only lines generating events are retained, followed by their events")
+	assert.Equal(t, "kernel: sxid123", records[0].Line)
+	assert.Equal(t, LocalKindEvent, records[1].Kind)
+	assert.True(t, records[1].Shipped)
+}
+
+func TestLocalEventsHandler(t *testing.T) {
+	sm := &SyslogMonitor{}
+	sm.EnableLocalEvents(1)
+	sm.localEvents.addLine("check", "raw line")
+	sm.localEvents.add("check", &pb.HealthEvent{Message: "retained"}, false)
+	sm.localEvents.add("other", &pb.HealthEvent{Message: "other"}, true)
+
+	get := func(target string) *httptest.ResponseRecorder {
+		recorder := httptest.NewRecorder()
+		sm.LocalEventsHandler().ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, target, nil))
+
+		return recorder
+	}
+
+	recorder := get(LocalEventsPath + "?check=check&kind=event")
+	require.Equal(t, http.StatusOK, recorder.Code)
+
+	var response LocalEventsResponse
+	require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &response))
+	require.Len(t, response.Events, 1)
+	assert.Equal(t, "check", response.Events[0].Check)
+	assert.False(t, response.Events[0].Shipped)
+	assert.NotNil(t, response.Since)
+
+	since := time.Now().Add(time.Hour).UTC().Format(time.RFC3339)
+	recorder = get(LocalEventsPath + "?since=" + since)
+	require.Equal(t, http.StatusOK, recorder.Code)
+	require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &response))
+	assert.Empty(t, response.Events)
+
+	assert.Equal(t, http.StatusBadRequest, get(LocalEventsPath+"?kind=metric").Code)
+	assert.Equal(t, http.StatusBadRequest, get(LocalEventsPath+"?since=yesterday").Code)
+
+	recorder = httptest.NewRecorder()
+	sm.LocalEventsHandler().ServeHTTP(recorder, httptest.NewRequest(http.MethodPost, LocalEventsPath, nil))
+	assert.Equal(t, http.StatusMethodNotAllowed, recorder.Code)
+}
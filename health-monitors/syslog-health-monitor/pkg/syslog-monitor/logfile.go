@@ -0,0 +1,224 @@
+// Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package syslogmonitor
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// fingerprintSize is the number of leading bytes of a log file hashed to
+// recognize it after it was truncated or replaced under the same path.
+const fingerprintSize = 256
+
+// logFileOffset is the position up to which a log file was processed.
+type logFileOffset struct {
+	Offset int64 `json:"offset"`
+	// Hash of the first min(Offset, fingerprintSize) bytes of the file
+	Fingerprint string `json:"fingerprint"`
+}
+
+// processLogFiles hands the lines appended to the log files of the check since
+// the last run to its handler. On the first run of the check, existing content
+// is skipped like the journal is; files that appear later are read from the
+// start. A file that was truncated or replaced is read again from the start.
+func (sm *SyslogMonitor) processLogFiles(check CheckDefinition) error {
+	if len(check.LogFiles) == 0 {
+		return nil
+	}
+
+	var paths []string
+
+	for _, pattern := range check.LogFiles {
+		matches, err := filepath.Glob(pattern)
+		if err != nil {
+			return fmt.Errorf("check '%s': invalid log file pattern %q: %w", check.Name, pattern, err)
+		}
+
+		paths = append(paths, matches...)
+	}
+
+	sm.mu.Lock()
+	offsets, initialized := sm.checkLogFileOffsets[check.Name]
+	sm.mu.Unlock()
+
+	next := make(map[string]logFileOffset, len(paths))
+
+	for _, path := range paths {
+		if _, seen := next[path]; seen {
+			continue
+		}
+
+		offset, err := sm.processLogFile(check, path, offsets[path], initialized)
+		if errors.Is(err, os.ErrNotExist) {
+			// Removed since it was matched, e.g. by log rotation
+			continue
+		}
+
+		if err != nil {
+			return fmt.Errorf("check '%s': failed to process log file %s: %w", check.Name, path, err)
+		}
+
+		next[path] = offset
+	}
+
+	// Offsets of files that no longer exist are dropped with the old map
+	sm.mu.Lock()
+	if sm.checkLogFileOffsets == nil {
+		sm.checkLogFileOffsets = make(map[string]map[string]logFileOffset)
+	}
+
+	sm.checkLogFileOffsets[check.Name] = next
+	sm.mu.Unlock()
+
+	return nil
+}
+
+// processLogFile handles the complete lines of path after last and returns the
+// new offset. With initialized false the file is only positioned at its end.
+// Events are delivered before the offset is returned, so that lines are
+// processed again on the next run when sending fails.
+func (sm *SyslogMonitor) processLogFile(
+	check CheckDefinition, path string, last logFileOffset, initialized bool,
+) (logFileOffset, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return last, fmt.Errorf("open: %w", err)
+	}
+
+	defer func() {
+		if cerr := file.Close(); cerr != nil {
+			slog.Warn("Error closing log file", "check", check.Name, "file", path, "error", cerr)
+		}
+	}()
+
+	info, err := file.Stat()
+	if err != nil {
+		return last, fmt.Errorf("stat: %w", err)
+	}
+
+	if !initialized {
+		slog.Info("Initialized log file, processing will start from new lines on the next run",
+			"check", check.Name, "file", path, "offset", info.Size())
+
+		return fileOffset(file, info.Size())
+	}
+
+	start := last.Offset
+
+	if start > info.Size() {
+		start = 0
+	} else if start > 0 {
+		current, err := fileOffset(file, start)
+		if err != nil {
+			return last, err
+		}
+
+		if current.Fingerprint != last.Fingerprint {
+			start = 0
+		}
+	}
+
+	if start == 0 && last.Offset > 0 {
+		slog.Info("Log file was truncated or replaced, reading it from the start", "check", check.Name, "file", path)
+	}
+
+	if _, err := file.Seek(start, io.SeekStart); err != nil {
+		return last, fmt.Errorf("seek: %w", err)
+	}
+
+	offset := start
+	reader := bufio.NewReader(file)
+
+	for {
+		line, err := reader.ReadBytes('\n')
+		if errors.Is(err, io.EOF) {
+			// An incomplete last line is read once it is complete
+			break
+		}
+
+		if err != nil {
+			return last, fmt.Errorf("read: %w", err)
+		}
+
+		text := string(bytes.TrimRight(line, "\r\n"))
+		if _, err := sm.handleLine(check, text, logLineTime(text), false); err != nil {
+			return last, err
+		}
+
+		offset += int64(len(line))
+	}
+
+	if err := sm.flushBatch(check.Name); err != nil {
+		return last, fmt.Errorf("failed to flush health event batch: %w", err)
+	}
+
+	return fileOffset(file, offset)
+}
+
+// logLineTime returns a function reporting the time the log line was logged, taken
+// from its leading RFC 3339 or BSD syslog timestamp, or the current time when the
+// line has neither. BSD syslog timestamps have no year, the line is assumed to be
+// logged within the last year.
+func logLineTime(line string) func() time.Time {
+	return func() time.Time {
+		now := time.Now()
+
+		if field, _, _ := strings.Cut(line, " "); field != "" {
+			if t, err := time.Parse(time.RFC3339Nano, field); err == nil {
+				return t
+			}
+		}
+
+		if len(line) < len(time.Stamp) {
+			return now
+		}
+
+		t, err := time.ParseInLocation(time.Stamp, line[:len(time.Stamp)], time.Local)
+		if err != nil {
+			return now
+		}
+
+		t = t.AddDate(now.Year(), 0, 0)
+		if t.After(now.Add(24 * time.Hour)) {
+			t = t.AddDate(-1, 0, 0)
+		}
+
+		return t
+	}
+}
+
+// fileOffset returns offset with the fingerprint of the file content before it.
+func fileOffset(file *os.File, offset int64) (logFileOffset, error) {
+	head := make([]byte, min(offset, fingerprintSize))
+
+	if _, err := file.ReadAt(head, 0); err != nil && !errors.Is(err, io.EOF) {
+		return logFileOffset{}, fmt.Errorf("read fingerprint: %w", err)
+	}
+
+	sum := sha256.Sum256(head)
+
+	return logFileOffset{Offset: offset, Fingerprint: hex.EncodeToString(sum[:])}, nil
+}
@@ -0,0 +1,87 @@
+// Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package syslogmonitor
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	pb "github.com/nvidia/nvsentinel/data-models/pkg/protos"
+	"github.com/nvidia/nvsentinel/health-monitors/syslog-health-monitor/pkg/corpus"
+	"github.com/nvidia/nvsentinel/health-monitors/syslog-health-monitor/pkg/types"
+	"github.com/stretchr/testify/require"
+)
+
+// lineRecorder records the lines handed to it.
+type lineRecorder struct {
+	lines []string
+}
+
+func (r *lineRecorder) ProcessLine(message string) (*pb.HealthEvents, error) {
+	r.lines = append(r.lines, message)
+	return nil, nil
+}
+
+// FuzzLogFileLines appends the content to a tailed log file in two writes split at
+// any byte, e.g. in the middle of a line, and checks that the lines are assembled
+// as written: every complete line is handled once and the incomplete last line
+// not at all.
+func FuzzLogFileLines(f *testing.F) {
+	content := strings.Join(corpus.Lines(), "\n")
+	for _, split := range []uint{0, 1, uint(len(content) / 2), uint(len(content))} {
+		f.Add(content+"\n", split)
+		f.Add(content, split)
+	}
+
+	f.Add("line\r\n\r\npartial", uint(3))
+
+	f.Fuzz(func(t *testing.T, content string, split uint) {
+		path := filepath.Join(t.TempDir(), "driver.log")
+		require.NoError(t, os.WriteFile(path, nil, 0o600))
+
+		recorder := &lineRecorder{}
+		check := CheckDefinition{Name: "fuzzCheck", LogFiles: []string{path}}
+		sm := &SyslogMonitor{
+			nodeName:          TEST_NODE,
+			pcClient:          &mockPlatformConnectorClient{},
+			checkToHandlerMap: map[string]types.Handler{check.Name: recorder},
+		}
+
+		require.NoError(t, sm.processLogFiles(check))
+
+		at := int(split % uint(len(content)+1))
+		for _, chunk := range []string{content[:at], content[at:]} {
+			appendToFile(t, path, chunk)
+			require.NoError(t, sm.processLogFiles(check))
+		}
+
+		complete := strings.Split(content, "\n")
+		complete = complete[:len(complete)-1]
+
+		for i, line := range complete {
+			complete[i] = strings.TrimRight(line, "\r")
+		}
+
+		require.Equal(t, len(complete), len(recorder.lines))
+
+		for i := range complete {
+			require.Equal(t, complete[i], recorder.lines[i])
+		}
+
+		require.Equal(t, int64(strings.LastIndex(content, "\n")+1), sm.checkLogFileOffsets[check.Name][path].Offset)
+	})
+}
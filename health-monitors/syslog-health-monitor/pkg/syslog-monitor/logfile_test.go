@@ -0,0 +1,169 @@
+// Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package syslogmonitor
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/nvidia/nvsentinel/data-models/pkg/model"
+	"github.com/nvidia/nvsentinel/health-monitors/syslog-health-monitor/pkg/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newLogFileTestMonitor(pcClient *mockPlatformConnectorClient, logFiles ...string) (*SyslogMonitor, CheckDefinition) {
+	check := CheckDefinition{Name: "mockCheck", LogFiles: logFiles}
+
+	return &SyslogMonitor{
+		nodeName:         TEST_NODE,
+		pcClient:         pcClient,
+		checkLastCursors: map[string]string{},
+		checkToHandlerMap: map[string]types.Handler{
+			check.Name: &mockHandler{nodeName: TEST_NODE, checkName: check.Name},
+		},
+	}, check
+}
+
+func appendToFile(t *testing.T, path, content string) {
+	t.Helper()
+
+	file, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+	require.NoError(t, err)
+
+	_, err = file.WriteString(content)
+	require.NoError(t, err)
+	require.NoError(t, file.Close())
+}
+
+func TestProcessLogFiles(t *testing.T) {
+	dir := t.TempDir()
+	existing := filepath.Join(dir, "installer.log")
+	appendToFile(t, existing, "old sxid123 line\n")
+
+	pcClient := &mockPlatformConnectorClient{}
+	sm, check := newLogFileTestMonitor(pcClient, filepath.Join(dir, "*.log"))
+
+	// The first run skips existing content
+	require.NoError(t, sm.processLogFiles(check))
+	assert.Empty(t, pcClient.RecordedHealthEvents)
+
+	// New lines are processed once; an incomplete line waits for its newline
+	appendToFile(t, existing, "new sxid123 line\nunrelated\npartial sxid123")
+	require.NoError(t, sm.processLogFiles(check))
+	assert.Len(t, pcClient.RecordedHealthEvents, 1)
+
+	require.NoError(t, sm.processLogFiles(check))
+	assert.Len(t, pcClient.RecordedHealthEvents, 1)
+
+	appendToFile(t, existing, " line\n")
+	require.NoError(t, sm.processLogFiles(check))
+	assert.Len(t, pcClient.RecordedHealthEvents, 2)
+
+	// A file appearing after the first run is read from the start
+	appendToFile(t, filepath.Join(dir, "driver.log"), "sxid123 in new file\n")
+	require.NoError(t, sm.processLogFiles(check))
+	assert.Len(t, pcClient.RecordedHealthEvents, 3)
+}
+
+func TestProcessLogFilesReplacedFile(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "nvidia-installer.log")
+	appendToFile(t, path, "nvidia-installer log file, creation time: Mon Jun  2 10:00:00 2025\n")
+
+	pcClient := &mockPlatformConnectorClient{}
+	sm, check := newLogFileTestMonitor(pcClient, path)
+	require.NoError(t, sm.processLogFiles(check))
+
+	// A new install rewrites the log; it is read from the start even when it
+	// is already longer than the old one
+	require.NoError(t, os.WriteFile(path, []byte(
+		"nvidia-installer log file, creation time: Tue Jun  3 10:00:00 2025\nsxid123 failure\nmore output\n"), 0600))
+	require.NoError(t, sm.processLogFiles(check))
+	assert.Len(t, pcClient.RecordedHealthEvents, 1)
+
+	// A truncated log is read from the start as well
+	require.NoError(t, os.WriteFile(path, []byte("sxid123\n"), 0600))
+	require.NoError(t, sm.processLogFiles(check))
+	assert.Len(t, pcClient.RecordedHealthEvents, 2)
+
+	// Offsets of removed files are dropped
+	require.NoError(t, os.Remove(path))
+	require.NoError(t, sm.processLogFiles(check))
+	assert.Empty(t, sm.checkLogFileOffsets[check.Name])
+}
+
+func TestProcessLogFilesStampsLineTime(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "kern.log")
+	appendToFile(t, path, "")
+
+	pcClient := &mockPlatformConnectorClient{}
+	sm, check := newLogFileTestMonitor(pcClient, path)
+	require.NoError(t, sm.processLogFiles(check))
+
+	appendToFile(t, path, "2025-06-02T10:00:00.250000+00:00 node kernel: sxid123\n")
+	require.NoError(t, sm.processLogFiles(check))
+	require.Len(t, pcClient.RecordedHealthEvents, 1)
+
+	readAt, ok := model.StageTimestamp(pcClient.RecordedHealthEvents[0].Events[0], model.StageLineRead)
+	require.True(t, ok)
+	assert.Equal(t, time.Date(2025, time.June, 2, 10, 0, 0, 250_000_000, time.UTC), readAt.UTC())
+}
+
+func TestLogLineTime(t *testing.T) {
+	now := time.Now()
+	recent := now.Add(-time.Hour).Truncate(time.Second)
+
+	// A BSD syslog timestamp later than now was logged last year
+	future := now.AddDate(0, 0, 2)
+	lastYear := time.Date(future.Year()-1, future.Month(), future.Day(), 8, 0, 0, 0, time.Local)
+
+	tests := []struct {
+		name string
+		line string
+		want time.Time
+	}{
+		{
+			name: "RFC 3339",
+			line: "2025-06-02T10:00:00.123456+02:00 node kernel: NVRM: Xid 79",
+			want: time.Date(2025, time.June, 2, 8, 0, 0, 123_456_000, time.UTC),
+		},
+		{
+			name: "BSD syslog",
+			line: recent.Format(time.Stamp) + " node kernel: NVRM: Xid 79",
+			want: recent,
+		},
+		{
+			name: "BSD syslog from last year",
+			line: lastYear.Format(time.Stamp) + " node kernel: NVRM: Xid 79",
+			want: lastYear,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.True(t, tt.want.Equal(logLineTime(tt.line)()), "got %v", logLineTime(tt.line)())
+		})
+	}
+
+	// Lines without a timestamp are stamped with the time they are read
+	before := time.Now()
+	got := logLineTime("NVRM: Xid 79")()
+	assert.False(t, got.Before(before))
+	assert.False(t, got.After(time.Now()))
+}
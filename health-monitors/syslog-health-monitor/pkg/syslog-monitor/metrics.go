@@ -0,0 +1,143 @@
+// Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package syslogmonitor
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+var (
+	eventStormActive = promauto.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "syslog_health_monitor_event_storm_active",
+			Help: "Whether the per-node event storm circuit breaker is currently open (1) or closed (0)",
+		},
+		[]string{"node"},
+	)
+
+	eventStormSuppressedEntries = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "syslog_health_monitor_event_storm_suppressed_entries_total",
+			Help: "Total number of journal entries whose non-fatal events were suppressed while the event storm " +
+				"circuit breaker was open",
+		},
+		[]string{"node", "check"},
+	)
+
+	pipelineStageLatency = promauto.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name:    "syslog_health_monitor_pipeline_stage_latency_seconds",
+			Help:    "Latency between pipeline stages of a health event, from log line read to event emitted",
+			Buckets: prometheus.DefBuckets,
+		},
+		[]string{"from", "to"},
+	)
+
+	payloadBytesSent = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "syslog_health_monitor_payload_bytes_sent_total",
+			Help: "Bytes of health event payloads sent, raw (uncompressed) and compressed (as sent on the wire)",
+		},
+		[]string{"type"},
+	)
+
+	batchSize = promauto.NewHistogram(
+		prometheus.HistogramOpts{
+			Name:    "syslog_health_monitor_batch_size",
+			Help:    "Number of health events sent per batched call to the platform connector",
+			Buckets: []float64{1, 2, 5, 10, 25, 50, 100, 250},
+		},
+	)
+
+	rulePackTopologyMismatches = promauto.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "syslog_health_monitor_rule_pack_topology_mismatches",
+			Help: "Number of differences between the node topology and the topology expected by the selected rule pack",
+		},
+		[]string{"node", "pack"},
+	)
+
+	rebootsDetected = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "syslog_health_monitor_reboots_detected_total",
+			Help: "Total number of node reboots detected from a boot ID change",
+		},
+		[]string{"node"},
+	)
+
+	filteredEvents = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "syslog_health_monitor_filtered_events_total",
+			Help: "Total number of events below the minimum shipped severity, only retained on the node",
+		},
+		[]string{"check", "severity"},
+	)
+
+	expressTriggers = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "syslog_health_monitor_express_triggers_total",
+			Help: "Total number of runs triggered early by a catastrophic line, by pattern",
+		},
+		[]string{"pattern"},
+	)
+
+	localBufferBytes = promauto.NewGauge(
+		prometheus.GaugeOpts{
+			Name: "syslog_health_monitor_local_buffer_bytes",
+			Help: "Approximate size of the events and matched raw lines retained on the node",
+		},
+	)
+
+	stateHandoffs = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "syslog_health_monitor_state_handoffs_total",
+			Help: "Total number of state handoffs between the pods of a rolling update, by result",
+		},
+		[]string{"node", "result"},
+	)
+
+	backfilledEvents = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "syslog_health_monitor_backfilled_events_total",
+			Help: "Total number of events emitted for journal lines replayed after a restart and marked as backfilled",
+		},
+		[]string{"check"},
+	)
+
+	rulePackBenignEvents = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "syslog_health_monitor_rule_pack_benign_events_total",
+			Help: "Total number of events downgraded to informational because the error code is benign on the SKU",
+		},
+		[]string{"check", "pack"},
+	)
+
+	rulePackBundleSyncs = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "syslog_health_monitor_rule_pack_bundle_syncs_total",
+			Help: "Total number of rule pack bundle syncs by result",
+		},
+		[]string{"result"},
+	)
+
+	rulePackBundleApplied = promauto.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "syslog_health_monitor_rule_pack_bundle_applied",
+			Help: "Set to 1 for the version of the distributed rule pack bundle the monitor applies",
+		},
+		[]string{"bundle"},
+	)
+)
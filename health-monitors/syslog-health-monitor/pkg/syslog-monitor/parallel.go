@@ -0,0 +1,107 @@
+// Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package syslogmonitor
+
+import (
+	"errors"
+	"log/slog"
+	"sync"
+)
+
+// EnableParallelChecks runs up to workers checks concurrently on every run
+// instead of one after another. Each check is handled by a single worker, so the
+// lines of a handler are still processed in journal order. A value of 1 or less
+// runs the checks sequentially.
+func (sm *SyslogMonitor) EnableParallelChecks(workers int) {
+	sm.workers = workers
+}
+
+// runChecks executes all checks on a bounded pool of workers and returns the
+// joined errors of the failed checks.
+func (sm *SyslogMonitor) runChecks() error {
+	workers := min(max(sm.workers, 1), len(sm.checks))
+
+	checks := make(chan CheckDefinition)
+	errs := make(chan error, len(sm.checks))
+
+	var wg sync.WaitGroup
+
+	for range workers {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			for check := range checks {
+				if err := sm.executeCheck(check); err != nil {
+					slog.Error("Check failed during execution",
+						"check", check.Name,
+						"error", err)
+
+					errs <- err
+				}
+			}
+		}()
+	}
+
+	for _, check := range sm.checks {
+		checks <- check
+	}
+
+	close(checks)
+	wg.Wait()
+	close(errs)
+
+	var jointError error
+
+	for err := range errs {
+		jointError = errors.Join(jointError, err)
+	}
+
+	return jointError
+}
+
+// lastCursor returns the cursor to resume checkName from.
+func (sm *SyslogMonitor) lastCursor(checkName string) (string, bool) {
+	sm.mu.Lock()
+	defer sm.mu.Unlock()
+
+	cursor, ok := sm.checkLastCursors[checkName]
+
+	return cursor, ok
+}
+
+// nextCursor returns the cursor to resume checkName from, or "" if there is none.
+func (sm *SyslogMonitor) nextCursor(checkName string) string {
+	cursor, _ := sm.lastCursor(checkName)
+	return cursor
+}
+
+// setLastCursor records the cursor to resume checkName from on the next run.
+func (sm *SyslogMonitor) setLastCursor(checkName, cursor string) {
+	sm.mu.Lock()
+	defer sm.mu.Unlock()
+
+	sm.checkLastCursors[checkName] = cursor
+}
+
+// isColdStart reports whether checkName has not completed a run since the
+// monitor started.
+func (sm *SyslogMonitor) isColdStart(checkName string) bool {
+	sm.mu.Lock()
+	defer sm.mu.Unlock()
+
+	return sm.coldStartChecks[checkName]
+}
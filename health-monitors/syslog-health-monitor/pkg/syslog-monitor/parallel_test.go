@@ -0,0 +1,101 @@
+// Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package syslogmonitor
+
+import (
+	"fmt"
+	"path/filepath"
+	"testing"
+
+	pb "github.com/nvidia/nvsentinel/data-models/pkg/protos"
+	"github.com/nvidia/nvsentinel/health-monitors/syslog-health-monitor/pkg/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// lineHandler emits a non-fatal event carrying the processed line as message.
+type lineHandler struct {
+	checkName string
+}
+
+func (h *lineHandler) ProcessLine(message string) (*pb.HealthEvents, error) {
+	return &pb.HealthEvents{
+		Version: 1,
+		Events:  []*pb.HealthEvent{{CheckName: h.checkName, Message: message, NodeName: TEST_NODE}},
+	}, nil
+}
+
+func TestRunChecksInParallel(t *testing.T) {
+	const (
+		numChecks = 4
+		numLines  = 50
+	)
+
+	pcClient := &mockPlatformConnectorClient{}
+	factory := NewMockJournalFactory()
+
+	sm := &SyslogMonitor{
+		nodeName:          TEST_NODE,
+		pcClient:          pcClient,
+		journalFactory:    factory,
+		currentBootID:     "boot-1",
+		stateFilePath:     filepath.Join(t.TempDir(), "state.json"),
+		checkLastCursors:  map[string]string{},
+		coldStartChecks:   map[string]bool{},
+		checkToHandlerMap: map[string]types.Handler{},
+	}
+	sm.EnableParallelChecks(numChecks)
+	sm.EnableBatching(3)
+	sm.EnableEventStormBreaker(1000000, 1, 0)
+
+	for i := range numChecks {
+		check := CheckDefinition{Name: fmt.Sprintf("check%d", i), JournalPath: fmt.Sprintf("/path%d", i)}
+
+		entries := []MockJournalEntry{{Message: "start", Cursor: check.Name + "-start", BootID: "boot-1"}}
+		for line := range numLines {
+			entries = append(entries, MockJournalEntry{
+				Message: fmt.Sprintf("line %d", line),
+				Cursor:  fmt.Sprintf("%s-%d", check.Name, line),
+				BootID:  "boot-1",
+			})
+		}
+
+		factory.JournalsByPath[check.JournalPath] = &MockJournal{Entries: entries, CurrentPosition: -1, TestBootID: "boot-1"}
+		sm.checks = append(sm.checks, check)
+		sm.checkLastCursors[check.Name] = check.Name + "-start"
+		sm.checkToHandlerMap[check.Name] = &lineHandler{checkName: check.Name}
+	}
+
+	require.NoError(t, sm.Run())
+
+	// Lines of every check must be delivered in journal order
+	delivered := map[string][]string{}
+
+	for _, healthEvents := range pcClient.RecordedHealthEvents {
+		for _, event := range healthEvents.Events {
+			delivered[event.CheckName] = append(delivered[event.CheckName], event.Message)
+		}
+	}
+
+	for _, check := range sm.checks {
+		require.Len(t, delivered[check.Name], numLines, "check %s", check.Name)
+
+		for line, message := range delivered[check.Name] {
+			assert.Equal(t, fmt.Sprintf("line %d", line), message, "check %s", check.Name)
+		}
+
+		assert.Equal(t, fmt.Sprintf("%s-%d", check.Name, numLines-1), sm.checkLastCursors[check.Name])
+	}
+}
@@ -0,0 +1,50 @@
+// Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package syslogmonitor
+
+import (
+	"context"
+
+	"google.golang.org/grpc/stats"
+)
+
+// PayloadStatsHandler is a gRPC client stats handler recording raw vs
+// compressed sizes of the health event payloads sent to the platform connector.
+type PayloadStatsHandler struct{}
+
+func (PayloadStatsHandler) TagRPC(ctx context.Context, _ *stats.RPCTagInfo) context.Context {
+	return ctx
+}
+
+func (PayloadStatsHandler) HandleRPC(_ context.Context, s stats.RPCStats) {
+	out, ok := s.(*stats.OutPayload)
+	if !ok {
+		return
+	}
+
+	compressed := out.CompressedLength
+	if compressed == 0 {
+		compressed = out.Length
+	}
+
+	payloadBytesSent.WithLabelValues("raw").Add(float64(out.Length))
+	payloadBytesSent.WithLabelValues("compressed").Add(float64(compressed))
+}
+
+func (PayloadStatsHandler) TagConn(ctx context.Context, _ *stats.ConnTagInfo) context.Context {
+	return ctx
+}
+
+func (PayloadStatsHandler) HandleConn(context.Context, stats.ConnStats) {}
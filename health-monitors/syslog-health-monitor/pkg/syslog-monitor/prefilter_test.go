@@ -0,0 +1,122 @@
+// Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package syslogmonitor
+
+import (
+	"testing"
+	"time"
+
+	"github.com/nvidia/nvsentinel/health-monitors/syslog-health-monitor/pkg/corpus"
+	"github.com/nvidia/nvsentinel/health-monitors/syslog-health-monitor/pkg/gpufallen"
+	"github.com/nvidia/nvsentinel/health-monitors/syslog-health-monitor/pkg/sxid"
+	"github.com/nvidia/nvsentinel/health-monitors/syslog-health-monitor/pkg/types"
+	"github.com/nvidia/nvsentinel/health-monitors/syslog-health-monitor/pkg/xid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// noiseLines are typical journal messages that are irrelevant to all handlers.
+var noiseLines = []string{
+	"systemd[1]: Started Session 42 of user root.",
+	"kernel: pcieport 0000:00:01.0: AER: Corrected error received: 0000:3b:00.0",
+	"containerd[1234]: time=\"2025-01-01T00:00:00Z\" level=info msg=\"StartContainer returns successfully\"",
+	"kubelet[5678]: I0101 00:00:00.000000    5678 kubelet.go:2410] \"SyncLoop (PLEG): event for pod\"",
+	"[12345.678901] IPv6: ADDRCONF(NETDEV_CHANGE): cali1234567890a: link becomes ready",
+}
+
+func newPrefilterTestMonitor(t testing.TB) *SyslogMonitor {
+	t.Helper()
+
+	xidHandler, err := xid.NewXIDHandler(TEST_NODE, TEST_AGENT, TEST_COMPONENT, XIDErrorCheck, "",
+		"/nonexistent/metadata.json")
+	require.NoError(t, err)
+
+	sxidHandler, err := sxid.NewSXIDHandler(TEST_NODE, TEST_AGENT, TEST_COMPONENT, SXIDErrorCheck,
+		"/nonexistent/metadata.json")
+	require.NoError(t, err)
+
+	gpuFallenHandler, err := gpufallen.NewGPUFallenHandler(TEST_NODE, TEST_AGENT, TEST_COMPONENT, GPUFallenOffCheck)
+	require.NoError(t, err)
+
+	return &SyslogMonitor{
+		nodeName: TEST_NODE,
+		pcClient: &mockPlatformConnectorClient{},
+		checkToHandlerMap: map[string]types.Handler{
+			XIDErrorCheck:     xidHandler,
+			SXIDErrorCheck:    sxidHandler,
+			GPUFallenOffCheck: gpuFallenHandler,
+		},
+	}
+}
+
+func TestPrefilterKeepsRelevantLines(t *testing.T) {
+	sm := newPrefilterTestMonitor(t)
+
+	// Every corpus line a handler produces events for must pass its prefilter
+	for checkName, handler := range sm.checkToHandlerMap {
+		prefilter, ok := handler.(types.Prefilter)
+		require.True(t, ok, "handler of %s should implement Prefilter", checkName)
+
+		for _, line := range corpus.Lines() {
+			events, _ := handler.ProcessLine(line)
+			if events != nil {
+				assert.True(t, prefilter.Prefilter(line), "%s: %q", checkName, line)
+			}
+		}
+
+		for _, line := range noiseLines {
+			assert.False(t, prefilter.Prefilter(line), "%s: %q", checkName, line)
+		}
+	}
+}
+
+func TestNonMatchingLinesDoNotAllocate(t *testing.T) {
+	sm := newPrefilterTestMonitor(t)
+
+	for checkName := range sm.checkToHandlerMap {
+		check := CheckDefinition{Name: checkName}
+
+		allocs := testing.AllocsPerRun(100, func() {
+			for _, line := range noiseLines {
+				_, _ = sm.handleLine(check, line, time.Now, false)
+			}
+		})
+		assert.Zero(t, allocs, "%s", checkName)
+	}
+}
+
+func BenchmarkNonMatchingLines(b *testing.B) {
+	sm := newPrefilterTestMonitor(b)
+
+	for checkName, handler := range sm.checkToHandlerMap {
+		check := CheckDefinition{Name: checkName}
+
+		b.Run(checkName+"/prefiltered", func(b *testing.B) {
+			b.ReportAllocs()
+
+			for i := 0; b.Loop(); i++ {
+				_, _ = sm.handleLine(check, noiseLines[i%len(noiseLines)], time.Now, false)
+			}
+		})
+
+		b.Run(checkName+"/unfiltered", func(b *testing.B) {
+			b.ReportAllocs()
+
+			for i := 0; b.Loop(); i++ {
+				_, _ = handler.ProcessLine(noiseLines[i%len(noiseLines)])
+			}
+		})
+	}
+}
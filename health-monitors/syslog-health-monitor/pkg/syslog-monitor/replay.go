@@ -0,0 +1,106 @@
+// Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package syslogmonitor
+
+import (
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/nvidia/nvsentinel/data-models/pkg/model"
+	pb "github.com/nvidia/nvsentinel/data-models/pkg/protos"
+)
+
+// isReplayedEntry reports whether the current journal entry was already read
+// before the monitor restarted. The persisted cursor is only saved at the end of
+// a check run, so after a restart the lines since that cursor are read again: an
+// entry is replayed if events were already emitted for it or a later entry, or if
+// it is from a previous boot.
+func (sm *SyslogMonitor) isReplayedEntry(journal Journal, checkName string) bool {
+	sm.mu.Lock()
+	currentBootID := sm.currentBootID
+	lastEventTime, ok := sm.checkLastEventTimes[checkName]
+	sm.mu.Unlock()
+
+	if entryBootID, err := journal.GetData(FieldBootID); err == nil {
+		entryBootID = strings.TrimPrefix(entryBootID, FieldBootID+"=")
+		if entryBootID != "" && currentBootID != "" && entryBootID != currentBootID {
+			return true
+		}
+	}
+
+	if !ok {
+		return false
+	}
+
+	realtime, err := journal.GetRealtimeUsec()
+	if err != nil {
+		slog.Debug("Failed to get journal entry time", "check", checkName, "error", err)
+		return false
+	}
+
+	return realtime <= lastEventTime
+}
+
+// recordEmittedEntry persists the time of the current journal entry after events
+// were emitted for it, so the entry is recognized as replayed after a restart.
+func (sm *SyslogMonitor) recordEmittedEntry(journal Journal, checkName string) {
+	realtime, err := journal.GetRealtimeUsec()
+	if err != nil {
+		slog.Debug("Failed to get journal entry time", "check", checkName, "error", err)
+		return
+	}
+
+	sm.mu.Lock()
+
+	if realtime <= sm.checkLastEventTimes[checkName] {
+		sm.mu.Unlock()
+		return
+	}
+
+	if sm.checkLastEventTimes == nil {
+		sm.checkLastEventTimes = make(map[string]uint64)
+	}
+
+	sm.checkLastEventTimes[checkName] = realtime
+	sm.mu.Unlock()
+
+	if err := sm.saveCurrentState(); err != nil {
+		slog.Warn("Failed to save state after emitting events", "check", checkName, "error", err)
+	}
+}
+
+// markBackfilled marks the events of a replayed line as backfilled, so they are
+// treated as historical downstream.
+func markBackfilled(checkName string, healthEvents *pb.HealthEvents) {
+	for _, event := range healthEvents.Events {
+		model.MarkBackfilled(event)
+	}
+
+	backfilledEvents.WithLabelValues(checkName).Add(float64(len(healthEvents.Events)))
+}
+
+// journalEntryTime returns a function reporting the time the current entry of the
+// journal was logged, or the current time when the journal does not report it.
+func journalEntryTime(journal Journal) func() time.Time {
+	return func() time.Time {
+		realtime, err := journal.GetRealtimeUsec()
+		if err != nil || realtime == 0 {
+			return time.Now()
+		}
+
+		return time.UnixMicro(int64(realtime))
+	}
+}
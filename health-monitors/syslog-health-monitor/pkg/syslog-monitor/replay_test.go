@@ -0,0 +1,109 @@
+// Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package syslogmonitor
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/nvidia/nvsentinel/data-models/pkg/model"
+	"github.com/nvidia/nvsentinel/health-monitors/syslog-health-monitor/pkg/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newReplayTestMonitor(t *testing.T, pcClient *mockPlatformConnectorClient,
+	journal *MockJournal) (*SyslogMonitor, CheckDefinition) {
+	t.Helper()
+
+	check := CheckDefinition{Name: "mockCheck", JournalPath: TEST_JOURNAL_PATH}
+
+	factory := NewMockJournalFactory()
+	factory.JournalsByPath[check.JournalPath] = journal
+
+	return &SyslogMonitor{
+		nodeName:            TEST_NODE,
+		checks:              []CheckDefinition{check},
+		pcClient:            pcClient,
+		journalFactory:      factory,
+		currentBootID:       "boot-2",
+		stateFilePath:       filepath.Join(t.TempDir(), "state.json"),
+		checkLastCursors:    map[string]string{check.Name: "cursor-1"},
+		checkLastEventTimes: map[string]uint64{check.Name: 200},
+		coldStartChecks:     map[string]bool{check.Name: true},
+		checkToHandlerMap: map[string]types.Handler{
+			check.Name: &mockHandler{nodeName: TEST_NODE, checkName: check.Name},
+		},
+	}, check
+}
+
+func TestColdStartMarksReplayedLinesAsBackfilled(t *testing.T) {
+	pcClient := &mockPlatformConnectorClient{}
+	journal := &MockJournal{
+		Entries: []MockJournalEntry{
+			{Message: "last saved line", Cursor: "cursor-1", BootID: "boot-2", Realtime: 100},
+			// Emitted before the restart, after the last saved cursor
+			{Message: "sxid123 already emitted", Cursor: "cursor-2", BootID: "boot-2", Realtime: 200},
+			{Message: "sxid123 new", Cursor: "cursor-3", BootID: "boot-2", Realtime: 300},
+		},
+		CurrentPosition: -1,
+	}
+	sm, check := newReplayTestMonitor(t, pcClient, journal)
+
+	require.NoError(t, sm.executeCheck(check))
+	require.Len(t, pcClient.RecordedHealthEvents, 2)
+
+	assert.True(t, model.IsBackfilled(pcClient.RecordedHealthEvents[0].Events[0]))
+	assert.False(t, model.IsBackfilled(pcClient.RecordedHealthEvents[1].Events[0]))
+	assert.Equal(t, uint64(300), sm.checkLastEventTimes[check.Name])
+	assert.NotContains(t, sm.coldStartChecks, check.Name, "cold start ends after the first run")
+
+	state, err := loadState(sm.stateFilePath)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(300), state.CheckLastEventTimes[check.Name])
+}
+
+func TestColdStartMarksLinesFromPreviousBootAsBackfilled(t *testing.T) {
+	pcClient := &mockPlatformConnectorClient{}
+	journal := &MockJournal{
+		Entries: []MockJournalEntry{
+			{Message: "last saved line", Cursor: "cursor-1", BootID: "boot-1", Realtime: 100},
+			{Message: "sxid123 before reboot", Cursor: "cursor-2", BootID: "boot-1", Realtime: 250},
+		},
+		CurrentPosition: -1,
+	}
+	sm, check := newReplayTestMonitor(t, pcClient, journal)
+
+	require.NoError(t, sm.executeCheck(check))
+	require.Len(t, pcClient.RecordedHealthEvents, 1)
+	assert.True(t, model.IsBackfilled(pcClient.RecordedHealthEvents[0].Events[0]))
+}
+
+func TestNoBackfillAfterColdStart(t *testing.T) {
+	pcClient := &mockPlatformConnectorClient{}
+	journal := &MockJournal{
+		Entries: []MockJournalEntry{
+			{Message: "last saved line", Cursor: "cursor-1", BootID: "boot-2", Realtime: 100},
+			{Message: "sxid123", Cursor: "cursor-2", BootID: "boot-2", Realtime: 150},
+		},
+		CurrentPosition: -1,
+	}
+	sm, check := newReplayTestMonitor(t, pcClient, journal)
+	delete(sm.coldStartChecks, check.Name)
+
+	require.NoError(t, sm.executeCheck(check))
+	require.Len(t, pcClient.RecordedHealthEvents, 1)
+	assert.False(t, model.IsBackfilled(pcClient.RecordedHealthEvents[0].Events[0]))
+}
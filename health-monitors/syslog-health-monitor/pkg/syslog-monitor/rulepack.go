@@ -0,0 +1,137 @@
+// Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package syslogmonitor
+
+import (
+	"encoding/json"
+	"log/slog"
+	"os"
+
+	"github.com/nvidia/nvsentinel/data-models/pkg/model"
+	pb "github.com/nvidia/nvsentinel/data-models/pkg/protos"
+	"github.com/nvidia/nvsentinel/health-monitors/syslog-health-monitor/pkg/rulepack"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// EnableRulePacks selects the rule pack matching the GPUs of the node from packs.
+// The selection happens once the GPU metadata is available; until then, and when
+// no pack matches, events are sent unchanged.
+func (sm *SyslogMonitor) EnableRulePacks(packs []rulepack.Pack) {
+	sm.localRulePacks = packs
+	sm.rulePacks = packs
+}
+
+// selectRulePack resolves the rule pack of the node from the GPU metadata, applies
+// its thresholds and reports topology mismatches. It is retried on every run until
+// the metadata can be read.
+func (sm *SyslogMonitor) selectRulePack() {
+	if len(sm.rulePacks) == 0 || sm.rulePackResolved {
+		return
+	}
+
+	data, err := os.ReadFile(sm.metadataPath)
+	if err != nil {
+		slog.Debug("GPU metadata not available yet, deferring rule pack selection", "error", err)
+		return
+	}
+
+	var metadata model.GPUMetadata
+	if err := json.Unmarshal(data, &metadata); err != nil {
+		slog.Warn("Failed to parse GPU metadata, deferring rule pack selection", "error", err)
+		return
+	}
+
+	sm.rulePackResolved = true
+
+	// A previously selected pack is replaced when a new bundle is applied
+	rulePackTopologyMismatches.DeletePartialMatch(prometheus.Labels{"node": sm.nodeName})
+
+	if sm.stormBreaker != nil {
+		sm.stormBreaker.threshold = sm.stormBreaker.configuredThreshold
+	}
+
+	sm.setRulePack(rulepack.Select(sm.rulePacks, &metadata))
+	if sm.rulePack == nil {
+		slog.Info("No rule pack matches the GPUs of the node, using defaults")
+		return
+	}
+
+	slog.Info("Selected rule pack", "pack", sm.rulePack.Name, "version", sm.rulePack.Version,
+		"bundle", sm.rulePackBundle)
+
+	if threshold := sm.rulePack.Thresholds.EventStormPerMinute; threshold > 0 && sm.stormBreaker != nil {
+		sm.stormBreaker.threshold = threshold
+	}
+
+	mismatches := sm.rulePack.CheckTopology(&metadata)
+	for _, mismatch := range mismatches {
+		slog.Warn("Node topology does not match the rule pack", "pack", sm.rulePack.Name, "mismatch", mismatch)
+	}
+
+	rulePackTopologyMismatches.WithLabelValues(sm.nodeName, sm.rulePack.Name).Set(float64(len(mismatches)))
+}
+
+// applyRulePack downgrades events with error codes that are known-benign on the
+// SKU of the node to informational events.
+func (sm *SyslogMonitor) applyRulePack(checkName string, healthEvents *pb.HealthEvents) {
+	if sm.rulePack == nil {
+		return
+	}
+
+	if benign := downgradeBenignEvents(sm.rulePack, checkName, healthEvents); benign > 0 {
+		rulePackBenignEvents.WithLabelValues(checkName, sm.rulePack.Name).Add(float64(benign))
+	}
+}
+
+// downgradeBenignEvents downgrades the events with error codes benign in the pack
+// and returns their number.
+func downgradeBenignEvents(pack *rulepack.Pack, checkName string, healthEvents *pb.HealthEvents) int {
+	benign := 0
+
+	for _, event := range healthEvents.Events {
+		if len(event.ErrorCode) == 0 || !pack.IsBenign(checkName, event.ErrorCode[0]) {
+			continue
+		}
+
+		event.IsFatal = false
+		event.RecommendedAction = pb.RecommendedAction_NONE
+
+		if event.Metadata == nil {
+			event.Metadata = make(map[string]string)
+		}
+
+		event.Metadata[model.MetadataRulePack] = pack.Name
+		benign++
+	}
+
+	return benign
+}
+
+// setRulePack sets the pack selected for the node. The pack is only read without
+// the lock by the checks, which run in the same cycle as the selection.
+func (sm *SyslogMonitor) setRulePack(pack *rulepack.Pack) {
+	sm.rulePackMu.Lock()
+	defer sm.rulePackMu.Unlock()
+
+	sm.rulePack = pack
+}
+
+// selectedRulePack returns the pack selected for the node, nil when none is.
+func (sm *SyslogMonitor) selectedRulePack() *rulepack.Pack {
+	sm.rulePackMu.RLock()
+	defer sm.rulePackMu.RUnlock()
+
+	return sm.rulePack
+}
@@ -0,0 +1,123 @@
+// Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package syslogmonitor
+
+import (
+	"context"
+	"crypto/ed25519"
+	"log/slog"
+	"time"
+
+	"github.com/nvidia/nvsentinel/data-models/pkg/model"
+	pb "github.com/nvidia/nvsentinel/data-models/pkg/protos"
+	"github.com/nvidia/nvsentinel/health-monitors/syslog-health-monitor/pkg/rulepack"
+)
+
+const rulePackSyncTimeout = 30 * time.Second
+
+// EnableRulePackSync fetches the rule pack bundle rolled out to the node from the
+// distribution service every interval. Bundles must be signed with the key
+// matching publicKey; their packs replace the packs of the same name passed to
+// EnableRulePacks, which are applied alone again when no bundle is rolled out to
+// the node anymore.
+func (sm *SyslogMonitor) EnableRulePackSync(client pb.RulePackDistributorClient, publicKey ed25519.PublicKey,
+	interval time.Duration) {
+	sm.rulePackClient = client
+	sm.rulePackPublicKey = publicKey
+	sm.rulePackSyncInterval = interval
+}
+
+// syncRulePacks fetches the rule pack bundle when a sync is due and applies it when
+// it changed. The selected pack is resolved again on the same run. Failed syncs
+// are logged and retried on the next interval, keeping the current packs.
+func (sm *SyslogMonitor) syncRulePacks(now time.Time) {
+	if sm.rulePackClient == nil || now.Sub(sm.lastRulePackSync) < sm.rulePackSyncInterval {
+		return
+	}
+
+	sm.lastRulePackSync = now
+
+	ctx, cancel := context.WithTimeout(context.Background(), rulePackSyncTimeout)
+	defer cancel()
+
+	response, err := sm.rulePackClient.GetRulePackBundle(ctx, &pb.RulePackBundleRequest{
+		NodeName:             sm.nodeName,
+		Agent:                sm.defaultAgentName,
+		AgentVersion:         sm.version,
+		MaxSchemaVersion:     rulepack.SchemaVersion,
+		CurrentBundleVersion: sm.rulePackBundle,
+	})
+	if err != nil {
+		slog.Warn("Failed to fetch rule pack bundle", "error", err)
+		rulePackBundleSyncs.WithLabelValues("failed").Inc()
+
+		return
+	}
+
+	switch bundle := response.GetBundle(); {
+	case response.GetNotModified():
+		rulePackBundleSyncs.WithLabelValues("not_modified").Inc()
+	case bundle == nil:
+		if sm.rulePackBundle == "" {
+			rulePackBundleSyncs.WithLabelValues("not_modified").Inc()
+			return
+		}
+
+		slog.Info("No rule pack bundle is rolled out to the node, applying the local rule packs",
+			"previous", sm.rulePackBundle)
+		sm.applyRulePackBundle(sm.localRulePacks, "")
+		rulePackBundleSyncs.WithLabelValues("reverted").Inc()
+	default:
+		if err := model.VerifyRulePackBundle(bundle, sm.rulePackPublicKey); err != nil {
+			slog.Error("Rejected rule pack bundle", "bundle", bundle.GetVersion(), "error", err)
+			rulePackBundleSyncs.WithLabelValues("rejected").Inc()
+
+			return
+		}
+
+		files := make(map[string][]byte, len(bundle.GetFiles()))
+		for _, file := range bundle.GetFiles() {
+			files[file.GetName()] = file.GetContent()
+		}
+
+		packs, err := rulepack.Overlay(sm.localRulePacks, files)
+		if err != nil {
+			slog.Error("Rejected rule pack bundle", "bundle", bundle.GetVersion(), "error", err)
+			rulePackBundleSyncs.WithLabelValues("rejected").Inc()
+
+			return
+		}
+
+		slog.Info("Applying rule pack bundle", "bundle", bundle.GetVersion(), "previous", sm.rulePackBundle)
+		sm.applyRulePackBundle(packs, bundle.GetVersion())
+		rulePackBundleSyncs.WithLabelValues("applied").Inc()
+	}
+}
+
+// applyRulePackBundle replaces the packs to select from, so the pack of the node
+// is selected again.
+func (sm *SyslogMonitor) applyRulePackBundle(packs []rulepack.Pack, version string) {
+	sm.rulePacks = packs
+	sm.setRulePack(nil)
+	sm.rulePackResolved = false
+
+	rulePackBundleApplied.Reset()
+
+	if version != "" {
+		rulePackBundleApplied.WithLabelValues(version).Set(1)
+	}
+
+	sm.rulePackBundle = version
+}
@@ -0,0 +1,132 @@
+// Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package syslogmonitor
+
+import (
+	"context"
+	"crypto/ed25519"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/nvidia/nvsentinel/data-models/pkg/model"
+	pb "github.com/nvidia/nvsentinel/data-models/pkg/protos"
+	"github.com/nvidia/nvsentinel/health-monitors/syslog-health-monitor/pkg/rulepack"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+)
+
+type fakeRulePackDistributor struct {
+	response *pb.RulePackBundleResponse
+	requests []*pb.RulePackBundleRequest
+}
+
+func (f *fakeRulePackDistributor) GetRulePackBundle(_ context.Context, request *pb.RulePackBundleRequest,
+	_ ...grpc.CallOption) (*pb.RulePackBundleResponse, error) {
+	f.requests = append(f.requests, request)
+	return f.response, nil
+}
+
+func TestSyncRulePacks(t *testing.T) {
+	publicKey, privateKey, err := ed25519.GenerateKey(nil)
+	require.NoError(t, err)
+
+	metadataPath := filepath.Join(t.TempDir(), "gpu_metadata.json")
+	require.NoError(t, os.WriteFile(metadataPath,
+		[]byte(`{"gpus": [{"gpu_id": 0, "device_name": "NVIDIA GB200"}]}`), 0o600))
+
+	distributor := &fakeRulePackDistributor{response: &pb.RulePackBundleResponse{}}
+
+	sm := &SyslogMonitor{nodeName: "node1", defaultAgentName: TEST_AGENT, metadataPath: metadataPath}
+	sm.EnableEventStormBreaker(100, 5, time.Minute)
+	sm.EnableHeartbeat("v0.6.0", 0)
+	sm.EnableRulePacks([]rulepack.Pack{{
+		Name:        "GB200",
+		Version:     "1",
+		DeviceNames: []string{"GB200"},
+		Thresholds:  rulepack.Thresholds{EventStormPerMinute: 200},
+	}})
+	sm.EnableRulePackSync(distributor, publicKey, time.Minute)
+
+	now := time.Now()
+	sm.syncRulePacks(now)
+	sm.selectRulePack()
+	require.NotNil(t, sm.rulePack)
+	assert.Equal(t, "1", sm.rulePack.Version)
+	assert.Equal(t, 200, sm.stormBreaker.threshold)
+
+	require.Len(t, distributor.requests, 1)
+	assert.Equal(t, &pb.RulePackBundleRequest{
+		NodeName:         "node1",
+		Agent:            TEST_AGENT,
+		AgentVersion:     "v0.6.0",
+		MaxSchemaVersion: rulepack.SchemaVersion,
+	}, distributor.requests[0])
+
+	bundle := &pb.RulePackBundle{Version: "2", SchemaVersion: 1, Files: []*pb.RulePackFile{{
+		Name: "gb200.toml",
+		Content: []byte(`
+name = "GB200"
+version = "2"
+device_names = ["GB200"]
+`),
+	}}}
+	model.SignRulePackBundle(bundle, privateKey)
+	distributor.response = &pb.RulePackBundleResponse{Bundle: bundle}
+
+	// not due yet
+	sm.syncRulePacks(now.Add(30 * time.Second))
+	assert.Len(t, distributor.requests, 1)
+
+	now = now.Add(time.Minute)
+	sm.syncRulePacks(now)
+	assert.Equal(t, "2", sm.rulePackBundle)
+	assert.Nil(t, sm.rulePack)
+
+	sm.selectRulePack()
+	require.NotNil(t, sm.rulePack)
+	assert.Equal(t, "2", sm.rulePack.Version)
+	// the bundle pack has no threshold, so the configured one applies again
+	assert.Equal(t, 100, sm.stormBreaker.threshold)
+
+	// a bundle with an invalid signature is rejected
+	tampered := &pb.RulePackBundle{Version: "3", SchemaVersion: 1, Files: bundle.Files, Signature: bundle.Signature}
+	distributor.response = &pb.RulePackBundleResponse{Bundle: tampered}
+
+	now = now.Add(time.Minute)
+	sm.syncRulePacks(now)
+	assert.Equal(t, "2", sm.rulePackBundle)
+	assert.Equal(t, "2", distributor.requests[len(distributor.requests)-1].CurrentBundleVersion)
+
+	distributor.response = &pb.RulePackBundleResponse{NotModified: true}
+
+	now = now.Add(time.Minute)
+	sm.syncRulePacks(now)
+	assert.True(t, sm.rulePackResolved)
+
+	// no bundle is rolled out to the node anymore
+	distributor.response = &pb.RulePackBundleResponse{}
+
+	now = now.Add(time.Minute)
+	sm.syncRulePacks(now)
+	assert.Empty(t, sm.rulePackBundle)
+
+	sm.selectRulePack()
+	require.NotNil(t, sm.rulePack)
+	assert.Equal(t, "1", sm.rulePack.Version)
+	assert.Equal(t, 200, sm.stormBreaker.threshold)
+}
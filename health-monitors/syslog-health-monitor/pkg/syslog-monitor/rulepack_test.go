@@ -0,0 +1,71 @@
+// Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package syslogmonitor
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	pb "github.com/nvidia/nvsentinel/data-models/pkg/protos"
+	"github.com/nvidia/nvsentinel/health-monitors/syslog-health-monitor/pkg/rulepack"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRulePack(t *testing.T) {
+	metadataPath := filepath.Join(t.TempDir(), "gpu_metadata.json")
+	sm := &SyslogMonitor{nodeName: "node1", metadataPath: metadataPath}
+	sm.EnableEventStormBreaker(100, 5, time.Minute)
+	sm.EnableRulePacks([]rulepack.Pack{{
+		Name:             "GB200",
+		DeviceNames:      []string{"GB200"},
+		BenignErrorCodes: map[string][]string{XIDErrorCheck: {"63"}},
+		Thresholds:       rulepack.Thresholds{EventStormPerMinute: 200},
+	}})
+
+	// selection is deferred until the metadata collector wrote the metadata
+	sm.selectRulePack()
+	assert.False(t, sm.rulePackResolved)
+
+	require.NoError(t, os.WriteFile(metadataPath,
+		[]byte(`{"gpus": [{"gpu_id": 0, "device_name": "NVIDIA GB200"}]}`), 0o600))
+
+	sm.selectRulePack()
+	require.NotNil(t, sm.rulePack)
+	assert.Equal(t, "GB200", sm.rulePack.Name)
+	assert.Equal(t, 200, sm.stormBreaker.threshold)
+
+	events := &pb.HealthEvents{Events: []*pb.HealthEvent{
+		{ErrorCode: []string{"63"}, IsFatal: true, RecommendedAction: pb.RecommendedAction_CONTACT_SUPPORT},
+		{ErrorCode: []string{"79"}, IsFatal: true, RecommendedAction: pb.RecommendedAction_RESTART_BM},
+	}}
+
+	sm.applyRulePack(XIDErrorCheck, events)
+
+	assert.False(t, events.Events[0].IsFatal)
+	assert.Equal(t, pb.RecommendedAction_NONE, events.Events[0].RecommendedAction)
+	assert.Equal(t, "GB200", events.Events[0].Metadata["rule_pack"])
+
+	assert.True(t, events.Events[1].IsFatal)
+	assert.Equal(t, pb.RecommendedAction_RESTART_BM, events.Events[1].RecommendedAction)
+	assert.Nil(t, events.Events[1].Metadata)
+
+	// the same code is not benign for another check
+	sxidEvents := &pb.HealthEvents{Events: []*pb.HealthEvent{{ErrorCode: []string{"63"}, IsFatal: true}}}
+	sm.applyRulePack(SXIDErrorCheck, sxidEvents)
+	assert.True(t, sxidEvents.Events[0].IsFatal)
+}
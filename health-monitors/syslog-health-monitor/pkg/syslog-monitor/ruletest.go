@@ -0,0 +1,203 @@
+// Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package syslogmonitor
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"slices"
+
+	"github.com/nvidia/nvsentinel/health-monitors/syslog-health-monitor/pkg/types"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+const (
+	// RuleTestPath is where the rule test handler is served
+	RuleTestPath = "/rules/test"
+
+	maxRuleTestLines     = 10000
+	maxRuleTestBodyBytes = 4 << 20
+)
+
+// RuleTestRequest holds the raw journal messages to run through the checks.
+type RuleTestRequest struct {
+	Lines []string `json:"lines"`
+	// Checks restricts the test to these checks, all checks of the monitor if empty
+	Checks []string `json:"checks,omitempty"`
+}
+
+// RuleTestResponse reports the events each line would produce.
+type RuleTestResponse struct {
+	// RulePack is the rule pack selected for the node, empty when none is
+	RulePack string       `json:"rulePack,omitempty"`
+	Lines    []LineResult `json:"lines"`
+}
+
+// LineResult holds the checks that matched a line.
+type LineResult struct {
+	Line    string       `json:"line"`
+	Matches []CheckMatch `json:"matches"`
+}
+
+// CheckMatch holds the events a check produced for a line, after the rule pack of
+// the node was applied.
+type CheckMatch struct {
+	Check  string            `json:"check"`
+	Events []json.RawMessage `json:"events,omitempty"`
+	Error  string            `json:"error,omitempty"`
+}
+
+// TestRules runs the lines in order through fresh handlers of the checks and the
+// rule pack of the node, without sending any event. Handlers are not shared with
+// the monitor, so handler state such as the PCI to GPU UUID mapping only carries
+// over between the lines of the request.
+func (sm *SyslogMonitor) TestRules(request RuleTestRequest) (*RuleTestResponse, error) {
+	if len(request.Lines) == 0 {
+		return nil, errors.New("no lines to test")
+	}
+
+	if len(request.Lines) > maxRuleTestLines {
+		return nil, fmt.Errorf("%d lines to test, at most %d are allowed", len(request.Lines), maxRuleTestLines)
+	}
+
+	handlers, err := sm.ruleTestHandlers(request.Checks)
+	if err != nil {
+		return nil, err
+	}
+
+	defer func() {
+		for _, handler := range handlers {
+			if closer, ok := handler.handler.(interface{ Close() }); ok {
+				closer.Close()
+			}
+		}
+	}()
+
+	response := &RuleTestResponse{Lines: make([]LineResult, 0, len(request.Lines))}
+
+	pack := sm.selectedRulePack()
+	if pack != nil {
+		response.RulePack = pack.Name
+	}
+
+	for _, line := range request.Lines {
+		result := LineResult{Line: line, Matches: []CheckMatch{}}
+
+		for _, check := range handlers {
+			if prefilter, ok := check.handler.(types.Prefilter); ok && !prefilter.Prefilter(line) {
+				continue
+			}
+
+			healthEvents, err := check.handler.ProcessLine(line)
+			if err != nil {
+				result.Matches = append(result.Matches, CheckMatch{Check: check.name, Error: err.Error()})
+				continue
+			}
+
+			if healthEvents == nil || len(healthEvents.Events) == 0 {
+				continue
+			}
+
+			if pack != nil {
+				downgradeBenignEvents(pack, check.name, healthEvents)
+			}
+
+			match := CheckMatch{Check: check.name}
+
+			for _, event := range healthEvents.Events {
+				data, err := protojson.Marshal(event)
+				if err != nil {
+					return nil, fmt.Errorf("failed to marshal event of check %s: %w", check.name, err)
+				}
+
+				match.Events = append(match.Events, data)
+			}
+
+			result.Matches = append(result.Matches, match)
+		}
+
+		response.Lines = append(response.Lines, result)
+	}
+
+	return response, nil
+}
+
+type ruleTestHandler struct {
+	name    string
+	handler types.Handler
+}
+
+// ruleTestHandlers creates handlers of the requested checks in the order of the
+// checks of the monitor.
+func (sm *SyslogMonitor) ruleTestHandlers(checkNames []string) ([]ruleTestHandler, error) {
+	for _, name := range checkNames {
+		if _, ok := sm.checkToHandlerMap[name]; !ok {
+			return nil, fmt.Errorf("check %s is not enabled", name)
+		}
+	}
+
+	var handlers []ruleTestHandler
+
+	for _, check := range sm.checks {
+		if _, ok := sm.checkToHandlerMap[check.Name]; !ok {
+			continue
+		}
+
+		if len(checkNames) > 0 && !slices.Contains(checkNames, check.Name) {
+			continue
+		}
+
+		handler, err := sm.newHandler(check.Name)
+		if err != nil {
+			return nil, err
+		}
+
+		handlers = append(handlers, ruleTestHandler{name: check.Name, handler: handler})
+	}
+
+	return handlers, nil
+}
+
+// RuleTestHandler serves POST /rules/test with a RuleTestRequest body.
+func (sm *SyslogMonitor) RuleTestHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			return
+		}
+
+		var request RuleTestRequest
+
+		if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxRuleTestBodyBytes)).Decode(&request); err != nil {
+			http.Error(w, fmt.Sprintf("invalid request: %v", err), http.StatusBadRequest)
+			return
+		}
+
+		response, err := sm.TestRules(request)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+
+		if err := json.NewEncoder(w).Encode(response); err != nil {
+			slog.Error("Failed to encode rule test response", "error", err)
+		}
+	})
+}
@@ -0,0 +1,124 @@
+// Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package syslogmonitor
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+
+	pb "github.com/nvidia/nvsentinel/data-models/pkg/protos"
+	"github.com/nvidia/nvsentinel/health-monitors/syslog-health-monitor/pkg/rulepack"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+func newRuleTestMonitor(t *testing.T) *SyslogMonitor {
+	t.Helper()
+
+	sm, err := NewSyslogMonitorWithFactory(
+		TEST_NODE,
+		[]CheckDefinition{{Name: XIDErrorCheck}, {Name: SXIDErrorCheck}},
+		&mockPlatformConnectorClient{},
+		TEST_AGENT,
+		TEST_COMPONENT,
+		"60s",
+		filepath.Join(t.TempDir(), "state.json"),
+		NewMockJournalFactory(),
+		"",
+		filepath.Join(t.TempDir(), "gpu_metadata.json"),
+	)
+	require.NoError(t, err)
+
+	return sm
+}
+
+func TestTestRules(t *testing.T) {
+	sm := newRuleTestMonitor(t)
+
+	response, err := sm.TestRules(RuleTestRequest{Lines: []string{
+		"NVRM: Xid (PCI:0000:b3:00.0): 79, pid=1234, name=process, Ch 00000001",
+		"systemd[1]: Started Session 1 of user root.",
+	}})
+	require.NoError(t, err)
+	require.Len(t, response.Lines, 2)
+	assert.Empty(t, response.RulePack)
+
+	matches := response.Lines[0].Matches
+	require.Len(t, matches, 1)
+	assert.Equal(t, XIDErrorCheck, matches[0].Check)
+	require.Len(t, matches[0].Events, 1)
+
+	var event pb.HealthEvent
+	require.NoError(t, protojson.Unmarshal(matches[0].Events[0], &event))
+	assert.Equal(t, []string{"79"}, event.ErrorCode)
+	assert.True(t, event.IsFatal)
+
+	assert.Empty(t, response.Lines[1].Matches)
+
+	t.Run("rule pack of the node is applied", func(t *testing.T) {
+		sm.setRulePack(&rulepack.Pack{Name: "GB200", BenignErrorCodes: map[string][]string{XIDErrorCheck: {"79"}}})
+		defer sm.setRulePack(nil)
+
+		response, err := sm.TestRules(RuleTestRequest{
+			Lines:  []string{"NVRM: Xid (PCI:0000:b3:00.0): 79, pid=1234, name=process, Ch 00000001"},
+			Checks: []string{XIDErrorCheck},
+		})
+		require.NoError(t, err)
+		assert.Equal(t, "GB200", response.RulePack)
+
+		var event pb.HealthEvent
+		require.NoError(t, protojson.Unmarshal(response.Lines[0].Matches[0].Events[0], &event))
+		assert.False(t, event.IsFatal)
+		assert.Equal(t, pb.RecommendedAction_NONE, event.RecommendedAction)
+		assert.Equal(t, "GB200", event.Metadata["rule_pack"])
+	})
+
+	t.Run("invalid requests", func(t *testing.T) {
+		_, err := sm.TestRules(RuleTestRequest{})
+		assert.Error(t, err)
+
+		_, err = sm.TestRules(RuleTestRequest{Lines: []string{"line"}, Checks: []string{GPUFallenOffCheck}})
+		assert.ErrorContains(t, err, "not enabled")
+	})
+}
+
+func TestRuleTestHandler(t *testing.T) {
+	handler := newRuleTestMonitor(t).RuleTestHandler()
+
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, RuleTestPath, nil))
+	assert.Equal(t, http.StatusMethodNotAllowed, recorder.Code)
+
+	recorder = httptest.NewRecorder()
+	handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodPost, RuleTestPath, bytes.NewBufferString("{")))
+	assert.Equal(t, http.StatusBadRequest, recorder.Code)
+
+	body, err := json.Marshal(RuleTestRequest{Lines: []string{"NVRM: Xid (PCI:0000:b3:00.0): 79, pid=1234"}})
+	require.NoError(t, err)
+
+	recorder = httptest.NewRecorder()
+	handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodPost, RuleTestPath, bytes.NewReader(body)))
+	require.Equal(t, http.StatusOK, recorder.Code)
+
+	var response RuleTestResponse
+	require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &response))
+	require.Len(t, response.Lines, 1)
+	assert.Len(t, response.Lines[0].Matches, 1)
+}
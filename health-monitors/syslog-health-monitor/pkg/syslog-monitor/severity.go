@@ -0,0 +1,108 @@
+// Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package syslogmonitor
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/nvidia/nvsentinel/data-models/pkg/model"
+	pb "github.com/nvidia/nvsentinel/data-models/pkg/protos"
+)
+
+// Severity ranks events for the minimum severity shipped to the platform connector.
+type Severity int
+
+// Severities of the events, from the least to the most severe.
+const (
+	// SeverityInfo events are not fatal and recommend no action
+	SeverityInfo Severity = iota
+	// SeverityWarning events are not fatal but recommend an action
+	SeverityWarning
+	// SeverityFatal events are fatal
+	SeverityFatal
+)
+
+var severityNames = map[Severity]string{
+	SeverityInfo:    "info",
+	SeverityWarning: "warning",
+	SeverityFatal:   "fatal",
+}
+
+func (s Severity) String() string {
+	return severityNames[s]
+}
+
+// ParseSeverity parses info, warning or fatal.
+func ParseSeverity(name string) (Severity, error) {
+	for severity, severityName := range severityNames {
+		if strings.EqualFold(name, severityName) {
+			return severity, nil
+		}
+	}
+
+	return SeverityInfo, fmt.Errorf("invalid severity %q, expected info, warning or fatal", name)
+}
+
+// eventSeverity returns the severity of an unhealthy event.
+func eventSeverity(event *pb.HealthEvent) Severity {
+	switch {
+	case event.IsFatal:
+		return SeverityFatal
+	case event.RecommendedAction != pb.RecommendedAction_NONE:
+		return SeverityWarning
+	default:
+		return SeverityInfo
+	}
+}
+
+// EnableSeverityFilter only ships the events of at least the minimum severity to
+// the platform connector, so that cost-sensitive deployments centralize the
+// actionable events only. All events are still retained in the local event
+// buffer. Healthy events are always shipped, since they clear the conditions
+// of the node.
+func (sm *SyslogMonitor) EnableSeverityFilter(minimum Severity) {
+	sm.minShippedSeverity = minimum
+}
+
+// shippedEvents records the events in the local event buffer and returns those
+// to ship, nil if none is.
+func (sm *SyslogMonitor) shippedEvents(checkName string, healthEvents *pb.HealthEvents) *pb.HealthEvents {
+	shipped := make([]*pb.HealthEvent, 0, len(healthEvents.Events))
+
+	for _, event := range healthEvents.Events {
+		// The retained event keeps the ID it is shipped with
+		model.AssignEventID(event)
+
+		ship := event.IsHealthy || eventSeverity(event) >= sm.minShippedSeverity
+
+		sm.localEvents.add(checkName, event, ship)
+
+		if !ship {
+			filteredEvents.WithLabelValues(checkName, eventSeverity(event).String()).Inc()
+			continue
+		}
+
+		shipped = append(shipped, event)
+	}
+
+	if len(shipped) == 0 {
+		return nil
+	}
+
+	healthEvents.Events = shipped
+
+	return healthEvents
+}
@@ -0,0 +1,86 @@
+// Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package syslogmonitor
+
+import (
+	"encoding/json"
+	"testing"
+
+	pb "github.com/nvidia/nvsentinel/data-models/pkg/protos"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseSeverity(t *testing.T) {
+	for name, expected := range map[string]Severity{
+		"info": SeverityInfo, "WARNING": SeverityWarning, "Fatal": SeverityFatal,
+	} {
+		severity, err := ParseSeverity(name)
+		require.NoError(t, err)
+		assert.Equal(t, expected, severity)
+	}
+
+	_, err := ParseSeverity("critical")
+	assert.Error(t, err)
+}
+
+func TestSeverityFilter(t *testing.T) {
+	pcClient := &mockPlatformConnectorClient{}
+	sm := &SyslogMonitor{nodeName: TEST_NODE, pcClient: pcClient}
+	sm.EnableLocalEvents(1)
+	sm.EnableSeverityFilter(SeverityWarning)
+
+	events := &pb.HealthEvents{Version: 1, Events: []*pb.HealthEvent{
+		{CheckName: "check", Message: "info", RecommendedAction: pb.RecommendedAction_NONE},
+		{CheckName: "check", Message: "warning", RecommendedAction: pb.RecommendedAction_COMPONENT_RESET},
+		{CheckName: "check", Message: "fatal", IsFatal: true, RecommendedAction: pb.RecommendedAction_RESTART_BM},
+		{CheckName: "check", Message: "recovered", IsHealthy: true},
+	}}
+
+	require.NoError(t, sm.emit("check", events))
+
+	var shipped []string
+
+	for _, healthEvents := range pcClient.RecordedHealthEvents {
+		for _, event := range healthEvents.Events {
+			shipped = append(shipped, event.Message)
+		}
+	}
+
+	assert.ElementsMatch(t, []string{"warning", "fatal", "recovered"}, shipped)
+
+	local, _ := sm.localEvents.list(localFilter{})
+	require.Len(t, local, 4, "all events are retained locally")
+	assert.False(t, local[0].Shipped)
+	assert.True(t, local[1].Shipped)
+
+	var retained struct {
+		ID      string `json:"id"`
+		Message string `json:"message"`
+	}
+
+	require.NoError(t, json.Unmarshal(local[0].Event, &retained))
+	assert.Equal(t, "info", retained.Message)
+	assert.NotEmpty(t, retained.ID, "retained events keep the ID they are shipped with")
+
+	t.Run("nothing shipped", func(t *testing.T) {
+		calls := len(pcClient.RecordedHealthEvents)
+
+		require.NoError(t, sm.emit("check", &pb.HealthEvents{Version: 1, Events: []*pb.HealthEvent{
+			{CheckName: "check", Message: "info"},
+		}}))
+		assert.Len(t, pcClient.RecordedHealthEvents, calls)
+	})
+}
@@ -0,0 +1,155 @@
+// Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package syslogmonitor
+
+import (
+	"sync"
+	"time"
+)
+
+// eventStormBackoff is the delay before each line handed to a handler while the
+// event storm breaker is open, which bounds the CPU spent parsing the storm.
+const eventStormBackoff = 10 * time.Millisecond
+
+// eventStormBreaker is a per-node circuit breaker that trips when the node keeps
+// producing more than threshold events per minute for sustainedMinutes
+// consecutive minutes. While open, handler processing backs off and non-fatal
+// events are suppressed, fatal and healthy events are always sent; the breaker
+// closes again after cooldown. It is shared by all checks and safe for
+// concurrent use.
+type eventStormBreaker struct {
+	mu sync.Mutex
+
+	threshold int
+	// configuredThreshold is restored when the rule pack is replaced
+	configuredThreshold int
+	sustainedMinutes    int
+	cooldown            time.Duration
+	backoff             time.Duration
+	now                 func() time.Time
+	sleep               func(time.Duration)
+
+	windowStart   time.Time
+	windowCount   int
+	lastHotMinute time.Time
+	hotMinutes    int
+
+	open       bool
+	openedAt   time.Time
+	suppressed int
+}
+
+func newEventStormBreaker(threshold, sustainedMinutes int, cooldown time.Duration) *eventStormBreaker {
+	return &eventStormBreaker{
+		threshold:           threshold,
+		configuredThreshold: threshold,
+		sustainedMinutes:    sustainedMinutes,
+		cooldown:            cooldown,
+		backoff:             eventStormBackoff,
+		now:                 time.Now,
+		sleep:               time.Sleep,
+	}
+}
+
+// record accounts for n newly generated events and reports whether this call
+// tripped the breaker.
+func (b *eventStormBreaker) record(n int) bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.open || n <= 0 {
+		return false
+	}
+
+	minute := b.now().Truncate(time.Minute)
+	if !minute.Equal(b.windowStart) {
+		b.windowStart = minute
+		b.windowCount = 0
+	}
+
+	prev := b.windowCount
+	b.windowCount += n
+
+	if prev > b.threshold || b.windowCount <= b.threshold {
+		return false
+	}
+
+	// The current minute just crossed the threshold.
+	if !b.lastHotMinute.IsZero() && minute.Sub(b.lastHotMinute) == time.Minute {
+		b.hotMinutes++
+	} else {
+		b.hotMinutes = 1
+	}
+
+	b.lastHotMinute = minute
+
+	if b.hotMinutes < b.sustainedMinutes {
+		return false
+	}
+
+	b.open = true
+	b.openedAt = b.now()
+	b.suppressed = 0
+
+	return true
+}
+
+// suppress reports whether n non-fatal events must be suppressed because the
+// breaker is open, counting them towards the storm summary.
+func (b *eventStormBreaker) suppress(n int) bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if !b.open {
+		return false
+	}
+
+	b.suppressed += n
+
+	return true
+}
+
+// wait sleeps for the backoff delay while the breaker is open.
+func (b *eventStormBreaker) wait() {
+	b.mu.Lock()
+	open := b.open
+	b.mu.Unlock()
+
+	if open {
+		b.sleep(b.backoff)
+	}
+}
+
+// tryClose closes the breaker once the cooldown has elapsed and returns the
+// number of events suppressed while it was open.
+func (b *eventStormBreaker) tryClose() (int, bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if !b.open || b.now().Sub(b.openedAt) < b.cooldown {
+		return 0, false
+	}
+
+	suppressed := b.suppressed
+
+	b.open = false
+	b.suppressed = 0
+	b.hotMinutes = 0
+	b.lastHotMinute = time.Time{}
+	b.windowStart = time.Time{}
+	b.windowCount = 0
+
+	return suppressed, true
+}
@@ -0,0 +1,196 @@
+// Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package syslogmonitor
+
+import (
+	"testing"
+	"time"
+
+	pb "github.com/nvidia/nvsentinel/data-models/pkg/protos"
+	"github.com/nvidia/nvsentinel/health-monitors/syslog-health-monitor/pkg/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeClock struct {
+	t time.Time
+}
+
+func (c *fakeClock) now() time.Time { return c.t }
+
+func newTestBreaker(threshold, minutes int, cooldown time.Duration) (*eventStormBreaker, *fakeClock) {
+	clock := &fakeClock{t: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)}
+	b := newEventStormBreaker(threshold, minutes, cooldown)
+	b.now = clock.now
+
+	return b, clock
+}
+
+func TestEventStormBreaker(t *testing.T) {
+	t.Run("trips after sustained noisy minutes", func(t *testing.T) {
+		b, clock := newTestBreaker(3, 2, 5*time.Minute)
+
+		for i := 0; i < 4; i++ {
+			assert.False(t, b.record(1), "first noisy minute must not trip")
+		}
+
+		clock.t = clock.t.Add(time.Minute)
+
+		for i := 0; i < 3; i++ {
+			assert.False(t, b.record(1))
+		}
+
+		assert.True(t, b.record(1), "second consecutive noisy minute must trip")
+		assert.True(t, b.suppress(1))
+		assert.True(t, b.suppress(1))
+	})
+
+	t.Run("quiet minute resets the streak", func(t *testing.T) {
+		b, clock := newTestBreaker(1, 2, time.Minute)
+
+		assert.False(t, b.record(2))
+
+		clock.t = clock.t.Add(2 * time.Minute)
+
+		assert.False(t, b.record(2), "non-consecutive noisy minute must restart the streak")
+		assert.False(t, b.suppress(1))
+	})
+
+	t.Run("closes after cooldown and reports suppressed entries", func(t *testing.T) {
+		b, clock := newTestBreaker(1, 1, 5*time.Minute)
+
+		require.True(t, b.record(2))
+		b.suppress(1)
+		b.suppress(1)
+
+		clock.t = clock.t.Add(4 * time.Minute)
+		_, closed := b.tryClose()
+		assert.False(t, closed)
+
+		clock.t = clock.t.Add(time.Minute)
+		suppressed, closed := b.tryClose()
+		assert.True(t, closed)
+		assert.Equal(t, 2, suppressed)
+		assert.False(t, b.suppress(1))
+	})
+}
+
+func TestHandleSingleLineEventStorm(t *testing.T) {
+	pcClient := &mockPlatformConnectorClient{}
+	check := CheckDefinition{Name: "mockCheck"}
+	sm := &SyslogMonitor{
+		nodeName:              TEST_NODE,
+		pcClient:              pcClient,
+		defaultAgentName:      TEST_AGENT,
+		defaultComponentClass: TEST_COMPONENT,
+		checkToHandlerMap: map[string]types.Handler{
+			check.Name: &mockHandler{nodeName: TEST_NODE, checkName: check.Name, nonFatal: true},
+		},
+	}
+
+	sm.EnableEventStormBreaker(1, 1, time.Minute)
+
+	clock := &fakeClock{t: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)}
+	sm.stormBreaker.now = clock.now
+
+	require.NoError(t, sm.handleSingleLine(check, "sxid123"))
+	require.NoError(t, sm.handleSingleLine(check, "sxid123"))
+
+	// Second event trips the breaker: the event itself plus the storm summary are sent.
+	require.Len(t, pcClient.RecordedHealthEvents, 3)
+
+	storm := pcClient.RecordedHealthEvents[2].Events[0]
+	assert.Equal(t, EventStormCheck, storm.CheckName)
+	assert.Equal(t, []string{EventStormErrorCode}, storm.ErrorCode)
+	assert.False(t, storm.IsHealthy)
+	assert.Equal(t, pb.RecommendedAction_NONE, storm.RecommendedAction)
+
+	// Further non-fatal events are suppressed while the breaker is open.
+	require.NoError(t, sm.handleSingleLine(check, "sxid123"))
+	assert.Len(t, pcClient.RecordedHealthEvents, 3)
+
+	// After the cooldown a healthy storm event is sent and processing resumes.
+	clock.t = clock.t.Add(time.Minute)
+	require.NoError(t, sm.handleSingleLine(check, "sxid123"))
+	require.Len(t, pcClient.RecordedHealthEvents, 5)
+
+	recovered := pcClient.RecordedHealthEvents[3].Events[0]
+	assert.Equal(t, EventStormCheck, recovered.CheckName)
+	assert.True(t, recovered.IsHealthy)
+	assert.Contains(t, recovered.Message, "1 non-fatal events were suppressed")
+	assert.Equal(t, "mockCheck", pcClient.RecordedHealthEvents[4].Events[0].CheckName)
+}
+
+func TestHandleLineEventStormKeepsFatalEvents(t *testing.T) {
+	pcClient := &mockPlatformConnectorClient{}
+	noisy := CheckDefinition{Name: "noisyCheck"}
+	fatal := CheckDefinition{Name: "fatalCheck"}
+	sm := &SyslogMonitor{
+		nodeName: TEST_NODE,
+		pcClient: pcClient,
+		checkToHandlerMap: map[string]types.Handler{
+			noisy.Name: &mockHandler{nodeName: TEST_NODE, checkName: noisy.Name, nonFatal: true},
+			fatal.Name: &mockHandler{nodeName: TEST_NODE, checkName: fatal.Name},
+		},
+	}
+
+	sm.EnableEventStormBreaker(1, 1, time.Minute)
+
+	require.NoError(t, sm.handleSingleLine(noisy, "sxid123"))
+	require.NoError(t, sm.handleSingleLine(noisy, "sxid123"))
+	require.True(t, sm.stormBreaker.suppress(0), "the breaker is open")
+
+	sent := len(pcClient.RecordedHealthEvents)
+
+	require.NoError(t, sm.handleSingleLine(noisy, "sxid123"))
+	assert.Len(t, pcClient.RecordedHealthEvents, sent, "non-fatal events are suppressed")
+
+	require.NoError(t, sm.handleSingleLine(fatal, "sxid123"))
+	require.Len(t, pcClient.RecordedHealthEvents, sent+1, "fatal events are never suppressed")
+	assert.Equal(t, fatal.Name, pcClient.RecordedHealthEvents[sent].Events[0].CheckName)
+}
+
+func TestHandleLineEventStormKeepsHealthyEvents(t *testing.T) {
+	pcClient := &mockPlatformConnectorClient{}
+	noisy := CheckDefinition{Name: "noisyCheck"}
+	recovered := CheckDefinition{Name: "recoveredCheck"}
+	sm := &SyslogMonitor{
+		nodeName: TEST_NODE,
+		pcClient: pcClient,
+		checkToHandlerMap: map[string]types.Handler{
+			noisy.Name:     &mockHandler{nodeName: TEST_NODE, checkName: noisy.Name, nonFatal: true},
+			recovered.Name: &mockHandler{nodeName: TEST_NODE, checkName: recovered.Name, healthy: true},
+		},
+	}
+
+	sm.EnableEventStormBreaker(1, 1, time.Minute)
+	sm.stormBreaker.sleep = func(time.Duration) {}
+
+	require.NoError(t, sm.handleSingleLine(noisy, "sxid123"))
+	require.NoError(t, sm.handleSingleLine(noisy, "sxid123"))
+	require.True(t, sm.stormBreaker.suppress(0), "the breaker is open")
+
+	sent := len(pcClient.RecordedHealthEvents)
+
+	require.NoError(t, sm.handleSingleLine(recovered, "sxid123"))
+	require.Len(t, pcClient.RecordedHealthEvents, sent+1, "healthy events are never suppressed")
+	assert.True(t, pcClient.RecordedHealthEvents[sent].Events[0].IsHealthy)
+}
+
+func TestEnableEventStormBreakerDisabled(t *testing.T) {
+	sm := &SyslogMonitor{}
+	sm.EnableEventStormBreaker(0, 5, time.Minute)
+	assert.Nil(t, sm.stormBreaker)
+}
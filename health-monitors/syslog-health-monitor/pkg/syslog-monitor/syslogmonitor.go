@@ -25,10 +25,21 @@ import (
 	"strings"
 	"time"
 
+	"github.com/nvidia/nvsentinel/data-models/pkg/model"
 	pb "github.com/nvidia/nvsentinel/data-models/pkg/protos"
+	"github.com/nvidia/nvsentinel/health-monitors/syslog-health-monitor/pkg/aer"
+	"github.com/nvidia/nvsentinel/health-monitors/syslog-health-monitor/pkg/cooling"
+	"github.com/nvidia/nvsentinel/health-monitors/syslog-health-monitor/pkg/detections"
+	"github.com/nvidia/nvsentinel/health-monitors/syslog-health-monitor/pkg/driverinstall"
+	"github.com/nvidia/nvsentinel/health-monitors/syslog-health-monitor/pkg/ecc"
 	"github.com/nvidia/nvsentinel/health-monitors/syslog-health-monitor/pkg/gpufallen"
+	"github.com/nvidia/nvsentinel/health-monitors/syslog-health-monitor/pkg/gsp"
+	"github.com/nvidia/nvsentinel/health-monitors/syslog-health-monitor/pkg/notices"
+	"github.com/nvidia/nvsentinel/health-monitors/syslog-health-monitor/pkg/policy"
 	"github.com/nvidia/nvsentinel/health-monitors/syslog-health-monitor/pkg/sxid"
+	"github.com/nvidia/nvsentinel/health-monitors/syslog-health-monitor/pkg/throttle"
 	"github.com/nvidia/nvsentinel/health-monitors/syslog-health-monitor/pkg/types"
+	"github.com/nvidia/nvsentinel/health-monitors/syslog-health-monitor/pkg/watchdog"
 	"github.com/nvidia/nvsentinel/health-monitors/syslog-health-monitor/pkg/xid"
 
 	"google.golang.org/grpc/codes"
@@ -77,13 +88,19 @@ func NewSyslogMonitorWithFactory(
 	}
 
 	// Get current boot ID
-	currentBootID, err := fetchCurrentBootID()
+	currentBootID, err := readBootID()
 	if err != nil {
 		slog.Warn("Failed to get current boot ID", "error", err)
 
 		currentBootID = ""
 	}
 
+	// The state handed off by the previous pod of a rolling update is more recent
+	// than the state file
+	if handedOff, ok := adoptHandoff(stateFilePath, nodeName, currentBootID, time.Now()); ok {
+		state = handedOff
+	}
+
 	sm := &SyslogMonitor{
 		nodeName:              nodeName,
 		checks:                checks,
@@ -92,75 +109,187 @@ func NewSyslogMonitorWithFactory(
 		defaultComponentClass: defaultComponentClass,
 		pollingInterval:       pollingInterval,
 		checkLastCursors:      state.CheckLastCursors,
+		checkLastEventTimes:   state.CheckLastEventTimes,
+		checkLogFileOffsets:   state.CheckLogFileOffsets,
+		coldStartChecks:       make(map[string]bool, len(checks)),
 		journalFactory:        journalFactory,
 		currentBootID:         currentBootID,
 		stateFilePath:         stateFilePath,
 		checkToHandlerMap:     make(map[string]types.Handler),
 		xidAnalyserEndpoint:   xidAnalyserEndpoint,
+		metadataPath:          metadataPath,
 	}
 
 	for _, check := range checks {
-		switch check.Name {
-		case XIDErrorCheck:
-			xidHandler, err := xid.NewXIDHandler(nodeName,
-				defaultAgentName, defaultComponentClass, check.Name, xidAnalyserEndpoint, metadataPath)
-			if err != nil {
-				slog.Error("Error initializing XID handler", "error", err.Error())
-				return nil, fmt.Errorf("failed to initialize XID handler: %w", err)
-			}
+		sm.coldStartChecks[check.Name] = true
 
-			sm.checkToHandlerMap[check.Name] = xidHandler
+		handler, err := sm.newHandler(check.Name)
+		if err != nil {
+			return nil, err
+		}
 
-		case SXIDErrorCheck:
-			sxidHandler, err := sxid.NewSXIDHandler(
-				nodeName, defaultAgentName, defaultComponentClass, check.Name, metadataPath)
-			if err != nil {
-				slog.Error("Error initializing SXID handler", "error", err.Error())
-				return nil, fmt.Errorf("failed to initialize SXID handler: %w", err)
-			}
+		if handler != nil {
+			sm.checkToHandlerMap[check.Name] = handler
+		}
+	}
 
-			sm.checkToHandlerMap[check.Name] = sxidHandler
+	// Handle boot ID changes (system reboot detection). Without a readable boot ID the
+	// reboot is detected at the journal boot boundary on the first check run instead.
+	if currentBootID != "" {
+		if err := sm.handleBootIDChange(state.BootID, currentBootID); err != nil {
+			return nil, fmt.Errorf("failed to handle boot ID change: %w", err)
+		}
+	} else {
+		sm.lastBootID = state.BootID
+	}
 
-		case GPUFallenOffCheck:
-			gpuFallenHandler, err := gpufallen.NewGPUFallenHandler(
-				nodeName, defaultAgentName, defaultComponentClass, check.Name)
-			if err != nil {
-				slog.Error("Error initializing GPU Fallen Off handler", "error", err.Error())
-				return nil, fmt.Errorf("failed to initialize GPU Fallen Off handler: %w", err)
-			}
+	slog.Info("SyslogMonitor initialized with persistent state. Each check will resume from last processed cursor.")
 
-			sm.checkToHandlerMap[check.Name] = gpuFallenHandler
+	return sm, nil
+}
 
-		default:
-			slog.Error("Unsupported check", "check", check.Name)
-		}
+// newHandler creates the handler of the check, nil for unsupported checks.
+// Handlers reporting facts get the event policy and stateful handlers the state
+// capacity.
+func (sm *SyslogMonitor) newHandler(checkName string) (types.Handler, error) {
+	handler, err := sm.createHandler(checkName)
+	if err != nil || handler == nil {
+		return handler, err
 	}
 
-	// Handle boot ID changes (system reboot detection)
-	if err := sm.handleBootIDChange(state.BootID, currentBootID); err != nil {
-		return nil, fmt.Errorf("failed to handle boot ID change: %w", err)
+	if receiver, ok := handler.(policy.Receiver); ok {
+		receiver.SetPolicy(sm.eventPolicy)
 	}
 
-	slog.Info("SyslogMonitor initialized with persistent state. Each check will resume from last processed cursor.")
+	if stateful, ok := handler.(types.Stateful); ok && sm.stateCapacity > 0 {
+		stateful.SetStateCapacity(sm.stateCapacity)
+	}
 
-	return sm, nil
+	return handler, nil
 }
 
-// Run executes all configured checks
-func (sm *SyslogMonitor) Run() error {
-	var jointError error = nil
+func (sm *SyslogMonitor) createHandler(checkName string) (types.Handler, error) {
+	switch checkName {
+	case XIDErrorCheck:
+		xidHandler, err := xid.NewXIDHandler(sm.nodeName,
+			sm.defaultAgentName, sm.defaultComponentClass, checkName, sm.xidAnalyserEndpoint, sm.metadataPath)
+		if err != nil {
+			slog.Error("Error initializing XID handler", "error", err.Error())
+			return nil, fmt.Errorf("failed to initialize XID handler: %w", err)
+		}
 
-	for _, check := range sm.checks {
-		err := sm.executeCheck(check)
+		return xidHandler, nil
+
+	case SXIDErrorCheck:
+		sxidHandler, err := sxid.NewSXIDHandler(
+			sm.nodeName, sm.defaultAgentName, sm.defaultComponentClass, checkName, sm.metadataPath)
 		if err != nil {
-			slog.Error("Check failed during execution",
-				"check", check.Name,
-				"error", err)
+			slog.Error("Error initializing SXID handler", "error", err.Error())
+			return nil, fmt.Errorf("failed to initialize SXID handler: %w", err)
+		}
+
+		return sxidHandler, nil
+
+	case GPUFallenOffCheck:
+		gpuFallenHandler, err := gpufallen.NewGPUFallenHandler(
+			sm.nodeName, sm.defaultAgentName, sm.defaultComponentClass, checkName)
+		if err != nil {
+			slog.Error("Error initializing GPU Fallen Off handler", "error", err.Error())
+			return nil, fmt.Errorf("failed to initialize GPU Fallen Off handler: %w", err)
+		}
+
+		return gpuFallenHandler, nil
+
+	case CoolingCheck:
+		coolingHandler, err := cooling.NewCoolingHandler(
+			sm.nodeName, sm.defaultAgentName, sm.defaultComponentClass, checkName, sm.metadataPath)
+		if err != nil {
+			slog.Error("Error initializing cooling handler", "error", err.Error())
+			return nil, fmt.Errorf("failed to initialize cooling handler: %w", err)
+		}
+
+		return coolingHandler, nil
+
+	case AERCheck:
+		aerHandler, err := aer.NewAERHandler(
+			sm.nodeName, sm.defaultAgentName, sm.defaultComponentClass, checkName, sm.metadataPath)
+		if err != nil {
+			slog.Error("Error initializing AER handler", "error", err.Error())
+			return nil, fmt.Errorf("failed to initialize AER handler: %w", err)
+		}
+
+		return aerHandler, nil
+
+	case ECCCheck:
+		eccHandler, err := ecc.NewECCHandler(sm.nodeName, sm.defaultAgentName,
+			sm.defaultComponentClass, checkName, sm.metadataPath, sm.eccSBEThreshold)
+		if err != nil {
+			slog.Error("Error initializing ECC handler", "error", err.Error())
+			return nil, fmt.Errorf("failed to initialize ECC handler: %w", err)
+		}
+
+		return eccHandler, nil
+
+	case GSPCheck:
+		gspHandler, err := gsp.NewGSPHandler(sm.nodeName, sm.defaultAgentName,
+			sm.defaultComponentClass, checkName, sm.metadataPath)
+		if err != nil {
+			slog.Error("Error initializing GSP handler", "error", err.Error())
+			return nil, fmt.Errorf("failed to initialize GSP handler: %w", err)
+		}
+
+		return gspHandler, nil
+
+	case DriverInstallCheck:
+		driverInstallHandler, err := driverinstall.NewDriverInstallHandler(
+			sm.nodeName, sm.defaultAgentName, sm.defaultComponentClass, checkName, "/proc/sys/kernel/osrelease")
+		if err != nil {
+			slog.Error("Error initializing driver install handler", "error", err.Error())
+			return nil, fmt.Errorf("failed to initialize driver install handler: %w", err)
+		}
+
+		return driverInstallHandler, nil
+
+	case MissingLineCheck:
+		watchdogHandler, err := watchdog.NewWatchdogHandler(
+			sm.nodeName, sm.defaultAgentName, sm.defaultComponentClass, checkName, sm.watchdogRules)
+		if err != nil {
+			slog.Error("Error initializing watchdog handler", "error", err.Error())
+			return nil, fmt.Errorf("failed to initialize watchdog handler: %w", err)
+		}
+
+		return watchdogHandler, nil
+
+	case DriverNoticeCheck:
+		noticeHandler, err := notices.NewNoticeHandler(sm.nodeName)
+		if err != nil {
+			slog.Error("Error initializing driver notice handler", "error", err.Error())
+			return nil, fmt.Errorf("failed to initialize driver notice handler: %w", err)
+		}
+
+		return noticeHandler, nil
 
-			jointError = errors.Join(jointError, err)
+	default:
+		// Checks declared by detection specs have generated handlers
+		if handler := detections.NewHandler(checkName,
+			sm.nodeName, sm.defaultAgentName, sm.defaultComponentClass); handler != nil {
+			return handler, nil
 		}
+
+		slog.Error("Unsupported check", "check", checkName)
+		return nil, nil
 	}
+}
+
+// Run executes all configured checks
+func (sm *SyslogMonitor) Run() error {
+	now := time.Now()
+
+	sm.syncRulePacks(now)
+	sm.selectRulePack()
+	sm.sendHeartbeat(now)
 
+	jointError := sm.runChecks()
 	if jointError != nil {
 		return jointError
 	}
@@ -255,6 +384,10 @@ func loadState(stateFilePath string) (syslogMonitorState, error) {
 		state.CheckLastCursors = make(map[string]string)
 	}
 
+	if state.CheckLastEventTimes == nil {
+		state.CheckLastEventTimes = make(map[string]uint64)
+	}
+
 	return state, nil
 }
 
@@ -264,6 +397,9 @@ func verifyStateFields(state syslogMonitorState) bool {
 	return state.CheckLastCursors != nil
 }
 
+// readBootID reads the current system boot ID, replaced in tests
+var readBootID = fetchCurrentBootID
+
 // fetchCurrentBootID returns the current system boot ID
 func fetchCurrentBootID() (string, error) {
 	data, err := os.ReadFile("/proc/sys/kernel/random/boot_id")
@@ -288,9 +424,11 @@ func (sm *SyslogMonitor) handleBootIDChange(oldBootID, newBootID string) error {
 
 		// Save updated state
 		state := syslogMonitorState{
-			Version:          stateFileVersion,
-			BootID:           newBootID,
-			CheckLastCursors: sm.checkLastCursors,
+			Version:             stateFileVersion,
+			BootID:              newBootID,
+			CheckLastCursors:    sm.checkLastCursors,
+			CheckLastEventTimes: sm.checkLastEventTimes,
+			CheckLogFileOffsets: sm.checkLogFileOffsets,
 		}
 
 		if err := saveState(sm.stateFilePath, state); err != nil {
@@ -312,17 +450,82 @@ func (sm *SyslogMonitor) handleBootIDChange(oldBootID, newBootID string) error {
 
 			slog.Info("Published healthy event after system reboot", "check", check.Name)
 		}
+
+		// Without a previous boot ID this is the first run on the node, not a reboot
+		if oldBootID != "" {
+			rebootsDetected.WithLabelValues(sm.nodeName).Inc()
+
+			if err := sm.sendHealthEventWithRetry(sm.prepareRebootEvent(oldBootID, newBootID), 5, 2*time.Second); err != nil {
+				return fmt.Errorf("failed to send reboot event: %w", err)
+			}
+
+			slog.Info("Published reboot event", "oldBootID", oldBootID, "newBootID", newBootID)
+		}
 	}
 
 	return nil
 }
 
+// detectJournalBootBoundary detects a reboot from the boot ID of the journal when the
+// system boot ID could not be read at startup
+func (sm *SyslogMonitor) detectJournalBootBoundary(journalBootID string) error {
+	sm.mu.Lock()
+	defer sm.mu.Unlock()
+
+	if journalBootID == "" || sm.currentBootID != "" {
+		return nil
+	}
+
+	slog.Info("Resolved boot ID from journal", "bootID", journalBootID, "lastBootID", sm.lastBootID)
+
+	sm.currentBootID = journalBootID
+
+	return sm.handleBootIDChange(sm.lastBootID, journalBootID)
+}
+
+// prepareRebootEvent builds the event reporting that the node rebooted. Downstream
+// modules use it to close incidents resolved by the reboot.
+func (sm *SyslogMonitor) prepareRebootEvent(oldBootID, newBootID string) *pb.HealthEvents {
+	event := &pb.HealthEvent{
+		Version:            1,
+		Agent:              sm.defaultAgentName,
+		CheckName:          model.RebootCheckName,
+		ComponentClass:     sm.defaultComponentClass,
+		GeneratedTimestamp: timestamppb.New(time.Now()),
+		Message:            "Node rebooted",
+		IsFatal:            false,
+		IsHealthy:          true,
+		NodeName:           sm.nodeName,
+		RecommendedAction:  pb.RecommendedAction_NONE,
+		Metadata: map[string]string{
+			model.MetadataBootID:         newBootID,
+			model.MetadataPreviousBootID: oldBootID,
+		},
+	}
+
+	return &pb.HealthEvents{
+		Version: 1,
+		Events:  []*pb.HealthEvent{event},
+	}
+}
+
 // saveCurrentState saves the current state to the state file
 func (sm *SyslogMonitor) saveCurrentState() error {
+	sm.mu.Lock()
+	defer sm.mu.Unlock()
+
+	bootID := sm.currentBootID
+	if bootID == "" {
+		// Keep the persisted boot ID until the journal reports the current one
+		bootID = sm.lastBootID
+	}
+
 	state := syslogMonitorState{
-		Version:          stateFileVersion,
-		BootID:           sm.currentBootID,
-		CheckLastCursors: sm.checkLastCursors,
+		Version:             stateFileVersion,
+		BootID:              bootID,
+		CheckLastCursors:    sm.checkLastCursors,
+		CheckLastEventTimes: sm.checkLastEventTimes,
+		CheckLogFileOffsets: sm.checkLogFileOffsets,
 	}
 
 	return saveState(sm.stateFilePath, state)
@@ -350,10 +553,28 @@ func (sm *SyslogMonitor) executeCheck(check CheckDefinition) error {
 	}
 
 	err = sm.processJournalEntries(journal, check)
+	if err == nil {
+		err = sm.processLogFiles(check)
+	}
+
+	if err == nil {
+		err = sm.evaluateCheck(check)
+	}
+
+	// Deliver events still waiting in the batch; this also commits the cursor
+	// held back for them.
+	if flushErr := sm.flushBatch(check.Name); flushErr != nil {
+		err = errors.Join(err, fmt.Errorf("failed to flush health event batch: %w", flushErr))
+	}
+
 	if err != nil {
 		return fmt.Errorf("failed to process journal entries for check %s: %w", check.Name, err)
 	}
 
+	sm.mu.Lock()
+	delete(sm.coldStartChecks, check.Name)
+	sm.mu.Unlock()
+
 	// Save state after successfully processing journal entries
 	if err := sm.saveCurrentState(); err != nil {
 		slog.Warn("Failed to save state after processing check",
@@ -507,14 +728,18 @@ func (sm *SyslogMonitor) configureTagFilters(journal Journal, check CheckDefinit
 func (sm *SyslogMonitor) processJournalEntries(journal Journal, check CheckDefinition) error {
 	// currentEntryCursor will store the cursor of the entry currently being processed or just processed.
 	// sm.checkLastCursors[checkName] will store the cursor to resume from on the NEXT run.
-	lastKnownCursor, hasLastCursor := sm.checkLastCursors[check.Name]
-
 	bootID, err := journal.GetBootID()
 	if err != nil {
 		slog.Warn("Failed to get boot ID", "check", check.Name, "error", err)
 	}
 
 	slog.Info("Boot ID for check", "check", check.Name, "bootID", bootID)
+
+	if err := sm.detectJournalBootBoundary(bootID); err != nil {
+		return fmt.Errorf("check '%s': failed to handle journal boot boundary: %w", check.Name, err)
+	}
+
+	lastKnownCursor, hasLastCursor := sm.lastCursor(check.Name)
 	// This block handles:
 	// 1. Non-boot checks on their first run (hasLastCursor == false)
 	// 2. All checks (boot or non-boot) on subsequent runs (hasLastCursor == true)
@@ -551,13 +776,15 @@ func (sm *SyslogMonitor) processJournalEntries(journal Journal, check CheckDefin
 			"check", check.Name,
 			"cursor", cursor)
 
-		sm.checkLastCursors[check.Name] = cursor
+		sm.setLastCursor(check.Name, cursor)
 
 		return nil // No entries processed on this initialization run.
 	}
 
 	// If we are here, hasLastCursor is true.
 
+	loggedAt := journalEntryTime(journal)
+
 	slog.Info("Resuming from last known cursor",
 		"check", check.Name,
 		"cursor", lastKnownCursor)
@@ -583,7 +810,7 @@ func (sm *SyslogMonitor) processJournalEntries(journal Journal, check CheckDefin
 			"check", check.Name,
 			"cursor", tailCursor)
 
-		sm.checkLastCursors[check.Name] = tailCursor
+		sm.setLastCursor(check.Name, tailCursor)
 
 		return nil // No entries processed on this re-initialization run.
 	}
@@ -610,13 +837,13 @@ func (sm *SyslogMonitor) processJournalEntries(journal Journal, check CheckDefin
 			slog.Warn("Failed to get cursor for current entry, attempting to advance",
 				"check", check.Name,
 				"error", err,
-				"lastStoredCursor", sm.checkLastCursors[check.Name])
+				"lastStoredCursor", sm.nextCursor(check.Name))
 
 			advancedNext, advErr := journal.Next()
 			if advErr == io.EOF || advancedNext == 0 { //nolint:errorlint // TODO
 				slog.Info("Reached end of journal while recovering from GetCursor error",
 					"check", check.Name,
-					"nextCursor", sm.checkLastCursors[check.Name])
+					"nextCursor", sm.nextCursor(check.Name))
 
 				break
 			}
@@ -625,10 +852,10 @@ func (sm *SyslogMonitor) processJournalEntries(journal Journal, check CheckDefin
 				slog.Error("Error advancing journal after GetCursor error, stopping",
 					"check", check.Name,
 					"error", advErr,
-					"nextCursor", sm.checkLastCursors[check.Name])
+					"nextCursor", sm.nextCursor(check.Name))
 
 				return fmt.Errorf("error advancing after GetCursor error for check '%s' (last stored cursor for next run %s): %w",
-					check.Name, sm.checkLastCursors[check.Name], advErr)
+					check.Name, sm.nextCursor(check.Name), advErr)
 			}
 
 			continue // Skip to the next iteration
@@ -640,7 +867,7 @@ func (sm *SyslogMonitor) processJournalEntries(journal Journal, check CheckDefin
 				"check", check.Name,
 				"cursor", currentEntryCursor,
 				"error", err,
-				"nextCursor", sm.checkLastCursors[check.Name])
+				"nextCursor", sm.nextCursor(check.Name))
 
 			advancedNext, advErr := journal.Next()
 
@@ -648,7 +875,7 @@ func (sm *SyslogMonitor) processJournalEntries(journal Journal, check CheckDefin
 				slog.Info("Reached end of journal while recovering from message error",
 					"check", check.Name,
 					"entryCursor", currentEntryCursor,
-					"nextCursor", sm.checkLastCursors[check.Name])
+					"nextCursor", sm.nextCursor(check.Name))
 
 				break
 			} else if advErr != nil {
@@ -656,11 +883,11 @@ func (sm *SyslogMonitor) processJournalEntries(journal Journal, check CheckDefin
 					"check", check.Name,
 					"entryCursor", currentEntryCursor,
 					"error", advErr,
-					"nextCursor", sm.checkLastCursors[check.Name])
+					"nextCursor", sm.nextCursor(check.Name))
 
 				return fmt.Errorf("error advancing after getJournalMessage for check '%s' (entry cursor %s, "+
 					"last stored cursor for next run %s): %v",
-					check.Name, currentEntryCursor, sm.checkLastCursors[check.Name], advErr)
+					check.Name, currentEntryCursor, sm.nextCursor(check.Name), advErr)
 			}
 
 			continue
@@ -668,20 +895,40 @@ func (sm *SyslogMonitor) processJournalEntries(journal Journal, check CheckDefin
 
 		if message == "" {
 			// Successfully read an empty message. This entry is considered processed.
-			sm.checkLastCursors[check.Name] = currentEntryCursor // Update cursor for the next run
+			sm.advanceCursor(check.Name, currentEntryCursor) // Update cursor for the next run
 			slog.Info("Check, read empty message", "name", check.Name,
 				"message", message,
 				"cursor", currentEntryCursor)
 		} else {
-			err = sm.handleSingleLine(check, message)
+			// Catastrophic lines take the express path
+			if expressLine(message) == "" {
+				sm.throttler.Wait()
+			}
+
+			backfilled := sm.isColdStart(check.Name) && sm.isReplayedEntry(journal, check.Name)
+
+			emitted, err := sm.handleLine(check, message, loggedAt, backfilled)
+			if errors.Is(err, errBatchFlush) {
+				return fmt.Errorf("check '%s': %w", check.Name, err)
+			}
+
 			if err != nil {
 				continue
 			}
+
+			if emitted {
+				sm.recordEmittedEntry(journal, check.Name)
+			}
+
 			// This entry (matched or not) is considered processed.
-			sm.checkLastCursors[check.Name] = currentEntryCursor // Update cursor for the next run
-			slog.Debug("Check errored but considered processed", "name", check.Name,
-				"message", message,
-				"cursor", currentEntryCursor)
+			sm.advanceCursor(check.Name, currentEntryCursor) // Update cursor for the next run
+
+			// Checked up front, the log arguments would otherwise be allocated for every line
+			if slog.Default().Enabled(context.Background(), slog.LevelDebug) {
+				slog.Debug("Check errored but considered processed", "name", check.Name,
+					"message", message,
+					"cursor", currentEntryCursor)
+			}
 		}
 
 		advancedNext, advErr := journal.Next()
@@ -703,7 +950,7 @@ func (sm *SyslogMonitor) processJournalEntries(journal Journal, check CheckDefin
 		}
 	}
 
-	finalCursor := sm.checkLastCursors[check.Name] // Should always exist if we passed initialization.
+	finalCursor := sm.nextCursor(check.Name) // Should always exist if we passed initialization.
 	slog.Info("Finished processing journal entries",
 		"check", check.Name,
 		"nextCursor", finalCursor)
@@ -817,6 +1064,11 @@ func (sm *SyslogMonitor) prepareHealthEventWithAction(
 // sendHealthEventWithRetry sends health events to platform connector with retry logic
 func (sm *SyslogMonitor) sendHealthEventWithRetry(healthEvents *pb.HealthEvents,
 	maxRetries int, retryDelay time.Duration) error {
+	// IDs are assigned once so that retries of an event keep its ID
+	for _, event := range healthEvents.Events {
+		model.AssignEventID(event)
+	}
+
 	slog.Info("Attempting to send health event", "events", healthEvents)
 
 	backoff := wait.Backoff{
@@ -827,12 +1079,17 @@ func (sm *SyslogMonitor) sendHealthEventWithRetry(healthEvents *pb.HealthEvents,
 	}
 
 	err := wait.ExponentialBackoff(backoff, func() (bool, error) {
-		_, err := sm.pcClient.HealthEventOccurredV1(context.Background(), healthEvents)
+		_, err := sm.pcClient.HealthEventOccurredV1(context.Background(), healthEvents, sm.callOptions()...)
 		if err == nil {
 			slog.Info("Successfully sent health events", "events", healthEvents)
 			return true, nil
 		}
 
+		if status.Code(err) == codes.Unimplemented && len(sm.callOptions()) > 0 {
+			sm.disableCompression(err)
+			return false, nil
+		}
+
 		if isRetryableError(err) {
 			slog.Warn("Retryable error sending health event, will retry", "error", err)
 			return false, nil
@@ -871,18 +1128,275 @@ func isRetryableError(err error) bool {
 }
 
 func (sm *SyslogMonitor) handleSingleLine(check CheckDefinition, lineToEvaluate string) error {
-	if handler, ok := sm.checkToHandlerMap[check.Name]; ok {
-		healthEvents, err := handler.ProcessLine(lineToEvaluate)
-		if err != nil {
-			return fmt.Errorf("error processing line %s: %w", lineToEvaluate, err)
+	_, err := sm.handleLine(check, lineToEvaluate, time.Now, false)
+	return err
+}
+
+// handleLine processes a line and reports whether events were emitted for it.
+// loggedAt returns the time the line was logged, it is only called for lines
+// with events. Events of backfilled lines are marked as backfilled.
+func (sm *SyslogMonitor) handleLine(check CheckDefinition, lineToEvaluate string, loggedAt func() time.Time,
+	backfilled bool,
+) (bool, error) {
+	handler, ok := sm.checkToHandlerMap[check.Name]
+	if !ok {
+		return false, nil
+	}
+
+	sm.closeEventStorm()
+
+	// Fast path for the vast majority of lines that are irrelevant to the handler
+	if prefilter, ok := handler.(types.Prefilter); ok && !prefilter.Prefilter(lineToEvaluate) {
+		return false, nil
+	}
+
+	// Catastrophic lines take the express path
+	if sm.stormBreaker != nil && expressLine(lineToEvaluate) == "" {
+		sm.stormBreaker.wait()
+	}
+
+	healthEvents, err := handler.ProcessLine(lineToEvaluate)
+	if err != nil {
+		return false, fmt.Errorf("error processing line %s: %w", lineToEvaluate, err)
+	}
+
+	if healthEvents == nil {
+		return false, nil
+	}
+
+	sm.localEvents.addLine(check.Name, lineToEvaluate)
+
+	sm.applyRulePack(check.Name, healthEvents)
+
+	if sm.suppressDuringEventStorm(check, healthEvents) {
+		return false, nil
+	}
+
+	stampPipelineStages(healthEvents, loggedAt(), !backfilled)
+
+	if backfilled {
+		markBackfilled(check.Name, healthEvents)
+	}
+
+	if err := sm.emit(check.Name, healthEvents); err != nil {
+		return false, fmt.Errorf("failed to send health event: %w", err)
+	}
+
+	if sm.stormBreaker != nil && sm.stormBreaker.record(len(healthEvents.Events)) {
+		message := fmt.Sprintf("%s: node generated more than %d events/minute for %d consecutive minutes, "+
+			"suppressing further events for %s", EventStormErrorCode, sm.stormBreaker.threshold,
+			sm.stormBreaker.sustainedMinutes, sm.stormBreaker.cooldown)
+
+		slog.Warn("Event storm detected, suppressing further events", "node", sm.nodeName,
+			"threshold", sm.stormBreaker.threshold, "cooldown", sm.stormBreaker.cooldown)
+		eventStormActive.WithLabelValues(sm.nodeName).Set(1)
+
+		if err := sm.sendHealthEventWithRetry(sm.prepareEventStormEvent(message, false), 5, 2*time.Second); err != nil {
+			return true, fmt.Errorf("failed to send event storm event: %w", err)
 		}
+	}
 
-		if healthEvents != nil {
-			if err := sm.sendHealthEventWithRetry(healthEvents, 5, 2*time.Second); err != nil {
-				return fmt.Errorf("failed to send health event: %w", err)
-			}
+	return true, nil
+}
+
+// evaluateCheck sends the events the handler of the check reports at the end
+// of a run, if it implements types.Evaluator.
+func (sm *SyslogMonitor) evaluateCheck(check CheckDefinition) error {
+	evaluator, ok := sm.checkToHandlerMap[check.Name].(types.Evaluator)
+	if !ok {
+		return nil
+	}
+
+	healthEvents, err := evaluator.Evaluate()
+	if err != nil {
+		return fmt.Errorf("check '%s': evaluation failed: %w", check.Name, err)
+	}
+
+	if healthEvents == nil {
+		return nil
+	}
+
+	sm.applyRulePack(check.Name, healthEvents)
+
+	if err := sm.emit(check.Name, healthEvents); err != nil {
+		return fmt.Errorf("failed to send health event: %w", err)
+	}
+
+	return nil
+}
+
+// EnableWatchdog sets the rules of the SysLogsMissingLine check. Their windows
+// start now.
+func (sm *SyslogMonitor) EnableWatchdog(rules []watchdog.Rule) error {
+	sm.watchdogRules = rules
+
+	for _, check := range sm.checks {
+		if check.Name != MissingLineCheck {
+			continue
+		}
+
+		handler, err := sm.newHandler(check.Name)
+		if err != nil {
+			return err
 		}
+
+		sm.checkToHandlerMap[check.Name] = handler
 	}
 
 	return nil
 }
+
+// EnableEventPolicy sets the policy deciding the severity, action and message of
+// the facts reported by the handlers.
+func (sm *SyslogMonitor) EnableEventPolicy(eventPolicy *policy.Policy) {
+	sm.eventPolicy = eventPolicy
+
+	for _, handler := range sm.checkToHandlerMap {
+		if receiver, ok := handler.(policy.Receiver); ok {
+			receiver.SetPolicy(eventPolicy)
+		}
+	}
+}
+
+// EnableStateCapacity bounds the state the handlers keep per GPU or kernel, the
+// least recently used entries are evicted beyond it.
+func (sm *SyslogMonitor) EnableStateCapacity(capacity int) {
+	sm.stateCapacity = capacity
+
+	for _, handler := range sm.checkToHandlerMap {
+		if stateful, ok := handler.(types.Stateful); ok {
+			stateful.SetStateCapacity(capacity)
+		}
+	}
+}
+
+// EnableECCSBEThreshold sets the number of single-bit ECC errors of a GPU the
+// ECCCheck reports as a degradation. 0 keeps the default.
+func (sm *SyslogMonitor) EnableECCSBEThreshold(threshold int) {
+	sm.eccSBEThreshold = threshold
+
+	for _, handler := range sm.checkToHandlerMap {
+		if eccHandler, ok := handler.(*ecc.ECCHandler); ok {
+			eccHandler.SetSBEThreshold(threshold)
+		}
+	}
+}
+
+// stampPipelineStages records the line-read and event-emitted stage timestamps
+// on every event so that end-to-end latency can be measured downstream. The
+// line-read stage is the time the line was logged, so the latency includes the
+// polling delay. Backfilled lines are not observed, their latency is the age of
+// the line.
+func stampPipelineStages(healthEvents *pb.HealthEvents, readAt time.Time, observe bool) {
+	emittedAt := time.Now()
+
+	for _, event := range healthEvents.Events {
+		model.SetStageTimestamp(event, model.StageLineRead, readAt)
+		model.SetStageTimestamp(event, model.StageEventEmitted, emittedAt)
+	}
+
+	if !observe {
+		return
+	}
+
+	pipelineStageLatency.WithLabelValues(string(model.StageLineRead), string(model.StageEventEmitted)).
+		Observe(emittedAt.Sub(readAt).Seconds())
+}
+
+// EnableEventStormBreaker turns on the per-node event storm circuit breaker. Once
+// the node produces more than eventsPerMinute events for sustainedMinutes
+// consecutive minutes, a single SysLogsNodeEventStorm event is sent, handler
+// processing backs off and non-fatal events are suppressed for cooldown. Fatal and
+// healthy events are never suppressed, and catastrophic lines do not back off. A
+// non-positive eventsPerMinute disables it.
+func (sm *SyslogMonitor) EnableEventStormBreaker(eventsPerMinute, sustainedMinutes int, cooldown time.Duration) {
+	if eventsPerMinute <= 0 {
+		sm.stormBreaker = nil
+		return
+	}
+
+	sm.stormBreaker = newEventStormBreaker(eventsPerMinute, max(sustainedMinutes, 1), cooldown)
+}
+
+// EnableCPUThrottling limits line processing to roughly cpuBudget cores by
+// inserting an adaptive delay between lines. A non-positive budget disables it.
+func (sm *SyslogMonitor) EnableCPUThrottling(cpuBudget float64) {
+	sm.throttler = throttle.NewThrottler(cpuBudget)
+}
+
+// closeEventStorm closes the event storm breaker once the cooldown has elapsed and
+// sends the healthy storm event summarizing how many events were suppressed.
+func (sm *SyslogMonitor) closeEventStorm() {
+	if sm.stormBreaker == nil {
+		return
+	}
+
+	if suppressed, closed := sm.stormBreaker.tryClose(); closed {
+		slog.Info("Event storm cooldown elapsed, resuming event processing",
+			"node", sm.nodeName, "suppressed", suppressed)
+		eventStormActive.WithLabelValues(sm.nodeName).Set(0)
+
+		message := fmt.Sprintf("%s cleared: %d non-fatal events were suppressed during the storm",
+			EventStormErrorCode, suppressed)
+		if err := sm.sendHealthEventWithRetry(sm.prepareEventStormEvent(message, true), 5, 2*time.Second); err != nil {
+			slog.Error("Failed to send event storm recovery event", "error", err)
+		}
+	}
+}
+
+// suppressDuringEventStorm drops the non-fatal events of a line during an ongoing
+// event storm and reports whether no event is left to send. Fatal events and the
+// healthy events clearing a condition are kept, and the suppressed events are
+// retained in the local buffer.
+func (sm *SyslogMonitor) suppressDuringEventStorm(check CheckDefinition, healthEvents *pb.HealthEvents) bool {
+	if sm.stormBreaker == nil {
+		return false
+	}
+
+	kept := make([]*pb.HealthEvent, 0, len(healthEvents.Events))
+	suppressed := make([]*pb.HealthEvent, 0, len(healthEvents.Events))
+
+	for _, event := range healthEvents.Events {
+		if event.IsFatal || event.IsHealthy {
+			kept = append(kept, event)
+		} else {
+			suppressed = append(suppressed, event)
+		}
+	}
+
+	if len(suppressed) == 0 || !sm.stormBreaker.suppress(len(suppressed)) {
+		return false
+	}
+
+	for _, event := range suppressed {
+		model.AssignEventID(event)
+		sm.localEvents.add(check.Name, event, false)
+	}
+
+	eventStormSuppressedEntries.WithLabelValues(sm.nodeName, check.Name).Inc()
+	healthEvents.Events = kept
+
+	return len(kept) == 0
+}
+
+// prepareEventStormEvent builds the NODE_EVENT_STORM summary event.
+func (sm *SyslogMonitor) prepareEventStormEvent(message string, isHealthy bool) *pb.HealthEvents {
+	event := &pb.HealthEvent{
+		Version:            1,
+		Agent:              sm.defaultAgentName,
+		CheckName:          EventStormCheck,
+		ComponentClass:     sm.defaultComponentClass,
+		GeneratedTimestamp: timestamppb.New(time.Now()),
+		Message:            message,
+		IsFatal:            false,
+		IsHealthy:          isHealthy,
+		NodeName:           sm.nodeName,
+		RecommendedAction:  pb.RecommendedAction_NONE,
+		ErrorCode:          []string{EventStormErrorCode},
+	}
+
+	return &pb.HealthEvents{
+		Version: 1,
+		Events:  []*pb.HealthEvent{event},
+	}
+}
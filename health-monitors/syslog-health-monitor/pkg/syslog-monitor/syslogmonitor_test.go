@@ -21,12 +21,18 @@ import (
 	"io"
 	"os"
 	"strings"
+	"sync"
 	"testing"
 	"time"
 
+	"github.com/nvidia/nvsentinel/data-models/pkg/model"
 	pb "github.com/nvidia/nvsentinel/data-models/pkg/protos"
+	"github.com/nvidia/nvsentinel/health-monitors/syslog-health-monitor/pkg/detections"
+	"github.com/nvidia/nvsentinel/health-monitors/syslog-health-monitor/pkg/gpufallen"
 	"github.com/nvidia/nvsentinel/health-monitors/syslog-health-monitor/pkg/types"
+	"github.com/nvidia/nvsentinel/health-monitors/syslog-health-monitor/pkg/watchdog"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"google.golang.org/grpc"
 	"google.golang.org/protobuf/types/known/emptypb"
 	"google.golang.org/protobuf/types/known/timestamppb"
@@ -49,6 +55,7 @@ type MockJournalEntry struct {
 	Cursor    string
 	Fields    map[string]string
 	Timestamp string
+	Realtime  uint64
 }
 
 // MockJournal is a mock implementation of the Journal interface for testing
@@ -145,6 +152,10 @@ func (j *MockJournal) GetData(field string) (string, error) {
 		return j.Entries[j.CurrentPosition].Message, nil
 	}
 
+	if field == FieldBootID {
+		return j.Entries[j.CurrentPosition].BootID, nil
+	}
+
 	value, ok := j.Entries[j.CurrentPosition].Fields[field]
 	if !ok {
 		return "", fmt.Errorf("field not found: %s", field)
@@ -153,6 +164,19 @@ func (j *MockJournal) GetData(field string) (string, error) {
 	return value, nil
 }
 
+// GetRealtimeUsec returns the wallclock time of the current journal entry
+func (j *MockJournal) GetRealtimeUsec() (uint64, error) {
+	if j.Closed {
+		return 0, errors.New(JOURNAL_CLOSED_ERROR)
+	}
+
+	if j.CurrentPosition < 0 || j.CurrentPosition >= len(j.Entries) {
+		return 0, fmt.Errorf("invalid cursor position")
+	}
+
+	return j.Entries[j.CurrentPosition].Realtime, nil
+}
+
 // Next moves to the next journal entry
 func (j *MockJournal) Next() (uint64, error) {
 	if j.Closed {
@@ -272,10 +296,14 @@ type LogCheckConfig struct {
 
 // Mock PlatformConnectorClient
 type mockPlatformConnectorClient struct {
+	mu                   sync.Mutex
 	RecordedHealthEvents []*pb.HealthEvents
 }
 
 func (m *mockPlatformConnectorClient) HealthEventOccurredV1(ctx context.Context, events *pb.HealthEvents, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
 	m.RecordedHealthEvents = append(m.RecordedHealthEvents, events)
 	return &emptypb.Empty{}, nil
 }
@@ -436,6 +464,8 @@ type mockHandler struct {
 	defaultAgentName      string
 	defaultComponentClass string
 	checkName             string
+	nonFatal              bool
+	healthy               bool
 }
 
 func (mh *mockHandler) ProcessLine(message string) (*pb.HealthEvents, error) {
@@ -452,8 +482,8 @@ func (mh *mockHandler) ProcessLine(message string) (*pb.HealthEvents, error) {
 			{EntityType: "GPU", EntityValue: "44"},
 		},
 		Message:           "TestMessage",
-		IsFatal:           true,
-		IsHealthy:         false,
+		IsFatal:           !mh.nonFatal && !mh.healthy,
+		IsHealthy:         mh.healthy,
 		NodeName:          mh.nodeName,
 		RecommendedAction: pb.RecommendedAction_RESTART_BM,
 		ErrorCode:         []string{"123"},
@@ -566,10 +596,12 @@ func TestBootIDChangeHandling(t *testing.T) {
 	stateData, _ := json.Marshal(initialState)
 	os.WriteFile(testStateFile, stateData, 0644)
 
+	pcClient := &mockPlatformConnectorClient{}
+
 	sm, err := NewSyslogMonitorWithFactory(
 		TEST_NODE,
 		[]CheckDefinition{check},
-		&mockPlatformConnectorClient{},
+		pcClient,
 		TEST_AGENT,
 		TEST_COMPONENT,
 		"60s",
@@ -584,6 +616,69 @@ func TestBootIDChangeHandling(t *testing.T) {
 	if exists {
 		assert.Empty(t, cursor, "Cursor should be cleared after boot ID change")
 	}
+
+	require.NotEmpty(t, pcClient.RecordedHealthEvents)
+	rebootEvent := pcClient.RecordedHealthEvents[len(pcClient.RecordedHealthEvents)-1].Events[0]
+	assert.True(t, model.IsRebootEvent(rebootEvent))
+	assert.Equal(t, "boot-1", rebootEvent.Metadata[model.MetadataPreviousBootID])
+}
+
+// TestJournalBootBoundary tests reboot detection from the journal when the system boot ID is unreadable
+func TestJournalBootBoundary(t *testing.T) {
+	testStateFile := "/tmp/test-syslog-monitor-journal-boot.json"
+	defer os.Remove(testStateFile)
+
+	originalReadBootID := readBootID
+	readBootID = func() (string, error) { return "", errors.New("boot_id not readable") }
+
+	defer func() { readBootID = originalReadBootID }()
+
+	check := CheckDefinition{
+		Name:        "bootCheck",
+		JournalPath: TEST_JOURNAL_PATH,
+	}
+
+	mockFactory := NewMockJournalFactory()
+	mockFactory.JournalsByPath[check.JournalPath] = &MockJournal{
+		Entries: []MockJournalEntry{
+			{Message: "entry1", Cursor: "cursor-1", BootID: "boot-2"},
+		},
+		CurrentPosition: -1,
+		TestBootID:      "boot-2",
+	}
+
+	stateData, _ := json.Marshal(syslogMonitorState{
+		BootID:           "boot-1",
+		CheckLastCursors: map[string]string{"bootCheck": "old-cursor"},
+	})
+	require.NoError(t, os.WriteFile(testStateFile, stateData, 0644))
+
+	pcClient := &mockPlatformConnectorClient{}
+
+	sm, err := NewSyslogMonitorWithFactory(TEST_NODE, []CheckDefinition{check}, pcClient, TEST_AGENT,
+		TEST_COMPONENT, "60s", testStateFile, mockFactory, "http://localhost:8080", "/tmp/metadata.json")
+	require.NoError(t, err)
+	assert.Empty(t, pcClient.RecordedHealthEvents, "Reboot must not be reported before the journal boot ID is known")
+
+	require.NoError(t, sm.Run())
+
+	var rebootEvents []*pb.HealthEvent
+
+	for _, events := range pcClient.RecordedHealthEvents {
+		for _, event := range events.Events {
+			if model.IsRebootEvent(event) {
+				rebootEvents = append(rebootEvents, event)
+			}
+		}
+	}
+
+	require.Len(t, rebootEvents, 1)
+	assert.Equal(t, "boot-2", rebootEvents[0].Metadata[model.MetadataBootID])
+	assert.Equal(t, "boot-1", rebootEvents[0].Metadata[model.MetadataPreviousBootID])
+
+	state, err := loadState(testStateFile)
+	require.NoError(t, err)
+	assert.Equal(t, "boot-2", state.BootID)
 }
 
 // TestRunMultipleChecks tests running multiple checks in sequence
@@ -670,3 +765,161 @@ func TestGPUFallenOffHandlerInitialization(t *testing.T) {
 	assert.NoError(t, err)
 	assert.NotNil(t, sm.checkToHandlerMap[GPUFallenOffCheck], "GPU Fallen Off handler should be initialized")
 }
+
+// TestGPUFallenOffReportsXid79 feeds the Xid 79 and the fallen off the bus lines the
+// driver logs when a GPU falls off the bus, which are reported as one fatal fall.
+func TestGPUFallenOffReportsXid79(t *testing.T) {
+	check := CheckDefinition{
+		Name:        GPUFallenOffCheck,
+		JournalPath: TEST_JOURNAL_PATH,
+	}
+
+	fakeJournal := NewFakeJournal()
+	fakeJournal.AddEntryWithMessage("nothing", "cursor-1")
+
+	fakeJournalFactory := NewFakeJournalFactory()
+	fakeJournalFactory.AddJournal(check.JournalPath, fakeJournal)
+
+	testStateFile := "/tmp/test-syslog-monitor-gpufallen-xid79.json"
+	defer os.Remove(testStateFile)
+
+	sm, err := NewSyslogMonitorWithFactory(
+		TEST_NODE,
+		[]CheckDefinition{check},
+		&mockPlatformConnectorClient{},
+		TEST_AGENT,
+		TEST_COMPONENT,
+		"60s",
+		testStateFile,
+		fakeJournalFactory,
+		"http://localhost:8080",
+		"/tmp/metadata.json",
+	)
+	require.NoError(t, err)
+	require.NoError(t, sm.executeCheck(check))
+
+	mockPCClient := &mockPlatformConnectorClient{}
+	sm.pcClient = mockPCClient
+
+	fakeJournal.AddEntryWithMessage("NVRM: Xid (PCI:0000:b3:00): 79, pid=1234, name=python, "+
+		"GPU has fallen off the bus.", "cursor-2")
+	fakeJournal.AddEntryWithMessage("NVRM: The NVIDIA GPU 0000:b3:00.0\n"+
+		"NVRM: (PCI ID: 10de:26b5) installed in this system has\n"+
+		"NVRM: fallen off the bus and is not responding to commands.", "cursor-3")
+
+	require.NoError(t, sm.executeCheck(check))
+
+	require.Len(t, mockPCClient.RecordedHealthEvents, 1, "the fall is reported once")
+	require.Len(t, mockPCClient.RecordedHealthEvents[0].Events, 1)
+
+	event := mockPCClient.RecordedHealthEvents[0].Events[0]
+	assert.Equal(t, GPUFallenOffCheck, event.CheckName)
+	assert.Equal(t, []string{gpufallen.ErrorCode}, event.ErrorCode)
+	assert.True(t, event.IsFatal)
+	assert.Equal(t, pb.RecommendedAction_CONTACT_SUPPORT, event.RecommendedAction)
+	assert.Equal(t, "1", event.Metadata["fall_count"])
+	assert.Equal(t, "0000:b3:00.0", event.EntitiesImpacted[0].EntityValue)
+}
+
+// TestGeneratedHandlerInitialization tests that checks declared by detection specs
+// get their generated handlers
+func TestGeneratedHandlerInitialization(t *testing.T) {
+	check := CheckDefinition{
+		Name:        detections.RmInitAdapterFailedCheck,
+		JournalPath: "/path",
+	}
+
+	mockFactory := NewMockJournalFactory()
+	mockFactory.JournalsByPath["/path"] = &MockJournal{CurrentPosition: -1, TestBootID: "b1"}
+
+	testStateFile := "/tmp/test-syslog-monitor-generated.json"
+	defer os.Remove(testStateFile)
+
+	sm, err := NewSyslogMonitorWithFactory(
+		TEST_NODE,
+		[]CheckDefinition{check},
+		&mockPlatformConnectorClient{},
+		TEST_AGENT,
+		TEST_COMPONENT,
+		"60s",
+		testStateFile,
+		mockFactory,
+		"http://localhost:8080",
+		"/tmp/metadata.json",
+	)
+	assert.NoError(t, err)
+	assert.IsType(t, &detections.RmInitAdapterFailedHandler{}, sm.checkToHandlerMap[check.Name])
+}
+
+func TestHandleSingleLineStampsPipelineStages(t *testing.T) {
+	pcClient := &mockPlatformConnectorClient{}
+	check := CheckDefinition{Name: "mockCheck"}
+	sm := &SyslogMonitor{
+		nodeName: TEST_NODE,
+		pcClient: pcClient,
+		checkToHandlerMap: map[string]types.Handler{
+			check.Name: &mockHandler{nodeName: TEST_NODE, checkName: check.Name},
+		},
+	}
+
+	assert.NoError(t, sm.handleSingleLine(check, "sxid123"))
+	assert.Len(t, pcClient.RecordedHealthEvents, 1)
+
+	event := pcClient.RecordedHealthEvents[0].Events[0]
+	readAt, ok := model.StageTimestamp(event, model.StageLineRead)
+	assert.True(t, ok, "line read stage should be stamped")
+
+	emittedAt, ok := model.StageTimestamp(event, model.StageEventEmitted)
+	assert.True(t, ok, "event emitted stage should be stamped")
+	assert.False(t, emittedAt.Before(readAt))
+}
+
+func TestJournalEntryTimeStampsLineRead(t *testing.T) {
+	journal := NewFakeJournal()
+	journal.Entries = []FakeJournalEntry{{Cursor: "cursor-1", Realtime: 1_700_000_000_000_000}}
+	journal.CurrentPosition = 0
+
+	pcClient := &mockPlatformConnectorClient{}
+	check := CheckDefinition{Name: "mockCheck"}
+	sm := &SyslogMonitor{
+		nodeName: TEST_NODE,
+		pcClient: pcClient,
+		checkToHandlerMap: map[string]types.Handler{
+			check.Name: &mockHandler{nodeName: TEST_NODE, checkName: check.Name},
+		},
+	}
+
+	_, err := sm.handleLine(check, "sxid123", journalEntryTime(journal), false)
+	require.NoError(t, err)
+	require.Len(t, pcClient.RecordedHealthEvents, 1)
+
+	readAt, ok := model.StageTimestamp(pcClient.RecordedHealthEvents[0].Events[0], model.StageLineRead)
+	require.True(t, ok)
+	assert.Equal(t, time.UnixMicro(1_700_000_000_000_000).UTC(), readAt.UTC())
+}
+
+func TestEvaluateCheckReportsMissingLines(t *testing.T) {
+	pcClient := &mockPlatformConnectorClient{}
+	check := CheckDefinition{Name: MissingLineCheck}
+	sm := &SyslogMonitor{
+		nodeName:          TEST_NODE,
+		pcClient:          pcClient,
+		checks:            []CheckDefinition{check},
+		checkToHandlerMap: map[string]types.Handler{},
+	}
+
+	require.NoError(t, sm.EnableWatchdog([]watchdog.Rule{
+		{Name: "heartbeat", Pattern: "heartbeat", Window: "1ms"},
+	}))
+	require.IsType(t, &watchdog.WatchdogHandler{}, sm.checkToHandlerMap[check.Name])
+
+	time.Sleep(5 * time.Millisecond)
+
+	require.NoError(t, sm.evaluateCheck(check))
+	require.Len(t, pcClient.RecordedHealthEvents, 1)
+	assert.Equal(t, []string{watchdog.ErrorCode}, pcClient.RecordedHealthEvents[0].Events[0].ErrorCode)
+
+	// Checks whose handler does not evaluate are left alone
+	require.NoError(t, sm.evaluateCheck(CheckDefinition{Name: "mockCheck"}))
+	assert.Len(t, pcClient.RecordedHealthEvents, 1)
+}
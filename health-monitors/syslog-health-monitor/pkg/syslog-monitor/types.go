@@ -14,8 +14,16 @@
 package syslogmonitor
 
 import (
+	"crypto/ed25519"
+	"sync"
+	"time"
+
 	pb "github.com/nvidia/nvsentinel/data-models/pkg/protos"
+	"github.com/nvidia/nvsentinel/health-monitors/syslog-health-monitor/pkg/policy"
+	"github.com/nvidia/nvsentinel/health-monitors/syslog-health-monitor/pkg/rulepack"
+	"github.com/nvidia/nvsentinel/health-monitors/syslog-health-monitor/pkg/throttle"
 	"github.com/nvidia/nvsentinel/health-monitors/syslog-health-monitor/pkg/types"
+	"github.com/nvidia/nvsentinel/health-monitors/syslog-health-monitor/pkg/watchdog"
 )
 
 const (
@@ -34,6 +42,33 @@ const (
 	XIDErrorCheck     = "SysLogsXIDError"
 	SXIDErrorCheck    = "SysLogsSXIDError"
 	GPUFallenOffCheck = "SysLogsGPUFallenOff"
+	// CoolingCheck reports coolant leaks and critical coolant temperatures of
+	// liquid-cooled racks from BMC sensor records and vendor agent messages.
+	CoolingCheck = "SysLogsCooling"
+	// AERCheck reports the PCIe Advanced Error Reporting errors of the kernel by
+	// the BDF of the device that sent them.
+	AERCheck = "SysLogsPCIeAER"
+	// ECCCheck reports the double-bit ECC errors, row remapping events and recurring
+	// single-bit ECC errors the NVIDIA driver logs for the GPUs.
+	ECCCheck = "SysLogsECCError"
+	// GSPCheck reports the crashes and RPC timeouts of the GSP firmware the NVIDIA
+	// driver logs for the GPUs.
+	GSPCheck = "SysLogsGSPFirmwareCrash"
+	// DriverInstallCheck reports NVIDIA driver builds that failed for a kernel,
+	// from the journal and the log files of the check.
+	DriverInstallCheck = "SysLogsDriverInstall"
+	// MissingLineCheck reports expected periodic lines of the watchdog rules
+	// that have not appeared within their window.
+	MissingLineCheck = "SysLogsMissingLine"
+	// DriverNoticeCheck counts informational driver messages, like throttle
+	// notices, in metrics. It publishes no events.
+	DriverNoticeCheck = "SysLogsDriverNotices"
+
+	// EventStormCheck is the check name of the summary event emitted when the
+	// per-node event storm breaker trips or recovers.
+	EventStormCheck = "SysLogsNodeEventStorm"
+	// EventStormErrorCode is the error code carried by the storm summary event.
+	EventStormErrorCode = "NODE_EVENT_STORM"
 )
 
 // syslogMonitorState represents the persistent state of the syslog monitor
@@ -41,6 +76,12 @@ type syslogMonitorState struct {
 	Version          int               `json:"version"`
 	BootID           string            `json:"boot_id"`
 	CheckLastCursors map[string]string `json:"check_last_cursors"`
+	// Wallclock time in microseconds of the latest journal entry per check that
+	// events were emitted for, used to recognize replayed lines after a restart
+	CheckLastEventTimes map[string]uint64 `json:"check_last_event_times,omitempty"`
+	// Read offsets of the log files per check. Unlike journal cursors they stay
+	// valid across reboots
+	CheckLogFileOffsets map[string]map[string]logFileOffset `json:"check_log_file_offsets,omitempty"`
 }
 
 // SyslogMonitor monitors journal logs for error patterns
@@ -51,18 +92,77 @@ type SyslogMonitor struct {
 	defaultAgentName      string
 	defaultComponentClass string
 	pollingInterval       string
+	// Guards the state shared by checks running in parallel: cursors, event
+	// times, cold start checks, batches, boot IDs and the state file
+	mu sync.Mutex
 	// Map of check name to last processed cursor
 	checkLastCursors map[string]string
+	// Map of check name to the time of the latest journal entry events were emitted for
+	checkLastEventTimes map[string]uint64
+	// Map of check name to the read offsets of its log files
+	checkLogFileOffsets map[string]map[string]logFileOffset
+	// Checks that have not completed a run since the monitor started
+	coldStartChecks map[string]bool
 	// Factory for creating Journal instances
 	journalFactory JournalFactory
 	// Current system boot ID
 	currentBootID string
+	// Boot ID persisted before the restart, kept until the journal reports the
+	// current boot ID when it could not be read at startup
+	lastBootID string
 	// Path to state file for persistence
 	stateFilePath string
 	// Map of check name to handler
 	checkToHandlerMap map[string]types.Handler
 	// Endpoint to the XID analyser service
 	xidAnalyserEndpoint string
+	// Per-node event storm circuit breaker, nil when disabled
+	stormBreaker *eventStormBreaker
+	// Adaptive CPU throttler for line processing, nil when disabled
+	throttler *throttle.Throttler
+	// Compressor used when sending events to the platform connector
+	compressor   string
+	compressorMu sync.RWMutex
+	// Maximum number of events per call; batching is disabled when <= 1
+	batchSize int
+	// Map of check name to the events waiting to be sent in its next batch
+	batches map[string]*eventBatch
+	// Number of checks run concurrently; checks run sequentially when <= 1
+	workers int
+	// Path to the GPU metadata file written by the metadata collector
+	metadataPath string
+	// Rule packs to select from, and the pack selected for the node
+	rulePacks        []rulepack.Pack
+	rulePack         *rulepack.Pack
+	rulePackMu       sync.RWMutex
+	rulePackResolved bool
+	// Packs loaded locally, and the version of the distributed bundle applied on
+	// top of them, empty when none is
+	localRulePacks []rulepack.Pack
+	rulePackBundle string
+	// Client of the rule pack distribution service, nil when bundles are not synced
+	rulePackClient       pb.RulePackDistributorClient
+	rulePackPublicKey    ed25519.PublicKey
+	rulePackSyncInterval time.Duration
+	lastRulePackSync     time.Time
+	// Version of the monitor reported in heartbeats, sent every heartbeatInterval
+	version           string
+	heartbeatInterval time.Duration
+	lastHeartbeat     time.Time
+	// Rules of the MissingLineCheck
+	watchdogRules []watchdog.Rule
+	// Policy deciding the severity and action of the facts reported by handlers
+	eventPolicy *policy.Policy
+	// Entries of per-GPU and per-kernel state a handler keeps, 0 keeps the default
+	stateCapacity int
+	// Single-bit ECC errors of a GPU reported by the ECCCheck, 0 keeps the default
+	eccSBEThreshold int
+	// Minimum severity of the unhealthy events shipped to the platform connector
+	minShippedSeverity Severity
+	// Latest events generated on the node, shipped or not, nil retains none
+	localEvents *localEventBuffer
+	// Interval between the scans of the express path, 0 disables it
+	expressInterval time.Duration
 }
 
 // CheckDefinition matches the structure of each check in the YAML config file
@@ -70,4 +170,7 @@ type CheckDefinition struct {
 	Name        string   `yaml:"name"`
 	Tags        []string `yaml:"tags"`
 	JournalPath string   `yaml:"journalPath"`
+	// Glob patterns of plain text log files whose new lines are also handed to
+	// the check, for tools that do not log to the journal
+	LogFiles []string `yaml:"logFiles"`
 }
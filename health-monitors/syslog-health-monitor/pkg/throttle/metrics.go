@@ -0,0 +1,50 @@
+// Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package throttle
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+var (
+	cpuUsageCores = promauto.NewGauge(
+		prometheus.GaugeOpts{
+			Name: "syslog_health_monitor_cpu_usage_cores",
+			Help: "CPU used by the monitor over the last throttle sample, in cores",
+		},
+	)
+
+	throttleActive = promauto.NewGauge(
+		prometheus.GaugeOpts{
+			Name: "syslog_health_monitor_throttle_active",
+			Help: "Whether line processing is currently throttled (1) or not (0)",
+		},
+	)
+
+	throttleActivations = promauto.NewCounter(
+		prometheus.CounterOpts{
+			Name: "syslog_health_monitor_throttle_activations_total",
+			Help: "Total number of times the CPU budget was exceeded and throttling kicked in",
+		},
+	)
+
+	throttleDelaySeconds = promauto.NewCounter(
+		prometheus.CounterOpts{
+			Name: "syslog_health_monitor_throttle_delay_seconds_total",
+			Help: "Total time spent sleeping due to CPU throttling",
+		},
+	)
+)
@@ -0,0 +1,169 @@
+// Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package throttle implements adaptive self-throttling of journal line
+// processing so that the monitor stays within a CPU budget on the node.
+package throttle
+
+import (
+	"log/slog"
+	"sync"
+	"syscall"
+	"time"
+)
+
+const (
+	defaultSampleInterval = time.Second
+	minDelay              = time.Millisecond
+	maxDelay              = 100 * time.Millisecond
+)
+
+// Throttler measures the CPU consumed by the current process and inserts an
+// adaptive delay between processed lines while usage exceeds the budget.
+// The delay doubles on every over-budget sample and halves once usage drops
+// back under the budget. It is safe for concurrent use; all callers share the
+// same delay.
+type Throttler struct {
+	mu sync.Mutex
+
+	budget         float64
+	sampleInterval time.Duration
+
+	cpuTime func() (time.Duration, error)
+	now     func() time.Time
+	sleep   func(time.Duration)
+
+	lastSample time.Time
+	lastCPU    time.Duration
+	delay      time.Duration
+}
+
+// NewThrottler returns a Throttler enforcing cpuBudget, expressed in cores
+// (e.g. 0.1 for 100m). A non-positive budget returns nil, which disables
+// throttling; a nil *Throttler is safe to use.
+func NewThrottler(cpuBudget float64) *Throttler {
+	if cpuBudget <= 0 {
+		return nil
+	}
+
+	return &Throttler{
+		budget:         cpuBudget,
+		sampleInterval: defaultSampleInterval,
+		cpuTime:        processCPUTime,
+		now:            time.Now,
+		sleep:          time.Sleep,
+	}
+}
+
+// Wait is called before each processed line. It re-evaluates CPU usage once per
+// sample interval and sleeps for the current throttle delay, if any.
+func (t *Throttler) Wait() {
+	if t == nil {
+		return
+	}
+
+	t.mu.Lock()
+
+	now := t.now()
+
+	if t.lastSample.IsZero() {
+		t.resetSample(now)
+		t.mu.Unlock()
+
+		return
+	}
+
+	if elapsed := now.Sub(t.lastSample); elapsed >= t.sampleInterval {
+		t.adjust(now, elapsed)
+	}
+
+	delay := t.delay
+	t.mu.Unlock()
+
+	if delay > 0 {
+		throttleDelaySeconds.Add(delay.Seconds())
+		t.sleep(delay)
+	}
+}
+
+// Delay returns the delay currently applied between lines.
+func (t *Throttler) Delay() time.Duration {
+	if t == nil {
+		return 0
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	return t.delay
+}
+
+func (t *Throttler) adjust(now time.Time, elapsed time.Duration) {
+	cpu, err := t.cpuTime()
+	if err != nil {
+		slog.Debug("Failed to read process CPU time, not throttling", "error", err)
+		t.delay = 0
+		t.lastSample = now
+
+		return
+	}
+
+	usage := float64(cpu-t.lastCPU) / float64(elapsed)
+	cpuUsageCores.Set(usage)
+
+	t.lastSample = now
+	t.lastCPU = cpu
+
+	if usage > t.budget {
+		if t.delay == 0 {
+			slog.Info("CPU budget exceeded, throttling line processing", "usage", usage, "budget", t.budget)
+			throttleActivations.Inc()
+		}
+
+		t.delay = min(max(t.delay*2, minDelay), maxDelay)
+	} else if t.delay > 0 {
+		t.delay /= 2
+		if t.delay < minDelay {
+			slog.Info("CPU usage back under budget, throttling disabled", "usage", usage, "budget", t.budget)
+
+			t.delay = 0
+		}
+	}
+
+	if t.delay > 0 {
+		throttleActive.Set(1)
+	} else {
+		throttleActive.Set(0)
+	}
+}
+
+func (t *Throttler) resetSample(now time.Time) {
+	cpu, err := t.cpuTime()
+	if err != nil {
+		slog.Debug("Failed to read process CPU time", "error", err)
+	}
+
+	t.lastSample = now
+	t.lastCPU = cpu
+}
+
+// processCPUTime returns the user+system CPU time consumed by this process.
+func processCPUTime() (time.Duration, error) {
+	var ru syscall.Rusage
+	if err := syscall.Getrusage(syscall.RUSAGE_SELF, &ru); err != nil {
+		return 0, err
+	}
+
+	return time.Duration(ru.Utime.Nano() + ru.Stime.Nano()), nil
+}
@@ -0,0 +1,115 @@
+// Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package throttle
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type fakeProcess struct {
+	now   time.Time
+	cpu   time.Duration
+	err   error
+	slept []time.Duration
+}
+
+func newTestThrottler(budget float64) (*Throttler, *fakeProcess) {
+	p := &fakeProcess{now: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)}
+	t := NewThrottler(budget)
+	t.now = func() time.Time { return p.now }
+	t.cpuTime = func() (time.Duration, error) { return p.cpu, p.err }
+	t.sleep = func(d time.Duration) { p.slept = append(p.slept, d) }
+
+	return t, p
+}
+
+// advance moves wall time by one sample interval while consuming cpu.
+func (p *fakeProcess) advance(cpu time.Duration) {
+	p.now = p.now.Add(defaultSampleInterval)
+	p.cpu += cpu
+}
+
+func TestNewThrottlerDisabled(t *testing.T) {
+	var th *Throttler = NewThrottler(0)
+	assert.Nil(t, th)
+	assert.NotPanics(t, th.Wait)
+	assert.Zero(t, th.Delay())
+}
+
+func TestThrottlerAdaptsToUsage(t *testing.T) {
+	th, p := newTestThrottler(0.1)
+
+	th.Wait()
+	assert.Zero(t, th.Delay(), "first call only records the baseline sample")
+
+	p.advance(50 * time.Millisecond)
+	th.Wait()
+	assert.Zero(t, th.Delay(), "usage under budget must not throttle")
+	assert.Empty(t, p.slept)
+
+	p.advance(500 * time.Millisecond)
+	th.Wait()
+	assert.Equal(t, minDelay, th.Delay())
+
+	p.advance(500 * time.Millisecond)
+	th.Wait()
+	assert.Equal(t, 2*minDelay, th.Delay())
+
+	for range 20 {
+		p.advance(500 * time.Millisecond)
+		th.Wait()
+	}
+
+	assert.Equal(t, maxDelay, th.Delay(), "delay is capped")
+
+	for range 10 {
+		p.advance(0)
+		th.Wait()
+	}
+
+	assert.Zero(t, th.Delay(), "delay decays once usage is back under budget")
+}
+
+func TestThrottlerSleepsBetweenSamples(t *testing.T) {
+	th, p := newTestThrottler(0.1)
+
+	th.Wait()
+	p.advance(time.Second)
+	th.Wait()
+
+	// Within the same sample interval the current delay is reused.
+	th.Wait()
+	th.Wait()
+
+	assert.Equal(t, []time.Duration{minDelay, minDelay, minDelay}, p.slept)
+}
+
+func TestThrottlerCPUTimeError(t *testing.T) {
+	th, p := newTestThrottler(0.1)
+
+	th.Wait()
+	p.advance(time.Second)
+	th.Wait()
+	assert.Equal(t, minDelay, th.Delay())
+
+	p.err = errors.New("getrusage failed")
+	p.advance(time.Second)
+	th.Wait()
+	assert.Zero(t, th.Delay(), "unreadable CPU time disables throttling")
+}
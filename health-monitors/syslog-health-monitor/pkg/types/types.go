@@ -30,6 +30,28 @@ type Handler interface {
 	ProcessLine(message string) (*pb.HealthEvents, error)
 }
 
+// Prefilter is implemented by handlers that can cheaply rule out lines before
+// ProcessLine. Prefilter must not allocate and must return true for every line
+// ProcessLine may produce events or update handler state for, since lines it
+// rejects are never handed to ProcessLine.
+type Prefilter interface {
+	Prefilter(message string) bool
+}
+
+// Evaluator is implemented by handlers that report on the absence of lines.
+// Evaluate is called at the end of every run of the check, after its new lines
+// were handed to ProcessLine.
+type Evaluator interface {
+	Evaluate() (*pb.HealthEvents, error)
+}
+
+// Stateful is implemented by handlers keeping state per entity, e.g. per GPU or
+// kernel. The state is bounded to capacity entities, the least recently used are
+// evicted beyond it.
+type Stateful interface {
+	SetStateCapacity(capacity int)
+}
+
 type ErrorResolution struct {
 	RecommendedAction pb.RecommendedAction
 }
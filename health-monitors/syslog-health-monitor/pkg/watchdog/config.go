@@ -0,0 +1,123 @@
+// Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package watchdog detects expected periodic log lines that stopped appearing,
+// e.g. the heartbeat of a daemon that hung without logging an error.
+package watchdog
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"time"
+
+	"github.com/BurntSushi/toml"
+
+	pb "github.com/nvidia/nvsentinel/data-models/pkg/protos"
+)
+
+// Config is the watchdog configuration file.
+type Config struct {
+	// LogFiles are glob patterns of log files read in addition to the journal,
+	// for daemons that do not log to it
+	LogFiles []string `toml:"log_files"`
+	Rules    []Rule   `toml:"rule"`
+}
+
+// Rule expects a line matching Pattern at least once every Window.
+type Rule struct {
+	// Name identifies the rule in events and metrics
+	Name    string `toml:"name"`
+	Pattern string `toml:"pattern"`
+	// Window is a duration such as "5m"
+	Window string `toml:"window"`
+	// IsFatal and RecommendedAction are set on the event reporting the missing
+	// line. RecommendedAction defaults to NONE.
+	IsFatal           bool   `toml:"is_fatal"`
+	RecommendedAction string `toml:"recommended_action"`
+
+	re     *regexp.Regexp
+	window time.Duration
+	action pb.RecommendedAction
+}
+
+// LoadConfig reads and validates the watchdog configuration at path.
+func LoadConfig(path string) (Config, error) {
+	var config Config
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return config, fmt.Errorf("reading watchdog config %s: %w", path, err)
+	}
+
+	if _, err := toml.Decode(string(data), &config); err != nil {
+		return config, fmt.Errorf("decoding watchdog config %s: %w", path, err)
+	}
+
+	names := make(map[string]bool, len(config.Rules))
+
+	for i := range config.Rules {
+		rule := &config.Rules[i]
+
+		if err := rule.compile(); err != nil {
+			return config, fmt.Errorf("watchdog config %s: %w", path, err)
+		}
+
+		if names[rule.Name] {
+			return config, fmt.Errorf("watchdog config %s: duplicate rule %q", path, rule.Name)
+		}
+
+		names[rule.Name] = true
+	}
+
+	return config, nil
+}
+
+// compile validates the rule and parses its pattern, window and action.
+func (r *Rule) compile() error {
+	if r.Name == "" {
+		return fmt.Errorf("rule name is required")
+	}
+
+	if r.Pattern == "" {
+		return fmt.Errorf("rule %q: pattern is required", r.Name)
+	}
+
+	re, err := regexp.Compile(r.Pattern)
+	if err != nil {
+		return fmt.Errorf("rule %q: invalid pattern %q: %w", r.Name, r.Pattern, err)
+	}
+
+	window, err := time.ParseDuration(r.Window)
+	if err != nil || window <= 0 {
+		return fmt.Errorf("rule %q: window must be a positive duration, got %q", r.Name, r.Window)
+	}
+
+	action := pb.RecommendedAction_NONE
+
+	if r.RecommendedAction != "" {
+		value, ok := pb.RecommendedAction_value[r.RecommendedAction]
+		if !ok {
+			return fmt.Errorf("rule %q: unknown recommended action %q", r.Name, r.RecommendedAction)
+		}
+
+		action = pb.RecommendedAction(value)
+	}
+
+	r.re = re
+	r.window = window
+	r.action = action
+
+	return nil
+}
@@ -0,0 +1,31 @@
+// Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package watchdog
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+var (
+	// Gauge metric set to 1 while the expected line of a rule is missing
+	missingLineMetric = promauto.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "syslog_health_monitor_expected_line_missing",
+			Help: "Set to 1 while the expected periodic log line of a watchdog rule has not appeared within its window",
+		},
+		[]string{"node", "rule"},
+	)
+)
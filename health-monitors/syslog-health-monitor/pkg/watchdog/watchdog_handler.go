@@ -0,0 +1,167 @@
+// Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package watchdog
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	pb "github.com/nvidia/nvsentinel/data-models/pkg/protos"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// ErrorCode is the error code of events reporting a missing expected line.
+const ErrorCode = "EXPECTED_LOG_LINE_MISSING"
+
+// ruleState tracks when the expected line of a rule was last seen.
+type ruleState struct {
+	rule     Rule
+	lastSeen time.Time
+	missing  bool
+}
+
+// WatchdogHandler records the lines matching its rules and reports rules whose
+// line has not been seen within their window. Windows start when the handler is
+// created, so lines logged before the monitor started do not count.
+type WatchdogHandler struct {
+	nodeName              string
+	defaultAgentName      string
+	defaultComponentClass string
+	checkName             string
+	mu                    sync.Mutex
+	rules                 []*ruleState
+	// now returns the current time, replaced in tests
+	now func() time.Time
+}
+
+// NewWatchdogHandler creates a new WatchdogHandler instance for rules loaded
+// with LoadConfig.
+func NewWatchdogHandler(nodeName, defaultAgentName,
+	defaultComponentClass, checkName string, rules []Rule) (*WatchdogHandler, error) {
+	h := &WatchdogHandler{
+		nodeName:              nodeName,
+		defaultAgentName:      defaultAgentName,
+		defaultComponentClass: defaultComponentClass,
+		checkName:             checkName,
+		now:                   time.Now,
+	}
+
+	start := h.now()
+
+	for _, rule := range rules {
+		if rule.re == nil {
+			if err := rule.compile(); err != nil {
+				return nil, err
+			}
+		}
+
+		h.rules = append(h.rules, &ruleState{rule: rule, lastSeen: start})
+	}
+
+	return h, nil
+}
+
+// ProcessLine records the rules matching the line. It returns a healthy event
+// for every rule whose missing line appeared again.
+func (h *WatchdogHandler) ProcessLine(message string) (*pb.HealthEvents, error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	var events []*pb.HealthEvent
+
+	for _, state := range h.rules {
+		if !state.rule.re.MatchString(message) {
+			continue
+		}
+
+		state.lastSeen = h.now()
+
+		if state.missing {
+			state.missing = false
+
+			missingLineMetric.WithLabelValues(h.nodeName, state.rule.Name).Set(0)
+			events = append(events, h.createHealthEvent(state,
+				fmt.Sprintf("Expected log line of %s appeared again", state.rule.Name)))
+		}
+	}
+
+	return healthEvents(events), nil
+}
+
+// Evaluate returns an event for every rule whose line has not been seen within
+// its window since it was last seen or last reported missing.
+func (h *WatchdogHandler) Evaluate() (*pb.HealthEvents, error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	now := h.now()
+
+	var events []*pb.HealthEvent
+
+	for _, state := range h.rules {
+		if state.missing || now.Sub(state.lastSeen) < state.rule.window {
+			continue
+		}
+
+		state.missing = true
+
+		missingLineMetric.WithLabelValues(h.nodeName, state.rule.Name).Set(1)
+		events = append(events, h.createHealthEvent(state,
+			fmt.Sprintf("%s: no log line matching %q of %s for %s", ErrorCode, state.rule.Pattern,
+				state.rule.Name, now.Sub(state.lastSeen).Round(time.Second))))
+	}
+
+	return healthEvents(events), nil
+}
+
+func (h *WatchdogHandler) createHealthEvent(state *ruleState, message string) *pb.HealthEvent {
+	event := &pb.HealthEvent{
+		Version:            1,
+		Agent:              h.defaultAgentName,
+		CheckName:          h.checkName,
+		ComponentClass:     h.defaultComponentClass,
+		GeneratedTimestamp: timestamppb.New(h.now()),
+		EntitiesImpacted: []*pb.Entity{
+			{EntityType: "LOG_RULE", EntityValue: state.rule.Name},
+		},
+		Message:           message,
+		IsFatal:           false,
+		IsHealthy:         true,
+		NodeName:          h.nodeName,
+		RecommendedAction: pb.RecommendedAction_NONE,
+	}
+
+	if state.missing {
+		event.IsFatal = state.rule.IsFatal
+		event.IsHealthy = false
+		event.RecommendedAction = state.rule.action
+		event.ErrorCode = []string{ErrorCode}
+	}
+
+	return event
+}
+
+func healthEvents(events []*pb.HealthEvent) *pb.HealthEvents {
+	if len(events) == 0 {
+		return nil
+	}
+
+	return &pb.HealthEvents{
+		Version: 1,
+		Events:  events,
+	}
+}
@@ -0,0 +1,140 @@
+// Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package watchdog
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	pb "github.com/nvidia/nvsentinel/data-models/pkg/protos"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeConfig(t *testing.T, content string) string {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), "watchdog.toml")
+	require.NoError(t, os.WriteFile(path, []byte(content), 0600))
+
+	return path
+}
+
+func TestLoadConfig(t *testing.T) {
+	config, err := LoadConfig(writeConfig(t, `
+log_files = ["/nvsentinel/var/log/fabricmanager.log"]
+
+[[rule]]
+name = "fabric-manager-heartbeat"
+pattern = 'Fabric Manager .*heartbeat'
+window = "5m"
+is_fatal = true
+recommended_action = "CONTACT_SUPPORT"
+`))
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"/nvsentinel/var/log/fabricmanager.log"}, config.LogFiles)
+	require.Len(t, config.Rules, 1)
+	assert.Equal(t, 5*time.Minute, config.Rules[0].window)
+	assert.Equal(t, pb.RecommendedAction_CONTACT_SUPPORT, config.Rules[0].action)
+
+	invalid := map[string]string{
+		"missing name":      "[[rule]]\npattern = 'x'\nwindow = '1m'\n",
+		"missing pattern":   "[[rule]]\nname = 'a'\nwindow = '1m'\n",
+		"invalid pattern":   "[[rule]]\nname = 'a'\npattern = '('\nwindow = '1m'\n",
+		"invalid window":    "[[rule]]\nname = 'a'\npattern = 'x'\nwindow = '0s'\n",
+		"unknown action":    "[[rule]]\nname = 'a'\npattern = 'x'\nwindow = '1m'\nrecommended_action = 'REBOOT'\n",
+		"duplicate rule":    "[[rule]]\nname = 'a'\npattern = 'x'\nwindow = '1m'\n[[rule]]\nname = 'a'\npattern = 'y'\nwindow = '1m'\n",
+		"invalid toml file": "[[rule]\n",
+	}
+
+	for name, content := range invalid {
+		t.Run(name, func(t *testing.T) {
+			_, err := LoadConfig(writeConfig(t, content))
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestWatchdogHandler(t *testing.T) {
+	rules := []Rule{
+		{Name: "dcgm-exporter-scrape", Pattern: `msg="Metrics collected"`, Window: "2m"},
+		{Name: "fabric-manager-heartbeat", Pattern: `fabric manager heartbeat`, Window: "5m", IsFatal: true,
+			RecommendedAction: "CONTACT_SUPPORT"},
+	}
+
+	h, err := NewWatchdogHandler("node1", "syslog-health-monitor", "GPU", "SysLogsMissingLine", rules)
+	require.NoError(t, err)
+
+	now := time.Now()
+	h.now = func() time.Time { return now }
+
+	// Windows start when the handler is created
+	events, err := h.Evaluate()
+	require.NoError(t, err)
+	assert.Nil(t, events)
+
+	now = now.Add(90 * time.Second)
+	events, err = h.ProcessLine(`level=info msg="Metrics collected" gpus=8`)
+	require.NoError(t, err)
+	assert.Nil(t, events)
+
+	// The scrape was seen 2m ago, the heartbeat was never seen within 5m
+	now = now.Add(4 * time.Minute)
+	events, err = h.Evaluate()
+	require.NoError(t, err)
+	require.NotNil(t, events)
+	require.Len(t, events.Events, 2)
+
+	for _, event := range events.Events {
+		assert.False(t, event.IsHealthy)
+		assert.Equal(t, []string{ErrorCode}, event.ErrorCode)
+		assert.Equal(t, "LOG_RULE", event.EntitiesImpacted[0].EntityType)
+	}
+
+	assert.Equal(t, "dcgm-exporter-scrape", events.Events[0].EntitiesImpacted[0].EntityValue)
+	assert.False(t, events.Events[0].IsFatal)
+	assert.Equal(t, pb.RecommendedAction_NONE, events.Events[0].RecommendedAction)
+	assert.Equal(t, "fabric-manager-heartbeat", events.Events[1].EntitiesImpacted[0].EntityValue)
+	assert.True(t, events.Events[1].IsFatal)
+	assert.Equal(t, pb.RecommendedAction_CONTACT_SUPPORT, events.Events[1].RecommendedAction)
+
+	// A missing line is reported once
+	now = now.Add(10 * time.Minute)
+	events, err = h.Evaluate()
+	require.NoError(t, err)
+	assert.Nil(t, events)
+
+	// The line appearing again clears the rule and restarts its window
+	events, err = h.ProcessLine("nv-fabricmanager: fabric manager heartbeat")
+	require.NoError(t, err)
+	require.NotNil(t, events)
+	require.Len(t, events.Events, 1)
+	assert.True(t, events.Events[0].IsHealthy)
+	assert.Equal(t, "fabric-manager-heartbeat", events.Events[0].EntitiesImpacted[0].EntityValue)
+
+	now = now.Add(4 * time.Minute)
+	events, err = h.Evaluate()
+	require.NoError(t, err)
+	assert.Nil(t, events)
+}
+
+func TestNewWatchdogHandlerInvalidRule(t *testing.T) {
+	_, err := NewWatchdogHandler("node1", "agent", "GPU", "SysLogsMissingLine",
+		[]Rule{{Name: "a", Pattern: "x", Window: "soon"}})
+	assert.Error(t, err)
+}
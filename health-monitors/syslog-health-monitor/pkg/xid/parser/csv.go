@@ -31,7 +31,7 @@ import (
 var (
 	// reXidNVL5Pattern matches NVIDIA NVL5 XID messages with subcode and intrinfo
 	reXidNVL5Pattern = regexp.MustCompile(
-		`NVRM: Xid \(PCI:([^)]+)\): (\d+)(?:, pid=[^,]*)?(?:, name=[^,]*)?, ` +
+		`NVRM: Xid \(PCI:([^)]+)\): (\d+)(?:, pid=([^,]*))?(?:, name=([^,]*))?, ` +
 			`(\w+)\s+(\w+)\s+(\w+)\s+(\w+)\s+Link\s+(-?\d+)\s+\((0x[0-9a-fA-F]+)\s+(0x[0-9a-fA-F]+)`,
 	)
 )
@@ -77,7 +77,7 @@ func (p *CSVParser) Parse(message string) (*Response, error) {
 // parseNVL5XID parses NVL5 XID messages with subcode and intrinfo
 func (p *CSVParser) parseNVL5XID(message string) (*Response, error) {
 	m := reXidNVL5Pattern.FindStringSubmatch(message)
-	if len(m) < 10 {
+	if len(m) < 12 {
 		return nil, nil
 	}
 
@@ -88,9 +88,10 @@ func (p *CSVParser) parseNVL5XID(message string) (*Response, error) {
 	}
 
 	pciAddr := m[1]
-	subcode := m[3]
-	intrInfo, _ := strconv.ParseInt(m[8], 0, 64)
-	errorStatusStr := m[9]
+	pid, processName := processFields(m[3], m[4])
+	subcode := m[5]
+	intrInfo, _ := strconv.ParseInt(m[10], 0, 64)
+	errorStatusStr := m[11]
 
 	rules, exists := p.nvl5Rules[xidCode]
 	if !exists {
@@ -121,6 +122,8 @@ func (p *CSVParser) parseNVL5XID(message string) (*Response, error) {
 		Name:          decodedXIDStr,
 		Number:        xidCode,
 		PCIE:          pciAddr,
+		PID:           pid,
+		ProcessName:   processName,
 		Resolution:    recommendedAction.String(),
 	}
 
@@ -146,6 +149,7 @@ func (p *CSVParser) parseStandardXID(message string) (*Response, error) {
 	}
 
 	pciAddr := m[1]
+	pid, processName := processFields(m[3], m[4])
 
 	var recommendedAction = pb.RecommendedAction_CONTACT_SUPPORT
 	if errRes, found := p.errorResolutionMap[xidCode]; found {
@@ -165,6 +169,8 @@ func (p *CSVParser) parseStandardXID(message string) (*Response, error) {
 		Name:          fmt.Sprintf("%d", xidCode),
 		Number:        xidCode,
 		PCIE:          pciAddr,
+		PID:           pid,
+		ProcessName:   processName,
 		Resolution:    recommendedAction.String(),
 	}
 
@@ -175,6 +181,21 @@ func (p *CSVParser) parseStandardXID(message string) (*Response, error) {
 	}, nil
 }
 
+// processFields returns the pid and name of the process that triggered the XID.
+// The driver reports pid='<unknown>' and name=<unknown> for XIDs not caused by a
+// process, which are dropped.
+func processFields(pid, name string) (string, string) {
+	if _, err := strconv.Atoi(pid); err != nil {
+		pid = ""
+	}
+
+	if name = strings.TrimSpace(name); strings.Contains(name, "<unknown>") {
+		name = ""
+	}
+
+	return pid, name
+}
+
 func (p *CSVParser) matchesNVL5Rule(rule common.NVL5DecodingRule, intrInfo int64, errorStatusStr string) bool {
 	foundMatch := false
 	allEmpty := true
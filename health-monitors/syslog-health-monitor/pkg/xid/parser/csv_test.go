@@ -47,6 +47,8 @@ func TestCSVParser_Parse(t *testing.T) {
 		expectedAction    pb.RecommendedAction
 		expectedMnemonic  string
 		expectedErrorCode string
+		expectedPID       string
+		expectedProcess   string
 	}{
 		{
 			name:              "NL5 XID",
@@ -67,6 +69,8 @@ func TestCSVParser_Parse(t *testing.T) {
 			expectedAction:    pb.RecommendedAction_NONE,
 			expectedMnemonic:  "XID 32",
 			expectedErrorCode: "32",
+			expectedPID:       "2280636",
+			expectedProcess:   "train.3",
 		},
 		{
 			name:              "Minimal XID format",
@@ -87,6 +91,8 @@ func TestCSVParser_Parse(t *testing.T) {
 			expectedAction:    pb.RecommendedAction_NONE,
 			expectedMnemonic:  "XID 69",
 			expectedErrorCode: "69",
+			expectedPID:       "1310987",
+			expectedProcess:   "train.3",
 		},
 		{
 			name:              "Different XID code",
@@ -97,6 +103,18 @@ func TestCSVParser_Parse(t *testing.T) {
 			expectedAction:    pb.RecommendedAction_NONE,
 			expectedMnemonic:  "XID 8",
 			expectedErrorCode: "8",
+			expectedPID:       "5678",
+			expectedProcess:   "idle_timeout",
+		},
+		{
+			name:              "XID without process",
+			message:           "NVRM: Xid (PCI:0000:3b:00): 79, pid='<unknown>', name=<unknown>, GPU has fallen off the bus.",
+			expectedSuccess:   true,
+			expectedXIDCode:   79,
+			expectedPCIAddr:   "0000:3b:00",
+			expectedAction:    pb.RecommendedAction_RESTART_BM,
+			expectedMnemonic:  "XID 79",
+			expectedErrorCode: "79",
 		},
 		{
 			name:              "Unknown XID defaults to CONTACT_SUPPORT",
@@ -107,6 +125,8 @@ func TestCSVParser_Parse(t *testing.T) {
 			expectedAction:    pb.RecommendedAction_CONTACT_SUPPORT,
 			expectedMnemonic:  "XID 999",
 			expectedErrorCode: "999",
+			expectedPID:       "1234",
+			expectedProcess:   "test",
 		},
 		{
 			name:            "Non-XID NVRM message",
@@ -153,6 +173,8 @@ func TestCSVParser_Parse(t *testing.T) {
 			assert.Equal(t, tc.expectedMnemonic, result.Result.Mnemonic, "Mnemonic should match")
 			assert.Equal(t, tc.expectedErrorCode, result.Result.DecodedXIDStr, "Decoded XID string should match")
 			assert.Equal(t, tc.expectedErrorCode, result.Result.Name, "Name should match")
+			assert.Equal(t, tc.expectedPID, result.Result.PID, "PID should match")
+			assert.Equal(t, tc.expectedProcess, result.Result.ProcessName, "Process name should match")
 
 			if tc.expectedXIDCode != 999 {
 				assert.NotEqual(t, pb.RecommendedAction_CONTACT_SUPPORT.String(), result.Result.Resolution,
@@ -43,5 +43,7 @@ type XIDDetails struct {
 	Name                string `json:"name"`
 	Number              int    `json:"number"`
 	PCIE                string `json:"pcie_bdf"`
+	PID                 string `json:"pid,omitempty"`
+	ProcessName         string `json:"process_name,omitempty"`
 	Resolution          string `json:"resolution"`
 }
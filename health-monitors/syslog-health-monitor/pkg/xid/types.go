@@ -17,10 +17,16 @@ package xid
 import (
 	"regexp"
 
+	"github.com/nvidia/nvsentinel/commons/pkg/lrucache"
 	"github.com/nvidia/nvsentinel/health-monitors/syslog-health-monitor/pkg/metadata"
+	"github.com/nvidia/nvsentinel/health-monitors/syslog-health-monitor/pkg/policy"
 	"github.com/nvidia/nvsentinel/health-monitors/syslog-health-monitor/pkg/xid/parser"
 )
 
+// nvrmMarker is present in every NVIDIA driver kernel message, including XID
+// and PCI to GPU UUID mapping lines.
+const nvrmMarker = "NVRM:"
+
 var (
 	reNvrmMap = regexp.MustCompile(`NVRM: GPU at PCI:([0-9a-fA-F:.]+): (GPU-[0-9a-fA-F-]+)`)
 )
@@ -31,7 +37,10 @@ type XIDHandler struct {
 	defaultComponentClass string
 	checkName             string
 
-	pciToGPUUUID   map[string]string
+	// pciToGPUUUID maps the PCI addresses of the driver mapping lines to GPU UUIDs
+	pciToGPUUUID   *lrucache.Cache[string, string]
 	parser         parser.Parser
 	metadataReader *metadata.Reader
+	// policy decides the reported severity and action, nil keeps the built-in ones
+	policy *policy.Policy
 }
@@ -21,13 +21,14 @@ import (
 	"strings"
 	"time"
 
+	"github.com/nvidia/nvsentinel/commons/pkg/lrucache"
+	"github.com/nvidia/nvsentinel/data-models/pkg/model"
 	pb "github.com/nvidia/nvsentinel/data-models/pkg/protos"
 	"github.com/nvidia/nvsentinel/health-monitors/syslog-health-monitor/pkg/common"
 	"github.com/nvidia/nvsentinel/health-monitors/syslog-health-monitor/pkg/metadata"
+	"github.com/nvidia/nvsentinel/health-monitors/syslog-health-monitor/pkg/policy"
 	"github.com/nvidia/nvsentinel/health-monitors/syslog-health-monitor/pkg/xid/metrics"
 	"github.com/nvidia/nvsentinel/health-monitors/syslog-health-monitor/pkg/xid/parser"
-
-	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
 func NewXIDHandler(nodeName, defaultAgentName,
@@ -48,7 +49,7 @@ func NewXIDHandler(nodeName, defaultAgentName,
 		defaultAgentName:      defaultAgentName,
 		defaultComponentClass: defaultComponentClass,
 		checkName:             checkName,
-		pciToGPUUUID:          make(map[string]string),
+		pciToGPUUUID:          lrucache.New[string, string]("syslog_xid_gpu_uuids", lrucache.DefaultCapacity),
 		parser:                xidParser,
 		metadataReader:        metadata.NewReader(metadataPath),
 	}, nil
@@ -63,7 +64,7 @@ func (xidHandler *XIDHandler) ProcessLine(message string) (*pb.HealthEvents, err
 
 	if pciID, gpuUUID := xidHandler.parseNVRMGPUMapLine(message); pciID != "" && gpuUUID != "" {
 		normPCI := xidHandler.normalizePCI(pciID)
-		xidHandler.pciToGPUUUID[normPCI] = gpuUUID
+		xidHandler.pciToGPUUUID.Add(normPCI, gpuUUID)
 
 		slog.Info("Updated PCI->GPU UUID mapping",
 			"pci", normPCI,
@@ -89,6 +90,12 @@ func (xidHandler *XIDHandler) ProcessLine(message string) (*pb.HealthEvents, err
 	return xidHandler.createHealthEventFromResponse(xidResp, message), nil
 }
 
+// Prefilter reports whether the line may be an XID or PCI to GPU UUID mapping
+// message. Other lines are never sent to the XID parser.
+func (xidHandler *XIDHandler) Prefilter(message string) bool {
+	return strings.Contains(message, nvrmMarker)
+}
+
 func (xidHandler *XIDHandler) parseNVRMGPUMapLine(message string) (string, string) {
 	m := reNvrmMap.FindStringSubmatch(message)
 	if len(m) >= 3 {
@@ -122,17 +129,25 @@ func (xidHandler *XIDHandler) getGPUUUID(normPCI string) string {
 		slog.Error("Error getting GPU UUID from metadata", "pci", normPCI, "error", err)
 	}
 
-	if uuid, ok := xidHandler.pciToGPUUUID[normPCI]; ok {
+	if uuid, ok := xidHandler.pciToGPUUUID.Get(normPCI); ok {
 		return uuid
 	}
 
 	return ""
 }
 
-func (xidHandler *XIDHandler) createHealthEventFromResponse(
-	xidResp *parser.Response,
-	message string,
-) *pb.HealthEvents {
+// SetStateCapacity bounds the number of PCI to GPU UUID mappings kept.
+func (xidHandler *XIDHandler) SetStateCapacity(capacity int) {
+	xidHandler.pciToGPUUUID.Resize(capacity)
+}
+
+// SetPolicy sets the event policy deciding the severity and action of XID facts.
+func (xidHandler *XIDHandler) SetPolicy(eventPolicy *policy.Policy) {
+	xidHandler.policy = eventPolicy
+}
+
+// extractFact returns what the XID line reports, without severity or action.
+func (xidHandler *XIDHandler) extractFact(xidResp *parser.Response, message string) policy.Fact {
 	entities := []*pb.Entity{
 		{EntityType: "PCI", EntityValue: xidResp.Result.PCIE},
 	}
@@ -150,30 +165,62 @@ func (xidHandler *XIDHandler) createHealthEventFromResponse(
 		metadata["chassis_serial"] = *chassisSerial
 	}
 
+	// The process lets the workload that hit the Xid be identified from the event
+	if xidResp.Result.PID != "" {
+		metadata[model.MetadataPID] = xidResp.Result.PID
+	}
+
+	if xidResp.Result.ProcessName != "" {
+		metadata[model.MetadataProcessName] = xidResp.Result.ProcessName
+	}
+
+	return policy.Fact{
+		CheckName: xidHandler.checkName,
+		ErrorCode: xidResp.Result.DecodedXIDStr,
+		Entities:  entities,
+		Attributes: map[string]string{
+			"pci":        xidResp.Result.PCIE,
+			"mnemonic":   xidResp.Result.Mnemonic,
+			"name":       xidResp.Result.Name,
+			"resolution": xidResp.Result.Resolution,
+			"pid":        xidResp.Result.PID,
+			"process":    xidResp.Result.ProcessName,
+		},
+		Metadata: metadata,
+		Line:     message,
+	}
+}
+
+// builtinDecision reports the XID with the resolution of the XID catalog.
+func (xidHandler *XIDHandler) builtinDecision(fact policy.Fact) policy.Decision {
+	recommendedAction := common.MapActionStringToProto(fact.Attributes["resolution"])
+
+	return policy.Decision{
+		IsFatal:           xidHandler.determineFatality(recommendedAction),
+		RecommendedAction: recommendedAction,
+		Message:           fact.Line,
+	}
+}
+
+func (xidHandler *XIDHandler) createHealthEventFromResponse(
+	xidResp *parser.Response,
+	message string,
+) *pb.HealthEvents {
+	fact := xidHandler.extractFact(xidResp, message)
+
 	metrics.XidCounterMetric.WithLabelValues(
 		xidHandler.nodeName,
 		xidResp.Result.DecodedXIDStr,
 	).Inc()
 
-	recommendedAction := common.MapActionStringToProto(xidResp.Result.Resolution)
-	event := &pb.HealthEvent{
-		Version:            1,
-		Agent:              xidHandler.defaultAgentName,
-		CheckName:          xidHandler.checkName,
-		ComponentClass:     xidHandler.defaultComponentClass,
-		GeneratedTimestamp: timestamppb.New(time.Now()),
-		EntitiesImpacted:   entities,
-		Message:            message,
-		IsFatal:            xidHandler.determineFatality(recommendedAction),
-		IsHealthy:          false,
-		NodeName:           xidHandler.nodeName,
-		RecommendedAction:  recommendedAction,
-		ErrorCode:          []string{xidResp.Result.DecodedXIDStr},
-		Metadata:           metadata,
+	source := policy.Source{
+		NodeName:       xidHandler.nodeName,
+		Agent:          xidHandler.defaultAgentName,
+		ComponentClass: xidHandler.defaultComponentClass,
 	}
 
 	return &pb.HealthEvents{
 		Version: 1,
-		Events:  []*pb.HealthEvent{event},
+		Events:  []*pb.HealthEvent{xidHandler.policy.Event(source, fact, xidHandler.builtinDecision(fact))},
 	}
 }
@@ -18,6 +18,7 @@ import (
 	"errors"
 	"testing"
 
+	"github.com/nvidia/nvsentinel/data-models/pkg/model"
 	pb "github.com/nvidia/nvsentinel/data-models/pkg/protos"
 	"github.com/nvidia/nvsentinel/health-monitors/syslog-health-monitor/pkg/xid/parser"
 	"github.com/stretchr/testify/assert"
@@ -211,7 +212,7 @@ func TestProcessLine(t *testing.T) {
 						}, nil
 					},
 				}
-				h.pciToGPUUUID["0000:00:08"] = "GPU-12345678-1234-1234-1234-123456789012"
+				h.pciToGPUUUID.Add("0000:00:08", "GPU-12345678-1234-1234-1234-123456789012")
 				return h
 			},
 			expectEvent: true,
@@ -300,6 +301,31 @@ func TestProcessLine(t *testing.T) {
 	}
 }
 
+func TestProcessLineReportsProcess(t *testing.T) {
+	xidHandler, err := NewXIDHandler("test-node", "test-agent", "GPU", "SysLogsXIDError", "",
+		"/nonexistent/metadata.json")
+	require.NoError(t, err)
+
+	events, err := xidHandler.ProcessLine(
+		"kernel: NVRM: Xid (PCI:0000:3b:00): 31, pid=48213, name=python3, Ch 00000008, intr 00000000. " +
+			"MMU Fault: ENGINE GRAPHICS GPCCLIENT_T1_0 faulted @ 0x7f_12340000.")
+	require.NoError(t, err)
+	require.NotNil(t, events)
+	require.Len(t, events.Events, 1)
+
+	event := events.Events[0]
+	assert.Equal(t, []string{"31"}, event.ErrorCode)
+	assert.Equal(t, "48213", event.Metadata[model.MetadataPID])
+	assert.Equal(t, "python3", event.Metadata[model.MetadataProcessName])
+
+	// Xids the driver logs without a process carry none
+	events, err = xidHandler.ProcessLine("kernel: NVRM: Xid (PCI:0000:3b:00): 79, GPU has fallen off the bus.")
+	require.NoError(t, err)
+	require.NotNil(t, events)
+	assert.NotContains(t, events.Events[0].Metadata, model.MetadataPID)
+	assert.NotContains(t, events.Events[0].Metadata, model.MetadataProcessName)
+}
+
 func TestCreateHealthEventFromResponse(t *testing.T) {
 	handler, _ := NewXIDHandler("test-node", "test-agent", "GPU", "xid-check", "", "/tmp/metadata.json")
 
@@ -358,7 +384,7 @@ func TestCreateHealthEventFromResponse(t *testing.T) {
 			},
 			message: "Test XID message",
 			setupHandler: func() {
-				handler.pciToGPUUUID["0000:00:09"] = "GPU-ABCDEF12-3456-7890-ABCD-EF1234567890"
+				handler.pciToGPUUUID.Add("0000:00:09", "GPU-ABCDEF12-3456-7890-ABCD-EF1234567890")
 			},
 			validateEvent: func(t *testing.T, events *pb.HealthEvents) {
 				require.NotNil(t, events)
@@ -377,7 +403,7 @@ func TestCreateHealthEventFromResponse(t *testing.T) {
 
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
-			handler.pciToGPUUUID = make(map[string]string)
+			handler.pciToGPUUUID.Purge()
 			tc.setupHandler()
 
 			events := handler.createHealthEventFromResponse(tc.xidResp, tc.message)
@@ -440,3 +466,16 @@ func TestNewXIDHandler(t *testing.T) {
 		})
 	}
 }
+
+func TestSetStateCapacity(t *testing.T) {
+	handler, err := NewXIDHandler("test-node", "test-agent", "GPU", "xid-check", "", "/tmp/metadata.json")
+	require.NoError(t, err)
+
+	handler.pciToGPUUUID.Add("0000:17:00", "GPU-1")
+	handler.pciToGPUUUID.Add("0000:18:00", "GPU-2")
+	handler.SetStateCapacity(1)
+
+	assert.Equal(t, 1, handler.pciToGPUUUID.Len())
+	assert.False(t, handler.pciToGPUUUID.Contains("0000:17:00"), "the least recently used mapping is evicted")
+	assert.True(t, handler.pciToGPUUUID.Contains("0000:18:00"))
+}
@@ -0,0 +1,217 @@
+// Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// DriverReload condition types
+const (
+	// DriverReloadConditionUpgradeRequested indicates whether the driver restart has been
+	// requested from the GPU Operator
+	DriverReloadConditionUpgradeRequested = "UpgradeRequested"
+	// DriverReloadConditionDriverReady indicates whether the GPU Operator validated the
+	// reloaded driver on the node
+	DriverReloadConditionDriverReady = "DriverReady"
+)
+
+// DriverReloadSpec defines the desired state of DriverReload
+type DriverReloadSpec struct {
+	// NodeName is the name of the node whose GPU driver is reloaded
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=1
+	NodeName string `json:"nodeName"`
+}
+
+// DriverReloadStatus defines the observed state of DriverReload
+type DriverReloadStatus struct {
+	// StartTime is the time when the driver reload was initiated
+	StartTime *metav1.Time `json:"startTime,omitempty"`
+
+	// CompletionTime is the time when the driver reload was completed
+	CompletionTime *metav1.Time `json:"completionTime,omitempty"`
+
+	// RetryCount tracks the number of reconciliation attempts for this driver reload
+	RetryCount int32 `json:"retryCount,omitempty"`
+
+	// ConsecutiveFailures tracks consecutive failed API calls for exponential backoff
+	// Reset to 0 on successful operations
+	ConsecutiveFailures int32 `json:"consecutiveFailures,omitempty"`
+
+	// Phase is the persisted state of the action, used to resume it after a
+	// controller restart
+	Phase ActionPhase `json:"phase,omitempty"`
+
+	// LockToken is the fencing token of the lock of the node held while the
+	// action runs, so only one destructive action runs on a node at a time
+	LockToken int64 `json:"lockToken,omitempty"`
+
+	// Conditions represent the latest available observations of an object's current state.
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:scope=Cluster
+// +kubebuilder:printcolumn:name="Node",type="string",JSONPath=".spec.nodeName"
+// +kubebuilder:printcolumn:name="DriverReady",type="string",JSONPath=".status.conditions[?(@.type=='DriverReady')].status"
+// +kubebuilder:printcolumn:name="Phase",type="string",JSONPath=".status.phase"
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+
+// DriverReload is the Schema for the driverreloads API. The driver is reloaded through
+// the GPU Operator driver upgrade mechanism instead of unloading kernel modules on the
+// node directly, and the reload is complete once the operator validated the driver.
+type DriverReload struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   DriverReloadSpec   `json:"spec,omitempty"`
+	Status DriverReloadStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// DriverReloadList contains a list of DriverReload
+type DriverReloadList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []DriverReload `json:"items"`
+}
+
+// GetUpgradeRequestTime returns the time the driver restart was requested from the GPU
+// Operator, or nil if it has not been requested yet
+func (d *DriverReload) GetUpgradeRequestTime() *metav1.Time {
+	for _, condition := range d.Status.Conditions {
+		if condition.Type == DriverReloadConditionUpgradeRequested && condition.Status == metav1.ConditionTrue {
+			requestTime := condition.LastTransitionTime
+			return &requestTime
+		}
+	}
+
+	return nil
+}
+
+// SetInitialConditions sets the initial conditions for the DriverReload to Unknown state
+func (d *DriverReload) SetInitialConditions() {
+	now := metav1.Now()
+
+	hasUpgradeRequested := false
+	hasDriverReady := false
+
+	for _, condition := range d.Status.Conditions {
+		if condition.Type == DriverReloadConditionUpgradeRequested {
+			hasUpgradeRequested = true
+		}
+
+		if condition.Type == DriverReloadConditionDriverReady {
+			hasDriverReady = true
+		}
+	}
+
+	if !hasUpgradeRequested {
+		d.SetCondition(metav1.Condition{
+			Type:               DriverReloadConditionUpgradeRequested,
+			Status:             metav1.ConditionUnknown,
+			Reason:             "Initializing",
+			Message:            "Driver restart not yet requested from the GPU Operator",
+			LastTransitionTime: now,
+		})
+	}
+
+	if !hasDriverReady {
+		d.SetCondition(metav1.Condition{
+			Type:               DriverReloadConditionDriverReady,
+			Status:             metav1.ConditionUnknown,
+			Reason:             "Initializing",
+			Message:            "Driver validation state not yet determined",
+			LastTransitionTime: now,
+		})
+	}
+}
+
+// SetCondition updates a condition only if it has changed
+func (d *DriverReload) SetCondition(newCondition metav1.Condition) {
+	for i, condition := range d.Status.Conditions {
+		if condition.Type == newCondition.Type {
+			if condition.Status == newCondition.Status &&
+				condition.Reason == newCondition.Reason &&
+				condition.Message == newCondition.Message {
+				return
+			}
+
+			d.Status.Conditions[i].Status = newCondition.Status
+			d.Status.Conditions[i].LastTransitionTime = newCondition.LastTransitionTime
+			d.Status.Conditions[i].Reason = newCondition.Reason
+			d.Status.Conditions[i].Message = newCondition.Message
+
+			return
+		}
+	}
+
+	d.Status.Conditions = append(d.Status.Conditions, newCondition)
+}
+
+// SetStartTime sets the start time to now if not set
+func (d *DriverReload) SetStartTime() {
+	if d.Status.StartTime == nil {
+		now := metav1.Now()
+		d.Status.StartTime = &now
+	}
+}
+
+// SetCompletionTime sets the completion time to now if not set
+func (d *DriverReload) SetCompletionTime() {
+	if d.Status.CompletionTime == nil {
+		now := metav1.Now()
+		d.Status.CompletionTime = &now
+	}
+}
+
+// Interface implementation for generic status update handling
+
+// GetRetryCount returns the retry count
+func (s *DriverReloadStatus) GetRetryCount() int32 {
+	return s.RetryCount
+}
+
+// GetConsecutiveFailures returns the consecutive failures count
+func (s *DriverReloadStatus) GetConsecutiveFailures() int32 {
+	return s.ConsecutiveFailures
+}
+
+// GetStartTime returns the start time
+func (s *DriverReloadStatus) GetStartTime() *metav1.Time {
+	return s.StartTime
+}
+
+// GetCompletionTime returns the completion time
+func (s *DriverReloadStatus) GetCompletionTime() *metav1.Time {
+	return s.CompletionTime
+}
+
+// GetPhase returns the phase
+func (s *DriverReloadStatus) GetPhase() ActionPhase {
+	return s.Phase
+}
+
+// GetConditions returns the conditions
+func (s *DriverReloadStatus) GetConditions() []metav1.Condition {
+	return s.Conditions
+}
+
+func init() {
+	SchemeBuilder.Register(&DriverReload{}, &DriverReloadList{})
+}
@@ -59,6 +59,24 @@ type GPUResetStatus struct {
 	// regardless of the outcome (success or failure).
 	CompletionTime *metav1.Time `json:"completionTime,omitempty"`
 
+	// RetryCount tracks the number of reconciliation attempts for this reset
+	RetryCount int32 `json:"retryCount,omitempty"`
+
+	// ConsecutiveFailures tracks consecutive failed API calls for exponential backoff
+	// Reset to 0 on successful operations
+	ConsecutiveFailures int32 `json:"consecutiveFailures,omitempty"`
+
+	// Phase is the persisted state of the action, used to resume it after a
+	// controller restart
+	Phase ActionPhase `json:"phase,omitempty"`
+
+	// LockToken is the fencing token of the lock of the node held while the
+	// action runs, so only one destructive action runs on a node at a time
+	LockToken int64 `json:"lockToken,omitempty"`
+
+	// JobName is the name of the Job resetting the GPUs on the node
+	JobName string `json:"jobName,omitempty"`
+
 	// Conditions represent the latest available observations of an object's current state.
 	Conditions []metav1.Condition `json:"conditions,omitempty"`
 }
@@ -73,9 +91,13 @@ const (
 // +kubebuilder:subresource:status
 //nolint:lll // kubebuilder printcolumn marker
 // +kubebuilder:printcolumn:name="Node",type="string",JSONPath=".spec.nodeName",description="The target node for the GPU reset"
+// +kubebuilder:printcolumn:name="GPUReset",type="string",JSONPath=".status.conditions[?(@.type=='GPUReset')].status"
+// +kubebuilder:printcolumn:name="Phase",type="string",JSONPath=".status.phase"
 // +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
 
-// GPUReset is the Schema for the gpuresets API.
+// GPUReset is the Schema for the gpuresets API. Only the pods the kubelet allocated
+// the selected GPUs to are evicted before the reset, instead of draining the node, and
+// the GPUs are reset once no process runs on them anymore.
 type GPUReset struct {
 	metav1.TypeMeta   `json:",inline"`
 	metav1.ObjectMeta `json:"metadata,omitempty"`
@@ -140,6 +162,17 @@ func (g *GPUReset) SetCondition(condition metav1.Condition) {
 	g.Status.Conditions = append(g.Status.Conditions, condition)
 }
 
+// GetCondition returns the condition of the given type, or nil if it is not set
+func (g *GPUReset) GetCondition(conditionType string) *metav1.Condition {
+	for i := range g.Status.Conditions {
+		if g.Status.Conditions[i].Type == conditionType {
+			return &g.Status.Conditions[i]
+		}
+	}
+
+	return nil
+}
+
 // IsResetInProgress returns true if the GPU reset is in progress
 func (g *GPUReset) IsResetInProgress() bool {
 	for _, condition := range g.Status.Conditions {
@@ -155,3 +188,35 @@ func (g *GPUReset) IsResetInProgress() bool {
 func (g *GPUReset) GetCSPReqRef() string {
 	return string(g.UID)
 }
+
+// Interface implementation for generic status update handling
+
+// GetRetryCount returns the retry count
+func (s *GPUResetStatus) GetRetryCount() int32 {
+	return s.RetryCount
+}
+
+// GetConsecutiveFailures returns the consecutive failures count
+func (s *GPUResetStatus) GetConsecutiveFailures() int32 {
+	return s.ConsecutiveFailures
+}
+
+// GetStartTime returns the start time
+func (s *GPUResetStatus) GetStartTime() *metav1.Time {
+	return s.StartTime
+}
+
+// GetCompletionTime returns the completion time
+func (s *GPUResetStatus) GetCompletionTime() *metav1.Time {
+	return s.CompletionTime
+}
+
+// GetPhase returns the phase
+func (s *GPUResetStatus) GetPhase() ActionPhase {
+	return s.Phase
+}
+
+// GetConditions returns the conditions
+func (s *GPUResetStatus) GetConditions() []metav1.Condition {
+	return s.Conditions
+}
@@ -0,0 +1,233 @@
+// Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// MIGReconfiguration condition types, in the order the reconfiguration passes them
+const (
+	// MIGReconfigurationConditionMIGDisabled indicates whether the GPU Operator MIG
+	// manager disabled MIG on the GPUs of the node, destroying their instances
+	MIGReconfigurationConditionMIGDisabled = "MIGDisabled"
+	// MIGReconfigurationConditionMIGConfigured indicates whether the MIG manager
+	// recreated the instances of the MIG configuration
+	MIGReconfigurationConditionMIGConfigured = "MIGConfigured"
+	// MIGReconfigurationConditionInstancesReady indicates whether the device plugin
+	// advertises the recreated instances again
+	MIGReconfigurationConditionInstancesReady = "InstancesReady"
+
+	// MIGReconfigurationReasonRequested marks a stage requested from the MIG manager
+	// that has not completed yet
+	MIGReconfigurationReasonRequested = "Requested"
+)
+
+// MIGReconfigurationSpec defines the desired state of MIGReconfiguration
+type MIGReconfigurationSpec struct {
+	// NodeName is the name of the node whose MIG instances are recreated
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=1
+	NodeName string `json:"nodeName"`
+
+	// MIGConfig is the MIG manager configuration the instances are recreated with,
+	// defaults to the nvidia.com/mig.config label of the node
+	// +optional
+	MIGConfig string `json:"migConfig,omitempty"`
+}
+
+// MIGReconfigurationStatus defines the observed state of MIGReconfiguration
+type MIGReconfigurationStatus struct {
+	// StartTime is the time when the MIG reconfiguration was initiated
+	StartTime *metav1.Time `json:"startTime,omitempty"`
+
+	// CompletionTime is the time when the MIG reconfiguration was completed
+	CompletionTime *metav1.Time `json:"completionTime,omitempty"`
+
+	// RetryCount tracks the number of reconciliation attempts for this MIG reconfiguration
+	RetryCount int32 `json:"retryCount,omitempty"`
+
+	// ConsecutiveFailures tracks consecutive failed API calls for exponential backoff
+	// Reset to 0 on successful operations
+	ConsecutiveFailures int32 `json:"consecutiveFailures,omitempty"`
+
+	// Phase is the persisted state of the action, used to resume it after a
+	// controller restart
+	Phase ActionPhase `json:"phase,omitempty"`
+
+	// LockToken is the fencing token of the lock of the node held while the
+	// action runs, so only one destructive action runs on a node at a time
+	LockToken int64 `json:"lockToken,omitempty"`
+
+	// MIGConfig is the MIG manager configuration the instances are recreated with,
+	// recorded before MIG is disabled
+	MIGConfig string `json:"migConfig,omitempty"`
+
+	// Conditions represent the latest available observations of an object's current state.
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:scope=Cluster
+// +kubebuilder:printcolumn:name="Node",type="string",JSONPath=".spec.nodeName"
+// +kubebuilder:printcolumn:name="MIGConfig",type="string",JSONPath=".status.migConfig"
+// +kubebuilder:printcolumn:name="InstancesReady",type="string",JSONPath=".status.conditions[?(@.type=='InstancesReady')].status"
+// +kubebuilder:printcolumn:name="Phase",type="string",JSONPath=".status.phase"
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+
+// MIGReconfiguration is the Schema for the migreconfigurations API. The MIG instances of
+// the GPUs of a node are destroyed and recreated through the GPU Operator MIG manager:
+// MIG is disabled with the all-disabled configuration, the MIG configuration is applied
+// again, and the reconfiguration is complete once the device plugin advertises the
+// recreated instances.
+type MIGReconfiguration struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   MIGReconfigurationSpec   `json:"spec,omitempty"`
+	Status MIGReconfigurationStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// MIGReconfigurationList contains a list of MIGReconfiguration
+type MIGReconfigurationList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []MIGReconfiguration `json:"items"`
+}
+
+// GetCondition returns the condition of the given type, or nil if it is not set
+func (m *MIGReconfiguration) GetCondition(conditionType string) *metav1.Condition {
+	for i := range m.Status.Conditions {
+		if m.Status.Conditions[i].Type == conditionType {
+			return &m.Status.Conditions[i]
+		}
+	}
+
+	return nil
+}
+
+// IsRequested returns true if the stage of the condition type was requested from the
+// MIG manager and has not completed yet
+func (m *MIGReconfiguration) IsRequested(conditionType string) bool {
+	condition := m.GetCondition(conditionType)
+
+	return condition != nil && condition.Status == metav1.ConditionFalse &&
+		condition.Reason == MIGReconfigurationReasonRequested
+}
+
+// IsDone returns true if the stage of the condition type completed
+func (m *MIGReconfiguration) IsDone(conditionType string) bool {
+	condition := m.GetCondition(conditionType)
+
+	return condition != nil && condition.Status == metav1.ConditionTrue
+}
+
+// SetInitialConditions sets the initial conditions for the MIGReconfiguration to Unknown state
+func (m *MIGReconfiguration) SetInitialConditions() {
+	now := metav1.Now()
+
+	for _, conditionType := range []string{
+		MIGReconfigurationConditionMIGDisabled,
+		MIGReconfigurationConditionMIGConfigured,
+		MIGReconfigurationConditionInstancesReady,
+	} {
+		if m.GetCondition(conditionType) == nil {
+			m.SetCondition(metav1.Condition{
+				Type:               conditionType,
+				Status:             metav1.ConditionUnknown,
+				Reason:             "Initializing",
+				Message:            "Not yet requested from the MIG manager",
+				LastTransitionTime: now,
+			})
+		}
+	}
+}
+
+// SetCondition updates a condition only if it has changed
+func (m *MIGReconfiguration) SetCondition(newCondition metav1.Condition) {
+	for i, condition := range m.Status.Conditions {
+		if condition.Type == newCondition.Type {
+			if condition.Status == newCondition.Status &&
+				condition.Reason == newCondition.Reason &&
+				condition.Message == newCondition.Message {
+				return
+			}
+
+			m.Status.Conditions[i].Status = newCondition.Status
+			m.Status.Conditions[i].LastTransitionTime = newCondition.LastTransitionTime
+			m.Status.Conditions[i].Reason = newCondition.Reason
+			m.Status.Conditions[i].Message = newCondition.Message
+
+			return
+		}
+	}
+
+	m.Status.Conditions = append(m.Status.Conditions, newCondition)
+}
+
+// SetStartTime sets the start time to now if not set
+func (m *MIGReconfiguration) SetStartTime() {
+	if m.Status.StartTime == nil {
+		now := metav1.Now()
+		m.Status.StartTime = &now
+	}
+}
+
+// SetCompletionTime sets the completion time to now if not set
+func (m *MIGReconfiguration) SetCompletionTime() {
+	if m.Status.CompletionTime == nil {
+		now := metav1.Now()
+		m.Status.CompletionTime = &now
+	}
+}
+
+// Interface implementation for generic status update handling
+
+// GetRetryCount returns the retry count
+func (s *MIGReconfigurationStatus) GetRetryCount() int32 {
+	return s.RetryCount
+}
+
+// GetConsecutiveFailures returns the consecutive failures count
+func (s *MIGReconfigurationStatus) GetConsecutiveFailures() int32 {
+	return s.ConsecutiveFailures
+}
+
+// GetStartTime returns the start time
+func (s *MIGReconfigurationStatus) GetStartTime() *metav1.Time {
+	return s.StartTime
+}
+
+// GetCompletionTime returns the completion time
+func (s *MIGReconfigurationStatus) GetCompletionTime() *metav1.Time {
+	return s.CompletionTime
+}
+
+// GetPhase returns the phase
+func (s *MIGReconfigurationStatus) GetPhase() ActionPhase {
+	return s.Phase
+}
+
+// GetConditions returns the conditions
+func (s *MIGReconfigurationStatus) GetConditions() []metav1.Condition {
+	return s.Conditions
+}
+
+func init() {
+	SchemeBuilder.Register(&MIGReconfiguration{}, &MIGReconfigurationList{})
+}
@@ -0,0 +1,93 @@
+// Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1alpha1
+
+const (
+	// CancelAnnotation cancels an in-flight node action when set. The value is recorded
+	// as the reason, e.g. kubectl annotate rebootnode <name> janitor.dgxc.nvidia.com/cancel="node fixed by hand"
+	CancelAnnotation = "janitor.dgxc.nvidia.com/cancel"
+	// ForceFailAnnotation fails a stuck node action when set. The value is recorded as the reason.
+	ForceFailAnnotation = "janitor.dgxc.nvidia.com/force-fail"
+	// ReverifyAnnotation makes the controller check an action in Verifying again right
+	// away instead of at its next backoff. Its value is the time it was requested at,
+	// so setting it again triggers another check.
+	ReverifyAnnotation = "janitor.dgxc.nvidia.com/reverify"
+	// ApproveAnnotation lets the controller perform an action waiting for an outside actor
+	// in manual mode. The value is recorded as the reason.
+	ApproveAnnotation = "janitor.dgxc.nvidia.com/approve"
+
+	// NeedsHumanAttentionConditionType is set on actions that ended without reaching the
+	// desired state and need an operator to look at the node
+	NeedsHumanAttentionConditionType = "NeedsHumanAttention"
+)
+
+// ActionPhase is the persisted state of a node action. Actions move through
+// Requested -> Approved -> Executing -> Verifying -> Done, and may end in
+// Failed from any non-terminal phase. Executing is recorded before the CSP
+// is called so that a controller restarted mid-call can tell that the
+// outcome of the signal is unknown and resume instead of re-firing blindly.
+// +kubebuilder:validation:Enum=Requested;Approved;Executing;Verifying;Done;Failed
+type ActionPhase string
+
+const (
+	// ActionPhaseRequested means the action was created and is awaiting approval.
+	// Actions stay here in manual mode until an outside actor performs them.
+	ActionPhaseRequested ActionPhase = "Requested"
+	// ActionPhaseApproved means the action may be executed.
+	ActionPhaseApproved ActionPhase = "Approved"
+	// ActionPhaseExecuting means the signal is being sent to the CSP.
+	ActionPhaseExecuting ActionPhase = "Executing"
+	// ActionPhaseVerifying means the signal was accepted and the controller
+	// is waiting for the node to reach the desired state.
+	ActionPhaseVerifying ActionPhase = "Verifying"
+	// ActionPhaseDone means the action completed successfully.
+	ActionPhaseDone ActionPhase = "Done"
+	// ActionPhaseFailed means the action failed and will not be retried.
+	ActionPhaseFailed ActionPhase = "Failed"
+)
+
+// nolint:gochecknoglobals // static transition table
+var actionPhaseTransitions = map[ActionPhase][]ActionPhase{
+	"": {ActionPhaseRequested},
+	// Requested moves straight to Verifying when an outside actor sent the
+	// signal in manual mode.
+	ActionPhaseRequested: {ActionPhaseApproved, ActionPhaseVerifying, ActionPhaseFailed},
+	ActionPhaseApproved:  {ActionPhaseExecuting, ActionPhaseFailed},
+	// Executing falls back to Approved when the CSP call timed out and
+	// will be retried.
+	ActionPhaseExecuting: {ActionPhaseVerifying, ActionPhaseApproved, ActionPhaseFailed},
+	ActionPhaseVerifying: {ActionPhaseDone, ActionPhaseFailed},
+}
+
+// IsTerminal returns true if no further transitions are possible.
+func (p ActionPhase) IsTerminal() bool {
+	return p == ActionPhaseDone || p == ActionPhaseFailed
+}
+
+// CanTransitionTo returns true if moving from p to next is a valid transition.
+// Staying in the same phase is always allowed.
+func (p ActionPhase) CanTransitionTo(next ActionPhase) bool {
+	if p == next {
+		return true
+	}
+
+	for _, allowed := range actionPhaseTransitions[p] {
+		if allowed == next {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,57 @@
+// Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1alpha1
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestActionPhase_CanTransitionTo(t *testing.T) {
+	tests := []struct {
+		from     ActionPhase
+		to       ActionPhase
+		expected bool
+	}{
+		{"", ActionPhaseRequested, true},
+		{"", ActionPhaseExecuting, false},
+		{ActionPhaseRequested, ActionPhaseApproved, true},
+		{ActionPhaseRequested, ActionPhaseVerifying, true},
+		{ActionPhaseRequested, ActionPhaseExecuting, false},
+		{ActionPhaseApproved, ActionPhaseExecuting, true},
+		{ActionPhaseExecuting, ActionPhaseVerifying, true},
+		{ActionPhaseExecuting, ActionPhaseApproved, true},
+		{ActionPhaseExecuting, ActionPhaseDone, false},
+		{ActionPhaseVerifying, ActionPhaseDone, true},
+		{ActionPhaseVerifying, ActionPhaseFailed, true},
+		{ActionPhaseVerifying, ActionPhaseVerifying, true},
+		{ActionPhaseDone, ActionPhaseExecuting, false},
+		{ActionPhaseFailed, ActionPhaseRequested, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.from)+"->"+string(tt.to), func(t *testing.T) {
+			assert.Equal(t, tt.expected, tt.from.CanTransitionTo(tt.to))
+		})
+	}
+}
+
+func TestActionPhase_IsTerminal(t *testing.T) {
+	assert.True(t, ActionPhaseDone.IsTerminal())
+	assert.True(t, ActionPhaseFailed.IsTerminal())
+	assert.False(t, ActionPhaseExecuting.IsTerminal())
+	assert.False(t, ActionPhase("").IsTerminal())
+}
@@ -0,0 +1,246 @@
+// Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// PowerCapConditionCapped is true while the power limits of the selected GPUs
+	// are capped
+	PowerCapConditionCapped = "Capped"
+	// PowerCapConditionReleased indicates whether the power cap has been lifted after
+	// the thermals normalized. It is unknown while the cap is applied or held.
+	PowerCapConditionReleased = "Released"
+
+	// PowerCapAnnotation records the power cap of a node while it is applied, so the
+	// cap is visible on the node and can be lifted by hand if its PowerCap is gone.
+	// The value is the JSON of PowerCapRecord.
+	PowerCapAnnotation = "janitor.dgxc.nvidia.com/power-cap"
+)
+
+// PowerCapSpec defines the desired state of PowerCap.
+type PowerCapSpec struct {
+	// NodeName identifies the node which contains the GPUs to cap.
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=1
+	NodeName string `json:"nodeName"`
+
+	// Selector is used to target one or more specific GPUs to cap.
+	// If this field is omitted or empty, all GPUs on the node are capped.
+	// +optional
+	Selector *GPUSelector `json:"selector,omitempty"`
+
+	// LimitPercent is the power limit of the GPUs while capped, in percent of their
+	// default power limit. Limits below the minimum of a GPU are raised to it. Defaults
+	// to the limit of the controller configuration.
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=100
+	LimitPercent int32 `json:"limitPercent,omitempty"`
+
+	// ConditionType is the node condition reporting the thermal fault, i.e. the check
+	// name of the health event. The cap is lifted once the condition has not been true
+	// for the settle time of the controller configuration. Without it, the cap is
+	// lifted after the hold duration.
+	// +optional
+	ConditionType string `json:"conditionType,omitempty"`
+}
+
+// GPUPowerLimit is the power limit of a GPU before and while it is capped.
+type GPUPowerLimit struct {
+	// UUID is the UUID of the GPU
+	UUID string `json:"uuid"`
+	// OriginalWatts is the power limit of the GPU before the cap, restored when the
+	// cap is lifted
+	OriginalWatts int32 `json:"originalWatts"`
+	// CappedWatts is the power limit of the GPU while capped
+	CappedWatts int32 `json:"cappedWatts"`
+}
+
+// PowerCapRecord is the value of the PowerCapAnnotation of a node.
+type PowerCapRecord struct {
+	// PowerCap is the name of the PowerCap that capped the node
+	PowerCap string `json:"powerCap"`
+	// GPUs are the power limits of the capped GPUs
+	GPUs []GPUPowerLimit `json:"gpus"`
+}
+
+// PowerCapStatus defines the observed state of PowerCap.
+type PowerCapStatus struct {
+	// StartTime is the time at which the power cap began processing.
+	StartTime *metav1.Time `json:"startTime,omitempty"`
+
+	// CompletionTime is the time at which the power cap was lifted or failed.
+	CompletionTime *metav1.Time `json:"completionTime,omitempty"`
+
+	// CappedTime is the time at which the power limits were capped.
+	CappedTime *metav1.Time `json:"cappedTime,omitempty"`
+
+	// NormalizedTime is the time since which the condition of the thermal fault has
+	// not been true, unset while it is true.
+	NormalizedTime *metav1.Time `json:"normalizedTime,omitempty"`
+
+	// RetryCount tracks the number of reconciliation attempts for this power cap
+	RetryCount int32 `json:"retryCount,omitempty"`
+
+	// ConsecutiveFailures tracks consecutive failed API calls for exponential backoff
+	// Reset to 0 on successful operations
+	ConsecutiveFailures int32 `json:"consecutiveFailures,omitempty"`
+
+	// Phase is the persisted state of the action, used to resume it after a
+	// controller restart
+	Phase ActionPhase `json:"phase,omitempty"`
+
+	// JobName is the name of the Job capping or restoring the power limits on the node
+	JobName string `json:"jobName,omitempty"`
+
+	// GPUs are the power limits of the capped GPUs, recorded when the cap is applied
+	GPUs []GPUPowerLimit `json:"gpus,omitempty"`
+
+	// Conditions represent the latest available observations of an object's current state.
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:resource:scope=Cluster
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="Node",type="string",JSONPath=".spec.nodeName"
+// +kubebuilder:printcolumn:name="Capped",type="string",JSONPath=".status.conditions[?(@.type=='Capped')].status"
+// +kubebuilder:printcolumn:name="Released",type="string",JSONPath=".status.conditions[?(@.type=='Released')].status"
+// +kubebuilder:printcolumn:name="Phase",type="string",JSONPath=".status.phase"
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+
+// PowerCap is the Schema for the powercaps API. It caps the power limit of GPUs in
+// response to a sustained thermal fault, as a gentler alternative to draining the
+// node, and restores the original limits once the thermals normalized.
+type PowerCap struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   PowerCapSpec   `json:"spec,omitempty"`
+	Status PowerCapStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// PowerCapList contains a list of PowerCap.
+type PowerCapList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []PowerCap `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&PowerCap{}, &PowerCapList{})
+}
+
+// SetInitialConditions sets the initial conditions for the PowerCap
+func (p *PowerCap) SetInitialConditions() {
+	if len(p.Status.Conditions) == 0 {
+		p.Status.Conditions = []metav1.Condition{
+			{
+				Type:               PowerCapConditionReleased,
+				Status:             metav1.ConditionUnknown,
+				Reason:             "Pending",
+				Message:            "Power cap is pending",
+				LastTransitionTime: metav1.Now(),
+			},
+		}
+	}
+}
+
+// SetStartTime sets the start time if not already set
+func (p *PowerCap) SetStartTime() {
+	if p.Status.StartTime == nil {
+		now := metav1.Now()
+		p.Status.StartTime = &now
+	}
+}
+
+// SetCompletionTime sets the completion time
+func (p *PowerCap) SetCompletionTime() {
+	if p.Status.CompletionTime == nil {
+		now := metav1.Now()
+		p.Status.CompletionTime = &now
+	}
+}
+
+// SetCondition sets or updates a condition
+func (p *PowerCap) SetCondition(condition metav1.Condition) {
+	for i, existingCondition := range p.Status.Conditions {
+		if existingCondition.Type == condition.Type {
+			p.Status.Conditions[i] = condition
+			return
+		}
+	}
+
+	p.Status.Conditions = append(p.Status.Conditions, condition)
+}
+
+// GetCondition returns the condition of the given type, or nil if it is not set
+func (p *PowerCap) GetCondition(conditionType string) *metav1.Condition {
+	for i := range p.Status.Conditions {
+		if p.Status.Conditions[i].Type == conditionType {
+			return &p.Status.Conditions[i]
+		}
+	}
+
+	return nil
+}
+
+// IsCapped returns true while the power limits of the GPUs are capped
+func (p *PowerCap) IsCapped() bool {
+	condition := p.GetCondition(PowerCapConditionCapped)
+	return condition != nil && condition.Status == metav1.ConditionTrue
+}
+
+// GetCSPReqRef returns a reference string for CSP tracking
+func (p *PowerCap) GetCSPReqRef() string {
+	return string(p.UID)
+}
+
+// Interface implementation for generic status update handling
+
+// GetRetryCount returns the retry count
+func (s *PowerCapStatus) GetRetryCount() int32 {
+	return s.RetryCount
+}
+
+// GetConsecutiveFailures returns the consecutive failures count
+func (s *PowerCapStatus) GetConsecutiveFailures() int32 {
+	return s.ConsecutiveFailures
+}
+
+// GetStartTime returns the start time
+func (s *PowerCapStatus) GetStartTime() *metav1.Time {
+	return s.StartTime
+}
+
+// GetCompletionTime returns the completion time
+func (s *PowerCapStatus) GetCompletionTime() *metav1.Time {
+	return s.CompletionTime
+}
+
+// GetPhase returns the phase
+func (s *PowerCapStatus) GetPhase() ActionPhase {
+	return s.Phase
+}
+
+// GetConditions returns the conditions
+func (s *PowerCapStatus) GetConditions() []metav1.Condition {
+	return s.Conditions
+}
@@ -57,6 +57,22 @@ type RebootNodeStatus struct {
 	// Reset to 0 on successful operations
 	ConsecutiveFailures int32 `json:"consecutiveFailures,omitempty"`
 
+	// Phase is the persisted state of the action, used to resume it after a
+	// controller restart
+	Phase ActionPhase `json:"phase,omitempty"`
+
+	// LockToken is the fencing token of the lock of the node held while the
+	// action runs, so only one destructive action runs on a node at a time
+	LockToken int64 `json:"lockToken,omitempty"`
+
+	// SignalBootID is the boot ID of the node when the reboot signal was
+	// issued. A different boot ID on resume shows the reboot already happened.
+	SignalBootID string `json:"signalBootID,omitempty"`
+
+	// SignalTime is the time the reboot signal was issued. A restart within the
+	// grace period after it waits for the boot ID to change instead of re-sending.
+	SignalTime *metav1.Time `json:"signalTime,omitempty"`
+
 	// Conditions represent the latest available observations of an object's current state.
 	Conditions []metav1.Condition `json:"conditions,omitempty"`
 }
@@ -67,6 +83,7 @@ type RebootNodeStatus struct {
 // +kubebuilder:printcolumn:name="Node",type="string",JSONPath=".spec.nodeName"
 // +kubebuilder:printcolumn:name="Force",type="boolean",JSONPath=".spec.force"
 // +kubebuilder:printcolumn:name="NodeReady",type="string",JSONPath=".status.conditions[?(@.type=='NodeReady')].status"
+// +kubebuilder:printcolumn:name="Phase",type="string",JSONPath=".status.phase"
 // +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
 
 // RebootNode is the Schema for the rebootnodes API
@@ -220,6 +237,11 @@ func (s *RebootNodeStatus) GetCompletionTime() *metav1.Time {
 	return s.CompletionTime
 }
 
+// GetPhase returns the phase
+func (s *RebootNodeStatus) GetPhase() ActionPhase {
+	return s.Phase
+}
+
 // GetConditions returns the conditions
 func (s *RebootNodeStatus) GetConditions() []metav1.Condition {
 	return s.Conditions
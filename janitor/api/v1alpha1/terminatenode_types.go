@@ -55,6 +55,14 @@ type TerminateNodeStatus struct {
 	// Reset to 0 on successful operations
 	ConsecutiveFailures int32 `json:"consecutiveFailures,omitempty"`
 
+	// Phase is the persisted state of the action, used to resume it after a
+	// controller restart
+	Phase ActionPhase `json:"phase,omitempty"`
+
+	// LockToken is the fencing token of the lock of the node held while the
+	// action runs, so only one destructive action runs on a node at a time
+	LockToken int64 `json:"lockToken,omitempty"`
+
 	// Conditions represent the latest available observations of an object's current state.
 	Conditions []metav1.Condition `json:"conditions,omitempty"`
 }
@@ -66,6 +74,7 @@ type TerminateNodeStatus struct {
 // +kubebuilder:printcolumn:name="Force",type="boolean",JSONPath=".spec.force"
 //nolint:lll // kubebuilder printcolumn marker
 // +kubebuilder:printcolumn:name="NodeTerminated",type="string",JSONPath=".status.conditions[?(@.type=='NodeTerminated')].status"
+// +kubebuilder:printcolumn:name="Phase",type="string",JSONPath=".status.phase"
 // +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
 
 // TerminateNode is the Schema for the terminatenodes API
@@ -209,6 +218,11 @@ func (s *TerminateNodeStatus) GetCompletionTime() *metav1.Time {
 	return s.CompletionTime
 }
 
+// GetPhase returns the phase
+func (s *TerminateNodeStatus) GetPhase() ActionPhase {
+	return s.Phase
+}
+
 // GetConditions returns the conditions
 func (s *TerminateNodeStatus) GetConditions() []metav1.Condition {
 	return s.Conditions
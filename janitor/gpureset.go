@@ -0,0 +1,162 @@
+// Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"log/slog"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"k8s.io/client-go/kubernetes"
+	podresourcesapi "k8s.io/kubelet/pkg/apis/podresources/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	"github.com/nvidia/nvsentinel/janitor/pkg/gpureset"
+)
+
+// podResourcesMaxMessageSize bounds the pod resources list of the kubelet, as in the
+// kubelet's own client
+const podResourcesMaxMessageSize = 16 * 1024 * 1024
+
+// runGPUReset runs `janitor gpu-reset` in the Job of the GPUReset controller: the pods
+// using the GPUs are evicted and the GPUs reset once their processes exited. The
+// result, or the error, is written to the termination log for the controller.
+func runGPUReset(args []string) error {
+	var (
+		uuids              string
+		pciBusIDs          string
+		timeout            time.Duration
+		pollInterval       time.Duration
+		hostRoot           string
+		nvidiaSMI          string
+		podResourcesSocket string
+		terminationLog     string
+	)
+
+	flags := flag.NewFlagSet("janitor gpu-reset", flag.ContinueOnError)
+	flags.StringVar(&uuids, "uuids", "", "Comma separated UUIDs of the GPUs to reset.")
+	flags.StringVar(&pciBusIDs, "pci-bus-ids", "", "Comma separated PCI bus IDs of the GPUs to reset. "+
+		"All GPUs of the node are reset when neither UUIDs nor PCI bus IDs are given.")
+	flags.DurationVar(&timeout, "timeout", 15*time.Minute,
+		"Timeout of waiting for the pods and processes using the GPUs to exit.")
+	flags.DurationVar(&pollInterval, "poll-interval", 5*time.Second,
+		"Interval between checks of the pods and processes using the GPUs.")
+	flags.StringVar(&hostRoot, "host-root", "/host", "Path the root filesystem of the node is mounted at.")
+	flags.StringVar(&nvidiaSMI, "nvidia-smi", "/usr/bin/nvidia-smi", "Path of nvidia-smi on the node.")
+	flags.StringVar(&podResourcesSocket, "pod-resources-socket", "/var/lib/kubelet/pod-resources/kubelet.sock",
+		"Path of the pod resources socket of the kubelet.")
+	flags.StringVar(&terminationLog, "termination-log", "/dev/termination-log",
+		"Path the result is written to, empty disables it.")
+
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	result, err := resetGPUs(ctx, gpureset.Config{
+		UUIDs:        splitList(uuids),
+		PCIBusIDs:    splitList(pciBusIDs),
+		Timeout:      timeout,
+		PollInterval: pollInterval,
+	}, gpureset.NewNvidiaSMI(hostRoot, nvidiaSMI), podResourcesSocket)
+
+	message := ""
+	if err != nil {
+		message = err.Error()
+	} else {
+		message = result.String()
+		slog.Info("GPU reset completed", "gpus", result.GPUs, "evictedPods", result.EvictedPods)
+	}
+
+	if terminationLog != "" {
+		if errWrite := os.WriteFile(terminationLog, []byte(message), 0o600); errWrite != nil {
+			slog.Warn("Failed to write termination log", "path", terminationLog, "error", errWrite)
+		}
+	}
+
+	return err
+}
+
+func resetGPUs(
+	ctx context.Context,
+	cfg gpureset.Config,
+	smi gpureset.SMI,
+	podResourcesSocket string,
+) (*gpureset.Result, error) {
+	restConfig, err := ctrl.GetConfig()
+	if err != nil {
+		return nil, fmt.Errorf("failed to obtain Kubernetes config: %w", err)
+	}
+
+	k8sClient, err := kubernetes.NewForConfig(restConfig)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create Kubernetes clientset: %w", err)
+	}
+
+	conn, err := grpc.NewClient("unix:"+podResourcesSocket,
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithDefaultCallOptions(grpc.MaxCallRecvMsgSize(podResourcesMaxMessageSize)))
+	if err != nil {
+		return nil, fmt.Errorf("failed to dial pod resources socket %s: %w", podResourcesSocket, err)
+	}
+
+	defer func() {
+		if errClose := conn.Close(); errClose != nil {
+			slog.Error("Error closing pod resources connection", "error", errClose)
+		}
+	}()
+
+	resetter := gpureset.NewResetter(cfg, smi, podresourcesapi.NewPodResourcesListerClient(conn), k8sClient)
+
+	return resetter.Run(ctx)
+}
+
+func splitList(value string) []string {
+	var items []string
+
+	for item := range strings.SplitSeq(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+
+	return items
+}
+
+// exitGPUReset runs the gpu-reset command and exits with its status
+func exitGPUReset(args []string) {
+	err := runGPUReset(args)
+	if errors.Is(err, flag.ErrHelp) {
+		os.Exit(0)
+	}
+
+	if err != nil {
+		slog.Error("GPU reset failed", "error", err)
+		os.Exit(1)
+	}
+
+	os.Exit(0)
+}
@@ -15,6 +15,7 @@
 package main
 
 import (
+	"context"
 	"crypto/tls"
 	"encoding/json"
 	"flag"
@@ -31,6 +32,7 @@ import (
 	"golang.org/x/sync/errgroup"
 	"k8s.io/apimachinery/pkg/runtime"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/client-go/kubernetes"
 	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/certwatcher"
@@ -40,9 +42,12 @@ import (
 	metricsserver "sigs.k8s.io/controller-runtime/pkg/metrics/server"
 	"sigs.k8s.io/controller-runtime/pkg/webhook"
 
+	"github.com/nvidia/nvsentinel/commons/pkg/auth"
 	"github.com/nvidia/nvsentinel/commons/pkg/logger"
+	"github.com/nvidia/nvsentinel/commons/pkg/nodelock"
 	"github.com/nvidia/nvsentinel/commons/pkg/server"
 	janitordgxcnvidiacomv1alpha1 "github.com/nvidia/nvsentinel/janitor/api/v1alpha1"
+	"github.com/nvidia/nvsentinel/janitor/pkg/api"
 	"github.com/nvidia/nvsentinel/janitor/pkg/config"
 	"github.com/nvidia/nvsentinel/janitor/pkg/controller"
 	webhookv1alpha1 "github.com/nvidia/nvsentinel/janitor/pkg/webhook/v1alpha1"
@@ -63,6 +68,17 @@ func init() {
 
 func main() {
 	logger.SetDefaultStructuredLogger("janitor", version)
+
+	// The reset Jobs of the GPUReset controller run the janitor image on the node
+	if len(os.Args) > 1 && os.Args[1] == "gpu-reset" {
+		exitGPUReset(os.Args[2:])
+	}
+
+	// The apply and restore Jobs of the PowerCap controller too
+	if len(os.Args) > 1 && os.Args[1] == "power-cap" {
+		exitPowerCap(os.Args[2:])
+	}
+
 	slog.Info("Starting janitor", "version", version, "commit", commit, "date", date)
 
 	// Bridge slog to logr for controller-runtime
@@ -89,6 +105,7 @@ func run() error {
 		secureMetrics                                    bool
 		enableHTTP2                                      bool
 		configFile                                       string
+		apiTokensFile                                    string
 		// Leader election tuning parameters
 		leaseDuration time.Duration
 		renewDeadline time.Duration
@@ -114,6 +131,9 @@ func run() error {
 	flag.BoolVar(&enableHTTP2, "enable-http2", false,
 		"If set, HTTP/2 will be enabled for the metrics and webhook servers")
 	flag.StringVar(&configFile, "config", "", "The path to the configuration file.")
+	flag.StringVar(&apiTokensFile, "api-tokens-file", "",
+		"The path to the API token file holding static tokens and OIDC settings. "+
+			"If empty, only read-only API endpoints are served, without authentication.")
 
 	// Leader election flags
 	// Defaulting to pretty high values, we were hitting some crashes
@@ -134,6 +154,7 @@ func run() error {
 		"config-bind-address", configAddr,
 		"leader-elect", enableLeaderElection,
 		"config", configFile,
+		"api-tokens-file", apiTokensFile,
 		"secure-metrics", secureMetrics)
 
 	// Load configuration from file
@@ -148,7 +169,16 @@ func run() error {
 		"rebootNode.timeout", cfg.RebootNode.Timeout,
 		"terminateNode.enabled", cfg.TerminateNode.Enabled,
 		"terminateNode.timeout", cfg.TerminateNode.Timeout,
-		"global.manualMode", cfg.Global.ManualMode)
+		"driverReload.enabled", cfg.DriverReload.Enabled,
+		"driverReload.timeout", cfg.DriverReload.Timeout,
+		"migReconfiguration.enabled", cfg.MIGReconfiguration.Enabled,
+		"migReconfiguration.timeout", cfg.MIGReconfiguration.Timeout,
+		"gpuReset.enabled", cfg.GPUReset.Enabled,
+		"gpuReset.timeout", cfg.GPUReset.Timeout,
+		"powerCap.enabled", cfg.PowerCap.Enabled,
+		"powerCap.timeout", cfg.PowerCap.Timeout,
+		"global.manualMode", cfg.Global.ManualMode,
+		"global.nodeLock.enabled", cfg.Global.NodeLock.Enabled)
 
 	// Parse config port from address
 	// Handles formats like ":8082", "localhost:8082", "0.0.0.0:8082"
@@ -179,13 +209,6 @@ func run() error {
 		}
 	})
 
-	// Create config server using common server implementation
-	// Note: Health checks are handled by controller-runtime manager on probeAddr
-	configServer := server.NewServer(
-		server.WithPort(configPort),
-		server.WithHandler("/config", configHandler),
-	)
-
 	// Setup TLS options
 	var tlsOpts []func(*tls.Config)
 
@@ -264,8 +287,10 @@ func run() error {
 		})
 	}
 
+	restConfig := ctrl.GetConfigOrDie()
+
 	// Setup controller manager
-	mgr, err := ctrl.NewManager(ctrl.GetConfigOrDie(), ctrl.Options{
+	mgr, err := ctrl.NewManager(restConfig, ctrl.Options{
 		Scheme:                 scheme,
 		Metrics:                metricsServerOptions,
 		WebhookServer:          webhookServer,
@@ -283,11 +308,26 @@ func run() error {
 
 	slog.Info("Manager created successfully")
 
+	var nodeLock *nodelock.Locker
+
+	if cfg.Global.NodeLock.Enabled {
+		clientset, err := kubernetes.NewForConfig(restConfig)
+		if err != nil {
+			slog.Error("Unable to create kubernetes clientset for node locks", "error", err)
+			return err
+		}
+
+		nodeLock = nodelock.NewLocker(clientset, nodelock.Namespace(), cfg.Global.NodeLock.Duration)
+
+		slog.Info("Node locks enabled", "namespace", nodelock.Namespace())
+	}
+
 	// Setup RebootNode controller
 	if err = (&controller.RebootNodeReconciler{
-		Client: mgr.GetClient(),
-		Scheme: mgr.GetScheme(),
-		Config: &cfg.RebootNode,
+		Client:   mgr.GetClient(),
+		Scheme:   mgr.GetScheme(),
+		Config:   &cfg.RebootNode,
+		NodeLock: nodeLock,
 	}).SetupWithManager(mgr); err != nil {
 		slog.Error("Unable to create controller", "controller", "RebootNode", "error", err)
 		return err
@@ -295,15 +335,63 @@ func run() error {
 
 	// Setup TerminateNode controller
 	if err = (&controller.TerminateNodeReconciler{
-		Client: mgr.GetClient(),
-		Scheme: mgr.GetScheme(),
-		Config: &cfg.TerminateNode,
+		Client:   mgr.GetClient(),
+		Scheme:   mgr.GetScheme(),
+		Config:   &cfg.TerminateNode,
+		NodeLock: nodeLock,
 	}).SetupWithManager(mgr); err != nil {
 		slog.Error("Unable to create controller", "controller", "TerminateNode", "error", err)
 		return err
 	}
 
-	slog.Info("RebootNode and TerminateNode controllers registered")
+	// Setup DriverReload controller
+	if err = (&controller.DriverReloadReconciler{
+		Client:    mgr.GetClient(),
+		Scheme:    mgr.GetScheme(),
+		Config:    &cfg.DriverReload,
+		APIReader: mgr.GetAPIReader(),
+		NodeLock:  nodeLock,
+	}).SetupWithManager(mgr); err != nil {
+		slog.Error("Unable to create controller", "controller", "DriverReload", "error", err)
+		return err
+	}
+
+	// Setup MIGReconfiguration controller
+	if err = (&controller.MIGReconfigurationReconciler{
+		Client:    mgr.GetClient(),
+		Scheme:    mgr.GetScheme(),
+		Config:    &cfg.MIGReconfiguration,
+		APIReader: mgr.GetAPIReader(),
+		NodeLock:  nodeLock,
+	}).SetupWithManager(mgr); err != nil {
+		slog.Error("Unable to create controller", "controller", "MIGReconfiguration", "error", err)
+		return err
+	}
+
+	// Setup GPUReset controller
+	if err = (&controller.GPUResetReconciler{
+		Client:    mgr.GetClient(),
+		Scheme:    mgr.GetScheme(),
+		Config:    &cfg.GPUReset,
+		APIReader: mgr.GetAPIReader(),
+		NodeLock:  nodeLock,
+	}).SetupWithManager(mgr); err != nil {
+		slog.Error("Unable to create controller", "controller", "GPUReset", "error", err)
+		return err
+	}
+
+	// Setup PowerCap controller
+	if err = (&controller.PowerCapReconciler{
+		Client:    mgr.GetClient(),
+		Scheme:    mgr.GetScheme(),
+		Config:    &cfg.PowerCap,
+		APIReader: mgr.GetAPIReader(),
+	}).SetupWithManager(mgr); err != nil {
+		slog.Error("Unable to create controller", "controller", "PowerCap", "error", err)
+		return err
+	}
+
+	slog.Info("RebootNode, TerminateNode, DriverReload, MIGReconfiguration, GPUReset and PowerCap controllers registered")
 
 	// Setup unified webhook for all Janitor CRDs
 	if err = webhookv1alpha1.SetupJanitorWebhookWithManager(mgr, cfg); err != nil {
@@ -313,6 +401,30 @@ func run() error {
 
 	slog.Info("Janitor validation webhook registered for all CRDs")
 
+	// Setup API authorization. Without a token file only the read-only endpoints are
+	// served, anonymously.
+	var authenticators []auth.Authenticator
+
+	if apiTokensFile != "" {
+		authenticators, err = auth.LoadAuthenticators(context.Background(), apiTokensFile)
+		if err != nil {
+			slog.Error("Unable to load API authorization", "error", err, "path", apiTokensFile)
+			return err
+		}
+	}
+
+	authMiddleware := auth.NewMiddleware(authenticators...)
+	slog.Info("API authorization configured", "enabled", authMiddleware.Enabled())
+
+	// Create config server using common server implementation
+	// Note: Health checks are handled by controller-runtime manager on probeAddr
+	configServer := server.NewServer(
+		server.WithPort(configPort),
+		server.WithHandler("/config", authMiddleware.Require(auth.RoleViewer, configHandler)),
+		server.WithHandler("/api/", api.NewHandler(mgr.GetClient(), authMiddleware)),
+		server.WithHandler(logger.LevelsPath, authMiddleware.Require(auth.RoleAdmin, logger.LevelsHandler())),
+	)
+
 	// Add certificate watchers to manager if configured
 	if metricsCertWatcher != nil {
 		slog.Info("Adding metrics certificate watcher to manager")
@@ -0,0 +1,309 @@
+// Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package api serves the janitor query and admin HTTP API. Listing node actions and
+// diffing nodes against their peers requires the viewer role, cancelling or force
+// failing actions and bulk node operations the operator role.
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"time"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/nvidia/nvsentinel/commons/pkg/auth"
+	janitordgxcnvidiacomv1alpha1 "github.com/nvidia/nvsentinel/janitor/api/v1alpha1"
+)
+
+const (
+	kindRebootNodes         = "rebootnodes"
+	kindTerminateNodes      = "terminatenodes"
+	kindDriverReloads       = "driverreloads"
+	kindMIGReconfigurations = "migreconfigurations"
+	kindGPUResets           = "gpuresets"
+	kindPowerCaps           = "powercaps"
+
+	// maxRequestBodyBytes bounds the optional JSON body of override requests
+	maxRequestBodyBytes = 4 << 10
+)
+
+// Action is the summary of a node action returned by the API.
+type Action struct {
+	Kind           string                                   `json:"kind"`
+	Name           string                                   `json:"name"`
+	NodeName       string                                   `json:"nodeName"`
+	Phase          janitordgxcnvidiacomv1alpha1.ActionPhase `json:"phase"`
+	StartTime      *time.Time                               `json:"startTime,omitempty"`
+	CompletionTime *time.Time                               `json:"completionTime,omitempty"`
+}
+
+// overrideRequest is the optional body of cancel and force-fail requests.
+type overrideRequest struct {
+	Reason string `json:"reason"`
+}
+
+// Handler serves the janitor API.
+type Handler struct {
+	client client.Client
+	mux    *http.ServeMux
+}
+
+// NewHandler creates the API handler. Routes are protected by mw.
+func NewHandler(c client.Client, mw *auth.Middleware) *Handler {
+	h := &Handler{client: c, mux: http.NewServeMux()}
+
+	h.mux.Handle("GET /api/v1/actions", mw.Require(auth.RoleViewer, http.HandlerFunc(h.listActions)))
+	h.mux.Handle("POST /api/v1/actions/{kind}/{name}/cancel",
+		mw.Require(auth.RoleOperator, h.override(janitordgxcnvidiacomv1alpha1.CancelAnnotation)))
+	h.mux.Handle("POST /api/v1/actions/{kind}/{name}/force-fail",
+		mw.Require(auth.RoleOperator, h.override(janitordgxcnvidiacomv1alpha1.ForceFailAnnotation)))
+	h.mux.Handle("POST /api/v1/actions/{kind}/{name}/approve",
+		mw.Require(auth.RoleOperator, h.override(janitordgxcnvidiacomv1alpha1.ApproveAnnotation)))
+	h.mux.Handle("POST /api/v1/nodes/bulk", mw.Require(auth.RoleOperator, http.HandlerFunc(h.bulk)))
+	h.mux.Handle("GET /api/v1/nodes/{name}/diff", mw.Require(auth.RoleViewer, http.HandlerFunc(h.diffNode)))
+
+	return h
+}
+
+// ServeHTTP implements http.Handler.
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	h.mux.ServeHTTP(w, r)
+}
+
+func (h *Handler) listActions(w http.ResponseWriter, r *http.Request) {
+	actions := []Action{}
+
+	var rebootNodes janitordgxcnvidiacomv1alpha1.RebootNodeList
+	if err := h.client.List(r.Context(), &rebootNodes); err != nil {
+		slog.Error("Failed to list rebootnodes", "error", err)
+		http.Error(w, "failed to list rebootnodes", http.StatusInternalServerError)
+
+		return
+	}
+
+	for _, rn := range rebootNodes.Items {
+		actions = append(actions, Action{
+			Kind:           kindRebootNodes,
+			Name:           rn.Name,
+			NodeName:       rn.Spec.NodeName,
+			Phase:          rn.Status.Phase,
+			StartTime:      timeOrNil(rn.Status.StartTime),
+			CompletionTime: timeOrNil(rn.Status.CompletionTime),
+		})
+	}
+
+	var terminateNodes janitordgxcnvidiacomv1alpha1.TerminateNodeList
+	if err := h.client.List(r.Context(), &terminateNodes); err != nil {
+		slog.Error("Failed to list terminatenodes", "error", err)
+		http.Error(w, "failed to list terminatenodes", http.StatusInternalServerError)
+
+		return
+	}
+
+	for _, tn := range terminateNodes.Items {
+		actions = append(actions, Action{
+			Kind:           kindTerminateNodes,
+			Name:           tn.Name,
+			NodeName:       tn.Spec.NodeName,
+			Phase:          tn.Status.Phase,
+			StartTime:      timeOrNil(tn.Status.StartTime),
+			CompletionTime: timeOrNil(tn.Status.CompletionTime),
+		})
+	}
+
+	var driverReloads janitordgxcnvidiacomv1alpha1.DriverReloadList
+	if err := h.client.List(r.Context(), &driverReloads); err != nil {
+		slog.Error("Failed to list driverreloads", "error", err)
+		http.Error(w, "failed to list driverreloads", http.StatusInternalServerError)
+
+		return
+	}
+
+	for _, dr := range driverReloads.Items {
+		actions = append(actions, Action{
+			Kind:           kindDriverReloads,
+			Name:           dr.Name,
+			NodeName:       dr.Spec.NodeName,
+			Phase:          dr.Status.Phase,
+			StartTime:      timeOrNil(dr.Status.StartTime),
+			CompletionTime: timeOrNil(dr.Status.CompletionTime),
+		})
+	}
+
+	var migReconfigurations janitordgxcnvidiacomv1alpha1.MIGReconfigurationList
+	if err := h.client.List(r.Context(), &migReconfigurations); err != nil {
+		slog.Error("Failed to list migreconfigurations", "error", err)
+		http.Error(w, "failed to list migreconfigurations", http.StatusInternalServerError)
+
+		return
+	}
+
+	for _, mr := range migReconfigurations.Items {
+		actions = append(actions, Action{
+			Kind:           kindMIGReconfigurations,
+			Name:           mr.Name,
+			NodeName:       mr.Spec.NodeName,
+			Phase:          mr.Status.Phase,
+			StartTime:      timeOrNil(mr.Status.StartTime),
+			CompletionTime: timeOrNil(mr.Status.CompletionTime),
+		})
+	}
+
+	var gpuResets janitordgxcnvidiacomv1alpha1.GPUResetList
+	if err := h.client.List(r.Context(), &gpuResets); err != nil {
+		slog.Error("Failed to list gpuresets", "error", err)
+		http.Error(w, "failed to list gpuresets", http.StatusInternalServerError)
+
+		return
+	}
+
+	for _, gr := range gpuResets.Items {
+		actions = append(actions, Action{
+			Kind:           kindGPUResets,
+			Name:           gr.Name,
+			NodeName:       gr.Spec.NodeName,
+			Phase:          gr.Status.Phase,
+			StartTime:      timeOrNil(gr.Status.StartTime),
+			CompletionTime: timeOrNil(gr.Status.CompletionTime),
+		})
+	}
+
+	var powerCaps janitordgxcnvidiacomv1alpha1.PowerCapList
+	if err := h.client.List(r.Context(), &powerCaps); err != nil {
+		slog.Error("Failed to list powercaps", "error", err)
+		http.Error(w, "failed to list powercaps", http.StatusInternalServerError)
+
+		return
+	}
+
+	for _, pc := range powerCaps.Items {
+		actions = append(actions, Action{
+			Kind:           kindPowerCaps,
+			Name:           pc.Name,
+			NodeName:       pc.Spec.NodeName,
+			Phase:          pc.Status.Phase,
+			StartTime:      timeOrNil(pc.Status.StartTime),
+			CompletionTime: timeOrNil(pc.Status.CompletionTime),
+		})
+	}
+
+	writeJSON(w, http.StatusOK, actions)
+}
+
+// override returns a handler setting the given override annotation on a node action.
+// The controllers act on the annotation, so the API and kubectl annotate behave the same.
+func (h *Handler) override(annotation string) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body overrideRequest
+
+		if err := json.NewDecoder(io.LimitReader(r.Body, maxRequestBodyBytes)).Decode(&body); err != nil &&
+			!errors.Is(err, io.EOF) {
+			http.Error(w, "invalid request body", http.StatusBadRequest)
+			return
+		}
+
+		obj, err := newActionObject(r.PathValue("kind"))
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusNotFound)
+			return
+		}
+
+		name := r.PathValue("name")
+		if err := h.client.Get(r.Context(), client.ObjectKey{Name: name}, obj); err != nil {
+			if apierrors.IsNotFound(err) {
+				http.Error(w, "action not found", http.StatusNotFound)
+				return
+			}
+
+			slog.Error("Failed to get node action", "name", name, "error", err)
+			http.Error(w, "failed to get action", http.StatusInternalServerError)
+
+			return
+		}
+
+		reason := body.Reason
+		if p, ok := auth.PrincipalFromContext(r.Context()); ok {
+			reason = fmt.Sprintf("requested by %s: %s", p.Name, body.Reason)
+		}
+
+		patch := client.MergeFrom(obj.DeepCopyObject().(client.Object))
+
+		annotations := obj.GetAnnotations()
+		if annotations == nil {
+			annotations = map[string]string{}
+		}
+
+		annotations[annotation] = reason
+		obj.SetAnnotations(annotations)
+
+		if err := h.client.Patch(r.Context(), obj, patch); err != nil {
+			slog.Error("Failed to annotate node action", "name", name, "annotation", annotation, "error", err)
+			http.Error(w, "failed to update action", http.StatusInternalServerError)
+
+			return
+		}
+
+		slog.Info("Node action override requested",
+			"kind", r.PathValue("kind"),
+			"name", name,
+			"annotation", annotation,
+			"reason", reason)
+
+		w.WriteHeader(http.StatusAccepted)
+	})
+}
+
+func newActionObject(kind string) (client.Object, error) {
+	switch kind {
+	case kindRebootNodes:
+		return &janitordgxcnvidiacomv1alpha1.RebootNode{}, nil
+	case kindTerminateNodes:
+		return &janitordgxcnvidiacomv1alpha1.TerminateNode{}, nil
+	case kindDriverReloads:
+		return &janitordgxcnvidiacomv1alpha1.DriverReload{}, nil
+	case kindMIGReconfigurations:
+		return &janitordgxcnvidiacomv1alpha1.MIGReconfiguration{}, nil
+	case kindGPUResets:
+		return &janitordgxcnvidiacomv1alpha1.GPUReset{}, nil
+	case kindPowerCaps:
+		return &janitordgxcnvidiacomv1alpha1.PowerCap{}, nil
+	default:
+		return nil, fmt.Errorf("unknown action kind %q", kind)
+	}
+}
+
+func timeOrNil(t *metav1.Time) *time.Time {
+	if t == nil {
+		return nil
+	}
+
+	return &t.Time
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		slog.Error("Failed to encode API response", "error", err)
+	}
+}
@@ -0,0 +1,279 @@
+// Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/nvidia/nvsentinel/commons/pkg/auth"
+	janitordgxcnvidiacomv1alpha1 "github.com/nvidia/nvsentinel/janitor/api/v1alpha1"
+)
+
+const (
+	viewerToken   = "viewer-token"
+	operatorToken = "operator-token"
+)
+
+func newTestHandler(t *testing.T, withAuth bool) (*Handler, client.Client) {
+	t.Helper()
+
+	scheme := runtime.NewScheme()
+	require.NoError(t, janitordgxcnvidiacomv1alpha1.AddToScheme(scheme))
+
+	c := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(
+			&janitordgxcnvidiacomv1alpha1.RebootNode{
+				ObjectMeta: metav1.ObjectMeta{Name: "reboot-node-1"},
+				Spec:       janitordgxcnvidiacomv1alpha1.RebootNodeSpec{NodeName: "node-1"},
+				Status: janitordgxcnvidiacomv1alpha1.RebootNodeStatus{
+					Phase: janitordgxcnvidiacomv1alpha1.ActionPhaseVerifying,
+				},
+			},
+			&janitordgxcnvidiacomv1alpha1.TerminateNode{
+				ObjectMeta: metav1.ObjectMeta{Name: "terminate-node-2"},
+				Spec:       janitordgxcnvidiacomv1alpha1.TerminateNodeSpec{NodeName: "node-2"},
+			},
+			&janitordgxcnvidiacomv1alpha1.DriverReload{
+				ObjectMeta: metav1.ObjectMeta{Name: "driver-reload-3"},
+				Spec:       janitordgxcnvidiacomv1alpha1.DriverReloadSpec{NodeName: "node-3"},
+			},
+			&janitordgxcnvidiacomv1alpha1.MIGReconfiguration{
+				ObjectMeta: metav1.ObjectMeta{Name: "mig-reconfiguration-4"},
+				Spec:       janitordgxcnvidiacomv1alpha1.MIGReconfigurationSpec{NodeName: "node-4"},
+			},
+			&janitordgxcnvidiacomv1alpha1.GPUReset{
+				ObjectMeta: metav1.ObjectMeta{Name: "gpu-reset-5"},
+				Spec:       janitordgxcnvidiacomv1alpha1.GPUResetSpec{NodeName: "node-5"},
+			},
+			&janitordgxcnvidiacomv1alpha1.PowerCap{
+				ObjectMeta: metav1.ObjectMeta{Name: "power-cap-6"},
+				Spec:       janitordgxcnvidiacomv1alpha1.PowerCapSpec{NodeName: "node-6"},
+			},
+		).
+		Build()
+
+	var authenticators []auth.Authenticator
+
+	if withAuth {
+		tokens, err := auth.NewTokenAuthenticator([]auth.TokenConfig{
+			{Name: "grafana", Role: "viewer", Token: viewerToken},
+			{Name: "oncall", Role: "operator", Token: operatorToken},
+		})
+		require.NoError(t, err)
+
+		authenticators = append(authenticators, tokens)
+	}
+
+	return NewHandler(c, auth.NewMiddleware(authenticators...)), c
+}
+
+func doRequest(h http.Handler, method, path, token, body string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, path, strings.NewReader(body))
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+
+	return rec
+}
+
+func TestListActions(t *testing.T) {
+	h, _ := newTestHandler(t, true)
+
+	rec := doRequest(h, http.MethodGet, "/api/v1/actions", viewerToken, "")
+	require.Equal(t, http.StatusOK, rec.Code)
+
+	var actions []Action
+	require.NoError(t, json.NewDecoder(rec.Body).Decode(&actions))
+	require.Len(t, actions, 6)
+
+	assert.Equal(t, kindRebootNodes, actions[0].Kind)
+	assert.Equal(t, "node-1", actions[0].NodeName)
+	assert.Equal(t, janitordgxcnvidiacomv1alpha1.ActionPhaseVerifying, actions[0].Phase)
+	assert.Equal(t, kindTerminateNodes, actions[1].Kind)
+	assert.Equal(t, "node-2", actions[1].NodeName)
+	assert.Equal(t, kindDriverReloads, actions[2].Kind)
+	assert.Equal(t, "node-3", actions[2].NodeName)
+	assert.Equal(t, kindMIGReconfigurations, actions[3].Kind)
+	assert.Equal(t, "node-4", actions[3].NodeName)
+	assert.Equal(t, kindGPUResets, actions[4].Kind)
+	assert.Equal(t, "node-5", actions[4].NodeName)
+	assert.Equal(t, kindPowerCaps, actions[5].Kind)
+	assert.Equal(t, "node-6", actions[5].NodeName)
+}
+
+func TestRoleEnforcement(t *testing.T) {
+	tests := []struct {
+		name     string
+		withAuth bool
+		method   string
+		path     string
+		token    string
+		expected int
+	}{
+		{
+			name:     "list without token",
+			withAuth: true,
+			method:   http.MethodGet,
+			path:     "/api/v1/actions",
+			expected: http.StatusUnauthorized,
+		},
+		{
+			name:     "list with unknown token",
+			withAuth: true,
+			method:   http.MethodGet,
+			path:     "/api/v1/actions",
+			token:    "unknown",
+			expected: http.StatusUnauthorized,
+		},
+		{
+			name:     "cancel as viewer",
+			withAuth: true,
+			method:   http.MethodPost,
+			path:     "/api/v1/actions/rebootnodes/reboot-node-1/cancel",
+			token:    viewerToken,
+			expected: http.StatusForbidden,
+		},
+		{
+			name:     "cancel as operator",
+			withAuth: true,
+			method:   http.MethodPost,
+			path:     "/api/v1/actions/rebootnodes/reboot-node-1/cancel",
+			token:    operatorToken,
+			expected: http.StatusAccepted,
+		},
+		{
+			name:     "list with auth disabled",
+			method:   http.MethodGet,
+			path:     "/api/v1/actions",
+			expected: http.StatusOK,
+		},
+		{
+			name:     "cancel with auth disabled",
+			method:   http.MethodPost,
+			path:     "/api/v1/actions/rebootnodes/reboot-node-1/cancel",
+			expected: http.StatusForbidden,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h, _ := newTestHandler(t, tt.withAuth)
+
+			rec := doRequest(h, tt.method, tt.path, tt.token, "")
+			assert.Equal(t, tt.expected, rec.Code)
+		})
+	}
+}
+
+func TestOverride(t *testing.T) {
+	tests := []struct {
+		name       string
+		path       string
+		body       string
+		expected   int
+		kind       client.Object
+		objectName string
+		annotation string
+		reason     string
+	}{
+		{
+			name:       "cancel rebootnode",
+			path:       "/api/v1/actions/rebootnodes/reboot-node-1/cancel",
+			body:       `{"reason": "node fixed by hand"}`,
+			expected:   http.StatusAccepted,
+			kind:       &janitordgxcnvidiacomv1alpha1.RebootNode{},
+			objectName: "reboot-node-1",
+			annotation: janitordgxcnvidiacomv1alpha1.CancelAnnotation,
+			reason:     "requested by oncall: node fixed by hand",
+		},
+		{
+			name:       "force fail terminatenode without body",
+			path:       "/api/v1/actions/terminatenodes/terminate-node-2/force-fail",
+			expected:   http.StatusAccepted,
+			kind:       &janitordgxcnvidiacomv1alpha1.TerminateNode{},
+			objectName: "terminate-node-2",
+			annotation: janitordgxcnvidiacomv1alpha1.ForceFailAnnotation,
+			reason:     "requested by oncall: ",
+		},
+		{
+			name:       "cancel driverreload",
+			path:       "/api/v1/actions/driverreloads/driver-reload-3/cancel",
+			body:       `{"reason": "driver reloaded by hand"}`,
+			expected:   http.StatusAccepted,
+			kind:       &janitordgxcnvidiacomv1alpha1.DriverReload{},
+			objectName: "driver-reload-3",
+			annotation: janitordgxcnvidiacomv1alpha1.CancelAnnotation,
+			reason:     "requested by oncall: driver reloaded by hand",
+		},
+		{
+			name:       "approve rebootnode",
+			path:       "/api/v1/actions/rebootnodes/reboot-node-1/approve",
+			body:       `{"reason": "drained by hand"}`,
+			expected:   http.StatusAccepted,
+			kind:       &janitordgxcnvidiacomv1alpha1.RebootNode{},
+			objectName: "reboot-node-1",
+			annotation: janitordgxcnvidiacomv1alpha1.ApproveAnnotation,
+			reason:     "requested by oncall: drained by hand",
+		},
+		{
+			name:     "unknown kind",
+			path:     "/api/v1/actions/nodedrains/drain-1/cancel",
+			expected: http.StatusNotFound,
+		},
+		{
+			name:     "unknown action",
+			path:     "/api/v1/actions/rebootnodes/missing/cancel",
+			expected: http.StatusNotFound,
+		},
+		{
+			name:     "invalid body",
+			path:     "/api/v1/actions/rebootnodes/reboot-node-1/cancel",
+			body:     `{"reason":`,
+			expected: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h, c := newTestHandler(t, true)
+
+			rec := doRequest(h, http.MethodPost, tt.path, operatorToken, tt.body)
+			require.Equal(t, tt.expected, rec.Code)
+
+			if tt.kind == nil {
+				return
+			}
+
+			require.NoError(t, c.Get(context.Background(), client.ObjectKey{Name: tt.objectName}, tt.kind))
+			assert.Equal(t, tt.reason, tt.kind.GetAnnotations()[tt.annotation])
+		})
+	}
+}
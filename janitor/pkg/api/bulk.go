@@ -0,0 +1,455 @@
+// Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"sync"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/nvidia/nvsentinel/commons/pkg/auth"
+	"github.com/nvidia/nvsentinel/commons/pkg/enforcement"
+	janitordgxcnvidiacomv1alpha1 "github.com/nvidia/nvsentinel/janitor/api/v1alpha1"
+)
+
+const (
+	// VerbQuarantine cordons the nodes. Fault quarantine leaves nodes it did not
+	// cordon alone, release them to undo it.
+	VerbQuarantine = "quarantine"
+	// VerbRelease uncordons the nodes. Fault quarantine handles it as a manual
+	// uncordon: it removes its taints and annotations and cancels the quarantine.
+	VerbRelease = "release"
+	// VerbSilence switches enforcement off for the nodes, they are observed only
+	VerbSilence = "silence"
+	// VerbUnsilence switches enforcement back on for the nodes
+	VerbUnsilence = "unsilence"
+	// VerbReverify checks the node actions of the nodes in Verifying again right away
+	VerbReverify = "reverify"
+	// VerbApprove lets the janitor perform the node actions of the nodes waiting for
+	// an outside actor in manual mode
+	VerbApprove = "approve"
+
+	defaultBatchSize = 10
+	maxBatchSize     = 100
+	maxBulkNodes     = 5000
+	// maxBulkRequestBytes bounds the body of bulk requests, which may list many nodes
+	maxBulkRequestBytes = 1 << 20
+)
+
+// BulkRequest is the body of POST /api/v1/nodes/bulk. The verb is applied to the
+// named nodes and the nodes matching the label selector.
+type BulkRequest struct {
+	Verb     string   `json:"verb"`
+	Nodes    []string `json:"nodes"`
+	Selector string   `json:"selector"`
+	Reason   string   `json:"reason"`
+	// BatchSize is the number of nodes processed concurrently, 10 by default
+	BatchSize int `json:"batchSize"`
+}
+
+// BulkStart is the first line of the response to a bulk request, once the nodes
+// are resolved.
+type BulkStart struct {
+	Verb  string `json:"verb"`
+	Total int    `json:"total"`
+}
+
+// BulkResult is the outcome of the verb on a node. A line is streamed for every
+// node after the first line, a batch at a time.
+type BulkResult struct {
+	Node      string `json:"node"`
+	Succeeded bool   `json:"succeeded"`
+	Error     string `json:"error,omitempty"`
+}
+
+// bulk serves POST /api/v1/nodes/bulk. The response is newline delimited JSON: a
+// BulkStart followed by a BulkResult per node.
+func (h *Handler) bulk(w http.ResponseWriter, r *http.Request) {
+	var request BulkRequest
+
+	decoder := json.NewDecoder(io.LimitReader(r.Body, maxBulkRequestBytes))
+	decoder.DisallowUnknownFields()
+
+	if err := decoder.Decode(&request); err != nil {
+		http.Error(w, fmt.Sprintf("invalid request body: %v", err), http.StatusBadRequest)
+		return
+	}
+
+	apply, err := h.verb(r.Context(), &request)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	batchSize := request.BatchSize
+	if batchSize == 0 {
+		batchSize = defaultBatchSize
+	}
+
+	if batchSize < 0 || batchSize > maxBatchSize {
+		http.Error(w, fmt.Sprintf("batchSize must be between 1 and %d", maxBatchSize), http.StatusBadRequest)
+		return
+	}
+
+	nodes, err := h.resolveNodes(r.Context(), request)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	principal := "anonymous"
+	if p, ok := auth.PrincipalFromContext(r.Context()); ok {
+		principal = p.Name
+	}
+
+	slog.Info("Bulk node operation requested",
+		"verb", request.Verb,
+		"nodes", len(nodes),
+		"selector", request.Selector,
+		"requestedBy", principal,
+		"reason", request.Reason)
+
+	w.Header().Set("Content-Type", "application/x-ndjson")
+	w.WriteHeader(http.StatusOK)
+
+	encoder := json.NewEncoder(w)
+	flusher, _ := w.(http.Flusher)
+
+	write := func(v any) bool {
+		if err := encoder.Encode(v); err != nil {
+			slog.Error("Failed to write bulk response", "error", err)
+			return false
+		}
+
+		if flusher != nil {
+			flusher.Flush()
+		}
+
+		return true
+	}
+
+	if !write(BulkStart{Verb: request.Verb, Total: len(nodes)}) {
+		return
+	}
+
+	failed := 0
+
+	for start := 0; start < len(nodes); start += batchSize {
+		batch := nodes[start:min(start+batchSize, len(nodes))]
+
+		for _, result := range applyBatch(r.Context(), batch, apply) {
+			if !result.Succeeded {
+				failed++
+			}
+
+			if !write(result) {
+				return
+			}
+		}
+	}
+
+	slog.Info("Bulk node operation done",
+		"verb", request.Verb,
+		"nodes", len(nodes),
+		"failed", failed,
+		"requestedBy", principal)
+}
+
+// applyBatch applies the verb to the nodes concurrently and returns the results in
+// the order of the nodes.
+func applyBatch(ctx context.Context, nodes []string, apply func(ctx context.Context, node string) error) []BulkResult {
+	results := make([]BulkResult, len(nodes))
+
+	var wg sync.WaitGroup
+
+	for i, node := range nodes {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			results[i] = BulkResult{Node: node, Succeeded: true}
+
+			if err := apply(ctx, node); err != nil {
+				results[i] = BulkResult{Node: node, Error: err.Error()}
+			}
+		}()
+	}
+
+	wg.Wait()
+
+	return results
+}
+
+// resolveNodes returns the named nodes and the nodes matching the selector, without
+// duplicates.
+func (h *Handler) resolveNodes(ctx context.Context, request BulkRequest) ([]string, error) {
+	if len(request.Nodes) == 0 && request.Selector == "" {
+		return nil, fmt.Errorf("nodes or selector is required")
+	}
+
+	seen := map[string]bool{}
+	nodes := []string{}
+
+	add := func(name string) {
+		if name != "" && !seen[name] {
+			seen[name] = true
+			nodes = append(nodes, name)
+		}
+	}
+
+	for _, name := range request.Nodes {
+		add(name)
+	}
+
+	if request.Selector != "" {
+		selector, err := labels.Parse(request.Selector)
+		if err != nil {
+			return nil, fmt.Errorf("invalid selector: %w", err)
+		}
+
+		var nodeList corev1.NodeList
+		if err := h.client.List(ctx, &nodeList, client.MatchingLabelsSelector{Selector: selector}); err != nil {
+			return nil, fmt.Errorf("failed to list nodes matching %q: %w", request.Selector, err)
+		}
+
+		for _, node := range nodeList.Items {
+			add(node.Name)
+		}
+	}
+
+	if len(nodes) == 0 {
+		return nil, fmt.Errorf("no nodes match")
+	}
+
+	if len(nodes) > maxBulkNodes {
+		return nil, fmt.Errorf("%d nodes match, at most %d can be processed at once", len(nodes), maxBulkNodes)
+	}
+
+	return nodes, nil
+}
+
+// verb returns the function applying the verb of the request to a node.
+func (h *Handler) verb(ctx context.Context,
+	request *BulkRequest) (func(ctx context.Context, node string) error, error) {
+	switch request.Verb {
+	case VerbQuarantine:
+		return func(ctx context.Context, node string) error { return h.setUnschedulable(ctx, node, true) }, nil
+	case VerbRelease:
+		return func(ctx context.Context, node string) error { return h.setUnschedulable(ctx, node, false) }, nil
+	case VerbSilence:
+		return func(ctx context.Context, node string) error { return h.setEnforcement(ctx, node, true) }, nil
+	case VerbUnsilence:
+		return func(ctx context.Context, node string) error { return h.setEnforcement(ctx, node, false) }, nil
+	case VerbReverify:
+		actions, err := h.actionsInPhase(ctx, janitordgxcnvidiacomv1alpha1.ActionPhaseVerifying)
+		if err != nil {
+			return nil, err
+		}
+
+		requestedAt := time.Now().UTC().Format(time.RFC3339Nano)
+
+		return func(ctx context.Context, node string) error {
+			return h.annotateActions(ctx, actions[node], janitordgxcnvidiacomv1alpha1.ReverifyAnnotation, requestedAt,
+				janitordgxcnvidiacomv1alpha1.ActionPhaseVerifying)
+		}, nil
+	case VerbApprove:
+		actions, err := h.actionsInPhase(ctx, janitordgxcnvidiacomv1alpha1.ActionPhaseRequested)
+		if err != nil {
+			return nil, err
+		}
+
+		reason := request.Reason
+		if p, ok := auth.PrincipalFromContext(ctx); ok {
+			reason = fmt.Sprintf("requested by %s: %s", p.Name, request.Reason)
+		}
+
+		return func(ctx context.Context, node string) error {
+			return h.annotateActions(ctx, actions[node], janitordgxcnvidiacomv1alpha1.ApproveAnnotation, reason,
+				janitordgxcnvidiacomv1alpha1.ActionPhaseRequested)
+		}, nil
+	default:
+		return nil, fmt.Errorf("unknown verb %q, expected one of %s, %s, %s, %s, %s or %s", request.Verb,
+			VerbQuarantine, VerbRelease, VerbSilence, VerbUnsilence, VerbReverify, VerbApprove)
+	}
+}
+
+// setUnschedulable cordons the node when unschedulable, uncordons it otherwise.
+func (h *Handler) setUnschedulable(ctx context.Context, name string, unschedulable bool) error {
+	var node corev1.Node
+	if err := h.client.Get(ctx, client.ObjectKey{Name: name}, &node); err != nil {
+		return fmt.Errorf("failed to get node: %w", err)
+	}
+
+	if node.Spec.Unschedulable == unschedulable {
+		return nil
+	}
+
+	patch := client.MergeFrom(node.DeepCopy())
+	node.Spec.Unschedulable = unschedulable
+
+	if err := h.client.Patch(ctx, &node, patch); err != nil {
+		return fmt.Errorf("failed to update node schedulability: %w", err)
+	}
+
+	return nil
+}
+
+// setEnforcement switches enforcement off for the node when silenced, back on
+// otherwise.
+func (h *Handler) setEnforcement(ctx context.Context, name string, silenced bool) error {
+	var node corev1.Node
+	if err := h.client.Get(ctx, client.ObjectKey{Name: name}, &node); err != nil {
+		return fmt.Errorf("failed to get node: %w", err)
+	}
+
+	if enforcement.Disabled(node.Annotations, nil) == silenced {
+		return nil
+	}
+
+	patch := client.MergeFrom(node.DeepCopy())
+
+	if silenced {
+		if node.Annotations == nil {
+			node.Annotations = map[string]string{}
+		}
+
+		node.Annotations[enforcement.Key] = enforcement.Off
+	} else {
+		delete(node.Annotations, enforcement.Key)
+	}
+
+	if err := h.client.Patch(ctx, &node, patch); err != nil {
+		return fmt.Errorf("failed to update enforcement annotation: %w", err)
+	}
+
+	return nil
+}
+
+// actionsInPhase returns the node actions in the phase by node.
+func (h *Handler) actionsInPhase(ctx context.Context,
+	phase janitordgxcnvidiacomv1alpha1.ActionPhase) (map[string][]client.Object, error) {
+	actions := map[string][]client.Object{}
+
+	var rebootNodes janitordgxcnvidiacomv1alpha1.RebootNodeList
+	if err := h.client.List(ctx, &rebootNodes); err != nil {
+		return nil, fmt.Errorf("failed to list rebootnodes: %w", err)
+	}
+
+	for i := range rebootNodes.Items {
+		if rebootNodes.Items[i].Status.Phase == phase {
+			node := rebootNodes.Items[i].Spec.NodeName
+			actions[node] = append(actions[node], &rebootNodes.Items[i])
+		}
+	}
+
+	var terminateNodes janitordgxcnvidiacomv1alpha1.TerminateNodeList
+	if err := h.client.List(ctx, &terminateNodes); err != nil {
+		return nil, fmt.Errorf("failed to list terminatenodes: %w", err)
+	}
+
+	for i := range terminateNodes.Items {
+		if terminateNodes.Items[i].Status.Phase == phase {
+			node := terminateNodes.Items[i].Spec.NodeName
+			actions[node] = append(actions[node], &terminateNodes.Items[i])
+		}
+	}
+
+	var driverReloads janitordgxcnvidiacomv1alpha1.DriverReloadList
+	if err := h.client.List(ctx, &driverReloads); err != nil {
+		return nil, fmt.Errorf("failed to list driverreloads: %w", err)
+	}
+
+	for i := range driverReloads.Items {
+		if driverReloads.Items[i].Status.Phase == phase {
+			node := driverReloads.Items[i].Spec.NodeName
+			actions[node] = append(actions[node], &driverReloads.Items[i])
+		}
+	}
+
+	var migReconfigurations janitordgxcnvidiacomv1alpha1.MIGReconfigurationList
+	if err := h.client.List(ctx, &migReconfigurations); err != nil {
+		return nil, fmt.Errorf("failed to list migreconfigurations: %w", err)
+	}
+
+	for i := range migReconfigurations.Items {
+		if migReconfigurations.Items[i].Status.Phase == phase {
+			node := migReconfigurations.Items[i].Spec.NodeName
+			actions[node] = append(actions[node], &migReconfigurations.Items[i])
+		}
+	}
+
+	var gpuResets janitordgxcnvidiacomv1alpha1.GPUResetList
+	if err := h.client.List(ctx, &gpuResets); err != nil {
+		return nil, fmt.Errorf("failed to list gpuresets: %w", err)
+	}
+
+	for i := range gpuResets.Items {
+		if gpuResets.Items[i].Status.Phase == phase {
+			node := gpuResets.Items[i].Spec.NodeName
+			actions[node] = append(actions[node], &gpuResets.Items[i])
+		}
+	}
+
+	var powerCaps janitordgxcnvidiacomv1alpha1.PowerCapList
+	if err := h.client.List(ctx, &powerCaps); err != nil {
+		return nil, fmt.Errorf("failed to list powercaps: %w", err)
+	}
+
+	for i := range powerCaps.Items {
+		if powerCaps.Items[i].Status.Phase == phase {
+			node := powerCaps.Items[i].Spec.NodeName
+			actions[node] = append(actions[node], &powerCaps.Items[i])
+		}
+	}
+
+	return actions, nil
+}
+
+// annotateActions sets the annotation on the node actions of a node, which are all
+// in the phase.
+func (h *Handler) annotateActions(ctx context.Context, actions []client.Object, annotation, value string,
+	phase janitordgxcnvidiacomv1alpha1.ActionPhase) error {
+	if len(actions) == 0 {
+		return fmt.Errorf("no node action of the node is in %s", phase)
+	}
+
+	for _, action := range actions {
+		patch := client.MergeFrom(action.DeepCopyObject().(client.Object))
+
+		annotations := action.GetAnnotations()
+		if annotations == nil {
+			annotations = map[string]string{}
+		}
+
+		annotations[annotation] = value
+		action.SetAnnotations(annotations)
+
+		if err := h.client.Patch(ctx, action, patch); err != nil {
+			return fmt.Errorf("failed to annotate %s: %w", action.GetName(), err)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,260 @@
+// Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package api
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/nvidia/nvsentinel/commons/pkg/auth"
+	"github.com/nvidia/nvsentinel/commons/pkg/enforcement"
+	janitordgxcnvidiacomv1alpha1 "github.com/nvidia/nvsentinel/janitor/api/v1alpha1"
+)
+
+func newBulkTestHandler(t *testing.T) (*Handler, client.Client) {
+	t.Helper()
+
+	scheme := runtime.NewScheme()
+	require.NoError(t, clientgoscheme.AddToScheme(scheme))
+	require.NoError(t, janitordgxcnvidiacomv1alpha1.AddToScheme(scheme))
+
+	node := func(name string, unschedulable bool, annotations map[string]string) *corev1.Node {
+		return &corev1.Node{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        name,
+				Labels:      map[string]string{"pool": "h100"},
+				Annotations: annotations,
+			},
+			Spec: corev1.NodeSpec{Unschedulable: unschedulable},
+		}
+	}
+
+	c := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(
+			node("node-1", true, nil),
+			node("node-2", true, map[string]string{enforcement.Key: enforcement.Off}),
+			node("node-3", false, nil),
+			&janitordgxcnvidiacomv1alpha1.RebootNode{
+				ObjectMeta: metav1.ObjectMeta{Name: "reboot-node-1"},
+				Spec:       janitordgxcnvidiacomv1alpha1.RebootNodeSpec{NodeName: "node-1"},
+				Status: janitordgxcnvidiacomv1alpha1.RebootNodeStatus{
+					Phase: janitordgxcnvidiacomv1alpha1.ActionPhaseVerifying,
+				},
+			},
+			&janitordgxcnvidiacomv1alpha1.RebootNode{
+				ObjectMeta: metav1.ObjectMeta{Name: "reboot-node-2"},
+				Spec:       janitordgxcnvidiacomv1alpha1.RebootNodeSpec{NodeName: "node-2"},
+				Status: janitordgxcnvidiacomv1alpha1.RebootNodeStatus{
+					Phase: janitordgxcnvidiacomv1alpha1.ActionPhaseDone,
+				},
+			},
+			&janitordgxcnvidiacomv1alpha1.DriverReload{
+				ObjectMeta: metav1.ObjectMeta{Name: "driver-reload-node-3"},
+				Spec:       janitordgxcnvidiacomv1alpha1.DriverReloadSpec{NodeName: "node-3"},
+				Status: janitordgxcnvidiacomv1alpha1.DriverReloadStatus{
+					Phase: janitordgxcnvidiacomv1alpha1.ActionPhaseRequested,
+				},
+			},
+		).
+		Build()
+
+	tokens, err := auth.NewTokenAuthenticator([]auth.TokenConfig{
+		{Name: "grafana", Role: "viewer", Token: viewerToken},
+		{Name: "oncall", Role: "operator", Token: operatorToken},
+	})
+	require.NoError(t, err)
+
+	return NewHandler(c, auth.NewMiddleware(tokens)), c
+}
+
+// decodeBulk returns the first line and the results of a bulk response.
+func decodeBulk(t *testing.T, body string) (BulkStart, []BulkResult) {
+	t.Helper()
+
+	scanner := bufio.NewScanner(strings.NewReader(body))
+
+	require.True(t, scanner.Scan())
+
+	var start BulkStart
+	require.NoError(t, json.Unmarshal(scanner.Bytes(), &start))
+
+	var results []BulkResult
+
+	for scanner.Scan() {
+		var result BulkResult
+		require.NoError(t, json.Unmarshal(scanner.Bytes(), &result))
+
+		results = append(results, result)
+	}
+
+	return start, results
+}
+
+func TestBulkRelease(t *testing.T) {
+	h, c := newBulkTestHandler(t)
+
+	rec := doRequest(h, http.MethodPost, "/api/v1/nodes/bulk", operatorToken,
+		`{"verb": "release", "nodes": ["node-1", "missing"], "selector": "pool=h100", "batchSize": 2}`)
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	assert.Equal(t, "application/x-ndjson", rec.Header().Get("Content-Type"))
+
+	start, results := decodeBulk(t, rec.Body.String())
+	assert.Equal(t, BulkStart{Verb: VerbRelease, Total: 4}, start)
+	require.Len(t, results, 4)
+
+	assert.Equal(t, BulkResult{Node: "node-1", Succeeded: true}, results[0])
+	assert.Equal(t, "missing", results[1].Node)
+	assert.False(t, results[1].Succeeded)
+	assert.Contains(t, results[1].Error, "failed to get node")
+	assert.Equal(t, BulkResult{Node: "node-2", Succeeded: true}, results[2])
+	assert.Equal(t, BulkResult{Node: "node-3", Succeeded: true}, results[3], "schedulable nodes are left alone")
+
+	for _, name := range []string{"node-1", "node-2", "node-3"} {
+		var node corev1.Node
+		require.NoError(t, c.Get(context.Background(), client.ObjectKey{Name: name}, &node))
+		assert.False(t, node.Spec.Unschedulable, name)
+	}
+}
+
+func TestBulkQuarantine(t *testing.T) {
+	h, c := newBulkTestHandler(t)
+
+	rec := doRequest(h, http.MethodPost, "/api/v1/nodes/bulk", operatorToken,
+		`{"verb": "quarantine", "nodes": ["node-1", "node-3"]}`)
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+
+	_, results := decodeBulk(t, rec.Body.String())
+	assert.Equal(t, []BulkResult{{Node: "node-1", Succeeded: true}, {Node: "node-3", Succeeded: true}}, results)
+
+	for _, name := range []string{"node-1", "node-3"} {
+		var node corev1.Node
+		require.NoError(t, c.Get(context.Background(), client.ObjectKey{Name: name}, &node))
+		assert.True(t, node.Spec.Unschedulable, name)
+	}
+}
+
+func TestBulkSilence(t *testing.T) {
+	h, c := newBulkTestHandler(t)
+
+	rec := doRequest(h, http.MethodPost, "/api/v1/nodes/bulk", operatorToken,
+		`{"verb": "silence", "nodes": ["node-1", "node-2"]}`)
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+
+	_, results := decodeBulk(t, rec.Body.String())
+	require.Len(t, results, 2)
+	assert.True(t, results[0].Succeeded)
+	assert.True(t, results[1].Succeeded)
+
+	var node corev1.Node
+	require.NoError(t, c.Get(context.Background(), client.ObjectKey{Name: "node-1"}, &node))
+	assert.Equal(t, enforcement.Off, node.Annotations[enforcement.Key])
+
+	rec = doRequest(h, http.MethodPost, "/api/v1/nodes/bulk", operatorToken,
+		`{"verb": "unsilence", "nodes": ["node-2"]}`)
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+
+	require.NoError(t, c.Get(context.Background(), client.ObjectKey{Name: "node-2"}, &node))
+	assert.NotContains(t, node.Annotations, enforcement.Key)
+}
+
+func TestBulkReverify(t *testing.T) {
+	h, c := newBulkTestHandler(t)
+
+	rec := doRequest(h, http.MethodPost, "/api/v1/nodes/bulk", operatorToken,
+		`{"verb": "reverify", "nodes": ["node-1", "node-2"]}`)
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+
+	_, results := decodeBulk(t, rec.Body.String())
+	require.Len(t, results, 2)
+	assert.True(t, results[0].Succeeded)
+	assert.False(t, results[1].Succeeded, "the action of node-2 is done")
+	assert.Contains(t, results[1].Error, "no node action of the node is in Verifying")
+
+	var rebootNode janitordgxcnvidiacomv1alpha1.RebootNode
+	require.NoError(t, c.Get(context.Background(), client.ObjectKey{Name: "reboot-node-1"}, &rebootNode))
+	assert.NotEmpty(t, rebootNode.Annotations[janitordgxcnvidiacomv1alpha1.ReverifyAnnotation])
+
+	require.NoError(t, c.Get(context.Background(), client.ObjectKey{Name: "reboot-node-2"}, &rebootNode))
+	assert.NotContains(t, rebootNode.Annotations, janitordgxcnvidiacomv1alpha1.ReverifyAnnotation)
+}
+
+func TestBulkApprove(t *testing.T) {
+	h, c := newBulkTestHandler(t)
+
+	rec := doRequest(h, http.MethodPost, "/api/v1/nodes/bulk", operatorToken,
+		`{"verb": "approve", "nodes": ["node-1", "node-3"], "reason": "drained by hand"}`)
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+
+	_, results := decodeBulk(t, rec.Body.String())
+	require.Len(t, results, 2)
+	assert.False(t, results[0].Succeeded, "the action of node-1 is in Verifying")
+	assert.Contains(t, results[0].Error, "no node action of the node is in Requested")
+	assert.Equal(t, BulkResult{Node: "node-3", Succeeded: true}, results[1])
+
+	var driverReload janitordgxcnvidiacomv1alpha1.DriverReload
+	require.NoError(t, c.Get(context.Background(), client.ObjectKey{Name: "driver-reload-node-3"}, &driverReload))
+	assert.Equal(t, "requested by oncall: drained by hand",
+		driverReload.Annotations[janitordgxcnvidiacomv1alpha1.ApproveAnnotation])
+
+	var rebootNode janitordgxcnvidiacomv1alpha1.RebootNode
+	require.NoError(t, c.Get(context.Background(), client.ObjectKey{Name: "reboot-node-1"}, &rebootNode))
+	assert.NotContains(t, rebootNode.Annotations, janitordgxcnvidiacomv1alpha1.ApproveAnnotation)
+}
+
+func TestBulkInvalidRequests(t *testing.T) {
+	h, _ := newBulkTestHandler(t)
+
+	tests := []struct {
+		name     string
+		token    string
+		body     string
+		expected int
+	}{
+		{name: "viewer", token: viewerToken, body: `{"verb": "release", "nodes": ["node-1"]}`,
+			expected: http.StatusForbidden},
+		{name: "unknown verb", token: operatorToken, body: `{"verb": "reboot", "nodes": ["node-1"]}`,
+			expected: http.StatusBadRequest},
+		{name: "no nodes", token: operatorToken, body: `{"verb": "release"}`, expected: http.StatusBadRequest},
+		{name: "no match", token: operatorToken, body: `{"verb": "release", "selector": "pool=a100"}`,
+			expected: http.StatusBadRequest},
+		{name: "invalid selector", token: operatorToken, body: `{"verb": "release", "selector": "pool=="}`,
+			expected: http.StatusBadRequest},
+		{name: "batch size", token: operatorToken, body: `{"verb": "release", "nodes": ["node-1"], "batchSize": 1000}`,
+			expected: http.StatusBadRequest},
+		{name: "unknown field", token: operatorToken, body: `{"verb": "release", "node": "node-1"}`,
+			expected: http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := doRequest(h, http.MethodPost, "/api/v1/nodes/bulk", tt.token, tt.body)
+			assert.Equal(t, tt.expected, rec.Code, rec.Body.String())
+		})
+	}
+}
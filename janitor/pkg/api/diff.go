@@ -0,0 +1,230 @@
+// Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package api
+
+import (
+	"fmt"
+	"log/slog"
+	"net/http"
+	"sort"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// skuLabels identify the SKU of a node, nodes are compared to the healthy nodes
+// with the same values of the labels the node has
+var skuLabels = []string{
+	"nvidia.com/gpu.product",
+	"node.kubernetes.io/instance-type",
+}
+
+// inventoryLabelPrefixes select the labels describing the inventory and the
+// configuration of a node: the labels of GPU and node feature discovery, which
+// hold the driver, CUDA, GPU and kernel, and those of NVSentinel
+var inventoryLabelPrefixes = []string{
+	"nvidia.com/",
+	"feature.node.kubernetes.io/",
+	"nvsentinel.dgxc.nvidia.com/",
+}
+
+// volatileLabels change on every node over time and are never compared
+var volatileLabels = map[string]bool{
+	"nvidia.com/gfd.timestamp": true,
+}
+
+// NodeDiff is the response of GET /api/v1/nodes/{name}/diff.
+type NodeDiff struct {
+	Node string `json:"node"`
+	// SKU holds the labels the peers were selected by
+	SKU map[string]string `json:"sku"`
+	// Peers is the number of healthy nodes of the SKU compared against
+	Peers      int         `json:"peers"`
+	Deviations []Deviation `json:"deviations"`
+}
+
+// Deviation is an attribute of the node that differs from the value most of its
+// peers have.
+type Deviation struct {
+	Attribute string `json:"attribute"`
+	// Value is empty when the node lacks the attribute
+	Value    string `json:"value"`
+	Expected string `json:"expected"`
+	// Values counts the peers by their value of the attribute
+	Values map[string]int `json:"values"`
+}
+
+// diffNode serves GET /api/v1/nodes/{name}/diff. It compares the inventory and the
+// configuration of the node, from its node info, capacity and labels, with the
+// healthy nodes of the same SKU, which are Ready and schedulable, and returns the
+// attributes where the node deviates from the majority.
+func (h *Handler) diffNode(w http.ResponseWriter, r *http.Request) {
+	var node corev1.Node
+	if err := h.client.Get(r.Context(), client.ObjectKey{Name: r.PathValue("name")}, &node); err != nil {
+		if apierrors.IsNotFound(err) {
+			http.Error(w, fmt.Sprintf("node %s not found", r.PathValue("name")), http.StatusNotFound)
+			return
+		}
+
+		slog.Error("Failed to get node", "node", r.PathValue("name"), "error", err)
+		http.Error(w, "failed to get node", http.StatusInternalServerError)
+
+		return
+	}
+
+	sku := map[string]string{}
+
+	for _, label := range skuLabels {
+		if value, ok := node.Labels[label]; ok {
+			sku[label] = value
+		}
+	}
+
+	if len(sku) == 0 {
+		http.Error(w, fmt.Sprintf("node %s has none of the SKU labels %s", node.Name,
+			strings.Join(skuLabels, ", ")), http.StatusUnprocessableEntity)
+
+		return
+	}
+
+	var nodes corev1.NodeList
+	if err := h.client.List(r.Context(), &nodes, client.MatchingLabels(sku)); err != nil {
+		slog.Error("Failed to list nodes", "error", err)
+		http.Error(w, "failed to list nodes", http.StatusInternalServerError)
+
+		return
+	}
+
+	var peers []map[string]string
+
+	for i := range nodes.Items {
+		peer := &nodes.Items[i]
+		if peer.Name != node.Name && isHealthy(peer) {
+			peers = append(peers, nodeAttributes(peer))
+		}
+	}
+
+	writeJSON(w, http.StatusOK, NodeDiff{
+		Node:       node.Name,
+		SKU:        sku,
+		Peers:      len(peers),
+		Deviations: deviations(nodeAttributes(&node), peers),
+	})
+}
+
+// nodeAttributes returns the inventory and configuration attributes of the node
+func nodeAttributes(node *corev1.Node) map[string]string {
+	info := node.Status.NodeInfo
+	attributes := map[string]string{
+		"kernelVersion":           info.KernelVersion,
+		"osImage":                 info.OSImage,
+		"containerRuntimeVersion": info.ContainerRuntimeVersion,
+		"kubeletVersion":          info.KubeletVersion,
+	}
+
+	if gpus, ok := node.Status.Capacity["nvidia.com/gpu"]; ok {
+		attributes["capacity:nvidia.com/gpu"] = gpus.String()
+	}
+
+	for label, value := range node.Labels {
+		if volatileLabels[label] {
+			continue
+		}
+
+		for _, prefix := range inventoryLabelPrefixes {
+			if strings.HasPrefix(label, prefix) {
+				attributes["label:"+label] = value
+				break
+			}
+		}
+	}
+
+	return attributes
+}
+
+// deviations returns the attributes of the node that differ from the value most
+// peers have, sorted by attribute. Without peers there is nothing to compare to.
+func deviations(node map[string]string, peers []map[string]string) []Deviation {
+	result := []Deviation{}
+
+	if len(peers) == 0 {
+		return result
+	}
+
+	names := map[string]bool{}
+	for name := range node {
+		names[name] = true
+	}
+
+	for _, peer := range peers {
+		for name := range peer {
+			names[name] = true
+		}
+	}
+
+	for name := range names {
+		values := map[string]int{}
+		for _, peer := range peers {
+			values[peer[name]]++
+		}
+
+		expected := majority(values)
+		if node[name] != expected {
+			result = append(result, Deviation{
+				Attribute: name,
+				Value:     node[name],
+				Expected:  expected,
+				Values:    values,
+			})
+		}
+	}
+
+	sort.Slice(result, func(i, j int) bool { return result[i].Attribute < result[j].Attribute })
+
+	return result
+}
+
+// majority returns the most common value, the smallest on ties so results are stable
+func majority(values map[string]int) string {
+	var (
+		best  string
+		count int
+	)
+
+	for value, n := range values {
+		if n > count || (n == count && value < best) {
+			best, count = value, n
+		}
+	}
+
+	return best
+}
+
+// isHealthy returns true for a Ready, schedulable node
+func isHealthy(node *corev1.Node) bool {
+	if node.Spec.Unschedulable {
+		return false
+	}
+
+	for _, condition := range node.Status.Conditions {
+		if condition.Type == corev1.NodeReady {
+			return condition.Status == corev1.ConditionTrue
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,116 @@
+// Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/nvidia/nvsentinel/commons/pkg/auth"
+)
+
+func skuNode(name, product, driver, kernel string, ready bool) *corev1.Node {
+	status := corev1.ConditionTrue
+	if !ready {
+		status = corev1.ConditionFalse
+	}
+
+	return &corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: name,
+			Labels: map[string]string{
+				"nvidia.com/gpu.product":              product,
+				"nvidia.com/cuda.driver-version.full": driver,
+				"nvidia.com/gfd.timestamp":            name,
+				"kubernetes.io/hostname":              name,
+			},
+		},
+		Status: corev1.NodeStatus{
+			NodeInfo:   corev1.NodeSystemInfo{KernelVersion: kernel, OSImage: "Ubuntu 22.04"},
+			Capacity:   corev1.ResourceList{"nvidia.com/gpu": resource.MustParse("8")},
+			Conditions: []corev1.NodeCondition{{Type: corev1.NodeReady, Status: status}},
+		},
+	}
+}
+
+func TestDiffNode(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, clientgoscheme.AddToScheme(scheme))
+
+	faulty := skuNode("faulty", "H100", "535.161", "5.15.0-100", false)
+	delete(faulty.Status.Capacity, "nvidia.com/gpu")
+
+	c := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(
+			faulty,
+			skuNode("healthy-1", "H100", "550.54", "5.15.0-100", true),
+			skuNode("healthy-2", "H100", "550.54", "5.15.0-100", true),
+			skuNode("healthy-3", "H100", "535.161", "5.15.0-100", true),
+			// unhealthy nodes and other SKUs are not peers
+			skuNode("not-ready", "H100", "535.161", "5.15.0-90", false),
+			skuNode("a100", "A100", "535.161", "5.15.0-90", true),
+		).
+		Build()
+
+	tokens, err := auth.NewTokenAuthenticator([]auth.TokenConfig{{Name: "grafana", Role: "viewer", Token: viewerToken}})
+	require.NoError(t, err)
+
+	h := NewHandler(c, auth.NewMiddleware(tokens))
+
+	rec := doRequest(h, http.MethodGet, "/api/v1/nodes/faulty/diff", viewerToken, "")
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+
+	var diff NodeDiff
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &diff))
+
+	assert.Equal(t, "faulty", diff.Node)
+	assert.Equal(t, map[string]string{"nvidia.com/gpu.product": "H100"}, diff.SKU)
+	assert.Equal(t, 3, diff.Peers)
+	assert.Equal(t, []Deviation{
+		{
+			Attribute: "capacity:nvidia.com/gpu",
+			Value:     "",
+			Expected:  "8",
+			Values:    map[string]int{"8": 3},
+		},
+		{
+			Attribute: "label:nvidia.com/cuda.driver-version.full",
+			Value:     "535.161",
+			Expected:  "550.54",
+			Values:    map[string]int{"550.54": 2, "535.161": 1},
+		},
+	}, diff.Deviations)
+
+	rec = doRequest(h, http.MethodGet, "/api/v1/nodes/missing/diff", viewerToken, "")
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+
+	rec = doRequest(h, http.MethodGet, "/api/v1/nodes/faulty/diff", "", "")
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+}
+
+func TestDeviationsWithoutPeers(t *testing.T) {
+	assert.Empty(t, deviations(map[string]string{"kernelVersion": "5.15"}, nil))
+}
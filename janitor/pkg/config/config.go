@@ -24,16 +24,31 @@ import (
 
 // Config represents the janitor configuration structure
 type Config struct {
-	Global        GlobalConfig                  `mapstructure:"global" json:"global"`
-	RebootNode    RebootNodeControllerConfig    `mapstructure:"rebootNodeController" json:"rebootNodeController"`
-	TerminateNode TerminateNodeControllerConfig `mapstructure:"terminateNodeController" json:"terminateNodeController"`
+	Global             GlobalConfig                       `mapstructure:"global" json:"global"`
+	RebootNode         RebootNodeControllerConfig         `mapstructure:"rebootNodeController" json:"rebootNodeController"`
+	TerminateNode      TerminateNodeControllerConfig      `mapstructure:"terminateNodeController" json:"terminateNodeController"`
+	DriverReload       DriverReloadControllerConfig       `mapstructure:"driverReloadController" json:"driverReloadController"`
+	MIGReconfiguration MIGReconfigurationControllerConfig `mapstructure:"migReconfigurationController" json:"migReconfigurationController"` //nolint:lll
+	GPUReset           GPUResetControllerConfig           `mapstructure:"gpuResetController" json:"gpuResetController"`
+	PowerCap           PowerCapControllerConfig           `mapstructure:"powerCapController" json:"powerCapController"`
 }
 
 // GlobalConfig contains global janitor settings
 type GlobalConfig struct {
-	Timeout    time.Duration `mapstructure:"timeout" json:"timeout"`
-	ManualMode bool          `mapstructure:"manualMode" json:"manualMode"`
-	Nodes      NodeConfig    `mapstructure:"nodes" json:"nodes"`
+	Timeout    time.Duration  `mapstructure:"timeout" json:"timeout"`
+	ManualMode bool           `mapstructure:"manualMode" json:"manualMode"`
+	Nodes      NodeConfig     `mapstructure:"nodes" json:"nodes"`
+	NodeLock   NodeLockConfig `mapstructure:"nodeLock" json:"nodeLock"`
+}
+
+// NodeLockConfig contains configuration for the per node locks serializing actions
+type NodeLockConfig struct {
+	// Enabled indicates if only one action at a time may act on a node, across the
+	// janitor controllers and the runbooks of fault remediation
+	Enabled bool `mapstructure:"enabled" json:"enabled"`
+	// Duration after which a lock that was not released can be taken over, e.g. after a
+	// crash of its holder, defaults to 1h
+	Duration time.Duration `mapstructure:"duration" json:"duration"`
 }
 
 // NodeConfig contains configuration for nodes
@@ -49,6 +64,11 @@ type RebootNodeControllerConfig struct {
 	ManualMode bool
 	// Timeout for reboot operations
 	Timeout time.Duration
+	// SignalTimeout bounds a single CSP call sending the reboot signal, defaults to 2m
+	SignalTimeout time.Duration
+	// ApprovalTimeout bounds how long an action waits for an outside actor in manual
+	// mode before it is failed and flagged for human attention, zero waits forever
+	ApprovalTimeout time.Duration
 	// NodeExclusions defines label selectors for nodes that should be excluded from reboot operations
 	// Nodes matching any of these label selectors will be rejected by the admission webhook
 	NodeExclusions []metav1.LabelSelector
@@ -62,11 +82,122 @@ type TerminateNodeControllerConfig struct {
 	ManualMode bool
 	// Timeout for terminate operations
 	Timeout time.Duration
+	// SignalTimeout bounds a single CSP call sending the terminate signal, defaults to 2m
+	SignalTimeout time.Duration
+	// ApprovalTimeout bounds how long an action waits for an outside actor in manual
+	// mode before it is failed and flagged for human attention, zero waits forever
+	ApprovalTimeout time.Duration
 	// NodeExclusions defines label selectors for nodes that should be excluded from terminate operations
 	// Nodes matching any of these label selectors will be rejected by the admission webhook
 	NodeExclusions []metav1.LabelSelector
 }
 
+// DriverReloadControllerConfig contains configuration for driver reload controller
+type DriverReloadControllerConfig struct {
+	// Enabled indicates if the controller is enabled
+	Enabled bool
+	// ManualMode indicates if the controller should skip requesting driver restarts
+	ManualMode bool
+	// Timeout for driver reload operations, from the restart request until the
+	// GPU Operator validated the driver
+	Timeout time.Duration
+	// ApprovalTimeout bounds how long an action waits for an outside actor in manual
+	// mode before it is failed and flagged for human attention, zero waits forever
+	ApprovalTimeout time.Duration
+	// OperatorNamespace is the namespace the GPU Operator runs its validator pods in,
+	// defaults to gpu-operator
+	OperatorNamespace string
+	// ValidatorSelector is the label selector of the GPU Operator validator pods,
+	// defaults to app=nvidia-operator-validator
+	ValidatorSelector string
+	// NodeExclusions defines label selectors for nodes that should be excluded from driver reloads
+	// Nodes matching any of these label selectors will be rejected by the admission webhook
+	NodeExclusions []metav1.LabelSelector
+}
+
+// MIGReconfigurationControllerConfig contains configuration for MIG reconfiguration controller
+type MIGReconfigurationControllerConfig struct {
+	// Enabled indicates if the controller is enabled
+	Enabled bool
+	// ManualMode indicates if the controller should wait for the approval of an outside
+	// actor before asking the MIG manager to reconfigure the node
+	ManualMode bool
+	// Timeout for MIG reconfiguration operations, from the start until the device
+	// plugin advertises the recreated instances
+	Timeout time.Duration
+	// ApprovalTimeout bounds how long an action waits for an outside actor in manual
+	// mode before it is failed and flagged for human attention, zero waits forever
+	ApprovalTimeout time.Duration
+	// OperatorNamespace is the namespace the GPU Operator runs its device plugin pods in,
+	// defaults to gpu-operator
+	OperatorNamespace string
+	// DevicePluginSelector is the label selector of the device plugin pods,
+	// defaults to app=nvidia-device-plugin-daemonset
+	DevicePluginSelector string
+	// DisabledConfig is the MIG manager configuration disabling MIG on all GPUs,
+	// defaults to all-disabled
+	DisabledConfig string
+	// NodeExclusions defines label selectors for nodes that should be excluded from MIG reconfigurations
+	// Nodes matching any of these label selectors will be rejected by the admission webhook
+	NodeExclusions []metav1.LabelSelector
+}
+
+// GPUResetControllerConfig contains configuration for GPU reset controller
+type GPUResetControllerConfig struct {
+	// Enabled indicates if the controller is enabled
+	Enabled bool
+	// ManualMode indicates if the controller should wait for the approval of an outside
+	// actor before resetting the GPUs
+	ManualMode bool
+	// Timeout for GPU reset operations, from the start until the GPUs were reset, which
+	// includes waiting for the evicted pods to terminate
+	Timeout time.Duration
+	// ApprovalTimeout bounds how long an action waits for an outside actor in manual
+	// mode before it is failed and flagged for human attention, zero waits forever
+	ApprovalTimeout time.Duration
+	// JobNamespace is the namespace the reset Jobs run in
+	JobNamespace string
+	// JobImage is the image of the reset Jobs, which run `janitor gpu-reset` on the node
+	JobImage string
+	// JobServiceAccount is the service account of the reset Jobs, allowed to list and
+	// evict pods
+	JobServiceAccount string
+	// NodeExclusions defines label selectors for nodes that should be excluded from GPU resets
+	// Nodes matching any of these label selectors will be rejected by the admission webhook
+	NodeExclusions []metav1.LabelSelector
+}
+
+// PowerCapControllerConfig contains configuration for power cap controller
+type PowerCapControllerConfig struct {
+	// Enabled indicates if the controller is enabled
+	Enabled bool
+	// ManualMode indicates if the controller should wait for the approval of an outside
+	// actor before capping the GPUs
+	ManualMode bool
+	// Timeout for the Jobs capping and restoring the power limits, defaults to 5m
+	Timeout time.Duration
+	// ApprovalTimeout bounds how long an action waits for an outside actor in manual
+	// mode before it is failed and flagged for human attention, zero waits forever
+	ApprovalTimeout time.Duration
+	// LimitPercent is the cap in percent of the default power limit of the GPUs for the
+	// PowerCaps that do not set one, defaults to 70
+	LimitPercent int32
+	// SettleTime is how long the node condition of the thermal fault must not be true
+	// before the cap is lifted, defaults to 10m
+	SettleTime time.Duration
+	// HoldDuration is how long the cap is held for the PowerCaps that name no node
+	// condition, defaults to 1h
+	HoldDuration time.Duration
+	// JobNamespace is the namespace the cap and restore Jobs run in
+	JobNamespace string
+	// JobImage is the image of the cap and restore Jobs, which run `janitor power-cap`
+	// on the node
+	JobImage string
+	// NodeExclusions defines label selectors for nodes that should be excluded from power caps
+	// Nodes matching any of these label selectors will be rejected by the admission webhook
+	NodeExclusions []metav1.LabelSelector
+}
+
 // LoadConfig loads configuration from a YAML file using Viper
 func LoadConfig(configPath string) (*Config, error) {
 	v := viper.New()
@@ -97,6 +228,10 @@ func LoadConfig(configPath string) (*Config, error) {
 	// Apply node exclusions from global config to controller-specific configs
 	config.RebootNode.NodeExclusions = config.Global.Nodes.Exclusions
 	config.TerminateNode.NodeExclusions = config.Global.Nodes.Exclusions
+	config.DriverReload.NodeExclusions = config.Global.Nodes.Exclusions
+	config.MIGReconfiguration.NodeExclusions = config.Global.Nodes.Exclusions
+	config.GPUReset.NodeExclusions = config.Global.Nodes.Exclusions
+	config.PowerCap.NodeExclusions = config.Global.Nodes.Exclusions
 
 	return &config, nil
 }
@@ -51,11 +51,39 @@ rebootNodeController:
   enabled: true
   manualMode: false
   timeout: 20m
+  signalTimeout: 1m
+  approvalTimeout: 2h
 
 terminateNodeController:
   enabled: false
   manualMode: true
   timeout: 15m
+
+driverReloadController:
+  enabled: true
+  timeout: 10m
+  operatorNamespace: nvidia-gpu-operator
+  validatorSelector: app=custom-validator
+
+migReconfigurationController:
+  enabled: true
+  timeout: 20m
+  disabledConfig: custom-disabled
+
+gpuResetController:
+  enabled: true
+  timeout: 15m
+  jobNamespace: nvsentinel
+  jobImage: ghcr.io/nvidia/nvsentinel/janitor:v1
+  jobServiceAccount: janitor-gpu-reset
+
+powerCapController:
+  enabled: true
+  limitPercent: 60
+  settleTime: 15m
+  holdDuration: 2h
+  jobNamespace: nvsentinel
+  jobImage: ghcr.io/nvidia/nvsentinel/janitor:v1
 `
 
 	err := os.WriteFile(configPath, []byte(configContent), 0644)
@@ -77,15 +105,47 @@ terminateNodeController:
 	assert.True(t, config.RebootNode.Enabled)
 	assert.False(t, config.RebootNode.ManualMode)
 	assert.Equal(t, 20*time.Minute, config.RebootNode.Timeout)
+	assert.Equal(t, time.Minute, config.RebootNode.SignalTimeout)
+	assert.Equal(t, 2*time.Hour, config.RebootNode.ApprovalTimeout)
 
 	// Verify TerminateNode config
 	assert.False(t, config.TerminateNode.Enabled)
 	assert.True(t, config.TerminateNode.ManualMode)
 	assert.Equal(t, 15*time.Minute, config.TerminateNode.Timeout)
 
+	// Verify DriverReload config
+	assert.True(t, config.DriverReload.Enabled)
+	assert.Equal(t, 10*time.Minute, config.DriverReload.Timeout)
+	assert.Equal(t, "nvidia-gpu-operator", config.DriverReload.OperatorNamespace)
+	assert.Equal(t, "app=custom-validator", config.DriverReload.ValidatorSelector)
+
+	// Verify MIGReconfiguration config
+	assert.True(t, config.MIGReconfiguration.Enabled)
+	assert.Equal(t, 20*time.Minute, config.MIGReconfiguration.Timeout)
+	assert.Equal(t, "custom-disabled", config.MIGReconfiguration.DisabledConfig)
+
+	// Verify GPUReset config
+	assert.True(t, config.GPUReset.Enabled)
+	assert.Equal(t, 15*time.Minute, config.GPUReset.Timeout)
+	assert.Equal(t, "nvsentinel", config.GPUReset.JobNamespace)
+	assert.Equal(t, "ghcr.io/nvidia/nvsentinel/janitor:v1", config.GPUReset.JobImage)
+	assert.Equal(t, "janitor-gpu-reset", config.GPUReset.JobServiceAccount)
+
+	// Verify PowerCap config
+	assert.True(t, config.PowerCap.Enabled)
+	assert.Equal(t, int32(60), config.PowerCap.LimitPercent)
+	assert.Equal(t, 15*time.Minute, config.PowerCap.SettleTime)
+	assert.Equal(t, 2*time.Hour, config.PowerCap.HoldDuration)
+	assert.Equal(t, "nvsentinel", config.PowerCap.JobNamespace)
+	assert.Equal(t, "ghcr.io/nvidia/nvsentinel/janitor:v1", config.PowerCap.JobImage)
+
 	// Verify that node exclusions are propagated to controller configs
 	assert.Equal(t, config.Global.Nodes.Exclusions, config.RebootNode.NodeExclusions)
 	assert.Equal(t, config.Global.Nodes.Exclusions, config.TerminateNode.NodeExclusions)
+	assert.Equal(t, config.Global.Nodes.Exclusions, config.DriverReload.NodeExclusions)
+	assert.Equal(t, config.Global.Nodes.Exclusions, config.MIGReconfiguration.NodeExclusions)
+	assert.Equal(t, config.Global.Nodes.Exclusions, config.GPUReset.NodeExclusions)
+	assert.Equal(t, config.Global.Nodes.Exclusions, config.PowerCap.NodeExclusions)
 }
 
 func TestLoadConfig_InvalidYAML(t *testing.T) {
@@ -0,0 +1,225 @@
+// Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controller
+
+import (
+	"context"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	janitordgxcnvidiacomv1alpha1 "github.com/nvidia/nvsentinel/janitor/api/v1alpha1"
+	"github.com/nvidia/nvsentinel/janitor/pkg/config"
+	"github.com/nvidia/nvsentinel/janitor/pkg/csp/chaos"
+)
+
+// These tests drive the reconcilers through their escalation ladder with
+// faults injected into the CSP client: retries with backoff on timeouts,
+// recovery once the fault clears, and terminal failure otherwise.
+var _ = Describe("Remediation under injected CSP faults", func() {
+	var (
+		ctx       context.Context
+		k8sClient client.Client
+		scheme    *runtime.Scheme
+		req       reconcile.Request
+	)
+
+	newNode := func() *corev1.Node {
+		return &corev1.Node{
+			ObjectMeta: metav1.ObjectMeta{Name: "chaos-node"},
+			Status: corev1.NodeStatus{
+				Conditions: []corev1.NodeCondition{
+					{Type: corev1.NodeReady, Status: corev1.ConditionTrue},
+				},
+			},
+		}
+	}
+
+	BeforeEach(func() {
+		ctx = context.Background()
+
+		scheme = runtime.NewScheme()
+		Expect(corev1.AddToScheme(scheme)).To(Succeed())
+		Expect(janitordgxcnvidiacomv1alpha1.AddToScheme(scheme)).To(Succeed())
+
+		req = reconcile.Request{NamespacedName: types.NamespacedName{Name: "chaos-action"}}
+	})
+
+	Context("RebootNode", func() {
+		var rebootNode *janitordgxcnvidiacomv1alpha1.RebootNode
+
+		newReconciler := func(faults map[chaos.Operation]chaos.Fault, timeout time.Duration) *RebootNodeReconciler {
+			rebootNode = &janitordgxcnvidiacomv1alpha1.RebootNode{
+				ObjectMeta: metav1.ObjectMeta{Name: req.Name},
+				Spec:       janitordgxcnvidiacomv1alpha1.RebootNodeSpec{NodeName: "chaos-node"},
+			}
+
+			k8sClient = fake.NewClientBuilder().
+				WithScheme(scheme).
+				WithObjects(newNode(), rebootNode).
+				WithStatusSubresource(&janitordgxcnvidiacomv1alpha1.RebootNode{}).
+				Build()
+
+			return &RebootNodeReconciler{
+				Client: k8sClient,
+				Scheme: scheme,
+				CSPClient: chaos.NewClient(&mockCSPClient{
+					sendRebootSignalResult: "chaos-ref",
+					isNodeReadyResult:      true,
+				}, faults),
+				Config: &config.RebootNodeControllerConfig{Timeout: timeout},
+			}
+		}
+
+		get := func() *janitordgxcnvidiacomv1alpha1.RebootNode {
+			var updated janitordgxcnvidiacomv1alpha1.RebootNode
+			Expect(k8sClient.Get(ctx, req.NamespacedName, &updated)).To(Succeed())
+			checkStatusConditions(updated.Status.Conditions)
+
+			return &updated
+		}
+
+		It("backs off on BMC timeouts and recovers once the BMC answers", func() {
+			r := newReconciler(map[chaos.Operation]chaos.Fault{
+				chaos.OpReboot: {Mode: chaos.ModeTimeout, Count: 2},
+			}, 30*time.Minute)
+
+			result, err := r.Reconcile(ctx, req)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result.RequeueAfter).To(Equal(getNextRequeueDelay(1)))
+			Expect(get().Status.ConsecutiveFailures).To(Equal(int32(1)))
+
+			result, err = r.Reconcile(ctx, req)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result.RequeueAfter).To(Equal(getNextRequeueDelay(2)))
+			Expect(get().Status.CompletionTime).To(BeNil())
+
+			result, err = r.Reconcile(ctx, req)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result.RequeueAfter).To(Equal(30 * time.Second))
+
+			updated := get()
+			Expect(updated.Status.ConsecutiveFailures).To(BeZero())
+			Expect(updated.IsRebootInProgress()).To(BeTrue())
+
+			_, err = r.Reconcile(ctx, req)
+			Expect(err).NotTo(HaveOccurred())
+
+			updated = get()
+			Expect(updated.Status.CompletionTime).NotTo(BeNil())
+			Expect(findCondition(updated.Status.Conditions,
+				janitordgxcnvidiacomv1alpha1.RebootNodeConditionNodeReady).Reason).To(Equal("Succeeded"))
+		})
+
+		It("fails the action when the CSP rejects the reboot signal", func() {
+			r := newReconciler(map[chaos.Operation]chaos.Fault{
+				chaos.OpReboot: {Mode: chaos.ModeError},
+			}, 30*time.Minute)
+
+			result, err := r.Reconcile(ctx, req)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result.RequeueAfter).To(BeZero())
+
+			updated := get()
+			Expect(updated.Status.CompletionTime).NotTo(BeNil())
+			Expect(findCondition(updated.Status.Conditions,
+				janitordgxcnvidiacomv1alpha1.RebootNodeConditionSignalSent).Reason).To(Equal("Failed"))
+		})
+
+		It("times out when a partial reset leaves the node wedged", func() {
+			r := newReconciler(map[chaos.Operation]chaos.Fault{
+				chaos.OpReady: {Mode: chaos.ModePartial},
+			}, time.Millisecond)
+
+			_, err := r.Reconcile(ctx, req)
+			Expect(err).NotTo(HaveOccurred())
+
+			time.Sleep(5 * time.Millisecond)
+
+			result, err := r.Reconcile(ctx, req)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result.RequeueAfter).To(BeZero())
+
+			updated := get()
+			Expect(updated.Status.CompletionTime).NotTo(BeNil())
+			Expect(findCondition(updated.Status.Conditions,
+				janitordgxcnvidiacomv1alpha1.RebootNodeConditionNodeReady).Reason).To(Equal("Timeout"))
+		})
+
+		It("keeps polling through readiness check timeouts", func() {
+			r := newReconciler(map[chaos.Operation]chaos.Fault{
+				chaos.OpReady: {Mode: chaos.ModeTimeout, Count: 1},
+			}, 30*time.Minute)
+
+			_, err := r.Reconcile(ctx, req)
+			Expect(err).NotTo(HaveOccurred())
+
+			result, err := r.Reconcile(ctx, req)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result.RequeueAfter).To(Equal(getNextRequeueDelay(1)))
+			Expect(get().Status.CompletionTime).To(BeNil())
+
+			_, err = r.Reconcile(ctx, req)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(findCondition(get().Status.Conditions,
+				janitordgxcnvidiacomv1alpha1.RebootNodeConditionNodeReady).Reason).To(Equal("Succeeded"))
+		})
+	})
+
+	Context("TerminateNode", func() {
+		newReconciler := func(faults map[chaos.Operation]chaos.Fault) *TerminateNodeReconciler {
+			terminateNode := &janitordgxcnvidiacomv1alpha1.TerminateNode{
+				ObjectMeta: metav1.ObjectMeta{Name: req.Name},
+				Spec:       janitordgxcnvidiacomv1alpha1.TerminateNodeSpec{NodeName: "chaos-node"},
+			}
+
+			k8sClient = fake.NewClientBuilder().
+				WithScheme(scheme).
+				WithObjects(newNode(), terminateNode).
+				WithStatusSubresource(&janitordgxcnvidiacomv1alpha1.TerminateNode{}).
+				Build()
+
+			return &TerminateNodeReconciler{
+				Client:    k8sClient,
+				Scheme:    scheme,
+				CSPClient: chaos.NewClient(&MockCSPClient{}, faults),
+				Config:    &config.TerminateNodeControllerConfig{Timeout: 30 * time.Minute},
+			}
+		}
+
+		It("retries terminate signal timeouts with backoff", func() {
+			r := newReconciler(map[chaos.Operation]chaos.Fault{
+				chaos.OpTerminate: {Mode: chaos.ModeTimeout, Count: 1},
+			})
+
+			result, err := r.Reconcile(ctx, req)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result.RequeueAfter).To(Equal(getNextRequeueDelay(1)))
+
+			var updated janitordgxcnvidiacomv1alpha1.TerminateNode
+			Expect(k8sClient.Get(ctx, req.NamespacedName, &updated)).To(Succeed())
+			Expect(updated.Status.CompletionTime).To(BeNil())
+			Expect(updated.Status.ConsecutiveFailures).To(Equal(int32(1)))
+		})
+	})
+})
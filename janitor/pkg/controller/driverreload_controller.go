@@ -0,0 +1,501 @@
+// Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// nolint:wsl,lll,gocognit,cyclop,gocyclo,nestif // Mirrors the RebootNode controller
+package controller
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	"github.com/nvidia/nvsentinel/commons/pkg/nodelock"
+	janitordgxcnvidiacomv1alpha1 "github.com/nvidia/nvsentinel/janitor/api/v1alpha1"
+	"github.com/nvidia/nvsentinel/janitor/pkg/config"
+	"github.com/nvidia/nvsentinel/janitor/pkg/metrics"
+)
+
+const (
+	// DriverReloadFinalizer is added to DriverReload objects to handle cleanup
+	DriverReloadFinalizer = "janitor.dgxc.nvidia.com/driverreload-finalizer"
+
+	// GPUOperatorUpgradeRequestedAnnotation asks the GPU Operator to run a node through its
+	// driver upgrade state machine, which cordons the node, waits for GPU workloads, restarts
+	// the driver pod and validates the driver, even if the driver version is unchanged
+	GPUOperatorUpgradeRequestedAnnotation = "nvidia.com/gpu-driver-upgrade-requested"
+	// GPUOperatorUpgradeStateLabel holds the state of a node in the GPU Operator driver
+	// upgrade state machine
+	GPUOperatorUpgradeStateLabel = "nvidia.com/gpu-driver-upgrade-state"
+
+	// MaxDriverReloadRequestFailures is the number of failed restart requests before giving up
+	MaxDriverReloadRequestFailures = 5
+
+	gpuOperatorUpgradeStateDone   = "upgrade-done"
+	gpuOperatorUpgradeStateFailed = "upgrade-failed"
+
+	defaultGPUOperatorNamespace = "gpu-operator"
+	defaultValidatorSelector    = "app=nvidia-operator-validator"
+
+	// driverReloadOutsideActorReason marks an UpgradeRequested condition observed on the
+	// node in manual mode rather than requested by the janitor
+	driverReloadOutsideActorReason = "OutsideActor"
+)
+
+// updateDriverReloadStatus is a helper function that handles status updates with proper error handling.
+// It delegates to the generic updateNodeActionStatus function.
+func (r *DriverReloadReconciler) updateDriverReloadStatus(
+	ctx context.Context,
+	original *janitordgxcnvidiacomv1alpha1.DriverReload,
+	updated *janitordgxcnvidiacomv1alpha1.DriverReload,
+	result ctrl.Result,
+) (ctrl.Result, error) {
+	return updateNodeActionStatus(
+		ctx,
+		r.Status(),
+		original,
+		updated,
+		&original.Status,
+		&updated.Status,
+		updated.Spec.NodeName,
+		"driverreload",
+		result,
+	)
+}
+
+// DriverReloadReconciler reconciles a DriverReload object. Instead of unloading and loading
+// the kernel modules on the node, the reload is delegated to the GPU Operator: the node is
+// annotated to request a driver restart through the operator's upgrade state machine, and
+// the operator validator pods becoming ready again on the node are the recovery signal.
+// This requires driver.upgradePolicy.autoUpgrade to be enabled in the ClusterPolicy.
+type DriverReloadReconciler struct {
+	client.Client
+	Scheme *runtime.Scheme
+	Config *config.DriverReloadControllerConfig
+	// APIReader reads the validator pods without caching all pods of the cluster,
+	// defaults to the client
+	APIReader client.Reader
+	// NodeLock serializes destructive actions on a node, nil disables locking
+	NodeLock *nodelock.Locker
+}
+
+// +kubebuilder:rbac:groups=janitor.dgxc.nvidia.com,resources=driverreloads,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=janitor.dgxc.nvidia.com,resources=driverreloads/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=janitor.dgxc.nvidia.com,resources=driverreloads/finalizers,verbs=update
+// +kubebuilder:rbac:groups=core,resources=nodes,verbs=get;list;watch;patch
+// +kubebuilder:rbac:groups=core,resources=pods,verbs=get;list
+
+// Reconcile is part of the main kubernetes reconciliation loop which aims to
+// move the current state of the cluster closer to the desired state.
+func (r *DriverReloadReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	logger := log.FromContext(ctx)
+
+	var driverReload janitordgxcnvidiacomv1alpha1.DriverReload
+	if err := r.Get(ctx, req.NamespacedName, &driverReload); err != nil {
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+
+	// Handle deletion with finalizer
+	if !driverReload.DeletionTimestamp.IsZero() {
+		if controllerutil.ContainsFinalizer(&driverReload, DriverReloadFinalizer) {
+			logger.Info("driverreload deletion requested, performing cleanup",
+				"node", driverReload.Spec.NodeName,
+				"conditions", driverReload.Status.Conditions)
+
+			releaseNodeLock(ctx, r.NodeLock, driverReload.Spec.NodeName, lockHolder("driverreload", driverReload.Name))
+
+			controllerutil.RemoveFinalizer(&driverReload, DriverReloadFinalizer)
+
+			if err := r.Update(ctx, &driverReload); err != nil {
+				return ctrl.Result{}, err
+			}
+		}
+
+		return ctrl.Result{}, nil
+	}
+
+	// Add finalizer if not present
+	if !controllerutil.ContainsFinalizer(&driverReload, DriverReloadFinalizer) {
+		controllerutil.AddFinalizer(&driverReload, DriverReloadFinalizer)
+
+		if err := r.Update(ctx, &driverReload); err != nil {
+			return ctrl.Result{}, err
+		}
+	}
+
+	if driverReload.Status.CompletionTime != nil {
+		logger.V(1).Info("driverreload has completion time set, skipping reconcile",
+			"node", driverReload.Spec.NodeName)
+
+		releaseNodeLock(ctx, r.NodeLock, driverReload.Spec.NodeName, lockHolder("driverreload", driverReload.Name))
+
+		return ctrl.Result{}, nil
+	}
+
+	// Take a deep copy to compare against at the end
+	originalDriverReload := driverReload.DeepCopy()
+
+	driverReload.SetInitialConditions()
+	driverReload.SetStartTime()
+
+	if driverReload.Status.Phase == "" {
+		setPhase(ctx, &driverReload.Status.Phase, janitordgxcnvidiacomv1alpha1.ActionPhaseRequested, driverReload.Spec.NodeName)
+	}
+
+	// Operators can cancel or force fail a stuck action through annotations
+	if reason, message, ok := operatorOverride(&driverReload); ok {
+		logger.Info("driverreload ended by operator",
+			"node", driverReload.Spec.NodeName,
+			"reason", reason,
+			"message", message)
+
+		failForHumanAttention(ctx, &driverReload, &driverReload.Status.Phase, driverReload.Spec.NodeName,
+			janitordgxcnvidiacomv1alpha1.DriverReloadConditionDriverReady, reason, message)
+		metrics.GlobalMetrics.IncActionCount(metrics.ActionTypeDriverReload, metrics.StatusFailed, driverReload.Spec.NodeName)
+
+		return r.updateDriverReloadStatus(ctx, originalDriverReload, &driverReload, ctrl.Result{})
+	}
+
+	if approvalTimedOut(driverReload.Status.Phase, driverReload.Status.StartTime, r.Config.ApprovalTimeout) {
+		logger.Info("no outside actor requested the driver restart within the approval timeout",
+			"node", driverReload.Spec.NodeName,
+			"approvalTimeout", r.Config.ApprovalTimeout)
+
+		failForHumanAttention(ctx, &driverReload, &driverReload.Status.Phase, driverReload.Spec.NodeName,
+			janitordgxcnvidiacomv1alpha1.DriverReloadConditionDriverReady, approvalTimeoutReason,
+			fmt.Sprintf("Driver reload was not performed within the approval timeout of %s", r.Config.ApprovalTimeout))
+		metrics.GlobalMetrics.IncActionCount(metrics.ActionTypeDriverReload, metrics.StatusFailed, driverReload.Spec.NodeName)
+
+		return r.updateDriverReloadStatus(ctx, originalDriverReload, &driverReload, ctrl.Result{})
+	}
+
+	var node corev1.Node
+	if err := r.Get(ctx, client.ObjectKey{Name: driverReload.Spec.NodeName}, &node); err != nil {
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+
+	var result ctrl.Result
+
+	if driverReload.GetUpgradeRequestTime() != nil {
+		result = r.verifyDriverReload(ctx, &driverReload, &node)
+	} else if r.Config.ManualMode && !approved(&driverReload) {
+		result = r.awaitOutsideActor(ctx, &driverReload, &node)
+	} else {
+		result = r.requestDriverRestart(ctx, &driverReload, &node)
+	}
+
+	return r.updateDriverReloadStatus(ctx, originalDriverReload, &driverReload, result)
+}
+
+// requestDriverRestart annotates the node to request a driver restart from the GPU Operator
+func (r *DriverReloadReconciler) requestDriverRestart(
+	ctx context.Context,
+	driverReload *janitordgxcnvidiacomv1alpha1.DriverReload,
+	node *corev1.Node,
+) ctrl.Result {
+	logger := log.FromContext(ctx)
+
+	if driverReload.Status.ConsecutiveFailures >= MaxDriverReloadRequestFailures {
+		logger.Info("max driver restart request failures exceeded, marking as failed",
+			"node", node.Name,
+			"failures", int(driverReload.Status.ConsecutiveFailures))
+
+		failForHumanAttention(ctx, driverReload, &driverReload.Status.Phase, node.Name,
+			janitordgxcnvidiacomv1alpha1.DriverReloadConditionDriverReady, "MaxRetriesExceeded",
+			fmt.Sprintf("Driver restart could not be requested from the GPU Operator after %d attempts",
+				MaxDriverReloadRequestFailures))
+		metrics.GlobalMetrics.IncActionCount(metrics.ActionTypeDriverReload, metrics.StatusFailed, node.Name)
+
+		return ctrl.Result{}
+	}
+
+	locked, err := acquireNodeLock(ctx, r.NodeLock, metrics.ActionTypeDriverReload, node.Name,
+		lockHolder("driverreload", driverReload.Name), &driverReload.Status.LockToken)
+	if err != nil {
+		logger.Error(err, "failed to lock node, will retry",
+			"node", node.Name)
+
+		return ctrl.Result{RequeueAfter: nodeLockRetryDelay}
+	}
+
+	if !locked {
+		return ctrl.Result{RequeueAfter: nodeLockRetryDelay}
+	}
+
+	setPhase(ctx, &driverReload.Status.Phase, janitordgxcnvidiacomv1alpha1.ActionPhaseApproved, node.Name)
+	setPhase(ctx, &driverReload.Status.Phase, janitordgxcnvidiacomv1alpha1.ActionPhaseExecuting, node.Name)
+
+	if driverReload.Status.ConsecutiveFailures == 0 {
+		metrics.GlobalMetrics.IncActionCount(metrics.ActionTypeDriverReload, metrics.StatusStarted, node.Name)
+	}
+
+	logger.Info("requesting driver restart from the GPU Operator",
+		"node", node.Name)
+
+	// The annotation is idempotent, so a restart during the request is safe to resume
+	// by sending it again
+	patch := client.MergeFrom(node.DeepCopy())
+	if node.Annotations == nil {
+		node.Annotations = map[string]string{}
+	}
+
+	node.Annotations[GPUOperatorUpgradeRequestedAnnotation] = "true"
+
+	if err := r.Patch(ctx, node, patch); err != nil {
+		logger.Error(err, "failed to request driver restart, will retry",
+			"node", node.Name)
+
+		driverReload.Status.ConsecutiveFailures++
+		driverReload.SetCondition(metav1.Condition{
+			Type:               janitordgxcnvidiacomv1alpha1.DriverReloadConditionUpgradeRequested,
+			Status:             metav1.ConditionFalse,
+			Reason:             "Failed",
+			Message:            err.Error(),
+			LastTransitionTime: metav1.Now(),
+		})
+		setPhase(ctx, &driverReload.Status.Phase, janitordgxcnvidiacomv1alpha1.ActionPhaseApproved, node.Name)
+
+		return ctrl.Result{RequeueAfter: getNextRequeueDelay(driverReload.Status.ConsecutiveFailures)}
+	}
+
+	driverReload.Status.ConsecutiveFailures = 0
+	driverReload.SetCondition(metav1.Condition{
+		Type:               janitordgxcnvidiacomv1alpha1.DriverReloadConditionUpgradeRequested,
+		Status:             metav1.ConditionTrue,
+		Reason:             "Succeeded",
+		Message:            fmt.Sprintf("Node annotated with %s", GPUOperatorUpgradeRequestedAnnotation),
+		LastTransitionTime: metav1.Now(),
+	})
+	setPhase(ctx, &driverReload.Status.Phase, janitordgxcnvidiacomv1alpha1.ActionPhaseVerifying, node.Name)
+
+	return ctrl.Result{RequeueAfter: 30 * time.Second}
+}
+
+// awaitOutsideActor waits in manual mode until an outside actor requested the driver restart
+// from the GPU Operator, which shows on the node as the request annotation or an upgrade in progress
+func (r *DriverReloadReconciler) awaitOutsideActor(
+	ctx context.Context,
+	driverReload *janitordgxcnvidiacomv1alpha1.DriverReload,
+	node *corev1.Node,
+) ctrl.Result {
+	logger := log.FromContext(ctx)
+
+	if isDriverUpgradeInProgress(node) {
+		logger.Info("driver restart requested by outside actor",
+			"node", node.Name,
+			"upgradeState", node.Labels[GPUOperatorUpgradeStateLabel])
+
+		driverReload.SetCondition(metav1.Condition{
+			Type:               janitordgxcnvidiacomv1alpha1.DriverReloadConditionUpgradeRequested,
+			Status:             metav1.ConditionTrue,
+			Reason:             driverReloadOutsideActorReason,
+			Message:            "Driver restart requested from the GPU Operator by an outside actor",
+			LastTransitionTime: metav1.Now(),
+		})
+		setPhase(ctx, &driverReload.Status.Phase, janitordgxcnvidiacomv1alpha1.ActionPhaseVerifying, node.Name)
+
+		return ctrl.Result{RequeueAfter: 30 * time.Second}
+	}
+
+	isManualModeConditionSet := false
+
+	for _, condition := range driverReload.Status.Conditions {
+		if condition.Type == janitordgxcnvidiacomv1alpha1.ManualModeConditionType {
+			isManualModeConditionSet = true
+			break
+		}
+	}
+
+	if !isManualModeConditionSet {
+		driverReload.SetCondition(metav1.Condition{
+			Type:               janitordgxcnvidiacomv1alpha1.ManualModeConditionType,
+			Status:             metav1.ConditionTrue,
+			Reason:             "OutsideActorRequired",
+			Message:            "Janitor is in manual mode, outside actor required to request the driver restart",
+			LastTransitionTime: metav1.Now(),
+		})
+		metrics.GlobalMetrics.IncActionCount(metrics.ActionTypeDriverReload, metrics.StatusStarted, node.Name)
+	}
+
+	logger.Info("manual mode enabled, janitor will not request the driver restart",
+		"node", node.Name)
+
+	// Poll for the outside actor, the node is not watched
+	return ctrl.Result{RequeueAfter: 30 * time.Second}
+}
+
+// verifyDriverReload consumes the GPU Operator upgrade state and validation results of the node
+func (r *DriverReloadReconciler) verifyDriverReload(
+	ctx context.Context,
+	driverReload *janitordgxcnvidiacomv1alpha1.DriverReload,
+	node *corev1.Node,
+) ctrl.Result {
+	logger := log.FromContext(ctx)
+
+	driverReload.Status.RetryCount++
+	setPhase(ctx, &driverReload.Status.Phase, janitordgxcnvidiacomv1alpha1.ActionPhaseVerifying, node.Name)
+
+	if node.Labels[GPUOperatorUpgradeStateLabel] == gpuOperatorUpgradeStateFailed {
+		logger.Info("GPU Operator reported the driver upgrade as failed",
+			"node", node.Name)
+
+		failForHumanAttention(ctx, driverReload, &driverReload.Status.Phase, node.Name,
+			janitordgxcnvidiacomv1alpha1.DriverReloadConditionDriverReady, "UpgradeFailed",
+			fmt.Sprintf("GPU Operator set %s=%s on the node", GPUOperatorUpgradeStateLabel, gpuOperatorUpgradeStateFailed))
+		metrics.GlobalMetrics.IncActionCount(metrics.ActionTypeDriverReload, metrics.StatusFailed, node.Name)
+
+		return ctrl.Result{}
+	}
+
+	validator, err := r.validatedSince(ctx, node.Name, driverReload.GetUpgradeRequestTime().Time)
+	if err != nil {
+		logger.Error(err, "failed to check GPU Operator validation results",
+			"node", node.Name)
+
+		driverReload.Status.ConsecutiveFailures++
+
+		return ctrl.Result{RequeueAfter: getNextRequeueDelay(driverReload.Status.ConsecutiveFailures)}
+	}
+
+	driverReload.Status.ConsecutiveFailures = 0
+
+	if validator != "" && !isDriverUpgradeInProgress(node) {
+		logger.Info("GPU Operator validated the reloaded driver",
+			"node", node.Name,
+			"validator", validator,
+			"duration", time.Since(driverReload.Status.StartTime.Time))
+
+		driverReload.SetCompletionTime()
+		driverReload.SetCondition(metav1.Condition{
+			Type:               janitordgxcnvidiacomv1alpha1.DriverReloadConditionDriverReady,
+			Status:             metav1.ConditionTrue,
+			Reason:             "Succeeded",
+			Message:            fmt.Sprintf("Driver validated by %s", validator),
+			LastTransitionTime: metav1.Now(),
+		})
+
+		metrics.GlobalMetrics.IncActionCount(metrics.ActionTypeDriverReload, metrics.StatusSucceeded, node.Name)
+		metrics.GlobalMetrics.RecordActionMTTR(metrics.ActionTypeDriverReload, time.Since(driverReload.Status.StartTime.Time))
+		setPhase(ctx, &driverReload.Status.Phase, janitordgxcnvidiacomv1alpha1.ActionPhaseDone, node.Name)
+
+		return ctrl.Result{}
+	}
+
+	if time.Since(driverReload.Status.StartTime.Time) > r.getTimeout() {
+		logger.Error(nil, "driver reload timed out",
+			"node", node.Name,
+			"timeout", r.getTimeout(),
+			"upgradeState", node.Labels[GPUOperatorUpgradeStateLabel])
+
+		failForHumanAttention(ctx, driverReload, &driverReload.Status.Phase, node.Name,
+			janitordgxcnvidiacomv1alpha1.DriverReloadConditionDriverReady, "Timeout",
+			"GPU Operator did not validate the driver within the timeout duration")
+		metrics.GlobalMetrics.IncActionCount(metrics.ActionTypeDriverReload, metrics.StatusFailed, node.Name)
+
+		return ctrl.Result{}
+	}
+
+	return ctrl.Result{RequeueAfter: 30 * time.Second}
+}
+
+// validatedSince returns the name of a GPU Operator validator pod on the node that became
+// ready after since, or an empty string if the driver has not been validated since then
+func (r *DriverReloadReconciler) validatedSince(ctx context.Context, nodeName string, since time.Time) (string, error) {
+	selector, err := labels.Parse(r.getValidatorSelector())
+	if err != nil {
+		return "", fmt.Errorf("invalid validator selector %q: %w", r.getValidatorSelector(), err)
+	}
+
+	reader := r.APIReader
+	if reader == nil {
+		reader = r.Client
+	}
+
+	var pods corev1.PodList
+	if err := reader.List(ctx, &pods,
+		client.InNamespace(r.getOperatorNamespace()),
+		client.MatchingLabelsSelector{Selector: selector}); err != nil {
+		return "", fmt.Errorf("failed to list validator pods: %w", err)
+	}
+
+	for _, pod := range pods.Items {
+		if pod.Spec.NodeName != nodeName || !pod.DeletionTimestamp.IsZero() {
+			continue
+		}
+
+		for _, condition := range pod.Status.Conditions {
+			if condition.Type == corev1.PodReady && condition.Status == corev1.ConditionTrue &&
+				!condition.LastTransitionTime.Time.Before(since) {
+				return pod.Name, nil
+			}
+		}
+	}
+
+	return "", nil
+}
+
+// isDriverUpgradeInProgress returns true if a driver restart was requested from the GPU
+// Operator and the operator has not finished it yet
+func isDriverUpgradeInProgress(node *corev1.Node) bool {
+	if node.Annotations[GPUOperatorUpgradeRequestedAnnotation] == "true" {
+		return true
+	}
+
+	state := node.Labels[GPUOperatorUpgradeStateLabel]
+
+	return state != "" && state != gpuOperatorUpgradeStateDone && state != gpuOperatorUpgradeStateFailed
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *DriverReloadReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&janitordgxcnvidiacomv1alpha1.DriverReload{}).
+		Named("driverreload").
+		Complete(r)
+}
+
+// getTimeout returns the timeout for driver reload operations
+func (r *DriverReloadReconciler) getTimeout() time.Duration {
+	if r.Config == nil || r.Config.Timeout == 0 {
+		return 30 * time.Minute // fallback default
+	}
+
+	return r.Config.Timeout
+}
+
+// getOperatorNamespace returns the namespace of the GPU Operator validator pods
+func (r *DriverReloadReconciler) getOperatorNamespace() string {
+	if r.Config == nil || r.Config.OperatorNamespace == "" {
+		return defaultGPUOperatorNamespace
+	}
+
+	return r.Config.OperatorNamespace
+}
+
+// getValidatorSelector returns the label selector of the GPU Operator validator pods
+func (r *DriverReloadReconciler) getValidatorSelector() string {
+	if r.Config == nil || r.Config.ValidatorSelector == "" {
+		return defaultValidatorSelector
+	}
+
+	return r.Config.ValidatorSelector
+}
@@ -0,0 +1,275 @@
+// Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/scheme"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	janitordgxcnvidiacomv1alpha1 "github.com/nvidia/nvsentinel/janitor/api/v1alpha1"
+	"github.com/nvidia/nvsentinel/janitor/pkg/config"
+)
+
+var _ = Describe("DriverReloadReconciler", func() {
+	var (
+		ctx          context.Context
+		node         *corev1.Node
+		reconciler   *DriverReloadReconciler
+		nodeName     string
+		crName       string
+		operatorNS   string
+		uniqueSuffix string
+	)
+
+	reconcile := func() ctrl.Result {
+		result, err := reconciler.Reconcile(ctx, ctrl.Request{
+			NamespacedName: types.NamespacedName{Name: crName},
+		})
+		Expect(err).NotTo(HaveOccurred())
+
+		return result
+	}
+
+	getDriverReload := func() *janitordgxcnvidiacomv1alpha1.DriverReload {
+		var driverReload janitordgxcnvidiacomv1alpha1.DriverReload
+		Expect(k8sClient.Get(ctx, types.NamespacedName{Name: crName}, &driverReload)).To(Succeed())
+
+		return &driverReload
+	}
+
+	getNode := func() *corev1.Node {
+		var updatedNode corev1.Node
+		Expect(k8sClient.Get(ctx, types.NamespacedName{Name: nodeName}, &updatedNode)).To(Succeed())
+
+		return &updatedNode
+	}
+
+	// simulateOperator moves the node through the GPU Operator upgrade state machine
+	simulateOperator := func(state string) {
+		updatedNode := getNode()
+		patch := client.MergeFrom(updatedNode.DeepCopy())
+
+		delete(updatedNode.Annotations, GPUOperatorUpgradeRequestedAnnotation)
+
+		if updatedNode.Labels == nil {
+			updatedNode.Labels = map[string]string{}
+		}
+
+		updatedNode.Labels[GPUOperatorUpgradeStateLabel] = state
+		Expect(k8sClient.Patch(ctx, updatedNode, patch)).To(Succeed())
+	}
+
+	// createValidatorPod creates a GPU Operator validator pod on the node that became ready at readyAt
+	createValidatorPod := func(readyAt time.Time) {
+		pod := &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "nvidia-operator-validator-" + uniqueSuffix,
+				Namespace: operatorNS,
+				Labels:    map[string]string{"app": "nvidia-operator-validator"},
+			},
+			Spec: corev1.PodSpec{
+				NodeName:   nodeName,
+				Containers: []corev1.Container{{Name: "validator", Image: "nvcr.io/nvidia/gpu-operator:v25.3.0"}},
+			},
+		}
+		Expect(k8sClient.Create(ctx, pod)).To(Succeed())
+
+		pod.Status.Conditions = []corev1.PodCondition{{
+			Type:               corev1.PodReady,
+			Status:             corev1.ConditionTrue,
+			LastTransitionTime: metav1.NewTime(readyAt),
+		}}
+		Expect(k8sClient.Status().Update(ctx, pod)).To(Succeed())
+	}
+
+	BeforeEach(func() {
+		ctx = context.Background()
+
+		uniqueSuffix = fmt.Sprintf("%d", time.Now().UnixNano())
+		nodeName = "test-node-" + uniqueSuffix
+		crName = "test-driver-reload-" + uniqueSuffix
+		operatorNS = "gpu-operator-" + uniqueSuffix
+
+		Expect(k8sClient.Create(ctx, &corev1.Namespace{
+			ObjectMeta: metav1.ObjectMeta{Name: operatorNS},
+		})).To(Succeed())
+
+		node = &corev1.Node{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: nodeName,
+			},
+		}
+		Expect(k8sClient.Create(ctx, node)).To(Succeed())
+
+		Expect(k8sClient.Create(ctx, &janitordgxcnvidiacomv1alpha1.DriverReload{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: crName,
+			},
+			Spec: janitordgxcnvidiacomv1alpha1.DriverReloadSpec{
+				NodeName: nodeName,
+			},
+		})).To(Succeed())
+
+		reconciler = &DriverReloadReconciler{
+			Client: k8sClient,
+			Scheme: scheme.Scheme,
+			Config: &config.DriverReloadControllerConfig{
+				OperatorNamespace: operatorNS,
+			},
+		}
+	})
+
+	AfterEach(func() {
+		checkStatusConditions(getDriverReload().Status.Conditions)
+	})
+
+	Context("When requesting the driver restart", func() {
+		It("Should annotate the node for the GPU Operator", func() {
+			result := reconcile()
+			Expect(result.RequeueAfter).To(BeNumerically(">", 0))
+
+			Expect(getNode().Annotations).To(HaveKeyWithValue(GPUOperatorUpgradeRequestedAnnotation, "true"))
+
+			driverReload := getDriverReload()
+			Expect(driverReload.Status.Phase).To(Equal(janitordgxcnvidiacomv1alpha1.ActionPhaseVerifying))
+
+			condition := meta.FindStatusCondition(driverReload.Status.Conditions,
+				janitordgxcnvidiacomv1alpha1.DriverReloadConditionUpgradeRequested)
+			Expect(condition).NotTo(BeNil())
+			Expect(condition.Status).To(Equal(metav1.ConditionTrue))
+		})
+	})
+
+	Context("When the GPU Operator validated the driver", func() {
+		It("Should complete the driver reload", func() {
+			reconcile()
+
+			// Validator pods that were ready before the request do not count
+			createValidatorPod(time.Now().Add(-time.Hour))
+			simulateOperator("upgrade-done")
+			reconcile()
+			Expect(getDriverReload().Status.CompletionTime).To(BeNil())
+
+			var pod corev1.Pod
+			Expect(k8sClient.Get(ctx, types.NamespacedName{
+				Namespace: operatorNS,
+				Name:      "nvidia-operator-validator-" + uniqueSuffix,
+			}, &pod)).To(Succeed())
+			pod.Status.Conditions[0].LastTransitionTime = metav1.NewTime(time.Now().Add(time.Minute))
+			Expect(k8sClient.Status().Update(ctx, &pod)).To(Succeed())
+
+			reconcile()
+
+			driverReload := getDriverReload()
+			Expect(driverReload.Status.CompletionTime).NotTo(BeNil())
+			Expect(driverReload.Status.Phase).To(Equal(janitordgxcnvidiacomv1alpha1.ActionPhaseDone))
+
+			condition := meta.FindStatusCondition(driverReload.Status.Conditions,
+				janitordgxcnvidiacomv1alpha1.DriverReloadConditionDriverReady)
+			Expect(condition).NotTo(BeNil())
+			Expect(condition.Status).To(Equal(metav1.ConditionTrue))
+		})
+
+		It("Should wait while the upgrade is still in progress", func() {
+			reconcile()
+
+			createValidatorPod(time.Now().Add(time.Minute))
+			simulateOperator("pod-restart-required")
+			reconcile()
+
+			Expect(getDriverReload().Status.CompletionTime).To(BeNil())
+		})
+	})
+
+	Context("When the GPU Operator failed the upgrade", func() {
+		It("Should fail the driver reload", func() {
+			reconcile()
+
+			simulateOperator("upgrade-failed")
+			reconcile()
+
+			driverReload := getDriverReload()
+			Expect(driverReload.Status.CompletionTime).NotTo(BeNil())
+			Expect(driverReload.Status.Phase).To(Equal(janitordgxcnvidiacomv1alpha1.ActionPhaseFailed))
+
+			condition := meta.FindStatusCondition(driverReload.Status.Conditions,
+				janitordgxcnvidiacomv1alpha1.DriverReloadConditionDriverReady)
+			Expect(condition).NotTo(BeNil())
+			Expect(condition.Reason).To(Equal("UpgradeFailed"))
+			Expect(meta.IsStatusConditionTrue(driverReload.Status.Conditions,
+				janitordgxcnvidiacomv1alpha1.NeedsHumanAttentionConditionType)).To(BeTrue())
+		})
+	})
+
+	Context("When the driver reload times out", func() {
+		It("Should fail the driver reload", func() {
+			reconciler.Config.Timeout = time.Second
+
+			reconcile()
+			time.Sleep(reconciler.Config.Timeout + time.Second)
+			reconcile()
+
+			condition := meta.FindStatusCondition(getDriverReload().Status.Conditions,
+				janitordgxcnvidiacomv1alpha1.DriverReloadConditionDriverReady)
+			Expect(condition).NotTo(BeNil())
+			Expect(condition.Status).To(Equal(metav1.ConditionFalse))
+			Expect(condition.Reason).To(Equal("Timeout"))
+		})
+	})
+
+	Context("When manual mode is enabled", func() {
+		BeforeEach(func() {
+			reconciler.Config.ManualMode = true
+		})
+
+		It("Should not annotate the node", func() {
+			reconcile()
+			reconcile()
+
+			Expect(getNode().Annotations).NotTo(HaveKey(GPUOperatorUpgradeRequestedAnnotation))
+
+			driverReload := getDriverReload()
+			Expect(driverReload.Status.Phase).To(Equal(janitordgxcnvidiacomv1alpha1.ActionPhaseRequested))
+			Expect(meta.IsStatusConditionTrue(driverReload.Status.Conditions,
+				janitordgxcnvidiacomv1alpha1.ManualModeConditionType)).To(BeTrue())
+		})
+
+		It("Should verify a driver restart requested by an outside actor", func() {
+			reconcile()
+
+			simulateOperator("cordon-required")
+			reconcile()
+
+			driverReload := getDriverReload()
+			Expect(driverReload.Status.Phase).To(Equal(janitordgxcnvidiacomv1alpha1.ActionPhaseVerifying))
+
+			condition := meta.FindStatusCondition(driverReload.Status.Conditions,
+				janitordgxcnvidiacomv1alpha1.DriverReloadConditionUpgradeRequested)
+			Expect(condition).NotTo(BeNil())
+			Expect(condition.Reason).To(Equal(driverReloadOutsideActorReason))
+		})
+	})
+})
@@ -0,0 +1,551 @@
+// Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// nolint:wsl,lll,gocognit,cyclop,gocyclo,nestif // Mirrors the MIGReconfiguration controller
+package controller
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	"github.com/nvidia/nvsentinel/commons/pkg/nodelock"
+	janitordgxcnvidiacomv1alpha1 "github.com/nvidia/nvsentinel/janitor/api/v1alpha1"
+	"github.com/nvidia/nvsentinel/janitor/pkg/config"
+	"github.com/nvidia/nvsentinel/janitor/pkg/metrics"
+)
+
+const (
+	// GPUResetFinalizer is added to GPUReset objects to handle cleanup
+	GPUResetFinalizer = "janitor.dgxc.nvidia.com/gpureset-finalizer"
+
+	// MaxGPUResetRequestFailures is the number of failed reset Job creations before giving up
+	MaxGPUResetRequestFailures = 5
+
+	// gpuResetJobGracePeriod is the time the reset Job gets on top of the timeout of
+	// waiting for the pods to exit, for the reset itself
+	gpuResetJobGracePeriod = 5 * time.Minute
+
+	gpuResetJobPrefix           = "gpu-reset-"
+	gpuResetHostRoot            = "/host"
+	gpuResetPodResourcesDir     = "/var/lib/kubelet/pod-resources"
+	gpuResetConditionInProgress = "InProgress"
+)
+
+// updateGPUResetStatus is a helper function that handles status updates with proper error handling.
+// It delegates to the generic updateNodeActionStatus function.
+func (r *GPUResetReconciler) updateGPUResetStatus(
+	ctx context.Context,
+	original *janitordgxcnvidiacomv1alpha1.GPUReset,
+	updated *janitordgxcnvidiacomv1alpha1.GPUReset,
+	result ctrl.Result,
+) (ctrl.Result, error) {
+	return updateNodeActionStatus(
+		ctx,
+		r.Status(),
+		original,
+		updated,
+		&original.Status,
+		&updated.Status,
+		updated.Spec.NodeName,
+		"gpureset",
+		result,
+	)
+}
+
+// GPUResetReconciler reconciles a GPUReset object. The GPUs are reset by a privileged
+// Job on the node running `janitor gpu-reset`: instead of draining the node, it evicts
+// only the pods the kubelet allocated the selected GPUs or their MIG instances to, waits
+// until no process runs on the GPUs anymore and resets them with nvidia-smi. The other
+// pods of the node keep running and the node is not cordoned.
+type GPUResetReconciler struct {
+	client.Client
+	Scheme *runtime.Scheme
+	Config *config.GPUResetControllerConfig
+	// APIReader reads the reset Jobs and their pods without caching all Jobs and pods
+	// of the cluster, defaults to the client
+	APIReader client.Reader
+	// NodeLock serializes destructive actions on a node, nil disables locking
+	NodeLock *nodelock.Locker
+}
+
+// +kubebuilder:rbac:groups=janitor.dgxc.nvidia.com,resources=gpuresets,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=janitor.dgxc.nvidia.com,resources=gpuresets/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=janitor.dgxc.nvidia.com,resources=gpuresets/finalizers,verbs=update
+// +kubebuilder:rbac:groups=batch,resources=jobs,verbs=get;list;create;delete
+// +kubebuilder:rbac:groups=core,resources=nodes,verbs=get;list;watch
+// +kubebuilder:rbac:groups=core,resources=pods,verbs=get;list
+
+// Reconcile is part of the main kubernetes reconciliation loop which aims to
+// move the current state of the cluster closer to the desired state.
+func (r *GPUResetReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	logger := log.FromContext(ctx)
+
+	var gpuReset janitordgxcnvidiacomv1alpha1.GPUReset
+	if err := r.Get(ctx, req.NamespacedName, &gpuReset); err != nil {
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+
+	// Handle deletion with finalizer
+	if !gpuReset.DeletionTimestamp.IsZero() {
+		if controllerutil.ContainsFinalizer(&gpuReset, GPUResetFinalizer) {
+			logger.Info("gpureset deletion requested, performing cleanup",
+				"node", gpuReset.Spec.NodeName,
+				"conditions", gpuReset.Status.Conditions)
+
+			releaseNodeLock(ctx, r.NodeLock, gpuReset.Spec.NodeName, lockHolder("gpureset", gpuReset.Name))
+
+			controllerutil.RemoveFinalizer(&gpuReset, GPUResetFinalizer)
+
+			if err := r.Update(ctx, &gpuReset); err != nil {
+				return ctrl.Result{}, err
+			}
+		}
+
+		return ctrl.Result{}, nil
+	}
+
+	// Add finalizer if not present
+	if !controllerutil.ContainsFinalizer(&gpuReset, GPUResetFinalizer) {
+		controllerutil.AddFinalizer(&gpuReset, GPUResetFinalizer)
+
+		if err := r.Update(ctx, &gpuReset); err != nil {
+			return ctrl.Result{}, err
+		}
+	}
+
+	if gpuReset.Status.CompletionTime != nil {
+		logger.V(1).Info("gpureset has completion time set, skipping reconcile",
+			"node", gpuReset.Spec.NodeName)
+
+		releaseNodeLock(ctx, r.NodeLock, gpuReset.Spec.NodeName, lockHolder("gpureset", gpuReset.Name))
+
+		return ctrl.Result{}, nil
+	}
+
+	// Take a deep copy to compare against at the end
+	originalGPUReset := gpuReset.DeepCopy()
+
+	gpuReset.SetInitialConditions()
+	gpuReset.SetStartTime()
+
+	if gpuReset.Status.Phase == "" {
+		setPhase(ctx, &gpuReset.Status.Phase, janitordgxcnvidiacomv1alpha1.ActionPhaseRequested,
+			gpuReset.Spec.NodeName)
+	}
+
+	// Operators can cancel or force fail a stuck action through annotations
+	if reason, message, ok := operatorOverride(&gpuReset); ok {
+		logger.Info("gpureset ended by operator",
+			"node", gpuReset.Spec.NodeName,
+			"reason", reason,
+			"message", message)
+
+		r.fail(ctx, &gpuReset, reason, message)
+
+		return r.updateGPUResetStatus(ctx, originalGPUReset, &gpuReset, ctrl.Result{})
+	}
+
+	if approvalTimedOut(gpuReset.Status.Phase, gpuReset.Status.StartTime, r.Config.ApprovalTimeout) {
+		logger.Info("no outside actor approved the GPU reset within the approval timeout",
+			"node", gpuReset.Spec.NodeName,
+			"approvalTimeout", r.Config.ApprovalTimeout)
+
+		r.fail(ctx, &gpuReset, approvalTimeoutReason,
+			fmt.Sprintf("GPU reset was not approved within the approval timeout of %s", r.Config.ApprovalTimeout))
+
+		return r.updateGPUResetStatus(ctx, originalGPUReset, &gpuReset, ctrl.Result{})
+	}
+
+	var node corev1.Node
+	if err := r.Get(ctx, client.ObjectKey{Name: gpuReset.Spec.NodeName}, &node); err != nil {
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+
+	var result ctrl.Result
+
+	switch {
+	case gpuReset.IsResetInProgress():
+		result = r.verifyReset(ctx, &gpuReset, &node)
+	case r.Config.ManualMode && !approved(&gpuReset):
+		result = r.awaitApproval(ctx, &gpuReset, &node)
+	default:
+		result = r.startReset(ctx, &gpuReset, &node)
+	}
+
+	return r.updateGPUResetStatus(ctx, originalGPUReset, &gpuReset, result)
+}
+
+// startReset locks the node and creates the Job resetting the GPUs on it
+func (r *GPUResetReconciler) startReset(
+	ctx context.Context,
+	gpuReset *janitordgxcnvidiacomv1alpha1.GPUReset,
+	node *corev1.Node,
+) ctrl.Result {
+	logger := log.FromContext(ctx)
+
+	if gpuReset.Status.ConsecutiveFailures >= MaxGPUResetRequestFailures {
+		logger.Info("max reset Job creation failures exceeded, marking as failed",
+			"node", node.Name,
+			"failures", int(gpuReset.Status.ConsecutiveFailures))
+
+		r.fail(ctx, gpuReset, "MaxRetriesExceeded",
+			fmt.Sprintf("GPU reset Job could not be created after %d attempts", MaxGPUResetRequestFailures))
+
+		return ctrl.Result{}
+	}
+
+	locked, err := acquireNodeLock(ctx, r.NodeLock, metrics.ActionTypeGPUReset, node.Name,
+		lockHolder("gpureset", gpuReset.Name), &gpuReset.Status.LockToken)
+	if err != nil {
+		logger.Error(err, "failed to lock node, will retry",
+			"node", node.Name)
+
+		return ctrl.Result{RequeueAfter: nodeLockRetryDelay}
+	}
+
+	if !locked {
+		return ctrl.Result{RequeueAfter: nodeLockRetryDelay}
+	}
+
+	setPhase(ctx, &gpuReset.Status.Phase, janitordgxcnvidiacomv1alpha1.ActionPhaseApproved, node.Name)
+	setPhase(ctx, &gpuReset.Status.Phase, janitordgxcnvidiacomv1alpha1.ActionPhaseExecuting, node.Name)
+
+	if gpuReset.Status.ConsecutiveFailures == 0 {
+		metrics.GlobalMetrics.IncActionCount(metrics.ActionTypeGPUReset, metrics.StatusStarted, node.Name)
+	}
+
+	job, err := r.newResetJob(gpuReset)
+	if err != nil {
+		r.fail(ctx, gpuReset, "InvalidJob", err.Error())
+		return ctrl.Result{}
+	}
+
+	logger.Info("creating GPU reset Job",
+		"node", node.Name,
+		"job", job.Namespace+"/"+job.Name,
+		"selector", gpuReset.Spec.Selector)
+
+	// The Job name is derived from the GPUReset, so a restart after the creation resumes
+	// with the existing Job instead of resetting the GPUs twice
+	if err := r.Create(ctx, job); err != nil && !apierrors.IsAlreadyExists(err) {
+		logger.Error(err, "failed to create GPU reset Job, will retry",
+			"node", node.Name)
+
+		gpuReset.Status.ConsecutiveFailures++
+		gpuReset.SetCondition(metav1.Condition{
+			Type:               janitordgxcnvidiacomv1alpha1.GPUResetConditionGPUReset,
+			Status:             metav1.ConditionUnknown,
+			Reason:             "Failed",
+			Message:            err.Error(),
+			LastTransitionTime: metav1.Now(),
+		})
+
+		return ctrl.Result{RequeueAfter: getNextRequeueDelay(gpuReset.Status.ConsecutiveFailures)}
+	}
+
+	gpuReset.Status.ConsecutiveFailures = 0
+	gpuReset.Status.JobName = job.Name
+	gpuReset.SetCondition(metav1.Condition{
+		Type:               janitordgxcnvidiacomv1alpha1.GPUResetConditionGPUReset,
+		Status:             metav1.ConditionFalse,
+		Reason:             gpuResetConditionInProgress,
+		Message:            fmt.Sprintf("Job %s/%s evicts the pods using the GPUs and resets them", job.Namespace, job.Name),
+		LastTransitionTime: metav1.Now(),
+	})
+
+	return ctrl.Result{RequeueAfter: 30 * time.Second}
+}
+
+// awaitApproval waits in manual mode until an outside actor approved the reset
+func (r *GPUResetReconciler) awaitApproval(
+	ctx context.Context,
+	gpuReset *janitordgxcnvidiacomv1alpha1.GPUReset,
+	node *corev1.Node,
+) ctrl.Result {
+	logger := log.FromContext(ctx)
+
+	if gpuReset.GetCondition(janitordgxcnvidiacomv1alpha1.ManualModeConditionType) == nil {
+		gpuReset.SetCondition(metav1.Condition{
+			Type:               janitordgxcnvidiacomv1alpha1.ManualModeConditionType,
+			Status:             metav1.ConditionTrue,
+			Reason:             "OutsideActorRequired",
+			Message:            "Janitor is in manual mode, outside actor required to approve the GPU reset",
+			LastTransitionTime: metav1.Now(),
+		})
+		metrics.GlobalMetrics.IncActionCount(metrics.ActionTypeGPUReset, metrics.StatusStarted, node.Name)
+	}
+
+	logger.Info("manual mode enabled, janitor will not reset the GPUs until approved",
+		"node", node.Name)
+
+	return ctrl.Result{RequeueAfter: 30 * time.Second}
+}
+
+// verifyReset follows the reset Job until it completed or failed
+func (r *GPUResetReconciler) verifyReset(
+	ctx context.Context,
+	gpuReset *janitordgxcnvidiacomv1alpha1.GPUReset,
+	node *corev1.Node,
+) ctrl.Result {
+	logger := log.FromContext(ctx)
+
+	gpuReset.Status.RetryCount++
+
+	reader := r.APIReader
+	if reader == nil {
+		reader = r.Client
+	}
+
+	var job batchv1.Job
+	if err := reader.Get(ctx, client.ObjectKey{Namespace: r.Config.JobNamespace, Name: gpuReset.Status.JobName},
+		&job); err != nil {
+		if apierrors.IsNotFound(err) {
+			logger.Info("GPU reset Job disappeared before it finished",
+				"node", node.Name,
+				"job", gpuReset.Status.JobName)
+
+			r.fail(ctx, gpuReset, "JobNotFound",
+				fmt.Sprintf("Job %s/%s was deleted before it finished", r.Config.JobNamespace, gpuReset.Status.JobName))
+
+			return ctrl.Result{}
+		}
+
+		logger.Error(err, "failed to get GPU reset Job",
+			"node", node.Name)
+
+		gpuReset.Status.ConsecutiveFailures++
+
+		return ctrl.Result{RequeueAfter: getNextRequeueDelay(gpuReset.Status.ConsecutiveFailures)}
+	}
+
+	gpuReset.Status.ConsecutiveFailures = 0
+
+	for _, condition := range job.Status.Conditions {
+		if condition.Status != corev1.ConditionTrue {
+			continue
+		}
+
+		switch condition.Type {
+		case batchv1.JobComplete:
+			message := jobTerminationMessage(ctx, reader, &job)
+			if message == "" {
+				message = "GPUs reset"
+			}
+
+			logger.Info("GPU reset Job completed",
+				"node", node.Name,
+				"job", job.Name,
+				"result", message,
+				"duration", time.Since(gpuReset.Status.StartTime.Time))
+
+			gpuReset.SetCompletionTime()
+			gpuReset.SetCondition(metav1.Condition{
+				Type:               janitordgxcnvidiacomv1alpha1.GPUResetConditionGPUReset,
+				Status:             metav1.ConditionTrue,
+				Reason:             "Succeeded",
+				Message:            message,
+				LastTransitionTime: metav1.Now(),
+			})
+
+			metrics.GlobalMetrics.IncActionCount(metrics.ActionTypeGPUReset, metrics.StatusSucceeded, node.Name)
+			metrics.GlobalMetrics.RecordActionMTTR(metrics.ActionTypeGPUReset, time.Since(gpuReset.Status.StartTime.Time))
+			setPhase(ctx, &gpuReset.Status.Phase, janitordgxcnvidiacomv1alpha1.ActionPhaseDone, node.Name)
+
+			return ctrl.Result{}
+		case batchv1.JobFailed:
+			message := jobTerminationMessage(ctx, reader, &job)
+			if message == "" {
+				message = condition.Message
+			}
+
+			logger.Info("GPU reset Job failed",
+				"node", node.Name,
+				"job", job.Name,
+				"reason", condition.Reason,
+				"message", message)
+
+			r.fail(ctx, gpuReset, "ResetFailed", fmt.Sprintf("Job %s/%s failed: %s", job.Namespace, job.Name, message))
+
+			return ctrl.Result{}
+		}
+	}
+
+	// The Job deadline normally fails it first, this covers a Job that never ran
+	if time.Since(gpuReset.Status.StartTime.Time) > r.getTimeout()+2*gpuResetJobGracePeriod {
+		logger.Error(nil, "GPU reset timed out",
+			"node", node.Name,
+			"job", job.Name,
+			"timeout", r.getTimeout())
+
+		r.fail(ctx, gpuReset, "Timeout", "GPU reset Job did not finish within the timeout duration")
+
+		return ctrl.Result{}
+	}
+
+	return ctrl.Result{RequeueAfter: 30 * time.Second}
+}
+
+// fail ends the reset for human attention
+func (r *GPUResetReconciler) fail(
+	ctx context.Context,
+	gpuReset *janitordgxcnvidiacomv1alpha1.GPUReset,
+	reason string,
+	message string,
+) {
+	failForHumanAttention(ctx, gpuReset, &gpuReset.Status.Phase, gpuReset.Spec.NodeName,
+		janitordgxcnvidiacomv1alpha1.GPUResetConditionGPUReset, reason, message)
+	metrics.GlobalMetrics.IncActionCount(metrics.ActionTypeGPUReset, metrics.StatusFailed, gpuReset.Spec.NodeName)
+}
+
+// jobTerminationMessage returns the result a Job running the janitor image on a node
+// wrote to its termination log, or an empty string if it cannot be read
+func jobTerminationMessage(ctx context.Context, reader client.Reader, job *batchv1.Job) string {
+	var pods corev1.PodList
+	if err := reader.List(ctx, &pods, client.InNamespace(job.Namespace),
+		client.MatchingLabels{batchv1.JobNameLabel: job.Name}); err != nil {
+		log.FromContext(ctx).Error(err, "failed to list Job pods",
+			"job", job.Name)
+
+		return ""
+	}
+
+	for _, pod := range pods.Items {
+		for _, status := range pod.Status.ContainerStatuses {
+			if status.State.Terminated != nil && status.State.Terminated.Message != "" {
+				return strings.TrimSpace(status.State.Terminated.Message)
+			}
+		}
+	}
+
+	return ""
+}
+
+// newResetJob returns the Job resetting the GPUs of the GPUReset on its node
+func (r *GPUResetReconciler) newResetJob(gpuReset *janitordgxcnvidiacomv1alpha1.GPUReset) (*batchv1.Job, error) {
+	args := []string{"gpu-reset", "--timeout=" + r.getTimeout().String()}
+
+	if selector := gpuReset.Spec.Selector; selector != nil {
+		if len(selector.UUIDs) > 0 {
+			args = append(args, "--uuids="+strings.Join(selector.UUIDs, ","))
+		}
+
+		if len(selector.PCIBusIDs) > 0 {
+			args = append(args, "--pci-bus-ids="+strings.Join(selector.PCIBusIDs, ","))
+		}
+	}
+
+	hostPathDirectory := corev1.HostPathDirectory
+
+	job := &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      gpuResetJobName(gpuReset.Name),
+			Namespace: r.Config.JobNamespace,
+			Labels: map[string]string{
+				"app.kubernetes.io/name":       "gpu-reset",
+				"app.kubernetes.io/managed-by": "janitor",
+			},
+		},
+		Spec: batchv1.JobSpec{
+			BackoffLimit:            ptr.To[int32](0),
+			TTLSecondsAfterFinished: ptr.To[int32](3600),
+			ActiveDeadlineSeconds:   ptr.To(int64((r.getTimeout() + gpuResetJobGracePeriod).Seconds())),
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: map[string]string{"app.kubernetes.io/name": "gpu-reset"},
+				},
+				Spec: corev1.PodSpec{
+					NodeName:           gpuReset.Spec.NodeName,
+					RestartPolicy:      corev1.RestartPolicyNever,
+					ServiceAccountName: r.Config.JobServiceAccount,
+					// The node may be tainted by the fault that needs the reset
+					Tolerations: []corev1.Toleration{{Operator: corev1.TolerationOpExists}},
+					Containers: []corev1.Container{{
+						Name:  "gpu-reset",
+						Image: r.Config.JobImage,
+						Args:  args,
+						// nvidia-smi runs chrooted into the host to reset the GPUs
+						SecurityContext: &corev1.SecurityContext{Privileged: ptr.To(true)},
+						VolumeMounts: []corev1.VolumeMount{
+							{Name: "host-root", MountPath: gpuResetHostRoot},
+							{Name: "pod-resources", MountPath: gpuResetPodResourcesDir, ReadOnly: true},
+						},
+						TerminationMessagePolicy: corev1.TerminationMessageFallbackToLogsOnError,
+					}},
+					Volumes: []corev1.Volume{
+						{
+							Name: "host-root",
+							VolumeSource: corev1.VolumeSource{
+								HostPath: &corev1.HostPathVolumeSource{Path: "/", Type: &hostPathDirectory},
+							},
+						},
+						{
+							Name: "pod-resources",
+							VolumeSource: corev1.VolumeSource{
+								HostPath: &corev1.HostPathVolumeSource{Path: gpuResetPodResourcesDir, Type: &hostPathDirectory},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	// The Job is garbage collected with the GPUReset
+	if err := controllerutil.SetControllerReference(gpuReset, job, r.Scheme); err != nil {
+		return nil, fmt.Errorf("failed to set owner of the GPU reset Job: %w", err)
+	}
+
+	return job, nil
+}
+
+// gpuResetJobName returns the name of the reset Job of the GPUReset, within the 63
+// characters of the job-name label
+func gpuResetJobName(name string) string {
+	jobName := gpuResetJobPrefix + name
+	if len(jobName) > 63 {
+		jobName = strings.TrimRight(jobName[:63], "-.")
+	}
+
+	return jobName
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *GPUResetReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&janitordgxcnvidiacomv1alpha1.GPUReset{}).
+		Named("gpureset").
+		Complete(r)
+}
+
+// getTimeout returns the timeout for waiting for the pods using the GPUs to exit
+func (r *GPUResetReconciler) getTimeout() time.Duration {
+	if r.Config == nil || r.Config.Timeout == 0 {
+		return 15 * time.Minute // fallback default
+	}
+
+	return r.Config.Timeout
+}
@@ -0,0 +1,222 @@
+// Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/scheme"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	janitordgxcnvidiacomv1alpha1 "github.com/nvidia/nvsentinel/janitor/api/v1alpha1"
+	"github.com/nvidia/nvsentinel/janitor/pkg/config"
+)
+
+var _ = Describe("GPUResetReconciler", func() {
+	const gpuUUID = "GPU-11111111-1111-1111-1111-111111111111"
+
+	var (
+		ctx        context.Context
+		reconciler *GPUResetReconciler
+		nodeName   string
+		crName     string
+		jobNS      string
+	)
+
+	reconcile := func() ctrl.Result {
+		result, err := reconciler.Reconcile(ctx, ctrl.Request{
+			NamespacedName: types.NamespacedName{Name: crName},
+		})
+		Expect(err).NotTo(HaveOccurred())
+
+		return result
+	}
+
+	getGPUReset := func() *janitordgxcnvidiacomv1alpha1.GPUReset {
+		var gpuReset janitordgxcnvidiacomv1alpha1.GPUReset
+		Expect(k8sClient.Get(ctx, types.NamespacedName{Name: crName}, &gpuReset)).To(Succeed())
+
+		return &gpuReset
+	}
+
+	getJob := func() *batchv1.Job {
+		var job batchv1.Job
+		Expect(k8sClient.Get(ctx, types.NamespacedName{Namespace: jobNS, Name: gpuResetJobName(crName)}, &job)).
+			To(Succeed())
+
+		return &job
+	}
+
+	// finishJob sets the terminal condition of the reset Job as the Job controller would
+	finishJob := func(conditionType batchv1.JobConditionType) {
+		job := getJob()
+		now := metav1.Now()
+
+		job.Status.StartTime = &now
+
+		switch conditionType {
+		case batchv1.JobComplete:
+			job.Status.Succeeded = 1
+			job.Status.CompletionTime = &now
+			job.Status.Conditions = []batchv1.JobCondition{
+				{Type: batchv1.JobSuccessCriteriaMet, Status: corev1.ConditionTrue, LastTransitionTime: now},
+				{Type: batchv1.JobComplete, Status: corev1.ConditionTrue, LastTransitionTime: now},
+			}
+		case batchv1.JobFailed:
+			job.Status.Failed = 1
+			job.Status.Conditions = []batchv1.JobCondition{
+				{Type: batchv1.JobFailureTarget, Status: corev1.ConditionTrue, Reason: "BackoffLimitExceeded",
+					LastTransitionTime: now},
+				{Type: batchv1.JobFailed, Status: corev1.ConditionTrue, Reason: "BackoffLimitExceeded",
+					Message: "Job has reached the specified backoff limit", LastTransitionTime: now},
+			}
+		}
+
+		Expect(k8sClient.Status().Update(ctx, job)).To(Succeed())
+	}
+
+	BeforeEach(func() {
+		ctx = context.Background()
+
+		uniqueSuffix := fmt.Sprintf("%d", time.Now().UnixNano())
+		nodeName = "test-node-" + uniqueSuffix
+		crName = "test-gpu-reset-" + uniqueSuffix
+		jobNS = "nvsentinel-" + uniqueSuffix
+
+		Expect(k8sClient.Create(ctx, &corev1.Namespace{
+			ObjectMeta: metav1.ObjectMeta{Name: jobNS},
+		})).To(Succeed())
+
+		Expect(k8sClient.Create(ctx, &corev1.Node{
+			ObjectMeta: metav1.ObjectMeta{Name: nodeName},
+		})).To(Succeed())
+
+		Expect(k8sClient.Create(ctx, &janitordgxcnvidiacomv1alpha1.GPUReset{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: crName,
+			},
+			Spec: janitordgxcnvidiacomv1alpha1.GPUResetSpec{
+				NodeName: nodeName,
+				Selector: &janitordgxcnvidiacomv1alpha1.GPUSelector{UUIDs: []string{gpuUUID}},
+			},
+		})).To(Succeed())
+
+		reconciler = &GPUResetReconciler{
+			Client: k8sClient,
+			Scheme: scheme.Scheme,
+			Config: &config.GPUResetControllerConfig{
+				Timeout:           10 * time.Minute,
+				JobNamespace:      jobNS,
+				JobImage:          "ghcr.io/nvidia/nvsentinel/janitor:test",
+				JobServiceAccount: "janitor-gpu-reset",
+			},
+		}
+	})
+
+	AfterEach(func() {
+		checkStatusConditions(getGPUReset().Status.Conditions)
+	})
+
+	Context("When the reset starts", func() {
+		It("Should create a Job on the node resetting the selected GPUs", func() {
+			result := reconcile()
+			Expect(result.RequeueAfter).To(BeNumerically(">", 0))
+
+			gpuReset := getGPUReset()
+			Expect(gpuReset.IsResetInProgress()).To(BeTrue())
+			Expect(gpuReset.Status.Phase).To(Equal(janitordgxcnvidiacomv1alpha1.ActionPhaseExecuting))
+			Expect(gpuReset.Status.JobName).To(Equal(gpuResetJobName(crName)))
+
+			job := getJob()
+			Expect(job.OwnerReferences).To(HaveLen(1))
+			Expect(job.OwnerReferences[0].Name).To(Equal(crName))
+			Expect(*job.Spec.ActiveDeadlineSeconds).To(Equal(int64((15 * time.Minute).Seconds())))
+
+			pod := job.Spec.Template.Spec
+			Expect(pod.NodeName).To(Equal(nodeName))
+			Expect(pod.ServiceAccountName).To(Equal("janitor-gpu-reset"))
+			Expect(pod.Containers[0].Image).To(Equal("ghcr.io/nvidia/nvsentinel/janitor:test"))
+			Expect(pod.Containers[0].Args).To(Equal([]string{"gpu-reset", "--timeout=10m0s", "--uuids=" + gpuUUID}))
+		})
+	})
+
+	Context("When the reset Job completes", func() {
+		It("Should complete the reset with the result of the Job", func() {
+			reconcile()
+
+			// Nothing changes while the Job runs
+			reconcile()
+			Expect(getGPUReset().IsResetInProgress()).To(BeTrue())
+
+			finishJob(batchv1.JobComplete)
+			reconcile()
+
+			gpuReset := getGPUReset()
+			Expect(gpuReset.Status.CompletionTime).NotTo(BeNil())
+			Expect(gpuReset.Status.Phase).To(Equal(janitordgxcnvidiacomv1alpha1.ActionPhaseDone))
+			Expect(meta.IsStatusConditionTrue(gpuReset.Status.Conditions,
+				janitordgxcnvidiacomv1alpha1.GPUResetConditionGPUReset)).To(BeTrue())
+		})
+	})
+
+	Context("When the reset Job fails", func() {
+		It("Should fail the reset for human attention", func() {
+			reconcile()
+
+			finishJob(batchv1.JobFailed)
+			reconcile()
+
+			gpuReset := getGPUReset()
+			Expect(gpuReset.Status.Phase).To(Equal(janitordgxcnvidiacomv1alpha1.ActionPhaseFailed))
+
+			condition := meta.FindStatusCondition(gpuReset.Status.Conditions,
+				janitordgxcnvidiacomv1alpha1.GPUResetConditionGPUReset)
+			Expect(condition).NotTo(BeNil())
+			Expect(condition.Reason).To(Equal("ResetFailed"))
+			Expect(meta.IsStatusConditionTrue(gpuReset.Status.Conditions,
+				janitordgxcnvidiacomv1alpha1.NeedsHumanAttentionConditionType)).To(BeTrue())
+		})
+	})
+
+	Context("When manual mode is enabled", func() {
+		BeforeEach(func() {
+			reconciler.Config.ManualMode = true
+		})
+
+		It("Should wait for approval without creating the Job", func() {
+			reconcile()
+			reconcile()
+
+			var jobs batchv1.JobList
+			Expect(k8sClient.List(ctx, &jobs, client.InNamespace(jobNS))).To(Succeed())
+			Expect(jobs.Items).To(BeEmpty())
+
+			gpuReset := getGPUReset()
+			Expect(gpuReset.Status.Phase).To(Equal(janitordgxcnvidiacomv1alpha1.ActionPhaseRequested))
+			Expect(meta.IsStatusConditionTrue(gpuReset.Status.Conditions,
+				janitordgxcnvidiacomv1alpha1.ManualModeConditionType)).To(BeTrue())
+		})
+	})
+})
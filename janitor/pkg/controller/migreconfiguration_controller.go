@@ -0,0 +1,618 @@
+// Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// nolint:wsl,lll,gocognit,cyclop,gocyclo,nestif // Mirrors the DriverReload controller
+package controller
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	"github.com/nvidia/nvsentinel/commons/pkg/nodelock"
+	janitordgxcnvidiacomv1alpha1 "github.com/nvidia/nvsentinel/janitor/api/v1alpha1"
+	"github.com/nvidia/nvsentinel/janitor/pkg/config"
+	"github.com/nvidia/nvsentinel/janitor/pkg/metrics"
+)
+
+const (
+	// MIGReconfigurationFinalizer is added to MIGReconfiguration objects to handle cleanup
+	MIGReconfigurationFinalizer = "janitor.dgxc.nvidia.com/migreconfiguration-finalizer"
+
+	// MIGConfigLabel selects the configuration of the GPU Operator MIG manager applied
+	// to the GPUs of the node
+	MIGConfigLabel = "nvidia.com/mig.config"
+	// MIGConfigStateLabel holds the state of the MIG manager applying the configuration:
+	// pending, rebooting, success or failed
+	MIGConfigStateLabel = "nvidia.com/mig.config.state"
+
+	// MaxMIGReconfigurationRequestFailures is the number of failed configuration requests before giving up
+	MaxMIGReconfigurationRequestFailures = 5
+
+	migConfigStateSuccess = "success"
+	migConfigStateFailed  = "failed"
+
+	defaultMIGDisabledConfig    = "all-disabled"
+	defaultDevicePluginSelector = "app=nvidia-device-plugin-daemonset"
+
+	// migResourcePrefix prefixes the resources the device plugin advertises for MIG
+	// instances with the mixed strategy, the single strategy advertises nvidia.com/gpu
+	migResourcePrefix = "nvidia.com/mig-"
+)
+
+// updateMIGReconfigurationStatus is a helper function that handles status updates with proper error handling.
+// It delegates to the generic updateNodeActionStatus function.
+func (r *MIGReconfigurationReconciler) updateMIGReconfigurationStatus(
+	ctx context.Context,
+	original *janitordgxcnvidiacomv1alpha1.MIGReconfiguration,
+	updated *janitordgxcnvidiacomv1alpha1.MIGReconfiguration,
+	result ctrl.Result,
+) (ctrl.Result, error) {
+	return updateNodeActionStatus(
+		ctx,
+		r.Status(),
+		original,
+		updated,
+		&original.Status,
+		&updated.Status,
+		updated.Spec.NodeName,
+		"migreconfiguration",
+		result,
+	)
+}
+
+// MIGReconfigurationReconciler reconciles a MIGReconfiguration object. The MIG instances
+// of the node are destroyed and recreated through the GPU Operator MIG manager, which
+// applies the configuration selected by the nvidia.com/mig.config label of the node and
+// pauses the device plugin and the other GPU clients while it does: the janitor first
+// selects the configuration disabling MIG, then the configuration of the node again, and
+// the device plugin advertising the instances after it restarted is the recovery signal.
+// The MIG manager configures all GPUs of a node, so all instances of the node are recreated.
+type MIGReconfigurationReconciler struct {
+	client.Client
+	Scheme *runtime.Scheme
+	Config *config.MIGReconfigurationControllerConfig
+	// APIReader reads the device plugin pods without caching all pods of the cluster,
+	// defaults to the client
+	APIReader client.Reader
+	// NodeLock serializes destructive actions on a node, nil disables locking
+	NodeLock *nodelock.Locker
+}
+
+// +kubebuilder:rbac:groups=janitor.dgxc.nvidia.com,resources=migreconfigurations,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=janitor.dgxc.nvidia.com,resources=migreconfigurations/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=janitor.dgxc.nvidia.com,resources=migreconfigurations/finalizers,verbs=update
+// +kubebuilder:rbac:groups=core,resources=nodes,verbs=get;list;watch;patch
+// +kubebuilder:rbac:groups=core,resources=pods,verbs=get;list
+
+// Reconcile is part of the main kubernetes reconciliation loop which aims to
+// move the current state of the cluster closer to the desired state.
+func (r *MIGReconfigurationReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	logger := log.FromContext(ctx)
+
+	var migReconfiguration janitordgxcnvidiacomv1alpha1.MIGReconfiguration
+	if err := r.Get(ctx, req.NamespacedName, &migReconfiguration); err != nil {
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+
+	// Handle deletion with finalizer
+	if !migReconfiguration.DeletionTimestamp.IsZero() {
+		if controllerutil.ContainsFinalizer(&migReconfiguration, MIGReconfigurationFinalizer) {
+			logger.Info("migreconfiguration deletion requested, performing cleanup",
+				"node", migReconfiguration.Spec.NodeName,
+				"conditions", migReconfiguration.Status.Conditions)
+
+			releaseNodeLock(ctx, r.NodeLock, migReconfiguration.Spec.NodeName,
+				lockHolder("migreconfiguration", migReconfiguration.Name))
+
+			controllerutil.RemoveFinalizer(&migReconfiguration, MIGReconfigurationFinalizer)
+
+			if err := r.Update(ctx, &migReconfiguration); err != nil {
+				return ctrl.Result{}, err
+			}
+		}
+
+		return ctrl.Result{}, nil
+	}
+
+	// Add finalizer if not present
+	if !controllerutil.ContainsFinalizer(&migReconfiguration, MIGReconfigurationFinalizer) {
+		controllerutil.AddFinalizer(&migReconfiguration, MIGReconfigurationFinalizer)
+
+		if err := r.Update(ctx, &migReconfiguration); err != nil {
+			return ctrl.Result{}, err
+		}
+	}
+
+	if migReconfiguration.Status.CompletionTime != nil {
+		logger.V(1).Info("migreconfiguration has completion time set, skipping reconcile",
+			"node", migReconfiguration.Spec.NodeName)
+
+		releaseNodeLock(ctx, r.NodeLock, migReconfiguration.Spec.NodeName,
+			lockHolder("migreconfiguration", migReconfiguration.Name))
+
+		return ctrl.Result{}, nil
+	}
+
+	// Take a deep copy to compare against at the end
+	originalMIGReconfiguration := migReconfiguration.DeepCopy()
+
+	migReconfiguration.SetInitialConditions()
+	migReconfiguration.SetStartTime()
+
+	if migReconfiguration.Status.Phase == "" {
+		setPhase(ctx, &migReconfiguration.Status.Phase, janitordgxcnvidiacomv1alpha1.ActionPhaseRequested,
+			migReconfiguration.Spec.NodeName)
+	}
+
+	// Operators can cancel or force fail a stuck action through annotations
+	if reason, message, ok := operatorOverride(&migReconfiguration); ok {
+		logger.Info("migreconfiguration ended by operator",
+			"node", migReconfiguration.Spec.NodeName,
+			"reason", reason,
+			"message", message)
+
+		r.fail(ctx, &migReconfiguration, reason, message)
+
+		return r.updateMIGReconfigurationStatus(ctx, originalMIGReconfiguration, &migReconfiguration, ctrl.Result{})
+	}
+
+	if approvalTimedOut(migReconfiguration.Status.Phase, migReconfiguration.Status.StartTime, r.Config.ApprovalTimeout) {
+		logger.Info("no outside actor approved the MIG reconfiguration within the approval timeout",
+			"node", migReconfiguration.Spec.NodeName,
+			"approvalTimeout", r.Config.ApprovalTimeout)
+
+		r.fail(ctx, &migReconfiguration, approvalTimeoutReason,
+			fmt.Sprintf("MIG reconfiguration was not approved within the approval timeout of %s", r.Config.ApprovalTimeout))
+
+		return r.updateMIGReconfigurationStatus(ctx, originalMIGReconfiguration, &migReconfiguration, ctrl.Result{})
+	}
+
+	var node corev1.Node
+	if err := r.Get(ctx, client.ObjectKey{Name: migReconfiguration.Spec.NodeName}, &node); err != nil {
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+
+	var result ctrl.Result
+
+	switch {
+	case migReconfiguration.IsDone(janitordgxcnvidiacomv1alpha1.MIGReconfigurationConditionMIGConfigured):
+		result = r.verifyInstances(ctx, &migReconfiguration, &node)
+	case migReconfiguration.IsRequested(janitordgxcnvidiacomv1alpha1.MIGReconfigurationConditionMIGConfigured):
+		result = r.awaitMIGManager(ctx, &migReconfiguration, &node,
+			janitordgxcnvidiacomv1alpha1.MIGReconfigurationConditionMIGConfigured, migReconfiguration.Status.MIGConfig)
+	case migReconfiguration.IsDone(janitordgxcnvidiacomv1alpha1.MIGReconfigurationConditionMIGDisabled):
+		result = r.requestMIGConfig(ctx, &migReconfiguration, &node,
+			janitordgxcnvidiacomv1alpha1.MIGReconfigurationConditionMIGConfigured, migReconfiguration.Status.MIGConfig)
+	case migReconfiguration.IsRequested(janitordgxcnvidiacomv1alpha1.MIGReconfigurationConditionMIGDisabled):
+		result = r.awaitMIGManager(ctx, &migReconfiguration, &node,
+			janitordgxcnvidiacomv1alpha1.MIGReconfigurationConditionMIGDisabled, r.getDisabledConfig())
+	case r.Config.ManualMode && !approved(&migReconfiguration):
+		result = r.awaitApproval(ctx, &migReconfiguration, &node)
+	default:
+		result = r.disableMIG(ctx, &migReconfiguration, &node)
+	}
+
+	return r.updateMIGReconfigurationStatus(ctx, originalMIGReconfiguration, &migReconfiguration, result)
+}
+
+// disableMIG locks the node, records the MIG configuration to recreate the instances
+// with, and asks the MIG manager to disable MIG
+func (r *MIGReconfigurationReconciler) disableMIG(
+	ctx context.Context,
+	migReconfiguration *janitordgxcnvidiacomv1alpha1.MIGReconfiguration,
+	node *corev1.Node,
+) ctrl.Result {
+	logger := log.FromContext(ctx)
+
+	if migReconfiguration.Status.MIGConfig == "" {
+		migConfig := migReconfiguration.Spec.MIGConfig
+		if migConfig == "" {
+			migConfig = node.Labels[MIGConfigLabel]
+		}
+
+		if migConfig == "" || migConfig == r.getDisabledConfig() {
+			logger.Info("node has no MIG configuration to recreate the instances with",
+				"node", node.Name)
+
+			r.fail(ctx, migReconfiguration, "MIGNotConfigured",
+				fmt.Sprintf("Node has no MIG configuration in %s and none was given in the spec", MIGConfigLabel))
+
+			return ctrl.Result{}
+		}
+
+		migReconfiguration.Status.MIGConfig = migConfig
+	}
+
+	locked, err := acquireNodeLock(ctx, r.NodeLock, metrics.ActionTypeMIGReconfigure, node.Name,
+		lockHolder("migreconfiguration", migReconfiguration.Name), &migReconfiguration.Status.LockToken)
+	if err != nil {
+		logger.Error(err, "failed to lock node, will retry",
+			"node", node.Name)
+
+		return ctrl.Result{RequeueAfter: nodeLockRetryDelay}
+	}
+
+	if !locked {
+		return ctrl.Result{RequeueAfter: nodeLockRetryDelay}
+	}
+
+	setPhase(ctx, &migReconfiguration.Status.Phase, janitordgxcnvidiacomv1alpha1.ActionPhaseApproved, node.Name)
+	setPhase(ctx, &migReconfiguration.Status.Phase, janitordgxcnvidiacomv1alpha1.ActionPhaseExecuting, node.Name)
+
+	if migReconfiguration.Status.ConsecutiveFailures == 0 {
+		metrics.GlobalMetrics.IncActionCount(metrics.ActionTypeMIGReconfigure, metrics.StatusStarted, node.Name)
+	}
+
+	return r.requestMIGConfig(ctx, migReconfiguration, node,
+		janitordgxcnvidiacomv1alpha1.MIGReconfigurationConditionMIGDisabled, r.getDisabledConfig())
+}
+
+// requestMIGConfig selects the MIG configuration of the node for the MIG manager to apply,
+// the stage of the condition type is then awaited
+func (r *MIGReconfigurationReconciler) requestMIGConfig(
+	ctx context.Context,
+	migReconfiguration *janitordgxcnvidiacomv1alpha1.MIGReconfiguration,
+	node *corev1.Node,
+	conditionType string,
+	migConfig string,
+) ctrl.Result {
+	logger := log.FromContext(ctx)
+
+	if migReconfiguration.Status.ConsecutiveFailures >= MaxMIGReconfigurationRequestFailures {
+		logger.Info("max MIG configuration request failures exceeded, marking as failed",
+			"node", node.Name,
+			"failures", int(migReconfiguration.Status.ConsecutiveFailures))
+
+		r.fail(ctx, migReconfiguration, "MaxRetriesExceeded",
+			fmt.Sprintf("MIG configuration %s could not be requested from the MIG manager after %d attempts",
+				migConfig, MaxMIGReconfigurationRequestFailures))
+
+		return ctrl.Result{}
+	}
+
+	logger.Info("requesting MIG configuration from the MIG manager",
+		"node", node.Name,
+		"migConfig", migConfig)
+
+	// The state of the previous configuration is removed so that only the MIG manager
+	// applying this one can report success. The labels are idempotent, so a restart
+	// during the request is safe to resume by sending it again.
+	patch := client.MergeFrom(node.DeepCopy())
+	if node.Labels == nil {
+		node.Labels = map[string]string{}
+	}
+
+	node.Labels[MIGConfigLabel] = migConfig
+	delete(node.Labels, MIGConfigStateLabel)
+
+	if err := r.Patch(ctx, node, patch); err != nil {
+		logger.Error(err, "failed to request MIG configuration, will retry",
+			"node", node.Name)
+
+		migReconfiguration.Status.ConsecutiveFailures++
+		migReconfiguration.SetCondition(metav1.Condition{
+			Type:               conditionType,
+			Status:             metav1.ConditionUnknown,
+			Reason:             "Failed",
+			Message:            err.Error(),
+			LastTransitionTime: metav1.Now(),
+		})
+
+		return ctrl.Result{RequeueAfter: getNextRequeueDelay(migReconfiguration.Status.ConsecutiveFailures)}
+	}
+
+	migReconfiguration.Status.ConsecutiveFailures = 0
+	migReconfiguration.SetCondition(metav1.Condition{
+		Type:               conditionType,
+		Status:             metav1.ConditionFalse,
+		Reason:             janitordgxcnvidiacomv1alpha1.MIGReconfigurationReasonRequested,
+		Message:            fmt.Sprintf("Node labeled with %s=%s", MIGConfigLabel, migConfig),
+		LastTransitionTime: metav1.Now(),
+	})
+
+	return ctrl.Result{RequeueAfter: 30 * time.Second}
+}
+
+// awaitMIGManager consumes the state of the MIG manager applying the MIG configuration
+// requested for the stage of the condition type
+func (r *MIGReconfigurationReconciler) awaitMIGManager(
+	ctx context.Context,
+	migReconfiguration *janitordgxcnvidiacomv1alpha1.MIGReconfiguration,
+	node *corev1.Node,
+	conditionType string,
+	migConfig string,
+) ctrl.Result {
+	logger := log.FromContext(ctx)
+
+	migReconfiguration.Status.RetryCount++
+
+	// Another actor changing the configuration in between would make the MIG manager
+	// report the result of its configuration instead
+	if node.Labels[MIGConfigLabel] != migConfig {
+		logger.Info("MIG configuration of the node was changed during the reconfiguration",
+			"node", node.Name,
+			"expected", migConfig,
+			"actual", node.Labels[MIGConfigLabel])
+
+		r.fail(ctx, migReconfiguration, "MIGConfigChanged",
+			fmt.Sprintf("%s changed from %s to %s during the reconfiguration", MIGConfigLabel, migConfig,
+				node.Labels[MIGConfigLabel]))
+
+		return ctrl.Result{}
+	}
+
+	switch node.Labels[MIGConfigStateLabel] {
+	case migConfigStateSuccess:
+		logger.Info("MIG manager applied the MIG configuration",
+			"node", node.Name,
+			"migConfig", migConfig,
+			"stage", conditionType)
+
+		migReconfiguration.SetCondition(metav1.Condition{
+			Type:               conditionType,
+			Status:             metav1.ConditionTrue,
+			Reason:             "Succeeded",
+			Message:            fmt.Sprintf("MIG manager applied %s", migConfig),
+			LastTransitionTime: metav1.Now(),
+		})
+
+		// The next stage starts right away
+		return ctrl.Result{RequeueAfter: time.Second}
+	case migConfigStateFailed:
+		logger.Info("MIG manager reported the MIG configuration as failed",
+			"node", node.Name,
+			"migConfig", migConfig)
+
+		r.fail(ctx, migReconfiguration, "MIGConfigFailed",
+			fmt.Sprintf("MIG manager set %s=%s applying %s", MIGConfigStateLabel, migConfigStateFailed, migConfig))
+
+		return ctrl.Result{}
+	}
+
+	if r.timedOut(ctx, migReconfiguration, node) {
+		return ctrl.Result{}
+	}
+
+	return ctrl.Result{RequeueAfter: 30 * time.Second}
+}
+
+// awaitApproval waits in manual mode until an outside actor approved the reconfiguration
+func (r *MIGReconfigurationReconciler) awaitApproval(
+	ctx context.Context,
+	migReconfiguration *janitordgxcnvidiacomv1alpha1.MIGReconfiguration,
+	node *corev1.Node,
+) ctrl.Result {
+	logger := log.FromContext(ctx)
+
+	if migReconfiguration.GetCondition(janitordgxcnvidiacomv1alpha1.ManualModeConditionType) == nil {
+		migReconfiguration.SetCondition(metav1.Condition{
+			Type:               janitordgxcnvidiacomv1alpha1.ManualModeConditionType,
+			Status:             metav1.ConditionTrue,
+			Reason:             "OutsideActorRequired",
+			Message:            "Janitor is in manual mode, outside actor required to approve the MIG reconfiguration",
+			LastTransitionTime: metav1.Now(),
+		})
+		metrics.GlobalMetrics.IncActionCount(metrics.ActionTypeMIGReconfigure, metrics.StatusStarted, node.Name)
+	}
+
+	logger.Info("manual mode enabled, janitor will not reconfigure MIG until approved",
+		"node", node.Name)
+
+	return ctrl.Result{RequeueAfter: 30 * time.Second}
+}
+
+// verifyInstances waits for the device plugin to restart after the MIG manager recreated
+// the instances and to advertise them on the node
+func (r *MIGReconfigurationReconciler) verifyInstances(
+	ctx context.Context,
+	migReconfiguration *janitordgxcnvidiacomv1alpha1.MIGReconfiguration,
+	node *corev1.Node,
+) ctrl.Result {
+	logger := log.FromContext(ctx)
+
+	migReconfiguration.Status.RetryCount++
+	setPhase(ctx, &migReconfiguration.Status.Phase, janitordgxcnvidiacomv1alpha1.ActionPhaseVerifying, node.Name)
+
+	configured := migReconfiguration.GetCondition(janitordgxcnvidiacomv1alpha1.MIGReconfigurationConditionMIGConfigured)
+
+	devicePlugin, err := r.devicePluginReadySince(ctx, node.Name, configured.LastTransitionTime.Time)
+	if err != nil {
+		logger.Error(err, "failed to check device plugin pods",
+			"node", node.Name)
+
+		migReconfiguration.Status.ConsecutiveFailures++
+
+		return ctrl.Result{RequeueAfter: getNextRequeueDelay(migReconfiguration.Status.ConsecutiveFailures)}
+	}
+
+	migReconfiguration.Status.ConsecutiveFailures = 0
+
+	resources := advertisedGPUResources(node)
+	if devicePlugin != "" && len(resources) > 0 {
+		logger.Info("device plugin advertises the recreated MIG instances",
+			"node", node.Name,
+			"devicePlugin", devicePlugin,
+			"resources", resources,
+			"duration", time.Since(migReconfiguration.Status.StartTime.Time))
+
+		migReconfiguration.SetCompletionTime()
+		migReconfiguration.SetCondition(metav1.Condition{
+			Type:               janitordgxcnvidiacomv1alpha1.MIGReconfigurationConditionInstancesReady,
+			Status:             metav1.ConditionTrue,
+			Reason:             "Succeeded",
+			Message:            fmt.Sprintf("%s advertises %s", devicePlugin, strings.Join(resources, ", ")),
+			LastTransitionTime: metav1.Now(),
+		})
+
+		metrics.GlobalMetrics.IncActionCount(metrics.ActionTypeMIGReconfigure, metrics.StatusSucceeded, node.Name)
+		metrics.GlobalMetrics.RecordActionMTTR(metrics.ActionTypeMIGReconfigure,
+			time.Since(migReconfiguration.Status.StartTime.Time))
+		setPhase(ctx, &migReconfiguration.Status.Phase, janitordgxcnvidiacomv1alpha1.ActionPhaseDone, node.Name)
+
+		return ctrl.Result{}
+	}
+
+	if r.timedOut(ctx, migReconfiguration, node) {
+		return ctrl.Result{}
+	}
+
+	return ctrl.Result{RequeueAfter: 30 * time.Second}
+}
+
+// timedOut fails the reconfiguration if it did not complete within the timeout
+func (r *MIGReconfigurationReconciler) timedOut(
+	ctx context.Context,
+	migReconfiguration *janitordgxcnvidiacomv1alpha1.MIGReconfiguration,
+	node *corev1.Node,
+) bool {
+	if time.Since(migReconfiguration.Status.StartTime.Time) <= r.getTimeout() {
+		return false
+	}
+
+	log.FromContext(ctx).Error(nil, "MIG reconfiguration timed out",
+		"node", node.Name,
+		"timeout", r.getTimeout(),
+		"migConfig", node.Labels[MIGConfigLabel],
+		"migConfigState", node.Labels[MIGConfigStateLabel])
+
+	r.fail(ctx, migReconfiguration, "Timeout",
+		"MIG instances were not recreated and advertised within the timeout duration")
+
+	return true
+}
+
+// fail ends the reconfiguration for human attention
+func (r *MIGReconfigurationReconciler) fail(
+	ctx context.Context,
+	migReconfiguration *janitordgxcnvidiacomv1alpha1.MIGReconfiguration,
+	reason string,
+	message string,
+) {
+	failForHumanAttention(ctx, migReconfiguration, &migReconfiguration.Status.Phase, migReconfiguration.Spec.NodeName,
+		janitordgxcnvidiacomv1alpha1.MIGReconfigurationConditionInstancesReady, reason, message)
+	metrics.GlobalMetrics.IncActionCount(metrics.ActionTypeMIGReconfigure, metrics.StatusFailed,
+		migReconfiguration.Spec.NodeName)
+}
+
+// devicePluginReadySince returns the name of a device plugin pod on the node that became
+// ready after since, or an empty string if the device plugin has not restarted since then
+func (r *MIGReconfigurationReconciler) devicePluginReadySince(
+	ctx context.Context,
+	nodeName string,
+	since time.Time,
+) (string, error) {
+	selector, err := labels.Parse(r.getDevicePluginSelector())
+	if err != nil {
+		return "", fmt.Errorf("invalid device plugin selector %q: %w", r.getDevicePluginSelector(), err)
+	}
+
+	reader := r.APIReader
+	if reader == nil {
+		reader = r.Client
+	}
+
+	var pods corev1.PodList
+	if err := reader.List(ctx, &pods,
+		client.InNamespace(r.getOperatorNamespace()),
+		client.MatchingLabelsSelector{Selector: selector}); err != nil {
+		return "", fmt.Errorf("failed to list device plugin pods: %w", err)
+	}
+
+	for _, pod := range pods.Items {
+		if pod.Spec.NodeName != nodeName || !pod.DeletionTimestamp.IsZero() {
+			continue
+		}
+
+		for _, condition := range pod.Status.Conditions {
+			if condition.Type == corev1.PodReady && condition.Status == corev1.ConditionTrue &&
+				!condition.LastTransitionTime.Time.Before(since) {
+				return pod.Name, nil
+			}
+		}
+	}
+
+	return "", nil
+}
+
+// advertisedGPUResources returns the MIG instance resources allocatable on the node,
+// or nvidia.com/gpu with the single strategy
+func advertisedGPUResources(node *corev1.Node) []string {
+	var resources []string
+
+	for name, quantity := range node.Status.Allocatable {
+		if (strings.HasPrefix(string(name), migResourcePrefix) || name == "nvidia.com/gpu") && !quantity.IsZero() {
+			resources = append(resources, fmt.Sprintf("%s=%s", name, quantity.String()))
+		}
+	}
+
+	sort.Strings(resources)
+
+	return resources
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *MIGReconfigurationReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&janitordgxcnvidiacomv1alpha1.MIGReconfiguration{}).
+		Named("migreconfiguration").
+		Complete(r)
+}
+
+// getTimeout returns the timeout for MIG reconfiguration operations
+func (r *MIGReconfigurationReconciler) getTimeout() time.Duration {
+	if r.Config == nil || r.Config.Timeout == 0 {
+		return 30 * time.Minute // fallback default
+	}
+
+	return r.Config.Timeout
+}
+
+// getOperatorNamespace returns the namespace of the GPU Operator device plugin pods
+func (r *MIGReconfigurationReconciler) getOperatorNamespace() string {
+	if r.Config == nil || r.Config.OperatorNamespace == "" {
+		return defaultGPUOperatorNamespace
+	}
+
+	return r.Config.OperatorNamespace
+}
+
+// getDevicePluginSelector returns the label selector of the device plugin pods
+func (r *MIGReconfigurationReconciler) getDevicePluginSelector() string {
+	if r.Config == nil || r.Config.DevicePluginSelector == "" {
+		return defaultDevicePluginSelector
+	}
+
+	return r.Config.DevicePluginSelector
+}
+
+// getDisabledConfig returns the MIG manager configuration disabling MIG
+func (r *MIGReconfigurationReconciler) getDisabledConfig() string {
+	if r.Config == nil || r.Config.DisabledConfig == "" {
+		return defaultMIGDisabledConfig
+	}
+
+	return r.Config.DisabledConfig
+}
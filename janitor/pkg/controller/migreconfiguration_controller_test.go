@@ -0,0 +1,265 @@
+// Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/scheme"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	janitordgxcnvidiacomv1alpha1 "github.com/nvidia/nvsentinel/janitor/api/v1alpha1"
+	"github.com/nvidia/nvsentinel/janitor/pkg/config"
+)
+
+var _ = Describe("MIGReconfigurationReconciler", func() {
+	var (
+		ctx          context.Context
+		reconciler   *MIGReconfigurationReconciler
+		nodeName     string
+		crName       string
+		operatorNS   string
+		uniqueSuffix string
+	)
+
+	reconcile := func() ctrl.Result {
+		result, err := reconciler.Reconcile(ctx, ctrl.Request{
+			NamespacedName: types.NamespacedName{Name: crName},
+		})
+		Expect(err).NotTo(HaveOccurred())
+
+		return result
+	}
+
+	getMIGReconfiguration := func() *janitordgxcnvidiacomv1alpha1.MIGReconfiguration {
+		var migReconfiguration janitordgxcnvidiacomv1alpha1.MIGReconfiguration
+		Expect(k8sClient.Get(ctx, types.NamespacedName{Name: crName}, &migReconfiguration)).To(Succeed())
+
+		return &migReconfiguration
+	}
+
+	getNode := func() *corev1.Node {
+		var updatedNode corev1.Node
+		Expect(k8sClient.Get(ctx, types.NamespacedName{Name: nodeName}, &updatedNode)).To(Succeed())
+
+		return &updatedNode
+	}
+
+	// simulateMIGManager reports the state of the MIG manager applying the configuration
+	simulateMIGManager := func(state string) {
+		updatedNode := getNode()
+		patch := client.MergeFrom(updatedNode.DeepCopy())
+
+		updatedNode.Labels[MIGConfigStateLabel] = state
+		Expect(k8sClient.Patch(ctx, updatedNode, patch)).To(Succeed())
+	}
+
+	// simulateDevicePlugin advertises the recreated instances from a device plugin pod
+	// that became ready at readyAt
+	simulateDevicePlugin := func(readyAt time.Time) {
+		pod := &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "nvidia-device-plugin-daemonset-" + uniqueSuffix,
+				Namespace: operatorNS,
+				Labels:    map[string]string{"app": "nvidia-device-plugin-daemonset"},
+			},
+			Spec: corev1.PodSpec{
+				NodeName:   nodeName,
+				Containers: []corev1.Container{{Name: "device-plugin", Image: "nvcr.io/nvidia/k8s-device-plugin:v0.17.0"}},
+			},
+		}
+		Expect(k8sClient.Create(ctx, pod)).To(Succeed())
+
+		pod.Status.Conditions = []corev1.PodCondition{{
+			Type:               corev1.PodReady,
+			Status:             corev1.ConditionTrue,
+			LastTransitionTime: metav1.NewTime(readyAt),
+		}}
+		Expect(k8sClient.Status().Update(ctx, pod)).To(Succeed())
+
+		updatedNode := getNode()
+		updatedNode.Status.Allocatable = corev1.ResourceList{"nvidia.com/mig-1g.10gb": resource.MustParse("7")}
+		Expect(k8sClient.Status().Update(ctx, updatedNode)).To(Succeed())
+	}
+
+	BeforeEach(func() {
+		ctx = context.Background()
+
+		uniqueSuffix = fmt.Sprintf("%d", time.Now().UnixNano())
+		nodeName = "test-node-" + uniqueSuffix
+		crName = "test-mig-reconfiguration-" + uniqueSuffix
+		operatorNS = "gpu-operator-" + uniqueSuffix
+
+		Expect(k8sClient.Create(ctx, &corev1.Namespace{
+			ObjectMeta: metav1.ObjectMeta{Name: operatorNS},
+		})).To(Succeed())
+
+		Expect(k8sClient.Create(ctx, &corev1.Node{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: nodeName,
+				Labels: map[string]string{
+					MIGConfigLabel:      "all-1g.10gb",
+					MIGConfigStateLabel: "success",
+				},
+			},
+		})).To(Succeed())
+
+		Expect(k8sClient.Create(ctx, &janitordgxcnvidiacomv1alpha1.MIGReconfiguration{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: crName,
+			},
+			Spec: janitordgxcnvidiacomv1alpha1.MIGReconfigurationSpec{
+				NodeName: nodeName,
+			},
+		})).To(Succeed())
+
+		reconciler = &MIGReconfigurationReconciler{
+			Client: k8sClient,
+			Scheme: scheme.Scheme,
+			Config: &config.MIGReconfigurationControllerConfig{
+				OperatorNamespace: operatorNS,
+			},
+		}
+	})
+
+	AfterEach(func() {
+		checkStatusConditions(getMIGReconfiguration().Status.Conditions)
+	})
+
+	Context("When disabling MIG", func() {
+		It("Should select the disabled configuration and record the configuration of the node", func() {
+			result := reconcile()
+			Expect(result.RequeueAfter).To(BeNumerically(">", 0))
+
+			labels := getNode().Labels
+			Expect(labels).To(HaveKeyWithValue(MIGConfigLabel, "all-disabled"))
+			Expect(labels).NotTo(HaveKey(MIGConfigStateLabel))
+
+			migReconfiguration := getMIGReconfiguration()
+			Expect(migReconfiguration.Status.MIGConfig).To(Equal("all-1g.10gb"))
+			Expect(migReconfiguration.Status.Phase).To(Equal(janitordgxcnvidiacomv1alpha1.ActionPhaseExecuting))
+			Expect(migReconfiguration.IsRequested(janitordgxcnvidiacomv1alpha1.MIGReconfigurationConditionMIGDisabled)).
+				To(BeTrue())
+		})
+	})
+
+	Context("When the MIG manager applied the configurations", func() {
+		It("Should recreate the instances and complete once the device plugin advertises them", func() {
+			reconcile()
+
+			simulateMIGManager("success")
+			reconcile()
+			Expect(getMIGReconfiguration().IsDone(janitordgxcnvidiacomv1alpha1.MIGReconfigurationConditionMIGDisabled)).
+				To(BeTrue())
+
+			reconcile()
+			Expect(getNode().Labels).To(HaveKeyWithValue(MIGConfigLabel, "all-1g.10gb"))
+			Expect(getMIGReconfiguration().IsRequested(janitordgxcnvidiacomv1alpha1.MIGReconfigurationConditionMIGConfigured)).
+				To(BeTrue())
+
+			simulateMIGManager("success")
+			reconcile()
+			Expect(getMIGReconfiguration().IsDone(janitordgxcnvidiacomv1alpha1.MIGReconfigurationConditionMIGConfigured)).
+				To(BeTrue())
+
+			// Device plugin pods that were ready before the instances were recreated do not count
+			simulateDevicePlugin(time.Now().Add(-time.Hour))
+			reconcile()
+			Expect(getMIGReconfiguration().Status.CompletionTime).To(BeNil())
+
+			var pod corev1.Pod
+			Expect(k8sClient.Get(ctx, types.NamespacedName{
+				Namespace: operatorNS,
+				Name:      "nvidia-device-plugin-daemonset-" + uniqueSuffix,
+			}, &pod)).To(Succeed())
+			pod.Status.Conditions[0].LastTransitionTime = metav1.NewTime(time.Now().Add(time.Minute))
+			Expect(k8sClient.Status().Update(ctx, &pod)).To(Succeed())
+
+			reconcile()
+
+			migReconfiguration := getMIGReconfiguration()
+			Expect(migReconfiguration.Status.CompletionTime).NotTo(BeNil())
+			Expect(migReconfiguration.Status.Phase).To(Equal(janitordgxcnvidiacomv1alpha1.ActionPhaseDone))
+			Expect(meta.IsStatusConditionTrue(migReconfiguration.Status.Conditions,
+				janitordgxcnvidiacomv1alpha1.MIGReconfigurationConditionInstancesReady)).To(BeTrue())
+		})
+	})
+
+	Context("When the MIG manager failed the configuration", func() {
+		It("Should fail the MIG reconfiguration", func() {
+			reconcile()
+
+			simulateMIGManager("failed")
+			reconcile()
+
+			migReconfiguration := getMIGReconfiguration()
+			Expect(migReconfiguration.Status.CompletionTime).NotTo(BeNil())
+			Expect(migReconfiguration.Status.Phase).To(Equal(janitordgxcnvidiacomv1alpha1.ActionPhaseFailed))
+
+			condition := meta.FindStatusCondition(migReconfiguration.Status.Conditions,
+				janitordgxcnvidiacomv1alpha1.MIGReconfigurationConditionInstancesReady)
+			Expect(condition).NotTo(BeNil())
+			Expect(condition.Reason).To(Equal("MIGConfigFailed"))
+			Expect(meta.IsStatusConditionTrue(migReconfiguration.Status.Conditions,
+				janitordgxcnvidiacomv1alpha1.NeedsHumanAttentionConditionType)).To(BeTrue())
+		})
+	})
+
+	Context("When the node has no MIG configuration", func() {
+		It("Should fail the MIG reconfiguration without touching the node", func() {
+			updatedNode := getNode()
+			patch := client.MergeFrom(updatedNode.DeepCopy())
+			delete(updatedNode.Labels, MIGConfigLabel)
+			Expect(k8sClient.Patch(ctx, updatedNode, patch)).To(Succeed())
+
+			reconcile()
+
+			Expect(getNode().Labels).NotTo(HaveKey(MIGConfigLabel))
+
+			condition := meta.FindStatusCondition(getMIGReconfiguration().Status.Conditions,
+				janitordgxcnvidiacomv1alpha1.MIGReconfigurationConditionInstancesReady)
+			Expect(condition).NotTo(BeNil())
+			Expect(condition.Reason).To(Equal("MIGNotConfigured"))
+		})
+	})
+
+	Context("When manual mode is enabled", func() {
+		BeforeEach(func() {
+			reconciler.Config.ManualMode = true
+		})
+
+		It("Should wait for approval", func() {
+			reconcile()
+			reconcile()
+
+			Expect(getNode().Labels).To(HaveKeyWithValue(MIGConfigLabel, "all-1g.10gb"))
+
+			migReconfiguration := getMIGReconfiguration()
+			Expect(migReconfiguration.Status.Phase).To(Equal(janitordgxcnvidiacomv1alpha1.ActionPhaseRequested))
+			Expect(meta.IsStatusConditionTrue(migReconfiguration.Status.Conditions,
+				janitordgxcnvidiacomv1alpha1.ManualModeConditionType)).To(BeTrue())
+		})
+	})
+})
@@ -0,0 +1,93 @@
+// Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controller
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/nvidia/nvsentinel/commons/pkg/nodelock"
+	"github.com/nvidia/nvsentinel/janitor/pkg/metrics"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+// nodeLockRetryDelay is how long an action waits before trying again to take the
+// lock of a node held by another action
+const nodeLockRetryDelay = 30 * time.Second
+
+// +kubebuilder:rbac:groups=coordination.k8s.io,resources=leases,verbs=get;create;update
+
+// lockHolder identifies an action as the holder of a node lock
+func lockHolder(kind, name string) string {
+	return kind + "/" + name
+}
+
+// acquireNodeLock takes the lock of the node for the action and records the fencing
+// token. It returns false when another action holds the node, the action then has to
+// wait for nodeLockRetryDelay. A nil locker disables locking.
+func acquireNodeLock(
+	ctx context.Context,
+	locker *nodelock.Locker,
+	actionType, nodeName, holder string,
+	token *int64,
+) (bool, error) {
+	if locker == nil {
+		return true, nil
+	}
+
+	acquired, err := locker.Acquire(ctx, nodeName, holder)
+	if errors.Is(err, nodelock.ErrHeld) {
+		log.FromContext(ctx).Info("node is locked by another action, waiting",
+			"node", nodeName,
+			"holder", holder,
+			"reason", err.Error())
+		metrics.GlobalMetrics.IncNodeLockContention(actionType)
+
+		return false, nil
+	}
+
+	if err != nil {
+		return false, err
+	}
+
+	*token = acquired
+
+	return true, nil
+}
+
+// checkNodeLock fences the action off the node unless it still holds the lock with
+// the token. It is called right before the destructive call.
+func checkNodeLock(ctx context.Context, locker *nodelock.Locker, nodeName, holder string, token int64) error {
+	if locker == nil {
+		return nil
+	}
+
+	return locker.Check(ctx, nodeName, holder, token)
+}
+
+// releaseNodeLock gives up the lock of the node once the action completed or was
+// deleted. Failing to release is not fatal, the lock then expires.
+func releaseNodeLock(ctx context.Context, locker *nodelock.Locker, nodeName, holder string) {
+	if locker == nil {
+		return
+	}
+
+	if err := locker.Release(ctx, nodeName, holder); err != nil {
+		log.FromContext(ctx).Error(err, "failed to release node lock, it will expire",
+			"node", nodeName,
+			"holder", holder)
+	}
+}
@@ -0,0 +1,79 @@
+// Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controller
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"k8s.io/client-go/kubernetes/fake"
+
+	"github.com/nvidia/nvsentinel/commons/pkg/nodelock"
+	"github.com/nvidia/nvsentinel/janitor/pkg/metrics"
+)
+
+func TestNodeLockSerializesActions(t *testing.T) {
+	ctx := context.Background()
+	locker := nodelock.NewLocker(fake.NewSimpleClientset(), "nvsentinel", time.Hour)
+
+	reboot := lockHolder("rebootnode", "reboot-node-1")
+	terminate := lockHolder("terminatenode", "terminate-node-1")
+
+	var rebootToken, terminateToken int64
+
+	locked, err := acquireNodeLock(ctx, locker, metrics.ActionTypeReboot, "node-1", reboot, &rebootToken)
+	if err != nil || !locked {
+		t.Fatalf("reboot did not lock the node: locked=%v err=%v", locked, err)
+	}
+
+	locked, err = acquireNodeLock(ctx, locker, metrics.ActionTypeTerminate, "node-1", terminate, &terminateToken)
+	if err != nil || locked {
+		t.Fatalf("terminate locked a node held by the reboot: locked=%v err=%v", locked, err)
+	}
+
+	if err := checkNodeLock(ctx, locker, "node-1", reboot, rebootToken); err != nil {
+		t.Fatalf("reboot lost its lock: %v", err)
+	}
+
+	releaseNodeLock(ctx, locker, "node-1", reboot)
+
+	locked, err = acquireNodeLock(ctx, locker, metrics.ActionTypeTerminate, "node-1", terminate, &terminateToken)
+	if err != nil || !locked {
+		t.Fatalf("terminate did not lock the released node: locked=%v err=%v", locked, err)
+	}
+
+	if terminateToken <= rebootToken {
+		t.Errorf("fencing token did not increase: reboot=%d terminate=%d", rebootToken, terminateToken)
+	}
+
+	// the released holder is fenced off
+	if err := checkNodeLock(ctx, locker, "node-1", reboot, rebootToken); err == nil {
+		t.Error("reboot still holds the lock after releasing it")
+	}
+}
+
+func TestNodeLockDisabled(t *testing.T) {
+	var token int64
+
+	locked, err := acquireNodeLock(context.Background(), nil, metrics.ActionTypeReboot, "node-1", "rebootnode/a", &token)
+	if err != nil || !locked {
+		t.Fatalf("nil locker must not block actions: locked=%v err=%v", locked, err)
+	}
+
+	if err := checkNodeLock(context.Background(), nil, "node-1", "rebootnode/a", token); err != nil {
+		t.Fatalf("nil locker must not fence actions: %v", err)
+	}
+}
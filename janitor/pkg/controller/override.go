@@ -0,0 +1,121 @@
+// Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controller
+
+import (
+	"context"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	janitordgxcnvidiacomv1alpha1 "github.com/nvidia/nvsentinel/janitor/api/v1alpha1"
+)
+
+const (
+	// cancelledReason is the condition reason of actions cancelled by an operator
+	cancelledReason = "Cancelled"
+	// forceFailedReason is the condition reason of actions force failed by an operator
+	forceFailedReason = "ForceFailed"
+	// approvalTimeoutReason is the condition reason of manual mode actions nobody performed
+	approvalTimeoutReason = "ApprovalTimeout"
+)
+
+// nodeAction is implemented by the node action CRDs that can be ended early
+type nodeAction interface {
+	metav1.Object
+	SetCondition(condition metav1.Condition)
+	SetCompletionTime()
+}
+
+// operatorOverride returns the condition reason and message when an operator cancelled
+// or force failed the action through its annotations.
+func operatorOverride(action metav1.Object) (string, string, bool) {
+	annotations := action.GetAnnotations()
+
+	if message, ok := annotations[janitordgxcnvidiacomv1alpha1.ForceFailAnnotation]; ok {
+		if message == "" {
+			message = "Action force failed by operator"
+		}
+
+		return forceFailedReason, message, true
+	}
+
+	if message, ok := annotations[janitordgxcnvidiacomv1alpha1.CancelAnnotation]; ok {
+		if message == "" {
+			message = "Action cancelled by operator"
+		}
+
+		return cancelledReason, message, true
+	}
+
+	return "", "", false
+}
+
+// approved returns true if an operator approved a manual mode action, so the
+// controller performs it instead of waiting for an outside actor.
+func approved(action metav1.Object) bool {
+	_, ok := action.GetAnnotations()[janitordgxcnvidiacomv1alpha1.ApproveAnnotation]
+	return ok
+}
+
+// approvalTimedOut returns true if a manual mode action waited longer than the approval
+// timeout for an outside actor.
+func approvalTimedOut(phase janitordgxcnvidiacomv1alpha1.ActionPhase, startTime *metav1.Time,
+	approvalTimeout time.Duration) bool {
+	return approvalTimeout > 0 &&
+		phase == janitordgxcnvidiacomv1alpha1.ActionPhaseRequested &&
+		startTime != nil &&
+		time.Since(startTime.Time) > approvalTimeout
+}
+
+// failForHumanAttention ends the action: the result condition is set to False with the
+// given reason, the action is flagged as needing human attention and moved to Failed.
+func failForHumanAttention(
+	ctx context.Context,
+	action nodeAction,
+	phase *janitordgxcnvidiacomv1alpha1.ActionPhase,
+	nodeName string,
+	resultConditionType string,
+	reason string,
+	message string,
+) {
+	now := metav1.Now()
+
+	action.SetCompletionTime()
+	action.SetCondition(metav1.Condition{
+		Type:               resultConditionType,
+		Status:             metav1.ConditionFalse,
+		Reason:             reason,
+		Message:            message,
+		LastTransitionTime: now,
+	})
+	action.SetCondition(metav1.Condition{
+		Type:               janitordgxcnvidiacomv1alpha1.NeedsHumanAttentionConditionType,
+		Status:             metav1.ConditionTrue,
+		Reason:             reason,
+		Message:            message,
+		LastTransitionTime: now,
+	})
+	setPhase(ctx, phase, janitordgxcnvidiacomv1alpha1.ActionPhaseFailed, nodeName)
+}
+
+// signalTimeoutOrDefault returns the configured CSP signal timeout or CSPOperationTimeout.
+func signalTimeoutOrDefault(timeout time.Duration) time.Duration {
+	if timeout <= 0 {
+		return CSPOperationTimeout
+	}
+
+	return timeout
+}
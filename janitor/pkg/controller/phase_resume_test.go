@@ -0,0 +1,299 @@
+// Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controller
+
+import (
+	"context"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	janitordgxcnvidiacomv1alpha1 "github.com/nvidia/nvsentinel/janitor/api/v1alpha1"
+	"github.com/nvidia/nvsentinel/janitor/pkg/config"
+)
+
+var _ = Describe("Node action phases and overrides", func() {
+	var (
+		ctx       context.Context
+		k8sClient client.Client
+		scheme    *runtime.Scheme
+		req       reconcile.Request
+	)
+
+	newNode := func(bootID string) *corev1.Node {
+		return &corev1.Node{
+			ObjectMeta: metav1.ObjectMeta{Name: "phase-node"},
+			Status: corev1.NodeStatus{
+				NodeInfo: corev1.NodeSystemInfo{BootID: bootID},
+				Conditions: []corev1.NodeCondition{
+					{Type: corev1.NodeReady, Status: corev1.ConditionTrue},
+				},
+			},
+		}
+	}
+
+	BeforeEach(func() {
+		ctx = context.Background()
+
+		scheme = runtime.NewScheme()
+		Expect(corev1.AddToScheme(scheme)).To(Succeed())
+		Expect(janitordgxcnvidiacomv1alpha1.AddToScheme(scheme)).To(Succeed())
+
+		req = reconcile.Request{NamespacedName: types.NamespacedName{Name: "phase-action"}}
+	})
+
+	Context("RebootNode", func() {
+		var mockCSP *mockCSPClient
+
+		newReconciler := func(node *corev1.Node, status janitordgxcnvidiacomv1alpha1.RebootNodeStatus) *RebootNodeReconciler {
+			rebootNode := &janitordgxcnvidiacomv1alpha1.RebootNode{
+				ObjectMeta: metav1.ObjectMeta{Name: req.Name},
+				Spec:       janitordgxcnvidiacomv1alpha1.RebootNodeSpec{NodeName: node.Name},
+				Status:     status,
+			}
+
+			k8sClient = fake.NewClientBuilder().
+				WithScheme(scheme).
+				WithObjects(node, rebootNode).
+				WithStatusSubresource(&janitordgxcnvidiacomv1alpha1.RebootNode{}).
+				Build()
+
+			mockCSP = &mockCSPClient{sendRebootSignalResult: "phase-ref", isNodeReadyResult: true}
+
+			return &RebootNodeReconciler{
+				Client:    k8sClient,
+				Scheme:    scheme,
+				CSPClient: mockCSP,
+				Config:    &config.RebootNodeControllerConfig{Timeout: 30 * time.Minute},
+			}
+		}
+
+		get := func() *janitordgxcnvidiacomv1alpha1.RebootNode {
+			var updated janitordgxcnvidiacomv1alpha1.RebootNode
+			Expect(k8sClient.Get(ctx, req.NamespacedName, &updated)).To(Succeed())
+			checkStatusConditions(updated.Status.Conditions)
+
+			return &updated
+		}
+
+		It("walks through executing and verifying to done", func() {
+			r := newReconciler(newNode("boot-1"), janitordgxcnvidiacomv1alpha1.RebootNodeStatus{})
+
+			_, err := r.Reconcile(ctx, req)
+			Expect(err).NotTo(HaveOccurred())
+
+			updated := get()
+			Expect(updated.Status.Phase).To(Equal(janitordgxcnvidiacomv1alpha1.ActionPhaseVerifying))
+			Expect(updated.Status.SignalBootID).To(Equal("boot-1"))
+			Expect(updated.Status.SignalTime).NotTo(BeNil())
+
+			_, err = r.Reconcile(ctx, req)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(get().Status.Phase).To(Equal(janitordgxcnvidiacomv1alpha1.ActionPhaseDone))
+			Expect(mockCSP.sendRebootSignalCalled).To(Equal(1))
+		})
+
+		It("resumes verification without re-firing when the node rebooted during a restart", func() {
+			startTime := metav1.NewTime(time.Now().Add(-time.Minute))
+			r := newReconciler(newNode("boot-2"), janitordgxcnvidiacomv1alpha1.RebootNodeStatus{
+				StartTime:    &startTime,
+				Phase:        janitordgxcnvidiacomv1alpha1.ActionPhaseExecuting,
+				SignalBootID: "boot-1",
+			})
+
+			result, err := r.Reconcile(ctx, req)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result.RequeueAfter).To(Equal(30 * time.Second))
+			Expect(mockCSP.sendRebootSignalCalled).To(BeZero())
+
+			updated := get()
+			Expect(updated.Status.Phase).To(Equal(janitordgxcnvidiacomv1alpha1.ActionPhaseVerifying))
+			Expect(findCondition(updated.Status.Conditions,
+				janitordgxcnvidiacomv1alpha1.RebootNodeConditionSignalSent).Reason).To(Equal(rebootSignalResumedReason))
+
+			// The CSP has no request to poll, readiness comes from the node alone
+			mockCSP.isNodeReadyError = context.Canceled
+
+			_, err = r.Reconcile(ctx, req)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(get().Status.Phase).To(Equal(janitordgxcnvidiacomv1alpha1.ActionPhaseDone))
+			Expect(mockCSP.sendRebootSignalCalled).To(BeZero())
+		})
+
+		It("waits for a recent signal to take effect before re-sending it", func() {
+			signalTime := metav1.NewTime(time.Now().Add(-time.Minute))
+			r := newReconciler(newNode("boot-1"), janitordgxcnvidiacomv1alpha1.RebootNodeStatus{
+				Phase:        janitordgxcnvidiacomv1alpha1.ActionPhaseExecuting,
+				SignalBootID: "boot-1",
+				SignalTime:   &signalTime,
+			})
+
+			result, err := r.Reconcile(ctx, req)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result.RequeueAfter).To(Equal(30 * time.Second))
+			Expect(mockCSP.sendRebootSignalCalled).To(BeZero())
+			Expect(get().Status.Phase).To(Equal(janitordgxcnvidiacomv1alpha1.ActionPhaseExecuting))
+		})
+
+		It("re-sends the signal when the node did not reboot within the grace period", func() {
+			signalTime := metav1.NewTime(time.Now().Add(-time.Hour))
+			r := newReconciler(newNode("boot-1"), janitordgxcnvidiacomv1alpha1.RebootNodeStatus{
+				Phase:        janitordgxcnvidiacomv1alpha1.ActionPhaseExecuting,
+				SignalBootID: "boot-1",
+				SignalTime:   &signalTime,
+			})
+
+			_, err := r.Reconcile(ctx, req)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(mockCSP.sendRebootSignalCalled).To(Equal(1))
+			Expect(get().Status.Phase).To(Equal(janitordgxcnvidiacomv1alpha1.ActionPhaseVerifying))
+		})
+
+		It("fails for human attention when cancelled by an operator", func() {
+			r := newReconciler(newNode("boot-1"), janitordgxcnvidiacomv1alpha1.RebootNodeStatus{})
+
+			_, err := r.Reconcile(ctx, req)
+			Expect(err).NotTo(HaveOccurred())
+
+			updated := get()
+			updated.Annotations = map[string]string{janitordgxcnvidiacomv1alpha1.CancelAnnotation: "fixed by hand"}
+			Expect(k8sClient.Update(ctx, updated)).To(Succeed())
+
+			result, err := r.Reconcile(ctx, req)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result.RequeueAfter).To(BeZero())
+
+			updated = get()
+			Expect(updated.Status.Phase).To(Equal(janitordgxcnvidiacomv1alpha1.ActionPhaseFailed))
+			Expect(updated.Status.CompletionTime).NotTo(BeNil())
+
+			nodeReady := findCondition(updated.Status.Conditions, janitordgxcnvidiacomv1alpha1.RebootNodeConditionNodeReady)
+			Expect(nodeReady.Status).To(Equal(metav1.ConditionFalse))
+			Expect(nodeReady.Reason).To(Equal(cancelledReason))
+			Expect(nodeReady.Message).To(Equal("fixed by hand"))
+
+			attention := findCondition(updated.Status.Conditions, janitordgxcnvidiacomv1alpha1.NeedsHumanAttentionConditionType)
+			Expect(attention).NotTo(BeNil())
+			Expect(attention.Status).To(Equal(metav1.ConditionTrue))
+		})
+
+		It("fails a manual mode action nobody performed within the approval timeout", func() {
+			startTime := metav1.NewTime(time.Now().Add(-time.Hour))
+			r := newReconciler(newNode("boot-1"), janitordgxcnvidiacomv1alpha1.RebootNodeStatus{
+				StartTime: &startTime,
+				Phase:     janitordgxcnvidiacomv1alpha1.ActionPhaseRequested,
+			})
+			r.Config.ManualMode = true
+			r.Config.ApprovalTimeout = 30 * time.Minute
+
+			_, err := r.Reconcile(ctx, req)
+			Expect(err).NotTo(HaveOccurred())
+
+			updated := get()
+			Expect(updated.Status.Phase).To(Equal(janitordgxcnvidiacomv1alpha1.ActionPhaseFailed))
+			Expect(findCondition(updated.Status.Conditions,
+				janitordgxcnvidiacomv1alpha1.NeedsHumanAttentionConditionType).Reason).To(Equal(approvalTimeoutReason))
+		})
+
+		It("stays requested in manual mode", func() {
+			r := newReconciler(newNode("boot-1"), janitordgxcnvidiacomv1alpha1.RebootNodeStatus{})
+			r.Config.ManualMode = true
+
+			_, err := r.Reconcile(ctx, req)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(get().Status.Phase).To(Equal(janitordgxcnvidiacomv1alpha1.ActionPhaseRequested))
+			Expect(mockCSP.sendRebootSignalCalled).To(BeZero())
+		})
+	})
+
+	Context("TerminateNode", func() {
+		It("fails for human attention when force failed by an operator", func() {
+			terminateNode := &janitordgxcnvidiacomv1alpha1.TerminateNode{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        req.Name,
+					Annotations: map[string]string{janitordgxcnvidiacomv1alpha1.ForceFailAnnotation: ""},
+				},
+				Spec: janitordgxcnvidiacomv1alpha1.TerminateNodeSpec{NodeName: "phase-node"},
+			}
+
+			k8sClient = fake.NewClientBuilder().
+				WithScheme(scheme).
+				WithObjects(newNode("boot-1"), terminateNode).
+				WithStatusSubresource(&janitordgxcnvidiacomv1alpha1.TerminateNode{}).
+				Build()
+
+			mockCSP := &MockCSPClient{}
+			r := &TerminateNodeReconciler{
+				Client:    k8sClient,
+				Scheme:    scheme,
+				CSPClient: mockCSP,
+				Config:    &config.TerminateNodeControllerConfig{Timeout: 30 * time.Minute},
+			}
+
+			_, err := r.Reconcile(ctx, req)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(mockCSP.terminateSignalSent).To(BeFalse())
+
+			var updated janitordgxcnvidiacomv1alpha1.TerminateNode
+			Expect(k8sClient.Get(ctx, req.NamespacedName, &updated)).To(Succeed())
+			checkStatusConditions(updated.Status.Conditions)
+			Expect(updated.Status.Phase).To(Equal(janitordgxcnvidiacomv1alpha1.ActionPhaseFailed))
+			Expect(findCondition(updated.Status.Conditions,
+				janitordgxcnvidiacomv1alpha1.TerminateNodeConditionNodeTerminated).Reason).To(Equal(forceFailedReason))
+		})
+
+		It("completes when the node was removed during a restart", func() {
+			terminateNode := &janitordgxcnvidiacomv1alpha1.TerminateNode{
+				ObjectMeta: metav1.ObjectMeta{Name: req.Name},
+				Spec:       janitordgxcnvidiacomv1alpha1.TerminateNodeSpec{NodeName: "phase-node"},
+				Status: janitordgxcnvidiacomv1alpha1.TerminateNodeStatus{
+					Phase: janitordgxcnvidiacomv1alpha1.ActionPhaseExecuting,
+				},
+			}
+
+			k8sClient = fake.NewClientBuilder().
+				WithScheme(scheme).
+				WithObjects(terminateNode).
+				WithStatusSubresource(&janitordgxcnvidiacomv1alpha1.TerminateNode{}).
+				Build()
+
+			mockCSP := &MockCSPClient{}
+			r := &TerminateNodeReconciler{
+				Client:    k8sClient,
+				Scheme:    scheme,
+				CSPClient: mockCSP,
+				Config:    &config.TerminateNodeControllerConfig{Timeout: 30 * time.Minute},
+			}
+
+			_, err := r.Reconcile(ctx, req)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(mockCSP.terminateSignalSent).To(BeFalse())
+
+			var updated janitordgxcnvidiacomv1alpha1.TerminateNode
+			Expect(k8sClient.Get(ctx, req.NamespacedName, &updated)).To(Succeed())
+			checkStatusConditions(updated.Status.Conditions)
+			Expect(updated.Status.Phase).To(Equal(janitordgxcnvidiacomv1alpha1.ActionPhaseDone))
+			Expect(updated.Status.CompletionTime).NotTo(BeNil())
+		})
+	})
+})
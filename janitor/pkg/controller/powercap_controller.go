@@ -0,0 +1,772 @@
+// Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// nolint:wsl,lll,gocognit,cyclop,gocyclo,nestif // Mirrors the GPUReset controller
+package controller
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/utils/ptr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	janitordgxcnvidiacomv1alpha1 "github.com/nvidia/nvsentinel/janitor/api/v1alpha1"
+	"github.com/nvidia/nvsentinel/janitor/pkg/config"
+	"github.com/nvidia/nvsentinel/janitor/pkg/metrics"
+	"github.com/nvidia/nvsentinel/janitor/pkg/powercap"
+)
+
+const (
+	// PowerCapFinalizer is added to PowerCap objects to handle cleanup
+	PowerCapFinalizer = "janitor.dgxc.nvidia.com/powercap-finalizer"
+
+	// MaxPowerCapRequestFailures is the number of failed Job creations before giving up
+	MaxPowerCapRequestFailures = 5
+
+	// powerCapJobGracePeriod is the time a cap or restore Job gets to be scheduled and
+	// to start on top of its timeout
+	powerCapJobGracePeriod = 5 * time.Minute
+
+	powerCapJobPrefix        = "power-cap-"
+	powerCapRestoreJobPrefix = "power-cap-restore-"
+	powerCapHostRoot         = "/host"
+)
+
+// updatePowerCapStatus is a helper function that handles status updates with proper error handling.
+// It delegates to the generic updateNodeActionStatus function.
+func (r *PowerCapReconciler) updatePowerCapStatus(
+	ctx context.Context,
+	original *janitordgxcnvidiacomv1alpha1.PowerCap,
+	updated *janitordgxcnvidiacomv1alpha1.PowerCap,
+	result ctrl.Result,
+) (ctrl.Result, error) {
+	return updateNodeActionStatus(
+		ctx,
+		r.Status(),
+		original,
+		updated,
+		&original.Status,
+		&updated.Status,
+		updated.Spec.NodeName,
+		"powercap",
+		result,
+	)
+}
+
+// PowerCapReconciler reconciles a PowerCap object. A privileged Job on the node running
+// `janitor power-cap` caps the power limit of the GPUs with nvidia-smi, the original
+// limits are recorded in the status and in an annotation of the node, and the cap is
+// held until the node condition of the thermal fault has not been true for the settle
+// time. A second Job then restores the original limits. The node is neither cordoned
+// nor drained and, as the cap is not destructive, not locked either.
+type PowerCapReconciler struct {
+	client.Client
+	Scheme *runtime.Scheme
+	Config *config.PowerCapControllerConfig
+	// APIReader reads the Jobs and their pods without caching all Jobs and pods of the
+	// cluster, defaults to the client
+	APIReader client.Reader
+}
+
+// +kubebuilder:rbac:groups=janitor.dgxc.nvidia.com,resources=powercaps,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=janitor.dgxc.nvidia.com,resources=powercaps/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=janitor.dgxc.nvidia.com,resources=powercaps/finalizers,verbs=update
+// +kubebuilder:rbac:groups=batch,resources=jobs,verbs=get;list;create;delete
+// +kubebuilder:rbac:groups=core,resources=nodes,verbs=get;list;watch;patch
+// +kubebuilder:rbac:groups=core,resources=pods,verbs=get;list
+
+// Reconcile is part of the main kubernetes reconciliation loop which aims to
+// move the current state of the cluster closer to the desired state.
+func (r *PowerCapReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	logger := log.FromContext(ctx)
+
+	var powerCap janitordgxcnvidiacomv1alpha1.PowerCap
+	if err := r.Get(ctx, req.NamespacedName, &powerCap); err != nil {
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+
+	// Handle deletion with finalizer
+	if !powerCap.DeletionTimestamp.IsZero() {
+		if controllerutil.ContainsFinalizer(&powerCap, PowerCapFinalizer) {
+			// A cap that was not lifted stays recorded in the annotation of the node
+			logger.Info("powercap deletion requested, performing cleanup",
+				"node", powerCap.Spec.NodeName,
+				"capped", powerCap.IsCapped(),
+				"conditions", powerCap.Status.Conditions)
+
+			controllerutil.RemoveFinalizer(&powerCap, PowerCapFinalizer)
+
+			if err := r.Update(ctx, &powerCap); err != nil {
+				return ctrl.Result{}, err
+			}
+		}
+
+		return ctrl.Result{}, nil
+	}
+
+	// Add finalizer if not present
+	if !controllerutil.ContainsFinalizer(&powerCap, PowerCapFinalizer) {
+		controllerutil.AddFinalizer(&powerCap, PowerCapFinalizer)
+
+		if err := r.Update(ctx, &powerCap); err != nil {
+			return ctrl.Result{}, err
+		}
+	}
+
+	if powerCap.Status.CompletionTime != nil {
+		logger.V(1).Info("powercap has completion time set, skipping reconcile",
+			"node", powerCap.Spec.NodeName)
+
+		return ctrl.Result{}, nil
+	}
+
+	// Take a deep copy to compare against at the end
+	originalPowerCap := powerCap.DeepCopy()
+
+	powerCap.SetInitialConditions()
+	powerCap.SetStartTime()
+
+	if powerCap.Status.Phase == "" {
+		setPhase(ctx, &powerCap.Status.Phase, janitordgxcnvidiacomv1alpha1.ActionPhaseRequested,
+			powerCap.Spec.NodeName)
+	}
+
+	// Operators can cancel or force fail a stuck action through annotations
+	if reason, message, ok := operatorOverride(&powerCap); ok {
+		logger.Info("powercap ended by operator",
+			"node", powerCap.Spec.NodeName,
+			"reason", reason,
+			"message", message)
+
+		r.fail(ctx, &powerCap, reason, message)
+
+		return r.updatePowerCapStatus(ctx, originalPowerCap, &powerCap, ctrl.Result{})
+	}
+
+	if approvalTimedOut(powerCap.Status.Phase, powerCap.Status.StartTime, r.Config.ApprovalTimeout) {
+		logger.Info("no outside actor approved the power cap within the approval timeout",
+			"node", powerCap.Spec.NodeName,
+			"approvalTimeout", r.Config.ApprovalTimeout)
+
+		r.fail(ctx, &powerCap, approvalTimeoutReason,
+			fmt.Sprintf("Power cap was not approved within the approval timeout of %s", r.Config.ApprovalTimeout))
+
+		return r.updatePowerCapStatus(ctx, originalPowerCap, &powerCap, ctrl.Result{})
+	}
+
+	var node corev1.Node
+	if err := r.Get(ctx, client.ObjectKey{Name: powerCap.Spec.NodeName}, &node); err != nil {
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+
+	var result ctrl.Result
+
+	switch {
+	case powerCap.IsCapped() && powerCap.Status.JobName != "":
+		result = r.verifyRestore(ctx, &powerCap, &node)
+	case powerCap.IsCapped():
+		result = r.holdCap(ctx, &powerCap, &node)
+	case powerCap.Status.JobName != "":
+		result = r.verifyCap(ctx, &powerCap, &node)
+	case r.Config.ManualMode && !approved(&powerCap):
+		result = r.awaitApproval(ctx, &powerCap, &node)
+	default:
+		result = r.startCap(ctx, &powerCap, &node)
+	}
+
+	return r.updatePowerCapStatus(ctx, originalPowerCap, &powerCap, result)
+}
+
+// startCap creates the Job capping the power limits of the GPUs on the node
+func (r *PowerCapReconciler) startCap(
+	ctx context.Context,
+	powerCap *janitordgxcnvidiacomv1alpha1.PowerCap,
+	node *corev1.Node,
+) ctrl.Result {
+	logger := log.FromContext(ctx)
+
+	// The original limits of a node still capped by an earlier PowerCap are unknown
+	if record, ok := node.Annotations[janitordgxcnvidiacomv1alpha1.PowerCapAnnotation]; ok {
+		logger.Info("node is still capped by an earlier power cap",
+			"node", node.Name,
+			"record", record)
+
+		r.fail(ctx, powerCap, "NodeAlreadyCapped",
+			fmt.Sprintf("Node carries the %s annotation of an earlier power cap that was not lifted: %s",
+				janitordgxcnvidiacomv1alpha1.PowerCapAnnotation, record))
+
+		return ctrl.Result{}
+	}
+
+	args := []string{"power-cap", "--limit-percent=" + strconv.Itoa(int(r.getLimitPercent(powerCap)))}
+
+	if selector := powerCap.Spec.Selector; selector != nil {
+		if len(selector.UUIDs) > 0 {
+			args = append(args, "--uuids="+strings.Join(selector.UUIDs, ","))
+		}
+
+		if len(selector.PCIBusIDs) > 0 {
+			args = append(args, "--pci-bus-ids="+strings.Join(selector.PCIBusIDs, ","))
+		}
+	}
+
+	if !r.createJob(ctx, powerCap, node, powerCapJobName(powerCapJobPrefix, powerCap.Name), args) {
+		return ctrl.Result{RequeueAfter: getNextRequeueDelay(powerCap.Status.ConsecutiveFailures)}
+	}
+
+	if powerCap.Status.Phase == janitordgxcnvidiacomv1alpha1.ActionPhaseFailed {
+		return ctrl.Result{}
+	}
+
+	setPhase(ctx, &powerCap.Status.Phase, janitordgxcnvidiacomv1alpha1.ActionPhaseApproved, node.Name)
+	setPhase(ctx, &powerCap.Status.Phase, janitordgxcnvidiacomv1alpha1.ActionPhaseExecuting, node.Name)
+
+	metrics.GlobalMetrics.IncActionCount(metrics.ActionTypePowerCap, metrics.StatusStarted, node.Name)
+
+	powerCap.SetCondition(metav1.Condition{
+		Type:               janitordgxcnvidiacomv1alpha1.PowerCapConditionCapped,
+		Status:             metav1.ConditionFalse,
+		Reason:             "Capping",
+		Message:            fmt.Sprintf("Job %s/%s caps the power limits of the GPUs", r.Config.JobNamespace, powerCap.Status.JobName),
+		LastTransitionTime: metav1.Now(),
+	})
+
+	return ctrl.Result{RequeueAfter: 30 * time.Second}
+}
+
+// awaitApproval waits in manual mode until an outside actor approved the power cap
+func (r *PowerCapReconciler) awaitApproval(
+	ctx context.Context,
+	powerCap *janitordgxcnvidiacomv1alpha1.PowerCap,
+	node *corev1.Node,
+) ctrl.Result {
+	logger := log.FromContext(ctx)
+
+	if powerCap.GetCondition(janitordgxcnvidiacomv1alpha1.ManualModeConditionType) == nil {
+		powerCap.SetCondition(metav1.Condition{
+			Type:               janitordgxcnvidiacomv1alpha1.ManualModeConditionType,
+			Status:             metav1.ConditionTrue,
+			Reason:             "OutsideActorRequired",
+			Message:            "Janitor is in manual mode, outside actor required to approve the power cap",
+			LastTransitionTime: metav1.Now(),
+		})
+		metrics.GlobalMetrics.IncActionCount(metrics.ActionTypePowerCap, metrics.StatusStarted, node.Name)
+	}
+
+	logger.Info("manual mode enabled, janitor will not cap the GPUs until approved",
+		"node", node.Name)
+
+	return ctrl.Result{RequeueAfter: 30 * time.Second}
+}
+
+// verifyCap follows the cap Job and records the limits it applied
+func (r *PowerCapReconciler) verifyCap(
+	ctx context.Context,
+	powerCap *janitordgxcnvidiacomv1alpha1.PowerCap,
+	node *corev1.Node,
+) ctrl.Result {
+	logger := log.FromContext(ctx)
+
+	done, message, result := r.followJob(ctx, powerCap, node)
+	if !done {
+		return result
+	}
+
+	var limits []janitordgxcnvidiacomv1alpha1.GPUPowerLimit
+	if err := json.Unmarshal([]byte(message), &limits); err != nil {
+		// The GPUs may be capped, but the limits to restore are unknown
+		r.fail(ctx, powerCap, "InvalidJobResult",
+			fmt.Sprintf("Job %s/%s reported unexpected power limits %q: %v",
+				r.Config.JobNamespace, powerCap.Status.JobName, message, err))
+
+		return ctrl.Result{}
+	}
+
+	record, err := json.Marshal(janitordgxcnvidiacomv1alpha1.PowerCapRecord{PowerCap: powerCap.Name, GPUs: limits})
+	if err != nil {
+		logger.Error(err, "failed to marshal the power cap record",
+			"node", node.Name)
+
+		return ctrl.Result{RequeueAfter: 30 * time.Second}
+	}
+
+	patch := client.MergeFrom(node.DeepCopy())
+
+	if node.Annotations == nil {
+		node.Annotations = map[string]string{}
+	}
+
+	node.Annotations[janitordgxcnvidiacomv1alpha1.PowerCapAnnotation] = string(record)
+
+	if err := r.Patch(ctx, node, patch); err != nil {
+		logger.Error(err, "failed to annotate node with the power cap, will retry",
+			"node", node.Name)
+
+		powerCap.Status.ConsecutiveFailures++
+
+		return ctrl.Result{RequeueAfter: getNextRequeueDelay(powerCap.Status.ConsecutiveFailures)}
+	}
+
+	logger.Info("GPU power capped",
+		"node", node.Name,
+		"limits", string(record))
+
+	now := metav1.Now()
+	powerCap.Status.ConsecutiveFailures = 0
+	powerCap.Status.JobName = ""
+	powerCap.Status.GPUs = limits
+	powerCap.Status.CappedTime = &now
+	powerCap.SetCondition(metav1.Condition{
+		Type:               janitordgxcnvidiacomv1alpha1.PowerCapConditionCapped,
+		Status:             metav1.ConditionTrue,
+		Reason:             "Capped",
+		Message:            fmt.Sprintf("Power limits of %d GPUs capped", len(limits)),
+		LastTransitionTime: now,
+	})
+	setPhase(ctx, &powerCap.Status.Phase, janitordgxcnvidiacomv1alpha1.ActionPhaseVerifying, node.Name)
+
+	return ctrl.Result{RequeueAfter: 30 * time.Second}
+}
+
+// holdCap holds the cap until the thermals of the node are back to normal and then
+// creates the Job restoring the original power limits
+func (r *PowerCapReconciler) holdCap(
+	ctx context.Context,
+	powerCap *janitordgxcnvidiacomv1alpha1.PowerCap,
+	node *corev1.Node,
+) ctrl.Result {
+	logger := log.FromContext(ctx)
+
+	if !r.thermalsNormalized(ctx, powerCap, node) {
+		return ctrl.Result{RequeueAfter: 30 * time.Second}
+	}
+
+	logger.Info("thermals normalized, lifting the power cap",
+		"node", node.Name,
+		"cappedFor", time.Since(powerCap.Status.CappedTime.Time))
+
+	args := []string{"power-cap", "--restore=" + powercap.FormatLimits(powerCap.Status.GPUs)}
+
+	if !r.createJob(ctx, powerCap, node, powerCapJobName(powerCapRestoreJobPrefix, powerCap.Name), args) {
+		return ctrl.Result{RequeueAfter: getNextRequeueDelay(powerCap.Status.ConsecutiveFailures)}
+	}
+
+	if powerCap.Status.Phase == janitordgxcnvidiacomv1alpha1.ActionPhaseFailed {
+		return ctrl.Result{}
+	}
+
+	powerCap.SetCondition(metav1.Condition{
+		Type:               janitordgxcnvidiacomv1alpha1.PowerCapConditionReleased,
+		Status:             metav1.ConditionUnknown,
+		Reason:             "Releasing",
+		Message:            fmt.Sprintf("Job %s/%s restores the power limits of the GPUs", r.Config.JobNamespace, powerCap.Status.JobName),
+		LastTransitionTime: metav1.Now(),
+	})
+
+	return ctrl.Result{RequeueAfter: 30 * time.Second}
+}
+
+// thermalsNormalized returns true once the node condition of the thermal fault has
+// not been true for the settle time, or the cap was held for the hold duration when
+// the PowerCap names no condition. The progress is recorded in the Capped condition.
+func (r *PowerCapReconciler) thermalsNormalized(
+	ctx context.Context,
+	powerCap *janitordgxcnvidiacomv1alpha1.PowerCap,
+	node *corev1.Node,
+) bool {
+	conditionType := powerCap.Spec.ConditionType
+	if conditionType == "" {
+		return time.Since(powerCap.Status.CappedTime.Time) >= r.getHoldDuration()
+	}
+
+	faulty := false
+
+	for _, condition := range node.Status.Conditions {
+		if string(condition.Type) == conditionType {
+			faulty = condition.Status == corev1.ConditionTrue
+		}
+	}
+
+	capped := powerCap.GetCondition(janitordgxcnvidiacomv1alpha1.PowerCapConditionCapped)
+
+	if faulty {
+		if powerCap.Status.NormalizedTime != nil {
+			log.FromContext(ctx).Info("thermal fault is back, holding the power cap",
+				"node", node.Name,
+				"condition", conditionType)
+
+			powerCap.Status.NormalizedTime = nil
+			capped.Message = fmt.Sprintf("Power limits of %d GPUs capped, node condition %s is true",
+				len(powerCap.Status.GPUs), conditionType)
+		}
+
+		return false
+	}
+
+	if powerCap.Status.NormalizedTime == nil {
+		now := metav1.Now()
+		powerCap.Status.NormalizedTime = &now
+		capped.Message = fmt.Sprintf("Power limits of %d GPUs capped, node condition %s normalized, lifting the cap after %s",
+			len(powerCap.Status.GPUs), conditionType, r.getSettleTime())
+	}
+
+	return time.Since(powerCap.Status.NormalizedTime.Time) >= r.getSettleTime()
+}
+
+// verifyRestore follows the restore Job and completes the power cap
+func (r *PowerCapReconciler) verifyRestore(
+	ctx context.Context,
+	powerCap *janitordgxcnvidiacomv1alpha1.PowerCap,
+	node *corev1.Node,
+) ctrl.Result {
+	logger := log.FromContext(ctx)
+
+	done, message, result := r.followJob(ctx, powerCap, node)
+	if !done {
+		return result
+	}
+
+	if value, ok := node.Annotations[janitordgxcnvidiacomv1alpha1.PowerCapAnnotation]; ok {
+		var record janitordgxcnvidiacomv1alpha1.PowerCapRecord
+
+		// The annotation of another PowerCap is left alone
+		if err := json.Unmarshal([]byte(value), &record); err != nil || record.PowerCap == powerCap.Name {
+			patch := client.MergeFrom(node.DeepCopy())
+			delete(node.Annotations, janitordgxcnvidiacomv1alpha1.PowerCapAnnotation)
+
+			if err := r.Patch(ctx, node, patch); err != nil {
+				logger.Error(err, "failed to remove the power cap annotation of the node, will retry",
+					"node", node.Name)
+
+				powerCap.Status.ConsecutiveFailures++
+
+				return ctrl.Result{RequeueAfter: getNextRequeueDelay(powerCap.Status.ConsecutiveFailures)}
+			}
+		}
+	}
+
+	logger.Info("GPU power limits restored",
+		"node", node.Name,
+		"result", message,
+		"duration", time.Since(powerCap.Status.StartTime.Time))
+
+	now := metav1.Now()
+	powerCap.Status.ConsecutiveFailures = 0
+	powerCap.SetCompletionTime()
+	powerCap.SetCondition(metav1.Condition{
+		Type:               janitordgxcnvidiacomv1alpha1.PowerCapConditionCapped,
+		Status:             metav1.ConditionFalse,
+		Reason:             "Restored",
+		Message:            message,
+		LastTransitionTime: now,
+	})
+	powerCap.SetCondition(metav1.Condition{
+		Type:               janitordgxcnvidiacomv1alpha1.PowerCapConditionReleased,
+		Status:             metav1.ConditionTrue,
+		Reason:             "Succeeded",
+		Message:            message,
+		LastTransitionTime: now,
+	})
+
+	metrics.GlobalMetrics.IncActionCount(metrics.ActionTypePowerCap, metrics.StatusSucceeded, node.Name)
+	metrics.GlobalMetrics.RecordActionMTTR(metrics.ActionTypePowerCap, time.Since(powerCap.Status.StartTime.Time))
+	setPhase(ctx, &powerCap.Status.Phase, janitordgxcnvidiacomv1alpha1.ActionPhaseDone, node.Name)
+
+	return ctrl.Result{}
+}
+
+// createJob creates the cap or restore Job and records it in the status. It returns
+// false when the creation should be retried, and fails the power cap after too many
+// failures.
+func (r *PowerCapReconciler) createJob(
+	ctx context.Context,
+	powerCap *janitordgxcnvidiacomv1alpha1.PowerCap,
+	node *corev1.Node,
+	name string,
+	args []string,
+) bool {
+	logger := log.FromContext(ctx)
+
+	if powerCap.Status.ConsecutiveFailures >= MaxPowerCapRequestFailures {
+		logger.Info("max Job creation failures exceeded, marking as failed",
+			"node", node.Name,
+			"failures", int(powerCap.Status.ConsecutiveFailures))
+
+		r.fail(ctx, powerCap, "MaxRetriesExceeded",
+			fmt.Sprintf("Power cap Job could not be created after %d attempts", MaxPowerCapRequestFailures))
+
+		return true
+	}
+
+	job, err := r.newPowerCapJob(powerCap, name, args)
+	if err != nil {
+		r.fail(ctx, powerCap, "InvalidJob", err.Error())
+		return true
+	}
+
+	logger.Info("creating power cap Job",
+		"node", node.Name,
+		"job", job.Namespace+"/"+job.Name,
+		"args", args)
+
+	// The Job name is derived from the PowerCap, so a restart after the creation
+	// resumes with the existing Job
+	if err := r.Create(ctx, job); err != nil && !apierrors.IsAlreadyExists(err) {
+		logger.Error(err, "failed to create power cap Job, will retry",
+			"node", node.Name)
+
+		powerCap.Status.ConsecutiveFailures++
+
+		return false
+	}
+
+	powerCap.Status.ConsecutiveFailures = 0
+	powerCap.Status.JobName = job.Name
+
+	return true
+}
+
+// followJob follows the current Job of the power cap. It returns true with the
+// termination message of the Job once it completed, and fails the power cap if the
+// Job failed or timed out.
+func (r *PowerCapReconciler) followJob(
+	ctx context.Context,
+	powerCap *janitordgxcnvidiacomv1alpha1.PowerCap,
+	node *corev1.Node,
+) (bool, string, ctrl.Result) {
+	logger := log.FromContext(ctx)
+
+	powerCap.Status.RetryCount++
+
+	reader := r.APIReader
+	if reader == nil {
+		reader = r.Client
+	}
+
+	var job batchv1.Job
+	if err := reader.Get(ctx, client.ObjectKey{Namespace: r.Config.JobNamespace, Name: powerCap.Status.JobName},
+		&job); err != nil {
+		if apierrors.IsNotFound(err) {
+			logger.Info("power cap Job disappeared before it finished",
+				"node", node.Name,
+				"job", powerCap.Status.JobName)
+
+			r.fail(ctx, powerCap, "JobNotFound",
+				fmt.Sprintf("Job %s/%s was deleted before it finished", r.Config.JobNamespace, powerCap.Status.JobName))
+
+			return false, "", ctrl.Result{}
+		}
+
+		logger.Error(err, "failed to get power cap Job",
+			"node", node.Name)
+
+		powerCap.Status.ConsecutiveFailures++
+
+		return false, "", ctrl.Result{RequeueAfter: getNextRequeueDelay(powerCap.Status.ConsecutiveFailures)}
+	}
+
+	powerCap.Status.ConsecutiveFailures = 0
+
+	for _, condition := range job.Status.Conditions {
+		if condition.Status != corev1.ConditionTrue {
+			continue
+		}
+
+		switch condition.Type {
+		case batchv1.JobComplete:
+			return true, jobTerminationMessage(ctx, reader, &job), ctrl.Result{}
+		case batchv1.JobFailed:
+			message := jobTerminationMessage(ctx, reader, &job)
+			if message == "" {
+				message = condition.Message
+			}
+
+			logger.Info("power cap Job failed",
+				"node", node.Name,
+				"job", job.Name,
+				"reason", condition.Reason,
+				"message", message)
+
+			r.fail(ctx, powerCap, "JobFailed", fmt.Sprintf("Job %s/%s failed: %s", job.Namespace, job.Name, message))
+
+			return false, "", ctrl.Result{}
+		}
+	}
+
+	// The Job deadline normally fails it first, this covers a Job that never ran
+	if time.Since(job.CreationTimestamp.Time) > r.getTimeout()+2*powerCapJobGracePeriod {
+		logger.Error(nil, "power cap Job timed out",
+			"node", node.Name,
+			"job", job.Name,
+			"timeout", r.getTimeout())
+
+		r.fail(ctx, powerCap, "Timeout", fmt.Sprintf("Job %s/%s did not finish within the timeout duration", job.Namespace, job.Name))
+
+		return false, "", ctrl.Result{}
+	}
+
+	return false, "", ctrl.Result{RequeueAfter: 30 * time.Second}
+}
+
+// fail ends the power cap for human attention. A cap that was applied stays recorded
+// in the annotation of the node.
+func (r *PowerCapReconciler) fail(
+	ctx context.Context,
+	powerCap *janitordgxcnvidiacomv1alpha1.PowerCap,
+	reason string,
+	message string,
+) {
+	if powerCap.IsCapped() {
+		message += ", the GPUs are still capped"
+	}
+
+	failForHumanAttention(ctx, powerCap, &powerCap.Status.Phase, powerCap.Spec.NodeName,
+		janitordgxcnvidiacomv1alpha1.PowerCapConditionReleased, reason, message)
+	metrics.GlobalMetrics.IncActionCount(metrics.ActionTypePowerCap, metrics.StatusFailed, powerCap.Spec.NodeName)
+}
+
+// newPowerCapJob returns the Job running `janitor power-cap` with the arguments on the
+// node of the PowerCap
+func (r *PowerCapReconciler) newPowerCapJob(
+	powerCap *janitordgxcnvidiacomv1alpha1.PowerCap,
+	name string,
+	args []string,
+) (*batchv1.Job, error) {
+	hostPathDirectory := corev1.HostPathDirectory
+
+	job := &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: r.Config.JobNamespace,
+			Labels: map[string]string{
+				"app.kubernetes.io/name":       "power-cap",
+				"app.kubernetes.io/managed-by": "janitor",
+			},
+		},
+		Spec: batchv1.JobSpec{
+			BackoffLimit:            ptr.To[int32](0),
+			TTLSecondsAfterFinished: ptr.To[int32](3600),
+			ActiveDeadlineSeconds:   ptr.To(int64(r.getTimeout().Seconds())),
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: map[string]string{"app.kubernetes.io/name": "power-cap"},
+				},
+				Spec: corev1.PodSpec{
+					NodeName:      powerCap.Spec.NodeName,
+					RestartPolicy: corev1.RestartPolicyNever,
+					// The Job only runs nvidia-smi, it does not call the API server
+					AutomountServiceAccountToken: ptr.To(false),
+					// The node may be tainted by the fault that needs the cap
+					Tolerations: []corev1.Toleration{{Operator: corev1.TolerationOpExists}},
+					Containers: []corev1.Container{{
+						Name:  "power-cap",
+						Image: r.Config.JobImage,
+						Args:  args,
+						// nvidia-smi runs chrooted into the host to set the power limits
+						SecurityContext: &corev1.SecurityContext{Privileged: ptr.To(true)},
+						VolumeMounts: []corev1.VolumeMount{
+							{Name: "host-root", MountPath: powerCapHostRoot},
+						},
+						TerminationMessagePolicy: corev1.TerminationMessageFallbackToLogsOnError,
+					}},
+					Volumes: []corev1.Volume{{
+						Name: "host-root",
+						VolumeSource: corev1.VolumeSource{
+							HostPath: &corev1.HostPathVolumeSource{Path: "/", Type: &hostPathDirectory},
+						},
+					}},
+				},
+			},
+		},
+	}
+
+	// The Job is garbage collected with the PowerCap
+	if err := controllerutil.SetControllerReference(powerCap, job, r.Scheme); err != nil {
+		return nil, fmt.Errorf("failed to set owner of the power cap Job: %w", err)
+	}
+
+	return job, nil
+}
+
+// powerCapJobName returns the name of a Job of the PowerCap, within the 63 characters
+// of the job-name label
+func powerCapJobName(prefix, name string) string {
+	jobName := prefix + name
+	if len(jobName) > 63 {
+		jobName = strings.TrimRight(jobName[:63], "-.")
+	}
+
+	return jobName
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *PowerCapReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&janitordgxcnvidiacomv1alpha1.PowerCap{}).
+		Named("powercap").
+		Complete(r)
+}
+
+// getTimeout returns the timeout of the cap and restore Jobs
+func (r *PowerCapReconciler) getTimeout() time.Duration {
+	if r.Config == nil || r.Config.Timeout == 0 {
+		return 5 * time.Minute // fallback default
+	}
+
+	return r.Config.Timeout
+}
+
+// getLimitPercent returns the cap of the PowerCap in percent of the default power limit
+func (r *PowerCapReconciler) getLimitPercent(powerCap *janitordgxcnvidiacomv1alpha1.PowerCap) int32 {
+	if powerCap.Spec.LimitPercent != 0 {
+		return powerCap.Spec.LimitPercent
+	}
+
+	if r.Config == nil || r.Config.LimitPercent == 0 {
+		return 70 // fallback default
+	}
+
+	return r.Config.LimitPercent
+}
+
+// getSettleTime returns how long the thermal fault must be gone before the cap is lifted
+func (r *PowerCapReconciler) getSettleTime() time.Duration {
+	if r.Config == nil || r.Config.SettleTime == 0 {
+		return 10 * time.Minute // fallback default
+	}
+
+	return r.Config.SettleTime
+}
+
+// getHoldDuration returns how long the cap is held without a node condition
+func (r *PowerCapReconciler) getHoldDuration() time.Duration {
+	if r.Config == nil || r.Config.HoldDuration == 0 {
+		return time.Hour // fallback default
+	}
+
+	return r.Config.HoldDuration
+}
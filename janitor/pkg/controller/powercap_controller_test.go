@@ -0,0 +1,301 @@
+// Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/scheme"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	janitordgxcnvidiacomv1alpha1 "github.com/nvidia/nvsentinel/janitor/api/v1alpha1"
+	"github.com/nvidia/nvsentinel/janitor/pkg/config"
+)
+
+var _ = Describe("PowerCapReconciler", func() {
+	const (
+		gpuUUID       = "GPU-11111111-1111-1111-1111-111111111111"
+		conditionType = "GpuThermalWatch"
+		limits        = `[{"uuid":"` + gpuUUID + `","originalWatts":700,"cappedWatts":490}]`
+	)
+
+	var (
+		ctx        context.Context
+		reconciler *PowerCapReconciler
+		nodeName   string
+		crName     string
+		jobNS      string
+	)
+
+	reconcile := func() ctrl.Result {
+		result, err := reconciler.Reconcile(ctx, ctrl.Request{
+			NamespacedName: types.NamespacedName{Name: crName},
+		})
+		Expect(err).NotTo(HaveOccurred())
+
+		return result
+	}
+
+	getPowerCap := func() *janitordgxcnvidiacomv1alpha1.PowerCap {
+		var powerCap janitordgxcnvidiacomv1alpha1.PowerCap
+		Expect(k8sClient.Get(ctx, types.NamespacedName{Name: crName}, &powerCap)).To(Succeed())
+
+		return &powerCap
+	}
+
+	getNode := func() *corev1.Node {
+		var node corev1.Node
+		Expect(k8sClient.Get(ctx, types.NamespacedName{Name: nodeName}, &node)).To(Succeed())
+
+		return &node
+	}
+
+	getJob := func(name string) *batchv1.Job {
+		var job batchv1.Job
+		Expect(k8sClient.Get(ctx, types.NamespacedName{Namespace: jobNS, Name: name}, &job)).To(Succeed())
+
+		return &job
+	}
+
+	setNodeCondition := func(status corev1.ConditionStatus) {
+		node := getNode()
+		node.Status.Conditions = []corev1.NodeCondition{{
+			Type:               conditionType,
+			Status:             status,
+			Reason:             "GpuThermalWatchIsHealthy",
+			LastTransitionTime: metav1.Now(),
+		}}
+		Expect(k8sClient.Status().Update(ctx, node)).To(Succeed())
+	}
+
+	// finishJob sets the terminal condition of the Job as the Job controller would, and
+	// creates its pod with the termination message
+	finishJob := func(name string, conditionType batchv1.JobConditionType, message string) {
+		job := getJob(name)
+		now := metav1.Now()
+
+		job.Status.StartTime = &now
+
+		switch conditionType {
+		case batchv1.JobComplete:
+			job.Status.Succeeded = 1
+			job.Status.CompletionTime = &now
+			job.Status.Conditions = []batchv1.JobCondition{
+				{Type: batchv1.JobSuccessCriteriaMet, Status: corev1.ConditionTrue, LastTransitionTime: now},
+				{Type: batchv1.JobComplete, Status: corev1.ConditionTrue, LastTransitionTime: now},
+			}
+		case batchv1.JobFailed:
+			job.Status.Failed = 1
+			job.Status.Conditions = []batchv1.JobCondition{
+				{Type: batchv1.JobFailureTarget, Status: corev1.ConditionTrue, Reason: "BackoffLimitExceeded",
+					LastTransitionTime: now},
+				{Type: batchv1.JobFailed, Status: corev1.ConditionTrue, Reason: "BackoffLimitExceeded",
+					Message: "Job has reached the specified backoff limit", LastTransitionTime: now},
+			}
+		}
+
+		Expect(k8sClient.Status().Update(ctx, job)).To(Succeed())
+
+		pod := &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name + "-pod",
+				Namespace: jobNS,
+				Labels:    map[string]string{batchv1.JobNameLabel: name},
+			},
+			Spec: corev1.PodSpec{
+				NodeName:   nodeName,
+				Containers: []corev1.Container{{Name: "power-cap", Image: "janitor"}},
+			},
+		}
+		Expect(k8sClient.Create(ctx, pod)).To(Succeed())
+
+		pod.Status.ContainerStatuses = []corev1.ContainerStatus{{
+			Name:  "power-cap",
+			Image: "janitor",
+			State: corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{Message: message}},
+		}}
+		Expect(k8sClient.Status().Update(ctx, pod)).To(Succeed())
+	}
+
+	BeforeEach(func() {
+		ctx = context.Background()
+
+		uniqueSuffix := fmt.Sprintf("%d", time.Now().UnixNano())
+		nodeName = "test-node-" + uniqueSuffix
+		crName = "test-power-cap-" + uniqueSuffix
+		jobNS = "nvsentinel-" + uniqueSuffix
+
+		Expect(k8sClient.Create(ctx, &corev1.Namespace{
+			ObjectMeta: metav1.ObjectMeta{Name: jobNS},
+		})).To(Succeed())
+
+		Expect(k8sClient.Create(ctx, &corev1.Node{
+			ObjectMeta: metav1.ObjectMeta{Name: nodeName},
+		})).To(Succeed())
+
+		Expect(k8sClient.Create(ctx, &janitordgxcnvidiacomv1alpha1.PowerCap{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: crName,
+			},
+			Spec: janitordgxcnvidiacomv1alpha1.PowerCapSpec{
+				NodeName:      nodeName,
+				Selector:      &janitordgxcnvidiacomv1alpha1.GPUSelector{UUIDs: []string{gpuUUID}},
+				ConditionType: conditionType,
+			},
+		})).To(Succeed())
+
+		reconciler = &PowerCapReconciler{
+			Client: k8sClient,
+			Scheme: scheme.Scheme,
+			Config: &config.PowerCapControllerConfig{
+				Timeout:      5 * time.Minute,
+				SettleTime:   time.Nanosecond,
+				JobNamespace: jobNS,
+				JobImage:     "ghcr.io/nvidia/nvsentinel/janitor:test",
+			},
+		}
+	})
+
+	AfterEach(func() {
+		checkStatusConditions(getPowerCap().Status.Conditions)
+	})
+
+	Context("When the power cap starts", func() {
+		It("Should create a Job on the node capping the selected GPUs", func() {
+			result := reconcile()
+			Expect(result.RequeueAfter).To(BeNumerically(">", 0))
+
+			powerCap := getPowerCap()
+			Expect(powerCap.Status.Phase).To(Equal(janitordgxcnvidiacomv1alpha1.ActionPhaseExecuting))
+			Expect(powerCap.Status.JobName).To(Equal(powerCapJobName(powerCapJobPrefix, crName)))
+
+			job := getJob(powerCapJobName(powerCapJobPrefix, crName))
+			Expect(job.OwnerReferences).To(HaveLen(1))
+			Expect(job.OwnerReferences[0].Name).To(Equal(crName))
+
+			pod := job.Spec.Template.Spec
+			Expect(pod.NodeName).To(Equal(nodeName))
+			Expect(*pod.AutomountServiceAccountToken).To(BeFalse())
+			Expect(pod.Containers[0].Args).To(Equal([]string{"power-cap", "--limit-percent=70", "--uuids=" + gpuUUID}))
+		})
+	})
+
+	Context("When the cap Job completes", func() {
+		It("Should record the limits and hold the cap while the node condition is true", func() {
+			setNodeCondition(corev1.ConditionTrue)
+			reconcile()
+
+			finishJob(powerCapJobName(powerCapJobPrefix, crName), batchv1.JobComplete, limits)
+			reconcile()
+
+			powerCap := getPowerCap()
+			Expect(powerCap.IsCapped()).To(BeTrue())
+			Expect(powerCap.Status.Phase).To(Equal(janitordgxcnvidiacomv1alpha1.ActionPhaseVerifying))
+			Expect(powerCap.Status.JobName).To(BeEmpty())
+			Expect(powerCap.Status.GPUs).To(Equal([]janitordgxcnvidiacomv1alpha1.GPUPowerLimit{
+				{UUID: gpuUUID, OriginalWatts: 700, CappedWatts: 490},
+			}))
+			Expect(getNode().Annotations).To(HaveKeyWithValue(janitordgxcnvidiacomv1alpha1.PowerCapAnnotation,
+				`{"powerCap":"`+crName+`","gpus":`+limits+`}`))
+
+			reconcile()
+			Expect(getPowerCap().Status.JobName).To(BeEmpty())
+			Expect(getPowerCap().Status.NormalizedTime).To(BeNil())
+		})
+	})
+
+	Context("When the thermals normalize", func() {
+		It("Should restore the original limits after the settle time", func() {
+			setNodeCondition(corev1.ConditionTrue)
+			reconcile()
+
+			finishJob(powerCapJobName(powerCapJobPrefix, crName), batchv1.JobComplete, limits)
+			reconcile()
+
+			setNodeCondition(corev1.ConditionFalse)
+			reconcile()
+
+			powerCap := getPowerCap()
+			Expect(powerCap.Status.NormalizedTime).NotTo(BeNil())
+			Expect(powerCap.Status.JobName).To(Equal(powerCapJobName(powerCapRestoreJobPrefix, crName)))
+
+			job := getJob(powerCapJobName(powerCapRestoreJobPrefix, crName))
+			Expect(job.Spec.Template.Spec.Containers[0].Args).To(Equal([]string{
+				"power-cap", "--restore=" + gpuUUID + "=700:490",
+			}))
+
+			finishJob(job.Name, batchv1.JobComplete, "restored the power limits of 1 GPUs")
+			reconcile()
+
+			powerCap = getPowerCap()
+			Expect(powerCap.Status.CompletionTime).NotTo(BeNil())
+			Expect(powerCap.Status.Phase).To(Equal(janitordgxcnvidiacomv1alpha1.ActionPhaseDone))
+			Expect(powerCap.IsCapped()).To(BeFalse())
+			Expect(meta.IsStatusConditionTrue(powerCap.Status.Conditions,
+				janitordgxcnvidiacomv1alpha1.PowerCapConditionReleased)).To(BeTrue())
+			Expect(getNode().Annotations).NotTo(HaveKey(janitordgxcnvidiacomv1alpha1.PowerCapAnnotation))
+		})
+	})
+
+	Context("When the cap Job fails", func() {
+		It("Should fail the power cap for human attention", func() {
+			reconcile()
+
+			finishJob(powerCapJobName(powerCapJobPrefix, crName), batchv1.JobFailed, "insufficient permissions")
+			reconcile()
+
+			powerCap := getPowerCap()
+			Expect(powerCap.Status.Phase).To(Equal(janitordgxcnvidiacomv1alpha1.ActionPhaseFailed))
+
+			condition := meta.FindStatusCondition(powerCap.Status.Conditions,
+				janitordgxcnvidiacomv1alpha1.PowerCapConditionReleased)
+			Expect(condition).NotTo(BeNil())
+			Expect(condition.Reason).To(Equal("JobFailed"))
+			Expect(meta.IsStatusConditionTrue(powerCap.Status.Conditions,
+				janitordgxcnvidiacomv1alpha1.NeedsHumanAttentionConditionType)).To(BeTrue())
+		})
+	})
+
+	Context("When the node is still capped by an earlier power cap", func() {
+		It("Should fail without creating the Job", func() {
+			node := getNode()
+			node.Annotations = map[string]string{
+				janitordgxcnvidiacomv1alpha1.PowerCapAnnotation: `{"powerCap":"earlier","gpus":` + limits + `}`,
+			}
+			Expect(k8sClient.Update(ctx, node)).To(Succeed())
+
+			reconcile()
+
+			var jobs batchv1.JobList
+			Expect(k8sClient.List(ctx, &jobs, client.InNamespace(jobNS))).To(Succeed())
+			Expect(jobs.Items).To(BeEmpty())
+
+			powerCap := getPowerCap()
+			Expect(powerCap.Status.Phase).To(Equal(janitordgxcnvidiacomv1alpha1.ActionPhaseFailed))
+			Expect(meta.FindStatusCondition(powerCap.Status.Conditions,
+				janitordgxcnvidiacomv1alpha1.PowerCapConditionReleased).Reason).To(Equal("NodeAlreadyCapped"))
+		})
+	})
+})
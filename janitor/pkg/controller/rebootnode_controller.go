@@ -29,6 +29,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 	"sigs.k8s.io/controller-runtime/pkg/log"
 
+	"github.com/nvidia/nvsentinel/commons/pkg/nodelock"
 	janitordgxcnvidiacomv1alpha1 "github.com/nvidia/nvsentinel/janitor/api/v1alpha1"
 	"github.com/nvidia/nvsentinel/janitor/pkg/config"
 	"github.com/nvidia/nvsentinel/janitor/pkg/csp"
@@ -45,6 +46,14 @@ const (
 
 	// MaxRebootRetries is the maximum number of retry attempts before giving up
 	MaxRebootRetries = 20 // 10 minutes at 30s base intervals
+
+	// rebootSignalResumedReason marks a SignalSent condition inferred after a controller
+	// restart from the node's boot ID rather than acknowledged by the CSP
+	rebootSignalResumedReason = "Resumed"
+
+	// rebootSignalGracePeriod is how long after the CSP call returns a signal may take
+	// to change the node's boot ID before an interrupted reboot is sent again
+	rebootSignalGracePeriod = 5 * time.Minute
 )
 
 // updateRebootNodeStatus is a helper function that handles status updates with proper error handling.
@@ -75,6 +84,8 @@ type RebootNodeReconciler struct {
 	Scheme    *runtime.Scheme
 	Config    *config.RebootNodeControllerConfig
 	CSPClient model.CSPClient
+	// NodeLock serializes destructive actions on a node, nil disables locking
+	NodeLock *nodelock.Locker
 }
 
 // +kubebuilder:rbac:groups=janitor.dgxc.nvidia.com,resources=rebootnodes,verbs=get;list;watch;create;update;patch;delete
@@ -104,6 +115,8 @@ func (r *RebootNodeReconciler) Reconcile(ctx context.Context, req ctrl.Request)
 			// Best effort: log the state for audit trail
 			// Future enhancement: Could add CSP cancellation API call here if available
 
+			releaseNodeLock(ctx, r.NodeLock, rebootNode.Spec.NodeName, lockHolder("rebootnode", rebootNode.Name))
+
 			controllerutil.RemoveFinalizer(&rebootNode, RebootNodeFinalizer)
 
 			if err := r.Update(ctx, &rebootNode); err != nil {
@@ -127,6 +140,8 @@ func (r *RebootNodeReconciler) Reconcile(ctx context.Context, req ctrl.Request)
 		logger.V(1).Info("rebootnode has completion time set, skipping reconcile",
 			"node", rebootNode.Spec.NodeName)
 
+		releaseNodeLock(ctx, r.NodeLock, rebootNode.Spec.NodeName, lockHolder("rebootnode", rebootNode.Name))
+
 		return ctrl.Result{}, nil
 	}
 
@@ -141,6 +156,37 @@ func (r *RebootNodeReconciler) Reconcile(ctx context.Context, req ctrl.Request)
 	// Set the start time if it is not already set
 	rebootNode.SetStartTime()
 
+	if rebootNode.Status.Phase == "" {
+		setPhase(ctx, &rebootNode.Status.Phase, janitordgxcnvidiacomv1alpha1.ActionPhaseRequested, rebootNode.Spec.NodeName)
+	}
+
+	// Operators can cancel or force fail a stuck action through annotations
+	if reason, message, ok := operatorOverride(&rebootNode); ok {
+		logger.Info("rebootnode ended by operator",
+			"node", rebootNode.Spec.NodeName,
+			"reason", reason,
+			"message", message)
+
+		failForHumanAttention(ctx, &rebootNode, &rebootNode.Status.Phase, rebootNode.Spec.NodeName,
+			janitordgxcnvidiacomv1alpha1.RebootNodeConditionNodeReady, reason, message)
+		metrics.GlobalMetrics.IncActionCount(metrics.ActionTypeReboot, metrics.StatusFailed, rebootNode.Spec.NodeName)
+
+		return r.updateRebootNodeStatus(ctx, req, originalRebootNode, &rebootNode, ctrl.Result{})
+	}
+
+	if approvalTimedOut(rebootNode.Status.Phase, rebootNode.Status.StartTime, r.Config.ApprovalTimeout) {
+		logger.Info("no outside actor performed the reboot within the approval timeout",
+			"node", rebootNode.Spec.NodeName,
+			"approvalTimeout", r.Config.ApprovalTimeout)
+
+		failForHumanAttention(ctx, &rebootNode, &rebootNode.Status.Phase, rebootNode.Spec.NodeName,
+			janitordgxcnvidiacomv1alpha1.RebootNodeConditionNodeReady, approvalTimeoutReason,
+			fmt.Sprintf("Reboot was not performed within the approval timeout of %s", r.Config.ApprovalTimeout))
+		metrics.GlobalMetrics.IncActionCount(metrics.ActionTypeReboot, metrics.StatusFailed, rebootNode.Spec.NodeName)
+
+		return r.updateRebootNodeStatus(ctx, req, originalRebootNode, &rebootNode, ctrl.Result{})
+	}
+
 	// Check if max retries exceeded
 	if rebootNode.Status.RetryCount >= MaxRebootRetries {
 		logger.Info("max retries exceeded, marking as failed",
@@ -148,15 +194,10 @@ func (r *RebootNodeReconciler) Reconcile(ctx context.Context, req ctrl.Request)
 			"retries", int(rebootNode.Status.RetryCount),
 			"maxRetries", MaxRebootRetries)
 
-		rebootNode.SetCompletionTime()
-		rebootNode.SetCondition(metav1.Condition{
-			Type:   janitordgxcnvidiacomv1alpha1.RebootNodeConditionNodeReady,
-			Status: metav1.ConditionFalse,
-			Reason: "MaxRetriesExceeded",
-			Message: fmt.Sprintf("Node failed to reach ready state after %d retries over %s",
-				MaxRebootRetries, r.getRebootTimeout()),
-			LastTransitionTime: metav1.Now(),
-		})
+		failForHumanAttention(ctx, &rebootNode, &rebootNode.Status.Phase, rebootNode.Spec.NodeName,
+			janitordgxcnvidiacomv1alpha1.RebootNodeConditionNodeReady, "MaxRetriesExceeded",
+			fmt.Sprintf("Node failed to reach ready state after %d retries over %s",
+				MaxRebootRetries, r.getRebootTimeout()))
 
 		metrics.GlobalMetrics.IncActionCount(metrics.ActionTypeReboot, metrics.StatusFailed, rebootNode.Spec.NodeName)
 
@@ -177,17 +218,23 @@ func (r *RebootNodeReconciler) Reconcile(ctx context.Context, req ctrl.Request)
 		// Increment retry count for monitoring attempts
 		rebootNode.Status.RetryCount++
 
+		// Actions sent by an outside actor in manual mode, or created before phases
+		// were tracked, enter verification here
+		setPhase(ctx, &rebootNode.Status.Phase, janitordgxcnvidiacomv1alpha1.ActionPhaseVerifying, node.Name)
+
 		// Check if csp reports the node is ready
 		cspReady := false
 
 		var nodeReadyErr error
 
-		if r.Config.ManualMode {
+		if (r.Config.ManualMode && !approved(&rebootNode)) || isRebootSignalResumed(&rebootNode) {
+			// A resumed signal has no CSP request to poll, the node's own
+			// readiness is the only signal left
 			cspReady = true
 			nodeReadyErr = nil
 		} else {
 			// Add timeout to CSP operation to prevent queue blocking
-			cspCtx, cancel := context.WithTimeout(ctx, CSPOperationTimeout)
+			cspCtx, cancel := context.WithTimeout(ctx, r.getSignalTimeout())
 			defer cancel()
 
 			cspReady, nodeReadyErr = r.CSPClient.IsNodeReady(cspCtx, node, rebootNode.GetCSPReqRef())
@@ -197,7 +244,7 @@ func (r *RebootNodeReconciler) Reconcile(ctx context.Context, req ctrl.Request)
 				logger.Info("CSP operation timed out, will retry",
 					"node", node.Name,
 					"operation", "IsNodeReady",
-					"timeout", CSPOperationTimeout)
+					"timeout", r.getSignalTimeout())
 
 				rebootNode.Status.ConsecutiveFailures++
 				delay := getNextRequeueDelay(rebootNode.Status.ConsecutiveFailures)
@@ -234,6 +281,7 @@ func (r *RebootNodeReconciler) Reconcile(ctx context.Context, req ctrl.Request)
 			})
 
 			metrics.GlobalMetrics.IncActionCount(metrics.ActionTypeReboot, metrics.StatusFailed, node.Name)
+			setPhase(ctx, &rebootNode.Status.Phase, janitordgxcnvidiacomv1alpha1.ActionPhaseFailed, node.Name)
 
 			result = ctrl.Result{} // Don't requeue on failure
 		} else if cspReady && kubernetesReady {
@@ -257,6 +305,7 @@ func (r *RebootNodeReconciler) Reconcile(ctx context.Context, req ctrl.Request)
 			// Metrics and final result
 			metrics.GlobalMetrics.IncActionCount(metrics.ActionTypeReboot, metrics.StatusSucceeded, node.Name)
 			metrics.GlobalMetrics.RecordActionMTTR(metrics.ActionTypeReboot, time.Since(rebootNode.Status.StartTime.Time))
+			setPhase(ctx, &rebootNode.Status.Phase, janitordgxcnvidiacomv1alpha1.ActionPhaseDone, node.Name)
 
 			result = ctrl.Result{} // Don't requeue on success
 		} else if time.Since(rebootNode.Status.StartTime.Time) > r.getRebootTimeout() {
@@ -266,14 +315,9 @@ func (r *RebootNodeReconciler) Reconcile(ctx context.Context, req ctrl.Request)
 				"elapsed", time.Since(rebootNode.Status.StartTime.Time))
 
 			// Update status
-			rebootNode.SetCompletionTime()
-			rebootNode.SetCondition(metav1.Condition{
-				Type:               janitordgxcnvidiacomv1alpha1.RebootNodeConditionNodeReady,
-				Status:             metav1.ConditionFalse,
-				Reason:             "Timeout",
-				Message:            "Node failed to return to ready state after timeout duration",
-				LastTransitionTime: metav1.Now(),
-			})
+			failForHumanAttention(ctx, &rebootNode, &rebootNode.Status.Phase, node.Name,
+				janitordgxcnvidiacomv1alpha1.RebootNodeConditionNodeReady, "Timeout",
+				"Node failed to return to ready state after timeout duration")
 
 			metrics.GlobalMetrics.IncActionCount(metrics.ActionTypeReboot, metrics.StatusFailed, node.Name)
 
@@ -302,8 +346,38 @@ func (r *RebootNodeReconciler) Reconcile(ctx context.Context, req ctrl.Request)
 
 			delay := getNextRequeueDelay(rebootNode.Status.ConsecutiveFailures)
 			result = ctrl.Result{RequeueAfter: delay}
+		} else if rebootNode.Status.Phase == janitordgxcnvidiacomv1alpha1.ActionPhaseExecuting &&
+			rebootNode.Status.SignalBootID != "" &&
+			rebootNode.Status.SignalBootID != node.Status.NodeInfo.BootID {
+			// The controller restarted after the signal was sent but before the outcome was
+			// recorded, and the node has rebooted since. Resume verification instead of
+			// rebooting the node a second time.
+			logger.Info("resuming interrupted reboot, node already rebooted",
+				"node", node.Name,
+				"signalBootID", rebootNode.Status.SignalBootID,
+				"bootID", node.Status.NodeInfo.BootID)
+
+			rebootNode.SetCondition(metav1.Condition{
+				Type:               janitordgxcnvidiacomv1alpha1.RebootNodeConditionSignalSent,
+				Status:             metav1.ConditionTrue,
+				Reason:             rebootSignalResumedReason,
+				Message:            "",
+				LastTransitionTime: metav1.Now(),
+			})
+			setPhase(ctx, &rebootNode.Status.Phase, janitordgxcnvidiacomv1alpha1.ActionPhaseVerifying, node.Name)
+
+			result = ctrl.Result{RequeueAfter: 30 * time.Second}
+		} else if remaining := r.signalGraceRemaining(&rebootNode); remaining > 0 {
+			// The controller restarted after the signal may have reached the CSP, and the
+			// node can take a while to go down. Re-sending now would reboot it twice.
+			logger.Info("waiting for interrupted reboot signal to take effect",
+				"node", node.Name,
+				"signalBootID", rebootNode.Status.SignalBootID,
+				"remaining", remaining)
+
+			result = ctrl.Result{RequeueAfter: min(remaining, 30*time.Second)}
 		} else {
-			if r.Config.ManualMode {
+			if r.Config.ManualMode && !approved(&rebootNode) {
 				isManualModeConditionSet := false
 
 				for _, condition := range rebootNode.Status.Conditions {
@@ -329,14 +403,54 @@ func (r *RebootNodeReconciler) Reconcile(ctx context.Context, req ctrl.Request)
 					"node", node.Name)
 
 				result = ctrl.Result{}
+			} else if locked, err := acquireNodeLock(ctx, r.NodeLock, metrics.ActionTypeReboot, node.Name,
+				lockHolder("rebootnode", rebootNode.Name), &rebootNode.Status.LockToken); err != nil {
+				return ctrl.Result{}, fmt.Errorf("failed to lock node: %w", err)
+			} else if !locked {
+				result = ctrl.Result{RequeueAfter: nodeLockRetryDelay}
 			} else {
+				if rebootNode.Status.Phase == janitordgxcnvidiacomv1alpha1.ActionPhaseExecuting {
+					// The node has not rebooted since the interrupted attempt, so the
+					// signal never landed and it is safe to send again
+					logger.Info("resuming interrupted reboot, signal was not applied",
+						"node", node.Name)
+				} else {
+					setPhase(ctx, &rebootNode.Status.Phase, janitordgxcnvidiacomv1alpha1.ActionPhaseApproved, node.Name)
+				}
+
+				// Persist the Executing phase before calling the CSP so that a restart
+				// during the call resumes instead of re-firing or orphaning the action
+				setPhase(ctx, &rebootNode.Status.Phase, janitordgxcnvidiacomv1alpha1.ActionPhaseExecuting, node.Name)
+				rebootNode.Status.SignalBootID = node.Status.NodeInfo.BootID
+				signalTime := metav1.Now()
+				rebootNode.Status.SignalTime = &signalTime
+
+				if err := r.Status().Update(ctx, &rebootNode); err != nil {
+					return ctrl.Result{}, fmt.Errorf("failed to record executing phase: %w", err)
+				}
+
+				originalRebootNode = rebootNode.DeepCopy()
+
+				// A holder that lost the lock while recording the phase must not act
+				if err := checkNodeLock(ctx, r.NodeLock, node.Name, lockHolder("rebootnode", rebootNode.Name),
+					rebootNode.Status.LockToken); err != nil {
+					logger.Info("node lock lost before sending reboot signal, will retry",
+						"node", node.Name,
+						"error", err.Error())
+					setPhase(ctx, &rebootNode.Status.Phase, janitordgxcnvidiacomv1alpha1.ActionPhaseApproved, node.Name)
+
+					result = ctrl.Result{RequeueAfter: nodeLockRetryDelay}
+
+					return r.updateRebootNodeStatus(ctx, req, originalRebootNode, &rebootNode, result)
+				}
+
 				// Start the reboot process
 				metrics.GlobalMetrics.IncActionCount(metrics.ActionTypeReboot, metrics.StatusStarted, node.Name)
 				logger.Info("sending reboot signal to node",
 					"node", node.Name)
 
 				// Add timeout to CSP operation
-				cspCtx, cancel := context.WithTimeout(ctx, CSPOperationTimeout)
+				cspCtx, cancel := context.WithTimeout(ctx, r.getSignalTimeout())
 				defer cancel()
 
 				reqRef, rebootErr := r.CSPClient.SendRebootSignal(cspCtx, node)
@@ -346,10 +460,11 @@ func (r *RebootNodeReconciler) Reconcile(ctx context.Context, req ctrl.Request)
 					logger.Info("CSP operation timed out, will retry",
 						"node", node.Name,
 						"operation", "SendRebootSignal",
-						"timeout", CSPOperationTimeout)
+						"timeout", r.getSignalTimeout())
 
 					rebootNode.Status.ConsecutiveFailures++
 					delay := getNextRequeueDelay(rebootNode.Status.ConsecutiveFailures)
+					setPhase(ctx, &rebootNode.Status.Phase, janitordgxcnvidiacomv1alpha1.ActionPhaseApproved, node.Name)
 
 					result = ctrl.Result{RequeueAfter: delay}
 					// Update status and return early
@@ -370,6 +485,7 @@ func (r *RebootNodeReconciler) Reconcile(ctx context.Context, req ctrl.Request)
 						Message:            string(reqRef),
 						LastTransitionTime: metav1.Now(),
 					}
+					setPhase(ctx, &rebootNode.Status.Phase, janitordgxcnvidiacomv1alpha1.ActionPhaseVerifying, node.Name)
 					// Continue monitoring if signal was sent successfully
 					result = ctrl.Result{RequeueAfter: 30 * time.Second}
 				} else {
@@ -388,6 +504,7 @@ func (r *RebootNodeReconciler) Reconcile(ctx context.Context, req ctrl.Request)
 					result = ctrl.Result{}
 
 					metrics.GlobalMetrics.IncActionCount(metrics.ActionTypeReboot, metrics.StatusFailed, node.Name)
+					setPhase(ctx, &rebootNode.Status.Phase, janitordgxcnvidiacomv1alpha1.ActionPhaseFailed, node.Name)
 				}
 
 				rebootNode.SetCondition(signalSentCondition)
@@ -422,6 +539,39 @@ func (r *RebootNodeReconciler) SetupWithManager(mgr ctrl.Manager) error {
 		Complete(r)
 }
 
+// isRebootSignalResumed returns true if the signal was inferred on resume rather than
+// acknowledged by the CSP, in which case there is no CSP request to poll
+func isRebootSignalResumed(rebootNode *janitordgxcnvidiacomv1alpha1.RebootNode) bool {
+	for _, condition := range rebootNode.Status.Conditions {
+		if condition.Type == janitordgxcnvidiacomv1alpha1.RebootNodeConditionSignalSent {
+			return condition.Reason == rebootSignalResumedReason
+		}
+	}
+
+	return false
+}
+
+// signalGraceRemaining returns how much longer an interrupted reboot waits for the
+// node's boot ID to change before the signal is sent again. It covers the CSP call
+// itself, which may still have been in flight when the controller restarted.
+func (r *RebootNodeReconciler) signalGraceRemaining(rebootNode *janitordgxcnvidiacomv1alpha1.RebootNode) time.Duration {
+	if rebootNode.Status.Phase != janitordgxcnvidiacomv1alpha1.ActionPhaseExecuting ||
+		rebootNode.Status.SignalTime == nil {
+		return 0
+	}
+
+	return time.Until(rebootNode.Status.SignalTime.Add(r.getSignalTimeout() + rebootSignalGracePeriod))
+}
+
+// getSignalTimeout returns the timeout for a single CSP call
+func (r *RebootNodeReconciler) getSignalTimeout() time.Duration {
+	if r.Config == nil {
+		return CSPOperationTimeout
+	}
+
+	return signalTimeoutOrDefault(r.Config.SignalTimeout)
+}
+
 // getRebootTimeout returns the timeout for reboot operations
 func (r *RebootNodeReconciler) getRebootTimeout() time.Duration {
 	cfg := r.Config
@@ -22,6 +22,8 @@ import (
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	janitordgxcnvidiacomv1alpha1 "github.com/nvidia/nvsentinel/janitor/api/v1alpha1"
 )
 
 // NodeActionStatus defines the interface that both RebootNodeStatus and TerminateNodeStatus must implement.
@@ -31,6 +33,7 @@ type NodeActionStatus interface {
 	GetConsecutiveFailures() int32
 	GetStartTime() *metav1.Time
 	GetCompletionTime() *metav1.Time
+	GetPhase() janitordgxcnvidiacomv1alpha1.ActionPhase
 	GetConditions() []metav1.Condition
 }
 
@@ -102,6 +105,7 @@ func updateNodeActionStatus[T client.Object](
 		originalStatus.GetConsecutiveFailures() != updatedStatus.GetConsecutiveFailures() ||
 		(originalStatus.GetStartTime() == nil) != (updatedStatus.GetStartTime() == nil) ||
 		(originalStatus.GetCompletionTime() == nil) != (updatedStatus.GetCompletionTime() == nil) ||
+		originalStatus.GetPhase() != updatedStatus.GetPhase() ||
 		conditionsChanged(originalStatus.GetConditions(), updatedStatus.GetConditions())
 
 	if statusChanged {
@@ -129,3 +133,33 @@ func updateNodeActionStatus[T client.Object](
 
 	return result, nil
 }
+
+// setPhase moves a node action to the next phase of its state machine. Transitions the
+// state machine does not allow are still applied, since the controller is the source of
+// truth, but are logged so unexpected paths show up in the audit trail.
+func setPhase(
+	ctx context.Context,
+	phase *janitordgxcnvidiacomv1alpha1.ActionPhase,
+	next janitordgxcnvidiacomv1alpha1.ActionPhase,
+	nodeName string,
+) {
+	if *phase == next {
+		return
+	}
+
+	logger := log.FromContext(ctx)
+
+	if !phase.CanTransitionTo(next) {
+		logger.Info("unexpected node action phase transition",
+			"node", nodeName,
+			"from", string(*phase),
+			"to", string(next))
+	} else {
+		logger.V(1).Info("node action phase transition",
+			"node", nodeName,
+			"from", string(*phase),
+			"to", string(next))
+	}
+
+	*phase = next
+}
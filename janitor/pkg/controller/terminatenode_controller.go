@@ -30,6 +30,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 	"sigs.k8s.io/controller-runtime/pkg/log"
 
+	"github.com/nvidia/nvsentinel/commons/pkg/nodelock"
 	janitordgxcnvidiacomv1alpha1 "github.com/nvidia/nvsentinel/janitor/api/v1alpha1"
 	"github.com/nvidia/nvsentinel/janitor/pkg/config"
 	"github.com/nvidia/nvsentinel/janitor/pkg/csp"
@@ -51,6 +52,8 @@ type TerminateNodeReconciler struct {
 	Scheme    *runtime.Scheme
 	Config    *config.TerminateNodeControllerConfig
 	CSPClient model.CSPClient
+	// NodeLock serializes destructive actions on a node, nil disables locking
+	NodeLock *nodelock.Locker
 }
 
 // updateTerminateNodeStatus is a helper function that handles status updates with proper error handling.
@@ -101,6 +104,8 @@ func (r *TerminateNodeReconciler) Reconcile(ctx context.Context, req ctrl.Reques
 			// Best effort: log the state for audit trail
 			// Future enhancement: Could add CSP cancellation API call here if available
 
+			releaseNodeLock(ctx, r.NodeLock, terminateNode.Spec.NodeName, lockHolder("terminatenode", terminateNode.Name))
+
 			controllerutil.RemoveFinalizer(&terminateNode, TerminateNodeFinalizer)
 
 			if err := r.Update(ctx, &terminateNode); err != nil {
@@ -124,6 +129,8 @@ func (r *TerminateNodeReconciler) Reconcile(ctx context.Context, req ctrl.Reques
 		logger.V(1).Info("terminatenode has completion time set, skipping reconcile",
 			"node", terminateNode.Spec.NodeName)
 
+		releaseNodeLock(ctx, r.NodeLock, terminateNode.Spec.NodeName, lockHolder("terminatenode", terminateNode.Name))
+
 		return ctrl.Result{}, nil
 	}
 
@@ -138,6 +145,38 @@ func (r *TerminateNodeReconciler) Reconcile(ctx context.Context, req ctrl.Reques
 	// Set the start time if it is not already set
 	terminateNode.SetStartTime()
 
+	if terminateNode.Status.Phase == "" {
+		setPhase(ctx, &terminateNode.Status.Phase, janitordgxcnvidiacomv1alpha1.ActionPhaseRequested,
+			terminateNode.Spec.NodeName)
+	}
+
+	// Operators can cancel or force fail a stuck action through annotations
+	if reason, message, ok := operatorOverride(&terminateNode); ok {
+		logger.Info("terminatenode ended by operator",
+			"node", terminateNode.Spec.NodeName,
+			"reason", reason,
+			"message", message)
+
+		failForHumanAttention(ctx, &terminateNode, &terminateNode.Status.Phase, terminateNode.Spec.NodeName,
+			janitordgxcnvidiacomv1alpha1.TerminateNodeConditionNodeTerminated, reason, message)
+		metrics.IncActionCount(metrics.ActionTypeTerminate, metrics.StatusFailed, terminateNode.Spec.NodeName)
+
+		return r.updateTerminateNodeStatus(ctx, req, originalTerminateNode, &terminateNode, ctrl.Result{})
+	}
+
+	if approvalTimedOut(terminateNode.Status.Phase, terminateNode.Status.StartTime, r.Config.ApprovalTimeout) {
+		logger.Info("no outside actor performed the termination within the approval timeout",
+			"node", terminateNode.Spec.NodeName,
+			"approvalTimeout", r.Config.ApprovalTimeout)
+
+		failForHumanAttention(ctx, &terminateNode, &terminateNode.Status.Phase, terminateNode.Spec.NodeName,
+			janitordgxcnvidiacomv1alpha1.TerminateNodeConditionNodeTerminated, approvalTimeoutReason,
+			fmt.Sprintf("Termination was not performed within the approval timeout of %s", r.Config.ApprovalTimeout))
+		metrics.IncActionCount(metrics.ActionTypeTerminate, metrics.StatusFailed, terminateNode.Spec.NodeName)
+
+		return r.updateTerminateNodeStatus(ctx, req, originalTerminateNode, &terminateNode, ctrl.Result{})
+	}
+
 	// Check if max retries exceeded
 	if terminateNode.Status.RetryCount >= MaxTerminateRetries {
 		logger.Info("max retries exceeded, marking as failed",
@@ -145,15 +184,10 @@ func (r *TerminateNodeReconciler) Reconcile(ctx context.Context, req ctrl.Reques
 			"retries", int(terminateNode.Status.RetryCount),
 			"maxRetries", MaxTerminateRetries)
 
-		terminateNode.SetCompletionTime()
-		terminateNode.SetCondition(metav1.Condition{
-			Type:   janitordgxcnvidiacomv1alpha1.TerminateNodeConditionNodeTerminated,
-			Status: metav1.ConditionFalse,
-			Reason: "MaxRetriesExceeded",
-			Message: fmt.Sprintf("Node failed to terminate after %d retries over %s",
-				MaxTerminateRetries, r.getTerminateTimeout()),
-			LastTransitionTime: metav1.Now(),
-		})
+		failForHumanAttention(ctx, &terminateNode, &terminateNode.Status.Phase, terminateNode.Spec.NodeName,
+			janitordgxcnvidiacomv1alpha1.TerminateNodeConditionNodeTerminated, "MaxRetriesExceeded",
+			fmt.Sprintf("Node failed to terminate after %d retries over %s",
+				MaxTerminateRetries, r.getTerminateTimeout()))
 
 		metrics.IncActionCount(metrics.ActionTypeTerminate, metrics.StatusFailed, terminateNode.Spec.NodeName)
 
@@ -182,6 +216,11 @@ func (r *TerminateNodeReconciler) Reconcile(ctx context.Context, req ctrl.Reques
 		// Increment retry count for monitoring attempts
 		terminateNode.Status.RetryCount++
 
+		// Actions sent by an outside actor in manual mode, or created before phases
+		// were tracked, enter verification here
+		setPhase(ctx, &terminateNode.Status.Phase, janitordgxcnvidiacomv1alpha1.ActionPhaseVerifying,
+			terminateNode.Spec.NodeName)
+
 		switch {
 		case !nodeExists:
 			logger.Info("node terminated successfully",
@@ -204,6 +243,8 @@ func (r *TerminateNodeReconciler) Reconcile(ctx context.Context, req ctrl.Reques
 			// Record successful termination metrics
 			metrics.IncActionCount(metrics.ActionTypeTerminate, metrics.StatusSucceeded, terminateNode.Spec.NodeName)
 			metrics.RecordActionMTTR(metrics.ActionTypeTerminate, time.Since(terminateNode.Status.StartTime.Time))
+			setPhase(ctx, &terminateNode.Status.Phase, janitordgxcnvidiacomv1alpha1.ActionPhaseDone,
+				terminateNode.Spec.NodeName)
 
 			result = ctrl.Result{} // Don't requeue on success
 		case isNodeNotReady(&node):
@@ -234,6 +275,8 @@ func (r *TerminateNodeReconciler) Reconcile(ctx context.Context, req ctrl.Reques
 			// Record successful termination metrics
 			metrics.IncActionCount(metrics.ActionTypeTerminate, metrics.StatusSucceeded, node.Name)
 			metrics.RecordActionMTTR(metrics.ActionTypeTerminate, time.Since(terminateNode.Status.StartTime.Time))
+			setPhase(ctx, &terminateNode.Status.Phase, janitordgxcnvidiacomv1alpha1.ActionPhaseDone,
+				terminateNode.Spec.NodeName)
 
 			result = ctrl.Result{} // Don't requeue on success
 		case time.Since(terminateNode.Status.StartTime.Time) > r.getTerminateTimeout():
@@ -244,14 +287,9 @@ func (r *TerminateNodeReconciler) Reconcile(ctx context.Context, req ctrl.Reques
 				"elapsed", time.Since(terminateNode.Status.StartTime.Time))
 
 			// Update status
-			terminateNode.SetCompletionTime()
-			terminateNode.SetCondition(metav1.Condition{
-				Type:               janitordgxcnvidiacomv1alpha1.TerminateNodeConditionNodeTerminated,
-				Status:             metav1.ConditionFalse,
-				Reason:             "Timeout",
-				Message:            "Node failed to transition to not ready state after timeout duration",
-				LastTransitionTime: metav1.Now(),
-			})
+			failForHumanAttention(ctx, &terminateNode, &terminateNode.Status.Phase, node.Name,
+				janitordgxcnvidiacomv1alpha1.TerminateNodeConditionNodeTerminated, "Timeout",
+				"Node failed to transition to not ready state after timeout duration")
 
 			metrics.IncActionCount(metrics.ActionTypeTerminate, metrics.StatusFailed, node.Name)
 
@@ -265,6 +303,35 @@ func (r *TerminateNodeReconciler) Reconcile(ctx context.Context, req ctrl.Reques
 	} else {
 		// Terminate not in progress yet, need to send signal
 
+		// The controller restarted after the signal was sent but before the outcome was
+		// recorded, and the node is already gone. The termination landed.
+		if !nodeExists && terminateNode.Status.Phase == janitordgxcnvidiacomv1alpha1.ActionPhaseExecuting {
+			logger.Info("resuming interrupted terminate, node already removed",
+				"node", terminateNode.Spec.NodeName)
+
+			terminateNode.SetCompletionTime()
+			terminateNode.SetCondition(metav1.Condition{
+				Type:               janitordgxcnvidiacomv1alpha1.TerminateNodeConditionSignalSent,
+				Status:             metav1.ConditionTrue,
+				Reason:             "Resumed",
+				Message:            "Terminate signal outcome inferred after controller restart",
+				LastTransitionTime: metav1.Now(),
+			})
+			terminateNode.SetCondition(metav1.Condition{
+				Type:               janitordgxcnvidiacomv1alpha1.TerminateNodeConditionNodeTerminated,
+				Status:             metav1.ConditionTrue,
+				Reason:             "Succeeded",
+				Message:            "CSP instance deleted and Kubernetes node removed.",
+				LastTransitionTime: metav1.Now(),
+			})
+			setPhase(ctx, &terminateNode.Status.Phase, janitordgxcnvidiacomv1alpha1.ActionPhaseDone,
+				terminateNode.Spec.NodeName)
+
+			metrics.IncActionCount(metrics.ActionTypeTerminate, metrics.StatusSucceeded, terminateNode.Spec.NodeName)
+
+			return r.updateTerminateNodeStatus(ctx, req, originalTerminateNode, &terminateNode, ctrl.Result{})
+		}
+
 		// If node doesn't exist, this is an error (should have been caught by webhook)
 		if !nodeExists {
 			return ctrl.Result{}, errors.New("node not found and terminate not in progress")
@@ -290,7 +357,7 @@ func (r *TerminateNodeReconciler) Reconcile(ctx context.Context, req ctrl.Reques
 			result = ctrl.Result{RequeueAfter: delay}
 		} else {
 			// Need to send terminate signal
-			if r.Config.ManualMode {
+			if r.Config.ManualMode && !approved(&terminateNode) {
 				// Check if manual mode condition is already set
 				isManualModeConditionSet := false
 
@@ -317,7 +384,43 @@ func (r *TerminateNodeReconciler) Reconcile(ctx context.Context, req ctrl.Reques
 					"node", node.Name)
 
 				result = ctrl.Result{}
+			} else if locked, err := acquireNodeLock(ctx, r.NodeLock, metrics.ActionTypeTerminate, node.Name,
+				lockHolder("terminatenode", terminateNode.Name), &terminateNode.Status.LockToken); err != nil {
+				return ctrl.Result{}, fmt.Errorf("failed to lock node: %w", err)
+			} else if !locked {
+				result = ctrl.Result{RequeueAfter: nodeLockRetryDelay}
 			} else {
+				if terminateNode.Status.Phase == janitordgxcnvidiacomv1alpha1.ActionPhaseExecuting {
+					// Termination is idempotent while the node still exists, resend it
+					logger.Info("resuming interrupted terminate, node still present",
+						"node", node.Name)
+				} else {
+					setPhase(ctx, &terminateNode.Status.Phase, janitordgxcnvidiacomv1alpha1.ActionPhaseApproved, node.Name)
+				}
+
+				// Persist the Executing phase before calling the CSP so that a restart
+				// during the call resumes instead of re-firing or orphaning the action
+				setPhase(ctx, &terminateNode.Status.Phase, janitordgxcnvidiacomv1alpha1.ActionPhaseExecuting, node.Name)
+
+				if err := r.Status().Update(ctx, &terminateNode); err != nil {
+					return ctrl.Result{}, fmt.Errorf("failed to record executing phase: %w", err)
+				}
+
+				originalTerminateNode = terminateNode.DeepCopy()
+
+				// A holder that lost the lock while recording the phase must not act
+				if err := checkNodeLock(ctx, r.NodeLock, node.Name, lockHolder("terminatenode", terminateNode.Name),
+					terminateNode.Status.LockToken); err != nil {
+					logger.Info("node lock lost before sending terminate signal, will retry",
+						"node", node.Name,
+						"error", err.Error())
+					setPhase(ctx, &terminateNode.Status.Phase, janitordgxcnvidiacomv1alpha1.ActionPhaseApproved, node.Name)
+
+					result = ctrl.Result{RequeueAfter: nodeLockRetryDelay}
+
+					return r.updateTerminateNodeStatus(ctx, req, originalTerminateNode, &terminateNode, result)
+				}
+
 				// Send terminate signal via CSP
 				logger.Info("sending terminate signal to node",
 					"node", terminateNode.Spec.NodeName)
@@ -325,7 +428,7 @@ func (r *TerminateNodeReconciler) Reconcile(ctx context.Context, req ctrl.Reques
 				metrics.IncActionCount(metrics.ActionTypeTerminate, metrics.StatusStarted, node.Name)
 
 				// Add timeout to CSP operation
-				cspCtx, cancel := context.WithTimeout(ctx, CSPOperationTimeout)
+				cspCtx, cancel := context.WithTimeout(ctx, r.getSignalTimeout())
 				defer cancel()
 
 				_, terminateErr := r.CSPClient.SendTerminateSignal(cspCtx, node)
@@ -335,10 +438,11 @@ func (r *TerminateNodeReconciler) Reconcile(ctx context.Context, req ctrl.Reques
 					logger.Info("CSP operation timed out, will retry",
 						"node", node.Name,
 						"operation", "SendTerminateSignal",
-						"timeout", CSPOperationTimeout)
+						"timeout", r.getSignalTimeout())
 
 					terminateNode.Status.ConsecutiveFailures++
 					delay := getNextRequeueDelay(terminateNode.Status.ConsecutiveFailures)
+					setPhase(ctx, &terminateNode.Status.Phase, janitordgxcnvidiacomv1alpha1.ActionPhaseApproved, node.Name)
 
 					result = ctrl.Result{RequeueAfter: delay}
 					// Update status and return early
@@ -359,6 +463,7 @@ func (r *TerminateNodeReconciler) Reconcile(ctx context.Context, req ctrl.Reques
 						Message:            "Terminate signal sent to CSP",
 						LastTransitionTime: metav1.Now(),
 					}
+					setPhase(ctx, &terminateNode.Status.Phase, janitordgxcnvidiacomv1alpha1.ActionPhaseVerifying, node.Name)
 					// Continue monitoring if signal was sent successfully
 					result = ctrl.Result{RequeueAfter: 30 * time.Second}
 				} else {
@@ -377,6 +482,7 @@ func (r *TerminateNodeReconciler) Reconcile(ctx context.Context, req ctrl.Reques
 					result = ctrl.Result{}
 
 					metrics.IncActionCount(metrics.ActionTypeTerminate, metrics.StatusFailed, node.Name)
+					setPhase(ctx, &terminateNode.Status.Phase, janitordgxcnvidiacomv1alpha1.ActionPhaseFailed, node.Name)
 				}
 
 				terminateNode.SetCondition(signalSentCondition)
@@ -399,6 +505,15 @@ func isNodeNotReady(node *corev1.Node) bool {
 	return false
 }
 
+// getSignalTimeout returns the timeout for a single CSP call
+func (r *TerminateNodeReconciler) getSignalTimeout() time.Duration {
+	if r.Config == nil {
+		return CSPOperationTimeout
+	}
+
+	return signalTimeoutOrDefault(r.Config.SignalTimeout)
+}
+
 // getTerminateTimeout returns the timeout for terminate operations
 func (r *TerminateNodeReconciler) getTerminateTimeout() time.Duration {
 	cfg := r.Config
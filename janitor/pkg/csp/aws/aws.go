@@ -40,11 +40,37 @@ type EC2 interface {
 		input *ec2.RebootInstancesInput,
 		opts ...func(*ec2.Options),
 	) (*ec2.RebootInstancesOutput, error)
+	StopInstances(
+		ctx context.Context,
+		input *ec2.StopInstancesInput,
+		opts ...func(*ec2.Options),
+	) (*ec2.StopInstancesOutput, error)
+	StartInstances(
+		ctx context.Context,
+		input *ec2.StartInstancesInput,
+		opts ...func(*ec2.Options),
+	) (*ec2.StartInstancesOutput, error)
+	TerminateInstances(
+		ctx context.Context,
+		input *ec2.TerminateInstancesInput,
+		opts ...func(*ec2.Options),
+	) (*ec2.TerminateInstancesOutput, error)
+	DescribeInstances(
+		ctx context.Context,
+		input *ec2.DescribeInstancesInput,
+		opts ...func(*ec2.Options),
+	) (*ec2.DescribeInstancesOutput, error)
 }
 
 // Client is the AWS implementation of the CSP Client interface.
 type Client struct {
 	ec2 EC2
+
+	// rebootMode selects how reboot signals are carried out
+	rebootMode RebootMode
+	// requiredTags must be present on an instance before it is stopped or
+	// terminated, an empty value matches any tag value
+	requiredTags map[string]string
 }
 
 // ClientOptionFunc is a function that configures a Client.
@@ -52,7 +78,7 @@ type ClientOptionFunc func(*Client) error
 
 // NewClient creates a new AWS client with the provided options.
 func NewClient(opts ...ClientOptionFunc) (*Client, error) {
-	c := &Client{}
+	c := &Client{rebootMode: RebootModeReboot}
 	for _, opt := range opts {
 		if err := opt(c); err != nil {
 			return nil, err
@@ -64,7 +90,11 @@ func NewClient(opts ...ClientOptionFunc) (*Client, error) {
 
 // NewClientFromEnv creates a new AWS client based on environment variables.
 func NewClientFromEnv(ctx context.Context) (*Client, error) {
-	return NewClient(WithEC2Client(ctx))
+	return NewClient(
+		WithEC2Client(ctx),
+		WithRebootMode(RebootMode(os.Getenv(RebootModeEnvVar))),
+		WithRequiredInstanceTags(os.Getenv(RequiredInstanceTagsEnvVar)),
+	)
 }
 
 // WithEC2Client returns an option function that configures the AWS EC2 client.
@@ -74,38 +104,92 @@ func WithEC2Client(ctx context.Context) ClientOptionFunc {
 			return nil
 		}
 
-		cfg, err := config.LoadDefaultConfig(ctx,
+		loadOpts := []func(*config.LoadOptions) error{
 			config.WithRegion(os.Getenv("AWS_REGION")),
-		)
+		}
+
+		if name := os.Getenv(CredentialsSecretEnvVar); name != "" {
+			log.FromContext(ctx).Info("Using AWS credentials from secret", "secret", name)
+
+			credentials, err := newSecretCredentialsProvider(name)
+			if err != nil {
+				return fmt.Errorf("failed to load credentials for EC2 client: %w", err)
+			}
+
+			loadOpts = append(loadOpts, config.WithCredentialsProvider(credentials))
+		}
+
+		cfg, err := config.LoadDefaultConfig(ctx, loadOpts...)
 		if err != nil {
 			return fmt.Errorf("failed to load config for EC2 client: %w", err)
 		}
 
+		if roleARN := os.Getenv(AssumeRoleARNEnvVar); roleARN != "" {
+			log.FromContext(ctx).Info("Assuming AWS IAM role for EC2 calls", "roleARN", roleARN)
+
+			cfg.Credentials = newAssumeRoleCredentialsProvider(cfg, roleARN)
+		}
+
 		c.ec2 = ec2.NewFromConfig(cfg)
 
 		return nil
 	}
 }
 
-// SendRebootSignal sends a reboot signal to AWS EC2 for the given node.
+// WithEC2 returns an option function that uses the given EC2 API.
+func WithEC2(api EC2) ClientOptionFunc {
+	return func(c *Client) error {
+		c.ec2 = api
+		return nil
+	}
+}
+
+// WithRebootMode returns an option function that sets the reboot mode. An
+// empty mode keeps the default in-place reboot.
+func WithRebootMode(mode RebootMode) ClientOptionFunc {
+	return func(c *Client) error {
+		switch mode {
+		case "":
+			return nil
+		case RebootModeReboot, RebootModeStopStart:
+			c.rebootMode = mode
+			return nil
+		default:
+			return fmt.Errorf("unsupported AWS reboot mode %q", mode)
+		}
+	}
+}
+
+// WithRequiredInstanceTags returns an option function that sets the tags an
+// instance must carry before it is stopped or terminated, given as a comma
+// separated list of key or key=value entries.
+func WithRequiredInstanceTags(tags string) ClientOptionFunc {
+	return func(c *Client) error {
+		required, err := parseRequiredTags(tags)
+		if err != nil {
+			return err
+		}
+
+		c.requiredTags = required
+
+		return nil
+	}
+}
+
+// SendRebootSignal sends a reboot signal to AWS EC2 for the given node. In the
+// stop-start mode the instance is stopped and started again by IsNodeReady.
 func (c *Client) SendRebootSignal(ctx context.Context, node corev1.Node) (model.ResetSignalRequestRef, error) {
 	logger := log.FromContext(ctx)
 
-	// Fetch the node's provider ID
-	providerID := node.Spec.ProviderID
-	if providerID == "" {
-		err := fmt.Errorf("no provider ID found for node %s", node.Name)
+	instanceID, err := nodeInstanceID(node)
+	if err != nil {
 		logger.Error(err, "Failed to reboot node")
 
 		return "", err
 	}
 
-	// Extract the instance ID from the provider ID
-	instanceID, err := parseAWSProviderID(providerID)
-	if err != nil {
-		logger.Error(err, "Failed to parse provider ID")
-
-		return "", err
+	if c.rebootMode == RebootModeStopStart {
+		return c.sendStopSignal(ctx, node.Name, instanceID)
 	}
 
 	// Reboot the EC2 instance
@@ -126,6 +210,10 @@ func (c *Client) SendRebootSignal(ctx context.Context, node corev1.Node) (model.
 // IsNodeReady checks if the node is ready after a reboot signal was sent.
 // AWS requires a 5-minute cooldown period before the node status is reliable.
 func (c *Client) IsNodeReady(ctx context.Context, node corev1.Node, message string) (bool, error) {
+	if strings.HasPrefix(message, stopStartRefPrefix) {
+		return c.isStopStartDone(ctx, node, message)
+	}
+
 	// Sending a reboot request to AWS doesn't update statuses immediately,
 	// the ec2 instance does not report that it isn't in a running state for some time
 	// and kubernetes still sees the node as ready. Wait five minutes before checking the status
@@ -141,9 +229,47 @@ func (c *Client) IsNodeReady(ctx context.Context, node corev1.Node, message stri
 	return true, nil
 }
 
-// SendTerminateSignal is not implemented for AWS.
+// SendTerminateSignal terminates the EC2 instance of the node, so that its
+// auto scaling group or node provisioner replaces it with a new instance.
 func (c *Client) SendTerminateSignal(ctx context.Context, node corev1.Node) (model.TerminateNodeRequestRef, error) {
-	return model.TerminateNodeRequestRef(""), fmt.Errorf("SendTerminateSignal not implemented for AWS")
+	logger := log.FromContext(ctx)
+
+	instanceID, err := nodeInstanceID(node)
+	if err != nil {
+		logger.Error(err, "Failed to terminate node")
+
+		return "", err
+	}
+
+	instance, err := c.describeInstance(ctx, instanceID)
+	if err != nil {
+		return "", err
+	}
+
+	if err := c.checkInstanceSafety(instance); err != nil {
+		return "", err
+	}
+
+	logger.Info(fmt.Sprintf("Terminating node %s (Instance ID: %s)", node.Name, instanceID))
+
+	if _, err := c.ec2.TerminateInstances(ctx, &ec2.TerminateInstancesInput{
+		InstanceIds: []string{instanceID},
+	}); err != nil {
+		logger.Error(err, fmt.Sprintf("Failed to terminate instance %s: %s", instanceID, err))
+
+		return "", err
+	}
+
+	return model.TerminateNodeRequestRef(time.Now().Format(time.RFC3339)), nil
+}
+
+// nodeInstanceID returns the EC2 instance ID from the node provider ID.
+func nodeInstanceID(node corev1.Node) (string, error) {
+	if node.Spec.ProviderID == "" {
+		return "", fmt.Errorf("no provider ID found for node %s", node.Name)
+	}
+
+	return parseAWSProviderID(node.Spec.ProviderID)
 }
 
 // parseAWSProviderID extracts the EC2 instance ID from an AWS provider ID.
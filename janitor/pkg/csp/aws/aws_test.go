@@ -0,0 +1,231 @@
+// Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package aws
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	awssdk "github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	"github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const testInstanceID = "i-0123456789abcdef0"
+
+// fakeEC2 keeps a single instance and records the calls made.
+type fakeEC2 struct {
+	instance types.Instance
+	calls    []string
+}
+
+func newFakeEC2(tags map[string]string) *fakeEC2 {
+	f := &fakeEC2{instance: types.Instance{
+		InstanceId:     awssdk.String(testInstanceID),
+		State:          &types.InstanceState{Name: types.InstanceStateNameRunning},
+		RootDeviceType: types.DeviceTypeEbs,
+		LaunchTime:     awssdk.Time(time.Now().Add(-24 * time.Hour)),
+	}}
+
+	for key, value := range tags {
+		f.instance.Tags = append(f.instance.Tags, types.Tag{Key: awssdk.String(key), Value: awssdk.String(value)})
+	}
+
+	return f
+}
+
+func (f *fakeEC2) setState(state types.InstanceStateName) {
+	f.instance.State = &types.InstanceState{Name: state}
+}
+
+func (f *fakeEC2) RebootInstances(
+	context.Context, *ec2.RebootInstancesInput, ...func(*ec2.Options),
+) (*ec2.RebootInstancesOutput, error) {
+	f.calls = append(f.calls, "reboot")
+	return &ec2.RebootInstancesOutput{}, nil
+}
+
+func (f *fakeEC2) StopInstances(
+	context.Context, *ec2.StopInstancesInput, ...func(*ec2.Options),
+) (*ec2.StopInstancesOutput, error) {
+	f.calls = append(f.calls, "stop")
+	f.setState(types.InstanceStateNameStopping)
+
+	return &ec2.StopInstancesOutput{}, nil
+}
+
+func (f *fakeEC2) StartInstances(
+	context.Context, *ec2.StartInstancesInput, ...func(*ec2.Options),
+) (*ec2.StartInstancesOutput, error) {
+	f.calls = append(f.calls, "start")
+	f.setState(types.InstanceStateNamePending)
+	f.instance.LaunchTime = awssdk.Time(time.Now())
+
+	return &ec2.StartInstancesOutput{}, nil
+}
+
+func (f *fakeEC2) TerminateInstances(
+	context.Context, *ec2.TerminateInstancesInput, ...func(*ec2.Options),
+) (*ec2.TerminateInstancesOutput, error) {
+	f.calls = append(f.calls, "terminate")
+	return &ec2.TerminateInstancesOutput{}, nil
+}
+
+func (f *fakeEC2) DescribeInstances(
+	context.Context, *ec2.DescribeInstancesInput, ...func(*ec2.Options),
+) (*ec2.DescribeInstancesOutput, error) {
+	return &ec2.DescribeInstancesOutput{
+		Reservations: []types.Reservation{{Instances: []types.Instance{f.instance}}},
+	}, nil
+}
+
+func testNode() corev1.Node {
+	return corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{Name: "gpu-node"},
+		Spec:       corev1.NodeSpec{ProviderID: "aws:///us-east-1a/" + testInstanceID},
+	}
+}
+
+func TestSendRebootSignalRebootMode(t *testing.T) {
+	api := newFakeEC2(nil)
+
+	c, err := NewClient(WithEC2(api))
+	require.NoError(t, err)
+
+	ref, err := c.SendRebootSignal(context.Background(), testNode())
+	require.NoError(t, err)
+	assert.NotContains(t, string(ref), stopStartRefPrefix)
+	assert.Equal(t, []string{"reboot"}, api.calls)
+}
+
+func TestStopStart(t *testing.T) {
+	ctx := context.Background()
+	node := testNode()
+	api := newFakeEC2(map[string]string{"kubernetes.io/cluster/gpu": "owned"})
+
+	c, err := NewClient(
+		WithEC2(api),
+		WithRebootMode(RebootModeStopStart),
+		WithRequiredInstanceTags("kubernetes.io/cluster/gpu=owned"),
+	)
+	require.NoError(t, err)
+
+	ref, err := c.SendRebootSignal(ctx, node)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"stop"}, api.calls)
+
+	// The launch time is older than the signal while stopping
+	ready, err := c.IsNodeReady(ctx, node, string(ref))
+	require.NoError(t, err)
+	assert.False(t, ready)
+
+	api.setState(types.InstanceStateNameStopped)
+
+	ready, err = c.IsNodeReady(ctx, node, string(ref))
+	require.NoError(t, err)
+	assert.False(t, ready)
+	assert.Equal(t, []string{"stop", "start"}, api.calls)
+
+	api.setState(types.InstanceStateNameRunning)
+
+	ready, err = c.IsNodeReady(ctx, node, string(ref))
+	require.NoError(t, err)
+	assert.True(t, ready)
+}
+
+func TestStopStartSafetyChecks(t *testing.T) {
+	tests := []struct {
+		name   string
+		tags   map[string]string
+		modify func(*types.Instance)
+	}{
+		{
+			name: "missing required tag",
+			tags: map[string]string{},
+		},
+		{
+			name: "required tag value mismatch",
+			tags: map[string]string{"kubernetes.io/cluster/gpu": "shared"},
+		},
+		{
+			name: "protected",
+			tags: map[string]string{"kubernetes.io/cluster/gpu": "owned", ProtectedTagKey: "true"},
+		},
+		{
+			name:   "instance store root device",
+			tags:   map[string]string{"kubernetes.io/cluster/gpu": "owned"},
+			modify: func(i *types.Instance) { i.RootDeviceType = types.DeviceTypeInstanceStore },
+		},
+		{
+			name:   "spot instance",
+			tags:   map[string]string{"kubernetes.io/cluster/gpu": "owned"},
+			modify: func(i *types.Instance) { i.InstanceLifecycle = types.InstanceLifecycleTypeSpot },
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			api := newFakeEC2(tt.tags)
+			if tt.modify != nil {
+				tt.modify(&api.instance)
+			}
+
+			c, err := NewClient(
+				WithEC2(api),
+				WithRebootMode(RebootModeStopStart),
+				WithRequiredInstanceTags("kubernetes.io/cluster/gpu=owned"),
+			)
+			require.NoError(t, err)
+
+			_, err = c.SendRebootSignal(context.Background(), testNode())
+			assert.Error(t, err)
+			assert.Empty(t, api.calls)
+		})
+	}
+}
+
+func TestSendTerminateSignal(t *testing.T) {
+	api := newFakeEC2(map[string]string{ProtectedTagKey: "true"})
+
+	c, err := NewClient(WithEC2(api))
+	require.NoError(t, err)
+
+	_, err = c.SendTerminateSignal(context.Background(), testNode())
+	require.Error(t, err)
+	assert.Empty(t, api.calls)
+
+	api.instance.Tags = nil
+
+	_, err = c.SendTerminateSignal(context.Background(), testNode())
+	require.NoError(t, err)
+	assert.Equal(t, []string{"terminate"}, api.calls)
+}
+
+func TestClientOptions(t *testing.T) {
+	_, err := NewClient(WithRebootMode("hibernate"))
+	assert.Error(t, err)
+
+	_, err = NewClient(WithRequiredInstanceTags("=value"))
+	assert.Error(t, err)
+
+	tags, err := parseRequiredTags(" team=gpu, kubernetes.io/cluster/gpu ,")
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"team": "gpu", "kubernetes.io/cluster/gpu": ""}, tags)
+}
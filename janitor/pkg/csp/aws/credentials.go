@@ -0,0 +1,108 @@
+// Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package aws
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	awssdk "github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/credentials/stscreds"
+	"github.com/aws/aws-sdk-go-v2/service/sts"
+
+	"github.com/nvidia/nvsentinel/commons/pkg/secrets"
+)
+
+const (
+	// CredentialsSecretEnvVar names the secret holding static AWS credentials. When
+	// unset, the default credential chain (IRSA, instance profile) is used.
+	CredentialsSecretEnvVar = "AWS_CREDENTIALS_SECRET"
+
+	// AssumeRoleARNEnvVar names an IAM role assumed for EC2 calls on top of the
+	// base credentials, e.g. a role in the account owning the GPU instances.
+	AssumeRoleARNEnvVar = "AWS_ASSUME_ROLE_ARN"
+
+	assumeRoleSessionName = "nvsentinel-janitor"
+
+	accessKeyIDKey     = "aws_access_key_id"
+	secretAccessKeyKey = "aws_secret_access_key"
+	sessionTokenKey    = "aws_session_token"
+
+	// credentialsRefreshInterval is how long credentials are used before the secret
+	// is read again, so rotated keys are picked up
+	credentialsRefreshInterval = 5 * time.Minute
+)
+
+// secretCredentialsProvider retrieves AWS credentials from a secret holding the
+// aws_access_key_id, aws_secret_access_key and optionally aws_session_token keys.
+type secretCredentialsProvider struct {
+	secrets secrets.Provider
+	name    string
+}
+
+var _ awssdk.CredentialsProvider = (*secretCredentialsProvider)(nil)
+
+// Retrieve implements aws.CredentialsProvider.
+func (p *secretCredentialsProvider) Retrieve(ctx context.Context) (awssdk.Credentials, error) {
+	secret, err := p.secrets.Get(ctx, p.name)
+	if err != nil {
+		return awssdk.Credentials{}, fmt.Errorf("retrieving AWS credentials: %w", err)
+	}
+
+	accessKeyID, err := secret.Value(accessKeyIDKey)
+	if err != nil {
+		return awssdk.Credentials{}, err
+	}
+
+	secretAccessKey, err := secret.Value(secretAccessKeyKey)
+	if err != nil {
+		return awssdk.Credentials{}, err
+	}
+
+	// the session token is only present for temporary credentials
+	sessionToken, _ := secret.Value(sessionTokenKey)
+
+	return awssdk.Credentials{
+		AccessKeyID:     accessKeyID,
+		SecretAccessKey: secretAccessKey,
+		SessionToken:    sessionToken,
+		Source:          "secret/" + p.name,
+		CanExpire:       true,
+		Expires:         time.Now().Add(credentialsRefreshInterval),
+	}, nil
+}
+
+// newSecretCredentialsProvider returns a cached credentials provider backed by the
+// named secret of the SECRETS_* configured secrets provider.
+func newSecretCredentialsProvider(name string) (awssdk.CredentialsProvider, error) {
+	provider, err := secrets.NewProviderFromEnv()
+	if err != nil {
+		return nil, fmt.Errorf("creating secrets provider: %w", err)
+	}
+
+	return awssdk.NewCredentialsCache(&secretCredentialsProvider{secrets: provider, name: name}), nil
+}
+
+// newAssumeRoleCredentialsProvider returns a cached provider of credentials of
+// the role, assumed with the credentials of cfg.
+func newAssumeRoleCredentialsProvider(cfg awssdk.Config, roleARN string) awssdk.CredentialsProvider {
+	provider := stscreds.NewAssumeRoleProvider(sts.NewFromConfig(cfg), roleARN,
+		func(o *stscreds.AssumeRoleOptions) {
+			o.RoleSessionName = assumeRoleSessionName
+		})
+
+	return awssdk.NewCredentialsCache(provider)
+}
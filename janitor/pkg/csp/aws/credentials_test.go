@@ -0,0 +1,62 @@
+// Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package aws
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/nvidia/nvsentinel/commons/pkg/secrets"
+)
+
+func TestSecretCredentialsProvider(t *testing.T) {
+	dir := t.TempDir()
+	secretDir := filepath.Join(dir, "aws-credentials")
+	require.NoError(t, os.MkdirAll(secretDir, 0o700))
+
+	writeKey := func(key, value string) {
+		require.NoError(t, os.WriteFile(filepath.Join(secretDir, key), []byte(value), 0o600))
+	}
+
+	p := &secretCredentialsProvider{secrets: secrets.NewFileProvider(dir), name: "aws-credentials"}
+
+	writeKey(accessKeyIDKey, "AKIA1")
+
+	_, err := p.Retrieve(context.Background())
+	assert.ErrorIs(t, err, secrets.ErrKeyNotFound)
+
+	writeKey(secretAccessKeyKey, "secret1")
+
+	creds, err := p.Retrieve(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "AKIA1", creds.AccessKeyID)
+	assert.Equal(t, "secret1", creds.SecretAccessKey)
+	assert.Empty(t, creds.SessionToken)
+	assert.True(t, creds.CanExpire)
+
+	// rotated keys are returned on the next retrieval
+	writeKey(accessKeyIDKey, "AKIA2")
+	writeKey(sessionTokenKey, "token2")
+
+	creds, err = p.Retrieve(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "AKIA2", creds.AccessKeyID)
+	assert.Equal(t, "token2", creds.SessionToken)
+}
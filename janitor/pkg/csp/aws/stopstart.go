@@ -0,0 +1,225 @@
+// Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package aws
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	awssdk "github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/ec2"
+	"github.com/aws/aws-sdk-go-v2/service/ec2/types"
+	"github.com/nvidia/nvsentinel/janitor/pkg/model"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+// RebootMode selects how a reboot signal is carried out on EC2.
+type RebootMode string
+
+const (
+	// RebootModeReboot reboots the instance in place (RebootInstances).
+	RebootModeReboot RebootMode = "reboot"
+	// RebootModeStopStart stops and starts the instance, which moves it to new
+	// underlying hardware. This is the bare metal restart of instances without
+	// a BMC reachable by the janitor.
+	RebootModeStopStart RebootMode = "stop-start"
+
+	// RebootModeEnvVar configures the reboot mode, reboot by default.
+	RebootModeEnvVar = "AWS_REBOOT_MODE"
+	// RequiredInstanceTagsEnvVar lists the tags an instance must carry before it
+	// is stopped or terminated, as comma separated key or key=value entries.
+	RequiredInstanceTagsEnvVar = "AWS_REQUIRED_INSTANCE_TAGS"
+
+	// ProtectedTagKey opts an instance out of stop-start and termination when
+	// set to "true", e.g. for instances holding local data.
+	ProtectedTagKey = "nvsentinel.nvidia.com/protected"
+
+	// stopStartRefPrefix marks request references of stop-start reboots
+	stopStartRefPrefix = "stop-start/"
+)
+
+// sendStopSignal stops the instance after the safety checks. The instance is
+// started again by isStopStartDone once it reached the stopped state.
+func (c *Client) sendStopSignal(
+	ctx context.Context,
+	nodeName, instanceID string,
+) (model.ResetSignalRequestRef, error) {
+	logger := log.FromContext(ctx)
+
+	instance, err := c.describeInstance(ctx, instanceID)
+	if err != nil {
+		return "", err
+	}
+
+	if err := c.checkInstanceSafety(instance); err != nil {
+		return "", err
+	}
+
+	if err := checkStoppable(instance); err != nil {
+		return "", err
+	}
+
+	logger.Info(fmt.Sprintf("Stopping node %s (Instance ID: %s) for stop-start", nodeName, instanceID))
+
+	if _, err := c.ec2.StopInstances(ctx, &ec2.StopInstancesInput{
+		InstanceIds: []string{instanceID},
+	}); err != nil {
+		logger.Error(err, fmt.Sprintf("Failed to stop instance %s: %s", instanceID, err))
+
+		return "", err
+	}
+
+	return model.ResetSignalRequestRef(stopStartRefPrefix + time.Now().UTC().Format(time.RFC3339)), nil
+}
+
+// isStopStartDone drives a stop-start reboot: it starts the instance once it
+// is stopped and reports it done once it runs again. The launch time of an
+// instance is updated on start, so a running instance launched before the
+// signal has not been stopped yet.
+func (c *Client) isStopStartDone(ctx context.Context, node corev1.Node, message string) (bool, error) {
+	logger := log.FromContext(ctx)
+
+	signalTime, err := time.Parse(time.RFC3339, strings.TrimPrefix(message, stopStartRefPrefix))
+	if err != nil {
+		return false, err
+	}
+
+	instanceID, err := nodeInstanceID(node)
+	if err != nil {
+		return false, err
+	}
+
+	instance, err := c.describeInstance(ctx, instanceID)
+	if err != nil {
+		return false, err
+	}
+
+	state := types.InstanceStateNameRunning
+	if instance.State != nil {
+		state = instance.State.Name
+	}
+
+	switch state {
+	case types.InstanceStateNameStopped:
+		logger.Info(fmt.Sprintf("Starting stopped node %s (Instance ID: %s)", node.Name, instanceID))
+
+		if _, err := c.ec2.StartInstances(ctx, &ec2.StartInstancesInput{
+			InstanceIds: []string{instanceID},
+		}); err != nil {
+			return false, fmt.Errorf("failed to start instance %s: %w", instanceID, err)
+		}
+
+		return false, nil
+	case types.InstanceStateNameRunning:
+		return instance.LaunchTime != nil && !instance.LaunchTime.Before(signalTime), nil
+	case types.InstanceStateNameTerminated, types.InstanceStateNameShuttingDown:
+		return false, fmt.Errorf("instance %s is %s", instanceID, state)
+	default:
+		// pending, stopping
+		return false, nil
+	}
+}
+
+func (c *Client) describeInstance(ctx context.Context, instanceID string) (*types.Instance, error) {
+	out, err := c.ec2.DescribeInstances(ctx, &ec2.DescribeInstancesInput{
+		InstanceIds: []string{instanceID},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to describe instance %s: %w", instanceID, err)
+	}
+
+	for _, reservation := range out.Reservations {
+		for i := range reservation.Instances {
+			if awssdk.ToString(reservation.Instances[i].InstanceId) == instanceID {
+				return &reservation.Instances[i], nil
+			}
+		}
+	}
+
+	return nil, fmt.Errorf("instance %s not found", instanceID)
+}
+
+// checkInstanceSafety refuses to act on instances that opted out through the
+// protected tag or lack one of the required tags, e.g. the cluster ownership
+// tag, so that a wrong provider ID can never stop an unrelated instance.
+func (c *Client) checkInstanceSafety(instance *types.Instance) error {
+	instanceID := awssdk.ToString(instance.InstanceId)
+
+	tags := make(map[string]string, len(instance.Tags))
+	for _, tag := range instance.Tags {
+		tags[awssdk.ToString(tag.Key)] = awssdk.ToString(tag.Value)
+	}
+
+	if strings.EqualFold(tags[ProtectedTagKey], "true") {
+		return fmt.Errorf("instance %s is protected by tag %s", instanceID, ProtectedTagKey)
+	}
+
+	for key, value := range c.requiredTags {
+		actual, ok := tags[key]
+		if !ok {
+			return fmt.Errorf("instance %s is missing required tag %s", instanceID, key)
+		}
+
+		if value != "" && actual != value {
+			return fmt.Errorf("instance %s tag %s is %q, required %q", instanceID, key, actual, value)
+		}
+	}
+
+	return nil
+}
+
+// checkStoppable refuses instances that lose their root volume or are
+// reclaimed when stopped.
+func checkStoppable(instance *types.Instance) error {
+	instanceID := awssdk.ToString(instance.InstanceId)
+
+	if instance.RootDeviceType != types.DeviceTypeEbs {
+		return fmt.Errorf("instance %s has an %s root device and cannot be stopped", instanceID, instance.RootDeviceType)
+	}
+
+	if instance.InstanceLifecycle == types.InstanceLifecycleTypeSpot {
+		return fmt.Errorf("instance %s is a spot instance and cannot be stop-started", instanceID)
+	}
+
+	if instance.State != nil && instance.State.Name != types.InstanceStateNameRunning {
+		return fmt.Errorf("instance %s is %s, not running", instanceID, instance.State.Name)
+	}
+
+	return nil
+}
+
+// parseRequiredTags parses comma separated key or key=value entries.
+func parseRequiredTags(tags string) (map[string]string, error) {
+	required := map[string]string{}
+
+	for _, entry := range strings.Split(tags, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+
+		key, value, _ := strings.Cut(entry, "=")
+		if key = strings.TrimSpace(key); key == "" {
+			return nil, fmt.Errorf("invalid required instance tag %q", entry)
+		}
+
+		required[key] = strings.TrimSpace(value)
+	}
+
+	return required, nil
+}
@@ -23,6 +23,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
 	"github.com/Azure/azure-sdk-for-go/sdk/azcore/runtime"
 	"github.com/Azure/azure-sdk-for-go/sdk/azidentity"
 	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/compute/armcompute"
@@ -51,19 +52,59 @@ type VMSSClientInterface interface {
 		instanceID string,
 		options *armcompute.VirtualMachineScaleSetVMsClientBeginRestartOptions,
 	) (*runtime.Poller[armcompute.VirtualMachineScaleSetVMsClientRestartResponse], error)
+	BeginRedeploy(
+		ctx context.Context,
+		resourceGroupName string,
+		vmScaleSetName string,
+		instanceID string,
+		options *armcompute.VirtualMachineScaleSetVMsClientBeginRedeployOptions,
+	) (*runtime.Poller[armcompute.VirtualMachineScaleSetVMsClientRedeployResponse], error)
 }
 
+// RebootMode selects how a reboot signal is carried out on Azure.
+type RebootMode string
+
+const (
+	// RebootModeRestart restarts the VM on its current host.
+	RebootModeRestart RebootMode = "restart"
+	// RebootModeRedeploy moves the VM to a new host and powers it on again,
+	// the bare metal restart of VMs without a reachable BMC.
+	RebootModeRedeploy RebootMode = "redeploy"
+
+	// RebootModeEnvVar configures the reboot mode, restart by default.
+	RebootModeEnvVar = "AZURE_REBOOT_MODE"
+)
+
 // Client is the Azure implementation of the CSP Client interface.
 type Client struct {
 	// Optional client for testing - if nil, uses default Azure client
 	vmssClient VMSSClientInterface
+	rebootMode RebootMode
+	// credential authenticates the default client, nil uses the default
+	// credential chain
+	credential azcore.TokenCredential
 }
 
 // NewClient creates a new Azure client.
 func NewClient(ctx context.Context) (*Client, error) {
+	mode := RebootMode(os.Getenv(RebootModeEnvVar))
+
+	switch mode {
+	case "":
+		mode = RebootModeRestart
+	case RebootModeRestart, RebootModeRedeploy:
+	default:
+		return nil, fmt.Errorf("unsupported Azure reboot mode %q", mode)
+	}
+
+	credential, err := newSecretCredentialFromEnv()
+	if err != nil {
+		return nil, err
+	}
+
 	// Azure client initialization is deferred until first API call
 	// This allows validation to happen at construction time in the future
-	return &Client{}, nil
+	return &Client{rebootMode: mode, credential: credential}, nil
 }
 
 // SendRebootSignal sends a reboot signal to Azure for the node.
@@ -93,8 +134,14 @@ func (c *Client) SendRebootSignal(ctx context.Context, node corev1.Node) (model.
 		return "", err
 	}
 
-	// Reboot the VM
-	_, err = vmssClient.BeginRestart(ctx, resourceGroup, vmName, instanceID, nil)
+	// Reboot the VM, redeploying it to a new host in the redeploy mode
+	if c.rebootMode == RebootModeRedeploy {
+		logger.Info(fmt.Sprintf("Redeploying node %s (VMSS: %s, instance: %s)", node.Name, vmName, instanceID))
+		_, err = vmssClient.BeginRedeploy(ctx, resourceGroup, vmName, instanceID, nil)
+	} else {
+		_, err = vmssClient.BeginRestart(ctx, resourceGroup, vmName, instanceID, nil)
+	}
+
 	if err != nil {
 		logger.Error(err, fmt.Sprintf("Failed to send restart signal to node %s: %s", vmName, err))
 		return "", err
@@ -187,10 +234,10 @@ func (c *Client) getVMSSClient(ctx context.Context) (VMSSClientInterface, error)
 	}
 
 	// Default production behavior
-	return createDefaultVMSSClient(ctx)
+	return createDefaultVMSSClient(ctx, c.credential)
 }
 
-func createDefaultVMSSClient(ctx context.Context) (VMSSClientInterface, error) {
+func createDefaultVMSSClient(ctx context.Context, cred azcore.TokenCredential) (VMSSClientInterface, error) {
 	logger := log.FromContext(ctx)
 
 	// Get the Azure subscription ID from environment variable or IMDS
@@ -200,10 +247,12 @@ func createDefaultVMSSClient(ctx context.Context) (VMSSClientInterface, error) {
 	}
 
 	// Create an Azure client
-	cred, err := azidentity.NewDefaultAzureCredential(nil)
-	if err != nil {
-		logger.Error(err, "Failed to create Azure credential")
-		return nil, err
+	if cred == nil {
+		cred, err = azidentity.NewDefaultAzureCredential(nil)
+		if err != nil {
+			logger.Error(err, "Failed to create Azure credential")
+			return nil, err
+		}
 	}
 
 	vmssClient, err := armcompute.NewVirtualMachineScaleSetVMsClient(subscriptionID, cred, nil)
@@ -0,0 +1,117 @@
+// Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package azure
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sync"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/policy"
+	"github.com/Azure/azure-sdk-for-go/sdk/azidentity"
+
+	"github.com/nvidia/nvsentinel/commons/pkg/secrets"
+)
+
+const (
+	// CredentialsSecretEnvVar names the secret holding the client secret of a
+	// service principal. When unset, the default credential chain (workload
+	// identity, managed identity) is used.
+	CredentialsSecretEnvVar = "AZURE_CREDENTIALS_SECRET"
+
+	tenantIDKey     = "tenant_id"
+	clientIDKey     = "client_id"
+	clientSecretKey = "client_secret"
+)
+
+// secretCredential authenticates as the service principal of a secret holding
+// the tenant_id, client_id and client_secret keys. The secret is read on every
+// token request, and a rotated secret replaces the underlying credential.
+type secretCredential struct {
+	secrets secrets.Provider
+	name    string
+
+	mu         sync.Mutex
+	version    string
+	credential azcore.TokenCredential
+}
+
+var _ azcore.TokenCredential = (*secretCredential)(nil)
+
+// GetToken implements azcore.TokenCredential.
+func (c *secretCredential) GetToken(ctx context.Context, opts policy.TokenRequestOptions) (azcore.AccessToken, error) {
+	credential, err := c.current(ctx)
+	if err != nil {
+		return azcore.AccessToken{}, err
+	}
+
+	return credential.GetToken(ctx, opts)
+}
+
+// current returns the credential of the current version of the secret. The
+// credential is kept across calls so its token cache is used.
+func (c *secretCredential) current(ctx context.Context) (azcore.TokenCredential, error) {
+	secret, err := c.secrets.Get(ctx, c.name)
+	if err != nil {
+		return nil, fmt.Errorf("retrieving Azure credentials: %w", err)
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.credential != nil && c.version == secret.Version {
+		return c.credential, nil
+	}
+
+	values := make(map[string]string, 3)
+
+	for _, key := range []string{tenantIDKey, clientIDKey, clientSecretKey} {
+		value, err := secret.Value(key)
+		if err != nil {
+			return nil, err
+		}
+
+		values[key] = value
+	}
+
+	credential, err := azidentity.NewClientSecretCredential(values[tenantIDKey], values[clientIDKey],
+		values[clientSecretKey], nil)
+	if err != nil {
+		return nil, fmt.Errorf("creating Azure credential from secret %s: %w", c.name, err)
+	}
+
+	c.credential = credential
+	c.version = secret.Version
+
+	return credential, nil
+}
+
+// newSecretCredentialFromEnv returns the secret credential named by
+// CredentialsSecretEnvVar, or nil when it is unset.
+func newSecretCredentialFromEnv() (azcore.TokenCredential, error) {
+	name := os.Getenv(CredentialsSecretEnvVar)
+	if name == "" {
+		return nil, nil
+	}
+
+	provider, err := secrets.NewProviderFromEnv()
+	if err != nil {
+		return nil, fmt.Errorf("creating secrets provider: %w", err)
+	}
+
+	return &secretCredential{secrets: provider, name: name}, nil
+}
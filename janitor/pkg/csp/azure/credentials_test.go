@@ -0,0 +1,76 @@
+// Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package azure
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/nvidia/nvsentinel/commons/pkg/secrets"
+)
+
+func TestSecretCredential(t *testing.T) {
+	dir := t.TempDir()
+	secretDir := filepath.Join(dir, "azure-credentials")
+	require.NoError(t, os.MkdirAll(secretDir, 0o700))
+
+	writeKey := func(key, value string) {
+		require.NoError(t, os.WriteFile(filepath.Join(secretDir, key), []byte(value), 0o600))
+	}
+
+	c := &secretCredential{secrets: secrets.NewFileProvider(dir), name: "azure-credentials"}
+
+	writeKey(tenantIDKey, "00000000-0000-0000-0000-000000000001")
+	writeKey(clientIDKey, "00000000-0000-0000-0000-000000000002")
+
+	_, err := c.current(context.Background())
+	assert.ErrorIs(t, err, secrets.ErrKeyNotFound)
+
+	writeKey(clientSecretKey, "secret1")
+
+	first, err := c.current(context.Background())
+	require.NoError(t, err)
+
+	// the credential is reused while the secret is unchanged
+	same, err := c.current(context.Background())
+	require.NoError(t, err)
+	assert.Same(t, first, same)
+
+	// a rotated secret replaces the credential
+	writeKey(clientSecretKey, "secret2")
+
+	rotated, err := c.current(context.Background())
+	require.NoError(t, err)
+	assert.NotSame(t, first, rotated)
+}
+
+func TestNewClientCredential(t *testing.T) {
+	t.Setenv(CredentialsSecretEnvVar, "")
+
+	client, err := NewClient(context.Background())
+	require.NoError(t, err)
+	assert.Nil(t, client.credential)
+
+	t.Setenv(CredentialsSecretEnvVar, "azure-credentials")
+
+	client, err = NewClient(context.Background())
+	require.NoError(t, err)
+	assert.IsType(t, &secretCredential{}, client.credential)
+}
@@ -0,0 +1,146 @@
+// Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package chaos wraps a CSP client with injectable failure points so the
+// remediation controllers can be exercised against BMC timeouts, rejected
+// signals and nodes that never come back from a reset.
+//
+// Faults are configured in the commons chaos format, for example
+// "reboot=timeout:2,ready=partial". Supported operations are reboot, ready and
+// terminate. Supported modes are:
+//
+//   - timeout: the call fails with context.DeadlineExceeded, as a hung BMC would
+//   - error: the call fails with a generic CSP error
+//   - partial: IsNodeReady reports the node as not ready, as after a partial
+//     GPU reset that left the node wedged (ready only)
+package chaos
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+
+	commonchaos "github.com/nvidia/nvsentinel/commons/pkg/chaos"
+	"github.com/nvidia/nvsentinel/janitor/pkg/model"
+)
+
+// EnvVar is the environment variable holding the fault specification.
+const EnvVar = commonchaos.EnvVar
+
+var (
+	_ model.CSPClient = (*Client)(nil)
+
+	// ErrInjected is returned by operations configured with the error mode.
+	ErrInjected = errors.New("chaos: injected CSP failure")
+)
+
+type (
+	// Operation identifies a CSP client call that faults can be injected into.
+	Operation = commonchaos.Operation
+	// Mode is the kind of failure injected into an operation.
+	Mode = commonchaos.Mode
+	// Fault describes the failure injected into a single operation.
+	Fault = commonchaos.Fault
+)
+
+const (
+	OpReboot    Operation = "reboot"
+	OpReady     Operation = "ready"
+	OpTerminate Operation = "terminate"
+)
+
+const (
+	ModeTimeout      = commonchaos.ModeTimeout
+	ModeError        = commonchaos.ModeError
+	ModePartial Mode = "partial"
+)
+
+// operations are the CSP client calls and the modes they support besides timeout
+// and error.
+var operations = commonchaos.Operations{
+	OpReboot:    nil,
+	OpReady:     {ModePartial},
+	OpTerminate: nil,
+}
+
+// Client is a CSP client that injects faults before delegating to the
+// wrapped client.
+type Client struct {
+	next     model.CSPClient
+	injector *commonchaos.Injector
+}
+
+// NewClient wraps next with the given faults.
+func NewClient(next model.CSPClient, faults map[Operation]Fault) *Client {
+	return &Client{
+		next:     next,
+		injector: commonchaos.NewInjector(faults),
+	}
+}
+
+// WrapFromEnv wraps next with the faults configured in EnvVar. The client is
+// returned unchanged when the variable is empty.
+func WrapFromEnv(next model.CSPClient) (model.CSPClient, error) {
+	faults, err := commonchaos.FaultsFromEnv(operations)
+	if err != nil {
+		return nil, err
+	}
+
+	if faults == nil {
+		return next, nil
+	}
+
+	return NewClient(next, faults), nil
+}
+
+func (c *Client) injectedError(op Operation, mode Mode) error {
+	if mode == ModeTimeout {
+		return fmt.Errorf("chaos: %s: %w", op, context.DeadlineExceeded)
+	}
+
+	return fmt.Errorf("%s: %w", op, ErrInjected)
+}
+
+// SendRebootSignal fails with the configured reboot fault or delegates.
+func (c *Client) SendRebootSignal(ctx context.Context, node corev1.Node) (model.ResetSignalRequestRef, error) {
+	if mode, ok := c.injector.Inject(OpReboot); ok {
+		return "", c.injectedError(OpReboot, mode)
+	}
+
+	return c.next.SendRebootSignal(ctx, node)
+}
+
+// IsNodeReady fails with the configured ready fault or delegates.
+func (c *Client) IsNodeReady(ctx context.Context, node corev1.Node, message string) (bool, error) {
+	if mode, ok := c.injector.Inject(OpReady); ok {
+		if mode == ModePartial {
+			return false, nil
+		}
+
+		return false, c.injectedError(OpReady, mode)
+	}
+
+	return c.next.IsNodeReady(ctx, node, message)
+}
+
+// SendTerminateSignal fails with the configured terminate fault or delegates.
+func (c *Client) SendTerminateSignal(ctx context.Context, node corev1.Node) (model.TerminateNodeRequestRef, error) {
+	if mode, ok := c.injector.Inject(OpTerminate); ok {
+		return "", c.injectedError(OpTerminate, mode)
+	}
+
+	return c.next.SendTerminateSignal(ctx, node)
+}
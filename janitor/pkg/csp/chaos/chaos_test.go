@@ -0,0 +1,134 @@
+// Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package chaos
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+
+	commonchaos "github.com/nvidia/nvsentinel/commons/pkg/chaos"
+	"github.com/nvidia/nvsentinel/janitor/pkg/model"
+)
+
+type healthyClient struct{}
+
+func (healthyClient) SendRebootSignal(context.Context, corev1.Node) (model.ResetSignalRequestRef, error) {
+	return "reboot-ref", nil
+}
+
+func (healthyClient) IsNodeReady(context.Context, corev1.Node, string) (bool, error) {
+	return true, nil
+}
+
+func (healthyClient) SendTerminateSignal(context.Context, corev1.Node) (model.TerminateNodeRequestRef, error) {
+	return "terminate-ref", nil
+}
+
+func TestParseFaults(t *testing.T) {
+	tests := []struct {
+		name     string
+		spec     string
+		expected map[Operation]Fault
+		wantErr  bool
+	}{
+		{
+			name: "multiple faults",
+			spec: "reboot=timeout:2, ready=partial,terminate=ERROR",
+			expected: map[Operation]Fault{
+				OpReboot:    {Mode: ModeTimeout, Count: 2},
+				OpReady:     {Mode: ModePartial},
+				OpTerminate: {Mode: ModeError},
+			},
+		},
+		{name: "empty spec", spec: "", expected: map[Operation]Fault{}},
+		{name: "missing mode", spec: "reboot", wantErr: true},
+		{name: "unknown operation", spec: "drain=error", wantErr: true},
+		{name: "unknown mode", spec: "reboot=explode", wantErr: true},
+		{name: "partial only for ready", spec: "reboot=partial", wantErr: true},
+		{name: "negative count", spec: "ready=error:-1", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			faults, err := commonchaos.ParseFaults(tt.spec, operations)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, faults)
+		})
+	}
+}
+
+func TestClient_TimeoutIsDeadlineExceeded(t *testing.T) {
+	c := NewClient(healthyClient{}, map[Operation]Fault{OpReboot: {Mode: ModeTimeout}})
+
+	_, err := c.SendRebootSignal(context.Background(), corev1.Node{})
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+}
+
+func TestClient_CountedFaultRecovers(t *testing.T) {
+	c := NewClient(healthyClient{}, map[Operation]Fault{OpTerminate: {Mode: ModeError, Count: 2}})
+
+	for range 2 {
+		_, err := c.SendTerminateSignal(context.Background(), corev1.Node{})
+		assert.ErrorIs(t, err, ErrInjected)
+	}
+
+	ref, err := c.SendTerminateSignal(context.Background(), corev1.Node{})
+	require.NoError(t, err)
+	assert.Equal(t, model.TerminateNodeRequestRef("terminate-ref"), ref)
+}
+
+func TestClient_PartialResetNeverReady(t *testing.T) {
+	c := NewClient(healthyClient{}, map[Operation]Fault{OpReady: {Mode: ModePartial}})
+
+	for range 3 {
+		ready, err := c.IsNodeReady(context.Background(), corev1.Node{}, "")
+		require.NoError(t, err)
+		assert.False(t, ready)
+	}
+
+	ref, err := c.SendRebootSignal(context.Background(), corev1.Node{})
+	require.NoError(t, err)
+	assert.Equal(t, model.ResetSignalRequestRef("reboot-ref"), ref)
+}
+
+func TestWrapFromEnv(t *testing.T) {
+	t.Setenv(EnvVar, "")
+
+	client, err := WrapFromEnv(healthyClient{})
+	require.NoError(t, err)
+	assert.Equal(t, healthyClient{}, client)
+
+	t.Setenv(EnvVar, "ready=error")
+
+	client, err = WrapFromEnv(healthyClient{})
+	require.NoError(t, err)
+
+	_, err = client.IsNodeReady(context.Background(), corev1.Node{}, "")
+	assert.ErrorIs(t, err, ErrInjected)
+
+	t.Setenv(EnvVar, "ready=bogus")
+
+	_, err = WrapFromEnv(healthyClient{})
+	assert.Error(t, err)
+}
@@ -22,6 +22,7 @@ import (
 
 	"github.com/nvidia/nvsentinel/janitor/pkg/csp/aws"
 	"github.com/nvidia/nvsentinel/janitor/pkg/csp/azure"
+	"github.com/nvidia/nvsentinel/janitor/pkg/csp/chaos"
 	"github.com/nvidia/nvsentinel/janitor/pkg/csp/gcp"
 	"github.com/nvidia/nvsentinel/janitor/pkg/csp/kind"
 	"github.com/nvidia/nvsentinel/janitor/pkg/csp/oci"
@@ -35,6 +36,9 @@ const (
 	ProviderGCP   Provider = "gcp"
 	ProviderAzure Provider = "azure"
 	ProviderOCI   Provider = "oci"
+	// ProviderAuto selects the provider of each node from its provider ID, for
+	// clusters spanning several clouds.
+	ProviderAuto Provider = "auto"
 )
 
 // Provider defines the supported cloud service providers.
@@ -62,6 +66,17 @@ func New(ctx context.Context) (model.CSPClient, error) {
 		return nil, fmt.Errorf("creating %s client: %w", provider, err)
 	}
 
+	if faults := os.Getenv(chaos.EnvVar); faults != "" {
+		logger.Info("chaos fault injection enabled for CSP client",
+			"provider", string(provider),
+			"faults", faults)
+
+		client, err = chaos.WrapFromEnv(client)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	logger.Info("CSP client initialized successfully",
 		"provider", string(provider))
 
@@ -81,6 +96,8 @@ func NewWithProvider(ctx context.Context, provider Provider) (model.CSPClient, e
 		return azure.NewClient(ctx)
 	case ProviderOCI:
 		return oci.NewClientFromEnv(ctx)
+	case ProviderAuto:
+		return newDispatchClient(NewWithProvider), nil
 	default:
 		return nil, fmt.Errorf("unsupported CSP provider: %s", provider)
 	}
@@ -110,6 +127,8 @@ func GetProviderFromString(providerStr string) (Provider, error) {
 		return ProviderAzure, nil
 	case "oci":
 		return ProviderOCI, nil
+	case "auto":
+		return ProviderAuto, nil
 	default:
 		return "", fmt.Errorf("unsupported CSP provider: %s", providerStr)
 	}
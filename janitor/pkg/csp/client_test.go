@@ -288,6 +288,7 @@ func TestGetProviderFromString(t *testing.T) {
 		{"gcp lowercase", "gcp", ProviderGCP, false},
 		{"azure lowercase", "azure", ProviderAzure, false},
 		{"oci lowercase", "oci", ProviderOCI, false},
+		{"auto lowercase", "auto", ProviderAuto, false},
 		{"kind uppercase", "KIND", ProviderKind, false}, // case insensitive
 		{"aws uppercase", "AWS", ProviderAWS, false},
 		{"gcp mixed case", "GcP", ProviderGCP, false},
@@ -0,0 +1,133 @@
+// Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package csp
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/nvidia/nvsentinel/janitor/pkg/model"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+var (
+	_ model.CSPClient = (*dispatchClient)(nil)
+)
+
+// providerIDSchemes maps node provider ID schemes to providers.
+var providerIDSchemes = map[string]Provider{
+	"aws":   ProviderAWS,
+	"gce":   ProviderGCP,
+	"azure": ProviderAzure,
+	"oci":   ProviderOCI,
+	"kind":  ProviderKind,
+}
+
+// ProviderFromNode detects the provider of a node from its provider ID.
+func ProviderFromNode(node corev1.Node) (Provider, error) {
+	scheme, _, ok := strings.Cut(node.Spec.ProviderID, "://")
+	if !ok {
+		return "", fmt.Errorf("no provider ID found for node %s", node.Name)
+	}
+
+	provider, ok := providerIDSchemes[scheme]
+	if !ok {
+		return "", fmt.Errorf("unsupported provider ID %s of node %s", node.Spec.ProviderID, node.Name)
+	}
+
+	return provider, nil
+}
+
+// dispatchClient sends every request to the client of the node's provider,
+// so that the same remediation actions run on nodes of different clouds. The
+// provider clients are created on first use.
+type dispatchClient struct {
+	newClient func(ctx context.Context, provider Provider) (model.CSPClient, error)
+
+	mu      sync.Mutex
+	clients map[Provider]model.CSPClient
+}
+
+func newDispatchClient(
+	newClient func(ctx context.Context, provider Provider) (model.CSPClient, error),
+) *dispatchClient {
+	return &dispatchClient{newClient: newClient, clients: make(map[Provider]model.CSPClient)}
+}
+
+func (d *dispatchClient) clientFor(ctx context.Context, node corev1.Node) (model.CSPClient, error) {
+	provider, err := ProviderFromNode(node)
+	if err != nil {
+		return nil, err
+	}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if client, ok := d.clients[provider]; ok {
+		return client, nil
+	}
+
+	log.FromContext(ctx).Info("initializing CSP client for node provider",
+		"node", node.Name,
+		"provider", string(provider))
+
+	client, err := d.newClient(ctx, provider)
+	if err != nil {
+		return nil, fmt.Errorf("creating %s client: %w", provider, err)
+	}
+
+	d.clients[provider] = client
+
+	return client, nil
+}
+
+// SendRebootSignal sends the reboot signal through the client of the node's provider.
+func (d *dispatchClient) SendRebootSignal(
+	ctx context.Context,
+	node corev1.Node,
+) (model.ResetSignalRequestRef, error) {
+	client, err := d.clientFor(ctx, node)
+	if err != nil {
+		return "", err
+	}
+
+	return client.SendRebootSignal(ctx, node)
+}
+
+// IsNodeReady checks the node through the client of the node's provider.
+func (d *dispatchClient) IsNodeReady(ctx context.Context, node corev1.Node, message string) (bool, error) {
+	client, err := d.clientFor(ctx, node)
+	if err != nil {
+		return false, err
+	}
+
+	return client.IsNodeReady(ctx, node, message)
+}
+
+// SendTerminateSignal sends the terminate signal through the client of the node's provider.
+func (d *dispatchClient) SendTerminateSignal(
+	ctx context.Context,
+	node corev1.Node,
+) (model.TerminateNodeRequestRef, error) {
+	client, err := d.clientFor(ctx, node)
+	if err != nil {
+		return "", err
+	}
+
+	return client.SendTerminateSignal(ctx, node)
+}
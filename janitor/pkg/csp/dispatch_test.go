@@ -0,0 +1,115 @@
+// Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package csp
+
+import (
+	"context"
+	"testing"
+
+	"github.com/nvidia/nvsentinel/janitor/pkg/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+type recordingClient struct {
+	provider Provider
+	calls    *[]string
+}
+
+func (r *recordingClient) SendRebootSignal(context.Context, corev1.Node) (model.ResetSignalRequestRef, error) {
+	*r.calls = append(*r.calls, string(r.provider)+"/reboot")
+	return model.ResetSignalRequestRef(r.provider), nil
+}
+
+func (r *recordingClient) IsNodeReady(context.Context, corev1.Node, string) (bool, error) {
+	*r.calls = append(*r.calls, string(r.provider)+"/ready")
+	return true, nil
+}
+
+func (r *recordingClient) SendTerminateSignal(context.Context, corev1.Node) (model.TerminateNodeRequestRef, error) {
+	*r.calls = append(*r.calls, string(r.provider)+"/terminate")
+	return model.TerminateNodeRequestRef(r.provider), nil
+}
+
+func nodeWithProviderID(providerID string) corev1.Node {
+	return corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{Name: "node"},
+		Spec:       corev1.NodeSpec{ProviderID: providerID},
+	}
+}
+
+func TestProviderFromNode(t *testing.T) {
+	tests := []struct {
+		providerID string
+		expected   Provider
+		wantErr    bool
+	}{
+		{"aws:///us-east-1a/i-0abc", ProviderAWS, false},
+		{"gce://project/us-central1-a/node", ProviderGCP, false},
+		{"azure:///subscriptions/s/resourceGroups/rg/providers/Microsoft.Compute/" +
+			"virtualMachineScaleSets/gpu/virtualMachines/0", ProviderAzure, false},
+		{"oci://ocid1.instance.oc1.iad.abc", ProviderOCI, false},
+		{"kind://docker/kind/kind-worker", ProviderKind, false},
+		{"vsphere://4204", "", true},
+		{"", "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.providerID, func(t *testing.T) {
+			provider, err := ProviderFromNode(nodeWithProviderID(tt.providerID))
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, provider)
+		})
+	}
+}
+
+func TestDispatchClient(t *testing.T) {
+	ctx := context.Background()
+
+	var calls []string
+
+	created := map[Provider]int{}
+	d := newDispatchClient(func(_ context.Context, provider Provider) (model.CSPClient, error) {
+		created[provider]++
+		return &recordingClient{provider: provider, calls: &calls}, nil
+	})
+
+	gcpNode := nodeWithProviderID("gce://project/us-central1-a/node")
+	azureNode := nodeWithProviderID("azure:///subscriptions/s/resourceGroups/rg/providers/" +
+		"Microsoft.Compute/virtualMachineScaleSets/gpu/virtualMachines/0")
+
+	ref, err := d.SendRebootSignal(ctx, gcpNode)
+	require.NoError(t, err)
+	assert.Equal(t, model.ResetSignalRequestRef(ProviderGCP), ref)
+
+	_, err = d.IsNodeReady(ctx, gcpNode, string(ref))
+	require.NoError(t, err)
+
+	_, err = d.SendTerminateSignal(ctx, azureNode)
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"gcp/reboot", "gcp/ready", "azure/terminate"}, calls)
+	assert.Equal(t, map[Provider]int{ProviderGCP: 1, ProviderAzure: 1}, created)
+
+	_, err = d.SendRebootSignal(ctx, nodeWithProviderID(""))
+	assert.Error(t, err)
+}
@@ -0,0 +1,97 @@
+// Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gcp
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+
+	"google.golang.org/api/option"
+
+	"github.com/nvidia/nvsentinel/commons/pkg/secrets"
+)
+
+const (
+	// CredentialsSecretEnvVar names the secret holding a service account key. When
+	// unset, Application Default Credentials (Workload Identity) are used.
+	CredentialsSecretEnvVar = "GCP_CREDENTIALS_SECRET"
+
+	// credentialsKey holds the service account key JSON in a mounted Secret
+	credentialsKey = "credentials.json"
+	// payloadKey holds the whole payload of a Secret Manager secret
+	payloadKey = "value"
+
+	serviceAccountType = "service_account"
+)
+
+// secretCredentials returns the client options authenticating with the service
+// account key of a secret. The secret is read on every call, so rotated keys are
+// picked up.
+type secretCredentials struct {
+	secrets secrets.Provider
+	name    string
+}
+
+func (c *secretCredentials) clientOptions(ctx context.Context) ([]option.ClientOption, error) {
+	secret, err := c.secrets.Get(ctx, c.name)
+	if err != nil {
+		return nil, fmt.Errorf("retrieving GCP credentials: %w", err)
+	}
+
+	key, err := secret.Value(credentialsKey)
+	if errors.Is(err, secrets.ErrKeyNotFound) {
+		key, err = secret.Value(payloadKey)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	// only service account keys are accepted, other credential configurations
+	// could make the client fetch tokens from arbitrary endpoints
+	var header struct {
+		Type string `json:"type"`
+	}
+
+	if err := json.Unmarshal([]byte(key), &header); err != nil {
+		return nil, fmt.Errorf("GCP credentials secret %s is not a JSON key", c.name)
+	}
+
+	if header.Type != serviceAccountType {
+		return nil, fmt.Errorf("GCP credentials secret %s holds a %q key, expected %q",
+			c.name, header.Type, serviceAccountType)
+	}
+
+	return []option.ClientOption{option.WithCredentialsJSON([]byte(key))}, nil
+}
+
+// newSecretCredentialsFromEnv returns the secret credentials named by
+// CredentialsSecretEnvVar, or nil when it is unset.
+func newSecretCredentialsFromEnv() (*secretCredentials, error) {
+	name := os.Getenv(CredentialsSecretEnvVar)
+	if name == "" {
+		return nil, nil
+	}
+
+	provider, err := secrets.NewProviderFromEnv()
+	if err != nil {
+		return nil, fmt.Errorf("creating secrets provider: %w", err)
+	}
+
+	return &secretCredentials{secrets: provider, name: name}, nil
+}
@@ -0,0 +1,79 @@
+// Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gcp
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/nvidia/nvsentinel/commons/pkg/secrets"
+)
+
+func TestSecretCredentials(t *testing.T) {
+	dir := t.TempDir()
+	secretDir := filepath.Join(dir, "gcp-credentials")
+	require.NoError(t, os.MkdirAll(secretDir, 0o700))
+
+	writeKey := func(key, value string) {
+		require.NoError(t, os.WriteFile(filepath.Join(secretDir, key), []byte(value), 0o600))
+	}
+
+	c := &secretCredentials{secrets: secrets.NewFileProvider(dir), name: "gcp-credentials"}
+
+	_, err := c.clientOptions(context.Background())
+	assert.ErrorIs(t, err, secrets.ErrKeyNotFound)
+
+	writeKey(credentialsKey, `{"type":"external_account","token_url":"https://example.com"}`)
+
+	_, err = c.clientOptions(context.Background())
+	assert.ErrorContains(t, err, "external_account")
+
+	writeKey(credentialsKey, "not json")
+
+	_, err = c.clientOptions(context.Background())
+	require.Error(t, err)
+	assert.NotContains(t, err.Error(), "not json")
+
+	writeKey(credentialsKey, `{"type":"service_account","client_email":"janitor@project.iam.gserviceaccount.com"}`)
+
+	opts, err := c.clientOptions(context.Background())
+	require.NoError(t, err)
+	assert.Len(t, opts, 1)
+}
+
+func TestNewClient(t *testing.T) {
+	t.Setenv(CredentialsSecretEnvVar, "")
+
+	client, err := NewClient(context.Background())
+	require.NoError(t, err)
+
+	opts, err := client.clientOptions(context.Background())
+	require.NoError(t, err)
+	assert.Empty(t, opts)
+
+	t.Setenv(CredentialsSecretEnvVar, "gcp-credentials")
+	t.Setenv(secrets.EnvDir, t.TempDir())
+
+	client, err = NewClient(context.Background())
+	require.NoError(t, err)
+
+	_, err = client.clientOptions(context.Background())
+	assert.ErrorIs(t, err, secrets.ErrNotFound)
+}
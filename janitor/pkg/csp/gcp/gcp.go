@@ -23,6 +23,7 @@ import (
 	compute "cloud.google.com/go/compute/apiv1"
 	computepb "cloud.google.com/go/compute/apiv1/computepb"
 	"github.com/nvidia/nvsentinel/janitor/pkg/model"
+	"google.golang.org/api/option"
 	corev1 "k8s.io/api/core/v1"
 	"sigs.k8s.io/controller-runtime/pkg/log"
 )
@@ -32,7 +33,11 @@ var (
 )
 
 // Client is the GCP implementation of the CSP Client interface.
-type Client struct{}
+type Client struct {
+	// credentials authenticates the API clients, nil uses Application Default
+	// Credentials
+	credentials *secretCredentials
+}
 
 type gcpNodeFields struct {
 	project  string
@@ -40,11 +45,25 @@ type gcpNodeFields struct {
 	instance string
 }
 
-// NewClient creates a new GCP client.
+// NewClient creates a new GCP client. The API clients are created on every call,
+// authenticated with the service account key of the secret named by
+// GCP_CREDENTIALS_SECRET when it is set.
 func NewClient(ctx context.Context) (*Client, error) {
-	// GCP client initialization is deferred until first API call
-	// This allows validation to happen at construction time in the future
-	return &Client{}, nil
+	credentials, err := newSecretCredentialsFromEnv()
+	if err != nil {
+		return nil, err
+	}
+
+	return &Client{credentials: credentials}, nil
+}
+
+// clientOptions returns the options of the API clients.
+func (c *Client) clientOptions(ctx context.Context) ([]option.ClientOption, error) {
+	if c.credentials == nil {
+		return nil, nil
+	}
+
+	return c.credentials.clientOptions(ctx)
 }
 
 func getNodeFields(node corev1.Node) (*gcpNodeFields, error) {
@@ -89,7 +108,12 @@ func getNodeFields(node corev1.Node) (*gcpNodeFields, error) {
 func (c *Client) SendRebootSignal(ctx context.Context, node corev1.Node) (model.ResetSignalRequestRef, error) {
 	logger := log.FromContext(ctx)
 
-	instancesClient, err := compute.NewInstancesRESTClient(ctx)
+	opts, err := c.clientOptions(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	instancesClient, err := compute.NewInstancesRESTClient(ctx, opts...)
 	if err != nil {
 		return "", err
 	}
@@ -125,7 +149,12 @@ func (c *Client) SendRebootSignal(ctx context.Context, node corev1.Node) (model.
 func (c *Client) IsNodeReady(ctx context.Context, node corev1.Node, message string) (bool, error) {
 	logger := log.FromContext(ctx)
 
-	zoneOperationsClient, err := compute.NewZoneOperationsRESTClient(ctx)
+	opts, err := c.clientOptions(ctx)
+	if err != nil {
+		return false, err
+	}
+
+	zoneOperationsClient, err := compute.NewZoneOperationsRESTClient(ctx, opts...)
 	if err != nil {
 		return false, err
 	}
@@ -164,7 +193,12 @@ func (c *Client) IsNodeReady(ctx context.Context, node corev1.Node, message stri
 func (c *Client) SendTerminateSignal(ctx context.Context, node corev1.Node) (model.TerminateNodeRequestRef, error) {
 	logger := log.FromContext(ctx)
 
-	instancesClient, err := compute.NewInstancesRESTClient(ctx)
+	opts, err := c.clientOptions(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	instancesClient, err := compute.NewInstancesRESTClient(ctx, opts...)
 	if err != nil {
 		return "", err
 	}
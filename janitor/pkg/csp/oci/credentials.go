@@ -0,0 +1,174 @@
+// Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package oci
+
+import (
+	"context"
+	"crypto/rsa"
+	"fmt"
+	"sync"
+
+	"github.com/oracle/oci-go-sdk/v65/common"
+
+	"github.com/nvidia/nvsentinel/commons/pkg/secrets"
+)
+
+const (
+	// CredentialsSecretEnvVar names the secret holding the API signing key of an
+	// OCI user. It takes precedence over OCI_CREDENTIALS_FILE; when neither is
+	// set, OKE workload identity is used.
+	CredentialsSecretEnvVar = "OCI_CREDENTIALS_SECRET"
+
+	tenancyKey     = "tenancy"
+	userKey        = "user"
+	fingerprintKey = "fingerprint"
+	regionKey      = "region"
+	privateKeyKey  = "key"
+	passphraseKey  = "passphrase"
+)
+
+// secretConfigurationProvider provides the OCI configuration of a secret holding
+// the tenancy, user, fingerprint, region and key (PEM private key) keys, and
+// optionally the passphrase of the key. The secret is read whenever a request is
+// signed, so a rotated key is picked up.
+type secretConfigurationProvider struct {
+	secrets secrets.Provider
+	name    string
+
+	mu      sync.Mutex
+	version string
+	raw     common.ConfigurationProvider
+}
+
+var _ common.ConfigurationProvider = (*secretConfigurationProvider)(nil)
+
+// current returns the configuration of the current version of the secret.
+func (p *secretConfigurationProvider) current() (common.ConfigurationProvider, error) {
+	// the SDK does not pass a context to configuration providers
+	secret, err := p.secrets.Get(context.Background(), p.name)
+	if err != nil {
+		return nil, fmt.Errorf("retrieving OCI credentials: %w", err)
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.raw != nil && p.version == secret.Version {
+		return p.raw, nil
+	}
+
+	values := make(map[string]string, 5)
+
+	for _, key := range []string{tenancyKey, userKey, fingerprintKey, regionKey, privateKeyKey} {
+		value, err := secret.Value(key)
+		if err != nil {
+			return nil, err
+		}
+
+		values[key] = value
+	}
+
+	var passphrase *string
+	if value, err := secret.Value(passphraseKey); err == nil {
+		passphrase = &value
+	}
+
+	p.raw = common.NewRawConfigurationProvider(values[tenancyKey], values[userKey], values[regionKey],
+		values[fingerprintKey], values[privateKeyKey], passphrase)
+	p.version = secret.Version
+
+	return p.raw, nil
+}
+
+// PrivateRSAKey implements common.KeyProvider.
+func (p *secretConfigurationProvider) PrivateRSAKey() (*rsa.PrivateKey, error) {
+	raw, err := p.current()
+	if err != nil {
+		return nil, err
+	}
+
+	return raw.PrivateRSAKey()
+}
+
+// KeyID implements common.KeyProvider.
+func (p *secretConfigurationProvider) KeyID() (string, error) {
+	raw, err := p.current()
+	if err != nil {
+		return "", err
+	}
+
+	return raw.KeyID()
+}
+
+// TenancyOCID implements common.ConfigurationProvider.
+func (p *secretConfigurationProvider) TenancyOCID() (string, error) {
+	raw, err := p.current()
+	if err != nil {
+		return "", err
+	}
+
+	return raw.TenancyOCID()
+}
+
+// UserOCID implements common.ConfigurationProvider.
+func (p *secretConfigurationProvider) UserOCID() (string, error) {
+	raw, err := p.current()
+	if err != nil {
+		return "", err
+	}
+
+	return raw.UserOCID()
+}
+
+// KeyFingerprint implements common.ConfigurationProvider.
+func (p *secretConfigurationProvider) KeyFingerprint() (string, error) {
+	raw, err := p.current()
+	if err != nil {
+		return "", err
+	}
+
+	return raw.KeyFingerprint()
+}
+
+// Region implements common.ConfigurationProvider.
+func (p *secretConfigurationProvider) Region() (string, error) {
+	raw, err := p.current()
+	if err != nil {
+		return "", err
+	}
+
+	return raw.Region()
+}
+
+// AuthType implements common.ConfigurationProvider.
+func (p *secretConfigurationProvider) AuthType() (common.AuthConfig, error) {
+	raw, err := p.current()
+	if err != nil {
+		return common.AuthConfig{}, err
+	}
+
+	return raw.AuthType()
+}
+
+// newSecretConfigurationProvider returns a configuration provider backed by the
+// named secret of the SECRETS_* configured secrets provider.
+func newSecretConfigurationProvider(name string) (common.ConfigurationProvider, error) {
+	provider, err := secrets.NewProviderFromEnv()
+	if err != nil {
+		return nil, fmt.Errorf("creating secrets provider: %w", err)
+	}
+
+	return &secretConfigurationProvider{secrets: provider, name: name}, nil
+}
@@ -0,0 +1,77 @@
+// Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package oci
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/nvidia/nvsentinel/commons/pkg/secrets"
+)
+
+func TestSecretConfigurationProvider(t *testing.T) {
+	dir := t.TempDir()
+	secretDir := filepath.Join(dir, "oci-credentials")
+	require.NoError(t, os.MkdirAll(secretDir, 0o700))
+
+	writeKey := func(key, value string) {
+		require.NoError(t, os.WriteFile(filepath.Join(secretDir, key), []byte(value), 0o600))
+	}
+
+	p := &secretConfigurationProvider{secrets: secrets.NewFileProvider(dir), name: "oci-credentials"}
+
+	writeKey(tenancyKey, "ocid1.tenancy.oc1..a")
+	writeKey(userKey, "ocid1.user.oc1..b")
+	writeKey(fingerprintKey, "aa:bb")
+	writeKey(regionKey, "us-ashburn-1")
+
+	_, err := p.KeyID()
+	assert.ErrorIs(t, err, secrets.ErrKeyNotFound)
+
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	writeKey(privateKeyKey, string(pem.EncodeToMemory(&pem.Block{
+		Type:  "RSA PRIVATE KEY",
+		Bytes: x509.MarshalPKCS1PrivateKey(key),
+	})))
+
+	keyID, err := p.KeyID()
+	require.NoError(t, err)
+	assert.Equal(t, "ocid1.tenancy.oc1..a/ocid1.user.oc1..b/aa:bb", keyID)
+
+	privateKey, err := p.PrivateRSAKey()
+	require.NoError(t, err)
+	assert.True(t, key.Equal(privateKey))
+
+	region, err := p.Region()
+	require.NoError(t, err)
+	assert.Equal(t, "us-ashburn-1", region)
+
+	// a rotated key is picked up
+	writeKey(fingerprintKey, "cc:dd")
+
+	keyID, err = p.KeyID()
+	require.NoError(t, err)
+	assert.Equal(t, "ocid1.tenancy.oc1..a/ocid1.user.oc1..b/cc:dd", keyID)
+}
@@ -61,9 +61,15 @@ func WithComputeClient() ClientOptionFunc {
 			err         error
 		)
 
-		if os.Getenv("OCI_CREDENTIALS_FILE") != "" {
+		switch {
+		case os.Getenv(CredentialsSecretEnvVar) != "":
+			cfgProvider, err = newSecretConfigurationProvider(os.Getenv(CredentialsSecretEnvVar))
+			if err != nil {
+				return err
+			}
+		case os.Getenv("OCI_CREDENTIALS_FILE") != "":
 			cfgProvider = common.CustomProfileConfigProvider(os.Getenv("OCI_CREDENTIALS_FILE"), os.Getenv("OCI_PROFILE"))
-		} else {
+		default:
 			cfgProvider, err = auth.OkeWorkloadIdentityConfigurationProvider()
 			if err != nil {
 				return err
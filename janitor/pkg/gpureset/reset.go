@@ -0,0 +1,287 @@
+// Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package gpureset resets GPUs of a node without draining it. It runs on the node,
+// in the Job the GPUReset controller creates: only the pods the kubelet allocated the
+// GPUs to are evicted, the reset waits until no compute process uses the GPUs anymore,
+// and the GPUs are then reset with nvidia-smi.
+package gpureset
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	policyv1 "k8s.io/api/policy/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes"
+	podresourcesapi "k8s.io/kubelet/pkg/apis/podresources/v1"
+)
+
+// ErrTimeout is returned when the GPUs were still in use at the timeout.
+var ErrTimeout = errors.New("GPUs still in use at the timeout")
+
+// Config configures a Resetter.
+type Config struct {
+	// UUIDs and PCIBusIDs select the GPUs to reset, all GPUs of the node when both are empty
+	UUIDs     []string
+	PCIBusIDs []string
+	// Timeout bounds the wait for the pods and processes using the GPUs to exit
+	Timeout time.Duration
+	// PollInterval is how often the pods and processes using the GPUs are checked
+	PollInterval time.Duration
+}
+
+// Result summarizes a reset.
+type Result struct {
+	// GPUs holds the UUIDs of the reset GPUs
+	GPUs []string
+	// EvictedPods holds the namespace/name of the evicted pods
+	EvictedPods []string
+}
+
+// String formats the result for the termination message of the reset Job
+func (r *Result) String() string {
+	evicted := "none"
+	if len(r.EvictedPods) > 0 {
+		evicted = strings.Join(r.EvictedPods, ",")
+	}
+
+	return fmt.Sprintf("reset GPUs %s, evicted pods %s", strings.Join(r.GPUs, ","), evicted)
+}
+
+// Resetter evicts the pods using GPUs of the node, waits for their processes to exit
+// and resets the GPUs.
+type Resetter struct {
+	cfg          Config
+	smi          SMI
+	podResources podresourcesapi.PodResourcesListerClient
+	k8sClient    kubernetes.Interface
+}
+
+// NewResetter constructs a Resetter.
+func NewResetter(
+	cfg Config,
+	smi SMI,
+	podResources podresourcesapi.PodResourcesListerClient,
+	k8sClient kubernetes.Interface,
+) *Resetter {
+	return &Resetter{cfg: cfg, smi: smi, podResources: podResources, k8sClient: k8sClient}
+}
+
+// Run resets the selected GPUs. Pods the GPUs are allocated to are evicted, also those
+// scheduled onto the GPUs while waiting, and the GPUs are reset once neither pods nor
+// compute processes use them. The pods using other GPUs of the node keep running.
+func (r *Resetter) Run(ctx context.Context) (*Result, error) {
+	gpus, err := r.smi.GPUs(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	targets, err := selectGPUs(gpus, r.cfg.UUIDs, r.cfg.PCIBusIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &Result{}
+
+	// Pods get allocated the GPU, or one of its MIG devices
+	devices := map[string]bool{}
+
+	for _, gpu := range targets {
+		result.GPUs = append(result.GPUs, gpu.UUID)
+		devices[gpu.UUID] = true
+
+		for _, device := range gpu.MIGDevices {
+			devices[device] = true
+		}
+	}
+
+	slog.Info("Resetting GPUs", "gpus", result.GPUs)
+
+	evicted := map[types.NamespacedName]bool{}
+	deadline := time.Now().Add(r.cfg.Timeout)
+
+	for {
+		pods, err := r.podsUsing(ctx, devices)
+		if err != nil {
+			return result, err
+		}
+
+		for _, pod := range pods {
+			if evicted[pod] {
+				continue
+			}
+
+			if r.evict(ctx, pod) {
+				evicted[pod] = true
+				result.EvictedPods = append(result.EvictedPods, pod.String())
+			}
+		}
+
+		processes, err := r.processesOn(ctx, result.GPUs)
+		if err != nil {
+			return result, err
+		}
+
+		if len(pods) == 0 && len(processes) == 0 {
+			break
+		}
+
+		if time.Now().After(deadline) {
+			return result, fmt.Errorf("%w: pods %v, processes %v", ErrTimeout, pods, processes)
+		}
+
+		slog.Info("Waiting for GPUs to be released", "pods", pods, "processes", processes)
+
+		select {
+		case <-ctx.Done():
+			return result, ctx.Err()
+		case <-time.After(r.cfg.PollInterval):
+		}
+	}
+
+	for _, uuid := range result.GPUs {
+		if err := r.smi.Reset(ctx, uuid); err != nil {
+			return result, fmt.Errorf("failed to reset GPU %s: %w", uuid, err)
+		}
+
+		slog.Info("Reset GPU", "gpu", uuid)
+	}
+
+	sort.Strings(result.EvictedPods)
+
+	return result, nil
+}
+
+// podsUsing returns the pods the kubelet allocated one of the devices to
+func (r *Resetter) podsUsing(ctx context.Context, devices map[string]bool) ([]types.NamespacedName, error) {
+	response, err := r.podResources.List(ctx, &podresourcesapi.ListPodResourcesRequest{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list pod resources: %w", err)
+	}
+
+	var pods []types.NamespacedName
+
+	for _, pod := range response.GetPodResources() {
+		if usesDevice(pod, devices) {
+			pods = append(pods, types.NamespacedName{Namespace: pod.GetNamespace(), Name: pod.GetName()})
+		}
+	}
+
+	return pods, nil
+}
+
+func usesDevice(pod *podresourcesapi.PodResources, devices map[string]bool) bool {
+	for _, container := range pod.GetContainers() {
+		for _, device := range container.GetDevices() {
+			for _, id := range device.GetDeviceIds() {
+				if devices[id] {
+					return true
+				}
+			}
+		}
+	}
+
+	return false
+}
+
+// evict evicts the pod through the eviction API, which honors its disruption budget,
+// and reports whether the pod is evicted or gone. Evictions blocked by a budget are
+// retried on the next poll.
+func (r *Resetter) evict(ctx context.Context, pod types.NamespacedName) bool {
+	err := r.k8sClient.CoreV1().Pods(pod.Namespace).EvictV1(ctx, &policyv1.Eviction{
+		ObjectMeta: metav1.ObjectMeta{Namespace: pod.Namespace, Name: pod.Name},
+	})
+
+	switch {
+	case err == nil:
+		slog.Info("Evicted pod using the GPUs", "pod", pod.String())
+		return true
+	case apierrors.IsNotFound(err):
+		return true
+	default:
+		slog.Warn("Failed to evict pod using the GPUs, retrying", "pod", pod.String(), "error", err)
+		return false
+	}
+}
+
+// processesOn returns the compute processes running on the GPUs by GPU UUID
+func (r *Resetter) processesOn(ctx context.Context, uuids []string) (map[string][]int, error) {
+	processes, err := r.smi.ComputeProcesses(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	for uuid := range processes {
+		if !slices.Contains(uuids, uuid) {
+			delete(processes, uuid)
+		}
+	}
+
+	return processes, nil
+}
+
+// selectGPUs returns the GPUs matching a UUID or PCI bus ID, or all GPUs if none is given
+func selectGPUs(gpus []GPU, uuids, pciBusIDs []string) ([]GPU, error) {
+	if len(uuids) == 0 && len(pciBusIDs) == 0 {
+		if len(gpus) == 0 {
+			return nil, errors.New("no GPU found on the node")
+		}
+
+		return gpus, nil
+	}
+
+	var selected []GPU
+
+	found := map[string]bool{}
+
+	for _, gpu := range gpus {
+		uuidMatch := slices.Contains(uuids, gpu.UUID)
+		busMatch := slices.ContainsFunc(pciBusIDs, func(id string) bool { return NormalizePCIBusID(id) == gpu.PCIBusID })
+
+		if uuidMatch || busMatch {
+			selected = append(selected, gpu)
+			found[gpu.UUID] = uuidMatch
+			found[gpu.PCIBusID] = busMatch
+		}
+	}
+
+	var missing []string
+
+	for _, uuid := range uuids {
+		if !found[uuid] {
+			missing = append(missing, uuid)
+		}
+	}
+
+	for _, id := range pciBusIDs {
+		if !found[NormalizePCIBusID(id)] {
+			missing = append(missing, id)
+		}
+	}
+
+	if len(missing) > 0 {
+		return nil, fmt.Errorf("GPUs %s not found on the node", strings.Join(missing, ", "))
+	}
+
+	return selected, nil
+}
@@ -0,0 +1,215 @@
+// Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gpureset
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+	podresourcesapi "k8s.io/kubelet/pkg/apis/podresources/v1"
+)
+
+const (
+	gpu0 = "GPU-11111111-1111-1111-1111-111111111111"
+	gpu1 = "GPU-22222222-2222-2222-2222-222222222222"
+	mig1 = "MIG-33333333-3333-3333-3333-333333333333"
+)
+
+// fakeSMI releases the GPUs once the evicted pods are gone
+type fakeSMI struct {
+	processes map[string][]int
+	reset     []string
+	resetErr  error
+}
+
+func (s *fakeSMI) GPUs(context.Context) ([]GPU, error) {
+	return []GPU{
+		{UUID: gpu0, PCIBusID: "0000:01:00.0"},
+		{UUID: gpu1, PCIBusID: "0000:02:00.0", MIGDevices: []string{mig1}},
+	}, nil
+}
+
+func (s *fakeSMI) ComputeProcesses(context.Context) (map[string][]int, error) {
+	processes := map[string][]int{}
+	for uuid, pids := range s.processes {
+		processes[uuid] = pids
+	}
+
+	return processes, nil
+}
+
+func (s *fakeSMI) Reset(_ context.Context, uuid string) error {
+	s.reset = append(s.reset, uuid)
+	return s.resetErr
+}
+
+// fakePodResources lists the pods and removes them, with their processes, once evicted
+type fakePodResources struct {
+	pods []*podresourcesapi.PodResources
+}
+
+func (f *fakePodResources) List(context.Context, *podresourcesapi.ListPodResourcesRequest,
+	...grpc.CallOption) (*podresourcesapi.ListPodResourcesResponse, error) {
+	return &podresourcesapi.ListPodResourcesResponse{PodResources: f.pods}, nil
+}
+
+func (f *fakePodResources) GetAllocatableResources(context.Context, *podresourcesapi.AllocatableResourcesRequest,
+	...grpc.CallOption) (*podresourcesapi.AllocatableResourcesResponse, error) {
+	return &podresourcesapi.AllocatableResourcesResponse{}, nil
+}
+
+func (f *fakePodResources) Get(context.Context, *podresourcesapi.GetPodResourcesRequest,
+	...grpc.CallOption) (*podresourcesapi.GetPodResourcesResponse, error) {
+	return &podresourcesapi.GetPodResourcesResponse{}, nil
+}
+
+func gpuPod(name string, devices ...string) *podresourcesapi.PodResources {
+	return &podresourcesapi.PodResources{
+		Name:      name,
+		Namespace: "team-a",
+		Containers: []*podresourcesapi.ContainerResources{{
+			Name:    "main",
+			Devices: []*podresourcesapi.ContainerDevices{{ResourceName: "nvidia.com/gpu", DeviceIds: devices}},
+		}},
+	}
+}
+
+func TestRunEvictsOnlyPodsOfTheGPU(t *testing.T) {
+	smi := &fakeSMI{processes: map[string][]int{gpu0: {100}, gpu1: {200}}}
+	podResources := &fakePodResources{pods: []*podresourcesapi.PodResources{
+		gpuPod("trainer", gpu0),
+		gpuPod("inference", gpu1),
+		{Name: "cpu-only", Namespace: "team-a"},
+	}}
+
+	client := fake.NewClientset()
+	client.PrependReactor("create", "pods", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		if action.GetSubresource() != "eviction" {
+			return false, nil, nil
+		}
+
+		// the evicted pod and its process exit
+		podResources.pods = podResources.pods[1:]
+		delete(smi.processes, gpu0)
+
+		return true, nil, nil
+	})
+
+	resetter := NewResetter(Config{UUIDs: []string{gpu0}, Timeout: time.Second, PollInterval: time.Millisecond},
+		smi, podResources, client)
+
+	result, err := resetter.Run(context.Background())
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{gpu0}, result.GPUs)
+	assert.Equal(t, []string{"team-a/trainer"}, result.EvictedPods)
+	assert.Equal(t, []string{gpu0}, smi.reset)
+	assert.Equal(t, "reset GPUs "+gpu0+", evicted pods team-a/trainer", result.String())
+}
+
+func TestRunMatchesMIGDevicesByPCIBusID(t *testing.T) {
+	smi := &fakeSMI{}
+	podResources := &fakePodResources{pods: []*podresourcesapi.PodResources{gpuPod("mig-user", mig1)}}
+
+	client := fake.NewClientset()
+	client.PrependReactor("create", "pods", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		podResources.pods = nil
+		return true, nil, nil
+	})
+
+	resetter := NewResetter(
+		Config{PCIBusIDs: []string{"00000000:02:00.0"}, Timeout: time.Second, PollInterval: time.Millisecond},
+		smi, podResources, client)
+
+	result, err := resetter.Run(context.Background())
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"team-a/mig-user"}, result.EvictedPods)
+	assert.Equal(t, []string{gpu1}, smi.reset)
+}
+
+func TestRunTimesOutWhileProcessesRun(t *testing.T) {
+	// a process outside of any pod keeps the GPU busy
+	smi := &fakeSMI{processes: map[string][]int{gpu0: {100}}}
+
+	resetter := NewResetter(Config{UUIDs: []string{gpu0}, Timeout: 10 * time.Millisecond, PollInterval: time.Millisecond},
+		smi, &fakePodResources{}, fake.NewClientset())
+
+	_, err := resetter.Run(context.Background())
+	require.ErrorIs(t, err, ErrTimeout)
+	assert.Empty(t, smi.reset)
+}
+
+func TestRunRetriesBlockedEvictions(t *testing.T) {
+	smi := &fakeSMI{}
+	podResources := &fakePodResources{pods: []*podresourcesapi.PodResources{gpuPod("trainer", gpu0)}}
+
+	attempts := 0
+	client := fake.NewClientset()
+	client.PrependReactor("create", "pods", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		attempts++
+		if attempts == 1 {
+			return true, nil, errors.New("cannot evict pod as it would violate the pod's disruption budget")
+		}
+
+		podResources.pods = nil
+
+		return true, nil, nil
+	})
+
+	resetter := NewResetter(Config{UUIDs: []string{gpu0}, Timeout: time.Second, PollInterval: time.Millisecond},
+		smi, podResources, client)
+
+	result, err := resetter.Run(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 2, attempts)
+	assert.Equal(t, []string{"team-a/trainer"}, result.EvictedPods)
+}
+
+func TestRunUnknownGPU(t *testing.T) {
+	resetter := NewResetter(Config{UUIDs: []string{"GPU-missing"}, Timeout: time.Second},
+		&fakeSMI{}, &fakePodResources{}, fake.NewClientset())
+
+	_, err := resetter.Run(context.Background())
+	require.ErrorContains(t, err, "GPUs GPU-missing not found on the node")
+}
+
+func TestParseNvidiaSMI(t *testing.T) {
+	gpus, err := parseGPUs(gpu0 + ", 00000000:01:00.0\n" + gpu1 + ", 00000000:0A:00.0\n")
+	require.NoError(t, err)
+	assert.Equal(t, []GPU{{UUID: gpu0, PCIBusID: "0000:01:00.0"}, {UUID: gpu1, PCIBusID: "0000:0a:00.0"}}, gpus)
+
+	migDevices := parseMIGDevices(`GPU 0: NVIDIA H100 80GB HBM3 (UUID: ` + gpu0 + `)
+GPU 1: NVIDIA A100-SXM4-40GB (UUID: ` + gpu1 + `)
+  MIG 1g.5gb      Device  0: (UUID: ` + mig1 + `)
+`)
+	assert.Equal(t, map[string][]string{gpu1: {mig1}}, migDevices)
+
+	processes, err := parseComputeProcesses(gpu0 + ", 100\n" + gpu0 + ", 101\n")
+	require.NoError(t, err)
+	assert.Equal(t, map[string][]int{gpu0: {100, 101}}, processes)
+
+	processes, err = parseComputeProcesses("No running processes found\n")
+	require.NoError(t, err)
+	assert.Empty(t, processes)
+}
@@ -0,0 +1,209 @@
+// Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package gpureset
+
+import (
+	"context"
+	"fmt"
+	"os/exec"
+	"regexp"
+	"strconv"
+	"strings"
+	"syscall"
+)
+
+// GPU is a GPU of the node as reported by nvidia-smi.
+type GPU struct {
+	UUID string
+	// PCIBusID is normalized to the domain:bus:device.function format of the GPUReset
+	// selector, e.g. 0000:01:00.0
+	PCIBusID string
+	// MIGDevices holds the UUIDs of the MIG devices of the GPU, which the device plugin
+	// allocates to pods instead of the GPU when MIG is enabled
+	MIGDevices []string
+}
+
+// SMI queries and resets the GPUs of the node.
+type SMI interface {
+	GPUs(ctx context.Context) ([]GPU, error)
+	// ComputeProcesses returns the PIDs of the compute processes by GPU UUID
+	ComputeProcesses(ctx context.Context) (map[string][]int, error)
+	Reset(ctx context.Context, uuid string) error
+}
+
+// runFunc runs nvidia-smi with the arguments and returns its output
+type runFunc func(ctx context.Context, args ...string) ([]byte, error)
+
+// NvidiaSMI runs nvidia-smi of the host.
+type NvidiaSMI struct {
+	run runFunc
+}
+
+// NewNvidiaSMI returns an SMI running the nvidia-smi binary at path inside the host
+// root, the driver libraries it loads are those of the host. An empty host root runs
+// it in the current root.
+func NewNvidiaSMI(hostRoot, path string) *NvidiaSMI {
+	return &NvidiaSMI{run: func(ctx context.Context, args ...string) ([]byte, error) {
+		cmd := exec.CommandContext(ctx, path, args...)
+		if hostRoot != "" {
+			cmd.SysProcAttr = &syscall.SysProcAttr{Chroot: hostRoot}
+			cmd.Dir = "/"
+		}
+
+		output, err := cmd.CombinedOutput()
+		if err != nil {
+			return output, fmt.Errorf("nvidia-smi %s failed: %w (output: %s)",
+				strings.Join(args, " "), err, strings.TrimSpace(string(output)))
+		}
+
+		return output, nil
+	}}
+}
+
+// GPUs returns the GPUs of the node with their MIG devices.
+func (s *NvidiaSMI) GPUs(ctx context.Context) ([]GPU, error) {
+	output, err := s.run(ctx, "--query-gpu=uuid,pci.bus_id", "--format=csv,noheader")
+	if err != nil {
+		return nil, err
+	}
+
+	gpus, err := parseGPUs(string(output))
+	if err != nil {
+		return nil, err
+	}
+
+	listing, err := s.run(ctx, "-L")
+	if err != nil {
+		return nil, err
+	}
+
+	migDevices := parseMIGDevices(string(listing))
+	for i := range gpus {
+		gpus[i].MIGDevices = migDevices[gpus[i].UUID]
+	}
+
+	return gpus, nil
+}
+
+// ComputeProcesses returns the PIDs of the compute processes by GPU UUID.
+func (s *NvidiaSMI) ComputeProcesses(ctx context.Context) (map[string][]int, error) {
+	output, err := s.run(ctx, "--query-compute-apps=gpu_uuid,pid", "--format=csv,noheader")
+	if err != nil {
+		return nil, err
+	}
+
+	return parseComputeProcesses(string(output))
+}
+
+// Reset resets the GPU, which fails while processes use it.
+func (s *NvidiaSMI) Reset(ctx context.Context, uuid string) error {
+	_, err := s.run(ctx, "--gpu-reset", "-i", uuid)
+	return err
+}
+
+// parseGPUs parses the uuid,pci.bus_id CSV of nvidia-smi --query-gpu
+func parseGPUs(output string) ([]GPU, error) {
+	var gpus []GPU
+
+	for _, line := range nonEmptyLines(output) {
+		fields := strings.Split(line, ",")
+		if len(fields) != 2 {
+			return nil, fmt.Errorf("unexpected nvidia-smi GPU line %q", line)
+		}
+
+		gpus = append(gpus, GPU{
+			UUID:     strings.TrimSpace(fields[0]),
+			PCIBusID: NormalizePCIBusID(fields[1]),
+		})
+	}
+
+	return gpus, nil
+}
+
+var (
+	gpuLinePattern = regexp.MustCompile(`^GPU \d+: .*\(UUID: (GPU-[^)]+)\)`)
+	migLinePattern = regexp.MustCompile(`^\s+MIG .*\(UUID: (MIG-[^)]+)\)`)
+)
+
+// parseMIGDevices parses the MIG device UUIDs by GPU UUID from nvidia-smi -L
+func parseMIGDevices(output string) map[string][]string {
+	devices := map[string][]string{}
+
+	var gpu string
+
+	for _, line := range strings.Split(output, "\n") {
+		if match := gpuLinePattern.FindStringSubmatch(line); match != nil {
+			gpu = match[1]
+			continue
+		}
+
+		if match := migLinePattern.FindStringSubmatch(line); match != nil && gpu != "" {
+			devices[gpu] = append(devices[gpu], match[1])
+		}
+	}
+
+	return devices
+}
+
+// parseComputeProcesses parses the gpu_uuid,pid CSV of nvidia-smi --query-compute-apps
+func parseComputeProcesses(output string) (map[string][]int, error) {
+	processes := map[string][]int{}
+
+	for _, line := range nonEmptyLines(output) {
+		// nvidia-smi prints a notice instead of rows on some versions
+		if strings.HasPrefix(line, "No running") {
+			continue
+		}
+
+		fields := strings.Split(line, ",")
+		if len(fields) != 2 {
+			return nil, fmt.Errorf("unexpected nvidia-smi compute app line %q", line)
+		}
+
+		pid, err := strconv.Atoi(strings.TrimSpace(fields[1]))
+		if err != nil {
+			return nil, fmt.Errorf("unexpected PID in nvidia-smi compute app line %q: %w", line, err)
+		}
+
+		uuid := strings.TrimSpace(fields[0])
+		processes[uuid] = append(processes[uuid], pid)
+	}
+
+	return processes, nil
+}
+
+// NormalizePCIBusID lowercases the PCI bus ID and shortens the 8 digit domain
+// nvidia-smi prints to the 4 digits of the kernel, e.g. 00000000:01:00.0 to 0000:01:00.0
+func NormalizePCIBusID(id string) string {
+	id = strings.ToLower(strings.TrimSpace(id))
+
+	if domain, rest, ok := strings.Cut(id, ":"); ok && len(domain) > 4 {
+		id = domain[len(domain)-4:] + ":" + rest
+	}
+
+	return id
+}
+
+func nonEmptyLines(output string) []string {
+	var lines []string
+
+	for _, line := range strings.Split(output, "\n") {
+		if line = strings.TrimSpace(line); line != "" {
+			lines = append(lines, line)
+		}
+	}
+
+	return lines
+}
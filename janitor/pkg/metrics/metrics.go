@@ -23,8 +23,12 @@ import (
 
 // Action types for metrics labeling
 const (
-	ActionTypeReboot    = "reboot"
-	ActionTypeTerminate = "terminate"
+	ActionTypeReboot         = "reboot"
+	ActionTypeTerminate      = "terminate"
+	ActionTypeDriverReload   = "driver_reload"
+	ActionTypeMIGReconfigure = "mig_reconfigure"
+	ActionTypeGPUReset       = "gpu_reset"
+	ActionTypePowerCap       = "power_cap"
 )
 
 // Status values for action metrics
@@ -53,6 +57,15 @@ var (
 		},
 		[]string{"action_type"},
 	)
+
+	// nodeLockContention tracks actions that waited because another action held the node
+	nodeLockContention = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "janitor_node_lock_contention_total",
+			Help: "Total number of times an action waited for the lock of its node held by another action",
+		},
+		[]string{"action_type"},
+	)
 )
 
 // ActionMetrics provides a centralized interface for recording action metrics
@@ -63,6 +76,7 @@ func NewActionMetrics() *ActionMetrics {
 	// Register metrics with the controller-runtime metrics registry
 	metrics.Registry.MustRegister(actionsCount)
 	metrics.Registry.MustRegister(actionMTTRHistogram)
+	metrics.Registry.MustRegister(nodeLockContention)
 
 	return &ActionMetrics{}
 }
@@ -83,6 +97,13 @@ func (m *ActionMetrics) RecordActionMTTR(actionType string, duration time.Durati
 	}).Observe(duration.Seconds())
 }
 
+// IncNodeLockContention counts an action waiting for the lock of its node
+func (m *ActionMetrics) IncNodeLockContention(actionType string) {
+	nodeLockContention.With(prometheus.Labels{
+		"action_type": actionType,
+	}).Inc()
+}
+
 // GlobalMetrics is the global metrics instance for easy access across controllers
 var GlobalMetrics *ActionMetrics
 
@@ -0,0 +1,212 @@
+// Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package powercap caps the power limits of the GPUs of a node and restores them. It
+// runs on the node, in the Jobs the PowerCap controller creates: the apply Job caps
+// the GPUs and reports their original limits, the restore Job sets those back once the
+// thermals of the node are back to normal.
+package powercap
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/nvidia/nvsentinel/janitor/api/v1alpha1"
+	"github.com/nvidia/nvsentinel/janitor/pkg/gpureset"
+)
+
+// Config configures a Capper.
+type Config struct {
+	// UUIDs and PCIBusIDs select the GPUs to cap, all GPUs of the node when both are empty
+	UUIDs     []string
+	PCIBusIDs []string
+	// LimitPercent is the cap in percent of the default power limit of the GPUs
+	LimitPercent int32
+}
+
+// Capper caps the power limits of the GPUs of the node.
+type Capper struct {
+	cfg Config
+	smi SMI
+}
+
+// NewCapper constructs a Capper.
+func NewCapper(cfg Config, smi SMI) *Capper {
+	return &Capper{cfg: cfg, smi: smi}
+}
+
+// Apply caps the selected GPUs to the percentage of their default power limit, but
+// not below their minimum limit, and returns the limits to restore. GPUs already
+// limited below the cap are left unchanged. When a GPU cannot be capped the GPUs
+// capped so far are restored.
+func (c *Capper) Apply(ctx context.Context) ([]v1alpha1.GPUPowerLimit, error) {
+	if c.cfg.LimitPercent < 1 || c.cfg.LimitPercent > 100 {
+		return nil, fmt.Errorf("invalid limit percent %d, expected 1 to 100", c.cfg.LimitPercent)
+	}
+
+	gpus, err := c.smi.GPUs(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	targets, err := selectGPUs(gpus, c.cfg.UUIDs, c.cfg.PCIBusIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	limits := make([]v1alpha1.GPUPowerLimit, 0, len(targets))
+
+	for _, gpu := range targets {
+		limit := v1alpha1.GPUPowerLimit{
+			UUID:          gpu.UUID,
+			OriginalWatts: gpu.LimitWatts,
+			CappedWatts:   max(gpu.DefaultWatts*c.cfg.LimitPercent/100, gpu.MinWatts),
+		}
+
+		if limit.CappedWatts >= gpu.LimitWatts {
+			slog.Info("GPU already limited below the cap", "gpu", gpu.UUID, "limitWatts", gpu.LimitWatts)
+
+			limit.CappedWatts = gpu.LimitWatts
+			limits = append(limits, limit)
+
+			continue
+		}
+
+		slog.Info("Capping GPU power", "gpu", gpu.UUID, "fromWatts", gpu.LimitWatts, "toWatts", limit.CappedWatts)
+
+		if err := c.smi.SetPowerLimit(ctx, gpu.UUID, limit.CappedWatts); err != nil {
+			if restoreErr := Restore(ctx, c.smi, limits); restoreErr != nil {
+				err = errors.Join(err, restoreErr)
+			}
+
+			return nil, fmt.Errorf("failed to cap GPU %s: %w", gpu.UUID, err)
+		}
+
+		limits = append(limits, limit)
+	}
+
+	return limits, nil
+}
+
+// Restore sets the GPUs back to their original power limits. All GPUs are attempted
+// before the errors are returned.
+func Restore(ctx context.Context, smi SMI, limits []v1alpha1.GPUPowerLimit) error {
+	var errs []error
+
+	for _, limit := range limits {
+		if limit.CappedWatts == limit.OriginalWatts {
+			continue
+		}
+
+		slog.Info("Restoring GPU power limit", "gpu", limit.UUID, "watts", limit.OriginalWatts)
+
+		if err := smi.SetPowerLimit(ctx, limit.UUID, limit.OriginalWatts); err != nil {
+			errs = append(errs, fmt.Errorf("failed to restore GPU %s: %w", limit.UUID, err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// FormatLimits formats the limits as uuid=original:capped pairs for the restore Job.
+func FormatLimits(limits []v1alpha1.GPUPowerLimit) string {
+	pairs := make([]string, 0, len(limits))
+	for _, limit := range limits {
+		pairs = append(pairs, fmt.Sprintf("%s=%d:%d", limit.UUID, limit.OriginalWatts, limit.CappedWatts))
+	}
+
+	return strings.Join(pairs, ",")
+}
+
+// ParseLimits parses the limits formatted by FormatLimits.
+func ParseLimits(value string) ([]v1alpha1.GPUPowerLimit, error) {
+	var limits []v1alpha1.GPUPowerLimit
+
+	for _, pair := range strings.Split(value, ",") {
+		if pair = strings.TrimSpace(pair); pair == "" {
+			continue
+		}
+
+		uuid, watts, ok := strings.Cut(pair, "=")
+		original, capped, found := strings.Cut(watts, ":")
+		originalWatts, originalErr := strconv.ParseInt(original, 10, 32)
+		cappedWatts, cappedErr := strconv.ParseInt(capped, 10, 32)
+
+		if !ok || !found || uuid == "" || originalErr != nil || cappedErr != nil {
+			return nil, fmt.Errorf("invalid power limit %q, expected uuid=original:capped", pair)
+		}
+
+		limits = append(limits, v1alpha1.GPUPowerLimit{
+			UUID:          uuid,
+			OriginalWatts: int32(originalWatts),
+			CappedWatts:   int32(cappedWatts),
+		})
+	}
+
+	return limits, nil
+}
+
+// selectGPUs returns the GPUs matching the UUIDs or PCI bus IDs, all GPUs when none
+// is given
+func selectGPUs(gpus []GPU, uuids, pciBusIDs []string) ([]GPU, error) {
+	if len(uuids) == 0 && len(pciBusIDs) == 0 {
+		if len(gpus) == 0 {
+			return nil, errors.New("no GPU found on the node")
+		}
+
+		return gpus, nil
+	}
+
+	var selected []GPU
+
+	found := map[string]bool{}
+
+	for _, gpu := range gpus {
+		uuidMatch := slices.Contains(uuids, gpu.UUID)
+		busMatch := slices.ContainsFunc(pciBusIDs, func(id string) bool {
+			return gpureset.NormalizePCIBusID(id) == gpu.PCIBusID
+		})
+
+		if uuidMatch || busMatch {
+			selected = append(selected, gpu)
+			found[gpu.UUID] = uuidMatch
+			found[gpu.PCIBusID] = busMatch
+		}
+	}
+
+	var missing []string
+
+	for _, uuid := range uuids {
+		if !found[uuid] {
+			missing = append(missing, uuid)
+		}
+	}
+
+	for _, id := range pciBusIDs {
+		if !found[gpureset.NormalizePCIBusID(id)] {
+			missing = append(missing, id)
+		}
+	}
+
+	if len(missing) > 0 {
+		return nil, fmt.Errorf("GPUs %s not found on the node", strings.Join(missing, ", "))
+	}
+
+	return selected, nil
+}
@@ -0,0 +1,161 @@
+// Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package powercap
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/nvidia/nvsentinel/janitor/api/v1alpha1"
+)
+
+const (
+	gpu0 = "GPU-11111111-1111-1111-1111-111111111111"
+	gpu1 = "GPU-22222222-2222-2222-2222-222222222222"
+)
+
+type fakeSMI struct {
+	gpus   []GPU
+	limits map[string]int32
+	// failOn fails setting the limit of the GPU
+	failOn string
+}
+
+func newFakeSMI() *fakeSMI {
+	return &fakeSMI{
+		gpus: []GPU{
+			{UUID: gpu0, PCIBusID: "0000:01:00.0", LimitWatts: 700, DefaultWatts: 700, MinWatts: 200},
+			{UUID: gpu1, PCIBusID: "0000:02:00.0", LimitWatts: 700, DefaultWatts: 700, MinWatts: 200},
+		},
+		limits: map[string]int32{},
+	}
+}
+
+func (s *fakeSMI) GPUs(context.Context) ([]GPU, error) {
+	return s.gpus, nil
+}
+
+func (s *fakeSMI) SetPowerLimit(_ context.Context, uuid string, watts int32) error {
+	if uuid == s.failOn {
+		return errors.New("insufficient permissions")
+	}
+
+	s.limits[uuid] = watts
+
+	return nil
+}
+
+func TestParseGPUs(t *testing.T) {
+	gpus, err := parseGPUs("GPU-a, 00000000:01:00.0, 700.00, 700.00, 200.00\n" +
+		"GPU-b, 00000000:0A:00.0, 500.00, 700.00, 200.00\n\n")
+	require.NoError(t, err)
+	assert.Equal(t, []GPU{
+		{UUID: "GPU-a", PCIBusID: "0000:01:00.0", LimitWatts: 700, DefaultWatts: 700, MinWatts: 200},
+		{UUID: "GPU-b", PCIBusID: "0000:0a:00.0", LimitWatts: 500, DefaultWatts: 700, MinWatts: 200},
+	}, gpus)
+
+	_, err = parseGPUs("GPU-a, 00000000:01:00.0, [N/A], [N/A], [N/A]")
+	assert.ErrorContains(t, err, "does not report its power limits")
+
+	_, err = parseGPUs("GPU-a, 00000000:01:00.0")
+	assert.ErrorContains(t, err, "unexpected nvidia-smi GPU line")
+}
+
+func TestApply(t *testing.T) {
+	smi := newFakeSMI()
+
+	limits, err := NewCapper(Config{LimitPercent: 70}, smi).Apply(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, []v1alpha1.GPUPowerLimit{
+		{UUID: gpu0, OriginalWatts: 700, CappedWatts: 490},
+		{UUID: gpu1, OriginalWatts: 700, CappedWatts: 490},
+	}, limits)
+	assert.Equal(t, map[string]int32{gpu0: 490, gpu1: 490}, smi.limits)
+}
+
+func TestApplySelectedGPUs(t *testing.T) {
+	smi := newFakeSMI()
+
+	limits, err := NewCapper(Config{PCIBusIDs: []string{"00000000:02:00.0"}, LimitPercent: 70}, smi).
+		Apply(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, []v1alpha1.GPUPowerLimit{{UUID: gpu1, OriginalWatts: 700, CappedWatts: 490}}, limits)
+	assert.Equal(t, map[string]int32{gpu1: 490}, smi.limits)
+
+	_, err = NewCapper(Config{UUIDs: []string{"GPU-missing"}, LimitPercent: 70}, smi).Apply(context.Background())
+	assert.ErrorContains(t, err, "GPU-missing not found")
+}
+
+func TestApplyKeepsMinimumAndLowerLimits(t *testing.T) {
+	smi := newFakeSMI()
+	smi.gpus[0].MinWatts = 500
+	smi.gpus[1].LimitWatts = 400
+
+	limits, err := NewCapper(Config{LimitPercent: 70}, smi).Apply(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, []v1alpha1.GPUPowerLimit{
+		{UUID: gpu0, OriginalWatts: 700, CappedWatts: 500},
+		{UUID: gpu1, OriginalWatts: 400, CappedWatts: 400},
+	}, limits)
+	assert.Equal(t, map[string]int32{gpu0: 500}, smi.limits, "the GPU already below the cap is not set")
+}
+
+func TestApplyRestoresOnFailure(t *testing.T) {
+	smi := newFakeSMI()
+	smi.failOn = gpu1
+
+	_, err := NewCapper(Config{LimitPercent: 70}, smi).Apply(context.Background())
+	assert.ErrorContains(t, err, "failed to cap GPU "+gpu1)
+	assert.Equal(t, map[string]int32{gpu0: 700}, smi.limits, "the capped GPU is restored")
+
+	_, err = NewCapper(Config{LimitPercent: 0}, smi).Apply(context.Background())
+	assert.ErrorContains(t, err, "invalid limit percent")
+}
+
+func TestRestore(t *testing.T) {
+	smi := newFakeSMI()
+	smi.failOn = gpu0
+
+	err := Restore(context.Background(), smi, []v1alpha1.GPUPowerLimit{
+		{UUID: gpu0, OriginalWatts: 700, CappedWatts: 490},
+		{UUID: gpu1, OriginalWatts: 650, CappedWatts: 490},
+		{UUID: "GPU-unchanged", OriginalWatts: 300, CappedWatts: 300},
+	})
+	assert.ErrorContains(t, err, "failed to restore GPU "+gpu0)
+	assert.Equal(t, map[string]int32{gpu1: 650}, smi.limits, "the other GPUs are restored")
+}
+
+func TestFormatAndParseLimits(t *testing.T) {
+	limits := []v1alpha1.GPUPowerLimit{
+		{UUID: gpu0, OriginalWatts: 700, CappedWatts: 490},
+		{UUID: gpu1, OriginalWatts: 650, CappedWatts: 490},
+	}
+
+	formatted := FormatLimits(limits)
+	assert.Equal(t, gpu0+"=700:490,"+gpu1+"=650:490", formatted)
+
+	parsed, err := ParseLimits(formatted)
+	require.NoError(t, err)
+	assert.Equal(t, limits, parsed)
+
+	for _, invalid := range []string{"GPU-a", "GPU-a=700", "GPU-a=x:490", "=700:490"} {
+		_, err := ParseLimits(invalid)
+		assert.Error(t, err, invalid)
+	}
+}
@@ -0,0 +1,133 @@
+// Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package powercap
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"os/exec"
+	"strconv"
+	"strings"
+	"syscall"
+
+	"github.com/nvidia/nvsentinel/janitor/pkg/gpureset"
+)
+
+// GPU is a GPU of the node with its power limits in watts as reported by nvidia-smi.
+type GPU struct {
+	UUID string
+	// PCIBusID is normalized to the domain:bus:device.function format of the PowerCap
+	// selector, e.g. 0000:01:00.0
+	PCIBusID     string
+	LimitWatts   int32
+	DefaultWatts int32
+	MinWatts     int32
+}
+
+// SMI queries and sets the power limits of the GPUs of the node.
+type SMI interface {
+	GPUs(ctx context.Context) ([]GPU, error)
+	// SetPowerLimit sets the power management limit of the GPU, which the driver keeps
+	// until it is set again or the driver is reloaded
+	SetPowerLimit(ctx context.Context, uuid string, watts int32) error
+}
+
+// runFunc runs nvidia-smi with the arguments and returns its output
+type runFunc func(ctx context.Context, args ...string) ([]byte, error)
+
+// NvidiaSMI runs nvidia-smi of the host.
+type NvidiaSMI struct {
+	run runFunc
+}
+
+// NewNvidiaSMI returns an SMI running the nvidia-smi binary at path inside the host
+// root, the driver libraries it loads are those of the host. An empty host root runs
+// it in the current root.
+func NewNvidiaSMI(hostRoot, path string) *NvidiaSMI {
+	return &NvidiaSMI{run: func(ctx context.Context, args ...string) ([]byte, error) {
+		cmd := exec.CommandContext(ctx, path, args...)
+		if hostRoot != "" {
+			cmd.SysProcAttr = &syscall.SysProcAttr{Chroot: hostRoot}
+			cmd.Dir = "/"
+		}
+
+		output, err := cmd.CombinedOutput()
+		if err != nil {
+			return output, fmt.Errorf("nvidia-smi %s failed: %w (output: %s)",
+				strings.Join(args, " "), err, strings.TrimSpace(string(output)))
+		}
+
+		return output, nil
+	}}
+}
+
+// GPUs returns the GPUs of the node with their power limits.
+func (s *NvidiaSMI) GPUs(ctx context.Context) ([]GPU, error) {
+	output, err := s.run(ctx, "--query-gpu=uuid,pci.bus_id,power.limit,power.default_limit,power.min_limit",
+		"--format=csv,noheader,nounits")
+	if err != nil {
+		return nil, err
+	}
+
+	return parseGPUs(string(output))
+}
+
+// SetPowerLimit sets the power management limit of the GPU with nvidia-smi -pl, which
+// calls nvmlDeviceSetPowerManagementLimit.
+func (s *NvidiaSMI) SetPowerLimit(ctx context.Context, uuid string, watts int32) error {
+	_, err := s.run(ctx, "-i", uuid, "-pl", strconv.Itoa(int(watts)))
+	return err
+}
+
+// parseGPUs parses the uuid,pci.bus_id,power.limit,power.default_limit,power.min_limit
+// CSV of nvidia-smi --query-gpu without units
+func parseGPUs(output string) ([]GPU, error) {
+	var gpus []GPU
+
+	for _, line := range strings.Split(output, "\n") {
+		if line = strings.TrimSpace(line); line == "" {
+			continue
+		}
+
+		fields := strings.Split(line, ",")
+		if len(fields) != 5 {
+			return nil, fmt.Errorf("unexpected nvidia-smi GPU line %q", line)
+		}
+
+		var watts [3]int32
+
+		for i, field := range fields[2:] {
+			value, err := strconv.ParseFloat(strings.TrimSpace(field), 64)
+			if err != nil {
+				// [N/A] on GPUs without power management
+				return nil, fmt.Errorf("GPU %s does not report its power limits: %q",
+					strings.TrimSpace(fields[0]), line)
+			}
+
+			watts[i] = int32(math.Round(value))
+		}
+
+		gpus = append(gpus, GPU{
+			UUID:         strings.TrimSpace(fields[0]),
+			PCIBusID:     gpureset.NormalizePCIBusID(fields[1]),
+			LimitWatts:   watts[0],
+			DefaultWatts: watts[1],
+			MinWatts:     watts[2],
+		})
+	}
+
+	return gpus, nil
+}
@@ -37,8 +37,12 @@ import (
 var janitorWebhookLog = logf.Log.WithName("janitor-webhook")
 
 const (
-	controllerTypeRebootNode    = "RebootNode"
-	controllerTypeTerminateNode = "TerminateNode"
+	controllerTypeRebootNode         = "RebootNode"
+	controllerTypeTerminateNode      = "TerminateNode"
+	controllerTypeDriverReload       = "DriverReload"
+	controllerTypeMIGReconfiguration = "MIGReconfiguration"
+	controllerTypeGPUReset           = "GPUReset"
+	controllerTypePowerCap           = "PowerCap"
 )
 
 // SetupJanitorWebhookWithManager registers the webhook for CRs managed by Janitor.
@@ -71,6 +75,38 @@ func SetupJanitorWebhookWithManager(mgr ctrl.Manager, cfg *config.Config) error
 		return err
 	}
 
+	// Register webhook for DriverReload
+	if err := ctrl.NewWebhookManagedBy(mgr).
+		For(&janitordgxcnvidiacomv1alpha1.DriverReload{}).
+		WithValidator(validator).
+		Complete(); err != nil {
+		return err
+	}
+
+	// Register webhook for MIGReconfiguration
+	if err := ctrl.NewWebhookManagedBy(mgr).
+		For(&janitordgxcnvidiacomv1alpha1.MIGReconfiguration{}).
+		WithValidator(validator).
+		Complete(); err != nil {
+		return err
+	}
+
+	// Register webhook for GPUReset
+	if err := ctrl.NewWebhookManagedBy(mgr).
+		For(&janitordgxcnvidiacomv1alpha1.GPUReset{}).
+		WithValidator(validator).
+		Complete(); err != nil {
+		return err
+	}
+
+	// Register webhook for PowerCap
+	if err := ctrl.NewWebhookManagedBy(mgr).
+		For(&janitordgxcnvidiacomv1alpha1.PowerCap{}).
+		WithValidator(validator).
+		Complete(); err != nil {
+		return err
+	}
+
 	return nil
 }
 
@@ -80,6 +116,18 @@ func SetupJanitorWebhookWithManager(mgr ctrl.Manager, cfg *config.Config) error
 // nolint:lll
 // +kubebuilder:webhook:path=/validate-janitor-dgxc-nvidia-com-v1alpha1-terminatenode,mutating=false,failurePolicy=fail,sideEffects=None,groups=janitor.dgxc.nvidia.com,resources=terminatenodes,verbs=create;update;delete,versions=v1alpha1,name=vterminatenode-v1alpha1.kb.io,admissionReviewVersions=v1
 
+// nolint:lll
+// +kubebuilder:webhook:path=/validate-janitor-dgxc-nvidia-com-v1alpha1-driverreload,mutating=false,failurePolicy=fail,sideEffects=None,groups=janitor.dgxc.nvidia.com,resources=driverreloads,verbs=create;update;delete,versions=v1alpha1,name=vdriverreload-v1alpha1.kb.io,admissionReviewVersions=v1
+
+// nolint:lll
+// +kubebuilder:webhook:path=/validate-janitor-dgxc-nvidia-com-v1alpha1-migreconfiguration,mutating=false,failurePolicy=fail,sideEffects=None,groups=janitor.dgxc.nvidia.com,resources=migreconfigurations,verbs=create;update;delete,versions=v1alpha1,name=vmigreconfiguration-v1alpha1.kb.io,admissionReviewVersions=v1
+
+// nolint:lll
+// +kubebuilder:webhook:path=/validate-janitor-dgxc-nvidia-com-v1alpha1-gpureset,mutating=false,failurePolicy=fail,sideEffects=None,groups=janitor.dgxc.nvidia.com,resources=gpuresets,verbs=create;update;delete,versions=v1alpha1,name=vgpureset-v1alpha1.kb.io,admissionReviewVersions=v1
+
+// nolint:lll
+// +kubebuilder:webhook:path=/validate-janitor-dgxc-nvidia-com-v1alpha1-powercap,mutating=false,failurePolicy=fail,sideEffects=None,groups=janitor.dgxc.nvidia.com,resources=powercaps,verbs=create;update;delete,versions=v1alpha1,name=vpowercap-v1alpha1.kb.io,admissionReviewVersions=v1
+
 // JanitorCustomValidator struct is responsible for validating all Janitor resources
 // when they are created, updated, or deleted.
 //
@@ -192,6 +240,118 @@ func (v *JanitorCustomValidator) validateNoActiveTermination(ctx context.Context
 	return nil
 }
 
+// validateNoActiveDriverReload checks if there's already an active driver reload for the node
+func (v *JanitorCustomValidator) validateNoActiveDriverReload(ctx context.Context, nodeName string) error {
+	if v.Client == nil {
+		return fmt.Errorf("kubernetes client not available for driver reload validation")
+	}
+
+	var driverReloadList janitordgxcnvidiacomv1alpha1.DriverReloadList
+	if err := v.Client.List(ctx, &driverReloadList); err != nil {
+		return fmt.Errorf("failed to list DriverReload resources: %w", err)
+	}
+
+	for _, driverReload := range driverReloadList.Items {
+		if driverReload.Spec.NodeName != nodeName {
+			continue
+		}
+
+		if driverReload.Status.CompletionTime == nil {
+			return fmt.Errorf(
+				"node '%s' already has an active driver reload in progress (DriverReload: %s)", // nolint:lll
+				nodeName,
+				driverReload.Name,
+			)
+		}
+	}
+
+	return nil
+}
+
+// validateNoActiveMIGReconfiguration checks if there's already an active MIG reconfiguration for the node
+func (v *JanitorCustomValidator) validateNoActiveMIGReconfiguration(ctx context.Context, nodeName string) error {
+	if v.Client == nil {
+		return fmt.Errorf("kubernetes client not available for MIG reconfiguration validation")
+	}
+
+	var migReconfigurationList janitordgxcnvidiacomv1alpha1.MIGReconfigurationList
+	if err := v.Client.List(ctx, &migReconfigurationList); err != nil {
+		return fmt.Errorf("failed to list MIGReconfiguration resources: %w", err)
+	}
+
+	for _, migReconfiguration := range migReconfigurationList.Items {
+		if migReconfiguration.Spec.NodeName != nodeName {
+			continue
+		}
+
+		if migReconfiguration.Status.CompletionTime == nil {
+			return fmt.Errorf(
+				"node '%s' already has an active MIG reconfiguration in progress (MIGReconfiguration: %s)", // nolint:lll
+				nodeName,
+				migReconfiguration.Name,
+			)
+		}
+	}
+
+	return nil
+}
+
+// validateNoActiveGPUReset checks if there's already an active GPU reset for the node
+func (v *JanitorCustomValidator) validateNoActiveGPUReset(ctx context.Context, nodeName string) error {
+	if v.Client == nil {
+		return fmt.Errorf("kubernetes client not available for GPU reset validation")
+	}
+
+	var gpuResetList janitordgxcnvidiacomv1alpha1.GPUResetList
+	if err := v.Client.List(ctx, &gpuResetList); err != nil {
+		return fmt.Errorf("failed to list GPUReset resources: %w", err)
+	}
+
+	for _, gpuReset := range gpuResetList.Items {
+		if gpuReset.Spec.NodeName != nodeName {
+			continue
+		}
+
+		if gpuReset.Status.CompletionTime == nil {
+			return fmt.Errorf(
+				"node '%s' already has an active GPU reset in progress (GPUReset: %s)",
+				nodeName,
+				gpuReset.Name,
+			)
+		}
+	}
+
+	return nil
+}
+
+// validateNoActivePowerCap checks if there's already an active power cap for the node
+func (v *JanitorCustomValidator) validateNoActivePowerCap(ctx context.Context, nodeName string) error {
+	if v.Client == nil {
+		return fmt.Errorf("kubernetes client not available for power cap validation")
+	}
+
+	var powerCapList janitordgxcnvidiacomv1alpha1.PowerCapList
+	if err := v.Client.List(ctx, &powerCapList); err != nil {
+		return fmt.Errorf("failed to list PowerCap resources: %w", err)
+	}
+
+	for _, powerCap := range powerCapList.Items {
+		if powerCap.Spec.NodeName != nodeName {
+			continue
+		}
+
+		if powerCap.Status.CompletionTime == nil {
+			return fmt.Errorf(
+				"node '%s' already has an active power cap in progress (PowerCap: %s)",
+				nodeName,
+				powerCap.Name,
+			)
+		}
+	}
+
+	return nil
+}
+
 // ValidateCreate implements webhook.CustomValidator so a webhook will be registered for all Janitor CRD types.
 // nolint:cyclop
 func (v *JanitorCustomValidator) ValidateCreate(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
@@ -248,6 +408,98 @@ func (v *JanitorCustomValidator) ValidateCreate(ctx context.Context, obj runtime
 			return nil, err
 		}
 
+	case *janitordgxcnvidiacomv1alpha1.DriverReload:
+		objName = typedObj.GetName()
+		controllerType = controllerTypeDriverReload
+		nodeName = typedObj.Spec.NodeName
+
+		if v.Config == nil || !v.Config.DriverReload.Enabled {
+			janitorWebhookLog.Info("DriverReload controller is disabled, rejecting creation", "name", objName)
+			return nil, fmt.Errorf("DriverReload controller is disabled in configuration")
+		}
+
+		// Check for active driver reloads
+		if err := v.validateNoActiveDriverReload(ctx, nodeName); err != nil {
+			janitorWebhookLog.Info(
+				"Active driver reload validation failed", // nolint:lll
+				"type", controllerType,
+				"name", objName,
+				"nodeName", nodeName,
+				"error", err.Error(),
+			)
+
+			return nil, err
+		}
+
+	case *janitordgxcnvidiacomv1alpha1.MIGReconfiguration:
+		objName = typedObj.GetName()
+		controllerType = controllerTypeMIGReconfiguration
+		nodeName = typedObj.Spec.NodeName
+
+		if v.Config == nil || !v.Config.MIGReconfiguration.Enabled {
+			janitorWebhookLog.Info("MIGReconfiguration controller is disabled, rejecting creation", "name", objName)
+			return nil, fmt.Errorf("MIGReconfiguration controller is disabled in configuration")
+		}
+
+		// Check for active MIG reconfigurations
+		if err := v.validateNoActiveMIGReconfiguration(ctx, nodeName); err != nil {
+			janitorWebhookLog.Info(
+				"Active MIG reconfiguration validation failed", // nolint:lll
+				"type", controllerType,
+				"name", objName,
+				"nodeName", nodeName,
+				"error", err.Error(),
+			)
+
+			return nil, err
+		}
+
+	case *janitordgxcnvidiacomv1alpha1.GPUReset:
+		objName = typedObj.GetName()
+		controllerType = controllerTypeGPUReset
+		nodeName = typedObj.Spec.NodeName
+
+		if v.Config == nil || !v.Config.GPUReset.Enabled {
+			janitorWebhookLog.Info("GPUReset controller is disabled, rejecting creation", "name", objName)
+			return nil, fmt.Errorf("GPUReset controller is disabled in configuration")
+		}
+
+		// Check for active GPU resets
+		if err := v.validateNoActiveGPUReset(ctx, nodeName); err != nil {
+			janitorWebhookLog.Info(
+				"Active GPU reset validation failed", // nolint:lll
+				"type", controllerType,
+				"name", objName,
+				"nodeName", nodeName,
+				"error", err.Error(),
+			)
+
+			return nil, err
+		}
+
+	case *janitordgxcnvidiacomv1alpha1.PowerCap:
+		objName = typedObj.GetName()
+		controllerType = controllerTypePowerCap
+		nodeName = typedObj.Spec.NodeName
+
+		if v.Config == nil || !v.Config.PowerCap.Enabled {
+			janitorWebhookLog.Info("PowerCap controller is disabled, rejecting creation", "name", objName)
+			return nil, fmt.Errorf("PowerCap controller is disabled in configuration")
+		}
+
+		// Check for active power caps
+		if err := v.validateNoActivePowerCap(ctx, nodeName); err != nil {
+			janitorWebhookLog.Info(
+				"Active power cap validation failed", // nolint:lll
+				"type", controllerType,
+				"name", objName,
+				"nodeName", nodeName,
+				"error", err.Error(),
+			)
+
+			return nil, err
+		}
+
 	default:
 		return nil, fmt.Errorf("expected a Janitor CR object but got %T", obj)
 	}
@@ -331,6 +583,78 @@ func (v *JanitorCustomValidator) ValidateUpdate(ctx context.Context, oldObj, new
 			}
 		}
 
+	case *janitordgxcnvidiacomv1alpha1.DriverReload:
+		objName = typedObj.GetName()
+		controllerType = controllerTypeDriverReload
+		nodeName = typedObj.Spec.NodeName
+
+		if v.Config == nil || !v.Config.DriverReload.Enabled {
+			janitorWebhookLog.Info("DriverReload controller is disabled, rejecting update", "name", objName)
+			return nil, fmt.Errorf("DriverReload controller is disabled in configuration")
+		}
+
+		// Prevent changes to nodeName
+		if oldDriverReload, ok := oldObj.(*janitordgxcnvidiacomv1alpha1.DriverReload); ok {
+			oldNodeName = oldDriverReload.Spec.NodeName
+			if oldNodeName != nodeName {
+				return nil, fmt.Errorf("nodeName cannot be changed after creation")
+			}
+		}
+
+	case *janitordgxcnvidiacomv1alpha1.MIGReconfiguration:
+		objName = typedObj.GetName()
+		controllerType = controllerTypeMIGReconfiguration
+		nodeName = typedObj.Spec.NodeName
+
+		if v.Config == nil || !v.Config.MIGReconfiguration.Enabled {
+			janitorWebhookLog.Info("MIGReconfiguration controller is disabled, rejecting update", "name", objName)
+			return nil, fmt.Errorf("MIGReconfiguration controller is disabled in configuration")
+		}
+
+		// Prevent changes to nodeName
+		if oldMIGReconfiguration, ok := oldObj.(*janitordgxcnvidiacomv1alpha1.MIGReconfiguration); ok {
+			oldNodeName = oldMIGReconfiguration.Spec.NodeName
+			if oldNodeName != nodeName {
+				return nil, fmt.Errorf("nodeName cannot be changed after creation")
+			}
+		}
+
+	case *janitordgxcnvidiacomv1alpha1.GPUReset:
+		objName = typedObj.GetName()
+		controllerType = controllerTypeGPUReset
+		nodeName = typedObj.Spec.NodeName
+
+		if v.Config == nil || !v.Config.GPUReset.Enabled {
+			janitorWebhookLog.Info("GPUReset controller is disabled, rejecting update", "name", objName)
+			return nil, fmt.Errorf("GPUReset controller is disabled in configuration")
+		}
+
+		// Prevent changes to nodeName
+		if oldGPUReset, ok := oldObj.(*janitordgxcnvidiacomv1alpha1.GPUReset); ok {
+			oldNodeName = oldGPUReset.Spec.NodeName
+			if oldNodeName != nodeName {
+				return nil, fmt.Errorf("nodeName cannot be changed after creation")
+			}
+		}
+
+	case *janitordgxcnvidiacomv1alpha1.PowerCap:
+		objName = typedObj.GetName()
+		controllerType = controllerTypePowerCap
+		nodeName = typedObj.Spec.NodeName
+
+		if v.Config == nil || !v.Config.PowerCap.Enabled {
+			janitorWebhookLog.Info("PowerCap controller is disabled, rejecting update", "name", objName)
+			return nil, fmt.Errorf("PowerCap controller is disabled in configuration")
+		}
+
+		// Prevent changes to nodeName
+		if oldPowerCap, ok := oldObj.(*janitordgxcnvidiacomv1alpha1.PowerCap); ok {
+			oldNodeName = oldPowerCap.Spec.NodeName
+			if oldNodeName != nodeName {
+				return nil, fmt.Errorf("nodeName cannot be changed after creation")
+			}
+		}
+
 	default:
 		return nil, fmt.Errorf("expected a Janitor CR object but got %T", newObj)
 	}
@@ -393,6 +717,42 @@ func (v *JanitorCustomValidator) ValidateDelete(ctx context.Context, obj runtime
 			return nil, fmt.Errorf("TerminateNode controller is disabled in configuration")
 		}
 
+	case *janitordgxcnvidiacomv1alpha1.DriverReload:
+		objName = typedObj.GetName()
+		controllerType = controllerTypeDriverReload
+
+		if v.Config == nil || !v.Config.DriverReload.Enabled {
+			janitorWebhookLog.Info("DriverReload controller is disabled, rejecting deletion", "name", objName)
+			return nil, fmt.Errorf("DriverReload controller is disabled in configuration")
+		}
+
+	case *janitordgxcnvidiacomv1alpha1.MIGReconfiguration:
+		objName = typedObj.GetName()
+		controllerType = controllerTypeMIGReconfiguration
+
+		if v.Config == nil || !v.Config.MIGReconfiguration.Enabled {
+			janitorWebhookLog.Info("MIGReconfiguration controller is disabled, rejecting deletion", "name", objName)
+			return nil, fmt.Errorf("MIGReconfiguration controller is disabled in configuration")
+		}
+
+	case *janitordgxcnvidiacomv1alpha1.GPUReset:
+		objName = typedObj.GetName()
+		controllerType = controllerTypeGPUReset
+
+		if v.Config == nil || !v.Config.GPUReset.Enabled {
+			janitorWebhookLog.Info("GPUReset controller is disabled, rejecting deletion", "name", objName)
+			return nil, fmt.Errorf("GPUReset controller is disabled in configuration")
+		}
+
+	case *janitordgxcnvidiacomv1alpha1.PowerCap:
+		objName = typedObj.GetName()
+		controllerType = controllerTypePowerCap
+
+		if v.Config == nil || !v.Config.PowerCap.Enabled {
+			janitorWebhookLog.Info("PowerCap controller is disabled, rejecting deletion", "name", objName)
+			return nil, fmt.Errorf("PowerCap controller is disabled in configuration")
+		}
+
 	default:
 		return nil, fmt.Errorf("expected a Janitor CR object but got %T", obj)
 	}
@@ -75,6 +75,22 @@ var _ = Describe("Janitor Webhook", func() {
 						Timeout:    30 * time.Minute,
 						ManualMode: false,
 					},
+					DriverReload: config.DriverReloadControllerConfig{
+						Enabled: true,
+						Timeout: 30 * time.Minute,
+					},
+					MIGReconfiguration: config.MIGReconfigurationControllerConfig{
+						Enabled: true,
+						Timeout: 30 * time.Minute,
+					},
+					GPUReset: config.GPUResetControllerConfig{
+						Enabled: true,
+						Timeout: 15 * time.Minute,
+					},
+					PowerCap: config.PowerCapControllerConfig{
+						Enabled: true,
+						Timeout: 5 * time.Minute,
+					},
 				},
 				Client: fakeClient,
 			}
@@ -108,6 +124,115 @@ var _ = Describe("Janitor Webhook", func() {
 			Expect(err).ToNot(HaveOccurred())
 		})
 
+		It("Should admit DriverReload creation when node exists", func() {
+			obj := &janitordgxcnvidiacomv1alpha1.DriverReload{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "test-driver-reload",
+				},
+				Spec: janitordgxcnvidiacomv1alpha1.DriverReloadSpec{
+					NodeName: "test-node",
+				},
+			}
+			_, err := validator.ValidateCreate(ctx, obj)
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		It("Should reject DriverReload creation when a driver reload is active", func() {
+			active := &janitordgxcnvidiacomv1alpha1.DriverReload{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "test-driver-reload-active",
+				},
+				Spec: janitordgxcnvidiacomv1alpha1.DriverReloadSpec{
+					NodeName: "test-node",
+				},
+			}
+			Expect(fakeClient.Create(ctx, active)).To(Succeed())
+
+			obj := &janitordgxcnvidiacomv1alpha1.DriverReload{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "test-driver-reload",
+				},
+				Spec: janitordgxcnvidiacomv1alpha1.DriverReloadSpec{
+					NodeName: "test-node",
+				},
+			}
+			_, err := validator.ValidateCreate(ctx, obj)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("active driver reload in progress"))
+		})
+
+		It("Should reject MIGReconfiguration creation when a MIG reconfiguration is active", func() {
+			active := &janitordgxcnvidiacomv1alpha1.MIGReconfiguration{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "test-mig-reconfiguration-active",
+				},
+				Spec: janitordgxcnvidiacomv1alpha1.MIGReconfigurationSpec{
+					NodeName: "test-node",
+				},
+			}
+			Expect(fakeClient.Create(ctx, active)).To(Succeed())
+
+			obj := &janitordgxcnvidiacomv1alpha1.MIGReconfiguration{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "test-mig-reconfiguration",
+				},
+				Spec: janitordgxcnvidiacomv1alpha1.MIGReconfigurationSpec{
+					NodeName: "test-node",
+				},
+			}
+			_, err := validator.ValidateCreate(ctx, obj)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("active MIG reconfiguration in progress"))
+		})
+
+		It("Should reject GPUReset creation when a GPU reset is active", func() {
+			active := &janitordgxcnvidiacomv1alpha1.GPUReset{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "test-gpu-reset-active",
+				},
+				Spec: janitordgxcnvidiacomv1alpha1.GPUResetSpec{
+					NodeName: "test-node",
+				},
+			}
+			Expect(fakeClient.Create(ctx, active)).To(Succeed())
+
+			obj := &janitordgxcnvidiacomv1alpha1.GPUReset{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "test-gpu-reset",
+				},
+				Spec: janitordgxcnvidiacomv1alpha1.GPUResetSpec{
+					NodeName: "test-node",
+				},
+			}
+			_, err := validator.ValidateCreate(ctx, obj)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("active GPU reset in progress"))
+		})
+
+		It("Should reject PowerCap creation when a power cap is active", func() {
+			active := &janitordgxcnvidiacomv1alpha1.PowerCap{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "test-power-cap-active",
+				},
+				Spec: janitordgxcnvidiacomv1alpha1.PowerCapSpec{
+					NodeName: "test-node",
+				},
+			}
+			Expect(fakeClient.Create(ctx, active)).To(Succeed())
+
+			obj := &janitordgxcnvidiacomv1alpha1.PowerCap{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "test-power-cap",
+				},
+				Spec: janitordgxcnvidiacomv1alpha1.PowerCapSpec{
+					NodeName: "test-node",
+				},
+			}
+			_, err := validator.ValidateCreate(ctx, obj)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("active power cap in progress"))
+		})
+
 		It("Should admit RebootNode updates when node exists", func() {
 			oldObj := &janitordgxcnvidiacomv1alpha1.RebootNode{
 				ObjectMeta: metav1.ObjectMeta{
@@ -275,6 +400,62 @@ var _ = Describe("Janitor Webhook", func() {
 			Expect(err.Error()).To(ContainSubstring("TerminateNode controller is disabled"))
 		})
 
+		It("Should reject DriverReload creation when controller disabled", func() {
+			obj := &janitordgxcnvidiacomv1alpha1.DriverReload{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "test-driver-reload",
+				},
+				Spec: janitordgxcnvidiacomv1alpha1.DriverReloadSpec{
+					NodeName: "test-node",
+				},
+			}
+			_, err := validator.ValidateCreate(ctx, obj)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("DriverReload controller is disabled"))
+		})
+
+		It("Should reject MIGReconfiguration creation when controller disabled", func() {
+			obj := &janitordgxcnvidiacomv1alpha1.MIGReconfiguration{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "test-mig-reconfiguration",
+				},
+				Spec: janitordgxcnvidiacomv1alpha1.MIGReconfigurationSpec{
+					NodeName: "test-node",
+				},
+			}
+			_, err := validator.ValidateCreate(ctx, obj)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("MIGReconfiguration controller is disabled"))
+		})
+
+		It("Should reject GPUReset creation when controller disabled", func() {
+			obj := &janitordgxcnvidiacomv1alpha1.GPUReset{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "test-gpu-reset",
+				},
+				Spec: janitordgxcnvidiacomv1alpha1.GPUResetSpec{
+					NodeName: "test-node",
+				},
+			}
+			_, err := validator.ValidateCreate(ctx, obj)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("GPUReset controller is disabled"))
+		})
+
+		It("Should reject PowerCap creation when controller disabled", func() {
+			obj := &janitordgxcnvidiacomv1alpha1.PowerCap{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "test-power-cap",
+				},
+				Spec: janitordgxcnvidiacomv1alpha1.PowerCapSpec{
+					NodeName: "test-node",
+				},
+			}
+			_, err := validator.ValidateCreate(ctx, obj)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("PowerCap controller is disabled"))
+		})
+
 		It("Should reject RebootNode updates when controller disabled", func() {
 			oldObj := &janitordgxcnvidiacomv1alpha1.RebootNode{
 				ObjectMeta: metav1.ObjectMeta{
@@ -0,0 +1,139 @@
+// Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"log/slog"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/nvidia/nvsentinel/janitor/pkg/powercap"
+)
+
+// runPowerCap runs `janitor power-cap` in the Jobs of the PowerCap controller: without
+// --restore the selected GPUs are capped and the JSON of their limits is written to
+// the termination log, with --restore the limits it lists are set back. Errors are
+// written to the termination log too.
+func runPowerCap(args []string) error {
+	var (
+		uuids          string
+		pciBusIDs      string
+		limitPercent   int
+		restore        string
+		hostRoot       string
+		nvidiaSMI      string
+		terminationLog string
+	)
+
+	flags := flag.NewFlagSet("janitor power-cap", flag.ContinueOnError)
+	flags.StringVar(&uuids, "uuids", "", "Comma separated UUIDs of the GPUs to cap.")
+	flags.StringVar(&pciBusIDs, "pci-bus-ids", "", "Comma separated PCI bus IDs of the GPUs to cap. "+
+		"All GPUs of the node are capped when neither UUIDs nor PCI bus IDs are given.")
+	flags.IntVar(&limitPercent, "limit-percent", 70, "Cap in percent of the default power limit of the GPUs.")
+	flags.StringVar(&restore, "restore", "",
+		"Comma separated uuid=original:capped power limits in watts to restore instead of capping.")
+	flags.StringVar(&hostRoot, "host-root", "/host", "Path the root filesystem of the node is mounted at.")
+	flags.StringVar(&nvidiaSMI, "nvidia-smi", "/usr/bin/nvidia-smi", "Path of nvidia-smi on the node.")
+	flags.StringVar(&terminationLog, "termination-log", "/dev/termination-log",
+		"Path the result is written to, empty disables it.")
+
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	smi := powercap.NewNvidiaSMI(hostRoot, nvidiaSMI)
+
+	var (
+		message string
+		err     error
+	)
+
+	if restore != "" {
+		message, err = restorePowerLimits(ctx, smi, restore)
+	} else {
+		message, err = capPowerLimits(ctx, smi, powercap.Config{
+			UUIDs:        splitList(uuids),
+			PCIBusIDs:    splitList(pciBusIDs),
+			LimitPercent: int32(limitPercent), //nolint:gosec // validated by the capper
+		})
+	}
+
+	if err != nil {
+		message = err.Error()
+	}
+
+	if terminationLog != "" {
+		if errWrite := os.WriteFile(terminationLog, []byte(message), 0o600); errWrite != nil {
+			slog.Warn("Failed to write termination log", "path", terminationLog, "error", errWrite)
+		}
+	}
+
+	return err
+}
+
+func capPowerLimits(ctx context.Context, smi powercap.SMI, cfg powercap.Config) (string, error) {
+	limits, err := powercap.NewCapper(cfg, smi).Apply(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	message, err := json.Marshal(limits)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal the power limits: %w", err)
+	}
+
+	slog.Info("GPU power capped", "limits", string(message))
+
+	return string(message), nil
+}
+
+func restorePowerLimits(ctx context.Context, smi powercap.SMI, restore string) (string, error) {
+	limits, err := powercap.ParseLimits(restore)
+	if err != nil {
+		return "", err
+	}
+
+	if err := powercap.Restore(ctx, smi, limits); err != nil {
+		return "", err
+	}
+
+	slog.Info("GPU power limits restored", "gpus", len(limits))
+
+	return fmt.Sprintf("restored the power limits of %d GPUs", len(limits)), nil
+}
+
+// exitPowerCap runs the power-cap command and exits with its status
+func exitPowerCap(args []string) {
+	err := runPowerCap(args)
+	if errors.Is(err, flag.ErrHelp) {
+		os.Exit(0)
+	}
+
+	if err != nil {
+		slog.Error("Power cap failed", "error", err)
+		os.Exit(1)
+	}
+
+	os.Exit(0)
+}
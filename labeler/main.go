@@ -62,6 +62,7 @@ func run() error {
 	srv := server.NewServer(
 		server.WithPort(portInt),
 		server.WithPrometheusMetrics(),
+		server.WithHandler(logger.LevelsPath, logger.LevelsHandler()),
 		server.WithSimpleHealth(),
 	)
 
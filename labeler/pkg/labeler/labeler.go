@@ -21,6 +21,7 @@ import (
 	"regexp"
 	"time"
 
+	"github.com/nvidia/nvsentinel/commons/pkg/nodecache"
 	"github.com/nvidia/nvsentinel/commons/pkg/stringutil"
 	"github.com/nvidia/nvsentinel/labeler/pkg/metrics"
 
@@ -30,7 +31,6 @@ import (
 	"k8s.io/client-go/informers"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/tools/cache"
-	"k8s.io/client-go/util/retry"
 	podutil "k8s.io/kubernetes/pkg/api/v1/pod"
 )
 
@@ -58,6 +58,7 @@ type Labeler struct {
 	clientset       kubernetes.Interface
 	podInformer     cache.SharedIndexInformer
 	nodeInformer    cache.SharedIndexInformer
+	nodes           *nodecache.Cache
 	informersSynced []cache.InformerSynced
 	ctx             context.Context
 	dcgmAppLabel    string
@@ -130,6 +131,7 @@ func NewLabeler(clientset kubernetes.Interface, resyncPeriod time.Duration,
 		clientset:       clientset,
 		podInformer:     podInformer,
 		nodeInformer:    nodeInformer,
+		nodes:           nodecache.New(clientset, nodeInformer),
 		informersSynced: []cache.InformerSynced{podInformer.HasSynced, nodeInformer.HasSynced},
 		ctx:             context.Background(),
 		dcgmAppLabel:    dcgmApp,
@@ -392,12 +394,7 @@ func (l *Labeler) getDriverLabelForNodeExcluding(nodeName string, excludePod *v1
 
 // updateNodeLabelsForPod updates only DCGM and driver labels (kata is handled separately by node events)
 func (l *Labeler) updateNodeLabelsForPod(nodeName, expectedDCGMVersion, expectedDriverLabel string) error {
-	err := retry.RetryOnConflict(retry.DefaultBackoff, func() error {
-		node, err := l.clientset.CoreV1().Nodes().Get(l.ctx, nodeName, metav1.GetOptions{})
-		if err != nil {
-			return err
-		}
-
+	err := l.nodes.Update(l.ctx, nodeName, func(node *v1.Node) error {
 		if node.Labels == nil {
 			node.Labels = make(map[string]string)
 		}
@@ -430,12 +427,9 @@ func (l *Labeler) updateNodeLabelsForPod(nodeName, expectedDCGMVersion, expected
 
 		if !needsUpdate {
 			slog.Debug("Node already has correct pod-related labels", "node", nodeName)
-			return nil
 		}
 
-		_, err = l.clientset.CoreV1().Nodes().Update(l.ctx, node, metav1.UpdateOptions{})
-
-		return err
+		return nil
 	})
 	if err != nil {
 		metrics.NodeUpdateFailures.Inc()
@@ -466,12 +460,7 @@ func (l *Labeler) handleNodeEvent(obj any) error {
 
 // updateKataLabel updates only the kata label on a node
 func (l *Labeler) updateKataLabel(nodeName, expectedKataLabel string) error {
-	err := retry.RetryOnConflict(retry.DefaultBackoff, func() error {
-		node, err := l.clientset.CoreV1().Nodes().Get(l.ctx, nodeName, metav1.GetOptions{})
-		if err != nil {
-			return err
-		}
-
+	err := l.nodes.Update(l.ctx, nodeName, func(node *v1.Node) error {
 		if node.Labels == nil {
 			node.Labels = make(map[string]string)
 		}
@@ -485,9 +474,7 @@ func (l *Labeler) updateKataLabel(nodeName, expectedKataLabel string) error {
 		node.Labels[KataEnabledLabel] = expectedKataLabel
 		slog.Info("Setting Kata enabled label on node", "node", nodeName, "kata", expectedKataLabel)
 
-		_, err = l.clientset.CoreV1().Nodes().Update(l.ctx, node, metav1.UpdateOptions{})
-
-		return err
+		return nil
 	})
 	if err != nil {
 		metrics.NodeUpdateFailures.Inc()
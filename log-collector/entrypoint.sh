@@ -18,6 +18,7 @@ set -xeuo pipefail
 # Simple collector:
 # - Run nvidia-bug-report inside the node's nvidia-driver-daemonset pod
 # - Run GPU Operator must-gather
+# - Pull the events and log lines the syslog health monitor retains on the node
 # - Optionally upload both artifacts to an in-cluster file server if UPLOAD_URL_BASE is set
 
 NODE_NAME="${NODE_NAME:-unknown-node}"
@@ -29,6 +30,8 @@ DRIVER_CONTAINER_NAME="${DRIVER_CONTAINER_NAME:-nvidia-driver-ctr}"
 MUST_GATHER_SCRIPT_URL="${MUST_GATHER_SCRIPT_URL:-https://raw.githubusercontent.com/NVIDIA/gpu-operator/main/hack/must-gather.sh}"
 ENABLE_GCP_SOS_COLLECTION="${ENABLE_GCP_SOS_COLLECTION:-false}"
 ENABLE_AWS_SOS_COLLECTION="${ENABLE_AWS_SOS_COLLECTION:-false}"
+NVSENTINEL_NAMESPACE="${NVSENTINEL_NAMESPACE:-nvsentinel}"
+SYSLOG_HEALTH_MONITOR_PORT="${SYSLOG_HEALTH_MONITOR_PORT:-2112}"
 
 mkdir -p "${ARTIFACTS_DIR}"
 echo "[INFO] Target node: ${NODE_NAME} | GPU Operator namespace: ${GPU_OPERATOR_NAMESPACE} | Driver container: ${DRIVER_CONTAINER_NAME}"
@@ -209,6 +212,26 @@ else
   echo "[INFO] No GPU Operator must-gather data to archive"
 fi
 
+# 5) Events and matched log lines retained by the syslog health monitor of the node,
+# including the events filtered from shipping by severity
+LOCAL_EVENTS=""
+SYSLOG_MONITOR_IPS="$(kubectl -n "${NVSENTINEL_NAMESPACE}" get pods -l app.kubernetes.io/name=syslog-health-monitor \
+  --field-selector spec.nodeName="${NODE_NAME}",status.phase=Running -o jsonpath='{.items[*].status.podIP}' || true)"
+
+for SYSLOG_MONITOR_IP in ${SYSLOG_MONITOR_IPS}; do
+  LOCAL_EVENTS_FILE="${ARTIFACTS_DIR}/syslog-health-monitor-local-events-${NODE_NAME}-${TIMESTAMP}-${SYSLOG_MONITOR_IP}.json"
+  if curl -fsS -m 30 "http://${SYSLOG_MONITOR_IP}:${SYSLOG_HEALTH_MONITOR_PORT}/events/local" -o "${LOCAL_EVENTS_FILE}"; then
+    LOCAL_EVENTS="${LOCAL_EVENTS} ${LOCAL_EVENTS_FILE}"
+    echo "[INFO] Syslog health monitor local events saved to ${LOCAL_EVENTS_FILE}"
+  else
+    echo "[WARN] Failed to pull local events of the syslog health monitor at ${SYSLOG_MONITOR_IP}" >&2
+  fi
+done
+
+if [ -z "${SYSLOG_MONITOR_IPS}" ]; then
+  echo "[INFO] No syslog health monitor found on node ${NODE_NAME} in namespace ${NVSENTINEL_NAMESPACE}"
+fi
+
 # Optional upload to in-cluster file server
 if [ -n "${UPLOAD_URL_BASE:-}" ]; then
   echo "[INFO] Uploading artifacts to ${UPLOAD_URL_BASE}/${NODE_NAME}/${TIMESTAMP}"
@@ -248,6 +271,15 @@ if [ -n "${UPLOAD_URL_BASE:-}" ]; then
       echo "[UPLOAD_FAILED] Failed to upload AWS SOS report: $(basename "${AWS_SOS_REPORT}")" >&2
     fi
   fi
+
+  for LOCAL_EVENTS_FILE in ${LOCAL_EVENTS}; do
+    if curl -fsS -X PUT --upload-file "${LOCAL_EVENTS_FILE}" \
+      "${UPLOAD_URL_BASE}/${NODE_NAME}/${TIMESTAMP}/$(basename "${LOCAL_EVENTS_FILE}")"; then
+      echo "[UPLOAD_SUCCESS] Syslog health monitor local events uploaded: $(basename "${LOCAL_EVENTS_FILE}")"
+    else
+      echo "[UPLOAD_FAILED] Failed to upload syslog health monitor local events: $(basename "${LOCAL_EVENTS_FILE}")" >&2
+    fi
+  done
 fi
 
 echo "[INFO] Done. Artifacts under ${ARTIFACTS_DIR}"
\ No newline at end of file
@@ -23,6 +23,7 @@ import (
 	"os/signal"
 	"syscall"
 
+	"github.com/nvidia/nvsentinel/commons/pkg/cloudmetadata"
 	"github.com/nvidia/nvsentinel/commons/pkg/logger"
 	"github.com/nvidia/nvsentinel/metadata-collector/pkg/collector"
 	"github.com/nvidia/nvsentinel/metadata-collector/pkg/nvml"
@@ -40,6 +41,11 @@ var (
 	date    = "unknown"
 
 	outputPath = flag.String("output-path", defaultOutputPath, "Path to write the GPU metadata JSON file")
+
+	cloudMetadata = flag.Bool("cloud-metadata", true,
+		"Look up the cloud instance ID, type and zone from the provider instance metadata service")
+	cloudMetadataTimeout = flag.Duration("cloud-metadata-timeout", cloudmetadata.DefaultTimeout,
+		"Timeout of the cloud instance metadata lookup")
 )
 
 func main() {
@@ -76,7 +82,12 @@ func run(ctx context.Context) error {
 
 	slog.Info("Collecting GPU metadata")
 
-	metadataCollector := collector.NewCollector(nvmlWrapper)
+	var cloudFetcher collector.CloudMetadataFetcher
+	if *cloudMetadata {
+		cloudFetcher = cloudmetadata.NewClient(*cloudMetadataTimeout)
+	}
+
+	metadataCollector := collector.NewCollector(nvmlWrapper, cloudFetcher)
 
 	metadata, err := metadataCollector.Collect(ctx)
 	if err != nil {
@@ -22,17 +22,27 @@ import (
 	"time"
 
 	gonvml "github.com/NVIDIA/go-nvml/pkg/nvml"
+	"github.com/nvidia/nvsentinel/commons/pkg/cloudmetadata"
 	"github.com/nvidia/nvsentinel/data-models/pkg/model"
 	"github.com/nvidia/nvsentinel/metadata-collector/pkg/nvml"
 )
 
+// CloudMetadataFetcher looks up the cloud instance of the node.
+type CloudMetadataFetcher interface {
+	Fetch(ctx context.Context) (*cloudmetadata.Instance, error)
+}
+
 type Collector struct {
-	nvml *nvml.NVMLWrapper
+	nvml  *nvml.NVMLWrapper
+	cloud CloudMetadataFetcher
 }
 
-func NewCollector(nvmlWrapper *nvml.NVMLWrapper) *Collector {
+// NewCollector creates a collector. The cloud instance is not collected if
+// cloud is nil.
+func NewCollector(nvmlWrapper *nvml.NVMLWrapper, cloud CloudMetadataFetcher) *Collector {
 	return &Collector{
-		nvml: nvmlWrapper,
+		nvml:  nvmlWrapper,
+		cloud: cloud,
 	}
 }
 
@@ -63,9 +73,39 @@ func (c *Collector) Collect(ctx context.Context) (*model.GPUMetadata, error) {
 		return nil, fmt.Errorf("failed to collect GPU data: %w", err)
 	}
 
+	metadata.Cloud = c.collectCloudInstance(ctx)
+
 	return metadata, nil
 }
 
+// collectCloudInstance returns the cloud instance of the node. A failed lookup
+// is not fatal since nodes may run off-cloud.
+func (c *Collector) collectCloudInstance(ctx context.Context) *model.CloudInstance {
+	if c.cloud == nil {
+		return nil
+	}
+
+	instance, err := c.cloud.Fetch(ctx)
+	if err != nil {
+		slog.Warn("Failed to get cloud instance metadata, continuing without it", "error", err)
+		return nil
+	}
+
+	slog.Info("Collected cloud instance metadata",
+		"provider", instance.Provider,
+		"instanceID", instance.InstanceID,
+		"instanceType", instance.InstanceType,
+		"zone", instance.Zone)
+
+	return &model.CloudInstance{
+		Provider:              string(instance.Provider),
+		InstanceID:            instance.InstanceID,
+		InstanceType:          instance.InstanceType,
+		Zone:                  instance.Zone,
+		CapacityReservationID: instance.CapacityReservationID,
+	}
+}
+
 func (c *Collector) prepareTopologyData(
 	ctx context.Context,
 ) (map[string]gonvml.Device, map[int]nvml.GPUNVLinkTopology, error) {
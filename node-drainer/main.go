@@ -24,8 +24,10 @@ import (
 	"strconv"
 	"syscall"
 
+	"github.com/nvidia/nvsentinel/commons/pkg/configmanager"
 	"github.com/nvidia/nvsentinel/commons/pkg/logger"
 	"github.com/nvidia/nvsentinel/commons/pkg/server"
+	"github.com/nvidia/nvsentinel/node-drainer/pkg/config"
 	"github.com/nvidia/nvsentinel/node-drainer/pkg/initializer"
 	"golang.org/x/sync/errgroup"
 )
@@ -106,12 +108,24 @@ func run() error {
 		return fmt.Errorf("invalid metrics port: %w", err)
 	}
 
-	// Create the server
-	srv := server.NewServer(
+	serverOpts := []server.Option{
 		server.WithPort(portInt),
 		server.WithPrometheusMetrics(),
+		server.WithHandler(logger.LevelsPath, logger.LevelsHandler()),
 		server.WithSimpleHealth(),
-	)
+		server.WithHandler(configmanager.ConfigPath, configmanager.Handler("node-drainer", components.Config,
+			func(data []byte) (*config.TomlConfig, error) {
+				return config.LoadTomlConfigFromString(string(data))
+			})),
+	}
+
+	// Drains queued by the node pool budgets
+	if components.DrainBudget != nil {
+		serverOpts = append(serverOpts, server.WithHandler("/drain-queue", components.DrainBudget))
+	}
+
+	// Create the server
+	srv := server.NewServer(serverOpts...)
 
 	// Start server in errgroup alongside event watcher monitoring
 	g, gCtx := errgroup.WithContext(ctx)
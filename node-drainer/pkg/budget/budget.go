@@ -0,0 +1,249 @@
+// Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package budget enforces per node pool drain budgets: a drain may only start
+// while the pool keeps at least the configured number of schedulable GPU
+// nodes. Drains that would go below are queued until capacity recovers, e.g.
+// a remediated node is uncordoned or a new node joins the pool.
+package budget
+
+import (
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/nvidia/nvsentinel/node-drainer/pkg/config"
+	"github.com/nvidia/nvsentinel/node-drainer/pkg/metrics"
+
+	v1 "k8s.io/api/core/v1"
+)
+
+// GPUResourceName is the extended resource GPU nodes advertise.
+const GPUResourceName v1.ResourceName = "nvidia.com/gpu"
+
+// NodeLister lists the nodes of the cluster, usually from an informer cache.
+type NodeLister interface {
+	ListNodes() ([]*v1.Node, error)
+}
+
+// QueuedDrain is a drain waiting for capacity in its node pool.
+type QueuedDrain struct {
+	Node                string    `json:"node"`
+	Pool                string    `json:"pool"`
+	QueuedAt            time.Time `json:"queuedAt"`
+	SchedulableNodes    int       `json:"schedulableNodes"`
+	MinSchedulableNodes int       `json:"minSchedulableNodes"`
+}
+
+// Tracker admits drains within the node pool budgets and tracks the queue of
+// drains that are held back. Admitted drains keep their admission until they
+// are released, so a drain that started is never paused halfway.
+type Tracker struct {
+	poolLabel string
+	budgets   map[string]int
+	nodes     NodeLister
+
+	mu       sync.Mutex
+	admitted map[string]struct{}
+	queued   map[string]*QueuedDrain
+}
+
+// NewTracker creates a Tracker for the budgets of the pools named by the
+// poolLabel node label.
+func NewTracker(poolLabel string, budgets []config.NodePoolBudget, nodes NodeLister) *Tracker {
+	t := &Tracker{
+		poolLabel: poolLabel,
+		budgets:   make(map[string]int, len(budgets)),
+		nodes:     nodes,
+		admitted:  make(map[string]struct{}),
+		queued:    make(map[string]*QueuedDrain),
+	}
+
+	for _, budget := range budgets {
+		t.budgets[budget.Pool] = budget.MinSchedulableNodes
+	}
+
+	return t
+}
+
+// Admit reports whether the drain of the node may start. Nodes outside of a
+// budgeted pool are always admitted and not tracked.
+func (t *Tracker) Admit(nodeName string) (bool, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if _, ok := t.admitted[nodeName]; ok {
+		return true, nil
+	}
+
+	nodes, err := t.nodes.ListNodes()
+	if err != nil {
+		return false, fmt.Errorf("failed to list nodes: %w", err)
+	}
+
+	pool, found := "", false
+
+	for _, node := range nodes {
+		if node.Name == nodeName {
+			pool, found = node.Labels[t.poolLabel], true
+			break
+		}
+	}
+
+	if !found {
+		return false, fmt.Errorf("node %s not found in cache", nodeName)
+	}
+
+	minSchedulable, budgeted := t.budgets[pool]
+	if !budgeted {
+		return true, nil
+	}
+
+	// The node is usually cordoned by quarantine already, admitted drains are
+	// excluded in case quarantine only tainted them
+	schedulable := 0
+
+	for _, node := range nodes {
+		if node.Name == nodeName || node.Labels[t.poolLabel] != pool {
+			continue
+		}
+
+		if _, draining := t.admitted[node.Name]; draining {
+			continue
+		}
+
+		if isSchedulableGPUNode(node) {
+			schedulable++
+		}
+	}
+
+	if schedulable < minSchedulable {
+		queued, ok := t.queued[nodeName]
+		if !ok {
+			queued = &QueuedDrain{Node: nodeName, Pool: pool, QueuedAt: time.Now().UTC()}
+			t.queued[nodeName] = queued
+
+			slog.Info("Queuing drain of node until its pool has capacity",
+				"node", nodeName,
+				"pool", pool,
+				"schedulableNodes", schedulable,
+				"minSchedulableNodes", minSchedulable)
+		}
+
+		queued.SchedulableNodes = schedulable
+		queued.MinSchedulableNodes = minSchedulable
+		t.updateQueueMetric()
+
+		return false, nil
+	}
+
+	if queued, ok := t.queued[nodeName]; ok {
+		slog.Info("Admitting queued drain of node", "node", nodeName, "pool", pool,
+			"queuedFor", time.Since(queued.QueuedAt).Round(time.Second))
+		delete(t.queued, nodeName)
+		t.updateQueueMetric()
+	}
+
+	t.admitted[nodeName] = struct{}{}
+
+	return true, nil
+}
+
+// Release ends the admission or queuing of the drain of the node, once it
+// completed or was cancelled.
+func (t *Tracker) Release(nodeName string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	delete(t.admitted, nodeName)
+
+	if _, ok := t.queued[nodeName]; ok {
+		delete(t.queued, nodeName)
+		t.updateQueueMetric()
+	}
+}
+
+// Queue returns the queued drains, oldest first.
+func (t *Tracker) Queue() []QueuedDrain {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	queue := make([]QueuedDrain, 0, len(t.queued))
+	for _, queued := range t.queued {
+		queue = append(queue, *queued)
+	}
+
+	sort.Slice(queue, func(i, j int) bool {
+		if queue[i].QueuedAt.Equal(queue[j].QueuedAt) {
+			return queue[i].Node < queue[j].Node
+		}
+
+		return queue[i].QueuedAt.Before(queue[j].QueuedAt)
+	})
+
+	return queue
+}
+
+// ServeHTTP returns the queued drains as JSON.
+func (t *Tracker) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+
+	if err := json.NewEncoder(w).Encode(map[string]any{"queued": t.Queue()}); err != nil {
+		slog.Error("Failed to encode drain queue", "error", err)
+	}
+}
+
+func (t *Tracker) updateQueueMetric() {
+	perPool := make(map[string]int, len(t.budgets))
+	for pool := range t.budgets {
+		perPool[pool] = 0
+	}
+
+	for _, queued := range t.queued {
+		perPool[queued.Pool]++
+	}
+
+	for pool, count := range perPool {
+		metrics.QueuedDrains.WithLabelValues(pool).Set(float64(count))
+	}
+}
+
+// isSchedulableGPUNode reports whether new GPU workloads can land on the node.
+func isSchedulableGPUNode(node *v1.Node) bool {
+	if node.Spec.Unschedulable {
+		return false
+	}
+
+	gpus, ok := node.Status.Allocatable[GPUResourceName]
+	if !ok || gpus.IsZero() {
+		return false
+	}
+
+	for _, condition := range node.Status.Conditions {
+		if condition.Type == v1.NodeReady {
+			return condition.Status == v1.ConditionTrue
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,173 @@
+// Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package budget
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/nvidia/nvsentinel/node-drainer/pkg/config"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const poolLabel = "nvidia.com/node-pool"
+
+type fakeLister struct {
+	nodes []*v1.Node
+}
+
+func (f *fakeLister) ListNodes() ([]*v1.Node, error) {
+	return f.nodes, nil
+}
+
+func (f *fakeLister) node(name string) *v1.Node {
+	for _, node := range f.nodes {
+		if node.Name == name {
+			return node
+		}
+	}
+
+	return nil
+}
+
+func gpuNode(name, pool string, cordoned bool) *v1.Node {
+	return &v1.Node{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Labels: map[string]string{poolLabel: pool}},
+		Spec:       v1.NodeSpec{Unschedulable: cordoned},
+		Status: v1.NodeStatus{
+			Allocatable: v1.ResourceList{GPUResourceName: resource.MustParse("8")},
+			Conditions:  []v1.NodeCondition{{Type: v1.NodeReady, Status: v1.ConditionTrue}},
+		},
+	}
+}
+
+func newTestTracker(lister *fakeLister) *Tracker {
+	return NewTracker(poolLabel, []config.NodePoolBudget{{Pool: "training", MinSchedulableNodes: 2}}, lister)
+}
+
+func TestAdmitWithinBudget(t *testing.T) {
+	lister := &fakeLister{nodes: []*v1.Node{
+		gpuNode("a", "training", true),
+		gpuNode("b", "training", false),
+		gpuNode("c", "training", false),
+	}}
+	tracker := newTestTracker(lister)
+
+	admitted, err := tracker.Admit("a")
+	require.NoError(t, err)
+	assert.True(t, admitted)
+	assert.Empty(t, tracker.Queue())
+}
+
+func TestAdmitQueuesUntilCapacity(t *testing.T) {
+	lister := &fakeLister{nodes: []*v1.Node{
+		gpuNode("a", "training", true),
+		gpuNode("b", "training", true),
+		gpuNode("c", "training", false),
+		gpuNode("d", "training", false),
+	}}
+	tracker := newTestTracker(lister)
+
+	admitted, err := tracker.Admit("a")
+	require.NoError(t, err)
+	assert.True(t, admitted)
+
+	// c is cordoned as well, only d is left schedulable
+	lister.node("c").Spec.Unschedulable = true
+
+	admitted, err = tracker.Admit("b")
+	require.NoError(t, err)
+	assert.False(t, admitted)
+
+	queue := tracker.Queue()
+	require.Len(t, queue, 1)
+	assert.Equal(t, "b", queue[0].Node)
+	assert.Equal(t, "training", queue[0].Pool)
+	assert.Equal(t, 1, queue[0].SchedulableNodes)
+	assert.Equal(t, 2, queue[0].MinSchedulableNodes)
+
+	// An admitted drain is not paused when capacity drops further
+	admitted, err = tracker.Admit("a")
+	require.NoError(t, err)
+	assert.True(t, admitted)
+
+	// a was remediated and uncordoned
+	tracker.Release("a")
+	lister.node("a").Spec.Unschedulable = false
+
+	admitted, err = tracker.Admit("b")
+	require.NoError(t, err)
+	assert.True(t, admitted)
+	assert.Empty(t, tracker.Queue())
+}
+
+func TestAdmitIgnoresUnschedulableAndOtherPools(t *testing.T) {
+	notReady := gpuNode("not-ready", "training", false)
+	notReady.Status.Conditions[0].Status = v1.ConditionFalse
+
+	noGPU := gpuNode("no-gpu", "training", false)
+	noGPU.Status.Allocatable = v1.ResourceList{}
+
+	lister := &fakeLister{nodes: []*v1.Node{
+		gpuNode("a", "training", true),
+		gpuNode("b", "training", false),
+		notReady,
+		noGPU,
+		gpuNode("other", "inference", false),
+	}}
+	tracker := newTestTracker(lister)
+
+	admitted, err := tracker.Admit("a")
+	require.NoError(t, err)
+	assert.False(t, admitted)
+
+	// Pools without a budget are not limited
+	admitted, err = tracker.Admit("other")
+	require.NoError(t, err)
+	assert.True(t, admitted)
+
+	_, err = tracker.Admit("missing")
+	assert.Error(t, err)
+}
+
+func TestServeHTTP(t *testing.T) {
+	lister := &fakeLister{nodes: []*v1.Node{gpuNode("a", "training", true)}}
+	tracker := newTestTracker(lister)
+
+	_, err := tracker.Admit("a")
+	require.NoError(t, err)
+
+	rec := httptest.NewRecorder()
+	tracker.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/drain-queue", nil))
+	require.Equal(t, http.StatusOK, rec.Code)
+
+	var body struct {
+		Queued []QueuedDrain `json:"queued"`
+	}
+
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+	require.Len(t, body.Queued, 1)
+	assert.Equal(t, "a", body.Queued[0].Node)
+
+	tracker.Release("a")
+	assert.Empty(t, tracker.Queue())
+}
@@ -0,0 +1,170 @@
+// Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package chaos wraps the transport of the Kubernetes client of the node drainer
+// with injectable failure points, so drains can be exercised against blocked
+// evictions and pods that cannot be deleted.
+//
+// Faults are configured in the commons chaos format, for example
+// "evict=pdb:3,delete=error". Supported operations are evict (pod evictions) and
+// delete (pod deletions, e.g. the force deletion after the drain timeout).
+// Supported modes are:
+//
+//   - timeout: the request fails with context.DeadlineExceeded, as against an
+//     unresponsive API server
+//   - error: the API server answers with an internal error
+//   - pdb: the eviction is rejected with 429 Too Many Requests, as when a
+//     PodDisruptionBudget blocks it (evict only)
+package chaos
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/rest"
+
+	commonchaos "github.com/nvidia/nvsentinel/commons/pkg/chaos"
+)
+
+// EnvVar is the environment variable holding the fault specification.
+const EnvVar = commonchaos.EnvVar
+
+type (
+	// Operation identifies a Kubernetes request that faults can be injected into.
+	Operation = commonchaos.Operation
+	// Mode is the kind of failure injected into an operation.
+	Mode = commonchaos.Mode
+	// Fault describes the failure injected into a single operation.
+	Fault = commonchaos.Fault
+)
+
+const (
+	OpEvict  Operation = "evict"
+	OpDelete Operation = "delete"
+)
+
+const (
+	ModeTimeout      = commonchaos.ModeTimeout
+	ModeError        = commonchaos.ModeError
+	ModePDB     Mode = "pdb"
+)
+
+// operations are the Kubernetes requests and the modes they support besides
+// timeout and error.
+var operations = commonchaos.Operations{
+	OpEvict:  {ModePDB},
+	OpDelete: nil,
+}
+
+// Transport is an HTTP transport that injects faults before delegating to the
+// wrapped transport.
+type Transport struct {
+	next     http.RoundTripper
+	injector *commonchaos.Injector
+}
+
+// NewTransport wraps next with the given faults.
+func NewTransport(next http.RoundTripper, faults map[Operation]Fault) *Transport {
+	return &Transport{
+		next:     next,
+		injector: commonchaos.NewInjector(faults),
+	}
+}
+
+// WrapFromEnv wraps the transport of config with the faults configured in
+// EnvVar and reports whether faults are injected. The config is left unchanged
+// when the variable is empty.
+func WrapFromEnv(config *rest.Config) (bool, error) {
+	faults, err := commonchaos.FaultsFromEnv(operations)
+	if err != nil || faults == nil {
+		return false, err
+	}
+
+	config.Wrap(func(next http.RoundTripper) http.RoundTripper {
+		return NewTransport(next, faults)
+	})
+
+	return true, nil
+}
+
+// operation returns the operation of a pod eviction or deletion request.
+func operation(req *http.Request) (Operation, bool) {
+	parts := strings.Split(strings.Trim(req.URL.Path, "/"), "/")
+	if len(parts) < 3 {
+		return "", false
+	}
+
+	switch {
+	case req.Method == http.MethodPost && parts[len(parts)-1] == "eviction" && parts[len(parts)-3] == "pods":
+		return OpEvict, true
+	case req.Method == http.MethodDelete && parts[len(parts)-2] == "pods":
+		return OpDelete, true
+	default:
+		return "", false
+	}
+}
+
+// RoundTrip fails pod evictions and deletions with the configured fault or
+// delegates.
+func (t *Transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	op, ok := operation(req)
+	if !ok {
+		return t.next.RoundTrip(req)
+	}
+
+	mode, ok := t.injector.Inject(op)
+	if !ok {
+		return t.next.RoundTrip(req)
+	}
+
+	switch mode {
+	case ModeTimeout:
+		return nil, fmt.Errorf("chaos: %s: %w", op, context.DeadlineExceeded)
+	case ModePDB:
+		return statusResponse(req, http.StatusTooManyRequests, metav1.StatusReasonTooManyRequests,
+			"chaos: cannot evict pod as it would violate the pod's disruption budget")
+	default:
+		return statusResponse(req, http.StatusInternalServerError, metav1.StatusReasonInternalError,
+			fmt.Sprintf("chaos: injected %s failure", op))
+	}
+}
+
+// statusResponse builds the Status response the API server returns for a
+// failed request, so the client reports it as the matching StatusError.
+func statusResponse(req *http.Request, code int, reason metav1.StatusReason, message string) (*http.Response, error) {
+	body, err := json.Marshal(metav1.Status{
+		TypeMeta: metav1.TypeMeta{Kind: "Status", APIVersion: "v1"},
+		Status:   metav1.StatusFailure,
+		Message:  message,
+		Reason:   reason,
+		Code:     int32(code),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &http.Response{
+		StatusCode: code,
+		Status:     fmt.Sprintf("%d %s", code, http.StatusText(code)),
+		Header:     http.Header{"Content-Type": []string{"application/json"}},
+		Body:       io.NopCloser(bytes.NewReader(body)),
+		Request:    req,
+	}, nil
+}
@@ -0,0 +1,145 @@
+// Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package chaos
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	policyv1 "k8s.io/api/policy/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+
+	commonchaos "github.com/nvidia/nvsentinel/commons/pkg/chaos"
+)
+
+// newClient returns a clientset talking to an API server stub that accepts
+// every request, with the given faults injected.
+func newClient(t *testing.T, faults map[Operation]Fault) kubernetes.Interface {
+	t.Helper()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"kind":"Status","apiVersion":"v1","status":"Success"}`))
+	}))
+	t.Cleanup(server.Close)
+
+	config := &rest.Config{Host: server.URL}
+	config.Wrap(func(next http.RoundTripper) http.RoundTripper { return NewTransport(next, faults) })
+
+	client, err := kubernetes.NewForConfig(config)
+	require.NoError(t, err)
+
+	return client
+}
+
+func evict(client kubernetes.Interface) error {
+	return client.PolicyV1().Evictions("ns").Evict(context.Background(), &policyv1.Eviction{
+		ObjectMeta: metav1.ObjectMeta{Name: "pod", Namespace: "ns"},
+	})
+}
+
+func TestParseFaults(t *testing.T) {
+	tests := []struct {
+		name     string
+		spec     string
+		expected map[Operation]Fault
+		wantErr  bool
+	}{
+		{
+			name: "multiple faults",
+			spec: "evict=pdb:2, delete=ERROR",
+			expected: map[Operation]Fault{
+				OpEvict:  {Mode: ModePDB, Count: 2},
+				OpDelete: {Mode: ModeError},
+			},
+		},
+		{name: "empty spec", spec: "", expected: map[Operation]Fault{}},
+		{name: "missing mode", spec: "evict", wantErr: true},
+		{name: "unknown operation", spec: "cordon=error", wantErr: true},
+		{name: "unknown mode", spec: "evict=explode", wantErr: true},
+		{name: "pdb only for evict", spec: "delete=pdb", wantErr: true},
+		{name: "negative count", spec: "delete=error:-1", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			faults, err := commonchaos.ParseFaults(tt.spec, operations)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, faults)
+		})
+	}
+}
+
+func TestTransport_BlockedEvictionRecovers(t *testing.T) {
+	client := newClient(t, map[Operation]Fault{OpEvict: {Mode: ModePDB, Count: 2}})
+
+	for range 2 {
+		assert.True(t, errors.IsTooManyRequests(evict(client)))
+	}
+
+	assert.NoError(t, evict(client))
+}
+
+func TestTransport_FailedDeletion(t *testing.T) {
+	client := newClient(t, map[Operation]Fault{OpDelete: {Mode: ModeError}})
+
+	err := client.CoreV1().Pods("ns").Delete(context.Background(), "pod", metav1.DeleteOptions{})
+	assert.True(t, errors.IsInternalError(err))
+
+	// Other requests are not affected
+	assert.NoError(t, evict(client))
+	assert.NoError(t, client.CoreV1().Pods("ns").DeleteCollection(context.Background(),
+		metav1.DeleteOptions{}, metav1.ListOptions{}))
+}
+
+func TestTransport_TimeoutIsDeadlineExceeded(t *testing.T) {
+	client := newClient(t, map[Operation]Fault{OpEvict: {Mode: ModeTimeout}})
+
+	assert.ErrorIs(t, evict(client), context.DeadlineExceeded)
+}
+
+func TestWrapFromEnv(t *testing.T) {
+	t.Setenv(EnvVar, "")
+
+	config := &rest.Config{}
+	enabled, err := WrapFromEnv(config)
+	require.NoError(t, err)
+	assert.False(t, enabled)
+	assert.Nil(t, config.WrapTransport)
+
+	t.Setenv(EnvVar, "delete=timeout")
+
+	enabled, err = WrapFromEnv(config)
+	require.NoError(t, err)
+	assert.True(t, enabled)
+	assert.NotNil(t, config.WrapTransport)
+
+	t.Setenv(EnvVar, "delete=bogus")
+
+	_, err = WrapFromEnv(config)
+	assert.Error(t, err)
+}
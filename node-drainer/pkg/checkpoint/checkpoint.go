@@ -0,0 +1,209 @@
+// Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package checkpoint asks workload controllers, e.g. training operators, to
+// checkpoint the pods of a node before it is drained. The hook is polled on
+// every evaluation of the drain until it acknowledges or the deadline passes,
+// the drain proceeds either way.
+package checkpoint
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/nvidia/nvsentinel/data-models/pkg/protos"
+	"github.com/nvidia/nvsentinel/node-drainer/pkg/config"
+	"github.com/nvidia/nvsentinel/node-drainer/pkg/metrics"
+
+	v1 "k8s.io/api/core/v1"
+)
+
+const (
+	resultAcknowledged     = "acknowledged"
+	resultDeadlineExceeded = "deadline_exceeded"
+)
+
+// Pod identifies a pod about to be evicted.
+type Pod struct {
+	Namespace string `json:"namespace"`
+	Name      string `json:"name"`
+}
+
+// Request is the body POSTed to the hook.
+type Request struct {
+	Node      string    `json:"node"`
+	CheckName string    `json:"checkName"`
+	Message   string    `json:"message"`
+	Force     bool      `json:"force"`
+	Deadline  time.Time `json:"deadline"`
+	Pods      []Pod     `json:"pods"`
+}
+
+// Response is the body returned by the hook. Ready acknowledges that the pods
+// are checkpointed and the drain may proceed.
+type Response struct {
+	Ready bool `json:"ready"`
+}
+
+// Coordinator tracks the checkpoint wait of every node being drained.
+type Coordinator struct {
+	url         string
+	ackDeadline time.Duration
+	client      *http.Client
+
+	mu sync.Mutex
+	// requested holds when the hook was first called for a node
+	requested map[string]time.Time
+	// done holds the nodes whose wait ended
+	done map[string]struct{}
+}
+
+// NewCoordinator creates a Coordinator for the hook.
+func NewCoordinator(hook config.CheckpointHook) *Coordinator {
+	return &Coordinator{
+		url:         hook.URL,
+		ackDeadline: time.Duration(hook.AckDeadlineSeconds) * time.Second,
+		client:      &http.Client{Timeout: time.Duration(hook.RequestTimeoutSeconds) * time.Second},
+		requested:   make(map[string]time.Time),
+		done:        make(map[string]struct{}),
+	}
+}
+
+// Ready reports whether the drain of the node may evict the pods. It calls
+// the hook until it acknowledges, and gives up once the deadline passed.
+func (c *Coordinator) Ready(ctx context.Context, healthEvent *protos.HealthEvent, pods []*v1.Pod) bool {
+	nodeName := healthEvent.NodeName
+
+	c.mu.Lock()
+
+	if _, ok := c.done[nodeName]; ok || len(pods) == 0 {
+		c.mu.Unlock()
+		return true
+	}
+
+	requestedAt, ok := c.requested[nodeName]
+	if !ok {
+		requestedAt = time.Now()
+		c.requested[nodeName] = requestedAt
+
+		slog.Info("Requesting workload checkpoint before drain", "node", nodeName, "pods", len(pods))
+	}
+
+	deadline := requestedAt.Add(c.ackDeadline)
+	if time.Now().After(deadline) {
+		slog.Warn("Checkpoint hook did not acknowledge before deadline, proceeding with drain",
+			"node", nodeName,
+			"deadline", c.ackDeadline)
+		c.finish(nodeName, resultDeadlineExceeded)
+		c.mu.Unlock()
+
+		return true
+	}
+
+	// The hook is called without the lock, other nodes are not held up by it
+	c.mu.Unlock()
+
+	ready, err := c.call(ctx, newRequest(healthEvent, deadline, pods))
+	if err != nil {
+		metrics.CheckpointHookErrors.Inc()
+		slog.Error("Failed to call checkpoint hook", "node", nodeName, "error", err)
+
+		return false
+	}
+
+	if !ready {
+		return false
+	}
+
+	slog.Info("Checkpoint hook acknowledged, proceeding with drain",
+		"node", nodeName,
+		"waited", time.Since(requestedAt).Round(time.Second))
+
+	c.mu.Lock()
+	c.finish(nodeName, resultAcknowledged)
+	c.mu.Unlock()
+
+	return true
+}
+
+// Release forgets the wait of the node, once its drain completed or was
+// cancelled.
+func (c *Coordinator) Release(nodeName string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	delete(c.requested, nodeName)
+	delete(c.done, nodeName)
+}
+
+func (c *Coordinator) finish(nodeName, result string) {
+	delete(c.requested, nodeName)
+	c.done[nodeName] = struct{}{}
+	metrics.CheckpointHookResults.WithLabelValues(result).Inc()
+}
+
+func (c *Coordinator) call(ctx context.Context, request Request) (bool, error) {
+	body, err := json.Marshal(request)
+	if err != nil {
+		return false, fmt.Errorf("failed to marshal checkpoint request: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.url, bytes.NewReader(body))
+	if err != nil {
+		return false, fmt.Errorf("failed to create checkpoint request: %w", err)
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return false, fmt.Errorf("checkpoint request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return false, fmt.Errorf("checkpoint hook returned status %d", resp.StatusCode)
+	}
+
+	var response Response
+	if err := json.NewDecoder(io.LimitReader(resp.Body, 1<<20)).Decode(&response); err != nil {
+		return false, fmt.Errorf("failed to decode checkpoint response: %w", err)
+	}
+
+	return response.Ready, nil
+}
+
+func newRequest(healthEvent *protos.HealthEvent, deadline time.Time, pods []*v1.Pod) Request {
+	request := Request{
+		Node:      healthEvent.NodeName,
+		CheckName: healthEvent.CheckName,
+		Message:   healthEvent.Message,
+		Force:     healthEvent.DrainOverrides != nil && healthEvent.DrainOverrides.Force,
+		Deadline:  deadline.UTC(),
+		Pods:      make([]Pod, 0, len(pods)),
+	}
+
+	for _, pod := range pods {
+		request.Pods = append(request.Pods, Pod{Namespace: pod.Namespace, Name: pod.Name})
+	}
+
+	return request
+}
@@ -0,0 +1,131 @@
+// Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package checkpoint
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/nvidia/nvsentinel/data-models/pkg/protos"
+	"github.com/nvidia/nvsentinel/node-drainer/pkg/config"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+type fakeHook struct {
+	mu       sync.Mutex
+	ready    bool
+	status   int
+	requests []Request
+}
+
+func (f *fakeHook) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	var request Request
+	if err := json.NewDecoder(r.Body).Decode(&request); err == nil {
+		f.requests = append(f.requests, request)
+	}
+
+	if f.status != 0 {
+		w.WriteHeader(f.status)
+		return
+	}
+
+	_ = json.NewEncoder(w).Encode(Response{Ready: f.ready})
+}
+
+func (f *fakeHook) setReady(ready bool) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.ready = ready
+}
+
+func newTestCoordinator(t *testing.T, hook *fakeHook, ackDeadlineSeconds int) *Coordinator {
+	server := httptest.NewServer(hook)
+	t.Cleanup(server.Close)
+
+	return NewCoordinator(config.CheckpointHook{
+		URL:                   server.URL,
+		AckDeadlineSeconds:    ackDeadlineSeconds,
+		RequestTimeoutSeconds: 1,
+	})
+}
+
+func testPods() []*v1.Pod {
+	return []*v1.Pod{{ObjectMeta: metav1.ObjectMeta{Namespace: "training", Name: "trainer-0"}}}
+}
+
+func testEvent() *protos.HealthEvent {
+	return &protos.HealthEvent{NodeName: "node-1", CheckName: "GpuXidError", Message: "XID 79"}
+}
+
+func TestReadyWaitsForAcknowledgment(t *testing.T) {
+	hook := &fakeHook{}
+	coordinator := newTestCoordinator(t, hook, 300)
+
+	assert.False(t, coordinator.Ready(context.Background(), testEvent(), testPods()))
+
+	hook.setReady(true)
+	assert.True(t, coordinator.Ready(context.Background(), testEvent(), testPods()))
+
+	// The acknowledgment is kept, the hook is not called again
+	assert.True(t, coordinator.Ready(context.Background(), testEvent(), testPods()))
+	require.Len(t, hook.requests, 2)
+
+	request := hook.requests[0]
+	assert.Equal(t, "node-1", request.Node)
+	assert.Equal(t, "GpuXidError", request.CheckName)
+	assert.Equal(t, []Pod{{Namespace: "training", Name: "trainer-0"}}, request.Pods)
+	assert.WithinDuration(t, time.Now().Add(300*time.Second), request.Deadline, 5*time.Second)
+	assert.Equal(t, request.Deadline, hook.requests[1].Deadline)
+
+	// A new drain of the node asks again
+	coordinator.Release("node-1")
+	hook.setReady(false)
+	assert.False(t, coordinator.Ready(context.Background(), testEvent(), testPods()))
+}
+
+func TestReadyAfterDeadline(t *testing.T) {
+	hook := &fakeHook{status: http.StatusServiceUnavailable}
+	coordinator := newTestCoordinator(t, hook, 300)
+
+	assert.False(t, coordinator.Ready(context.Background(), testEvent(), testPods()))
+
+	coordinator.mu.Lock()
+	coordinator.requested["node-1"] = time.Now().Add(-301 * time.Second)
+	coordinator.mu.Unlock()
+
+	assert.True(t, coordinator.Ready(context.Background(), testEvent(), testPods()))
+	assert.Len(t, hook.requests, 1)
+}
+
+func TestReadyWithoutPods(t *testing.T) {
+	hook := &fakeHook{}
+	coordinator := newTestCoordinator(t, hook, 300)
+
+	assert.True(t, coordinator.Ready(context.Background(), testEvent(), nil))
+	assert.Empty(t, hook.requests)
+}
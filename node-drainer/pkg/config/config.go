@@ -44,6 +44,26 @@ type UserNamespace struct {
 	Mode EvictMode `toml:"mode"`
 }
 
+// NodePoolBudget keeps at least MinSchedulableNodes schedulable GPU nodes in
+// the node pool Pool. Drains that would go below are queued until capacity
+// recovers.
+type NodePoolBudget struct {
+	Pool                string `toml:"pool"`
+	MinSchedulableNodes int    `toml:"minSchedulableNodes"`
+}
+
+// CheckpointHook notifies workload controllers before a drain so that they can
+// checkpoint. The drain waits until the hook acknowledges or the deadline
+// passes.
+type CheckpointHook struct {
+	// URL receives a POST with the node and the pods about to be evicted
+	URL string `toml:"url"`
+	// AckDeadlineSeconds is how long the drain waits for the acknowledgment
+	AckDeadlineSeconds int `toml:"ackDeadlineSeconds"`
+	// RequestTimeoutSeconds is the timeout of a single request to URL
+	RequestTimeoutSeconds int `toml:"requestTimeoutSeconds"`
+}
+
 type TomlConfig struct {
 	EvictionTimeoutInSeconds  Duration `toml:"evictionTimeoutInSeconds"`
 	SystemNamespaces          string   `toml:"systemNamespaces"`
@@ -51,6 +71,14 @@ type TomlConfig struct {
 	// NotReadyTimeoutMinutes is the time after which a pod in NotReady state is considered stuck
 	NotReadyTimeoutMinutes int             `toml:"notReadyTimeoutMinutes"`
 	UserNamespaces         []UserNamespace `toml:"userNamespaces"`
+	// NodePoolLabel is the node label whose value names the node pool of a node
+	NodePoolLabel   string           `toml:"nodePoolLabel"`
+	NodePoolBudgets []NodePoolBudget `toml:"nodePoolBudgets"`
+	// PublishImpactEvents publishes an event on the owner of each evicted pod
+	// naming the hardware fault
+	PublishImpactEvents bool `toml:"publishImpactEvents"`
+	// CheckpointHook is nil if no pre-drain checkpoint hook is configured
+	CheckpointHook *CheckpointHook `toml:"checkpointHook"`
 }
 
 func (d *Duration) UnmarshalTOML(text any) error {
@@ -72,6 +100,11 @@ func (d *Duration) UnmarshalTOML(text any) error {
 	return fmt.Errorf("invalid duration format: %v", text)
 }
 
+// MarshalTOML writes the duration in seconds, as it is read.
+func (d Duration) MarshalTOML() ([]byte, error) {
+	return []byte(strconv.Quote(strconv.Itoa(int(d.Seconds())))), nil
+}
+
 func LoadTomlConfig(path string) (*TomlConfig, error) {
 	var config TomlConfig
 	if _, err := toml.DecodeFile(path, &config); err != nil {
@@ -107,9 +140,72 @@ func validateAndSetDefaults(config *TomlConfig) (*TomlConfig, error) {
 		return nil, fmt.Errorf("notReadyTimeoutMinutes must be a positive integer")
 	}
 
+	if err := validateNodePoolBudgets(config); err != nil {
+		return nil, err
+	}
+
+	if err := validateCheckpointHook(config); err != nil {
+		return nil, err
+	}
+
 	return config, nil
 }
 
+func validateNodePoolBudgets(config *TomlConfig) error {
+	if len(config.NodePoolBudgets) > 0 && config.NodePoolLabel == "" {
+		return fmt.Errorf("nodePoolLabel is required when nodePoolBudgets are configured")
+	}
+
+	pools := make(map[string]struct{}, len(config.NodePoolBudgets))
+
+	for _, budget := range config.NodePoolBudgets {
+		if budget.Pool == "" {
+			return fmt.Errorf("nodePoolBudgets entry without pool")
+		}
+
+		if budget.MinSchedulableNodes < 0 {
+			return fmt.Errorf("minSchedulableNodes of pool %s must not be negative", budget.Pool)
+		}
+
+		if _, exists := pools[budget.Pool]; exists {
+			return fmt.Errorf("duplicate nodePoolBudgets entry for pool %s", budget.Pool)
+		}
+
+		pools[budget.Pool] = struct{}{}
+	}
+
+	return nil
+}
+
+func validateCheckpointHook(config *TomlConfig) error {
+	hook := config.CheckpointHook
+	if hook == nil {
+		return nil
+	}
+
+	if hook.URL == "" {
+		return fmt.Errorf("checkpointHook.url is required when checkpointHook is configured")
+	}
+
+	if hook.AckDeadlineSeconds == 0 {
+		hook.AckDeadlineSeconds = 300 // Default: 5 minutes
+	}
+
+	if hook.AckDeadlineSeconds < 0 {
+		return fmt.Errorf("checkpointHook.ackDeadlineSeconds must be a positive integer")
+	}
+
+	if hook.RequestTimeoutSeconds == 0 {
+		hook.RequestTimeoutSeconds = 10 // Default: 10 seconds
+	}
+
+	if hook.RequestTimeoutSeconds < 0 {
+		return fmt.Errorf("checkpointHook.requestTimeoutSeconds must be a positive integer")
+	}
+
+	return nil
+}
+
 type ReconcilerConfig struct {
 	TomlConfig    TomlConfig
 	MongoConfig   storewatcher.MongoDBConfig
@@ -19,16 +19,37 @@ import (
 	"log/slog"
 	"time"
 
+	"github.com/nvidia/nvsentinel/commons/pkg/donotdisturb"
 	"github.com/nvidia/nvsentinel/data-models/pkg/model"
+	"github.com/nvidia/nvsentinel/data-models/pkg/protos"
 	"github.com/nvidia/nvsentinel/node-drainer/pkg/config"
 	"github.com/nvidia/nvsentinel/node-drainer/pkg/mongodb"
 	"github.com/nvidia/nvsentinel/node-drainer/pkg/queue"
+
+	v1 "k8s.io/api/core/v1"
 )
 
-func NewNodeDrainEvaluator(cfg config.TomlConfig, informers InformersInterface) DrainEvaluator {
+// budgetRequeueDelay is how long a drain queued by the pool budget waits
+// before capacity is checked again
+const budgetRequeueDelay = 30 * time.Second
+
+// checkpointRequeueDelay is how long a drain waits before the checkpoint hook
+// is called again
+const checkpointRequeueDelay = 10 * time.Second
+
+// doNotDisturbRequeueDelay is how long a drain paused by a do not disturb mark
+// without an end waits before the node is checked again
+const doNotDisturbRequeueDelay = time.Minute
+
+// NewNodeDrainEvaluator creates an evaluator. budget and checkpoint may be nil
+// if no node pool budgets or checkpoint hook are configured.
+func NewNodeDrainEvaluator(cfg config.TomlConfig, informers InformersInterface,
+	budget DrainBudget, checkpoint CheckpointHook) DrainEvaluator {
 	return &NodeDrainEvaluator{
-		config:    cfg,
-		informers: informers,
+		config:     cfg,
+		informers:  informers,
+		budget:     budget,
+		checkpoint: checkpoint,
 	}
 }
 
@@ -49,6 +70,16 @@ func (e *NodeDrainEvaluator) EvaluateEvent(ctx context.Context, healthEvent mode
 		}, nil
 	}
 
+	// A power cap is remediated with the workloads running, the node is not drained
+	if healthEvent.HealthEvent.RecommendedAction == protos.RecommendedAction_POWER_CAP {
+		slog.Info("Power cap recommended, skipping drain", "node", nodeName)
+
+		return &DrainActionResult{
+			Action: ActionMarkAlreadyDrained,
+			Status: "AlreadyDrained",
+		}, nil
+	}
+
 	if statusPtr != nil && *statusPtr == model.AlreadyQuarantined {
 		isDrained, err := mongodb.IsNodeAlreadyDrained(ctx, collection, nodeName)
 		if err != nil {
@@ -70,9 +101,90 @@ func (e *NodeDrainEvaluator) EvaluateEvent(ctx context.Context, healthEvent mode
 		}
 	}
 
+	if result := e.checkDoNotDisturb(healthEvent); result != nil {
+		return result, nil
+	}
+
+	if result := e.checkDrainBudget(healthEvent); result != nil {
+		return result, nil
+	}
+
 	return e.evaluateUserNamespaceActions(ctx, healthEvent)
 }
 
+// checkDoNotDisturb returns a wait action while the node is marked do not disturb
+// and the event is not urgent. Fatal events and forced drains are urgent, the
+// workloads are lost anyway.
+func (e *NodeDrainEvaluator) checkDoNotDisturb(healthEvent model.HealthEventWithStatus) *DrainActionResult {
+	if healthEvent.HealthEvent.IsFatal ||
+		(healthEvent.HealthEvent.DrainOverrides != nil && healthEvent.HealthEvent.DrainOverrides.Force) {
+		return nil
+	}
+
+	nodeName := healthEvent.HealthEvent.NodeName
+
+	node, err := e.informers.GetNode(nodeName)
+	if err != nil {
+		slog.Error("Failed to check node for do not disturb mark",
+			"node", nodeName,
+			"error", err)
+
+		return nil
+	}
+
+	until, active := donotdisturb.Until(node.Annotations, node.Labels, time.Now())
+	if !active {
+		return nil
+	}
+
+	delay := doNotDisturbRequeueDelay
+	if !until.IsZero() && time.Until(until) < delay {
+		delay = time.Until(until)
+	}
+
+	slog.Info("Node is marked do not disturb, pausing drain for non-fatal event",
+		"node", nodeName,
+		"until", until)
+
+	return &DrainActionResult{
+		Action:    ActionWait,
+		WaitDelay: delay,
+	}
+}
+
+// checkDrainBudget returns a wait action while the drain is queued by the
+// node pool budget. Forced drains are never queued, the node is going away
+// regardless, e.g. on spot preemption.
+func (e *NodeDrainEvaluator) checkDrainBudget(healthEvent model.HealthEventWithStatus) *DrainActionResult {
+	if e.budget == nil ||
+		(healthEvent.HealthEvent.DrainOverrides != nil && healthEvent.HealthEvent.DrainOverrides.Force) {
+		return nil
+	}
+
+	nodeName := healthEvent.HealthEvent.NodeName
+
+	admitted, err := e.budget.Admit(nodeName)
+	if err != nil {
+		slog.Error("Failed to check node pool budget",
+			"node", nodeName,
+			"error", err)
+
+		return &DrainActionResult{
+			Action:    ActionWait,
+			WaitDelay: time.Minute,
+		}
+	}
+
+	if !admitted {
+		return &DrainActionResult{
+			Action:    ActionWait,
+			WaitDelay: budgetRequeueDelay,
+		}
+	}
+
+	return nil
+}
+
 func (e *NodeDrainEvaluator) evaluateUserNamespaceActions(ctx context.Context,
 	healthEvent model.HealthEventWithStatus) (*DrainActionResult, error) {
 	nodeName := healthEvent.HealthEvent.NodeName
@@ -117,9 +229,57 @@ func (e *NodeDrainEvaluator) evaluateUserNamespaceActions(ctx context.Context,
 		}
 	}
 
+	if result := e.checkCheckpoint(ctx, healthEvent, ns); result != nil {
+		return result, nil
+	}
+
 	return e.getAction(ctx, ns, nodeName), nil
 }
 
+// checkCheckpoint returns a wait action until the checkpoint hook acknowledged
+// the pods about to be evicted or its deadline passed. Forced drains wait as
+// well, a checkpoint saves the most training time when the node goes away.
+func (e *NodeDrainEvaluator) checkCheckpoint(ctx context.Context, healthEvent model.HealthEventWithStatus,
+	ns namespaces) *DrainActionResult {
+	if e.checkpoint == nil {
+		return nil
+	}
+
+	nodeName := healthEvent.HealthEvent.NodeName
+
+	var pods []*v1.Pod
+
+	for _, namespaceList := range [][]string{
+		ns.immediateEvictionNamespaces, ns.allowCompletionNamespaces, ns.deleteAfterTimeoutNamespaces,
+	} {
+		for _, namespace := range namespaceList {
+			namespacePods, err := e.informers.FindEvictablePodsInNamespaceAndNode(namespace, nodeName)
+			if err != nil {
+				slog.Error("Failed to list pods for checkpoint hook",
+					"node", nodeName,
+					"namespace", namespace,
+					"error", err)
+
+				return &DrainActionResult{
+					Action:    ActionWait,
+					WaitDelay: time.Minute,
+				}
+			}
+
+			pods = append(pods, namespacePods...)
+		}
+	}
+
+	if !e.checkpoint.Ready(ctx, healthEvent.HealthEvent, pods) {
+		return &DrainActionResult{
+			Action:    ActionWait,
+			WaitDelay: checkpointRequeueDelay,
+		}
+	}
+
+	return nil
+}
+
 func (e *NodeDrainEvaluator) getAction(ctx context.Context, ns namespaces, nodeName string) *DrainActionResult {
 	if len(ns.immediateEvictionNamespaces) > 0 {
 		timeout := e.config.EvictionTimeoutInSeconds.Duration
@@ -21,6 +21,7 @@ import (
 	v1 "k8s.io/api/core/v1"
 
 	"github.com/nvidia/nvsentinel/data-models/pkg/model"
+	"github.com/nvidia/nvsentinel/data-models/pkg/protos"
 	"github.com/nvidia/nvsentinel/node-drainer/pkg/config"
 	"github.com/nvidia/nvsentinel/node-drainer/pkg/queue"
 )
@@ -31,14 +32,27 @@ type DrainEvaluator interface {
 }
 
 type NodeDrainEvaluator struct {
-	config    config.TomlConfig
-	informers InformersInterface
+	config     config.TomlConfig
+	informers  InformersInterface
+	budget     DrainBudget
+	checkpoint CheckpointHook
+}
+
+// DrainBudget admits drains within the node pool budgets.
+type DrainBudget interface {
+	Admit(nodeName string) (bool, error)
+}
+
+// CheckpointHook asks workload controllers to checkpoint pods before eviction.
+type CheckpointHook interface {
+	Ready(ctx context.Context, healthEvent *protos.HealthEvent, pods []*v1.Pod) bool
 }
 
 type InformersInterface interface {
 	GetNamespacesMatchingPattern(context.Context, string, string, string) ([]string, error)
 	CheckIfAllPodsAreEvictedInImmediateMode(context.Context, []string, string, time.Duration) bool
 	FindEvictablePodsInNamespaceAndNode(namespace, nodeName string) ([]*v1.Pod, error)
+	GetNode(nodeName string) (*v1.Node, error)
 }
 
 type DrainAction int
@@ -0,0 +1,236 @@
+// Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package impact tells workload owners why their pods are evicted. Before a
+// drain evicts pods it publishes a Warning event in the workload namespace on
+// the controller of the pods, e.g. the Job or PyTorchJob, naming the hardware
+// fault, so that users do not chase the failure in their own code.
+package impact
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/nvidia/nvsentinel/data-models/pkg/protos"
+	"github.com/nvidia/nvsentinel/node-drainer/pkg/metrics"
+
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes"
+)
+
+const (
+	// EventReason is the reason of the events published on workload owners
+	EventReason = "NodeHardwareFault"
+
+	eventSource = "nvsentinel-node-drainer"
+
+	// maxListedPods caps the pod names listed in an event message
+	maxListedPods = 10
+)
+
+// Publisher publishes an impact event once per workload owner and drain.
+type Publisher struct {
+	clientset  kubernetes.Interface
+	dryRunMode []string
+
+	mu sync.Mutex
+	// published holds the owners notified per node being drained
+	published map[string]map[types.UID]struct{}
+}
+
+// NewPublisher creates a Publisher. In dry run mode events are sent with
+// DryRun=All and not persisted.
+func NewPublisher(clientset kubernetes.Interface, dryRun bool) *Publisher {
+	dryRunMode := []string{}
+	if dryRun {
+		dryRunMode = []string{metav1.DryRunAll}
+	}
+
+	return &Publisher{
+		clientset:  clientset,
+		dryRunMode: dryRunMode,
+		published:  make(map[string]map[types.UID]struct{}),
+	}
+}
+
+// owner is the object an impact event is published on.
+type owner struct {
+	ref       v1.ObjectReference
+	podNames  []string
+	namespace string
+}
+
+// Publish publishes an impact event on the owner of each of the pods. Owners
+// already notified during the current drain of the node are skipped, so
+// Publish may be called on every retry of the eviction.
+func (p *Publisher) Publish(ctx context.Context, healthEvent *protos.HealthEvent, pods []*v1.Pod) {
+	nodeName := healthEvent.NodeName
+
+	for _, o := range p.pendingOwners(nodeName, pods) {
+		if err := p.publish(ctx, healthEvent, o); err != nil {
+			metrics.ProcessingErrors.WithLabelValues("impact_event_error", nodeName).Inc()
+			slog.Error("Failed to publish impact event",
+				"node", nodeName,
+				"namespace", o.namespace,
+				"kind", o.ref.Kind,
+				"name", o.ref.Name,
+				"error", err)
+
+			continue
+		}
+
+		p.markPublished(nodeName, o.ref.UID)
+		metrics.ImpactEventsPublished.WithLabelValues(o.ref.Kind).Inc()
+	}
+}
+
+// Release forgets the owners notified for the node, once its drain completed
+// or was cancelled.
+func (p *Publisher) Release(nodeName string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	delete(p.published, nodeName)
+}
+
+// pendingOwners groups the pods by owner and drops the owners that were
+// already notified. The result is sorted for deterministic publishing.
+func (p *Publisher) pendingOwners(nodeName string, pods []*v1.Pod) []*owner {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	owners := map[types.UID]*owner{}
+
+	for _, pod := range pods {
+		ref := ownerReference(pod)
+		if _, done := p.published[nodeName][ref.UID]; done {
+			continue
+		}
+
+		o, ok := owners[ref.UID]
+		if !ok {
+			o = &owner{ref: ref, namespace: pod.Namespace}
+			owners[ref.UID] = o
+		}
+
+		o.podNames = append(o.podNames, pod.Name)
+	}
+
+	result := make([]*owner, 0, len(owners))
+	for _, o := range owners {
+		sort.Strings(o.podNames)
+		result = append(result, o)
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].namespace != result[j].namespace {
+			return result[i].namespace < result[j].namespace
+		}
+
+		return result[i].ref.Name < result[j].ref.Name
+	})
+
+	return result
+}
+
+func (p *Publisher) markPublished(nodeName string, uid types.UID) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.published[nodeName] == nil {
+		p.published[nodeName] = make(map[types.UID]struct{})
+	}
+
+	p.published[nodeName][uid] = struct{}{}
+}
+
+func (p *Publisher) publish(ctx context.Context, healthEvent *protos.HealthEvent, o *owner) error {
+	now := metav1.NewTime(time.Now())
+
+	event := &v1.Event{
+		ObjectMeta: metav1.ObjectMeta{
+			GenerateName: o.ref.Name + "-",
+			Namespace:    o.namespace,
+		},
+		InvolvedObject: o.ref,
+		Reason:         EventReason,
+		Message:        message(healthEvent, o.podNames),
+		Type:           v1.EventTypeWarning,
+		Source:         v1.EventSource{Component: eventSource, Host: healthEvent.NodeName},
+		FirstTimestamp: now,
+		LastTimestamp:  now,
+		Count:          1,
+	}
+
+	_, err := p.clientset.CoreV1().Events(o.namespace).Create(ctx, event, metav1.CreateOptions{DryRun: p.dryRunMode})
+	if err != nil {
+		return fmt.Errorf("error creating event for %s %s/%s: %w", o.ref.Kind, o.namespace, o.ref.Name, err)
+	}
+
+	return nil
+}
+
+// ownerReference returns the controller of the pod, or the pod itself for
+// bare pods.
+func ownerReference(pod *v1.Pod) v1.ObjectReference {
+	if controller := metav1.GetControllerOf(pod); controller != nil {
+		return v1.ObjectReference{
+			APIVersion: controller.APIVersion,
+			Kind:       controller.Kind,
+			Name:       controller.Name,
+			UID:        controller.UID,
+			Namespace:  pod.Namespace,
+		}
+	}
+
+	return v1.ObjectReference{
+		APIVersion: "v1",
+		Kind:       "Pod",
+		Name:       pod.Name,
+		UID:        pod.UID,
+		Namespace:  pod.Namespace,
+	}
+}
+
+func message(healthEvent *protos.HealthEvent, podNames []string) string {
+	listed := podNames
+	if len(listed) > maxListedPods {
+		listed = listed[:maxListedPods]
+	}
+
+	pods := strings.Join(listed, ", ")
+	if len(podNames) > maxListedPods {
+		pods += fmt.Sprintf(" and %d more", len(podNames)-maxListedPods)
+	}
+
+	cause := healthEvent.CheckName
+	if healthEvent.Message != "" {
+		cause += ": " + healthEvent.Message
+	}
+
+	if len(healthEvent.ErrorCode) > 0 {
+		cause += fmt.Sprintf(" (error code %s)", strings.Join(healthEvent.ErrorCode, ", "))
+	}
+
+	return fmt.Sprintf("Pods %s are being evicted from node %s because of a hardware fault detected by NVSentinel, "+
+		"not a workload failure. %s. Recommended action: %s",
+		pods, healthEvent.NodeName, cause, healthEvent.RecommendedAction)
+}
@@ -0,0 +1,141 @@
+// Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package impact
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/nvidia/nvsentinel/data-models/pkg/protos"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+	"k8s.io/utils/ptr"
+)
+
+func jobPod(name, job string, uid types.UID) *v1.Pod {
+	return &v1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: "training",
+			OwnerReferences: []metav1.OwnerReference{{
+				APIVersion: "kubeflow.org/v1",
+				Kind:       "PyTorchJob",
+				Name:       job,
+				UID:        uid,
+				Controller: ptr.To(true),
+			}},
+		},
+	}
+}
+
+func testEvent() *protos.HealthEvent {
+	return &protos.HealthEvent{
+		NodeName:          "gpu-node-1",
+		CheckName:         "GpuXidError",
+		Message:           "Uncorrectable ECC error",
+		ErrorCode:         []string{"48"},
+		RecommendedAction: protos.RecommendedAction_RESTART_VM,
+	}
+}
+
+// newClientset returns a fake clientset that generates names, which the fake
+// object tracker does not do on its own.
+func newClientset() *fake.Clientset {
+	clientset := fake.NewSimpleClientset()
+	generated := 0
+
+	clientset.PrependReactor("create", "events", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		event := action.(k8stesting.CreateAction).GetObject().(*v1.Event)
+		if event.Name == "" {
+			generated++
+			event.Name = fmt.Sprintf("%s%d", event.GenerateName, generated)
+		}
+
+		return false, nil, nil
+	})
+
+	return clientset
+}
+
+func listEvents(t *testing.T, clientset *fake.Clientset, namespace string) []v1.Event {
+	t.Helper()
+
+	events, err := clientset.CoreV1().Events(namespace).List(context.Background(), metav1.ListOptions{})
+	require.NoError(t, err)
+
+	return events.Items
+}
+
+func TestPublishOncePerOwner(t *testing.T) {
+	clientset := newClientset()
+	publisher := NewPublisher(clientset, false)
+
+	bare := &v1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "debug", Namespace: "training", UID: "pod-uid"}}
+	pods := []*v1.Pod{
+		jobPod("llm-worker-1", "llm", "job-uid"),
+		jobPod("llm-worker-0", "llm", "job-uid"),
+		bare,
+	}
+
+	publisher.Publish(context.Background(), testEvent(), pods)
+
+	events := listEvents(t, clientset, "training")
+	require.Len(t, events, 2)
+
+	byKind := map[string]v1.Event{}
+	for _, event := range events {
+		byKind[event.InvolvedObject.Kind] = event
+	}
+
+	job := byKind["PyTorchJob"]
+	assert.Equal(t, "llm", job.InvolvedObject.Name)
+	assert.Equal(t, types.UID("job-uid"), job.InvolvedObject.UID)
+	assert.Equal(t, EventReason, job.Reason)
+	assert.Equal(t, v1.EventTypeWarning, job.Type)
+	assert.Contains(t, job.Message, "llm-worker-0, llm-worker-1")
+	assert.Contains(t, job.Message, "gpu-node-1")
+	assert.Contains(t, job.Message, "GpuXidError: Uncorrectable ECC error (error code 48)")
+	assert.Contains(t, job.Message, "RESTART_VM")
+
+	assert.Equal(t, "debug", byKind["Pod"].InvolvedObject.Name)
+
+	// Retries of the eviction do not publish again
+	publisher.Publish(context.Background(), testEvent(), pods)
+	assert.Len(t, listEvents(t, clientset, "training"), 2)
+
+	// A new drain of the node does
+	publisher.Release("gpu-node-1")
+	publisher.Publish(context.Background(), testEvent(), pods)
+	assert.Len(t, listEvents(t, clientset, "training"), 4)
+}
+
+func TestMessageCapsListedPods(t *testing.T) {
+	names := make([]string, 0, maxListedPods+2)
+	for i := 0; i < maxListedPods+2; i++ {
+		names = append(names, string(rune('a'+i)))
+	}
+
+	msg := message(testEvent(), names)
+	assert.Contains(t, msg, "a, b, c, d, e, f, g, h, i, j and 2 more")
+	assert.NotContains(t, msg, "k,")
+}
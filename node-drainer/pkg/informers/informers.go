@@ -109,6 +109,23 @@ func (i *Informers) HasSynced() bool {
 	return i.podInformer.HasSynced() && i.eventInformer.HasSynced() && i.nodeInformer.HasSynced()
 }
 
+// ListNodes returns the nodes in the informer cache.
+func (i *Informers) ListNodes() ([]*v1.Node, error) {
+	objs := i.nodeInformer.GetStore().List()
+	nodes := make([]*v1.Node, 0, len(objs))
+
+	for _, obj := range objs {
+		node, ok := obj.(*v1.Node)
+		if !ok {
+			return nil, fmt.Errorf("unexpected object type %T in node cache", obj)
+		}
+
+		nodes = append(nodes, node)
+	}
+
+	return nodes, nil
+}
+
 func NodeIndexFunc(obj any) ([]string, error) {
 	pod, ok := obj.(*v1.Pod)
 	if !ok {
@@ -152,6 +169,25 @@ func NodeEventReasonIndexFunc(obj any) ([]string, error) {
 	return []string{compositeKey}, nil
 }
 
+// GetNode returns the node from the informer cache.
+func (i *Informers) GetNode(nodeName string) (*v1.Node, error) {
+	obj, exists, err := i.nodeInformer.GetIndexer().GetByKey(nodeName)
+	if err != nil {
+		return nil, fmt.Errorf("error getting node %s from cache: %w", nodeName, err)
+	}
+
+	if !exists {
+		return nil, fmt.Errorf("node %s not found in cache", nodeName)
+	}
+
+	node, ok := obj.(*v1.Node)
+	if !ok {
+		return nil, fmt.Errorf("unexpected object type %T in node cache", obj)
+	}
+
+	return node, nil
+}
+
 func (i *Informers) Run(ctx context.Context) error {
 	go i.podInformer.Run(ctx.Done())
 	go i.eventInformer.Run(ctx.Done())
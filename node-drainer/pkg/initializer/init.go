@@ -18,9 +18,12 @@ import (
 	"context"
 	"fmt"
 	"log/slog"
+	"os"
 	"time"
 
 	"github.com/nvidia/nvsentinel/commons/pkg/statemanager"
+	"github.com/nvidia/nvsentinel/node-drainer/pkg/budget"
+	"github.com/nvidia/nvsentinel/node-drainer/pkg/chaos"
 	"github.com/nvidia/nvsentinel/node-drainer/pkg/config"
 	"github.com/nvidia/nvsentinel/node-drainer/pkg/informers"
 	"github.com/nvidia/nvsentinel/node-drainer/pkg/mongodb"
@@ -41,9 +44,13 @@ type InitializationParams struct {
 }
 
 type Components struct {
+	// Config is the loaded configuration, with defaults applied
+	Config       *config.TomlConfig
 	Informers    *informers.Informers
 	EventWatcher *mongodb.EventWatcher
 	QueueManager queue.EventQueueManager
+	// DrainBudget is nil if no node pool budgets are configured
+	DrainBudget *budget.Tracker
 }
 
 func InitializeAll(ctx context.Context, params InitializationParams) (*Components, error) {
@@ -102,9 +109,11 @@ func InitializeAll(ctx context.Context, params InitializationParams) (*Component
 	slog.Info("Initialization completed successfully")
 
 	return &Components{
+		Config:       tomlCfg,
 		Informers:    informersInstance,
 		EventWatcher: eventWatcher,
 		QueueManager: queueManager,
+		DrainBudget:  reconciler.GetDrainBudget(),
 	}, nil
 }
 
@@ -114,6 +123,15 @@ func initializeKubernetesClient(kubeconfigPath string) (kubernetes.Interface, er
 		return nil, fmt.Errorf("failed to build config: %w", err)
 	}
 
+	chaosEnabled, err := chaos.WrapFromEnv(restConfig)
+	if err != nil {
+		return nil, fmt.Errorf("failed to configure fault injection: %w", err)
+	}
+
+	if chaosEnabled {
+		slog.Warn("Chaos fault injection enabled for pod evictions and deletions", "faults", os.Getenv(chaos.EnvVar))
+	}
+
 	clientSet, err := kubernetes.NewForConfig(restConfig)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create kubernetes clientset: %w", err)
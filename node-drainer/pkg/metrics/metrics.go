@@ -108,4 +108,39 @@ var (
 			Help: "Total number of pending events in the queue.",
 		},
 	)
+
+	// QueuedDrains tracks drains held back by the node pool budgets
+	QueuedDrains = promauto.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "node_drainer_pool_budget_queued_drains",
+			Help: "Number of drains queued until their node pool has enough schedulable GPU nodes.",
+		},
+		[]string{"pool"},
+	)
+
+	// CheckpointHookResults tracks how pre-drain checkpoint waits ended
+	CheckpointHookResults = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "node_drainer_checkpoint_hook_results_total",
+			Help: "Total number of pre-drain checkpoint waits by result (acknowledged, deadline_exceeded).",
+		},
+		[]string{"result"},
+	)
+
+	// ImpactEventsPublished tracks events published on the owners of evicted pods
+	ImpactEventsPublished = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "node_drainer_impact_events_published_total",
+			Help: "Total number of hardware fault events published on the owners of evicted pods.",
+		},
+		[]string{"owner_kind"},
+	)
+
+	// CheckpointHookErrors tracks failed requests to the checkpoint hook
+	CheckpointHookErrors = promauto.NewCounter(
+		prometheus.CounterOpts{
+			Name: "node_drainer_checkpoint_hook_errors_total",
+			Help: "Total number of failed requests to the pre-drain checkpoint hook.",
+		},
+	)
 )
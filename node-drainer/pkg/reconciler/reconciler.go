@@ -21,16 +21,21 @@ import (
 	"sync"
 	"time"
 
+	"github.com/nvidia/nvsentinel/commons/pkg/logger"
 	"github.com/nvidia/nvsentinel/commons/pkg/statemanager"
 	"github.com/nvidia/nvsentinel/data-models/pkg/model"
+	"github.com/nvidia/nvsentinel/node-drainer/pkg/budget"
+	"github.com/nvidia/nvsentinel/node-drainer/pkg/checkpoint"
 	"github.com/nvidia/nvsentinel/node-drainer/pkg/config"
 	"github.com/nvidia/nvsentinel/node-drainer/pkg/evaluator"
+	"github.com/nvidia/nvsentinel/node-drainer/pkg/impact"
 	"github.com/nvidia/nvsentinel/node-drainer/pkg/informers"
 	"github.com/nvidia/nvsentinel/node-drainer/pkg/metrics"
 	"github.com/nvidia/nvsentinel/node-drainer/pkg/queue"
 	"github.com/nvidia/nvsentinel/store-client/pkg/storewatcher"
 
 	"go.mongodb.org/mongo-driver/bson"
+	v1 "k8s.io/api/core/v1"
 	"k8s.io/client-go/kubernetes"
 )
 
@@ -43,6 +48,9 @@ type Reconciler struct {
 	queueManager        queue.EventQueueManager
 	informers           *informers.Informers
 	evaluator           evaluator.DrainEvaluator
+	drainBudget         *budget.Tracker
+	checkpoint          *checkpoint.Coordinator
+	impactPublisher     *impact.Publisher
 	kubernetesClient    kubernetes.Interface
 	nodeEventsMap       map[string]eventStatusMap // nodeName → eventStatusMap
 	cancelledNodes      map[string]struct{}       // Node-level cancellation flags
@@ -52,7 +60,31 @@ type Reconciler struct {
 func NewReconciler(cfg config.ReconcilerConfig,
 	dryRunEnabled bool, kubeClient kubernetes.Interface, informersInstance *informers.Informers) *Reconciler {
 	queueManager := queue.NewEventQueueManager()
-	drainEvaluator := evaluator.NewNodeDrainEvaluator(cfg.TomlConfig, informersInstance)
+
+	var (
+		drainBudget        *budget.Tracker
+		evaluateBudget     evaluator.DrainBudget
+		checkpointHook     *checkpoint.Coordinator
+		evaluateCheckpoint evaluator.CheckpointHook
+	)
+
+	if len(cfg.TomlConfig.NodePoolBudgets) > 0 {
+		drainBudget = budget.NewTracker(cfg.TomlConfig.NodePoolLabel, cfg.TomlConfig.NodePoolBudgets, informersInstance)
+		evaluateBudget = drainBudget
+	}
+
+	if cfg.TomlConfig.CheckpointHook != nil {
+		checkpointHook = checkpoint.NewCoordinator(*cfg.TomlConfig.CheckpointHook)
+		evaluateCheckpoint = checkpointHook
+	}
+
+	var impactPublisher *impact.Publisher
+	if cfg.TomlConfig.PublishImpactEvents {
+		impactPublisher = impact.NewPublisher(kubeClient, dryRunEnabled)
+	}
+
+	drainEvaluator := evaluator.NewNodeDrainEvaluator(cfg.TomlConfig, informersInstance,
+		evaluateBudget, evaluateCheckpoint)
 
 	reconciler := &Reconciler{
 		Config:              cfg,
@@ -61,6 +93,9 @@ func NewReconciler(cfg config.ReconcilerConfig,
 		queueManager:        queueManager,
 		informers:           informersInstance,
 		evaluator:           drainEvaluator,
+		drainBudget:         drainBudget,
+		checkpoint:          checkpointHook,
+		impactPublisher:     impactPublisher,
 		kubernetesClient:    kubeClient,
 		nodeEventsMap:       make(map[string]eventStatusMap),
 		cancelledNodes:      make(map[string]struct{}),
@@ -75,6 +110,57 @@ func (r *Reconciler) GetQueueManager() queue.EventQueueManager {
 	return r.queueManager
 }
 
+// GetDrainBudget returns the node pool budget tracker, nil if no budgets are
+// configured.
+func (r *Reconciler) GetDrainBudget() *budget.Tracker {
+	return r.drainBudget
+}
+
+// releaseDrain releases the node pool budget, checkpoint wait and published
+// impact events of a drain that completed or was cancelled.
+func (r *Reconciler) releaseDrain(nodeName string) {
+	if r.drainBudget != nil {
+		r.drainBudget.Release(nodeName)
+	}
+
+	if r.checkpoint != nil {
+		r.checkpoint.Release(nodeName)
+	}
+
+	if r.impactPublisher != nil {
+		r.impactPublisher.Release(nodeName)
+	}
+}
+
+// publishImpactEvents tells the owners of the pods about to be evicted from
+// the namespaces which hardware fault caused the eviction.
+func (r *Reconciler) publishImpactEvents(ctx context.Context,
+	healthEvent model.HealthEventWithStatus, namespaces []string) {
+	if r.impactPublisher == nil {
+		return
+	}
+
+	nodeName := healthEvent.HealthEvent.NodeName
+
+	var pods []*v1.Pod
+
+	for _, namespace := range namespaces {
+		namespacePods, err := r.informers.FindEvictablePodsInNamespaceAndNode(namespace, nodeName)
+		if err != nil {
+			slog.ErrorContext(ctx, "Failed to list pods for impact events",
+				"node", nodeName,
+				"namespace", namespace,
+				"error", err)
+
+			continue
+		}
+
+		pods = append(pods, namespacePods...)
+	}
+
+	r.impactPublisher.Publish(ctx, healthEvent.HealthEvent, pods)
+}
+
 func (r *Reconciler) Shutdown() {
 	r.queueManager.Shutdown()
 }
@@ -93,6 +179,9 @@ func (r *Reconciler) ProcessEvent(ctx context.Context,
 		return fmt.Errorf("failed to unmarshal health event: %w", err)
 	}
 
+	ctx = logger.WithEventID(logger.WithSubsystem(ctx, "reconciler"), healthEventWithStatus.HealthEvent.GetId())
+	ctx = logger.WithIncidentID(ctx, healthEventWithStatus.IncidentID())
+
 	document, ok := event["fullDocument"].(bson.M)
 	if !ok {
 		metrics.ProcessingErrors.WithLabelValues("extract_document_error", nodeName).Inc()
@@ -106,7 +195,7 @@ func (r *Reconciler) ProcessEvent(ctx context.Context,
 	nodeQuarantinedStatus := healthEventWithStatus.HealthEventStatus.NodeQuarantined
 
 	if r.isEventCancelled(eventID, nodeName, nodeQuarantinedStatus) {
-		slog.Info("Event was cancelled, performing cleanup", "node", nodeName, "eventID", eventID)
+		slog.InfoContext(ctx, "Event was cancelled, performing cleanup", "node", nodeName, "eventID", eventID)
 		return r.handleCancelledEvent(ctx, nodeName, &healthEventWithStatus, event, collection, eventID)
 	}
 
@@ -118,7 +207,7 @@ func (r *Reconciler) ProcessEvent(ctx context.Context,
 		return fmt.Errorf("failed to evaluate event: %w", err)
 	}
 
-	slog.Info("Evaluated action for node",
+	slog.InfoContext(ctx, "Evaluated action for node",
 		"node", nodeName,
 		"action", actionResult.Action.String())
 
@@ -133,10 +222,12 @@ func (r *Reconciler) executeAction(ctx context.Context,
 	switch action.Action {
 	case evaluator.ActionSkip:
 		r.clearEventStatus(eventID, nodeName)
+		r.releaseDrain(nodeName)
+
 		return r.executeSkip(ctx, nodeName, healthEvent, event, collection)
 
 	case evaluator.ActionWait:
-		slog.Info("Waiting for node",
+		slog.InfoContext(ctx, "Waiting for node",
 			"node", nodeName,
 			"delay", action.WaitDelay)
 
@@ -156,10 +247,14 @@ func (r *Reconciler) executeAction(ctx context.Context,
 
 	case evaluator.ActionMarkAlreadyDrained:
 		r.clearEventStatus(eventID, nodeName)
+		r.releaseDrain(nodeName)
+
 		return r.executeMarkAlreadyDrained(ctx, healthEvent, event, collection)
 
 	case evaluator.ActionUpdateStatus:
 		r.clearEventStatus(eventID, nodeName)
+		r.releaseDrain(nodeName)
+
 		return r.executeUpdateStatus(ctx, healthEvent, event, collection)
 
 	default:
@@ -170,7 +265,7 @@ func (r *Reconciler) executeAction(ctx context.Context,
 func (r *Reconciler) executeSkip(ctx context.Context,
 	nodeName string, healthEvent model.HealthEventWithStatus,
 	event bson.M, collection queue.MongoCollectionAPI) error {
-	slog.Info("Skipping event for node", "node", nodeName)
+	slog.InfoContext(ctx, "Skipping event for node", "node", nodeName)
 
 	if statusPtr := healthEvent.HealthEventStatus.NodeQuarantined; statusPtr != nil &&
 		*statusPtr == model.UnQuarantined {
@@ -179,14 +274,14 @@ func (r *Reconciler) executeSkip(ctx context.Context,
 
 		if err := r.updateNodeUserPodsEvictedStatus(ctx, collection, event, podsEvictionStatus, nodeName,
 			metrics.DrainStatusCancelled); err != nil {
-			slog.Error("Failed to update MongoDB status for unquarantined node",
+			slog.ErrorContext(ctx, "Failed to update MongoDB status for unquarantined node",
 				"node", nodeName,
 				"error", err)
 
 			return fmt.Errorf("failed to update MongoDB status for node %s: %w", nodeName, err)
 		}
 
-		slog.Info("Updated MongoDB status for unquarantined node",
+		slog.InfoContext(ctx, "Updated MongoDB status for unquarantined node",
 			"node", nodeName,
 			"status", "succeeded")
 	}
@@ -199,6 +294,9 @@ func (r *Reconciler) executeSkip(ctx context.Context,
 func (r *Reconciler) executeImmediateEviction(ctx context.Context,
 	action *evaluator.DrainActionResult, healthEvent model.HealthEventWithStatus) error {
 	nodeName := healthEvent.HealthEvent.NodeName
+
+	r.publishImpactEvents(ctx, healthEvent, action.Namespaces)
+
 	for _, namespace := range action.Namespaces {
 		if err := r.informers.EvictAllPodsInImmediateMode(ctx, namespace, nodeName, action.Timeout); err != nil {
 			metrics.ProcessingErrors.WithLabelValues("immediate_eviction_error", nodeName).Inc()
@@ -214,6 +312,8 @@ func (r *Reconciler) executeTimeoutEviction(ctx context.Context,
 	nodeName := healthEvent.HealthEvent.NodeName
 	timeoutMinutes := int(action.Timeout.Minutes())
 
+	r.publishImpactEvents(ctx, healthEvent, action.Namespaces)
+
 	if err := r.informers.DeletePodsAfterTimeout(ctx,
 		nodeName, action.Namespaces, timeoutMinutes, &healthEvent); err != nil {
 		metrics.ProcessingErrors.WithLabelValues("timeout_eviction_error", nodeName).Inc()
@@ -251,19 +351,19 @@ func (r *Reconciler) executeCheckCompletion(ctx context.Context,
 
 		if err := r.informers.UpdateNodeEvent(ctx, nodeName, reason, message); err != nil {
 			// Don't fail the whole operation just because event update failed
-			slog.Error("Failed to update node event",
+			slog.ErrorContext(ctx, "Failed to update node event",
 				"node", nodeName,
 				"error", err)
 		}
 
-		slog.Info("Pods still running on node, requeueing for later check",
+		slog.InfoContext(ctx, "Pods still running on node, requeueing for later check",
 			"node", nodeName,
 			"remainingPods", remainingPods)
 
 		return fmt.Errorf("waiting for pods to complete: %d pods remaining", len(remainingPods))
 	}
 
-	slog.Info("All pods completed on node", "node", nodeName)
+	slog.InfoContext(ctx, "All pods completed on node", "node", nodeName)
 
 	return fmt.Errorf("pod completion verified, requeuing for status update")
 }
@@ -286,7 +386,7 @@ func (r *Reconciler) executeUpdateStatus(ctx context.Context,
 
 	if _, err := r.Config.StateManager.UpdateNVSentinelStateNodeLabel(ctx,
 		nodeName, statemanager.DrainSucceededLabelValue, false); err != nil {
-		slog.Error("Failed to update node label to drain-succeeded",
+		slog.ErrorContext(ctx, "Failed to update node label to drain-succeeded",
 			"node", nodeName,
 			"error", err)
 		metrics.ProcessingErrors.WithLabelValues("label_update_error", nodeName).Inc()
@@ -310,7 +410,7 @@ func (r *Reconciler) updateNodeDrainStatus(ctx context.Context,
 	if *healthEvent.HealthEventStatus.NodeQuarantined == model.UnQuarantined {
 		if _, err := r.Config.StateManager.UpdateNVSentinelStateNodeLabel(ctx,
 			nodeName, statemanager.DrainingLabelValue, true); err != nil {
-			slog.Error("Failed to remove draining label for unquarantined node",
+			slog.ErrorContext(ctx, "Failed to remove draining label for unquarantined node",
 				"node", nodeName,
 				"error", err)
 		}
@@ -321,7 +421,7 @@ func (r *Reconciler) updateNodeDrainStatus(ctx context.Context,
 	if isDraining {
 		if _, err := r.Config.StateManager.UpdateNVSentinelStateNodeLabel(ctx,
 			nodeName, statemanager.DrainingLabelValue, false); err != nil {
-			slog.Error("Failed to update node label to draining",
+			slog.ErrorContext(ctx, "Failed to update node label to draining",
 				"node", nodeName,
 				"error", err)
 			metrics.ProcessingErrors.WithLabelValues("label_update_error", nodeName).Inc()
@@ -349,7 +449,7 @@ func (r *Reconciler) updateNodeUserPodsEvictedStatus(ctx context.Context, collec
 		return fmt.Errorf("error updating document with ID: %v, error: %w", document["_id"], err)
 	}
 
-	slog.Info("Health event status has been updated",
+	slog.InfoContext(ctx, "Health event status has been updated",
 		"documentID", document["_id"],
 		"evictionStatus", userPodsEvictionStatus.Status)
 	metrics.EventsProcessed.WithLabelValues(drainStatus, nodeName).Inc()
@@ -462,13 +562,14 @@ func (r *Reconciler) handleCancelledEvent(ctx context.Context, nodeName string,
 	healthEvent *model.HealthEventWithStatus, event bson.M, collection queue.MongoCollectionAPI,
 	eventID string) error {
 	r.clearEventStatus(eventID, nodeName)
+	r.releaseDrain(nodeName)
 
 	podsEvictionStatus := &healthEvent.HealthEventStatus.UserPodsEvictionStatus
 	podsEvictionStatus.Status = model.Cancelled
 
 	if err := r.updateNodeUserPodsEvictedStatus(ctx, collection, event, podsEvictionStatus, nodeName,
 		metrics.DrainStatusCancelled); err != nil {
-		slog.Error("Failed to update MongoDB status for cancelled event",
+		slog.ErrorContext(ctx, "Failed to update MongoDB status for cancelled event",
 			"node", nodeName,
 			"error", err)
 
@@ -477,13 +578,13 @@ func (r *Reconciler) handleCancelledEvent(ctx context.Context, nodeName string,
 
 	if _, err := r.Config.StateManager.UpdateNVSentinelStateNodeLabel(ctx,
 		nodeName, statemanager.DrainingLabelValue, true); err != nil {
-		slog.Error("Failed to remove draining label for cancelled event",
+		slog.ErrorContext(ctx, "Failed to remove draining label for cancelled event",
 			"node", nodeName,
 			"error", err)
 	}
 
 	metrics.CancelledEvent.WithLabelValues(nodeName, healthEvent.HealthEvent.CheckName).Inc()
-	slog.Info("Successfully cleaned up cancelled event", "node", nodeName, "eventID", eventID)
+	slog.InfoContext(ctx, "Successfully cleaned up cancelled event", "node", nodeName, "eventID", eventID)
 
 	return nil
 }
@@ -17,12 +17,15 @@ package reconciler_test
 import (
 	"context"
 	"fmt"
+	"net/http"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/nvidia/nvsentinel/commons/pkg/statemanager"
 	"github.com/nvidia/nvsentinel/data-models/pkg/model"
 	"github.com/nvidia/nvsentinel/data-models/pkg/protos"
+	"github.com/nvidia/nvsentinel/node-drainer/pkg/chaos"
 	"github.com/nvidia/nvsentinel/node-drainer/pkg/config"
 	"github.com/nvidia/nvsentinel/node-drainer/pkg/informers"
 	"github.com/nvidia/nvsentinel/node-drainer/pkg/metrics"
@@ -40,6 +43,7 @@ import (
 	v1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
 	"k8s.io/utils/ptr"
 	"sigs.k8s.io/controller-runtime/pkg/envtest"
 )
@@ -546,6 +550,14 @@ type testSetup struct {
 }
 
 func setupDirectTest(t *testing.T, userNamespaces []config.UserNamespace, dryRun bool) *testSetup {
+	t.Helper()
+	return setupChaosTest(t, userNamespaces, dryRun, nil)
+}
+
+// setupChaosTest injects the faults into the requests of the drainer. The client of
+// the test is not affected.
+func setupChaosTest(t *testing.T, userNamespaces []config.UserNamespace, dryRun bool,
+	faults map[chaos.Operation]chaos.Fault) *testSetup {
 	t.Helper()
 	ctx := t.Context()
 
@@ -557,6 +569,16 @@ func setupDirectTest(t *testing.T, userNamespaces []config.UserNamespace, dryRun
 	client, err := kubernetes.NewForConfig(cfg)
 	require.NoError(t, err)
 
+	drainerClient := kubernetes.Interface(client)
+
+	if faults != nil {
+		chaosCfg := rest.CopyConfig(cfg)
+		chaosCfg.Wrap(func(next http.RoundTripper) http.RoundTripper { return chaos.NewTransport(next, faults) })
+
+		drainerClient, err = kubernetes.NewForConfig(chaosCfg)
+		require.NoError(t, err)
+	}
+
 	tomlConfig := config.TomlConfig{
 		EvictionTimeoutInSeconds:  config.Duration{Duration: 30 * time.Second},
 		SystemNamespaces:          "kube-*",
@@ -570,16 +592,16 @@ func setupDirectTest(t *testing.T, userNamespaces []config.UserNamespace, dryRun
 		MongoConfig:   storewatcher.MongoDBConfig{},
 		TokenConfig:   storewatcher.TokenConfig{},
 		MongoPipeline: mongo.Pipeline{},
-		StateManager:  statemanager.NewStateManager(client),
+		StateManager:  statemanager.NewStateManager(drainerClient),
 	}
 
-	informersInstance, err := informers.NewInformers(client, 1*time.Minute, ptr.To(2), dryRun)
+	informersInstance, err := informers.NewInformers(drainerClient, 1*time.Minute, ptr.To(2), dryRun)
 	require.NoError(t, err)
 
 	go informersInstance.Run(ctx)
 	require.Eventually(t, informersInstance.HasSynced, 30*time.Second, 1*time.Second)
 
-	r := reconciler.NewReconciler(reconcilerConfig, dryRun, client, informersInstance)
+	r := reconciler.NewReconciler(reconcilerConfig, dryRun, drainerClient, informersInstance)
 
 	return &testSetup{
 		ctx:               ctx,
@@ -645,6 +667,8 @@ type healthEventOptions struct {
 	checkName       string
 	nodeQuarantined model.Status
 	drainForce      bool
+	// createdAt defaults to now
+	createdAt time.Time
 }
 
 func createHealthEvent(opts healthEventOptions) bson.M {
@@ -662,6 +686,11 @@ func createHealthEvent(opts healthEventOptions) bson.M {
 		healthEvent.DrainOverrides = &protos.BehaviourOverrides{Force: true}
 	}
 
+	createdAt := opts.createdAt
+	if createdAt.IsZero() {
+		createdAt = time.Now()
+	}
+
 	return bson.M{
 		"fullDocument": bson.M{
 			"_id":         opts.nodeName + "-event",
@@ -670,7 +699,7 @@ func createHealthEvent(opts healthEventOptions) bson.M {
 				NodeQuarantined:        &opts.nodeQuarantined,
 				UserPodsEvictionStatus: model.OperationStatus{Status: model.StatusInProgress},
 			},
-			"createdAt": time.Now(),
+			"createdAt": createdAt,
 		},
 	}
 }
@@ -733,6 +762,70 @@ func assertPodsEvicted(t *testing.T, client kubernetes.Interface, ctx context.Co
 	}, 10*time.Second, 200*time.Millisecond)
 }
 
+// TestReconciler_EscalationUnderDrainFailures drives a drain through its escalation
+// ladder with faults injected into the requests of the drainer: evictions blocked
+// by a PodDisruptionBudget are retried, the drain then escalates to force deleting
+// the pods of DeleteAfterTimeout namespaces, and a failed force deletion keeps the
+// node draining, so remediation does not start, until it succeeds.
+func TestReconciler_EscalationUnderDrainFailures(t *testing.T) {
+	setup := setupChaosTest(t, []config.UserNamespace{
+		{Name: "immediate-*", Mode: config.ModeImmediateEvict},
+		{Name: "timeout-*", Mode: config.ModeDeleteAfterTimeout},
+	}, false, map[chaos.Operation]chaos.Fault{
+		chaos.OpEvict:  {Mode: chaos.ModePDB, Count: 1},
+		chaos.OpDelete: {Mode: chaos.ModeError, Count: 1},
+	})
+
+	nodeName := "chaos-node"
+	createNode(setup.ctx, t, setup.client, nodeName)
+	createNamespace(setup.ctx, t, setup.client, "immediate-chaos")
+	createNamespace(setup.ctx, t, setup.client, "timeout-chaos")
+	createPod(setup.ctx, t, setup.client, "immediate-chaos", "evicted-pod", nodeName, v1.PodRunning)
+	createPod(setup.ctx, t, setup.client, "timeout-chaos", "deleted-pod", nodeName, v1.PodRunning)
+
+	// The drain timeout of the DeleteAfterTimeout namespaces has passed
+	opts := healthEventOptions{
+		nodeName:        nodeName,
+		nodeQuarantined: model.Quarantined,
+		createdAt:       time.Now().Add(-10 * time.Minute),
+	}
+
+	require.Eventually(t, func() bool {
+		immediate, _ := setup.informersInstance.FindEvictablePodsInNamespaceAndNode("immediate-chaos", nodeName)
+		timeout, _ := setup.informersInstance.FindEvictablePodsInNamespaceAndNode("timeout-chaos", nodeName)
+
+		return len(immediate) == 1 && len(timeout) == 1
+	}, 30*time.Second, 100*time.Millisecond, "the drainer should see the pods")
+
+	err := processHealthEvent(setup.ctx, t, setup.reconciler, setup.mockCollection, opts)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "failed immediate eviction")
+	assertNodeLabel(t, setup.client, setup.ctx, nodeName, statemanager.DrainingLabelValue)
+
+	err = processHealthEvent(setup.ctx, t, setup.reconciler, setup.mockCollection, opts)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "immediate eviction completed")
+	assertPodsEvicted(t, setup.client, setup.ctx, "immediate-chaos")
+
+	require.Eventually(t, func() bool {
+		err := processHealthEvent(setup.ctx, t, setup.reconciler, setup.mockCollection, opts)
+		return err != nil && strings.Contains(err.Error(), "failed timeout eviction")
+	}, 30*time.Second, 500*time.Millisecond, "the force deletion should fail once")
+
+	_, err = setup.client.CoreV1().Pods("timeout-chaos").Get(setup.ctx, "deleted-pod", metav1.GetOptions{})
+	require.NoError(t, err, "the pod survives the failed force deletion")
+	assertNodeLabel(t, setup.client, setup.ctx, nodeName, statemanager.DrainingLabelValue)
+
+	err = processHealthEvent(setup.ctx, t, setup.reconciler, setup.mockCollection, opts)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "force deleted 1 pods")
+
+	require.Eventually(t, func() bool {
+		return processHealthEvent(setup.ctx, t, setup.reconciler, setup.mockCollection, opts) == nil
+	}, 30*time.Second, 500*time.Millisecond, "the drain should complete once the pods are gone")
+	assertNodeLabel(t, setup.client, setup.ctx, nodeName, statemanager.DrainSucceededLabelValue)
+}
+
 // TestReconciler_CancelledEventWithOngoingDrain validates that Cancelled events stop ongoing drain operations
 func TestReconciler_CancelledEventWithOngoingDrain(t *testing.T) {
 	setup := setupRequeueTest(t, []config.UserNamespace{
@@ -0,0 +1,22 @@
+module github.com/nvidia/nvsentinel/nvsentinelctl
+
+go 1.25
+
+toolchain go1.25.3
+
+require (
+	github.com/nvidia/nvsentinel/commons v0.0.0
+	github.com/stretchr/testify v1.11.1
+	golang.org/x/term v0.36.0
+)
+
+require (
+	github.com/BurntSushi/toml v1.5.0 // indirect
+	github.com/davecgh/go-spew v1.1.1 // indirect
+	github.com/golang-jwt/jwt/v5 v5.2.2 // indirect
+	github.com/pmezard/go-difflib v1.0.0 // indirect
+	golang.org/x/sys v0.37.0 // indirect
+	gopkg.in/yaml.v3 v3.0.1 // indirect
+)
+
+replace github.com/nvidia/nvsentinel/commons => ../commons
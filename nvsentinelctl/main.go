@@ -0,0 +1,192 @@
+// Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Command nvsentinelctl is the command line client of the NVSentinel APIs.
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/nvidia/nvsentinel/nvsentinelctl/pkg/events"
+	"github.com/nvidia/nvsentinel/nvsentinelctl/pkg/export"
+	"github.com/nvidia/nvsentinel/nvsentinelctl/pkg/login"
+	"github.com/nvidia/nvsentinel/nvsentinelctl/pkg/nodes"
+	"github.com/nvidia/nvsentinel/nvsentinelctl/pkg/remediation"
+	"github.com/nvidia/nvsentinel/nvsentinelctl/pkg/rules"
+	"github.com/nvidia/nvsentinel/nvsentinelctl/pkg/tui"
+)
+
+var (
+	// These variables will be populated during the build process
+	version = "dev"
+	commit  = "none"
+	date    = "unknown"
+)
+
+type command struct {
+	name        string
+	description string
+	run         func(ctx context.Context, args []string) error
+}
+
+var commands = []command{
+	{
+		name:        "login",
+		description: "Sign in with the identity provider of the NVSentinel APIs and cache the token",
+		run:         login.Login,
+	},
+	{
+		name:        "rules test",
+		description: "Run raw log lines through the checks of a syslog health monitor",
+		run:         rules.Test,
+	},
+	{
+		name:        "remediation plan",
+		description: "Print the remediation plans computed by fault remediation",
+		run:         remediation.Plan,
+	},
+	{
+		name:        "events query",
+		description: "Print the stored health events matching a filter expression",
+		run:         events.Query,
+	},
+	{
+		name:        "events affected",
+		description: "Print the nodes and GPUs affected by an error code, entity or driver version recently",
+		run:         events.Affected,
+	},
+	{
+		name:        "events local",
+		description: "Print the events and matched log lines a syslog health monitor retains on its node",
+		run:         events.Local,
+	},
+	{
+		name:        "nodes quarantine",
+		description: "Cordon nodes by hand, by name, file or label selector",
+		run:         nodes.Quarantine,
+	},
+	{
+		name:        "nodes release",
+		description: "Uncordon nodes quarantined by NVSentinel, by name, file or label selector",
+		run:         nodes.Release,
+	},
+	{
+		name:        "nodes silence",
+		description: "Switch enforcement off for nodes, faults are only observed",
+		run:         nodes.Silence,
+	},
+	{
+		name:        "nodes unsilence",
+		description: "Switch enforcement back on for nodes",
+		run:         nodes.Unsilence,
+	},
+	{
+		name:        "nodes reverify",
+		description: "Check the node actions of nodes in verification again right away",
+		run:         nodes.Reverify,
+	},
+	{
+		name:        "nodes approve",
+		description: "Let janitor perform the node actions of nodes waiting for approval in manual mode",
+		run:         nodes.Approve,
+	},
+	{
+		name:        "nodes diff",
+		description: "Print where a node's driver, kernel and GPU inventory deviate from healthy nodes of its SKU",
+		run:         nodes.Diff,
+	},
+	{
+		name:        "why",
+		description: "Explain why NVSentinel quarantined a node: nvsentinelctl why <node>",
+		run:         events.Why,
+	},
+	{
+		name:        "tui",
+		description: "Watch node health and events live and act on nodes from the terminal",
+		run:         tui.Run,
+	},
+	{
+		name:        "export scrub",
+		description: "Scrub hostnames, IPs and tenant identifiers from events and bundles before sharing them",
+		run:         export.Scrub,
+	},
+}
+
+func main() {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+
+	err := run(ctx, os.Args[1:])
+
+	stop()
+
+	if errors.Is(err, flag.ErrHelp) {
+		return
+	}
+
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "Error:", err)
+		os.Exit(1)
+	}
+}
+
+func run(ctx context.Context, args []string) error {
+	if len(args) == 1 && args[0] == "version" {
+		fmt.Printf("nvsentinelctl %s (commit %s, built %s)\n", version, commit, date)
+		return nil
+	}
+
+	if len(args) >= 1 {
+		for _, cmd := range commands {
+			if cmd.name == args[0] {
+				return cmd.run(ctx, args[1:])
+			}
+		}
+	}
+
+	if len(args) >= 2 {
+		name := args[0] + " " + args[1]
+
+		for _, cmd := range commands {
+			if cmd.name == name {
+				return cmd.run(ctx, args[2:])
+			}
+		}
+	}
+
+	usage()
+
+	if len(args) == 0 || args[0] == "help" || args[0] == "-h" || args[0] == "--help" {
+		return nil
+	}
+
+	return fmt.Errorf("unknown command %q", args)
+}
+
+func usage() {
+	fmt.Fprintln(os.Stderr, "Usage: nvsentinelctl <command> [flags]")
+	fmt.Fprintln(os.Stderr, "\nCommands:")
+
+	for _, cmd := range commands {
+		fmt.Fprintf(os.Stderr, "  %-18s %s\n", cmd.name, cmd.description)
+	}
+
+	fmt.Fprintf(os.Stderr, "  %-18s %s\n", "version", "Print the version")
+	fmt.Fprintln(os.Stderr, "\nRun nvsentinelctl <command> -h for the flags of a command.")
+}
@@ -0,0 +1,174 @@
+// Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package events
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/nvidia/nvsentinel/nvsentinelctl/pkg/output"
+)
+
+// affectedPath is where health events analyzer serves the affected nodes
+const affectedPath = "/affected"
+
+// affectedResponse mirrors the affected nodes API of health events analyzer.
+type affectedResponse struct {
+	Since time.Time `json:"since"`
+	Nodes []struct {
+		NodeName      string   `json:"nodeName"`
+		DriverVersion string   `json:"driverVersion"`
+		Events        int      `json:"events"`
+		ErrorCodes    []string `json:"errorCodes"`
+		GPUs          []struct {
+			Type  string `json:"type"`
+			Value string `json:"value"`
+		} `json:"gpus"`
+		FirstSeen time.Time `json:"firstSeen"`
+		LastSeen  time.Time `json:"lastSeen"`
+	} `json:"nodes"`
+	GPUs int `json:"gpus"`
+}
+
+type affectedOptions struct {
+	server        string
+	errorCode     string
+	entity        string
+	driverVersion string
+	days          int
+	output        string
+	watch         bool
+	interval      time.Duration
+	timeout       time.Duration
+}
+
+// Affected runs `events affected`: it prints the nodes and GPUs with unhealthy
+// events for an error code, entity or driver version over the last days, the most
+// affected first. With --watch it prints them again whenever they change.
+func Affected(ctx context.Context, args []string) error {
+	return runAffected(ctx, args, os.Stdout)
+}
+
+func runAffected(ctx context.Context, args []string, stdout io.Writer) error {
+	var opts affectedOptions
+
+	flags := flag.NewFlagSet("events affected", flag.ContinueOnError)
+	flags.StringVar(&opts.server, "server", "http://localhost:2112",
+		"Metrics endpoint of health events analyzer, e.g. after "+
+			"kubectl port-forward -n nvsentinel deployment/health-events-analyzer 2112")
+	flags.StringVar(&opts.errorCode, "error-code", "", "Error code of the events, e.g. 79")
+	flags.StringVar(&opts.entity, "entity", "", "Impacted entity of the events, e.g. a GPU UUID")
+	flags.StringVar(&opts.driverVersion, "driver-version", "",
+		"Driver version of the nodes when the events were generated, e.g. 550.54.15")
+	flags.IntVar(&opts.days, "days", 7, "Number of days to look back, at most 90")
+	flags.StringVar(&opts.output, "output", "text", output.Usage)
+	flags.BoolVar(&opts.watch, "watch", false, "Keep polling and print the affected nodes whenever they change")
+	flags.DurationVar(&opts.interval, "interval", 30*time.Second, "Polling interval of --watch")
+	flags.DurationVar(&opts.timeout, "timeout", 30*time.Second, "Timeout of the request")
+
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	if opts.errorCode == "" && opts.entity == "" && opts.driverVersion == "" {
+		return fmt.Errorf("one of --error-code, --entity and --driver-version is required")
+	}
+
+	format, err := output.Parse(opts.output)
+	if err != nil {
+		return err
+	}
+
+	values := url.Values{"days": {strconv.Itoa(opts.days)}}
+
+	for key, value := range map[string]string{
+		"errorCode":     opts.errorCode,
+		"entity":        opts.entity,
+		"driverVersion": opts.driverVersion,
+	} {
+		if value != "" {
+			values.Set(key, value)
+		}
+	}
+
+	printResponse := func(body []byte, first bool) error {
+		// Text snapshots are apart by an empty line
+		if !first && format.IsText() {
+			fmt.Fprintln(stdout)
+		}
+
+		return format.Print(stdout, body, func() error {
+			var response affectedResponse
+			if err := json.Unmarshal(body, &response); err != nil {
+				return fmt.Errorf("failed to decode response: %w", err)
+			}
+
+			return printAffected(stdout, &response)
+		})
+	}
+
+	if !opts.watch {
+		body, err := get(ctx, opts.server, affectedPath, values, opts.timeout)
+		if err != nil {
+			return err
+		}
+
+		return printResponse(body, true)
+	}
+
+	// The start of the period moves with every poll
+	return output.WatchSnapshots(ctx, opts.interval, os.Stderr, func(ctx context.Context) ([]byte, error) {
+		return get(ctx, opts.server, affectedPath, values, opts.timeout)
+	}, printResponse, "since")
+}
+
+func printAffected(out io.Writer, response *affectedResponse) error {
+	if len(response.Nodes) == 0 {
+		fmt.Fprintf(out, "No affected nodes since %s\n", response.Since.Format(time.RFC3339))
+		return nil
+	}
+
+	writer := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(writer, "NODE\tDRIVER\tEVENTS\tERROR CODES\tGPUS\tFIRST SEEN\tLAST SEEN")
+
+	for _, node := range response.Nodes {
+		gpus := make([]string, 0, len(node.GPUs))
+		for _, gpu := range node.GPUs {
+			gpus = append(gpus, gpu.Value)
+		}
+
+		fmt.Fprintf(writer, "%s\t%s\t%d\t%s\t%s\t%s\t%s\n", node.NodeName, node.DriverVersion, node.Events,
+			strings.Join(node.ErrorCodes, ","), strings.Join(gpus, ","), node.FirstSeen.Format(time.RFC3339),
+			node.LastSeen.Format(time.RFC3339))
+	}
+
+	if err := writer.Flush(); err != nil {
+		return err
+	}
+
+	fmt.Fprintf(out, "\n%d nodes, %d GPUs affected since %s\n", len(response.Nodes), response.GPUs,
+		response.Since.Format(time.RFC3339))
+
+	return nil
+}
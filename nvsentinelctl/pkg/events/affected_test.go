@@ -0,0 +1,104 @@
+// Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package events
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newAffectedServer(t *testing.T) *httptest.Server {
+	t.Helper()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != affectedPath || r.Method != http.MethodGet {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		if r.URL.Query().Get("driverVersion") != "" {
+			http.Error(w, "driver versions are not recorded", http.StatusBadRequest)
+			return
+		}
+
+		if r.URL.Query().Get("errorCode") != "79" || r.URL.Query().Get("days") != "30" {
+			_, _ = w.Write([]byte(`{"since": "2025-05-25T12:00:00Z", "nodes": [], "gpus": 0}`))
+			return
+		}
+
+		_, _ = w.Write([]byte(`{"errorCode": "79", "since": "2025-05-02T12:00:00Z", "gpus": 3, "nodes": [
+			{"nodeName": "h100-2", "driverVersion": "550.54.15", "events": 3, "errorCodes": ["48", "79"],
+				"gpus": [{"type": "GPU", "value": "0"}, {"type": "GPU", "value": "1"}],
+				"firstSeen": "2025-05-30T12:00:00Z", "lastSeen": "2025-06-01T11:00:00Z"},
+			{"nodeName": "h100-1", "driverVersion": "550.54.15", "events": 1, "errorCodes": ["79"],
+				"gpus": [{"type": "GPU", "value": "0"}],
+				"firstSeen": "2025-06-01T11:00:00Z", "lastSeen": "2025-06-01T11:00:00Z"}]}`))
+	}))
+	t.Cleanup(server.Close)
+
+	return server
+}
+
+func TestRunAffected(t *testing.T) {
+	server := newAffectedServer(t)
+
+	var out bytes.Buffer
+	require.NoError(t, runAffected(context.Background(),
+		[]string{"--server", server.URL, "--error-code", "79", "--days", "30"}, &out))
+
+	assert.Equal(t, `NODE    DRIVER     EVENTS  ERROR CODES  GPUS  FIRST SEEN            LAST SEEN
+h100-2  550.54.15  3       48,79        0,1   2025-05-30T12:00:00Z  2025-06-01T11:00:00Z
+h100-1  550.54.15  1       79           0     2025-06-01T11:00:00Z  2025-06-01T11:00:00Z
+
+2 nodes, 3 GPUs affected since 2025-05-02T12:00:00Z
+`, out.String())
+}
+
+func TestRunAffectedJSONPath(t *testing.T) {
+	server := newAffectedServer(t)
+
+	var out bytes.Buffer
+	require.NoError(t, runAffected(context.Background(), []string{"--server", server.URL, "--error-code", "79",
+		"--days", "30", "--output", "jsonpath={.nodes[?(@.events > 1)].nodeName}"}, &out))
+	assert.Equal(t, "h100-2", out.String())
+}
+
+func TestRunAffectedEmpty(t *testing.T) {
+	server := newAffectedServer(t)
+
+	var out bytes.Buffer
+	require.NoError(t, runAffected(context.Background(), []string{"--server", server.URL, "--error-code", "48"}, &out))
+	assert.Equal(t, "No affected nodes since 2025-05-25T12:00:00Z\n", out.String())
+}
+
+func TestRunAffectedErrors(t *testing.T) {
+	server := newAffectedServer(t)
+
+	err := runAffected(context.Background(),
+		[]string{"--server", server.URL, "--driver-version", "550.54.15"}, &bytes.Buffer{})
+	assert.ErrorContains(t, err, "driver versions are not recorded")
+
+	err = runAffected(context.Background(), []string{"--days", "3"}, &bytes.Buffer{})
+	assert.ErrorContains(t, err, "one of --error-code")
+
+	err = runAffected(context.Background(), []string{"--error-code", "79", "--output", "yaml"}, &bytes.Buffer{})
+	assert.ErrorContains(t, err, "invalid output")
+}
@@ -0,0 +1,172 @@
+// Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package events
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/nvidia/nvsentinel/nvsentinelctl/pkg/output"
+)
+
+// localPath is where the syslog health monitor serves the events and lines it
+// retains on its node
+const localPath = "/events/local"
+
+// localResponse mirrors the local events API of the syslog health monitor.
+type localResponse struct {
+	Events []struct {
+		Time    time.Time `json:"time"`
+		Kind    string    `json:"kind"`
+		Check   string    `json:"check"`
+		Shipped bool      `json:"shipped"`
+		Line    string    `json:"line"`
+		Event   struct {
+			ErrorCode         []string `json:"errorCode"`
+			IsFatal           bool     `json:"isFatal"`
+			IsHealthy         bool     `json:"isHealthy"`
+			RecommendedAction string   `json:"recommendedAction"`
+			Message           string   `json:"message"`
+		} `json:"event"`
+	} `json:"events"`
+	Since *time.Time `json:"since"`
+}
+
+type localOptions struct {
+	server  string
+	check   string
+	kind    string
+	since   time.Duration
+	output  string
+	timeout time.Duration
+}
+
+// Local runs `events local`: it pulls the events a syslog health monitor
+// generated on its node, shipped or filtered by severity, and the raw log lines
+// they were generated from, the oldest first.
+func Local(ctx context.Context, args []string) error {
+	return runLocal(ctx, args, os.Stdout)
+}
+
+func runLocal(ctx context.Context, args []string, stdout io.Writer) error {
+	var opts localOptions
+
+	flags := flag.NewFlagSet("events local", flag.ContinueOnError)
+	flags.StringVar(&opts.server, "server", "http://localhost:2112",
+		"Metrics endpoint of the syslog health monitor, e.g. after "+
+			"kubectl port-forward -n nvsentinel pod/<syslog-health-monitor pod> 2112")
+	flags.StringVar(&opts.check, "check", "", "Only print the records of this check")
+	flags.StringVar(&opts.kind, "kind", "", "Only print the records of this kind: event or line")
+	flags.DurationVar(&opts.since, "since", 0, "Only print the records of this last duration, e.g. 1h")
+	flags.StringVar(&opts.output, "output", "text", output.Usage)
+	flags.DurationVar(&opts.timeout, "timeout", 30*time.Second, "Timeout of the request")
+
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	format, err := output.Parse(opts.output)
+	if err != nil {
+		return err
+	}
+
+	if opts.kind != "" && opts.kind != "event" && opts.kind != "line" {
+		return fmt.Errorf("invalid kind %q, expected event or line", opts.kind)
+	}
+
+	values := url.Values{}
+
+	if opts.check != "" {
+		values.Set("check", opts.check)
+	}
+
+	if opts.kind != "" {
+		values.Set("kind", opts.kind)
+	}
+
+	if opts.since > 0 {
+		values.Set("since", time.Now().Add(-opts.since).UTC().Format(time.RFC3339))
+	}
+
+	body, err := get(ctx, opts.server, localPath, values, opts.timeout)
+	if err != nil {
+		return err
+	}
+
+	return format.Print(stdout, body, func() error {
+		var response localResponse
+		if err := json.Unmarshal(body, &response); err != nil {
+			return fmt.Errorf("failed to decode response: %w", err)
+		}
+
+		return printLocal(stdout, &response)
+	})
+}
+
+func printLocal(out io.Writer, response *localResponse) error {
+	if len(response.Events) == 0 {
+		fmt.Fprintln(out, "No retained events or lines")
+		return nil
+	}
+
+	writer := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+
+	fmt.Fprintln(writer, "TIME\tCHECK\tKIND\tERROR CODES\tSTATUS\tACTION\tMESSAGE")
+
+	for _, record := range response.Events {
+		if record.Kind == "line" {
+			fmt.Fprintf(writer, "%s\t%s\tline\t\t\t\t%s\n", record.Time.Format(time.RFC3339), record.Check,
+				shorten(record.Line))
+
+			continue
+		}
+
+		event := record.Event
+		status := "healthy"
+
+		switch {
+		case event.IsFatal:
+			status = "fatal"
+		case !event.IsHealthy:
+			status = "unhealthy"
+		}
+
+		if !record.Shipped {
+			status += ",local"
+		}
+
+		fmt.Fprintf(writer, "%s\t%s\tevent\t%s\t%s\t%s\t%s\n", record.Time.Format(time.RFC3339), record.Check,
+			strings.Join(event.ErrorCode, ","), status, event.RecommendedAction, shorten(event.Message))
+	}
+
+	if err := writer.Flush(); err != nil {
+		return err
+	}
+
+	if response.Since != nil {
+		fmt.Fprintf(out, "\nRecords retained since %s, events marked local were not shipped\n",
+			response.Since.Format(time.RFC3339))
+	}
+
+	return nil
+}
@@ -0,0 +1,90 @@
+// Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package events
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newLocalServer(t *testing.T) *httptest.Server {
+	t.Helper()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != localPath || r.Method != http.MethodGet {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		if r.URL.Query().Get("check") != "SysLogsXIDError" {
+			_, _ = w.Write([]byte(`{"events": []}`))
+			return
+		}
+
+		if since := r.URL.Query().Get("since"); since != "" {
+			_, err := time.Parse(time.RFC3339, since)
+			assert.NoError(t, err)
+		}
+
+		_, _ = w.Write([]byte(`{"since": "2025-06-01T10:00:00Z", "events": [
+			{"time": "2025-06-01T11:00:00Z", "kind": "line", "check": "SysLogsXIDError",
+				"line": "NVRM: Xid (PCI:0000:b3:00): 13"},
+			{"time": "2025-06-01T11:00:00Z", "kind": "event", "check": "SysLogsXIDError",
+				"event": {"errorCode": ["13"], "recommendedAction": "NONE", "message": "Graphics Engine Exception"}},
+			{"time": "2025-06-01T11:05:00Z", "kind": "event", "check": "SysLogsXIDError", "shipped": true,
+				"event": {"errorCode": ["79"], "isFatal": true, "recommendedAction": "RESTART_BM",
+					"message": "GPU has fallen off the bus"}}]}`))
+	}))
+	t.Cleanup(server.Close)
+
+	return server
+}
+
+func TestRunLocal(t *testing.T) {
+	server := newLocalServer(t)
+
+	var out bytes.Buffer
+
+	err := runLocal(context.Background(),
+		[]string{"--server", server.URL, "--check", "SysLogsXIDError", "--since", "1h"}, &out)
+	require.NoError(t, err)
+
+	assert.Equal(t, `TIME                  CHECK            KIND   ERROR CODES  STATUS           ACTION      MESSAGE
+2025-06-01T11:00:00Z  SysLogsXIDError  line                                             NVRM: Xid (PCI:0000:b3:00): 13
+2025-06-01T11:00:00Z  SysLogsXIDError  event  13           unhealthy,local  NONE        Graphics Engine Exception
+2025-06-01T11:05:00Z  SysLogsXIDError  event  79           fatal            RESTART_BM  GPU has fallen off the bus
+
+Records retained since 2025-06-01T10:00:00Z, events marked local were not shipped
+`, out.String())
+
+	out.Reset()
+	require.NoError(t, runLocal(context.Background(), []string{"--server", server.URL}, &out))
+	assert.Equal(t, "No retained events or lines\n", out.String())
+
+	out.Reset()
+	require.NoError(t, runLocal(context.Background(), []string{"--server", server.URL, "--check", "SysLogsXIDError",
+		"--output", `jsonpath={range .events[*]}{.kind}{"\n"}{end}`}, &out))
+	assert.Equal(t, "line\nevent\nevent\n", out.String())
+
+	err = runLocal(context.Background(), []string{"--server", server.URL, "--kind", "metric"}, &out)
+	assert.ErrorContains(t, err, "invalid kind")
+}
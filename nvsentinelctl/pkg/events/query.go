@@ -0,0 +1,278 @@
+// Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package events implements the event store commands of nvsentinelctl.
+package events
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/nvidia/nvsentinel/nvsentinelctl/pkg/output"
+)
+
+// eventsPath is where health events analyzer serves the stored events
+const eventsPath = "/events"
+
+// maxMessageLength is the length messages are cut to in text output
+const maxMessageLength = 80
+
+// queryResponse mirrors the events API of health events analyzer.
+type queryResponse struct {
+	Events []struct {
+		ID                string    `json:"id"`
+		NodeName          string    `json:"nodeName"`
+		CheckName         string    `json:"checkName"`
+		ErrorCodes        []string  `json:"errorCodes"`
+		Message           string    `json:"message"`
+		IsFatal           bool      `json:"isFatal"`
+		IsHealthy         bool      `json:"isHealthy"`
+		RecommendedAction string    `json:"recommendedAction"`
+		GeneratedAt       time.Time `json:"generatedAt"`
+	} `json:"events"`
+	Truncated bool `json:"truncated"`
+}
+
+type queryOptions struct {
+	server   string
+	query    string
+	limit    int
+	output   string
+	watch    bool
+	interval time.Duration
+	timeout  time.Duration
+}
+
+// Query runs `events query`: it prints the stored health events matching a
+// filter expression, the most recent first. With --watch it keeps polling and
+// prints the events stored since.
+func Query(ctx context.Context, args []string) error {
+	return runQuery(ctx, args, os.Stdout)
+}
+
+func runQuery(ctx context.Context, args []string, stdout io.Writer) error {
+	var opts queryOptions
+
+	flags := flag.NewFlagSet("events query", flag.ContinueOnError)
+	flags.StringVar(&opts.server, "server", "http://localhost:2112",
+		"Metrics endpoint of health events analyzer, e.g. after "+
+			"kubectl port-forward -n nvsentinel deployment/health-events-analyzer 2112")
+	flags.StringVar(&opts.query, "query", "",
+		`Filter expression, e.g. 'error_code in ("79", "48") and node =~ "h100-*" and time > now-24h'. `+
+			"All events by default")
+	flags.IntVar(&opts.limit, "limit", 100, "Maximum number of events to print, at most 1000")
+	flags.StringVar(&opts.output, "output", "text", output.Usage)
+	flags.BoolVar(&opts.watch, "watch", false, "Keep polling and print new events as they are stored")
+	flags.DurationVar(&opts.interval, "interval", 10*time.Second, "Polling interval of --watch")
+	flags.DurationVar(&opts.timeout, "timeout", 30*time.Second, "Timeout of the request")
+
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	format, err := output.Parse(opts.output)
+	if err != nil {
+		return err
+	}
+
+	if opts.watch {
+		return watchEvents(ctx, opts, format, stdout)
+	}
+
+	body, err := requestEvents(ctx, opts)
+	if err != nil {
+		return err
+	}
+
+	return format.Print(stdout, body, func() error {
+		var response queryResponse
+		if err := json.Unmarshal(body, &response); err != nil {
+			return fmt.Errorf("failed to decode response: %w", err)
+		}
+
+		return printEvents(stdout, &response)
+	})
+}
+
+// watchEvents prints the matching events, then the events that match on each
+// poll and were not printed yet. JSON is printed a line per poll, with the new
+// events only.
+func watchEvents(ctx context.Context, opts queryOptions, format output.Format, stdout io.Writer) error {
+	seen := map[string]bool{}
+	first := true
+
+	return output.Watch(ctx, opts.interval, os.Stderr, func(ctx context.Context) error {
+		body, err := requestEvents(ctx, opts)
+		if err != nil {
+			return err
+		}
+
+		var response struct {
+			Events    []json.RawMessage `json:"events"`
+			Truncated bool              `json:"truncated"`
+		}
+		if err := json.Unmarshal(body, &response); err != nil {
+			return fmt.Errorf("failed to decode response: %w", err)
+		}
+
+		fresh := []json.RawMessage{}
+
+		for _, raw := range response.Events {
+			var event struct {
+				ID string `json:"id"`
+			}
+			if err := json.Unmarshal(raw, &event); err != nil {
+				return fmt.Errorf("failed to decode event: %w", err)
+			}
+
+			if !seen[event.ID] {
+				seen[event.ID] = true
+
+				fresh = append(fresh, raw)
+			}
+		}
+
+		if !first && len(fresh) == 0 {
+			return nil
+		}
+
+		response.Events = fresh
+		response.Truncated = response.Truncated && first
+
+		body, err = json.Marshal(response)
+		if err != nil {
+			return fmt.Errorf("failed to encode events: %w", err)
+		}
+
+		body = append(body, '\n')
+
+		printHeader := first
+		first = false
+
+		return format.Print(stdout, body, func() error {
+			var events queryResponse
+			if err := json.Unmarshal(body, &events); err != nil {
+				return fmt.Errorf("failed to decode response: %w", err)
+			}
+
+			if printHeader {
+				return printEvents(stdout, &events)
+			}
+
+			return writeEvents(stdout, &events, false)
+		})
+	})
+}
+
+func requestEvents(ctx context.Context, opts queryOptions) ([]byte, error) {
+	values := url.Values{"limit": {strconv.Itoa(opts.limit)}}
+	if opts.query != "" {
+		values.Set("query", opts.query)
+	}
+
+	return get(ctx, opts.server, eventsPath, values, opts.timeout)
+}
+
+// get requests path of health events analyzer and returns the body of a 200 response.
+func get(ctx context.Context, server, path string, values url.Values, timeout time.Duration) ([]byte, error) {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	target := strings.TrimSuffix(server, "/") + path + "?" + values.Encode()
+
+	httpRequest, err := http.NewRequestWithContext(ctx, http.MethodGet, target, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	httpResponse, err := http.DefaultClient.Do(httpRequest)
+	if err != nil {
+		return nil, fmt.Errorf("failed to reach %s: %w", target, err)
+	}
+	defer httpResponse.Body.Close()
+
+	body, err := io.ReadAll(httpResponse.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response: %w", err)
+	}
+
+	if httpResponse.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("%s returned %s: %s", target, httpResponse.Status, strings.TrimSpace(string(body)))
+	}
+
+	return body, nil
+}
+
+func printEvents(out io.Writer, response *queryResponse) error {
+	if len(response.Events) == 0 {
+		fmt.Fprintln(out, "No matching events")
+		return nil
+	}
+
+	if err := writeEvents(out, response, true); err != nil {
+		return err
+	}
+
+	if response.Truncated {
+		fmt.Fprintf(out, "\nShowing the %d most recent events, raise --limit to see more\n", len(response.Events))
+	}
+
+	return nil
+}
+
+// writeEvents writes a row per event, below the column header if header is set.
+func writeEvents(out io.Writer, response *queryResponse, header bool) error {
+	writer := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+
+	if header {
+		fmt.Fprintln(writer, "TIME\tNODE\tCHECK\tERROR CODES\tSTATUS\tACTION\tMESSAGE")
+	}
+
+	for _, event := range response.Events {
+		status := "healthy"
+
+		switch {
+		case event.IsFatal:
+			status = "fatal"
+		case !event.IsHealthy:
+			status = "unhealthy"
+		}
+
+		fmt.Fprintf(writer, "%s\t%s\t%s\t%s\t%s\t%s\t%s\n", event.GeneratedAt.Format(time.RFC3339),
+			event.NodeName, event.CheckName, strings.Join(event.ErrorCodes, ","), status,
+			event.RecommendedAction, shorten(event.Message))
+	}
+
+	return writer.Flush()
+}
+
+// shorten keeps the first line of message, cut to maxMessageLength.
+func shorten(message string) string {
+	message, _, _ = strings.Cut(message, "\n")
+	if len(message) > maxMessageLength {
+		return message[:maxMessageLength-3] + "..."
+	}
+
+	return message
+}
@@ -0,0 +1,147 @@
+// Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package events
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newEventsServer(t *testing.T) *httptest.Server {
+	t.Helper()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != eventsPath || r.Method != http.MethodGet {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		if query := r.URL.Query().Get("query"); query != `node =~ "h100-*"` {
+			http.Error(w, "invalid query: position 1: unknown field", http.StatusBadRequest)
+			return
+		}
+
+		_, _ = w.Write([]byte(`{"query": "node =~ \"h100-*\"", "truncated": true, "events": [
+			{"id": "1", "nodeName": "h100-1", "checkName": "SysLogsXIDError", "errorCodes": ["79"],
+				"message": "NVRM: Xid (PCI:0000:b3:00.0): 79, GPU has fallen off the bus.\nmore",
+				"isFatal": true, "recommendedAction": "RESTART_BM", "generatedAt": "2025-06-01T10:00:00Z"},
+			{"id": "2", "nodeName": "h100-2", "checkName": "GpuThermalWatch", "isHealthy": true,
+				"message": "` + strings.Repeat("x", 100) + `", "recommendedAction": "NONE",
+				"generatedAt": "2025-06-01T09:00:00Z"}]}`))
+	}))
+	t.Cleanup(server.Close)
+
+	return server
+}
+
+func TestRunQuery(t *testing.T) {
+	server := newEventsServer(t)
+
+	var out bytes.Buffer
+	require.NoError(t, runQuery(context.Background(),
+		[]string{"--server", server.URL, "--query", `node =~ "h100-*"`, "--limit", "2"}, &out))
+
+	assert.Equal(t, `TIME                  NODE    CHECK            ERROR CODES  STATUS   ACTION      MESSAGE
+2025-06-01T10:00:00Z  h100-1  SysLogsXIDError  79           fatal    RESTART_BM  NVRM: Xid (PCI:0000:b3:00.0): 79, GPU has fallen off the bus.
+2025-06-01T09:00:00Z  h100-2  GpuThermalWatch               healthy  NONE        `+
+		strings.Repeat("x", 77)+`...
+
+Showing the 2 most recent events, raise --limit to see more
+`, out.String())
+}
+
+func TestRunQueryJSON(t *testing.T) {
+	server := newEventsServer(t)
+
+	var out bytes.Buffer
+	require.NoError(t, runQuery(context.Background(),
+		[]string{"--server", server.URL, "--query", `node =~ "h100-*"`, "--output", "json"}, &out))
+	assert.Contains(t, out.String(), `"nodeName": "h100-1"`)
+}
+
+func TestRunQueryWatch(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	event := func(id, node string) string {
+		return `{"id": "` + id + `", "nodeName": "` + node + `", "checkName": "SysLogsXIDError", "errorCodes": ["79"],
+			"message": "Xid 79", "isFatal": true, "recommendedAction": "RESTART_BM",
+			"generatedAt": "2025-06-01T10:0` + id + `:00Z"}`
+	}
+	responses := []string{
+		`{"events": [` + event("2", "h100-2") + `, ` + event("1", "h100-1") + `]}`,
+		`{"events": [` + event("2", "h100-2") + `, ` + event("1", "h100-1") + `]}`,
+		`{"events": [` + event("3", "h100-3") + `, ` + event("2", "h100-2") + `]}`,
+		// The watch is stopped during this poll
+		`{"events": []}`,
+	}
+
+	var calls atomic.Int32
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		call := int(calls.Add(1))
+		if call >= len(responses) {
+			cancel()
+			call = len(responses)
+		}
+
+		_, _ = w.Write([]byte(responses[call-1]))
+	}))
+	t.Cleanup(server.Close)
+
+	var out bytes.Buffer
+	require.NoError(t, runQuery(ctx, []string{"--server", server.URL, "--watch", "--interval", "1ms"}, &out))
+
+	assert.Equal(t, `TIME                  NODE    CHECK            ERROR CODES  STATUS  ACTION      MESSAGE
+2025-06-01T10:02:00Z  h100-2  SysLogsXIDError  79           fatal   RESTART_BM  Xid 79
+2025-06-01T10:01:00Z  h100-1  SysLogsXIDError  79           fatal   RESTART_BM  Xid 79
+2025-06-01T10:03:00Z  h100-3  SysLogsXIDError  79  fatal  RESTART_BM  Xid 79
+`, out.String(), "new events are printed without the header, aligned on their own")
+
+	calls.Store(0)
+	ctx, cancel = context.WithCancel(context.Background())
+	defer cancel()
+
+	out.Reset()
+
+	require.NoError(t, runQuery(ctx, []string{"--server", server.URL, "--watch", "--interval", "1ms",
+		"--output", "jsonpath={range .events[*]}{.id}{\"\\n\"}{end}"}, &out))
+	assert.Equal(t, "2\n1\n3\n", out.String())
+}
+
+func TestRunQueryErrors(t *testing.T) {
+	server := newEventsServer(t)
+
+	err := runQuery(context.Background(), []string{"--server", server.URL, "--query", `gpu = "0"`}, &bytes.Buffer{})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "unknown field")
+
+	err = runQuery(context.Background(), []string{"--output", "yaml"}, &bytes.Buffer{})
+	assert.ErrorContains(t, err, "invalid output")
+}
+
+func TestPrintEventsEmpty(t *testing.T) {
+	var out bytes.Buffer
+	require.NoError(t, printEvents(&out, &queryResponse{}))
+	assert.Equal(t, "No matching events\n", out.String())
+}
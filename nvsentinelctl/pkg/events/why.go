@@ -0,0 +1,234 @@
+// Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package events
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+)
+
+// quarantinePath is where health events analyzer serves why nodes are quarantined
+const quarantinePath = "/quarantine"
+
+// quarantineResponse mirrors the quarantine API of health events analyzer.
+type quarantineResponse struct {
+	NodeName    string `json:"nodeName"`
+	Quarantined bool   `json:"quarantined"`
+	Status      string `json:"status"`
+	Reason      *struct {
+		IncidentID    string    `json:"incidentId"`
+		Agent         string    `json:"agent"`
+		CheckName     string    `json:"checkName"`
+		ErrorCodes    []string  `json:"errorCodes"`
+		Message       string    `json:"message"`
+		RuleSets      []string  `json:"ruleSets"`
+		Cordoned      bool      `json:"cordoned"`
+		Taints        []string  `json:"taints"`
+		RunbookURL    string    `json:"runbookUrl"`
+		QuarantinedAt time.Time `json:"quarantinedAt"`
+	} `json:"reason"`
+	Events []struct {
+		ID          string    `json:"id"`
+		CheckName   string    `json:"checkName"`
+		ErrorCodes  []string  `json:"errorCodes"`
+		Message     string    `json:"message"`
+		Status      string    `json:"status"`
+		GeneratedAt time.Time `json:"generatedAt"`
+	} `json:"events"`
+	Truncated bool `json:"truncated"`
+}
+
+type whyOptions struct {
+	server  string
+	output  string
+	timeout time.Duration
+}
+
+// Why runs `why <node>`: it explains why NVSentinel quarantined the node, from the
+// quarantine reason fault quarantine recorded and the events that kept the node
+// quarantined since.
+func Why(ctx context.Context, args []string) error {
+	return runWhy(ctx, args, os.Stdout)
+}
+
+func runWhy(ctx context.Context, args []string, stdout io.Writer) error {
+	var opts whyOptions
+
+	flags := flag.NewFlagSet("why", flag.ContinueOnError)
+	flags.StringVar(&opts.server, "server", "http://localhost:2112",
+		"Metrics endpoint of health events analyzer, e.g. after "+
+			"kubectl port-forward -n nvsentinel deployment/health-events-analyzer 2112")
+	flags.StringVar(&opts.output, "output", "text", "Output format: text or json")
+	flags.DurationVar(&opts.timeout, "timeout", 30*time.Second, "Timeout of the request")
+
+	// The node comes first on the command line, flag parsing stops at it
+	var nodeName string
+	if len(args) > 0 && !strings.HasPrefix(args[0], "-") {
+		nodeName, args = args[0], args[1:]
+	}
+
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	if nodeName == "" {
+		nodeName = flags.Arg(0)
+	}
+
+	if nodeName == "" {
+		return fmt.Errorf("a node name is required: nvsentinelctl why <node>")
+	}
+
+	if opts.output != "text" && opts.output != "json" {
+		return fmt.Errorf("invalid output %q, expected text or json", opts.output)
+	}
+
+	if opts.output == "text" {
+		return Explain(ctx, opts.server, nodeName, opts.timeout, stdout)
+	}
+
+	body, err := get(ctx, opts.server, quarantinePath, url.Values{"node": {nodeName}}, opts.timeout)
+	if err != nil {
+		return err
+	}
+
+	_, err = stdout.Write(body)
+
+	return err
+}
+
+// Explain prints why NVSentinel quarantined the node as `why` does, from the health
+// events analyzer at server.
+func Explain(ctx context.Context, server, nodeName string, timeout time.Duration, out io.Writer) error {
+	body, err := get(ctx, server, quarantinePath, url.Values{"node": {nodeName}}, timeout)
+	if err != nil {
+		return err
+	}
+
+	var response quarantineResponse
+	if err := json.Unmarshal(body, &response); err != nil {
+		return fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	return printWhy(out, &response)
+}
+
+func printWhy(out io.Writer, response *quarantineResponse) error {
+	if !response.Quarantined {
+		switch response.Status {
+		case "UnQuarantined":
+			fmt.Fprintf(out, "Node %s is not quarantined by NVSentinel, it was released after it recovered\n",
+				response.NodeName)
+		case "Cancelled":
+			fmt.Fprintf(out, "Node %s is not quarantined by NVSentinel, it was uncordoned manually\n",
+				response.NodeName)
+		default:
+			fmt.Fprintf(out, "Node %s was never quarantined by NVSentinel\n", response.NodeName)
+		}
+
+		fmt.Fprintf(out, "If it is unschedulable, it was cordoned by something else, see kubectl describe node %s\n",
+			response.NodeName)
+
+		return nil
+	}
+
+	if err := printReason(out, response); err != nil {
+		return err
+	}
+
+	if len(response.Events) == 0 {
+		return nil
+	}
+
+	fmt.Fprintln(out, "\nEvents that kept the node quarantined, the most recent first:")
+
+	writer := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(writer, "TIME\tCHECK\tERROR CODES\tSTATUS\tMESSAGE")
+
+	for _, event := range response.Events {
+		fmt.Fprintf(writer, "%s\t%s\t%s\t%s\t%s\n", event.GeneratedAt.Format(time.RFC3339), event.CheckName,
+			strings.Join(event.ErrorCodes, ","), event.Status, shorten(event.Message))
+	}
+
+	if err := writer.Flush(); err != nil {
+		return err
+	}
+
+	if response.Truncated {
+		fmt.Fprintf(out, "\nShowing the %d most recent events of the quarantine\n", len(response.Events))
+	}
+
+	return nil
+}
+
+func printReason(out io.Writer, response *quarantineResponse) error {
+	reason := response.Reason
+	if reason == nil {
+		fmt.Fprintf(out, "Node %s is quarantined by NVSentinel, no reason was recorded for the quarantine\n",
+			response.NodeName)
+
+		return nil
+	}
+
+	fmt.Fprintf(out, "Node %s is quarantined by NVSentinel since %s\n\n", response.NodeName,
+		reason.QuarantinedAt.Format(time.RFC3339))
+
+	action := "tainted"
+
+	switch {
+	case reason.Cordoned && len(reason.Taints) > 0:
+		action = "cordoned and tainted"
+	case reason.Cordoned:
+		action = "cordoned"
+	}
+
+	ruleSets := strings.Join(reason.RuleSets, ", ")
+	if ruleSets == "" {
+		ruleSets = "none, quarantine forced by the event"
+	}
+
+	writer := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(writer, "Incident:\t%s\n", reason.IncidentID)
+	fmt.Fprintf(writer, "Check:\t%s (%s)\n", reason.CheckName, reason.Agent)
+
+	if len(reason.ErrorCodes) > 0 {
+		fmt.Fprintf(writer, "Error codes:\t%s\n", strings.Join(reason.ErrorCodes, ", "))
+	}
+
+	if reason.Message != "" {
+		fmt.Fprintf(writer, "Message:\t%s\n", shorten(reason.Message))
+	}
+
+	fmt.Fprintf(writer, "Rule sets:\t%s\n", ruleSets)
+	fmt.Fprintf(writer, "Action:\t%s\n", action)
+
+	if len(reason.Taints) > 0 {
+		fmt.Fprintf(writer, "Taints:\t%s\n", strings.Join(reason.Taints, ", "))
+	}
+
+	if reason.RunbookURL != "" {
+		fmt.Fprintf(writer, "Runbook:\t%s\n", reason.RunbookURL)
+	}
+
+	return writer.Flush()
+}
@@ -0,0 +1,108 @@
+// Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package events
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newQuarantineServer(t *testing.T) *httptest.Server {
+	t.Helper()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != quarantinePath || r.Method != http.MethodGet {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		switch r.URL.Query().Get("node") {
+		case "h100-1":
+			_, _ = w.Write([]byte(`{"nodeName": "h100-1", "quarantined": true, "status": "Quarantined",
+				"reason": {"incidentId": "01J0000000000000000000000", "agent": "syslog-health-monitor",
+					"checkName": "SysLogsXIDError", "errorCodes": ["79"], "message": "GPU has fallen off the bus",
+					"ruleSets": ["GPU fatal error ruleset"], "cordoned": true,
+					"taints": ["nvidia.com/gpu-error=fatal:NoSchedule"],
+					"runbookUrl": "https://runbooks.example.com/xid-79", "quarantinedAt": "2025-06-01T11:00:00Z"},
+				"events": [
+					{"checkName": "GpuMemWatch", "errorCodes": ["48"], "message": "DBE", "status": "AlreadyQuarantined",
+						"generatedAt": "2025-06-01T11:30:00Z"},
+					{"checkName": "SysLogsXIDError", "errorCodes": ["79"], "message": "GPU has fallen off the bus",
+						"status": "Quarantined", "generatedAt": "2025-06-01T11:00:00Z"}],
+				"truncated": false}`))
+		case "h100-2":
+			_, _ = w.Write([]byte(`{"nodeName": "h100-2", "quarantined": false, "status": "Cancelled",
+				"truncated": false}`))
+		default:
+			_, _ = w.Write([]byte(`{"nodeName": "` + r.URL.Query().Get("node") + `", "quarantined": false,
+				"truncated": false}`))
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	return server
+}
+
+func TestRunWhy(t *testing.T) {
+	server := newQuarantineServer(t)
+
+	var out bytes.Buffer
+	require.NoError(t, runWhy(context.Background(), []string{"h100-1", "--server", server.URL}, &out))
+
+	assert.Equal(t, `Node h100-1 is quarantined by NVSentinel since 2025-06-01T11:00:00Z
+
+Incident:     01J0000000000000000000000
+Check:        SysLogsXIDError (syslog-health-monitor)
+Error codes:  79
+Message:      GPU has fallen off the bus
+Rule sets:    GPU fatal error ruleset
+Action:       cordoned and tainted
+Taints:       nvidia.com/gpu-error=fatal:NoSchedule
+Runbook:      https://runbooks.example.com/xid-79
+
+Events that kept the node quarantined, the most recent first:
+TIME                  CHECK            ERROR CODES  STATUS              MESSAGE
+2025-06-01T11:30:00Z  GpuMemWatch      48           AlreadyQuarantined  DBE
+2025-06-01T11:00:00Z  SysLogsXIDError  79           Quarantined         GPU has fallen off the bus
+`, out.String())
+}
+
+func TestRunWhyNotQuarantined(t *testing.T) {
+	server := newQuarantineServer(t)
+
+	var out bytes.Buffer
+	require.NoError(t, runWhy(context.Background(), []string{"--server", server.URL, "h100-2"}, &out))
+	assert.Equal(t, `Node h100-2 is not quarantined by NVSentinel, it was uncordoned manually
+If it is unschedulable, it was cordoned by something else, see kubectl describe node h100-2
+`, out.String())
+
+	out.Reset()
+	require.NoError(t, runWhy(context.Background(), []string{"--server", server.URL, "h100-3"}, &out))
+	assert.Contains(t, out.String(), "Node h100-3 was never quarantined by NVSentinel")
+}
+
+func TestRunWhyErrors(t *testing.T) {
+	err := runWhy(context.Background(), []string{"--output", "text"}, &bytes.Buffer{})
+	assert.ErrorContains(t, err, "a node name is required")
+
+	err = runWhy(context.Background(), []string{"h100-1", "--output", "yaml"}, &bytes.Buffer{})
+	assert.ErrorContains(t, err, "invalid output")
+}
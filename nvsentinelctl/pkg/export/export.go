@@ -0,0 +1,370 @@
+// Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package export
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// maxFileSize bounds what is read into memory from a file or archive entry
+const maxFileSize = 2 << 30
+
+type stringList []string
+
+func (l *stringList) String() string {
+	return strings.Join(*l, ",")
+}
+
+func (l *stringList) Set(value string) error {
+	*l = append(*l, value)
+	return nil
+}
+
+type scrubOptions struct {
+	outDir  string
+	config  string
+	mapping string
+	keepIPs bool
+	files   []string
+}
+
+// Scrub runs `export scrub`: it writes copies of events and diagnostic bundles
+// without hostnames, IP addresses and tenant identifiers, e.g. to attach them
+// to a support case.
+func Scrub(ctx context.Context, args []string) error {
+	return runScrub(ctx, args, os.Stdout)
+}
+
+func runScrub(ctx context.Context, args []string, stdout io.Writer) error {
+	var (
+		opts             scrubOptions
+		hostnamePatterns stringList
+		tenantPatterns   stringList
+	)
+
+	flags := flag.NewFlagSet("export scrub", flag.ContinueOnError)
+	flags.StringVar(&opts.outDir, "out-dir", "scrubbed", "Directory the scrubbed files are written to")
+	flags.StringVar(&opts.config, "config", "", "JSON scrub configuration. Scrubs node names, cluster names "+
+		"and IP addresses by default")
+	flags.StringVar(&opts.mapping, "mapping", "", "File to write the original values of the pseudonyms to. "+
+		"Keep it: it holds what was scrubbed")
+	flags.BoolVar(&opts.keepIPs, "keep-ips", false, "Do not scrub IP addresses")
+	flags.Var(&hostnamePatterns, "hostname-pattern", "Regular expression of hostnames to scrub, may be repeated")
+	flags.Var(&tenantPatterns, "tenant-pattern", "Regular expression of tenant identifiers to scrub, may be repeated")
+	flags.Usage = func() {
+		fmt.Fprintln(flags.Output(), "Usage: nvsentinelctl export scrub [flags] FILE...")
+		fmt.Fprintln(flags.Output(), "\nFILE is a JSON or JSON lines export of health events, a text log, "+
+			"a .gz file or a .tar, .tar.gz or .tgz bundle.")
+		flags.PrintDefaults()
+	}
+
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	opts.files = flags.Args()
+	if len(opts.files) == 0 {
+		flags.Usage()
+		return errors.New("no files to scrub")
+	}
+
+	cfg := DefaultConfig()
+
+	if opts.config != "" {
+		file, err := os.Open(opts.config)
+		if err != nil {
+			return fmt.Errorf("failed to open scrub config: %w", err)
+		}
+
+		cfg, err = LoadConfig(file)
+		file.Close()
+
+		if err != nil {
+			return err
+		}
+	}
+
+	cfg.HostnamePatterns = append(cfg.HostnamePatterns, hostnamePatterns...)
+	cfg.TenantPatterns = append(cfg.TenantPatterns, tenantPatterns...)
+	cfg.KeepIPs = cfg.KeepIPs || opts.keepIPs
+
+	scrubber, err := NewScrubber(cfg)
+	if err != nil {
+		return err
+	}
+
+	return scrubFiles(ctx, scrubber, opts, stdout)
+}
+
+func scrubFiles(ctx context.Context, scrubber *Scrubber, opts scrubOptions, stdout io.Writer) error {
+	// The values of the events are learned first, so they are also scrubbed
+	// from the bundles
+	for _, path := range opts.files {
+		if !isJSON(path) {
+			continue
+		}
+
+		data, err := readFile(path)
+		if err != nil {
+			return err
+		}
+
+		if err := scrubber.Learn(data); err != nil {
+			return fmt.Errorf("%s: %w", path, err)
+		}
+	}
+
+	if err := os.MkdirAll(opts.outDir, 0o750); err != nil {
+		return fmt.Errorf("failed to create %s: %w", opts.outDir, err)
+	}
+
+	for _, path := range opts.files {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		out := filepath.Join(opts.outDir, string(scrubber.Scrub([]byte(filepath.Base(path)))))
+
+		if sameFile(path, out) {
+			return fmt.Errorf("%s: refusing to overwrite the input, use another --out-dir", path)
+		}
+
+		if err := scrubFile(scrubber, path, out); err != nil {
+			return fmt.Errorf("%s: %w", path, err)
+		}
+
+		fmt.Fprintf(stdout, "%s -> %s\n", path, out)
+	}
+
+	if opts.mapping == "" {
+		return nil
+	}
+
+	data, err := json.MarshalIndent(scrubber.Mapping(), "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode mapping: %w", err)
+	}
+
+	if err := os.WriteFile(opts.mapping, append(data, '\n'), 0o600); err != nil {
+		return fmt.Errorf("failed to write mapping: %w", err)
+	}
+
+	fmt.Fprintf(stdout, "Pseudonyms written to %s, do not share it\n", opts.mapping)
+
+	return nil
+}
+
+func scrubFile(scrubber *Scrubber, path, out string) error {
+	name := strings.ToLower(path)
+
+	switch {
+	case strings.HasSuffix(name, ".tar.gz") || strings.HasSuffix(name, ".tgz"):
+		return rewrite(path, out, func(r io.Reader, w io.Writer) error {
+			gzipReader, err := gzip.NewReader(r)
+			if err != nil {
+				return err
+			}
+
+			gzipWriter := gzip.NewWriter(w)
+
+			if err := scrubTar(scrubber, gzipReader, gzipWriter); err != nil {
+				return err
+			}
+
+			return gzipWriter.Close()
+		})
+	case strings.HasSuffix(name, ".tar"):
+		return rewrite(path, out, func(r io.Reader, w io.Writer) error {
+			return scrubTar(scrubber, r, w)
+		})
+	case strings.HasSuffix(name, ".gz"):
+		return rewrite(path, out, func(r io.Reader, w io.Writer) error {
+			gzipReader, err := gzip.NewReader(r)
+			if err != nil {
+				return err
+			}
+
+			data, err := readAll(gzipReader)
+			if err != nil {
+				return err
+			}
+
+			gzipWriter := gzip.NewWriter(w)
+
+			if _, err := gzipWriter.Write(scrubber.Scrub(data)); err != nil {
+				return err
+			}
+
+			return gzipWriter.Close()
+		})
+	case strings.HasSuffix(name, ".xz") || strings.HasSuffix(name, ".bz2") || strings.HasSuffix(name, ".zip"):
+		return errors.New("unsupported compression, extract the archive and pass its files or a .tar of them")
+	case isJSON(path):
+		return rewrite(path, out, func(r io.Reader, w io.Writer) error {
+			data, err := readAll(r)
+			if err != nil {
+				return err
+			}
+
+			scrubbed, err := scrubber.ScrubJSON(data)
+			if err != nil {
+				return err
+			}
+
+			_, err = w.Write(scrubbed)
+
+			return err
+		})
+	default:
+		return rewrite(path, out, func(r io.Reader, w io.Writer) error {
+			data, err := readAll(r)
+			if err != nil {
+				return err
+			}
+
+			_, err = w.Write(scrubber.Scrub(data))
+
+			return err
+		})
+	}
+}
+
+// scrubTar scrubs the names, link targets and contents of the entries of a tar archive.
+func scrubTar(scrubber *Scrubber, r io.Reader, w io.Writer) error {
+	reader := tar.NewReader(r)
+	writer := tar.NewWriter(w)
+
+	for {
+		header, err := reader.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+
+		if err != nil {
+			return fmt.Errorf("failed to read archive: %w", err)
+		}
+
+		header.Name = string(scrubber.Scrub([]byte(header.Name)))
+		header.Linkname = string(scrubber.Scrub([]byte(header.Linkname)))
+		header.Uname = ""
+		header.Gname = ""
+		header.PAXRecords = nil
+
+		var data []byte
+
+		if header.Size > 0 {
+			if data, err = readAll(reader); err != nil {
+				return err
+			}
+
+			data = scrubber.Scrub(data)
+			header.Typeflag = tar.TypeReg
+		}
+
+		header.Size = int64(len(data))
+
+		if err := writer.WriteHeader(header); err != nil {
+			return fmt.Errorf("failed to write archive: %w", err)
+		}
+
+		if _, err := writer.Write(data); err != nil {
+			return fmt.Errorf("failed to write archive: %w", err)
+		}
+	}
+
+	return writer.Close()
+}
+
+// rewrite writes the scrubbed copy of path to out, removing it if scrubbing fails
+// so that no partially scrubbed file is left to share.
+func rewrite(path, out string, scrub func(io.Reader, io.Writer) error) error {
+	in, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+
+	file, err := os.OpenFile(out, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0o600)
+	if err != nil {
+		return err
+	}
+
+	err = scrub(in, file)
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+
+	if err != nil {
+		os.Remove(out)
+		return err
+	}
+
+	return nil
+}
+
+func readFile(path string) ([]byte, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	return readAll(file)
+}
+
+func readAll(r io.Reader) ([]byte, error) {
+	var buf bytes.Buffer
+
+	n, err := io.Copy(&buf, io.LimitReader(r, maxFileSize+1))
+	if err != nil {
+		return nil, err
+	}
+
+	if n > maxFileSize {
+		return nil, fmt.Errorf("file larger than %d bytes", maxFileSize)
+	}
+
+	return buf.Bytes(), nil
+}
+
+func isJSON(path string) bool {
+	name := strings.ToLower(path)
+	return strings.HasSuffix(name, ".json") || strings.HasSuffix(name, ".jsonl")
+}
+
+func sameFile(a, b string) bool {
+	infoA, err := os.Stat(a)
+	if err != nil {
+		return false
+	}
+
+	infoB, err := os.Stat(b)
+	if err != nil {
+		return false
+	}
+
+	return os.SameFile(infoA, infoB)
+}
@@ -0,0 +1,378 @@
+// Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package export implements the export commands of nvsentinelctl.
+package export
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// Config is what the scrubber removes. Hostnames and tenant identifiers are
+// learned from the values of the JSON keys of the events and matched by the
+// patterns in every file; IP addresses are detected unless KeepIPs is set.
+type Config struct {
+	// HostnameKeys are JSON keys, compared case-insensitively, whose values are hostnames
+	HostnameKeys []string `json:"hostnameKeys"`
+	// HostnamePatterns are regular expressions of hostnames, e.g. of the internal domain
+	HostnamePatterns []string `json:"hostnamePatterns"`
+	// TenantKeys are JSON keys, compared case-insensitively, whose values identify tenants
+	TenantKeys []string `json:"tenantKeys"`
+	// TenantPatterns are regular expressions of tenant identifiers
+	TenantPatterns []string `json:"tenantPatterns"`
+	KeepIPs        bool     `json:"keepIPs"`
+}
+
+// DefaultConfig scrubs the node names of events, their cluster metadata and IP addresses.
+func DefaultConfig() Config {
+	return Config{
+		HostnameKeys: []string{"nodename", "node_name", "hostname"},
+		TenantKeys:   []string{"cluster", "tenant", "tenant_id", "tenantid"},
+	}
+}
+
+// LoadConfig reads a scrub configuration. Unset keys keep their default.
+func LoadConfig(r io.Reader) (Config, error) {
+	cfg := DefaultConfig()
+
+	decoder := json.NewDecoder(r)
+	decoder.DisallowUnknownFields()
+
+	if err := decoder.Decode(&cfg); err != nil {
+		return Config{}, fmt.Errorf("failed to decode scrub config: %w", err)
+	}
+
+	return cfg, nil
+}
+
+const (
+	kindHost   = "host"
+	kindTenant = "tenant"
+	kindIP     = "ip"
+)
+
+var (
+	ipv4Pattern = regexp.MustCompile(`[0-9]{1,3}(?:\.[0-9]{1,3}){3}`)
+	ipv6Pattern = regexp.MustCompile(`[0-9A-Fa-f]{0,4}(?::[0-9A-Fa-f]{0,4}){2,7}`)
+)
+
+// Scrubber replaces hostnames, tenant identifiers and IP addresses with
+// pseudonyms such as host-1, tenant-1 and ip-1. The same value gets the same
+// pseudonym in every file, so events and bundles of an incident can still be
+// correlated.
+type Scrubber struct {
+	cfg            Config
+	hostnameKeys   map[string]bool
+	tenantKeys     map[string]bool
+	hostPatterns   []*regexp.Regexp
+	tenantPatterns []*regexp.Regexp
+
+	// pseudonyms maps the scrubbed values to their pseudonym
+	pseudonyms map[string]string
+	counts     map[string]int
+	// literals matches the values learned from the events
+	literals *regexp.Regexp
+	kinds    map[string]string
+}
+
+// NewScrubber creates a Scrubber.
+func NewScrubber(cfg Config) (*Scrubber, error) {
+	s := &Scrubber{
+		cfg:          cfg,
+		hostnameKeys: lowerSet(cfg.HostnameKeys),
+		tenantKeys:   lowerSet(cfg.TenantKeys),
+		pseudonyms:   map[string]string{},
+		counts:       map[string]int{},
+		kinds:        map[string]string{},
+	}
+
+	for _, pattern := range cfg.HostnamePatterns {
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			return nil, fmt.Errorf("invalid hostname pattern %q: %w", pattern, err)
+		}
+
+		s.hostPatterns = append(s.hostPatterns, re)
+	}
+
+	for _, pattern := range cfg.TenantPatterns {
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			return nil, fmt.Errorf("invalid tenant pattern %q: %w", pattern, err)
+		}
+
+		s.tenantPatterns = append(s.tenantPatterns, re)
+	}
+
+	return s, nil
+}
+
+func lowerSet(values []string) map[string]bool {
+	set := make(map[string]bool, len(values))
+	for _, value := range values {
+		set[strings.ToLower(value)] = true
+	}
+
+	return set
+}
+
+// Learn records the hostnames and tenant identifiers in the values of the
+// configured keys of JSON documents, so they are also scrubbed where they
+// appear in free text. data holds one or more JSON values.
+func (s *Scrubber) Learn(data []byte) error {
+	values, err := decodeJSON(data)
+	if err != nil {
+		return err
+	}
+
+	for _, value := range values {
+		s.learn("", value)
+	}
+
+	s.literals = nil
+
+	return nil
+}
+
+func (s *Scrubber) learn(key string, value any) {
+	switch v := value.(type) {
+	case map[string]any:
+		for k, child := range v {
+			s.learn(k, child)
+		}
+	case []any:
+		for _, child := range v {
+			s.learn(key, child)
+		}
+	case string:
+		if v == "" {
+			return
+		}
+
+		switch {
+		case s.hostnameKeys[strings.ToLower(key)]:
+			s.kinds[v] = kindHost
+		case s.tenantKeys[strings.ToLower(key)]:
+			s.kinds[v] = kindTenant
+		}
+	}
+}
+
+// Scrub returns data with the hostnames, tenant identifiers and IP addresses replaced.
+func (s *Scrubber) Scrub(data []byte) []byte {
+	if literals := s.literalPattern(); literals != nil {
+		data = replaceBounded(literals, data, isWordByte, func(match []byte) []byte {
+			return []byte(s.pseudonym(s.kinds[string(match)], string(match)))
+		})
+	}
+
+	for _, re := range s.hostPatterns {
+		data = replaceBounded(re, data, isWordByte, func(match []byte) []byte {
+			return []byte(s.pseudonym(kindHost, string(match)))
+		})
+	}
+
+	for _, re := range s.tenantPatterns {
+		data = replaceBounded(re, data, isWordByte, func(match []byte) []byte {
+			return []byte(s.pseudonym(kindTenant, string(match)))
+		})
+	}
+
+	if !s.cfg.KeepIPs {
+		data = replaceBounded(ipv4Pattern, data, isIPv4Byte, s.scrubIP)
+		data = replaceBounded(ipv6Pattern, data, isIPv6Byte, s.scrubIP)
+	}
+
+	return data
+}
+
+// ScrubJSON scrubs the keys and string values of JSON documents. The
+// documents are written one per line.
+func (s *Scrubber) ScrubJSON(data []byte) ([]byte, error) {
+	values, err := decodeJSON(data)
+	if err != nil {
+		return nil, err
+	}
+
+	var out bytes.Buffer
+
+	encoder := json.NewEncoder(&out)
+	encoder.SetEscapeHTML(false)
+
+	for _, value := range values {
+		if err := encoder.Encode(s.scrubValue(value)); err != nil {
+			return nil, fmt.Errorf("failed to encode scrubbed document: %w", err)
+		}
+	}
+
+	return out.Bytes(), nil
+}
+
+func (s *Scrubber) scrubValue(value any) any {
+	switch v := value.(type) {
+	case map[string]any:
+		scrubbed := make(map[string]any, len(v))
+		for key, child := range v {
+			scrubbed[string(s.Scrub([]byte(key)))] = s.scrubValue(child)
+		}
+
+		return scrubbed
+	case []any:
+		for i, child := range v {
+			v[i] = s.scrubValue(child)
+		}
+
+		return v
+	case string:
+		return string(s.Scrub([]byte(v)))
+	default:
+		return v
+	}
+}
+
+// Mapping returns the original of every pseudonym. It must stay with the
+// operator: it is what the scrubbing removed.
+func (s *Scrubber) Mapping() map[string]string {
+	mapping := make(map[string]string, len(s.pseudonyms))
+	for original, pseudonym := range s.pseudonyms {
+		mapping[pseudonym] = original
+	}
+
+	return mapping
+}
+
+func (s *Scrubber) pseudonym(kind, original string) string {
+	if pseudonym, ok := s.pseudonyms[original]; ok {
+		return pseudonym
+	}
+
+	s.counts[kind]++
+	pseudonym := fmt.Sprintf("%s-%d", kind, s.counts[kind])
+	s.pseudonyms[original] = pseudonym
+
+	return pseudonym
+}
+
+func (s *Scrubber) scrubIP(match []byte) []byte {
+	ip := net.ParseIP(string(match))
+	if ip == nil || ip.IsLoopback() || ip.IsUnspecified() {
+		return match
+	}
+
+	return []byte(s.pseudonym(kindIP, ip.String()))
+}
+
+// literalPattern matches the learned values, longest first so that a value
+// containing another one is replaced as a whole.
+func (s *Scrubber) literalPattern() *regexp.Regexp {
+	if s.literals != nil || len(s.kinds) == 0 {
+		return s.literals
+	}
+
+	values := make([]string, 0, len(s.kinds))
+	for value := range s.kinds {
+		values = append(values, value)
+	}
+
+	sort.Slice(values, func(i, j int) bool {
+		if len(values[i]) != len(values[j]) {
+			return len(values[i]) > len(values[j])
+		}
+
+		return values[i] < values[j]
+	})
+
+	for i, value := range values {
+		values[i] = regexp.QuoteMeta(value)
+	}
+
+	s.literals = regexp.MustCompile(strings.Join(values, "|"))
+
+	return s.literals
+}
+
+// replaceBounded replaces the matches of re that are not part of a longer
+// token, i.e. not preceded or followed by a byte for which inToken is true.
+func replaceBounded(re *regexp.Regexp, data []byte, inToken func(byte) bool,
+	replace func([]byte) []byte) []byte {
+	matches := re.FindAllIndex(data, -1)
+	if len(matches) == 0 {
+		return data
+	}
+
+	var out bytes.Buffer
+
+	last := 0
+
+	for _, match := range matches {
+		start, end := match[0], match[1]
+		if start == end ||
+			(start > 0 && inToken(data[start-1])) ||
+			(end < len(data) && inToken(data[end])) {
+			continue
+		}
+
+		out.Write(data[last:start])
+		out.Write(replace(data[start:end]))
+		last = end
+	}
+
+	out.Write(data[last:])
+
+	return out.Bytes()
+}
+
+func isWordByte(b byte) bool {
+	return b >= 'a' && b <= 'z' || b >= 'A' && b <= 'Z' || b >= '0' && b <= '9'
+}
+
+func isIPv4Byte(b byte) bool {
+	return b >= '0' && b <= '9' || b == '.'
+}
+
+func isIPv6Byte(b byte) bool {
+	return b >= 'a' && b <= 'f' || b >= 'A' && b <= 'F' || b >= '0' && b <= '9' || b == ':'
+}
+
+// decodeJSON decodes a JSON document, a JSON array or JSON lines such as the
+// output of mongoexport.
+func decodeJSON(data []byte) ([]any, error) {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+
+	var values []any
+
+	for {
+		var value any
+
+		err := decoder.Decode(&value)
+		if errors.Is(err, io.EOF) {
+			return values, nil
+		}
+
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode JSON: %w", err)
+		}
+
+		values = append(values, value)
+	}
+}
@@ -0,0 +1,174 @@
+// Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package export
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const events = `{"healthevent": {"nodename": "gpu-node-42", "message": "Xid 79 on gpu-node-42 (10.1.2.3)",
+  "metadata": {"cluster": "acme-prod-east"}}}
+{"healthevent": {"nodename": "gpu-node-4", "message": "peer gpu-node-42 unreachable at fd00::42"}}
+`
+
+func TestScrubber(t *testing.T) {
+	scrubber, err := NewScrubber(DefaultConfig())
+	require.NoError(t, err)
+	require.NoError(t, scrubber.Learn([]byte(events)))
+
+	scrubbed, err := scrubber.ScrubJSON([]byte(events))
+	require.NoError(t, err)
+
+	lines := strings.Split(strings.TrimSpace(string(scrubbed)), "\n")
+	require.Len(t, lines, 2)
+
+	var first, second map[string]map[string]any
+
+	require.NoError(t, json.Unmarshal([]byte(lines[0]), &first))
+	require.NoError(t, json.Unmarshal([]byte(lines[1]), &second))
+
+	host42 := first["healthevent"]["nodename"]
+	assert.Regexp(t, `^host-\d$`, host42)
+	assert.Equal(t, "Xid 79 on "+host42.(string)+" (ip-1)", first["healthevent"]["message"])
+	assert.Equal(t, map[string]any{"cluster": "tenant-1"}, first["healthevent"]["metadata"])
+	assert.NotEqual(t, host42, second["healthevent"]["nodename"], "gpu-node-4 is another host")
+	assert.Equal(t, "peer "+host42.(string)+" unreachable at ip-2", second["healthevent"]["message"])
+
+	text := scrubber.Scrub([]byte("gpu-node-42.corp.example.com 127.0.0.1 driver 570.86.15 PCI:0000:b3:00.0 " +
+		"gpu-node-420 10:15:35 10.1.2.3:8080"))
+	assert.Equal(t, host42.(string)+".corp.example.com 127.0.0.1 driver 570.86.15 PCI:0000:b3:00.0 "+
+		"gpu-node-420 10:15:35 ip-1:8080", string(text))
+
+	mapping := scrubber.Mapping()
+	assert.Equal(t, "gpu-node-42", mapping[host42.(string)])
+	assert.Equal(t, "acme-prod-east", mapping["tenant-1"])
+	assert.Equal(t, "10.1.2.3", mapping["ip-1"])
+	assert.Equal(t, "fd00::42", mapping["ip-2"])
+}
+
+func TestScrubberPatterns(t *testing.T) {
+	scrubber, err := NewScrubber(Config{
+		HostnamePatterns: []string{`[a-z0-9-]+\.corp\.example\.com`},
+		TenantPatterns:   []string{`acme-[a-z]+`},
+		KeepIPs:          true,
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, "login to host-1 by tenant-1 from 10.0.0.1",
+		string(scrubber.Scrub([]byte("login to gpu-7.corp.example.com by acme-research from 10.0.0.1"))))
+
+	_, err = NewScrubber(Config{TenantPatterns: []string{"("}})
+	assert.Error(t, err)
+}
+
+func TestLoadConfig(t *testing.T) {
+	cfg, err := LoadConfig(strings.NewReader(`{"tenantPatterns": ["acme-[a-z]+"], "keepIPs": true}`))
+	require.NoError(t, err)
+	assert.Equal(t, DefaultConfig().HostnameKeys, cfg.HostnameKeys)
+	assert.Equal(t, []string{"acme-[a-z]+"}, cfg.TenantPatterns)
+	assert.True(t, cfg.KeepIPs)
+
+	_, err = LoadConfig(strings.NewReader(`{"tenants": []}`))
+	assert.Error(t, err)
+}
+
+func TestRunScrub(t *testing.T) {
+	dir := t.TempDir()
+	outDir := filepath.Join(dir, "out")
+
+	eventsPath := filepath.Join(dir, "events.jsonl")
+	require.NoError(t, os.WriteFile(eventsPath, []byte(events), 0o600))
+
+	var bundle bytes.Buffer
+
+	gzipWriter := gzip.NewWriter(&bundle)
+	tarWriter := tar.NewWriter(gzipWriter)
+	content := []byte("kernel: NVRM: Xid 79 on gpu-node-42, link 10.1.2.3\n")
+	require.NoError(t, tarWriter.WriteHeader(&tar.Header{Name: "sos-gpu-node-42/messages", Mode: 0o644,
+		Size: int64(len(content)), Typeflag: tar.TypeReg}))
+	_, err := tarWriter.Write(content)
+	require.NoError(t, err)
+	require.NoError(t, tarWriter.Close())
+	require.NoError(t, gzipWriter.Close())
+
+	bundlePath := filepath.Join(dir, "must-gather-gpu-node-42.tar.gz")
+	require.NoError(t, os.WriteFile(bundlePath, bundle.Bytes(), 0o600))
+
+	mappingPath := filepath.Join(dir, "mapping.json")
+
+	var stdout bytes.Buffer
+
+	require.NoError(t, runScrub(context.Background(), []string{"--out-dir", outDir, "--mapping", mappingPath,
+		bundlePath, eventsPath}, &stdout))
+
+	var mapping map[string]string
+
+	data, err := os.ReadFile(mappingPath)
+	require.NoError(t, err)
+	require.NoError(t, json.Unmarshal(data, &mapping))
+
+	var host42 string
+
+	for pseudonym, original := range mapping {
+		if original == "gpu-node-42" {
+			host42 = pseudonym
+		}
+	}
+
+	require.NotEmpty(t, host42, "node names are learned from the events before the bundle is scrubbed")
+
+	file, err := os.Open(filepath.Join(outDir, "must-gather-"+host42+".tar.gz"))
+	require.NoError(t, err)
+	defer file.Close()
+
+	gzipReader, err := gzip.NewReader(file)
+	require.NoError(t, err)
+
+	tarReader := tar.NewReader(gzipReader)
+	header, err := tarReader.Next()
+	require.NoError(t, err)
+	assert.Equal(t, "sos-"+host42+"/messages", header.Name)
+
+	scrubbed, err := io.ReadAll(tarReader)
+	require.NoError(t, err)
+	assert.Equal(t, "kernel: NVRM: Xid 79 on "+host42+", link ip-1\n", string(scrubbed))
+
+	events, err := os.ReadFile(filepath.Join(outDir, "events.jsonl"))
+	require.NoError(t, err)
+	assert.NotContains(t, string(events), "gpu-node-42")
+	assert.NotContains(t, string(events), "acme-prod-east")
+	assert.Contains(t, stdout.String(), "do not share it")
+
+	err = runScrub(context.Background(), []string{"--out-dir", dir, eventsPath}, &stdout)
+	assert.ErrorContains(t, err, "refusing to overwrite the input")
+
+	xz := filepath.Join(dir, "sosreport.tar.xz")
+	require.NoError(t, os.WriteFile(xz, []byte("xz"), 0o600))
+	assert.ErrorContains(t, runScrub(context.Background(), []string{"--out-dir", outDir, xz}, &stdout),
+		"unsupported compression")
+	assert.NoFileExists(t, filepath.Join(outDir, "sosreport.tar.xz"))
+}
@@ -0,0 +1,245 @@
+// Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package login signs in to the identity provider of the NVSentinel APIs with the
+// device code flow and caches the ID token the other commands send by default.
+package login
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/nvidia/nvsentinel/commons/pkg/auth"
+)
+
+// cacheEnv overrides where the token is cached
+const cacheEnv = "NVSENTINEL_TOKEN_CACHE"
+
+// cachedToken is the cached sign-in, with the provider needed to refresh it.
+type cachedToken struct {
+	IssuerURL string   `json:"issuer_url"`
+	ClientID  string   `json:"client_id"`
+	Scopes    []string `json:"scopes,omitempty"`
+	auth.DeviceToken
+}
+
+type loginOptions struct {
+	issuer   string
+	clientID string
+	scopes   string
+	timeout  time.Duration
+}
+
+// Login runs `login`: it signs in with the device code flow, the user completes the
+// sign-in in a browser, and caches the ID token for the other commands.
+func Login(ctx context.Context, args []string) error {
+	return runLogin(ctx, args, os.Stdout)
+}
+
+func runLogin(ctx context.Context, args []string, stdout io.Writer) error {
+	var opts loginOptions
+
+	flags := flag.NewFlagSet("login", flag.ContinueOnError)
+	flags.StringVar(&opts.issuer, "issuer", os.Getenv("NVSENTINEL_OIDC_ISSUER"),
+		"Issuer URL of the identity provider configured in apiAuth.oidc, $NVSENTINEL_OIDC_ISSUER by default")
+	flags.StringVar(&opts.clientID, "client-id", envOr("NVSENTINEL_OIDC_CLIENT_ID", "nvsentinel"),
+		"Client ID configured in apiAuth.oidc, $NVSENTINEL_OIDC_CLIENT_ID by default")
+	flags.StringVar(&opts.scopes, "scopes", "offline_access",
+		"Comma separated scopes requested besides openid, offline_access lets the token be refreshed")
+	flags.DurationVar(&opts.timeout, "timeout", 10*time.Minute, "Time to complete the sign-in")
+
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	if opts.issuer == "" {
+		return fmt.Errorf("--issuer is required")
+	}
+
+	flow := &auth.DeviceCodeFlow{IssuerURL: opts.issuer, ClientID: opts.clientID, Scopes: splitScopes(opts.scopes)}
+
+	ctx, cancel := context.WithTimeout(ctx, opts.timeout)
+	defer cancel()
+
+	authorization, err := flow.Start(ctx)
+	if err != nil {
+		return err
+	}
+
+	if authorization.VerificationURIComplete != "" {
+		fmt.Fprintf(stdout, "Open %s to sign in, the code is %s\n",
+			authorization.VerificationURIComplete, authorization.UserCode)
+	} else {
+		fmt.Fprintf(stdout, "Open %s and enter the code %s\n", authorization.VerificationURI, authorization.UserCode)
+	}
+
+	token, err := flow.Wait(ctx, authorization)
+	if err != nil {
+		return err
+	}
+
+	path, err := cachePath()
+	if err != nil {
+		return err
+	}
+
+	if err := save(path, &cachedToken{
+		IssuerURL:   flow.IssuerURL,
+		ClientID:    flow.ClientID,
+		Scopes:      flow.Scopes,
+		DeviceToken: *token,
+	}); err != nil {
+		return err
+	}
+
+	fmt.Fprintf(stdout, "Signed in, the token is cached in %s\n", path)
+
+	return nil
+}
+
+// BearerToken returns the token a command sends: the one given with --token or
+// $NVSENTINEL_TOKEN, otherwise the cached one of `nvsentinelctl login`, refreshed
+// when it expired. Without either it returns an empty token.
+func BearerToken(ctx context.Context, token string) (string, error) {
+	if token != "" {
+		return token, nil
+	}
+
+	path, err := cachePath()
+	if err != nil {
+		return "", err
+	}
+
+	cached, err := load(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return "", nil
+	} else if err != nil {
+		return "", err
+	}
+
+	if !cached.Expired() {
+		return cached.IDToken, nil
+	}
+
+	if cached.RefreshToken == "" {
+		return "", fmt.Errorf("the cached token expired, run nvsentinelctl login again")
+	}
+
+	flow := &auth.DeviceCodeFlow{IssuerURL: cached.IssuerURL, ClientID: cached.ClientID, Scopes: cached.Scopes}
+
+	refreshed, err := flow.Refresh(ctx, &cached.DeviceToken)
+	if err != nil {
+		return "", fmt.Errorf("failed to refresh the cached token, run nvsentinelctl login again: %w", err)
+	}
+
+	cached.DeviceToken = *refreshed
+
+	if err := save(path, cached); err != nil {
+		return "", err
+	}
+
+	return cached.IDToken, nil
+}
+
+// cachePath is $NVSENTINEL_TOKEN_CACHE, or token.json in the nvsentinelctl directory
+// of the user's cache directory.
+func cachePath() (string, error) {
+	if path := os.Getenv(cacheEnv); path != "" {
+		return path, nil
+	}
+
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to find the token cache, set $%s: %w", cacheEnv, err)
+	}
+
+	return filepath.Join(dir, "nvsentinelctl", "token.json"), nil
+}
+
+func load(path string) (*cachedToken, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var cached cachedToken
+	if err := json.Unmarshal(data, &cached); err != nil {
+		return nil, fmt.Errorf("failed to decode the token cache %s: %w", path, err)
+	}
+
+	return &cached, nil
+}
+
+// save writes the token readable by the user only, through a temporary file so a
+// concurrent command never reads half of it.
+func save(path string, cached *cachedToken) error {
+	data, err := json.Marshal(cached)
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return fmt.Errorf("failed to create the token cache: %w", err)
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".token-*")
+	if err != nil {
+		return fmt.Errorf("failed to write the token cache: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to write the token cache: %w", err)
+	}
+
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to write the token cache: %w", err)
+	}
+
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return fmt.Errorf("failed to write the token cache: %w", err)
+	}
+
+	return nil
+}
+
+func splitScopes(scopes string) []string {
+	var result []string
+
+	for _, scope := range strings.Split(scopes, ",") {
+		if scope = strings.TrimSpace(scope); scope != "" {
+			result = append(result, scope)
+		}
+	}
+
+	return result
+}
+
+func envOr(key, fallback string) string {
+	if value := os.Getenv(key); value != "" {
+		return value
+	}
+
+	return fallback
+}
@@ -0,0 +1,196 @@
+// Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package login
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newProvider serves the discovery document, the device flow and refreshes. The
+// ID tokens are unsigned JWTs, the command never verifies them.
+func newProvider(t *testing.T) *httptest.Server {
+	t.Helper()
+
+	var server *httptest.Server
+
+	refreshes := 0
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("/.well-known/openid-configuration", func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode(map[string]string{
+			"issuer":                        server.URL,
+			"token_endpoint":                server.URL + "/token",
+			"device_authorization_endpoint": server.URL + "/device",
+		})
+	})
+	mux.HandleFunc("/device", func(w http.ResponseWriter, r *http.Request) {
+		require.NoError(t, r.ParseForm())
+		assert.Equal(t, "openid offline_access", r.PostForm.Get("scope"))
+
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"device_code":      "device-code",
+			"user_code":        "ABCD-EFGH",
+			"verification_uri": server.URL + "/activate",
+			"interval":         1,
+		})
+	})
+	mux.HandleFunc("/token", func(w http.ResponseWriter, r *http.Request) {
+		require.NoError(t, r.ParseForm())
+
+		switch r.PostForm.Get("grant_type") {
+		case "refresh_token":
+			if r.PostForm.Get("refresh_token") != "refresh-token" {
+				w.WriteHeader(http.StatusBadRequest)
+				_ = json.NewEncoder(w).Encode(map[string]string{"error": "invalid_grant"})
+
+				return
+			}
+
+			refreshes++
+			_ = json.NewEncoder(w).Encode(map[string]string{
+				"id_token": idToken(fmt.Sprintf("refreshed-%d", refreshes), time.Hour),
+			})
+		default:
+			_ = json.NewEncoder(w).Encode(map[string]string{
+				"id_token":      idToken("signed-in", time.Hour),
+				"refresh_token": "refresh-token",
+			})
+		}
+	})
+
+	server = httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	return server
+}
+
+func idToken(subject string, validFor time.Duration) string {
+	encode := func(v any) string {
+		data, _ := json.Marshal(v)
+		return base64.RawURLEncoding.EncodeToString(data)
+	}
+
+	return encode(map[string]string{"alg": "none"}) + "." +
+		encode(map[string]any{"sub": subject, "exp": time.Now().Add(validFor).Unix()}) + "."
+}
+
+func subject(t *testing.T, token string) string {
+	t.Helper()
+
+	parts := strings.Split(token, ".")
+	require.Len(t, parts, 3)
+
+	payload, err := base64.RawURLEncoding.DecodeString(parts[1])
+	require.NoError(t, err)
+
+	var claims struct {
+		Subject string `json:"sub"`
+	}
+	require.NoError(t, json.Unmarshal(payload, &claims))
+
+	return claims.Subject
+}
+
+func TestLogin(t *testing.T) {
+	provider := newProvider(t)
+	path := filepath.Join(t.TempDir(), "token.json")
+	t.Setenv(cacheEnv, path)
+
+	var out bytes.Buffer
+
+	require.NoError(t, runLogin(context.Background(), []string{"--issuer", provider.URL}, &out))
+	assert.Contains(t, out.String(), "Open "+provider.URL+"/activate and enter the code ABCD-EFGH")
+
+	info, err := os.Stat(path)
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0o600), info.Mode().Perm())
+
+	token, err := BearerToken(context.Background(), "")
+	require.NoError(t, err)
+	assert.Equal(t, "signed-in", subject(t, token))
+
+	// an explicit token wins over the cached one
+	token, err = BearerToken(context.Background(), "explicit")
+	require.NoError(t, err)
+	assert.Equal(t, "explicit", token)
+
+	err = runLogin(context.Background(), nil, &out)
+	assert.ErrorContains(t, err, "--issuer is required")
+}
+
+func TestBearerToken_Refresh(t *testing.T) {
+	provider := newProvider(t)
+	path := filepath.Join(t.TempDir(), "token.json")
+	t.Setenv(cacheEnv, path)
+
+	require.NoError(t, save(path, &cachedToken{IssuerURL: provider.URL, ClientID: "nvsentinel"}))
+	expire := func(refreshToken string) {
+		cached, err := load(path)
+		require.NoError(t, err)
+
+		cached.IDToken = "expired"
+		cached.RefreshToken = refreshToken
+		cached.Expiry = time.Now().Add(-time.Minute)
+		require.NoError(t, save(path, cached))
+	}
+
+	expire("refresh-token")
+
+	token, err := BearerToken(context.Background(), "")
+	require.NoError(t, err)
+	assert.Equal(t, "refreshed-1", subject(t, token))
+
+	// the refreshed token is cached and used until it expires
+	again, err := BearerToken(context.Background(), "")
+	require.NoError(t, err)
+	assert.Equal(t, token, again)
+
+	cached, err := load(path)
+	require.NoError(t, err)
+	assert.Equal(t, "refresh-token", cached.RefreshToken)
+	assert.False(t, cached.Expired())
+
+	expire("revoked")
+
+	_, err = BearerToken(context.Background(), "")
+	assert.ErrorContains(t, err, "run nvsentinelctl login again")
+
+	expire("")
+
+	_, err = BearerToken(context.Background(), "")
+	assert.ErrorContains(t, err, "the cached token expired")
+}
+
+func TestBearerToken_NotLoggedIn(t *testing.T) {
+	t.Setenv(cacheEnv, filepath.Join(t.TempDir(), "token.json"))
+
+	token, err := BearerToken(context.Background(), "")
+	require.NoError(t, err)
+	assert.Empty(t, token)
+}
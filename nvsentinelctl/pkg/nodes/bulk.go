@@ -0,0 +1,363 @@
+// Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package nodes implements the node commands of nvsentinelctl.
+package nodes
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/nvidia/nvsentinel/nvsentinelctl/pkg/login"
+)
+
+// bulkPath is where janitor serves bulk node operations
+const bulkPath = "/api/v1/nodes/bulk"
+
+// bulkRequest mirrors the bulk request of the janitor API.
+type bulkRequest struct {
+	Verb      string   `json:"verb"`
+	Nodes     []string `json:"nodes,omitempty"`
+	Selector  string   `json:"selector,omitempty"`
+	Reason    string   `json:"reason,omitempty"`
+	BatchSize int      `json:"batchSize,omitempty"`
+}
+
+// bulkStart mirrors the first line of the bulk response.
+type bulkStart struct {
+	Verb  string `json:"verb"`
+	Total int    `json:"total"`
+}
+
+// bulkResult mirrors the lines of the bulk response after the first.
+type bulkResult struct {
+	Node      string `json:"node"`
+	Succeeded bool   `json:"succeeded"`
+	Error     string `json:"error,omitempty"`
+}
+
+// bulkSummary is printed with --output json.
+type bulkSummary struct {
+	Verb      string       `json:"verb"`
+	Total     int          `json:"total"`
+	Succeeded int          `json:"succeeded"`
+	Failed    int          `json:"failed"`
+	Results   []bulkResult `json:"results"`
+}
+
+type bulkOptions struct {
+	server    string
+	token     string
+	nodes     string
+	file      string
+	selector  string
+	reason    string
+	batchSize int
+	output    string
+	timeout   time.Duration
+}
+
+// Release runs `nodes release`: it uncordons the nodes, which fault quarantine
+// handles as a manual uncordon.
+func Release(ctx context.Context, args []string) error {
+	return runBulk(ctx, "release", args, os.Stdin, os.Stdout)
+}
+
+// Silence runs `nodes silence`: it switches enforcement off for the nodes.
+func Silence(ctx context.Context, args []string) error {
+	return runBulk(ctx, "silence", args, os.Stdin, os.Stdout)
+}
+
+// Unsilence runs `nodes unsilence`: it switches enforcement back on for the nodes.
+func Unsilence(ctx context.Context, args []string) error {
+	return runBulk(ctx, "unsilence", args, os.Stdin, os.Stdout)
+}
+
+// Reverify runs `nodes reverify`: it has janitor check the node actions of the
+// nodes in Verifying again right away.
+func Reverify(ctx context.Context, args []string) error {
+	return runBulk(ctx, "reverify", args, os.Stdin, os.Stdout)
+}
+
+// Quarantine runs `nodes quarantine`: it cordons the nodes. Release them to undo it.
+func Quarantine(ctx context.Context, args []string) error {
+	return runBulk(ctx, "quarantine", args, os.Stdin, os.Stdout)
+}
+
+// Approve runs `nodes approve`: it lets janitor perform the node actions of the
+// nodes waiting for an outside actor in manual mode.
+func Approve(ctx context.Context, args []string) error {
+	return runBulk(ctx, "approve", args, os.Stdin, os.Stdout)
+}
+
+// Apply applies the verb to the nodes through the janitor API at server, without
+// printing progress. The error lists the nodes the verb failed on.
+func Apply(ctx context.Context, server, token, verb, reason string, nodes []string, timeout time.Duration) error {
+	opts := bulkOptions{server: server, token: token, timeout: timeout}
+	request := bulkRequest{Verb: verb, Nodes: nodes, Reason: reason}
+
+	summary, err := post(ctx, opts, request, func(int, int, bulkResult) {})
+	if err != nil {
+		return err
+	}
+
+	var failures []string
+
+	for _, result := range summary.Results {
+		if !result.Succeeded {
+			failures = append(failures, result.Node+": "+result.Error)
+		}
+	}
+
+	if len(failures) > 0 {
+		return fmt.Errorf("%s failed on %s", verb, strings.Join(failures, ", "))
+	}
+
+	return nil
+}
+
+func runBulk(ctx context.Context, verb string, args []string, stdin io.Reader, stdout io.Writer) error {
+	var opts bulkOptions
+
+	flags := flag.NewFlagSet("nodes "+verb, flag.ContinueOnError)
+	flags.StringVar(&opts.server, "server", "http://localhost:8082",
+		"API endpoint of janitor, e.g. after kubectl port-forward -n nvsentinel deployment/janitor 8082")
+	flags.StringVar(&opts.token, "token", os.Getenv("NVSENTINEL_TOKEN"),
+		"API token with the operator role, $NVSENTINEL_TOKEN or the token of nvsentinelctl login by default")
+	flags.StringVar(&opts.nodes, "nodes", "", "Comma separated node names, also accepted as arguments")
+	flags.StringVar(&opts.file, "file", "", "File of node names, one per line, - for stdin")
+	flags.StringVar(&opts.selector, "selector", "", "Label selector of the nodes, e.g. nvidia.com/gpu.product=H100")
+	flags.StringVar(&opts.reason, "reason", "", "Reason recorded in the janitor logs")
+	flags.IntVar(&opts.batchSize, "batch-size", 10, "Number of nodes janitor processes concurrently, at most 100")
+	flags.StringVar(&opts.output, "output", "text", "Output format: text or json")
+	flags.DurationVar(&opts.timeout, "timeout", 10*time.Minute, "Timeout of the request")
+
+	// Node names may come before, between or after the flags
+	var names []string
+
+	for {
+		if err := flags.Parse(args); err != nil {
+			return err
+		}
+
+		if flags.NArg() == 0 {
+			break
+		}
+
+		names = append(names, flags.Arg(0))
+		args = flags.Args()[1:]
+	}
+
+	if opts.output != "text" && opts.output != "json" {
+		return fmt.Errorf("invalid output %q, expected text or json", opts.output)
+	}
+
+	nodes, err := nodeNames(opts, names, stdin)
+	if err != nil {
+		return err
+	}
+
+	if len(nodes) == 0 && opts.selector == "" {
+		return fmt.Errorf("no nodes given, use node names, --nodes, --file or --selector")
+	}
+
+	request := bulkRequest{
+		Verb:      verb,
+		Nodes:     nodes,
+		Selector:  opts.selector,
+		Reason:    opts.reason,
+		BatchSize: opts.batchSize,
+	}
+
+	summary, err := post(ctx, opts, request, func(done, total int, result bulkResult) {
+		if opts.output == "text" {
+			printProgress(stdout, done, total, result)
+		}
+	})
+	if err != nil {
+		return err
+	}
+
+	if opts.output == "json" {
+		encoder := json.NewEncoder(stdout)
+		encoder.SetIndent("", "  ")
+
+		if err := encoder.Encode(summary); err != nil {
+			return err
+		}
+	} else {
+		printSummary(stdout, summary)
+	}
+
+	if summary.Failed > 0 {
+		return fmt.Errorf("%s failed on %d of %d nodes", verb, summary.Failed, summary.Total)
+	}
+
+	return nil
+}
+
+// nodeNames returns the nodes named by the arguments, --nodes and --file.
+func nodeNames(opts bulkOptions, args []string, stdin io.Reader) ([]string, error) {
+	nodes := append([]string{}, args...)
+
+	for _, name := range strings.Split(opts.nodes, ",") {
+		if name = strings.TrimSpace(name); name != "" {
+			nodes = append(nodes, name)
+		}
+	}
+
+	if opts.file == "" {
+		return nodes, nil
+	}
+
+	reader := stdin
+
+	if opts.file != "-" {
+		file, err := os.Open(opts.file)
+		if err != nil {
+			return nil, fmt.Errorf("failed to open node file: %w", err)
+		}
+		defer file.Close()
+
+		reader = file
+	}
+
+	scanner := bufio.NewScanner(reader)
+
+	for scanner.Scan() {
+		// Blank lines and comments are skipped
+		line, _, _ := strings.Cut(scanner.Text(), "#")
+		if line = strings.TrimSpace(line); line != "" {
+			nodes = append(nodes, line)
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read node file: %w", err)
+	}
+
+	return nodes, nil
+}
+
+// post sends the bulk request and calls progress for every node result janitor
+// streams back.
+func post(ctx context.Context, opts bulkOptions, request bulkRequest,
+	progress func(done, total int, result bulkResult)) (*bulkSummary, error) {
+	ctx, cancel := context.WithTimeout(ctx, opts.timeout)
+	defer cancel()
+
+	body, err := json.Marshal(request)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode request: %w", err)
+	}
+
+	target := strings.TrimSuffix(opts.server, "/") + bulkPath
+
+	httpRequest, err := http.NewRequestWithContext(ctx, http.MethodPost, target, bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	httpRequest.Header.Set("Content-Type", "application/json")
+
+	token, err := login.BearerToken(ctx, opts.token)
+	if err != nil {
+		return nil, err
+	}
+
+	if token != "" {
+		httpRequest.Header.Set("Authorization", "Bearer "+token)
+	}
+
+	httpResponse, err := http.DefaultClient.Do(httpRequest)
+	if err != nil {
+		return nil, fmt.Errorf("failed to reach %s: %w", target, err)
+	}
+	defer httpResponse.Body.Close()
+
+	if httpResponse.StatusCode != http.StatusOK {
+		message, _ := io.ReadAll(httpResponse.Body)
+		return nil, fmt.Errorf("%s returned %s: %s", target, httpResponse.Status, strings.TrimSpace(string(message)))
+	}
+
+	decoder := json.NewDecoder(httpResponse.Body)
+
+	var start bulkStart
+	if err := decoder.Decode(&start); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	summary := &bulkSummary{Verb: start.Verb, Total: start.Total, Results: []bulkResult{}}
+
+	for decoder.More() {
+		var result bulkResult
+		if err := decoder.Decode(&result); err != nil {
+			return nil, fmt.Errorf("failed to decode response after %d of %d nodes: %w",
+				len(summary.Results), start.Total, err)
+		}
+
+		summary.Results = append(summary.Results, result)
+
+		if result.Succeeded {
+			summary.Succeeded++
+		} else {
+			summary.Failed++
+		}
+
+		progress(len(summary.Results), start.Total, result)
+	}
+
+	if len(summary.Results) != start.Total {
+		return nil, fmt.Errorf("response ended after %d of %d nodes", len(summary.Results), start.Total)
+	}
+
+	return summary, nil
+}
+
+func printProgress(out io.Writer, done, total int, result bulkResult) {
+	width := len(fmt.Sprint(total))
+
+	if result.Succeeded {
+		fmt.Fprintf(out, "[%*d/%d] %s ok\n", width, done, total, result.Node)
+		return
+	}
+
+	fmt.Fprintf(out, "[%*d/%d] %s failed: %s\n", width, done, total, result.Node, result.Error)
+}
+
+func printSummary(out io.Writer, summary *bulkSummary) {
+	fmt.Fprintf(out, "\n%s: %d succeeded, %d failed of %d nodes\n", summary.Verb, summary.Succeeded,
+		summary.Failed, summary.Total)
+
+	if summary.Failed == 0 {
+		return
+	}
+
+	fmt.Fprintln(out, "Failed nodes:")
+
+	for _, result := range summary.Results {
+		if !result.Succeeded {
+			fmt.Fprintf(out, "  %s: %s\n", result.Node, result.Error)
+		}
+	}
+}
@@ -0,0 +1,181 @@
+// Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package nodes
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testToken = "operator-token"
+
+// newBulkServer fails the nodes whose name starts with bad and records the requests.
+func newBulkServer(t *testing.T, requests *[]bulkRequest) *httptest.Server {
+	t.Helper()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != bulkPath || r.Method != http.MethodPost {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		if r.Header.Get("Authorization") != "Bearer "+testToken {
+			http.Error(w, "unauthorized", http.StatusUnauthorized)
+			return
+		}
+
+		var request bulkRequest
+		if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		*requests = append(*requests, request)
+
+		nodes := request.Nodes
+		if request.Selector == "pool=h100" {
+			nodes = append(nodes, "h100-1", "h100-2")
+		}
+
+		encoder := json.NewEncoder(w)
+		_ = encoder.Encode(bulkStart{Verb: request.Verb, Total: len(nodes)})
+
+		for _, node := range nodes {
+			result := bulkResult{Node: node, Succeeded: true}
+			if strings.HasPrefix(node, "bad") {
+				result = bulkResult{Node: node, Error: "failed to get node: not found"}
+			}
+
+			_ = encoder.Encode(result)
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	return server
+}
+
+func TestRunBulk(t *testing.T) {
+	var requests []bulkRequest
+
+	server := newBulkServer(t, &requests)
+
+	file := filepath.Join(t.TempDir(), "nodes.txt")
+	require.NoError(t, os.WriteFile(file, []byte("# drained for maintenance\nnode-3\n\nnode-4 # rack 12\n"), 0o600))
+
+	var out bytes.Buffer
+	require.NoError(t, runBulk(context.Background(), "release",
+		[]string{"node-1", "--server", server.URL, "--token", testToken, "--nodes", "node-2", "--file", file,
+			"--selector", "pool=h100", "--reason", "repaired", "--batch-size", "5"},
+		strings.NewReader(""), &out))
+
+	require.Len(t, requests, 1)
+	assert.Equal(t, bulkRequest{
+		Verb:      "release",
+		Nodes:     []string{"node-1", "node-2", "node-3", "node-4"},
+		Selector:  "pool=h100",
+		Reason:    "repaired",
+		BatchSize: 5,
+	}, requests[0])
+
+	assert.Equal(t, `[1/6] node-1 ok
+[2/6] node-2 ok
+[3/6] node-3 ok
+[4/6] node-4 ok
+[5/6] h100-1 ok
+[6/6] h100-2 ok
+
+release: 6 succeeded, 0 failed of 6 nodes
+`, out.String())
+}
+
+func TestRunBulkFailures(t *testing.T) {
+	var requests []bulkRequest
+
+	server := newBulkServer(t, &requests)
+
+	var out bytes.Buffer
+	err := runBulk(context.Background(), "silence",
+		[]string{"--server", server.URL, "--token", testToken, "--file", "-"},
+		strings.NewReader("node-1\nbad-node\n"), &out)
+	assert.EqualError(t, err, "silence failed on 1 of 2 nodes")
+
+	assert.Equal(t, `[1/2] node-1 ok
+[2/2] bad-node failed: failed to get node: not found
+
+silence: 1 succeeded, 1 failed of 2 nodes
+Failed nodes:
+  bad-node: failed to get node: not found
+`, out.String())
+
+	out.Reset()
+	err = runBulk(context.Background(), "reverify",
+		[]string{"--server", server.URL, "--token", testToken, "--output", "json", "bad-node"},
+		strings.NewReader(""), &out)
+	require.Error(t, err)
+
+	var summary bulkSummary
+	require.NoError(t, json.Unmarshal(out.Bytes(), &summary))
+	assert.Equal(t, 1, summary.Failed)
+	assert.Equal(t, "reverify", summary.Verb)
+}
+
+func TestRunBulkErrors(t *testing.T) {
+	var requests []bulkRequest
+
+	server := newBulkServer(t, &requests)
+
+	err := runBulk(context.Background(), "release", []string{"--server", server.URL}, strings.NewReader(""),
+		&bytes.Buffer{})
+	assert.ErrorContains(t, err, "no nodes given")
+
+	err = runBulk(context.Background(), "release", []string{"--server", server.URL, "node-1"},
+		strings.NewReader(""), &bytes.Buffer{})
+	assert.ErrorContains(t, err, "401 Unauthorized")
+
+	err = runBulk(context.Background(), "release", []string{"--output", "yaml", "node-1"},
+		strings.NewReader(""), &bytes.Buffer{})
+	assert.ErrorContains(t, err, "invalid output")
+
+	assert.Empty(t, requests)
+}
+
+func TestApply(t *testing.T) {
+	var requests []bulkRequest
+
+	server := newBulkServer(t, &requests)
+
+	require.NoError(t, Apply(context.Background(), server.URL, testToken, "approve", "from the tui",
+		[]string{"node-1"}, time.Minute))
+
+	err := Apply(context.Background(), server.URL, testToken, "quarantine", "", []string{"node-1", "bad-1"},
+		time.Minute)
+	assert.EqualError(t, err, "quarantine failed on bad-1: failed to get node: not found")
+
+	assert.Equal(t, []bulkRequest{
+		{Verb: "approve", Nodes: []string{"node-1"}, Reason: "from the tui"},
+		{Verb: "quarantine", Nodes: []string{"node-1", "bad-1"}},
+	}, requests)
+}
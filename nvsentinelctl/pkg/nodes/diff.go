@@ -0,0 +1,212 @@
+// Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package nodes
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"sort"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/nvidia/nvsentinel/nvsentinelctl/pkg/login"
+)
+
+// nodeDiff mirrors the node diff response of the janitor API.
+type nodeDiff struct {
+	Node       string            `json:"node"`
+	SKU        map[string]string `json:"sku"`
+	Peers      int               `json:"peers"`
+	Deviations []struct {
+		Attribute string         `json:"attribute"`
+		Value     string         `json:"value"`
+		Expected  string         `json:"expected"`
+		Values    map[string]int `json:"values"`
+	} `json:"deviations"`
+}
+
+type diffOptions struct {
+	server  string
+	token   string
+	output  string
+	timeout time.Duration
+}
+
+// Diff runs `nodes diff <node>`: it prints where the inventory and configuration of
+// the node, such as the driver, kernel and GPU labels, deviate from the healthy
+// nodes of the same SKU.
+func Diff(ctx context.Context, args []string) error {
+	return runDiff(ctx, args, os.Stdout)
+}
+
+func runDiff(ctx context.Context, args []string, stdout io.Writer) error {
+	var opts diffOptions
+
+	flags := flag.NewFlagSet("nodes diff", flag.ContinueOnError)
+	flags.StringVar(&opts.server, "server", "http://localhost:8082",
+		"API endpoint of janitor, e.g. after kubectl port-forward -n nvsentinel deployment/janitor 8082")
+	flags.StringVar(&opts.token, "token", os.Getenv("NVSENTINEL_TOKEN"),
+		"API token with the viewer role, $NVSENTINEL_TOKEN or the token of nvsentinelctl login by default")
+	flags.StringVar(&opts.output, "output", "text", "Output format: text or json")
+	flags.DurationVar(&opts.timeout, "timeout", 30*time.Second, "Timeout of the request")
+
+	// The node comes first on the command line, flag parsing stops at it
+	var nodeName string
+	if len(args) > 0 && !strings.HasPrefix(args[0], "-") {
+		nodeName, args = args[0], args[1:]
+	}
+
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	if nodeName == "" {
+		nodeName = flags.Arg(0)
+	}
+
+	if nodeName == "" {
+		return fmt.Errorf("a node name is required: nvsentinelctl nodes diff <node>")
+	}
+
+	if opts.output != "text" && opts.output != "json" {
+		return fmt.Errorf("invalid output %q, expected text or json", opts.output)
+	}
+
+	body, err := getDiff(ctx, opts, nodeName)
+	if err != nil {
+		return err
+	}
+
+	if opts.output == "json" {
+		_, err = stdout.Write(body)
+		return err
+	}
+
+	var diff nodeDiff
+	if err := json.Unmarshal(body, &diff); err != nil {
+		return fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	return printDiff(stdout, &diff)
+}
+
+func getDiff(ctx context.Context, opts diffOptions, nodeName string) ([]byte, error) {
+	ctx, cancel := context.WithTimeout(ctx, opts.timeout)
+	defer cancel()
+
+	target := strings.TrimSuffix(opts.server, "/") + "/api/v1/nodes/" + url.PathEscape(nodeName) + "/diff"
+
+	httpRequest, err := http.NewRequestWithContext(ctx, http.MethodGet, target, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	token, err := login.BearerToken(ctx, opts.token)
+	if err != nil {
+		return nil, err
+	}
+
+	if token != "" {
+		httpRequest.Header.Set("Authorization", "Bearer "+token)
+	}
+
+	httpResponse, err := http.DefaultClient.Do(httpRequest)
+	if err != nil {
+		return nil, fmt.Errorf("failed to reach %s: %w", target, err)
+	}
+	defer httpResponse.Body.Close()
+
+	body, err := io.ReadAll(httpResponse.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response: %w", err)
+	}
+
+	if httpResponse.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("%s returned %s: %s", target, httpResponse.Status, strings.TrimSpace(string(body)))
+	}
+
+	return body, nil
+}
+
+func printDiff(out io.Writer, diff *nodeDiff) error {
+	sku := make([]string, 0, len(diff.SKU))
+	for label, value := range diff.SKU {
+		sku = append(sku, label+"="+value)
+	}
+
+	sort.Strings(sku)
+
+	fmt.Fprintf(out, "Node %s compared to %d healthy nodes with %s\n", diff.Node, diff.Peers, strings.Join(sku, ","))
+
+	if diff.Peers == 0 {
+		fmt.Fprintln(out, "No healthy node of the SKU to compare to")
+		return nil
+	}
+
+	if len(diff.Deviations) == 0 {
+		fmt.Fprintln(out, "No deviations")
+		return nil
+	}
+
+	fmt.Fprintln(out)
+
+	writer := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(writer, "ATTRIBUTE\tNODE\tEXPECTED\tPEERS")
+
+	for _, deviation := range diff.Deviations {
+		fmt.Fprintf(writer, "%s\t%s\t%s\t%s\n", deviation.Attribute, orMissing(deviation.Value),
+			orMissing(deviation.Expected), peerValues(deviation.Values))
+	}
+
+	return writer.Flush()
+}
+
+// peerValues formats the number of peers per value, the most common first
+func peerValues(values map[string]int) string {
+	keys := make([]string, 0, len(values))
+	for value := range values {
+		keys = append(keys, value)
+	}
+
+	sort.Slice(keys, func(i, j int) bool {
+		if values[keys[i]] != values[keys[j]] {
+			return values[keys[i]] > values[keys[j]]
+		}
+
+		return keys[i] < keys[j]
+	})
+
+	parts := make([]string, 0, len(keys))
+	for _, value := range keys {
+		parts = append(parts, fmt.Sprintf("%s:%d", orMissing(value), values[value]))
+	}
+
+	return strings.Join(parts, " ")
+}
+
+func orMissing(value string) string {
+	if value == "" {
+		return "<missing>"
+	}
+
+	return value
+}
@@ -0,0 +1,92 @@
+// Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package nodes
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const diffResponse = `{"node": "gpu-7", "sku": {"nvidia.com/gpu.product": "H100"}, "peers": 3, "deviations": [
+  {"attribute": "kernelVersion", "value": "5.15.0-90", "expected": "5.15.0-100", "values": {"5.15.0-100": 2, "5.15.0-90": 1}},
+  {"attribute": "capacity:nvidia.com/gpu", "value": "", "expected": "8", "values": {"8": 3}}
+]}`
+
+func TestDiff(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v1/nodes/gpu-7/diff" || r.Method != http.MethodGet {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		if r.Header.Get("Authorization") != "Bearer "+testToken {
+			http.Error(w, "unauthorized", http.StatusUnauthorized)
+			return
+		}
+
+		_, _ = w.Write([]byte(diffResponse))
+	}))
+	defer server.Close()
+
+	var out bytes.Buffer
+
+	err := runDiff(context.Background(), []string{"gpu-7", "--server", server.URL, "--token", testToken}, &out)
+	require.NoError(t, err)
+
+	assert.Equal(t, `Node gpu-7 compared to 3 healthy nodes with nvidia.com/gpu.product=H100
+
+ATTRIBUTE                NODE       EXPECTED    PEERS
+kernelVersion            5.15.0-90  5.15.0-100  5.15.0-100:2 5.15.0-90:1
+capacity:nvidia.com/gpu  <missing>  8           8:3
+`, out.String())
+
+	out.Reset()
+
+	err = runDiff(context.Background(), []string{"missing", "--server", server.URL, "--token", testToken}, &out)
+	assert.ErrorContains(t, err, "404")
+
+	err = runDiff(context.Background(), []string{"--server", server.URL}, &out)
+	assert.ErrorContains(t, err, "a node name is required")
+}
+
+func TestDiffCachedToken(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer "+testToken {
+			http.Error(w, "unauthorized", http.StatusUnauthorized)
+			return
+		}
+
+		_, _ = w.Write([]byte(diffResponse))
+	}))
+	defer server.Close()
+
+	// without --token the token cached by nvsentinelctl login is sent
+	cache := filepath.Join(t.TempDir(), "token.json")
+	require.NoError(t, os.WriteFile(cache, []byte(`{"id_token": "`+testToken+`"}`), 0o600))
+	t.Setenv("NVSENTINEL_TOKEN_CACHE", cache)
+
+	var out bytes.Buffer
+
+	require.NoError(t, runDiff(context.Background(), []string{"gpu-7", "--server", server.URL}, &out))
+	assert.Contains(t, out.String(), "kernelVersion")
+}
@@ -0,0 +1,598 @@
+// Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package output
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// JSONPath is a template in the JSONPath syntax of kubectl, e.g.
+// {range .events[*]}{.nodeName}{"\t"}{.checkName}{"\n"}{end}. Text outside braces
+// is printed as is. Within braces it supports fields, [n] indexes, [*]
+// wildcards, [start:end] slices, [?(@.field op value)] filters, "quoted" text
+// and range ... end.
+type JSONPath struct {
+	nodes []templateNode
+}
+
+type templateNode struct {
+	// text is printed as is when path is nil and the node is not a range
+	text string
+	path []step
+	// body is printed for each result of path when the node is a range
+	body    []templateNode
+	isRange bool
+}
+
+type stepKind int
+
+const (
+	stepField stepKind = iota
+	stepIndex
+	stepWildcard
+	stepSlice
+	stepFilter
+)
+
+type step struct {
+	kind  stepKind
+	field string
+	// index is the index of stepIndex and the start of stepSlice
+	index int
+	end   int
+	// hasEnd is false for open slices such as [1:]
+	hasEnd bool
+	filter *filter
+}
+
+type filter struct {
+	path     []step
+	operator string
+	value    any
+}
+
+// ParseJSONPath parses a template.
+func ParseJSONPath(template string) (*JSONPath, error) {
+	nodes, _, err := parseNodes(template, false)
+	if err != nil {
+		return nil, err
+	}
+
+	return &JSONPath{nodes: nodes}, nil
+}
+
+// parseNodes parses the template up to its end, or up to the {end} of the range
+// being parsed and then returns the template after it.
+func parseNodes(template string, inRange bool) ([]templateNode, string, error) {
+	var nodes []templateNode
+
+	for template != "" {
+		open := strings.Index(template, "{")
+		if open < 0 {
+			nodes = append(nodes, templateNode{text: template})
+			break
+		}
+
+		if open > 0 {
+			nodes = append(nodes, templateNode{text: template[:open]})
+		}
+
+		expression, rest, err := cutExpression(template[open+1:])
+		if err != nil {
+			return nil, "", err
+		}
+
+		template = rest
+
+		switch {
+		case expression == "end":
+			if !inRange {
+				return nil, "", fmt.Errorf("{end} without {range}")
+			}
+
+			return nodes, template, nil
+		case strings.HasPrefix(expression, "range "):
+			path, err := parsePath(strings.TrimSpace(strings.TrimPrefix(expression, "range ")))
+			if err != nil {
+				return nil, "", err
+			}
+
+			body, rest, err := parseNodes(template, true)
+			if err != nil {
+				return nil, "", err
+			}
+
+			nodes = append(nodes, templateNode{path: path, body: body, isRange: true})
+			template = rest
+		case strings.HasPrefix(expression, `"`):
+			text, err := strconv.Unquote(expression)
+			if err != nil {
+				return nil, "", fmt.Errorf("invalid text %s: %w", expression, err)
+			}
+
+			nodes = append(nodes, templateNode{text: text})
+		default:
+			path, err := parsePath(expression)
+			if err != nil {
+				return nil, "", err
+			}
+
+			nodes = append(nodes, templateNode{path: path})
+		}
+	}
+
+	if inRange {
+		return nil, "", fmt.Errorf("{range} without {end}")
+	}
+
+	return nodes, "", nil
+}
+
+// cutExpression returns the expression up to the closing brace, which may appear
+// in quoted text, and the template after it.
+func cutExpression(template string) (string, string, error) {
+	inQuote := false
+
+	for i := 0; i < len(template); i++ {
+		switch template[i] {
+		case '\\':
+			if inQuote {
+				i++
+			}
+		case '"':
+			inQuote = !inQuote
+		case '}':
+			if !inQuote {
+				return strings.TrimSpace(template[:i]), template[i+1:], nil
+			}
+		}
+	}
+
+	return "", "", fmt.Errorf("missing } in %q", template)
+}
+
+// parsePath parses a path such as .events[0].errorCodes[*], @.nodeName or $.gpus.
+func parsePath(path string) ([]step, error) {
+	original := path
+
+	path = strings.TrimPrefix(path, "$")
+	path = strings.TrimPrefix(path, "@")
+
+	// Not nil, so that a path to the current object is not taken for text
+	steps := []step{}
+
+	for path != "" {
+		switch path[0] {
+		case '.':
+			path = path[1:]
+
+			end := strings.IndexAny(path, ".[")
+			if end < 0 {
+				end = len(path)
+			}
+
+			if end == 0 {
+				if path == "" {
+					// A lone . is the current object
+					return steps, nil
+				}
+
+				return nil, fmt.Errorf("invalid path %q: empty field", original)
+			}
+
+			steps = append(steps, step{kind: stepField, field: path[:end]})
+			path = path[end:]
+		case '[':
+			end := matchingBracket(path)
+			if end < 0 {
+				return nil, fmt.Errorf("invalid path %q: missing ]", original)
+			}
+
+			s, err := parseBracket(path[1:end])
+			if err != nil {
+				return nil, fmt.Errorf("invalid path %q: %w", original, err)
+			}
+
+			steps = append(steps, s)
+			path = path[end+1:]
+		default:
+			return nil, fmt.Errorf("invalid path %q: expected . or [ at %q", original, path)
+		}
+	}
+
+	return steps, nil
+}
+
+// matchingBracket returns the index of the ] closing the [ path starts with.
+func matchingBracket(path string) int {
+	depth := 0
+	inQuote := byte(0)
+
+	for i := 0; i < len(path); i++ {
+		switch c := path[i]; {
+		case inQuote != 0:
+			if c == inQuote {
+				inQuote = 0
+			}
+		case c == '\'' || c == '"':
+			inQuote = c
+		case c == '[':
+			depth++
+		case c == ']':
+			depth--
+			if depth == 0 {
+				return i
+			}
+		}
+	}
+
+	return -1
+}
+
+func parseBracket(content string) (step, error) {
+	content = strings.TrimSpace(content)
+
+	switch {
+	case content == "*":
+		return step{kind: stepWildcard}, nil
+	case strings.HasPrefix(content, "?(") && strings.HasSuffix(content, ")"):
+		f, err := parseFilter(content[2 : len(content)-1])
+		if err != nil {
+			return step{}, err
+		}
+
+		return step{kind: stepFilter, filter: f}, nil
+	case strings.HasPrefix(content, "'") || strings.HasPrefix(content, `"`):
+		// ['field.with.dots']
+		return step{kind: stepField, field: strings.Trim(content, `'"`)}, nil
+	case strings.Contains(content, ":"):
+		startText, endText, _ := strings.Cut(content, ":")
+		s := step{kind: stepSlice}
+
+		if startText != "" {
+			start, err := strconv.Atoi(startText)
+			if err != nil {
+				return step{}, fmt.Errorf("invalid slice [%s]", content)
+			}
+
+			s.index = start
+		}
+
+		if endText != "" {
+			end, err := strconv.Atoi(endText)
+			if err != nil {
+				return step{}, fmt.Errorf("invalid slice [%s]", content)
+			}
+
+			s.end, s.hasEnd = end, true
+		}
+
+		return s, nil
+	default:
+		index, err := strconv.Atoi(content)
+		if err != nil {
+			return step{}, fmt.Errorf("invalid index [%s]", content)
+		}
+
+		return step{kind: stepIndex, index: index}, nil
+	}
+}
+
+// filterOperators are checked in order, so the two character operators come first
+var filterOperators = []string{"==", "!=", "<=", ">=", "<", ">"}
+
+// parseFilter parses @.field op value, or @.field to test that the field is set.
+func parseFilter(expression string) (*filter, error) {
+	expression = strings.TrimSpace(expression)
+
+	for _, operator := range filterOperators {
+		left, right, found := strings.Cut(expression, operator)
+		if !found {
+			continue
+		}
+
+		path, err := parsePath(strings.TrimSpace(left))
+		if err != nil {
+			return nil, err
+		}
+
+		value, err := parseLiteral(strings.TrimSpace(right))
+		if err != nil {
+			return nil, err
+		}
+
+		return &filter{path: path, operator: operator, value: value}, nil
+	}
+
+	path, err := parsePath(expression)
+	if err != nil {
+		return nil, err
+	}
+
+	return &filter{path: path}, nil
+}
+
+// parseLiteral parses a quoted string, a number, true, false or null.
+func parseLiteral(literal string) (any, error) {
+	if len(literal) >= 2 && literal[0] == '\'' && literal[len(literal)-1] == '\'' {
+		return literal[1 : len(literal)-1], nil
+	}
+
+	var value any
+	if err := json.Unmarshal([]byte(literal), &value); err != nil {
+		return nil, fmt.Errorf("invalid value %s in filter", literal)
+	}
+
+	return value, nil
+}
+
+// Execute prints the template for the JSON document data.
+func (j *JSONPath) Execute(out io.Writer, data []byte) error {
+	var root any
+
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+
+	if err := decoder.Decode(&root); err != nil {
+		return fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	return execute(out, j.nodes, root)
+}
+
+func execute(out io.Writer, nodes []templateNode, current any) error {
+	for _, node := range nodes {
+		switch {
+		case node.isRange:
+			for _, value := range evaluate(node.path, current) {
+				if err := execute(out, node.body, value); err != nil {
+					return err
+				}
+			}
+		case node.path != nil:
+			values := evaluate(node.path, current)
+			texts := make([]string, 0, len(values))
+
+			for _, value := range values {
+				text, err := format(value)
+				if err != nil {
+					return err
+				}
+
+				texts = append(texts, text)
+			}
+
+			if _, err := io.WriteString(out, strings.Join(texts, " ")); err != nil {
+				return err
+			}
+		default:
+			if _, err := io.WriteString(out, node.text); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// evaluate returns the values the path selects from current. Missing fields and
+// out of range indexes select nothing.
+func evaluate(path []step, current any) []any {
+	values := []any{current}
+
+	for _, s := range path {
+		var next []any
+
+		for _, value := range values {
+			next = append(next, apply(s, value)...)
+		}
+
+		values = next
+	}
+
+	return values
+}
+
+func apply(s step, value any) []any {
+	switch s.kind {
+	case stepField:
+		object, ok := value.(map[string]any)
+		if !ok {
+			return nil
+		}
+
+		field, ok := object[s.field]
+		if !ok {
+			return nil
+		}
+
+		return []any{field}
+	case stepWildcard:
+		switch v := value.(type) {
+		case []any:
+			return v
+		case map[string]any:
+			values := make([]any, 0, len(v))
+			for _, field := range v {
+				values = append(values, field)
+			}
+
+			return values
+		}
+
+		return nil
+	case stepIndex:
+		list, ok := value.([]any)
+		if !ok {
+			return nil
+		}
+
+		index := s.index
+		if index < 0 {
+			index += len(list)
+		}
+
+		if index < 0 || index >= len(list) {
+			return nil
+		}
+
+		return []any{list[index]}
+	case stepSlice:
+		list, ok := value.([]any)
+		if !ok {
+			return nil
+		}
+
+		start, end := s.index, len(list)
+		if s.hasEnd {
+			end = s.end
+		}
+
+		start, end = bound(start, len(list)), bound(end, len(list))
+		if start >= end {
+			return nil
+		}
+
+		return list[start:end]
+	case stepFilter:
+		list, ok := value.([]any)
+		if !ok {
+			return nil
+		}
+
+		var matches []any
+
+		for _, item := range list {
+			if s.filter.matches(item) {
+				matches = append(matches, item)
+			}
+		}
+
+		return matches
+	}
+
+	return nil
+}
+
+// bound turns a negative index into one from the end and keeps it within a list of n.
+func bound(index, n int) int {
+	if index < 0 {
+		index += n
+	}
+
+	return min(max(index, 0), n)
+}
+
+func (f *filter) matches(item any) bool {
+	values := evaluate(f.path, item)
+	if f.operator == "" {
+		return len(values) > 0 && values[0] != nil && values[0] != false
+	}
+
+	if len(values) == 0 {
+		return f.operator == "!="
+	}
+
+	comparison, comparable := compare(values[0], f.value)
+
+	switch f.operator {
+	case "==":
+		return comparable && comparison == 0
+	case "!=":
+		return !comparable || comparison != 0
+	case "<":
+		return comparable && comparison < 0
+	case "<=":
+		return comparable && comparison <= 0
+	case ">":
+		return comparable && comparison > 0
+	case ">=":
+		return comparable && comparison >= 0
+	}
+
+	return false
+}
+
+// compare compares a value of the document with a literal of a filter. Values of
+// different types are not comparable.
+func compare(value, literal any) (int, bool) {
+	switch v := value.(type) {
+	case json.Number:
+		number, err := v.Float64()
+		if err != nil {
+			return 0, false
+		}
+
+		l, ok := literal.(float64)
+		if !ok {
+			return 0, false
+		}
+
+		switch {
+		case number < l:
+			return -1, true
+		case number > l:
+			return 1, true
+		default:
+			return 0, true
+		}
+	case string:
+		l, ok := literal.(string)
+		if !ok {
+			return 0, false
+		}
+
+		return strings.Compare(v, l), true
+	case bool:
+		l, ok := literal.(bool)
+		if !ok || v != l {
+			return 1, ok
+		}
+
+		return 0, true
+	case nil:
+		if literal == nil {
+			return 0, true
+		}
+
+		return 0, false
+	}
+
+	return 0, false
+}
+
+// format prints strings and numbers as they are, and objects and lists as JSON.
+func format(value any) (string, error) {
+	switch v := value.(type) {
+	case string:
+		return v, nil
+	case json.Number:
+		return v.String(), nil
+	case nil:
+		return "", nil
+	}
+
+	text, err := json.Marshal(value)
+	if err != nil {
+		return "", fmt.Errorf("failed to encode value: %w", err)
+	}
+
+	return string(text), nil
+}
@@ -0,0 +1,89 @@
+// Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package output
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testDocument = `{"truncated": false, "events": [
+	{"id": "1", "nodeName": "h100-1", "errorCodes": ["79", "48"], "isFatal": true, "gpu": 0,
+		"metadata": {"dgxc.nvidia.com/zone": "a"}},
+	{"id": "2", "nodeName": "h100-2", "errorCodes": [], "isFatal": false, "gpu": 7},
+	{"id": "3", "nodeName": "h100-3", "isFatal": false, "gpu": 3, "message": "clocks {throttled}"}]}`
+
+func TestJSONPath(t *testing.T) {
+	tests := []struct {
+		name     string
+		template string
+		expected string
+	}{
+		{name: "field", template: "{.truncated}", expected: "false"},
+		{name: "wildcard", template: "{.events[*].nodeName}", expected: "h100-1 h100-2 h100-3"},
+		{name: "index", template: "{.events[0].errorCodes[1]}", expected: "48"},
+		{name: "negative index", template: "{.events[-1].id}", expected: "3"},
+		{name: "slice", template: "{.events[1:].id}", expected: "2 3"},
+		{name: "text", template: `nodes: {.events[:2].nodeName}{"\n"}`, expected: "nodes: h100-1 h100-2\n"},
+		{name: "list as JSON", template: "{.events[0].errorCodes}", expected: `["79","48"]`},
+		{name: "quoted field", template: "{.events[0].metadata['dgxc.nvidia.com/zone']}", expected: "a"},
+		{name: "missing field", template: "{.events[*].message}", expected: "clocks {throttled}"},
+		{name: "bool filter", template: "{.events[?(@.isFatal==true)].nodeName}", expected: "h100-1"},
+		{name: "number filter", template: "{.events[?(@.gpu >= 3)].id}", expected: "2 3"},
+		{name: "string filter", template: `{.events[?(@.nodeName!="h100-2")].id}`, expected: "1 3"},
+		{name: "quoted filter", template: "{.events[?(@.nodeName=='h100-3')].gpu}", expected: "3"},
+		{name: "exists filter", template: "{.events[?(@.message)].id}", expected: "3"},
+		{
+			name:     "range",
+			template: `{range .events[*]}{.id}{"\t"}{.nodeName}{"\t"}{.errorCodes[*]}{"\n"}{end}`,
+			expected: "1\th100-1\t79 48\n2\th100-2\t\n3\th100-3\t\n",
+		},
+		{
+			name:     "nested range",
+			template: `{range .events[0:2]}{.id}:{range .errorCodes[*]} {@}{end};{end}`,
+			expected: "1: 79 48;2:;",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			jsonPath, err := ParseJSONPath(tt.template)
+			require.NoError(t, err)
+
+			var out bytes.Buffer
+			require.NoError(t, jsonPath.Execute(&out, []byte(testDocument)))
+			assert.Equal(t, tt.expected, out.String())
+		})
+	}
+}
+
+func TestParseJSONPathErrors(t *testing.T) {
+	for template, expected := range map[string]string{
+		"{.events":                  "missing }",
+		"{range .events[*]}{.id}":   "{range} without {end}",
+		"{.id}{end}":                "{end} without {range}",
+		"{.events[x]}":              "invalid index",
+		"{.events[0}":               "missing ]",
+		"{events}":                  "expected . or [",
+		`{"unterminated}`:           "missing }",
+		"{.events[?(@.gpu > one)]}": "invalid value one",
+	} {
+		_, err := ParseJSONPath(template)
+		assert.ErrorContains(t, err, expected, template)
+	}
+}
@@ -0,0 +1,158 @@
+// Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package output implements the output formats and the watch mode shared by the
+// list commands of nvsentinelctl.
+package output
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+)
+
+// Usage is the usage of the --output flag of list commands
+const Usage = "Output format: text, json or jsonpath=<template>, " +
+	`e.g. jsonpath='{range .events[*]}{.nodeName}{"\n"}{end}'`
+
+// Format is how a list command prints the API response.
+type Format struct {
+	name     string
+	jsonPath *JSONPath
+}
+
+// Parse parses the value of --output: text, json or jsonpath=<template>.
+func Parse(value string) (Format, error) {
+	switch {
+	case value == "text" || value == "json":
+		return Format{name: value}, nil
+	case strings.HasPrefix(value, "jsonpath="):
+		jsonPath, err := ParseJSONPath(strings.TrimPrefix(value, "jsonpath="))
+		if err != nil {
+			return Format{}, fmt.Errorf("invalid jsonpath output: %w", err)
+		}
+
+		return Format{name: "jsonpath", jsonPath: jsonPath}, nil
+	default:
+		return Format{}, fmt.Errorf("invalid output %q, expected text, json or jsonpath=<template>", value)
+	}
+}
+
+// IsText returns true for the human readable output.
+func (f Format) IsText() bool {
+	return f.name == "text"
+}
+
+// Print prints the JSON response body: as is for json, through the template for
+// jsonpath and with text for text.
+func (f Format) Print(out io.Writer, body []byte, text func() error) error {
+	switch f.name {
+	case "json":
+		_, err := out.Write(body)
+		return err
+	case "jsonpath":
+		return f.jsonPath.Execute(out, body)
+	default:
+		return text()
+	}
+}
+
+// Watch calls poll right away and then every interval until ctx is done. An
+// error of the first poll is returned, later errors are printed to stderr and the
+// watch goes on.
+func Watch(ctx context.Context, interval time.Duration, stderr io.Writer, poll func(ctx context.Context) error) error {
+	if interval <= 0 {
+		return fmt.Errorf("invalid watch interval %s", interval)
+	}
+
+	if err := poll(ctx); err != nil {
+		return err
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+			if err := poll(ctx); err != nil && ctx.Err() == nil {
+				fmt.Fprintf(stderr, "%s: %v\n", time.Now().Format(time.RFC3339), err)
+			}
+		}
+	}
+}
+
+// WatchSnapshots watches a response that is a snapshot: fetch is polled right away
+// and then every interval, and printSnapshot is called when the response differs
+// from the last printed one. The top level fields named volatile, such as times
+// relative to now, are ignored when comparing responses.
+func WatchSnapshots(ctx context.Context, interval time.Duration, stderr io.Writer,
+	fetch func(ctx context.Context) ([]byte, error), printSnapshot func(body []byte, first bool) error,
+	volatile ...string) error {
+	var last string
+
+	first := true
+
+	return Watch(ctx, interval, stderr, func(ctx context.Context) error {
+		body, err := fetch(ctx)
+		if err != nil {
+			return err
+		}
+
+		content, err := withoutFields(body, volatile)
+		if err != nil {
+			return err
+		}
+
+		if !first && content == last {
+			return nil
+		}
+
+		last = content
+
+		err = printSnapshot(body, first)
+		first = false
+
+		return err
+	})
+}
+
+// withoutFields returns the JSON object body without the top level fields.
+func withoutFields(body []byte, fields []string) (string, error) {
+	if len(fields) == 0 {
+		return string(body), nil
+	}
+
+	var object map[string]json.RawMessage
+	if err := json.Unmarshal(body, &object); err != nil {
+		return "", fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	for _, field := range fields {
+		delete(object, field)
+	}
+
+	// Keys are sorted, so equal objects encode the same
+	content, err := json.Marshal(object)
+	if err != nil {
+		return "", fmt.Errorf("failed to encode response: %w", err)
+	}
+
+	return string(content), nil
+}
@@ -0,0 +1,121 @@
+// Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package output
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFormatPrint(t *testing.T) {
+	body := []byte(`{"events": [{"nodeName": "h100-1"}]}`)
+	text := func(out *bytes.Buffer) func() error {
+		return func() error {
+			out.WriteString("as text")
+			return nil
+		}
+	}
+
+	for value, expected := range map[string]string{
+		"text":                           "as text",
+		"json":                           string(body),
+		"jsonpath={.events[*].nodeName}": "h100-1",
+	} {
+		format, err := Parse(value)
+		require.NoError(t, err)
+
+		var out bytes.Buffer
+		require.NoError(t, format.Print(&out, body, text(&out)))
+		assert.Equal(t, expected, out.String(), value)
+	}
+
+	_, err := Parse("yaml")
+	assert.ErrorContains(t, err, "invalid output")
+
+	_, err = Parse("jsonpath={.events")
+	assert.ErrorContains(t, err, "invalid jsonpath output")
+}
+
+func TestWatch(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var stderr bytes.Buffer
+
+	polls := 0
+	err := Watch(ctx, time.Millisecond, &stderr, func(context.Context) error {
+		polls++
+
+		switch polls {
+		case 2:
+			return errors.New("connection refused")
+		case 3:
+			cancel()
+		}
+
+		return nil
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, 3, polls)
+	assert.Contains(t, stderr.String(), "connection refused", "errors after the first poll do not stop the watch")
+
+	err = Watch(context.Background(), time.Millisecond, &stderr, func(context.Context) error {
+		return errors.New("connection refused")
+	})
+	assert.EqualError(t, err, "connection refused")
+}
+
+func TestWatchSnapshots(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	responses := []string{
+		`{"since": "1", "nodes": ["a"]}`,
+		`{"since": "2", "nodes": ["a"]}`,
+		`{"since": "3", "nodes": ["a", "b"]}`,
+		`{"since": "4", "nodes": ["a", "b"]}`,
+	}
+
+	var printed []string
+
+	polls := 0
+	err := WatchSnapshots(ctx, time.Millisecond, &bytes.Buffer{}, func(context.Context) ([]byte, error) {
+		body := responses[polls]
+
+		polls++
+		if polls == len(responses) {
+			cancel()
+		}
+
+		return []byte(body), nil
+	}, func(body []byte, first bool) error {
+		printed = append(printed, fmt.Sprintf("%t %s", first, body))
+		return nil
+	}, "since")
+
+	require.NoError(t, err)
+	assert.Equal(t, []string{
+		`true {"since": "1", "nodes": ["a"]}`,
+		`false {"since": "3", "nodes": ["a", "b"]}`,
+	}, printed)
+}
@@ -0,0 +1,223 @@
+// Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package remediation implements the remediation commands of nvsentinelctl.
+package remediation
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/nvidia/nvsentinel/nvsentinelctl/pkg/output"
+)
+
+// planPath is where fault remediation serves the remediation plans
+const planPath = "/remediation-plans"
+
+// planResponse mirrors the remediation plan API of fault remediation.
+type planResponse struct {
+	Plans []remediationPlan `json:"plans"`
+}
+
+type remediationPlan struct {
+	NodeName          string `json:"nodeName"`
+	HealthEventID     string `json:"healthEventId"`
+	RecommendedAction string `json:"recommendedAction"`
+	Steps             []struct {
+		Order                    int    `json:"order"`
+		Type                     string `json:"type"`
+		Description              string `json:"description"`
+		Resource                 string `json:"resource"`
+		EstimatedDurationSeconds int    `json:"estimatedDurationSeconds"`
+	} `json:"steps"`
+	AffectedWorkloads []struct {
+		Namespace string `json:"namespace"`
+		Name      string `json:"name"`
+		OwnerKind string `json:"ownerKind"`
+		OwnerName string `json:"ownerName"`
+	} `json:"affectedWorkloads"`
+	EstimatedDowntimeSeconds int       `json:"estimatedDowntimeSeconds"`
+	DryRun                   bool      `json:"dryRun"`
+	Status                   string    `json:"status"`
+	CreatedAt                time.Time `json:"createdAt"`
+}
+
+type planOptions struct {
+	server   string
+	node     string
+	output   string
+	watch    bool
+	interval time.Duration
+	timeout  time.Duration
+}
+
+// Plan runs `remediation plan`: it prints the latest remediation plan fault
+// remediation computed for every node, or for one node. With --watch it prints
+// them again whenever they change.
+func Plan(ctx context.Context, args []string) error {
+	return runPlan(ctx, args, os.Stdout)
+}
+
+func runPlan(ctx context.Context, args []string, stdout io.Writer) error {
+	var opts planOptions
+
+	flags := flag.NewFlagSet("remediation plan", flag.ContinueOnError)
+	flags.StringVar(&opts.server, "server", "http://localhost:2112",
+		"Metrics endpoint of fault remediation, e.g. after "+
+			"kubectl port-forward -n nvsentinel deployment/fault-remediation 2112")
+	flags.StringVar(&opts.node, "node", "", "Node to print the plan of. All nodes by default")
+	flags.StringVar(&opts.output, "output", "text", output.Usage)
+	flags.BoolVar(&opts.watch, "watch", false, "Keep polling and print the plans whenever they change")
+	flags.DurationVar(&opts.interval, "interval", 10*time.Second, "Polling interval of --watch")
+	flags.DurationVar(&opts.timeout, "timeout", 30*time.Second, "Timeout of the request")
+
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	format, err := output.Parse(opts.output)
+	if err != nil {
+		return err
+	}
+
+	printResponse := func(body []byte, first bool) error {
+		// Text snapshots are apart by an empty line
+		if !first && format.IsText() {
+			fmt.Fprintln(stdout)
+		}
+
+		return format.Print(stdout, body, func() error {
+			var response planResponse
+			if err := json.Unmarshal(body, &response); err != nil {
+				return fmt.Errorf("failed to decode response: %w", err)
+			}
+
+			printPlans(stdout, &response)
+
+			return nil
+		})
+	}
+
+	if !opts.watch {
+		body, err := requestPlans(ctx, opts)
+		if err != nil {
+			return err
+		}
+
+		return printResponse(body, true)
+	}
+
+	return output.WatchSnapshots(ctx, opts.interval, os.Stderr, func(ctx context.Context) ([]byte, error) {
+		return requestPlans(ctx, opts)
+	}, printResponse)
+}
+
+func requestPlans(ctx context.Context, opts planOptions) ([]byte, error) {
+	ctx, cancel := context.WithTimeout(ctx, opts.timeout)
+	defer cancel()
+
+	target := strings.TrimSuffix(opts.server, "/") + planPath
+	if opts.node != "" {
+		target += "?node=" + url.QueryEscape(opts.node)
+	}
+
+	httpRequest, err := http.NewRequestWithContext(ctx, http.MethodGet, target, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	httpResponse, err := http.DefaultClient.Do(httpRequest)
+	if err != nil {
+		return nil, fmt.Errorf("failed to reach %s: %w", target, err)
+	}
+
+	defer httpResponse.Body.Close()
+
+	body, err := io.ReadAll(httpResponse.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response: %w", err)
+	}
+
+	if httpResponse.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("%s returned %s: %s", target, httpResponse.Status, strings.TrimSpace(string(body)))
+	}
+
+	return body, nil
+}
+
+func printPlans(out io.Writer, response *planResponse) {
+	if len(response.Plans) == 0 {
+		fmt.Fprintln(out, "No remediation plans")
+		return
+	}
+
+	for i, plan := range response.Plans {
+		if i > 0 {
+			fmt.Fprintln(out)
+		}
+
+		status := plan.Status
+		if plan.DryRun {
+			status += ", dry-run"
+		}
+
+		fmt.Fprintf(out, "Node %s: %s for event %s (%s, created %s)\n", plan.NodeName,
+			plan.RecommendedAction, plan.HealthEventID, status, plan.CreatedAt.Format(time.RFC3339))
+
+		for _, step := range plan.Steps {
+			fmt.Fprintf(out, "  %d. %s", step.Order, step.Type)
+
+			if step.Resource != "" {
+				fmt.Fprintf(out, " %s", step.Resource)
+			}
+
+			fmt.Fprintf(out, " (%s): %s\n", duration(step.EstimatedDurationSeconds), step.Description)
+		}
+
+		fmt.Fprintf(out, "  Estimated downtime: %s\n", duration(plan.EstimatedDowntimeSeconds))
+
+		if len(plan.AffectedWorkloads) == 0 {
+			fmt.Fprintln(out, "  Affected workloads: none")
+			continue
+		}
+
+		fmt.Fprintln(out, "  Affected workloads:")
+
+		for _, workload := range plan.AffectedWorkloads {
+			fmt.Fprintf(out, "    %s/%s", workload.Namespace, workload.Name)
+
+			if workload.OwnerKind != "" {
+				fmt.Fprintf(out, " (%s %s)", workload.OwnerKind, workload.OwnerName)
+			}
+
+			fmt.Fprintln(out)
+		}
+	}
+}
+
+func duration(seconds int) string {
+	if seconds == 0 {
+		return "unknown"
+	}
+
+	return (time.Duration(seconds) * time.Second).String()
+}
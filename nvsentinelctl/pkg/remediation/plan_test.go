@@ -0,0 +1,106 @@
+// Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package remediation
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newPlanServer(t *testing.T) *httptest.Server {
+	t.Helper()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != planPath || r.Method != http.MethodGet {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		if node := r.URL.Query().Get("node"); node != "" && node != "node-1" {
+			http.Error(w, "no remediation plan for node "+node, http.StatusNotFound)
+			return
+		}
+
+		_, _ = w.Write([]byte(`{"plans": [{"nodeName": "node-1", "healthEventId": "abc",
+			"recommendedAction": "RESTART_BM", "status": "Pending", "createdAt": "2025-06-01T10:00:00Z",
+			"steps": [
+				{"order": 1, "type": "CollectLogs", "description": "Run the log collector job",
+					"estimatedDurationSeconds": 300},
+				{"order": 2, "type": "Maintenance", "description": "Create the RebootNode maintenance resource",
+					"resource": "RebootNode/maintenance-node-1-abc", "estimatedDurationSeconds": 600}
+			],
+			"affectedWorkloads": [{"namespace": "monitoring", "name": "exporter-x", "ownerKind": "DaemonSet",
+				"ownerName": "exporter"}],
+			"estimatedDowntimeSeconds": 900}]}`))
+	}))
+	t.Cleanup(server.Close)
+
+	return server
+}
+
+func TestRunPlan(t *testing.T) {
+	server := newPlanServer(t)
+
+	var out bytes.Buffer
+	require.NoError(t, runPlan(context.Background(), []string{"--server", server.URL, "--node", "node-1"}, &out))
+
+	assert.Equal(t, `Node node-1: RESTART_BM for event abc (Pending, created 2025-06-01T10:00:00Z)
+  1. CollectLogs (5m0s): Run the log collector job
+  2. Maintenance RebootNode/maintenance-node-1-abc (10m0s): Create the RebootNode maintenance resource
+  Estimated downtime: 15m0s
+  Affected workloads:
+    monitoring/exporter-x (DaemonSet exporter)
+`, out.String())
+}
+
+func TestRunPlanJSON(t *testing.T) {
+	server := newPlanServer(t)
+
+	var out bytes.Buffer
+	require.NoError(t, runPlan(context.Background(), []string{"--server", server.URL, "--output", "json"}, &out))
+	assert.Contains(t, out.String(), `"healthEventId": "abc"`)
+}
+
+func TestRunPlanJSONPath(t *testing.T) {
+	server := newPlanServer(t)
+
+	var out bytes.Buffer
+	require.NoError(t, runPlan(context.Background(), []string{"--server", server.URL, "--output",
+		`jsonpath={range .plans[*]}{.nodeName}{"\t"}{.steps[*].type}{"\n"}{end}`}, &out))
+	assert.Equal(t, "node-1\tCollectLogs Maintenance\n", out.String())
+}
+
+func TestRunPlanErrors(t *testing.T) {
+	server := newPlanServer(t)
+
+	err := runPlan(context.Background(), []string{"--server", server.URL, "--node", "node-2"}, &bytes.Buffer{})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "no remediation plan for node node-2")
+
+	err = runPlan(context.Background(), []string{"--output", "yaml"}, &bytes.Buffer{})
+	assert.ErrorContains(t, err, "invalid output")
+}
+
+func TestPrintPlansEmpty(t *testing.T) {
+	var out bytes.Buffer
+	printPlans(&out, &planResponse{})
+	assert.Equal(t, "No remediation plans\n", out.String())
+}
@@ -0,0 +1,260 @@
+// Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package rules implements the rule commands of nvsentinelctl.
+package rules
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+)
+
+// testPath is where the syslog health monitor serves the rule test
+const testPath = "/rules/test"
+
+// testRequest and testResponse mirror the rule test API of the syslog health monitor.
+type testRequest struct {
+	Lines  []string `json:"lines"`
+	Checks []string `json:"checks,omitempty"`
+}
+
+type testResponse struct {
+	RulePack string `json:"rulePack,omitempty"`
+	Lines    []struct {
+		Line    string `json:"line"`
+		Matches []struct {
+			Check  string      `json:"check"`
+			Events []testEvent `json:"events"`
+			Error  string      `json:"error"`
+		} `json:"matches"`
+	} `json:"lines"`
+}
+
+type testEvent struct {
+	ErrorCode         []string          `json:"errorCode"`
+	IsFatal           bool              `json:"isFatal"`
+	IsHealthy         bool              `json:"isHealthy"`
+	RecommendedAction string            `json:"recommendedAction"`
+	Message           string            `json:"message"`
+	Metadata          map[string]string `json:"metadata"`
+}
+
+type stringList []string
+
+func (l *stringList) String() string {
+	return strings.Join(*l, ",")
+}
+
+func (l *stringList) Set(value string) error {
+	*l = append(*l, value)
+	return nil
+}
+
+type testOptions struct {
+	server  string
+	lines   stringList
+	file    string
+	checks  stringList
+	output  string
+	timeout time.Duration
+}
+
+// Test runs `rules test`: it sends raw log lines to the syslog health monitor,
+// which runs them through its checks and the rule pack of its node, and prints
+// the events each line would produce.
+func Test(ctx context.Context, args []string) error {
+	return runTest(ctx, args, os.Stdin, os.Stdout)
+}
+
+func runTest(ctx context.Context, args []string, stdin io.Reader, stdout io.Writer) error {
+	var opts testOptions
+
+	flags := flag.NewFlagSet("rules test", flag.ContinueOnError)
+	flags.StringVar(&opts.server, "server", "http://localhost:2112",
+		"Metrics endpoint of the syslog health monitor, e.g. after "+
+			"kubectl port-forward -n nvsentinel pod/<syslog-health-monitor pod> 2112")
+	flags.Var(&opts.lines, "line", "Raw log line to test, may be repeated")
+	flags.StringVar(&opts.file, "file", "", "File with one raw log line per line, - for stdin")
+	flags.Var(&opts.checks, "check", "Check to test, may be repeated. All checks of the monitor by default")
+	flags.StringVar(&opts.output, "output", "text", "Output format: text or json")
+	flags.DurationVar(&opts.timeout, "timeout", 30*time.Second, "Timeout of the request")
+
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	if opts.output != "text" && opts.output != "json" {
+		return fmt.Errorf("invalid output %q, expected text or json", opts.output)
+	}
+
+	lines, err := readLines(opts, stdin)
+	if err != nil {
+		return err
+	}
+
+	if len(lines) == 0 {
+		return errors.New("no lines to test, use --line or --file")
+	}
+
+	body, err := requestTest(ctx, opts, testRequest{Lines: lines, Checks: opts.checks})
+	if err != nil {
+		return err
+	}
+
+	if opts.output == "json" {
+		_, err := stdout.Write(body)
+		return err
+	}
+
+	var response testResponse
+	if err := json.Unmarshal(body, &response); err != nil {
+		return fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	printTest(stdout, &response)
+
+	return nil
+}
+
+func readLines(opts testOptions, stdin io.Reader) ([]string, error) {
+	lines := append([]string(nil), opts.lines...)
+
+	if opts.file == "" {
+		return lines, nil
+	}
+
+	input := stdin
+
+	if opts.file != "-" {
+		file, err := os.Open(opts.file)
+		if err != nil {
+			return nil, fmt.Errorf("failed to open %s: %w", opts.file, err)
+		}
+
+		defer file.Close()
+
+		input = file
+	}
+
+	scanner := bufio.NewScanner(input)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+
+	for scanner.Scan() {
+		if line := scanner.Text(); strings.TrimSpace(line) != "" {
+			lines = append(lines, line)
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", opts.file, err)
+	}
+
+	return lines, nil
+}
+
+func requestTest(ctx context.Context, opts testOptions, request testRequest) ([]byte, error) {
+	payload, err := json.Marshal(request)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode request: %w", err)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, opts.timeout)
+	defer cancel()
+
+	url := strings.TrimSuffix(opts.server, "/") + testPath
+
+	httpRequest, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(payload))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	httpRequest.Header.Set("Content-Type", "application/json")
+
+	httpResponse, err := http.DefaultClient.Do(httpRequest)
+	if err != nil {
+		return nil, fmt.Errorf("failed to reach %s: %w", url, err)
+	}
+
+	defer httpResponse.Body.Close()
+
+	body, err := io.ReadAll(httpResponse.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response: %w", err)
+	}
+
+	if httpResponse.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("%s returned %s: %s", url, httpResponse.Status, strings.TrimSpace(string(body)))
+	}
+
+	return body, nil
+}
+
+func printTest(out io.Writer, response *testResponse) {
+	if response.RulePack != "" {
+		fmt.Fprintf(out, "Rule pack: %s\n\n", response.RulePack)
+	}
+
+	for i, line := range response.Lines {
+		fmt.Fprintf(out, "Line %d: %s\n", i+1, line.Line)
+
+		if len(line.Matches) == 0 {
+			fmt.Fprintln(out, "  no check matched")
+		}
+
+		for _, match := range line.Matches {
+			if match.Error != "" {
+				fmt.Fprintf(out, "  %s: error: %s\n", match.Check, match.Error)
+				continue
+			}
+
+			for _, event := range match.Events {
+				fmt.Fprintf(out, "  %s: %s\n", match.Check, describe(event))
+			}
+		}
+	}
+}
+
+func describe(event testEvent) string {
+	severity := "non-fatal"
+
+	switch {
+	case event.IsHealthy:
+		severity = "healthy"
+	case event.IsFatal:
+		severity = "fatal"
+	}
+
+	action := event.RecommendedAction
+	if action == "" {
+		action = "NONE"
+	}
+
+	description := fmt.Sprintf("error_code=%s %s action=%s", strings.Join(event.ErrorCode, ","), severity, action)
+
+	if pack := event.Metadata["rule_pack"]; pack != "" {
+		description += " benign_in_rule_pack=" + pack
+	}
+
+	return description + ": " + event.Message
+}
@@ -0,0 +1,110 @@
+// Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rules
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestServer(t *testing.T, received *testRequest) *httptest.Server {
+	t.Helper()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != testPath || r.Method != http.MethodPost {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		require.NoError(t, json.NewDecoder(r.Body).Decode(received))
+
+		if len(received.Checks) > 0 && received.Checks[0] == "Unknown" {
+			http.Error(w, "check Unknown is not enabled", http.StatusBadRequest)
+			return
+		}
+
+		_, _ = w.Write([]byte(`{"rulePack": "GB200", "lines": [
+			{"line": "Xid 79", "matches": [{"check": "SysLogsXIDError", "events": [{"errorCode": ["79"], "isFatal": true,
+				"recommendedAction": "RESTART_BM", "message": "GPU has fallen off the bus"}]}]},
+			{"line": "Xid 63", "matches": [{"check": "SysLogsXIDError", "events": [{"errorCode": ["63"],
+				"message": "Row remapping", "metadata": {"rule_pack": "GB200"}}]}]},
+			{"line": "systemd", "matches": []}
+		]}`))
+	}))
+	t.Cleanup(server.Close)
+
+	return server
+}
+
+func TestRunTest(t *testing.T) {
+	var received testRequest
+
+	server := newTestServer(t, &received)
+
+	file := filepath.Join(t.TempDir(), "lines.log")
+	require.NoError(t, os.WriteFile(file, []byte("Xid 63\n\nsystemd\n"), 0o600))
+
+	var out bytes.Buffer
+
+	err := runTest(context.Background(),
+		[]string{"--server", server.URL, "--line", "Xid 79", "--file", file, "--check", "SysLogsXIDError"},
+		strings.NewReader(""), &out)
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"Xid 79", "Xid 63", "systemd"}, received.Lines)
+	assert.Equal(t, []string{"SysLogsXIDError"}, received.Checks)
+	assert.Equal(t, `Rule pack: GB200
+
+Line 1: Xid 79
+  SysLogsXIDError: error_code=79 fatal action=RESTART_BM: GPU has fallen off the bus
+Line 2: Xid 63
+  SysLogsXIDError: error_code=63 non-fatal action=NONE benign_in_rule_pack=GB200: Row remapping
+Line 3: systemd
+  no check matched
+`, out.String())
+
+	t.Run("lines from stdin as json", func(t *testing.T) {
+		var out bytes.Buffer
+
+		err := runTest(context.Background(), []string{"--server", server.URL, "--file", "-", "--output", "json"},
+			strings.NewReader("Xid 79\n"), &out)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"Xid 79"}, received.Lines)
+		assert.True(t, json.Valid(out.Bytes()))
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		err := runTest(context.Background(), []string{"--server", server.URL}, strings.NewReader(""), &bytes.Buffer{})
+		assert.ErrorContains(t, err, "no lines")
+
+		err = runTest(context.Background(), []string{"--server", server.URL, "--line", "x", "--check", "Unknown"},
+			strings.NewReader(""), &bytes.Buffer{})
+		assert.ErrorContains(t, err, "check Unknown is not enabled")
+
+		err = runTest(context.Background(), []string{"--line", "x", "--output", "yaml"},
+			strings.NewReader(""), &bytes.Buffer{})
+		assert.ErrorContains(t, err, "invalid output")
+	})
+}
@@ -0,0 +1,428 @@
+// Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tui
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Node health, worst first
+const (
+	statusFatal     = "fatal"
+	statusUnhealthy = "unhealthy"
+	statusHealthy   = "healthy"
+)
+
+// Verbs of the janitor bulk API behind the actions
+const (
+	verbQuarantine = "quarantine"
+	verbRelease    = "release"
+	verbApprove    = "approve"
+)
+
+// event mirrors an event of the events API of health events analyzer.
+type event struct {
+	ID                string    `json:"id"`
+	NodeName          string    `json:"nodeName"`
+	CheckName         string    `json:"checkName"`
+	ErrorCodes        []string  `json:"errorCodes"`
+	Message           string    `json:"message"`
+	IsFatal           bool      `json:"isFatal"`
+	IsHealthy         bool      `json:"isHealthy"`
+	RecommendedAction string    `json:"recommendedAction"`
+	GeneratedAt       time.Time `json:"generatedAt"`
+}
+
+// status returns the health the event reports.
+func (e event) status() string {
+	switch {
+	case e.IsFatal:
+		return statusFatal
+	case !e.IsHealthy:
+		return statusUnhealthy
+	default:
+		return statusHealthy
+	}
+}
+
+// nodeHealth is the health of a node, from the latest event of each of its checks.
+type nodeHealth struct {
+	name   string
+	status string
+	// failing are the checks whose latest event is not healthy
+	failing   []string
+	events    int
+	lastEvent time.Time
+}
+
+// summarize returns the health of the nodes of the events, which are sorted the
+// most recent first. Fatal nodes come first, then unhealthy and healthy ones.
+func summarize(events []event) []nodeHealth {
+	byName := map[string]*nodeHealth{}
+	seen := map[[2]string]bool{}
+
+	for _, e := range events {
+		node, ok := byName[e.NodeName]
+		if !ok {
+			node = &nodeHealth{name: e.NodeName, status: statusHealthy, lastEvent: e.GeneratedAt}
+			byName[e.NodeName] = node
+		}
+
+		node.events++
+
+		// Older events of a check were superseded by its latest one
+		key := [2]string{e.NodeName, e.CheckName}
+		if seen[key] {
+			continue
+		}
+
+		seen[key] = true
+
+		status := e.status()
+		if status == statusHealthy {
+			continue
+		}
+
+		node.failing = append(node.failing, e.CheckName)
+
+		if severity(status) > severity(node.status) {
+			node.status = status
+		}
+	}
+
+	nodes := make([]nodeHealth, 0, len(byName))
+	for _, node := range byName {
+		sort.Strings(node.failing)
+		nodes = append(nodes, *node)
+	}
+
+	sort.Slice(nodes, func(i, j int) bool {
+		if severity(nodes[i].status) != severity(nodes[j].status) {
+			return severity(nodes[i].status) > severity(nodes[j].status)
+		}
+
+		return nodes[i].name < nodes[j].name
+	})
+
+	return nodes
+}
+
+func severity(status string) int {
+	switch status {
+	case statusFatal:
+		return 2
+	case statusUnhealthy:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// client is how the TUI reaches NVSentinel.
+type client interface {
+	// Events returns the recent events, the most recent first
+	Events(ctx context.Context) ([]event, error)
+	// Explain returns why NVSentinel quarantined the node, as printed by `why`
+	Explain(ctx context.Context, node string) (string, error)
+	// Apply applies a janitor bulk verb to the node
+	Apply(ctx context.Context, verb, node string) error
+}
+
+// Messages update the model. Keys come from the terminal, the others from
+// commands and the refresh ticker.
+type (
+	msg       any
+	keyMsg    string
+	resizeMsg struct{ width, height int }
+	tickMsg   time.Time
+	eventsMsg struct {
+		events []event
+		at     time.Time
+		err    error
+	}
+	detailMsg struct {
+		node string
+		text string
+		err  error
+	}
+	actionMsg struct {
+		verb string
+		node string
+		err  error
+	}
+)
+
+// cmd is run outside of update, its message is fed back to update.
+type cmd func(ctx context.Context) msg
+
+type pane int
+
+const (
+	nodesPane pane = iota
+	eventsPane
+	detailPane
+)
+
+// model is the state of the TUI. It only changes in update and is drawn by view.
+type model struct {
+	client client
+
+	nodes   []nodeHealth
+	events  []event
+	updated time.Time
+
+	pane        pane
+	nodeCursor  int
+	eventCursor int
+
+	detailNode   string
+	detail       string
+	detailOffset int
+
+	// pending is the verb waiting for confirmation on the selected node
+	pending string
+	status  string
+
+	width  int
+	height int
+	// color enables ANSI colors, off in tests
+	color bool
+	quit  bool
+}
+
+func newModel(c client) model {
+	return model{client: c, width: 80, height: 24, status: "Loading events..."}
+}
+
+// init returns the command loading the first events.
+func (m model) init() cmd {
+	return m.fetchEvents()
+}
+
+// update applies the message and returns the next command to run, if any.
+func (m model) update(message msg) (model, cmd) {
+	switch message := message.(type) {
+	case keyMsg:
+		return m.handleKey(message)
+	case resizeMsg:
+		m.width, m.height = message.width, message.height
+	case tickMsg:
+		return m, m.fetchEvents()
+	case eventsMsg:
+		if message.err != nil {
+			m.status = fmt.Sprintf("Failed to refresh events: %v", message.err)
+			return m, nil
+		}
+
+		selected := m.selectedNode()
+		m.events = message.events
+		m.nodes = summarize(message.events)
+		m.updated = message.at
+		m.nodeCursor = 0
+
+		// Stay on the selected node when it moves in the list
+		for i, node := range m.nodes {
+			if node.name == selected {
+				m.nodeCursor = i
+			}
+		}
+
+		m.eventCursor = clamp(m.eventCursor, len(m.events))
+
+		if strings.HasPrefix(m.status, "Loading") || strings.HasPrefix(m.status, "Failed to refresh") {
+			m.status = ""
+		}
+	case detailMsg:
+		if message.node != m.detailNode {
+			return m, nil
+		}
+
+		if message.err != nil {
+			m.detail = fmt.Sprintf("Failed to explain %s: %v", message.node, message.err)
+		} else {
+			m.detail = message.text
+		}
+	case actionMsg:
+		if message.err != nil {
+			m.status = fmt.Sprintf("%s %s failed: %v", message.verb, message.node, message.err)
+		} else {
+			m.status = fmt.Sprintf("%s %s done", message.verb, message.node)
+		}
+
+		if m.pane == detailPane {
+			return m, batch(m.fetchEvents(), m.fetchDetail(m.detailNode))
+		}
+
+		return m, m.fetchEvents()
+	}
+
+	return m, nil
+}
+
+func (m model) handleKey(key keyMsg) (model, cmd) {
+	if key == "ctrl+c" {
+		m.quit = true
+		return m, nil
+	}
+
+	if m.pending != "" {
+		verb, node := m.pending, m.selectedNode()
+		m.pending = ""
+
+		if key != "y" && key != "Y" {
+			m.status = "Cancelled"
+			return m, nil
+		}
+
+		m.status = fmt.Sprintf("Running %s on %s...", verb, node)
+
+		return m, m.apply(verb, node)
+	}
+
+	switch key {
+	case "q":
+		m.quit = true
+	case "up", "k":
+		m.move(-1)
+	case "down", "j":
+		m.move(1)
+	case "tab":
+		switch m.pane {
+		case nodesPane:
+			m.pane = eventsPane
+		case eventsPane:
+			m.pane = nodesPane
+		}
+	case "esc":
+		if m.pane == detailPane {
+			m.pane = nodesPane
+		}
+	case "enter":
+		node := m.selectedNode()
+		if node == "" || m.pane == detailPane {
+			return m, nil
+		}
+
+		m.pane = detailPane
+		m.detailNode = node
+		m.detail = "Loading..."
+		m.detailOffset = 0
+
+		return m, m.fetchDetail(node)
+	case "r":
+		m.status = "Loading events..."
+
+		if m.pane == detailPane {
+			return m, batch(m.fetchEvents(), m.fetchDetail(m.detailNode))
+		}
+
+		return m, m.fetchEvents()
+	case "Q":
+		m.confirm(verbQuarantine)
+	case "R":
+		m.confirm(verbRelease)
+	case "A":
+		m.confirm(verbApprove)
+	}
+
+	return m, nil
+}
+
+// confirm asks to confirm the verb on the selected node.
+func (m *model) confirm(verb string) {
+	node := m.selectedNode()
+	if node == "" {
+		return
+	}
+
+	m.pending = verb
+	m.status = fmt.Sprintf("%s %s? [y/N]", verb, node)
+}
+
+func (m *model) move(delta int) {
+	switch m.pane {
+	case nodesPane:
+		m.nodeCursor = clamp(m.nodeCursor+delta, len(m.nodes))
+	case eventsPane:
+		m.eventCursor = clamp(m.eventCursor+delta, len(m.events))
+	case detailPane:
+		m.detailOffset = clamp(m.detailOffset+delta, strings.Count(m.detail, "\n")+1)
+	}
+}
+
+// selectedNode returns the node the actions apply to: the node of the detail
+// pane, the selected event or the selected node.
+func (m model) selectedNode() string {
+	switch {
+	case m.pane == detailPane:
+		return m.detailNode
+	case m.pane == eventsPane && m.eventCursor < len(m.events):
+		return m.events[m.eventCursor].NodeName
+	case m.pane == nodesPane && m.nodeCursor < len(m.nodes):
+		return m.nodes[m.nodeCursor].name
+	default:
+		return ""
+	}
+}
+
+func (m model) fetchEvents() cmd {
+	c := m.client
+
+	return func(ctx context.Context) msg {
+		events, err := c.Events(ctx)
+		return eventsMsg{events: events, at: time.Now(), err: err}
+	}
+}
+
+func (m model) fetchDetail(node string) cmd {
+	c := m.client
+
+	return func(ctx context.Context) msg {
+		text, err := c.Explain(ctx, node)
+		return detailMsg{node: node, text: text, err: err}
+	}
+}
+
+func (m model) apply(verb, node string) cmd {
+	c := m.client
+
+	return func(ctx context.Context) msg {
+		return actionMsg{verb: verb, node: node, err: c.Apply(ctx, verb, node)}
+	}
+}
+
+// batchMsg carries the commands of batch, the run loop runs each of them.
+type batchMsg []cmd
+
+func batch(cmds ...cmd) cmd {
+	return func(context.Context) msg { return batchMsg(cmds) }
+}
+
+// clamp keeps the index within a list of n items.
+func clamp(index, n int) int {
+	if index >= n {
+		index = n - 1
+	}
+
+	if index < 0 {
+		index = 0
+	}
+
+	return index
+}
@@ -0,0 +1,173 @@
+// Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tui
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeClient struct {
+	events  []event
+	explain map[string]string
+	applied []string
+	err     error
+}
+
+func (c *fakeClient) Events(context.Context) ([]event, error) {
+	return c.events, c.err
+}
+
+func (c *fakeClient) Explain(_ context.Context, node string) (string, error) {
+	return c.explain[node], c.err
+}
+
+func (c *fakeClient) Apply(_ context.Context, verb, node string) error {
+	c.applied = append(c.applied, verb+" "+node)
+	return c.err
+}
+
+func testEvents() []event {
+	at := func(minute int) time.Time { return time.Date(2025, 6, 1, 12, minute, 0, 0, time.UTC) }
+
+	return []event{
+		{NodeName: "h100-2", CheckName: "SysLogsXIDError", ErrorCodes: []string{"79"},
+			Message: "GPU has fallen off the bus", IsFatal: true, GeneratedAt: at(5)},
+		{NodeName: "h100-1", CheckName: "GpuMemWatch", IsHealthy: true, GeneratedAt: at(4)},
+		{NodeName: "h100-3", CheckName: "GpuThermalWatch", ErrorCodes: []string{"DCGM_FR_CLOCK_THROTTLE_THERMAL"},
+			Message: "Clocks throttled", GeneratedAt: at(3)},
+		{NodeName: "h100-1", CheckName: "GpuMemWatch", ErrorCodes: []string{"48"}, Message: "DBE",
+			IsFatal: true, GeneratedAt: at(1)},
+	}
+}
+
+// updated runs the command of an update and feeds its message back, as the run loop does.
+func updated(t *testing.T, m model, message msg) model {
+	t.Helper()
+
+	m, next := m.update(message)
+	if next == nil {
+		return m
+	}
+
+	result := next(context.Background())
+	if cmds, ok := result.(batchMsg); ok {
+		for _, c := range cmds {
+			m, _ = m.update(c(context.Background()))
+		}
+
+		return m
+	}
+
+	m, _ = m.update(result)
+
+	return m
+}
+
+func TestSummarize(t *testing.T) {
+	nodes := summarize(testEvents())
+
+	require.Len(t, nodes, 3)
+	assert.Equal(t, nodeHealth{name: "h100-2", status: statusFatal, failing: []string{"SysLogsXIDError"},
+		events: 1, lastEvent: testEvents()[0].GeneratedAt}, nodes[0])
+	assert.Equal(t, "h100-3", nodes[1].name)
+	assert.Equal(t, statusUnhealthy, nodes[1].status)
+	assert.Equal(t, nodeHealth{name: "h100-1", status: statusHealthy, events: 2,
+		lastEvent: testEvents()[1].GeneratedAt}, nodes[2], "the healthy event supersedes the fatal one")
+}
+
+func TestUpdateNavigation(t *testing.T) {
+	c := &fakeClient{events: testEvents(), explain: map[string]string{"h100-3": "Node h100-3 is quarantined\n"}}
+	m := updated(t, newModel(c), tickMsg(time.Now()))
+
+	assert.Equal(t, "h100-2", m.selectedNode())
+
+	m = updated(t, m, keyMsg("down"))
+	m = updated(t, m, keyMsg("j"))
+	m = updated(t, m, keyMsg("down"))
+	assert.Equal(t, "h100-1", m.selectedNode(), "the cursor stops at the last node")
+
+	m = updated(t, m, keyMsg("tab"))
+	m = updated(t, m, keyMsg("down"))
+	m = updated(t, m, keyMsg("down"))
+	assert.Equal(t, eventsPane, m.pane)
+	assert.Equal(t, "h100-3", m.selectedNode())
+
+	m = updated(t, m, keyMsg("enter"))
+	assert.Equal(t, detailPane, m.pane)
+	assert.Equal(t, "Node h100-3 is quarantined\n", m.detail)
+
+	m = updated(t, m, keyMsg("esc"))
+	assert.Equal(t, nodesPane, m.pane)
+
+	m = updated(t, m, keyMsg("q"))
+	assert.True(t, m.quit)
+}
+
+func TestUpdateKeepsSelectedNode(t *testing.T) {
+	c := &fakeClient{events: testEvents()}
+	m := updated(t, newModel(c), tickMsg(time.Now()))
+	m = updated(t, m, keyMsg("down"))
+	assert.Equal(t, "h100-3", m.selectedNode())
+
+	// h100-1 turns fatal and moves above h100-3
+	c.events = append([]event{{NodeName: "h100-1", CheckName: "GpuMemWatch", IsFatal: true}}, c.events...)
+	m = updated(t, m, tickMsg(time.Now()))
+	assert.Equal(t, "h100-3", m.selectedNode())
+	assert.Equal(t, 2, m.nodeCursor)
+}
+
+func TestUpdateActions(t *testing.T) {
+	c := &fakeClient{events: testEvents()}
+	m := updated(t, newModel(c), tickMsg(time.Now()))
+
+	m = updated(t, m, keyMsg("Q"))
+	assert.Equal(t, "quarantine h100-2? [y/N]", m.status)
+	m = updated(t, m, keyMsg("n"))
+	assert.Equal(t, "Cancelled", m.status)
+	assert.Empty(t, c.applied)
+
+	m = updated(t, m, keyMsg("Q"))
+	m = updated(t, m, keyMsg("y"))
+	assert.Equal(t, "quarantine h100-2 done", m.status)
+
+	m = updated(t, m, keyMsg("down"))
+	m = updated(t, m, keyMsg("R"))
+	m = updated(t, m, keyMsg("y"))
+	m = updated(t, m, keyMsg("A"))
+	m = updated(t, m, keyMsg("y"))
+	assert.Equal(t, []string{"quarantine h100-2", "release h100-3", "approve h100-3"}, c.applied)
+
+	c.err = errors.New("no node action of the node is in Requested")
+	m = updated(t, m, keyMsg("A"))
+	m = updated(t, m, keyMsg("y"))
+	assert.Equal(t, "approve h100-3 failed: no node action of the node is in Requested", m.status)
+}
+
+func TestUpdateRefreshFailure(t *testing.T) {
+	c := &fakeClient{events: testEvents()}
+	m := updated(t, newModel(c), tickMsg(time.Now()))
+
+	c.err = errors.New("connection refused")
+	m = updated(t, m, tickMsg(time.Now()))
+
+	assert.Equal(t, "Failed to refresh events: connection refused", m.status)
+	assert.Len(t, m.nodes, 3, "the last events are kept")
+}
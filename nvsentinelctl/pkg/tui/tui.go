@@ -0,0 +1,302 @@
+// Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package tui implements the interactive terminal UI of nvsentinelctl: live node
+// health, the event stream and incident details, with actions on the nodes.
+package tui
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"golang.org/x/term"
+
+	"github.com/nvidia/nvsentinel/nvsentinelctl/pkg/events"
+	"github.com/nvidia/nvsentinel/nvsentinelctl/pkg/nodes"
+)
+
+// ANSI escape sequences driving the screen
+const (
+	enterScreen = "\x1b[?1049h\x1b[?25l"
+	leaveScreen = "\x1b[?25h\x1b[?1049l"
+	clearScreen = "\x1b[H\x1b[2J"
+)
+
+type options struct {
+	server   string
+	janitor  string
+	token    string
+	window   time.Duration
+	limit    int
+	interval time.Duration
+	timeout  time.Duration
+}
+
+// Run runs `tui`: it shows the health of the nodes with recent events, the events
+// and why a node was quarantined, refreshed live, and quarantines, releases or
+// approves the node actions of the selected node through janitor.
+func Run(ctx context.Context, args []string) error {
+	var opts options
+
+	flags := flag.NewFlagSet("tui", flag.ContinueOnError)
+	flags.StringVar(&opts.server, "server", "http://localhost:2112",
+		"Metrics endpoint of health events analyzer, e.g. after "+
+			"kubectl port-forward -n nvsentinel deployment/health-events-analyzer 2112")
+	flags.StringVar(&opts.janitor, "janitor", "http://localhost:8082",
+		"API endpoint of janitor, e.g. after kubectl port-forward -n nvsentinel deployment/janitor 8082")
+	flags.StringVar(&opts.token, "token", os.Getenv("NVSENTINEL_TOKEN"),
+		"Janitor API token with the operator role for actions, "+
+			"$NVSENTINEL_TOKEN or the token of nvsentinelctl login by default")
+	flags.DurationVar(&opts.window, "window", time.Hour, "Events newer than this are shown")
+	flags.IntVar(&opts.limit, "limit", 1000, "Maximum number of events to load, at most 1000")
+	flags.DurationVar(&opts.interval, "interval", 5*time.Second, "Refresh interval")
+	flags.DurationVar(&opts.timeout, "timeout", 30*time.Second, "Timeout of each request")
+
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	if opts.interval <= 0 || opts.window <= 0 {
+		return fmt.Errorf("--interval and --window must be positive")
+	}
+
+	stdin, stdout := int(os.Stdin.Fd()), int(os.Stdout.Fd())
+	if !term.IsTerminal(stdin) || !term.IsTerminal(stdout) {
+		return fmt.Errorf("tui needs a terminal, use events query or why in scripts")
+	}
+
+	state, err := term.MakeRaw(stdin)
+	if err != nil {
+		return fmt.Errorf("failed to set up terminal: %w", err)
+	}
+	defer func() { _ = term.Restore(stdin, state) }()
+
+	fmt.Fprint(os.Stdout, enterScreen)
+	defer fmt.Fprint(os.Stdout, leaveScreen)
+
+	m := newModel(&httpClient{opts: opts})
+	m.color = true
+
+	size := func() msg {
+		width, height, err := term.GetSize(stdout)
+		if err != nil {
+			return nil
+		}
+
+		return resizeMsg{width: width, height: height}
+	}
+
+	return loop(ctx, m, readKeys(os.Stdin), size, opts.interval, os.Stdout)
+}
+
+// loop feeds the keys, the refresh ticks and the messages of the commands to the
+// model and draws it after each of them, until it quits or ctx is done.
+func loop(ctx context.Context, m model, keys <-chan keyMsg, size func() msg, interval time.Duration,
+	out io.Writer) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	messages := make(chan msg)
+
+	var run func(c cmd)
+	run = func(c cmd) {
+		if c == nil {
+			return
+		}
+
+		go func() {
+			message := c(ctx)
+
+			if cmds, ok := message.(batchMsg); ok {
+				for _, c := range cmds {
+					run(c)
+				}
+
+				return
+			}
+
+			select {
+			case messages <- message:
+			case <-ctx.Done():
+			}
+		}()
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	if message := size(); message != nil {
+		m, _ = m.update(message)
+	}
+
+	run(m.init())
+
+	for {
+		fmt.Fprint(out, clearScreen+strings.ReplaceAll(m.view(), "\n", "\r\n"))
+
+		var (
+			message msg
+			next    cmd
+		)
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case key, ok := <-keys:
+			if !ok {
+				return nil
+			}
+
+			message = key
+		case at := <-ticker.C:
+			message = tickMsg(at)
+		case message = <-messages:
+		}
+
+		// The terminal may have been resized since the last message
+		if resize := size(); resize != nil {
+			m, _ = m.update(resize)
+		}
+
+		m, next = m.update(message)
+		if m.quit {
+			return nil
+		}
+
+		run(next)
+	}
+}
+
+// readKeys reads the keys typed in the terminal until it fails.
+func readKeys(in io.Reader) <-chan keyMsg {
+	keys := make(chan keyMsg)
+
+	go func() {
+		defer close(keys)
+
+		buf := make([]byte, 64)
+
+		for {
+			n, err := in.Read(buf)
+			if err != nil {
+				return
+			}
+
+			for _, key := range parseKeys(buf[:n]) {
+				keys <- key
+			}
+		}
+	}()
+
+	return keys
+}
+
+// parseKeys returns the keys of bytes read from a terminal in raw mode. Arrow
+// keys arrive as escape sequences, other keys as their characters.
+func parseKeys(data []byte) []keyMsg {
+	var keys []keyMsg
+
+	for len(data) > 0 {
+		switch {
+		case strings.HasPrefix(string(data), "\x1b[A") || strings.HasPrefix(string(data), "\x1bOA"):
+			keys, data = append(keys, "up"), data[3:]
+		case strings.HasPrefix(string(data), "\x1b[B") || strings.HasPrefix(string(data), "\x1bOB"):
+			keys, data = append(keys, "down"), data[3:]
+		case data[0] == 0x1b && len(data) >= 3 && (data[1] == '[' || data[1] == 'O'):
+			// Other escape sequences, such as the remaining arrow keys, are ignored
+			data = data[3:]
+		case data[0] == 0x1b:
+			keys, data = append(keys, "esc"), data[1:]
+		case data[0] == '\r' || data[0] == '\n':
+			keys, data = append(keys, "enter"), data[1:]
+		case data[0] == '\t':
+			keys, data = append(keys, "tab"), data[1:]
+		case data[0] == 0x03:
+			keys, data = append(keys, "ctrl+c"), data[1:]
+		default:
+			keys, data = append(keys, keyMsg(data[:1])), data[1:]
+		}
+	}
+
+	return keys
+}
+
+// httpClient reaches health events analyzer and janitor over HTTP.
+type httpClient struct {
+	opts options
+}
+
+func (c *httpClient) Events(ctx context.Context) ([]event, error) {
+	ctx, cancel := context.WithTimeout(ctx, c.opts.timeout)
+	defer cancel()
+
+	values := url.Values{
+		"query": {"time > now-" + c.opts.window.String()},
+		"limit": {strconv.Itoa(c.opts.limit)},
+	}
+	target := strings.TrimSuffix(c.opts.server, "/") + "/events?" + values.Encode()
+
+	httpRequest, err := http.NewRequestWithContext(ctx, http.MethodGet, target, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	httpResponse, err := http.DefaultClient.Do(httpRequest)
+	if err != nil {
+		return nil, fmt.Errorf("failed to reach %s: %w", c.opts.server, err)
+	}
+	defer httpResponse.Body.Close()
+
+	body, err := io.ReadAll(httpResponse.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response: %w", err)
+	}
+
+	if httpResponse.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("%s returned %s: %s", c.opts.server, httpResponse.Status,
+			strings.TrimSpace(string(body)))
+	}
+
+	var response struct {
+		Events []event `json:"events"`
+	}
+	if err := json.Unmarshal(body, &response); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	return response.Events, nil
+}
+
+func (c *httpClient) Explain(ctx context.Context, node string) (string, error) {
+	var out strings.Builder
+	if err := events.Explain(ctx, c.opts.server, node, c.opts.timeout, &out); err != nil {
+		return "", err
+	}
+
+	return out.String(), nil
+}
+
+func (c *httpClient) Apply(ctx context.Context, verb, node string) error {
+	return nodes.Apply(ctx, c.opts.janitor, c.opts.token, verb, "requested from nvsentinelctl tui",
+		[]string{node}, c.opts.timeout)
+}
@@ -0,0 +1,91 @@
+// Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tui
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseKeys(t *testing.T) {
+	assert.Equal(t, []keyMsg{"up", "down", "up", "esc", "enter", "tab", "ctrl+c", "Q", "y"},
+		parseKeys([]byte("\x1b[A\x1b[B\x1bOA\x1b\r\t\x03Qy")))
+	assert.Equal(t, []keyMsg{"j"}, parseKeys([]byte("\x1b[Cj")), "right arrow is ignored")
+}
+
+func TestLoop(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/events" && r.URL.Query().Get("query") == "time > now-1h0m0s":
+			_, _ = w.Write([]byte(`{"events": [{"nodeName": "h100-2", "checkName": "SysLogsXIDError",
+				"errorCodes": ["79"], "isFatal": true, "generatedAt": "2025-06-01T12:05:00Z"}]}`))
+		case r.URL.Path == "/quarantine" && r.URL.Query().Get("node") == "h100-2":
+			_, _ = w.Write([]byte(`{"nodeName": "h100-2", "quarantined": false, "status": "", "events": []}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	c := &httpClient{opts: options{server: server.URL, window: time.Hour, limit: 10, timeout: 5 * time.Second}}
+	keys := make(chan keyMsg)
+	size := func() msg { return resizeMsg{width: 100, height: 20} }
+	out := &screen{}
+
+	done := make(chan error)
+	go func() { done <- loop(context.Background(), newModel(c), keys, size, time.Hour, out) }()
+
+	require.Eventually(t, func() bool { return strings.Contains(out.String(), "NODES (1)") },
+		5*time.Second, 10*time.Millisecond)
+
+	keys <- "enter"
+
+	require.Eventually(t, func() bool { return strings.Contains(out.String(), "Node h100-2 was never quarantined") },
+		5*time.Second, 10*time.Millisecond)
+
+	keys <- "q"
+
+	require.NoError(t, <-done)
+	assert.NotContains(t, strings.ReplaceAll(out.String(), "\r\n", ""), "\n", "raw mode needs carriage returns")
+}
+
+// screen records what the loop draws.
+type screen struct {
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+func (s *screen) Write(p []byte) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.buf.Write(p)
+}
+
+func (s *screen) String() string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.buf.String()
+}
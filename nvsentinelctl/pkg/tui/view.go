@@ -0,0 +1,215 @@
+// Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tui
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"text/tabwriter"
+	"time"
+)
+
+// ANSI escape sequences of the styles
+const (
+	styleReset   = "\x1b[0m"
+	styleBold    = "\x1b[1m"
+	styleReverse = "\x1b[7m"
+	styleRed     = "\x1b[31m"
+	styleYellow  = "\x1b[33m"
+)
+
+const (
+	listHelp   = "up/down move  tab nodes/events  enter details  Q quarantine  R release  A approve  r refresh  q quit"
+	detailHelp = "up/down scroll  esc back  Q quarantine  R release  A approve  r refresh  q quit"
+)
+
+// view draws the model on a screen of m.width by m.height.
+func (m model) view() string {
+	var lines []string
+
+	lines = append(lines, m.style(styleBold, m.header()))
+
+	// The header, status and help lines frame the panes
+	body := max(m.height-3, 6)
+
+	if m.pane == detailPane {
+		lines = append(lines, m.detailLines(body)...)
+	} else {
+		// Each pane has a title and a column header above its rows
+		nodeRows := (body - 4) / 2
+		lines = append(lines, m.nodeLines(nodeRows)...)
+		lines = append(lines, m.eventLines(body-4-nodeRows)...)
+	}
+
+	help := listHelp
+	if m.pane == detailPane {
+		help = detailHelp
+	}
+
+	lines = append(lines, m.style(styleBold, m.fit(m.status)), m.fit(help))
+
+	return strings.Join(lines, "\n")
+}
+
+func (m model) header() string {
+	fatal, unhealthy := 0, 0
+
+	for _, node := range m.nodes {
+		switch node.status {
+		case statusFatal:
+			fatal++
+		case statusUnhealthy:
+			unhealthy++
+		}
+	}
+
+	header := fmt.Sprintf("NVSentinel  %d nodes, %d fatal, %d unhealthy", len(m.nodes), fatal, unhealthy)
+	if !m.updated.IsZero() {
+		header += "  updated " + m.updated.Format(time.TimeOnly)
+	}
+
+	return m.fit(header)
+}
+
+// nodeLines draws the title and rows of the nodes pane.
+func (m model) nodeLines(rows int) []string {
+	table := []string{"NODE\tHEALTH\tFAILING CHECKS\tEVENTS\tLAST EVENT"}
+	statuses := []string{""}
+
+	for _, node := range m.nodes {
+		table = append(table, fmt.Sprintf("%s\t%s\t%s\t%d\t%s", node.name, node.status,
+			strings.Join(node.failing, ","), node.events, node.lastEvent.Format(time.TimeOnly)))
+		statuses = append(statuses, node.status)
+	}
+
+	return m.paneLines(fmt.Sprintf("NODES (%d)", len(m.nodes)), m.pane == nodesPane, table, statuses,
+		m.nodeCursor, rows)
+}
+
+// eventLines draws the title and rows of the events pane.
+func (m model) eventLines(rows int) []string {
+	table := []string{"TIME\tNODE\tCHECK\tERROR CODES\tSTATUS\tMESSAGE"}
+	statuses := []string{""}
+
+	for _, e := range m.events {
+		message, _, _ := strings.Cut(e.Message, "\n")
+		table = append(table, fmt.Sprintf("%s\t%s\t%s\t%s\t%s\t%s", e.GeneratedAt.Format(time.TimeOnly),
+			e.NodeName, e.CheckName, strings.Join(e.ErrorCodes, ","), e.status(), message))
+		statuses = append(statuses, e.status())
+	}
+
+	return m.paneLines(fmt.Sprintf("EVENTS (%d)", len(m.events)), m.pane == eventsPane, table, statuses,
+		m.eventCursor, rows)
+}
+
+// paneLines aligns the table, whose first row is the column header, and keeps
+// rows lines of it around the cursor. The lines are colored by status and the
+// cursor is highlighted when the pane is focused.
+func (m model) paneLines(title string, focused bool, table, statuses []string, cursor, rows int) []string {
+	if focused {
+		title = m.style(styleBold, title)
+	}
+
+	lines := []string{title}
+
+	var buf bytes.Buffer
+
+	writer := tabwriter.NewWriter(&buf, 0, 0, 2, ' ', 0)
+	for _, row := range table {
+		fmt.Fprintln(writer, row)
+	}
+
+	_ = writer.Flush()
+
+	aligned := strings.Split(strings.TrimSuffix(buf.String(), "\n"), "\n")
+	for i := range aligned {
+		// Empty last columns leave padding behind
+		aligned[i] = strings.TrimRight(aligned[i], " ")
+	}
+
+	lines = append(lines, m.fit("  "+aligned[0]))
+
+	items := aligned[1:]
+	start := 0
+
+	if rows > 0 && cursor >= rows {
+		start = cursor - rows + 1
+	}
+
+	for i := start; i < len(items) && i < start+rows; i++ {
+		prefix := "  "
+		if focused && i == cursor {
+			prefix = "> "
+		}
+
+		line := m.fit(prefix + items[i])
+
+		switch statuses[i+1] {
+		case statusFatal:
+			line = m.style(styleRed, line)
+		case statusUnhealthy:
+			line = m.style(styleYellow, line)
+		}
+
+		if focused && i == cursor {
+			line = m.style(styleReverse, line)
+		}
+
+		lines = append(lines, line)
+	}
+
+	// Panes keep their height so the screen does not jump as lists change
+	for len(lines) < rows+2 {
+		lines = append(lines, "")
+	}
+
+	return lines
+}
+
+// detailLines draws the title and the lines of the detail pane from the scroll
+// offset.
+func (m model) detailLines(rows int) []string {
+	lines := []string{m.style(styleBold, m.fit("DETAILS "+m.detailNode))}
+
+	text := strings.Split(strings.TrimSuffix(m.detail, "\n"), "\n")
+	for i := m.detailOffset; i < len(text) && i < m.detailOffset+rows-1; i++ {
+		lines = append(lines, m.fit(text[i]))
+	}
+
+	for len(lines) < rows {
+		lines = append(lines, "")
+	}
+
+	return lines
+}
+
+// fit cuts the line to the width of the screen.
+func (m model) fit(line string) string {
+	runes := []rune(line)
+	if m.width > 0 && len(runes) > m.width {
+		return string(runes[:m.width])
+	}
+
+	return line
+}
+
+func (m model) style(style, text string) string {
+	if !m.color || text == "" {
+		return text
+	}
+
+	return style + text + styleReset
+}
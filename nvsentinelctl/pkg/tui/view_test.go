@@ -0,0 +1,73 @@
+// Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tui
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestView(t *testing.T) {
+	m := newModel(&fakeClient{})
+	m, _ = m.update(resizeMsg{width: 100, height: 15})
+	m, _ = m.update(eventsMsg{events: testEvents(), at: time.Date(2025, 6, 1, 12, 6, 0, 0, time.UTC)})
+
+	assert.Equal(t, `NVSentinel  3 nodes, 1 fatal, 1 unhealthy  updated 12:06:00
+NODES (3)
+  NODE    HEALTH     FAILING CHECKS   EVENTS  LAST EVENT
+> h100-2  fatal      SysLogsXIDError  1       12:05:00
+  h100-3  unhealthy  GpuThermalWatch  1       12:03:00
+  h100-1  healthy                     2       12:04:00
+
+EVENTS (4)
+  TIME      NODE    CHECK            ERROR CODES                     STATUS     MESSAGE
+  12:05:00  h100-2  SysLogsXIDError  79                              fatal      GPU has fallen off t
+  12:04:00  h100-1  GpuMemWatch                                      healthy
+  12:03:00  h100-3  GpuThermalWatch  DCGM_FR_CLOCK_THROTTLE_THERMAL  unhealthy  Clocks throttled
+  12:01:00  h100-1  GpuMemWatch      48                              fatal      DBE
+
+up/down move  tab nodes/events  enter details  Q quarantine  R release  A approve  r refresh  q quit`,
+		m.view())
+}
+
+func TestViewDetail(t *testing.T) {
+	m := newModel(&fakeClient{})
+	m, _ = m.update(resizeMsg{width: 40, height: 9})
+	m.pane = detailPane
+	m.detailNode = "h100-2"
+	m.detail = "Node h100-2 is quarantined\nline 2\nline 3\nline 4\nline 5\nline 6\nline 7\n"
+	m.detailOffset = 1
+	m.status = "release h100-2 done"
+
+	assert.Equal(t, `NVSentinel  0 nodes, 0 fatal, 0 unhealth
+DETAILS h100-2
+line 2
+line 3
+line 4
+line 5
+line 6
+release h100-2 done
+up/down scroll  esc back  Q quarantine  `, m.view())
+}
+
+func TestViewColor(t *testing.T) {
+	m := newModel(&fakeClient{})
+	m.color = true
+	m, _ = m.update(eventsMsg{events: testEvents()[:1]})
+
+	assert.Contains(t, m.view(), styleReverse+styleRed+"> h100-2  fatal")
+}
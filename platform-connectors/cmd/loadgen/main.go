@@ -0,0 +1,117 @@
+// Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Command loadgen simulates the health monitors of a large cluster publishing
+// events to a platform connector and reports its throughput and latency.
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/nvidia/nvsentinel/platform-connectors/pkg/loadgen"
+)
+
+func main() {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	if err := run(ctx); err != nil {
+		fmt.Fprintln(os.Stderr, "Error:", err)
+		os.Exit(1)
+	}
+}
+
+func run(ctx context.Context) error {
+	cfg := loadgen.Config{Mix: loadgen.DefaultMix}
+
+	var (
+		mixPath string
+		output  string
+	)
+
+	flag.StringVar(&cfg.Socket, "socket", "/var/run/nvsentinel.sock", "Unix socket of the platform connector")
+	flag.IntVar(&cfg.Agents, "agents", 1000, "Number of simulated health monitors, one per node")
+	flag.StringVar(&cfg.NodePrefix, "node-prefix", "loadgen", "Prefix of the simulated node names")
+	flag.Float64Var(&cfg.Rate, "rate", 1000, "Events per second published by all the agents")
+	flag.IntVar(&cfg.BatchSize, "batch-size", 1, "Events per request")
+	flag.IntVar(&cfg.Connections, "connections", 16, "gRPC connections shared by the agents")
+	flag.DurationVar(&cfg.Duration, "duration", time.Minute, "How long to run, until interrupted when 0")
+	flag.DurationVar(&cfg.Timeout, "timeout", 10*time.Second, "Timeout of a request")
+	flag.DurationVar(&cfg.ProgressInterval, "progress-interval", 10*time.Second,
+		"Interval between progress lines on stderr, none when 0")
+	flag.Uint64Var(&cfg.Seed, "seed", uint64(time.Now().UnixNano()), "Seed of the event mix")
+	flag.StringVar(&mixPath, "mix", "",
+		"JSON file with the weighted event mix, see the platform connectors README. A built-in mix by default")
+	flag.StringVar(&output, "output", "text", "Output format of the report: text or json")
+	flag.Parse()
+
+	if output != "text" && output != "json" {
+		return fmt.Errorf("invalid output %q, expected text or json", output)
+	}
+
+	if mixPath != "" {
+		mix, err := loadgen.LoadMix(mixPath)
+		if err != nil {
+			return err
+		}
+
+		cfg.Mix = mix
+	}
+
+	if cfg.ProgressInterval > 0 {
+		cfg.Progress = func(report *loadgen.Report) {
+			fmt.Fprintf(os.Stderr, "%s: %d events, %.1f events/s, p99 %s, %d errors\n",
+				report.Elapsed.Round(time.Second), report.Events, report.Throughput, report.Latency.P99, report.Errors)
+		}
+	}
+
+	report, err := loadgen.Run(ctx, cfg)
+	if err != nil {
+		return err
+	}
+
+	if output == "json" {
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+
+		return encoder.Encode(report)
+	}
+
+	printReport(os.Stdout, &cfg, report)
+
+	return nil
+}
+
+func printReport(out io.Writer, cfg *loadgen.Config, report *loadgen.Report) {
+	fmt.Fprintf(out, "Agents:      %d over %d connections, %d events per request\n",
+		cfg.Agents, cfg.Connections, cfg.BatchSize)
+	fmt.Fprintf(out, "Elapsed:     %s\n", report.Elapsed.Round(time.Millisecond))
+	fmt.Fprintf(out, "Events:      %d in %d requests, %d failed requests\n",
+		report.Events, report.Requests, report.Errors)
+	fmt.Fprintf(out, "Throughput:  %.1f events/s (target %.1f)\n", report.Throughput, report.TargetRate)
+	fmt.Fprintf(out, "Latency:     mean %s, p50 %s, p90 %s, p99 %s, max %s\n",
+		report.Latency.Mean, report.Latency.P50, report.Latency.P90, report.Latency.P99, report.Latency.Max)
+
+	if report.LastError != "" {
+		fmt.Fprintf(out, "Last error:  %s\n", report.LastError)
+	}
+}
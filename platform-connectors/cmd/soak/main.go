@@ -0,0 +1,281 @@
+// Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Command soak plays hours of scripted faults of a simulated fleet against a control
+// plane and reports its memory growth, store size and decision latency. It exits
+// with an error when a threshold is exceeded, so it can gate releases.
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"sort"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/nvidia/nvsentinel/platform-connectors/pkg/soak"
+	"github.com/nvidia/nvsentinel/store-client/pkg/storewatcher"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/clientcmd"
+)
+
+const mib = 1 << 20
+
+func main() {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	if err := run(ctx); err != nil {
+		fmt.Fprintln(os.Stderr, "Error:", err)
+		os.Exit(1)
+	}
+}
+
+//nolint:cyclop // flag parsing
+func run(ctx context.Context) error {
+	var (
+		cfg           soak.Config
+		nodes         int
+		scenarioPath  string
+		store         bool
+		createNodes   bool
+		kubeconfig    string
+		memoryGrowth  float64
+		output        string
+		progressLines bool
+	)
+
+	flag.StringVar(&cfg.Socket, "socket", "/var/run/nvsentinel.sock", "Unix socket of the platform connector")
+	flag.StringVar(&cfg.NodePrefix, "node-prefix", "soak", "Prefix of the simulated node names")
+	flag.IntVar(&nodes, "nodes", 5000, "Size of the simulated fleet of the default scenario")
+	flag.StringVar(&scenarioPath, "scenario", "",
+		"JSON file with the scenario, see the platform connectors README. A built-in scenario by default")
+	flag.IntVar(&cfg.Connections, "connections", 16, "gRPC connections of every load")
+	flag.DurationVar(&cfg.Timeout, "timeout", 10*time.Second, "Timeout of a request")
+	flag.Func("metrics", "Metrics endpoint of a control plane component as name=url, repeatable", func(value string) error {
+		target, err := soak.ParseTarget(value)
+		if err != nil {
+			return err
+		}
+
+		cfg.Targets = append(cfg.Targets, target)
+
+		return nil
+	})
+	flag.BoolVar(&store, "store", false,
+		"Sample the size of the health events collection, configured by the MONGODB_* environment variables")
+	flag.BoolVar(&createNodes, "create-nodes", false,
+		"Create the nodes of the fleet as KWOK fake nodes and delete them at the end")
+	flag.StringVar(&kubeconfig, "kubeconfig", "", "Kubeconfig of --create-nodes, the in-cluster config by default")
+	flag.DurationVar(&cfg.SampleInterval, "sample-interval", 30*time.Second, "Interval between samples")
+	flag.DurationVar(&cfg.Warmup, "warmup", 15*time.Minute, "Start of the run excluded from the growth rates")
+	flag.Float64Var(&memoryGrowth, "max-memory-growth", 32,
+		"MiB per hour the memory of a component may grow by after the warm up, 0 to disable")
+	flag.Float64Var(&cfg.Thresholds.MaxStoreBytesPerEvent, "max-store-bytes-per-event", 4096,
+		"Bytes the store may grow by per event, 0 to disable")
+	flag.DurationVar(&cfg.Thresholds.MaxDecisionLatency, "max-decision-latency", time.Minute,
+		"p99 latency from ingestion to a decision allowed in every phase, 0 to disable")
+	flag.Float64Var(&cfg.Thresholds.MinThroughputRatio, "min-throughput-ratio", 0.9,
+		"Share of the rate of every load the connector must accept, 0 to disable")
+	flag.Uint64Var(&cfg.Seed, "seed", uint64(time.Now().UnixNano()), "Seed of the events")
+	flag.BoolVar(&progressLines, "progress", true, "Print a progress line on stderr with every sample")
+	flag.StringVar(&output, "output", "text", "Output format of the report: text or json")
+	flag.Parse()
+
+	if output != "text" && output != "json" {
+		return fmt.Errorf("invalid output %q, expected text or json", output)
+	}
+
+	cfg.Thresholds.MaxMemoryGrowth = memoryGrowth * mib
+	cfg.Scenario = soak.DefaultScenario(nodes)
+
+	if scenarioPath != "" {
+		scenario, err := soak.LoadScenario(scenarioPath)
+		if err != nil {
+			return err
+		}
+
+		cfg.Scenario = *scenario
+	}
+
+	if store {
+		mongoConfig, _, err := storewatcher.LoadConfigFromEnv("soak")
+		if err != nil {
+			return err
+		}
+
+		collection, err := storewatcher.GetCollectionClient(ctx, mongoConfig)
+		if err != nil {
+			return fmt.Errorf("failed to connect to the store: %w", err)
+		}
+
+		cfg.StoreStats = soak.MongoStoreStats(collection)
+	}
+
+	if progressLines {
+		cfg.Progress = printProgress
+	}
+
+	if createNodes {
+		cleanup, err := createFleet(ctx, kubeconfig, cfg.NodePrefix, cfg.Scenario.Nodes)
+		if err != nil {
+			return err
+		}
+		defer cleanup()
+	}
+
+	fmt.Fprintf(os.Stderr, "Playing scenario %s of %d nodes for %s\n",
+		cfg.Scenario.Name, cfg.Scenario.Nodes, cfg.Scenario.Duration())
+
+	report, err := soak.Run(ctx, cfg)
+	if err != nil {
+		return err
+	}
+
+	if output == "json" {
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+
+		if err := encoder.Encode(report); err != nil {
+			return err
+		}
+	} else {
+		printReport(os.Stdout, report)
+	}
+
+	if !report.Passed() {
+		return fmt.Errorf("%d thresholds exceeded", len(report.Violations))
+	}
+
+	return nil
+}
+
+func createFleet(ctx context.Context, kubeconfig, prefix string, nodes int) (func(), error) {
+	restConfig, err := clientcmd.BuildConfigFromFlags("", kubeconfig)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load kubeconfig: %w", err)
+	}
+
+	client, err := kubernetes.NewForConfig(restConfig)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create kubernetes client: %w", err)
+	}
+
+	cleanup := func() {
+		if err := soak.DeleteFleet(context.WithoutCancel(ctx), client); err != nil {
+			fmt.Fprintln(os.Stderr, "Failed to delete the fleet:", err)
+		}
+	}
+
+	fmt.Fprintf(os.Stderr, "Creating %d KWOK nodes %s-0 to %s-%d\n", nodes, prefix, prefix, nodes-1)
+
+	if err := soak.CreateFleet(ctx, client, prefix, nodes); err != nil {
+		cleanup()
+		return nil, err
+	}
+
+	return cleanup, nil
+}
+
+func printProgress(phase string, sample soak.Sample, errs []error) {
+	components := sortedKeys(sample.Memory)
+	memory := make([]string, 0, len(components))
+
+	for _, component := range components {
+		memory = append(memory, fmt.Sprintf("%s %.0fMiB", component, sample.Memory[component]/mib))
+	}
+
+	line := fmt.Sprintf("%s [%s] memory: %s", sample.Time.Format(time.TimeOnly), phase, strings.Join(memory, ", "))
+	if sample.Store != nil {
+		line += fmt.Sprintf("; store: %d documents, %.1fMiB", sample.Store.Documents, float64(sample.Store.Bytes)/mib)
+	}
+
+	fmt.Fprintln(os.Stderr, line)
+
+	for _, err := range errs {
+		fmt.Fprintln(os.Stderr, "  sample failed:", err)
+	}
+}
+
+func printReport(out io.Writer, report *soak.Report) {
+	status := "completed"
+	if !report.Completed {
+		status = "interrupted"
+	}
+
+	fmt.Fprintf(out, "Scenario:    %s, %d nodes, %s in %s\n",
+		report.Scenario, report.Nodes, status, report.Elapsed.Round(time.Second))
+	fmt.Fprintf(out, "Events:      %d accepted, %d failed requests, %d failed samples\n",
+		report.Events, report.Errors, report.SampleErrors)
+
+	fmt.Fprintln(out, "\nPhases:")
+
+	for _, phase := range report.Phases {
+		fmt.Fprintf(out, "  %s (%s)\n", phase.Name, phase.Elapsed.Round(time.Second))
+
+		for i, load := range phase.Loads {
+			fmt.Fprintf(out, "    load %d: %.1f events/s (target %.1f), p99 %s, %d errors\n",
+				i, load.Throughput, load.TargetRate, load.Latency.P99, load.Errors)
+		}
+
+		for _, component := range sortedKeys(phase.DecisionLatency) {
+			latency := phase.DecisionLatency[component]
+			fmt.Fprintf(out, "    %s: %d decisions, p50 %s, p99 %s\n",
+				component, latency.Decisions, latency.P50.Round(time.Millisecond), latency.P99.Round(time.Millisecond))
+		}
+	}
+
+	fmt.Fprintln(out, "\nMemory:")
+
+	for _, component := range sortedKeys(report.Memory) {
+		memory := report.Memory[component]
+		fmt.Fprintf(out, "  %s: %.0fMiB to %.0fMiB, peak %.0fMiB, %+.1fMiB/h, goroutines %.0f to %.0f\n",
+			component, memory.Start/mib, memory.End/mib, memory.Peak/mib, memory.GrowthPerHour/mib,
+			memory.StartGoroutines, memory.EndGoroutines)
+	}
+
+	if store := report.Store; store != nil {
+		fmt.Fprintf(out, "\nStore:       %d to %d documents, %.1fMiB to %.1fMiB, %+.1fMiB/h, %.0f bytes/event\n",
+			store.Start.Documents, store.End.Documents, float64(store.Start.Bytes)/mib, float64(store.End.Bytes)/mib,
+			store.GrowthPerHour/mib, store.BytesPerEvent)
+	}
+
+	if report.Passed() {
+		fmt.Fprintln(out, "\nPASSED")
+		return
+	}
+
+	fmt.Fprintln(out, "\nFAILED:")
+
+	for _, violation := range report.Violations {
+		fmt.Fprintf(out, "  %s\n", violation)
+	}
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+
+	sort.Strings(keys)
+
+	return keys
+}
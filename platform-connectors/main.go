@@ -28,15 +28,24 @@ import (
 
 	"github.com/nvidia/nvsentinel/commons/pkg/logger"
 	srv "github.com/nvidia/nvsentinel/commons/pkg/server"
+	_ "github.com/nvidia/nvsentinel/data-models/pkg/compression"
 	pb "github.com/nvidia/nvsentinel/data-models/pkg/protos"
+	"github.com/nvidia/nvsentinel/data-models/pkg/schema"
+	"github.com/nvidia/nvsentinel/platform-connectors/pkg/autotune"
+	"github.com/nvidia/nvsentinel/platform-connectors/pkg/canary"
 	"github.com/nvidia/nvsentinel/platform-connectors/pkg/connectors/kubernetes"
 	"github.com/nvidia/nvsentinel/platform-connectors/pkg/connectors/store"
+	"github.com/nvidia/nvsentinel/platform-connectors/pkg/dlq"
 	"github.com/nvidia/nvsentinel/platform-connectors/pkg/nodemetadata"
+	"github.com/nvidia/nvsentinel/platform-connectors/pkg/redaction"
 	"github.com/nvidia/nvsentinel/platform-connectors/pkg/ringbuffer"
 	"github.com/nvidia/nvsentinel/platform-connectors/pkg/server"
 	"golang.org/x/sync/errgroup"
 
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
 	"k8s.io/apimachinery/pkg/util/json"
 	k8s "k8s.io/client-go/kubernetes"
 )
@@ -84,8 +93,10 @@ func initializeK8sConnector(
 	ctx context.Context,
 	config map[string]interface{},
 	stopCh chan struct{},
+	deadLetters *dlq.Queue,
 ) (*ringbuffer.RingBuffer, nodemetadata.Processor, error) {
 	k8sRingBuffer := ringbuffer.NewRingBuffer("kubernetes", ctx)
+	k8sRingBuffer.SetDeadLetterQueue(deadLetters)
 	server.InitializeAndAttachRingBufferForConnectors(k8sRingBuffer)
 
 	qpsTemp, ok := config["K8sConnectorQps"].(float64)
@@ -145,8 +156,10 @@ func initializeNodeMetadataProcessor(
 func initializeMongoDBConnector(
 	ctx context.Context,
 	mongoClientCertMountPath string,
+	deadLetters *dlq.Queue,
 ) (*store.MongoDbStoreConnector, error) {
 	ringBuffer := ringbuffer.NewRingBuffer("mongodbStore", ctx)
+	ringBuffer.SetDeadLetterQueue(deadLetters)
 	server.InitializeAndAttachRingBufferForConnectors(ringBuffer)
 
 	storeConnector, err := store.InitializeMongoDbStoreConnector(ctx, ringBuffer, mongoClientCertMountPath)
@@ -159,7 +172,101 @@ func initializeMongoDBConnector(
 	return storeConnector, nil
 }
 
-func startGRPCServer(ctx context.Context, socket string, processor nodemetadata.Processor) (net.Listener, error) {
+// deadLetterQueueCapacity returns the configured number of dead letters kept.
+func deadLetterQueueCapacity(config map[string]interface{}) int {
+	switch capacity := config["deadLetterQueueCapacity"].(type) {
+	case int64:
+		return int(capacity)
+	case float64:
+		return int(capacity)
+	default:
+		return dlq.DefaultCapacity
+	}
+}
+
+func initializeAutotune(ctx context.Context, config map[string]interface{}) (*autotune.Controller, error) {
+	cfg, err := autotune.NewConfigFromMap(config)
+	if err != nil {
+		return nil, fmt.Errorf("invalid autotune config: %w", err)
+	}
+
+	if !cfg.Enabled {
+		slog.Info("Event filter auto-tuning is disabled")
+
+		return nil, nil
+	}
+
+	tuner := autotune.NewController(*cfg, server.QueueDepth)
+	go tuner.Run(ctx)
+
+	slog.Info("Event filter auto-tuning enabled",
+		"baseDedupInterval", cfg.BaseDedupInterval,
+		"baseRateThreshold", cfg.BaseRateThreshold,
+		"targetQueueDepth", cfg.TargetQueueDepth,
+		"targetEventsPerSecond", cfg.TargetEventsPerSecond)
+
+	return tuner, nil
+}
+
+// initializeRedactor compiles the redaction rules, it returns nil without rules.
+func initializeRedactor(config map[string]interface{}) (*redaction.Redactor, error) {
+	rules, err := redaction.NewRulesFromMap(config)
+	if err != nil {
+		return nil, fmt.Errorf("invalid redaction rules: %w", err)
+	}
+
+	redactor, err := redaction.NewRedactor(rules)
+	if err != nil {
+		return nil, fmt.Errorf("invalid redaction rules: %w", err)
+	}
+
+	if redactor != nil {
+		slog.Info("Redacting health events", "rules", len(rules))
+	}
+
+	return redactor, nil
+}
+
+// initializeCanary starts the canary prober when this platform connector runs on the
+// canary node. The canary events are sent over the socket like a health monitor's.
+func initializeCanary(
+	ctx context.Context,
+	config map[string]interface{},
+	socket string,
+	storeConnector *store.MongoDbStoreConnector,
+) (*grpc.ClientConn, error) {
+	cfg, err := canary.NewConfigFromMap(config)
+	if err != nil {
+		return nil, fmt.Errorf("invalid canary config: %w", err)
+	}
+
+	if !cfg.Enabled || cfg.NodeName != os.Getenv("NODE_NAME") {
+		return nil, nil
+	}
+
+	if storeConnector == nil {
+		return nil, fmt.Errorf("the canary requires the MongoDB store connector")
+	}
+
+	conn, err := grpc.NewClient("unix://"+socket, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create canary client: %w", err)
+	}
+
+	go canary.NewProber(*cfg, pb.NewPlatformConnectorClient(conn), storeConnector).Run(ctx)
+
+	return conn, nil
+}
+
+func startGRPCServer(
+	ctx context.Context,
+	socket string,
+	processor nodemetadata.Processor,
+	tuner *autotune.Controller,
+	clusterName string,
+	deadLetters *dlq.Queue,
+	redactor *redaction.Redactor,
+) (net.Listener, error) {
 	err := os.Remove(socket)
 	if err != nil && !os.IsNotExist(err) {
 		return nil, fmt.Errorf("failed to remove existing socket: %w", err)
@@ -172,12 +279,25 @@ func startGRPCServer(ctx context.Context, socket string, processor nodemetadata.
 		return nil, fmt.Errorf("failed to listen on unix socket %s: %w", socket, err)
 	}
 
-	var opts []grpc.ServerOption
+	// Compressors are registered by the compression package and negotiated per
+	// call, so clients may send gzip, zstd or uncompressed payloads.
+	opts := []grpc.ServerOption{
+		grpc.StatsHandler(server.PayloadStatsHandler{}),
+	}
+
+	connectorServer := &server.PlatformConnectorServer{
+		Processor:   processor,
+		Tuner:       tuner,
+		ClusterName: clusterName,
+		DeadLetters: deadLetters,
+		Redactor:    redactor,
+	}
+	deadLetters.SetRedrive(connectorServer.RedriveDeadLetter)
 
 	grpcServer := grpc.NewServer(opts...)
-	pb.RegisterPlatformConnectorServer(grpcServer, &server.PlatformConnectorServer{
-		Processor: processor,
-	})
+	pb.RegisterPlatformConnectorServer(grpcServer, connectorServer)
+	// Agents probe the health service to fail over between connector endpoints
+	healthpb.RegisterHealthServer(grpcServer, health.NewServer())
 
 	go func() {
 		err = grpcServer.Serve(lis)
@@ -195,6 +315,7 @@ func initializeConnectors(
 	config map[string]interface{},
 	stopCh chan struct{},
 	mongoClientCertMountPath string,
+	deadLetters *dlq.Queue,
 ) (*ringbuffer.RingBuffer, *store.MongoDbStoreConnector, nodemetadata.Processor, error) {
 	var (
 		k8sRingBuffer  *ringbuffer.RingBuffer
@@ -204,14 +325,14 @@ func initializeConnectors(
 	)
 
 	if config["enableK8sPlatformConnector"] == True {
-		k8sRingBuffer, processor, err = initializeK8sConnector(ctx, config, stopCh)
+		k8sRingBuffer, processor, err = initializeK8sConnector(ctx, config, stopCh, deadLetters)
 		if err != nil {
 			return nil, nil, nil, fmt.Errorf("failed to initialize K8s connector: %w", err)
 		}
 	}
 
 	if config["enableMongoDBStorePlatformConnector"] == True {
-		storeConnector, err = initializeMongoDBConnector(ctx, mongoClientCertMountPath)
+		storeConnector, err = initializeMongoDBConnector(ctx, mongoClientCertMountPath, deadLetters)
 		if err != nil {
 			return nil, nil, nil, fmt.Errorf("failed to initialize MongoDB store connector: %w", err)
 		}
@@ -278,25 +399,60 @@ func run() error {
 		return err
 	}
 
-	k8sRingBuffer, storeConnector, processor, err := initializeConnectors(ctx, config, stopCh, *mongoClientCertMountPath)
+	// Events that fail validation or a connector are kept for inspection and re-drive
+	deadLetters := dlq.NewQueue(deadLetterQueueCapacity(config))
+
+	k8sRingBuffer, storeConnector, processor, err := initializeConnectors(ctx, config, stopCh,
+		*mongoClientCertMountPath, deadLetters)
+	if err != nil {
+		return err
+	}
+
+	tuner, err := initializeAutotune(ctx, config)
 	if err != nil {
 		return err
 	}
 
-	lis, err := startGRPCServer(ctx, *socket, processor)
+	// Clusters sharing a store are told apart by the name stamped on their events
+	clusterName, _ := config["clusterName"].(string)
+
+	redactor, err := initializeRedactor(config)
 	if err != nil {
 		return err
 	}
 
+	lis, err := startGRPCServer(ctx, *socket, processor, tuner, clusterName, deadLetters, redactor)
+	if err != nil {
+		return err
+	}
+
+	canaryConn, err := initializeCanary(ctx, config, *socket, storeConnector)
+	if err != nil {
+		return err
+	}
+
+	if canaryConn != nil {
+		defer canaryConn.Close()
+	}
+
 	portInt, err := strconv.Atoi(*metricsPort)
 	if err != nil {
 		return fmt.Errorf("invalid metrics port: %w", err)
 	}
 
+	// Publish the HealthEvent Avro and JSON-Schema for downstream consumers
+	schemaHandler, err := schema.Handler()
+	if err != nil {
+		return fmt.Errorf("failed to create schema registry handler: %w", err)
+	}
+
 	srv := srv.NewServer(
 		srv.WithPort(portInt),
 		srv.WithPrometheusMetrics(),
+		srv.WithHandler(logger.LevelsPath, logger.LevelsHandler()),
 		srv.WithSimpleHealth(),
+		srv.WithHandler(schema.PathPrefix, schemaHandler),
+		srv.WithHandler(dlq.PathPrefix, dlq.Handler(deadLetters)),
 	)
 
 	g, gCtx := errgroup.WithContext(ctx)
@@ -0,0 +1,133 @@
+// Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package autotune
+
+import (
+	"fmt"
+	"time"
+)
+
+const (
+	DefaultBaseDedupInterval     = 10 * time.Second
+	DefaultMaxDedupInterval      = 5 * time.Minute
+	DefaultBaseRateThreshold     = 600
+	DefaultMinRateThreshold      = 30
+	DefaultTargetQueueDepth      = 1000
+	DefaultTargetEventsPerSecond = 500
+	DefaultAdjustInterval        = 15 * time.Second
+	DefaultDedupCacheSize        = 65536
+)
+
+type Config struct {
+	Enabled bool `json:"enabled"`
+	// BaseDedupInterval suppresses repeats of an identical non-fatal event
+	// while the pipeline is within its SLO
+	BaseDedupInterval time.Duration `json:"baseDedupInterval"`
+	// MaxDedupInterval bounds how far the interval is widened under load
+	MaxDedupInterval time.Duration `json:"maxDedupInterval"`
+	// BaseRateThreshold is the number of non-fatal events per node and minute
+	// accepted while the pipeline is within its SLO
+	BaseRateThreshold int `json:"baseRateThreshold"`
+	// MinRateThreshold bounds how far the threshold is lowered under load
+	MinRateThreshold int `json:"minRateThreshold"`
+	// TargetQueueDepth is the connector queue depth the pipeline should stay below
+	TargetQueueDepth int `json:"targetQueueDepth"`
+	// TargetEventsPerSecond is the ingest rate the pipeline should stay below
+	TargetEventsPerSecond float64 `json:"targetEventsPerSecond"`
+	// AdjustInterval is how often the load is evaluated
+	AdjustInterval time.Duration `json:"adjustInterval"`
+	// DedupCacheSize bounds the number of distinct events remembered for
+	// deduplication, the least recently seen are evicted first
+	DedupCacheSize int `json:"dedupCacheSize"`
+}
+
+func NewConfigFromMap(cfgMap map[string]interface{}) (*Config, error) {
+	cfg := &Config{
+		BaseDedupInterval:     DefaultBaseDedupInterval,
+		MaxDedupInterval:      DefaultMaxDedupInterval,
+		BaseRateThreshold:     DefaultBaseRateThreshold,
+		MinRateThreshold:      DefaultMinRateThreshold,
+		TargetQueueDepth:      DefaultTargetQueueDepth,
+		TargetEventsPerSecond: DefaultTargetEventsPerSecond,
+		AdjustInterval:        DefaultAdjustInterval,
+		DedupCacheSize:        DefaultDedupCacheSize,
+	}
+
+	if enabled, ok := cfgMap["autotuneEnabled"].(string); ok && enabled == "true" {
+		cfg.Enabled = true
+	}
+
+	if seconds, ok := cfgMap["autotuneBaseDedupIntervalSeconds"].(float64); ok {
+		cfg.BaseDedupInterval = time.Duration(seconds) * time.Second
+	}
+
+	if seconds, ok := cfgMap["autotuneMaxDedupIntervalSeconds"].(float64); ok {
+		cfg.MaxDedupInterval = time.Duration(seconds) * time.Second
+	}
+
+	if threshold, ok := cfgMap["autotuneBaseRateThreshold"].(float64); ok {
+		cfg.BaseRateThreshold = int(threshold)
+	}
+
+	if threshold, ok := cfgMap["autotuneMinRateThreshold"].(float64); ok {
+		cfg.MinRateThreshold = int(threshold)
+	}
+
+	if depth, ok := cfgMap["autotuneTargetQueueDepth"].(float64); ok {
+		cfg.TargetQueueDepth = int(depth)
+	}
+
+	if rate, ok := cfgMap["autotuneTargetEventsPerSecond"].(float64); ok {
+		cfg.TargetEventsPerSecond = rate
+	}
+
+	if seconds, ok := cfgMap["autotuneAdjustIntervalSeconds"].(float64); ok {
+		cfg.AdjustInterval = time.Duration(seconds) * time.Second
+	}
+
+	if size, ok := cfgMap["autotuneDedupCacheSize"].(float64); ok {
+		cfg.DedupCacheSize = int(size)
+	}
+
+	return cfg, cfg.Validate()
+}
+
+func (c *Config) Validate() error {
+	if !c.Enabled {
+		return nil
+	}
+
+	if c.BaseDedupInterval <= 0 || c.MaxDedupInterval < c.BaseDedupInterval {
+		return fmt.Errorf("dedup interval must be positive and not exceed the max dedup interval")
+	}
+
+	if c.MinRateThreshold <= 0 || c.BaseRateThreshold < c.MinRateThreshold {
+		return fmt.Errorf("rate threshold must be at least the positive min rate threshold")
+	}
+
+	if c.TargetQueueDepth <= 0 || c.TargetEventsPerSecond <= 0 {
+		return fmt.Errorf("target queue depth and events per second must be positive")
+	}
+
+	if c.AdjustInterval <= 0 {
+		return fmt.Errorf("adjust interval must be positive")
+	}
+
+	if c.DedupCacheSize <= 0 {
+		return fmt.Errorf("dedup cache size must be positive")
+	}
+
+	return nil
+}
@@ -0,0 +1,228 @@
+// Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package autotune keeps the platform connector within its latency SLO during
+// event storms. It deduplicates repeated non-fatal events and limits the
+// non-fatal events per node and minute, and a feedback loop widens the dedup
+// interval and lowers the rate threshold while the observed ingest rate or
+// connector queue depth exceed their targets. Once the load is back under the
+// targets both step back to their base values, so detection is never loosened
+// permanently. Fatal, high priority and healthy events always pass.
+package autotune
+
+import (
+	"context"
+	"log/slog"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/nvidia/nvsentinel/commons/pkg/lrucache"
+	pb "github.com/nvidia/nvsentinel/data-models/pkg/protos"
+)
+
+const (
+	reasonDuplicate = "duplicate"
+	reasonRateLimit = "rate_limit"
+
+	// relaxLoad is the load below which the controller steps back to base
+	relaxLoad = 0.5
+)
+
+// QueueDepthFunc returns the current depth of the connector queues.
+type QueueDepthFunc func() int
+
+// Controller filters incoming events with dynamically tuned windows.
+type Controller struct {
+	cfg        Config
+	queueDepth QueueDepthFunc
+	now        func() time.Time
+
+	mu            sync.Mutex
+	dedupInterval time.Duration
+	rateThreshold int
+	// lastSeen holds when an identical event was last accepted
+	lastSeen *lrucache.Cache[string, time.Time]
+	// nodeWindow counts the accepted non-fatal events per node in the current minute
+	nodeWindow      map[string]int
+	nodeWindowStart time.Time
+	// received counts all events since the last adjustment
+	received     int
+	lastAdjusted time.Time
+}
+
+// NewController creates a Controller starting from the base windows.
+func NewController(cfg Config, queueDepth QueueDepthFunc) *Controller {
+	c := &Controller{
+		cfg:           cfg,
+		queueDepth:    queueDepth,
+		now:           time.Now,
+		dedupInterval: cfg.BaseDedupInterval,
+		rateThreshold: cfg.BaseRateThreshold,
+		lastSeen:      lrucache.New[string, time.Time]("platform_connector_autotune_dedup", cfg.DedupCacheSize),
+		nodeWindow:    make(map[string]int),
+	}
+
+	c.lastAdjusted = c.now()
+	c.updateMetrics(0)
+
+	return c
+}
+
+// Filter returns the events that pass the dedup and rate limit.
+func (c *Controller) Filter(events []*pb.HealthEvent) []*pb.HealthEvent {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := c.now()
+	c.received += len(events)
+
+	if minute := now.Truncate(time.Minute); !minute.Equal(c.nodeWindowStart) {
+		c.nodeWindowStart = minute
+		c.nodeWindow = make(map[string]int)
+	}
+
+	accepted := make([]*pb.HealthEvent, 0, len(events))
+
+	for _, event := range events {
+		if alwaysAccepted(event) {
+			accepted = append(accepted, event)
+			continue
+		}
+
+		key := dedupKey(event)
+		if last, ok := c.lastSeen.Get(key); ok && now.Sub(last) < c.dedupInterval {
+			suppressedEvents.WithLabelValues(reasonDuplicate).Inc()
+			continue
+		}
+
+		if c.nodeWindow[event.NodeName] >= c.rateThreshold {
+			suppressedEvents.WithLabelValues(reasonRateLimit).Inc()
+			continue
+		}
+
+		c.lastSeen.Add(key, now)
+		c.nodeWindow[event.NodeName]++
+		accepted = append(accepted, event)
+	}
+
+	return accepted
+}
+
+// Run adjusts the windows every adjust interval until the context is done.
+func (c *Controller) Run(ctx context.Context) {
+	ticker := time.NewTicker(c.cfg.AdjustInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			c.adjust()
+		}
+	}
+}
+
+// adjust compares the observed load with the targets and tightens or relaxes
+// the windows by one step.
+func (c *Controller) adjust() {
+	depth := 0
+	if c.queueDepth != nil {
+		depth = c.queueDepth()
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := c.now()
+
+	elapsed := now.Sub(c.lastAdjusted).Seconds()
+	if elapsed <= 0 {
+		return
+	}
+
+	rate := float64(c.received) / elapsed
+	c.received = 0
+	c.lastAdjusted = now
+
+	load := max(float64(depth)/float64(c.cfg.TargetQueueDepth), rate/c.cfg.TargetEventsPerSecond)
+
+	switch {
+	case load > 1:
+		c.dedupInterval = min(c.dedupInterval*2, c.cfg.MaxDedupInterval)
+		c.rateThreshold = max(c.rateThreshold/2, c.cfg.MinRateThreshold)
+	case load < relaxLoad:
+		c.dedupInterval = max(c.dedupInterval/2, c.cfg.BaseDedupInterval)
+		c.rateThreshold = min(c.rateThreshold*2, c.cfg.BaseRateThreshold)
+	}
+
+	if load > 1 || load < relaxLoad {
+		slog.Debug("Adjusted event filter windows",
+			"load", load,
+			"queueDepth", depth,
+			"eventsPerSecond", rate,
+			"dedupInterval", c.dedupInterval,
+			"rateThreshold", c.rateThreshold)
+	}
+
+	// Entries older than the widest interval can never suppress again
+	for _, key := range c.lastSeen.Keys() {
+		if last, ok := c.lastSeen.Peek(key); ok && now.Sub(last) >= c.cfg.MaxDedupInterval {
+			c.lastSeen.Remove(key)
+		}
+	}
+
+	c.updateMetrics(load)
+}
+
+// Windows returns the current dedup interval and rate threshold.
+func (c *Controller) Windows() (time.Duration, int) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.dedupInterval, c.rateThreshold
+}
+
+func (c *Controller) updateMetrics(load float64) {
+	dedupIntervalSeconds.Set(c.dedupInterval.Seconds())
+	rateThresholdPerMinute.Set(float64(c.rateThreshold))
+	observedLoad.Set(load)
+}
+
+// alwaysAccepted reports whether the event must never be filtered: actionable
+// faults and recoveries, which would otherwise leave nodes quarantined.
+func alwaysAccepted(event *pb.HealthEvent) bool {
+	return event.IsFatal || event.IsHealthy || event.Priority == pb.Priority_PRIORITY_HIGH
+}
+
+// dedupKey identifies repeats of the same condition on the same node.
+func dedupKey(event *pb.HealthEvent) string {
+	entities := make([]string, 0, len(event.EntitiesImpacted))
+	for _, entity := range event.EntitiesImpacted {
+		entities = append(entities, entity.EntityType+"="+entity.EntityValue)
+	}
+
+	sort.Strings(entities)
+
+	return strings.Join([]string{
+		event.NodeName,
+		event.Agent,
+		event.ComponentClass,
+		event.CheckName,
+		strings.Join(event.ErrorCode, ","),
+		strings.Join(entities, ","),
+	}, "|")
+}
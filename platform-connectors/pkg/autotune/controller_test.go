@@ -0,0 +1,222 @@
+// Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package autotune
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	pb "github.com/nvidia/nvsentinel/data-models/pkg/protos"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeClock struct {
+	now time.Time
+}
+
+func (f *fakeClock) Now() time.Time { return f.now }
+
+func (f *fakeClock) advance(d time.Duration) { f.now = f.now.Add(d) }
+
+func testConfig() Config {
+	return Config{
+		Enabled:               true,
+		BaseDedupInterval:     10 * time.Second,
+		MaxDedupInterval:      80 * time.Second,
+		BaseRateThreshold:     8,
+		MinRateThreshold:      2,
+		TargetQueueDepth:      100,
+		TargetEventsPerSecond: 10,
+		AdjustInterval:        time.Second,
+		DedupCacheSize:        16,
+	}
+}
+
+func newTestController(depth *int) (*Controller, *fakeClock) {
+	clock := &fakeClock{now: time.Date(2025, 6, 1, 8, 0, 0, 0, time.UTC)}
+	c := NewController(testConfig(), func() int { return *depth })
+	c.now = clock.Now
+	c.lastAdjusted = clock.now
+
+	return c, clock
+}
+
+func warning(node, check string) *pb.HealthEvent {
+	return &pb.HealthEvent{
+		NodeName:       node,
+		Agent:          "syslog-health-monitor",
+		ComponentClass: "GPU",
+		CheckName:      check,
+		ErrorCode:      []string{"31"},
+		EntitiesImpacted: []*pb.Entity{
+			{EntityType: "GPU", EntityValue: "0"},
+		},
+	}
+}
+
+func TestFilterDeduplicates(t *testing.T) {
+	depth := 0
+	c, clock := newTestController(&depth)
+
+	assert.Len(t, c.Filter([]*pb.HealthEvent{warning("node-1", "SysLogsXIDError")}), 1)
+
+	clock.advance(5 * time.Second)
+	assert.Empty(t, c.Filter([]*pb.HealthEvent{warning("node-1", "SysLogsXIDError")}))
+
+	// Other nodes and checks are not duplicates
+	assert.Len(t, c.Filter([]*pb.HealthEvent{
+		warning("node-2", "SysLogsXIDError"),
+		warning("node-1", "SysLogsSXIDError"),
+	}), 2)
+
+	clock.advance(5 * time.Second)
+	assert.Len(t, c.Filter([]*pb.HealthEvent{warning("node-1", "SysLogsXIDError")}), 1)
+}
+
+func TestFilterAlwaysAcceptsActionableEvents(t *testing.T) {
+	depth := 0
+	c, _ := newTestController(&depth)
+
+	fatal := warning("node-1", "SysLogsXIDError")
+	fatal.IsFatal = true
+
+	healthy := warning("node-1", "SysLogsXIDError")
+	healthy.IsHealthy = true
+
+	highPriority := warning("node-1", "SysLogsXIDError")
+	highPriority.Priority = pb.Priority_PRIORITY_HIGH
+
+	for i := 0; i < 3; i++ {
+		assert.Len(t, c.Filter([]*pb.HealthEvent{fatal, healthy, highPriority}), 3)
+	}
+}
+
+func TestFilterRateLimitsPerNode(t *testing.T) {
+	depth := 0
+	c, clock := newTestController(&depth)
+
+	accepted := 0
+
+	for i := 0; i < 12; i++ {
+		event := warning("node-1", "SysLogsXIDError")
+		event.ErrorCode = []string{string(rune('a' + i))}
+		accepted += len(c.Filter([]*pb.HealthEvent{event}))
+	}
+
+	assert.Equal(t, 8, accepted)
+
+	// The limit applies per minute
+	clock.advance(time.Minute)
+	assert.Len(t, c.Filter([]*pb.HealthEvent{warning("node-1", "SysLogsXIDError")}), 1)
+}
+
+func TestFilterForgetsLeastRecentlySeenEvents(t *testing.T) {
+	depth := 0
+	c, clock := newTestController(&depth)
+
+	// One more distinct event than the dedup cache holds evicts the first one
+	for i := 0; i <= testConfig().DedupCacheSize; i++ {
+		assert.Len(t, c.Filter([]*pb.HealthEvent{warning(fmt.Sprintf("node-%d", i), "SysLogsXIDError")}), 1)
+	}
+
+	clock.advance(time.Second)
+	assert.Len(t, c.Filter([]*pb.HealthEvent{warning("node-0", "SysLogsXIDError")}), 1)
+	assert.Empty(t, c.Filter([]*pb.HealthEvent{warning("node-2", "SysLogsXIDError")}))
+}
+
+func TestAdjustTightensUnderLoadAndRelaxesBack(t *testing.T) {
+	depth := 0
+	c, clock := newTestController(&depth)
+
+	// Queue depth above the target
+	depth = 250
+
+	for i := 0; i < 5; i++ {
+		clock.advance(time.Second)
+		c.adjust()
+	}
+
+	interval, threshold := c.Windows()
+	assert.Equal(t, 80*time.Second, interval)
+	assert.Equal(t, 2, threshold)
+
+	// Between half and full target the windows are kept
+	depth = 70
+
+	clock.advance(time.Second)
+	c.adjust()
+
+	interval, threshold = c.Windows()
+	assert.Equal(t, 80*time.Second, interval)
+	assert.Equal(t, 2, threshold)
+
+	// Back under half the target, the windows return to base and no further
+	depth = 0
+
+	for i := 0; i < 5; i++ {
+		clock.advance(time.Second)
+		c.adjust()
+	}
+
+	interval, threshold = c.Windows()
+	assert.Equal(t, 10*time.Second, interval)
+	assert.Equal(t, 8, threshold)
+}
+
+func TestAdjustReactsToIngestRate(t *testing.T) {
+	depth := 0
+	c, clock := newTestController(&depth)
+
+	events := make([]*pb.HealthEvent, 0, 50)
+	for i := 0; i < 50; i++ {
+		events = append(events, warning("node-1", "SysLogsXIDError"))
+	}
+
+	c.Filter(events)
+	clock.advance(time.Second)
+	c.adjust()
+
+	interval, threshold := c.Windows()
+	assert.Equal(t, 20*time.Second, interval)
+	assert.Equal(t, 4, threshold)
+}
+
+func TestNewConfigFromMap(t *testing.T) {
+	cfg, err := NewConfigFromMap(map[string]interface{}{})
+	require.NoError(t, err)
+	assert.False(t, cfg.Enabled)
+	assert.Equal(t, DefaultBaseDedupInterval, cfg.BaseDedupInterval)
+
+	cfg, err = NewConfigFromMap(map[string]interface{}{
+		"autotuneEnabled":                  "true",
+		"autotuneBaseDedupIntervalSeconds": float64(30),
+		"autotuneMaxDedupIntervalSeconds":  float64(600),
+		"autotuneTargetQueueDepth":         float64(500),
+	})
+	require.NoError(t, err)
+	assert.True(t, cfg.Enabled)
+	assert.Equal(t, 30*time.Second, cfg.BaseDedupInterval)
+	assert.Equal(t, 10*time.Minute, cfg.MaxDedupInterval)
+	assert.Equal(t, 500, cfg.TargetQueueDepth)
+
+	_, err = NewConfigFromMap(map[string]interface{}{
+		"autotuneEnabled":           "true",
+		"autotuneBaseRateThreshold": float64(10),
+		"autotuneMinRateThreshold":  float64(20),
+	})
+	assert.Error(t, err)
+}
@@ -0,0 +1,42 @@
+// Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package autotune
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+var (
+	dedupIntervalSeconds = promauto.NewGauge(prometheus.GaugeOpts{
+		Name: "platform_connector_autotune_dedup_interval_seconds",
+		Help: "Current interval in which repeats of an identical non-fatal event are suppressed",
+	})
+
+	rateThresholdPerMinute = promauto.NewGauge(prometheus.GaugeOpts{
+		Name: "platform_connector_autotune_rate_threshold",
+		Help: "Current number of non-fatal events accepted per node and minute",
+	})
+
+	observedLoad = promauto.NewGauge(prometheus.GaugeOpts{
+		Name: "platform_connector_autotune_load",
+		Help: "Observed load relative to the queue depth and ingest rate targets, above 1 tightens the filter",
+	})
+
+	suppressedEvents = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "platform_connector_autotune_suppressed_events_total",
+		Help: "Total number of non-fatal events suppressed by the auto-tuned filter",
+	}, []string{"reason"})
+)
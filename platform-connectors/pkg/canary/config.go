@@ -0,0 +1,140 @@
+// Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package canary
+
+import (
+	"fmt"
+	"time"
+)
+
+const (
+	DefaultInterval       = 5 * time.Minute
+	DefaultSLO            = 2 * time.Minute
+	DefaultPollInterval   = 5 * time.Second
+	DefaultAgent          = "syslog-health-monitor"
+	DefaultCheckName      = "SysLogsXIDError"
+	DefaultComponentClass = "GPU"
+	DefaultErrorCode      = "79"
+)
+
+type Config struct {
+	Enabled bool
+	// NodeName is the designated canary node, only the platform connector running
+	// on it injects canary events
+	NodeName string
+	// Interval is the time between two canary events
+	Interval time.Duration
+	// SLO is how long a canary event may take to go through every stage
+	SLO time.Duration
+	// PollInterval is how often the store is checked for the progress of the event
+	PollInterval time.Duration
+	// VerifyAnalysis also waits for health-events-analyzer to process the event,
+	// disable it when the analyzer is not deployed
+	VerifyAnalysis bool
+	// Agent, CheckName, ComponentClass and ErrorCode describe the canary event. They
+	// must match a fault-quarantine ruleset for the policy stage to pass.
+	Agent          string
+	CheckName      string
+	ComponentClass string
+	ErrorCode      string
+}
+
+func NewConfigFromMap(cfgMap map[string]interface{}) (*Config, error) {
+	cfg := &Config{
+		Interval:       DefaultInterval,
+		SLO:            DefaultSLO,
+		PollInterval:   DefaultPollInterval,
+		VerifyAnalysis: true,
+		Agent:          DefaultAgent,
+		CheckName:      DefaultCheckName,
+		ComponentClass: DefaultComponentClass,
+		ErrorCode:      DefaultErrorCode,
+	}
+
+	if enabled, ok := cfgMap["canaryEnabled"].(string); ok && enabled == "true" {
+		cfg.Enabled = true
+	}
+
+	if nodeName, ok := cfgMap["canaryNodeName"].(string); ok {
+		cfg.NodeName = nodeName
+	}
+
+	if interval, ok := seconds(cfgMap["canaryIntervalSeconds"]); ok {
+		cfg.Interval = interval
+	}
+
+	if interval, ok := seconds(cfgMap["canarySLOSeconds"]); ok {
+		cfg.SLO = interval
+	}
+
+	if interval, ok := seconds(cfgMap["canaryPollIntervalSeconds"]); ok {
+		cfg.PollInterval = interval
+	}
+
+	if verify, ok := cfgMap["canaryVerifyAnalysis"].(string); ok {
+		cfg.VerifyAnalysis = verify == "true"
+	}
+
+	if agent, ok := cfgMap["canaryAgent"].(string); ok && agent != "" {
+		cfg.Agent = agent
+	}
+
+	if checkName, ok := cfgMap["canaryCheckName"].(string); ok && checkName != "" {
+		cfg.CheckName = checkName
+	}
+
+	if componentClass, ok := cfgMap["canaryComponentClass"].(string); ok && componentClass != "" {
+		cfg.ComponentClass = componentClass
+	}
+
+	if errorCode, ok := cfgMap["canaryErrorCode"].(string); ok {
+		cfg.ErrorCode = errorCode
+	}
+
+	return cfg, cfg.Validate()
+}
+
+// seconds converts a number of seconds of the config map to a duration. The config
+// loader decodes whole numbers as int64 and others as float64.
+func seconds(value interface{}) (time.Duration, bool) {
+	switch v := value.(type) {
+	case int64:
+		return time.Duration(v) * time.Second, true
+	case float64:
+		return time.Duration(v * float64(time.Second)), true
+	default:
+		return 0, false
+	}
+}
+
+func (c *Config) Validate() error {
+	if !c.Enabled {
+		return nil
+	}
+
+	if c.NodeName == "" {
+		return fmt.Errorf("canary node name must be set")
+	}
+
+	if c.Interval <= 0 || c.SLO <= 0 || c.PollInterval <= 0 {
+		return fmt.Errorf("canary interval, SLO and poll interval must be positive")
+	}
+
+	if c.SLO > c.Interval {
+		return fmt.Errorf("canary SLO %s must not exceed the interval %s", c.SLO, c.Interval)
+	}
+
+	return nil
+}
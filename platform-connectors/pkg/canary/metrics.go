@@ -0,0 +1,43 @@
+// Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package canary
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+var (
+	probesTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "platform_connector_canary_probes_total",
+		Help: "Total number of canary probes, by result and the first stage a failed probe did not reach",
+	}, []string{"result", "stage"})
+
+	stageLatency = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "platform_connector_canary_stage_latency_seconds",
+		Help:    "Time from injecting a canary event to observing it at a pipeline stage",
+		Buckets: prometheus.DefBuckets,
+	}, []string{"stage"})
+
+	pipelineHealthy = promauto.NewGauge(prometheus.GaugeOpts{
+		Name: "platform_connector_canary_pipeline_healthy",
+		Help: "1 when the last canary event went through every stage within the SLO, 0 otherwise",
+	})
+
+	lastSuccessTimestamp = promauto.NewGauge(prometheus.GaugeOpts{
+		Name: "platform_connector_canary_last_success_timestamp_seconds",
+		Help: "Unix time of the last canary probe that succeeded",
+	})
+)
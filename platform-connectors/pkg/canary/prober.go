@@ -0,0 +1,221 @@
+// Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package canary verifies the health event pipeline end to end. It periodically
+// injects a synthetic fault event, tagged with model.MarkCanary, on a designated
+// canary node and checks that it is ingested into the store, processed by
+// health-events-analyzer and evaluated by fault-quarantine within the SLO.
+// Components process canary events in dry-run, so the canary node is never
+// cordoned, drained or remediated.
+package canary
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/nvidia/nvsentinel/data-models/pkg/model"
+	pb "github.com/nvidia/nvsentinel/data-models/pkg/protos"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// Stage is a stage a canary event is verified to reach, in order.
+type Stage string
+
+const (
+	StageSent        Stage = "sent"
+	StageIngested    Stage = "ingested"
+	StageAnalyzed    Stage = "analyzed"
+	StageQuarantined Stage = "quarantined"
+)
+
+const (
+	ResultSuccess = "success"
+	ResultFailure = "failure"
+)
+
+// Sender sends the canary events to the platform connector.
+type Sender interface {
+	HealthEventOccurredV1(ctx context.Context, events *pb.HealthEvents,
+		opts ...grpc.CallOption) (*emptypb.Empty, error)
+}
+
+// Store looks up the canary events in the health event store.
+type Store interface {
+	// FindHealthEvent returns nil when the event is not stored
+	FindHealthEvent(ctx context.Context, id string) (*model.HealthEventWithStatus, error)
+	DeleteHealthEvent(ctx context.Context, id string) error
+}
+
+// Prober injects the canary events and verifies their progress.
+type Prober struct {
+	config Config
+	sender Sender
+	store  Store
+	now    func() time.Time
+}
+
+func NewProber(config Config, sender Sender, store Store) *Prober {
+	return &Prober{config: config, sender: sender, store: store, now: time.Now}
+}
+
+// Run probes the pipeline every interval until the context is cancelled.
+func (p *Prober) Run(ctx context.Context) {
+	slog.Info("Starting canary prober",
+		"node", p.config.NodeName,
+		"interval", p.config.Interval,
+		"slo", p.config.SLO)
+
+	ticker := time.NewTicker(p.config.Interval)
+	defer ticker.Stop()
+
+	for {
+		if err := p.Probe(ctx); err != nil && ctx.Err() == nil {
+			slog.Error("Canary probe failed, the health event pipeline may be broken", "error", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Probe injects one canary event and waits for it to reach every stage. It returns
+// an error naming the first stage the event did not reach within the SLO. The
+// canary event is deleted from the store afterwards.
+func (p *Prober) Probe(ctx context.Context) error {
+	start := p.now()
+	event := p.newEvent(start)
+
+	ctx, cancel := context.WithTimeout(ctx, p.config.SLO)
+	defer cancel()
+
+	defer func() {
+		// The event is deleted even when the probe timed out
+		cleanupCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), p.config.PollInterval)
+		defer cancel()
+
+		if err := p.store.DeleteHealthEvent(cleanupCtx, event.Id); err != nil {
+			slog.Warn("Failed to delete canary event", "id", event.Id, "error", err)
+		}
+	}()
+
+	if _, err := p.sender.HealthEventOccurredV1(ctx, &pb.HealthEvents{
+		Version: 1,
+		Events:  []*pb.HealthEvent{event},
+	}); err != nil {
+		return p.fail(StageSent, fmt.Errorf("failed to send canary event: %w", err))
+	}
+
+	stageLatency.WithLabelValues(string(StageSent)).Observe(p.now().Sub(start).Seconds())
+
+	pending := p.stages()
+
+	ticker := time.NewTicker(p.config.PollInterval)
+	defer ticker.Stop()
+
+	for {
+		stored, err := p.store.FindHealthEvent(ctx, event.Id)
+		if err != nil {
+			slog.Warn("Failed to look up canary event", "id", event.Id, "error", err)
+		}
+
+		for len(pending) > 0 && reached(stored, pending[0]) {
+			stageLatency.WithLabelValues(string(pending[0])).Observe(p.now().Sub(start).Seconds())
+			pending = pending[1:]
+		}
+
+		if len(pending) == 0 {
+			slog.Info("Canary event went through the pipeline", "id", event.Id, "latency", p.now().Sub(start))
+			probesTotal.WithLabelValues(ResultSuccess, "").Inc()
+			pipelineHealthy.Set(1)
+			lastSuccessTimestamp.Set(float64(p.now().Unix()))
+
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return p.fail(pending[0], fmt.Errorf("canary event %s did not reach stage %s within %s",
+				event.Id, pending[0], p.config.SLO))
+		case <-ticker.C:
+		}
+	}
+}
+
+func (p *Prober) fail(stage Stage, err error) error {
+	probesTotal.WithLabelValues(ResultFailure, string(stage)).Inc()
+	pipelineHealthy.Set(0)
+
+	return err
+}
+
+// stages returns the stages to verify after the event was sent, in order.
+func (p *Prober) stages() []Stage {
+	if p.config.VerifyAnalysis {
+		return []Stage{StageIngested, StageAnalyzed, StageQuarantined}
+	}
+
+	return []Stage{StageIngested, StageQuarantined}
+}
+
+func (p *Prober) newEvent(now time.Time) *pb.HealthEvent {
+	event := &pb.HealthEvent{
+		Version:            1,
+		Id:                 model.NewEventID(),
+		Agent:              p.config.Agent,
+		ComponentClass:     p.config.ComponentClass,
+		CheckName:          p.config.CheckName,
+		IsFatal:            true,
+		IsHealthy:          false,
+		Message:            "Synthetic canary event injected by NVSentinel, no action is taken on the node",
+		RecommendedAction:  pb.RecommendedAction_NONE,
+		NodeName:           p.config.NodeName,
+		GeneratedTimestamp: timestamppb.New(now),
+		EntitiesImpacted:   []*pb.Entity{{EntityType: "GPU", EntityValue: "0"}},
+	}
+
+	if p.config.ErrorCode != "" {
+		event.ErrorCode = []string{p.config.ErrorCode}
+	}
+
+	model.MarkCanary(event)
+	model.SetStageTimestamp(event, model.StageEventEmitted, now)
+
+	return event
+}
+
+// reached returns true if the stored event shows it reached the stage.
+func reached(stored *model.HealthEventWithStatus, stage Stage) bool {
+	if stored == nil {
+		return false
+	}
+
+	switch stage {
+	case StageIngested:
+		return true
+	case StageAnalyzed:
+		return stored.HealthEventStatus.Canary != nil && stored.HealthEventStatus.Canary.AnalyzedAt != nil
+	case StageQuarantined:
+		return stored.HealthEventStatus.NodeQuarantined != nil &&
+			*stored.HealthEventStatus.NodeQuarantined == model.CanaryQuarantined
+	default:
+		return false
+	}
+}
@@ -0,0 +1,202 @@
+// Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package canary
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/nvidia/nvsentinel/data-models/pkg/model"
+	pb "github.com/nvidia/nvsentinel/data-models/pkg/protos"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+// fakePipeline stores the sent events and advances them up to the configured stage.
+type fakePipeline struct {
+	mu      sync.Mutex
+	reach   Stage
+	sendErr error
+	events  map[string]*model.HealthEventWithStatus
+	deleted []string
+}
+
+func newFakePipeline(reach Stage) *fakePipeline {
+	return &fakePipeline{reach: reach, events: make(map[string]*model.HealthEventWithStatus)}
+}
+
+func (f *fakePipeline) HealthEventOccurredV1(_ context.Context, events *pb.HealthEvents,
+	_ ...grpc.CallOption) (*emptypb.Empty, error) {
+	if f.sendErr != nil {
+		return nil, f.sendErr
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	for _, event := range events.Events {
+		stored := &model.HealthEventWithStatus{HealthEvent: event}
+
+		if f.reach == StageAnalyzed || f.reach == StageQuarantined {
+			analyzedAt := time.Now()
+			stored.HealthEventStatus.Canary = &model.CanaryStatus{AnalyzedAt: &analyzedAt}
+		}
+
+		if f.reach == StageQuarantined {
+			status := model.CanaryQuarantined
+			stored.HealthEventStatus.NodeQuarantined = &status
+		}
+
+		if f.reach != StageSent {
+			f.events[event.Id] = stored
+		}
+	}
+
+	return &emptypb.Empty{}, nil
+}
+
+func (f *fakePipeline) FindHealthEvent(_ context.Context, id string) (*model.HealthEventWithStatus, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	return f.events[id], nil
+}
+
+func (f *fakePipeline) DeleteHealthEvent(_ context.Context, id string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	delete(f.events, id)
+	f.deleted = append(f.deleted, id)
+
+	return nil
+}
+
+func testConfig() Config {
+	return Config{
+		Enabled:        true,
+		NodeName:       "canary-node",
+		Interval:       time.Second,
+		SLO:            200 * time.Millisecond,
+		PollInterval:   10 * time.Millisecond,
+		VerifyAnalysis: true,
+		Agent:          DefaultAgent,
+		CheckName:      DefaultCheckName,
+		ComponentClass: DefaultComponentClass,
+		ErrorCode:      DefaultErrorCode,
+	}
+}
+
+func TestProbe(t *testing.T) {
+	tests := []struct {
+		name           string
+		reach          Stage
+		verifyAnalysis bool
+		wantFailed     Stage
+	}{
+		{name: "every stage reached", reach: StageQuarantined, verifyAnalysis: true},
+		{name: "not ingested", reach: StageSent, verifyAnalysis: true, wantFailed: StageIngested},
+		{name: "not analyzed", reach: StageIngested, verifyAnalysis: true, wantFailed: StageAnalyzed},
+		{name: "not quarantined", reach: StageAnalyzed, verifyAnalysis: true, wantFailed: StageQuarantined},
+		{name: "analysis not verified", reach: StageIngested, verifyAnalysis: false, wantFailed: StageQuarantined},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pipeline := newFakePipeline(tt.reach)
+			cfg := testConfig()
+			cfg.VerifyAnalysis = tt.verifyAnalysis
+
+			err := NewProber(cfg, pipeline, pipeline).Probe(context.Background())
+			if tt.wantFailed == "" {
+				require.NoError(t, err)
+			} else {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), "stage "+string(tt.wantFailed))
+			}
+
+			require.Len(t, pipeline.deleted, 1, "the canary event is deleted after the probe")
+			assert.Empty(t, pipeline.events)
+		})
+	}
+
+	t.Run("send failure", func(t *testing.T) {
+		pipeline := newFakePipeline(StageQuarantined)
+		pipeline.sendErr = errors.New("connection refused")
+
+		err := NewProber(testConfig(), pipeline, pipeline).Probe(context.Background())
+		require.ErrorContains(t, err, "failed to send canary event")
+	})
+}
+
+func TestNewEvent(t *testing.T) {
+	prober := NewProber(testConfig(), nil, nil)
+	now := time.Now()
+
+	event := prober.newEvent(now)
+	assert.True(t, model.IsCanary(event))
+	assert.Len(t, event.Id, model.EventIDLength)
+	assert.Equal(t, "canary-node", event.NodeName)
+	assert.Equal(t, []string{DefaultErrorCode}, event.ErrorCode)
+	assert.True(t, event.IsFatal)
+
+	emitted, ok := model.StageTimestamp(event, model.StageEventEmitted)
+	require.True(t, ok)
+	assert.True(t, emitted.Equal(now))
+
+	assert.NotEqual(t, event.Id, prober.newEvent(now).Id, "every canary event gets a new ID")
+}
+
+func TestNewConfigFromMap(t *testing.T) {
+	cfg, err := NewConfigFromMap(map[string]interface{}{})
+	require.NoError(t, err)
+	assert.False(t, cfg.Enabled)
+	assert.Equal(t, DefaultInterval, cfg.Interval)
+	assert.True(t, cfg.VerifyAnalysis)
+
+	cfg, err = NewConfigFromMap(map[string]interface{}{
+		"canaryEnabled":         "true",
+		"canaryNodeName":        "canary-node",
+		"canaryIntervalSeconds": int64(600),
+		"canarySLOSeconds":      float64(60),
+		"canaryVerifyAnalysis":  "false",
+		"canaryCheckName":       "GpuXidError",
+		"canaryAgent":           "gpu-health-monitor",
+	})
+	require.NoError(t, err)
+	assert.True(t, cfg.Enabled)
+	assert.Equal(t, "canary-node", cfg.NodeName)
+	assert.Equal(t, 10*time.Minute, cfg.Interval)
+	assert.Equal(t, time.Minute, cfg.SLO)
+	assert.False(t, cfg.VerifyAnalysis)
+	assert.Equal(t, "GpuXidError", cfg.CheckName)
+	assert.Equal(t, "gpu-health-monitor", cfg.Agent)
+
+	_, err = NewConfigFromMap(map[string]interface{}{"canaryEnabled": "true"})
+	assert.Error(t, err, "the canary node is required")
+
+	_, err = NewConfigFromMap(map[string]interface{}{
+		"canaryEnabled":         "true",
+		"canaryNodeName":        "canary-node",
+		"canaryIntervalSeconds": float64(60),
+		"canarySLOSeconds":      float64(120),
+	})
+	assert.Error(t, err, "the SLO must not exceed the interval")
+}
@@ -0,0 +1,93 @@
+// Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package embedded stores the health events received by the platform
+// connector in an embedded store, in place of the MongoDB store connector.
+package embedded
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/nvidia/nvsentinel/data-models/pkg/model"
+	"github.com/nvidia/nvsentinel/data-models/pkg/protos"
+	"github.com/nvidia/nvsentinel/platform-connectors/pkg/ringbuffer"
+	"github.com/nvidia/nvsentinel/store-client/pkg/store"
+)
+
+type EmbeddedStoreConnector struct {
+	store      store.Store
+	ringBuffer *ringbuffer.RingBuffer
+}
+
+func NewEmbeddedStoreConnector(s store.Store, ringBuffer *ringbuffer.RingBuffer) *EmbeddedStoreConnector {
+	return &EmbeddedStoreConnector{
+		store:      s,
+		ringBuffer: ringBuffer,
+	}
+}
+
+func (r *EmbeddedStoreConnector) FetchAndProcessHealthMetric(ctx context.Context) {
+	for {
+		select {
+		case <-ctx.Done():
+			slog.Info("Context canceled, exiting health metric processing loop")
+			return
+		default:
+			healthEvents := r.ringBuffer.Dequeue()
+			if healthEvents == nil || len(healthEvents.GetEvents()) == 0 {
+				continue
+			}
+
+			err := r.insertHealthEvents(ctx, healthEvents)
+			if err != nil {
+				slog.Error("Error inserting health events", "error", err)
+				r.ringBuffer.DeadLetter(healthEvents, err)
+			} else {
+				r.ringBuffer.HealthMetricEleProcessingCompleted(healthEvents)
+			}
+		}
+	}
+}
+
+func (r *EmbeddedStoreConnector) insertHealthEvents(
+	ctx context.Context,
+	healthEvents *protos.HealthEvents,
+) error {
+	healthEventWithStatusList := make([]model.HealthEventWithStatus, 0, len(healthEvents.GetEvents()))
+
+	for _, healthEvent := range healthEvents.GetEvents() {
+		// the edge profile has no control plane to report agent versions to
+		if model.IsHeartbeat(healthEvent) {
+			continue
+		}
+
+		healthEventWithStatusList = append(healthEventWithStatusList, model.HealthEventWithStatus{
+			CreatedAt:   time.Now().UTC(),
+			HealthEvent: healthEvent,
+		})
+	}
+
+	if len(healthEventWithStatusList) == 0 {
+		return nil
+	}
+
+	if _, err := r.store.Insert(ctx, healthEventWithStatusList); err != nil {
+		return fmt.Errorf("insert failed: %w", err)
+	}
+
+	return nil
+}
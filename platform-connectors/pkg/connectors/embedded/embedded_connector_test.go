@@ -0,0 +1,64 @@
+// Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package embedded
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/nvidia/nvsentinel/data-models/pkg/model"
+	"github.com/nvidia/nvsentinel/data-models/pkg/protos"
+	"github.com/nvidia/nvsentinel/platform-connectors/pkg/ringbuffer"
+	"github.com/nvidia/nvsentinel/store-client/pkg/store"
+	"github.com/nvidia/nvsentinel/store-client/pkg/store/bolt"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFetchAndProcessHealthMetric(t *testing.T) {
+	s, err := bolt.Open(filepath.Join(t.TempDir(), "events.db"))
+	require.NoError(t, err)
+
+	defer s.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	ringBuffer := ringbuffer.NewRingBuffer("testRingBuffer", ctx)
+	connector := NewEmbeddedStoreConnector(s, ringBuffer)
+
+	go connector.FetchAndProcessHealthMetric(ctx)
+
+	ringBuffer.Enqueue(&protos.HealthEvents{
+		Events: []*protos.HealthEvent{
+			{NodeName: "node-1", CheckName: "SysLogsXIDError"},
+			{NodeName: "node-1", CheckName: "SysLogsSXIDError"},
+			model.NewHeartbeat("syslog-health-monitor", "node-1", "v1.2.0", time.Now()),
+		},
+	})
+
+	var records []store.Record
+
+	require.Eventually(t, func() bool {
+		records, err = s.List(context.Background(), store.Filter{}, store.ListOptions{})
+		return err == nil && len(records) == 2
+	}, 5*time.Second, 10*time.Millisecond)
+
+	assert.Equal(t, "SysLogsXIDError", records[0].HealthEvent.GetCheckName())
+	assert.Equal(t, "SysLogsSXIDError", records[1].HealthEvent.GetCheckName())
+	assert.False(t, records[0].CreatedAt.IsZero())
+}
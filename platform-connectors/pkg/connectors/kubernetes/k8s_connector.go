@@ -19,6 +19,7 @@ import (
 	"fmt"
 	"log/slog"
 
+	"github.com/nvidia/nvsentinel/data-models/pkg/protos"
 	"github.com/nvidia/nvsentinel/platform-connectors/pkg/ringbuffer"
 
 	"k8s.io/client-go/kubernetes"
@@ -32,6 +33,9 @@ hence it is complex to test this. Hence, ignoring this initilization part for no
 Hence, ignoring this file as part of unit testing for now.
 */
 
+// maxCoalescedBatches caps how many queued batches are dequeued together in one pass.
+const maxCoalescedBatches = 64
+
 type K8sConnector struct {
 	// clientset is the Kubernetes client
 	clientset kubernetes.Interface
@@ -82,13 +86,109 @@ func (r *K8sConnector) FetchAndProcessHealthMetric(ctx context.Context) {
 			slog.Info("k8sConnector queue received stop signal")
 			return
 		default:
-			healthEvents := r.ringBuffer.Dequeue()
-			if err := r.processHealthEvents(ctx, healthEvents); err != nil {
-				slog.Error("Not able to process healthEvent", "error", err)
-				r.ringBuffer.HealthMetricEleProcessingFailed(healthEvents)
-			} else {
-				r.ringBuffer.HealthMetricEleProcessingCompleted(healthEvents)
+			batches := r.dequeueBatches()
+			if len(batches) == 0 {
+				slog.Info("k8sConnector queue shut down")
+				return
 			}
+
+			coalescedBatchesHistogram.Observe(float64(len(batches)))
+
+			for _, group := range groupBatchesByNode(batches) {
+				r.processBatches(ctx, group)
+			}
+		}
+	}
+}
+
+// processBatches merges batches of the same node, so they cost one condition write,
+// and completes or dead-letters them together.
+func (r *K8sConnector) processBatches(ctx context.Context, batches []*protos.HealthEvents) {
+	healthEvents := &protos.HealthEvents{Version: batches[0].Version}
+	for _, batch := range batches {
+		healthEvents.Events = append(healthEvents.Events, batch.Events...)
+	}
+
+	err := r.processHealthEvents(ctx, healthEvents)
+	if err != nil {
+		slog.Error("Not able to process healthEvent", "error", err)
+	}
+
+	for _, batch := range batches {
+		if err != nil {
+			r.ringBuffer.DeadLetter(batch, err)
+		} else {
+			r.ringBuffer.HealthMetricEleProcessingCompleted(batch)
+		}
+	}
+}
+
+// groupBatchesByNode groups batches by the node of their events, keeping the order in
+// which the nodes first appear, so a node that cannot be updated fails only its own
+// batches. A batch carrying events for several nodes forms a group of its own.
+func groupBatchesByNode(batches []*protos.HealthEvents) [][]*protos.HealthEvents {
+	var groups [][]*protos.HealthEvents
+
+	index := make(map[string]int)
+
+	for _, batch := range batches {
+		nodeName, single := batchNode(batch)
+		if !single {
+			groups = append(groups, []*protos.HealthEvents{batch})
+			continue
+		}
+
+		i, ok := index[nodeName]
+		if !ok {
+			i = len(groups)
+			index[nodeName] = i
+			groups = append(groups, nil)
+		}
+
+		groups[i] = append(groups[i], batch)
+	}
+
+	return groups
+}
+
+// batchNode returns the node of the events of the batch and whether they all belong
+// to that node.
+func batchNode(batch *protos.HealthEvents) (string, bool) {
+	if len(batch.Events) == 0 {
+		return "", true
+	}
+
+	nodeName := batch.Events[0].NodeName
+
+	for _, event := range batch.Events[1:] {
+		if event.NodeName != nodeName {
+			return "", false
 		}
 	}
+
+	return nodeName, true
+}
+
+// dequeueBatches blocks for the next batch and then takes whatever else is already
+// queued, up to maxCoalescedBatches, so an event storm for a node costs one condition
+// write instead of one per batch. It returns nil once the ring buffer shuts down.
+func (r *K8sConnector) dequeueBatches() []*protos.HealthEvents {
+	first := r.ringBuffer.Dequeue()
+	if first == nil {
+		return nil
+	}
+
+	batches := []*protos.HealthEvents{first}
+
+	// the connector is the only consumer, so a non-empty buffer never blocks Dequeue
+	for len(batches) < maxCoalescedBatches && r.ringBuffer.CurrentLength() > 0 {
+		next := r.ringBuffer.Dequeue()
+		if next == nil {
+			break
+		}
+
+		batches = append(batches, next)
+	}
+
+	return batches
 }
@@ -21,6 +21,7 @@ import (
 	"log/slog"
 	"net/url"
 	"os"
+	"sync"
 	"syscall"
 	"testing"
 	"time"
@@ -1383,3 +1384,304 @@ func TestUpdateNodeConditions_ErrorHandling(t *testing.T) {
 		})
 	}
 }
+
+func newFieldManagedConnector(t *testing.T, nodes ...*corev1.Node) (*K8sConnector, *fake.Clientset) {
+	t.Helper()
+
+	localCtx := context.Background()
+	localClientSet := fake.NewClientset()
+
+	for _, node := range nodes {
+		_, err := localClientSet.CoreV1().Nodes().Create(localCtx, node, metav1.CreateOptions{})
+		require.NoError(t, err)
+	}
+
+	stopCh := make(chan struct{})
+	t.Cleanup(func() { close(stopCh) })
+
+	ringBuffer := ringbuffer.NewRingBuffer(t.Name(), localCtx)
+
+	return NewK8sConnector(localClientSet, ringBuffer, stopCh, localCtx), localClientSet
+}
+
+func statusPatches(clientset *fake.Clientset) int {
+	patches := 0
+
+	for _, action := range clientset.Actions() {
+		if action.GetVerb() == "patch" && action.GetSubresource() == "status" {
+			patches++
+		}
+	}
+
+	return patches
+}
+
+func xidEvent(nodeName string, generated time.Time) *protos.HealthEvent {
+	return &protos.HealthEvent{
+		CheckName:          "GpuXidError",
+		IsHealthy:          false,
+		IsFatal:            true,
+		EntitiesImpacted:   []*protos.Entity{{EntityType: "GPU", EntityValue: "0"}},
+		ErrorCode:          []string{"79"},
+		GeneratedTimestamp: timestamppb.New(generated),
+		RecommendedAction:  protos.RecommendedAction_RESTART_BM,
+		NodeName:           nodeName,
+	}
+}
+
+func TestUpdateNodeConditions_SkipsUnchanged(t *testing.T) {
+	connector, localClientSet := newFieldManagedConnector(t,
+		&corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "test-node"}})
+
+	now := time.Now()
+
+	require.NoError(t, connector.updateNodeConditions(ctx, []*protos.HealthEvent{xidEvent("test-node", now)}))
+	require.Equal(t, 1, statusPatches(localClientSet))
+
+	// the same failure reported again within the heartbeat refresh interval is not written
+	later := now.Add(time.Minute)
+	require.NoError(t, connector.updateNodeConditions(ctx, []*protos.HealthEvent{xidEvent("test-node", later)}))
+	require.Equal(t, 1, statusPatches(localClientSet))
+
+	node, err := localClientSet.CoreV1().Nodes().Get(ctx, "test-node", metav1.GetOptions{})
+	require.NoError(t, err)
+	require.Len(t, node.Status.Conditions, 1)
+	require.True(t, node.Status.Conditions[0].LastHeartbeatTime.Time.Equal(now.Truncate(time.Second)))
+
+	// once the heartbeat is stale the unchanged condition is written again
+	stale := now.Add(HeartbeatRefreshInterval)
+	require.NoError(t, connector.updateNodeConditions(ctx, []*protos.HealthEvent{xidEvent("test-node", stale)}))
+	require.Equal(t, 2, statusPatches(localClientSet))
+
+	node, err = localClientSet.CoreV1().Nodes().Get(ctx, "test-node", metav1.GetOptions{})
+	require.NoError(t, err)
+	require.True(t, node.Status.Conditions[0].LastHeartbeatTime.Time.Equal(stale.Truncate(time.Second)))
+	require.True(t, node.Status.Conditions[0].LastTransitionTime.Time.Equal(now.Truncate(time.Second)))
+
+	// a recovery changes the status and is written straight away
+	recovered := xidEvent("test-node", stale.Add(time.Second))
+	recovered.IsHealthy = true
+	recovered.IsFatal = false
+
+	require.NoError(t, connector.updateNodeConditions(ctx, []*protos.HealthEvent{recovered}))
+	require.Equal(t, 3, statusPatches(localClientSet))
+
+	node, err = localClientSet.CoreV1().Nodes().Get(ctx, "test-node", metav1.GetOptions{})
+	require.NoError(t, err)
+	require.Equal(t, corev1.ConditionFalse, node.Status.Conditions[0].Status)
+	require.Equal(t, NoHealthFailureMsg, node.Status.Conditions[0].Message)
+}
+
+func TestUpdateNodeConditions_ServerSideApply(t *testing.T) {
+	connector, localClientSet := newFieldManagedConnector(t, &corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-node"},
+		Status: corev1.NodeStatus{
+			Conditions: []corev1.NodeCondition{
+				{Type: corev1.NodeReady, Status: corev1.ConditionTrue, Reason: "KubeletReady"},
+			},
+		},
+	})
+
+	require.NoError(t, connector.updateNodeConditions(ctx, []*protos.HealthEvent{xidEvent("test-node", time.Now())}))
+
+	node, err := localClientSet.CoreV1().Nodes().Get(ctx, "test-node", metav1.GetOptions{})
+	require.NoError(t, err)
+
+	conditions := map[corev1.NodeConditionType]corev1.NodeCondition{}
+	for _, condition := range node.Status.Conditions {
+		conditions[condition.Type] = condition
+	}
+
+	// the condition owned by another manager is left alone
+	require.Len(t, conditions, 2)
+	require.Equal(t, corev1.ConditionTrue, conditions["GpuXidError"].Status)
+	require.Equal(t, "KubeletReady", conditions[corev1.NodeReady].Reason)
+
+	var managers []string
+	for _, entry := range node.ManagedFields {
+		managers = append(managers, entry.Manager)
+	}
+
+	require.Contains(t, managers, FieldManager)
+}
+
+func TestNodeStatusApplyConfiguration(t *testing.T) {
+	heartbeat := metav1.NewTime(time.Now().Truncate(time.Second))
+	node := &corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:            "test-node",
+			ResourceVersion: "42",
+			ManagedFields: []metav1.ManagedFieldsEntry{
+				{
+					Manager:     FieldManager,
+					Operation:   metav1.ManagedFieldsOperationApply,
+					APIVersion:  "v1",
+					FieldsType:  "FieldsV1",
+					Subresource: "status",
+					FieldsV1: &metav1.FieldsV1{Raw: []byte(`{"f:status":{"f:conditions":{` +
+						`"k:{\"type\":\"GpuXidError\"}":{".":{},"f:message":{},"f:reason":{},"f:status":{},"f:type":{}},` +
+						`"k:{\"type\":\"GpuThermalWatch\"}":{".":{},"f:message":{},"f:reason":{},"f:status":{},"f:type":{}}}}}`)},
+				},
+			},
+		},
+		Status: corev1.NodeStatus{
+			Conditions: []corev1.NodeCondition{
+				{Type: corev1.NodeReady, Status: corev1.ConditionTrue, Reason: "KubeletReady"},
+				{Type: "GpuXidError", Status: corev1.ConditionTrue, Reason: "GpuXidErrorIsNotHealthy", Message: "xid;"},
+				{Type: "GpuThermalWatch", Status: corev1.ConditionTrue, Reason: "GpuThermalWatchIsNotHealthy"},
+			},
+		},
+	}
+
+	changed := map[corev1.NodeConditionType]corev1.NodeCondition{
+		"GpuThermalWatch": {
+			Type:               "GpuThermalWatch",
+			Status:             corev1.ConditionFalse,
+			Reason:             "GpuThermalWatchIsHealthy",
+			Message:            NoHealthFailureMsg,
+			LastHeartbeatTime:  heartbeat,
+			LastTransitionTime: heartbeat,
+		},
+	}
+
+	apply, err := k8sConnector.nodeStatusApplyConfiguration(node, changed)
+	require.NoError(t, err)
+	require.Equal(t, "42", *apply.ResourceVersion)
+	require.Len(t, apply.Status.Conditions, 2)
+
+	// the owned XID condition is carried over so the apply does not remove it, Ready is
+	// not owned by the connector and is left out
+	xid := apply.Status.Conditions[0]
+	require.Equal(t, corev1.NodeConditionType("GpuXidError"), *xid.Type)
+	require.Equal(t, "xid;", *xid.Message)
+
+	thermal := apply.Status.Conditions[1]
+	require.Equal(t, corev1.NodeConditionType("GpuThermalWatch"), *thermal.Type)
+	require.Equal(t, corev1.ConditionFalse, *thermal.Status)
+	require.Equal(t, NoHealthFailureMsg, *thermal.Message)
+	require.True(t, thermal.LastHeartbeatTime.Equal(&heartbeat))
+}
+
+func TestGroupByNode(t *testing.T) {
+	now := time.Now()
+	events := []*protos.HealthEvent{
+		xidEvent("node-b", now),
+		xidEvent("node-a", now),
+		xidEvent("node-b", now.Add(time.Second)),
+	}
+
+	groups := groupByNode(events)
+	require.Len(t, groups, 2)
+	require.Equal(t, []*protos.HealthEvent{events[0], events[2]}, groups[0])
+	require.Equal(t, []*protos.HealthEvent{events[1]}, groups[1])
+	require.Empty(t, groupByNode(nil))
+}
+
+func TestGroupBatchesByNode(t *testing.T) {
+	now := time.Now()
+	batch := func(nodes ...string) *protos.HealthEvents {
+		events := &protos.HealthEvents{Version: 1}
+		for _, node := range nodes {
+			events.Events = append(events.Events, xidEvent(node, now))
+		}
+
+		return events
+	}
+
+	batches := []*protos.HealthEvents{batch("node-b"), batch("node-a", "node-b"), batch("node-a"), batch("node-b")}
+
+	groups := groupBatchesByNode(batches)
+	require.Equal(t, [][]*protos.HealthEvents{
+		{batches[0], batches[3]},
+		{batches[1]},
+		{batches[2]},
+	}, groups)
+	require.Empty(t, groupBatchesByNode(nil))
+}
+
+type recordingDeadLetters struct {
+	mu      sync.Mutex
+	batches []*protos.HealthEvents
+}
+
+func (d *recordingDeadLetters) Add(_, _ string, _ error, healthEvents *protos.HealthEvents) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	d.batches = append(d.batches, healthEvents)
+}
+
+func (d *recordingDeadLetters) Len() int {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	return len(d.batches)
+}
+
+func TestFetchAndProcessHealthMetric_FailsOnlyTheFailingNode(t *testing.T) {
+	connector, localClientSet := newFieldManagedConnector(t,
+		&corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "node-a"}})
+
+	deadLetters := &recordingDeadLetters{}
+	connector.ringBuffer.SetDeadLetterQueue(deadLetters)
+
+	now := time.Now()
+
+	// node-gone does not exist, so its condition write fails
+	gone := []*protos.HealthEvents{
+		{Version: 1, Events: []*protos.HealthEvent{xidEvent("node-gone", now)}},
+		{Version: 1, Events: []*protos.HealthEvent{xidEvent("node-gone", now.Add(time.Second))}},
+	}
+
+	connector.ringBuffer.Enqueue(gone[0])
+	connector.ringBuffer.Enqueue(&protos.HealthEvents{Version: 1, Events: []*protos.HealthEvent{xidEvent("node-a", now)}})
+	connector.ringBuffer.Enqueue(gone[1])
+
+	go connector.FetchAndProcessHealthMetric(ctx)
+	t.Cleanup(connector.ringBuffer.ShutDownHealthMetricQueue)
+
+	require.Eventually(t, func() bool {
+		return connector.ringBuffer.CurrentLength() == 0 && deadLetters.Len() == 2
+	}, 5*time.Second, 10*time.Millisecond)
+
+	// the batches of the healthy node are applied, not dead-lettered with the failing ones
+	require.ElementsMatch(t, gone, deadLetters.batches)
+
+	node, err := localClientSet.CoreV1().Nodes().Get(ctx, "node-a", metav1.GetOptions{})
+	require.NoError(t, err)
+	require.Len(t, node.Status.Conditions, 1)
+}
+
+func TestFetchAndProcessHealthMetric_CoalescesBatches(t *testing.T) {
+	connector, localClientSet := newFieldManagedConnector(t,
+		&corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "node-a"}},
+		&corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "node-b"}})
+
+	now := time.Now()
+
+	for i := range 10 {
+		event := xidEvent("node-a", now.Add(time.Duration(i)*time.Second))
+		event.EntitiesImpacted = []*protos.Entity{{EntityType: "GPU", EntityValue: fmt.Sprint(i % 2)}}
+
+		connector.ringBuffer.Enqueue(&protos.HealthEvents{Version: 1, Events: []*protos.HealthEvent{event}})
+	}
+
+	connector.ringBuffer.Enqueue(&protos.HealthEvents{Version: 1, Events: []*protos.HealthEvent{xidEvent("node-b", now)}})
+
+	go connector.FetchAndProcessHealthMetric(ctx)
+	t.Cleanup(connector.ringBuffer.ShutDownHealthMetricQueue)
+
+	require.Eventually(t, func() bool {
+		return connector.ringBuffer.CurrentLength() == 0 && statusPatches(localClientSet) >= 2
+	}, 5*time.Second, 10*time.Millisecond)
+
+	// all eleven batches were queued before the connector started, so they are merged
+	// into one pass with a single write per node
+	require.Equal(t, 2, statusPatches(localClientSet))
+
+	node, err := localClientSet.CoreV1().Nodes().Get(ctx, "node-a", metav1.GetOptions{})
+	require.NoError(t, err)
+	require.Len(t, node.Status.Conditions, 1)
+	require.Contains(t, node.Status.Conditions[0].Message, "GPU:0 ")
+	require.Contains(t, node.Status.Conditions[0].Message, "GPU:1 ")
+}
@@ -38,6 +38,11 @@ var (
 		Help: "The total number of node condition updates by status",
 	}, []string{"status"})
 
+	nodeConditionUpdateSkippedCounter = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "k8s_platform_connector_node_condition_update_skipped_total",
+		Help: "The total number of node condition updates skipped because no condition changed",
+	})
+
 	nodeEventOperationsCounter = promauto.NewCounterVec(prometheus.CounterOpts{
 		Name: "k8s_platform_connector_node_event_operations_total",
 		Help: "The total number of node event operations by type and status",
@@ -49,6 +54,12 @@ var (
 		Buckets: prometheus.LinearBuckets(0, 10, 500),
 	})
 
+	coalescedBatchesHistogram = promauto.NewHistogram(prometheus.HistogramOpts{
+		Name:    "k8s_platform_connector_coalesced_batches",
+		Help:    "Number of queued health event batches processed together in one pass",
+		Buckets: prometheus.ExponentialBuckets(1, 2, 7),
+	})
+
 	nodeEventUpdateCreateDuration = promauto.NewHistogram(prometheus.HistogramOpts{
 		Name:    "k8s_platform_connector_node_event_update_create_duration_milliseconds",
 		Help:    "Duration of node event updates/creations in milliseconds",
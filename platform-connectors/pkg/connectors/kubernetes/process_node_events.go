@@ -20,24 +20,31 @@ import (
 	"fmt"
 	"io"
 	"log/slog"
+	"maps"
 	"net"
 	"slices"
 	"strings"
 	"syscall"
 	"time"
 
+	"github.com/nvidia/nvsentinel/data-models/pkg/model"
 	"github.com/nvidia/nvsentinel/data-models/pkg/protos"
 
 	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
+	corev1ac "k8s.io/client-go/applyconfigurations/core/v1"
 	"k8s.io/client-go/util/retry"
 )
 
 const (
 	DefaultNamespace   = "default"
 	NoHealthFailureMsg = "No Health Failures"
+	// FieldManager owns the node conditions the connector writes with server-side apply.
+	FieldManager = "nvsentinel-platform-connector"
+	// HeartbeatRefreshInterval is how long an unchanged condition goes without a write.
+	HeartbeatRefreshInterval = 5 * time.Minute
 )
 
 //nolint:cyclop, gocognit
@@ -84,7 +91,7 @@ func (r *K8sConnector) updateNodeConditions(ctx context.Context, healthEvents []
 		conditionToHealthEventsMap[conditionType] = append(conditionToHealthEventsMap[conditionType], event)
 	}
 
-	err := retry.OnError(retry.DefaultRetry, func(err error) bool {
+	return retry.OnError(retry.DefaultRetry, func(err error) bool {
 		return apierrors.IsConflict(err) || isTemporaryError(err)
 	}, func() error {
 		node, err := r.clientset.CoreV1().Nodes().Get(ctx, healthEvents[0].NodeName, metav1.GetOptions{})
@@ -93,90 +100,163 @@ func (r *K8sConnector) updateNodeConditions(ctx context.Context, healthEvents []
 			return fmt.Errorf("failed to get node %s: %w", healthEvents[0].NodeName, err)
 		}
 
+		changed := make(map[corev1.NodeConditionType]corev1.NodeCondition)
+
 		for conditionType, events := range conditionToHealthEventsMap {
-			var matchedCondition *corev1.NodeCondition
+			existing := findNodeCondition(node.Status.Conditions, conditionType)
+			condition := r.buildNodeCondition(conditionType, existing, events)
 
-			var conditionIndex int
+			if existing != nil && !nodeConditionChanged(*existing, condition) {
+				continue
+			}
 
-			conditionExists := false
+			changed[conditionType] = condition
+		}
 
-			// search for existing condition
-			for i, c := range node.Status.Conditions {
-				if c.Type == conditionType {
-					matchedCondition = &c
-					conditionIndex = i
-					conditionExists = true
+		if len(changed) == 0 {
+			nodeConditionUpdateSkippedCounter.Inc()
+			return nil
+		}
 
-					break
-				}
-			}
+		apply, err := r.nodeStatusApplyConfiguration(node, changed)
+		if err != nil {
+			return err
+		}
 
-			// Initialize condition if it doesn't exist
-			if !conditionExists {
-				matchedCondition = &corev1.NodeCondition{
-					Type:               conditionType,
-					LastHeartbeatTime:  metav1.NewTime(events[len(events)-1].GeneratedTimestamp.AsTime()),
-					LastTransitionTime: metav1.NewTime(events[len(events)-1].GeneratedTimestamp.AsTime()),
-				}
+		_, err = r.clientset.CoreV1().Nodes().ApplyStatus(ctx, apply, metav1.ApplyOptions{
+			FieldManager: FieldManager,
+			Force:        true,
+		})
+		if err != nil {
+			for conditionType := range changed {
+				slog.Info("Node condition update failed", "conditionType", conditionType, "error", err)
 			}
 
-			// split messages by ";" in condition
-			messages := r.parseMessages(matchedCondition.Message)
+			return fmt.Errorf("failed to update node %s status: %w", node.Name, err)
+		}
 
-			// aggregate messages from all health events for the associated condition
-			for _, event := range events {
-				if !event.IsHealthy {
-					// add the new message if it doesn't exist
-					messages = r.addMessageIfNotExist(messages, event)
-				} else {
-					// remove messages that include any of the entities in entitiesImpacted, else if
-					// empty then clear all the messages for all entities
-					if len(event.EntitiesImpacted) > 0 {
-						messages = r.removeImpactedEntitiesMessages(messages, event.EntitiesImpacted)
-					} else {
-						messages = []string{}
-					}
-				}
-			}
+		return nil
+	})
+}
 
-			if len(messages) > 0 {
-				matchedCondition.Message = fmt.Sprintf("%s;", strings.Join(messages, ";"))
-				matchedCondition.Status = corev1.ConditionTrue
-				matchedCondition.Reason = r.updateHealthEventReason(events[len(events)-1].CheckName, false)
-			} else {
-				matchedCondition.Message = NoHealthFailureMsg
-				matchedCondition.Status = corev1.ConditionFalse
-				matchedCondition.Reason = r.updateHealthEventReason(events[len(events)-1].CheckName, true)
-			}
+// buildNodeCondition folds the health events for one condition type into the existing
+// condition, or into a new one when the node does not carry it yet.
+func (r *K8sConnector) buildNodeCondition(conditionType corev1.NodeConditionType,
+	existing *corev1.NodeCondition, events []*protos.HealthEvent) corev1.NodeCondition {
+	latest := metav1.NewTime(events[len(events)-1].GeneratedTimestamp.AsTime())
 
-			matchedCondition.LastHeartbeatTime = metav1.NewTime(events[len(events)-1].GeneratedTimestamp.AsTime())
+	condition := corev1.NodeCondition{
+		Type:               conditionType,
+		LastTransitionTime: latest,
+	}
 
-			// update transition time if status has changed
-			if conditionExists && matchedCondition.Status != node.Status.Conditions[conditionIndex].Status {
-				matchedCondition.LastTransitionTime = matchedCondition.LastHeartbeatTime
-			}
+	if existing != nil {
+		condition = *existing
+	}
+
+	// split messages by ";" in condition
+	messages := r.parseMessages(condition.Message)
 
-			// updates to the node conditions
-			if conditionExists {
-				node.Status.Conditions[conditionIndex] = *matchedCondition
+	// aggregate messages from all health events for the associated condition
+	for _, event := range events {
+		if !event.IsHealthy {
+			// add the new message if it doesn't exist
+			messages = r.addMessageIfNotExist(messages, event)
+		} else {
+			// remove messages that include any of the entities in entitiesImpacted, else if
+			// empty then clear all the messages for all entities
+			if len(event.EntitiesImpacted) > 0 {
+				messages = r.removeImpactedEntitiesMessages(messages, event.EntitiesImpacted)
 			} else {
-				node.Status.Conditions = append(node.Status.Conditions, *matchedCondition)
+				messages = []string{}
 			}
 		}
+	}
 
-		_, err = r.clientset.CoreV1().Nodes().UpdateStatus(ctx, node, metav1.UpdateOptions{})
-		if err != nil {
-			for conditionType := range conditionToHealthEventsMap {
-				slog.Info("Node condition update failed", "conditionType", conditionType, "error", err)
+	checkName := events[len(events)-1].CheckName
+
+	if len(messages) > 0 {
+		condition.Message = fmt.Sprintf("%s;", strings.Join(messages, ";"))
+		condition.Status = corev1.ConditionTrue
+		condition.Reason = r.updateHealthEventReason(checkName, false)
+	} else {
+		condition.Message = NoHealthFailureMsg
+		condition.Status = corev1.ConditionFalse
+		condition.Reason = r.updateHealthEventReason(checkName, true)
+	}
+
+	condition.LastHeartbeatTime = latest
+
+	// update transition time if status has changed
+	if existing != nil && condition.Status != existing.Status {
+		condition.LastTransitionTime = latest
+	}
+
+	return condition
+}
+
+// nodeConditionChanged reports whether desired differs from the condition already on the
+// node. An unchanged condition is still rewritten once its heartbeat is older than
+// HeartbeatRefreshInterval, so LastHeartbeatTime keeps tracking the monitors.
+func nodeConditionChanged(existing, desired corev1.NodeCondition) bool {
+	if existing.Status != desired.Status || existing.Reason != desired.Reason ||
+		existing.Message != desired.Message {
+		return true
+	}
+
+	return desired.LastHeartbeatTime.Sub(existing.LastHeartbeatTime.Time) >= HeartbeatRefreshInterval
+}
+
+// nodeStatusApplyConfiguration builds the server-side apply request for the changed
+// conditions. Conditions the connector applied earlier are carried over unchanged,
+// since leaving them out of the request would remove them from the node.
+func (r *K8sConnector) nodeStatusApplyConfiguration(node *corev1.Node,
+	changed map[corev1.NodeConditionType]corev1.NodeCondition) (*corev1ac.NodeApplyConfiguration, error) {
+	owned, err := corev1ac.ExtractNodeStatus(node, FieldManager)
+	if err != nil {
+		return nil, fmt.Errorf("failed to extract conditions owned by %s on node %s: %w", FieldManager, node.Name, err)
+	}
+
+	status := corev1ac.NodeStatus()
+
+	if owned.Status != nil {
+		for i := range owned.Status.Conditions {
+			condition := &owned.Status.Conditions[i]
+			if condition.Type == nil {
+				continue
 			}
 
-			return fmt.Errorf("failed to update node %s status: %w", node.Name, err)
+			if _, ok := changed[*condition.Type]; !ok {
+				status.WithConditions(condition)
+			}
 		}
+	}
 
-		return nil
-	})
+	for _, conditionType := range slices.Sorted(maps.Keys(changed)) {
+		condition := changed[conditionType]
+		status.WithConditions(corev1ac.NodeCondition().
+			WithType(condition.Type).
+			WithStatus(condition.Status).
+			WithReason(condition.Reason).
+			WithMessage(condition.Message).
+			WithLastHeartbeatTime(condition.LastHeartbeatTime).
+			WithLastTransitionTime(condition.LastTransitionTime))
+	}
 
-	return err
+	// the resource version makes the apply fail with a conflict, and so be retried, when
+	// the node changed after the conditions were computed from it
+	return corev1ac.Node(node.Name).WithResourceVersion(node.ResourceVersion).WithStatus(status), nil
+}
+
+func findNodeCondition(conditions []corev1.NodeCondition,
+	conditionType corev1.NodeConditionType) *corev1.NodeCondition {
+	for i := range conditions {
+		if conditions[i].Type == conditionType {
+			return &conditions[i]
+		}
+	}
+
+	return nil
 }
 
 func (r *K8sConnector) parseMessages(message string) []string {
@@ -322,9 +402,15 @@ func (r *K8sConnector) constructHealthEventMessage(healthEvent *protos.HealthEve
 }
 
 func (r *K8sConnector) processHealthEvents(ctx context.Context, healthEvents *protos.HealthEvents) error {
+	// Canary events exercise the pipeline without acting on the canary node, and
+	// heartbeats only report the agent version to the store
+	events := slices.DeleteFunc(slices.Clone(healthEvents.Events), func(event *protos.HealthEvent) bool {
+		return model.IsCanary(event) || model.IsHeartbeat(event)
+	})
+
 	var nodeConditions []corev1.NodeCondition
 
-	for _, healthEvent := range healthEvents.Events {
+	for _, healthEvent := range events {
 		conditionType := corev1.NodeConditionType(string(healthEvent.CheckName))
 		message := r.fetchHealthEventMessage(healthEvent)
 
@@ -341,21 +427,23 @@ func (r *K8sConnector) processHealthEvents(ctx context.Context, healthEvents *pr
 	}
 
 	if len(nodeConditions) > 0 {
-		start := time.Now()
-		err := r.updateNodeConditions(ctx, healthEvents.Events)
+		for _, nodeEvents := range groupByNode(events) {
+			start := time.Now()
+			err := r.updateNodeConditions(ctx, nodeEvents)
 
-		duration := float64(time.Since(start).Milliseconds())
-		nodeConditionUpdateDuration.Observe(duration)
+			duration := float64(time.Since(start).Milliseconds())
+			nodeConditionUpdateDuration.Observe(duration)
 
-		if err != nil {
-			nodeConditionUpdateCounter.WithLabelValues(StatusFailed).Inc()
-			return fmt.Errorf("failed to update node conditions: %w", err)
-		}
+			if err != nil {
+				nodeConditionUpdateCounter.WithLabelValues(StatusFailed).Inc()
+				return fmt.Errorf("failed to update node conditions: %w", err)
+			}
 
-		nodeConditionUpdateCounter.WithLabelValues(StatusSuccess).Inc()
+			nodeConditionUpdateCounter.WithLabelValues(StatusSuccess).Inc()
+		}
 	}
 
-	for _, healthEvent := range healthEvents.Events {
+	for _, healthEvent := range events {
 		if !healthEvent.IsHealthy && !healthEvent.IsFatal {
 			event := &corev1.Event{
 				ObjectMeta: metav1.ObjectMeta{
@@ -395,6 +483,27 @@ func (r *K8sConnector) processHealthEvents(ctx context.Context, healthEvents *pr
 	return nil
 }
 
+// groupByNode splits events by node, keeping the order in which the nodes first appear,
+// since coalesced batches may carry events for several nodes.
+func groupByNode(events []*protos.HealthEvent) [][]*protos.HealthEvent {
+	var groups [][]*protos.HealthEvent
+
+	index := make(map[string]int)
+
+	for _, event := range events {
+		i, ok := index[event.NodeName]
+		if !ok {
+			i = len(groups)
+			index[event.NodeName] = i
+			groups = append(groups, nil)
+		}
+
+		groups[i] = append(groups[i], event)
+	}
+
+	return groups
+}
+
 // isTemporaryError checks if the error is a temporary network error that should be retried
 func isTemporaryError(err error) bool {
 	if err == nil {
@@ -18,9 +18,11 @@ import (
 	"context"
 	"crypto/tls"
 	"crypto/x509"
+	"errors"
 	"fmt"
 	"log/slog"
 	"os"
+	"slices"
 	"strconv"
 	"time"
 
@@ -43,6 +45,9 @@ type MongoDbStoreConnector struct {
 	ringBuffer *ringbuffer.RingBuffer
 	nodeName   string
 	collection *mongo.Collection
+	// heartbeats holds the last heartbeat per node and agent, nil when heartbeats
+	// are not recorded
+	heartbeats *mongo.Collection
 }
 
 func new(
@@ -163,9 +168,15 @@ func InitializeMongoDbStoreConnector(ctx context.Context, ringbuffer *ringbuffer
 
 	collection := client.Database(mongoDbName).Collection(mongoDbCollection, collOpts)
 
+	connector := new(client, ringbuffer, nodeName, collection)
+
+	if heartbeatCollection := os.Getenv("MONGODB_HEARTBEAT_COLLECTION_NAME"); heartbeatCollection != "" {
+		connector.heartbeats = client.Database(mongoDbName).Collection(heartbeatCollection, collOpts)
+	}
+
 	slog.Info("Successfully initialized mongodb store connector")
 
-	return new(client, ringbuffer, nodeName, collection), nil
+	return connector, nil
 }
 
 func (r *MongoDbStoreConnector) FetchAndProcessHealthMetric(ctx context.Context) {
@@ -184,7 +195,7 @@ func (r *MongoDbStoreConnector) FetchAndProcessHealthMetric(ctx context.Context)
 			err := r.insertHealthEvents(ctx, healthEvents)
 			if err != nil {
 				slog.Error("Error inserting health events", "error", err)
-				r.ringBuffer.HealthMetricEleProcessingFailed(healthEvents)
+				r.ringBuffer.DeadLetter(healthEvents, err)
 			} else {
 				r.ringBuffer.HealthMetricEleProcessingCompleted(healthEvents)
 			}
@@ -217,6 +228,15 @@ func (r *MongoDbStoreConnector) insertHealthEvents(
 	ctx context.Context,
 	healthEvents *protos.HealthEvents,
 ) error {
+	if err := r.recordHeartbeats(ctx, healthEvents.GetEvents()); err != nil {
+		return err
+	}
+
+	events := slices.DeleteFunc(slices.Clone(healthEvents.GetEvents()), model.IsHeartbeat)
+	if len(events) == 0 {
+		return nil
+	}
+
 	session, err := r.client.StartSession()
 	if err != nil {
 		return fmt.Errorf("failed to start MongoDB session: %w", err)
@@ -233,7 +253,7 @@ func (r *MongoDbStoreConnector) insertHealthEvents(
 	callback := func(sessionContext mongo.SessionContext) (interface{}, error) {
 		healthEventWithStatusList := []interface{}{}
 
-		for _, healthEvent := range healthEvents.GetEvents() {
+		for _, healthEvent := range events {
 			healthEventWithStatusObj := model.HealthEventWithStatus{
 				CreatedAt:   time.Now().UTC(),
 				HealthEvent: healthEvent,
@@ -258,6 +278,56 @@ func (r *MongoDbStoreConnector) insertHealthEvents(
 	return nil
 }
 
+// recordHeartbeats upserts the last heartbeat per node and agent. Heartbeats are
+// not stored as health events.
+func (r *MongoDbStoreConnector) recordHeartbeats(ctx context.Context, events []*protos.HealthEvent) error {
+	if r.heartbeats == nil {
+		return nil
+	}
+
+	for _, event := range events {
+		if !model.IsHeartbeat(event) {
+			continue
+		}
+
+		heartbeat := model.HeartbeatFromEvent(event)
+		filter := bson.M{"nodename": heartbeat.NodeName, "agent": heartbeat.Agent}
+
+		_, err := r.heartbeats.ReplaceOne(ctx, filter, heartbeat, options.Replace().SetUpsert(true))
+		if err != nil {
+			return fmt.Errorf("failed to record heartbeat of %s on %s: %w", heartbeat.Agent, heartbeat.NodeName, err)
+		}
+	}
+
+	return nil
+}
+
+// FindHealthEvent returns the stored health event with the given event ID, or nil
+// when it is not stored.
+func (r *MongoDbStoreConnector) FindHealthEvent(ctx context.Context, id string) (*model.HealthEventWithStatus, error) {
+	var event model.HealthEventWithStatus
+
+	err := r.collection.FindOne(ctx, bson.M{"healthevent.id": id}).Decode(&event)
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to find health event %s: %w", id, err)
+	}
+
+	return &event, nil
+}
+
+// DeleteHealthEvent deletes the stored health event with the given event ID.
+func (r *MongoDbStoreConnector) DeleteHealthEvent(ctx context.Context, id string) error {
+	if _, err := r.collection.DeleteOne(ctx, bson.M{"healthevent.id": id}); err != nil {
+		return fmt.Errorf("failed to delete health event %s: %w", id, err)
+	}
+
+	return nil
+}
+
 func pollTillCACertIsMountedSuccessfully(certPath string, timeoutInterval time.Duration,
 	pingInterval time.Duration) ([]byte, error) {
 	timeout := time.Now().Add(timeoutInterval) // total timeout
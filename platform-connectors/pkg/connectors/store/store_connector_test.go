@@ -21,6 +21,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/nvidia/nvsentinel/data-models/pkg/model"
 	"github.com/nvidia/nvsentinel/data-models/pkg/protos"
 	"github.com/nvidia/nvsentinel/platform-connectors/pkg/ringbuffer"
 	"github.com/stretchr/testify/require"
@@ -81,6 +82,30 @@ func TestInsertHealthEvents(t *testing.T) {
 		require.Error(mt, err)
 		require.Contains(mt, err.Error(), "duplicate key error", "error message should contain 'duplicate key error'")
 	})
+
+	mt.Run("heartbeats are recorded instead of inserted", func(mt *mtest.T) {
+		mt.AddMockResponses(mtest.CreateSuccessResponse(bson.E{Key: "n", Value: 1})) // ReplaceOne
+
+		connector := &MongoDbStoreConnector{
+			client:     mt.Client,
+			ringBuffer: ringBuffer,
+			nodeName:   nodeName,
+			collection: mt.Coll,
+			heartbeats: mt.Coll,
+		}
+
+		healthEvents := &protos.HealthEvents{
+			Events: []*protos.HealthEvent{model.NewHeartbeat("syslog-health-monitor", nodeName, "v1.2.0", time.Now())},
+		}
+
+		err := connector.insertHealthEvents(context.Background(), healthEvents)
+		require.NoError(mt, err)
+
+		started := mt.GetStartedEvent()
+		require.NotNil(mt, started)
+		require.Equal(mt, "update", started.CommandName)
+		require.Nil(mt, mt.GetStartedEvent(), "heartbeats must not be inserted as health events")
+	})
 }
 
 func TestFetchAndProcessHealthMetric(t *testing.T) {
@@ -178,6 +203,36 @@ func TestFetchAndProcessHealthMetric(t *testing.T) {
 	})
 }
 
+func TestFindHealthEvent(t *testing.T) {
+	mtOpts := mtest.NewOptions().ClientType(mtest.Mock).ClientOptions(options.Client().SetRetryWrites(false))
+	mt := mtest.New(t, mtOpts)
+
+	mt.Run("stored event", func(mt *mtest.T) {
+		mt.AddMockResponses(mtest.CreateCursorResponse(0, "testdb.events", mtest.FirstBatch, bson.D{
+			{Key: "healthevent", Value: bson.D{{Key: "id", Value: "event-1"}, {Key: "nodename", Value: "testNode"}}},
+			{Key: "healtheventstatus", Value: bson.D{{Key: "nodequarantined", Value: "CanaryQuarantined"}}},
+		}))
+
+		connector := &MongoDbStoreConnector{client: mt.Client, collection: mt.Coll}
+
+		event, err := connector.FindHealthEvent(context.Background(), "event-1")
+		require.NoError(mt, err)
+		require.NotNil(mt, event)
+		require.Equal(mt, "testNode", event.HealthEvent.NodeName)
+		require.Equal(mt, model.CanaryQuarantined, *event.HealthEventStatus.NodeQuarantined)
+	})
+
+	mt.Run("missing event", func(mt *mtest.T) {
+		mt.AddMockResponses(mtest.CreateCursorResponse(0, "testdb.events", mtest.FirstBatch))
+
+		connector := &MongoDbStoreConnector{client: mt.Client, collection: mt.Coll}
+
+		event, err := connector.FindHealthEvent(context.Background(), "event-1")
+		require.NoError(mt, err)
+		require.Nil(mt, event)
+	})
+}
+
 func TestGetEnvAsInt(t *testing.T) {
 	tests := []struct {
 		name         string
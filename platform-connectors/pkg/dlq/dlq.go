@@ -0,0 +1,332 @@
+// Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package dlq keeps the health events the platform connector could not process,
+// so they can be inspected, re-driven or purged instead of being dropped.
+package dlq
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/nvidia/nvsentinel/data-models/pkg/model"
+	"github.com/nvidia/nvsentinel/data-models/pkg/protos"
+	"github.com/nvidia/nvsentinel/platform-connectors/pkg/ringbuffer"
+	"google.golang.org/protobuf/proto"
+)
+
+const (
+	// DefaultCapacity is the number of entries kept when none is configured
+	DefaultCapacity = 1000
+
+	// ReasonValidation is set on events rejected on ingest
+	ReasonValidation = "validation"
+	// ReasonProcessing is set on events a connector failed to process
+	ReasonProcessing = ringbuffer.ReasonProcessing
+)
+
+// ErrNotFound is returned for IDs without an entry.
+var ErrNotFound = errors.New("dead letter not found")
+
+var _ ringbuffer.DeadLetterQueue = (*Queue)(nil)
+
+// Entry is a batch of health events that could not be processed.
+type Entry struct {
+	ID string `json:"id"`
+	// Connector is the ring buffer the events failed in, empty for events
+	// rejected on ingest
+	Connector string                `json:"connector,omitempty"`
+	Reason    string                `json:"reason"`
+	Error     string                `json:"error"`
+	Events    []*protos.HealthEvent `json:"events"`
+	FailedAt  time.Time             `json:"failedAt"`
+	// Attempts counts how often the events failed, re-drives included
+	Attempts int `json:"attempts"`
+}
+
+// RedriveFunc processes the events of an entry again.
+type RedriveFunc func(entry Entry) error
+
+// Queue is a bounded in-memory dead letter queue. When it is full, the oldest
+// entry is evicted.
+type Queue struct {
+	mu       sync.Mutex
+	capacity int
+	entries  []*Entry
+	redrive  RedriveFunc
+	// attempts carries the attempts of re-driven entries until they fail again
+	attempts map[string]int
+	now      func() time.Time
+}
+
+// NewQueue creates a Queue keeping at most capacity entries.
+func NewQueue(capacity int) *Queue {
+	if capacity <= 0 {
+		capacity = DefaultCapacity
+	}
+
+	return &Queue{
+		capacity: capacity,
+		attempts: make(map[string]int),
+		now:      time.Now,
+	}
+}
+
+// SetRedrive sets how entries are re-driven.
+func (q *Queue) SetRedrive(redrive RedriveFunc) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	q.redrive = redrive
+}
+
+// Add records events that failed in the connector with the reason and error.
+func (q *Queue) Add(connector, reason string, err error, healthEvents *protos.HealthEvents) {
+	if len(healthEvents.GetEvents()) == 0 {
+		return
+	}
+
+	entry := &Entry{
+		ID:        model.NewEventID(),
+		Connector: connector,
+		Reason:    reason,
+		Events:    cloneEvents(healthEvents.GetEvents()),
+		FailedAt:  q.now().UTC(),
+		Attempts:  1,
+	}
+
+	if err != nil {
+		entry.Error = err.Error()
+	}
+
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	// a re-driven entry that fails again keeps counting its attempts
+	if key := redriveKey(connector, healthEvents.GetEvents()); key != "" {
+		if attempts, ok := q.attempts[key]; ok {
+			entry.Attempts = attempts + 1
+
+			delete(q.attempts, key)
+		}
+	}
+
+	if len(q.entries) >= q.capacity {
+		evicted := q.entries[0]
+		q.entries = q.entries[1:]
+
+		deadLettersEvicted.WithLabelValues(evicted.Reason).Inc()
+		slog.Warn("Dead letter queue is full, evicted oldest entry",
+			"id", evicted.ID,
+			"connector", evicted.Connector,
+			"reason", evicted.Reason)
+	}
+
+	q.entries = append(q.entries, entry)
+
+	deadLettersTotal.WithLabelValues(connectorLabel(connector), reason).Inc()
+	q.updateDepth()
+
+	slog.Warn("Health events moved to the dead letter queue",
+		"id", entry.ID,
+		"connector", connector,
+		"reason", reason,
+		"events", len(entry.Events),
+		"error", entry.Error)
+}
+
+// List returns the entries, oldest first.
+func (q *Queue) List() []Entry {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	entries := make([]Entry, 0, len(q.entries))
+	for _, entry := range q.entries {
+		entries = append(entries, *entry)
+	}
+
+	return entries
+}
+
+// Get returns the entry with the ID.
+func (q *Queue) Get(id string) (Entry, error) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	i := q.index(id)
+	if i < 0 {
+		return Entry{}, fmt.Errorf("%w: %s", ErrNotFound, id)
+	}
+
+	return *q.entries[i], nil
+}
+
+// Depth returns the number of entries.
+func (q *Queue) Depth() int {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	return len(q.entries)
+}
+
+// Redrive removes the entry with the ID and processes its events again. The
+// entry is kept when it cannot be re-driven.
+func (q *Queue) Redrive(id string) error {
+	q.mu.Lock()
+
+	i := q.index(id)
+	if i < 0 {
+		q.mu.Unlock()
+		return fmt.Errorf("%w: %s", ErrNotFound, id)
+	}
+
+	if q.redrive == nil {
+		q.mu.Unlock()
+		return errors.New("re-driving dead letters is not supported")
+	}
+
+	entry := q.entries[i]
+	q.entries = append(q.entries[:i], q.entries[i+1:]...)
+
+	if key := redriveKey(entry.Connector, entry.Events); key != "" {
+		q.attempts[key] = entry.Attempts
+	}
+
+	q.updateDepth()
+	redrive := q.redrive
+	q.mu.Unlock()
+
+	if err := redrive(*entry); err != nil {
+		q.mu.Lock()
+		delete(q.attempts, redriveKey(entry.Connector, entry.Events))
+		q.entries = append(q.entries, entry)
+		q.updateDepth()
+		q.mu.Unlock()
+
+		return fmt.Errorf("failed to re-drive dead letter %s: %w", id, err)
+	}
+
+	deadLettersRedriven.WithLabelValues(connectorLabel(entry.Connector), entry.Reason).Inc()
+	slog.Info("Dead letter re-driven", "id", id, "connector", entry.Connector, "events", len(entry.Events))
+
+	return nil
+}
+
+// RedriveAll re-drives every entry and returns the number of entries re-driven.
+func (q *Queue) RedriveAll() (int, error) {
+	var (
+		redriven int
+		errs     []error
+	)
+
+	for _, entry := range q.List() {
+		if err := q.Redrive(entry.ID); err != nil {
+			errs = append(errs, err)
+			continue
+		}
+
+		redriven++
+	}
+
+	return redriven, errors.Join(errs...)
+}
+
+// Purge deletes the entry with the ID.
+func (q *Queue) Purge(id string) error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	i := q.index(id)
+	if i < 0 {
+		return fmt.Errorf("%w: %s", ErrNotFound, id)
+	}
+
+	slog.Info("Dead letter purged", "id", id, "events", len(q.entries[i].Events))
+
+	q.entries = append(q.entries[:i], q.entries[i+1:]...)
+	q.updateDepth()
+
+	return nil
+}
+
+// PurgeAll deletes every entry and returns the number of entries deleted.
+func (q *Queue) PurgeAll() int {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	purged := len(q.entries)
+	q.entries = nil
+	q.updateDepth()
+
+	slog.Info("Dead letter queue purged", "entries", purged)
+
+	return purged
+}
+
+func (q *Queue) index(id string) int {
+	for i, entry := range q.entries {
+		if entry.ID == id {
+			return i
+		}
+	}
+
+	return -1
+}
+
+func (q *Queue) updateDepth() {
+	depth := make(map[string]int)
+	for _, entry := range q.entries {
+		depth[entry.Reason]++
+	}
+
+	for _, reason := range []string{ReasonValidation, ReasonProcessing} {
+		deadLetterDepth.WithLabelValues(reason).Set(float64(depth[reason]))
+	}
+}
+
+// redriveKey identifies the events of an entry across a re-drive by the ID of
+// its first event.
+func redriveKey(connector string, events []*protos.HealthEvent) string {
+	if len(events) == 0 || events[0].GetId() == "" {
+		return ""
+	}
+
+	return connector + "/" + events[0].GetId()
+}
+
+func connectorLabel(connector string) string {
+	if connector == "" {
+		return "ingest"
+	}
+
+	return connector
+}
+
+// cloneEvents copies the events, so later changes to the batch by other
+// connectors do not alter the dead letter.
+func cloneEvents(events []*protos.HealthEvent) []*protos.HealthEvent {
+	cloned := make([]*protos.HealthEvent, 0, len(events))
+	for _, event := range events {
+		if event == nil {
+			continue
+		}
+
+		cloned = append(cloned, proto.Clone(event).(*protos.HealthEvent))
+	}
+
+	return cloned
+}
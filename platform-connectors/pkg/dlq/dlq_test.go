@@ -0,0 +1,166 @@
+// Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dlq
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	pb "github.com/nvidia/nvsentinel/data-models/pkg/protos"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func batch(ids ...string) *pb.HealthEvents {
+	healthEvents := &pb.HealthEvents{Version: 1}
+	for _, id := range ids {
+		healthEvents.Events = append(healthEvents.Events, &pb.HealthEvent{Id: id, NodeName: "node-1"})
+	}
+
+	return healthEvents
+}
+
+func TestQueueEvictsOldest(t *testing.T) {
+	queue := NewQueue(2)
+
+	queue.Add("mongodbStore", ReasonProcessing, errors.New("insert failed"), batch("a"))
+	queue.Add("mongodbStore", ReasonProcessing, errors.New("insert failed"), batch("b"))
+	queue.Add("", ReasonValidation, errors.New("nodeName is required"), batch("c"))
+	queue.Add("mongodbStore", ReasonProcessing, nil, batch())
+
+	entries := queue.List()
+	require.Len(t, entries, 2)
+	assert.Equal(t, "b", entries[0].Events[0].Id)
+	assert.Equal(t, "c", entries[1].Events[0].Id)
+	assert.Equal(t, ReasonValidation, entries[1].Reason)
+	assert.Equal(t, "nodeName is required", entries[1].Error)
+}
+
+func TestQueueKeepsACopy(t *testing.T) {
+	queue := NewQueue(10)
+	healthEvents := batch("a")
+
+	queue.Add("kubernetes", ReasonProcessing, errors.New("update failed"), healthEvents)
+	healthEvents.Events[0].NodeName = "node-2"
+
+	assert.Equal(t, "node-1", queue.List()[0].Events[0].NodeName)
+}
+
+func TestQueueRedrive(t *testing.T) {
+	queue := NewQueue(10)
+	queue.Add("mongodbStore", ReasonProcessing, errors.New("insert failed"), batch("a"))
+	id := queue.List()[0].ID
+
+	require.Error(t, queue.Redrive(id), "re-drive without a redrive func")
+	assert.Equal(t, 1, queue.Depth())
+
+	var redriven []Entry
+
+	fail := true
+	queue.SetRedrive(func(entry Entry) error {
+		if fail {
+			return errors.New("connector is not enabled")
+		}
+
+		redriven = append(redriven, entry)
+
+		return nil
+	})
+
+	require.Error(t, queue.Redrive(id))
+	assert.Equal(t, 1, queue.Depth(), "failed re-drives keep the entry")
+
+	fail = false
+
+	require.NoError(t, queue.Redrive(id))
+	require.Len(t, redriven, 1)
+	assert.Equal(t, 0, queue.Depth())
+
+	// the re-driven events fail again
+	queue.Add("mongodbStore", ReasonProcessing, errors.New("insert failed"), batch("a"))
+	assert.Equal(t, 2, queue.List()[0].Attempts)
+
+	assert.ErrorIs(t, queue.Redrive("unknown"), ErrNotFound)
+}
+
+func TestQueuePurge(t *testing.T) {
+	queue := NewQueue(10)
+	queue.Add("mongodbStore", ReasonProcessing, nil, batch("a"))
+	queue.Add("mongodbStore", ReasonProcessing, nil, batch("b"))
+
+	require.NoError(t, queue.Purge(queue.List()[0].ID))
+	assert.Equal(t, 1, queue.Depth())
+	assert.ErrorIs(t, queue.Purge("unknown"), ErrNotFound)
+
+	assert.Equal(t, 1, queue.PurgeAll())
+	assert.Equal(t, 0, queue.Depth())
+}
+
+func TestHandler(t *testing.T) {
+	queue := NewQueue(10)
+	queue.SetRedrive(func(Entry) error { return nil })
+	queue.Add("mongodbStore", ReasonProcessing, errors.New("insert failed"), batch("a"))
+	queue.Add("", ReasonValidation, errors.New("agent is required"), batch("b"))
+	queue.Add("kubernetes", ReasonProcessing, errors.New("update failed"), batch("c"))
+
+	handler := Handler(queue)
+
+	serve := func(method, target string) *httptest.ResponseRecorder {
+		recorder := httptest.NewRecorder()
+		handler.ServeHTTP(recorder, httptest.NewRequest(method, target, nil))
+
+		return recorder
+	}
+
+	recorder := serve(http.MethodGet, PathPrefix+"?reason="+ReasonProcessing)
+	require.Equal(t, http.StatusOK, recorder.Code)
+
+	var entries []Entry
+	require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &entries))
+	require.Len(t, entries, 2)
+
+	recorder = serve(http.MethodGet, PathPrefix+"?connector=kubernetes")
+	require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &entries))
+	require.Len(t, entries, 1)
+
+	id := entries[0].ID
+
+	recorder = serve(http.MethodGet, PathPrefix+id)
+	require.Equal(t, http.StatusOK, recorder.Code)
+	assert.Contains(t, recorder.Body.String(), "update failed")
+
+	assert.Equal(t, http.StatusNotFound, serve(http.MethodGet, PathPrefix+"unknown").Code)
+
+	assert.Equal(t, http.StatusOK, serve(http.MethodPost, PathPrefix+id+"/redrive").Code)
+	assert.Equal(t, 2, queue.Depth())
+
+	assert.Equal(t, http.StatusOK, serve(http.MethodDelete, PathPrefix+queue.List()[0].ID).Code)
+	assert.Equal(t, 1, queue.Depth())
+
+	recorder = serve(http.MethodPost, PathPrefix+"redrive")
+	require.Equal(t, http.StatusOK, recorder.Code)
+	assert.JSONEq(t, `{"redriven":1}`, recorder.Body.String())
+
+	queue.Add("kubernetes", ReasonProcessing, nil, batch("d"))
+
+	recorder = serve(http.MethodDelete, PathPrefix)
+	require.Equal(t, http.StatusOK, recorder.Code)
+	assert.JSONEq(t, `{"purged":1}`, recorder.Body.String())
+
+	assert.Equal(t, http.StatusMethodNotAllowed, serve(http.MethodPut, PathPrefix).Code)
+}
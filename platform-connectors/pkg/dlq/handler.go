@@ -0,0 +1,124 @@
+// Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dlq
+
+import (
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+)
+
+// PathPrefix is the path the dead letter queue handler is mounted on.
+const PathPrefix = "/dlq/"
+
+// Result is the response of the re-drive and purge requests.
+type Result struct {
+	Redriven int    `json:"redriven,omitempty"`
+	Purged   int    `json:"purged,omitempty"`
+	Error    string `json:"error,omitempty"`
+}
+
+// Handler serves the dead letter queue:
+//
+//	GET    /dlq/                 lists the entries, filtered by ?reason= and ?connector=
+//	GET    /dlq/{id}             returns an entry
+//	POST   /dlq/{id}/redrive     processes the events of an entry again
+//	POST   /dlq/redrive          re-drives every entry
+//	DELETE /dlq/{id}             deletes an entry
+//	DELETE /dlq/                 deletes every entry
+func Handler(queue *Queue) http.Handler {
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("GET "+PathPrefix+"{$}", func(w http.ResponseWriter, r *http.Request) {
+		reason := r.URL.Query().Get("reason")
+		connector := r.URL.Query().Get("connector")
+
+		entries := []Entry{}
+
+		for _, entry := range queue.List() {
+			if (reason == "" || entry.Reason == reason) && (connector == "" || entry.Connector == connector) {
+				entries = append(entries, entry)
+			}
+		}
+
+		writeJSON(w, http.StatusOK, entries)
+	})
+
+	mux.HandleFunc("GET "+PathPrefix+"{id}", func(w http.ResponseWriter, r *http.Request) {
+		entry, err := queue.Get(r.PathValue("id"))
+		if err != nil {
+			writeError(w, err)
+			return
+		}
+
+		writeJSON(w, http.StatusOK, entry)
+	})
+
+	mux.HandleFunc("POST "+PathPrefix+"redrive", func(w http.ResponseWriter, _ *http.Request) {
+		redriven, err := queue.RedriveAll()
+		if err != nil {
+			slog.Error("Failed to re-drive dead letters", "error", err)
+			writeJSON(w, http.StatusInternalServerError, Result{Redriven: redriven, Error: err.Error()})
+
+			return
+		}
+
+		writeJSON(w, http.StatusOK, Result{Redriven: redriven})
+	})
+
+	mux.HandleFunc("POST "+PathPrefix+"{id}/redrive", func(w http.ResponseWriter, r *http.Request) {
+		if err := queue.Redrive(r.PathValue("id")); err != nil {
+			writeError(w, err)
+			return
+		}
+
+		writeJSON(w, http.StatusOK, Result{Redriven: 1})
+	})
+
+	mux.HandleFunc("DELETE "+PathPrefix+"{$}", func(w http.ResponseWriter, _ *http.Request) {
+		writeJSON(w, http.StatusOK, Result{Purged: queue.PurgeAll()})
+	})
+
+	mux.HandleFunc("DELETE "+PathPrefix+"{id}", func(w http.ResponseWriter, r *http.Request) {
+		if err := queue.Purge(r.PathValue("id")); err != nil {
+			writeError(w, err)
+			return
+		}
+
+		writeJSON(w, http.StatusOK, Result{Purged: 1})
+	})
+
+	return mux
+}
+
+func writeError(w http.ResponseWriter, err error) {
+	if errors.Is(err, ErrNotFound) {
+		http.Error(w, err.Error(), http.StatusNotFound)
+		return
+	}
+
+	slog.Error("Failed to serve dead letter queue request", "error", err)
+	http.Error(w, err.Error(), http.StatusInternalServerError)
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		slog.Error("Failed to encode dead letter queue response", "error", err)
+	}
+}
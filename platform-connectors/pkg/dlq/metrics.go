@@ -0,0 +1,42 @@
+// Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package dlq
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+var (
+	deadLetterDepth = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "platform_connector_dlq_depth",
+		Help: "Current number of entries in the dead letter queue, by reason",
+	}, []string{"reason"})
+
+	deadLettersTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "platform_connector_dlq_entries_total",
+		Help: "Total number of health event batches moved to the dead letter queue, by connector and reason",
+	}, []string{"connector", "reason"})
+
+	deadLettersRedriven = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "platform_connector_dlq_redriven_total",
+		Help: "Total number of dead letter queue entries re-driven, by connector and reason",
+	}, []string{"connector", "reason"})
+
+	deadLettersEvicted = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "platform_connector_dlq_evicted_total",
+		Help: "Total number of dead letter queue entries evicted because the queue was full, by reason",
+	}, []string{"reason"})
+)
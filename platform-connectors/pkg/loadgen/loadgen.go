@@ -0,0 +1,352 @@
+// Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package loadgen simulates health monitors of many nodes publishing events to the
+// platform connector socket and measures the throughput and latency of the
+// connector, so the capacity of large clusters is planned on measurements.
+package loadgen
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"math/rand/v2"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	pb "github.com/nvidia/nvsentinel/data-models/pkg/protos"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// MetadataKey marks the generated events, so they can be told from real ones
+const MetadataKey = "loadgen"
+
+// gpusPerNode is the number of GPUs the impacted entities are picked from
+const gpusPerNode = 8
+
+// Config describes the simulated load.
+type Config struct {
+	// Socket is the unix socket of the platform connector
+	Socket string
+	// Agents is the number of simulated health monitors, each publishing for its
+	// own node
+	Agents int
+	// NodePrefix names the nodes of the agents, <prefix>-<index>
+	NodePrefix string
+	// FirstNode is the index of the node of the first agent, so that several loads
+	// publish for disjoint nodes of the same fleet
+	FirstNode int
+	// Rate is the number of events per second published by all the agents
+	Rate float64
+	// BatchSize is the number of events of a request
+	BatchSize int
+	// Connections is the number of gRPC connections the agents share
+	Connections int
+	// Duration is how long the load runs, until the context is cancelled when zero
+	Duration time.Duration
+	// Timeout is the timeout of a request
+	Timeout time.Duration
+	Mix     Mix
+	// Progress, when set, is called with the report so far every ProgressInterval
+	Progress         func(*Report)
+	ProgressInterval time.Duration
+	// Seed makes the published events reproducible
+	Seed uint64
+}
+
+// Validate checks that the config describes a load.
+func (c *Config) Validate() error {
+	if c.Socket == "" {
+		return fmt.Errorf("socket must be set")
+	}
+
+	if c.Agents <= 0 || c.BatchSize <= 0 || c.Connections <= 0 {
+		return fmt.Errorf("agents, batch size and connections must be positive")
+	}
+
+	if c.FirstNode < 0 {
+		return fmt.Errorf("first node must not be negative")
+	}
+
+	if c.Rate <= 0 {
+		return fmt.Errorf("rate must be positive")
+	}
+
+	if c.Duration < 0 || c.Timeout <= 0 {
+		return fmt.Errorf("duration must not be negative and timeout must be positive")
+	}
+
+	if c.Progress != nil && c.ProgressInterval <= 0 {
+		return fmt.Errorf("progress interval must be positive")
+	}
+
+	return c.Mix.Validate()
+}
+
+// Latency summarizes the request latencies.
+type Latency struct {
+	Mean time.Duration `json:"mean"`
+	P50  time.Duration `json:"p50"`
+	P90  time.Duration `json:"p90"`
+	P99  time.Duration `json:"p99"`
+	Max  time.Duration `json:"max"`
+}
+
+// Report is the outcome of a load run.
+type Report struct {
+	Elapsed time.Duration `json:"elapsed"`
+	// Events and Requests count the accepted events and requests
+	Events   int `json:"events"`
+	Requests int `json:"requests"`
+	Errors   int `json:"errors"`
+	// Throughput is the number of accepted events per second
+	Throughput float64 `json:"throughput"`
+	// TargetRate is the configured rate, a lower throughput means the connector
+	// or the agents could not keep up
+	TargetRate float64 `json:"targetRate"`
+	Latency    Latency `json:"latency"`
+	LastError  string  `json:"lastError,omitempty"`
+}
+
+// recorder collects the outcome of the requests of all the agents.
+type recorder struct {
+	mu        sync.Mutex
+	start     time.Time
+	events    int
+	requests  int
+	errors    int
+	latencies []time.Duration
+	lastError error
+}
+
+func (r *recorder) record(events int, latency time.Duration, err error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if err != nil {
+		r.errors++
+		r.lastError = err
+
+		return
+	}
+
+	r.events += events
+	r.requests++
+	r.latencies = append(r.latencies, latency)
+}
+
+func (r *recorder) report(targetRate float64) *Report {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	report := &Report{
+		Elapsed:    time.Since(r.start),
+		Events:     r.events,
+		Requests:   r.requests,
+		Errors:     r.errors,
+		TargetRate: targetRate,
+		Latency:    summarize(r.latencies),
+	}
+
+	if seconds := report.Elapsed.Seconds(); seconds > 0 {
+		report.Throughput = float64(report.Events) / seconds
+	}
+
+	if r.lastError != nil {
+		report.LastError = r.lastError.Error()
+	}
+
+	return report
+}
+
+// Run publishes the mix until the duration elapsed or ctx is cancelled and reports
+// the throughput and latencies of the connector.
+func Run(ctx context.Context, cfg Config) (*Report, error) {
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+
+	clients := make([]pb.PlatformConnectorClient, 0, cfg.Connections)
+
+	for range cfg.Connections {
+		conn, err := grpc.NewClient("unix://"+cfg.Socket, grpc.WithTransportCredentials(insecure.NewCredentials()))
+		if err != nil {
+			return nil, fmt.Errorf("failed to create client for %s: %w", cfg.Socket, err)
+		}
+		defer conn.Close()
+
+		clients = append(clients, pb.NewPlatformConnectorClient(conn))
+	}
+
+	if cfg.Duration > 0 {
+		var cancel context.CancelFunc
+
+		ctx, cancel = context.WithTimeout(ctx, cfg.Duration)
+		defer cancel()
+	}
+
+	// every agent sends a batch per interval, so that all of them send Rate events
+	// per second
+	interval := time.Duration(float64(cfg.Agents*cfg.BatchSize) / cfg.Rate * float64(time.Second))
+	recorder := &recorder{start: time.Now()}
+
+	var wg sync.WaitGroup
+
+	for i := range cfg.Agents {
+		node := cfg.FirstNode + i
+		agent := &agent{
+			client:   clients[i%len(clients)],
+			nodeName: cfg.NodePrefix + "-" + strconv.Itoa(node),
+			rng:      rand.New(rand.NewPCG(cfg.Seed, uint64(node))),
+			cfg:      &cfg,
+			recorder: recorder,
+		}
+
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+			agent.run(ctx, interval)
+		}()
+	}
+
+	if cfg.Progress != nil {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			ticker := time.NewTicker(cfg.ProgressInterval)
+			defer ticker.Stop()
+
+			for {
+				select {
+				case <-ctx.Done():
+					return
+				case <-ticker.C:
+					cfg.Progress(recorder.report(cfg.Rate))
+				}
+			}
+		}()
+	}
+
+	wg.Wait()
+
+	return recorder.report(cfg.Rate), nil
+}
+
+// agent simulates the health monitor of a node.
+type agent struct {
+	client   pb.PlatformConnectorClient
+	nodeName string
+	rng      *rand.Rand
+	cfg      *Config
+	recorder *recorder
+}
+
+// run sends a batch every interval. The first batch is delayed by a random part of
+// the interval so the agents do not send in lockstep. Batches that are due while a
+// request is still running are dropped, which shows as a throughput below the rate.
+func (a *agent) run(ctx context.Context, interval time.Duration) {
+	delay := time.NewTimer(time.Duration(a.rng.Int64N(int64(interval) + 1)))
+	defer delay.Stop()
+
+	select {
+	case <-ctx.Done():
+		return
+	case <-delay.C:
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		a.send(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (a *agent) send(ctx context.Context) {
+	events := &pb.HealthEvents{Version: 1, Events: make([]*pb.HealthEvent, 0, a.cfg.BatchSize)}
+	for range a.cfg.BatchSize {
+		events.Events = append(events.Events, a.event())
+	}
+
+	// requests running at the end of the run complete, so every event the connector
+	// accepted is counted
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), a.cfg.Timeout)
+	defer cancel()
+
+	start := time.Now()
+	_, err := a.client.HealthEventOccurredV1(ctx, events)
+	a.recorder.record(len(events.Events), time.Since(start), err)
+}
+
+func (a *agent) event() *pb.HealthEvent {
+	entry := a.cfg.Mix.pick(a.rng)
+
+	return &pb.HealthEvent{
+		Version:            1,
+		Agent:              entry.Agent,
+		ComponentClass:     entry.ComponentClass,
+		CheckName:          entry.CheckName,
+		IsFatal:            entry.IsFatal,
+		IsHealthy:          entry.IsHealthy,
+		Message:            entry.Message,
+		RecommendedAction:  pb.RecommendedAction(pb.RecommendedAction_value[entry.action()]),
+		ErrorCode:          entry.ErrorCodes,
+		EntitiesImpacted:   []*pb.Entity{{EntityType: "GPU", EntityValue: strconv.Itoa(a.rng.IntN(gpusPerNode))}},
+		Metadata:           map[string]string{MetadataKey: "true"},
+		GeneratedTimestamp: timestamppb.Now(),
+		NodeName:           a.nodeName,
+	}
+}
+
+// summarize computes the latency percentiles with the nearest rank method.
+func summarize(latencies []time.Duration) Latency {
+	if len(latencies) == 0 {
+		return Latency{}
+	}
+
+	sorted := make([]time.Duration, len(latencies))
+	copy(sorted, latencies)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+
+	var total time.Duration
+	for _, latency := range sorted {
+		total += latency
+	}
+
+	percentile := func(p float64) time.Duration {
+		rank := int(math.Ceil(p * float64(len(sorted))))
+		return sorted[max(rank, 1)-1]
+	}
+
+	return Latency{
+		Mean: total / time.Duration(len(sorted)),
+		P50:  percentile(0.5),
+		P90:  percentile(0.9),
+		P99:  percentile(0.99),
+		Max:  sorted[len(sorted)-1],
+	}
+}
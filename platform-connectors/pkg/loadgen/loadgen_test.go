@@ -0,0 +1,229 @@
+// Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package loadgen
+
+import (
+	"context"
+	"math/rand/v2"
+	"net"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	pb "github.com/nvidia/nvsentinel/data-models/pkg/protos"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+type fakeConnector struct {
+	pb.UnimplementedPlatformConnectorServer
+
+	mu     sync.Mutex
+	events []*pb.HealthEvent
+}
+
+func (f *fakeConnector) HealthEventOccurredV1(_ context.Context, events *pb.HealthEvents) (*emptypb.Empty, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.events = append(f.events, events.Events...)
+
+	return &emptypb.Empty{}, nil
+}
+
+func startConnector(t *testing.T) (string, *fakeConnector) {
+	t.Helper()
+
+	dir, err := os.MkdirTemp("", "loadgen")
+	require.NoError(t, err)
+	t.Cleanup(func() { os.RemoveAll(dir) })
+
+	socket := filepath.Join(dir, "nvsentinel.sock")
+
+	listener, err := net.Listen("unix", socket)
+	require.NoError(t, err)
+
+	connector := &fakeConnector{}
+	server := grpc.NewServer()
+	pb.RegisterPlatformConnectorServer(server, connector)
+
+	go func() { _ = server.Serve(listener) }()
+
+	t.Cleanup(server.Stop)
+
+	return socket, connector
+}
+
+func testConfig(socket string) Config {
+	return Config{
+		Socket:      socket,
+		Agents:      20,
+		NodePrefix:  "loadgen",
+		Rate:        400,
+		BatchSize:   2,
+		Connections: 3,
+		Duration:    500 * time.Millisecond,
+		Timeout:     time.Second,
+		Mix:         DefaultMix,
+		Seed:        1,
+	}
+}
+
+func TestRun(t *testing.T) {
+	socket, connector := startConnector(t)
+
+	var progress int
+
+	cfg := testConfig(socket)
+	cfg.ProgressInterval = 100 * time.Millisecond
+	cfg.Progress = func(*Report) { progress++ }
+
+	report, err := Run(context.Background(), cfg)
+	require.NoError(t, err)
+
+	connector.mu.Lock()
+	defer connector.mu.Unlock()
+
+	assert.Zero(t, report.Errors, report.LastError)
+	assert.Equal(t, len(connector.events), report.Events)
+	assert.Equal(t, report.Events, 2*report.Requests)
+	// 400 events/s for half a second, with slack for slow test machines
+	assert.InDelta(t, 200, report.Events, 120)
+	assert.Positive(t, report.Throughput)
+	assert.Equal(t, 400.0, report.TargetRate)
+	assert.Positive(t, report.Latency.Max)
+	assert.LessOrEqual(t, report.Latency.P50, report.Latency.P99)
+	assert.Positive(t, progress)
+
+	nodes := map[string]bool{}
+
+	for _, event := range connector.events {
+		nodes[event.NodeName] = true
+
+		assert.Equal(t, "true", event.Metadata[MetadataKey])
+		assert.NotNil(t, event.GeneratedTimestamp)
+	}
+
+	assert.Len(t, nodes, 20)
+	assert.Contains(t, nodes, "loadgen-19")
+}
+
+func TestRunFirstNode(t *testing.T) {
+	socket, connector := startConnector(t)
+
+	cfg := testConfig(socket)
+	cfg.FirstNode = 100
+	cfg.Duration = 200 * time.Millisecond
+
+	_, err := Run(context.Background(), cfg)
+	require.NoError(t, err)
+
+	connector.mu.Lock()
+	defer connector.mu.Unlock()
+
+	nodes := map[string]bool{}
+	for _, event := range connector.events {
+		nodes[event.NodeName] = true
+	}
+
+	assert.Contains(t, nodes, "loadgen-100")
+	assert.Contains(t, nodes, "loadgen-119")
+	assert.NotContains(t, nodes, "loadgen-0")
+}
+
+func TestRunReportsErrors(t *testing.T) {
+	cfg := testConfig(filepath.Join(t.TempDir(), "missing.sock"))
+	cfg.Duration = 200 * time.Millisecond
+	cfg.Timeout = 50 * time.Millisecond
+
+	report, err := Run(context.Background(), cfg)
+	require.NoError(t, err)
+
+	assert.Zero(t, report.Events)
+	assert.Positive(t, report.Errors)
+	assert.NotEmpty(t, report.LastError)
+}
+
+func TestConfigValidate(t *testing.T) {
+	tests := map[string]func(*Config){
+		"no socket":         func(c *Config) { c.Socket = "" },
+		"no agents":         func(c *Config) { c.Agents = 0 },
+		"negative node":     func(c *Config) { c.FirstNode = -1 },
+		"no rate":           func(c *Config) { c.Rate = 0 },
+		"no timeout":        func(c *Config) { c.Timeout = 0 },
+		"no progress":       func(c *Config) { c.Progress = func(*Report) {} },
+		"empty mix":         func(c *Config) { c.Mix = nil },
+		"zero weight":       func(c *Config) { c.Mix = Mix{{Agent: "a", CheckName: "c"}} },
+		"unknown action":    func(c *Config) { c.Mix = Mix{{Weight: 1, Agent: "a", CheckName: "c", RecommendedAction: "X"}} },
+		"missing checkName": func(c *Config) { c.Mix = Mix{{Weight: 1, Agent: "a"}} },
+	}
+
+	for name, mutate := range tests {
+		t.Run(name, func(t *testing.T) {
+			cfg := testConfig("/tmp/nvsentinel.sock")
+			mutate(&cfg)
+			assert.Error(t, cfg.Validate())
+		})
+	}
+
+	cfg := testConfig("/tmp/nvsentinel.sock")
+	assert.NoError(t, cfg.Validate())
+}
+
+func TestLoadMix(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "mix.json")
+	require.NoError(t, os.WriteFile(path, []byte(`[
+		{"weight": 3, "agent": "syslog-health-monitor", "checkName": "SysLogsXIDError", "errorCodes": ["48"],
+			"isFatal": true, "recommendedAction": "RESTART_BM"},
+		{"weight": 1, "agent": "gpu-health-monitor", "checkName": "GpuMemWatch", "isHealthy": true}]`), 0o600))
+
+	mix, err := LoadMix(path)
+	require.NoError(t, err)
+	require.Len(t, mix, 2)
+	assert.Equal(t, []string{"48"}, mix[0].ErrorCodes)
+	assert.Equal(t, "NONE", mix[1].action())
+
+	rng := rand.New(rand.NewPCG(1, 2))
+	picked := map[string]int{}
+
+	for range 4000 {
+		picked[mix.pick(rng).CheckName]++
+	}
+
+	assert.InDelta(t, 3000, picked["SysLogsXIDError"], 150)
+
+	require.NoError(t, os.WriteFile(path, []byte(`[]`), 0o600))
+	_, err = LoadMix(path)
+	assert.ErrorContains(t, err, "no entries")
+}
+
+func TestSummarize(t *testing.T) {
+	var latencies []time.Duration
+	for i := 100; i >= 1; i-- {
+		latencies = append(latencies, time.Duration(i)*time.Millisecond)
+	}
+
+	latency := summarize(latencies)
+	assert.Equal(t, 50*time.Millisecond, latency.P50)
+	assert.Equal(t, 90*time.Millisecond, latency.P90)
+	assert.Equal(t, 99*time.Millisecond, latency.P99)
+	assert.Equal(t, 100*time.Millisecond, latency.Max)
+	assert.Equal(t, 50500*time.Microsecond, latency.Mean)
+	assert.Equal(t, Latency{}, summarize(nil))
+}
@@ -0,0 +1,135 @@
+// Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package loadgen
+
+import (
+	"encoding/json"
+	"fmt"
+	"math/rand/v2"
+	"os"
+
+	pb "github.com/nvidia/nvsentinel/data-models/pkg/protos"
+)
+
+// MixEntry is an event kind of the mix, published with a probability of its
+// weight over the total weight.
+type MixEntry struct {
+	Weight            int      `json:"weight"`
+	Agent             string   `json:"agent"`
+	CheckName         string   `json:"checkName"`
+	ComponentClass    string   `json:"componentClass"`
+	ErrorCodes        []string `json:"errorCodes"`
+	IsFatal           bool     `json:"isFatal"`
+	IsHealthy         bool     `json:"isHealthy"`
+	RecommendedAction string   `json:"recommendedAction"`
+	Message           string   `json:"message"`
+}
+
+// Mix is the weighted set of events the simulated agents publish.
+type Mix []MixEntry
+
+// DefaultMix resembles a large cluster: mostly recoveries and non-fatal XIDs,
+// some thermal warnings and few fatal XIDs.
+var DefaultMix = Mix{
+	{
+		Weight: 40, Agent: "syslog-health-monitor", CheckName: "SysLogsXIDError", ComponentClass: "GPU",
+		IsHealthy: true, RecommendedAction: "NONE", Message: "No Health Failures",
+	},
+	{
+		Weight: 30, Agent: "syslog-health-monitor", CheckName: "SysLogsXIDError", ComponentClass: "GPU",
+		ErrorCodes: []string{"13"}, RecommendedAction: "NONE",
+		Message: "NVRM: Xid (PCI:0000:b3:00): 13, Graphics Engine Exception",
+	},
+	{
+		Weight: 20, Agent: "gpu-health-monitor", CheckName: "GpuThermalWatch", ComponentClass: "GPU",
+		ErrorCodes: []string{"DCGM_FR_CLOCK_THROTTLE_THERMAL"}, RecommendedAction: "NONE",
+		Message: "GPU is throttled for thermal reasons",
+	},
+	{
+		Weight: 10, Agent: "syslog-health-monitor", CheckName: "SysLogsXIDError", ComponentClass: "GPU",
+		ErrorCodes: []string{"79"}, IsFatal: true, RecommendedAction: "RESTART_BM",
+		Message: "NVRM: Xid (PCI:0000:b3:00): 79, GPU has fallen off the bus",
+	},
+}
+
+// LoadMix reads a JSON array of mix entries.
+func LoadMix(path string) (Mix, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read mix %s: %w", path, err)
+	}
+
+	var mix Mix
+	if err := json.Unmarshal(data, &mix); err != nil {
+		return nil, fmt.Errorf("failed to parse mix %s: %w", path, err)
+	}
+
+	if err := mix.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid mix %s: %w", path, err)
+	}
+
+	return mix, nil
+}
+
+// Validate checks that the mix publishes events.
+func (m Mix) Validate() error {
+	if len(m) == 0 {
+		return fmt.Errorf("mix has no entries")
+	}
+
+	for i, entry := range m {
+		if entry.Weight <= 0 {
+			return fmt.Errorf("entry %d: weight must be positive", i)
+		}
+
+		if entry.Agent == "" || entry.CheckName == "" {
+			return fmt.Errorf("entry %d: agent and checkName are required", i)
+		}
+
+		if _, ok := pb.RecommendedAction_value[entry.action()]; !ok {
+			return fmt.Errorf("entry %d: unknown recommended action %q", i, entry.RecommendedAction)
+		}
+	}
+
+	return nil
+}
+
+// pick returns an entry with a probability of its weight.
+func (m Mix) pick(rng *rand.Rand) *MixEntry {
+	total := 0
+	for _, entry := range m {
+		total += entry.Weight
+	}
+
+	n := rng.IntN(total)
+
+	for i := range m {
+		if n < m[i].Weight {
+			return &m[i]
+		}
+
+		n -= m[i].Weight
+	}
+
+	return &m[len(m)-1]
+}
+
+func (e *MixEntry) action() string {
+	if e.RecommendedAction == "" {
+		return pb.RecommendedAction_NONE.String()
+	}
+
+	return e.RecommendedAction
+}
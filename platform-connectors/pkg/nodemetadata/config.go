@@ -17,6 +17,8 @@ package nodemetadata
 import (
 	"fmt"
 	"time"
+
+	"github.com/nvidia/nvsentinel/commons/pkg/cloudmetadata"
 )
 
 const (
@@ -29,6 +31,15 @@ type Config struct {
 	CacheSize     int           `json:"cacheSize"`
 	CacheTTL      time.Duration `json:"cacheTTL"`
 	AllowedLabels []string      `json:"allowedLabels"`
+	// CloudEnabled adds the cloud instance ID, type, zone and capacity
+	// reservation derived from the node provider ID and labels
+	CloudEnabled bool `json:"cloudEnabled"`
+	// CloudIMDSEnabled prefers the instance metadata service over the node
+	// object for events of the local node
+	CloudIMDSEnabled bool `json:"cloudIMDSEnabled"`
+	// CapacityReservationLabels are the node labels holding the capacity
+	// reservation, the first one present is used
+	CapacityReservationLabels []string `json:"capacityReservationLabels"`
 }
 
 func NewConfigFromMap(cfgMap map[string]interface{}) (*Config, error) {
@@ -37,6 +48,8 @@ func NewConfigFromMap(cfgMap map[string]interface{}) (*Config, error) {
 		CacheSize:     DefaultCacheSize,
 		CacheTTL:      DefaultCacheTTL,
 		AllowedLabels: []string{},
+
+		CapacityReservationLabels: cloudmetadata.DefaultCapacityReservationLabels,
 	}
 
 	if enabled, ok := cfgMap["nodeMetadataAugmentationEnabled"].(string); ok && enabled == "true" {
@@ -52,18 +65,36 @@ func NewConfigFromMap(cfgMap map[string]interface{}) (*Config, error) {
 	}
 
 	if allowedLabels, ok := cfgMap["nodeMetadataAllowedLabels"].([]interface{}); ok {
-		cfg.AllowedLabels = make([]string, 0, len(allowedLabels))
+		cfg.AllowedLabels = toStrings(allowedLabels)
+	}
 
-		for _, label := range allowedLabels {
-			if labelStr, ok := label.(string); ok {
-				cfg.AllowedLabels = append(cfg.AllowedLabels, labelStr)
-			}
-		}
+	if enabled, ok := cfgMap["nodeMetadataCloudEnabled"].(string); ok && enabled == "true" {
+		cfg.CloudEnabled = true
+	}
+
+	if enabled, ok := cfgMap["nodeMetadataCloudIMDSEnabled"].(string); ok && enabled == "true" {
+		cfg.CloudIMDSEnabled = true
+	}
+
+	if labels, ok := cfgMap["nodeMetadataCapacityReservationLabels"].([]interface{}); ok {
+		cfg.CapacityReservationLabels = toStrings(labels)
 	}
 
 	return cfg, nil
 }
 
+func toStrings(values []interface{}) []string {
+	result := make([]string, 0, len(values))
+
+	for _, value := range values {
+		if str, ok := value.(string); ok {
+			result = append(result, str)
+		}
+	}
+
+	return result
+}
+
 func (c *Config) Validate() error {
 	if c.CacheSize <= 0 {
 		return fmt.Errorf("cacheSize must be positive")
@@ -18,6 +18,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/nvidia/nvsentinel/commons/pkg/cloudmetadata"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -35,6 +36,8 @@ func TestNewConfigFromMap(t *testing.T) {
 				assert.False(t, cfg.Enabled)
 				assert.Equal(t, DefaultCacheSize, cfg.CacheSize)
 				assert.Equal(t, DefaultCacheTTL, cfg.CacheTTL)
+				assert.False(t, cfg.CloudEnabled)
+				assert.Equal(t, cloudmetadata.DefaultCapacityReservationLabels, cfg.CapacityReservationLabels)
 			},
 		},
 		{
@@ -95,6 +98,19 @@ func TestNewConfigFromMap(t *testing.T) {
 				assert.Len(t, cfg.AllowedLabels, 2)
 			},
 		},
+		{
+			name: "cloud enrichment",
+			cfgMap: map[string]interface{}{
+				"nodeMetadataCloudEnabled":              "true",
+				"nodeMetadataCloudIMDSEnabled":          "true",
+				"nodeMetadataCapacityReservationLabels": []interface{}{"example.com/reservation"},
+			},
+			validate: func(t *testing.T, cfg *Config) {
+				assert.True(t, cfg.CloudEnabled)
+				assert.True(t, cfg.CloudIMDSEnabled)
+				assert.Equal(t, []string{"example.com/reservation"}, cfg.CapacityReservationLabels)
+			},
+		},
 	}
 
 	for _, tt := range tests {
@@ -18,9 +18,11 @@ import (
 	"context"
 	"fmt"
 	"log/slog"
+	"os"
 	"sync"
 
 	"github.com/hashicorp/golang-lru/v2/expirable"
+	"github.com/nvidia/nvsentinel/commons/pkg/cloudmetadata"
 	pb "github.com/nvidia/nvsentinel/data-models/pkg/protos"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/kubernetes"
@@ -31,6 +33,10 @@ type processor struct {
 	clientset kubernetes.Interface
 	cache     *expirable.LRU[string, *NodeMetadata]
 	fetchMu   sync.Mutex
+
+	// imds looks up the cloud instance of localNodeName, nil if disabled
+	imds          CloudMetadataFetcher
+	localNodeName string
 }
 
 func newKubernetesProcessor(ctx context.Context, config *Config, clientset kubernetes.Interface) (Processor, error) {
@@ -50,10 +56,19 @@ func newKubernetesProcessor(ctx context.Context, config *Config, clientset kuber
 		cache:     cache,
 	}
 
+	// The platform connector runs on every node and only the local instance
+	// metadata service is reachable
+	if config.CloudEnabled && config.CloudIMDSEnabled {
+		p.imds = cloudmetadata.NewClient(cloudmetadata.DefaultTimeout)
+		p.localNodeName = os.Getenv("NODE_NAME")
+	}
+
 	slog.Info("Node metadata processor initialized",
 		"cacheSize", config.CacheSize,
 		"cacheTTL", config.CacheTTL,
-		"allowedLabels", config.AllowedLabels)
+		"allowedLabels", config.AllowedLabels,
+		"cloudEnabled", config.CloudEnabled,
+		"cloudIMDSEnabled", p.imds != nil)
 
 	return p, nil
 }
@@ -82,6 +97,10 @@ func (p *processor) AugmentHealthEvent(ctx context.Context, event *pb.HealthEven
 		}
 	}
 
+	if metadata.Cloud != nil {
+		metadata.Cloud.AddMetadata(event.Metadata)
+	}
+
 	slog.Info("Node metadata enriched successfully",
 		"nodeName", event.NodeName,
 		"providerID", metadata.ProviderID,
@@ -142,5 +161,36 @@ func (p *processor) fetchNodeMetadata(ctx context.Context, nodeName string) (*No
 		}
 	}
 
+	if p.config.CloudEnabled {
+		metadata.Cloud = p.cloudInstance(ctx, nodeName, node.Spec.ProviderID, node.Labels)
+	}
+
 	return metadata, nil
 }
+
+// cloudInstance derives the cloud instance from the node, overridden by the
+// instance metadata service for the local node. Capacity reservations are only
+// known from the node labels.
+func (p *processor) cloudInstance(
+	ctx context.Context,
+	nodeName, providerID string,
+	labels map[string]string,
+) *cloudmetadata.Instance {
+	fromNode := cloudmetadata.FromNode(providerID, labels, p.config.CapacityReservationLabels)
+
+	if p.imds == nil || nodeName != p.localNodeName {
+		return fromNode
+	}
+
+	instance, err := p.imds.Fetch(ctx)
+	if err != nil {
+		slog.Warn("Failed to get cloud instance metadata, falling back to node provider ID",
+			"nodeName", nodeName, "error", err)
+
+		return fromNode
+	}
+
+	instance.Merge(fromNode)
+
+	return instance
+}
@@ -23,6 +23,7 @@ import (
 	"time"
 
 	"github.com/hashicorp/golang-lru/v2/expirable"
+	"github.com/nvidia/nvsentinel/commons/pkg/cloudmetadata"
 	pb "github.com/nvidia/nvsentinel/data-models/pkg/protos"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -454,6 +455,98 @@ func TestProcessorConcurrentAugmentations(t *testing.T) {
 	wg.Wait()
 }
 
+type fakeCloudMetadataFetcher struct {
+	instance *cloudmetadata.Instance
+	err      error
+}
+
+func (f *fakeCloudMetadataFetcher) Fetch(context.Context) (*cloudmetadata.Instance, error) {
+	return f.instance, f.err
+}
+
+func TestProcessorCloudEnrichment(t *testing.T) {
+	node := &corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "cloud-test-node",
+			Labels: map[string]string{
+				cloudmetadata.LabelInstanceType:             "p5.48xlarge",
+				"karpenter.k8s.aws/capacity-reservation-id": "cr-0123",
+			},
+		},
+		Spec: corev1.NodeSpec{ProviderID: "aws:///us-east-1a/i-node"},
+	}
+
+	createTestNode(t, node)
+	defer deleteTestNode(t, node.Name)
+
+	config := &Config{
+		Enabled:                   true,
+		CacheSize:                 100,
+		CacheTTL:                  1 * time.Hour,
+		CloudEnabled:              true,
+		CapacityReservationLabels: cloudmetadata.DefaultCapacityReservationLabels,
+	}
+
+	tests := []struct {
+		name          string
+		imds          CloudMetadataFetcher
+		localNodeName string
+		expected      map[string]string
+	}{
+		{
+			name: "from node",
+			expected: map[string]string{
+				cloudmetadata.MetadataKeyProvider:              "aws",
+				cloudmetadata.MetadataKeyInstanceID:            "i-node",
+				cloudmetadata.MetadataKeyInstanceType:          "p5.48xlarge",
+				cloudmetadata.MetadataKeyZone:                  "us-east-1a",
+				cloudmetadata.MetadataKeyCapacityReservationID: "cr-0123",
+			},
+		},
+		{
+			name: "imds of local node",
+			imds: &fakeCloudMetadataFetcher{instance: &cloudmetadata.Instance{
+				Provider: cloudmetadata.ProviderAWS, InstanceID: "i-imds", Zone: "us-east-1b",
+			}},
+			localNodeName: "cloud-test-node",
+			expected: map[string]string{
+				cloudmetadata.MetadataKeyProvider:              "aws",
+				cloudmetadata.MetadataKeyInstanceID:            "i-imds",
+				cloudmetadata.MetadataKeyInstanceType:          "p5.48xlarge",
+				cloudmetadata.MetadataKeyZone:                  "us-east-1b",
+				cloudmetadata.MetadataKeyCapacityReservationID: "cr-0123",
+			},
+		},
+		{
+			name:          "imds failure falls back to node",
+			imds:          &fakeCloudMetadataFetcher{err: cloudmetadata.ErrNoMetadataService},
+			localNodeName: "cloud-test-node",
+			expected: map[string]string{
+				cloudmetadata.MetadataKeyProvider:              "aws",
+				cloudmetadata.MetadataKeyInstanceID:            "i-node",
+				cloudmetadata.MetadataKeyInstanceType:          "p5.48xlarge",
+				cloudmetadata.MetadataKeyZone:                  "us-east-1a",
+				cloudmetadata.MetadataKeyCapacityReservationID: "cr-0123",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := createTestProcessor(config)
+			p.imds = tt.imds
+			p.localNodeName = tt.localNodeName
+
+			event := &pb.HealthEvent{NodeName: node.Name}
+			require.NoError(t, p.AugmentHealthEvent(context.Background(), event))
+
+			for key, value := range tt.expected {
+				assert.Equal(t, value, event.Metadata[key], key)
+			}
+		})
+	}
+}
+
 func TestNewProcessorValidation(t *testing.T) {
 	tests := []struct {
 		name        string
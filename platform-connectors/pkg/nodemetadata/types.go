@@ -19,6 +19,7 @@ package nodemetadata
 import (
 	"context"
 
+	"github.com/nvidia/nvsentinel/commons/pkg/cloudmetadata"
 	pb "github.com/nvidia/nvsentinel/data-models/pkg/protos"
 )
 
@@ -29,4 +30,11 @@ type Processor interface {
 type NodeMetadata struct {
 	ProviderID string
 	Labels     map[string]string
+	// Cloud is set if cloud enrichment is enabled and the node runs on a known cloud
+	Cloud *cloudmetadata.Instance
+}
+
+// CloudMetadataFetcher looks up the cloud instance of the local node.
+type CloudMetadataFetcher interface {
+	Fetch(ctx context.Context) (*cloudmetadata.Instance, error)
 }
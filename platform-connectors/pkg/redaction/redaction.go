@@ -0,0 +1,144 @@
+// Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package redaction scrubs sensitive text, such as tokens, user names or paths that
+// kernel logs sometimes carry, from health events before they leave the node.
+package redaction
+
+import (
+	"fmt"
+	"regexp"
+
+	pb "github.com/nvidia/nvsentinel/data-models/pkg/protos"
+)
+
+// DefaultReplacement replaces the matches of rules without a replacement
+const DefaultReplacement = "[REDACTED]"
+
+// Rule replaces the matches of a regular expression.
+type Rule struct {
+	// Name identifies the rule in the redaction metrics
+	Name string
+	// Pattern is the RE2 regular expression to redact
+	Pattern string
+	// Replacement replaces each match and may refer to submatches as in
+	// regexp.Regexp.ReplaceAllString, e.g. "token=[REDACTED]" or "$1[REDACTED]"
+	Replacement string
+}
+
+// NewRulesFromMap returns the rules of the redactionRules list of the config map.
+func NewRulesFromMap(cfgMap map[string]interface{}) ([]Rule, error) {
+	entries, ok := cfgMap["redactionRules"].([]interface{})
+	if !ok {
+		return nil, nil
+	}
+
+	rules := make([]Rule, 0, len(entries))
+
+	for i, entry := range entries {
+		fields, ok := entry.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("redaction rule %d is not an object", i)
+		}
+
+		rule := Rule{Replacement: DefaultReplacement}
+		rule.Name, _ = fields["name"].(string)
+		rule.Pattern, _ = fields["pattern"].(string)
+
+		if replacement, ok := fields["replacement"].(string); ok {
+			rule.Replacement = replacement
+		}
+
+		if rule.Name == "" {
+			rule.Name = fmt.Sprintf("rule-%d", i)
+		}
+
+		rules = append(rules, rule)
+	}
+
+	return rules, nil
+}
+
+type compiledRule struct {
+	name        string
+	pattern     *regexp.Regexp
+	replacement string
+}
+
+// Redactor applies redaction rules to health events.
+type Redactor struct {
+	rules []compiledRule
+}
+
+// NewRedactor compiles the rules. It returns nil without rules, which redacts nothing.
+func NewRedactor(rules []Rule) (*Redactor, error) {
+	if len(rules) == 0 {
+		return nil, nil
+	}
+
+	redactor := &Redactor{rules: make([]compiledRule, 0, len(rules))}
+
+	for _, rule := range rules {
+		if rule.Pattern == "" {
+			return nil, fmt.Errorf("redaction rule %s has no pattern", rule.Name)
+		}
+
+		pattern, err := regexp.Compile(rule.Pattern)
+		if err != nil {
+			return nil, fmt.Errorf("invalid pattern of redaction rule %s: %w", rule.Name, err)
+		}
+
+		redactor.rules = append(redactor.rules, compiledRule{
+			name:        rule.Name,
+			pattern:     pattern,
+			replacement: rule.Replacement,
+		})
+	}
+
+	return redactor, nil
+}
+
+// Redact scrubs the message and the metadata values of the event, which hold the
+// raw log lines the monitors attach, in place. A nil Redactor redacts nothing.
+func (r *Redactor) Redact(event *pb.HealthEvent) {
+	if r == nil || event == nil {
+		return
+	}
+
+	event.Message = r.redact(event.Message, "message")
+
+	for key, value := range event.Metadata {
+		event.Metadata[key] = r.redact(value, "metadata")
+	}
+}
+
+// redact applies the rules in order to the text of the field
+func (r *Redactor) redact(text, field string) string {
+	if text == "" {
+		return text
+	}
+
+	for _, rule := range r.rules {
+		matches := rule.pattern.FindAllStringIndex(text, -1)
+		if len(matches) == 0 {
+			continue
+		}
+
+		redactionsApplied.WithLabelValues(rule.name, field).Add(float64(len(matches)))
+
+		text = rule.pattern.ReplaceAllString(text, rule.replacement)
+	}
+
+	return text
+}
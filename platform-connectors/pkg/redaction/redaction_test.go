@@ -0,0 +1,91 @@
+// Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package redaction
+
+import (
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	pb "github.com/nvidia/nvsentinel/data-models/pkg/protos"
+)
+
+func TestRedact(t *testing.T) {
+	redactor, err := NewRedactor([]Rule{
+		{Name: "token", Pattern: `(token=)\S+`, Replacement: "${1}[REDACTED]"},
+		{Name: "home", Pattern: `/home/[^/\s]+`, Replacement: "/home/[REDACTED]"},
+		{Name: "user", Pattern: `user \w+`, Replacement: DefaultReplacement},
+	})
+	require.NoError(t, err)
+
+	event := &pb.HealthEvent{
+		Message: "NVRM: Xid 13, pid=42, name=python /home/alice/train.py token=abc123 token=def456",
+		Metadata: map[string]string{
+			"line":     "audit: user bob opened /home/bob/.ssh/id_rsa",
+			"rulepack": "default",
+		},
+	}
+
+	before := testutil.ToFloat64(redactionsApplied.WithLabelValues("token", "message"))
+
+	redactor.Redact(event)
+
+	assert.Equal(t, "NVRM: Xid 13, pid=42, name=python /home/[REDACTED]/train.py token=[REDACTED] token=[REDACTED]",
+		event.Message)
+	assert.Equal(t, "audit: [REDACTED] opened /home/[REDACTED]/.ssh/id_rsa", event.Metadata["line"])
+	assert.Equal(t, "default", event.Metadata["rulepack"])
+	assert.Equal(t, float64(2), testutil.ToFloat64(redactionsApplied.WithLabelValues("token", "message"))-before)
+}
+
+func TestNilRedactor(t *testing.T) {
+	redactor, err := NewRedactor(nil)
+	require.NoError(t, err)
+	assert.Nil(t, redactor)
+
+	event := &pb.HealthEvent{Message: "token=abc123"}
+	redactor.Redact(event)
+	assert.Equal(t, "token=abc123", event.Message)
+}
+
+func TestNewRedactorInvalidRule(t *testing.T) {
+	_, err := NewRedactor([]Rule{{Name: "broken", Pattern: `(unclosed`}})
+	require.ErrorContains(t, err, "invalid pattern of redaction rule broken")
+
+	_, err = NewRedactor([]Rule{{Name: "empty"}})
+	require.ErrorContains(t, err, "redaction rule empty has no pattern")
+}
+
+func TestNewRulesFromMap(t *testing.T) {
+	rules, err := NewRulesFromMap(map[string]interface{}{
+		"redactionRules": []interface{}{
+			map[string]interface{}{"name": "token", "pattern": `token=\S+`, "replacement": "token=***"},
+			map[string]interface{}{"pattern": `/home/\w+`},
+		},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []Rule{
+		{Name: "token", Pattern: `token=\S+`, Replacement: "token=***"},
+		{Name: "rule-1", Pattern: `/home/\w+`, Replacement: DefaultReplacement},
+	}, rules)
+
+	rules, err = NewRulesFromMap(map[string]interface{}{})
+	require.NoError(t, err)
+	assert.Empty(t, rules)
+
+	_, err = NewRulesFromMap(map[string]interface{}{"redactionRules": []interface{}{"token=.*"}})
+	require.Error(t, err)
+}
@@ -18,67 +18,243 @@ import (
 	"context"
 	"errors"
 	"log/slog"
+	"slices"
+	"sync"
 
 	"github.com/nvidia/nvsentinel/data-models/pkg/protos"
 
 	"k8s.io/client-go/util/workqueue"
 )
 
+// RingBuffer queues health events for a connector. Events marked with
+// PRIORITY_HIGH go to a separate lane that is always drained first, so
+// actionable faults are not delayed behind backlogged informational traffic of
+// other nodes. The events of a node keep their order: a high priority batch
+// waits in the normal lane behind the batches of its nodes queued before it, so
+// an earlier healthy event cannot clear the condition it sets.
 type RingBuffer struct {
 	ringBufferIdentifier string
 	healthMetricQueue    workqueue.TypedRateLimitingInterface[*protos.HealthEvents]
+	highPriorityQueue    workqueue.TypedRateLimitingInterface[*protos.HealthEvents]
+	notify               chan struct{}
+	shutdown             chan struct{}
+	shutdownOnce         sync.Once
 	ctx                  context.Context
+	// deadLetters receives the events connectors fail to process, nil drops them
+	deadLetters DeadLetterQueue
+
+	mu sync.Mutex
+	// queuedPerNode counts the batches of every node waiting in the normal lane
+	queuedPerNode map[string]int
+	// demoted holds the high priority batches queued in the normal lane
+	demoted map[*protos.HealthEvents]bool
+}
+
+// DeadLetterQueue keeps events that could not be processed.
+type DeadLetterQueue interface {
+	Add(connector, reason string, err error, healthEvents *protos.HealthEvents)
 }
 
+// ReasonProcessing is the dead letter reason of events a connector failed to process.
+const ReasonProcessing = "processing"
+
 func NewRingBuffer(ringBufferName string, ctx context.Context) *RingBuffer {
 	workqueue.SetProvider(prometheusMetricsProvider{})
-	queue := workqueue.NewTypedRateLimitingQueueWithConfig(
+
+	return &RingBuffer{
+		ringBufferIdentifier: ringBufferName,
+		healthMetricQueue:    newQueue(ringBufferName),
+		highPriorityQueue:    newQueue(ringBufferName + "_high_priority"),
+		notify:               make(chan struct{}, 1),
+		shutdown:             make(chan struct{}),
+		ctx:                  ctx,
+		queuedPerNode:        make(map[string]int),
+		demoted:              make(map[*protos.HealthEvents]bool),
+	}
+}
+
+func newQueue(name string) workqueue.TypedRateLimitingInterface[*protos.HealthEvents] {
+	return workqueue.NewTypedRateLimitingQueueWithConfig(
 		workqueue.DefaultTypedControllerRateLimiter[*protos.HealthEvents](),
 		workqueue.TypedRateLimitingQueueConfig[*protos.HealthEvents]{
-			Name: ringBufferName,
+			Name: name,
 		},
 	)
+}
 
-	return &RingBuffer{
-		ringBufferIdentifier: ringBufferName,
-		healthMetricQueue:    queue,
-		ctx:                  ctx,
+// IsHighPriority reports whether any event in data is marked PRIORITY_HIGH.
+func IsHighPriority(data *protos.HealthEvents) bool {
+	for _, event := range data.GetEvents() {
+		if event.GetPriority() == protos.Priority_PRIORITY_HIGH {
+			return true
+		}
 	}
+
+	return false
+}
+
+// batchNodes returns the distinct nodes of the events of data.
+func batchNodes(data *protos.HealthEvents) []string {
+	var nodes []string
+
+	for _, event := range data.GetEvents() {
+		if !slices.Contains(nodes, event.GetNodeName()) {
+			nodes = append(nodes, event.GetNodeName())
+		}
+	}
+
+	return nodes
+}
+
+func (rb *RingBuffer) queueFor(data *protos.HealthEvents) workqueue.TypedRateLimitingInterface[*protos.HealthEvents] {
+	rb.mu.Lock()
+	defer rb.mu.Unlock()
+
+	if IsHighPriority(data) && !rb.demoted[data] {
+		return rb.highPriorityQueue
+	}
+
+	return rb.healthMetricQueue
 }
 
 func (rb *RingBuffer) Enqueue(data *protos.HealthEvents) {
-	rb.healthMetricQueue.Add(data)
+	rb.mu.Lock()
+
+	nodes := batchNodes(data)
+	queue := rb.highPriorityQueue
+
+	if !IsHighPriority(data) || slices.ContainsFunc(nodes, func(node string) bool { return rb.queuedPerNode[node] > 0 }) {
+		queue = rb.healthMetricQueue
+
+		for _, node := range nodes {
+			rb.queuedPerNode[node]++
+		}
+
+		if IsHighPriority(data) {
+			rb.demoted[data] = true
+		}
+	}
+
+	rb.mu.Unlock()
+
+	queue.Add(data)
+
+	select {
+	case rb.notify <- struct{}{}:
+	default:
+	}
 }
 
-func (rb *RingBuffer) Dequeue() *protos.HealthEvents {
-	healthEvents, quit := rb.healthMetricQueue.Get()
-	if quit {
-		slog.Info("quitting from queue processing")
-		return nil
+// dequeued records that data left the normal lane.
+func (rb *RingBuffer) dequeued(data *protos.HealthEvents) {
+	rb.mu.Lock()
+	defer rb.mu.Unlock()
+
+	for _, node := range batchNodes(data) {
+		if rb.queuedPerNode[node]--; rb.queuedPerNode[node] <= 0 {
+			delete(rb.queuedPerNode, node)
+		}
 	}
+}
 
-	slog.Info("Successfully got item", "healthEvents", healthEvents)
+// forget drops the lane recorded for data once it is processed.
+func (rb *RingBuffer) forget(data *protos.HealthEvents) {
+	rb.mu.Lock()
+	defer rb.mu.Unlock()
 
-	if errors.Is(rb.ctx.Err(), context.Canceled) {
-		slog.Info("Processing cancelled")
-		return nil
+	delete(rb.demoted, data)
+}
+
+func (rb *RingBuffer) Dequeue() *protos.HealthEvents {
+	for {
+		queue := rb.nextQueue()
+		if queue == nil {
+			select {
+			case <-rb.notify:
+				continue
+			case <-rb.shutdown:
+				slog.Info("quitting from queue processing")
+				return nil
+			case <-rb.ctx.Done():
+				slog.Info("Processing cancelled")
+				return nil
+			}
+		}
+
+		healthEvents, quit := queue.Get()
+		if quit {
+			slog.Info("quitting from queue processing")
+			return nil
+		}
+
+		if queue == rb.healthMetricQueue {
+			rb.dequeued(healthEvents)
+		}
+
+		slog.Info("Successfully got item", "healthEvents", healthEvents)
+
+		if errors.Is(rb.ctx.Err(), context.Canceled) {
+			slog.Info("Processing cancelled")
+			return nil
+		}
+
+		return healthEvents
 	}
+}
 
-	return healthEvents
+// nextQueue returns the lane to take the next item from, high priority first,
+// or nil when both lanes are empty. The high priority lane only holds batches of
+// nodes without older batches queued, so taking it first keeps the order of
+// every node.
+func (rb *RingBuffer) nextQueue() workqueue.TypedRateLimitingInterface[*protos.HealthEvents] {
+	switch {
+	case rb.highPriorityQueue.Len() > 0:
+		return rb.highPriorityQueue
+	case rb.healthMetricQueue.Len() > 0:
+		return rb.healthMetricQueue
+	default:
+		return nil
+	}
 }
 
 func (rb *RingBuffer) HealthMetricEleProcessingCompleted(data *protos.HealthEvents) {
-	rb.healthMetricQueue.Done(data)
+	rb.queueFor(data).Done(data)
+	rb.forget(data)
 }
 
 func (rb *RingBuffer) HealthMetricEleProcessingFailed(data *protos.HealthEvents) {
-	rb.healthMetricQueue.Forget(data)
+	rb.queueFor(data).Forget(data)
+	rb.forget(data)
+}
+
+// SetDeadLetterQueue makes DeadLetter hand the events to queue.
+func (rb *RingBuffer) SetDeadLetterQueue(queue DeadLetterQueue) {
+	rb.deadLetters = queue
+}
+
+// DeadLetter marks the processing of data as failed and moves it to the dead
+// letter queue, if one is set, with the error that made it fail.
+func (rb *RingBuffer) DeadLetter(data *protos.HealthEvents, err error) {
+	rb.HealthMetricEleProcessingFailed(data)
+
+	if rb.deadLetters != nil {
+		rb.deadLetters.Add(rb.ringBufferIdentifier, ReasonProcessing, err, data)
+	}
+}
+
+// Name returns the name the ring buffer was created with.
+func (rb *RingBuffer) Name() string {
+	return rb.ringBufferIdentifier
 }
 
 func (rb *RingBuffer) ShutDownHealthMetricQueue() {
-	rb.healthMetricQueue.ShutDown()
+	rb.shutdownOnce.Do(func() {
+		rb.healthMetricQueue.ShutDown()
+		rb.highPriorityQueue.ShutDown()
+		close(rb.shutdown)
+	})
 }
 
 func (rb *RingBuffer) CurrentLength() int {
-	return rb.healthMetricQueue.Len()
+	return rb.healthMetricQueue.Len() + rb.highPriorityQueue.Len()
 }
@@ -218,3 +218,122 @@ func TestRingBuffer_CurrentLength(t *testing.T) {
 		t.Errorf("Expected length 3 after adding 3 events, got %d", ringBuffer.CurrentLength())
 	}
 }
+
+func TestRingBuffer_HighPriorityBypassesBacklog(t *testing.T) {
+	ringBuffer := NewRingBuffer("testPriority", context.Background())
+
+	for i := 0; i < 3; i++ {
+		ringBuffer.Enqueue(&protos.HealthEvents{Version: 1, Events: []*protos.HealthEvent{
+			{NodeName: "node-a", CheckName: "InfoCheck", IsHealthy: true},
+		}})
+	}
+
+	ringBuffer.Enqueue(&protos.HealthEvents{Version: 1, Events: []*protos.HealthEvent{
+		{NodeName: "node-b", CheckName: "FatalCheck", IsFatal: true, Priority: protos.Priority_PRIORITY_HIGH},
+	}})
+
+	if ringBuffer.CurrentLength() != 4 {
+		t.Fatalf("Expected length 4, got %d", ringBuffer.CurrentLength())
+	}
+
+	first := ringBuffer.Dequeue()
+	if first.Events[0].CheckName != "FatalCheck" {
+		t.Errorf("Expected high priority event first, got %s", first.Events[0].CheckName)
+	}
+
+	ringBuffer.HealthMetricEleProcessingCompleted(first)
+
+	for i := 0; i < 3; i++ {
+		item := ringBuffer.Dequeue()
+		if item.Events[0].CheckName != "InfoCheck" {
+			t.Errorf("Expected InfoCheck, got %s", item.Events[0].CheckName)
+		}
+
+		ringBuffer.HealthMetricEleProcessingCompleted(item)
+	}
+
+	if ringBuffer.CurrentLength() != 0 {
+		t.Errorf("Expected empty ring buffer, got %d", ringBuffer.CurrentLength())
+	}
+}
+
+func TestRingBuffer_HighPriorityKeepsNodeOrder(t *testing.T) {
+	ringBuffer := NewRingBuffer("testNodeOrder", context.Background())
+
+	ringBuffer.Enqueue(&protos.HealthEvents{Version: 1, Events: []*protos.HealthEvent{
+		{NodeName: "node-a", CheckName: "GpuXidError", IsHealthy: true},
+	}})
+	ringBuffer.Enqueue(&protos.HealthEvents{Version: 1, Events: []*protos.HealthEvent{
+		{NodeName: "node-a", CheckName: "GpuXidError", IsFatal: true, Priority: protos.Priority_PRIORITY_HIGH},
+	}})
+	ringBuffer.Enqueue(&protos.HealthEvents{Version: 1, Events: []*protos.HealthEvent{
+		{NodeName: "node-b", CheckName: "GpuXidError", IsFatal: true, Priority: protos.Priority_PRIORITY_HIGH},
+	}})
+
+	expected := []struct {
+		node    string
+		healthy bool
+	}{
+		{"node-b", false},
+		{"node-a", true},
+		{"node-a", false},
+	}
+
+	for i, want := range expected {
+		item := ringBuffer.Dequeue()
+		event := item.Events[0]
+
+		if event.NodeName != want.node || event.IsHealthy != want.healthy {
+			t.Errorf("item %d: expected %s healthy=%t, got %s healthy=%t",
+				i, want.node, want.healthy, event.NodeName, event.IsHealthy)
+		}
+
+		ringBuffer.HealthMetricEleProcessingCompleted(item)
+	}
+
+	if ringBuffer.CurrentLength() != 0 {
+		t.Errorf("Expected empty ring buffer, got %d", ringBuffer.CurrentLength())
+	}
+
+	ringBuffer.Enqueue(&protos.HealthEvents{Version: 1, Events: []*protos.HealthEvent{
+		{NodeName: "node-a", CheckName: "GpuXidError", IsFatal: true, Priority: protos.Priority_PRIORITY_HIGH},
+	}})
+
+	if ringBuffer.highPriorityQueue.Len() != 1 {
+		t.Errorf("Expected the fast lane once the earlier batches of the node are dequeued")
+	}
+}
+
+func TestRingBuffer_DequeueBlocksUntilEnqueue(t *testing.T) {
+	ringBuffer := NewRingBuffer("testBlockingDequeue", context.Background())
+
+	result := make(chan *protos.HealthEvents)
+	go func() { result <- ringBuffer.Dequeue() }()
+
+	time.Sleep(50 * time.Millisecond)
+	ringBuffer.Enqueue(&protos.HealthEvents{Version: 1, Events: []*protos.HealthEvent{
+		{CheckName: "FatalCheck", Priority: protos.Priority_PRIORITY_HIGH},
+	}})
+
+	select {
+	case item := <-result:
+		if item == nil || item.Events[0].CheckName != "FatalCheck" {
+			t.Errorf("Expected FatalCheck, got %v", item)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("Dequeue did not return after Enqueue")
+	}
+
+	go func() { result <- ringBuffer.Dequeue() }()
+
+	ringBuffer.ShutDownHealthMetricQueue()
+
+	select {
+	case item := <-result:
+		if item != nil {
+			t.Errorf("Expected nil after shutdown, got %v", item)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("Dequeue did not return after shutdown")
+	}
+}
@@ -0,0 +1,178 @@
+// Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package schemaregistry registers the HealthEvent schema with a Confluent
+// compatible schema registry and encodes events in the registry wire format, so
+// that Kafka consumers can decode them with the stock registry deserializers.
+// Apicurio Registry is supported through its Confluent compatible API
+// (https://<host>/apis/ccompat/v7).
+package schemaregistry
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+const (
+	contentType = "application/vnd.schemaregistry.v1+json"
+
+	// errorCodeSubjectNotFound is returned by the registry for unknown subjects
+	errorCodeSubjectNotFound = 40401
+)
+
+// ErrIncompatible is returned when the registry rejects a schema as an
+// incompatible evolution of the latest registered version.
+var ErrIncompatible = errors.New("schema is incompatible with the latest registered version")
+
+// Config configures the registry client.
+type Config struct {
+	// URL of the registry, e.g. http://schema-registry:8081
+	URL string `json:"url"`
+	// Optional basic auth credentials
+	Username string `json:"username,omitempty"`
+	Password string `json:"password,omitempty"`
+	// Timeout of registry requests
+	Timeout time.Duration `json:"timeout,omitempty"`
+}
+
+// Client talks to the Confluent schema registry REST API.
+type Client struct {
+	config     Config
+	httpClient *http.Client
+}
+
+// NewClient creates a registry client.
+func NewClient(config Config) (*Client, error) {
+	if _, err := url.ParseRequestURI(config.URL); err != nil {
+		return nil, fmt.Errorf("invalid schema registry URL %q: %w", config.URL, err)
+	}
+
+	if config.Timeout <= 0 {
+		config.Timeout = 10 * time.Second
+	}
+
+	return &Client{config: config, httpClient: &http.Client{Timeout: config.Timeout}}, nil
+}
+
+type schemaRequest struct {
+	Schema     string `json:"schema"`
+	SchemaType string `json:"schemaType,omitempty"`
+}
+
+type registryError struct {
+	ErrorCode int    `json:"error_code"`
+	Message   string `json:"message"`
+}
+
+// CheckCompatibility reports an error wrapping ErrIncompatible if the schema
+// cannot be registered as the next version of subject. A subject without any
+// version accepts every schema.
+func (c *Client) CheckCompatibility(ctx context.Context, subject, schemaType, schema string) error {
+	var resp struct {
+		IsCompatible bool     `json:"is_compatible"`
+		Messages     []string `json:"messages"`
+	}
+
+	path := "/compatibility/subjects/" + url.PathEscape(subject) + "/versions/latest"
+
+	err := c.post(ctx, path, schemaRequest{Schema: schema, SchemaType: schemaType}, &resp)
+
+	var regErr *registryError
+	if errors.As(err, &regErr) && regErr.ErrorCode == errorCodeSubjectNotFound {
+		return nil
+	}
+
+	if err != nil {
+		return fmt.Errorf("failed to check compatibility of subject %s: %w", subject, err)
+	}
+
+	if !resp.IsCompatible {
+		return fmt.Errorf("subject %s: %w: %v", subject, ErrIncompatible, resp.Messages)
+	}
+
+	return nil
+}
+
+// Register registers the schema under subject and returns its global ID. The
+// registry returns the existing ID if the schema is already registered.
+func (c *Client) Register(ctx context.Context, subject, schemaType, schema string) (int, error) {
+	var resp struct {
+		ID int `json:"id"`
+	}
+
+	path := "/subjects/" + url.PathEscape(subject) + "/versions"
+
+	if err := c.post(ctx, path, schemaRequest{Schema: schema, SchemaType: schemaType}, &resp); err != nil {
+		return 0, fmt.Errorf("failed to register schema for subject %s: %w", subject, err)
+	}
+
+	return resp.ID, nil
+}
+
+func (c *Client) post(ctx context.Context, path string, body, out any) error {
+	data, err := json.Marshal(body)
+	if err != nil {
+		return fmt.Errorf("failed to marshal request: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.config.URL+path, bytes.NewReader(data))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+
+	req.Header.Set("Content-Type", contentType)
+	req.Header.Set("Accept", contentType)
+
+	if c.config.Username != "" {
+		req.SetBasicAuth(c.config.Username, c.config.Password)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("request to %s failed: %w", path, err)
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("failed to read response: %w", err)
+	}
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		regErr := &registryError{}
+		if json.Unmarshal(respBody, regErr) != nil || regErr.ErrorCode == 0 {
+			regErr.ErrorCode = resp.StatusCode
+			regErr.Message = string(respBody)
+		}
+
+		return regErr
+	}
+
+	if err := json.Unmarshal(respBody, out); err != nil {
+		return fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	return nil
+}
+
+func (e *registryError) Error() string {
+	return fmt.Sprintf("schema registry error %d: %s", e.ErrorCode, e.Message)
+}
@@ -0,0 +1,142 @@
+// Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package schemaregistry
+
+import (
+	"context"
+	"encoding/binary"
+	"fmt"
+	"log/slog"
+	"sync"
+
+	"google.golang.org/protobuf/encoding/protojson"
+
+	"github.com/nvidia/nvsentinel/data-models/pkg/protos"
+	"github.com/nvidia/nvsentinel/data-models/pkg/schema"
+)
+
+// Format is the encoding of published events.
+type Format string
+
+const (
+	// FormatAvro encodes events in Avro binary encoding.
+	FormatAvro Format = "avro"
+	// FormatJSON encodes events as protojson validated by the JSON-Schema.
+	FormatJSON Format = "json"
+
+	// magicByte starts every message in the registry wire format
+	magicByte = 0
+)
+
+// Serializer encodes health events in the registry wire format: a magic byte,
+// the 4 byte big-endian schema ID and the encoded event. The schema is checked
+// for compatibility and registered on first use, so a publisher running an
+// incompatible schema fails before producing any message.
+type Serializer struct {
+	client  *Client
+	subject string
+	format  Format
+
+	mu       sync.Mutex
+	schemaID int
+}
+
+// NewSerializer creates a serializer registering the HealthEvent schema under
+// the value subject of topic (TopicNameStrategy).
+func NewSerializer(client *Client, topic string, format Format) (*Serializer, error) {
+	if format != FormatAvro && format != FormatJSON {
+		return nil, fmt.Errorf("unsupported schema registry format %q", format)
+	}
+
+	return &Serializer{client: client, subject: topic + "-value", format: format}, nil
+}
+
+// Serialize encodes the event, registering the schema if needed.
+func (s *Serializer) Serialize(ctx context.Context, event *protos.HealthEvent) ([]byte, error) {
+	schemaID, err := s.ensureSchema(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	buf := binary.BigEndian.AppendUint32([]byte{magicByte}, uint32(schemaID)) //nolint:gosec // registry IDs are positive int32
+
+	switch s.format {
+	case FormatAvro:
+		payload, err := schema.EncodeAvro(event)
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode event as avro: %w", err)
+		}
+
+		return append(buf, payload...), nil
+	default:
+		payload, err := protojson.Marshal(event)
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode event as json: %w", err)
+		}
+
+		return append(buf, payload...), nil
+	}
+}
+
+// ensureSchema returns the ID of the registered schema, checking compatibility
+// and registering it on the first call. Failures are retried on the next call.
+func (s *Serializer) ensureSchema(ctx context.Context) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.schemaID != 0 {
+		return s.schemaID, nil
+	}
+
+	schemaType, definition, err := s.definition()
+	if err != nil {
+		return 0, err
+	}
+
+	if err := s.client.CheckCompatibility(ctx, s.subject, schemaType, definition); err != nil {
+		return 0, err
+	}
+
+	id, err := s.client.Register(ctx, s.subject, schemaType, definition)
+	if err != nil {
+		return 0, err
+	}
+
+	slog.Info("Registered health event schema", "subject", s.subject, "format", s.format, "schemaID", id)
+
+	s.schemaID = id
+
+	return id, nil
+}
+
+// definition returns the registry schema type and the schema of the format.
+func (s *Serializer) definition() (string, string, error) {
+	if s.format == FormatAvro {
+		data, err := schema.Avro()
+		if err != nil {
+			return "", "", err
+		}
+
+		// AVRO is the registry default and is left out for older registries
+		return "", string(data), nil
+	}
+
+	data, err := schema.JSONSchema()
+	if err != nil {
+		return "", "", err
+	}
+
+	return "JSON", string(data), nil
+}
@@ -0,0 +1,167 @@
+// Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package schemaregistry
+
+import (
+	"context"
+	"encoding/binary"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/encoding/protojson"
+
+	"github.com/nvidia/nvsentinel/data-models/pkg/protos"
+	"github.com/nvidia/nvsentinel/data-models/pkg/schema"
+)
+
+// fakeRegistry implements the subset of the Confluent schema registry API used by the client.
+type fakeRegistry struct {
+	mu            sync.Mutex
+	subjects      map[string][]schemaRequest
+	incompatible  bool
+	registrations int
+}
+
+func newFakeRegistry(t *testing.T) (*fakeRegistry, *Client) {
+	t.Helper()
+
+	registry := &fakeRegistry{subjects: map[string][]schemaRequest{}}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /compatibility/subjects/{subject}/versions/latest", func(w http.ResponseWriter, r *http.Request) {
+		registry.mu.Lock()
+		defer registry.mu.Unlock()
+
+		assert.Equal(t, contentType, r.Header.Get("Content-Type"))
+
+		if len(registry.subjects[r.PathValue("subject")]) == 0 {
+			w.WriteHeader(http.StatusNotFound)
+			_ = json.NewEncoder(w).Encode(registryError{ErrorCode: errorCodeSubjectNotFound, Message: "Subject not found"})
+
+			return
+		}
+
+		_ = json.NewEncoder(w).Encode(map[string]any{"is_compatible": !registry.incompatible})
+	})
+	mux.HandleFunc("POST /subjects/{subject}/versions", func(w http.ResponseWriter, r *http.Request) {
+		registry.mu.Lock()
+		defer registry.mu.Unlock()
+
+		var req schemaRequest
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+
+		subject := r.PathValue("subject")
+		registry.subjects[subject] = append(registry.subjects[subject], req)
+		registry.registrations++
+
+		_ = json.NewEncoder(w).Encode(map[string]any{"id": 42})
+	})
+
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	client, err := NewClient(Config{URL: server.URL})
+	require.NoError(t, err)
+
+	return registry, client
+}
+
+func TestSerializeAvro(t *testing.T) {
+	registry, client := newFakeRegistry(t)
+
+	serializer, err := NewSerializer(client, "health-events", FormatAvro)
+	require.NoError(t, err)
+
+	event := &protos.HealthEvent{Version: 1, CheckName: "SysLogsXIDError", NodeName: "node-1"}
+
+	for range 2 {
+		data, err := serializer.Serialize(context.Background(), event)
+		require.NoError(t, err)
+
+		require.Greater(t, len(data), 5)
+		assert.Equal(t, byte(magicByte), data[0])
+		assert.Equal(t, uint32(42), binary.BigEndian.Uint32(data[1:5]))
+
+		payload, err := schema.EncodeAvro(event)
+		require.NoError(t, err)
+		assert.Equal(t, payload, data[5:])
+	}
+
+	assert.Equal(t, 1, registry.registrations, "schema is registered once")
+
+	registered := registry.subjects["health-events-value"]
+	require.Len(t, registered, 1)
+	assert.Empty(t, registered[0].SchemaType)
+
+	avro, err := schema.Avro()
+	require.NoError(t, err)
+	assert.JSONEq(t, string(avro), registered[0].Schema)
+}
+
+func TestSerializeJSON(t *testing.T) {
+	registry, client := newFakeRegistry(t)
+
+	serializer, err := NewSerializer(client, "health-events", FormatJSON)
+	require.NoError(t, err)
+
+	event := &protos.HealthEvent{CheckName: "SysLogsXIDError", RecommendedAction: protos.RecommendedAction_RESTART_BM}
+
+	data, err := serializer.Serialize(context.Background(), event)
+	require.NoError(t, err)
+
+	decoded := &protos.HealthEvent{}
+	require.NoError(t, protojson.Unmarshal(data[5:], decoded))
+	assert.Equal(t, protos.RecommendedAction_RESTART_BM, decoded.RecommendedAction)
+
+	assert.Equal(t, "JSON", registry.subjects["health-events-value"][0].SchemaType)
+}
+
+func TestSerializeIncompatibleSchema(t *testing.T) {
+	registry, client := newFakeRegistry(t)
+	registry.subjects["health-events-value"] = []schemaRequest{{Schema: "{}"}}
+	registry.incompatible = true
+
+	serializer, err := NewSerializer(client, "health-events", FormatAvro)
+	require.NoError(t, err)
+
+	_, err = serializer.Serialize(context.Background(), &protos.HealthEvent{})
+	require.ErrorIs(t, err, ErrIncompatible)
+	assert.Zero(t, registry.registrations, "incompatible schemas must not be registered")
+
+	// The check is retried once the registry accepts the schema
+	registry.mu.Lock()
+	registry.incompatible = false
+	registry.mu.Unlock()
+
+	_, err = serializer.Serialize(context.Background(), &protos.HealthEvent{})
+	require.NoError(t, err)
+	assert.Equal(t, 1, registry.registrations)
+}
+
+func TestNewSerializerValidation(t *testing.T) {
+	_, err := NewClient(Config{URL: "not a url"})
+	require.Error(t, err)
+
+	client, err := NewClient(Config{URL: "http://registry:8081"})
+	require.NoError(t, err)
+
+	_, err = NewSerializer(client, "topic", "protobuf")
+	require.Error(t, err)
+}
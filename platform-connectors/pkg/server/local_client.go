@@ -0,0 +1,49 @@
+// Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"context"
+
+	pb "github.com/nvidia/nvsentinel/data-models/pkg/protos"
+
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+// LocalClient is a pb.PlatformConnectorClient calling a server in the same
+// process, for health monitors embedded in the platform connector binary.
+type LocalClient struct {
+	Server pb.PlatformConnectorServer
+}
+
+var _ pb.PlatformConnectorClient = LocalClient{}
+
+func (c LocalClient) HealthEventOccurredV1(ctx context.Context, in *pb.HealthEvents,
+	_ ...grpc.CallOption) (*emptypb.Empty, error) {
+	// The server stamps and enqueues the events, so it gets a copy like it
+	// would over the wire
+	out, err := c.Server.HealthEventOccurredV1(ctx, proto.Clone(in).(*pb.HealthEvents))
+	if err != nil {
+		return nil, err
+	}
+
+	if out == nil {
+		out = &emptypb.Empty{}
+	}
+
+	return out, nil
+}
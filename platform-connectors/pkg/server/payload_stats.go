@@ -0,0 +1,57 @@
+// Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"context"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"google.golang.org/grpc/stats"
+)
+
+var payloadBytesReceived = promauto.NewCounterVec(prometheus.CounterOpts{
+	Name: "platform_connector_payload_bytes_received_total",
+	Help: "Bytes of health event payloads received, raw (uncompressed) and compressed (as sent on the wire)",
+}, []string{"type"})
+
+// PayloadStatsHandler is a gRPC stats handler recording raw vs compressed
+// payload sizes of incoming health events.
+type PayloadStatsHandler struct{}
+
+func (PayloadStatsHandler) TagRPC(ctx context.Context, _ *stats.RPCTagInfo) context.Context {
+	return ctx
+}
+
+func (PayloadStatsHandler) HandleRPC(_ context.Context, s stats.RPCStats) {
+	in, ok := s.(*stats.InPayload)
+	if !ok {
+		return
+	}
+
+	compressed := in.CompressedLength
+	if compressed == 0 {
+		compressed = in.Length
+	}
+
+	payloadBytesReceived.WithLabelValues("raw").Add(float64(in.Length))
+	payloadBytesReceived.WithLabelValues("compressed").Add(float64(compressed))
+}
+
+func (PayloadStatsHandler) TagConn(ctx context.Context, _ *stats.ConnTagInfo) context.Context {
+	return ctx
+}
+
+func (PayloadStatsHandler) HandleConn(context.Context, stats.ConnStats) {}
@@ -16,11 +16,19 @@ package server
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"log/slog"
+	"time"
 
 	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/nvidia/nvsentinel/commons/pkg/logger"
+	"github.com/nvidia/nvsentinel/data-models/pkg/model"
 	pb "github.com/nvidia/nvsentinel/data-models/pkg/protos"
+	"github.com/nvidia/nvsentinel/platform-connectors/pkg/autotune"
+	"github.com/nvidia/nvsentinel/platform-connectors/pkg/dlq"
 	"github.com/nvidia/nvsentinel/platform-connectors/pkg/nodemetadata"
+	"github.com/nvidia/nvsentinel/platform-connectors/pkg/redaction"
 	"github.com/nvidia/nvsentinel/platform-connectors/pkg/ringbuffer"
 
 	"github.com/prometheus/client_golang/prometheus"
@@ -41,23 +49,70 @@ var (
 		Name: "platform_connector_health_events_received_total",
 		Help: "The total number of health events that the platform connector has received",
 	})
+
+	pipelineStageLatency = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "platform_connector_pipeline_stage_latency_seconds",
+		Help:    "Latency between pipeline stages of a health event, from event emitted to ingested",
+		Buckets: prometheus.DefBuckets,
+	}, []string{"from", "to"})
 )
 
 type PlatformConnectorServer struct {
 	pb.UnimplementedPlatformConnectorServer
 	Processor nodemetadata.Processor
+	// Tuner filters repeated non-fatal events during storms, nil disables it
+	Tuner *autotune.Controller
+	// ClusterName is stamped on the events when set
+	ClusterName string
+	// DeadLetters keeps the events rejected on ingest, nil drops them
+	DeadLetters *dlq.Queue
+	// Redactor scrubs the events before they are logged or leave the node, nil disables it
+	Redactor *redaction.Redactor
 }
 
 func (p *PlatformConnectorServer) HealthEventOccurredV1(ctx context.Context,
 	he *pb.HealthEvents) (*empty.Empty, error) {
-	slog.Info("Health events received", "events", he)
+	for _, event := range he.Events {
+		p.Redactor.Redact(event)
+	}
+
+	ctx = logger.WithSubsystem(ctx, "server")
+
+	slog.InfoContext(ctx, "Health events received", "events", he)
 
 	healthEventsReceived.Add(float64(len(he.Events)))
 
+	// Invalid events are set aside, retrying them would not make them valid
+	he.Events = p.rejectInvalid(he)
+	if len(he.Events) == 0 {
+		return nil, nil
+	}
+
+	if p.Tuner != nil {
+		he.Events = p.Tuner.Filter(he.Events)
+		if len(he.Events) == 0 {
+			return nil, nil
+		}
+	}
+
+	ingestedAt := time.Now()
+	for _, event := range he.Events {
+		// Events from agents predating event IDs get one on ingest
+		model.AssignEventID(event)
+		model.SetCluster(event, p.ClusterName)
+
+		if latency, ok := model.StageLatency(event, model.StageEventEmitted, ingestedAt); ok {
+			pipelineStageLatency.WithLabelValues(string(model.StageEventEmitted), string(model.StageIngested)).
+				Observe(latency.Seconds())
+		}
+
+		model.SetStageTimestamp(event, model.StageIngested, ingestedAt)
+	}
+
 	if p.Processor != nil {
 		for i := range he.Events {
 			if err := p.Processor.AugmentHealthEvent(ctx, he.Events[i]); err != nil {
-				slog.Warn("Failed to augment health event",
+				slog.WarnContext(logger.WithEventID(ctx, he.Events[i].Id), "Failed to augment health event",
 					"nodeName", he.Events[i].NodeName,
 					"error", err)
 			}
@@ -71,6 +126,78 @@ func (p *PlatformConnectorServer) HealthEventOccurredV1(ctx context.Context,
 	return nil, nil
 }
 
+// rejectInvalid returns the valid events of he and moves the others to the dead
+// letter queue.
+func (p *PlatformConnectorServer) rejectInvalid(he *pb.HealthEvents) []*pb.HealthEvent {
+	var (
+		valid    []*pb.HealthEvent
+		rejected []*pb.HealthEvent
+		errs     []error
+	)
+
+	for _, event := range he.Events {
+		if err := model.ValidateHealthEvent(event); err != nil {
+			rejected = append(rejected, event)
+			errs = append(errs, err)
+
+			continue
+		}
+
+		valid = append(valid, event)
+	}
+
+	if len(errs) == 0 {
+		return valid
+	}
+
+	err := errors.Join(errs...)
+	slog.Warn("Rejected invalid health events", "count", len(errs), "error", err)
+
+	if p.DeadLetters != nil {
+		p.DeadLetters.Add("", dlq.ReasonValidation, err, &pb.HealthEvents{Version: he.Version, Events: rejected})
+	}
+
+	return valid
+}
+
+// RedriveDeadLetter processes the events of a dead letter again. Events rejected
+// on ingest are validated again, events a connector failed to process are queued
+// for that connector only.
+func (p *PlatformConnectorServer) RedriveDeadLetter(entry dlq.Entry) error {
+	healthEvents := &pb.HealthEvents{Version: 1, Events: entry.Events}
+
+	if entry.Connector == "" {
+		for _, event := range entry.Events {
+			if err := model.ValidateHealthEvent(event); err != nil {
+				return err
+			}
+		}
+
+		_, err := p.HealthEventOccurredV1(context.Background(), healthEvents)
+
+		return err
+	}
+
+	for _, buffer := range ringBufferQueue {
+		if buffer.Name() == entry.Connector {
+			buffer.Enqueue(healthEvents)
+			return nil
+		}
+	}
+
+	return fmt.Errorf("connector %s is not enabled", entry.Connector)
+}
+
+// QueueDepth returns the depth of the deepest connector queue.
+func QueueDepth() int {
+	depth := 0
+	for _, buffer := range ringBufferQueue {
+		depth = max(depth, buffer.CurrentLength())
+	}
+
+	return depth
+}
+
 func InitializeAndAttachRingBufferForConnectors(buffer *ringbuffer.RingBuffer) {
 	ringBufferQueue = append(ringBufferQueue, buffer)
 }
@@ -0,0 +1,122 @@
+// Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package soak
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"golang.org/x/sync/errgroup"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+const (
+	// FleetLabel marks the nodes created for the simulated fleet
+	FleetLabel = "nvsentinel.dgxc.nvidia.com/soak"
+
+	fleetConcurrency = 20
+)
+
+// CreateFleet creates the nodes <prefix>-0 to <prefix>-<nodes-1> as fake GPU nodes
+// managed by KWOK, so the control plane quarantines, drains and remediates them like
+// real nodes. Nodes that already exist are kept.
+func CreateFleet(ctx context.Context, client kubernetes.Interface, prefix string, nodes int) error {
+	group, ctx := errgroup.WithContext(ctx)
+	group.SetLimit(fleetConcurrency)
+
+	for i := range nodes {
+		group.Go(func() error {
+			_, err := client.CoreV1().Nodes().Create(ctx, fleetNode(prefix+"-"+strconv.Itoa(i)), metav1.CreateOptions{})
+			if err != nil && !apierrors.IsAlreadyExists(err) {
+				return fmt.Errorf("failed to create node %s-%d: %w", prefix, i, err)
+			}
+
+			return nil
+		})
+	}
+
+	return group.Wait()
+}
+
+// DeleteFleet deletes the nodes created by CreateFleet.
+func DeleteFleet(ctx context.Context, client kubernetes.Interface) error {
+	nodes, err := client.CoreV1().Nodes().List(ctx, metav1.ListOptions{LabelSelector: FleetLabel + "=true"})
+	if err != nil {
+		return fmt.Errorf("failed to list the nodes of the fleet: %w", err)
+	}
+
+	group, ctx := errgroup.WithContext(ctx)
+	group.SetLimit(fleetConcurrency)
+
+	for i := range nodes.Items {
+		name := nodes.Items[i].Name
+
+		group.Go(func() error {
+			err := client.CoreV1().Nodes().Delete(ctx, name, metav1.DeleteOptions{})
+			if err != nil && !apierrors.IsNotFound(err) {
+				return fmt.Errorf("failed to delete node %s: %w", name, err)
+			}
+
+			return nil
+		})
+	}
+
+	return group.Wait()
+}
+
+// fleetNode mirrors the KWOK node template of the UAT tests
+func fleetNode(name string) *corev1.Node {
+	return &corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: name,
+			Annotations: map[string]string{
+				"node.alpha.kubernetes.io/ttl": "0",
+				"kwok.x-k8s.io/node":           "fake",
+			},
+			Labels: map[string]string{
+				"kubernetes.io/hostname":       name,
+				"kubernetes.io/os":             "linux",
+				"kubernetes.io/arch":           "amd64",
+				"type":                         "kwok",
+				"nvidia.com/gpu.present":       "true",
+				"nvidia.com/gpu.deploy.dcgm":   "true",
+				"nvidia.com/gpu.deploy.driver": "true",
+				FleetLabel:                     "true",
+			},
+		},
+		Spec: corev1.NodeSpec{
+			Taints: []corev1.Taint{{Key: "nvidia.com/gpu", Effect: corev1.TaintEffectNoSchedule}},
+		},
+		Status: corev1.NodeStatus{
+			Allocatable: corev1.ResourceList{
+				corev1.ResourceCPU:    resource.MustParse("224"),
+				corev1.ResourceMemory: resource.MustParse("1024Gi"),
+				corev1.ResourcePods:   resource.MustParse("110"),
+				"nvidia.com/gpu":      resource.MustParse("8"),
+			},
+			Capacity: corev1.ResourceList{
+				corev1.ResourceCPU:    resource.MustParse("224"),
+				corev1.ResourceMemory: resource.MustParse("1024Gi"),
+				corev1.ResourcePods:   resource.MustParse("110"),
+				"nvidia.com/gpu":      resource.MustParse("8"),
+			},
+		},
+	}
+}
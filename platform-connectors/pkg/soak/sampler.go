@@ -0,0 +1,275 @@
+// Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package soak
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/nvidia/nvsentinel/data-models/pkg/model"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/prometheus/common/expfmt"
+	prommodel "github.com/prometheus/common/model"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+const (
+	memoryMetric     = "process_resident_memory_bytes"
+	goroutinesMetric = "go_goroutines"
+	// latencySuffix selects the histograms of the latency from a pipeline stage to
+	// a decision, e.g. fault_quarantine_pipeline_stage_latency_seconds
+	latencySuffix = "_pipeline_stage_latency_seconds"
+)
+
+// Target is a control plane component whose metrics endpoint is sampled.
+type Target struct {
+	Name string `json:"name"`
+	URL  string `json:"url"`
+}
+
+// ParseTarget parses name=url.
+func ParseTarget(value string) (Target, error) {
+	name, url, ok := strings.Cut(value, "=")
+	if !ok || name == "" || url == "" {
+		return Target{}, fmt.Errorf("invalid target %q, expected name=url", value)
+	}
+
+	return Target{Name: name, URL: url}, nil
+}
+
+// StoreStats is the size of the health events collection.
+type StoreStats struct {
+	Documents int64 `json:"documents"`
+	// Bytes is the uncompressed size of the documents
+	Bytes int64 `json:"bytes"`
+	// StorageBytes is the size on disk
+	StorageBytes int64 `json:"storageBytes"`
+}
+
+// StoreStatsFunc reads the size of the store.
+type StoreStatsFunc func(ctx context.Context) (StoreStats, error)
+
+// MongoStoreStats reads the size of the collection with collStats.
+func MongoStoreStats(collection *mongo.Collection) StoreStatsFunc {
+	return func(ctx context.Context) (StoreStats, error) {
+		var result struct {
+			Count       int64 `bson:"count"`
+			Size        int64 `bson:"size"`
+			StorageSize int64 `bson:"storageSize"`
+		}
+
+		err := collection.Database().RunCommand(ctx, bson.D{{Key: "collStats", Value: collection.Name()}}).
+			Decode(&result)
+		if err != nil {
+			return StoreStats{}, fmt.Errorf("failed to get stats of collection %s: %w", collection.Name(), err)
+		}
+
+		return StoreStats{Documents: result.Count, Bytes: result.Size, StorageBytes: result.StorageSize}, nil
+	}
+}
+
+// Sample is a reading of the control plane. Components that could not be scraped
+// are missing from the maps.
+type Sample struct {
+	Time time.Time `json:"time"`
+	// Memory is the resident memory of the components in bytes
+	Memory     map[string]float64 `json:"memory"`
+	Goroutines map[string]float64 `json:"goroutines"`
+	Store      *StoreStats        `json:"store,omitempty"`
+	// latency holds the cumulative decision latency histograms by component
+	latency map[string]histogram
+}
+
+// histogram holds the cumulative counts of a histogram by upper bound.
+type histogram map[float64]float64
+
+// sampler reads the metrics of the targets and the size of the store.
+type sampler struct {
+	targets    []Target
+	storeStats StoreStatsFunc
+	client     *http.Client
+}
+
+func (s *sampler) sample(ctx context.Context) (Sample, []error) {
+	sample := Sample{
+		Time:       time.Now(),
+		Memory:     map[string]float64{},
+		Goroutines: map[string]float64{},
+		latency:    map[string]histogram{},
+	}
+
+	var errs []error
+
+	for _, target := range s.targets {
+		families, err := s.scrape(ctx, target.URL)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", target.Name, err))
+			continue
+		}
+
+		if value, ok := gauge(families[memoryMetric]); ok {
+			sample.Memory[target.Name] = value
+		}
+
+		if value, ok := gauge(families[goroutinesMetric]); ok {
+			sample.Goroutines[target.Name] = value
+		}
+
+		for name, family := range families {
+			if strings.HasSuffix(name, latencySuffix) {
+				sample.latency[strings.TrimSuffix(name, latencySuffix)] = ingestedLatency(family)
+			}
+		}
+	}
+
+	if s.storeStats != nil {
+		stats, err := s.storeStats(ctx)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("store: %w", err))
+		} else {
+			sample.Store = &stats
+		}
+	}
+
+	return sample, errs
+}
+
+func (s *sampler) scrape(ctx context.Context, url string) (map[string]*dto.MetricFamily, error) {
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	response, err := s.client.Do(request)
+	if err != nil {
+		return nil, fmt.Errorf("failed to scrape %s: %w", url, err)
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("%s returned %s", url, response.Status)
+	}
+
+	parser := expfmt.NewTextParser(prommodel.UTF8Validation)
+
+	families, err := parser.TextToMetricFamilies(response.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse metrics of %s: %w", url, err)
+	}
+
+	return families, nil
+}
+
+// gauge returns the value of an unlabeled gauge
+func gauge(family *dto.MetricFamily) (float64, bool) {
+	if family == nil || len(family.GetMetric()) == 0 || family.GetMetric()[0].GetGauge() == nil {
+		return 0, false
+	}
+
+	return family.GetMetric()[0].GetGauge().GetValue(), true
+}
+
+// ingestedLatency sums the series of the latency from the ingestion of the events,
+// which is stamped on every event, to the decision. The intermediate incident created
+// stage of fault-quarantine is left out so its events are not counted twice.
+func ingestedLatency(family *dto.MetricFamily) histogram {
+	result := histogram{}
+
+	for _, metric := range family.GetMetric() {
+		if !hasLabel(metric, "from", string(model.StageIngested)) ||
+			hasLabel(metric, "to", string(model.StageIncidentCreated)) || metric.GetHistogram() == nil {
+			continue
+		}
+
+		for _, bucket := range metric.GetHistogram().GetBucket() {
+			if !math.IsInf(bucket.GetUpperBound(), 1) {
+				result[bucket.GetUpperBound()] += float64(bucket.GetCumulativeCount())
+			}
+		}
+
+		result[math.Inf(1)] += float64(metric.GetHistogram().GetSampleCount())
+	}
+
+	return result
+}
+
+func hasLabel(metric *dto.Metric, name, value string) bool {
+	for _, label := range metric.GetLabel() {
+		if label.GetName() == name {
+			return label.GetValue() == value
+		}
+	}
+
+	return false
+}
+
+// sub returns the observations made since the earlier histogram.
+func (h histogram) sub(earlier histogram) histogram {
+	result := make(histogram, len(h))
+	for bound, count := range h {
+		result[bound] = count - earlier[bound]
+	}
+
+	return result
+}
+
+// quantile estimates the quantile like histogram_quantile of PromQL does, by
+// interpolating linearly within the bucket of the quantile. Observations beyond
+// the largest bound are reported at that bound.
+func (h histogram) quantile(q float64) (time.Duration, bool) {
+	bounds := make([]float64, 0, len(h))
+	for bound := range h {
+		bounds = append(bounds, bound)
+	}
+
+	sort.Float64s(bounds)
+
+	total := h[math.Inf(1)]
+	if total <= 0 || len(bounds) < 2 {
+		return 0, false
+	}
+
+	rank := q * total
+	lower, below := 0.0, 0.0
+
+	for _, bound := range bounds {
+		count := h[bound]
+		if count >= rank {
+			if math.IsInf(bound, 1) {
+				return seconds(lower), true
+			}
+
+			if count == below {
+				return seconds(bound), true
+			}
+
+			return seconds(lower + (bound-lower)*(rank-below)/(count-below)), true
+		}
+
+		lower, below = bound, count
+	}
+
+	return seconds(lower), true
+}
+
+func seconds(value float64) time.Duration {
+	return time.Duration(value * float64(time.Second))
+}
@@ -0,0 +1,221 @@
+// Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package soak
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/nvidia/nvsentinel/platform-connectors/pkg/loadgen"
+)
+
+// Duration is a time.Duration written as a Go duration string, e.g. "90m".
+type Duration struct {
+	time.Duration
+}
+
+func (d Duration) MarshalJSON() ([]byte, error) {
+	return json.Marshal(d.String())
+}
+
+func (d *Duration) UnmarshalJSON(data []byte) error {
+	var value string
+	if err := json.Unmarshal(data, &value); err != nil {
+		return fmt.Errorf("duration must be a string such as \"30m\": %w", err)
+	}
+
+	duration, err := time.ParseDuration(value)
+	if err != nil {
+		return err
+	}
+
+	d.Duration = duration
+
+	return nil
+}
+
+// Load is the traffic of a range of nodes of the fleet during a phase.
+type Load struct {
+	// FirstNode and Nodes select the nodes <prefix>-<FirstNode> to
+	// <prefix>-<FirstNode+Nodes-1>, the whole fleet when Nodes is zero
+	FirstNode int `json:"firstNode"`
+	Nodes     int `json:"nodes"`
+	// Rate is the number of events per second of all the nodes of the load
+	Rate float64     `json:"rate"`
+	Mix  loadgen.Mix `json:"mix"`
+}
+
+// Phase runs its loads concurrently for its duration, e.g. the background noise
+// of the fleet and a burst of faults on some of its nodes.
+type Phase struct {
+	Name     string   `json:"name"`
+	Duration Duration `json:"duration"`
+	Loads    []Load   `json:"loads"`
+}
+
+// Scenario is the script of faults played against the control plane, its phases
+// run one after the other.
+type Scenario struct {
+	Name string `json:"name"`
+	// Nodes is the size of the simulated fleet
+	Nodes  int     `json:"nodes"`
+	Phases []Phase `json:"phases"`
+}
+
+var (
+	// heartbeat is what a healthy node reports
+	heartbeat = loadgen.MixEntry{
+		Weight: 1, Agent: "gpu-health-monitor", CheckName: "GpuMemWatch", ComponentClass: "GPU",
+		IsHealthy: true, RecommendedAction: "NONE", Message: "No Health Failures",
+	}
+	// fallenOffTheBus is a fatal fault that quarantines and remediates the node
+	fallenOffTheBus = loadgen.MixEntry{
+		Weight: 1, Agent: "syslog-health-monitor", CheckName: "SysLogsXIDError", ComponentClass: "GPU",
+		ErrorCodes: []string{"79"}, IsFatal: true, RecommendedAction: "RESTART_BM",
+		Message: "NVRM: Xid (PCI:0000:b3:00): 79, GPU has fallen off the bus",
+	}
+	// xidRecovered clears the fault of fallenOffTheBus
+	xidRecovered = loadgen.MixEntry{
+		Weight: 1, Agent: "syslog-health-monitor", CheckName: "SysLogsXIDError", ComponentClass: "GPU",
+		IsHealthy: true, RecommendedAction: "NONE", Message: "No Health Failures",
+	}
+)
+
+// DefaultScenario plays a few hours of a fleet of the given size: a warm up with
+// heartbeats, then twice a storm of fatal XIDs on 2% of the nodes followed by their
+// recovery, with the background noise of the default mix throughout.
+func DefaultScenario(nodes int) Scenario {
+	faulty := max(nodes/50, 1)
+	// the nodes of the storms are the last of the fleet, the others make the noise
+	healthy := max(nodes-faulty, 1)
+	noise := Load{Nodes: healthy, Rate: float64(nodes) / 50, Mix: loadgen.DefaultMix}
+
+	phases := []Phase{{
+		Name:     "warm-up",
+		Duration: Duration{15 * time.Minute},
+		Loads:    []Load{{Rate: float64(nodes) / 50, Mix: loadgen.Mix{heartbeat}}},
+	}}
+
+	for i := 1; i <= 2; i++ {
+		phases = append(phases,
+			Phase{
+				Name:     fmt.Sprintf("fault-storm-%d", i),
+				Duration: Duration{30 * time.Minute},
+				Loads: []Load{
+					noise,
+					{FirstNode: healthy, Nodes: faulty, Rate: float64(faulty) / 30, Mix: loadgen.Mix{fallenOffTheBus}},
+				},
+			},
+			Phase{
+				Name:     fmt.Sprintf("recovery-%d", i),
+				Duration: Duration{30 * time.Minute},
+				Loads: []Load{
+					noise,
+					{FirstNode: healthy, Nodes: faulty, Rate: float64(faulty) / 30, Mix: loadgen.Mix{xidRecovered}},
+				},
+			},
+			Phase{
+				Name:     fmt.Sprintf("steady-%d", i),
+				Duration: Duration{time.Hour},
+				Loads:    []Load{{Rate: float64(nodes) / 25, Mix: loadgen.DefaultMix}},
+			},
+		)
+	}
+
+	return Scenario{Name: "default", Nodes: nodes, Phases: phases}
+}
+
+// LoadScenario reads a scenario from a JSON file.
+func LoadScenario(path string) (*Scenario, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read scenario %s: %w", path, err)
+	}
+
+	var scenario Scenario
+	if err := json.Unmarshal(data, &scenario); err != nil {
+		return nil, fmt.Errorf("failed to parse scenario %s: %w", path, err)
+	}
+
+	if err := scenario.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid scenario %s: %w", path, err)
+	}
+
+	return &scenario, nil
+}
+
+// Validate checks that every load of the scenario targets nodes of the fleet.
+func (s *Scenario) Validate() error {
+	if s.Nodes <= 0 {
+		return fmt.Errorf("nodes must be positive")
+	}
+
+	if len(s.Phases) == 0 {
+		return fmt.Errorf("scenario has no phases")
+	}
+
+	for i, phase := range s.Phases {
+		if phase.Name == "" {
+			return fmt.Errorf("phase %d: name is required", i)
+		}
+
+		if phase.Duration.Duration <= 0 {
+			return fmt.Errorf("phase %s: duration must be positive", phase.Name)
+		}
+
+		if len(phase.Loads) == 0 {
+			return fmt.Errorf("phase %s: no loads", phase.Name)
+		}
+
+		for j, load := range phase.Loads {
+			nodes := load.nodes(s.Nodes)
+			if load.FirstNode < 0 || load.Nodes < 0 || nodes <= 0 || load.FirstNode+nodes > s.Nodes {
+				return fmt.Errorf("phase %s, load %d: nodes %d to %d are not in the fleet of %d nodes",
+					phase.Name, j, load.FirstNode, load.FirstNode+nodes-1, s.Nodes)
+			}
+
+			if load.Rate <= 0 {
+				return fmt.Errorf("phase %s, load %d: rate must be positive", phase.Name, j)
+			}
+
+			if err := load.Mix.Validate(); err != nil {
+				return fmt.Errorf("phase %s, load %d: %w", phase.Name, j, err)
+			}
+		}
+	}
+
+	return nil
+}
+
+// Duration is the total duration of the phases.
+func (s *Scenario) Duration() time.Duration {
+	var total time.Duration
+	for _, phase := range s.Phases {
+		total += phase.Duration.Duration
+	}
+
+	return total
+}
+
+// nodes returns the number of nodes of the load.
+func (l *Load) nodes(fleet int) int {
+	if l.Nodes == 0 {
+		return fleet - l.FirstNode
+	}
+
+	return l.Nodes
+}
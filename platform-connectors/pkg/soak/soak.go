@@ -0,0 +1,486 @@
+// Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package soak plays scripted fault scenarios of a simulated fleet against a real
+// control plane for hours and measures the memory growth of its components, the
+// size of the store and the latency of the quarantine and remediation decisions,
+// to catch leaks and scalability cliffs before a release. The fleet is simulated
+// with the agents of loadgen.
+package soak
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/nvidia/nvsentinel/platform-connectors/pkg/loadgen"
+)
+
+// Config describes a soak run.
+type Config struct {
+	// Socket is the unix socket of the platform connector
+	Socket string
+	// NodePrefix names the nodes of the fleet, <prefix>-<index>
+	NodePrefix string
+	// Connections is the number of gRPC connections of every load
+	Connections int
+	// Timeout is the timeout of a request
+	Timeout  time.Duration
+	Scenario Scenario
+	// Targets are the control plane components whose metrics are sampled
+	Targets []Target
+	// StoreStats, when set, reads the size of the store with every sample
+	StoreStats     StoreStatsFunc
+	SampleInterval time.Duration
+	// Warmup is the time at the start of the run excluded from the growth rates,
+	// while caches and connection pools fill
+	Warmup     time.Duration
+	Thresholds Thresholds
+	// Progress, when set, is called with the current phase and every sample
+	Progress func(phase string, sample Sample, errs []error)
+	// Seed makes the published events reproducible
+	Seed uint64
+}
+
+// Thresholds fail the run when exceeded, zero disables a threshold.
+type Thresholds struct {
+	// MaxMemoryGrowth is the resident memory in bytes a component may grow by per
+	// hour after the warm up
+	MaxMemoryGrowth float64 `json:"maxMemoryGrowth"`
+	// MaxStoreBytesPerEvent is how much the store may grow per published event
+	MaxStoreBytesPerEvent float64 `json:"maxStoreBytesPerEvent"`
+	// MaxDecisionLatency is the p99 latency from ingestion to a decision in every
+	// phase
+	MaxDecisionLatency time.Duration `json:"maxDecisionLatency"`
+	// MinThroughputRatio is the share of the rate of a load the connector must
+	// accept
+	MinThroughputRatio float64 `json:"minThroughputRatio"`
+}
+
+// Validate checks that the config describes a soak run.
+func (c *Config) Validate() error {
+	if c.Socket == "" {
+		return fmt.Errorf("socket must be set")
+	}
+
+	if c.Connections <= 0 || c.Timeout <= 0 || c.SampleInterval <= 0 {
+		return fmt.Errorf("connections, timeout and sample interval must be positive")
+	}
+
+	if c.Warmup < 0 {
+		return fmt.Errorf("warm up must not be negative")
+	}
+
+	if len(c.Targets) == 0 && c.StoreStats == nil {
+		return fmt.Errorf("nothing to sample, set metrics targets or the store")
+	}
+
+	return c.Scenario.Validate()
+}
+
+// DecisionLatency summarizes the latency from ingestion to the decisions of a
+// component during a phase, estimated from its histogram buckets.
+type DecisionLatency struct {
+	Decisions int           `json:"decisions"`
+	P50       time.Duration `json:"p50"`
+	P99       time.Duration `json:"p99"`
+}
+
+// PhaseReport is the outcome of a phase.
+type PhaseReport struct {
+	Name    string            `json:"name"`
+	Elapsed time.Duration     `json:"elapsed"`
+	Loads   []*loadgen.Report `json:"loads"`
+	// DecisionLatency is keyed by the component of the histogram, e.g.
+	// fault_quarantine
+	DecisionLatency map[string]DecisionLatency `json:"decisionLatency"`
+}
+
+// MemoryReport is the memory of a component over the run.
+type MemoryReport struct {
+	Start float64 `json:"start"`
+	End   float64 `json:"end"`
+	Peak  float64 `json:"peak"`
+	// GrowthPerHour is the slope of the least squares fit of the samples after the
+	// warm up, in bytes per hour
+	GrowthPerHour   float64 `json:"growthPerHour"`
+	StartGoroutines float64 `json:"startGoroutines"`
+	EndGoroutines   float64 `json:"endGoroutines"`
+}
+
+// StoreReport is the size of the store over the run.
+type StoreReport struct {
+	Start StoreStats `json:"start"`
+	End   StoreStats `json:"end"`
+	// GrowthPerHour is the slope of the size after the warm up, in bytes per hour
+	GrowthPerHour float64 `json:"growthPerHour"`
+	// BytesPerEvent is the growth of the size over the accepted events
+	BytesPerEvent float64 `json:"bytesPerEvent"`
+}
+
+// Report is the outcome of a soak run.
+type Report struct {
+	Scenario string        `json:"scenario"`
+	Nodes    int           `json:"nodes"`
+	Elapsed  time.Duration `json:"elapsed"`
+	// Completed is false when the run was interrupted
+	Completed bool                     `json:"completed"`
+	Events    int                      `json:"events"`
+	Errors    int                      `json:"errors"`
+	Phases    []PhaseReport            `json:"phases"`
+	Memory    map[string]*MemoryReport `json:"memory"`
+	Store     *StoreReport             `json:"store,omitempty"`
+	// SampleErrors counts the failed scrapes and store reads
+	SampleErrors int      `json:"sampleErrors"`
+	Samples      []Sample `json:"samples"`
+	// Violations are the exceeded thresholds, the run passed without any
+	Violations []string `json:"violations"`
+}
+
+// Passed returns true when no threshold was exceeded.
+func (r *Report) Passed() bool {
+	return len(r.Violations) == 0
+}
+
+// recorder collects the samples of the periodic sampler and the phase boundaries.
+type recorder struct {
+	mu       sync.Mutex
+	sampler  *sampler
+	progress func(string, Sample, []error)
+	phase    string
+	samples  []Sample
+	errors   int
+}
+
+func (r *recorder) record(ctx context.Context) Sample {
+	sample, errs := r.sampler.sample(ctx)
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.samples = append(r.samples, sample)
+	r.errors += len(errs)
+
+	if r.progress != nil {
+		r.progress(r.phase, sample, errs)
+	}
+
+	return sample
+}
+
+func (r *recorder) setPhase(phase string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.phase = phase
+}
+
+// Run plays the scenario, sampling the control plane throughout, and reports the
+// measurements. An interrupted run reports the phases played so far.
+func Run(ctx context.Context, cfg Config) (*Report, error) {
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+
+	recorder := &recorder{
+		sampler: &sampler{
+			targets:    cfg.Targets,
+			storeStats: cfg.StoreStats,
+			client:     &http.Client{Timeout: cfg.SampleInterval},
+		},
+		progress: cfg.Progress,
+	}
+
+	start := time.Now()
+	// the samples at the phase boundaries delimit the decisions of every phase
+	boundary := recorder.record(ctx)
+
+	samplingCtx, stopSampling := context.WithCancel(ctx)
+
+	var sampling sync.WaitGroup
+
+	sampling.Add(1)
+
+	go func() {
+		defer sampling.Done()
+
+		ticker := time.NewTicker(cfg.SampleInterval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-samplingCtx.Done():
+				return
+			case <-ticker.C:
+				recorder.record(samplingCtx)
+			}
+		}
+	}()
+
+	report := &Report{Scenario: cfg.Scenario.Name, Nodes: cfg.Scenario.Nodes}
+
+	for i, phase := range cfg.Scenario.Phases {
+		if ctx.Err() != nil {
+			break
+		}
+
+		recorder.setPhase(phase.Name)
+
+		phaseReport, err := runPhase(ctx, &cfg, i, &phase)
+		if err != nil {
+			stopSampling()
+			sampling.Wait()
+
+			return nil, err
+		}
+
+		end := recorder.record(context.WithoutCancel(ctx))
+		phaseReport.DecisionLatency = decisionLatencies(boundary, end)
+		boundary = end
+
+		for _, load := range phaseReport.Loads {
+			report.Events += load.Events
+			report.Errors += load.Errors
+		}
+
+		report.Phases = append(report.Phases, *phaseReport)
+	}
+
+	report.Completed = ctx.Err() == nil && len(report.Phases) == len(cfg.Scenario.Phases)
+
+	stopSampling()
+	sampling.Wait()
+
+	report.Elapsed = time.Since(start)
+	report.Samples = recorder.samples
+	report.SampleErrors = recorder.errors
+	report.Memory = memoryReports(report.Samples, start.Add(cfg.Warmup))
+	report.Store = storeReport(report.Samples, start.Add(cfg.Warmup), report.Events)
+	report.Violations = violations(report, &cfg.Thresholds)
+
+	return report, nil
+}
+
+// runPhase runs the loads of the phase concurrently.
+func runPhase(ctx context.Context, cfg *Config, index int, phase *Phase) (*PhaseReport, error) {
+	start := time.Now()
+	reports := make([]*loadgen.Report, len(phase.Loads))
+	errs := make([]error, len(phase.Loads))
+
+	var wg sync.WaitGroup
+
+	for i, load := range phase.Loads {
+		loadCfg := loadgen.Config{
+			Socket:      cfg.Socket,
+			Agents:      load.nodes(cfg.Scenario.Nodes),
+			NodePrefix:  cfg.NodePrefix,
+			FirstNode:   load.FirstNode,
+			Rate:        load.Rate,
+			BatchSize:   1,
+			Connections: cfg.Connections,
+			Duration:    phase.Duration.Duration,
+			Timeout:     cfg.Timeout,
+			Mix:         load.Mix,
+			Seed:        cfg.Seed + uint64(index*len(phase.Loads)+i),
+		}
+
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			reports[i], errs[i] = loadgen.Run(ctx, loadCfg)
+		}()
+	}
+
+	wg.Wait()
+
+	for i, err := range errs {
+		if err != nil {
+			return nil, fmt.Errorf("phase %s, load %d: %w", phase.Name, i, err)
+		}
+	}
+
+	return &PhaseReport{Name: phase.Name, Elapsed: time.Since(start), Loads: reports}, nil
+}
+
+// decisionLatencies summarizes the decisions made between the two samples.
+func decisionLatencies(start, end Sample) map[string]DecisionLatency {
+	result := map[string]DecisionLatency{}
+
+	for component, histogram := range end.latency {
+		delta := histogram.sub(start.latency[component])
+
+		p50, ok := delta.quantile(0.5)
+		if !ok {
+			continue
+		}
+
+		p99, _ := delta.quantile(0.99)
+		result[component] = DecisionLatency{Decisions: int(delta[math.Inf(1)]), P50: p50, P99: p99}
+	}
+
+	return result
+}
+
+func memoryReports(samples []Sample, warmedUp time.Time) map[string]*MemoryReport {
+	result := map[string]*MemoryReport{}
+	points := map[string][]point{}
+
+	for _, sample := range samples {
+		for component, memory := range sample.Memory {
+			memoryReport, ok := result[component]
+			if !ok {
+				memoryReport = &MemoryReport{Start: memory, StartGoroutines: sample.Goroutines[component]}
+				result[component] = memoryReport
+			}
+
+			memoryReport.End = memory
+			memoryReport.EndGoroutines = sample.Goroutines[component]
+			memoryReport.Peak = max(memoryReport.Peak, memory)
+
+			if !sample.Time.Before(warmedUp) {
+				points[component] = append(points[component], point{sample.Time, memory})
+			}
+		}
+	}
+
+	for component, memoryReport := range result {
+		memoryReport.GrowthPerHour = perHour(points[component])
+	}
+
+	return result
+}
+
+func storeReport(samples []Sample, warmedUp time.Time, events int) *StoreReport {
+	var (
+		result *StoreReport
+		points []point
+	)
+
+	for _, sample := range samples {
+		if sample.Store == nil {
+			continue
+		}
+
+		if result == nil {
+			result = &StoreReport{Start: *sample.Store}
+		}
+
+		result.End = *sample.Store
+
+		if !sample.Time.Before(warmedUp) {
+			points = append(points, point{sample.Time, float64(sample.Store.Bytes)})
+		}
+	}
+
+	if result == nil {
+		return nil
+	}
+
+	result.GrowthPerHour = perHour(points)
+
+	if events > 0 {
+		result.BytesPerEvent = float64(result.End.Bytes-result.Start.Bytes) / float64(events)
+	}
+
+	return result
+}
+
+type point struct {
+	time  time.Time
+	value float64
+}
+
+// perHour returns the slope of the least squares fit of the points per hour, zero
+// with less than two points.
+func perHour(points []point) float64 {
+	if len(points) < 2 {
+		return 0
+	}
+
+	var sumX, sumY, sumXY, sumXX float64
+
+	for _, p := range points {
+		x := p.time.Sub(points[0].time).Hours()
+		sumX += x
+		sumY += p.value
+		sumXY += x * p.value
+		sumXX += x * x
+	}
+
+	n := float64(len(points))
+
+	denominator := n*sumXX - sumX*sumX
+	if denominator == 0 {
+		return 0
+	}
+
+	return (n*sumXY - sumX*sumY) / denominator
+}
+
+func violations(report *Report, thresholds *Thresholds) []string {
+	result := []string{}
+
+	components := make([]string, 0, len(report.Memory))
+	for component := range report.Memory {
+		components = append(components, component)
+	}
+
+	sort.Strings(components)
+
+	for _, component := range components {
+		growth := report.Memory[component].GrowthPerHour
+		if thresholds.MaxMemoryGrowth > 0 && growth > thresholds.MaxMemoryGrowth {
+			result = append(result, fmt.Sprintf("%s memory grows by %.0f bytes/h, more than %.0f bytes/h",
+				component, growth, thresholds.MaxMemoryGrowth))
+		}
+	}
+
+	if store := report.Store; store != nil && thresholds.MaxStoreBytesPerEvent > 0 &&
+		store.BytesPerEvent > thresholds.MaxStoreBytesPerEvent {
+		result = append(result, fmt.Sprintf("store grows by %.0f bytes/event, more than %.0f bytes/event",
+			store.BytesPerEvent, thresholds.MaxStoreBytesPerEvent))
+	}
+
+	for _, phase := range report.Phases {
+		for i, load := range phase.Loads {
+			if thresholds.MinThroughputRatio > 0 && load.Throughput < thresholds.MinThroughputRatio*load.TargetRate {
+				result = append(result, fmt.Sprintf("phase %s, load %d: throughput %.1f events/s below %.0f%% of %.1f",
+					phase.Name, i, load.Throughput, thresholds.MinThroughputRatio*100, load.TargetRate))
+			}
+		}
+
+		latencyComponents := make([]string, 0, len(phase.DecisionLatency))
+		for component := range phase.DecisionLatency {
+			latencyComponents = append(latencyComponents, component)
+		}
+
+		sort.Strings(latencyComponents)
+
+		for _, component := range latencyComponents {
+			latency := phase.DecisionLatency[component]
+			if thresholds.MaxDecisionLatency > 0 && latency.P99 > thresholds.MaxDecisionLatency {
+				result = append(result, fmt.Sprintf("phase %s: %s p99 decision latency %s above %s",
+					phase.Name, component, latency.P99, thresholds.MaxDecisionLatency))
+			}
+		}
+	}
+
+	return result
+}
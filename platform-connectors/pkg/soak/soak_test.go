@@ -0,0 +1,364 @@
+// Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package soak
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	pb "github.com/nvidia/nvsentinel/data-models/pkg/protos"
+	"github.com/nvidia/nvsentinel/platform-connectors/pkg/loadgen"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/types/known/emptypb"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+type fakeConnector struct {
+	pb.UnimplementedPlatformConnectorServer
+
+	mu    sync.Mutex
+	nodes map[string]bool
+}
+
+func (f *fakeConnector) HealthEventOccurredV1(_ context.Context, events *pb.HealthEvents) (*emptypb.Empty, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	for _, event := range events.Events {
+		f.nodes[event.NodeName] = true
+	}
+
+	return &emptypb.Empty{}, nil
+}
+
+func startConnector(t *testing.T) (string, *fakeConnector) {
+	t.Helper()
+
+	// unix socket paths are too short for t.TempDir on some machines
+	dir, err := os.MkdirTemp("", "soak")
+	require.NoError(t, err)
+	t.Cleanup(func() { os.RemoveAll(dir) })
+
+	socket := filepath.Join(dir, "nvsentinel.sock")
+
+	listener, err := net.Listen("unix", socket)
+	require.NoError(t, err)
+
+	connector := &fakeConnector{nodes: map[string]bool{}}
+	server := grpc.NewServer()
+	pb.RegisterPlatformConnectorServer(server, connector)
+
+	go func() { _ = server.Serve(listener) }()
+
+	t.Cleanup(server.Stop)
+
+	return socket, connector
+}
+
+// startComponent serves metrics of a component that leaks a MiB per scrape and
+// makes a decision taking 2s per scrape.
+func startComponent(t *testing.T) string {
+	t.Helper()
+
+	var scrapes atomic.Int64
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		n := scrapes.Add(1)
+
+		fmt.Fprintf(w, "# TYPE process_resident_memory_bytes gauge\nprocess_resident_memory_bytes %d\n", (100+n)<<20)
+		fmt.Fprintf(w, "# TYPE go_goroutines gauge\ngo_goroutines %d\n", 10+n)
+		fmt.Fprintln(w, "# TYPE fault_quarantine_pipeline_stage_latency_seconds histogram")
+
+		for _, from := range []string{"stage_ingested", "stage_event_emitted"} {
+			for _, bound := range []string{"1", "5", "+Inf"} {
+				count := n
+				if bound == "1" {
+					count = 0
+				}
+
+				fmt.Fprintf(w,
+					"fault_quarantine_pipeline_stage_latency_seconds_bucket{from=%q,to=\"stage_quarantined\",le=%q} %d\n",
+					from, bound, count)
+			}
+
+			fmt.Fprintf(w, "fault_quarantine_pipeline_stage_latency_seconds_sum{from=%q,to=\"stage_quarantined\"} %d\n",
+				from, 2*n)
+			fmt.Fprintf(w, "fault_quarantine_pipeline_stage_latency_seconds_count{from=%q,to=\"stage_quarantined\"} %d\n",
+				from, n)
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	return server.URL
+}
+
+func testScenario() Scenario {
+	heartbeats := loadgen.Mix{heartbeat}
+
+	return Scenario{
+		Name:  "test",
+		Nodes: 30,
+		Phases: []Phase{
+			{Name: "warm-up", Duration: Duration{300 * time.Millisecond}, Loads: []Load{{Rate: 200, Mix: heartbeats}}},
+			{Name: "storm", Duration: Duration{300 * time.Millisecond}, Loads: []Load{
+				{Nodes: 20, Rate: 200, Mix: heartbeats},
+				{FirstNode: 20, Nodes: 10, Rate: 100, Mix: loadgen.Mix{fallenOffTheBus}},
+			}},
+		},
+	}
+}
+
+func TestRun(t *testing.T) {
+	socket, connector := startConnector(t)
+
+	var (
+		storeReads  atomic.Int64
+		progressMu  sync.Mutex
+		progressed  = map[string]bool{}
+		storeStatsF = func(context.Context) (StoreStats, error) {
+			n := storeReads.Add(1)
+			return StoreStats{Documents: n * 10, Bytes: n * 10000}, nil
+		}
+	)
+
+	report, err := Run(context.Background(), Config{
+		Socket:         socket,
+		NodePrefix:     "soak",
+		Connections:    2,
+		Timeout:        time.Second,
+		Scenario:       testScenario(),
+		Targets:        []Target{{Name: "fault-quarantine", URL: startComponent(t)}},
+		StoreStats:     storeStatsF,
+		SampleInterval: 50 * time.Millisecond,
+		Thresholds: Thresholds{
+			MaxMemoryGrowth:    mibPerHour(1),
+			MaxDecisionLatency: 10 * time.Second,
+		},
+		Progress: func(phase string, _ Sample, errs []error) {
+			progressMu.Lock()
+			defer progressMu.Unlock()
+
+			assert.Empty(t, errs)
+			progressed[phase] = true
+		},
+		Seed: 1,
+	})
+	require.NoError(t, err)
+
+	assert.True(t, report.Completed)
+	assert.Equal(t, 30, report.Nodes)
+	assert.Positive(t, report.Events)
+	assert.Zero(t, report.Errors)
+	assert.Zero(t, report.SampleErrors)
+	require.Len(t, report.Phases, 2)
+	assert.Len(t, report.Phases[1].Loads, 2)
+	assert.True(t, progressed["storm"])
+
+	connector.mu.Lock()
+	assert.Contains(t, connector.nodes, "soak-0")
+	assert.Contains(t, connector.nodes, "soak-29")
+	connector.mu.Unlock()
+
+	// one decision per scrape, all between 1s and 5s
+	latency := report.Phases[1].DecisionLatency["fault_quarantine"]
+	assert.Positive(t, latency.Decisions)
+	assert.Greater(t, latency.P99, time.Second)
+	assert.LessOrEqual(t, latency.P99, 5*time.Second)
+
+	memory := report.Memory["fault-quarantine"]
+	require.NotNil(t, memory)
+	assert.Greater(t, memory.End, memory.Start)
+	assert.Equal(t, memory.End, memory.Peak)
+	assert.Greater(t, memory.GrowthPerHour, mibPerHour(1))
+	assert.Greater(t, memory.EndGoroutines, memory.StartGoroutines)
+
+	require.NotNil(t, report.Store)
+	assert.Greater(t, report.Store.End.Documents, report.Store.Start.Documents)
+	assert.Positive(t, report.Store.BytesPerEvent)
+
+	assert.False(t, report.Passed())
+	require.Len(t, report.Violations, 1)
+	assert.Contains(t, report.Violations[0], "fault-quarantine memory grows")
+	// the start and the end of every phase at least
+	assert.GreaterOrEqual(t, len(report.Samples), 3)
+}
+
+func TestRunInterrupted(t *testing.T) {
+	socket, _ := startConnector(t)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+
+	report, err := Run(ctx, Config{
+		Socket:         socket,
+		NodePrefix:     "soak",
+		Connections:    1,
+		Timeout:        time.Second,
+		Scenario:       testScenario(),
+		Targets:        []Target{{Name: "missing", URL: "http://127.0.0.1:1/metrics"}},
+		SampleInterval: 50 * time.Millisecond,
+	})
+	require.NoError(t, err)
+
+	assert.False(t, report.Completed)
+	assert.Len(t, report.Phases, 1)
+	assert.Positive(t, report.SampleErrors)
+	assert.Empty(t, report.Memory)
+	assert.Nil(t, report.Store)
+}
+
+func mibPerHour(n float64) float64 {
+	return n * (1 << 20)
+}
+
+func TestHistogramQuantile(t *testing.T) {
+	h := histogram{1: 50, 5: 90, 10: 100, math.Inf(1): 100}
+
+	p50, ok := h.quantile(0.5)
+	require.True(t, ok)
+	assert.Equal(t, time.Second, p50)
+
+	p70, _ := h.quantile(0.7)
+	assert.Equal(t, 3*time.Second, p70)
+
+	p99, _ := h.quantile(0.99)
+	assert.Equal(t, 9500*time.Millisecond, p99)
+
+	// observations beyond the largest bound are reported at the bound
+	beyond := histogram{1: 0, 5: 0, math.Inf(1): 10}
+	p99, _ = beyond.quantile(0.99)
+	assert.Equal(t, 5*time.Second, p99)
+
+	delta := h.sub(histogram{1: 50, 5: 50, 10: 50, math.Inf(1): 50})
+	p50, _ = delta.quantile(0.5)
+	assert.Equal(t, 3500*time.Millisecond, p50)
+
+	_, ok = histogram{1: 0, math.Inf(1): 0}.quantile(0.5)
+	assert.False(t, ok)
+}
+
+func TestPerHour(t *testing.T) {
+	start := time.Now()
+	points := []point{
+		{start, 100},
+		{start.Add(30 * time.Minute), 160},
+		{start.Add(time.Hour), 200},
+		{start.Add(90 * time.Minute), 310},
+	}
+
+	assert.InDelta(t, 134, perHour(points), 0.01)
+	assert.Zero(t, perHour(points[:1]))
+}
+
+func TestParseTarget(t *testing.T) {
+	target, err := ParseTarget("fault-quarantine=http://localhost:2112/metrics")
+	require.NoError(t, err)
+	assert.Equal(t, Target{Name: "fault-quarantine", URL: "http://localhost:2112/metrics"}, target)
+
+	for _, value := range []string{"", "fault-quarantine", "=http://localhost", "name="} {
+		_, err := ParseTarget(value)
+		assert.Error(t, err, value)
+	}
+}
+
+func TestDefaultScenario(t *testing.T) {
+	scenario := DefaultScenario(5000)
+	require.NoError(t, scenario.Validate())
+
+	assert.Equal(t, 5000, scenario.Nodes)
+	assert.Equal(t, 255*time.Minute, scenario.Duration())
+
+	storm := scenario.Phases[1]
+	assert.Equal(t, "fault-storm-1", storm.Name)
+	assert.Equal(t, Load{FirstNode: 4900, Nodes: 100, Rate: 100.0 / 30, Mix: loadgen.Mix{fallenOffTheBus}}, storm.Loads[1])
+	assert.Equal(t, 4900, storm.Loads[0].nodes(5000))
+}
+
+func TestLoadScenario(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "scenario.json")
+	require.NoError(t, os.WriteFile(path, []byte(`{
+		"name": "nightly", "nodes": 100,
+		"phases": [{"name": "storm", "duration": "90m", "loads": [
+			{"rate": 10, "mix": [{"weight": 1, "agent": "syslog-health-monitor", "checkName": "SysLogsXIDError",
+				"errorCodes": ["79"], "isFatal": true, "recommendedAction": "RESTART_BM"}]}]}]}`), 0o600))
+
+	scenario, err := LoadScenario(path)
+	require.NoError(t, err)
+	assert.Equal(t, "nightly", scenario.Name)
+	assert.Equal(t, 90*time.Minute, scenario.Duration())
+	assert.Equal(t, 100, scenario.Phases[0].Loads[0].nodes(scenario.Nodes))
+
+	require.NoError(t, os.WriteFile(path, []byte(`{"name": "bad", "nodes": 1, "phases": [
+		{"name": "p", "duration": 90, "loads": []}]}`), 0o600))
+	_, err = LoadScenario(path)
+	assert.ErrorContains(t, err, "duration must be a string")
+}
+
+func TestScenarioValidate(t *testing.T) {
+	tests := map[string]func(*Scenario){
+		"no nodes":           func(s *Scenario) { s.Nodes = 0 },
+		"no phases":          func(s *Scenario) { s.Phases = nil },
+		"no phase name":      func(s *Scenario) { s.Phases[0].Name = "" },
+		"no duration":        func(s *Scenario) { s.Phases[0].Duration = Duration{} },
+		"no loads":           func(s *Scenario) { s.Phases[0].Loads = nil },
+		"no rate":            func(s *Scenario) { s.Phases[0].Loads[0].Rate = 0 },
+		"empty mix":          func(s *Scenario) { s.Phases[0].Loads[0].Mix = nil },
+		"beyond fleet":       func(s *Scenario) { s.Phases[1].Loads[1].Nodes = 11 },
+		"first beyond fleet": func(s *Scenario) { s.Phases[0].Loads[0].FirstNode = 30 },
+		"negative nodes":     func(s *Scenario) { s.Phases[0].Loads[0].Nodes = -1 },
+	}
+
+	for name, mutate := range tests {
+		t.Run(name, func(t *testing.T) {
+			scenario := testScenario()
+			mutate(&scenario)
+			assert.Error(t, scenario.Validate())
+		})
+	}
+
+	scenario := testScenario()
+	assert.NoError(t, scenario.Validate())
+}
+
+func TestFleet(t *testing.T) {
+	ctx := context.Background()
+	client := fake.NewSimpleClientset(fleetNode("soak-1"))
+
+	require.NoError(t, CreateFleet(ctx, client, "soak", 3))
+
+	nodes, err := client.CoreV1().Nodes().List(ctx, metav1.ListOptions{LabelSelector: FleetLabel + "=true"})
+	require.NoError(t, err)
+	assert.Len(t, nodes.Items, 3)
+	assert.Equal(t, "fake", nodes.Items[0].Annotations["kwok.x-k8s.io/node"])
+
+	require.NoError(t, DeleteFleet(ctx, client))
+
+	nodes, err = client.CoreV1().Nodes().List(ctx, metav1.ListOptions{})
+	require.NoError(t, err)
+	assert.Empty(t, nodes.Items)
+}
@@ -45,6 +45,7 @@ if [ ! -f go.work ]; then
     ./fault-remediation \
     ./health-events-analyzer \
     ./health-monitors/csp-health-monitor \
+    ./health-monitors/fabric-health-monitor \
     ./janitor \
     ./labeler \
     ./node-drainer \
@@ -57,6 +58,9 @@ ko build "${KO_FLAGS[@]}" \
   ./health-events-analyzer \
   ./health-monitors/csp-health-monitor/cmd/csp-health-monitor \
   ./health-monitors/csp-health-monitor/cmd/maintenance-notifier \
+  ./health-monitors/csp-health-monitor/cmd/preemption-watcher \
+  ./health-monitors/fabric-health-monitor \
+  ./health-monitors/kubernetes-object-monitor/cmd/npd-adapter \
   ./janitor \
   ./labeler \
   ./node-drainer \
@@ -62,6 +62,9 @@ KO_IMAGES=(
     "nvsentinel/health-events-analyzer"
     "nvsentinel/csp-health-monitor"
     "nvsentinel/maintenance-notifier"
+    "nvsentinel/preemption-watcher"
+    "nvsentinel/fabric-health-monitor"
+    "nvsentinel/npd-adapter"
     "nvsentinel/labeler"
     "nvsentinel/node-drainer"
     "nvsentinel/janitor"
@@ -0,0 +1,293 @@
+// Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package bolt is a store backed by a single bbolt file. Records are kept in
+// ID order and filtered by scanning, which is fast enough for the few
+// thousand events of a small cluster between two retention runs.
+package bolt
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/nvidia/nvsentinel/data-models/pkg/model"
+	"github.com/nvidia/nvsentinel/store-client/pkg/store"
+	bolt "go.etcd.io/bbolt"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+var (
+	eventsBucket    = []byte("events")
+	positionsBucket = []byte("positions")
+)
+
+// Store is a bbolt backed store.Store.
+type Store struct {
+	db *bolt.DB
+}
+
+var _ store.Store = (*Store)(nil)
+
+// Open opens the store at path, creating it if needed.
+func Open(path string) (*Store, error) {
+	db, err := bolt.Open(path, 0o600, &bolt.Options{Timeout: 5 * time.Second})
+	if err != nil {
+		return nil, fmt.Errorf("failed to open %s: %w", path, err)
+	}
+
+	err = db.Update(func(tx *bolt.Tx) error {
+		for _, bucket := range [][]byte{eventsBucket, positionsBucket} {
+			if _, err := tx.CreateBucketIfNotExists(bucket); err != nil {
+				return fmt.Errorf("failed to create bucket %s: %w", bucket, err)
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		_ = db.Close()
+		return nil, err
+	}
+
+	return &Store{db: db}, nil
+}
+
+func key(id uint64) []byte {
+	return binary.BigEndian.AppendUint64(nil, id)
+}
+
+func decode(k, v []byte) (store.Record, error) {
+	record := store.Record{ID: binary.BigEndian.Uint64(k)}
+	if err := bson.Unmarshal(v, &record.HealthEventWithStatus); err != nil {
+		return store.Record{}, fmt.Errorf("failed to decode record %d: %w", record.ID, err)
+	}
+
+	return record, nil
+}
+
+func (s *Store) view(fn func(tx *bolt.Tx) error) error {
+	err := s.db.View(fn)
+	if errors.Is(err, bolt.ErrDatabaseNotOpen) {
+		return store.ErrClosed
+	}
+
+	return err
+}
+
+func (s *Store) update(fn func(tx *bolt.Tx) error) error {
+	err := s.db.Update(fn)
+	if errors.Is(err, bolt.ErrDatabaseNotOpen) {
+		return store.ErrClosed
+	}
+
+	return err
+}
+
+// Insert implements store.Store.
+func (s *Store) Insert(_ context.Context, events []model.HealthEventWithStatus) ([]uint64, error) {
+	ids := make([]uint64, 0, len(events))
+
+	err := s.update(func(tx *bolt.Tx) error {
+		bucket := tx.Bucket(eventsBucket)
+
+		for _, event := range events {
+			id, err := bucket.NextSequence()
+			if err != nil {
+				return fmt.Errorf("failed to assign record ID: %w", err)
+			}
+
+			data, err := bson.Marshal(event)
+			if err != nil {
+				return fmt.Errorf("failed to encode health event: %w", err)
+			}
+
+			if err := bucket.Put(key(id), data); err != nil {
+				return fmt.Errorf("failed to store record %d: %w", id, err)
+			}
+
+			ids = append(ids, id)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return ids, nil
+}
+
+// scan calls fn for the records selected by the filter in ID order until fn
+// returns false.
+func scan(bucket *bolt.Bucket, filter store.Filter, descending bool, fn func(store.Record) (bool, error)) error {
+	cursor := bucket.Cursor()
+
+	first, next := cursor.Seek, cursor.Next
+	start := key(filter.AfterID + 1)
+
+	if descending {
+		first = func([]byte) ([]byte, []byte) { return cursor.Last() }
+		next = cursor.Prev
+	}
+
+	for k, v := first(start); k != nil; k, v = next() {
+		if descending && binary.BigEndian.Uint64(k) <= filter.AfterID {
+			break
+		}
+
+		record, err := decode(k, v)
+		if err != nil {
+			return err
+		}
+
+		if !filter.Matches(record) {
+			continue
+		}
+
+		more, err := fn(record)
+		if err != nil || !more {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// List implements store.Store.
+func (s *Store) List(_ context.Context, filter store.Filter, opts store.ListOptions) ([]store.Record, error) {
+	var records []store.Record
+
+	err := s.view(func(tx *bolt.Tx) error {
+		return scan(tx.Bucket(eventsBucket), filter, opts.Descending, func(record store.Record) (bool, error) {
+			records = append(records, record)
+			return opts.Limit <= 0 || len(records) < opts.Limit, nil
+		})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return records, nil
+}
+
+// Update implements store.Store.
+func (s *Store) Update(
+	_ context.Context,
+	filter store.Filter,
+	update func(*model.HealthEventStatus),
+) (int, error) {
+	updated := 0
+
+	err := s.update(func(tx *bolt.Tx) error {
+		bucket := tx.Bucket(eventsBucket)
+
+		var records []store.Record
+
+		err := scan(bucket, filter, false, func(record store.Record) (bool, error) {
+			records = append(records, record)
+			return true, nil
+		})
+		if err != nil {
+			return err
+		}
+
+		// Records are written after the scan, bbolt cursors do not support
+		// modifying the bucket they iterate
+		for _, record := range records {
+			update(&record.HealthEventStatus)
+
+			data, err := bson.Marshal(record.HealthEventWithStatus)
+			if err != nil {
+				return fmt.Errorf("failed to encode record %d: %w", record.ID, err)
+			}
+
+			if err := bucket.Put(key(record.ID), data); err != nil {
+				return fmt.Errorf("failed to update record %d: %w", record.ID, err)
+			}
+		}
+
+		updated = len(records)
+
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return updated, nil
+}
+
+// Delete implements store.Store.
+func (s *Store) Delete(_ context.Context, filter store.Filter) (int, error) {
+	deleted := 0
+
+	err := s.update(func(tx *bolt.Tx) error {
+		bucket := tx.Bucket(eventsBucket)
+
+		var ids []uint64
+
+		err := scan(bucket, filter, false, func(record store.Record) (bool, error) {
+			ids = append(ids, record.ID)
+			return true, nil
+		})
+		if err != nil {
+			return err
+		}
+
+		for _, id := range ids {
+			if err := bucket.Delete(key(id)); err != nil {
+				return fmt.Errorf("failed to delete record %d: %w", id, err)
+			}
+		}
+
+		deleted = len(ids)
+
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return deleted, nil
+}
+
+// Position implements store.Store.
+func (s *Store) Position(_ context.Context, client string) (uint64, error) {
+	var position uint64
+
+	err := s.view(func(tx *bolt.Tx) error {
+		if v := tx.Bucket(positionsBucket).Get([]byte(client)); v != nil {
+			position = binary.BigEndian.Uint64(v)
+		}
+
+		return nil
+	})
+
+	return position, err
+}
+
+// SetPosition implements store.Store.
+func (s *Store) SetPosition(_ context.Context, client string, id uint64) error {
+	return s.update(func(tx *bolt.Tx) error {
+		return tx.Bucket(positionsBucket).Put([]byte(client), key(id))
+	})
+}
+
+// Close implements store.Store.
+func (s *Store) Close() error {
+	return s.db.Close()
+}
@@ -0,0 +1,371 @@
+// Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package sqlite is a store backed by a SQLite database in WAL mode, using a
+// pure Go driver so binaries stay static. The fields records are filtered on
+// are kept in indexed columns next to the BSON encoded record, so filters do
+// not scan the table like the bolt store does.
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/nvidia/nvsentinel/data-models/pkg/model"
+	"github.com/nvidia/nvsentinel/store-client/pkg/store"
+	"go.mongodb.org/mongo-driver/bson"
+
+	// Registers the "sqlite" database/sql driver
+	_ "modernc.org/sqlite"
+)
+
+// migrations upgrade the schema, the schema version recorded in the database
+// is the number of migrations applied. Migrations are only ever appended.
+var migrations = []string{
+	`CREATE TABLE events (
+		id               INTEGER PRIMARY KEY AUTOINCREMENT,
+		created_at       INTEGER NOT NULL,
+		node_name        TEXT NOT NULL,
+		agent            TEXT NOT NULL,
+		check_name       TEXT NOT NULL,
+		is_healthy       INTEGER NOT NULL,
+		node_quarantined TEXT,
+		document         BLOB NOT NULL
+	);
+	CREATE INDEX events_node_name ON events (node_name, id);
+	CREATE INDEX events_created_at ON events (created_at);
+	CREATE INDEX events_node_quarantined ON events (node_quarantined);
+	CREATE TABLE positions (
+		client TEXT PRIMARY KEY,
+		id     INTEGER NOT NULL
+	);`,
+}
+
+// Store is a SQLite backed store.Store.
+type Store struct {
+	db *sql.DB
+}
+
+var _ store.Store = (*Store)(nil)
+
+// Open opens the database at path, creating it if needed, and migrates it to
+// the current schema.
+func Open(path string) (*Store, error) {
+	// Write transactions take the lock up front, so concurrent writers wait
+	// for each other instead of failing to upgrade a read lock
+	dsn := "file:" + path + "?" + url.Values{
+		"_pragma": {"journal_mode(WAL)", "busy_timeout(5000)", "synchronous(NORMAL)"},
+		"_txlock": {"immediate"},
+	}.Encode()
+
+	db, err := sql.Open("sqlite", dsn)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open %s: %w", path, err)
+	}
+
+	if err := migrate(db); err != nil {
+		_ = db.Close()
+		return nil, fmt.Errorf("failed to migrate %s: %w", path, err)
+	}
+
+	return &Store{db: db}, nil
+}
+
+func migrate(db *sql.DB) error {
+	tx, err := db.Begin()
+	if err != nil {
+		return err
+	}
+
+	defer func() { _ = tx.Rollback() }()
+
+	var version int
+	if err := tx.QueryRow("PRAGMA user_version").Scan(&version); err != nil {
+		return fmt.Errorf("failed to read schema version: %w", err)
+	}
+
+	if version > len(migrations) {
+		return fmt.Errorf("schema version %d is newer than the supported version %d", version, len(migrations))
+	}
+
+	for i := version; i < len(migrations); i++ {
+		if _, err := tx.Exec(migrations[i]); err != nil {
+			return fmt.Errorf("migration %d failed: %w", i+1, err)
+		}
+	}
+
+	// PRAGMA does not take parameters
+	if _, err := tx.Exec(fmt.Sprintf("PRAGMA user_version = %d", len(migrations))); err != nil {
+		return fmt.Errorf("failed to record schema version: %w", err)
+	}
+
+	return tx.Commit()
+}
+
+func wrap(err error) error {
+	if err != nil && strings.Contains(err.Error(), "database is closed") {
+		return store.ErrClosed
+	}
+
+	return err
+}
+
+// where returns the condition selecting the records of the filter.
+func where(filter store.Filter) (string, []any) {
+	conditions := []string{"id > ?"}
+	args := []any{filter.AfterID}
+
+	if len(filter.IDs) > 0 {
+		conditions = append(conditions, "id IN ("+placeholders(len(filter.IDs))+")")
+		for _, id := range filter.IDs {
+			args = append(args, id)
+		}
+	}
+
+	for _, column := range []struct {
+		name  string
+		value string
+	}{
+		{"node_name", filter.NodeName},
+		{"agent", filter.Agent},
+		{"check_name", filter.CheckName},
+	} {
+		if column.value != "" {
+			conditions = append(conditions, column.name+" = ?")
+			args = append(args, column.value)
+		}
+	}
+
+	if filter.IsHealthy != nil {
+		conditions = append(conditions, "is_healthy = ?")
+		args = append(args, *filter.IsHealthy)
+	}
+
+	if len(filter.NodeQuarantined) > 0 {
+		conditions = append(conditions, "node_quarantined IN ("+placeholders(len(filter.NodeQuarantined))+")")
+		for _, status := range filter.NodeQuarantined {
+			args = append(args, string(status))
+		}
+	}
+
+	if !filter.CreatedSince.IsZero() {
+		conditions = append(conditions, "created_at >= ?")
+		args = append(args, filter.CreatedSince.UnixNano())
+	}
+
+	if !filter.CreatedBefore.IsZero() {
+		conditions = append(conditions, "created_at < ?")
+		args = append(args, filter.CreatedBefore.UnixNano())
+	}
+
+	return strings.Join(conditions, " AND "), args
+}
+
+func placeholders(n int) string {
+	return strings.TrimSuffix(strings.Repeat("?, ", n), ", ")
+}
+
+func nodeQuarantined(status model.HealthEventStatus) any {
+	if status.NodeQuarantined == nil {
+		return nil
+	}
+
+	return string(*status.NodeQuarantined)
+}
+
+// Insert implements store.Store.
+func (s *Store) Insert(ctx context.Context, events []model.HealthEventWithStatus) ([]uint64, error) {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, wrap(err)
+	}
+
+	defer func() { _ = tx.Rollback() }()
+
+	ids := make([]uint64, 0, len(events))
+
+	for _, event := range events {
+		data, err := bson.Marshal(event)
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode health event: %w", err)
+		}
+
+		result, err := tx.ExecContext(ctx,
+			`INSERT INTO events (created_at, node_name, agent, check_name, is_healthy, node_quarantined, document)
+			VALUES (?, ?, ?, ?, ?, ?, ?)`,
+			event.CreatedAt.UnixNano(),
+			event.HealthEvent.GetNodeName(),
+			event.HealthEvent.GetAgent(),
+			event.HealthEvent.GetCheckName(),
+			event.HealthEvent.GetIsHealthy(),
+			nodeQuarantined(event.HealthEventStatus),
+			data,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to store health event: %w", err)
+		}
+
+		id, err := result.LastInsertId()
+		if err != nil {
+			return nil, fmt.Errorf("failed to read record ID: %w", err)
+		}
+
+		ids = append(ids, uint64(id))
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, wrap(err)
+	}
+
+	return ids, nil
+}
+
+type querier interface {
+	QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error)
+}
+
+func list(ctx context.Context, q querier, filter store.Filter, opts store.ListOptions) ([]store.Record, error) {
+	condition, args := where(filter)
+
+	query := "SELECT id, document FROM events WHERE " + condition + " ORDER BY id"
+	if opts.Descending {
+		query += " DESC"
+	}
+
+	if opts.Limit > 0 {
+		query += " LIMIT ?"
+
+		args = append(args, opts.Limit)
+	}
+
+	rows, err := q.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, wrap(err)
+	}
+
+	defer rows.Close()
+
+	var records []store.Record
+
+	for rows.Next() {
+		var (
+			record store.Record
+			data   []byte
+		)
+
+		if err := rows.Scan(&record.ID, &data); err != nil {
+			return nil, fmt.Errorf("failed to read record: %w", err)
+		}
+
+		if err := bson.Unmarshal(data, &record.HealthEventWithStatus); err != nil {
+			return nil, fmt.Errorf("failed to decode record %d: %w", record.ID, err)
+		}
+
+		records = append(records, record)
+	}
+
+	return records, wrap(rows.Err())
+}
+
+// List implements store.Store.
+func (s *Store) List(ctx context.Context, filter store.Filter, opts store.ListOptions) ([]store.Record, error) {
+	return list(ctx, s.db, filter, opts)
+}
+
+// Update implements store.Store.
+func (s *Store) Update(
+	ctx context.Context,
+	filter store.Filter,
+	update func(*model.HealthEventStatus),
+) (int, error) {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, wrap(err)
+	}
+
+	defer func() { _ = tx.Rollback() }()
+
+	records, err := list(ctx, tx, filter, store.ListOptions{})
+	if err != nil {
+		return 0, err
+	}
+
+	for _, record := range records {
+		update(&record.HealthEventStatus)
+
+		data, err := bson.Marshal(record.HealthEventWithStatus)
+		if err != nil {
+			return 0, fmt.Errorf("failed to encode record %d: %w", record.ID, err)
+		}
+
+		_, err = tx.ExecContext(ctx, "UPDATE events SET node_quarantined = ?, document = ? WHERE id = ?",
+			nodeQuarantined(record.HealthEventStatus), data, record.ID)
+		if err != nil {
+			return 0, fmt.Errorf("failed to update record %d: %w", record.ID, err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, wrap(err)
+	}
+
+	return len(records), nil
+}
+
+// Delete implements store.Store.
+func (s *Store) Delete(ctx context.Context, filter store.Filter) (int, error) {
+	condition, args := where(filter)
+
+	result, err := s.db.ExecContext(ctx, "DELETE FROM events WHERE "+condition, args...)
+	if err != nil {
+		return 0, wrap(err)
+	}
+
+	deleted, err := result.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("failed to read deleted records: %w", err)
+	}
+
+	return int(deleted), nil
+}
+
+// Position implements store.Store.
+func (s *Store) Position(ctx context.Context, client string) (uint64, error) {
+	var position uint64
+
+	err := s.db.QueryRowContext(ctx, "SELECT id FROM positions WHERE client = ?", client).Scan(&position)
+	if errors.Is(err, sql.ErrNoRows) {
+		return 0, nil
+	}
+
+	return position, wrap(err)
+}
+
+// SetPosition implements store.Store.
+func (s *Store) SetPosition(ctx context.Context, client string, id uint64) error {
+	_, err := s.db.ExecContext(ctx,
+		"INSERT INTO positions (client, id) VALUES (?, ?) ON CONFLICT (client) DO UPDATE SET id = excluded.id",
+		client, id)
+
+	return wrap(err)
+}
+
+// Close implements store.Store.
+func (s *Store) Close() error {
+	return s.db.Close()
+}
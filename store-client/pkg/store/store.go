@@ -0,0 +1,181 @@
+// Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package store defines the interface of embedded health event stores, used
+// where running MongoDB is not an option. Stores keep health events with their
+// status in insertion order, and consumers follow new events by polling from
+// the last position they processed instead of a change stream.
+//
+// Implementations must pass the conformance suite of package storetest.
+package store
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"slices"
+	"time"
+
+	"github.com/nvidia/nvsentinel/data-models/pkg/model"
+)
+
+// ErrClosed is returned by the operations of a closed store.
+var ErrClosed = errors.New("store is closed")
+
+// Record is a stored health event. IDs are assigned in insertion order,
+// starting at 1.
+type Record struct {
+	ID uint64
+	model.HealthEventWithStatus
+}
+
+// Filter selects records. Zero fields match all records.
+type Filter struct {
+	// IDs restricts the records to these IDs
+	IDs []uint64
+	// AfterID selects the records inserted after the record with this ID
+	AfterID   uint64
+	NodeName  string
+	Agent     string
+	CheckName string
+	IsHealthy *bool
+	// NodeQuarantined restricts the records to these quarantine statuses
+	NodeQuarantined []model.Status
+	// CreatedSince selects the records created at or after this time
+	CreatedSince time.Time
+	// CreatedBefore selects the records created before this time
+	CreatedBefore time.Time
+}
+
+// Matches returns true if the record is selected by the filter.
+//
+//nolint:cyclop // one condition per filter field
+func (f Filter) Matches(record Record) bool {
+	if len(f.IDs) > 0 && !slices.Contains(f.IDs, record.ID) {
+		return false
+	}
+
+	if record.ID <= f.AfterID {
+		return false
+	}
+
+	event := record.HealthEvent
+	if (f.NodeName != "" && event.GetNodeName() != f.NodeName) ||
+		(f.Agent != "" && event.GetAgent() != f.Agent) ||
+		(f.CheckName != "" && event.GetCheckName() != f.CheckName) ||
+		(f.IsHealthy != nil && event.GetIsHealthy() != *f.IsHealthy) {
+		return false
+	}
+
+	if len(f.NodeQuarantined) > 0 {
+		status := record.HealthEventStatus.NodeQuarantined
+		if status == nil || !slices.Contains(f.NodeQuarantined, *status) {
+			return false
+		}
+	}
+
+	if !f.CreatedSince.IsZero() && record.CreatedAt.Before(f.CreatedSince) {
+		return false
+	}
+
+	if !f.CreatedBefore.IsZero() && !record.CreatedAt.Before(f.CreatedBefore) {
+		return false
+	}
+
+	return true
+}
+
+// ListOptions control the records returned by List.
+type ListOptions struct {
+	// Limit is the maximum number of records returned, 0 for all
+	Limit int
+	// Descending returns the latest records first
+	Descending bool
+}
+
+// Store is an embedded health event store.
+type Store interface {
+	// Insert stores the events and returns their IDs. The events are stored
+	// atomically and get consecutive IDs.
+	Insert(ctx context.Context, events []model.HealthEventWithStatus) ([]uint64, error)
+	// List returns the records selected by the filter in ID order.
+	List(ctx context.Context, filter Filter, opts ListOptions) ([]Record, error)
+	// Update applies update to the status of the selected records and returns
+	// the number of records updated.
+	Update(ctx context.Context, filter Filter, update func(*model.HealthEventStatus)) (int, error)
+	// Delete removes the selected records and returns the number removed.
+	Delete(ctx context.Context, filter Filter) (int, error)
+	// Position returns the ID of the last record the client processed, 0 if
+	// it has not processed any.
+	Position(ctx context.Context, client string) (uint64, error)
+	// SetPosition records the ID of the last record the client processed.
+	SetPosition(ctx context.Context, client string, id uint64) error
+	// Close releases the store.
+	Close() error
+}
+
+// Watch calls handle for each record selected by the filter that is inserted
+// after the last position of the client, in ID order, and records the
+// position after each. New records are polled every interval until the
+// context is cancelled. A record whose handler fails is retried at the next
+// poll.
+func Watch(
+	ctx context.Context,
+	s Store,
+	client string,
+	filter Filter,
+	interval time.Duration,
+	handle func(context.Context, Record) error,
+) error {
+	position, err := s.Position(ctx, client)
+	if err != nil {
+		return fmt.Errorf("failed to load position of %s: %w", client, err)
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		filter.AfterID = position
+
+		records, err := s.List(ctx, filter, ListOptions{})
+		if err != nil {
+			slog.Error("Failed to list new health events", "client", client, "error", err)
+		}
+
+		for _, record := range records {
+			if err := handle(ctx, record); err != nil {
+				slog.Error("Failed to handle health event, retrying at next poll",
+					"client", client, "id", record.ID, "error", err)
+
+				break
+			}
+
+			position = record.ID
+
+			// The record is handled, so its position is stored even when the
+			// watch is being cancelled
+			if err := s.SetPosition(context.WithoutCancel(ctx), client, position); err != nil {
+				slog.Error("Failed to store position", "client", client, "id", position, "error", err)
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,113 @@
+// Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package store_test
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/nvidia/nvsentinel/data-models/pkg/model"
+	"github.com/nvidia/nvsentinel/data-models/pkg/protos"
+	"github.com/nvidia/nvsentinel/store-client/pkg/store"
+	"github.com/nvidia/nvsentinel/store-client/pkg/store/bolt"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFilterMatches(t *testing.T) {
+	createdAt := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	quarantined := model.Quarantined
+	healthy := true
+
+	record := store.Record{ID: 5, HealthEventWithStatus: model.HealthEventWithStatus{
+		CreatedAt:         createdAt,
+		HealthEvent:       &protos.HealthEvent{NodeName: "node-1", Agent: "agent", CheckName: "check"},
+		HealthEventStatus: model.HealthEventStatus{NodeQuarantined: &quarantined},
+	}}
+
+	matching := []store.Filter{
+		{},
+		{IDs: []uint64{4, 5}},
+		{AfterID: 4, NodeName: "node-1", Agent: "agent", CheckName: "check"},
+		{NodeQuarantined: []model.Status{model.Quarantined, model.AlreadyQuarantined}},
+		{CreatedSince: createdAt, CreatedBefore: createdAt.Add(time.Second)},
+	}
+
+	for _, filter := range matching {
+		assert.True(t, filter.Matches(record), "%+v", filter)
+	}
+
+	other := []store.Filter{
+		{IDs: []uint64{4}},
+		{AfterID: 5},
+		{NodeName: "node-2"},
+		{IsHealthy: &healthy},
+		{NodeQuarantined: []model.Status{model.UnQuarantined}},
+		{CreatedSince: createdAt.Add(time.Second)},
+		{CreatedBefore: createdAt},
+	}
+
+	for _, filter := range other {
+		assert.False(t, filter.Matches(record), "%+v", filter)
+	}
+}
+
+func TestWatch(t *testing.T) {
+	s, err := bolt.Open(filepath.Join(t.TempDir(), "events.db"))
+	require.NoError(t, err)
+
+	defer s.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	for _, node := range []string{"node-1", "node-2", "node-1"} {
+		_, err := s.Insert(ctx, []model.HealthEventWithStatus{{HealthEvent: &protos.HealthEvent{NodeName: node}}})
+		require.NoError(t, err)
+	}
+
+	var handled []uint64
+
+	failed := false
+	done := make(chan error)
+
+	go func() {
+		done <- store.Watch(ctx, s, "test", store.Filter{NodeName: "node-1"}, time.Millisecond,
+			func(_ context.Context, record store.Record) error {
+				// The first attempt of the last record fails and is retried
+				if record.ID == 3 && !failed {
+					failed = true
+					return errors.New("transient")
+				}
+
+				handled = append(handled, record.ID)
+				if len(handled) == 2 {
+					cancel()
+				}
+
+				return nil
+			})
+	}()
+
+	require.NoError(t, <-done)
+	assert.Equal(t, []uint64{1, 3}, handled)
+
+	position, err := s.Position(context.Background(), "test")
+	require.NoError(t, err)
+	assert.Equal(t, uint64(3), position)
+}
@@ -0,0 +1,478 @@
+// Copyright (c) 2025, NVIDIA CORPORATION.  All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package storetest is the conformance suite of store.Store implementations.
+// Every backend, including ones maintained outside of this repository, runs it
+// from its own tests:
+//
+//	func TestConformance(t *testing.T) {
+//		storetest.Run(t, func(path string) (store.Store, error) {
+//			return mybackend.Open(path)
+//		})
+//	}
+//
+// The suite covers the semantics consumers rely on: record IDs, ordering,
+// filtering, pagination, concurrent writes, retention and persistence.
+package storetest
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/nvidia/nvsentinel/data-models/pkg/model"
+	"github.com/nvidia/nvsentinel/data-models/pkg/protos"
+	"github.com/nvidia/nvsentinel/store-client/pkg/store"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testNow = time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+
+func event(node, check string, healthy bool, createdAt time.Time) model.HealthEventWithStatus {
+	return model.HealthEventWithStatus{
+		CreatedAt: createdAt,
+		HealthEvent: &protos.HealthEvent{
+			NodeName:  node,
+			Agent:     "syslog-health-monitor",
+			CheckName: check,
+			IsHealthy: healthy,
+			ErrorCode: []string{"79"},
+		},
+	}
+}
+
+// OpenFunc opens the store at path, creating it if needed. Opening the path of
+// a closed store must return the records stored before.
+type OpenFunc func(path string) (store.Store, error)
+
+func openTestStore(t *testing.T, open OpenFunc) (store.Store, string) {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), "events.db")
+
+	s, err := open(path)
+	require.NoError(t, err)
+
+	t.Cleanup(func() { _ = s.Close() })
+
+	return s, path
+}
+
+// Run runs the conformance suite against the stores opened by open. Each test
+// opens a new store in its own directory.
+func Run(t *testing.T, open OpenFunc) {
+	t.Run("InsertAndList", func(t *testing.T) { testInsertAndList(t, open) })
+	t.Run("Ordering", func(t *testing.T) { testOrdering(t, open) })
+	t.Run("Filtering", func(t *testing.T) { testFiltering(t, open) })
+	t.Run("Pagination", func(t *testing.T) { testPagination(t, open) })
+	t.Run("UpdateAndDelete", func(t *testing.T) { testUpdateAndDelete(t, open) })
+	t.Run("ConcurrentWrites", func(t *testing.T) { testConcurrentWrites(t, open) })
+	t.Run("Retention", func(t *testing.T) { testRetention(t, open) })
+	t.Run("Persistence", func(t *testing.T) { testPersistence(t, open) })
+	t.Run("Watch", func(t *testing.T) { testWatch(t, open) })
+}
+
+func ids(records []store.Record) []uint64 {
+	result := make([]uint64, 0, len(records))
+	for _, record := range records {
+		result = append(result, record.ID)
+	}
+
+	return result
+}
+
+func testInsertAndList(t *testing.T, open OpenFunc) {
+	ctx := context.Background()
+	s, _ := openTestStore(t, open)
+
+	ids, err := s.Insert(ctx, []model.HealthEventWithStatus{
+		event("node-1", "SysLogsXIDError", false, testNow),
+		event("node-2", "SysLogsXIDError", false, testNow.Add(time.Minute)),
+		event("node-1", "SysLogsXIDError", true, testNow.Add(2*time.Minute)),
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []uint64{1, 2, 3}, ids)
+
+	records, err := s.List(ctx, store.Filter{NodeName: "node-1"}, store.ListOptions{})
+	require.NoError(t, err)
+	require.Len(t, records, 2)
+	assert.Equal(t, uint64(1), records[0].ID)
+	assert.Equal(t, []string{"79"}, records[0].HealthEvent.ErrorCode)
+	assert.True(t, records[1].HealthEvent.IsHealthy)
+	assert.True(t, testNow.Equal(records[0].CreatedAt))
+
+	records, err = s.List(ctx, store.Filter{}, store.ListOptions{Limit: 1, Descending: true})
+	require.NoError(t, err)
+	require.Len(t, records, 1)
+	assert.Equal(t, uint64(3), records[0].ID)
+
+	records, err = s.List(ctx, store.Filter{AfterID: 2}, store.ListOptions{Descending: true})
+	require.NoError(t, err)
+	require.Len(t, records, 1)
+	assert.Equal(t, uint64(3), records[0].ID)
+}
+
+func testUpdateAndDelete(t *testing.T, open OpenFunc) {
+	ctx := context.Background()
+	s, _ := openTestStore(t, open)
+
+	_, err := s.Insert(ctx, []model.HealthEventWithStatus{
+		event("node-1", "SysLogsXIDError", false, testNow),
+		event("node-1", "SysLogsSXIDError", false, testNow.Add(time.Hour)),
+	})
+	require.NoError(t, err)
+
+	quarantined := model.Quarantined
+
+	updated, err := s.Update(ctx, store.Filter{IDs: []uint64{1}}, func(status *model.HealthEventStatus) {
+		status.NodeQuarantined = &quarantined
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 1, updated)
+
+	records, err := s.List(ctx, store.Filter{NodeQuarantined: []model.Status{model.Quarantined}}, store.ListOptions{})
+	require.NoError(t, err)
+	require.Len(t, records, 1)
+	assert.Equal(t, uint64(1), records[0].ID)
+
+	deleted, err := s.Delete(ctx, store.Filter{CreatedBefore: testNow.Add(time.Minute)})
+	require.NoError(t, err)
+	assert.Equal(t, 1, deleted)
+
+	records, err = s.List(ctx, store.Filter{}, store.ListOptions{})
+	require.NoError(t, err)
+	require.Len(t, records, 1)
+	assert.Equal(t, "SysLogsSXIDError", records[0].HealthEvent.CheckName)
+}
+
+func testPersistence(t *testing.T, open OpenFunc) {
+	ctx := context.Background()
+	s, path := openTestStore(t, open)
+
+	_, err := s.Insert(ctx, []model.HealthEventWithStatus{event("node-1", "SysLogsXIDError", false, testNow)})
+	require.NoError(t, err)
+	require.NoError(t, s.SetPosition(ctx, "fault-quarantine", 1))
+	require.NoError(t, s.Close())
+
+	_, err = s.List(ctx, store.Filter{}, store.ListOptions{})
+	assert.ErrorIs(t, err, store.ErrClosed)
+
+	s, err = open(path)
+	require.NoError(t, err)
+
+	defer s.Close()
+
+	position, err := s.Position(ctx, "fault-quarantine")
+	require.NoError(t, err)
+	assert.Equal(t, uint64(1), position)
+
+	position, err = s.Position(ctx, "other")
+	require.NoError(t, err)
+	assert.Zero(t, position)
+
+	// IDs continue after the stored records, even deleted ones
+	deleted, err := s.Delete(ctx, store.Filter{})
+	require.NoError(t, err)
+	assert.Equal(t, 1, deleted)
+
+	ids, err := s.Insert(ctx, []model.HealthEventWithStatus{event("node-1", "SysLogsXIDError", true, testNow)})
+	require.NoError(t, err)
+	assert.Equal(t, []uint64{2}, ids)
+}
+
+func testOrdering(t *testing.T, open OpenFunc) {
+	ctx := context.Background()
+	s, _ := openTestStore(t, open)
+
+	// Records are ordered by insertion, not by creation time
+	for i := range 5 {
+		_, err := s.Insert(ctx, []model.HealthEventWithStatus{
+			event("node-1", "SysLogsXIDError", false, testNow.Add(-time.Duration(i)*time.Minute)),
+		})
+		require.NoError(t, err)
+	}
+
+	records, err := s.List(ctx, store.Filter{}, store.ListOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, []uint64{1, 2, 3, 4, 5}, ids(records))
+
+	records, err = s.List(ctx, store.Filter{}, store.ListOptions{Descending: true})
+	require.NoError(t, err)
+	assert.Equal(t, []uint64{5, 4, 3, 2, 1}, ids(records))
+
+	records, err = s.List(ctx, store.Filter{AfterID: 2}, store.ListOptions{Limit: 2, Descending: true})
+	require.NoError(t, err)
+	assert.Equal(t, []uint64{5, 4}, ids(records))
+
+	records, err = s.List(ctx, store.Filter{AfterID: 5}, store.ListOptions{})
+	require.NoError(t, err)
+	assert.Empty(t, records)
+}
+
+func testFiltering(t *testing.T, open OpenFunc) {
+	ctx := context.Background()
+	s, _ := openTestStore(t, open)
+
+	quarantined, cancelled := model.Quarantined, model.Cancelled
+
+	events := []model.HealthEventWithStatus{
+		event("node-1", "SysLogsXIDError", false, testNow),
+		event("node-2", "SysLogsSXIDError", false, testNow.Add(time.Minute)),
+		event("node-1", "SysLogsXIDError", true, testNow.Add(2*time.Minute)),
+		event("node-2", "GpuXidError", false, testNow.Add(3*time.Minute)),
+	}
+	events[0].HealthEventStatus.NodeQuarantined = &quarantined
+	events[1].HealthEventStatus.NodeQuarantined = &cancelled
+	events[3].HealthEvent.Agent = "gpu-health-monitor"
+
+	_, err := s.Insert(ctx, events)
+	require.NoError(t, err)
+
+	healthy, unhealthy := true, false
+
+	for name, tc := range map[string]struct {
+		filter store.Filter
+		want   []uint64
+	}{
+		"all":              {store.Filter{}, []uint64{1, 2, 3, 4}},
+		"IDs":              {store.Filter{IDs: []uint64{4, 2, 9}}, []uint64{2, 4}},
+		"after ID":         {store.Filter{AfterID: 2}, []uint64{3, 4}},
+		"node":             {store.Filter{NodeName: "node-2"}, []uint64{2, 4}},
+		"agent":            {store.Filter{Agent: "gpu-health-monitor"}, []uint64{4}},
+		"check":            {store.Filter{CheckName: "SysLogsXIDError"}, []uint64{1, 3}},
+		"healthy":          {store.Filter{IsHealthy: &healthy}, []uint64{3}},
+		"unhealthy":        {store.Filter{IsHealthy: &unhealthy}, []uint64{1, 2, 4}},
+		"status":           {store.Filter{NodeQuarantined: []model.Status{model.Quarantined}}, []uint64{1}},
+		"statuses":         {store.Filter{NodeQuarantined: []model.Status{model.Quarantined, model.Cancelled}}, []uint64{1, 2}},
+		"created since":    {store.Filter{CreatedSince: testNow.Add(2 * time.Minute)}, []uint64{3, 4}},
+		"created before":   {store.Filter{CreatedBefore: testNow.Add(2 * time.Minute)}, []uint64{1, 2}},
+		"combined":         {store.Filter{NodeName: "node-1", IsHealthy: &unhealthy, AfterID: 0}, []uint64{1}},
+		"no match":         {store.Filter{NodeName: "node-1", CheckName: "GpuXidError"}, nil},
+		"IDs and after ID": {store.Filter{IDs: []uint64{1, 3}, AfterID: 1}, []uint64{3}},
+	} {
+		t.Run(name, func(t *testing.T) {
+			records, err := s.List(ctx, tc.filter, store.ListOptions{})
+			require.NoError(t, err)
+			assert.Equal(t, tc.want, nilIfEmpty(ids(records)))
+
+			// Filters select the same records for every operation
+			for _, record := range records {
+				assert.True(t, tc.filter.Matches(record), "record %d", record.ID)
+			}
+		})
+	}
+}
+
+func nilIfEmpty(ids []uint64) []uint64 {
+	if len(ids) == 0 {
+		return nil
+	}
+
+	return ids
+}
+
+func testPagination(t *testing.T, open OpenFunc) {
+	ctx := context.Background()
+	s, _ := openTestStore(t, open)
+
+	events := make([]model.HealthEventWithStatus, 0, 25)
+	for i := range 25 {
+		events = append(events, event(fmt.Sprintf("node-%d", i%3), "SysLogsXIDError", false, testNow))
+	}
+
+	_, err := s.Insert(ctx, events)
+	require.NoError(t, err)
+
+	// Pages are read by passing the last ID of a page as AfterID of the next
+	var (
+		seen  []uint64
+		pages int
+	)
+
+	filter := store.Filter{NodeName: "node-1"}
+
+	for {
+		page, err := s.List(ctx, filter, store.ListOptions{Limit: 3})
+		require.NoError(t, err)
+
+		if len(page) == 0 {
+			break
+		}
+
+		assert.LessOrEqual(t, len(page), 3)
+
+		pages++
+		seen = append(seen, ids(page)...)
+		filter.AfterID = page[len(page)-1].ID
+	}
+
+	all, err := s.List(ctx, store.Filter{NodeName: "node-1"}, store.ListOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, ids(all), seen)
+	assert.Len(t, seen, 8)
+	assert.Equal(t, 3, pages)
+}
+
+func testConcurrentWrites(t *testing.T, open OpenFunc) {
+	ctx := context.Background()
+	s, _ := openTestStore(t, open)
+
+	const (
+		writers = 8
+		batches = 10
+		size    = 3
+	)
+
+	var (
+		wg      sync.WaitGroup
+		mu      sync.Mutex
+		written [][]uint64
+	)
+
+	for w := range writers {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			for range batches {
+				events := make([]model.HealthEventWithStatus, 0, size)
+				for range size {
+					events = append(events, event(fmt.Sprintf("node-%d", w), "SysLogsXIDError", false, testNow))
+				}
+
+				batch, err := s.Insert(ctx, events)
+				if !assert.NoError(t, err) {
+					return
+				}
+
+				mu.Lock()
+				written = append(written, batch)
+				mu.Unlock()
+
+				// Updates of other writers interleave with the inserts
+				_, err = s.Update(ctx, store.Filter{IDs: batch[:1]}, func(status *model.HealthEventStatus) {
+					quarantined := model.Quarantined
+					status.NodeQuarantined = &quarantined
+				})
+				assert.NoError(t, err)
+			}
+		}()
+	}
+
+	wg.Wait()
+
+	require.Len(t, written, writers*batches)
+
+	var all []uint64
+
+	for _, batch := range written {
+		require.Len(t, batch, size)
+
+		// Batches get consecutive IDs
+		for i := 1; i < len(batch); i++ {
+			assert.Equal(t, batch[i-1]+1, batch[i])
+		}
+
+		all = append(all, batch...)
+	}
+
+	slices.Sort(all)
+	assert.Len(t, slices.Compact(all), writers*batches*size, "IDs are unique")
+
+	records, err := s.List(ctx, store.Filter{}, store.ListOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, all, ids(records))
+
+	quarantined, err := s.List(ctx, store.Filter{NodeQuarantined: []model.Status{model.Quarantined}},
+		store.ListOptions{})
+	require.NoError(t, err)
+	assert.Len(t, quarantined, writers*batches)
+}
+
+func testRetention(t *testing.T, open OpenFunc) {
+	ctx := context.Background()
+	s, _ := openTestStore(t, open)
+
+	_, err := s.Insert(ctx, []model.HealthEventWithStatus{
+		event("node-1", "SysLogsXIDError", false, testNow.Add(-48*time.Hour)),
+		event("node-2", "SysLogsXIDError", false, testNow.Add(-24*time.Hour)),
+		event("node-1", "SysLogsXIDError", false, testNow.Add(-time.Hour)),
+	})
+	require.NoError(t, err)
+
+	// CreatedBefore is exclusive, the record created at the cutoff is kept
+	deleted, err := s.Delete(ctx, store.Filter{CreatedBefore: testNow.Add(-24 * time.Hour)})
+	require.NoError(t, err)
+	assert.Equal(t, 1, deleted)
+
+	records, err := s.List(ctx, store.Filter{}, store.ListOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, []uint64{2, 3}, ids(records))
+
+	// Deleting again is a no-op
+	deleted, err = s.Delete(ctx, store.Filter{CreatedBefore: testNow.Add(-24 * time.Hour)})
+	require.NoError(t, err)
+	assert.Zero(t, deleted)
+
+	// Positions of consumers are kept, and point past the deleted records
+	require.NoError(t, s.SetPosition(ctx, "fault-quarantine", 1))
+
+	records, err = s.List(ctx, store.Filter{AfterID: 1}, store.ListOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, []uint64{2, 3}, ids(records))
+
+	deleted, err = s.Delete(ctx, store.Filter{})
+	require.NoError(t, err)
+	assert.Equal(t, 2, deleted)
+
+	// IDs of deleted records are not reused
+	newIDs, err := s.Insert(ctx, []model.HealthEventWithStatus{event("node-1", "SysLogsXIDError", true, testNow)})
+	require.NoError(t, err)
+	assert.Equal(t, []uint64{4}, newIDs)
+}
+
+func testWatch(t *testing.T, open OpenFunc) {
+	s, _ := openTestStore(t, open)
+
+	_, err := s.Insert(context.Background(), []model.HealthEventWithStatus{
+		event("node-1", "SysLogsXIDError", false, testNow),
+		event("node-2", "SysLogsXIDError", false, testNow),
+	})
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var handled []uint64
+
+	err = store.Watch(ctx, s, "test", store.Filter{NodeName: "node-2"}, time.Millisecond,
+		func(_ context.Context, record store.Record) error {
+			handled = append(handled, record.ID)
+			cancel()
+
+			return nil
+		})
+	require.NoError(t, err)
+	assert.Equal(t, []uint64{2}, handled)
+
+	position, err := s.Position(context.Background(), "test")
+	require.NoError(t, err)
+	assert.Equal(t, uint64(2), position)
+}